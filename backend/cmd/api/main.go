@@ -6,14 +6,83 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 
 	admincmd "github.com/katerji/butchery-app/backend/internal/application/admin/commands"
+	auditqry "github.com/katerji/butchery-app/backend/internal/application/audit/queries"
 	authcmd "github.com/katerji/butchery-app/backend/internal/application/auth/commands"
+	branchcmd "github.com/katerji/butchery-app/backend/internal/application/branch/commands"
+	branchqry "github.com/katerji/butchery-app/backend/internal/application/branch/queries"
+	businesscmd "github.com/katerji/butchery-app/backend/internal/application/business/commands"
+	businessqry "github.com/katerji/butchery-app/backend/internal/application/business/queries"
+	carcmd "github.com/katerji/butchery-app/backend/internal/application/carcass/commands"
+	carqry "github.com/katerji/butchery-app/backend/internal/application/carcass/queries"
 	custcmd "github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	custqry "github.com/katerji/butchery-app/backend/internal/application/customer/queries"
+	delivcmd "github.com/katerji/butchery-app/backend/internal/application/delivery/commands"
+	delivqry "github.com/katerji/butchery-app/backend/internal/application/delivery/queries"
+	dispatchcmd "github.com/katerji/butchery-app/backend/internal/application/dispatch/commands"
+	dispatchqry "github.com/katerji/butchery-app/backend/internal/application/dispatch/queries"
+	fulfilcmd "github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	fulfilqry "github.com/katerji/butchery-app/backend/internal/application/fulfilment/queries"
+	haccpcmd "github.com/katerji/butchery-app/backend/internal/application/haccp/commands"
+	haccpqry "github.com/katerji/butchery-app/backend/internal/application/haccp/queries"
+	invcmd "github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	invqry "github.com/katerji/butchery-app/backend/internal/application/inventory/queries"
+	invoicecmd "github.com/katerji/butchery-app/backend/internal/application/invoice/commands"
+	invoiceqry "github.com/katerji/butchery-app/backend/internal/application/invoice/queries"
+	labelcmd "github.com/katerji/butchery-app/backend/internal/application/label/commands"
+	labelqry "github.com/katerji/butchery-app/backend/internal/application/label/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/labelling"
+	loyaltycmd "github.com/katerji/butchery-app/backend/internal/application/loyalty/commands"
+	loyaltyqry "github.com/katerji/butchery-app/backend/internal/application/loyalty/queries"
+	ordercmd "github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	orderqry "github.com/katerji/butchery-app/backend/internal/application/order/queries"
+	paycmd "github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	payqry "github.com/katerji/butchery-app/backend/internal/application/payment/queries"
+	plucmd "github.com/katerji/butchery-app/backend/internal/application/plu/commands"
+	pluqry "github.com/katerji/butchery-app/backend/internal/application/plu/queries"
+	poscmd "github.com/katerji/butchery-app/backend/internal/application/pos/commands"
+	posqry "github.com/katerji/butchery-app/backend/internal/application/pos/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	proccmd "github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	procqry "github.com/katerji/butchery-app/backend/internal/application/procurement/queries"
+	promocmd "github.com/katerji/butchery-app/backend/internal/application/promotion/commands"
+	promoqry "github.com/katerji/butchery-app/backend/internal/application/promotion/queries"
+	qurbanicmd "github.com/katerji/butchery-app/backend/internal/application/qurbani/commands"
+	qurbaniqry "github.com/katerji/butchery-app/backend/internal/application/qurbani/queries"
+	scalecmd "github.com/katerji/butchery-app/backend/internal/application/scale/commands"
+	scaleqry "github.com/katerji/butchery-app/backend/internal/application/scale/queries"
+	staffcmd "github.com/katerji/butchery-app/backend/internal/application/staff/commands"
+	staffqry "github.com/katerji/butchery-app/backend/internal/application/staff/queries"
+	subscriptioncmd "github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	subscriptionqry "github.com/katerji/butchery-app/backend/internal/application/subscription/queries"
+	taxcmd "github.com/katerji/butchery-app/backend/internal/application/tax/commands"
+	taxqry "github.com/katerji/butchery-app/backend/internal/application/tax/queries"
+	tracecmd "github.com/katerji/butchery-app/backend/internal/application/traceability/commands"
+	traceqry "github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/tracking"
+	"github.com/katerji/butchery-app/backend/internal/application/weighing"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
 	infraauth "github.com/katerji/butchery-app/backend/internal/infrastructure/auth"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/csvreport"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/notification"
+	infrapayment "github.com/katerji/butchery-app/backend/internal/infrastructure/payment"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/pdf"
 	"github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/printer"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/scaleio"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/zpl"
 	apphttp "github.com/katerji/butchery-app/backend/internal/interface/http"
 	"github.com/katerji/butchery-app/backend/internal/interface/http/handler"
 	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
@@ -47,6 +116,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	location, err := cfg.Fulfilment.Location()
+	if err != nil {
+		logger.Error("invalid fulfilment timezone", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	barcodeLayouts, err := cfg.Barcode.ParsedLayouts()
+	if err != nil {
+		logger.Error("invalid barcode layouts", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	if _, err := label.FindTemplate(cfg.Label.Template); err != nil {
+		logger.Error("invalid label template", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
 	// Database
 	ctx := context.Background()
 	pool, err := postgres.NewConnectionPool(ctx, cfg.DB.DSN())
@@ -60,10 +146,75 @@ func main() {
 	adminRepo := postgres.NewAdminRepository(pool)
 	customerRepo := postgres.NewCustomerRepository(pool)
 	refreshTokenRepo := postgres.NewRefreshTokenRepository(pool)
+	fulfilmentScheduleRepo := postgres.NewFulfilmentScheduleRepository(pool)
+	slotReservationRepo := postgres.NewSlotReservationRepository(pool)
+	customerAddressRepo := postgres.NewCustomerAddressRepository(pool)
+	deliveryZoneRepo := postgres.NewDeliveryZoneRepository(pool)
+	inventoryStockRepo := postgres.NewInventoryStockRepository(pool)
+	inventoryWasteRepo := postgres.NewInventoryWasteRepository(pool)
+	stockReservationRepo := postgres.NewStockReservationRepository(pool)
+	carcassRepo := postgres.NewCarcassRepository(pool)
+	yieldTemplateRepo := postgres.NewYieldTemplateRepository(pool)
+	halalCertificateRepo := postgres.NewHalalCertificateRepository(pool)
+	lotOriginRepo := postgres.NewLotOriginRepository(pool)
+	supplierRepo := postgres.NewSupplierRepository(pool)
+	purchaseOrderRepo := postgres.NewPurchaseOrderRepository(pool)
+	paymentRepo := postgres.NewPaymentRepository(pool)
+	orderRepo := postgres.NewOrderRepository(pool)
+	orderEventRepo := postgres.NewOrderEventRepository(pool)
+	auditRepo := postgres.NewAuditRepository(pool)
+	invoiceRepo := postgres.NewInvoiceRepository(pool)
+	taxRateRepo := postgres.NewTaxRateRepository(pool)
+	productTaxCategoryRepo := postgres.NewProductTaxCategoryRepository(pool)
+	promotionRepo := postgres.NewPromotionRepository(pool)
+	promotionProductRepo := postgres.NewPromotionProductRepository(pool)
+	promotionRedemptionRepo := postgres.NewPromotionRedemptionRepository(pool)
+	loyaltyLedger := postgres.NewLoyaltyLedgerRepository(pool)
+	loyaltyRulesRepo := postgres.NewLoyaltyRulesRepository(pool)
+	loyaltyMemberRepo := postgres.NewLoyaltyMemberRepository(pool)
+	qurbaniOfferingRepo := postgres.NewQurbaniOfferingRepository(pool)
+	qurbaniBookingRepo := postgres.NewQurbaniBookingRepository(pool)
+	qurbaniSlaughterRepo := postgres.NewQurbaniSlaughterRepository(pool)
+	subscriptionBoxRepo := postgres.NewSubscriptionBoxRepository(pool)
+	subscriptionRepo := postgres.NewSubscriptionRepository(pool)
+	subscriptionRenewalRepo := postgres.NewSubscriptionRenewalRepository(pool)
+	businessAccountRepo := postgres.NewBusinessAccountRepository(pool)
+	businessMemberRepo := postgres.NewBusinessMemberRepository(pool)
+	businessLedger := postgres.NewBusinessLedger(pool)
+	pluItemRepo := postgres.NewPLUItemRepository(pool)
+	labelPrinterRepo := postgres.NewLabelPrinterRepository(pool)
+	printJobRepo := postgres.NewPrintJobRepository(pool)
+	scaleRepo := postgres.NewScaleRepository(pool)
+	posTillRepo := postgres.NewPosTillRepository(pool)
+	posSessionRepo := postgres.NewPosSessionRepository(pool)
+	posSaleRepo := postgres.NewPosSaleRepository(pool)
+	branchRepo := postgres.NewBranchRepository(pool)
+	haccpUnitRepo := postgres.NewHACCPUnitRepository(pool)
+	haccpLogRepo := postgres.NewHACCPLogRepository(pool)
+	staffProfileRepo := postgres.NewStaffProfileRepository(pool)
+	staffShiftRepo := postgres.NewStaffShiftRepository(pool)
+	staffClockRepo := postgres.NewStaffClockRepository(pool)
+	driverRepo := postgres.NewDriverRepository(pool)
+	deliveryRunRepo := postgres.NewDeliveryRunRepository(pool)
+	deliveryProofRepo := postgres.NewDeliveryProofRepository(pool)
 
 	// Infrastructure services
 	passwordHasher := infraauth.NewBcryptHasher()
 	tokenService := infraauth.NewTokenService(cfg.JWT.Secret, cfg.JWT.AccessTokenTTL)
+	paymentGateway := newPaymentGateway(cfg.Payment)
+	invoiceRenderer := pdf.NewInvoiceRenderer(location)
+	zplLabelRenderer := zpl.NewLabelRenderer()
+	pdfLabelRenderer := pdf.NewLabelRenderer()
+	printTransport := printer.NewRawTransport(cfg.Label.PrintTimeout)
+	scaleConnector := scaleio.NewConnector(cfg.Scale.ReadTimeout, cfg.Scale.PollInterval)
+	taxCalculator := tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.Rounding(cfg.Tax.Rounding)}
+	if !cfg.Tax.PricesIncludeVAT {
+		taxCalculator.Mode = tax.ModeExclusive
+	}
+	pricer := pricing.NewPricer(taxRateRepo, productTaxCategoryRepo, taxCalculator, location)
+	discounter := pricing.NewDiscounter(promotionRepo, promotionProductRepo, promotionRedemptionRepo, orderRepo)
+	rewards := pricing.NewRewards(loyaltyRulesRepo, loyaltyLedger)
+	notifier := notification.NewLogNotifier(logger)
 
 	// Use case handlers
 	adminLoginHandler := admincmd.NewAdminLoginHandler(adminRepo, passwordHasher, tokenService, refreshTokenRepo, cfg.JWT.AccessTokenTTL)
@@ -71,29 +222,486 @@ func main() {
 	customerLoginHandler := custcmd.NewCustomerLoginHandler(customerRepo, passwordHasher, tokenService, refreshTokenRepo, cfg.JWT.AccessTokenTTL)
 	refreshTokenHandler := authcmd.NewRefreshTokenHandler(refreshTokenRepo, tokenService, cfg.JWT.AccessTokenTTL)
 	logoutHandler := authcmd.NewLogoutHandler(refreshTokenRepo)
+	setOpeningHoursHandler := fulfilcmd.NewSetOpeningHoursHandler(fulfilmentScheduleRepo)
+	addClosureHandler := fulfilcmd.NewAddClosureHandler(fulfilmentScheduleRepo, location)
+	createSlotTemplateHandler := fulfilcmd.NewCreateSlotTemplateHandler(fulfilmentScheduleRepo, deliveryZoneRepo)
+	reserveSlotHandler := fulfilcmd.NewReserveSlotHandler(fulfilmentScheduleRepo, slotReservationRepo, cfg.Fulfilment.HoldTTL, cfg.Fulfilment.LeadTime, location)
+	releaseReservationHandler := fulfilcmd.NewReleaseReservationHandler(slotReservationRepo)
+	listAvailableSlotsHandler := fulfilqry.NewListAvailableSlotsHandler(fulfilmentScheduleRepo, slotReservationRepo, cfg.Fulfilment.LeadTime, location)
+	listAddressesHandler := custqry.NewListAddressesHandler(customerAddressRepo)
+	addAddressHandler := custcmd.NewAddAddressHandler(customerAddressRepo)
+	updateAddressHandler := custcmd.NewUpdateAddressHandler(customerAddressRepo)
+	deleteAddressHandler := custcmd.NewDeleteAddressHandler(customerAddressRepo)
+	setDefaultAddressHandler := custcmd.NewSetDefaultAddressHandler(customerAddressRepo)
+	listZonesHandler := delivqry.NewListZonesHandler(deliveryZoneRepo)
+	createZoneHandler := delivcmd.NewCreateZoneHandler(deliveryZoneRepo)
+	updateZoneHandler := delivcmd.NewUpdateZoneHandler(deliveryZoneRepo)
+	validateDeliveryAddressHandler := delivqry.NewValidateDeliveryAddressHandler(customerAddressRepo, deliveryZoneRepo)
+	receiveLotHandler := invcmd.NewReceiveLotHandler(inventoryStockRepo)
+	recordMovementHandler := invcmd.NewRecordMovementHandler(inventoryStockRepo)
+	stockTakeHandler := invcmd.NewStockTakeHandler(inventoryStockRepo)
+	setLowStockThresholdHandler := invcmd.NewSetLowStockThresholdHandler(inventoryStockRepo)
+	reserveStockHandler := invcmd.NewReserveStockHandler(stockReservationRepo, cfg.Inventory.HoldTTL)
+	releaseStockHandler := invcmd.NewReleaseStockHandler(stockReservationRepo)
+	fulfilStockHandler := invcmd.NewFulfilStockHandler(stockReservationRepo)
+	listStockLevelsHandler := invqry.NewListStockLevelsHandler(inventoryStockRepo)
+	listMovementsHandler := invqry.NewListMovementsHandler(inventoryStockRepo)
+	expiryPolicy := inventory.ExpiryPolicy{MarkdownDays: cfg.Inventory.MarkdownDays, MarkdownBP: cfg.Inventory.MarkdownBP}
+	recordWasteHandler := invcmd.NewRecordWasteHandler(inventoryStockRepo)
+	setUseByHandler := invcmd.NewSetUseByHandler(inventoryStockRepo, inventoryWasteRepo)
+	scanExpiryHandler := invcmd.NewScanExpiryHandler(inventoryWasteRepo, notifier, expiryPolicy, location)
+	acceptExpiryProposalHandler := invcmd.NewAcceptExpiryProposalHandler(inventoryStockRepo, inventoryWasteRepo)
+	dismissExpiryProposalHandler := invcmd.NewDismissExpiryProposalHandler(inventoryStockRepo, inventoryWasteRepo)
+	listExpiryProposalsHandler := invqry.NewListExpiryProposalsHandler(inventoryWasteRepo)
+	shrinkageReportHandler := invqry.NewShrinkageReportHandler(inventoryWasteRepo, location)
+	recordIntakeHandler := carcmd.NewRecordIntakeHandler(carcassRepo)
+	recordBreakdownHandler := carcmd.NewRecordBreakdownHandler(carcassRepo)
+	setYieldTemplateHandler := carcmd.NewSetYieldTemplateHandler(yieldTemplateRepo)
+	listCarcassesHandler := carqry.NewListCarcassesHandler(carcassRepo)
+	yieldReportHandler := carqry.NewYieldReportHandler(carcassRepo, yieldTemplateRepo)
+	listYieldTemplatesHandler := carqry.NewListYieldTemplatesHandler(yieldTemplateRepo)
+	registerCertificateHandler := tracecmd.NewRegisterCertificateHandler(halalCertificateRepo)
+	recordOriginHandler := tracecmd.NewRecordOriginHandler(lotOriginRepo)
+	listCertificatesHandler := traceqry.NewListCertificatesHandler(halalCertificateRepo, cfg.Traceability.CertificateExpiryWarning)
+	lotTraceHandler := traceqry.NewLotTraceHandler(lotOriginRepo)
+	consumedLotsHandler := traceqry.NewConsumedLotsHandler(lotOriginRepo)
+	createSupplierHandler := proccmd.NewCreateSupplierHandler(supplierRepo)
+	updateSupplierHandler := proccmd.NewUpdateSupplierHandler(supplierRepo)
+	listSuppliersHandler := procqry.NewListSuppliersHandler(supplierRepo)
+	priceHistoryHandler := procqry.NewPriceHistoryHandler(supplierRepo, purchaseOrderRepo)
+	createPurchaseOrderHandler := proccmd.NewCreatePurchaseOrderHandler(supplierRepo, purchaseOrderRepo)
+	revisePurchaseOrderHandler := proccmd.NewRevisePurchaseOrderHandler(purchaseOrderRepo)
+	sendPurchaseOrderHandler := proccmd.NewSendPurchaseOrderHandler(purchaseOrderRepo)
+	closePurchaseOrderHandler := proccmd.NewClosePurchaseOrderHandler(purchaseOrderRepo)
+	receiveGoodsHandler := proccmd.NewReceiveGoodsHandler(purchaseOrderRepo)
+	listPurchaseOrdersHandler := procqry.NewListPurchaseOrdersHandler(purchaseOrderRepo)
+	getPurchaseOrderHandler := procqry.NewGetPurchaseOrderHandler(purchaseOrderRepo)
+	listReceiptsHandler := procqry.NewListReceiptsHandler(purchaseOrderRepo)
+	varianceReportHandler := procqry.NewVarianceReportHandler(purchaseOrderRepo)
+	authorizePaymentHandler := paycmd.NewAuthorizePaymentHandler(orderRepo, paymentRepo, paymentGateway, cfg.Payment.Currency, cfg.Payment.AuthorizationMarginBP)
+	capturePaymentHandler := paycmd.NewCapturePaymentHandler(orderRepo, paymentRepo, paymentGateway)
+	voidPaymentHandler := paycmd.NewVoidPaymentHandler(paymentRepo, paymentGateway)
+	handleWebhookHandler := paycmd.NewHandleWebhookHandler(paymentRepo, paymentGateway)
+	getPaymentHandler := payqry.NewGetPaymentHandler(paymentRepo)
+	createOrderHandler := ordercmd.NewCreateOrderHandler(customerRepo, orderRepo, branchRepo, pricer, discounter, rewards)
+	getOrderHandler := orderqry.NewGetOrderHandler(orderRepo)
+	requestRefundHandler := ordercmd.NewRequestRefundHandler(orderRepo, adminRepo, paymentRepo, paymentGateway, auditRepo, rewards, cfg.Refund.ApprovalThresholdCents)
+	approveRefundHandler := ordercmd.NewApproveRefundHandler(orderRepo, adminRepo, paymentRepo, paymentGateway, auditRepo, rewards)
+	rejectRefundHandler := ordercmd.NewRejectRefundHandler(orderRepo, adminRepo, auditRepo)
+	refundReceiptHandler := orderqry.NewGetRefundReceiptHandler(orderRepo, paymentRepo)
+	advanceStageHandler := ordercmd.NewAdvanceStageHandler(orderRepo, stockReservationRepo, rewards)
+	cancelOrderHandler := ordercmd.NewCancelOrderHandler(orderRepo, stockReservationRepo, slotReservationRepo, paymentRepo, paymentGateway, discounter, rewards, auditRepo)
+	placeOrderHandler := ordercmd.NewPlaceOrderHandler(createOrderHandler, cancelOrderHandler, stockReservationRepo, slotReservationRepo,
+		fulfilmentScheduleRepo, pluItemRepo, customerAddressRepo, deliveryZoneRepo)
+	orderTracker := tracking.NewTracker(orderEventRepo, postgres.NewOrderEventFeed(pool))
+	watchOrdersHandler := orderqry.NewWatchOrdersHandler(orderTracker)
+	listAuditEntriesHandler := auditqry.NewListEntriesHandler(auditRepo)
+	invoiceSettings := invoicecmd.Settings{
+		Seller: invoice.Party{
+			Name:          cfg.Shop.Name,
+			Address:       cfg.Shop.Address,
+			VATNumber:     cfg.Shop.VATNumber,
+			CompanyNumber: cfg.Shop.CompanyNumber,
+			Email:         cfg.Shop.Email,
+		},
+		Currency:             cfg.Payment.Currency,
+		FiscalYearStartMonth: time.Month(cfg.Shop.FiscalYearStartMonth),
+		Location:             location,
+	}
+	issueInvoiceHandler := invoicecmd.NewIssueInvoiceHandler(orderRepo, customerRepo, invoiceRepo, invoiceRenderer, auditRepo, invoiceSettings)
+	issueCreditNoteHandler := invoicecmd.NewIssueCreditNoteHandler(orderRepo, invoiceRepo, invoiceRenderer, auditRepo, invoiceSettings)
+	listOrderDocumentsHandler := invoiceqry.NewListOrderDocumentsHandler(invoiceRepo)
+	listCustomerDocumentsHandler := invoiceqry.NewListCustomerDocumentsHandler(invoiceRepo)
+	getDocumentHandler := invoiceqry.NewGetDocumentHandler(invoiceRepo)
+	createTaxRateHandler := taxcmd.NewCreateRateHandler(taxRateRepo, adminRepo)
+	assignProductTaxCategoryHandler := taxcmd.NewAssignProductCategoryHandler(taxRateRepo, productTaxCategoryRepo, adminRepo)
+	listTaxRatesHandler := taxqry.NewListRatesHandler(taxRateRepo)
+	quoteCartHandler := taxqry.NewQuoteCartHandler(pricer, discounter, deliveryZoneRepo)
+	createPromotionHandler := promocmd.NewCreatePromotionHandler(promotionRepo, adminRepo)
+	updatePromotionHandler := promocmd.NewUpdatePromotionHandler(promotionRepo, adminRepo)
+	classifyPromotionProductHandler := promocmd.NewClassifyProductHandler(promotionProductRepo, adminRepo)
+	listPromotionsHandler := promoqry.NewListPromotionsHandler(promotionRepo)
+	getLoyaltyRulesHandler := loyaltyqry.NewGetRulesHandler(loyaltyRulesRepo)
+	updateLoyaltyRulesHandler := loyaltycmd.NewUpdateRulesHandler(loyaltyRulesRepo, adminRepo)
+	setProductBonusHandler := loyaltycmd.NewSetProductBonusHandler(loyaltyRulesRepo, adminRepo)
+	getLoyaltyAccountHandler := loyaltyqry.NewGetAccountHandler(loyaltyLedger, loyaltyRulesRepo, loyaltyMemberRepo)
+	setBirthdayHandler := loyaltycmd.NewSetBirthdayHandler(loyaltyMemberRepo)
+	adjustPointsHandler := loyaltycmd.NewAdjustPointsHandler(customerRepo, loyaltyLedger, rewards, adminRepo)
+	rebuildBalanceHandler := loyaltycmd.NewRebuildBalanceHandler(customerRepo, loyaltyLedger)
+	expirePointsHandler := loyaltycmd.NewExpirePointsHandler(loyaltyLedger, rewards)
+	awardBirthdayBonusesHandler := loyaltycmd.NewAwardBirthdayBonusesHandler(loyaltyMemberRepo, loyaltyLedger, rewards, location)
+	createQurbaniOfferingHandler := qurbanicmd.NewCreateOfferingHandler(qurbaniOfferingRepo)
+	updateQurbaniOfferingHandler := qurbanicmd.NewUpdateOfferingHandler(qurbaniOfferingRepo, qurbaniBookingRepo)
+	listQurbaniOfferingsHandler := qurbaniqry.NewListOfferingsHandler(qurbaniOfferingRepo, qurbaniBookingRepo)
+	bookQurbaniShareHandler := qurbanicmd.NewBookShareHandler(qurbaniOfferingRepo, qurbaniBookingRepo, customerAddressRepo)
+	listQurbaniBookingsHandler := qurbaniqry.NewListBookingsHandler(qurbaniBookingRepo)
+	cancelQurbaniBookingHandler := qurbanicmd.NewCancelBookingHandler(qurbaniBookingRepo)
+	allocateQurbaniSharesHandler := qurbanicmd.NewAllocateSharesHandler(qurbaniOfferingRepo, qurbaniBookingRepo, qurbaniSlaughterRepo)
+	slaughterSheetHandler := qurbaniqry.NewSlaughterSheetHandler(qurbaniOfferingRepo, qurbaniBookingRepo, qurbaniSlaughterRepo)
+	planQurbaniBatchesHandler := qurbanicmd.NewPlanBatchesHandler(qurbaniSlaughterRepo)
+	listQurbaniBatchesHandler := qurbaniqry.NewListBatchesHandler(qurbaniSlaughterRepo)
+	completeQurbaniBatchHandler := qurbanicmd.NewCompleteBatchHandler(qurbaniSlaughterRepo, qurbaniOfferingRepo,
+		qurbaniBookingRepo, customerRepo, notifier)
+	notifyQurbaniBatchHandler := qurbanicmd.NewNotifyBatchHandler(qurbaniSlaughterRepo, qurbaniOfferingRepo,
+		qurbaniBookingRepo, customerRepo, notifier)
+	qurbaniDistributionHandler := qurbaniqry.NewDistributionListHandler(qurbaniOfferingRepo, qurbaniBookingRepo,
+		qurbaniSlaughterRepo, customerRepo, customerAddressRepo)
+	subscriptionSettings := subscriptioncmd.Settings{
+		Location:              location,
+		LeadTime:              cfg.Fulfilment.LeadTime,
+		GenerateAhead:         cfg.Subscription.GenerateAhead,
+		RetryIntervals:        cfg.Subscription.RetryIntervals,
+		MaxFailedRenewals:     cfg.Subscription.MaxFailedRenewals,
+		Currency:              cfg.Payment.Currency,
+		AuthorizationMarginBP: cfg.Payment.AuthorizationMarginBP,
+	}
+	createSubscriptionBoxHandler := subscriptioncmd.NewCreateBoxHandler(subscriptionBoxRepo)
+	updateSubscriptionBoxHandler := subscriptioncmd.NewUpdateBoxHandler(subscriptionBoxRepo)
+	listSubscriptionBoxesHandler := subscriptionqry.NewListBoxesHandler(subscriptionBoxRepo)
+	subscribeHandler := subscriptioncmd.NewSubscribeHandler(subscriptionBoxRepo, subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	listSubscriptionsHandler := subscriptionqry.NewListSubscriptionsHandler(subscriptionRepo)
+	skipDeliveryHandler := subscriptioncmd.NewSkipDeliveryHandler(subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	swapCutHandler := subscriptioncmd.NewSwapCutHandler(subscriptionRepo, subscriptionBoxRepo)
+	changeSubscriptionSlotHandler := subscriptioncmd.NewChangeSlotHandler(subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	changePaymentMethodHandler := subscriptioncmd.NewChangePaymentMethodHandler(subscriptionRepo)
+	pauseSubscriptionHandler := subscriptioncmd.NewPauseSubscriptionHandler(subscriptionRepo)
+	resumeSubscriptionHandler := subscriptioncmd.NewResumeSubscriptionHandler(subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	cancelSubscriptionHandler := subscriptioncmd.NewCancelSubscriptionHandler(subscriptionRepo)
+	listRenewalsHandler := subscriptionqry.NewListRenewalsHandler(subscriptionRenewalRepo)
+	runRenewalsHandler := subscriptioncmd.NewRunRenewalsHandler(subscriptionRepo, subscriptionRenewalRepo, subscriptionBoxRepo,
+		fulfilmentScheduleRepo, slotReservationRepo, stockReservationRepo, orderRepo, paymentRepo, paymentGateway,
+		customerRepo, pricer, notifier, subscriptionSettings)
+	createBusinessAccountHandler := businesscmd.NewCreateAccountHandler(businessAccountRepo)
+	updateBusinessAccountHandler := businesscmd.NewUpdateAccountHandler(businessAccountRepo)
+	setBusinessPricesHandler := businesscmd.NewSetPricesHandler(businessAccountRepo)
+	listBusinessAccountsHandler := businessqry.NewListAccountsHandler(businessAccountRepo)
+	getBusinessAccountHandler := businessqry.NewGetAccountHandler(businessAccountRepo)
+	listBusinessMembersHandler := businessqry.NewListMembersHandler(businessAccountRepo, businessMemberRepo)
+	saveBusinessMemberHandler := businesscmd.NewSaveMemberHandler(businessAccountRepo, businessMemberRepo, customerRepo)
+	removeBusinessMemberHandler := businesscmd.NewRemoveMemberHandler(businessMemberRepo)
+	recordBusinessPaymentHandler := businesscmd.NewRecordPaymentHandler(businessAccountRepo, businessLedger)
+	getBusinessMembershipHandler := businessqry.NewGetMembershipHandler(businessAccountRepo, businessMemberRepo)
+	placeBusinessOrderHandler := businesscmd.NewPlaceOrderHandler(businessAccountRepo, businessMemberRepo, businessLedger,
+		orderRepo, pricer, location)
+	decideBusinessOrderHandler := businesscmd.NewDecideOrderHandler(businessAccountRepo, businessMemberRepo, businessLedger,
+		orderRepo, pricer, location)
+	listBusinessOrdersHandler := businessqry.NewListOrdersHandler(businessLedger)
+	businessStatementHandler := businessqry.NewStatementHandler(businessAccountRepo, businessLedger, location)
+	createPLUItemHandler := plucmd.NewCreateItemHandler(pluItemRepo)
+	updatePLUItemHandler := plucmd.NewUpdateItemHandler(pluItemRepo)
+	listPLUItemsHandler := pluqry.NewListItemsHandler(pluItemRepo)
+	encodeBarcodeHandler := pluqry.NewEncodeBarcodeHandler(pluItemRepo, barcodeLayouts)
+	resolveBarcodeHandler := pluqry.NewResolveBarcodeHandler(pluItemRepo, barcodeLayouts)
+	labeller := labelling.NewLabeller(orderRepo, inventoryStockRepo, lotOriginRepo, pluItemRepo, barcodeLayouts,
+		cfg.Label.Template, cfg.Payment.Currency, location)
+	printSettings := labelcmd.Settings{
+		Lease:       2 * cfg.Label.PrintTimeout,
+		RetryAfter:  cfg.Label.RetryAfter,
+		MaxAttempts: cfg.Label.MaxAttempts,
+	}
+	createPrinterHandler := labelcmd.NewCreatePrinterHandler(labelPrinterRepo)
+	updatePrinterHandler := labelcmd.NewUpdatePrinterHandler(labelPrinterRepo)
+	printLabelsHandler := labelcmd.NewPrintLabelsHandler(labeller, zplLabelRenderer, labelPrinterRepo, printJobRepo,
+		printTransport, printSettings)
+	dispatchPrintJobsHandler := labelcmd.NewDispatchJobsHandler(labelPrinterRepo, printJobRepo, printTransport, printSettings)
+	retryPrintJobHandler := labelcmd.NewRetryJobHandler(labelPrinterRepo, printJobRepo, printTransport, printSettings)
+	listPrintersHandler := labelqry.NewListPrintersHandler(labelPrinterRepo)
+	listPrintJobsHandler := labelqry.NewListJobsHandler(printJobRepo)
+	renderLabelsHandler := labelqry.NewRenderLabelsHandler(labeller, zplLabelRenderer, pdfLabelRenderer)
+	scaleMonitor := weighing.NewMonitor(scaleRepo, scaleConnector, cfg.Scale.StableWindow, cfg.Scale.StableToleranceGrams)
+	createScaleHandler := scalecmd.NewCreateScaleHandler(scaleRepo)
+	updateScaleHandler := scalecmd.NewUpdateScaleHandler(scaleRepo)
+	tareScaleHandler := scalecmd.NewTareScaleHandler(scaleMonitor)
+	listScalesHandler := scaleqry.NewListScalesHandler(scaleRepo)
+	watchScaleHandler := scaleqry.NewWatchScaleHandler(scaleMonitor)
+	weighHandler := scaleqry.NewWeighHandler(scaleMonitor, cfg.Scale.WeighTimeout)
+	receiptRenderer := pdf.NewReceiptRenderer(invoiceSettings.Seller, cfg.Payment.Currency, location)
+	createTillHandler := poscmd.NewCreateTillHandler(posTillRepo)
+	updateTillHandler := poscmd.NewUpdateTillHandler(posTillRepo)
+	openSessionHandler := poscmd.NewOpenSessionHandler(posTillRepo, posSessionRepo, adminRepo)
+	closeSessionHandler := poscmd.NewCloseSessionHandler(posSessionRepo, posSaleRepo, adminRepo)
+	startSaleHandler := poscmd.NewStartSaleHandler(posSessionRepo, posSaleRepo, adminRepo)
+	addSaleLineHandler := poscmd.NewAddSaleLineHandler(posSaleRepo, pluItemRepo, branchRepo, resolveBarcodeHandler, pricer, adminRepo)
+	voidSaleLineHandler := poscmd.NewVoidSaleLineHandler(posSaleRepo, pricer, adminRepo)
+	takePaymentHandler := poscmd.NewTakePaymentHandler(posSaleRepo, adminRepo)
+	voidSaleHandler := poscmd.NewVoidSaleHandler(posSessionRepo, posSaleRepo, adminRepo)
+	returnSaleHandler := poscmd.NewReturnSaleHandler(posSessionRepo, posSaleRepo, adminRepo)
+	listTillsHandler := posqry.NewListTillsHandler(posTillRepo)
+	listTillSessionsHandler := posqry.NewListSessionsHandler(posTillRepo, posSessionRepo)
+	tillReportHandler := posqry.NewSessionReportHandler(posSessionRepo, posSaleRepo)
+	getSaleHandler := posqry.NewGetSaleHandler(posSaleRepo)
+	getReceiptHandler := posqry.NewGetReceiptHandler(posSaleRepo, posTillRepo, adminRepo, receiptRenderer)
+	createBranchHandler := branchcmd.NewCreateBranchHandler(branchRepo, adminRepo)
+	updateBranchHandler := branchcmd.NewUpdateBranchHandler(branchRepo, adminRepo)
+	setBranchPriceHandler := branchcmd.NewSetPriceHandler(branchRepo, branchRepo, adminRepo)
+	deleteBranchPriceHandler := branchcmd.NewDeletePriceHandler(branchRepo, branchRepo, adminRepo)
+	assignAdminBranchesHandler := branchcmd.NewAssignAdminBranchesHandler(adminRepo, branchRepo)
+	listBranchesHandler := branchqry.NewListBranchesHandler(branchRepo)
+	getBranchHandler := branchqry.NewGetBranchHandler(branchRepo, fulfilmentScheduleRepo)
+	listBranchPricesHandler := branchqry.NewListPricesHandler(branchRepo, branchRepo)
+	listAdminsHandler := branchqry.NewListAdminsHandler(adminRepo)
+	haccpRenderers := map[haccp.Format]haccp.ReportRenderer{
+		haccp.FormatPDF: pdf.NewHACCPReportRenderer(),
+		haccp.FormatCSV: csvreport.NewHACCPReportRenderer(),
+	}
+	createHACCPUnitHandler := haccpcmd.NewCreateUnitHandler(haccpUnitRepo, branchRepo)
+	updateHACCPUnitHandler := haccpcmd.NewUpdateUnitHandler(haccpUnitRepo)
+	recordHACCPReadingHandler := haccpcmd.NewRecordReadingHandler(haccpUnitRepo, haccpLogRepo, notifier)
+	ingestHACCPReadingsHandler := haccpcmd.NewIngestReadingsHandler(haccpUnitRepo, haccpLogRepo, notifier, cfg.HACCP.SensorKey)
+	recordHACCPActionHandler := haccpcmd.NewRecordActionHandler(haccpUnitRepo, haccpLogRepo)
+	runHACCPChecksHandler := haccpcmd.NewRunChecksHandler(haccpUnitRepo, haccpLogRepo, notifier)
+	listHACCPUnitsHandler := haccpqry.NewListUnitsHandler(haccpUnitRepo)
+	listHACCPReadingsHandler := haccpqry.NewListReadingsHandler(haccpLogRepo)
+	listHACCPAlertsHandler := haccpqry.NewListAlertsHandler(haccpLogRepo)
+	exportHACCPReportHandler := haccpqry.NewExportReportHandler(haccpUnitRepo, haccpLogRepo, branchRepo, adminRepo,
+		haccpRenderers, location)
+
+	staffPolicy := staff.Policy{
+		BreakAfter:       cfg.Staff.BreakAfter,
+		MinBreak:         cfg.Staff.MinBreak,
+		OrdersPerButcher: cfg.Staff.OrdersPerButcher,
+		KgPerButcher:     cfg.Staff.KgPerButcher,
+	}
+	staffRenderers := map[staff.Format]staff.TimesheetRenderer{staff.FormatCSV: csvreport.NewTimesheetReportRenderer()}
+	setStaffProfileHandler := staffcmd.NewSetProfileHandler(adminRepo, branchRepo, staffProfileRepo)
+	scheduleShiftHandler := staffcmd.NewScheduleShiftHandler(adminRepo, branchRepo, staffProfileRepo, staffShiftRepo)
+	updateShiftHandler := staffcmd.NewUpdateShiftHandler(adminRepo, branchRepo, staffProfileRepo, staffShiftRepo)
+	deleteShiftHandler := staffcmd.NewDeleteShiftHandler(adminRepo, staffShiftRepo)
+	clockInHandler := staffcmd.NewClockInHandler(staffProfileRepo, staffClockRepo)
+	startBreakHandler := staffcmd.NewStartBreakHandler(staffClockRepo)
+	endBreakHandler := staffcmd.NewEndBreakHandler(staffClockRepo)
+	clockOutHandler := staffcmd.NewClockOutHandler(staffClockRepo)
+	listStaffProfilesHandler := staffqry.NewListProfilesHandler(adminRepo, staffProfileRepo)
+	rotaHandler := staffqry.NewRotaHandler(staffProfileRepo, staffShiftRepo, fulfilmentScheduleRepo, slotReservationRepo,
+		staffPolicy, location)
+	timesheetsHandler := staffqry.NewTimesheetsHandler(adminRepo, staffProfileRepo, staffShiftRepo, staffClockRepo,
+		staffPolicy, location)
+	exportTimesheetsHandler := staffqry.NewExportTimesheetsHandler(timesheetsHandler, branchRepo, staffRenderers, location)
+
+	createDriverHandler := dispatchcmd.NewCreateDriverHandler(adminRepo, branchRepo, driverRepo, passwordHasher)
+	updateDriverHandler := dispatchcmd.NewUpdateDriverHandler(adminRepo, branchRepo, driverRepo)
+	planRunHandler := dispatchcmd.NewPlanRunHandler(adminRepo, deliveryZoneRepo, fulfilmentScheduleRepo, slotReservationRepo,
+		customerAddressRepo, driverRepo, deliveryRunRepo)
+	addStopHandler := dispatchcmd.NewAddStopHandler(adminRepo, orderRepo, customerAddressRepo, deliveryRunRepo)
+	removeStopHandler := dispatchcmd.NewRemoveStopHandler(adminRepo, deliveryRunRepo)
+	optimiseRunHandler := dispatchcmd.NewOptimiseRunHandler(adminRepo, deliveryRunRepo)
+	assignDriverHandler := dispatchcmd.NewAssignDriverHandler(adminRepo, driverRepo, deliveryRunRepo)
+	startRunHandler := dispatchcmd.NewStartRunHandler(deliveryRunRepo)
+	deliverStopHandler := dispatchcmd.NewDeliverStopHandler(deliveryRunRepo, deliveryProofRepo)
+	failStopHandler := dispatchcmd.NewFailStopHandler(deliveryRunRepo, deliveryProofRepo)
+	listDriversHandler := dispatchqry.NewListDriversHandler(adminRepo, driverRepo)
+	listRunsHandler := dispatchqry.NewListRunsHandler(adminRepo, deliveryRunRepo, location)
+	getRunHandler := dispatchqry.NewGetRunHandler(adminRepo, deliveryRunRepo)
+	driverRunsHandler := dispatchqry.NewDriverRunsHandler(deliveryRunRepo, location)
+	getProofHandler := dispatchqry.NewGetProofHandler(adminRepo, deliveryProofRepo)
+	orderDeliveryHandler := dispatchqry.NewOrderDeliveryHandler(deliveryRunRepo, driverRepo)
 
 	// HTTP handlers
 	adminAuthHandler := handler.NewAdminAuthHandler(adminLoginHandler)
 	customerAuthHandler := handler.NewCustomerAuthHandler(registerCustomerHandler, customerLoginHandler)
 	authHandler := handler.NewAuthHandler(refreshTokenHandler, logoutHandler)
+	fulfilmentHandler := handler.NewFulfilmentHandler(listAvailableSlotsHandler, reserveSlotHandler, releaseReservationHandler)
+	adminFulfilmentHandler := handler.NewAdminFulfilmentHandler(setOpeningHoursHandler, addClosureHandler, createSlotTemplateHandler)
+	addressHandler := handler.NewAddressHandler(listAddressesHandler, addAddressHandler, updateAddressHandler, deleteAddressHandler, setDefaultAddressHandler)
+	deliveryHandler := handler.NewDeliveryHandler(validateDeliveryAddressHandler)
+	adminDeliveryHandler := handler.NewAdminDeliveryHandler(listZonesHandler, createZoneHandler, updateZoneHandler)
+	inventoryHandler := handler.NewInventoryHandler(reserveStockHandler, releaseStockHandler)
+	adminInventoryHandler := handler.NewAdminInventoryHandler(receiveLotHandler, recordMovementHandler, stockTakeHandler,
+		setLowStockThresholdHandler, fulfilStockHandler, listStockLevelsHandler, listMovementsHandler)
+	adminWasteHandler := handler.NewAdminWasteHandler(recordWasteHandler, setUseByHandler, scanExpiryHandler,
+		acceptExpiryProposalHandler, dismissExpiryProposalHandler, listExpiryProposalsHandler, shrinkageReportHandler)
+	adminCarcassHandler := handler.NewAdminCarcassHandler(recordIntakeHandler, recordBreakdownHandler, setYieldTemplateHandler,
+		listCarcassesHandler, yieldReportHandler, listYieldTemplatesHandler)
+	traceHandler := handler.NewTraceHandler(lotTraceHandler)
+	adminTraceabilityHandler := handler.NewAdminTraceabilityHandler(registerCertificateHandler, recordOriginHandler,
+		listCertificatesHandler, consumedLotsHandler)
+	adminSupplierHandler := handler.NewAdminSupplierHandler(createSupplierHandler, updateSupplierHandler,
+		listSuppliersHandler, priceHistoryHandler)
+	adminPurchaseOrderHandler := handler.NewAdminPurchaseOrderHandler(createPurchaseOrderHandler, revisePurchaseOrderHandler,
+		sendPurchaseOrderHandler, closePurchaseOrderHandler, receiveGoodsHandler, listPurchaseOrdersHandler,
+		getPurchaseOrderHandler, listReceiptsHandler, varianceReportHandler)
+	paymentHandler := handler.NewPaymentHandler(authorizePaymentHandler, handleWebhookHandler)
+	adminPaymentHandler := handler.NewAdminPaymentHandler(getPaymentHandler, capturePaymentHandler, voidPaymentHandler)
+	adminOrderHandler := handler.NewAdminOrderHandler(createOrderHandler, getOrderHandler, requestRefundHandler,
+		approveRefundHandler, rejectRefundHandler, refundReceiptHandler, listAuditEntriesHandler, advanceStageHandler,
+		watchOrdersHandler, cancelOrderHandler)
+	orderHandler := handler.NewOrderHandler(watchOrdersHandler, placeOrderHandler, cancelOrderHandler)
+	invoiceHandler := handler.NewInvoiceHandler(listCustomerDocumentsHandler, getDocumentHandler)
+	adminInvoiceHandler := handler.NewAdminInvoiceHandler(issueInvoiceHandler, issueCreditNoteHandler,
+		listOrderDocumentsHandler, getDocumentHandler)
+	cartHandler := handler.NewCartHandler(quoteCartHandler)
+	adminTaxHandler := handler.NewAdminTaxHandler(listTaxRatesHandler, createTaxRateHandler, assignProductTaxCategoryHandler)
+	adminPromotionHandler := handler.NewAdminPromotionHandler(listPromotionsHandler, createPromotionHandler,
+		updatePromotionHandler, classifyPromotionProductHandler)
+	loyaltyHandler := handler.NewLoyaltyHandler(getLoyaltyAccountHandler, setBirthdayHandler)
+	adminLoyaltyHandler := handler.NewAdminLoyaltyHandler(getLoyaltyRulesHandler, updateLoyaltyRulesHandler,
+		setProductBonusHandler, getLoyaltyAccountHandler, adjustPointsHandler, rebuildBalanceHandler,
+		expirePointsHandler, awardBirthdayBonusesHandler)
+	qurbaniHandler := handler.NewQurbaniHandler(listQurbaniOfferingsHandler, bookQurbaniShareHandler,
+		listQurbaniBookingsHandler, cancelQurbaniBookingHandler)
+	adminQurbaniHandler := handler.NewAdminQurbaniHandler(createQurbaniOfferingHandler, updateQurbaniOfferingHandler,
+		listQurbaniOfferingsHandler, listQurbaniBookingsHandler, cancelQurbaniBookingHandler, allocateQurbaniSharesHandler,
+		slaughterSheetHandler, planQurbaniBatchesHandler, listQurbaniBatchesHandler, completeQurbaniBatchHandler,
+		notifyQurbaniBatchHandler, qurbaniDistributionHandler)
+	subscriptionHandler := handler.NewSubscriptionHandler(listSubscriptionBoxesHandler, subscribeHandler,
+		listSubscriptionsHandler, skipDeliveryHandler, swapCutHandler, changeSubscriptionSlotHandler,
+		changePaymentMethodHandler, pauseSubscriptionHandler, resumeSubscriptionHandler, cancelSubscriptionHandler,
+		listRenewalsHandler)
+	adminSubscriptionHandler := handler.NewAdminSubscriptionHandler(createSubscriptionBoxHandler,
+		updateSubscriptionBoxHandler, listSubscriptionBoxesHandler, listSubscriptionsHandler, listRenewalsHandler,
+		runRenewalsHandler)
+	businessHandler := handler.NewBusinessHandler(getBusinessMembershipHandler, placeBusinessOrderHandler,
+		decideBusinessOrderHandler, listBusinessOrdersHandler, businessStatementHandler)
+	adminBusinessHandler := handler.NewAdminBusinessHandler(createBusinessAccountHandler, updateBusinessAccountHandler,
+		setBusinessPricesHandler, listBusinessAccountsHandler, getBusinessAccountHandler, listBusinessMembersHandler,
+		saveBusinessMemberHandler, removeBusinessMemberHandler, recordBusinessPaymentHandler, listBusinessOrdersHandler,
+		businessStatementHandler)
+	adminBarcodeHandler := handler.NewAdminBarcodeHandler(createPLUItemHandler, updatePLUItemHandler,
+		listPLUItemsHandler, encodeBarcodeHandler, resolveBarcodeHandler)
+	adminLabelHandler := handler.NewAdminLabelHandler(createPrinterHandler, updatePrinterHandler, printLabelsHandler,
+		dispatchPrintJobsHandler, retryPrintJobHandler, listPrintersHandler, listPrintJobsHandler, renderLabelsHandler)
+	adminScaleHandler := handler.NewAdminScaleHandler(createScaleHandler, updateScaleHandler, tareScaleHandler,
+		listScalesHandler, watchScaleHandler, weighHandler)
+	adminPOSHandler := handler.NewAdminPOSHandler(createTillHandler, updateTillHandler, openSessionHandler,
+		closeSessionHandler, startSaleHandler, addSaleLineHandler, voidSaleLineHandler, takePaymentHandler,
+		voidSaleHandler, returnSaleHandler, listTillsHandler, listTillSessionsHandler, tillReportHandler,
+		getSaleHandler, getReceiptHandler)
+	branchHandler := handler.NewBranchHandler(listBranchesHandler, getBranchHandler)
+	adminBranchHandler := handler.NewAdminBranchHandler(createBranchHandler, updateBranchHandler, setBranchPriceHandler,
+		deleteBranchPriceHandler, assignAdminBranchesHandler, listBranchesHandler, getBranchHandler,
+		listBranchPricesHandler, listAdminsHandler)
+	haccpHandler := handler.NewHACCPHandler(ingestHACCPReadingsHandler)
+	adminHACCPHandler := handler.NewAdminHACCPHandler(createHACCPUnitHandler, updateHACCPUnitHandler,
+		recordHACCPReadingHandler, recordHACCPActionHandler, runHACCPChecksHandler, listHACCPUnitsHandler,
+		listHACCPReadingsHandler, listHACCPAlertsHandler, exportHACCPReportHandler)
+	adminStaffHandler := handler.NewAdminStaffHandler(setStaffProfileHandler, scheduleShiftHandler, updateShiftHandler,
+		deleteShiftHandler, clockInHandler, startBreakHandler, endBreakHandler, clockOutHandler,
+		listStaffProfilesHandler, rotaHandler, timesheetsHandler, exportTimesheetsHandler)
+	dispatchHandler := handler.NewDispatchHandler(orderDeliveryHandler)
+	adminDispatchHandler := handler.NewAdminDispatchHandler(createDriverHandler, updateDriverHandler, planRunHandler,
+		addStopHandler, removeStopHandler, optimiseRunHandler, assignDriverHandler, listDriversHandler, listRunsHandler,
+		getRunHandler, getProofHandler)
+	driverHandler := handler.NewDriverHandler(startRunHandler, deliverStopHandler, failStopHandler, driverRunsHandler,
+		getRunHandler)
 
 	// Middleware
 	authMiddleware := middleware.NewAuthMiddleware(tokenService)
+	branchScopeMiddleware := middleware.NewBranchScopeMiddleware(adminRepo)
+	roleMiddleware := middleware.NewRoleMiddleware(adminRepo)
 
 	// Router
 	router := apphttp.NewRouter(apphttp.RouterDeps{
 		Logger:              logger,
 		AuthMiddleware:      authMiddleware,
+		BranchScope:         branchScopeMiddleware,
+		Roles:               roleMiddleware,
 		AdminAuthHandler:    adminAuthHandler,
 		CustomerAuthHandler: customerAuthHandler,
 		AuthHandler:         authHandler,
+		FulfilmentHandler:   fulfilmentHandler,
+		AdminFulfilment:     adminFulfilmentHandler,
+		AddressHandler:      addressHandler,
+		DeliveryHandler:     deliveryHandler,
+		AdminDelivery:       adminDeliveryHandler,
+		InventoryHandler:    inventoryHandler,
+		AdminInventory:      adminInventoryHandler,
+		AdminWaste:          adminWasteHandler,
+		AdminCarcass:        adminCarcassHandler,
+		TraceHandler:        traceHandler,
+		AdminTraceability:   adminTraceabilityHandler,
+		AdminSupplier:       adminSupplierHandler,
+		AdminPurchaseOrder:  adminPurchaseOrderHandler,
+		PaymentHandler:      paymentHandler,
+		AdminPayment:        adminPaymentHandler,
+		OrderHandler:        orderHandler,
+		AdminOrder:          adminOrderHandler,
+		InvoiceHandler:      invoiceHandler,
+		AdminInvoice:        adminInvoiceHandler,
+		CartHandler:         cartHandler,
+		AdminTax:            adminTaxHandler,
+		AdminPromotion:      adminPromotionHandler,
+		LoyaltyHandler:      loyaltyHandler,
+		AdminLoyalty:        adminLoyaltyHandler,
+		QurbaniHandler:      qurbaniHandler,
+		AdminQurbani:        adminQurbaniHandler,
+		SubscriptionHandler: subscriptionHandler,
+		AdminSubscription:   adminSubscriptionHandler,
+		BusinessHandler:     businessHandler,
+		AdminBusiness:       adminBusinessHandler,
+		AdminBarcode:        adminBarcodeHandler,
+		AdminLabel:          adminLabelHandler,
+		AdminScale:          adminScaleHandler,
+		AdminPOS:            adminPOSHandler,
+		BranchHandler:       branchHandler,
+		AdminBranch:         adminBranchHandler,
+		HACCPHandler:        haccpHandler,
+		AdminHACCP:          adminHACCPHandler,
+		AdminStaff:          adminStaffHandler,
+		DispatchHandler:     dispatchHandler,
+		AdminDispatch:       adminDispatchHandler,
+		Driver:              driverHandler,
+	})
+
+	shutdownCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go runEvery(shutdownCtx, logger, "subscription renewals", cfg.Subscription.RunEvery, func(ctx context.Context) error {
+		_, err := runRenewalsHandler.Handle(ctx, time.Now())
+		return err
+	})
+	go runEvery(shutdownCtx, logger, "haccp missing reading checks", cfg.HACCP.CheckEvery, func(ctx context.Context) error {
+		_, err := runHACCPChecksHandler.Handle(ctx)
+		return err
+	})
+	go runEvery(shutdownCtx, logger, "expiry scan", cfg.Inventory.ScanEvery, func(ctx context.Context) error {
+		_, err := scanExpiryHandler.Handle(ctx, invcmd.ScanExpiryCommand{})
+		return err
 	})
 
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
+	server := &http.Server{Addr: addr, Handler: router}
 	logger.Info("starting server", slog.String("addr", addr))
 
-	if err := http.ListenAndServe(addr, router); err != nil {
+	serverErr := make(chan error, 1)
+	go func() { serverErr <- server.ListenAndServe() }()
+
+	select {
+	case err := <-serverErr:
 		logger.Error("server failed", slog.String("error", err.Error()))
 		os.Exit(1)
+	case <-shutdownCtx.Done():
+	}
+
+	logger.Info("shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("shutdown failed", slog.String("error", err.Error()))
+	}
+}
+
+// runEvery runs job every interval until ctx is done. Errors are logged and
+// the job runs again at the next tick.
+func runEvery(ctx context.Context, logger *slog.Logger, name string, every time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				logger.Error("scheduled job failed", slog.String("job", name), slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// newPaymentGateway returns the configured payment provider. config.Load has
+// already checked that the provider is known and fully configured.
+func newPaymentGateway(cfg config.PaymentConfig) payment.PaymentGateway {
+	if cfg.Provider == "stripe" {
+		return infrapayment.NewStripeGateway(cfg.StripeAPIKey, cfg.StripeBaseURL, cfg.WebhookSecret)
 	}
+	return infrapayment.NewFakeGateway(cfg.WebhookSecret)
 }
@@ -0,0 +1,64 @@
+// Command scalesim runs a simulated weighing scale on a TCP port, so the
+// weighing screen can be tried without hardware. Register it as a tcp scale
+// at the address it listens on, then type a weight in grams to put it on
+// the platter, "t" to tare, or "0" to clear it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/scaleio"
+)
+
+func main() {
+	address := flag.String("addr", "127.0.0.1:4001", "address to listen on")
+	protocol := flag.String("protocol", string(scale.ProtocolMTSICS), "mt-sics or cas")
+	interval := flag.Duration("interval", 200*time.Millisecond, "how often a cas scale sends its weight")
+	load := flag.Int64("load", 0, "grams on the platter at start")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	sim, err := scaleio.NewSimulator(scale.Protocol(*protocol), *interval)
+	if err != nil {
+		logger.Error("invalid protocol", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	listening, err := sim.Listen(*address)
+	if err != nil {
+		logger.Error("failed to listen", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer sim.Close()
+	sim.Place(*load)
+	logger.Info("simulated scale listening", slog.String("address", listening), slog.String("protocol", *protocol))
+
+	input := bufio.NewScanner(os.Stdin)
+	fmt.Fprint(os.Stderr, "grams, t to tare> ")
+	for input.Scan() {
+		line := strings.TrimSpace(input.Text())
+		switch {
+		case line == "":
+		case line == "t":
+			sim.Tare()
+			logger.Info("tared")
+		default:
+			grams, err := strconv.ParseInt(line, 10, 64)
+			if err != nil {
+				logger.Warn("not a weight in grams", slog.String("input", line))
+				break
+			}
+			sim.Place(grams)
+			logger.Info("placed", slog.Int64("grams", grams))
+		}
+		fmt.Fprint(os.Stderr, "grams, t to tare> ")
+	}
+}
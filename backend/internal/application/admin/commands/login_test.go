@@ -37,6 +37,24 @@ func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admi
 	return args.Get(0).(*admin.Admin), args.Error(1)
 }
 
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
 type mockPasswordHasher struct {
 	mock.Mock
 }
@@ -101,7 +119,7 @@ func TestAdminLogin_ValidCredentials_ReturnsTokens(t *testing.T) {
 	refreshRepo := new(mockRefreshTokenRepository)
 
 	adminID := uuid.New()
-	a, _ := admin.NewAdmin(adminID, "admin@butchery.com", "$2a$10$hash", "Admin")
+	a, _ := admin.NewAdmin(adminID, "admin@butchery.com", "$2a$10$hash", "Admin", admin.RoleStaff)
 
 	adminRepo.On("FindByEmail", mock.Anything, "admin@butchery.com").Return(a, nil)
 	hasher.On("Compare", "$2a$10$hash", "password123").Return(nil)
@@ -149,7 +167,7 @@ func TestAdminLogin_WrongPassword_ReturnsError(t *testing.T) {
 	refreshRepo := new(mockRefreshTokenRepository)
 
 	adminID := uuid.New()
-	a, _ := admin.NewAdmin(adminID, "admin@butchery.com", "$2a$10$hash", "Admin")
+	a, _ := admin.NewAdmin(adminID, "admin@butchery.com", "$2a$10$hash", "Admin", admin.RoleStaff)
 
 	adminRepo.On("FindByEmail", mock.Anything, "admin@butchery.com").Return(a, nil)
 	hasher.On("Compare", "$2a$10$hash", "wrongpassword").Return(errors.New("mismatch"))
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+)
+
+// ListEntriesHandler returns the audit trail of one record.
+type ListEntriesHandler struct {
+	auditRepo audit.Repository
+}
+
+// NewListEntriesHandler creates a new ListEntriesHandler.
+func NewListEntriesHandler(auditRepo audit.Repository) *ListEntriesHandler {
+	return &ListEntriesHandler{auditRepo: auditRepo}
+}
+
+// Handle returns the entries recorded against a record, oldest first.
+func (h *ListEntriesHandler) Handle(ctx context.Context, entityType string, entityID uuid.UUID) ([]*audit.Entry, error) {
+	entries, err := h.auditRepo.FindByEntity(ctx, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("querying audit entries: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// AssignAdminBranchesCommand is the input for the assign admin branches use
+// case. AllBranches gives the admin every branch, now and to come, and
+// BranchIDs is then ignored.
+type AssignAdminBranchesCommand struct {
+	AdminID     uuid.UUID
+	AllBranches bool
+	BranchIDs   []uuid.UUID
+	ActorID     uuid.UUID
+}
+
+// AssignAdminBranchesHandler sets the branches an admin works at.
+type AssignAdminBranchesHandler struct {
+	adminRepo  admin.Repository
+	branchRepo branch.Repository
+}
+
+// NewAssignAdminBranchesHandler creates a new AssignAdminBranchesHandler.
+func NewAssignAdminBranchesHandler(adminRepo admin.Repository, branchRepo branch.Repository) *AssignAdminBranchesHandler {
+	return &AssignAdminBranchesHandler{adminRepo: adminRepo, branchRepo: branchRepo}
+}
+
+// Handle executes the assign admin branches use case. A manager can only
+// hand out branches in their own scope, and only change admins who work
+// nowhere outside it, so nobody can widen their own reach or another's
+// beyond what they have themselves.
+func (h *AssignAdminBranchesHandler) Handle(ctx context.Context, cmd AssignAdminBranchesCommand) (*admin.Admin, error) {
+	if _, err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	target, err := h.adminRepo.FindByID(ctx, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := branch.ScopeFrom(ctx)
+	if !scope.All() && (cmd.AllBranches || target.AllBranches()) {
+		return nil, branch.ErrOutOfScope
+	}
+	for _, id := range target.BranchIDs() {
+		if err := scope.Check(id); err != nil {
+			return nil, err
+		}
+	}
+	if !cmd.AllBranches {
+		for _, id := range cmd.BranchIDs {
+			if _, err := h.branchRepo.FindByID(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	target.AssignBranches(cmd.AllBranches, cmd.BranchIDs)
+	if err := h.adminRepo.SaveBranches(ctx, target); err != nil {
+		return nil, fmt.Errorf("saving admin branches: %w", err)
+	}
+	return target, nil
+}
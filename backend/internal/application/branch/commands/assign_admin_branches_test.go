@@ -0,0 +1,201 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/branch/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+type mockBranchRepository struct {
+	mock.Mock
+}
+
+func (m *mockBranchRepository) Save(ctx context.Context, b *branch.Branch) error {
+	return m.Called(ctx, b).Error(0)
+}
+
+func (m *mockBranchRepository) FindByID(ctx context.Context, id uuid.UUID) (*branch.Branch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*branch.Branch), args.Error(1)
+}
+
+func (m *mockBranchRepository) FindAll(ctx context.Context, includeInactive bool) ([]*branch.Branch, error) {
+	args := m.Called(ctx, includeInactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*branch.Branch), args.Error(1)
+}
+
+type mockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *mockPriceRepository) SetPrice(ctx context.Context, p *branch.PriceOverride) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockPriceRepository) DeletePrice(ctx context.Context, branchID, productID uuid.UUID) error {
+	return m.Called(ctx, branchID, productID).Error(0)
+}
+
+func (m *mockPriceRepository) FindPrices(ctx context.Context, branchID uuid.UUID) ([]*branch.PriceOverride, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*branch.PriceOverride), args.Error(1)
+}
+
+func (m *mockPriceRepository) PricesFor(ctx context.Context, branchID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(ctx, branchID, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+// --- Fixtures ---
+
+// newAdmin returns an admin with role, known to repo, working at branchIDs
+// or at every branch if there are none.
+func newAdmin(t *testing.T, repo *mockAdminRepository, role admin.Role, branchIDs ...uuid.UUID) *admin.Admin {
+	t.Helper()
+	id := uuid.New()
+	a, err := admin.NewAdmin(id, id.String()+"@butchery.com", "$2a$10$hash", "Admin", role)
+	require.NoError(t, err)
+	a.AssignBranches(len(branchIDs) == 0, branchIDs)
+	repo.On("FindByID", mock.Anything, id).Return(a, nil)
+	return a
+}
+
+// newBranch returns a branch known to repo.
+func newBranch(t *testing.T, repo *mockBranchRepository, name string) *branch.Branch {
+	t.Helper()
+	b, err := branch.NewBranch(uuid.New(), name, []string{"1 High Street"}, "", "", time.Now())
+	require.NoError(t, err)
+	repo.On("FindByID", mock.Anything, b.ID()).Return(b, nil)
+	return b
+}
+
+// --- Tests ---
+
+func TestCreateBranch_NotManager_ReturnsError(t *testing.T) {
+	admins, branches := new(mockAdminRepository), new(mockBranchRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+
+	_, err := commands.NewCreateBranchHandler(branches, admins).Handle(context.Background(), commands.CreateBranchCommand{
+		Name: "Whitechapel", Address: []string{"1 High Street"}, ActorID: cashier.ID(),
+	})
+
+	assert.ErrorIs(t, err, branch.ErrManageNotAllowed)
+	branches.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestSetPrice_StoresOverride(t *testing.T) {
+	admins, branches, prices := new(mockAdminRepository), new(mockBranchRepository), new(mockPriceRepository)
+	mgr := newAdmin(t, admins, admin.RoleManager)
+	b := newBranch(t, branches, "Whitechapel")
+	prices.On("SetPrice", mock.Anything, mock.Anything).Return(nil)
+
+	p, err := commands.NewSetPriceHandler(branches, prices, admins).Handle(context.Background(), commands.SetPriceCommand{
+		BranchID: b.ID(), ProductID: uuid.New(), PricePerKgCents: 2000, ActorID: mgr.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, b.ID(), p.BranchID())
+	assert.Equal(t, int64(2000), p.PricePerKgCents())
+	prices.AssertCalled(t, "SetPrice", mock.Anything, p)
+}
+
+func TestAssignAdminBranches_ManagerOfEveryBranch_AssignsBranches(t *testing.T) {
+	admins, branches := new(mockAdminRepository), new(mockBranchRepository)
+	mgr := newAdmin(t, admins, admin.RoleManager)
+	east := newBranch(t, branches, "East")
+	cashier := newAdmin(t, admins, admin.RoleCashier, uuid.New())
+	admins.On("SaveBranches", mock.Anything, cashier).Return(nil)
+
+	got, err := commands.NewAssignAdminBranchesHandler(admins, branches).Handle(context.Background(), commands.AssignAdminBranchesCommand{
+		AdminID: cashier.ID(), BranchIDs: []uuid.UUID{east.ID()}, ActorID: mgr.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.False(t, got.AllBranches())
+	assert.Equal(t, []uuid.UUID{east.ID()}, got.BranchIDs())
+}
+
+func TestAssignAdminBranches_BeyondActorsScope_ReturnsError(t *testing.T) {
+	east, west := uuid.New(), uuid.New()
+	tests := []struct {
+		name   string
+		target []uuid.UUID
+		cmd    commands.AssignAdminBranchesCommand
+	}{
+		{"every branch", []uuid.UUID{east}, commands.AssignAdminBranchesCommand{AllBranches: true}},
+		{"admin of every branch", nil, commands.AssignAdminBranchesCommand{BranchIDs: []uuid.UUID{east}}},
+		{"admin of another branch", []uuid.UUID{west}, commands.AssignAdminBranchesCommand{BranchIDs: []uuid.UUID{east}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admins, branches := new(mockAdminRepository), new(mockBranchRepository)
+			mgr := newAdmin(t, admins, admin.RoleManager, east)
+			target := newAdmin(t, admins, admin.RoleCashier, tt.target...)
+			tt.cmd.AdminID, tt.cmd.ActorID = target.ID(), mgr.ID()
+			ctx := branch.WithScope(context.Background(), branch.Only(east))
+
+			_, err := commands.NewAssignAdminBranchesHandler(admins, branches).Handle(ctx, tt.cmd)
+
+			assert.ErrorIs(t, err, branch.ErrOutOfScope)
+			admins.AssertNotCalled(t, "SaveBranches", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// CreateBranchCommand is the input for the create branch use case.
+type CreateBranchCommand struct {
+	Name    string
+	Address []string
+	Phone   string
+	Email   string
+	ActorID uuid.UUID
+}
+
+// CreateBranchHandler opens a new branch.
+type CreateBranchHandler struct {
+	branchRepo branch.Repository
+	adminRepo  admin.Repository
+}
+
+// NewCreateBranchHandler creates a new CreateBranchHandler.
+func NewCreateBranchHandler(branchRepo branch.Repository, adminRepo admin.Repository) *CreateBranchHandler {
+	return &CreateBranchHandler{branchRepo: branchRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the create branch use case. Only managers of every
+// branch may open one; admins are then assigned to it separately.
+func (h *CreateBranchHandler) Handle(ctx context.Context, cmd CreateBranchCommand) (*branch.Branch, error) {
+	if _, err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	b, err := branch.NewBranch(uuid.New(), cmd.Name, cmd.Address, cmd.Phone, cmd.Email, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.branchRepo.Save(ctx, b); err != nil {
+		if errors.Is(err, branch.ErrDuplicateName) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving branch: %w", err)
+	}
+	return b, nil
+}
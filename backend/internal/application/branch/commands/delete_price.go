@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// DeletePriceCommand is the input for the delete price use case.
+type DeletePriceCommand struct {
+	BranchID  uuid.UUID
+	ProductID uuid.UUID
+	ActorID   uuid.UUID
+}
+
+// DeletePriceHandler puts a product back on its usual price at a branch.
+type DeletePriceHandler struct {
+	branchRepo branch.Repository
+	priceRepo  branch.PriceRepository
+	adminRepo  admin.Repository
+}
+
+// NewDeletePriceHandler creates a new DeletePriceHandler.
+func NewDeletePriceHandler(branchRepo branch.Repository, priceRepo branch.PriceRepository, adminRepo admin.Repository) *DeletePriceHandler {
+	return &DeletePriceHandler{branchRepo: branchRepo, priceRepo: priceRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the delete price use case.
+func (h *DeletePriceHandler) Handle(ctx context.Context, cmd DeletePriceCommand) error {
+	if _, err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return err
+	}
+	if err := h.priceRepo.DeletePrice(ctx, cmd.BranchID, cmd.ProductID); err != nil {
+		if errors.Is(err, branch.ErrPriceNotFound) {
+			return err
+		}
+		return fmt.Errorf("deleting branch price: %w", err)
+	}
+	return nil
+}
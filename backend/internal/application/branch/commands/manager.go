@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// manager loads the admin making the change and checks they may manage
+// branches.
+func manager(ctx context.Context, adminRepo admin.Repository, actorID uuid.UUID) (*admin.Admin, error) {
+	actor, err := adminRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasPermission(admin.PermissionManageBranches) {
+		return nil, branch.ErrManageNotAllowed
+	}
+	return actor, nil
+}
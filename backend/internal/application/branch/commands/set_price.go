@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// SetPriceCommand is the input for the set price use case.
+type SetPriceCommand struct {
+	BranchID        uuid.UUID
+	ProductID       uuid.UUID
+	PricePerKgCents int64
+	ActorID         uuid.UUID
+}
+
+// SetPriceHandler sets what a branch charges for a product.
+type SetPriceHandler struct {
+	branchRepo branch.Repository
+	priceRepo  branch.PriceRepository
+	adminRepo  admin.Repository
+}
+
+// NewSetPriceHandler creates a new SetPriceHandler.
+func NewSetPriceHandler(branchRepo branch.Repository, priceRepo branch.PriceRepository, adminRepo admin.Repository) *SetPriceHandler {
+	return &SetPriceHandler{branchRepo: branchRepo, priceRepo: priceRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the set price use case, replacing any price the branch
+// already had for the product.
+func (h *SetPriceHandler) Handle(ctx context.Context, cmd SetPriceCommand) (*branch.PriceOverride, error) {
+	if _, err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+	p, err := branch.NewPriceOverride(cmd.BranchID, cmd.ProductID, cmd.PricePerKgCents, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.priceRepo.SetPrice(ctx, p); err != nil {
+		return nil, fmt.Errorf("saving branch price: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// UpdateBranchCommand is the input for the update branch use case.
+type UpdateBranchCommand struct {
+	BranchID uuid.UUID
+	Name     string
+	Address  []string
+	Phone    string
+	Email    string
+	Active   bool
+	ActorID  uuid.UUID
+}
+
+// UpdateBranchHandler changes a branch's details or closes it.
+type UpdateBranchHandler struct {
+	branchRepo branch.Repository
+	adminRepo  admin.Repository
+}
+
+// NewUpdateBranchHandler creates a new UpdateBranchHandler.
+func NewUpdateBranchHandler(branchRepo branch.Repository, adminRepo admin.Repository) *UpdateBranchHandler {
+	return &UpdateBranchHandler{branchRepo: branchRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the update branch use case.
+func (h *UpdateBranchHandler) Handle(ctx context.Context, cmd UpdateBranchCommand) (*branch.Branch, error) {
+	if _, err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	b, err := h.branchRepo.FindByID(ctx, cmd.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Update(cmd.Name, cmd.Address, cmd.Phone, cmd.Email, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.branchRepo.Save(ctx, b); err != nil {
+		if errors.Is(err, branch.ErrDuplicateName) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving branch: %w", err)
+	}
+	return b, nil
+}
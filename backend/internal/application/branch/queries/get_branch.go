@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// BranchDetails is a branch with its trading hours.
+type BranchDetails struct {
+	Branch *branch.Branch
+	Hours  []*fulfilment.OpeningHours
+}
+
+// GetBranchHandler returns a branch with its trading hours.
+type GetBranchHandler struct {
+	branchRepo   branch.Repository
+	scheduleRepo fulfilment.ScheduleRepository
+}
+
+// NewGetBranchHandler creates a new GetBranchHandler.
+func NewGetBranchHandler(branchRepo branch.Repository, scheduleRepo fulfilment.ScheduleRepository) *GetBranchHandler {
+	return &GetBranchHandler{branchRepo: branchRepo, scheduleRepo: scheduleRepo}
+}
+
+// Handle executes the get branch query.
+func (h *GetBranchHandler) Handle(ctx context.Context, branchID uuid.UUID) (*BranchDetails, error) {
+	b, err := h.branchRepo.FindByID(ctx, branchID)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := h.scheduleRepo.FindOpeningHours(ctx, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("loading opening hours: %w", err)
+	}
+	return &BranchDetails{Branch: b, Hours: hours}, nil
+}
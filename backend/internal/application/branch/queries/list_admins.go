@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+)
+
+// ListAdminsHandler lists the admins working at branches in scope.
+type ListAdminsHandler struct {
+	adminRepo admin.Repository
+}
+
+// NewListAdminsHandler creates a new ListAdminsHandler.
+func NewListAdminsHandler(adminRepo admin.Repository) *ListAdminsHandler {
+	return &ListAdminsHandler{adminRepo: adminRepo}
+}
+
+// Handle returns the admins by name.
+func (h *ListAdminsHandler) Handle(ctx context.Context) ([]*admin.Admin, error) {
+	admins, err := h.adminRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing admins: %w", err)
+	}
+	return admins, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// ListBranchesHandler lists branches.
+type ListBranchesHandler struct {
+	branchRepo branch.Repository
+}
+
+// NewListBranchesHandler creates a new ListBranchesHandler.
+func NewListBranchesHandler(branchRepo branch.Repository) *ListBranchesHandler {
+	return &ListBranchesHandler{branchRepo: branchRepo}
+}
+
+// Handle returns the branches by name, closed ones only if asked for.
+func (h *ListBranchesHandler) Handle(ctx context.Context, includeInactive bool) ([]*branch.Branch, error) {
+	branches, err := h.branchRepo.FindAll(ctx, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	return branches, nil
+}
@@ -0,0 +1,32 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// ListPricesHandler lists a branch's own prices.
+type ListPricesHandler struct {
+	branchRepo branch.Repository
+	priceRepo  branch.PriceRepository
+}
+
+// NewListPricesHandler creates a new ListPricesHandler.
+func NewListPricesHandler(branchRepo branch.Repository, priceRepo branch.PriceRepository) *ListPricesHandler {
+	return &ListPricesHandler{branchRepo: branchRepo, priceRepo: priceRepo}
+}
+
+// Handle returns the branch's price overrides.
+func (h *ListPricesHandler) Handle(ctx context.Context, branchID uuid.UUID) ([]*branch.PriceOverride, error) {
+	if _, err := h.branchRepo.FindByID(ctx, branchID); err != nil {
+		return nil, err
+	}
+	prices, err := h.priceRepo.FindPrices(ctx, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("listing branch prices: %w", err)
+	}
+	return prices, nil
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// CreateAccountCommand is the input for the create account use case.
+type CreateAccountCommand struct {
+	Details business.Details
+	Terms   business.Terms
+}
+
+// CreateAccountHandler opens a business account.
+type CreateAccountHandler struct {
+	accountRepo business.AccountRepository
+}
+
+// NewCreateAccountHandler creates a new CreateAccountHandler.
+func NewCreateAccountHandler(accountRepo business.AccountRepository) *CreateAccountHandler {
+	return &CreateAccountHandler{accountRepo: accountRepo}
+}
+
+// Handle executes the create account use case.
+func (h *CreateAccountHandler) Handle(ctx context.Context, cmd CreateAccountCommand) (*business.Account, error) {
+	a, err := business.NewAccount(uuid.New(), cmd.Details, cmd.Terms, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.accountRepo.Create(ctx, a); err != nil {
+		return nil, fmt.Errorf("saving business account: %w", err)
+	}
+	return a, nil
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// DecideOrderCommand is the input for approving or rejecting an order
+// waiting for approval. Reason is only kept for rejections.
+type DecideOrderCommand struct {
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Approve    bool
+	Reason     string
+}
+
+// DecideOrderHandler lets a business's approvers approve or reject the
+// orders its buyers placed over the approval threshold.
+type DecideOrderHandler struct {
+	accountRepo business.AccountRepository
+	memberRepo  business.MemberRepository
+	ledger      business.Ledger
+	orderRepo   order.Repository
+	pricer      *pricing.Pricer
+	location    *time.Location
+}
+
+// NewDecideOrderHandler creates a new DecideOrderHandler.
+func NewDecideOrderHandler(
+	accountRepo business.AccountRepository,
+	memberRepo business.MemberRepository,
+	ledger business.Ledger,
+	orderRepo order.Repository,
+	pricer *pricing.Pricer,
+	location *time.Location,
+) *DecideOrderHandler {
+	return &DecideOrderHandler{
+		accountRepo: accountRepo,
+		memberRepo:  memberRepo,
+		ledger:      ledger,
+		orderRepo:   orderRepo,
+		pricer:      pricer,
+		location:    location,
+	}
+}
+
+// Handle executes the decide order use case. An approved order is priced
+// again at today's VAT rates, charged to the account in place of the
+// credit held for it and placed; the credit check is repeated because the
+// balance may have moved since. A rejected order gives its credit back.
+// Orders of other businesses are reported as not found.
+func (h *DecideOrderHandler) Handle(ctx context.Context, cmd DecideOrderCommand) (*business.Order, error) {
+	m, err := h.memberRepo.FindByCustomer(ctx, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	o, err := h.ledger.FindOrder(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.AccountID() != m.AccountID {
+		return nil, business.ErrOrderNotFound
+	}
+	a, err := h.accountRepo.FindByID(ctx, o.AccountID())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	held := o.TotalCents()
+	if !cmd.Approve {
+		if err := o.Reject(m, cmd.Reason, now); err != nil {
+			return nil, err
+		}
+		a.Release(held, now)
+		if err := record(ctx, h.ledger, a, o, nil); err != nil {
+			return nil, err
+		}
+		return o, nil
+	}
+
+	sold, total, err := price(ctx, h.pricer, o.Lines(), now)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.Approve(m, total, now); err != nil {
+		return nil, err
+	}
+	a.Release(held, now)
+	if err := a.Charge(total, now); err != nil {
+		return nil, err
+	}
+	if err := record(ctx, h.ledger, a, o, business.NewCharge(uuid.New(), a, o, now.In(h.location), now)); err != nil {
+		return nil, err
+	}
+	if err := recordSale(ctx, h.orderRepo, o, sold, now); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
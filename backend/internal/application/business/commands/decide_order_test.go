@@ -0,0 +1,118 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/business/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type decideFixture struct {
+	handler  *commands.DecideOrderHandler
+	accounts *mockAccountRepository
+	members  *mockMemberRepository
+	ledger   *mockLedger
+	orders   *mockOrderRepository
+}
+
+// newPendingOrder returns an order for 6kg of mince waiting for approval,
+// with credit held for it on the account.
+func newPendingOrder(t *testing.T, a *business.Account) *business.Order {
+	t.Helper()
+	buyer := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: business.RoleBuyer}
+	o, err := business.NewOrder(uuid.New(), uuid.New(), a, buyer, uuid.New(), []business.Line{
+		{ProductID: mince, Description: "Beef mince", Grams: 6000, PricePerKgCents: 900},
+	}, 5400, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, business.OrderPendingApproval, o.Status())
+	require.NoError(t, a.Hold(5400, time.Now()))
+	return o
+}
+
+func newDecideFixture(a *business.Account, o *business.Order, role business.Role) (*decideFixture, business.Member) {
+	f := &decideFixture{
+		accounts: new(mockAccountRepository),
+		members:  new(mockMemberRepository),
+		ledger:   new(mockLedger),
+		orders:   new(mockOrderRepository),
+	}
+	f.handler = commands.NewDecideOrderHandler(f.accounts, f.members, f.ledger, f.orders, newPricer(), time.UTC)
+	m := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: role}
+	f.members.On("FindByCustomer", mock.Anything, m.CustomerID).Return(m, nil)
+	f.ledger.On("FindOrder", mock.Anything, o.ID()).Return(o, nil)
+	f.accounts.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return f, m
+}
+
+func TestDecideOrder_Approve_ChargesInPlaceOfHold(t *testing.T) {
+	threshold := int64(5000)
+	a := newAccount(t, 100000, &threshold)
+	o := newPendingOrder(t, a)
+	f, m := newDecideFixture(a, o, business.RoleApprover)
+	f.ledger.On("Record", mock.Anything, a, o, mock.MatchedBy(func(e *business.Entry) bool {
+		return e.Type == business.EntryCharge && e.AmountCents == 5400 && *e.OrderID == o.OrderID()
+	})).Return(nil)
+	f.orders.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	got, err := f.handler.Handle(context.Background(), commands.DecideOrderCommand{
+		OrderID: o.ID(), CustomerID: m.CustomerID, Approve: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, business.OrderPlaced, got.Status())
+	assert.Equal(t, int64(0), a.HeldCents())
+	assert.Equal(t, int64(5400), a.BalanceCents())
+	f.orders.AssertExpectations(t)
+}
+
+func TestDecideOrder_Reject_ReleasesHold(t *testing.T) {
+	threshold := int64(5000)
+	a := newAccount(t, 100000, &threshold)
+	o := newPendingOrder(t, a)
+	f, m := newDecideFixture(a, o, business.RoleApprover)
+	f.ledger.On("Record", mock.Anything, a, o, (*business.Entry)(nil)).Return(nil)
+
+	got, err := f.handler.Handle(context.Background(), commands.DecideOrderCommand{
+		OrderID: o.ID(), CustomerID: m.CustomerID, Reason: "over budget",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, business.OrderRejected, got.Status())
+	assert.Equal(t, int64(0), a.HeldCents())
+	assert.Equal(t, int64(0), a.BalanceCents())
+	f.orders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDecideOrder_Buyer_NotPermitted(t *testing.T) {
+	threshold := int64(5000)
+	a := newAccount(t, 100000, &threshold)
+	o := newPendingOrder(t, a)
+	f, m := newDecideFixture(a, o, business.RoleBuyer)
+
+	_, err := f.handler.Handle(context.Background(), commands.DecideOrderCommand{
+		OrderID: o.ID(), CustomerID: m.CustomerID, Approve: true,
+	})
+
+	assert.ErrorIs(t, err, business.ErrNotApprover)
+	f.ledger.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDecideOrder_OtherBusiness_NotFound(t *testing.T) {
+	a := newAccount(t, 100000, nil)
+	threshold := int64(0)
+	other := newAccount(t, 100000, &threshold)
+	o := newPendingOrder(t, other)
+	f, m := newDecideFixture(a, o, business.RoleApprover)
+
+	_, err := f.handler.Handle(context.Background(), commands.DecideOrderCommand{
+		OrderID: o.ID(), CustomerID: m.CustomerID, Approve: true,
+	})
+
+	assert.ErrorIs(t, err, business.ErrOrderNotFound)
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// LineInput is a weight of one product on the account's price list.
+type LineInput struct {
+	ProductID uuid.UUID
+	Grams     int64
+}
+
+// PlaceOrderCommand is the input for the place order use case.
+type PlaceOrderCommand struct {
+	CustomerID uuid.UUID
+	BranchID   uuid.UUID
+	Lines      []LineInput
+}
+
+// PlaceOrderHandler places an order on a business's account.
+type PlaceOrderHandler struct {
+	accountRepo business.AccountRepository
+	memberRepo  business.MemberRepository
+	ledger      business.Ledger
+	orderRepo   order.Repository
+	pricer      *pricing.Pricer
+	location    *time.Location
+}
+
+// NewPlaceOrderHandler creates a new PlaceOrderHandler. Charges fall due
+// counting from the date in location.
+func NewPlaceOrderHandler(
+	accountRepo business.AccountRepository,
+	memberRepo business.MemberRepository,
+	ledger business.Ledger,
+	orderRepo order.Repository,
+	pricer *pricing.Pricer,
+	location *time.Location,
+) *PlaceOrderHandler {
+	return &PlaceOrderHandler{
+		accountRepo: accountRepo,
+		memberRepo:  memberRepo,
+		ledger:      ledger,
+		orderRepo:   orderRepo,
+		pricer:      pricer,
+		location:    location,
+	}
+}
+
+// Handle executes the place order use case. Lines are priced from the
+// account's price list at the tier their weight reaches, then VAT is worked
+// out as for any other sale. An order within the account's approval
+// threshold is charged to the account and placed; a larger one holds the
+// credit it needs until an approver decides on it. Either way it is refused
+// if the account would go over its credit limit.
+func (h *PlaceOrderHandler) Handle(ctx context.Context, cmd PlaceOrderCommand) (*business.Order, error) {
+	m, err := h.memberRepo.FindByCustomer(ctx, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := h.accountRepo.FindByID(ctx, m.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]business.Line, 0, len(cmd.Lines))
+	for _, l := range cmd.Lines {
+		p, err := a.Price(l.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, business.Line{
+			ProductID:       l.ProductID,
+			Description:     p.Description,
+			Grams:           l.Grams,
+			PricePerKgCents: p.PerKgFor(l.Grams),
+		})
+	}
+	now := time.Now()
+	sold, total, err := price(ctx, h.pricer, lines, now)
+	if err != nil {
+		return nil, err
+	}
+	o, err := business.NewOrder(uuid.New(), uuid.New(), a, m, cmd.BranchID, lines, total, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Status() == business.OrderPendingApproval {
+		if err := a.Hold(total, now); err != nil {
+			return nil, err
+		}
+		if err := record(ctx, h.ledger, a, o, nil); err != nil {
+			return nil, err
+		}
+		return o, nil
+	}
+
+	if err := a.Charge(total, now); err != nil {
+		return nil, err
+	}
+	if err := record(ctx, h.ledger, a, o, business.NewCharge(uuid.New(), a, o, now.In(h.location), now)); err != nil {
+		return nil, err
+	}
+	if err := recordSale(ctx, h.orderRepo, o, sold, now); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
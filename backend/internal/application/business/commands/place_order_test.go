@@ -0,0 +1,289 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/business/commands"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockAccountRepository struct {
+	mock.Mock
+}
+
+func (m *mockAccountRepository) Create(ctx context.Context, a *business.Account) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAccountRepository) Update(ctx context.Context, a *business.Account) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*business.Account, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*business.Account), args.Error(1)
+}
+
+func (m *mockAccountRepository) FindAll(ctx context.Context) ([]*business.Account, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*business.Account), args.Error(1)
+}
+
+type mockMemberRepository struct {
+	mock.Mock
+}
+
+func (m *mockMemberRepository) Save(ctx context.Context, member business.Member) error {
+	return m.Called(ctx, member).Error(0)
+}
+
+func (m *mockMemberRepository) Remove(ctx context.Context, accountID, customerID uuid.UUID) error {
+	return m.Called(ctx, accountID, customerID).Error(0)
+}
+
+func (m *mockMemberRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) (business.Member, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(business.Member), args.Error(1)
+}
+
+func (m *mockMemberRepository) FindByAccount(ctx context.Context, accountID uuid.UUID) ([]business.Member, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]business.Member), args.Error(1)
+}
+
+type mockLedger struct {
+	mock.Mock
+}
+
+func (m *mockLedger) Record(ctx context.Context, a *business.Account, o *business.Order, e *business.Entry) error {
+	return m.Called(ctx, a, o, e).Error(0)
+}
+
+func (m *mockLedger) Entries(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*business.Entry, error) {
+	args := m.Called(ctx, accountID, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*business.Entry), args.Error(1)
+}
+
+func (m *mockLedger) FindOrder(ctx context.Context, id uuid.UUID) (*business.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*business.Order), args.Error(1)
+}
+
+func (m *mockLedger) FindOrders(ctx context.Context, filter business.OrderFilter) ([]*business.Order, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*business.Order), args.Error(1)
+}
+
+type mockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockOrderRepository) Create(ctx context.Context, o *order.Order) error {
+	return m.Called(ctx, o).Error(0)
+}
+
+func (m *mockOrderRepository) Update(ctx context.Context, o *order.Order) error {
+	return m.Called(ctx, o).Error(0)
+}
+
+func (m *mockOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *mockOrderRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+type mockRateRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateRepository) Save(ctx context.Context, r *tax.Rate) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(tax.Table), args.Error(1)
+}
+
+type mockProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockProductRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	return m.Called(ctx, productID, category).Error(0)
+}
+
+func (m *mockProductRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]tax.Category), args.Error(1)
+}
+
+// --- Fixtures ---
+
+var mince = uuid.New()
+
+// newPricer prices mince as fresh meat at a zero rate, so totals are the
+// shelf prices.
+func newPricer() *pricing.Pricer {
+	rates := new(mockRateRepository)
+	rates.On("FindAll", mock.Anything).Return(tax.Table{
+		tax.ReconstructRate(uuid.New(), tax.CategoryFreshMeat, 0, time.Time{}, time.Now()),
+	}, nil)
+	products := new(mockProductRepository)
+	products.On("FindCategories", mock.Anything, mock.Anything).Return(map[uuid.UUID]tax.Category{
+		mince: tax.CategoryFreshMeat,
+	}, nil)
+	return pricing.NewPricer(rates, products, tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}, time.UTC)
+}
+
+// newAccount returns an account that buys mince at 900 a kilogram, or 800
+// from 10kg.
+func newAccount(t *testing.T, creditLimitCents int64, threshold *int64) *business.Account {
+	t.Helper()
+	a, err := business.NewAccount(uuid.New(), business.Details{Name: "The Grill House", BillingEmail: "accounts@grill.example"},
+		business.Terms{CreditLimitCents: creditLimitCents, TermsDays: 30, ApprovalThresholdCents: threshold}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, a.SetPrices([]business.Price{{ProductID: mince, Description: "Beef mince", Tiers: []business.Tier{
+		{MinGrams: 0, PricePerKgCents: 900},
+		{MinGrams: 10000, PricePerKgCents: 800},
+	}}}, time.Now()))
+	return a
+}
+
+type placeFixture struct {
+	handler  *commands.PlaceOrderHandler
+	accounts *mockAccountRepository
+	members  *mockMemberRepository
+	ledger   *mockLedger
+	orders   *mockOrderRepository
+}
+
+func newPlaceFixture(a *business.Account, role business.Role) (*placeFixture, business.Member) {
+	f := &placeFixture{
+		accounts: new(mockAccountRepository),
+		members:  new(mockMemberRepository),
+		ledger:   new(mockLedger),
+		orders:   new(mockOrderRepository),
+	}
+	f.handler = commands.NewPlaceOrderHandler(f.accounts, f.members, f.ledger, f.orders, newPricer(), time.UTC)
+	m := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: role}
+	f.members.On("FindByCustomer", mock.Anything, m.CustomerID).Return(m, nil)
+	f.accounts.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return f, m
+}
+
+// --- Tests ---
+
+func TestPlaceOrder_WithinThreshold_ChargesAccount(t *testing.T) {
+	threshold := int64(20000)
+	a := newAccount(t, 100000, &threshold)
+	f, m := newPlaceFixture(a, business.RoleBuyer)
+	f.ledger.On("Record", mock.Anything, a, mock.Anything, mock.MatchedBy(func(e *business.Entry) bool {
+		return e.Type == business.EntryCharge && e.AmountCents == 9600 && e.DueOn != nil
+	})).Return(nil)
+	f.orders.On("Create", mock.Anything, mock.MatchedBy(func(o *order.Order) bool {
+		return o.CustomerID() == m.CustomerID && o.TotalCents() == 9600
+	})).Return(nil)
+
+	o, err := f.handler.Handle(context.Background(), commands.PlaceOrderCommand{
+		CustomerID: m.CustomerID,
+		BranchID:   uuid.New(),
+		Lines:      []commands.LineInput{{ProductID: mince, Grams: 12000}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, business.OrderPlaced, o.Status())
+	assert.Equal(t, int64(800), o.Lines()[0].PricePerKgCents)
+	assert.Equal(t, "Beef mince", o.Lines()[0].Description)
+	assert.Equal(t, int64(9600), a.BalanceCents())
+	f.ledger.AssertExpectations(t)
+	f.orders.AssertExpectations(t)
+}
+
+func TestPlaceOrder_OverThreshold_HoldsCredit(t *testing.T) {
+	threshold := int64(5000)
+	a := newAccount(t, 100000, &threshold)
+	f, m := newPlaceFixture(a, business.RoleBuyer)
+	f.ledger.On("Record", mock.Anything, a, mock.Anything, (*business.Entry)(nil)).Return(nil)
+
+	o, err := f.handler.Handle(context.Background(), commands.PlaceOrderCommand{
+		CustomerID: m.CustomerID,
+		BranchID:   uuid.New(),
+		Lines:      []commands.LineInput{{ProductID: mince, Grams: 6000}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, business.OrderPendingApproval, o.Status())
+	assert.Equal(t, int64(5400), a.HeldCents())
+	assert.Equal(t, int64(0), a.BalanceCents())
+	f.orders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_OverCreditLimit_Refused(t *testing.T) {
+	a := newAccount(t, 5000, nil)
+	f, m := newPlaceFixture(a, business.RoleApprover)
+
+	_, err := f.handler.Handle(context.Background(), commands.PlaceOrderCommand{
+		CustomerID: m.CustomerID,
+		BranchID:   uuid.New(),
+		Lines:      []commands.LineInput{{ProductID: mince, Grams: 6000}},
+	})
+
+	assert.ErrorIs(t, err, business.ErrCreditLimitExceeded)
+	f.ledger.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_NotOnPriceList_Refused(t *testing.T) {
+	a := newAccount(t, 100000, nil)
+	f, m := newPlaceFixture(a, business.RoleBuyer)
+
+	_, err := f.handler.Handle(context.Background(), commands.PlaceOrderCommand{
+		CustomerID: m.CustomerID,
+		BranchID:   uuid.New(),
+		Lines:      []commands.LineInput{{ProductID: uuid.New(), Grams: 1000}},
+	})
+
+	assert.ErrorIs(t, err, business.ErrNotOnPriceList)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// RecordPaymentCommand is the input for the record payment use case.
+type RecordPaymentCommand struct {
+	AccountID   uuid.UUID
+	AmountCents int64
+	Reference   string
+}
+
+// RecordPaymentHandler records a payment received from a business, such as
+// a bank transfer settling its statement.
+type RecordPaymentHandler struct {
+	accountRepo business.AccountRepository
+	ledger      business.Ledger
+}
+
+// NewRecordPaymentHandler creates a new RecordPaymentHandler.
+func NewRecordPaymentHandler(accountRepo business.AccountRepository, ledger business.Ledger) *RecordPaymentHandler {
+	return &RecordPaymentHandler{accountRepo: accountRepo, ledger: ledger}
+}
+
+// Handle executes the record payment use case and returns the entry posted.
+func (h *RecordPaymentHandler) Handle(ctx context.Context, cmd RecordPaymentCommand) (*business.Entry, error) {
+	a, err := h.accountRepo.FindByID(ctx, cmd.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := a.Pay(cmd.AmountCents, now); err != nil {
+		return nil, err
+	}
+	e := business.NewPayment(uuid.New(), a, cmd.AmountCents, cmd.Reference, now)
+	if err := record(ctx, h.ledger, a, nil, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
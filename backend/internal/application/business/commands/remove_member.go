@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// RemoveMemberHandler stops a customer buying for a business. Orders they
+// placed stay on the account.
+type RemoveMemberHandler struct {
+	memberRepo business.MemberRepository
+}
+
+// NewRemoveMemberHandler creates a new RemoveMemberHandler.
+func NewRemoveMemberHandler(memberRepo business.MemberRepository) *RemoveMemberHandler {
+	return &RemoveMemberHandler{memberRepo: memberRepo}
+}
+
+// Handle executes the remove member use case.
+func (h *RemoveMemberHandler) Handle(ctx context.Context, accountID, customerID uuid.UUID) error {
+	return h.memberRepo.Remove(ctx, accountID, customerID)
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// price taxes business order lines at the rates in force at the given time
+// and returns them as order lines with what they come to in all.
+func price(ctx context.Context, pricer *pricing.Pricer, lines []business.Line, at time.Time) ([]order.Line, int64, error) {
+	toPrice := make([]pricing.Line, 0, len(lines))
+	for _, l := range lines {
+		toPrice = append(toPrice, pricing.Line{ProductID: l.ProductID, AmountCents: order.PriceOf(l.Grams, l.PricePerKgCents)})
+	}
+	priced, err := pricer.Price(ctx, toPrice, at)
+	if err != nil {
+		return nil, 0, err
+	}
+	sold := make([]order.Line, 0, len(lines))
+	for i, p := range priced.Lines {
+		sold = append(sold, order.Line{
+			ProductID:       lines[i].ProductID,
+			Description:     lines[i].Description,
+			TaxCategory:     string(p.Category),
+			Grams:           lines[i].Grams,
+			PricePerKgCents: lines[i].PricePerKgCents,
+			VATRateBP:       p.RateBP,
+			NetCents:        p.NetCents,
+			VATCents:        p.VATCents,
+			GrossCents:      p.GrossCents,
+		})
+	}
+	return sold, priced.GrossCents, nil
+}
+
+// record stores a change to an account's balance with what caused it.
+func record(ctx context.Context, ledger business.Ledger, a *business.Account, o *business.Order, e *business.Entry) error {
+	if err := ledger.Record(ctx, a, o, e); err != nil {
+		if errors.Is(err, business.ErrConcurrentUpdate) {
+			return err
+		}
+		return fmt.Errorf("recording business account entry: %w", err)
+	}
+	return nil
+}
+
+// recordSale records a placed business order as a sale under its order ID,
+// once it has been charged to the account.
+func recordSale(ctx context.Context, orderRepo order.Repository, o *business.Order, lines []order.Line, now time.Time) error {
+	sale, err := order.NewOrder(o.OrderID(), o.PlacedBy(), o.BranchID(), lines, nil, now)
+	if err != nil {
+		return err
+	}
+	if err := orderRepo.Create(ctx, sale); err != nil {
+		return fmt.Errorf("saving order: %w", err)
+	}
+	return nil
+}
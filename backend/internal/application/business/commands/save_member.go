@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// SaveMemberCommand is the input for the save member use case.
+type SaveMemberCommand struct {
+	AccountID  uuid.UUID
+	CustomerID uuid.UUID
+	Role       business.Role
+}
+
+// SaveMemberHandler lets a customer buy for a business, or changes what
+// they may do on its account.
+type SaveMemberHandler struct {
+	accountRepo  business.AccountRepository
+	memberRepo   business.MemberRepository
+	customerRepo customer.Repository
+}
+
+// NewSaveMemberHandler creates a new SaveMemberHandler.
+func NewSaveMemberHandler(accountRepo business.AccountRepository, memberRepo business.MemberRepository, customerRepo customer.Repository) *SaveMemberHandler {
+	return &SaveMemberHandler{accountRepo: accountRepo, memberRepo: memberRepo, customerRepo: customerRepo}
+}
+
+// Handle executes the save member use case. A member keeps the date they
+// were first added when their role changes.
+func (h *SaveMemberHandler) Handle(ctx context.Context, cmd SaveMemberCommand) (business.Member, error) {
+	if _, err := h.accountRepo.FindByID(ctx, cmd.AccountID); err != nil {
+		return business.Member{}, err
+	}
+	if _, err := h.customerRepo.FindByID(ctx, cmd.CustomerID); err != nil {
+		return business.Member{}, err
+	}
+
+	m := business.Member{AccountID: cmd.AccountID, CustomerID: cmd.CustomerID, Role: cmd.Role, CreatedAt: time.Now()}
+	existing, err := h.memberRepo.FindByCustomer(ctx, cmd.CustomerID)
+	switch {
+	case err == nil && existing.AccountID != cmd.AccountID:
+		return business.Member{}, business.ErrMemberOfAnotherAccount
+	case err == nil:
+		m.CreatedAt = existing.CreatedAt
+	case !errors.Is(err, business.ErrMemberNotFound):
+		return business.Member{}, fmt.Errorf("loading business member: %w", err)
+	}
+	if err := h.memberRepo.Save(ctx, m); err != nil {
+		if errors.Is(err, business.ErrMemberOfAnotherAccount) {
+			return business.Member{}, err
+		}
+		return business.Member{}, fmt.Errorf("saving business member: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// SetPricesCommand is the input for the set prices use case.
+type SetPricesCommand struct {
+	AccountID uuid.UUID
+	Prices    []business.Price
+}
+
+// SetPricesHandler replaces the price list a business orders from.
+type SetPricesHandler struct {
+	accountRepo business.AccountRepository
+}
+
+// NewSetPricesHandler creates a new SetPricesHandler.
+func NewSetPricesHandler(accountRepo business.AccountRepository) *SetPricesHandler {
+	return &SetPricesHandler{accountRepo: accountRepo}
+}
+
+// Handle executes the set prices use case.
+func (h *SetPricesHandler) Handle(ctx context.Context, cmd SetPricesCommand) (*business.Account, error) {
+	a, err := h.accountRepo.FindByID(ctx, cmd.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.SetPrices(cmd.Prices, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateAccount(ctx, h.accountRepo, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
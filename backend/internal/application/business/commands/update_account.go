@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// UpdateAccountCommand is the input for the update account use case.
+type UpdateAccountCommand struct {
+	AccountID uuid.UUID
+	Details   business.Details
+	Terms     business.Terms
+	Active    bool
+}
+
+// UpdateAccountHandler changes a business's details and credit terms, or
+// puts its account on hold.
+type UpdateAccountHandler struct {
+	accountRepo business.AccountRepository
+}
+
+// NewUpdateAccountHandler creates a new UpdateAccountHandler.
+func NewUpdateAccountHandler(accountRepo business.AccountRepository) *UpdateAccountHandler {
+	return &UpdateAccountHandler{accountRepo: accountRepo}
+}
+
+// Handle executes the update account use case.
+func (h *UpdateAccountHandler) Handle(ctx context.Context, cmd UpdateAccountCommand) (*business.Account, error) {
+	a, err := h.accountRepo.FindByID(ctx, cmd.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Update(cmd.Details, cmd.Terms, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateAccount(ctx, h.accountRepo, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func updateAccount(ctx context.Context, repo business.AccountRepository, a *business.Account) error {
+	if err := repo.Update(ctx, a); err != nil {
+		if errors.Is(err, business.ErrConcurrentUpdate) {
+			return err
+		}
+		return fmt.Errorf("updating business account: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// GetAccountHandler returns a business account with its price list and
+// balance.
+type GetAccountHandler struct {
+	accountRepo business.AccountRepository
+}
+
+// NewGetAccountHandler creates a new GetAccountHandler.
+func NewGetAccountHandler(accountRepo business.AccountRepository) *GetAccountHandler {
+	return &GetAccountHandler{accountRepo: accountRepo}
+}
+
+// Handle returns the account with the given ID.
+func (h *GetAccountHandler) Handle(ctx context.Context, id uuid.UUID) (*business.Account, error) {
+	return h.accountRepo.FindByID(ctx, id)
+}
@@ -0,0 +1,41 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// Membership is the business a customer buys for and what they may do on
+// its account.
+type Membership struct {
+	Member  business.Member
+	Account *business.Account
+}
+
+// GetMembershipHandler finds the business a customer buys for.
+type GetMembershipHandler struct {
+	accountRepo business.AccountRepository
+	memberRepo  business.MemberRepository
+}
+
+// NewGetMembershipHandler creates a new GetMembershipHandler.
+func NewGetMembershipHandler(accountRepo business.AccountRepository, memberRepo business.MemberRepository) *GetMembershipHandler {
+	return &GetMembershipHandler{accountRepo: accountRepo, memberRepo: memberRepo}
+}
+
+// Handle returns the customer's membership, or ErrMemberNotFound if they do
+// not buy for a business.
+func (h *GetMembershipHandler) Handle(ctx context.Context, customerID uuid.UUID) (*Membership, error) {
+	m, err := h.memberRepo.FindByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := h.accountRepo.FindByID(ctx, m.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("loading business account: %w", err)
+	}
+	return &Membership{Member: m, Account: a}, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// ListAccountsHandler lists business accounts.
+type ListAccountsHandler struct {
+	accountRepo business.AccountRepository
+}
+
+// NewListAccountsHandler creates a new ListAccountsHandler.
+func NewListAccountsHandler(accountRepo business.AccountRepository) *ListAccountsHandler {
+	return &ListAccountsHandler{accountRepo: accountRepo}
+}
+
+// Handle returns every business account by name.
+func (h *ListAccountsHandler) Handle(ctx context.Context) ([]*business.Account, error) {
+	accounts, err := h.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying business accounts: %w", err)
+	}
+	return accounts, nil
+}
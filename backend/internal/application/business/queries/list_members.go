@@ -0,0 +1,32 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// ListMembersHandler lists the customers who buy for a business.
+type ListMembersHandler struct {
+	accountRepo business.AccountRepository
+	memberRepo  business.MemberRepository
+}
+
+// NewListMembersHandler creates a new ListMembersHandler.
+func NewListMembersHandler(accountRepo business.AccountRepository, memberRepo business.MemberRepository) *ListMembersHandler {
+	return &ListMembersHandler{accountRepo: accountRepo, memberRepo: memberRepo}
+}
+
+// Handle returns an account's members, earliest added first.
+func (h *ListMembersHandler) Handle(ctx context.Context, accountID uuid.UUID) ([]business.Member, error) {
+	if _, err := h.accountRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	members, err := h.memberRepo.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("querying business members: %w", err)
+	}
+	return members, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// ListOrdersHandler lists orders placed on business accounts.
+type ListOrdersHandler struct {
+	ledger business.Ledger
+}
+
+// NewListOrdersHandler creates a new ListOrdersHandler.
+func NewListOrdersHandler(ledger business.Ledger) *ListOrdersHandler {
+	return &ListOrdersHandler{ledger: ledger}
+}
+
+// Handle returns orders matching filter, newest first.
+func (h *ListOrdersHandler) Handle(ctx context.Context, filter business.OrderFilter) ([]*business.Order, error) {
+	orders, err := h.ledger.FindOrders(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying business orders: %w", err)
+	}
+	return orders, nil
+}
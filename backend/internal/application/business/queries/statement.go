@@ -0,0 +1,38 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// StatementHandler draws up a business's monthly statement.
+type StatementHandler struct {
+	accountRepo business.AccountRepository
+	ledger      business.Ledger
+	location    *time.Location
+}
+
+// NewStatementHandler creates a new StatementHandler. Months start at
+// midnight in location.
+func NewStatementHandler(accountRepo business.AccountRepository, ledger business.Ledger, location *time.Location) *StatementHandler {
+	return &StatementHandler{accountRepo: accountRepo, ledger: ledger, location: location}
+}
+
+// Handle returns the statement for the calendar month containing month.
+func (h *StatementHandler) Handle(ctx context.Context, accountID uuid.UUID, month time.Time) (business.Statement, error) {
+	a, err := h.accountRepo.FindByID(ctx, accountID)
+	if err != nil {
+		return business.Statement{}, err
+	}
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, h.location)
+	to := from.AddDate(0, 1, 0)
+	entries, err := h.ledger.Entries(ctx, accountID, to)
+	if err != nil {
+		return business.Statement{}, fmt.Errorf("querying business account entries: %w", err)
+	}
+	return business.NewStatement(a, from, to, entries), nil
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// breakdownReason is recorded on the received movement of every cut's lot.
+const breakdownReason = "carcass breakdown"
+
+// CutInput is one cut weighed off a carcass.
+type CutInput struct {
+	Primal    string
+	ProductID uuid.UUID
+	Grams     int64
+	ExpiresOn string // YYYY-MM-DD, optional
+}
+
+// RecordBreakdownCommand is the input for the record breakdown use case.
+type RecordBreakdownCommand struct {
+	CarcassID  uuid.UUID
+	Cuts       []CutInput
+	TrimGrams  int64
+	WasteGrams int64
+	ActorID    uuid.UUID
+}
+
+// RecordBreakdownHandler records how a carcass was cut up and receives each
+// cut into inventory as a lot coded with the carcass tag.
+type RecordBreakdownHandler struct {
+	carcassRepo carcass.CarcassRepository
+}
+
+// NewRecordBreakdownHandler creates a new RecordBreakdownHandler.
+func NewRecordBreakdownHandler(carcassRepo carcass.CarcassRepository) *RecordBreakdownHandler {
+	return &RecordBreakdownHandler{carcassRepo: carcassRepo}
+}
+
+// Handle executes the record breakdown use case.
+func (h *RecordBreakdownHandler) Handle(ctx context.Context, cmd RecordBreakdownCommand) (*carcass.Breakdown, error) {
+	c, err := h.carcassRepo.FindByID(ctx, cmd.CarcassID)
+	if err != nil {
+		return nil, err
+	}
+
+	cuts := make([]carcass.Cut, 0, len(cmd.Cuts))
+	for _, in := range cmd.Cuts {
+		cut := carcass.Cut{Primal: in.Primal, ProductID: in.ProductID, Grams: in.Grams}
+		if in.ExpiresOn != "" {
+			d, err := parseDate(in.ExpiresOn)
+			if err != nil {
+				return nil, err
+			}
+			cut.ExpiresOn = &d
+		}
+		cuts = append(cuts, cut)
+	}
+
+	now := time.Now()
+	b, err := c.BreakDown(cuts, cmd.TrimGrams, cmd.WasteGrams, &cmd.ActorID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cuts are costed at the carcass cost per saleable kilogram, so that
+	// waste can be valued.
+	costPerKg := carcass.NewYieldReport(c, b, nil).CostPerSaleableKgCents
+
+	lots := make([]*inventory.Lot, 0, len(b.Cuts()))
+	received := make([]*inventory.Movement, 0, len(b.Cuts()))
+	origins := make([]*traceability.Origin, 0, len(b.Cuts()))
+	carcassID := c.ID()
+	for _, cut := range b.Cuts() {
+		lot, m, err := inventory.ReceiveLotFrom(cut.LotID, cut.ProductID, c.BranchID(), c.Tag(), cut.Grams,
+			cut.ExpiresOn, breakdownReason, c.ID(), &cmd.ActorID, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := lot.SetCost(costPerKg); err != nil {
+			return nil, err
+		}
+		o, err := traceability.NewOrigin(cut.LotID, &carcassID, c.Supplier(), c.SlaughteredOn(), c.HalalCertificateRef(), now)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+		received = append(received, m)
+		origins = append(origins, o)
+	}
+
+	if err := h.carcassRepo.SaveBreakdown(ctx, c, b, lots, received, origins); err != nil {
+		if errors.Is(err, carcass.ErrAlreadyBrokenDown) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving breakdown: %w", err)
+	}
+	return b, nil
+}
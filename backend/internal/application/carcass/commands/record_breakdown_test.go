@@ -0,0 +1,102 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/carcass/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordBreakdown_ReceivesEachCutAsLot(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	c := newTestCarcass(t)
+	repo.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+	repo.On("SaveBreakdown", mock.Anything, c, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	legID, shoulderID, actorID := uuid.New(), uuid.New(), uuid.New()
+	handler := commands.NewRecordBreakdownHandler(repo)
+	b, err := handler.Handle(context.Background(), commands.RecordBreakdownCommand{
+		CarcassID: c.ID(),
+		Cuts: []commands.CutInput{
+			{Primal: "leg", ProductID: legID, Grams: 6000, ExpiresOn: "2026-10-30"},
+			{Primal: "shoulder", ProductID: shoulderID, Grams: 5000},
+		},
+		TrimGrams:  2500,
+		WasteGrams: 1200,
+		ActorID:    actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, carcass.StatusBrokenDown, c.Status())
+	assert.Equal(t, int64(11000), b.SaleableGrams())
+
+	lots := repo.Calls[1].Arguments.Get(3).([]*inventory.Lot)
+	received := repo.Calls[1].Arguments.Get(4).([]*inventory.Movement)
+	require.Len(t, lots, 2)
+	require.Len(t, received, 2)
+	assert.Equal(t, b.Cuts()[0].LotID, lots[0].ID())
+	assert.Equal(t, legID, lots[0].ProductID())
+	assert.Equal(t, c.BranchID(), lots[0].BranchID())
+	assert.Equal(t, "KILL-1042", lots[0].Code())
+	require.NotNil(t, lots[0].ExpiresOn())
+	assert.Equal(t, int64(5000), lots[1].OnHandGrams())
+	require.NotNil(t, received[0].ReferenceID())
+	assert.Equal(t, c.ID(), *received[0].ReferenceID())
+	assert.Equal(t, &actorID, received[0].ActorID())
+
+	origins := repo.Calls[1].Arguments.Get(5).([]*traceability.Origin)
+	require.Len(t, origins, 2)
+	assert.Equal(t, lots[1].ID(), origins[1].LotID())
+	assert.Equal(t, c.ID(), *origins[1].CarcassID())
+	assert.Equal(t, c.Supplier(), origins[1].Farm())
+	assert.Equal(t, "HMC-2026-0193", origins[1].CertificateNumber())
+}
+
+func TestRecordBreakdown_ExceedsCarcass_ReturnsError(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	c := newTestCarcass(t)
+	repo.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+
+	handler := commands.NewRecordBreakdownHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordBreakdownCommand{
+		CarcassID: c.ID(),
+		Cuts:      []commands.CutInput{{Primal: "leg", ProductID: uuid.New(), Grams: 19000}},
+		TrimGrams: 2000,
+	})
+
+	assert.ErrorIs(t, err, carcass.ErrBreakdownExceedsCarcass)
+	repo.AssertNotCalled(t, "SaveBreakdown", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecordBreakdown_CarcassNotFound_ReturnsError(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	id := uuid.New()
+	repo.On("FindByID", mock.Anything, id).Return(nil, carcass.ErrCarcassNotFound)
+
+	handler := commands.NewRecordBreakdownHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordBreakdownCommand{CarcassID: id})
+
+	assert.ErrorIs(t, err, carcass.ErrCarcassNotFound)
+}
+
+func TestRecordBreakdown_ConcurrentBreakdown_ReturnsError(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	c := newTestCarcass(t)
+	repo.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+	repo.On("SaveBreakdown", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(carcass.ErrAlreadyBrokenDown)
+
+	handler := commands.NewRecordBreakdownHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordBreakdownCommand{
+		CarcassID: c.ID(),
+		Cuts:      []commands.CutInput{{Primal: "leg", ProductID: uuid.New(), Grams: 6000}},
+	})
+
+	assert.ErrorIs(t, err, carcass.ErrAlreadyBrokenDown)
+}
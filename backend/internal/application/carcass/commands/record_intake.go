@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+)
+
+// RecordIntakeCommand is the input for the record carcass intake use case.
+type RecordIntakeCommand struct {
+	BranchID            uuid.UUID
+	Tag                 string
+	Species             string
+	HangingWeightGrams  int64
+	CostCents           int64
+	Supplier            string
+	SlaughteredOn       string // YYYY-MM-DD
+	HalalCertificateRef string
+}
+
+// RecordIntakeHandler records a carcass arriving at a branch.
+type RecordIntakeHandler struct {
+	carcassRepo carcass.CarcassRepository
+}
+
+// NewRecordIntakeHandler creates a new RecordIntakeHandler.
+func NewRecordIntakeHandler(carcassRepo carcass.CarcassRepository) *RecordIntakeHandler {
+	return &RecordIntakeHandler{carcassRepo: carcassRepo}
+}
+
+// Handle executes the record carcass intake use case.
+func (h *RecordIntakeHandler) Handle(ctx context.Context, cmd RecordIntakeCommand) (*carcass.Carcass, error) {
+	species, err := carcass.NewSpecies(cmd.Species)
+	if err != nil {
+		return nil, err
+	}
+	slaughteredOn, err := parseDate(cmd.SlaughteredOn)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := carcass.NewCarcass(uuid.New(), cmd.BranchID, cmd.Tag, species, cmd.HangingWeightGrams,
+		cmd.CostCents, cmd.Supplier, slaughteredOn, cmd.HalalCertificateRef, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.carcassRepo.Save(ctx, c); err != nil {
+		if errors.Is(err, carcass.ErrDuplicateTag) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving carcass: %w", err)
+	}
+	return c, nil
+}
+
+func parseDate(raw string) (time.Time, error) {
+	d, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, carcass.ErrInvalidDate
+	}
+	return d, nil
+}
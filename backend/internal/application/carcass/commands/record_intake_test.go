@@ -0,0 +1,152 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/carcass/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockCarcassRepository struct {
+	mock.Mock
+}
+
+func (m *mockCarcassRepository) Save(ctx context.Context, c *carcass.Carcass) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCarcassRepository) FindByID(ctx context.Context, id uuid.UUID) (*carcass.Carcass, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*carcass.Carcass), args.Error(1)
+}
+
+func (m *mockCarcassRepository) FindAll(ctx context.Context, filter carcass.Filter) ([]*carcass.Carcass, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*carcass.Carcass), args.Error(1)
+}
+
+func (m *mockCarcassRepository) SaveBreakdown(ctx context.Context, c *carcass.Carcass, b *carcass.Breakdown, lots []*inventory.Lot, received []*inventory.Movement, origins []*traceability.Origin) error {
+	args := m.Called(ctx, c, b, lots, received, origins)
+	return args.Error(0)
+}
+
+func (m *mockCarcassRepository) FindBreakdown(ctx context.Context, carcassID uuid.UUID) (*carcass.Breakdown, error) {
+	args := m.Called(ctx, carcassID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*carcass.Breakdown), args.Error(1)
+}
+
+type mockYieldTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *mockYieldTemplateRepository) SaveYieldTemplate(ctx context.Context, t *carcass.YieldTemplate) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockYieldTemplateRepository) FindYieldTemplate(ctx context.Context, species carcass.Species) (*carcass.YieldTemplate, error) {
+	args := m.Called(ctx, species)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*carcass.YieldTemplate), args.Error(1)
+}
+
+func (m *mockYieldTemplateRepository) FindYieldTemplates(ctx context.Context) ([]*carcass.YieldTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*carcass.YieldTemplate), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func validIntake() commands.RecordIntakeCommand {
+	return commands.RecordIntakeCommand{
+		BranchID:            uuid.New(),
+		Tag:                 "KILL-1042",
+		Species:             "lamb",
+		HangingWeightGrams:  21500,
+		CostCents:           16000,
+		Supplier:            "Green Valley Farm",
+		SlaughteredOn:       time.Now().AddDate(0, 0, -3).Format(time.DateOnly),
+		HalalCertificateRef: "HMC-2026-0193",
+	}
+}
+
+func newTestCarcass(t *testing.T) *carcass.Carcass {
+	t.Helper()
+	c, err := carcass.NewCarcass(uuid.New(), uuid.New(), "KILL-1042", carcass.SpeciesLamb, 20000, 16000,
+		"Green Valley Farm", time.Now().AddDate(0, 0, -3), "HMC-2026-0193", time.Now())
+	require.NoError(t, err)
+	return c
+}
+
+// --- RecordIntake Tests ---
+
+func TestRecordIntake_ValidInput_SavesCarcass(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*carcass.Carcass")).Return(nil)
+
+	handler := commands.NewRecordIntakeHandler(repo)
+	c, err := handler.Handle(context.Background(), validIntake())
+
+	require.NoError(t, err)
+	assert.Equal(t, carcass.SpeciesLamb, c.Species())
+	assert.Equal(t, carcass.StatusReceived, c.Status())
+	repo.AssertExpectations(t)
+}
+
+func TestRecordIntake_InvalidSpecies_ReturnsError(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	cmd := validIntake()
+	cmd.Species = "pork"
+
+	handler := commands.NewRecordIntakeHandler(repo)
+	_, err := handler.Handle(context.Background(), cmd)
+
+	assert.ErrorIs(t, err, carcass.ErrInvalidSpecies)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRecordIntake_InvalidSlaughterDate_ReturnsError(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	cmd := validIntake()
+	cmd.SlaughteredOn = "12/10/2026"
+
+	handler := commands.NewRecordIntakeHandler(repo)
+	_, err := handler.Handle(context.Background(), cmd)
+
+	assert.ErrorIs(t, err, carcass.ErrInvalidDate)
+}
+
+func TestRecordIntake_DuplicateTag_ReturnsError(t *testing.T) {
+	repo := new(mockCarcassRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(carcass.ErrDuplicateTag)
+
+	handler := commands.NewRecordIntakeHandler(repo)
+	_, err := handler.Handle(context.Background(), validIntake())
+
+	assert.ErrorIs(t, err, carcass.ErrDuplicateTag)
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+)
+
+// SetYieldTemplateCommand is the input for the set yield template use case.
+// Percentages are in basis points (10000 = 100%).
+type SetYieldTemplateCommand struct {
+	Species         string
+	ExpectedYieldBP int64
+	ExpectedTrimBP  int64
+	ExpectedWasteBP int64
+	Primals         []carcass.PrimalYield
+}
+
+// SetYieldTemplateHandler replaces a species' expected yields.
+type SetYieldTemplateHandler struct {
+	templateRepo carcass.YieldTemplateRepository
+}
+
+// NewSetYieldTemplateHandler creates a new SetYieldTemplateHandler.
+func NewSetYieldTemplateHandler(templateRepo carcass.YieldTemplateRepository) *SetYieldTemplateHandler {
+	return &SetYieldTemplateHandler{templateRepo: templateRepo}
+}
+
+// Handle executes the set yield template use case.
+func (h *SetYieldTemplateHandler) Handle(ctx context.Context, cmd SetYieldTemplateCommand) (*carcass.YieldTemplate, error) {
+	species, err := carcass.NewSpecies(cmd.Species)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := carcass.NewYieldTemplate(species, cmd.ExpectedYieldBP, cmd.ExpectedTrimBP, cmd.ExpectedWasteBP,
+		cmd.Primals, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.templateRepo.SaveYieldTemplate(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("saving yield template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,46 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/internal/application/carcass/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetYieldTemplate_ValidInput_Saves(t *testing.T) {
+	repo := new(mockYieldTemplateRepository)
+	repo.On("SaveYieldTemplate", mock.Anything, mock.AnythingOfType("*carcass.YieldTemplate")).Return(nil)
+
+	handler := commands.NewSetYieldTemplateHandler(repo)
+	tmpl, err := handler.Handle(context.Background(), commands.SetYieldTemplateCommand{
+		Species:         "beef",
+		ExpectedYieldBP: 6500,
+		ExpectedTrimBP:  2000,
+		ExpectedWasteBP: 800,
+		Primals:         []carcass.PrimalYield{{Name: "Rump", ExpectedBP: 1200, ValueFactor: 180}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, carcass.SpeciesBeef, tmpl.Species())
+	assert.Equal(t, "rump", tmpl.Primals()[0].Name)
+	repo.AssertExpectations(t)
+}
+
+func TestSetYieldTemplate_OverFullCarcass_ReturnsError(t *testing.T) {
+	repo := new(mockYieldTemplateRepository)
+
+	handler := commands.NewSetYieldTemplateHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.SetYieldTemplateCommand{
+		Species:         "beef",
+		ExpectedYieldBP: 7000,
+		ExpectedTrimBP:  2500,
+		ExpectedWasteBP: 1000,
+	})
+
+	assert.ErrorIs(t, err, carcass.ErrInvalidYieldTemplate)
+	repo.AssertNotCalled(t, "SaveYieldTemplate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+)
+
+// ListCarcassesHandler lists carcass intakes.
+type ListCarcassesHandler struct {
+	carcassRepo carcass.CarcassRepository
+}
+
+// NewListCarcassesHandler creates a new ListCarcassesHandler.
+func NewListCarcassesHandler(carcassRepo carcass.CarcassRepository) *ListCarcassesHandler {
+	return &ListCarcassesHandler{carcassRepo: carcassRepo}
+}
+
+// Handle returns carcasses matching filter, most recently received first.
+func (h *ListCarcassesHandler) Handle(ctx context.Context, filter carcass.Filter) ([]*carcass.Carcass, error) {
+	carcasses, err := h.carcassRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying carcasses: %w", err)
+	}
+	return carcasses, nil
+}
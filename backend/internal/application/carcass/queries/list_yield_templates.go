@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+)
+
+// ListYieldTemplatesHandler lists the expected yields of every species.
+type ListYieldTemplatesHandler struct {
+	templateRepo carcass.YieldTemplateRepository
+}
+
+// NewListYieldTemplatesHandler creates a new ListYieldTemplatesHandler.
+func NewListYieldTemplatesHandler(templateRepo carcass.YieldTemplateRepository) *ListYieldTemplatesHandler {
+	return &ListYieldTemplatesHandler{templateRepo: templateRepo}
+}
+
+// Handle executes the list yield templates query.
+func (h *ListYieldTemplatesHandler) Handle(ctx context.Context) ([]*carcass.YieldTemplate, error) {
+	templates, err := h.templateRepo.FindYieldTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying yield templates: %w", err)
+	}
+	return templates, nil
+}
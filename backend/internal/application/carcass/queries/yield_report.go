@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+)
+
+// YieldReportHandler reports the yield and cut costs of a broken-down carcass.
+type YieldReportHandler struct {
+	carcassRepo  carcass.CarcassRepository
+	templateRepo carcass.YieldTemplateRepository
+}
+
+// NewYieldReportHandler creates a new YieldReportHandler.
+func NewYieldReportHandler(carcassRepo carcass.CarcassRepository, templateRepo carcass.YieldTemplateRepository) *YieldReportHandler {
+	return &YieldReportHandler{carcassRepo: carcassRepo, templateRepo: templateRepo}
+}
+
+// Handle executes the yield report query. The report is compared against the
+// species' yield template when there is one.
+func (h *YieldReportHandler) Handle(ctx context.Context, carcassID uuid.UUID) (*carcass.YieldReport, error) {
+	c, err := h.carcassRepo.FindByID(ctx, carcassID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := h.carcassRepo.FindBreakdown(ctx, carcassID)
+	if err != nil {
+		if errors.Is(err, carcass.ErrNotBrokenDown) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("querying breakdown: %w", err)
+	}
+
+	tmpl, err := h.templateRepo.FindYieldTemplate(ctx, c.Species())
+	if err != nil && !errors.Is(err, carcass.ErrYieldTemplateNotFound) {
+		return nil, fmt.Errorf("querying yield template: %w", err)
+	}
+
+	report := carcass.NewYieldReport(c, b, tmpl)
+	return &report, nil
+}
@@ -0,0 +1,137 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/carcass/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCarcassRepository struct {
+	mock.Mock
+}
+
+func (m *mockCarcassRepository) Save(ctx context.Context, c *carcass.Carcass) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCarcassRepository) FindByID(ctx context.Context, id uuid.UUID) (*carcass.Carcass, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*carcass.Carcass), args.Error(1)
+}
+
+func (m *mockCarcassRepository) FindAll(ctx context.Context, filter carcass.Filter) ([]*carcass.Carcass, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*carcass.Carcass), args.Error(1)
+}
+
+func (m *mockCarcassRepository) SaveBreakdown(ctx context.Context, c *carcass.Carcass, b *carcass.Breakdown, lots []*inventory.Lot, received []*inventory.Movement, origins []*traceability.Origin) error {
+	args := m.Called(ctx, c, b, lots, received, origins)
+	return args.Error(0)
+}
+
+func (m *mockCarcassRepository) FindBreakdown(ctx context.Context, carcassID uuid.UUID) (*carcass.Breakdown, error) {
+	args := m.Called(ctx, carcassID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*carcass.Breakdown), args.Error(1)
+}
+
+type mockYieldTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *mockYieldTemplateRepository) SaveYieldTemplate(ctx context.Context, t *carcass.YieldTemplate) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockYieldTemplateRepository) FindYieldTemplate(ctx context.Context, species carcass.Species) (*carcass.YieldTemplate, error) {
+	args := m.Called(ctx, species)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*carcass.YieldTemplate), args.Error(1)
+}
+
+func (m *mockYieldTemplateRepository) FindYieldTemplates(ctx context.Context) ([]*carcass.YieldTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*carcass.YieldTemplate), args.Error(1)
+}
+
+func brokenDownCarcass(t *testing.T) (*carcass.Carcass, *carcass.Breakdown) {
+	t.Helper()
+	c, err := carcass.NewCarcass(uuid.New(), uuid.New(), "KILL-1042", carcass.SpeciesLamb, 20000, 16000,
+		"Green Valley Farm", time.Now().AddDate(0, 0, -3), "HMC-2026-0193", time.Now())
+	require.NoError(t, err)
+	b, err := c.BreakDown([]carcass.Cut{{Primal: "leg", ProductID: uuid.New(), Grams: 12000}}, 3000, 1000, nil, time.Now())
+	require.NoError(t, err)
+	return c, b
+}
+
+func TestYieldReport_WithTemplate_IncludesExpectations(t *testing.T) {
+	carcassRepo := new(mockCarcassRepository)
+	templateRepo := new(mockYieldTemplateRepository)
+	c, b := brokenDownCarcass(t)
+	tmpl, err := carcass.NewYieldTemplate(carcass.SpeciesLamb, 6500, 1500, 500, nil, time.Now())
+	require.NoError(t, err)
+	carcassRepo.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+	carcassRepo.On("FindBreakdown", mock.Anything, c.ID()).Return(b, nil)
+	templateRepo.On("FindYieldTemplate", mock.Anything, carcass.SpeciesLamb).Return(tmpl, nil)
+
+	handler := queries.NewYieldReportHandler(carcassRepo, templateRepo)
+	report, err := handler.Handle(context.Background(), c.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(6000), report.YieldBP)
+	require.NotNil(t, report.Expected)
+	assert.Equal(t, int64(6500), report.Expected.YieldBP)
+}
+
+func TestYieldReport_NoTemplate_StillReports(t *testing.T) {
+	carcassRepo := new(mockCarcassRepository)
+	templateRepo := new(mockYieldTemplateRepository)
+	c, b := brokenDownCarcass(t)
+	carcassRepo.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+	carcassRepo.On("FindBreakdown", mock.Anything, c.ID()).Return(b, nil)
+	templateRepo.On("FindYieldTemplate", mock.Anything, carcass.SpeciesLamb).Return(nil, carcass.ErrYieldTemplateNotFound)
+
+	handler := queries.NewYieldReportHandler(carcassRepo, templateRepo)
+	report, err := handler.Handle(context.Background(), c.ID())
+
+	require.NoError(t, err)
+	assert.Nil(t, report.Expected)
+	assert.Equal(t, int64(1333), report.CostPerSaleableKgCents)
+}
+
+func TestYieldReport_NotBrokenDown_ReturnsError(t *testing.T) {
+	carcassRepo := new(mockCarcassRepository)
+	templateRepo := new(mockYieldTemplateRepository)
+	c, _ := brokenDownCarcass(t)
+	carcassRepo.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+	carcassRepo.On("FindBreakdown", mock.Anything, c.ID()).Return(nil, carcass.ErrNotBrokenDown)
+
+	handler := queries.NewYieldReportHandler(carcassRepo, templateRepo)
+	_, err := handler.Handle(context.Background(), c.ID())
+
+	assert.ErrorIs(t, err, carcass.ErrNotBrokenDown)
+}
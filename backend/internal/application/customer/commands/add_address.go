@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// AddAddressCommand is the input for the add address use case.
+type AddAddressCommand struct {
+	CustomerID  uuid.UUID
+	Fields      AddressFields
+	MakeDefault bool
+}
+
+// AddAddressHandler adds an entry to a customer's address book.
+type AddAddressHandler struct {
+	addressRepo customer.AddressRepository
+}
+
+// NewAddAddressHandler creates a new AddAddressHandler.
+func NewAddAddressHandler(addressRepo customer.AddressRepository) *AddAddressHandler {
+	return &AddAddressHandler{addressRepo: addressRepo}
+}
+
+// Handle executes the add address use case. A customer's first address always
+// becomes their default.
+func (h *AddAddressHandler) Handle(ctx context.Context, cmd AddAddressCommand) (*customer.Address, error) {
+	postcode, coords, err := cmd.Fields.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	f := cmd.Fields
+	a, err := customer.NewAddress(uuid.New(), cmd.CustomerID, f.Label, f.Line1, f.Line2, f.City, postcode, coords)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := h.addressRepo.FindByCustomer(ctx, cmd.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("finding addresses: %w", err)
+	}
+	first := len(existing) == 0
+	if first {
+		a.MarkDefault()
+	}
+
+	if err := h.addressRepo.Save(ctx, a); err != nil {
+		return nil, fmt.Errorf("saving address: %w", err)
+	}
+
+	if cmd.MakeDefault && !first {
+		if err := h.addressRepo.SetDefault(ctx, cmd.CustomerID, a.ID()); err != nil {
+			return nil, fmt.Errorf("setting default address: %w", err)
+		}
+		a.MarkDefault()
+	}
+	return a, nil
+}
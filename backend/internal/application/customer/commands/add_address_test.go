@@ -0,0 +1,153 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAddressRepository struct {
+	mock.Mock
+}
+
+func (m *mockAddressRepository) Save(ctx context.Context, a *customer.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockAddressRepository) SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error {
+	args := m.Called(ctx, customerID, addressID)
+	return args.Error(0)
+}
+
+func newTestAddress(t *testing.T, customerID uuid.UUID) *customer.Address {
+	t.Helper()
+	postcode, err := customer.NewPostcode("E1 6AN")
+	require.NoError(t, err)
+	a, err := customer.NewAddress(uuid.New(), customerID, "Home", "1 High Street", "", "London", postcode, nil)
+	require.NoError(t, err)
+	return a
+}
+
+func validAddressFields() commands.AddressFields {
+	lat, lng := 51.52, -0.07
+	return commands.AddressFields{
+		Label:     "Home",
+		Line1:     "1 High Street",
+		City:      "London",
+		Postcode:  "e1 6an",
+		Latitude:  &lat,
+		Longitude: &lng,
+	}
+}
+
+func TestAddAddress_FirstAddress_BecomesDefault(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+
+	repo.On("FindByCustomer", mock.Anything, customerID).Return([]*customer.Address{}, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(a *customer.Address) bool {
+		return a.IsDefault()
+	})).Return(nil)
+
+	handler := commands.NewAddAddressHandler(repo)
+	a, err := handler.Handle(context.Background(), commands.AddAddressCommand{
+		CustomerID: customerID,
+		Fields:     validAddressFields(),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, a.IsDefault())
+	assert.Equal(t, "E1 6AN", a.Postcode().String())
+	require.NotNil(t, a.Coordinates())
+	repo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestAddAddress_MakeDefault_MovesDefault(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+	existing := newTestAddress(t, customerID)
+
+	repo.On("FindByCustomer", mock.Anything, customerID).Return([]*customer.Address{existing}, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(a *customer.Address) bool {
+		return !a.IsDefault()
+	})).Return(nil)
+	repo.On("SetDefault", mock.Anything, customerID, mock.AnythingOfType("uuid.UUID")).Return(nil)
+
+	handler := commands.NewAddAddressHandler(repo)
+	a, err := handler.Handle(context.Background(), commands.AddAddressCommand{
+		CustomerID:  customerID,
+		Fields:      validAddressFields(),
+		MakeDefault: true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, a.IsDefault())
+	repo.AssertExpectations(t)
+}
+
+func TestAddAddress_InvalidFields_ReturnError(t *testing.T) {
+	lat := 51.52
+	tests := []struct {
+		name    string
+		mutate  func(f *commands.AddressFields)
+		wantErr error
+	}{
+		{"missing postcode", func(f *commands.AddressFields) { f.Postcode = "" }, customer.ErrInvalidPostcode},
+		{"missing line 1", func(f *commands.AddressFields) { f.Line1 = "" }, customer.ErrEmptyAddressLine},
+		{"latitude without longitude", func(f *commands.AddressFields) { f.Latitude, f.Longitude = &lat, nil }, customer.ErrInvalidCoordinates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := validAddressFields()
+			tt.mutate(&fields)
+
+			handler := commands.NewAddAddressHandler(new(mockAddressRepository))
+			_, err := handler.Handle(context.Background(), commands.AddAddressCommand{CustomerID: uuid.New(), Fields: fields})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAddAddress_SaveFails_ReturnsError(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+
+	repo.On("FindByCustomer", mock.Anything, customerID).Return(nil, nil)
+	repo.On("Save", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	handler := commands.NewAddAddressHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.AddAddressCommand{CustomerID: customerID, Fields: validAddressFields()})
+
+	assert.ErrorContains(t, err, "saving address")
+}
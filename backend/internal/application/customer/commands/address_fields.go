@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// AddressFields are the editable parts of an address book entry. Latitude and
+// Longitude must be given together or not at all.
+type AddressFields struct {
+	Label     string
+	Line1     string
+	Line2     string
+	City      string
+	Postcode  string
+	Latitude  *float64
+	Longitude *float64
+}
+
+func (f AddressFields) parse() (customer.Postcode, *customer.Coordinates, error) {
+	postcode, err := customer.NewPostcode(f.Postcode)
+	if err != nil {
+		return customer.Postcode{}, nil, err
+	}
+
+	if (f.Latitude == nil) != (f.Longitude == nil) {
+		return customer.Postcode{}, nil, customer.ErrInvalidCoordinates
+	}
+	if f.Latitude == nil {
+		return postcode, nil, nil
+	}
+	coords, err := customer.NewCoordinates(*f.Latitude, *f.Longitude)
+	if err != nil {
+		return customer.Postcode{}, nil, err
+	}
+	return postcode, &coords, nil
+}
+
+// loadOwnedAddress fetches an address and checks it belongs to customerID.
+// Another customer's address is reported as not found.
+func loadOwnedAddress(ctx context.Context, repo customer.AddressRepository, customerID, addressID uuid.UUID) (*customer.Address, error) {
+	a, err := repo.FindByID(ctx, addressID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.BelongsTo(customerID) {
+		return nil, customer.ErrAddressNotFound
+	}
+	return a, nil
+}
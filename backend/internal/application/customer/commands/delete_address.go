@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// DeleteAddressCommand is the input for the delete address use case.
+type DeleteAddressCommand struct {
+	CustomerID uuid.UUID
+	AddressID  uuid.UUID
+}
+
+// DeleteAddressHandler removes an entry from a customer's address book.
+type DeleteAddressHandler struct {
+	addressRepo customer.AddressRepository
+}
+
+// NewDeleteAddressHandler creates a new DeleteAddressHandler.
+func NewDeleteAddressHandler(addressRepo customer.AddressRepository) *DeleteAddressHandler {
+	return &DeleteAddressHandler{addressRepo: addressRepo}
+}
+
+// Handle executes the delete address use case. Deleting the default address
+// promotes the oldest remaining one.
+func (h *DeleteAddressHandler) Handle(ctx context.Context, cmd DeleteAddressCommand) error {
+	a, err := loadOwnedAddress(ctx, h.addressRepo, cmd.CustomerID, cmd.AddressID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.addressRepo.Delete(ctx, a.ID()); err != nil {
+		return fmt.Errorf("deleting address: %w", err)
+	}
+	if !a.IsDefault() {
+		return nil
+	}
+
+	remaining, err := h.addressRepo.FindByCustomer(ctx, cmd.CustomerID)
+	if err != nil {
+		return fmt.Errorf("finding addresses: %w", err)
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	if err := h.addressRepo.SetDefault(ctx, cmd.CustomerID, remaining[0].ID()); err != nil {
+		return fmt.Errorf("setting default address: %w", err)
+	}
+	return nil
+}
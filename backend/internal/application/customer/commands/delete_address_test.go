@@ -0,0 +1,59 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAddress_DefaultAddress_PromotesOldestRemaining(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+	deleted := newTestAddress(t, customerID)
+	deleted.MarkDefault()
+	remaining := newTestAddress(t, customerID)
+
+	repo.On("FindByID", mock.Anything, deleted.ID()).Return(deleted, nil)
+	repo.On("Delete", mock.Anything, deleted.ID()).Return(nil)
+	repo.On("FindByCustomer", mock.Anything, customerID).Return([]*customer.Address{remaining}, nil)
+	repo.On("SetDefault", mock.Anything, customerID, remaining.ID()).Return(nil)
+
+	handler := commands.NewDeleteAddressHandler(repo)
+	err := handler.Handle(context.Background(), commands.DeleteAddressCommand{CustomerID: customerID, AddressID: deleted.ID()})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteAddress_NonDefaultAddress_LeavesDefault(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+	a := newTestAddress(t, customerID)
+
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	repo.On("Delete", mock.Anything, a.ID()).Return(nil)
+
+	handler := commands.NewDeleteAddressHandler(repo)
+	err := handler.Handle(context.Background(), commands.DeleteAddressCommand{CustomerID: customerID, AddressID: a.ID()})
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteAddress_Unknown_ReturnsNotFound(t *testing.T) {
+	repo := new(mockAddressRepository)
+	id := uuid.New()
+
+	repo.On("FindByID", mock.Anything, id).Return(nil, customer.ErrAddressNotFound)
+
+	handler := commands.NewDeleteAddressHandler(repo)
+	err := handler.Handle(context.Background(), commands.DeleteAddressCommand{CustomerID: uuid.New(), AddressID: id})
+
+	assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+}
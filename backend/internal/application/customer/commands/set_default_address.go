@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// SetDefaultAddressCommand is the input for the set default address use case.
+type SetDefaultAddressCommand struct {
+	CustomerID uuid.UUID
+	AddressID  uuid.UUID
+}
+
+// SetDefaultAddressHandler changes a customer's default address.
+type SetDefaultAddressHandler struct {
+	addressRepo customer.AddressRepository
+}
+
+// NewSetDefaultAddressHandler creates a new SetDefaultAddressHandler.
+func NewSetDefaultAddressHandler(addressRepo customer.AddressRepository) *SetDefaultAddressHandler {
+	return &SetDefaultAddressHandler{addressRepo: addressRepo}
+}
+
+// Handle executes the set default address use case.
+func (h *SetDefaultAddressHandler) Handle(ctx context.Context, cmd SetDefaultAddressCommand) error {
+	if _, err := loadOwnedAddress(ctx, h.addressRepo, cmd.CustomerID, cmd.AddressID); err != nil {
+		return err
+	}
+
+	if err := h.addressRepo.SetDefault(ctx, cmd.CustomerID, cmd.AddressID); err != nil {
+		return fmt.Errorf("setting default address: %w", err)
+	}
+	return nil
+}
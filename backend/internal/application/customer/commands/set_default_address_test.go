@@ -0,0 +1,41 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDefaultAddress_OwnAddress_SetsDefault(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+	a := newTestAddress(t, customerID)
+
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	repo.On("SetDefault", mock.Anything, customerID, a.ID()).Return(nil)
+
+	handler := commands.NewSetDefaultAddressHandler(repo)
+	err := handler.Handle(context.Background(), commands.SetDefaultAddressCommand{CustomerID: customerID, AddressID: a.ID()})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestSetDefaultAddress_OtherCustomersAddress_ReturnsNotFound(t *testing.T) {
+	repo := new(mockAddressRepository)
+	a := newTestAddress(t, uuid.New())
+
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+
+	handler := commands.NewSetDefaultAddressHandler(repo)
+	err := handler.Handle(context.Background(), commands.SetDefaultAddressCommand{CustomerID: uuid.New(), AddressID: a.ID()})
+
+	assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+	repo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
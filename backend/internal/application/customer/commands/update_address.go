@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// UpdateAddressCommand is the input for the update address use case.
+type UpdateAddressCommand struct {
+	CustomerID uuid.UUID
+	AddressID  uuid.UUID
+	Fields     AddressFields
+}
+
+// UpdateAddressHandler edits an entry in a customer's address book.
+type UpdateAddressHandler struct {
+	addressRepo customer.AddressRepository
+}
+
+// NewUpdateAddressHandler creates a new UpdateAddressHandler.
+func NewUpdateAddressHandler(addressRepo customer.AddressRepository) *UpdateAddressHandler {
+	return &UpdateAddressHandler{addressRepo: addressRepo}
+}
+
+// Handle executes the update address use case.
+func (h *UpdateAddressHandler) Handle(ctx context.Context, cmd UpdateAddressCommand) (*customer.Address, error) {
+	postcode, coords, err := cmd.Fields.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := loadOwnedAddress(ctx, h.addressRepo, cmd.CustomerID, cmd.AddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	f := cmd.Fields
+	if err := a.Update(f.Label, f.Line1, f.Line2, f.City, postcode, coords); err != nil {
+		return nil, err
+	}
+
+	if err := h.addressRepo.Save(ctx, a); err != nil {
+		return nil, fmt.Errorf("saving address: %w", err)
+	}
+	return a, nil
+}
@@ -0,0 +1,52 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAddress_OwnAddress_SavesChanges(t *testing.T) {
+	repo := new(mockAddressRepository)
+	customerID := uuid.New()
+	a := newTestAddress(t, customerID)
+
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	repo.On("Save", mock.Anything, a).Return(nil)
+
+	fields := validAddressFields()
+	fields.Line1 = "2 Low Road"
+	handler := commands.NewUpdateAddressHandler(repo)
+	updated, err := handler.Handle(context.Background(), commands.UpdateAddressCommand{
+		CustomerID: customerID,
+		AddressID:  a.ID(),
+		Fields:     fields,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2 Low Road", updated.Line1())
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateAddress_OtherCustomersAddress_ReturnsNotFound(t *testing.T) {
+	repo := new(mockAddressRepository)
+	a := newTestAddress(t, uuid.New())
+
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+
+	handler := commands.NewUpdateAddressHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.UpdateAddressCommand{
+		CustomerID: uuid.New(),
+		AddressID:  a.ID(),
+		Fields:     validAddressFields(),
+	})
+
+	assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
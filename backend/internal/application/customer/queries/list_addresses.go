@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// ListAddressesHandler returns a customer's address book.
+type ListAddressesHandler struct {
+	addressRepo customer.AddressRepository
+}
+
+// NewListAddressesHandler creates a new ListAddressesHandler.
+func NewListAddressesHandler(addressRepo customer.AddressRepository) *ListAddressesHandler {
+	return &ListAddressesHandler{addressRepo: addressRepo}
+}
+
+// Handle returns the customer's addresses with the default first.
+func (h *ListAddressesHandler) Handle(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	addresses, err := h.addressRepo.FindByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("finding addresses: %w", err)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// CreateZoneHandler creates delivery zones.
+type CreateZoneHandler struct {
+	zoneRepo delivery.ZoneRepository
+}
+
+// NewCreateZoneHandler creates a new CreateZoneHandler.
+func NewCreateZoneHandler(zoneRepo delivery.ZoneRepository) *CreateZoneHandler {
+	return &CreateZoneHandler{zoneRepo: zoneRepo}
+}
+
+// Handle executes the create zone use case.
+func (h *CreateZoneHandler) Handle(ctx context.Context, fields ZoneFields) (*delivery.Zone, error) {
+	area, err := fields.parseArea()
+	if err != nil {
+		return nil, err
+	}
+
+	z, err := delivery.NewZone(uuid.New(), fields.Name, area, fields.PostcodePrefixes,
+		fields.DeliveryFeeCents, fields.MinimumOrderCents)
+	if err != nil {
+		return nil, err
+	}
+	if err := fields.applyFeeTaxCategory(z); err != nil {
+		return nil, err
+	}
+
+	if err := h.zoneRepo.Save(ctx, z); err != nil {
+		return nil, fmt.Errorf("saving delivery zone: %w", err)
+	}
+	return z, nil
+}
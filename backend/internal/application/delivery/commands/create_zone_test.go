@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/delivery/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockZoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	args := m.Called(ctx, z)
+	return args.Error(0)
+}
+
+func (m *mockZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*delivery.Zone), args.Error(1)
+}
+
+func (m *mockZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*delivery.Zone), args.Error(1)
+}
+
+const testPolygon = `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`
+
+func TestCreateZone_Polygon_SavesZone(t *testing.T) {
+	repo := new(mockZoneRepository)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*delivery.Zone")).Return(nil)
+
+	handler := commands.NewCreateZoneHandler(repo)
+	z, err := handler.Handle(context.Background(), commands.ZoneFields{
+		Name:              "Around the shop",
+		Area:              json.RawMessage(testPolygon),
+		DeliveryFeeCents:  0,
+		MinimumOrderCents: 1500,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, z.Area())
+	assert.True(t, z.Area().Contains(delivery.Point{Longitude: 0.5, Latitude: 0.5}))
+	repo.AssertExpectations(t)
+}
+
+func TestCreateZone_PostcodesOnly_SavesZone(t *testing.T) {
+	repo := new(mockZoneRepository)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*delivery.Zone")).Return(nil)
+
+	handler := commands.NewCreateZoneHandler(repo)
+	z, err := handler.Handle(context.Background(), commands.ZoneFields{
+		Name:             "East",
+		Area:             json.RawMessage("null"),
+		PostcodePrefixes: []string{"e1"},
+		DeliveryFeeCents: 499,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, z.Area())
+	assert.Equal(t, []string{"E1"}, z.PostcodePrefixes())
+}
+
+func TestCreateZone_InvalidInputs_ReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  commands.ZoneFields
+		wantErr error
+	}{
+		{"bad geojson", commands.ZoneFields{Name: "X", Area: json.RawMessage(`{"type":"Point"}`)}, delivery.ErrInvalidGeoJSON},
+		{"no area", commands.ZoneFields{Name: "X"}, delivery.ErrMissingZoneArea},
+		{"no name", commands.ZoneFields{PostcodePrefixes: []string{"E1"}}, delivery.ErrEmptyZoneName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := commands.NewCreateZoneHandler(new(mockZoneRepository))
+			_, err := handler.Handle(context.Background(), tt.fields)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
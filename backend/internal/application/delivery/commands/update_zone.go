@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// UpdateZoneCommand is the input for the update zone use case.
+type UpdateZoneCommand struct {
+	ZoneID uuid.UUID
+	Fields ZoneFields
+	Active bool
+}
+
+// UpdateZoneHandler redefines a delivery zone or switches it on and off.
+type UpdateZoneHandler struct {
+	zoneRepo delivery.ZoneRepository
+}
+
+// NewUpdateZoneHandler creates a new UpdateZoneHandler.
+func NewUpdateZoneHandler(zoneRepo delivery.ZoneRepository) *UpdateZoneHandler {
+	return &UpdateZoneHandler{zoneRepo: zoneRepo}
+}
+
+// Handle executes the update zone use case.
+func (h *UpdateZoneHandler) Handle(ctx context.Context, cmd UpdateZoneCommand) (*delivery.Zone, error) {
+	area, err := cmd.Fields.parseArea()
+	if err != nil {
+		return nil, err
+	}
+
+	z, err := h.zoneRepo.FindByID(ctx, cmd.ZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	f := cmd.Fields
+	if err := z.Update(f.Name, area, f.PostcodePrefixes, f.DeliveryFeeCents, f.MinimumOrderCents); err != nil {
+		return nil, err
+	}
+	if err := f.applyFeeTaxCategory(z); err != nil {
+		return nil, err
+	}
+	z.SetActive(cmd.Active)
+
+	if err := h.zoneRepo.Save(ctx, z); err != nil {
+		return nil, fmt.Errorf("saving delivery zone: %w", err)
+	}
+	return z, nil
+}
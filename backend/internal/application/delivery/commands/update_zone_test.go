@@ -0,0 +1,54 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/delivery/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateZone_ExistingZone_SavesChanges(t *testing.T) {
+	repo := new(mockZoneRepository)
+	z, err := delivery.NewZone(uuid.New(), "East", nil, []string{"E1"}, 499, 2000)
+	require.NoError(t, err)
+
+	repo.On("FindByID", mock.Anything, z.ID()).Return(z, nil)
+	repo.On("Save", mock.Anything, z).Return(nil)
+
+	handler := commands.NewUpdateZoneHandler(repo)
+	updated, err := handler.Handle(context.Background(), commands.UpdateZoneCommand{
+		ZoneID: z.ID(),
+		Fields: commands.ZoneFields{
+			Name:              "East London",
+			PostcodePrefixes:  []string{"E1", "E2"},
+			DeliveryFeeCents:  599,
+			MinimumOrderCents: 2500,
+		},
+		Active: false,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "East London", updated.Name())
+	assert.Equal(t, int64(599), updated.DeliveryFeeCents())
+	assert.False(t, updated.IsActive())
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateZone_UnknownZone_ReturnsNotFound(t *testing.T) {
+	repo := new(mockZoneRepository)
+	id := uuid.New()
+	repo.On("FindByID", mock.Anything, id).Return(nil, delivery.ErrZoneNotFound)
+
+	handler := commands.NewUpdateZoneHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.UpdateZoneCommand{
+		ZoneID: id,
+		Fields: commands.ZoneFields{Name: "East", PostcodePrefixes: []string{"E1"}},
+	})
+
+	assert.ErrorIs(t, err, delivery.ErrZoneNotFound)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// ZoneFields describe a delivery zone. Area is an optional GeoJSON Polygon or
+// MultiPolygon; at least one of Area or PostcodePrefixes must be given.
+// FeeTaxCategory is left as it is when empty.
+type ZoneFields struct {
+	Name              string
+	Area              json.RawMessage
+	PostcodePrefixes  []string
+	DeliveryFeeCents  int64
+	MinimumOrderCents int64
+	FeeTaxCategory    string
+}
+
+func (f ZoneFields) parseArea() (*delivery.Area, error) {
+	if len(f.Area) == 0 || string(f.Area) == "null" {
+		return nil, nil
+	}
+	return delivery.ParseArea(f.Area)
+}
+
+func (f ZoneFields) applyFeeTaxCategory(z *delivery.Zone) error {
+	if f.FeeTaxCategory == "" {
+		return nil
+	}
+	category, err := tax.NewCategory(f.FeeTaxCategory)
+	if err != nil {
+		return err
+	}
+	return z.SetFeeTaxCategory(string(category))
+}
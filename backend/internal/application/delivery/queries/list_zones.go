@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// ListZonesHandler returns delivery zones for the back office.
+type ListZonesHandler struct {
+	zoneRepo delivery.ZoneRepository
+}
+
+// NewListZonesHandler creates a new ListZonesHandler.
+func NewListZonesHandler(zoneRepo delivery.ZoneRepository) *ListZonesHandler {
+	return &ListZonesHandler{zoneRepo: zoneRepo}
+}
+
+// Handle returns all zones, including inactive ones, ordered by name.
+func (h *ListZonesHandler) Handle(ctx context.Context) ([]*delivery.Zone, error) {
+	zones, err := h.zoneRepo.FindAll(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("finding delivery zones: %w", err)
+	}
+	return zones, nil
+}
@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// ValidateDeliveryAddressQuery asks whether a saved address can be delivered
+// to. When SubtotalCents is set the zone's minimum order is enforced too.
+type ValidateDeliveryAddressQuery struct {
+	CustomerID    uuid.UUID
+	AddressID     uuid.UUID
+	SubtotalCents *int64
+}
+
+// DeliveryQuote is the zone serving an address and what it charges.
+type DeliveryQuote struct {
+	ZoneID            uuid.UUID
+	ZoneName          string
+	DeliveryFeeCents  int64
+	MinimumOrderCents int64
+}
+
+// ValidateDeliveryAddressHandler resolves the delivery zone for an address at
+// checkout. Addresses are matched on their stored coordinates and postcode
+// only; no external geocoder is consulted.
+type ValidateDeliveryAddressHandler struct {
+	addressRepo customer.AddressRepository
+	zoneRepo    delivery.ZoneRepository
+}
+
+// NewValidateDeliveryAddressHandler creates a new ValidateDeliveryAddressHandler.
+func NewValidateDeliveryAddressHandler(addressRepo customer.AddressRepository, zoneRepo delivery.ZoneRepository) *ValidateDeliveryAddressHandler {
+	return &ValidateDeliveryAddressHandler{addressRepo: addressRepo, zoneRepo: zoneRepo}
+}
+
+// Handle returns ErrAddressNotServed when no active zone covers the address
+// and ErrBelowMinimumOrder when the subtotal is too small for the zone.
+func (h *ValidateDeliveryAddressHandler) Handle(ctx context.Context, q ValidateDeliveryAddressQuery) (*DeliveryQuote, error) {
+	address, err := h.addressRepo.FindByID(ctx, q.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	if !address.BelongsTo(q.CustomerID) {
+		return nil, customer.ErrAddressNotFound
+	}
+
+	zones, err := h.zoneRepo.FindAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding delivery zones: %w", err)
+	}
+
+	var point *delivery.Point
+	if c := address.Coordinates(); c != nil {
+		point = &delivery.Point{Longitude: c.Longitude(), Latitude: c.Latitude()}
+	}
+	zone, err := delivery.FindZone(zones, address.Postcode().String(), point)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.SubtotalCents != nil {
+		if err := zone.CheckMinimumOrder(*q.SubtotalCents); err != nil {
+			return nil, err
+		}
+	}
+
+	return &DeliveryQuote{
+		ZoneID:            zone.ID(),
+		ZoneName:          zone.Name(),
+		DeliveryFeeCents:  zone.DeliveryFeeCents(),
+		MinimumOrderCents: zone.MinimumOrderCents(),
+	}, nil
+}
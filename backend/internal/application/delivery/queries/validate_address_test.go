@@ -0,0 +1,164 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/delivery/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockAddressRepository struct {
+	mock.Mock
+}
+
+func (m *mockAddressRepository) Save(ctx context.Context, a *customer.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockAddressRepository) SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error {
+	args := m.Called(ctx, customerID, addressID)
+	return args.Error(0)
+}
+
+type mockZoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	args := m.Called(ctx, z)
+	return args.Error(0)
+}
+
+func (m *mockZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*delivery.Zone), args.Error(1)
+}
+
+func (m *mockZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*delivery.Zone), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func newAddress(t *testing.T, customerID uuid.UUID, postcode string, lat, lng *float64) *customer.Address {
+	t.Helper()
+	p, err := customer.NewPostcode(postcode)
+	require.NoError(t, err)
+	var coords *customer.Coordinates
+	if lat != nil {
+		c, err := customer.NewCoordinates(*lat, *lng)
+		require.NoError(t, err)
+		coords = &c
+	}
+	a, err := customer.NewAddress(uuid.New(), customerID, "", "1 High Street", "", "London", p, coords)
+	require.NoError(t, err)
+	return a
+}
+
+func testZones(t *testing.T) (polygonZone, postcodeZone *delivery.Zone) {
+	t.Helper()
+	area, err := delivery.ParseArea([]byte(`{"type":"Polygon","coordinates":[
+		[[-0.08,51.51],[-0.06,51.51],[-0.06,51.53],[-0.08,51.53],[-0.08,51.51]]
+	]}`))
+	require.NoError(t, err)
+	polygonZone, err = delivery.NewZone(uuid.New(), "Around the shop", area, nil, 0, 1500)
+	require.NoError(t, err)
+	postcodeZone, err = delivery.NewZone(uuid.New(), "East", nil, []string{"E"}, 699, 3000)
+	require.NoError(t, err)
+	return polygonZone, postcodeZone
+}
+
+func TestValidateDeliveryAddress(t *testing.T) {
+	customerID := uuid.New()
+	polygonZone, postcodeZone := testZones(t)
+	lat, lng := 51.52, -0.07
+	pinned := newAddress(t, customerID, "E1 6AN", &lat, &lng)
+	unpinned := newAddress(t, customerID, "E1 6AN", nil, nil)
+	outside := newAddress(t, customerID, "SW1A 1AA", nil, nil)
+
+	addressRepo := new(mockAddressRepository)
+	for _, a := range []*customer.Address{pinned, unpinned, outside} {
+		addressRepo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	}
+	zoneRepo := new(mockZoneRepository)
+	zoneRepo.On("FindAll", mock.Anything, true).Return([]*delivery.Zone{polygonZone, postcodeZone}, nil)
+
+	handler := queries.NewValidateDeliveryAddressHandler(addressRepo, zoneRepo)
+	ctx := context.Background()
+
+	t.Run("pinned address inside polygon", func(t *testing.T) {
+		quote, err := handler.Handle(ctx, queries.ValidateDeliveryAddressQuery{CustomerID: customerID, AddressID: pinned.ID()})
+
+		require.NoError(t, err)
+		assert.Equal(t, polygonZone.ID(), quote.ZoneID)
+		assert.Equal(t, int64(0), quote.DeliveryFeeCents)
+	})
+
+	t.Run("unpinned address falls back to postcode", func(t *testing.T) {
+		quote, err := handler.Handle(ctx, queries.ValidateDeliveryAddressQuery{CustomerID: customerID, AddressID: unpinned.ID()})
+
+		require.NoError(t, err)
+		assert.Equal(t, postcodeZone.ID(), quote.ZoneID)
+		assert.Equal(t, int64(3000), quote.MinimumOrderCents)
+	})
+
+	t.Run("address outside all zones", func(t *testing.T) {
+		_, err := handler.Handle(ctx, queries.ValidateDeliveryAddressQuery{CustomerID: customerID, AddressID: outside.ID()})
+
+		assert.ErrorIs(t, err, delivery.ErrAddressNotServed)
+	})
+
+	t.Run("subtotal below zone minimum", func(t *testing.T) {
+		subtotal := int64(2999)
+		_, err := handler.Handle(ctx, queries.ValidateDeliveryAddressQuery{
+			CustomerID:    customerID,
+			AddressID:     unpinned.ID(),
+			SubtotalCents: &subtotal,
+		})
+
+		assert.ErrorIs(t, err, delivery.ErrBelowMinimumOrder)
+	})
+
+	t.Run("another customer's address", func(t *testing.T) {
+		_, err := handler.Handle(ctx, queries.ValidateDeliveryAddressQuery{CustomerID: uuid.New(), AddressID: pinned.ID()})
+
+		assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+	})
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// AddStopCommand is the input for the add stop use case. Without an
+// AddressID the order goes to its customer's default address.
+type AddStopCommand struct {
+	RunID     uuid.UUID
+	OrderID   uuid.UUID
+	AddressID *uuid.UUID
+	ActorID   uuid.UUID
+}
+
+// AddStopHandler adds an order to the end of a planned run, for orders
+// taken over the phone or moved off another run. The run is not rerouted
+// until it is optimised.
+type AddStopHandler struct {
+	adminRepo   admin.Repository
+	orderRepo   order.Repository
+	addressRepo customer.AddressRepository
+	runRepo     dispatch.RunRepository
+}
+
+// NewAddStopHandler creates a new AddStopHandler.
+func NewAddStopHandler(
+	adminRepo admin.Repository,
+	orderRepo order.Repository,
+	addressRepo customer.AddressRepository,
+	runRepo dispatch.RunRepository,
+) *AddStopHandler {
+	return &AddStopHandler{adminRepo: adminRepo, orderRepo: orderRepo, addressRepo: addressRepo, runRepo: runRepo}
+}
+
+// Handle executes the add stop use case.
+func (h *AddStopHandler) Handle(ctx context.Context, cmd AddStopCommand) (*dispatch.Run, error) {
+	return editRun(ctx, h.adminRepo, h.runRepo, cmd.RunID, cmd.ActorID, func(r *dispatch.Run, now time.Time) error {
+		o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+		if err != nil {
+			return err
+		}
+		if o.BranchID() != r.BranchID() {
+			return dispatch.ErrOrderWrongBranch
+		}
+
+		var addr *customer.Address
+		if cmd.AddressID != nil {
+			if addr, err = h.addressRepo.FindByID(ctx, *cmd.AddressID); err != nil {
+				return err
+			}
+			if !addr.BelongsTo(o.CustomerID()) {
+				return dispatch.ErrAddressNotCustomers
+			}
+		} else {
+			if addr, err = defaultAddress(ctx, h.addressRepo, o.CustomerID()); err != nil {
+				return err
+			}
+			if addr == nil {
+				return dispatch.ErrNoAddress
+			}
+		}
+
+		return r.AddStop(dispatch.NewStop(uuid.New(), o.ID(), addr), now)
+	})
+}
+
+// defaultAddress returns the customer's default address, or their only
+// one, or nil if they have none to choose between.
+func defaultAddress(ctx context.Context, addressRepo customer.AddressRepository, customerID uuid.UUID) (*customer.Address, error) {
+	addresses, err := addressRepo.FindByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("loading addresses: %w", err)
+	}
+	for _, a := range addresses {
+		if a.IsDefault() {
+			return a, nil
+		}
+	}
+	if len(addresses) == 1 {
+		return addresses[0], nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	domainauth "github.com/katerji/butchery-app/backend/internal/domain/auth"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// CreateDriverCommand is the input for the create driver use case.
+type CreateDriverCommand struct {
+	Email    string
+	Password string
+	FullName string
+	BranchID uuid.UUID
+	Phone    string
+	Vehicle  string
+	ActorID  uuid.UUID
+}
+
+// CreateDriverHandler takes on a driver: it opens an admin account with the
+// driver role, assigned to the driver's home branch, that they sign in to
+// the driver app with.
+type CreateDriverHandler struct {
+	adminRepo  admin.Repository
+	branchRepo branch.Repository
+	driverRepo dispatch.DriverRepository
+	hasher     domainauth.PasswordHasher
+}
+
+// NewCreateDriverHandler creates a new CreateDriverHandler.
+func NewCreateDriverHandler(
+	adminRepo admin.Repository,
+	branchRepo branch.Repository,
+	driverRepo dispatch.DriverRepository,
+	hasher domainauth.PasswordHasher,
+) *CreateDriverHandler {
+	return &CreateDriverHandler{adminRepo: adminRepo, branchRepo: branchRepo, driverRepo: driverRepo, hasher: hasher}
+}
+
+// Handle executes the create driver use case.
+func (h *CreateDriverHandler) Handle(ctx context.Context, cmd CreateDriverCommand) (*dispatch.Driver, error) {
+	if err := dispatcher(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	if err := dispatch.ValidatePassword(cmd.Password); err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+
+	hash, err := h.hasher.Hash(cmd.Password)
+	if err != nil {
+		return nil, fmt.Errorf("hashing password: %w", err)
+	}
+	account, err := admin.NewAdmin(uuid.New(), strings.TrimSpace(cmd.Email), hash, strings.TrimSpace(cmd.FullName), admin.RoleDriver)
+	if err != nil {
+		return nil, err
+	}
+	account.AssignBranches(false, []uuid.UUID{cmd.BranchID})
+	d, err := dispatch.NewDriver(account, cmd.BranchID, cmd.Phone, cmd.Vehicle, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.driverRepo.Create(ctx, account, d); err != nil {
+		if errors.Is(err, dispatch.ErrEmailTaken) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving driver: %w", err)
+	}
+	return d, nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// dispatcher checks the admin making the change may dispatch deliveries.
+func dispatcher(ctx context.Context, adminRepo admin.Repository, actorID uuid.UUID) error {
+	actor, err := adminRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !actor.HasPermission(admin.PermissionDispatchDeliveries) {
+		return dispatch.ErrDispatchNotAllowed
+	}
+	return nil
+}
+
+// updateRun stores a changed run, passing through the errors callers can
+// act on.
+func updateRun(ctx context.Context, runRepo dispatch.RunRepository, r *dispatch.Run) error {
+	if err := runRepo.Update(ctx, r); err != nil {
+		if errors.Is(err, dispatch.ErrConcurrentUpdate) || errors.Is(err, dispatch.ErrOrderAlreadyRouted) ||
+			errors.Is(err, branch.ErrOutOfScope) {
+			return err
+		}
+		return fmt.Errorf("saving delivery run: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// StartRunCommand is the input for the start run use case. DriverID is the
+// signed-in driver.
+type StartRunCommand struct {
+	RunID    uuid.UUID
+	DriverID uuid.UUID
+}
+
+// StartRunHandler lets a driver set off on the run they were given. From
+// then on its stops are fixed and customers see it as out for delivery.
+type StartRunHandler struct {
+	runRepo dispatch.RunRepository
+}
+
+// NewStartRunHandler creates a new StartRunHandler.
+func NewStartRunHandler(runRepo dispatch.RunRepository) *StartRunHandler {
+	return &StartRunHandler{runRepo: runRepo}
+}
+
+// Handle executes the start run use case.
+func (h *StartRunHandler) Handle(ctx context.Context, cmd StartRunCommand) (*dispatch.Run, error) {
+	r, err := h.runRepo.FindByID(ctx, cmd.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.StartRun(cmd.DriverID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateRun(ctx, h.runRepo, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ProofUpload is an image a driver uploads as proof of delivery.
+type ProofUpload struct {
+	Kind        dispatch.ProofKind
+	ContentType string
+	Data        []byte
+}
+
+// CompleteStopCommand is the input for the deliver and fail stop use cases.
+// RecipientName is who took a delivered order; Reason is why a failed one
+// could not be delivered.
+type CompleteStopCommand struct {
+	RunID         uuid.UUID
+	StopID        uuid.UUID
+	DriverID      uuid.UUID
+	RecipientName string
+	Reason        string
+	Proofs        []ProofUpload
+}
+
+// completeStop validates the uploaded proofs, closes the stop with change
+// and stores the proofs and then the run. Proofs stored for a stop whose
+// run then fails to save are replaced when the driver tries again.
+func completeStop(
+	ctx context.Context,
+	runRepo dispatch.RunRepository,
+	proofRepo dispatch.ProofRepository,
+	cmd CompleteStopCommand,
+	change func(r *dispatch.Run, proofs []*dispatch.Proof, now time.Time) error,
+) (*dispatch.Run, error) {
+	now := time.Now()
+	proofs := make([]*dispatch.Proof, 0, len(cmd.Proofs))
+	for _, u := range cmd.Proofs {
+		p, err := dispatch.NewProof(cmd.StopID, u.Kind, u.ContentType, u.Data, now)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, p)
+	}
+
+	r, err := runRepo.FindByID(ctx, cmd.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(r, proofs, now); err != nil {
+		return nil, err
+	}
+
+	for _, p := range proofs {
+		if err := proofRepo.Save(ctx, p); err != nil {
+			return nil, fmt.Errorf("saving proof of delivery: %w", err)
+		}
+	}
+	if err := updateRun(ctx, runRepo, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeliverStopHandler records that a driver handed over a stop's order.
+type DeliverStopHandler struct {
+	runRepo   dispatch.RunRepository
+	proofRepo dispatch.ProofRepository
+}
+
+// NewDeliverStopHandler creates a new DeliverStopHandler.
+func NewDeliverStopHandler(runRepo dispatch.RunRepository, proofRepo dispatch.ProofRepository) *DeliverStopHandler {
+	return &DeliverStopHandler{runRepo: runRepo, proofRepo: proofRepo}
+}
+
+// Handle executes the deliver stop use case.
+func (h *DeliverStopHandler) Handle(ctx context.Context, cmd CompleteStopCommand) (*dispatch.Run, error) {
+	return completeStop(ctx, h.runRepo, h.proofRepo, cmd, func(r *dispatch.Run, proofs []*dispatch.Proof, now time.Time) error {
+		return r.Deliver(cmd.StopID, cmd.DriverID, cmd.RecipientName, proofs, now)
+	})
+}
+
+// FailStopHandler records that a driver could not deliver a stop's order.
+type FailStopHandler struct {
+	runRepo   dispatch.RunRepository
+	proofRepo dispatch.ProofRepository
+}
+
+// NewFailStopHandler creates a new FailStopHandler.
+func NewFailStopHandler(runRepo dispatch.RunRepository, proofRepo dispatch.ProofRepository) *FailStopHandler {
+	return &FailStopHandler{runRepo: runRepo, proofRepo: proofRepo}
+}
+
+// Handle executes the fail stop use case.
+func (h *FailStopHandler) Handle(ctx context.Context, cmd CompleteStopCommand) (*dispatch.Run, error) {
+	return completeStop(ctx, h.runRepo, h.proofRepo, cmd, func(r *dispatch.Run, proofs []*dispatch.Proof, now time.Time) error {
+		return r.Fail(cmd.StopID, cmd.DriverID, cmd.Reason, proofs, now)
+	})
+}
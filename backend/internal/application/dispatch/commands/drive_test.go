@@ -0,0 +1,102 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/dispatch/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockProofRepository struct {
+	mock.Mock
+}
+
+func (m *mockProofRepository) Save(ctx context.Context, p *dispatch.Proof) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockProofRepository) Find(ctx context.Context, stopID uuid.UUID, kind dispatch.ProofKind) (*dispatch.Proof, error) {
+	args := m.Called(ctx, stopID, kind)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Proof), args.Error(1)
+}
+
+// --- Fixtures ---
+
+// startedRun returns a run with one stop that driver has started.
+func startedRun(t *testing.T, driver *dispatch.Driver) *dispatch.Run {
+	t.Helper()
+	r, err := dispatch.NewRun(uuid.New(), driver.BranchID(), uuid.New(), slotStart, slotStart.Add(2*time.Hour), nil, uuid.New(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, r.AddStop(dispatch.NewStop(uuid.New(), uuid.New(), newAddress(t, uuid.New(), 51.5, -0.07)), time.Now()))
+	require.NoError(t, r.AssignDriver(driver, time.Now()))
+	require.NoError(t, r.StartRun(driver.AdminID(), time.Now()))
+	return r
+}
+
+var signature = []byte("\x89PNG\r\n\x1a\n")
+
+// --- Tests ---
+
+func TestDeliverStop_StoresProofThenRun(t *testing.T) {
+	runs, proofs := new(mockRunRepository), new(mockProofRepository)
+	driver := newDriver(t, uuid.New())
+	r := startedRun(t, driver)
+	stop := r.Stops()[0]
+	runs.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	proofs.On("Save", mock.Anything, mock.MatchedBy(func(p *dispatch.Proof) bool {
+		return p.StopID() == stop.ID() && p.Kind() == dispatch.ProofSignature
+	})).Return(nil).Once()
+	runs.On("Update", mock.Anything, r).Return(nil).Once()
+
+	got, err := commands.NewDeliverStopHandler(runs, proofs).Handle(context.Background(), commands.CompleteStopCommand{
+		RunID: r.ID(), StopID: stop.ID(), DriverID: driver.AdminID(), RecipientName: "Amina",
+		Proofs: []commands.ProofUpload{{Kind: dispatch.ProofSignature, ContentType: "image/png", Data: signature}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, dispatch.RunCompleted, got.Status())
+	assert.Equal(t, dispatch.StopDelivered, got.Stops()[0].Status())
+	proofs.AssertExpectations(t)
+	runs.AssertExpectations(t)
+}
+
+func TestDeliverStop_AnotherDriver_StoresNothing(t *testing.T) {
+	runs, proofs := new(mockRunRepository), new(mockProofRepository)
+	driver := newDriver(t, uuid.New())
+	r := startedRun(t, driver)
+	runs.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	_, err := commands.NewDeliverStopHandler(runs, proofs).Handle(context.Background(), commands.CompleteStopCommand{
+		RunID: r.ID(), StopID: r.Stops()[0].ID(), DriverID: uuid.New(),
+		Proofs: []commands.ProofUpload{{Kind: dispatch.ProofPhoto, ContentType: "image/png", Data: signature}},
+	})
+
+	assert.ErrorIs(t, err, dispatch.ErrNotRunDriver)
+	proofs.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	runs.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestFailStop_ChangedMeanwhile_ReturnsErrConcurrentUpdate(t *testing.T) {
+	runs, proofs := new(mockRunRepository), new(mockProofRepository)
+	driver := newDriver(t, uuid.New())
+	r := startedRun(t, driver)
+	runs.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	runs.On("Update", mock.Anything, r).Return(dispatch.ErrConcurrentUpdate)
+
+	_, err := commands.NewFailStopHandler(runs, proofs).Handle(context.Background(), commands.CompleteStopCommand{
+		RunID: r.ID(), StopID: r.Stops()[0].ID(), DriverID: driver.AdminID(), Reason: "No one home",
+	})
+
+	assert.ErrorIs(t, err, dispatch.ErrConcurrentUpdate)
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// editRun loads a run for a dispatcher, applies change to it and stores it.
+func editRun(
+	ctx context.Context,
+	adminRepo admin.Repository,
+	runRepo dispatch.RunRepository,
+	runID, actorID uuid.UUID,
+	change func(*dispatch.Run, time.Time) error,
+) (*dispatch.Run, error) {
+	if err := dispatcher(ctx, adminRepo, actorID); err != nil {
+		return nil, err
+	}
+	r, err := runRepo.FindByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(r, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateRun(ctx, runRepo, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RemoveStopCommand is the input for the remove stop use case.
+type RemoveStopCommand struct {
+	RunID   uuid.UUID
+	StopID  uuid.UUID
+	ActorID uuid.UUID
+}
+
+// RemoveStopHandler takes an order off a planned run, so that it can go on
+// another.
+type RemoveStopHandler struct {
+	adminRepo admin.Repository
+	runRepo   dispatch.RunRepository
+}
+
+// NewRemoveStopHandler creates a new RemoveStopHandler.
+func NewRemoveStopHandler(adminRepo admin.Repository, runRepo dispatch.RunRepository) *RemoveStopHandler {
+	return &RemoveStopHandler{adminRepo: adminRepo, runRepo: runRepo}
+}
+
+// Handle executes the remove stop use case.
+func (h *RemoveStopHandler) Handle(ctx context.Context, cmd RemoveStopCommand) (*dispatch.Run, error) {
+	return editRun(ctx, h.adminRepo, h.runRepo, cmd.RunID, cmd.ActorID, func(r *dispatch.Run, now time.Time) error {
+		return r.RemoveStop(cmd.StopID, now)
+	})
+}
+
+// OptimiseRunCommand is the input for the optimise run use case.
+type OptimiseRunCommand struct {
+	RunID   uuid.UUID
+	ActorID uuid.UUID
+}
+
+// OptimiseRunHandler reroutes a planned run, say after stops were added to
+// or removed from it.
+type OptimiseRunHandler struct {
+	adminRepo admin.Repository
+	runRepo   dispatch.RunRepository
+}
+
+// NewOptimiseRunHandler creates a new OptimiseRunHandler.
+func NewOptimiseRunHandler(adminRepo admin.Repository, runRepo dispatch.RunRepository) *OptimiseRunHandler {
+	return &OptimiseRunHandler{adminRepo: adminRepo, runRepo: runRepo}
+}
+
+// Handle executes the optimise run use case.
+func (h *OptimiseRunHandler) Handle(ctx context.Context, cmd OptimiseRunCommand) (*dispatch.Run, error) {
+	return editRun(ctx, h.adminRepo, h.runRepo, cmd.RunID, cmd.ActorID, (*dispatch.Run).Optimise)
+}
+
+// AssignDriverCommand is the input for the assign driver use case.
+type AssignDriverCommand struct {
+	RunID    uuid.UUID
+	DriverID uuid.UUID
+	ActorID  uuid.UUID
+}
+
+// AssignDriverHandler gives a planned run to a driver, or to another one.
+type AssignDriverHandler struct {
+	adminRepo  admin.Repository
+	driverRepo dispatch.DriverRepository
+	runRepo    dispatch.RunRepository
+}
+
+// NewAssignDriverHandler creates a new AssignDriverHandler.
+func NewAssignDriverHandler(adminRepo admin.Repository, driverRepo dispatch.DriverRepository, runRepo dispatch.RunRepository) *AssignDriverHandler {
+	return &AssignDriverHandler{adminRepo: adminRepo, driverRepo: driverRepo, runRepo: runRepo}
+}
+
+// Handle executes the assign driver use case.
+func (h *AssignDriverHandler) Handle(ctx context.Context, cmd AssignDriverCommand) (*dispatch.Run, error) {
+	return editRun(ctx, h.adminRepo, h.runRepo, cmd.RunID, cmd.ActorID, func(r *dispatch.Run, now time.Time) error {
+		d, err := h.driverRepo.FindByAdminID(ctx, cmd.DriverID)
+		if err != nil {
+			return err
+		}
+		return r.AssignDriver(d, now)
+	})
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// PlanRunCommand is the input for the plan run use case. Start is where
+// the route is planned from, usually the shop; without it the route starts
+// at whichever stop makes it shortest. DriverID optionally assigns the run
+// straight away.
+type PlanRunCommand struct {
+	BranchID     uuid.UUID
+	ZoneID       uuid.UUID
+	SlotStartsAt time.Time
+	Start        *delivery.Point
+	DriverID     *uuid.UUID
+	ActorID      uuid.UUID
+}
+
+// PlanRunResult is the planned run and the orders left off it because
+// their customer has no address to deliver to.
+type PlanRunResult struct {
+	Run         *dispatch.Run
+	Unaddressed []uuid.UUID
+}
+
+// PlanRunHandler batches the confirmed delivery orders for a zone's slot
+// that are not yet on a run into a new run, and routes it. Each order goes
+// to its customer's default address, or their only one.
+type PlanRunHandler struct {
+	adminRepo       admin.Repository
+	zoneRepo        delivery.ZoneRepository
+	scheduleRepo    fulfilment.ScheduleRepository
+	reservationRepo fulfilment.ReservationRepository
+	addressRepo     customer.AddressRepository
+	driverRepo      dispatch.DriverRepository
+	runRepo         dispatch.RunRepository
+}
+
+// NewPlanRunHandler creates a new PlanRunHandler.
+func NewPlanRunHandler(
+	adminRepo admin.Repository,
+	zoneRepo delivery.ZoneRepository,
+	scheduleRepo fulfilment.ScheduleRepository,
+	reservationRepo fulfilment.ReservationRepository,
+	addressRepo customer.AddressRepository,
+	driverRepo dispatch.DriverRepository,
+	runRepo dispatch.RunRepository,
+) *PlanRunHandler {
+	return &PlanRunHandler{
+		adminRepo:       adminRepo,
+		zoneRepo:        zoneRepo,
+		scheduleRepo:    scheduleRepo,
+		reservationRepo: reservationRepo,
+		addressRepo:     addressRepo,
+		driverRepo:      driverRepo,
+		runRepo:         runRepo,
+	}
+}
+
+// Handle executes the plan run use case.
+func (h *PlanRunHandler) Handle(ctx context.Context, cmd PlanRunCommand) (*PlanRunResult, error) {
+	if err := dispatcher(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	if err := branch.ScopeFrom(ctx).Check(cmd.BranchID); err != nil {
+		return nil, err
+	}
+	if _, err := h.zoneRepo.FindByID(ctx, cmd.ZoneID); err != nil {
+		return nil, err
+	}
+
+	orders, err := h.waitingOrders(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, dispatch.ErrNoOrders
+	}
+
+	now := time.Now()
+	run, err := dispatch.NewRun(uuid.New(), cmd.BranchID, cmd.ZoneID, cmd.SlotStartsAt, orders[0].SlotEndsAt(), cmd.Start, cmd.ActorID, now)
+	if err != nil {
+		return nil, err
+	}
+	result := &PlanRunResult{Run: run}
+	for _, res := range orders {
+		addr, err := defaultAddress(ctx, h.addressRepo, res.CustomerID())
+		if err != nil {
+			return nil, err
+		}
+		if addr == nil {
+			result.Unaddressed = append(result.Unaddressed, *res.OrderID())
+			continue
+		}
+		if err := run.AddStop(dispatch.NewStop(uuid.New(), *res.OrderID(), addr), now); err != nil {
+			return nil, err
+		}
+	}
+	if len(run.Stops()) == 0 {
+		return nil, dispatch.ErrNoAddress
+	}
+	if err := run.Optimise(now); err != nil {
+		return nil, err
+	}
+	if cmd.DriverID != nil {
+		d, err := h.driverRepo.FindByAdminID(ctx, *cmd.DriverID)
+		if err != nil {
+			return nil, err
+		}
+		if err := run.AssignDriver(d, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.runRepo.Create(ctx, run); err != nil {
+		if errors.Is(err, dispatch.ErrOrderAlreadyRouted) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving delivery run: %w", err)
+	}
+	return result, nil
+}
+
+// waitingOrders returns the confirmed reservations with an order for the
+// branch's delivery slots in the zone starting at the slot's start, less
+// those whose order is already on a run.
+func (h *PlanRunHandler) waitingOrders(ctx context.Context, cmd PlanRunCommand) ([]*fulfilment.Reservation, error) {
+	method := fulfilment.MethodDelivery
+	templates, err := h.scheduleRepo.FindSlotTemplates(ctx, fulfilment.SlotTemplateFilter{
+		Method: &method, BranchID: &cmd.BranchID, ZoneID: &cmd.ZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading slot templates: %w", err)
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	templateIDs := make([]uuid.UUID, len(templates))
+	for i, t := range templates {
+		templateIDs[i] = t.ID()
+	}
+
+	reservations, err := h.reservationRepo.FindConfirmed(ctx, templateIDs, cmd.SlotStartsAt, cmd.SlotStartsAt.Add(time.Minute))
+	if err != nil {
+		return nil, fmt.Errorf("loading reservations: %w", err)
+	}
+	var orderIDs []uuid.UUID
+	for _, res := range reservations {
+		if res.OrderID() != nil {
+			orderIDs = append(orderIDs, *res.OrderID())
+		}
+	}
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	routed, err := h.runRepo.RoutedOrders(ctx, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("loading routed orders: %w", err)
+	}
+
+	var waiting []*fulfilment.Reservation
+	for _, res := range reservations {
+		if res.OrderID() != nil && !routed[*res.OrderID()] {
+			waiting = append(waiting, res)
+		}
+	}
+	return waiting, nil
+}
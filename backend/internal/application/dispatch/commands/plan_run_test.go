@@ -0,0 +1,406 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/dispatch/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+type mockZoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	return m.Called(ctx, z).Error(0)
+}
+
+func (m *mockZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*delivery.Zone), args.Error(1)
+}
+
+func (m *mockZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*delivery.Zone), args.Error(1)
+}
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	return m.Called(ctx, branchID, hours).Error(0)
+}
+
+func (m *mockScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.OpeningHours), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveClosure(ctx context.Context, closure *fulfilment.Closure) error {
+	return m.Called(ctx, closure).Error(0)
+}
+
+func (m *mockScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	args := m.Called(ctx, branchID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Closure), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSlotTemplate(ctx context.Context, t *fulfilment.SlotTemplate) error {
+	return m.Called(ctx, t).Error(0)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.SlotTemplate), args.Error(1)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.SlotTemplate), args.Error(1)
+}
+
+type mockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, r *fulfilment.Reservation) error {
+	return m.Called(ctx, slot, r).Error(0)
+}
+
+func (m *mockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) Update(ctx context.Context, r *fulfilment.Reservation) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	args := m.Called(ctx, templateIDs, from, to, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[fulfilment.SlotKey]int64), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, templateIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+type mockAddressRepository struct {
+	mock.Mock
+}
+
+func (m *mockAddressRepository) Save(ctx context.Context, a *customer.Address) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockAddressRepository) SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error {
+	return m.Called(ctx, customerID, addressID).Error(0)
+}
+
+type mockDriverRepository struct {
+	mock.Mock
+}
+
+func (m *mockDriverRepository) Create(ctx context.Context, account *admin.Admin, d *dispatch.Driver) error {
+	return m.Called(ctx, account, d).Error(0)
+}
+
+func (m *mockDriverRepository) Save(ctx context.Context, d *dispatch.Driver) error {
+	return m.Called(ctx, d).Error(0)
+}
+
+func (m *mockDriverRepository) FindByAdminID(ctx context.Context, adminID uuid.UUID) (*dispatch.Driver, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Driver), args.Error(1)
+}
+
+func (m *mockDriverRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*dispatch.Driver, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispatch.Driver), args.Error(1)
+}
+
+type mockRunRepository struct {
+	mock.Mock
+}
+
+func (m *mockRunRepository) Create(ctx context.Context, r *dispatch.Run) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRunRepository) Update(ctx context.Context, r *dispatch.Run) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRunRepository) FindByID(ctx context.Context, id uuid.UUID) (*dispatch.Run, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Run), args.Error(1)
+}
+
+func (m *mockRunRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) (*dispatch.Run, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Run), args.Error(1)
+}
+
+func (m *mockRunRepository) FindRuns(ctx context.Context, f dispatch.RunFilter) ([]*dispatch.Run, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispatch.Run), args.Error(1)
+}
+
+func (m *mockRunRepository) RoutedOrders(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	args := m.Called(ctx, orderIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
+}
+
+// --- Fixtures ---
+
+var slotStart = time.Date(2026, 11, 10, 16, 0, 0, 0, time.UTC)
+
+func newAdmin(t *testing.T, role admin.Role) *admin.Admin {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), string(role)+"@butchery.com", "$2a$10$hash", "Sam Ahmed", role)
+	require.NoError(t, err)
+	return a
+}
+
+func newDriver(t *testing.T, branchID uuid.UUID) *dispatch.Driver {
+	t.Helper()
+	d, err := dispatch.NewDriver(newAdmin(t, admin.RoleDriver), branchID, "", "Van", time.Now())
+	require.NoError(t, err)
+	return d
+}
+
+func newAddress(t *testing.T, customerID uuid.UUID, lat, lon float64) *customer.Address {
+	t.Helper()
+	postcode, err := customer.NewPostcode("E1 6AN")
+	require.NoError(t, err)
+	coords, err := customer.NewCoordinates(lat, lon)
+	require.NoError(t, err)
+	a, err := customer.NewAddress(uuid.New(), customerID, "Home", "1 Brick Lane", "", "London", postcode, &coords)
+	require.NoError(t, err)
+	return a
+}
+
+func confirmed(templateID, customerID uuid.UUID) *fulfilment.Reservation {
+	orderID := uuid.New()
+	return fulfilment.ReconstructReservation(uuid.New(), templateID, customerID, slotStart, slotStart.Add(2*time.Hour), 1,
+		fulfilment.ReservationConfirmed, &orderID, slotStart, slotStart)
+}
+
+type planFixture struct {
+	admins       *mockAdminRepository
+	zones        *mockZoneRepository
+	schedule     *mockScheduleRepository
+	reservations *mockReservationRepository
+	addresses    *mockAddressRepository
+	drivers      *mockDriverRepository
+	runs         *mockRunRepository
+	handler      *commands.PlanRunHandler
+	manager      *admin.Admin
+	branchID     uuid.UUID
+	zoneID       uuid.UUID
+	templateID   uuid.UUID
+}
+
+func newPlanFixture(t *testing.T) *planFixture {
+	f := &planFixture{
+		admins: new(mockAdminRepository), zones: new(mockZoneRepository), schedule: new(mockScheduleRepository),
+		reservations: new(mockReservationRepository), addresses: new(mockAddressRepository),
+		drivers: new(mockDriverRepository), runs: new(mockRunRepository),
+		manager: newAdmin(t, admin.RoleManager), branchID: uuid.New(), templateID: uuid.New(),
+	}
+	f.handler = commands.NewPlanRunHandler(f.admins, f.zones, f.schedule, f.reservations, f.addresses, f.drivers, f.runs)
+	zone, err := delivery.NewZone(uuid.New(), "Whitechapel", nil, []string{"E1"}, 499, 2500)
+	require.NoError(t, err)
+	f.zoneID = zone.ID()
+	f.admins.On("FindByID", mock.Anything, f.manager.ID()).Return(f.manager, nil)
+	f.zones.On("FindByID", mock.Anything, f.zoneID).Return(zone, nil)
+	template := fulfilment.ReconstructSlotTemplate(f.templateID, f.branchID, &f.zoneID, fulfilment.MethodDelivery,
+		time.Tuesday, fulfilment.TimeOfDay{}, fulfilment.TimeOfDay{}, fulfilment.Capacity{}, true)
+	f.schedule.On("FindSlotTemplates", mock.Anything, mock.Anything).Return([]*fulfilment.SlotTemplate{template}, nil)
+	return f
+}
+
+// --- Tests ---
+
+func TestPlanRun_BatchesWaitingOrdersAndRoutesFromStart(t *testing.T) {
+	f := newPlanFixture(t)
+	near, far, routed, homeless := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	farRes, nearRes := confirmed(f.templateID, far), confirmed(f.templateID, near)
+	routedRes, homelessRes := confirmed(f.templateID, routed), confirmed(f.templateID, homeless)
+	f.reservations.On("FindConfirmed", mock.Anything, []uuid.UUID{f.templateID}, slotStart, slotStart.Add(time.Minute)).
+		Return([]*fulfilment.Reservation{farRes, routedRes, nearRes, homelessRes}, nil)
+	f.runs.On("RoutedOrders", mock.Anything, mock.Anything).Return(map[uuid.UUID]bool{*routedRes.OrderID(): true}, nil)
+	f.addresses.On("FindByCustomer", mock.Anything, far).Return([]*customer.Address{newAddress(t, far, 51.53, -0.05)}, nil)
+	f.addresses.On("FindByCustomer", mock.Anything, near).Return([]*customer.Address{newAddress(t, near, 51.516, -0.069)}, nil)
+	f.addresses.On("FindByCustomer", mock.Anything, homeless).Return([]*customer.Address{}, nil)
+	driver := newDriver(t, f.branchID)
+	driverID := driver.AdminID()
+	f.drivers.On("FindByAdminID", mock.Anything, driverID).Return(driver, nil)
+	f.runs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := f.handler.Handle(context.Background(), commands.PlanRunCommand{
+		BranchID: f.branchID, ZoneID: f.zoneID, SlotStartsAt: slotStart,
+		Start: &delivery.Point{Longitude: -0.07, Latitude: 51.515}, DriverID: &driverID, ActorID: f.manager.ID(),
+	})
+
+	require.NoError(t, err)
+	stops := result.Run.Stops()
+	require.Len(t, stops, 2)
+	assert.Equal(t, *nearRes.OrderID(), stops[0].OrderID(), "the stop nearest the shop comes first")
+	assert.Equal(t, *farRes.OrderID(), stops[1].OrderID())
+	assert.Equal(t, []uuid.UUID{*homelessRes.OrderID()}, result.Unaddressed)
+	assert.Equal(t, slotStart.Add(2*time.Hour), result.Run.SlotEndsAt())
+	assert.Equal(t, driverID, *result.Run.DriverID())
+	f.runs.AssertCalled(t, "Create", mock.Anything, result.Run)
+}
+
+func TestPlanRun_NothingWaiting_ReturnsErrNoOrders(t *testing.T) {
+	f := newPlanFixture(t)
+	res := confirmed(f.templateID, uuid.New())
+	f.reservations.On("FindConfirmed", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*fulfilment.Reservation{res}, nil)
+	f.runs.On("RoutedOrders", mock.Anything, mock.Anything).Return(map[uuid.UUID]bool{*res.OrderID(): true}, nil)
+
+	_, err := f.handler.Handle(context.Background(), commands.PlanRunCommand{
+		BranchID: f.branchID, ZoneID: f.zoneID, SlotStartsAt: slotStart, ActorID: f.manager.ID(),
+	})
+
+	assert.ErrorIs(t, err, dispatch.ErrNoOrders)
+	f.runs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPlanRun_NotDispatcher_ReturnsErrDispatchNotAllowed(t *testing.T) {
+	f := newPlanFixture(t)
+	counter := newAdmin(t, admin.RoleStaff)
+	f.admins.On("FindByID", mock.Anything, counter.ID()).Return(counter, nil)
+
+	_, err := f.handler.Handle(context.Background(), commands.PlanRunCommand{
+		BranchID: f.branchID, ZoneID: f.zoneID, SlotStartsAt: slotStart, ActorID: counter.ID(),
+	})
+
+	assert.ErrorIs(t, err, dispatch.ErrDispatchNotAllowed)
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// UpdateDriverCommand is the input for the update driver use case.
+type UpdateDriverCommand struct {
+	AdminID  uuid.UUID
+	BranchID uuid.UUID
+	Phone    string
+	Vehicle  string
+	Active   bool
+	ActorID  uuid.UUID
+}
+
+// UpdateDriverHandler changes a driver's profile. A driver moved to another
+// branch is assigned to it in place of their old one.
+type UpdateDriverHandler struct {
+	adminRepo  admin.Repository
+	branchRepo branch.Repository
+	driverRepo dispatch.DriverRepository
+}
+
+// NewUpdateDriverHandler creates a new UpdateDriverHandler.
+func NewUpdateDriverHandler(adminRepo admin.Repository, branchRepo branch.Repository, driverRepo dispatch.DriverRepository) *UpdateDriverHandler {
+	return &UpdateDriverHandler{adminRepo: adminRepo, branchRepo: branchRepo, driverRepo: driverRepo}
+}
+
+// Handle executes the update driver use case.
+func (h *UpdateDriverHandler) Handle(ctx context.Context, cmd UpdateDriverCommand) (*dispatch.Driver, error) {
+	if err := dispatcher(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	d, err := h.driverRepo.FindByAdminID(ctx, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	moved := d.BranchID() != cmd.BranchID
+	if err := d.Update(cmd.BranchID, cmd.Phone, cmd.Vehicle, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+
+	if err := h.driverRepo.Save(ctx, d); err != nil {
+		if errors.Is(err, dispatch.ErrDriverNotFound) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving driver: %w", err)
+	}
+	if moved {
+		account, err := h.adminRepo.FindByID(ctx, d.AdminID())
+		if err != nil {
+			return nil, fmt.Errorf("loading driver account: %w", err)
+		}
+		account.AssignBranches(false, []uuid.UUID{d.BranchID()})
+		if err := h.adminRepo.SaveBranches(ctx, account); err != nil {
+			return nil, fmt.Errorf("saving driver branches: %w", err)
+		}
+	}
+	return d, nil
+}
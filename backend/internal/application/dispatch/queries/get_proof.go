@@ -0,0 +1,36 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// GetProofQuery is the input for the get proof use case.
+type GetProofQuery struct {
+	StopID  uuid.UUID
+	Kind    dispatch.ProofKind
+	ActorID uuid.UUID
+}
+
+// GetProofHandler returns a stop's proof of delivery to a dispatcher, say
+// to settle a customer's complaint that an order never came.
+type GetProofHandler struct {
+	adminRepo admin.Repository
+	proofRepo dispatch.ProofRepository
+}
+
+// NewGetProofHandler creates a new GetProofHandler.
+func NewGetProofHandler(adminRepo admin.Repository, proofRepo dispatch.ProofRepository) *GetProofHandler {
+	return &GetProofHandler{adminRepo: adminRepo, proofRepo: proofRepo}
+}
+
+// Handle executes the get proof use case.
+func (h *GetProofHandler) Handle(ctx context.Context, q GetProofQuery) (*dispatch.Proof, error) {
+	if err := dispatcher(ctx, h.adminRepo, q.ActorID); err != nil {
+		return nil, err
+	}
+	return h.proofRepo.Find(ctx, q.StopID, q.Kind)
+}
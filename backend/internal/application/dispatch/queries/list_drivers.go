@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// ListDriversQuery is the input for the list drivers use case. BranchID
+// limits the list to drivers based at a branch.
+type ListDriversQuery struct {
+	BranchID *uuid.UUID
+	ActorID  uuid.UUID
+}
+
+// ListDriversHandler lists drivers for dispatchers.
+type ListDriversHandler struct {
+	adminRepo  admin.Repository
+	driverRepo dispatch.DriverRepository
+}
+
+// NewListDriversHandler creates a new ListDriversHandler.
+func NewListDriversHandler(adminRepo admin.Repository, driverRepo dispatch.DriverRepository) *ListDriversHandler {
+	return &ListDriversHandler{adminRepo: adminRepo, driverRepo: driverRepo}
+}
+
+// Handle returns the drivers by name.
+func (h *ListDriversHandler) Handle(ctx context.Context, q ListDriversQuery) ([]*dispatch.Driver, error) {
+	if err := dispatcher(ctx, h.adminRepo, q.ActorID); err != nil {
+		return nil, err
+	}
+	drivers, err := h.driverRepo.FindAll(ctx, q.BranchID)
+	if err != nil {
+		return nil, fmt.Errorf("listing drivers: %w", err)
+	}
+	return drivers, nil
+}
+
+// dispatcher checks the admin asking may dispatch deliveries.
+func dispatcher(ctx context.Context, adminRepo admin.Repository, actorID uuid.UUID) error {
+	actor, err := adminRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !actor.HasPermission(admin.PermissionDispatchDeliveries) {
+		return dispatch.ErrDispatchNotAllowed
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// OrderDeliveryQuery is the input for the order delivery use case.
+type OrderDeliveryQuery struct {
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+}
+
+// OrderDelivery is where a customer's order is on its delivery run, and
+// who is driving it once the run has started.
+type OrderDelivery struct {
+	Position   *dispatch.Position
+	DriverName string
+}
+
+// OrderDeliveryHandler shows customers how far their order is from being
+// delivered.
+type OrderDeliveryHandler struct {
+	runRepo    dispatch.RunRepository
+	driverRepo dispatch.DriverRepository
+}
+
+// NewOrderDeliveryHandler creates a new OrderDeliveryHandler.
+func NewOrderDeliveryHandler(runRepo dispatch.RunRepository, driverRepo dispatch.DriverRepository) *OrderDeliveryHandler {
+	return &OrderDeliveryHandler{runRepo: runRepo, driverRepo: driverRepo}
+}
+
+// Handle returns the order's position on its run. Orders of other
+// customers are reported as not on a run.
+func (h *OrderDeliveryHandler) Handle(ctx context.Context, q OrderDeliveryQuery) (*OrderDelivery, error) {
+	r, err := h.runRepo.FindByOrderID(ctx, q.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	pos, err := r.PositionOf(q.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if pos.Stop.CustomerID() != q.CustomerID {
+		return nil, dispatch.ErrOrderNotOnRun
+	}
+
+	delivery := &OrderDelivery{Position: pos}
+	if r.Status() != dispatch.RunPlanned && r.DriverID() != nil {
+		d, err := h.driverRepo.FindByAdminID(ctx, *r.DriverID())
+		if err != nil && !errors.Is(err, dispatch.ErrDriverNotFound) {
+			return nil, err
+		}
+		if d != nil {
+			delivery.DriverName = d.FullName()
+		}
+	}
+	return delivery, nil
+}
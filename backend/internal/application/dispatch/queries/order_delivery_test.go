@@ -0,0 +1,134 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/dispatch/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockRunRepository struct {
+	mock.Mock
+}
+
+func (m *mockRunRepository) Create(ctx context.Context, r *dispatch.Run) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRunRepository) Update(ctx context.Context, r *dispatch.Run) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRunRepository) FindByID(ctx context.Context, id uuid.UUID) (*dispatch.Run, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Run), args.Error(1)
+}
+
+func (m *mockRunRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) (*dispatch.Run, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Run), args.Error(1)
+}
+
+func (m *mockRunRepository) FindRuns(ctx context.Context, f dispatch.RunFilter) ([]*dispatch.Run, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispatch.Run), args.Error(1)
+}
+
+func (m *mockRunRepository) RoutedOrders(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	args := m.Called(ctx, orderIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
+}
+
+type mockDriverRepository struct {
+	mock.Mock
+}
+
+func (m *mockDriverRepository) Create(ctx context.Context, account *admin.Admin, d *dispatch.Driver) error {
+	return m.Called(ctx, account, d).Error(0)
+}
+
+func (m *mockDriverRepository) Save(ctx context.Context, d *dispatch.Driver) error {
+	return m.Called(ctx, d).Error(0)
+}
+
+func (m *mockDriverRepository) FindByAdminID(ctx context.Context, adminID uuid.UUID) (*dispatch.Driver, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispatch.Driver), args.Error(1)
+}
+
+func (m *mockDriverRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*dispatch.Driver, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispatch.Driver), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func newStop(t *testing.T, customerID uuid.UUID) *dispatch.Stop {
+	t.Helper()
+	postcode, err := customer.NewPostcode("E1 6AN")
+	require.NoError(t, err)
+	addr, err := customer.NewAddress(uuid.New(), customerID, "Home", "1 Brick Lane", "", "London", postcode, nil)
+	require.NoError(t, err)
+	return dispatch.NewStop(uuid.New(), uuid.New(), addr)
+}
+
+// --- Tests ---
+
+func TestOrderDelivery_StartedRun_ShowsPositionAndDriver(t *testing.T) {
+	runs, drivers := new(mockRunRepository), new(mockDriverRepository)
+	customerID, now := uuid.New(), time.Now()
+	account, err := admin.NewAdmin(uuid.New(), "driver@butchery.com", "$2a$10$hash", "Bilal Khan", admin.RoleDriver)
+	require.NoError(t, err)
+	driver, err := dispatch.NewDriver(account, uuid.New(), "", "Van", now)
+	require.NoError(t, err)
+	r, err := dispatch.NewRun(uuid.New(), driver.BranchID(), uuid.New(), now, now.Add(2*time.Hour), nil, uuid.New(), now)
+	require.NoError(t, err)
+	first, second, mine := newStop(t, uuid.New()), newStop(t, uuid.New()), newStop(t, customerID)
+	for _, s := range []*dispatch.Stop{first, second, mine} {
+		require.NoError(t, r.AddStop(s, now))
+	}
+	require.NoError(t, r.AssignDriver(driver, now))
+	require.NoError(t, r.StartRun(driver.AdminID(), now))
+	require.NoError(t, r.Fail(first.ID(), driver.AdminID(), "No one home", nil, now))
+	runs.On("FindByOrderID", mock.Anything, mine.OrderID()).Return(r, nil)
+	drivers.On("FindByAdminID", mock.Anything, driver.AdminID()).Return(driver, nil)
+	handler := queries.NewOrderDeliveryHandler(runs, drivers)
+
+	got, err := handler.Handle(context.Background(), queries.OrderDeliveryQuery{OrderID: mine.OrderID(), CustomerID: customerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.Position.Stop.Sequence())
+	assert.Equal(t, 1, got.Position.Ahead)
+	assert.Equal(t, "Bilal Khan", got.DriverName)
+
+	_, err = handler.Handle(context.Background(), queries.OrderDeliveryQuery{OrderID: mine.OrderID(), CustomerID: uuid.New()})
+	assert.ErrorIs(t, err, dispatch.ErrOrderNotOnRun, "other customers' orders are hidden")
+}
@@ -0,0 +1,120 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// ListRunsQuery is the input for the list runs use case: the runs for
+// slots on a day, optionally at one branch, with one driver or in one
+// status.
+type ListRunsQuery struct {
+	Date     time.Time
+	BranchID *uuid.UUID
+	DriverID *uuid.UUID
+	Status   *dispatch.RunStatus
+	ActorID  uuid.UUID
+}
+
+// ListRunsHandler lists a day's delivery runs for dispatchers.
+type ListRunsHandler struct {
+	adminRepo admin.Repository
+	runRepo   dispatch.RunRepository
+	location  *time.Location
+}
+
+// NewListRunsHandler creates a new ListRunsHandler. Days start in location.
+func NewListRunsHandler(adminRepo admin.Repository, runRepo dispatch.RunRepository, location *time.Location) *ListRunsHandler {
+	return &ListRunsHandler{adminRepo: adminRepo, runRepo: runRepo, location: location}
+}
+
+// Handle returns the runs by slot start.
+func (h *ListRunsHandler) Handle(ctx context.Context, q ListRunsQuery) ([]*dispatch.Run, error) {
+	if err := dispatcher(ctx, h.adminRepo, q.ActorID); err != nil {
+		return nil, err
+	}
+	from, to := day(q.Date, h.location)
+	runs, err := h.runRepo.FindRuns(ctx, dispatch.RunFilter{
+		BranchID: q.BranchID, DriverID: q.DriverID, Status: q.Status, From: from, To: to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing delivery runs: %w", err)
+	}
+	return runs, nil
+}
+
+func day(date time.Time, loc *time.Location) (time.Time, time.Time) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	return from, from.AddDate(0, 0, 1)
+}
+
+// GetRunQuery is the input for the get run use case.
+type GetRunQuery struct {
+	RunID   uuid.UUID
+	ActorID uuid.UUID
+}
+
+// GetRunHandler returns a run with its stops to a dispatcher or to the
+// run's driver.
+type GetRunHandler struct {
+	adminRepo admin.Repository
+	runRepo   dispatch.RunRepository
+}
+
+// NewGetRunHandler creates a new GetRunHandler.
+func NewGetRunHandler(adminRepo admin.Repository, runRepo dispatch.RunRepository) *GetRunHandler {
+	return &GetRunHandler{adminRepo: adminRepo, runRepo: runRepo}
+}
+
+// Handle executes the get run use case.
+func (h *GetRunHandler) Handle(ctx context.Context, q GetRunQuery) (*dispatch.Run, error) {
+	r, err := h.runRepo.FindByID(ctx, q.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if d := r.DriverID(); d != nil && *d == q.ActorID {
+		return r, nil
+	}
+	if err := dispatcher(ctx, h.adminRepo, q.ActorID); err != nil {
+		if errors.Is(err, dispatch.ErrDispatchNotAllowed) {
+			return nil, dispatch.ErrNotRunDriver
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// DriverRunsQuery is the input for the driver runs use case: the runs the
+// signed-in driver has for slots on a day.
+type DriverRunsQuery struct {
+	Date     time.Time
+	DriverID uuid.UUID
+}
+
+// DriverRunsHandler lists a driver's own runs for the day.
+type DriverRunsHandler struct {
+	runRepo  dispatch.RunRepository
+	location *time.Location
+}
+
+// NewDriverRunsHandler creates a new DriverRunsHandler. Days start in
+// location.
+func NewDriverRunsHandler(runRepo dispatch.RunRepository, location *time.Location) *DriverRunsHandler {
+	return &DriverRunsHandler{runRepo: runRepo, location: location}
+}
+
+// Handle returns the driver's runs by slot start.
+func (h *DriverRunsHandler) Handle(ctx context.Context, q DriverRunsQuery) ([]*dispatch.Run, error) {
+	from, to := day(q.Date, h.location)
+	runs, err := h.runRepo.FindRuns(ctx, dispatch.RunFilter{DriverID: &q.DriverID, From: from, To: to})
+	if err != nil {
+		return nil, fmt.Errorf("listing delivery runs: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// AddClosureCommand is the input for the add closure use case.
+type AddClosureCommand struct {
+	BranchID uuid.UUID
+	Date     string // YYYY-MM-DD
+	Reason   string
+}
+
+// AddClosureHandler records a date on which a branch does not trade.
+type AddClosureHandler struct {
+	scheduleRepo fulfilment.ScheduleRepository
+	location     *time.Location
+}
+
+// NewAddClosureHandler creates a new AddClosureHandler.
+func NewAddClosureHandler(scheduleRepo fulfilment.ScheduleRepository, location *time.Location) *AddClosureHandler {
+	return &AddClosureHandler{scheduleRepo: scheduleRepo, location: location}
+}
+
+// Handle executes the add closure use case and returns the new closure's ID.
+func (h *AddClosureHandler) Handle(ctx context.Context, cmd AddClosureCommand) (uuid.UUID, error) {
+	date, err := fulfilment.ParseDate(cmd.Date, h.location)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	closure := fulfilment.NewClosure(uuid.New(), cmd.BranchID, date, cmd.Reason)
+	if err := h.scheduleRepo.SaveClosure(ctx, closure); err != nil {
+		return uuid.Nil, fmt.Errorf("saving closure: %w", err)
+	}
+	return closure.ID(), nil
+}
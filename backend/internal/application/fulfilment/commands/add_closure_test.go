@@ -0,0 +1,46 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddClosure_ValidDate_SavesClosure(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	branchID := uuid.New()
+
+	scheduleRepo.On("SaveClosure", mock.Anything, mock.MatchedBy(func(c *fulfilment.Closure) bool {
+		return c.BranchID() == branchID && c.Date().Equal(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC))
+	})).Return(nil)
+
+	handler := commands.NewAddClosureHandler(scheduleRepo, time.UTC)
+	id, err := handler.Handle(context.Background(), commands.AddClosureCommand{
+		BranchID: branchID,
+		Date:     "2026-12-25",
+		Reason:   "Christmas Day",
+	})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, id)
+	scheduleRepo.AssertExpectations(t)
+}
+
+func TestAddClosure_InvalidDate_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+
+	handler := commands.NewAddClosureHandler(scheduleRepo, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.AddClosureCommand{
+		BranchID: uuid.New(),
+		Date:     "25/12/2026",
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidDate)
+}
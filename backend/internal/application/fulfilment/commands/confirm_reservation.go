@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// ConfirmReservationCommand is the input for the confirm reservation use case.
+type ConfirmReservationCommand struct {
+	ReservationID uuid.UUID
+	OrderID       uuid.UUID
+}
+
+// ConfirmReservationHandler turns a checkout hold into a booking once the order is placed.
+type ConfirmReservationHandler struct {
+	reservationRepo fulfilment.ReservationRepository
+}
+
+// NewConfirmReservationHandler creates a new ConfirmReservationHandler.
+func NewConfirmReservationHandler(reservationRepo fulfilment.ReservationRepository) *ConfirmReservationHandler {
+	return &ConfirmReservationHandler{reservationRepo: reservationRepo}
+}
+
+// Handle executes the confirm reservation use case.
+func (h *ConfirmReservationHandler) Handle(ctx context.Context, cmd ConfirmReservationCommand) error {
+	reservation, err := h.reservationRepo.FindByID(ctx, cmd.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	if err := reservation.Confirm(cmd.OrderID, time.Now()); err != nil {
+		return err
+	}
+
+	if err := h.reservationRepo.Update(ctx, reservation); err != nil {
+		return fmt.Errorf("updating reservation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newReservation(customerID uuid.UUID, status fulfilment.ReservationStatus, expiresAt time.Time) *fulfilment.Reservation {
+	start := nextWeek()
+	return fulfilment.ReconstructReservation(uuid.New(), uuid.New(), customerID,
+		start, start.Add(2*time.Hour), 1, status, nil, expiresAt, time.Now())
+}
+
+func TestConfirmReservation_LiveHold_ConfirmsAndSaves(t *testing.T) {
+	reservationRepo := new(mockReservationRepository)
+	r := newReservation(uuid.New(), fulfilment.ReservationHeld, time.Now().Add(10*time.Minute))
+	orderID := uuid.New()
+
+	reservationRepo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	reservationRepo.On("Update", mock.Anything, r).Return(nil)
+
+	handler := commands.NewConfirmReservationHandler(reservationRepo)
+	err := handler.Handle(context.Background(), commands.ConfirmReservationCommand{ReservationID: r.ID(), OrderID: orderID})
+
+	require.NoError(t, err)
+	assert.Equal(t, fulfilment.ReservationConfirmed, r.Status())
+	assert.Equal(t, orderID, *r.OrderID())
+	reservationRepo.AssertExpectations(t)
+}
+
+func TestConfirmReservation_ExpiredHold_ReturnsError(t *testing.T) {
+	reservationRepo := new(mockReservationRepository)
+	r := newReservation(uuid.New(), fulfilment.ReservationHeld, time.Now().Add(-time.Minute))
+
+	reservationRepo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewConfirmReservationHandler(reservationRepo)
+	err := handler.Handle(context.Background(), commands.ConfirmReservationCommand{ReservationID: r.ID(), OrderID: uuid.New()})
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationExpired)
+	reservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// CreateSlotTemplateCommand is the input for the create slot template use case.
+// Capacity is a number of orders, or grams when CapacityUnit is "kg".
+type CreateSlotTemplateCommand struct {
+	BranchID     uuid.UUID
+	ZoneID       *uuid.UUID
+	Method       string
+	Weekday      int
+	StartsAt     string
+	EndsAt       string
+	CapacityUnit string
+	Capacity     int64
+}
+
+// CreateSlotTemplateHandler creates recurring weekly fulfilment slots.
+type CreateSlotTemplateHandler struct {
+	scheduleRepo fulfilment.ScheduleRepository
+	zoneRepo     delivery.ZoneRepository
+}
+
+// NewCreateSlotTemplateHandler creates a new CreateSlotTemplateHandler.
+func NewCreateSlotTemplateHandler(scheduleRepo fulfilment.ScheduleRepository, zoneRepo delivery.ZoneRepository) *CreateSlotTemplateHandler {
+	return &CreateSlotTemplateHandler{scheduleRepo: scheduleRepo, zoneRepo: zoneRepo}
+}
+
+// Handle executes the create slot template use case and returns the new template's ID.
+func (h *CreateSlotTemplateHandler) Handle(ctx context.Context, cmd CreateSlotTemplateCommand) (uuid.UUID, error) {
+	method, err := fulfilment.NewMethod(cmd.Method)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	startsAt, err := fulfilment.ParseTimeOfDay(cmd.StartsAt)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	endsAt, err := fulfilment.ParseTimeOfDay(cmd.EndsAt)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	capacity, err := fulfilment.NewCapacity(fulfilment.CapacityUnit(cmd.CapacityUnit), cmd.Capacity)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), cmd.BranchID, cmd.ZoneID, method,
+		time.Weekday(cmd.Weekday), startsAt, endsAt, capacity)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if zoneID := tmpl.ZoneID(); zoneID != nil {
+		if _, err := h.zoneRepo.FindByID(ctx, *zoneID); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if err := h.scheduleRepo.SaveSlotTemplate(ctx, tmpl); err != nil {
+		return uuid.Nil, fmt.Errorf("saving slot template: %w", err)
+	}
+	return tmpl.ID(), nil
+}
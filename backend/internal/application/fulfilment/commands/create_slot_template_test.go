@@ -0,0 +1,113 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockZoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	args := m.Called(ctx, z)
+	return args.Error(0)
+}
+
+func (m *mockZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*delivery.Zone), args.Error(1)
+}
+
+func (m *mockZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*delivery.Zone), args.Error(1)
+}
+
+func TestCreateSlotTemplate_ValidInputs_SavesTemplate(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	zoneRepo := new(mockZoneRepository)
+	zone, err := delivery.NewZone(uuid.New(), "East", nil, []string{"E1"}, 499, 2000)
+	require.NoError(t, err)
+	zoneID := zone.ID()
+
+	zoneRepo.On("FindByID", mock.Anything, zoneID).Return(zone, nil)
+	scheduleRepo.On("SaveSlotTemplate", mock.Anything, mock.AnythingOfType("*fulfilment.SlotTemplate")).Return(nil)
+
+	handler := commands.NewCreateSlotTemplateHandler(scheduleRepo, zoneRepo)
+	id, err := handler.Handle(context.Background(), commands.CreateSlotTemplateCommand{
+		BranchID:     uuid.New(),
+		ZoneID:       &zoneID,
+		Method:       "delivery",
+		Weekday:      5,
+		StartsAt:     "10:00",
+		EndsAt:       "12:00",
+		CapacityUnit: "kg",
+		Capacity:     60000,
+	})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, id)
+	scheduleRepo.AssertExpectations(t)
+	zoneRepo.AssertExpectations(t)
+}
+
+func TestCreateSlotTemplate_UnknownZone_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	zoneRepo := new(mockZoneRepository)
+	zoneID := uuid.New()
+
+	zoneRepo.On("FindByID", mock.Anything, zoneID).Return(nil, delivery.ErrZoneNotFound)
+
+	handler := commands.NewCreateSlotTemplateHandler(scheduleRepo, zoneRepo)
+	_, err := handler.Handle(context.Background(), commands.CreateSlotTemplateCommand{
+		BranchID:     uuid.New(),
+		ZoneID:       &zoneID,
+		Method:       "delivery",
+		Weekday:      5,
+		StartsAt:     "10:00",
+		EndsAt:       "12:00",
+		CapacityUnit: "orders",
+		Capacity:     10,
+	})
+
+	assert.ErrorIs(t, err, delivery.ErrZoneNotFound)
+	scheduleRepo.AssertNotCalled(t, "SaveSlotTemplate", mock.Anything, mock.Anything)
+}
+
+func TestCreateSlotTemplate_InvalidInputs_ReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     commands.CreateSlotTemplateCommand
+		wantErr error
+	}{
+		{"unknown method", commands.CreateSlotTemplateCommand{Method: "drone", StartsAt: "10:00", EndsAt: "12:00", CapacityUnit: "orders", Capacity: 5}, fulfilment.ErrInvalidMethod},
+		{"bad start time", commands.CreateSlotTemplateCommand{Method: "collection", StartsAt: "ten", EndsAt: "12:00", CapacityUnit: "orders", Capacity: 5}, fulfilment.ErrInvalidTimeOfDay},
+		{"unknown unit", commands.CreateSlotTemplateCommand{Method: "collection", StartsAt: "10:00", EndsAt: "12:00", CapacityUnit: "boxes", Capacity: 5}, fulfilment.ErrInvalidCapacityUnit},
+		{"zero capacity", commands.CreateSlotTemplateCommand{Method: "collection", StartsAt: "10:00", EndsAt: "12:00", CapacityUnit: "orders"}, fulfilment.ErrInvalidCapacity},
+		{"delivery without zone", commands.CreateSlotTemplateCommand{Method: "delivery", StartsAt: "10:00", EndsAt: "12:00", CapacityUnit: "orders", Capacity: 5}, fulfilment.ErrMissingZone},
+		{"weekday out of range", commands.CreateSlotTemplateCommand{Method: "collection", Weekday: 9, StartsAt: "10:00", EndsAt: "12:00", CapacityUnit: "orders", Capacity: 5}, fulfilment.ErrInvalidWeekday},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := commands.NewCreateSlotTemplateHandler(new(mockScheduleRepository), new(mockZoneRepository))
+			_, err := handler.Handle(context.Background(), tt.cmd)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
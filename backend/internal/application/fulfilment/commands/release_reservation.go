@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// ReleaseReservationCommand is the input for the release reservation use case.
+type ReleaseReservationCommand struct {
+	ReservationID uuid.UUID
+	CustomerID    uuid.UUID
+}
+
+// ReleaseReservationHandler gives a customer's reserved slot capacity back.
+type ReleaseReservationHandler struct {
+	reservationRepo fulfilment.ReservationRepository
+}
+
+// NewReleaseReservationHandler creates a new ReleaseReservationHandler.
+func NewReleaseReservationHandler(reservationRepo fulfilment.ReservationRepository) *ReleaseReservationHandler {
+	return &ReleaseReservationHandler{reservationRepo: reservationRepo}
+}
+
+// Handle executes the release reservation use case. It is idempotent, but
+// a booking already confirmed for an order cannot be released.
+func (h *ReleaseReservationHandler) Handle(ctx context.Context, cmd ReleaseReservationCommand) error {
+	reservation, err := h.reservationRepo.FindByID(ctx, cmd.ReservationID)
+	if err != nil {
+		return err
+	}
+	if reservation.CustomerID() != cmd.CustomerID {
+		return fulfilment.ErrReservationNotOwned
+	}
+
+	if err := reservation.Release(); err != nil {
+		return err
+	}
+	if err := h.reservationRepo.Update(ctx, reservation); err != nil {
+		return fmt.Errorf("updating reservation: %w", err)
+	}
+	return nil
+}
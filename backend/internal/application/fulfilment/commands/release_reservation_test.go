@@ -0,0 +1,57 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseReservation_Owner_ReleasesHold(t *testing.T) {
+	reservationRepo := new(mockReservationRepository)
+	customerID := uuid.New()
+	r := newReservation(customerID, fulfilment.ReservationHeld, time.Now().Add(10*time.Minute))
+
+	reservationRepo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	reservationRepo.On("Update", mock.Anything, r).Return(nil)
+
+	handler := commands.NewReleaseReservationHandler(reservationRepo)
+	err := handler.Handle(context.Background(), commands.ReleaseReservationCommand{ReservationID: r.ID(), CustomerID: customerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, fulfilment.ReservationReleased, r.Status())
+}
+
+func TestReleaseReservation_OtherCustomer_ReturnsError(t *testing.T) {
+	reservationRepo := new(mockReservationRepository)
+	r := newReservation(uuid.New(), fulfilment.ReservationHeld, time.Now().Add(10*time.Minute))
+
+	reservationRepo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewReleaseReservationHandler(reservationRepo)
+	err := handler.Handle(context.Background(), commands.ReleaseReservationCommand{ReservationID: r.ID(), CustomerID: uuid.New()})
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationNotOwned)
+	reservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestReleaseReservation_Confirmed_ReturnsError(t *testing.T) {
+	reservationRepo := new(mockReservationRepository)
+	customerID := uuid.New()
+	r := newReservation(customerID, fulfilment.ReservationConfirmed, time.Now().Add(10*time.Minute))
+
+	reservationRepo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewReleaseReservationHandler(reservationRepo)
+	err := handler.Handle(context.Background(), commands.ReleaseReservationCommand{ReservationID: r.ID(), CustomerID: customerID})
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationNotHeld)
+	assert.Equal(t, fulfilment.ReservationConfirmed, r.Status())
+	reservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
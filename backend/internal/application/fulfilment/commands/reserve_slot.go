@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// ReserveSlotCommand is the input for the reserve slot use case.
+// WeightGrams is the estimated order weight and is required for kg-capacity slots.
+type ReserveSlotCommand struct {
+	CustomerID  uuid.UUID
+	TemplateID  uuid.UUID
+	Date        string // YYYY-MM-DD
+	WeightGrams int64
+}
+
+// ReserveSlotResult is the output of the reserve slot use case.
+type ReserveSlotResult struct {
+	ReservationID uuid.UUID
+	StartsAt      time.Time
+	EndsAt        time.Time
+	ExpiresAt     time.Time
+}
+
+// ReserveSlotHandler places a time-limited hold on a fulfilment slot during checkout.
+type ReserveSlotHandler struct {
+	scheduleRepo    fulfilment.ScheduleRepository
+	reservationRepo fulfilment.ReservationRepository
+	holdTTL         time.Duration
+	leadTime        time.Duration
+	location        *time.Location
+}
+
+// NewReserveSlotHandler creates a new ReserveSlotHandler.
+func NewReserveSlotHandler(
+	scheduleRepo fulfilment.ScheduleRepository,
+	reservationRepo fulfilment.ReservationRepository,
+	holdTTL time.Duration,
+	leadTime time.Duration,
+	location *time.Location,
+) *ReserveSlotHandler {
+	return &ReserveSlotHandler{
+		scheduleRepo:    scheduleRepo,
+		reservationRepo: reservationRepo,
+		holdTTL:         holdTTL,
+		leadTime:        leadTime,
+		location:        location,
+	}
+}
+
+// Handle executes the reserve slot use case.
+func (h *ReserveSlotHandler) Handle(ctx context.Context, cmd ReserveSlotCommand) (*ReserveSlotResult, error) {
+	date, err := fulfilment.ParseDate(cmd.Date, h.location)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := h.scheduleRepo.FindSlotTemplateByID(ctx, cmd.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := loadSchedule(ctx, h.scheduleRepo, tmpl.BranchID(), date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	if !schedule.Runs(tmpl, date) {
+		return nil, fulfilment.ErrSlotUnavailable
+	}
+
+	now := time.Now()
+	slot := tmpl.SlotOn(date, h.location)
+	if slot.StartsAt().Before(now.Add(h.leadTime)) {
+		return nil, fulfilment.ErrSlotUnavailable
+	}
+
+	reservation, err := fulfilment.NewReservation(uuid.New(), cmd.CustomerID, slot, cmd.WeightGrams, h.holdTTL, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.reservationRepo.Reserve(ctx, slot, reservation); err != nil {
+		return nil, err
+	}
+
+	return &ReserveSlotResult{
+		ReservationID: reservation.ID(),
+		StartsAt:      slot.StartsAt(),
+		EndsAt:        slot.EndsAt(),
+		ExpiresAt:     reservation.ExpiresAt(),
+	}, nil
+}
+
+func loadSchedule(ctx context.Context, repo fulfilment.ScheduleRepository, branchID uuid.UUID, from, to time.Time) (*fulfilment.Schedule, error) {
+	hours, err := repo.FindOpeningHours(ctx, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("loading opening hours: %w", err)
+	}
+	closures, err := repo.FindClosures(ctx, branchID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("loading closures: %w", err)
+	}
+	return fulfilment.NewSchedule(branchID, hours, closures), nil
+}
@@ -0,0 +1,304 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	args := m.Called(ctx, branchID, hours)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.OpeningHours), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveClosure(ctx context.Context, closure *fulfilment.Closure) error {
+	args := m.Called(ctx, closure)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	args := m.Called(ctx, branchID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Closure), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSlotTemplate(ctx context.Context, tmpl *fulfilment.SlotTemplate) error {
+	args := m.Called(ctx, tmpl)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.SlotTemplate), args.Error(1)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.SlotTemplate), args.Error(1)
+}
+
+type mockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, r *fulfilment.Reservation) error {
+	args := m.Called(ctx, slot, r)
+	return args.Error(0)
+}
+
+func (m *mockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) Update(ctx context.Context, r *fulfilment.Reservation) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	args := m.Called(ctx, templateIDs, from, to, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[fulfilment.SlotKey]int64), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, templateIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func mustTime(t *testing.T, raw string) fulfilment.TimeOfDay {
+	t.Helper()
+	tod, err := fulfilment.ParseTimeOfDay(raw)
+	require.NoError(t, err)
+	return tod
+}
+
+// nextWeek returns a date a week from now, far enough ahead to clear any lead time.
+func nextWeek() time.Time {
+	return time.Now().UTC().AddDate(0, 0, 7)
+}
+
+func newTemplate(t *testing.T, branchID uuid.UUID, weekday time.Weekday, unit fulfilment.CapacityUnit) *fulfilment.SlotTemplate {
+	t.Helper()
+	capacity, err := fulfilment.NewCapacity(unit, 10)
+	require.NoError(t, err)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), branchID, nil, fulfilment.MethodCollection,
+		weekday, mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+	require.NoError(t, err)
+	return tmpl
+}
+
+func newHours(t *testing.T, branchID uuid.UUID, weekday time.Weekday) []*fulfilment.OpeningHours {
+	t.Helper()
+	h, err := fulfilment.NewOpeningHours(branchID, weekday, mustTime(t, "08:00"), mustTime(t, "18:00"))
+	require.NoError(t, err)
+	return []*fulfilment.OpeningHours{h}
+}
+
+// --- ReserveSlot Tests ---
+
+func TestReserveSlot_OpenSlot_PlacesHold(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID, customerID := uuid.New(), uuid.New()
+	date := nextWeek()
+	tmpl := newTemplate(t, branchID, date.Weekday(), fulfilment.CapacityUnitOrders)
+
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(newHours(t, branchID, date.Weekday()), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+	reservationRepo.On("Reserve", mock.Anything, mock.AnythingOfType("fulfilment.Slot"), mock.AnythingOfType("*fulfilment.Reservation")).Return(nil)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 2*time.Hour, time.UTC)
+	result, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: customerID,
+		TemplateID: tmpl.ID(),
+		Date:       date.Format(time.DateOnly),
+	})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, result.ReservationID)
+	assert.Equal(t, 10, result.StartsAt.Hour())
+	assert.Equal(t, 12, result.EndsAt.Hour())
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), result.ExpiresAt, 5*time.Second)
+	reservationRepo.AssertExpectations(t)
+}
+
+func TestReserveSlot_SlotFull_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID := uuid.New()
+	date := nextWeek()
+	tmpl := newTemplate(t, branchID, date.Weekday(), fulfilment.CapacityUnitOrders)
+
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(newHours(t, branchID, date.Weekday()), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+	reservationRepo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(fulfilment.ErrSlotFull)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 2*time.Hour, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: uuid.New(),
+		TemplateID: tmpl.ID(),
+		Date:       date.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrSlotFull)
+}
+
+func TestReserveSlot_WrongWeekday_ReturnsUnavailable(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID := uuid.New()
+	date := nextWeek()
+	tmpl := newTemplate(t, branchID, (date.Weekday()+1)%7, fulfilment.CapacityUnitOrders)
+
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(newHours(t, branchID, date.Weekday()), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 2*time.Hour, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: uuid.New(),
+		TemplateID: tmpl.ID(),
+		Date:       date.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrSlotUnavailable)
+	reservationRepo.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReserveSlot_ClosedDate_ReturnsUnavailable(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID := uuid.New()
+	date := nextWeek()
+	tmpl := newTemplate(t, branchID, date.Weekday(), fulfilment.CapacityUnitOrders)
+	closure := fulfilment.NewClosure(uuid.New(), branchID, date, "Stock take")
+
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(newHours(t, branchID, date.Weekday()), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{closure}, nil)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 2*time.Hour, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: uuid.New(),
+		TemplateID: tmpl.ID(),
+		Date:       date.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrSlotUnavailable)
+}
+
+func TestReserveSlot_WithinLeadTime_ReturnsUnavailable(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID := uuid.New()
+	date := nextWeek()
+	tmpl := newTemplate(t, branchID, date.Weekday(), fulfilment.CapacityUnitOrders)
+
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(newHours(t, branchID, date.Weekday()), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 14*24*time.Hour, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: uuid.New(),
+		TemplateID: tmpl.ID(),
+		Date:       date.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrSlotUnavailable)
+}
+
+func TestReserveSlot_KgSlotWithoutWeight_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID := uuid.New()
+	date := nextWeek()
+	tmpl := newTemplate(t, branchID, date.Weekday(), fulfilment.CapacityUnitKg)
+
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(newHours(t, branchID, date.Weekday()), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 2*time.Hour, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: uuid.New(),
+		TemplateID: tmpl.ID(),
+		Date:       date.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidWeight)
+}
+
+func TestReserveSlot_UnknownTemplate_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	templateID := uuid.New()
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, templateID).Return(nil, fulfilment.ErrSlotTemplateNotFound)
+
+	handler := commands.NewReserveSlotHandler(scheduleRepo, reservationRepo, 15*time.Minute, 2*time.Hour, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ReserveSlotCommand{
+		CustomerID: uuid.New(),
+		TemplateID: templateID,
+		Date:       nextWeek().Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrSlotTemplateNotFound)
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// OpeningHoursInput is the trading window for a single weekday.
+type OpeningHoursInput struct {
+	Weekday  int
+	OpensAt  string
+	ClosesAt string
+}
+
+// SetOpeningHoursCommand is the input for the set opening hours use case.
+// Weekdays missing from Days are treated as closed.
+type SetOpeningHoursCommand struct {
+	BranchID uuid.UUID
+	Days     []OpeningHoursInput
+}
+
+// SetOpeningHoursHandler replaces a branch's weekly opening hours.
+type SetOpeningHoursHandler struct {
+	scheduleRepo fulfilment.ScheduleRepository
+}
+
+// NewSetOpeningHoursHandler creates a new SetOpeningHoursHandler.
+func NewSetOpeningHoursHandler(scheduleRepo fulfilment.ScheduleRepository) *SetOpeningHoursHandler {
+	return &SetOpeningHoursHandler{scheduleRepo: scheduleRepo}
+}
+
+// Handle executes the set opening hours use case.
+func (h *SetOpeningHoursHandler) Handle(ctx context.Context, cmd SetOpeningHoursCommand) error {
+	seen := make(map[int]bool, len(cmd.Days))
+	hours := make([]*fulfilment.OpeningHours, 0, len(cmd.Days))
+
+	for _, day := range cmd.Days {
+		if seen[day.Weekday] {
+			return fulfilment.ErrDuplicateWeekday
+		}
+		seen[day.Weekday] = true
+
+		opensAt, err := fulfilment.ParseTimeOfDay(day.OpensAt)
+		if err != nil {
+			return err
+		}
+		closesAt, err := fulfilment.ParseTimeOfDay(day.ClosesAt)
+		if err != nil {
+			return err
+		}
+
+		oh, err := fulfilment.NewOpeningHours(cmd.BranchID, time.Weekday(day.Weekday), opensAt, closesAt)
+		if err != nil {
+			return err
+		}
+		hours = append(hours, oh)
+	}
+
+	if err := h.scheduleRepo.ReplaceOpeningHours(ctx, cmd.BranchID, hours); err != nil {
+		return fmt.Errorf("saving opening hours: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOpeningHours_ValidDays_ReplacesHours(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	branchID := uuid.New()
+
+	scheduleRepo.On("ReplaceOpeningHours", mock.Anything, branchID, mock.MatchedBy(func(h []*fulfilment.OpeningHours) bool {
+		return len(h) == 2
+	})).Return(nil)
+
+	handler := commands.NewSetOpeningHoursHandler(scheduleRepo)
+	err := handler.Handle(context.Background(), commands.SetOpeningHoursCommand{
+		BranchID: branchID,
+		Days: []commands.OpeningHoursInput{
+			{Weekday: 1, OpensAt: "08:00", ClosesAt: "18:00"},
+			{Weekday: 6, OpensAt: "09:00", ClosesAt: "14:00"},
+		},
+	})
+
+	require.NoError(t, err)
+	scheduleRepo.AssertExpectations(t)
+}
+
+func TestSetOpeningHours_DuplicateWeekday_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+
+	handler := commands.NewSetOpeningHoursHandler(scheduleRepo)
+	err := handler.Handle(context.Background(), commands.SetOpeningHoursCommand{
+		BranchID: uuid.New(),
+		Days: []commands.OpeningHoursInput{
+			{Weekday: 1, OpensAt: "08:00", ClosesAt: "18:00"},
+			{Weekday: 1, OpensAt: "09:00", ClosesAt: "14:00"},
+		},
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrDuplicateWeekday)
+}
+
+func TestSetOpeningHours_InvalidTime_ReturnsError(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+
+	handler := commands.NewSetOpeningHoursHandler(scheduleRepo)
+	err := handler.Handle(context.Background(), commands.SetOpeningHoursCommand{
+		BranchID: uuid.New(),
+		Days:     []commands.OpeningHoursInput{{Weekday: 1, OpensAt: "8am", ClosesAt: "18:00"}},
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidTimeOfDay)
+}
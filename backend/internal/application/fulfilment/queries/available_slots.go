@@ -0,0 +1,162 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+const (
+	defaultSlotDays = 7
+	maxSlotDays     = 28
+)
+
+// ErrMissingSlotFilter is returned when neither a zone nor a branch is given.
+var ErrMissingSlotFilter = errors.New("a delivery zone or pickup branch is required")
+
+// ListAvailableSlotsQuery is the input for the list available slots use case.
+// Delivery slots are looked up by ZoneID and collection slots by BranchID.
+type ListAvailableSlotsQuery struct {
+	Method   string
+	ZoneID   *uuid.UUID
+	BranchID *uuid.UUID
+	From     string // YYYY-MM-DD, defaults to today
+	Days     int
+}
+
+// AvailableSlot is a bookable slot with its remaining capacity.
+type AvailableSlot struct {
+	TemplateID   uuid.UUID
+	BranchID     uuid.UUID
+	ZoneID       *uuid.UUID
+	Method       string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	CapacityUnit string
+	Capacity     int64
+	Remaining    int64
+}
+
+// ListAvailableSlotsHandler lists upcoming slots that still have capacity.
+type ListAvailableSlotsHandler struct {
+	scheduleRepo    fulfilment.ScheduleRepository
+	reservationRepo fulfilment.ReservationRepository
+	leadTime        time.Duration
+	location        *time.Location
+}
+
+// NewListAvailableSlotsHandler creates a new ListAvailableSlotsHandler.
+func NewListAvailableSlotsHandler(
+	scheduleRepo fulfilment.ScheduleRepository,
+	reservationRepo fulfilment.ReservationRepository,
+	leadTime time.Duration,
+	location *time.Location,
+) *ListAvailableSlotsHandler {
+	return &ListAvailableSlotsHandler{
+		scheduleRepo:    scheduleRepo,
+		reservationRepo: reservationRepo,
+		leadTime:        leadTime,
+		location:        location,
+	}
+}
+
+// Handle executes the list available slots use case.
+func (h *ListAvailableSlotsHandler) Handle(ctx context.Context, q ListAvailableSlotsQuery) ([]AvailableSlot, error) {
+	method, err := fulfilment.NewMethod(q.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := fulfilment.SlotTemplateFilter{Method: &method, ActiveOnly: true}
+	switch {
+	case method == fulfilment.MethodDelivery && q.ZoneID != nil:
+		filter.ZoneID = q.ZoneID
+	case q.BranchID != nil:
+		filter.BranchID = q.BranchID
+	default:
+		return nil, ErrMissingSlotFilter
+	}
+
+	now := time.Now()
+	from := now.In(h.location)
+	if q.From != "" {
+		if from, err = fulfilment.ParseDate(q.From, h.location); err != nil {
+			return nil, err
+		}
+	}
+	days := q.Days
+	if days <= 0 {
+		days = defaultSlotDays
+	}
+	if days > maxSlotDays {
+		days = maxSlotDays
+	}
+	to := from.AddDate(0, 0, days)
+
+	templates, err := h.scheduleRepo.FindSlotTemplates(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("loading slot templates: %w", err)
+	}
+	if len(templates) == 0 {
+		return []AvailableSlot{}, nil
+	}
+
+	byBranch := make(map[uuid.UUID][]*fulfilment.SlotTemplate)
+	templateIDs := make([]uuid.UUID, 0, len(templates))
+	for _, t := range templates {
+		byBranch[t.BranchID()] = append(byBranch[t.BranchID()], t)
+		templateIDs = append(templateIDs, t.ID())
+	}
+
+	var slots []fulfilment.Slot
+	for branchID, branchTemplates := range byBranch {
+		hours, err := h.scheduleRepo.FindOpeningHours(ctx, branchID)
+		if err != nil {
+			return nil, fmt.Errorf("loading opening hours: %w", err)
+		}
+		closures, err := h.scheduleRepo.FindClosures(ctx, branchID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("loading closures: %w", err)
+		}
+		schedule := fulfilment.NewSchedule(branchID, hours, closures)
+		slots = append(slots, schedule.Slots(branchTemplates, from, days, h.location)...)
+	}
+
+	usage, err := h.reservationRepo.UsageBySlot(ctx, templateIDs, from, to, now)
+	if err != nil {
+		return nil, fmt.Errorf("loading slot usage: %w", err)
+	}
+
+	cutoff := now.Add(h.leadTime)
+	result := make([]AvailableSlot, 0, len(slots))
+	for _, s := range slots {
+		if s.StartsAt().Before(cutoff) {
+			continue
+		}
+		availability := fulfilment.Availability{Slot: s, Used: usage[s.Key()]}
+		if availability.Remaining() == 0 {
+			continue
+		}
+		result = append(result, AvailableSlot{
+			TemplateID:   s.TemplateID(),
+			BranchID:     s.BranchID(),
+			ZoneID:       s.ZoneID(),
+			Method:       s.Method().String(),
+			StartsAt:     s.StartsAt(),
+			EndsAt:       s.EndsAt(),
+			CapacityUnit: string(s.Capacity().Unit()),
+			Capacity:     s.Capacity().Amount(),
+			Remaining:    availability.Remaining(),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartsAt.Before(result[j].StartsAt)
+	})
+	return result, nil
+}
@@ -0,0 +1,216 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/fulfilment/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	args := m.Called(ctx, branchID, hours)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.OpeningHours), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveClosure(ctx context.Context, closure *fulfilment.Closure) error {
+	args := m.Called(ctx, closure)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	args := m.Called(ctx, branchID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Closure), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSlotTemplate(ctx context.Context, tmpl *fulfilment.SlotTemplate) error {
+	args := m.Called(ctx, tmpl)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.SlotTemplate), args.Error(1)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.SlotTemplate), args.Error(1)
+}
+
+type mockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, r *fulfilment.Reservation) error {
+	args := m.Called(ctx, slot, r)
+	return args.Error(0)
+}
+
+func (m *mockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) Update(ctx context.Context, r *fulfilment.Reservation) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	args := m.Called(ctx, templateIDs, from, to, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[fulfilment.SlotKey]int64), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, templateIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func mustTime(t *testing.T, raw string) fulfilment.TimeOfDay {
+	t.Helper()
+	tod, err := fulfilment.ParseTimeOfDay(raw)
+	require.NoError(t, err)
+	return tod
+}
+
+func allWeekHours(t *testing.T, branchID uuid.UUID) []*fulfilment.OpeningHours {
+	t.Helper()
+	hours := make([]*fulfilment.OpeningHours, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		h, err := fulfilment.NewOpeningHours(branchID, d, mustTime(t, "08:00"), mustTime(t, "18:00"))
+		require.NoError(t, err)
+		hours = append(hours, h)
+	}
+	return hours
+}
+
+// --- ListAvailableSlots Tests ---
+
+func TestListAvailableSlots_ByZone_ReturnsSlotsWithRemainingCapacity(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID, zoneID := uuid.New(), uuid.New()
+	from := time.Now().UTC().AddDate(0, 0, 3)
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 4)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), branchID, &zoneID, fulfilment.MethodDelivery,
+		from.Weekday(), mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+	require.NoError(t, err)
+
+	slot := tmpl.SlotOn(from, time.UTC)
+	scheduleRepo.On("FindSlotTemplates", mock.Anything, mock.MatchedBy(func(f fulfilment.SlotTemplateFilter) bool {
+		return f.ZoneID != nil && *f.ZoneID == zoneID && f.BranchID == nil && f.ActiveOnly
+	})).Return([]*fulfilment.SlotTemplate{tmpl}, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(allWeekHours(t, branchID), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+	reservationRepo.On("UsageBySlot", mock.Anything, []uuid.UUID{tmpl.ID()}, mock.Anything, mock.Anything, mock.Anything).
+		Return(map[fulfilment.SlotKey]int64{slot.Key(): 3}, nil)
+
+	handler := queries.NewListAvailableSlotsHandler(scheduleRepo, reservationRepo, 2*time.Hour, time.UTC)
+	slots, err := handler.Handle(context.Background(), queries.ListAvailableSlotsQuery{
+		Method: "delivery",
+		ZoneID: &zoneID,
+		From:   from.Format(time.DateOnly),
+		Days:   7,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	assert.Equal(t, tmpl.ID(), slots[0].TemplateID)
+	assert.Equal(t, "delivery", slots[0].Method)
+	assert.Equal(t, int64(4), slots[0].Capacity)
+	assert.Equal(t, int64(1), slots[0].Remaining)
+}
+
+func TestListAvailableSlots_FullSlot_IsOmitted(t *testing.T) {
+	scheduleRepo := new(mockScheduleRepository)
+	reservationRepo := new(mockReservationRepository)
+
+	branchID := uuid.New()
+	from := time.Now().UTC().AddDate(0, 0, 3)
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 2)
+	tmpl, _ := fulfilment.NewSlotTemplate(uuid.New(), branchID, nil, fulfilment.MethodCollection,
+		from.Weekday(), mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+
+	slot := tmpl.SlotOn(from, time.UTC)
+	scheduleRepo.On("FindSlotTemplates", mock.Anything, mock.Anything).Return([]*fulfilment.SlotTemplate{tmpl}, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return(allWeekHours(t, branchID), nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, mock.Anything, mock.Anything).Return([]*fulfilment.Closure{}, nil)
+	reservationRepo.On("UsageBySlot", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(map[fulfilment.SlotKey]int64{slot.Key(): 2}, nil)
+
+	handler := queries.NewListAvailableSlotsHandler(scheduleRepo, reservationRepo, 2*time.Hour, time.UTC)
+	slots, err := handler.Handle(context.Background(), queries.ListAvailableSlotsQuery{
+		Method:   "collection",
+		BranchID: &branchID,
+		From:     from.Format(time.DateOnly),
+		Days:     1,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, slots)
+}
+
+func TestListAvailableSlots_NoFilter_ReturnsError(t *testing.T) {
+	handler := queries.NewListAvailableSlotsHandler(new(mockScheduleRepository), new(mockReservationRepository), 2*time.Hour, time.UTC)
+
+	_, err := handler.Handle(context.Background(), queries.ListAvailableSlotsQuery{Method: "delivery"})
+
+	assert.ErrorIs(t, err, queries.ErrMissingSlotFilter)
+}
+
+func TestListAvailableSlots_InvalidMethod_ReturnsError(t *testing.T) {
+	handler := queries.NewListAvailableSlotsHandler(new(mockScheduleRepository), new(mockReservationRepository), 2*time.Hour, time.UTC)
+
+	_, err := handler.Handle(context.Background(), queries.ListAvailableSlotsQuery{Method: "post"})
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidMethod)
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// CreateUnitCommand is the input for the create unit use case. SensorID is
+// left empty for units read by hand.
+type CreateUnitCommand struct {
+	BranchID   uuid.UUID
+	Name       string
+	Kind       haccp.Kind
+	MinCelsius float64
+	MaxCelsius float64
+	SensorID   string
+	CheckEvery time.Duration
+}
+
+// CreateUnitHandler adds a chiller, freezer or display counter to a
+// branch's temperature records.
+type CreateUnitHandler struct {
+	unitRepo   haccp.UnitRepository
+	branchRepo branch.Repository
+}
+
+// NewCreateUnitHandler creates a new CreateUnitHandler.
+func NewCreateUnitHandler(unitRepo haccp.UnitRepository, branchRepo branch.Repository) *CreateUnitHandler {
+	return &CreateUnitHandler{unitRepo: unitRepo, branchRepo: branchRepo}
+}
+
+// Handle executes the create unit use case.
+func (h *CreateUnitHandler) Handle(ctx context.Context, cmd CreateUnitCommand) (*haccp.Unit, error) {
+	u, err := haccp.NewUnit(uuid.New(), cmd.BranchID, cmd.Name, cmd.Kind, cmd.MinCelsius, cmd.MaxCelsius,
+		cmd.SensorID, cmd.CheckEvery, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+	if err := h.unitRepo.Save(ctx, u); err != nil {
+		if errors.Is(err, haccp.ErrDuplicateUnit) || errors.Is(err, haccp.ErrDuplicateSensor) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving unit: %w", err)
+	}
+	return u, nil
+}
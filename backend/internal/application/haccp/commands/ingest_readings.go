@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// SensorReading is a temperature a sensor pushed. TakenAt defaults to when
+// it was received.
+type SensorReading struct {
+	SensorID string
+	Celsius  float64
+	TakenAt  *time.Time
+}
+
+// IngestReadingsCommand is the input for the ingest readings use case. Key
+// is the shared key sensors authenticate with.
+type IngestReadingsCommand struct {
+	Key      string
+	Readings []SensorReading
+}
+
+// IngestReadingsResult counts what happened to a batch of readings.
+// Rejected readings came from sensors no unit in service has, or could not
+// be taken, such as implausible temperatures.
+type IngestReadingsResult struct {
+	Accepted int
+	Rejected int
+	Alerts   int
+}
+
+// IngestReadingsHandler takes the readings temperature sensors push.
+type IngestReadingsHandler struct {
+	unitRepo  haccp.UnitRepository
+	logRepo   haccp.LogRepository
+	notifier  haccp.Notifier
+	sensorKey string
+}
+
+// NewIngestReadingsHandler creates a new IngestReadingsHandler. Sensors
+// must send sensorKey; if it is empty, no sensor is accepted.
+func NewIngestReadingsHandler(unitRepo haccp.UnitRepository, logRepo haccp.LogRepository, notifier haccp.Notifier, sensorKey string) *IngestReadingsHandler {
+	return &IngestReadingsHandler{unitRepo: unitRepo, logRepo: logRepo, notifier: notifier, sensorKey: sensorKey}
+}
+
+// Handle executes the ingest readings use case. A gateway pushing for
+// several sensors does not lose the whole batch to one it should not
+// have sent: readings that cannot be taken are counted and skipped.
+func (h *IngestReadingsHandler) Handle(ctx context.Context, cmd IngestReadingsCommand) (IngestReadingsResult, error) {
+	var result IngestReadingsResult
+	if h.sensorKey == "" || subtle.ConstantTimeCompare([]byte(cmd.Key), []byte(h.sensorKey)) != 1 {
+		return result, haccp.ErrInvalidSensorKey
+	}
+
+	now := time.Now()
+	units := make(map[string]*haccp.Unit)
+	for _, sr := range cmd.Readings {
+		sensorID := strings.TrimSpace(sr.SensorID)
+		u, ok := units[sensorID]
+		if !ok {
+			found, err := h.unitRepo.FindBySensor(ctx, sensorID)
+			if err != nil && !errors.Is(err, haccp.ErrUnitNotFound) {
+				return result, err
+			}
+			u, units[sensorID] = found, found
+		}
+		if u == nil {
+			result.Rejected++
+			continue
+		}
+
+		takenAt := now
+		if sr.TakenAt != nil {
+			takenAt = *sr.TakenAt
+		}
+		r, err := u.Record(uuid.New(), sr.Celsius, haccp.SourceSensor, nil, "", takenAt, now)
+		if err != nil {
+			result.Rejected++
+			continue
+		}
+		raised, err := record(ctx, h.logRepo, h.notifier, u, r, now)
+		if err != nil {
+			return result, err
+		}
+		result.Accepted++
+		if raised {
+			result.Alerts++
+		}
+	}
+	return result, nil
+}
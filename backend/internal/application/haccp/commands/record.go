@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// record stores a reading of u, raising an alarm and telling staff if it
+// is out of range and the unit has no out of range alarm open already. It
+// reports whether an alarm was raised.
+func record(ctx context.Context, logRepo haccp.LogRepository, notifier haccp.Notifier, u *haccp.Unit, r *haccp.Reading, now time.Time) (bool, error) {
+	var alert *haccp.Alert
+	if !r.InRange() {
+		alert = haccp.NewOutOfRangeAlert(uuid.New(), r, now)
+	}
+	raised, err := logRepo.SaveReading(ctx, r, alert)
+	if err != nil {
+		return false, fmt.Errorf("saving reading: %w", err)
+	}
+	if raised {
+		takenAt := r.TakenAt()
+		_ = notifier.AlertRaised(ctx, haccp.NewNotice(alert, u, &takenAt))
+	}
+	return raised, nil
+}
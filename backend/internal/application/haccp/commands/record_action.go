@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// RecordActionCommand is the input for the record corrective action use
+// case. AlertID is the alarm the action dealt with, if any.
+type RecordActionCommand struct {
+	UnitID  uuid.UUID
+	AlertID *uuid.UUID
+	Action  string
+	ActorID uuid.UUID
+}
+
+// RecordActionHandler records what staff did about a unit.
+type RecordActionHandler struct {
+	unitRepo haccp.UnitRepository
+	logRepo  haccp.LogRepository
+}
+
+// NewRecordActionHandler creates a new RecordActionHandler.
+func NewRecordActionHandler(unitRepo haccp.UnitRepository, logRepo haccp.LogRepository) *RecordActionHandler {
+	return &RecordActionHandler{unitRepo: unitRepo, logRepo: logRepo}
+}
+
+// Handle executes the record corrective action use case, resolving the
+// alarm it dealt with. The alarm must be on the unit and still open.
+func (h *RecordActionHandler) Handle(ctx context.Context, cmd RecordActionCommand) (*haccp.CorrectiveAction, error) {
+	u, err := h.unitRepo.FindByID(ctx, cmd.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	c, err := haccp.NewCorrectiveAction(uuid.New(), u.ID(), cmd.AlertID, cmd.Action, cmd.ActorID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var alert *haccp.Alert
+	if cmd.AlertID != nil {
+		alert, err = h.logRepo.FindAlertByID(ctx, *cmd.AlertID)
+		if err != nil {
+			return nil, err
+		}
+		if alert.UnitID() != u.ID() {
+			return nil, haccp.ErrAlertNotFound
+		}
+		if err := alert.Resolve(cmd.ActorID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.logRepo.SaveAction(ctx, c, alert); err != nil {
+		if errors.Is(err, haccp.ErrAlertResolved) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving corrective action: %w", err)
+	}
+	return c, nil
+}
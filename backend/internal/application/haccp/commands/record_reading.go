@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// RecordReadingCommand is the input for the record reading use case.
+// TakenAt defaults to now, for readings written down earlier and entered
+// later.
+type RecordReadingCommand struct {
+	UnitID  uuid.UUID
+	Celsius float64
+	TakenAt *time.Time
+	Note    string
+	ActorID uuid.UUID
+}
+
+// RecordReadingHandler records a temperature read by hand.
+type RecordReadingHandler struct {
+	unitRepo haccp.UnitRepository
+	logRepo  haccp.LogRepository
+	notifier haccp.Notifier
+}
+
+// NewRecordReadingHandler creates a new RecordReadingHandler.
+func NewRecordReadingHandler(unitRepo haccp.UnitRepository, logRepo haccp.LogRepository, notifier haccp.Notifier) *RecordReadingHandler {
+	return &RecordReadingHandler{unitRepo: unitRepo, logRepo: logRepo, notifier: notifier}
+}
+
+// Handle executes the record reading use case. A reading out of range
+// raises an alarm unless one is already open on the unit.
+func (h *RecordReadingHandler) Handle(ctx context.Context, cmd RecordReadingCommand) (*haccp.Reading, error) {
+	u, err := h.unitRepo.FindByID(ctx, cmd.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	takenAt := now
+	if cmd.TakenAt != nil {
+		takenAt = *cmd.TakenAt
+	}
+	actorID := cmd.ActorID
+	r, err := u.Record(uuid.New(), cmd.Celsius, haccp.SourceManual, &actorID, cmd.Note, takenAt, now)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := record(ctx, h.logRepo, h.notifier, u, r, now); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
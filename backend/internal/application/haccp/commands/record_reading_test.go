@@ -0,0 +1,265 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/haccp/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockUnitRepository struct {
+	mock.Mock
+}
+
+func (m *mockUnitRepository) Save(ctx context.Context, u *haccp.Unit) error {
+	return m.Called(ctx, u).Error(0)
+}
+
+func (m *mockUnitRepository) FindByID(ctx context.Context, id uuid.UUID) (*haccp.Unit, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*haccp.Unit), args.Error(1)
+}
+
+func (m *mockUnitRepository) FindBySensor(ctx context.Context, sensorID string) (*haccp.Unit, error) {
+	args := m.Called(ctx, sensorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*haccp.Unit), args.Error(1)
+}
+
+func (m *mockUnitRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*haccp.Unit, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*haccp.Unit), args.Error(1)
+}
+
+type mockLogRepository struct {
+	mock.Mock
+}
+
+func (m *mockLogRepository) SaveReading(ctx context.Context, r *haccp.Reading, alert *haccp.Alert) (bool, error) {
+	args := m.Called(ctx, r, alert)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockLogRepository) RaiseAlert(ctx context.Context, a *haccp.Alert) (bool, error) {
+	args := m.Called(ctx, a)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockLogRepository) FindAlertByID(ctx context.Context, id uuid.UUID) (*haccp.Alert, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*haccp.Alert), args.Error(1)
+}
+
+func (m *mockLogRepository) SaveAction(ctx context.Context, c *haccp.CorrectiveAction, resolved *haccp.Alert) error {
+	return m.Called(ctx, c, resolved).Error(0)
+}
+
+func (m *mockLogRepository) LastReadings(ctx context.Context) (map[uuid.UUID]time.Time, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]time.Time), args.Error(1)
+}
+
+func (m *mockLogRepository) FindReadings(ctx context.Context, f haccp.Filter) ([]*haccp.Reading, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*haccp.Reading), args.Error(1)
+}
+
+func (m *mockLogRepository) FindAlerts(ctx context.Context, f haccp.Filter) ([]*haccp.Alert, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*haccp.Alert), args.Error(1)
+}
+
+func (m *mockLogRepository) FindActions(ctx context.Context, f haccp.Filter) ([]*haccp.CorrectiveAction, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*haccp.CorrectiveAction), args.Error(1)
+}
+
+type mockNotifier struct {
+	mock.Mock
+}
+
+func (m *mockNotifier) AlertRaised(ctx context.Context, n haccp.Notice) error {
+	return m.Called(ctx, n).Error(0)
+}
+
+// --- Fixtures ---
+
+// newChiller returns a chiller kept between 0 and 5 C, checked every 12
+// hours, added at createdAt and known to repo.
+func newChiller(t *testing.T, repo *mockUnitRepository, sensorID string, createdAt time.Time) *haccp.Unit {
+	t.Helper()
+	u, err := haccp.NewUnit(uuid.New(), uuid.New(), "Walk-in chiller", haccp.KindChiller, 0, 5, sensorID, 12*time.Hour, createdAt)
+	require.NoError(t, err)
+	repo.On("FindByID", mock.Anything, u.ID()).Return(u, nil)
+	if sensorID != "" {
+		repo.On("FindBySensor", mock.Anything, sensorID).Return(u, nil)
+	}
+	return u
+}
+
+// --- Tests ---
+
+func TestRecordReading_OutOfRange_RaisesAlertAndNotifies(t *testing.T) {
+	units, logs, notifier := new(mockUnitRepository), new(mockLogRepository), new(mockNotifier)
+	u := newChiller(t, units, "", time.Now())
+	logs.On("SaveReading", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	notifier.On("AlertRaised", mock.Anything, mock.Anything).Return(nil)
+	actorID := uuid.New()
+
+	r, err := commands.NewRecordReadingHandler(units, logs, notifier).Handle(context.Background(), commands.RecordReadingCommand{
+		UnitID: u.ID(), Celsius: 8.4, Note: "Door left open", ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, r.InRange())
+	assert.Equal(t, &actorID, r.RecordedBy())
+	logs.AssertCalled(t, "SaveReading", mock.Anything, r, mock.MatchedBy(func(a *haccp.Alert) bool {
+		return a.Kind() == haccp.AlertOutOfRange && *a.ReadingID() == r.ID()
+	}))
+	notifier.AssertCalled(t, "AlertRaised", mock.Anything, mock.MatchedBy(func(n haccp.Notice) bool {
+		return n.UnitID == u.ID() && *n.Celsius == 8.4
+	}))
+}
+
+func TestRecordReading_AlertAlreadyOpen_DoesNotNotify(t *testing.T) {
+	units, logs, notifier := new(mockUnitRepository), new(mockLogRepository), new(mockNotifier)
+	u := newChiller(t, units, "", time.Now())
+	logs.On("SaveReading", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	_, err := commands.NewRecordReadingHandler(units, logs, notifier).Handle(context.Background(), commands.RecordReadingCommand{
+		UnitID: u.ID(), Celsius: 9, ActorID: uuid.New(),
+	})
+
+	require.NoError(t, err)
+	notifier.AssertNotCalled(t, "AlertRaised", mock.Anything, mock.Anything)
+}
+
+func TestIngestReadings_WrongKey_ReturnsError(t *testing.T) {
+	units, logs, notifier := new(mockUnitRepository), new(mockLogRepository), new(mockNotifier)
+
+	for _, key := range []string{"", "guess"} {
+		_, err := commands.NewIngestReadingsHandler(units, logs, notifier, "s3cret").Handle(context.Background(), commands.IngestReadingsCommand{
+			Key: key, Readings: []commands.SensorReading{{SensorID: "probe-1", Celsius: 3}},
+		})
+		assert.ErrorIs(t, err, haccp.ErrInvalidSensorKey)
+	}
+	_, err := commands.NewIngestReadingsHandler(units, logs, notifier, "").Handle(context.Background(), commands.IngestReadingsCommand{})
+	assert.ErrorIs(t, err, haccp.ErrInvalidSensorKey, "no sensor is accepted without a key configured")
+}
+
+func TestIngestReadings_SkipsReadingsThatCannotBeTaken(t *testing.T) {
+	units, logs, notifier := new(mockUnitRepository), new(mockLogRepository), new(mockNotifier)
+	u := newChiller(t, units, "probe-1", time.Now())
+	units.On("FindBySensor", mock.Anything, "probe-9").Return(nil, haccp.ErrUnitNotFound)
+	logs.On("SaveReading", mock.Anything, mock.Anything, (*haccp.Alert)(nil)).Return(false, nil)
+	logs.On("SaveReading", mock.Anything, mock.Anything, mock.AnythingOfType("*haccp.Alert")).Return(true, nil)
+	notifier.On("AlertRaised", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := commands.NewIngestReadingsHandler(units, logs, notifier, "s3cret").Handle(context.Background(), commands.IngestReadingsCommand{
+		Key: "s3cret",
+		Readings: []commands.SensorReading{
+			{SensorID: "probe-1", Celsius: 3.2},
+			{SensorID: "probe-1", Celsius: 6.1},
+			{SensorID: "probe-9", Celsius: 3},
+			{SensorID: "probe-1", Celsius: 900},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, commands.IngestReadingsResult{Accepted: 2, Rejected: 2, Alerts: 1}, result)
+	units.AssertNumberOfCalls(t, "FindBySensor", 2)
+	logs.AssertCalled(t, "SaveReading", mock.Anything, mock.MatchedBy(func(r *haccp.Reading) bool {
+		return r.UnitID() == u.ID() && r.Source() == haccp.SourceSensor && r.RecordedBy() == nil
+	}), (*haccp.Alert)(nil))
+}
+
+func TestRunChecks_RaisesAlertForOverdueUnits(t *testing.T) {
+	units, logs, notifier := new(mockUnitRepository), new(mockLogRepository), new(mockNotifier)
+	now := time.Now()
+	overdue := newChiller(t, units, "", now.Add(-48*time.Hour))
+	neverRead := newChiller(t, units, "", now.Add(-13*time.Hour))
+	readRecently := newChiller(t, units, "", now.Add(-48*time.Hour))
+	units.On("FindAll", mock.Anything, (*uuid.UUID)(nil)).Return([]*haccp.Unit{overdue, neverRead, readRecently}, nil)
+	logs.On("LastReadings", mock.Anything).Return(map[uuid.UUID]time.Time{
+		overdue.ID():      now.Add(-13 * time.Hour),
+		readRecently.ID(): now.Add(-time.Hour),
+	}, nil)
+	logs.On("RaiseAlert", mock.Anything, mock.MatchedBy(func(a *haccp.Alert) bool { return a.UnitID() == overdue.ID() })).Return(true, nil)
+	logs.On("RaiseAlert", mock.Anything, mock.MatchedBy(func(a *haccp.Alert) bool { return a.UnitID() == neverRead.ID() })).Return(false, nil)
+	notifier.On("AlertRaised", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := commands.NewRunChecksHandler(units, logs, notifier).Handle(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, commands.RunChecksResult{Checked: 3, Raised: 1}, result)
+	logs.AssertNumberOfCalls(t, "RaiseAlert", 2)
+	notifier.AssertNumberOfCalls(t, "AlertRaised", 1)
+	notifier.AssertCalled(t, "AlertRaised", mock.Anything, mock.MatchedBy(func(n haccp.Notice) bool {
+		return n.Kind == haccp.AlertMissing && n.UnitID == overdue.ID() && n.LastReadingAt != nil
+	}))
+}
+
+func TestRecordAction_ResolvesAlert(t *testing.T) {
+	units, logs := new(mockUnitRepository), new(mockLogRepository)
+	u := newChiller(t, units, "", time.Now())
+	alert := haccp.NewMissingAlert(uuid.New(), u.ID(), time.Now())
+	logs.On("FindAlertByID", mock.Anything, alert.ID()).Return(alert, nil)
+	logs.On("SaveAction", mock.Anything, mock.Anything, alert).Return(nil)
+	actorID, alertID := uuid.New(), alert.ID()
+
+	c, err := commands.NewRecordActionHandler(units, logs).Handle(context.Background(), commands.RecordActionCommand{
+		UnitID: u.ID(), AlertID: &alertID, Action: "Reading missed over the bank holiday; taken now", ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &alertID, c.AlertID())
+	assert.False(t, alert.Open())
+	assert.Equal(t, &actorID, alert.ResolvedBy())
+}
+
+func TestRecordAction_AlertOnAnotherUnit_ReturnsError(t *testing.T) {
+	units, logs := new(mockUnitRepository), new(mockLogRepository)
+	u := newChiller(t, units, "", time.Now())
+	alert := haccp.NewMissingAlert(uuid.New(), uuid.New(), time.Now())
+	logs.On("FindAlertByID", mock.Anything, alert.ID()).Return(alert, nil)
+	alertID := alert.ID()
+
+	_, err := commands.NewRecordActionHandler(units, logs).Handle(context.Background(), commands.RecordActionCommand{
+		UnitID: u.ID(), AlertID: &alertID, Action: "Moved stock", ActorID: uuid.New(),
+	})
+
+	assert.ErrorIs(t, err, haccp.ErrAlertNotFound)
+	assert.True(t, alert.Open())
+	logs.AssertNotCalled(t, "SaveAction", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// RunChecksResult counts what a check run did. Checked units are those in
+// service; Raised is how many of them had a missing reading alarm raised.
+type RunChecksResult struct {
+	Checked int
+	Raised  int
+}
+
+// RunChecksHandler raises an alarm for every unit in service that has gone
+// longer than it should without a reading. The server runs it every few
+// minutes; a unit whose missing reading alarm is still open does not raise
+// another.
+type RunChecksHandler struct {
+	unitRepo haccp.UnitRepository
+	logRepo  haccp.LogRepository
+	notifier haccp.Notifier
+}
+
+// NewRunChecksHandler creates a new RunChecksHandler.
+func NewRunChecksHandler(unitRepo haccp.UnitRepository, logRepo haccp.LogRepository, notifier haccp.Notifier) *RunChecksHandler {
+	return &RunChecksHandler{unitRepo: unitRepo, logRepo: logRepo, notifier: notifier}
+}
+
+// Handle executes the run checks use case over the units in scope.
+func (h *RunChecksHandler) Handle(ctx context.Context) (RunChecksResult, error) {
+	var result RunChecksResult
+	units, err := h.unitRepo.FindAll(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("loading units: %w", err)
+	}
+	last, err := h.logRepo.LastReadings(ctx)
+	if err != nil {
+		return result, fmt.Errorf("loading last readings: %w", err)
+	}
+
+	now := time.Now()
+	for _, u := range units {
+		if !u.Active() {
+			continue
+		}
+		result.Checked++
+		var lastAt *time.Time
+		if at, ok := last[u.ID()]; ok {
+			lastAt = &at
+		}
+		if !u.Overdue(lastAt, now) {
+			continue
+		}
+		alert := haccp.NewMissingAlert(uuid.New(), u.ID(), now)
+		raised, err := h.logRepo.RaiseAlert(ctx, alert)
+		if err != nil {
+			return result, fmt.Errorf("raising alert: %w", err)
+		}
+		if raised {
+			result.Raised++
+			_ = h.notifier.AlertRaised(ctx, haccp.NewNotice(alert, u, lastAt))
+		}
+	}
+	return result, nil
+}
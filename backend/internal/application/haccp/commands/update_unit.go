@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// UpdateUnitCommand is the input for the update unit use case.
+type UpdateUnitCommand struct {
+	UnitID     uuid.UUID
+	Name       string
+	Kind       haccp.Kind
+	MinCelsius float64
+	MaxCelsius float64
+	SensorID   string
+	CheckEvery time.Duration
+	Active     bool
+}
+
+// UpdateUnitHandler changes a unit's details, or takes it out of service.
+type UpdateUnitHandler struct {
+	unitRepo haccp.UnitRepository
+}
+
+// NewUpdateUnitHandler creates a new UpdateUnitHandler.
+func NewUpdateUnitHandler(unitRepo haccp.UnitRepository) *UpdateUnitHandler {
+	return &UpdateUnitHandler{unitRepo: unitRepo}
+}
+
+// Handle executes the update unit use case. Readings already taken keep
+// whether they were in range at the time.
+func (h *UpdateUnitHandler) Handle(ctx context.Context, cmd UpdateUnitCommand) (*haccp.Unit, error) {
+	u, err := h.unitRepo.FindByID(ctx, cmd.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.Update(cmd.Name, cmd.Kind, cmd.MinCelsius, cmd.MaxCelsius, cmd.SensorID, cmd.CheckEvery, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.unitRepo.Save(ctx, u); err != nil {
+		if errors.Is(err, haccp.ErrDuplicateUnit) || errors.Is(err, haccp.ErrDuplicateSensor) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving unit: %w", err)
+	}
+	return u, nil
+}
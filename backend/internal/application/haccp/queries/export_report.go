@@ -0,0 +1,152 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// ExportReportQuery is the input for the export report use case. From and
+// To are the first and last days covered; BranchID limits the report to a
+// branch.
+type ExportReportQuery struct {
+	BranchID *uuid.UUID
+	From     time.Time
+	To       time.Time
+	Format   haccp.Format
+}
+
+// ExportReportHandler exports the HACCP temperature record inspectors ask
+// for: every reading, alarm and corrective action of each unit over a
+// period.
+type ExportReportHandler struct {
+	unitRepo   haccp.UnitRepository
+	logRepo    haccp.LogRepository
+	branchRepo branch.Repository
+	adminRepo  admin.Repository
+	renderers  map[haccp.Format]haccp.ReportRenderer
+	location   *time.Location
+}
+
+// NewExportReportHandler creates a new ExportReportHandler. Days start and
+// end in location.
+func NewExportReportHandler(
+	unitRepo haccp.UnitRepository,
+	logRepo haccp.LogRepository,
+	branchRepo branch.Repository,
+	adminRepo admin.Repository,
+	renderers map[haccp.Format]haccp.ReportRenderer,
+	location *time.Location,
+) *ExportReportHandler {
+	return &ExportReportHandler{
+		unitRepo:   unitRepo,
+		logRepo:    logRepo,
+		branchRepo: branchRepo,
+		adminRepo:  adminRepo,
+		renderers:  renderers,
+		location:   location,
+	}
+}
+
+// Handle renders the report. Units added after the period are left out.
+func (h *ExportReportHandler) Handle(ctx context.Context, q ExportReportQuery) ([]byte, error) {
+	renderer, ok := h.renderers[q.Format]
+	if !ok {
+		return nil, haccp.ErrUnsupportedReportFormat
+	}
+	period, err := haccp.NewPeriod(q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	start, end := period.Start(h.location), period.End(h.location)
+
+	branchNames := make(map[uuid.UUID]string)
+	if q.BranchID != nil {
+		b, err := h.branchRepo.FindByID(ctx, *q.BranchID)
+		if err != nil {
+			return nil, err
+		}
+		branchNames[b.ID()] = b.Name()
+	} else {
+		branches, err := h.branchRepo.FindAll(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("loading branches: %w", err)
+		}
+		for _, b := range branches {
+			branchNames[b.ID()] = b.Name()
+		}
+	}
+
+	units, err := h.unitRepo.FindAll(ctx, q.BranchID)
+	if err != nil {
+		return nil, fmt.Errorf("loading units: %w", err)
+	}
+	f := haccp.Filter{BranchID: q.BranchID, From: &start, To: &end}
+	readings, err := h.logRepo.FindReadings(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("loading readings: %w", err)
+	}
+	alerts, err := h.logRepo.FindAlerts(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("loading alerts: %w", err)
+	}
+	actions, err := h.logRepo.FindActions(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("loading corrective actions: %w", err)
+	}
+
+	logs := make(map[uuid.UUID]*haccp.UnitLog, len(units))
+	report := &haccp.Report{Period: period, Location: h.location, Staff: make(map[uuid.UUID]string), GeneratedAt: time.Now()}
+	if q.BranchID != nil {
+		report.BranchName = branchNames[*q.BranchID]
+	}
+	for _, u := range units {
+		if !u.CreatedAt().Before(end) {
+			continue
+		}
+		report.Units = append(report.Units, haccp.UnitLog{Unit: u, BranchName: branchNames[u.BranchID()]})
+	}
+	for i := range report.Units {
+		logs[report.Units[i].Unit.ID()] = &report.Units[i]
+	}
+
+	staff := make(map[uuid.UUID]bool)
+	for _, r := range readings {
+		if l, ok := logs[r.UnitID()]; ok {
+			l.Readings = append(l.Readings, r)
+			if r.RecordedBy() != nil {
+				staff[*r.RecordedBy()] = true
+			}
+		}
+	}
+	// Alerts come newest first; the report lists them as they happened.
+	for i := len(alerts) - 1; i >= 0; i-- {
+		if l, ok := logs[alerts[i].UnitID()]; ok {
+			l.Alerts = append(l.Alerts, alerts[i])
+		}
+	}
+	for _, c := range actions {
+		if l, ok := logs[c.UnitID()]; ok {
+			l.Actions = append(l.Actions, c)
+			staff[c.TakenBy()] = true
+		}
+	}
+	for id := range staff {
+		a, err := h.adminRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("loading admin %s: %w", id, err)
+		}
+		report.Staff[id] = a.FullName()
+	}
+
+	out, err := renderer.Render(report)
+	if err != nil {
+		return nil, fmt.Errorf("rendering report: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// ListAlertsHandler lists the alarms raised on units.
+type ListAlertsHandler struct {
+	logRepo haccp.LogRepository
+}
+
+// NewListAlertsHandler creates a new ListAlertsHandler.
+func NewListAlertsHandler(logRepo haccp.LogRepository) *ListAlertsHandler {
+	return &ListAlertsHandler{logRepo: logRepo}
+}
+
+// Handle returns the alerts matching f, newest first.
+func (h *ListAlertsHandler) Handle(ctx context.Context, f haccp.Filter) ([]*haccp.Alert, error) {
+	alerts, err := h.logRepo.FindAlerts(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("listing alerts: %w", err)
+	}
+	return alerts, nil
+}
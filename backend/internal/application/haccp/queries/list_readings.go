@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// ListReadingsHandler lists the temperatures recorded for units.
+type ListReadingsHandler struct {
+	logRepo haccp.LogRepository
+}
+
+// NewListReadingsHandler creates a new ListReadingsHandler.
+func NewListReadingsHandler(logRepo haccp.LogRepository) *ListReadingsHandler {
+	return &ListReadingsHandler{logRepo: logRepo}
+}
+
+// Handle returns the readings matching f by when they were taken.
+func (h *ListReadingsHandler) Handle(ctx context.Context, f haccp.Filter) ([]*haccp.Reading, error) {
+	readings, err := h.logRepo.FindReadings(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("listing readings: %w", err)
+	}
+	return readings, nil
+}
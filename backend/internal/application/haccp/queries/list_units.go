@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// ListUnitsHandler lists the units at a branch, or at every branch.
+type ListUnitsHandler struct {
+	unitRepo haccp.UnitRepository
+}
+
+// NewListUnitsHandler creates a new ListUnitsHandler.
+func NewListUnitsHandler(unitRepo haccp.UnitRepository) *ListUnitsHandler {
+	return &ListUnitsHandler{unitRepo: unitRepo}
+}
+
+// Handle returns the units by name.
+func (h *ListUnitsHandler) Handle(ctx context.Context, branchID *uuid.UUID) ([]*haccp.Unit, error) {
+	units, err := h.unitRepo.FindAll(ctx, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("listing units: %w", err)
+	}
+	return units, nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// DecideExpiryProposalCommand is the input for accepting or dismissing an
+// expiry proposal.
+type DecideExpiryProposalCommand struct {
+	ProposalID uuid.UUID
+	ActorID    uuid.UUID
+}
+
+// AcceptExpiryProposalHandler carries out an expiry proposal: it marks the
+// lot down, or writes what is left of it off as expired.
+type AcceptExpiryProposalHandler struct {
+	stockRepo inventory.StockRepository
+	wasteRepo inventory.WasteRepository
+}
+
+// NewAcceptExpiryProposalHandler creates a new AcceptExpiryProposalHandler.
+func NewAcceptExpiryProposalHandler(stockRepo inventory.StockRepository, wasteRepo inventory.WasteRepository) *AcceptExpiryProposalHandler {
+	return &AcceptExpiryProposalHandler{stockRepo: stockRepo, wasteRepo: wasteRepo}
+}
+
+// Handle executes the accept expiry proposal use case.
+func (h *AcceptExpiryProposalHandler) Handle(ctx context.Context, cmd DecideExpiryProposalCommand) (*inventory.ExpiryProposal, error) {
+	p, err := h.wasteRepo.FindProposalByID(ctx, cmd.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	lot, err := h.stockRepo.FindLotByID(ctx, p.LotID())
+	if err != nil {
+		return nil, err
+	}
+
+	wasted, err := p.Accept(lot, cmd.ActorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.wasteRepo.DecideProposal(ctx, p, lot, wasted); err != nil {
+		if errors.Is(err, inventory.ErrProposalDecided) || errors.Is(err, inventory.ErrInsufficientStock) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving expiry proposal: %w", err)
+	}
+	return p, nil
+}
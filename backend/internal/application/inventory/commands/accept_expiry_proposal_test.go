@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// proposeFor returns the proposal testExpiryPolicy makes for lot on the
+// 10th, known to both repositories.
+func proposeFor(t *testing.T, stockRepo *mockStockRepository, wasteRepo *mockWasteRepository, lot *inventory.Lot) *inventory.ExpiryProposal {
+	t.Helper()
+	today := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+	p := testExpiryPolicy.Propose(uuid.New(), lot, today, today)
+	require.NotNil(t, p)
+	stockRepo.On("FindLotByID", mock.Anything, lot.ID()).Return(lot, nil)
+	wasteRepo.On("FindProposalByID", mock.Anything, p.ID()).Return(p, nil)
+	return p
+}
+
+func TestAcceptExpiryProposal_Waste_WritesOffWhatIsLeft(t *testing.T) {
+	stockRepo, wasteRepo := new(mockStockRepository), new(mockWasteRepository)
+	lot := newExpiringLot("2026-11-09")
+	p := proposeFor(t, stockRepo, wasteRepo, lot)
+	wasteRepo.On("DecideProposal", mock.Anything, p, lot, mock.AnythingOfType("*inventory.Movement")).Return(nil)
+
+	handler := commands.NewAcceptExpiryProposalHandler(stockRepo, wasteRepo)
+	got, err := handler.Handle(context.Background(), commands.DecideExpiryProposalCommand{ProposalID: p.ID(), ActorID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, inventory.ProposalAccepted, got.Status())
+	assert.Equal(t, int64(2500), got.WastedGrams())
+	wasted := wasteRepo.Calls[1].Arguments.Get(3).(*inventory.Movement)
+	assert.Equal(t, inventory.MovementWasted, wasted.Type())
+	assert.Equal(t, int64(-2500), wasted.DeltaGrams())
+	assert.Equal(t, "expired", wasted.Reason())
+	wasteRepo.AssertExpectations(t)
+}
+
+func TestAcceptExpiryProposal_Markdown_MarksLotDown(t *testing.T) {
+	stockRepo, wasteRepo := new(mockStockRepository), new(mockWasteRepository)
+	lot := newExpiringLot("2026-11-10")
+	p := proposeFor(t, stockRepo, wasteRepo, lot)
+	wasteRepo.On("DecideProposal", mock.Anything, p, lot, (*inventory.Movement)(nil)).Return(nil)
+
+	handler := commands.NewAcceptExpiryProposalHandler(stockRepo, wasteRepo)
+	_, err := handler.Handle(context.Background(), commands.DecideExpiryProposalCommand{ProposalID: p.ID(), ActorID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3000, lot.MarkdownBP())
+	wasteRepo.AssertExpectations(t)
+}
+
+func TestAcceptExpiryProposal_DecidedConcurrently_ReturnsDecided(t *testing.T) {
+	stockRepo, wasteRepo := new(mockStockRepository), new(mockWasteRepository)
+	lot := newExpiringLot("2026-11-09")
+	p := proposeFor(t, stockRepo, wasteRepo, lot)
+	wasteRepo.On("DecideProposal", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(inventory.ErrProposalDecided)
+
+	handler := commands.NewAcceptExpiryProposalHandler(stockRepo, wasteRepo)
+	_, err := handler.Handle(context.Background(), commands.DecideExpiryProposalCommand{ProposalID: p.ID(), ActorID: uuid.New()})
+
+	assert.Equal(t, inventory.ErrProposalDecided, err)
+}
+
+func TestDismissExpiryProposal_AlreadyDismissed_ReturnsDecided(t *testing.T) {
+	stockRepo, wasteRepo := new(mockStockRepository), new(mockWasteRepository)
+	lot := newExpiringLot("2026-11-09")
+	p := proposeFor(t, stockRepo, wasteRepo, lot)
+	require.NoError(t, p.Dismiss(uuid.New(), time.Now()))
+
+	handler := commands.NewDismissExpiryProposalHandler(stockRepo, wasteRepo)
+	_, err := handler.Handle(context.Background(), commands.DecideExpiryProposalCommand{ProposalID: p.ID(), ActorID: uuid.New()})
+
+	assert.ErrorIs(t, err, inventory.ErrProposalDecided)
+	wasteRepo.AssertNotCalled(t, "DecideProposal", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDismissExpiryProposal_RepoError_IsWrapped(t *testing.T) {
+	stockRepo, wasteRepo := new(mockStockRepository), new(mockWasteRepository)
+	p := proposeFor(t, stockRepo, wasteRepo, newExpiringLot("2026-11-09"))
+	repoErr := errors.New("connection refused")
+	wasteRepo.On("DecideProposal", mock.Anything, p, mock.Anything, (*inventory.Movement)(nil)).Return(repoErr)
+
+	handler := commands.NewDismissExpiryProposalHandler(stockRepo, wasteRepo)
+	_, err := handler.Handle(context.Background(), commands.DecideExpiryProposalCommand{ProposalID: p.ID(), ActorID: uuid.New()})
+
+	assert.ErrorIs(t, err, repoErr)
+	assert.Contains(t, err.Error(), "saving expiry proposal")
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ConfirmStockCommand is the input for the confirm stock use case.
+type ConfirmStockCommand struct {
+	ReservationID uuid.UUID
+	OrderID       uuid.UUID
+}
+
+// ConfirmStockHandler attaches a stock hold to a placed order.
+type ConfirmStockHandler struct {
+	reservationRepo inventory.ReservationRepository
+}
+
+// NewConfirmStockHandler creates a new ConfirmStockHandler.
+func NewConfirmStockHandler(reservationRepo inventory.ReservationRepository) *ConfirmStockHandler {
+	return &ConfirmStockHandler{reservationRepo: reservationRepo}
+}
+
+// Handle executes the confirm stock use case.
+func (h *ConfirmStockHandler) Handle(ctx context.Context, cmd ConfirmStockCommand) error {
+	r, err := h.reservationRepo.FindByID(ctx, cmd.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Confirm(cmd.OrderID, time.Now()); err != nil {
+		return err
+	}
+	if err := h.reservationRepo.Update(ctx, r); err != nil {
+		return fmt.Errorf("updating stock reservation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmStock_HeldReservation_AttachesOrder(t *testing.T) {
+	repo := new(mockReservationRepository)
+	r := newTestReservation(t, uuid.New())
+	orderID := uuid.New()
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	repo.On("Update", mock.Anything, r).Return(nil)
+
+	handler := commands.NewConfirmStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.ConfirmStockCommand{ReservationID: r.ID(), OrderID: orderID})
+
+	require.NoError(t, err)
+	assert.Equal(t, inventory.ReservationConfirmed, r.Status())
+	require.NotNil(t, r.OrderID())
+	assert.Equal(t, orderID, *r.OrderID())
+}
+
+func TestConfirmStock_Released_ReturnsError(t *testing.T) {
+	repo := new(mockReservationRepository)
+	r := newTestReservation(t, uuid.New())
+	require.NoError(t, r.Release())
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewConfirmStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.ConfirmStockCommand{ReservationID: r.ID(), OrderID: uuid.New()})
+
+	assert.ErrorIs(t, err, inventory.ErrReservationNotHeld)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// DismissExpiryProposalHandler leaves a lot as it is, for stock staff have
+// checked and judged still fit to sell at full price.
+type DismissExpiryProposalHandler struct {
+	stockRepo inventory.StockRepository
+	wasteRepo inventory.WasteRepository
+}
+
+// NewDismissExpiryProposalHandler creates a new DismissExpiryProposalHandler.
+func NewDismissExpiryProposalHandler(stockRepo inventory.StockRepository, wasteRepo inventory.WasteRepository) *DismissExpiryProposalHandler {
+	return &DismissExpiryProposalHandler{stockRepo: stockRepo, wasteRepo: wasteRepo}
+}
+
+// Handle executes the dismiss expiry proposal use case.
+func (h *DismissExpiryProposalHandler) Handle(ctx context.Context, cmd DecideExpiryProposalCommand) (*inventory.ExpiryProposal, error) {
+	p, err := h.wasteRepo.FindProposalByID(ctx, cmd.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	lot, err := h.stockRepo.FindLotByID(ctx, p.LotID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Dismiss(cmd.ActorID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := h.wasteRepo.DecideProposal(ctx, p, lot, nil); err != nil {
+		if errors.Is(err, inventory.ErrProposalDecided) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving expiry proposal: %w", err)
+	}
+	return p, nil
+}
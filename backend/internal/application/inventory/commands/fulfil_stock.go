@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// FulfilStockCommand is the input for the fulfil stock use case.
+type FulfilStockCommand struct {
+	ReservationID uuid.UUID
+	ActorID       uuid.UUID
+}
+
+// FulfilStockHandler turns reserved stock into sales once an order is picked.
+type FulfilStockHandler struct {
+	reservationRepo inventory.ReservationRepository
+}
+
+// NewFulfilStockHandler creates a new FulfilStockHandler.
+func NewFulfilStockHandler(reservationRepo inventory.ReservationRepository) *FulfilStockHandler {
+	return &FulfilStockHandler{reservationRepo: reservationRepo}
+}
+
+// Handle executes the fulfil stock use case.
+func (h *FulfilStockHandler) Handle(ctx context.Context, cmd FulfilStockCommand) error {
+	r, err := h.reservationRepo.FindByID(ctx, cmd.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := r.Fulfil(now); err != nil {
+		return err
+	}
+	if err := h.reservationRepo.Fulfil(ctx, r, &cmd.ActorID, now); err != nil {
+		if errors.Is(err, inventory.ErrInsufficientStock) {
+			return err
+		}
+		return fmt.Errorf("fulfilling stock reservation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFulfilStock_ActiveReservation_RecordsSale(t *testing.T) {
+	repo := new(mockReservationRepository)
+	r := newTestReservation(t, uuid.New())
+	actorID := uuid.New()
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	repo.On("Fulfil", mock.Anything, r, &actorID, mock.Anything).Return(nil)
+
+	handler := commands.NewFulfilStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.FulfilStockCommand{ReservationID: r.ID(), ActorID: actorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, inventory.ReservationFulfilled, r.Status())
+	repo.AssertExpectations(t)
+}
+
+func TestFulfilStock_Released_ReturnsError(t *testing.T) {
+	repo := new(mockReservationRepository)
+	r := newTestReservation(t, uuid.New())
+	require.NoError(t, r.Release())
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewFulfilStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.FulfilStockCommand{ReservationID: r.ID(), ActorID: uuid.New()})
+
+	assert.ErrorIs(t, err, inventory.ErrReservationNotActive)
+	repo.AssertNotCalled(t, "Fulfil", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ReceiveLotCommand is the input for the receive lot use case.
+type ReceiveLotCommand struct {
+	ProductID      uuid.UUID
+	BranchID       uuid.UUID
+	Code           string
+	Grams          int64
+	ExpiresOn      string // YYYY-MM-DD, optional
+	CostPerKgCents int64  // what the stock cost, used to value waste
+	ActorID        uuid.UUID
+}
+
+// ReceiveLotHandler books a new batch of stock into a branch.
+type ReceiveLotHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewReceiveLotHandler creates a new ReceiveLotHandler.
+func NewReceiveLotHandler(stockRepo inventory.StockRepository) *ReceiveLotHandler {
+	return &ReceiveLotHandler{stockRepo: stockRepo}
+}
+
+// Handle executes the receive lot use case and returns the new lot.
+func (h *ReceiveLotHandler) Handle(ctx context.Context, cmd ReceiveLotCommand) (*inventory.Lot, error) {
+	var expiresOn *time.Time
+	if cmd.ExpiresOn != "" {
+		d, err := time.Parse(time.DateOnly, cmd.ExpiresOn)
+		if err != nil {
+			return nil, inventory.ErrInvalidDate
+		}
+		expiresOn = &d
+	}
+
+	lot, received, err := inventory.ReceiveLot(uuid.New(), cmd.ProductID, cmd.BranchID, cmd.Code,
+		cmd.Grams, expiresOn, &cmd.ActorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := lot.SetCost(cmd.CostPerKgCents); err != nil {
+		return nil, err
+	}
+
+	if err := h.stockRepo.ReceiveLot(ctx, lot, received); err != nil {
+		return nil, fmt.Errorf("saving lot: %w", err)
+	}
+	return lot, nil
+}
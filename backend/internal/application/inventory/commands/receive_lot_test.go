@@ -0,0 +1,186 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockStockRepository struct {
+	mock.Mock
+}
+
+func (m *mockStockRepository) ReceiveLot(ctx context.Context, lot *inventory.Lot, received *inventory.Movement) error {
+	args := m.Called(ctx, lot, received)
+	return args.Error(0)
+}
+
+func (m *mockStockRepository) FindLotByID(ctx context.Context, id uuid.UUID) (*inventory.Lot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Lot), args.Error(1)
+}
+
+func (m *mockStockRepository) FindLots(ctx context.Context, productID, branchID uuid.UUID) ([]*inventory.Lot, error) {
+	args := m.Called(ctx, productID, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Lot), args.Error(1)
+}
+
+func (m *mockStockRepository) RecordMovements(ctx context.Context, movements []*inventory.Movement) error {
+	args := m.Called(ctx, movements)
+	return args.Error(0)
+}
+
+func (m *mockStockRepository) TakeStock(ctx context.Context, branchID uuid.UUID, counts []inventory.Count, actorID *uuid.UUID, now time.Time) ([]*inventory.Movement, error) {
+	args := m.Called(ctx, branchID, counts, actorID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Movement), args.Error(1)
+}
+
+func (m *mockStockRepository) FindMovements(ctx context.Context, filter inventory.MovementFilter) ([]*inventory.Movement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Movement), args.Error(1)
+}
+
+func (m *mockStockRepository) StockLevels(ctx context.Context, branchID *uuid.UUID, now time.Time) ([]inventory.StockLevel, error) {
+	args := m.Called(ctx, branchID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.StockLevel), args.Error(1)
+}
+
+func (m *mockStockRepository) SetLowStockThreshold(ctx context.Context, productID uuid.UUID, grams int64) error {
+	args := m.Called(ctx, productID, grams)
+	return args.Error(0)
+}
+
+type mockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockReservationRepository) Reserve(ctx context.Context, r *inventory.Reservation, now time.Time) error {
+	args := m.Called(ctx, r, now)
+	return args.Error(0)
+}
+
+func (m *mockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*inventory.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) Update(ctx context.Context, r *inventory.Reservation) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockReservationRepository) Fulfil(ctx context.Context, r *inventory.Reservation, actorID *uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, r, actorID, now)
+	return args.Error(0)
+}
+
+// --- Fixtures ---
+
+func newTestLot(branchID uuid.UUID, onHandGrams int64) *inventory.Lot {
+	return inventory.ReconstructLot(uuid.New(), uuid.New(), branchID, "LOT-1",
+		onHandGrams, onHandGrams, nil, 0, 0, time.Now().Add(-24*time.Hour))
+}
+
+func newTestReservation(t *testing.T, customerID uuid.UUID) *inventory.Reservation {
+	t.Helper()
+	r, err := inventory.NewReservation(uuid.New(), uuid.New(), customerID,
+		[]inventory.ReservationLine{{ProductID: uuid.New(), Grams: 1500}}, 30*time.Minute, time.Now())
+	require.NoError(t, err)
+	return r
+}
+
+// --- ReceiveLot Tests ---
+
+func TestReceiveLot_ValidInput_SavesLotWithReceivedMovement(t *testing.T) {
+	repo := new(mockStockRepository)
+	repo.On("ReceiveLot", mock.Anything, mock.AnythingOfType("*inventory.Lot"), mock.AnythingOfType("*inventory.Movement")).Return(nil)
+
+	handler := commands.NewReceiveLotHandler(repo)
+	lot, err := handler.Handle(context.Background(), commands.ReceiveLotCommand{
+		ProductID: uuid.New(),
+		BranchID:  uuid.New(),
+		Code:      "BEEF-0412",
+		Grams:     25000,
+		ExpiresOn: "2026-11-02",
+		ActorID:   uuid.New(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(25000), lot.OnHandGrams())
+	require.NotNil(t, lot.ExpiresOn())
+	assert.Equal(t, "2026-11-02", lot.ExpiresOn().Format(time.DateOnly))
+
+	received := repo.Calls[0].Arguments.Get(2).(*inventory.Movement)
+	assert.Equal(t, inventory.MovementReceived, received.Type())
+	assert.Equal(t, int64(25000), received.DeltaGrams())
+	repo.AssertExpectations(t)
+}
+
+func TestReceiveLot_InvalidExpiry_ReturnsError(t *testing.T) {
+	repo := new(mockStockRepository)
+
+	handler := commands.NewReceiveLotHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.ReceiveLotCommand{
+		ProductID: uuid.New(),
+		BranchID:  uuid.New(),
+		Code:      "BEEF-0412",
+		Grams:     25000,
+		ExpiresOn: "02/11/2026",
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrInvalidDate)
+	repo.AssertNotCalled(t, "ReceiveLot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReceiveLot_RepoError_IsWrapped(t *testing.T) {
+	repo := new(mockStockRepository)
+	repoErr := errors.New("connection refused")
+	repo.On("ReceiveLot", mock.Anything, mock.Anything, mock.Anything).Return(repoErr)
+
+	handler := commands.NewReceiveLotHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.ReceiveLotCommand{
+		ProductID: uuid.New(),
+		BranchID:  uuid.New(),
+		Code:      "BEEF-0412",
+		Grams:     25000,
+	})
+
+	assert.ErrorIs(t, err, repoErr)
+	assert.Contains(t, err.Error(), "saving lot")
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// RecordMovementCommand is the input for a manual stock adjustment. DeltaGrams
+// is negative for stock leaving the lot.
+type RecordMovementCommand struct {
+	LotID      uuid.UUID
+	Type       string
+	DeltaGrams int64
+	Reason     string
+	ActorID    uuid.UUID
+}
+
+// RecordMovementHandler records cutting, wastage and ad-hoc adjustments.
+type RecordMovementHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewRecordMovementHandler creates a new RecordMovementHandler.
+func NewRecordMovementHandler(stockRepo inventory.StockRepository) *RecordMovementHandler {
+	return &RecordMovementHandler{stockRepo: stockRepo}
+}
+
+// Handle executes the record movement use case.
+func (h *RecordMovementHandler) Handle(ctx context.Context, cmd RecordMovementCommand) (*inventory.Movement, error) {
+	kind, err := inventory.NewMovementType(cmd.Type)
+	if err != nil {
+		return nil, err
+	}
+	if !kind.IsManual() {
+		return nil, inventory.ErrMovementTypeNotAllowed
+	}
+	// Waste is reported on by reason, so it must be one of the reason codes.
+	if kind == inventory.MovementWasted {
+		if _, err := inventory.ParseWasteReason(cmd.Reason); err != nil {
+			return nil, err
+		}
+	}
+
+	lot, err := h.stockRepo.FindLotByID(ctx, cmd.LotID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := inventory.NewMovement(uuid.New(), lot, kind, cmd.DeltaGrams, cmd.Reason, nil, &cmd.ActorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.stockRepo.RecordMovements(ctx, []*inventory.Movement{m}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
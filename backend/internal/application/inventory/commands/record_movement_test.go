@@ -0,0 +1,95 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordMovement_Wastage_RecordsNegativeMovement(t *testing.T) {
+	repo := new(mockStockRepository)
+	lot := newTestLot(uuid.New(), 10000)
+	repo.On("FindLotByID", mock.Anything, lot.ID()).Return(lot, nil)
+	repo.On("RecordMovements", mock.Anything, mock.AnythingOfType("[]*inventory.Movement")).Return(nil)
+
+	handler := commands.NewRecordMovementHandler(repo)
+	m, err := handler.Handle(context.Background(), commands.RecordMovementCommand{
+		LotID:      lot.ID(),
+		Type:       "wasted",
+		DeltaGrams: -800,
+		Reason:     "trim",
+		ActorID:    uuid.New(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, inventory.MovementWasted, m.Type())
+	assert.Equal(t, lot.ProductID(), m.ProductID())
+	repo.AssertExpectations(t)
+}
+
+func TestRecordMovement_SoldType_IsRejected(t *testing.T) {
+	repo := new(mockStockRepository)
+
+	handler := commands.NewRecordMovementHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordMovementCommand{
+		LotID:      uuid.New(),
+		Type:       "sold",
+		DeltaGrams: -500,
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrMovementTypeNotAllowed)
+	repo.AssertNotCalled(t, "FindLotByID", mock.Anything, mock.Anything)
+}
+
+func TestRecordMovement_WouldGoNegative_ReturnsInsufficientStock(t *testing.T) {
+	repo := new(mockStockRepository)
+	lot := newTestLot(uuid.New(), 500)
+	repo.On("FindLotByID", mock.Anything, lot.ID()).Return(lot, nil)
+	repo.On("RecordMovements", mock.Anything, mock.Anything).Return(inventory.ErrInsufficientStock)
+
+	handler := commands.NewRecordMovementHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordMovementCommand{
+		LotID:      lot.ID(),
+		Type:       "adjusted",
+		DeltaGrams: -900,
+		Reason:     "miscount",
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+}
+
+func TestRecordMovement_LotNotFound_ReturnsError(t *testing.T) {
+	repo := new(mockStockRepository)
+	lotID := uuid.New()
+	repo.On("FindLotByID", mock.Anything, lotID).Return(nil, inventory.ErrLotNotFound)
+
+	handler := commands.NewRecordMovementHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordMovementCommand{
+		LotID:      lotID,
+		Type:       "cut",
+		DeltaGrams: -1000,
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrLotNotFound)
+}
+
+func TestRecordMovement_WastageWithoutReasonCode_IsRejected(t *testing.T) {
+	repo := new(mockStockRepository)
+
+	handler := commands.NewRecordMovementHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordMovementCommand{
+		LotID:      uuid.New(),
+		Type:       "wasted",
+		DeltaGrams: -800,
+		Reason:     "dropped on floor",
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrInvalidWasteReason)
+	repo.AssertNotCalled(t, "FindLotByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// RecordWasteCommand is the input for the record waste use case.
+type RecordWasteCommand struct {
+	LotID   uuid.UUID
+	Grams   int64
+	Reason  string // trim, expired, damaged or returned
+	ActorID uuid.UUID
+}
+
+// RecordWasteHandler writes stock off a lot with a reason code.
+type RecordWasteHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewRecordWasteHandler creates a new RecordWasteHandler.
+func NewRecordWasteHandler(stockRepo inventory.StockRepository) *RecordWasteHandler {
+	return &RecordWasteHandler{stockRepo: stockRepo}
+}
+
+// Handle executes the record waste use case and returns the wasted movement.
+func (h *RecordWasteHandler) Handle(ctx context.Context, cmd RecordWasteCommand) (*inventory.Movement, error) {
+	reason, err := inventory.ParseWasteReason(cmd.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	lot, err := h.stockRepo.FindLotByID(ctx, cmd.LotID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := lot.Waste(cmd.Grams, reason, nil, &cmd.ActorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.stockRepo.RecordMovements(ctx, []*inventory.Movement{m}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
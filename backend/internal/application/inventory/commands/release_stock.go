@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ReleaseStockCommand is the input for the release stock use case.
+type ReleaseStockCommand struct {
+	ReservationID uuid.UUID
+	CustomerID    uuid.UUID
+}
+
+// ReleaseStockHandler gives reserved stock back when checkout is abandoned.
+// Stock confirmed for an order goes back when the order is cancelled.
+type ReleaseStockHandler struct {
+	reservationRepo inventory.ReservationRepository
+}
+
+// NewReleaseStockHandler creates a new ReleaseStockHandler.
+func NewReleaseStockHandler(reservationRepo inventory.ReservationRepository) *ReleaseStockHandler {
+	return &ReleaseStockHandler{reservationRepo: reservationRepo}
+}
+
+// Handle executes the release stock use case. It is idempotent, but stock
+// already confirmed for an order cannot be released.
+func (h *ReleaseStockHandler) Handle(ctx context.Context, cmd ReleaseStockCommand) error {
+	r, err := h.reservationRepo.FindByID(ctx, cmd.ReservationID)
+	if err != nil {
+		return err
+	}
+	if r.CustomerID() != cmd.CustomerID {
+		return inventory.ErrReservationNotOwned
+	}
+
+	if err := r.Release(); err != nil {
+		return err
+	}
+	if err := h.reservationRepo.Update(ctx, r); err != nil {
+		return fmt.Errorf("updating stock reservation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseStock_OwnReservation_Releases(t *testing.T) {
+	repo := new(mockReservationRepository)
+	customerID := uuid.New()
+	r := newTestReservation(t, customerID)
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+	repo.On("Update", mock.Anything, r).Return(nil)
+
+	handler := commands.NewReleaseStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.ReleaseStockCommand{ReservationID: r.ID(), CustomerID: customerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, inventory.ReservationReleased, r.Status())
+	repo.AssertExpectations(t)
+}
+
+func TestReleaseStock_OtherCustomer_ReturnsError(t *testing.T) {
+	repo := new(mockReservationRepository)
+	r := newTestReservation(t, uuid.New())
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewReleaseStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.ReleaseStockCommand{ReservationID: r.ID(), CustomerID: uuid.New()})
+
+	assert.ErrorIs(t, err, inventory.ErrReservationNotOwned)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestReleaseStock_Confirmed_ReturnsError(t *testing.T) {
+	repo := new(mockReservationRepository)
+	customerID := uuid.New()
+	r := newTestReservation(t, customerID)
+	require.NoError(t, r.Confirm(uuid.New(), time.Now()))
+	repo.On("FindByID", mock.Anything, r.ID()).Return(r, nil)
+
+	handler := commands.NewReleaseStockHandler(repo)
+	err := handler.Handle(context.Background(), commands.ReleaseStockCommand{ReservationID: r.ID(), CustomerID: customerID})
+
+	assert.ErrorIs(t, err, inventory.ErrReservationNotHeld)
+	assert.Equal(t, inventory.ReservationConfirmed, r.Status())
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
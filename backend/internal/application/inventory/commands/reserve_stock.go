@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ReserveStockCommand is the input for the reserve stock use case.
+type ReserveStockCommand struct {
+	CustomerID uuid.UUID
+	BranchID   uuid.UUID
+	Lines      []inventory.ReservationLine
+}
+
+// ReserveStockResult is the output of the reserve stock use case.
+type ReserveStockResult struct {
+	ReservationID uuid.UUID
+	ExpiresAt     time.Time
+}
+
+// ReserveStockHandler holds stock for a basket during checkout so that cuts
+// which have run out cannot be ordered.
+type ReserveStockHandler struct {
+	reservationRepo inventory.ReservationRepository
+	holdTTL         time.Duration
+}
+
+// NewReserveStockHandler creates a new ReserveStockHandler.
+func NewReserveStockHandler(reservationRepo inventory.ReservationRepository, holdTTL time.Duration) *ReserveStockHandler {
+	return &ReserveStockHandler{reservationRepo: reservationRepo, holdTTL: holdTTL}
+}
+
+// Handle executes the reserve stock use case.
+func (h *ReserveStockHandler) Handle(ctx context.Context, cmd ReserveStockCommand) (*ReserveStockResult, error) {
+	now := time.Now()
+
+	r, err := inventory.NewReservation(uuid.New(), cmd.BranchID, cmd.CustomerID, cmd.Lines, h.holdTTL, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.reservationRepo.Reserve(ctx, r, now); err != nil {
+		if errors.Is(err, inventory.ErrInsufficientStock) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving stock reservation: %w", err)
+	}
+
+	return &ReserveStockResult{ReservationID: r.ID(), ExpiresAt: r.ExpiresAt()}, nil
+}
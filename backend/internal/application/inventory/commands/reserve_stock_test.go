@@ -0,0 +1,58 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserveStock_Available_PlacesHold(t *testing.T) {
+	repo := new(mockReservationRepository)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*inventory.Reservation"), mock.Anything).Return(nil)
+
+	handler := commands.NewReserveStockHandler(repo, 30*time.Minute)
+	result, err := handler.Handle(context.Background(), commands.ReserveStockCommand{
+		CustomerID: uuid.New(),
+		BranchID:   uuid.New(),
+		Lines:      []inventory.ReservationLine{{ProductID: uuid.New(), Grams: 1200}},
+	})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, result.ReservationID)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), result.ExpiresAt, 5*time.Second)
+	repo.AssertExpectations(t)
+}
+
+func TestReserveStock_InsufficientStock_ReturnsError(t *testing.T) {
+	repo := new(mockReservationRepository)
+	repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(inventory.ErrInsufficientStock)
+
+	handler := commands.NewReserveStockHandler(repo, 30*time.Minute)
+	_, err := handler.Handle(context.Background(), commands.ReserveStockCommand{
+		CustomerID: uuid.New(),
+		BranchID:   uuid.New(),
+		Lines:      []inventory.ReservationLine{{ProductID: uuid.New(), Grams: 1200}},
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+}
+
+func TestReserveStock_NoLines_ReturnsError(t *testing.T) {
+	repo := new(mockReservationRepository)
+
+	handler := commands.NewReserveStockHandler(repo, 30*time.Minute)
+	_, err := handler.Handle(context.Background(), commands.ReserveStockCommand{
+		CustomerID: uuid.New(),
+		BranchID:   uuid.New(),
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrEmptyReservation)
+	repo.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ScanExpiryCommand is the input for the scan expiry use case.
+type ScanExpiryCommand struct {
+	Date string // YYYY-MM-DD; empty means today
+}
+
+// ScanExpiryResult counts what an expiry scan did. Scanned lots are those
+// with stock whose use-by date is near or past; Markdowns and Waste are how
+// many proposals of each kind it raised.
+type ScanExpiryResult struct {
+	Scanned   int
+	Markdowns int
+	Waste     int
+}
+
+// ScanExpiryHandler proposes markdowns for stock nearing its use-by date
+// and write-offs for stock past it, and alerts staff to each. The server
+// runs it through the day; a lot is proposed each kind at most once however
+// often it runs.
+type ScanExpiryHandler struct {
+	wasteRepo inventory.WasteRepository
+	notifier  inventory.Notifier
+	policy    inventory.ExpiryPolicy
+	location  *time.Location
+}
+
+// NewScanExpiryHandler creates a new ScanExpiryHandler.
+func NewScanExpiryHandler(wasteRepo inventory.WasteRepository, notifier inventory.Notifier, policy inventory.ExpiryPolicy, location *time.Location) *ScanExpiryHandler {
+	return &ScanExpiryHandler{wasteRepo: wasteRepo, notifier: notifier, policy: policy, location: location}
+}
+
+// Handle executes the scan expiry use case over the lots in scope. Days
+// are the shop's calendar days.
+func (h *ScanExpiryHandler) Handle(ctx context.Context, cmd ScanExpiryCommand) (ScanExpiryResult, error) {
+	var result ScanExpiryResult
+	now := time.Now()
+	today := now.In(h.location)
+	if cmd.Date != "" {
+		d, err := time.ParseInLocation(time.DateOnly, cmd.Date, h.location)
+		if err != nil {
+			return result, inventory.ErrInvalidDate
+		}
+		today = d
+	}
+
+	lots, err := h.wasteRepo.ExpiringLots(ctx, h.policy.Horizon(today))
+	if err != nil {
+		return result, fmt.Errorf("loading expiring lots: %w", err)
+	}
+
+	for _, lot := range lots {
+		result.Scanned++
+		p := h.policy.Propose(uuid.New(), lot, today, now)
+		if p == nil {
+			continue
+		}
+		saved, err := h.wasteRepo.SaveProposal(ctx, p)
+		if err != nil {
+			return result, fmt.Errorf("saving expiry proposal: %w", err)
+		}
+		if !saved {
+			continue
+		}
+		if p.Kind() == inventory.ProposalWaste {
+			result.Waste++
+		} else {
+			result.Markdowns++
+		}
+		_ = h.notifier.UseByReached(ctx, inventory.NewExpiryNotice(p, lot))
+	}
+	return result, nil
+}
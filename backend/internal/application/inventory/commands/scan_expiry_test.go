@@ -0,0 +1,149 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockWasteRepository struct {
+	mock.Mock
+}
+
+func (m *mockWasteRepository) ExpiringLots(ctx context.Context, before time.Time) ([]*inventory.Lot, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Lot), args.Error(1)
+}
+
+func (m *mockWasteRepository) UpdateLot(ctx context.Context, lot *inventory.Lot) error {
+	return m.Called(ctx, lot).Error(0)
+}
+
+func (m *mockWasteRepository) SaveProposal(ctx context.Context, p *inventory.ExpiryProposal) (bool, error) {
+	args := m.Called(ctx, p)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockWasteRepository) FindProposalByID(ctx context.Context, id uuid.UUID) (*inventory.ExpiryProposal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.ExpiryProposal), args.Error(1)
+}
+
+func (m *mockWasteRepository) FindProposals(ctx context.Context, filter inventory.ProposalFilter) ([]*inventory.ExpiryProposal, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.ExpiryProposal), args.Error(1)
+}
+
+func (m *mockWasteRepository) DecideProposal(ctx context.Context, p *inventory.ExpiryProposal, lot *inventory.Lot, wasted *inventory.Movement) error {
+	return m.Called(ctx, p, lot, wasted).Error(0)
+}
+
+func (m *mockWasteRepository) LedgerTotals(ctx context.Context, filter inventory.LedgerFilter) ([]inventory.LedgerTotal, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.LedgerTotal), args.Error(1)
+}
+
+type mockExpiryNotifier struct {
+	mock.Mock
+}
+
+func (m *mockExpiryNotifier) UseByReached(ctx context.Context, n inventory.ExpiryNotice) error {
+	return m.Called(ctx, n).Error(0)
+}
+
+// --- Fixtures ---
+
+var testExpiryPolicy = inventory.ExpiryPolicy{MarkdownDays: 1, MarkdownBP: 3000}
+
+// newExpiringLot returns a lot with stock whose use-by date is useBy.
+func newExpiringLot(useBy string) *inventory.Lot {
+	d, _ := time.Parse(time.DateOnly, useBy)
+	return inventory.ReconstructLot(uuid.New(), uuid.New(), uuid.New(), "LOT-1",
+		4000, 2500, &d, 1200, 0, d.AddDate(0, 0, -5))
+}
+
+// --- ScanExpiry Tests ---
+
+func TestScanExpiry_ProposesMarkdownsAndWasteAndNotifies(t *testing.T) {
+	repo := new(mockWasteRepository)
+	notifier := new(mockExpiryNotifier)
+	expired, expiring := newExpiringLot("2026-11-09"), newExpiringLot("2026-11-10")
+	horizon := time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC)
+	repo.On("ExpiringLots", mock.Anything, horizon).Return([]*inventory.Lot{expired, expiring}, nil)
+	repo.On("SaveProposal", mock.Anything, mock.AnythingOfType("*inventory.ExpiryProposal")).Return(true, nil)
+	notifier.On("UseByReached", mock.Anything, mock.Anything).Return(nil)
+
+	handler := commands.NewScanExpiryHandler(repo, notifier, testExpiryPolicy, time.UTC)
+	result, err := handler.Handle(context.Background(), commands.ScanExpiryCommand{Date: "2026-11-10"})
+
+	require.NoError(t, err)
+	assert.Equal(t, commands.ScanExpiryResult{Scanned: 2, Markdowns: 1, Waste: 1}, result)
+
+	waste := notifier.Calls[0].Arguments.Get(1).(inventory.ExpiryNotice)
+	assert.Equal(t, inventory.ProposalWaste, waste.Kind)
+	assert.Equal(t, expired.ID(), waste.LotID)
+	markdown := notifier.Calls[1].Arguments.Get(1).(inventory.ExpiryNotice)
+	assert.Equal(t, inventory.ProposalMarkdown, markdown.Kind)
+	assert.Equal(t, 3000, markdown.MarkdownBP)
+	assert.Equal(t, int64(2500), markdown.OnHandGrams)
+	repo.AssertExpectations(t)
+}
+
+func TestScanExpiry_AlreadyProposed_DoesNotNotifyAgain(t *testing.T) {
+	repo := new(mockWasteRepository)
+	notifier := new(mockExpiryNotifier)
+	repo.On("ExpiringLots", mock.Anything, mock.Anything).Return([]*inventory.Lot{newExpiringLot("2026-11-09")}, nil)
+	repo.On("SaveProposal", mock.Anything, mock.Anything).Return(false, nil)
+
+	handler := commands.NewScanExpiryHandler(repo, notifier, testExpiryPolicy, time.UTC)
+	result, err := handler.Handle(context.Background(), commands.ScanExpiryCommand{Date: "2026-11-10"})
+
+	require.NoError(t, err)
+	assert.Equal(t, commands.ScanExpiryResult{Scanned: 1}, result)
+	notifier.AssertNotCalled(t, "UseByReached", mock.Anything, mock.Anything)
+}
+
+func TestScanExpiry_InvalidDate_ReturnsError(t *testing.T) {
+	repo := new(mockWasteRepository)
+
+	handler := commands.NewScanExpiryHandler(repo, new(mockExpiryNotifier), testExpiryPolicy, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ScanExpiryCommand{Date: "10/11/2026"})
+
+	assert.ErrorIs(t, err, inventory.ErrInvalidDate)
+	repo.AssertNotCalled(t, "ExpiringLots", mock.Anything, mock.Anything)
+}
+
+func TestScanExpiry_RepoError_IsWrapped(t *testing.T) {
+	repo := new(mockWasteRepository)
+	repoErr := errors.New("connection refused")
+	repo.On("ExpiringLots", mock.Anything, mock.Anything).Return([]*inventory.Lot{newExpiringLot("2026-11-09")}, nil)
+	repo.On("SaveProposal", mock.Anything, mock.Anything).Return(false, repoErr)
+
+	handler := commands.NewScanExpiryHandler(repo, new(mockExpiryNotifier), testExpiryPolicy, time.UTC)
+	_, err := handler.Handle(context.Background(), commands.ScanExpiryCommand{Date: "2026-11-10"})
+
+	assert.ErrorIs(t, err, repoErr)
+	assert.Contains(t, err.Error(), "saving expiry proposal")
+}
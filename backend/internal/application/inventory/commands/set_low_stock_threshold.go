@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// SetLowStockThresholdCommand is the input for the set low-stock threshold use
+// case. A threshold of zero turns low-stock reporting off for the product.
+type SetLowStockThresholdCommand struct {
+	ProductID uuid.UUID
+	Grams     int64
+}
+
+// SetLowStockThresholdHandler sets the weight at which a product is reported as low.
+type SetLowStockThresholdHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewSetLowStockThresholdHandler creates a new SetLowStockThresholdHandler.
+func NewSetLowStockThresholdHandler(stockRepo inventory.StockRepository) *SetLowStockThresholdHandler {
+	return &SetLowStockThresholdHandler{stockRepo: stockRepo}
+}
+
+// Handle executes the set low-stock threshold use case.
+func (h *SetLowStockThresholdHandler) Handle(ctx context.Context, cmd SetLowStockThresholdCommand) error {
+	if cmd.Grams < 0 {
+		return inventory.ErrInvalidThreshold
+	}
+	if err := h.stockRepo.SetLowStockThreshold(ctx, cmd.ProductID, cmd.Grams); err != nil {
+		return fmt.Errorf("saving low-stock threshold: %w", err)
+	}
+	return nil
+}
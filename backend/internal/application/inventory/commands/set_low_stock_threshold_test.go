@@ -0,0 +1,33 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetLowStockThreshold_ValidInput_Saves(t *testing.T) {
+	repo := new(mockStockRepository)
+	productID := uuid.New()
+	repo.On("SetLowStockThreshold", mock.Anything, productID, int64(5000)).Return(nil)
+
+	handler := commands.NewSetLowStockThresholdHandler(repo)
+	err := handler.Handle(context.Background(), commands.SetLowStockThresholdCommand{ProductID: productID, Grams: 5000})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestSetLowStockThreshold_Negative_ReturnsError(t *testing.T) {
+	repo := new(mockStockRepository)
+
+	handler := commands.NewSetLowStockThresholdHandler(repo)
+	err := handler.Handle(context.Background(), commands.SetLowStockThresholdCommand{ProductID: uuid.New(), Grams: -1})
+
+	assert.ErrorIs(t, err, inventory.ErrInvalidThreshold)
+}
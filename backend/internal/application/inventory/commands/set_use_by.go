@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// SetUseByCommand is the input for the set use-by use case.
+type SetUseByCommand struct {
+	LotID uuid.UUID
+	UseBy string // YYYY-MM-DD; empty clears it
+}
+
+// SetUseByHandler corrects or clears a lot's use-by date.
+type SetUseByHandler struct {
+	stockRepo inventory.StockRepository
+	wasteRepo inventory.WasteRepository
+}
+
+// NewSetUseByHandler creates a new SetUseByHandler.
+func NewSetUseByHandler(stockRepo inventory.StockRepository, wasteRepo inventory.WasteRepository) *SetUseByHandler {
+	return &SetUseByHandler{stockRepo: stockRepo, wasteRepo: wasteRepo}
+}
+
+// Handle executes the set use-by use case and returns the updated lot.
+func (h *SetUseByHandler) Handle(ctx context.Context, cmd SetUseByCommand) (*inventory.Lot, error) {
+	var useBy *time.Time
+	if cmd.UseBy != "" {
+		d, err := time.Parse(time.DateOnly, cmd.UseBy)
+		if err != nil {
+			return nil, inventory.ErrInvalidDate
+		}
+		useBy = &d
+	}
+
+	lot, err := h.stockRepo.FindLotByID(ctx, cmd.LotID)
+	if err != nil {
+		return nil, err
+	}
+	lot.SetUseBy(useBy)
+
+	if err := h.wasteRepo.UpdateLot(ctx, lot); err != nil {
+		return nil, fmt.Errorf("saving lot: %w", err)
+	}
+	return lot, nil
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// LotCount is the weight counted for one lot during a stock take.
+type LotCount struct {
+	LotID        uuid.UUID
+	CountedGrams int64
+}
+
+// StockTakeCommand is the input for the stock take use case.
+type StockTakeCommand struct {
+	BranchID uuid.UUID
+	Counts   []LotCount
+	ActorID  uuid.UUID
+}
+
+// StockTakeHandler reconciles counted stock with the ledger.
+type StockTakeHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewStockTakeHandler creates a new StockTakeHandler.
+func NewStockTakeHandler(stockRepo inventory.StockRepository) *StockTakeHandler {
+	return &StockTakeHandler{stockRepo: stockRepo}
+}
+
+// Handle records an adjustment for every lot whose count differs from the
+// ledger and returns those adjustments. All adjustments are applied together,
+// against the lots' stock as it stands when they are applied.
+func (h *StockTakeHandler) Handle(ctx context.Context, cmd StockTakeCommand) ([]*inventory.Movement, error) {
+	counts := make([]inventory.Count, 0, len(cmd.Counts))
+	for _, c := range cmd.Counts {
+		if c.CountedGrams < 0 {
+			return nil, inventory.ErrInvalidCount
+		}
+		counts = append(counts, inventory.Count{LotID: c.LotID, CountedGrams: c.CountedGrams})
+	}
+
+	return h.stockRepo.TakeStock(ctx, cmd.BranchID, counts, &cmd.ActorID, time.Now())
+}
@@ -0,0 +1,66 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockTake_PassesCountsToRepository(t *testing.T) {
+	repo := new(mockStockRepository)
+	branchID := uuid.New()
+	actorID := uuid.New()
+	lotA, lotB := uuid.New(), uuid.New()
+	adjustment := &inventory.Movement{}
+	repo.On("TakeStock", mock.Anything, branchID, []inventory.Count{
+		{LotID: lotA, CountedGrams: 4000},
+		{LotID: lotB, CountedGrams: 5200},
+	}, &actorID, mock.Anything).Return([]*inventory.Movement{adjustment}, nil)
+
+	handler := commands.NewStockTakeHandler(repo)
+	adjustments, err := handler.Handle(context.Background(), commands.StockTakeCommand{
+		BranchID: branchID,
+		Counts: []commands.LotCount{
+			{LotID: lotA, CountedGrams: 4000},
+			{LotID: lotB, CountedGrams: 5200},
+		},
+		ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []*inventory.Movement{adjustment}, adjustments)
+	repo.AssertExpectations(t)
+}
+
+func TestStockTake_NegativeCount_ReturnsError(t *testing.T) {
+	repo := new(mockStockRepository)
+
+	handler := commands.NewStockTakeHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.StockTakeCommand{
+		BranchID: uuid.New(),
+		Counts:   []commands.LotCount{{LotID: uuid.New(), CountedGrams: -1}},
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrInvalidCount)
+	repo.AssertNotCalled(t, "TakeStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStockTake_LotFromAnotherBranch_ReturnsError(t *testing.T) {
+	repo := new(mockStockRepository)
+	repo.On("TakeStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, inventory.ErrLotBranchMismatch)
+
+	handler := commands.NewStockTakeHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.StockTakeCommand{
+		BranchID: uuid.New(),
+		Counts:   []commands.LotCount{{LotID: uuid.New(), CountedGrams: 3000}},
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrLotBranchMismatch)
+}
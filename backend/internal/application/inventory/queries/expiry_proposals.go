@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ListExpiryProposalsHandler lists the markdowns and write-offs expiry
+// scans have proposed.
+type ListExpiryProposalsHandler struct {
+	wasteRepo inventory.WasteRepository
+}
+
+// NewListExpiryProposalsHandler creates a new ListExpiryProposalsHandler.
+func NewListExpiryProposalsHandler(wasteRepo inventory.WasteRepository) *ListExpiryProposalsHandler {
+	return &ListExpiryProposalsHandler{wasteRepo: wasteRepo}
+}
+
+// Handle returns proposals matching filter, newest first.
+func (h *ListExpiryProposalsHandler) Handle(ctx context.Context, filter inventory.ProposalFilter) ([]*inventory.ExpiryProposal, error) {
+	proposals, err := h.wasteRepo.FindProposals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying expiry proposals: %w", err)
+	}
+	return proposals, nil
+}
@@ -0,0 +1,38 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+const (
+	defaultMovementLimit = 100
+	maxMovementLimit     = 500
+)
+
+// ListMovementsHandler reads the stock ledger.
+type ListMovementsHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewListMovementsHandler creates a new ListMovementsHandler.
+func NewListMovementsHandler(stockRepo inventory.StockRepository) *ListMovementsHandler {
+	return &ListMovementsHandler{stockRepo: stockRepo}
+}
+
+// Handle returns ledger entries matching filter, newest first. The limit
+// defaults to 100 and is capped at 500.
+func (h *ListMovementsHandler) Handle(ctx context.Context, filter inventory.MovementFilter) ([]*inventory.Movement, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultMovementLimit
+	}
+	filter.Limit = min(filter.Limit, maxMovementLimit)
+
+	movements, err := h.stockRepo.FindMovements(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock movements: %w", err)
+	}
+	return movements, nil
+}
@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ShrinkageQuery is the input for the shrinkage report use case. From and
+// To are the first and last days covered; BranchID limits the report to a
+// branch.
+type ShrinkageQuery struct {
+	BranchID *uuid.UUID
+	From     time.Time
+	To       time.Time
+	GroupBy  inventory.ShrinkageGroup
+}
+
+// ShrinkageReportHandler reports stock lost to waste and stock-take
+// differences over a period, in weight and cost. It is built from the stock
+// ledger, so its figures reconcile with the movements behind them.
+type ShrinkageReportHandler struct {
+	wasteRepo inventory.WasteRepository
+	location  *time.Location
+}
+
+// NewShrinkageReportHandler creates a new ShrinkageReportHandler. Days
+// start and end in location.
+func NewShrinkageReportHandler(wasteRepo inventory.WasteRepository, location *time.Location) *ShrinkageReportHandler {
+	return &ShrinkageReportHandler{wasteRepo: wasteRepo, location: location}
+}
+
+// Handle builds the report over the branches in scope.
+func (h *ShrinkageReportHandler) Handle(ctx context.Context, q ShrinkageQuery) (inventory.ShrinkageReport, error) {
+	if _, err := inventory.ParseShrinkageGroup(string(q.GroupBy)); err != nil {
+		return inventory.ShrinkageReport{}, err
+	}
+	start := time.Date(q.From.Year(), q.From.Month(), q.From.Day(), 0, 0, 0, 0, h.location)
+	end := time.Date(q.To.Year(), q.To.Month(), q.To.Day()+1, 0, 0, 0, 0, h.location)
+	if !end.After(start) {
+		return inventory.ShrinkageReport{}, inventory.ErrInvalidPeriod
+	}
+
+	totals, err := h.wasteRepo.LedgerTotals(ctx, inventory.LedgerFilter{From: start, To: end, BranchID: q.BranchID})
+	if err != nil {
+		return inventory.ShrinkageReport{}, fmt.Errorf("querying ledger totals: %w", err)
+	}
+	return inventory.NewShrinkageReport(q.GroupBy, totals), nil
+}
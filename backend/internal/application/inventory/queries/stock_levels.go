@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// ListStockLevelsQuery narrows the stock report to a branch and, optionally,
+// to products at or below their low-stock threshold.
+type ListStockLevelsQuery struct {
+	BranchID *uuid.UUID
+	LowOnly  bool
+}
+
+// ListStockLevelsHandler reports on-hand, reserved and available stock.
+type ListStockLevelsHandler struct {
+	stockRepo inventory.StockRepository
+}
+
+// NewListStockLevelsHandler creates a new ListStockLevelsHandler.
+func NewListStockLevelsHandler(stockRepo inventory.StockRepository) *ListStockLevelsHandler {
+	return &ListStockLevelsHandler{stockRepo: stockRepo}
+}
+
+// Handle executes the list stock levels query.
+func (h *ListStockLevelsHandler) Handle(ctx context.Context, q ListStockLevelsQuery) ([]inventory.StockLevel, error) {
+	levels, err := h.stockRepo.StockLevels(ctx, q.BranchID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("querying stock levels: %w", err)
+	}
+	if !q.LowOnly {
+		return levels, nil
+	}
+
+	low := make([]inventory.StockLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.IsLow() {
+			low = append(low, l)
+		}
+	}
+	return low, nil
+}
@@ -0,0 +1,103 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/inventory/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStockRepository struct {
+	mock.Mock
+}
+
+func (m *mockStockRepository) ReceiveLot(ctx context.Context, lot *inventory.Lot, received *inventory.Movement) error {
+	args := m.Called(ctx, lot, received)
+	return args.Error(0)
+}
+
+func (m *mockStockRepository) FindLotByID(ctx context.Context, id uuid.UUID) (*inventory.Lot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Lot), args.Error(1)
+}
+
+func (m *mockStockRepository) FindLots(ctx context.Context, productID, branchID uuid.UUID) ([]*inventory.Lot, error) {
+	args := m.Called(ctx, productID, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Lot), args.Error(1)
+}
+
+func (m *mockStockRepository) RecordMovements(ctx context.Context, movements []*inventory.Movement) error {
+	args := m.Called(ctx, movements)
+	return args.Error(0)
+}
+
+func (m *mockStockRepository) TakeStock(ctx context.Context, branchID uuid.UUID, counts []inventory.Count, actorID *uuid.UUID, now time.Time) ([]*inventory.Movement, error) {
+	args := m.Called(ctx, branchID, counts, actorID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Movement), args.Error(1)
+}
+
+func (m *mockStockRepository) FindMovements(ctx context.Context, filter inventory.MovementFilter) ([]*inventory.Movement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Movement), args.Error(1)
+}
+
+func (m *mockStockRepository) StockLevels(ctx context.Context, branchID *uuid.UUID, now time.Time) ([]inventory.StockLevel, error) {
+	args := m.Called(ctx, branchID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.StockLevel), args.Error(1)
+}
+
+func (m *mockStockRepository) SetLowStockThreshold(ctx context.Context, productID uuid.UUID, grams int64) error {
+	args := m.Called(ctx, productID, grams)
+	return args.Error(0)
+}
+
+func TestListStockLevels_LowOnly_FiltersByThreshold(t *testing.T) {
+	repo := new(mockStockRepository)
+	branchID := uuid.New()
+	low := inventory.StockLevel{ProductID: uuid.New(), BranchID: branchID, OnHandGrams: 6000, ReservedGrams: 2000, LowStockThresholdGrams: 5000}
+	healthy := inventory.StockLevel{ProductID: uuid.New(), BranchID: branchID, OnHandGrams: 20000, LowStockThresholdGrams: 5000}
+	untracked := inventory.StockLevel{ProductID: uuid.New(), BranchID: branchID, OnHandGrams: 100}
+	repo.On("StockLevels", mock.Anything, &branchID, mock.Anything).Return([]inventory.StockLevel{low, healthy, untracked}, nil)
+
+	handler := queries.NewListStockLevelsHandler(repo)
+	levels, err := handler.Handle(context.Background(), queries.ListStockLevelsQuery{BranchID: &branchID, LowOnly: true})
+
+	require.NoError(t, err)
+	require.Len(t, levels, 1)
+	assert.Equal(t, low.ProductID, levels[0].ProductID)
+}
+
+func TestListMovements_DefaultsAndCapsLimit(t *testing.T) {
+	repo := new(mockStockRepository)
+	repo.On("FindMovements", mock.Anything, inventory.MovementFilter{Limit: 100}).Return([]*inventory.Movement{}, nil)
+	repo.On("FindMovements", mock.Anything, inventory.MovementFilter{Limit: 500}).Return([]*inventory.Movement{}, nil)
+
+	handler := queries.NewListMovementsHandler(repo)
+	_, err := handler.Handle(context.Background(), inventory.MovementFilter{})
+	require.NoError(t, err)
+	_, err = handler.Handle(context.Background(), inventory.MovementFilter{Limit: 10000})
+	require.NoError(t, err)
+
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// IssueCreditNoteCommand is the input for the issue credit note use case.
+type IssueCreditNoteCommand struct {
+	OrderID  uuid.UUID
+	RefundID uuid.UUID
+	ActorID  uuid.UUID
+}
+
+// IssueCreditNoteHandler raises a credit note against an order's invoice for
+// an issued refund. Each refund gets one credit note; asking again returns
+// it.
+type IssueCreditNoteHandler struct {
+	orderRepo   order.Repository
+	invoiceRepo invoice.Repository
+	renderer    invoice.Renderer
+	auditRepo   audit.Repository
+	settings    Settings
+}
+
+// NewIssueCreditNoteHandler creates a new IssueCreditNoteHandler.
+func NewIssueCreditNoteHandler(
+	orderRepo order.Repository,
+	invoiceRepo invoice.Repository,
+	renderer invoice.Renderer,
+	auditRepo audit.Repository,
+	settings Settings,
+) *IssueCreditNoteHandler {
+	return &IssueCreditNoteHandler{
+		orderRepo:   orderRepo,
+		invoiceRepo: invoiceRepo,
+		renderer:    renderer,
+		auditRepo:   auditRepo,
+		settings:    settings,
+	}
+}
+
+// Handle executes the issue credit note use case.
+func (h *IssueCreditNoteHandler) Handle(ctx context.Context, cmd IssueCreditNoteCommand) (*invoice.Document, error) {
+	existing, err := h.invoiceRepo.FindByRefundID(ctx, cmd.RefundID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, invoice.ErrDocumentNotFound) {
+		return nil, fmt.Errorf("finding credit note: %w", err)
+	}
+
+	o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := o.Refund(cmd.RefundID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status() != order.RefundStatusIssued {
+		return nil, order.ErrRefundNotIssued
+	}
+	inv, err := h.invoiceRepo.FindInvoiceByOrderID(ctx, o.ID())
+	if err != nil {
+		if errors.Is(err, invoice.ErrDocumentNotFound) {
+			return nil, invoice.ErrInvoiceRequired
+		}
+		return nil, fmt.Errorf("finding invoice: %w", err)
+	}
+
+	now := time.Now()
+	cn, err := invoice.NewCreditNote(uuid.New(), inv, r.ID(), creditLines(o, r), h.settings.fiscalYear(now), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.invoiceRepo.Issue(ctx, cn, h.renderer); err != nil {
+		if errors.Is(err, invoice.ErrAlreadyIssued) {
+			return h.invoiceRepo.FindByRefundID(ctx, cmd.RefundID)
+		}
+		return nil, fmt.Errorf("issuing credit note: %w", err)
+	}
+	if err := recordDocument(ctx, h.auditRepo, cmd.ActorID, cn); err != nil {
+		return nil, err
+	}
+	return cn, nil
+}
+
+// creditLines credits a refund against the lines it was for. A
+// weight-difference refund credits the missing weight; a whole-order refund
+// is spread over the lines and any delivery charge in proportion to what
+// they cost. Each line gives
+// back its own VAT in proportion, so crediting a whole line reverses exactly
+// what was charged.
+func creditLines(o *order.Order, r *order.Refund) []invoice.LineInput {
+	if r.LineID() != nil {
+		l, _ := o.Line(*r.LineID())
+		grams := l.Grams
+		if r.Kind() == order.RefundKindWeightDifference {
+			grams -= r.DeliveredGrams()
+		}
+		return []invoice.LineInput{creditLine(l, grams, r.AmountCents())}
+	}
+
+	weights := make([]int64, 0, len(o.Lines())+1)
+	for _, l := range o.Lines() {
+		weights = append(weights, l.TotalCents())
+	}
+	if d := o.Delivery(); d != nil {
+		weights = append(weights, d.GrossCents)
+	}
+	parts := tax.Allocate(r.AmountCents(), weights)
+	lines := make([]invoice.LineInput, 0, len(parts))
+	for i, l := range o.Lines() {
+		if parts[i] == 0 {
+			continue
+		}
+		lines = append(lines, creditLine(l, l.Grams, parts[i]))
+	}
+	if d := o.Delivery(); d != nil && parts[len(o.Lines())] > 0 {
+		lines = append(lines, deliveryLine(d, parts[len(o.Lines())]))
+	}
+	return lines
+}
+
+// deliveryLine is grossCents of an order's delivery charge, with its VAT
+// in proportion.
+func deliveryLine(d *order.DeliveryCharge, grossCents int64) invoice.LineInput {
+	charged := tax.Line{RateBP: d.VATRateBP, NetCents: d.NetCents, VATCents: d.VATCents, GrossCents: d.GrossCents}
+	return invoice.LineInput{
+		Description: "Delivery",
+		VATRateBP:   d.VATRateBP,
+		VATCents:    charged.Portion(grossCents).VATCents,
+		GrossCents:  grossCents,
+	}
+}
+
+func creditLine(l order.Line, grams, grossCents int64) invoice.LineInput {
+	charged := tax.Line{RateBP: l.VATRateBP, NetCents: l.NetCents, VATCents: l.VATCents, GrossCents: l.GrossCents}
+	return invoice.LineInput{
+		Description:     lineDescription(l),
+		Grams:           grams,
+		PricePerKgCents: l.PricePerKgCents,
+		VATRateBP:       l.VATRateBP,
+		VATCents:        charged.Portion(grossCents).VATCents,
+		GrossCents:      grossCents,
+	}
+}
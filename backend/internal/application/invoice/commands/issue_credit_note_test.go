@@ -0,0 +1,122 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/invoice/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// invoiced issues the fixture order's invoice and expects it to be looked
+// up.
+func (f *invoiceFixture) invoiced(t *testing.T) *invoice.Document {
+	t.Helper()
+	var lines []invoice.LineInput
+	for _, l := range f.order.Lines() {
+		lines = append(lines, invoice.LineInput{
+			Description: l.Description, Grams: l.Grams, PricePerKgCents: l.PricePerKgCents,
+			VATRateBP: l.VATRateBP, VATCents: l.VATCents, GrossCents: l.TotalCents(),
+		})
+	}
+	inv, err := invoice.NewInvoice(uuid.New(), f.order.BranchID(), f.order.ID(), f.order.CustomerID(),
+		testSettings.Seller, invoice.Party{Name: "Ali Hassan"}, lines, "gbp", 2026, time.Now())
+	require.NoError(t, err)
+	inv.AssignNumber(7)
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, f.order.ID()).Return(inv, nil)
+	return inv
+}
+
+// refund requests and issues a refund on the fixture order.
+func (f *invoiceFixture) refund(t *testing.T, req order.RefundRequest) *order.Refund {
+	t.Helper()
+	req.Reason = order.ReasonQuality
+	req.IdempotencyKey = uuid.NewString()
+	req.RequestedBy = uuid.New()
+	r, err := f.order.RequestRefund(uuid.New(), req, order.ApprovalPolicy{ThresholdCents: 10000}, time.Now())
+	require.NoError(t, err)
+	_, err = f.order.IssueRefund(r.ID(), uuid.New(), uuid.New(), "RF-000001", time.Now())
+	require.NoError(t, err)
+	f.invoices.On("FindByRefundID", mock.Anything, r.ID()).Return(nil, invoice.ErrDocumentNotFound)
+	return r
+}
+
+func (f *invoiceFixture) creditNoteHandler() *commands.IssueCreditNoteHandler {
+	return commands.NewIssueCreditNoteHandler(f.orders, f.invoices, stubRenderer{}, f.audit, testSettings)
+}
+
+func TestIssueCreditNote_WeightDifference_CreditsMissingWeight(t *testing.T) {
+	f := newInvoiceFixture(t)
+	inv := f.invoiced(t)
+	lineID := f.order.Lines()[1].ID
+	r := f.refund(t, order.RefundRequest{Kind: order.RefundKindWeightDifference, LineID: &lineID, DeliveredGrams: 600})
+	f.invoices.On("Issue", mock.Anything, mock.AnythingOfType("*invoice.Document"), stubRenderer{}).Return(nil)
+
+	cn, err := f.creditNoteHandler().Handle(context.Background(), commands.IssueCreditNoteCommand{
+		OrderID: f.order.ID(), RefundID: r.ID(), ActorID: uuid.New(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, invoice.KindCreditNote, cn.Kind())
+	assert.Equal(t, inv.Number(), cn.CreditedNumber())
+	require.Len(t, cn.Lines(), 1)
+	assert.Equal(t, int64(200), cn.Lines()[0].Grams)
+	assert.Equal(t, 2000, cn.Lines()[0].VATRateBP)
+	assert.Equal(t, r.AmountCents(), cn.GrossCents())
+
+	entry := f.audit.Calls[0].Arguments.Get(1).(*audit.Entry)
+	assert.Equal(t, "credit_note.issued", entry.Action)
+	assert.Equal(t, r.ID().String(), entry.Details["refund_id"])
+}
+
+func TestIssueCreditNote_WholeOrder_SpreadsOverLines(t *testing.T) {
+	f := newInvoiceFixture(t)
+	f.invoiced(t)
+	r := f.refund(t, order.RefundRequest{Kind: order.RefundKindOrder})
+	f.invoices.On("Issue", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	cn, err := f.creditNoteHandler().Handle(context.Background(), commands.IssueCreditNoteCommand{
+		OrderID: f.order.ID(), RefundID: r.ID(),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, cn.Lines(), 2)
+	assert.Equal(t, int64(3000), cn.Lines()[0].GrossCents)
+	assert.Equal(t, int64(900), cn.Lines()[1].GrossCents)
+	assert.Equal(t, int64(150), cn.VATCents())
+}
+
+func TestIssueCreditNote_NotInvoiced_ReturnsError(t *testing.T) {
+	f := newInvoiceFixture(t)
+	r := f.refund(t, order.RefundRequest{Kind: order.RefundKindOrder})
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, f.order.ID()).Return(nil, invoice.ErrDocumentNotFound)
+
+	_, err := f.creditNoteHandler().Handle(context.Background(), commands.IssueCreditNoteCommand{
+		OrderID: f.order.ID(), RefundID: r.ID(),
+	})
+
+	assert.ErrorIs(t, err, invoice.ErrInvoiceRequired)
+}
+
+func TestIssueCreditNote_RefundNotIssued_ReturnsError(t *testing.T) {
+	f := newInvoiceFixture(t)
+	f.invoiced(t)
+	r, err := f.order.RequestRefund(uuid.New(), order.RefundRequest{
+		Kind: order.RefundKindOrder, Reason: order.ReasonQuality, IdempotencyKey: "k", RequestedBy: uuid.New(),
+	}, order.ApprovalPolicy{ThresholdCents: 10000}, time.Now())
+	require.NoError(t, err)
+	f.invoices.On("FindByRefundID", mock.Anything, r.ID()).Return(nil, invoice.ErrDocumentNotFound)
+
+	_, err = f.creditNoteHandler().Handle(context.Background(), commands.IssueCreditNoteCommand{
+		OrderID: f.order.ID(), RefundID: r.ID(),
+	})
+
+	assert.ErrorIs(t, err, order.ErrRefundNotIssued)
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// IssueInvoiceCommand is the input for the issue invoice use case.
+type IssueInvoiceCommand struct {
+	OrderID uuid.UUID
+	ActorID uuid.UUID
+}
+
+// IssueInvoiceHandler invoices an order. An order is only ever invoiced
+// once; asking again returns the invoice already issued.
+type IssueInvoiceHandler struct {
+	orderRepo    order.Repository
+	customerRepo customer.Repository
+	invoiceRepo  invoice.Repository
+	renderer     invoice.Renderer
+	auditRepo    audit.Repository
+	settings     Settings
+}
+
+// NewIssueInvoiceHandler creates a new IssueInvoiceHandler.
+func NewIssueInvoiceHandler(
+	orderRepo order.Repository,
+	customerRepo customer.Repository,
+	invoiceRepo invoice.Repository,
+	renderer invoice.Renderer,
+	auditRepo audit.Repository,
+	settings Settings,
+) *IssueInvoiceHandler {
+	return &IssueInvoiceHandler{
+		orderRepo:    orderRepo,
+		customerRepo: customerRepo,
+		invoiceRepo:  invoiceRepo,
+		renderer:     renderer,
+		auditRepo:    auditRepo,
+		settings:     settings,
+	}
+}
+
+// Handle executes the issue invoice use case.
+func (h *IssueInvoiceHandler) Handle(ctx context.Context, cmd IssueInvoiceCommand) (*invoice.Document, error) {
+	existing, err := h.invoiceRepo.FindInvoiceByOrderID(ctx, cmd.OrderID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, invoice.ErrDocumentNotFound) {
+		return nil, fmt.Errorf("finding invoice: %w", err)
+	}
+
+	o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := h.customerRepo.FindByID(ctx, o.CustomerID())
+	if err != nil {
+		return nil, fmt.Errorf("finding invoiced customer: %w", err)
+	}
+
+	lines := make([]invoice.LineInput, 0, len(o.Lines())+1)
+	for _, l := range o.Lines() {
+		lines = append(lines, invoice.LineInput{
+			Description:     lineDescription(l),
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+			VATRateBP:       l.VATRateBP,
+			VATCents:        l.VATCents,
+			GrossCents:      l.GrossCents,
+		})
+	}
+	if d := o.Delivery(); d != nil {
+		lines = append(lines, deliveryLine(d, d.GrossCents))
+	}
+	now := time.Now()
+	buyer := invoice.Party{Name: c.FullName(), Email: c.Email().String()}
+	d, err := invoice.NewInvoice(uuid.New(), o.BranchID(), o.ID(), o.CustomerID(), h.settings.Seller, buyer, lines,
+		h.settings.Currency, h.settings.fiscalYear(now), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.invoiceRepo.Issue(ctx, d, h.renderer); err != nil {
+		if errors.Is(err, invoice.ErrAlreadyIssued) {
+			return h.invoiceRepo.FindInvoiceByOrderID(ctx, cmd.OrderID)
+		}
+		return nil, fmt.Errorf("issuing invoice: %w", err)
+	}
+	if err := recordDocument(ctx, h.auditRepo, cmd.ActorID, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,292 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/invoice/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockOrderRepository) Create(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) Update(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *mockOrderRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+type mockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *mockCustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCustomerRepository) FindByEmail(ctx context.Context, email customer.Email) (*customer.Customer, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) ExistsByEmail(ctx context.Context, email customer.Email) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockInvoiceRepository struct {
+	mock.Mock
+}
+
+// Issue numbers the document and renders it as the real repository does.
+func (m *mockInvoiceRepository) Issue(ctx context.Context, d *invoice.Document, renderer invoice.Renderer) error {
+	args := m.Called(ctx, d, renderer)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	d.AssignNumber(1)
+	pdf, err := renderer.Render(d)
+	if err != nil {
+		return err
+	}
+	d.AttachPDF(pdf)
+	return nil
+}
+
+func (m *mockInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*invoice.Document, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Document), args.Error(1)
+}
+
+func (m *mockInvoiceRepository) FindInvoiceByOrderID(ctx context.Context, orderID uuid.UUID) (*invoice.Document, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Document), args.Error(1)
+}
+
+func (m *mockInvoiceRepository) FindByRefundID(ctx context.Context, refundID uuid.UUID) (*invoice.Document, error) {
+	args := m.Called(ctx, refundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Document), args.Error(1)
+}
+
+func (m *mockInvoiceRepository) ListByOrderID(ctx context.Context, orderID uuid.UUID) ([]*invoice.Document, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*invoice.Document), args.Error(1)
+}
+
+func (m *mockInvoiceRepository) ListByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*invoice.Document, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*invoice.Document), args.Error(1)
+}
+
+type stubRenderer struct{}
+
+func (stubRenderer) Render(d *invoice.Document) ([]byte, error) {
+	return []byte("%PDF " + d.Number()), nil
+}
+
+type mockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *mockAuditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *mockAuditRepository) FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*audit.Entry, error) {
+	args := m.Called(ctx, entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.Entry), args.Error(1)
+}
+
+// --- Fixtures ---
+
+var testSettings = commands.Settings{
+	Seller:               invoice.Party{Name: "Halal Butchery Ltd", VATNumber: "GB123456789"},
+	Currency:             "GBP",
+	FiscalYearStartMonth: time.April,
+	Location:             time.UTC,
+}
+
+// invoiceFixture is an order of 1.5kg lamb at £20/kg (3000, zero-rated)
+// and 800g mince at £11.25/kg (900, standard-rated).
+type invoiceFixture struct {
+	orders    *mockOrderRepository
+	customers *mockCustomerRepository
+	invoices  *mockInvoiceRepository
+	audit     *mockAuditRepository
+	order     *order.Order
+}
+
+func newInvoiceFixture(t *testing.T) *invoiceFixture {
+	t.Helper()
+	email, err := customer.NewEmail("ali@example.com")
+	require.NoError(t, err)
+	phone, err := customer.NewPhoneNumber("+447700900123")
+	require.NoError(t, err)
+	c, err := customer.NewCustomer(uuid.New(), email, "$2a$10$hash", "Ali Hassan", phone)
+	require.NoError(t, err)
+	o, err := order.NewOrder(uuid.New(), c.ID(), uuid.New(), []order.Line{
+		{ProductID: uuid.New(), Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 2000,
+			TaxCategory: "fresh_meat", NetCents: 3000, GrossCents: 3000},
+		{ProductID: uuid.New(), Grams: 800, PricePerKgCents: 1125,
+			TaxCategory: "prepared_food", VATRateBP: 2000, NetCents: 750, VATCents: 150, GrossCents: 900},
+	}, nil, time.Now())
+	require.NoError(t, err)
+
+	f := &invoiceFixture{
+		orders:    new(mockOrderRepository),
+		customers: new(mockCustomerRepository),
+		invoices:  new(mockInvoiceRepository),
+		audit:     new(mockAuditRepository),
+		order:     o,
+	}
+	f.orders.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	f.customers.On("FindByID", mock.Anything, c.ID()).Return(c, nil)
+	f.audit.On("Append", mock.Anything, mock.AnythingOfType("*audit.Entry")).Return(nil)
+	return f
+}
+
+func (f *invoiceFixture) handler() *commands.IssueInvoiceHandler {
+	return commands.NewIssueInvoiceHandler(f.orders, f.customers, f.invoices, stubRenderer{}, f.audit, testSettings)
+}
+
+// --- Tests ---
+
+func TestIssueInvoice_Success(t *testing.T) {
+	f := newInvoiceFixture(t)
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, f.order.ID()).Return(nil, invoice.ErrDocumentNotFound)
+	f.invoices.On("Issue", mock.Anything, mock.AnythingOfType("*invoice.Document"), stubRenderer{}).Return(nil)
+
+	d, err := f.handler().Handle(context.Background(), commands.IssueInvoiceCommand{OrderID: f.order.ID(), ActorID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, invoice.KindInvoice, d.Kind())
+	assert.Equal(t, f.order.BranchID(), d.BranchID())
+	assert.Equal(t, "Ali Hassan", d.Buyer().Name)
+	assert.Equal(t, "ali@example.com", d.Buyer().Email)
+	assert.Equal(t, "gbp", d.Currency())
+	assert.Equal(t, int64(3900), d.GrossCents())
+	assert.Equal(t, int64(150), d.VATCents(), "only the mince carries VAT")
+	require.Len(t, d.Lines(), 2)
+	assert.Equal(t, "Lamb shoulder", d.Lines()[0].Description)
+	assert.Contains(t, d.Lines()[1].Description, f.order.Lines()[1].ProductID.String())
+	assert.NotEmpty(t, d.Checksum())
+
+	entry := f.audit.Calls[0].Arguments.Get(1).(*audit.Entry)
+	assert.Equal(t, "invoice.issued", entry.Action)
+	assert.Equal(t, d.Number(), entry.Details["number"])
+}
+
+func TestIssueInvoice_Delivered_InvoicesTheDeliveryCharge(t *testing.T) {
+	f := newInvoiceFixture(t)
+	require.NoError(t, f.order.ChargeDelivery(order.DeliveryCharge{ZoneID: uuid.New(), TaxCategory: "delivery", FeeCents: 499,
+		VATRateBP: 2000, NetCents: 416, VATCents: 83, GrossCents: 499}))
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, f.order.ID()).Return(nil, invoice.ErrDocumentNotFound)
+	f.invoices.On("Issue", mock.Anything, mock.AnythingOfType("*invoice.Document"), stubRenderer{}).Return(nil)
+
+	d, err := f.handler().Handle(context.Background(), commands.IssueInvoiceCommand{OrderID: f.order.ID(), ActorID: uuid.New()})
+
+	require.NoError(t, err)
+	require.Len(t, d.Lines(), 3)
+	assert.Equal(t, "Delivery", d.Lines()[2].Description)
+	assert.Equal(t, int64(499), d.Lines()[2].GrossCents)
+	assert.Equal(t, int64(4399), d.GrossCents())
+	assert.Equal(t, int64(233), d.VATCents())
+}
+
+func TestIssueInvoice_AlreadyInvoiced_ReturnsExisting(t *testing.T) {
+	f := newInvoiceFixture(t)
+	existing, err := invoice.NewInvoice(uuid.New(), f.order.BranchID(), f.order.ID(), f.order.CustomerID(),
+		invoice.Party{}, invoice.Party{}, []invoice.LineInput{{GrossCents: 3900}}, "gbp", 2026, time.Now())
+	require.NoError(t, err)
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, f.order.ID()).Return(existing, nil)
+
+	d, err := f.handler().Handle(context.Background(), commands.IssueInvoiceCommand{OrderID: f.order.ID()})
+
+	require.NoError(t, err)
+	assert.Same(t, existing, d)
+	f.invoices.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	f.audit.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
+
+func TestIssueInvoice_OrderNotFound_ReturnsError(t *testing.T) {
+	f := newInvoiceFixture(t)
+	orderID := uuid.New()
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, orderID).Return(nil, invoice.ErrDocumentNotFound)
+	f.orders.On("FindByID", mock.Anything, orderID).Return(nil, order.ErrOrderNotFound)
+
+	_, err := f.handler().Handle(context.Background(), commands.IssueInvoiceCommand{OrderID: orderID})
+
+	assert.ErrorIs(t, err, order.ErrOrderNotFound)
+}
+
+func TestIssueInvoice_IssueFails_ReturnsError(t *testing.T) {
+	f := newInvoiceFixture(t)
+	f.invoices.On("FindInvoiceByOrderID", mock.Anything, f.order.ID()).Return(nil, invoice.ErrDocumentNotFound)
+	f.invoices.On("Issue", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	_, err := f.handler().Handle(context.Background(), commands.IssueInvoiceCommand{OrderID: f.order.ID()})
+
+	assert.Error(t, err)
+	f.audit.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// Settings describe the shop as it appears on its documents. Fiscal years
+// start on the first of FiscalYearStartMonth in Location.
+type Settings struct {
+	Seller               invoice.Party
+	Currency             string
+	FiscalYearStartMonth time.Month
+	Location             *time.Location
+}
+
+func (s Settings) fiscalYear(t time.Time) int {
+	return invoice.FiscalYear(t.In(s.Location), s.FiscalYearStartMonth)
+}
+
+// lineDescription is what an order line is called on a document. Lines
+// recorded without a description fall back to their product ID.
+func lineDescription(l order.Line) string {
+	if l.Description != "" {
+		return l.Description
+	}
+	return "Product " + l.ProductID.String()
+}
+
+func recordDocument(ctx context.Context, repo audit.Repository, actorID uuid.UUID, d *invoice.Document) error {
+	details := map[string]string{
+		"document_id": d.ID().String(),
+		"number":      d.Number(),
+		"gross_cents": strconv.FormatInt(d.GrossCents(), 10),
+		"vat_cents":   strconv.FormatInt(d.VATCents(), 10),
+		"sha256":      d.Checksum(),
+	}
+	if d.RefundID() != nil {
+		details["refund_id"] = d.RefundID().String()
+	}
+
+	e, err := audit.NewEntry(uuid.New(), actorID, string(d.Kind())+".issued", "order", d.OrderID(), details, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := repo.Append(ctx, e); err != nil {
+		return fmt.Errorf("recording audit entry: %w", err)
+	}
+	return nil
+}
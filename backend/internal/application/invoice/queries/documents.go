@@ -0,0 +1,70 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+)
+
+// ListOrderDocumentsHandler returns the invoice and credit notes issued for
+// an order, oldest first.
+type ListOrderDocumentsHandler struct {
+	invoiceRepo invoice.Repository
+}
+
+// NewListOrderDocumentsHandler creates a new ListOrderDocumentsHandler.
+func NewListOrderDocumentsHandler(invoiceRepo invoice.Repository) *ListOrderDocumentsHandler {
+	return &ListOrderDocumentsHandler{invoiceRepo: invoiceRepo}
+}
+
+// Handle executes the list order documents query.
+func (h *ListOrderDocumentsHandler) Handle(ctx context.Context, orderID uuid.UUID) ([]*invoice.Document, error) {
+	return h.invoiceRepo.ListByOrderID(ctx, orderID)
+}
+
+// ListCustomerDocumentsHandler returns every document issued to a customer,
+// newest first.
+type ListCustomerDocumentsHandler struct {
+	invoiceRepo invoice.Repository
+}
+
+// NewListCustomerDocumentsHandler creates a new ListCustomerDocumentsHandler.
+func NewListCustomerDocumentsHandler(invoiceRepo invoice.Repository) *ListCustomerDocumentsHandler {
+	return &ListCustomerDocumentsHandler{invoiceRepo: invoiceRepo}
+}
+
+// Handle executes the list customer documents query.
+func (h *ListCustomerDocumentsHandler) Handle(ctx context.Context, customerID uuid.UUID) ([]*invoice.Document, error) {
+	return h.invoiceRepo.ListByCustomerID(ctx, customerID)
+}
+
+// GetDocumentHandler returns a document with the PDF stored when it was
+// issued.
+type GetDocumentHandler struct {
+	invoiceRepo invoice.Repository
+}
+
+// NewGetDocumentHandler creates a new GetDocumentHandler.
+func NewGetDocumentHandler(invoiceRepo invoice.Repository) *GetDocumentHandler {
+	return &GetDocumentHandler{invoiceRepo: invoiceRepo}
+}
+
+// Handle executes the get document query for staff, who may see any
+// document.
+func (h *GetDocumentHandler) Handle(ctx context.Context, id uuid.UUID) (*invoice.Document, error) {
+	return h.invoiceRepo.FindByID(ctx, id)
+}
+
+// HandleForCustomer executes the get document query for a customer.
+// Another customer's document is reported as not found.
+func (h *GetDocumentHandler) HandleForCustomer(ctx context.Context, id, customerID uuid.UUID) (*invoice.Document, error) {
+	d, err := h.invoiceRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.CustomerID() != customerID {
+		return nil, invoice.ErrDocumentNotFound
+	}
+	return d, nil
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// CreatePrinterCommand is the input for the create label printer use case.
+type CreatePrinterCommand struct {
+	Name    string
+	Address string
+}
+
+// CreatePrinterHandler registers a label printer on the shop's network.
+type CreatePrinterHandler struct {
+	printerRepo label.PrinterRepository
+}
+
+// NewCreatePrinterHandler creates a new CreatePrinterHandler.
+func NewCreatePrinterHandler(printerRepo label.PrinterRepository) *CreatePrinterHandler {
+	return &CreatePrinterHandler{printerRepo: printerRepo}
+}
+
+// Handle executes the create label printer use case. A name already in use
+// returns ErrDuplicatePrinter.
+func (h *CreatePrinterHandler) Handle(ctx context.Context, cmd CreatePrinterCommand) (*label.Printer, error) {
+	p, err := label.NewPrinter(uuid.New(), cmd.Name, cmd.Address, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.printerRepo.Save(ctx, p); err != nil {
+		if errors.Is(err, label.ErrDuplicatePrinter) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving printer: %w", err)
+	}
+	return p, nil
+}
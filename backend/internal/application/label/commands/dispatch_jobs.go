@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// DispatchResult counts what became of the jobs a dispatch run sent.
+type DispatchResult struct {
+	Printed  int
+	Retrying int
+	Failed   int
+}
+
+// DispatchJobsHandler sends every queued print job that is due.
+type DispatchJobsHandler struct {
+	sender sender
+}
+
+// NewDispatchJobsHandler creates a new DispatchJobsHandler.
+func NewDispatchJobsHandler(
+	printerRepo label.PrinterRepository,
+	jobRepo label.JobRepository,
+	transport label.Transport,
+	settings Settings,
+) *DispatchJobsHandler {
+	return &DispatchJobsHandler{
+		sender: sender{printerRepo: printerRepo, jobRepo: jobRepo, transport: transport, settings: settings},
+	}
+}
+
+// Handle sends the jobs due as of now, oldest first, one at a time, until
+// none is left. Each job is claimed before it is sent, so runs that overlap
+// never send the same job twice.
+func (h *DispatchJobsHandler) Handle(ctx context.Context, now time.Time) (DispatchResult, error) {
+	var result DispatchResult
+	for {
+		j, err := h.sender.jobRepo.ClaimNext(ctx, h.sender.settings.Lease, now)
+		if errors.Is(err, label.ErrNoJobDue) {
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("claiming print job: %w", err)
+		}
+		if err := h.sender.send(ctx, j); err != nil {
+			return result, err
+		}
+		switch j.Status() {
+		case label.JobPrinted:
+			result.Printed++
+		case label.JobFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/label/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockPrinterRepository struct {
+	mock.Mock
+}
+
+func (m *mockPrinterRepository) Save(ctx context.Context, p *label.Printer) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *mockPrinterRepository) FindByID(ctx context.Context, id uuid.UUID) (*label.Printer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*label.Printer), args.Error(1)
+}
+
+func (m *mockPrinterRepository) FindAll(ctx context.Context) ([]*label.Printer, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*label.Printer), args.Error(1)
+}
+
+type mockJobRepository struct {
+	mock.Mock
+}
+
+func (m *mockJobRepository) Save(ctx context.Context, j *label.Job) error {
+	args := m.Called(ctx, j)
+	return args.Error(0)
+}
+
+func (m *mockJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*label.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*label.Job), args.Error(1)
+}
+
+func (m *mockJobRepository) FindAll(ctx context.Context, filter label.JobFilter) ([]*label.Job, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*label.Job), args.Error(1)
+}
+
+func (m *mockJobRepository) ClaimNext(ctx context.Context, lease time.Duration, now time.Time) (*label.Job, error) {
+	args := m.Called(ctx, lease, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*label.Job), args.Error(1)
+}
+
+type mockTransport struct {
+	mock.Mock
+}
+
+func (m *mockTransport) Send(ctx context.Context, address string, payload []byte) error {
+	args := m.Called(ctx, address, payload)
+	return args.Error(0)
+}
+
+// --- Fixtures ---
+
+var settings = commands.Settings{Lease: time.Minute, RetryAfter: 30 * time.Second, MaxAttempts: 2}
+
+func newPrinter(t *testing.T, address string) *label.Printer {
+	t.Helper()
+	p, err := label.NewPrinter(uuid.New(), "Counter "+address, address, time.Now())
+	require.NoError(t, err)
+	return p
+}
+
+// claimedJob is a job for p as ClaimNext returns it, on its given attempt.
+func claimedJob(t *testing.T, p *label.Printer, payload string, attempt int) *label.Job {
+	t.Helper()
+	now := time.Now()
+	j, err := label.NewJob(uuid.New(), p.ID(), label.SourceLot, uuid.New(), "standard", 1, []byte(payload), now)
+	require.NoError(t, err)
+	for range attempt {
+		j.Attempt(settings.Lease, now)
+	}
+	return j
+}
+
+// --- Tests ---
+
+func TestDispatchJobs_SendsEveryDueJob(t *testing.T) {
+	printers := new(mockPrinterRepository)
+	jobs := new(mockJobRepository)
+	transport := new(mockTransport)
+
+	up, down := newPrinter(t, "10.0.0.5"), newPrinter(t, "10.0.0.6")
+	printed := claimedJob(t, up, "^XA^XZ", 1)
+	retrying := claimedJob(t, down, "^XA1^XZ", 1)
+	failed := claimedJob(t, down, "^XA2^XZ", 2)
+	now := time.Now()
+
+	printers.On("FindByID", mock.Anything, up.ID()).Return(up, nil)
+	printers.On("FindByID", mock.Anything, down.ID()).Return(down, nil)
+	jobs.On("ClaimNext", mock.Anything, time.Minute, now).Return(printed, nil).Once()
+	jobs.On("ClaimNext", mock.Anything, time.Minute, now).Return(retrying, nil).Once()
+	jobs.On("ClaimNext", mock.Anything, time.Minute, now).Return(failed, nil).Once()
+	jobs.On("ClaimNext", mock.Anything, time.Minute, now).Return(nil, label.ErrNoJobDue)
+	jobs.On("Save", mock.Anything, mock.Anything).Return(nil)
+	transport.On("Send", mock.Anything, "10.0.0.5:9100", mock.Anything).Return(nil)
+	transport.On("Send", mock.Anything, "10.0.0.6:9100", mock.Anything).Return(errors.New("connection refused"))
+
+	handler := commands.NewDispatchJobsHandler(printers, jobs, transport, settings)
+	result, err := handler.Handle(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, commands.DispatchResult{Printed: 1, Retrying: 1, Failed: 1}, result)
+	assert.Equal(t, label.JobPrinted, printed.Status())
+	assert.Equal(t, label.JobQueued, retrying.Status())
+	assert.Equal(t, "connection refused", retrying.LastError())
+	assert.Equal(t, label.JobFailed, failed.Status())
+}
+
+func TestDispatchJobs_DisabledPrinter_FailsAttempt(t *testing.T) {
+	printers := new(mockPrinterRepository)
+	jobs := new(mockJobRepository)
+	transport := new(mockTransport)
+
+	p := newPrinter(t, "10.0.0.5")
+	require.NoError(t, p.Update(p.Name(), p.Address(), false, time.Now()))
+	j := claimedJob(t, p, "^XA^XZ", 1)
+	now := time.Now()
+
+	printers.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+	jobs.On("ClaimNext", mock.Anything, time.Minute, now).Return(j, nil).Once()
+	jobs.On("ClaimNext", mock.Anything, time.Minute, now).Return(nil, label.ErrNoJobDue)
+	jobs.On("Save", mock.Anything, j).Return(nil)
+
+	handler := commands.NewDispatchJobsHandler(printers, jobs, transport, settings)
+	result, err := handler.Handle(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+	assert.Equal(t, label.ErrPrinterInactive.Error(), j.LastError())
+	transport.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetryJob(t *testing.T) {
+	printers := new(mockPrinterRepository)
+	jobs := new(mockJobRepository)
+	transport := new(mockTransport)
+
+	p := newPrinter(t, "10.0.0.5")
+	j := claimedJob(t, p, "^XA^XZ", 2)
+	j.Failed("connection refused", settings.RetryAfter, settings.MaxAttempts, time.Now())
+	require.Equal(t, label.JobFailed, j.Status())
+
+	queued := claimedJob(t, p, "^XA^XZ", 0)
+
+	printers.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+	jobs.On("FindByID", mock.Anything, j.ID()).Return(j, nil)
+	jobs.On("FindByID", mock.Anything, queued.ID()).Return(queued, nil)
+	jobs.On("Save", mock.Anything, j).Return(nil)
+	transport.On("Send", mock.Anything, "10.0.0.5:9100", []byte("^XA^XZ")).Return(nil)
+
+	handler := commands.NewRetryJobHandler(printers, jobs, transport, settings)
+	retried, err := handler.Handle(context.Background(), j.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, label.JobPrinted, retried.Status())
+	assert.Equal(t, 1, retried.Attempts())
+	assert.Empty(t, retried.LastError())
+
+	_, err = handler.Handle(context.Background(), queued.ID())
+	assert.ErrorIs(t, err, label.ErrJobNotFailed)
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/labelling"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// PrintLabelsCommand is the input for the print labels use case. An empty
+// Template uses the default one.
+type PrintLabelsCommand struct {
+	PrinterID uuid.UUID
+	Source    labelling.Source
+	Template  string
+}
+
+// PrintLabelsHandler renders labels to ZPL and queues them for a printer.
+type PrintLabelsHandler struct {
+	labeller *labelling.Labeller
+	renderer label.Renderer
+	sender   sender
+}
+
+// NewPrintLabelsHandler creates a new PrintLabelsHandler that renders with
+// renderer and sends through transport.
+func NewPrintLabelsHandler(
+	labeller *labelling.Labeller,
+	renderer label.Renderer,
+	printerRepo label.PrinterRepository,
+	jobRepo label.JobRepository,
+	transport label.Transport,
+	settings Settings,
+) *PrintLabelsHandler {
+	return &PrintLabelsHandler{
+		labeller: labeller,
+		renderer: renderer,
+		sender:   sender{printerRepo: printerRepo, jobRepo: jobRepo, transport: transport, settings: settings},
+	}
+}
+
+// Handle executes the print labels use case. The job is sent straight
+// away; if the printer does not take it, it stays queued for the
+// dispatcher to retry, so the returned job says which happened.
+func (h *PrintLabelsHandler) Handle(ctx context.Context, cmd PrintLabelsCommand) (*label.Job, error) {
+	p, err := h.sender.printerRepo.FindByID(ctx, cmd.PrinterID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Active() {
+		return nil, label.ErrPrinterInactive
+	}
+	tmpl, err := h.labeller.Template(cmd.Template)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	labels, err := h.labeller.Labels(ctx, cmd.Source, now)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := h.renderer.Render(tmpl, labels)
+	if err != nil {
+		return nil, fmt.Errorf("rendering labels: %w", err)
+	}
+
+	source, sourceID := cmd.Source.Job()
+	j, err := label.NewJob(uuid.New(), p.ID(), source, sourceID, tmpl.Name, len(labels), payload, now)
+	if err != nil {
+		return nil, err
+	}
+	j.Attempt(h.sender.settings.Lease, now)
+	if err := h.sender.jobRepo.Save(ctx, j); err != nil {
+		return nil, fmt.Errorf("saving print job: %w", err)
+	}
+	if err := h.sender.send(ctx, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// RetryJobHandler puts a print job that was given up on back in the queue
+// and sends it again.
+type RetryJobHandler struct {
+	sender sender
+}
+
+// NewRetryJobHandler creates a new RetryJobHandler.
+func NewRetryJobHandler(
+	printerRepo label.PrinterRepository,
+	jobRepo label.JobRepository,
+	transport label.Transport,
+	settings Settings,
+) *RetryJobHandler {
+	return &RetryJobHandler{
+		sender: sender{printerRepo: printerRepo, jobRepo: jobRepo, transport: transport, settings: settings},
+	}
+}
+
+// Handle executes the retry print job use case. Only a failed job can be
+// retried; it gets a fresh set of attempts.
+func (h *RetryJobHandler) Handle(ctx context.Context, jobID uuid.UUID) (*label.Job, error) {
+	j, err := h.sender.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := j.Retry(now); err != nil {
+		return nil, err
+	}
+	j.Attempt(h.sender.settings.Lease, now)
+	if err := h.sender.jobRepo.Save(ctx, j); err != nil {
+		return nil, fmt.Errorf("saving print job: %w", err)
+	}
+	if err := h.sender.send(ctx, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// Settings say how the print queue sends jobs. A job being sent is not
+// picked up again for Lease, a failed send is retried after RetryAfter, and
+// a job is given up on once it has had MaxAttempts sends.
+type Settings struct {
+	Lease       time.Duration
+	RetryAfter  time.Duration
+	MaxAttempts int
+}
+
+// sender sends jobs to their printers and records how it went.
+type sender struct {
+	printerRepo label.PrinterRepository
+	jobRepo     label.JobRepository
+	transport   label.Transport
+	settings    Settings
+}
+
+// send sends a job whose attempt has already been saved. A printer that is
+// disabled or cannot be reached fails the attempt rather than the call;
+// only a failure to record the outcome is returned.
+func (s sender) send(ctx context.Context, j *label.Job) error {
+	if err := s.deliver(ctx, j); err != nil {
+		j.Failed(err.Error(), s.settings.RetryAfter, s.settings.MaxAttempts, time.Now())
+	} else {
+		j.Printed(time.Now())
+	}
+	if err := s.jobRepo.Save(ctx, j); err != nil {
+		return fmt.Errorf("saving print job: %w", err)
+	}
+	return nil
+}
+
+func (s sender) deliver(ctx context.Context, j *label.Job) error {
+	p, err := s.printerRepo.FindByID(ctx, j.PrinterID())
+	if err != nil {
+		return err
+	}
+	if !p.Active() {
+		return label.ErrPrinterInactive
+	}
+	return s.transport.Send(ctx, p.Address(), j.Payload())
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// UpdatePrinterCommand is the input for the update label printer use case.
+type UpdatePrinterCommand struct {
+	PrinterID uuid.UUID
+	Name      string
+	Address   string
+	Active    bool
+}
+
+// UpdatePrinterHandler renames, moves or disables a label printer.
+type UpdatePrinterHandler struct {
+	printerRepo label.PrinterRepository
+}
+
+// NewUpdatePrinterHandler creates a new UpdatePrinterHandler.
+func NewUpdatePrinterHandler(printerRepo label.PrinterRepository) *UpdatePrinterHandler {
+	return &UpdatePrinterHandler{printerRepo: printerRepo}
+}
+
+// Handle executes the update label printer use case.
+func (h *UpdatePrinterHandler) Handle(ctx context.Context, cmd UpdatePrinterCommand) (*label.Printer, error) {
+	p, err := h.printerRepo.FindByID(ctx, cmd.PrinterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Update(cmd.Name, cmd.Address, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.printerRepo.Save(ctx, p); err != nil {
+		if errors.Is(err, label.ErrDuplicatePrinter) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving printer: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// ListJobsHandler lists the print queue.
+type ListJobsHandler struct {
+	jobRepo label.JobRepository
+}
+
+// NewListJobsHandler creates a new ListJobsHandler.
+func NewListJobsHandler(jobRepo label.JobRepository) *ListJobsHandler {
+	return &ListJobsHandler{jobRepo: jobRepo}
+}
+
+// Handle returns the jobs matching filter, newest first.
+func (h *ListJobsHandler) Handle(ctx context.Context, filter label.JobFilter) ([]*label.Job, error) {
+	jobs, err := h.jobRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing print jobs: %w", err)
+	}
+	return jobs, nil
+}
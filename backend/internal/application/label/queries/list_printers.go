@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// ListPrintersHandler lists the label printers.
+type ListPrintersHandler struct {
+	printerRepo label.PrinterRepository
+}
+
+// NewListPrintersHandler creates a new ListPrintersHandler.
+func NewListPrintersHandler(printerRepo label.PrinterRepository) *ListPrintersHandler {
+	return &ListPrintersHandler{printerRepo: printerRepo}
+}
+
+// Handle returns every printer by name.
+func (h *ListPrintersHandler) Handle(ctx context.Context) ([]*label.Printer, error) {
+	printers, err := h.printerRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing printers: %w", err)
+	}
+	return printers, nil
+}
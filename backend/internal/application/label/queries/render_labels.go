@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/application/labelling"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// RenderLabelsHandler renders labels for download, as ZPL to send to a
+// printer by hand or as PDF for an office printer.
+type RenderLabelsHandler struct {
+	labeller  *labelling.Labeller
+	renderers map[label.Format]label.Renderer
+}
+
+// NewRenderLabelsHandler creates a new RenderLabelsHandler.
+func NewRenderLabelsHandler(labeller *labelling.Labeller, zpl, pdf label.Renderer) *RenderLabelsHandler {
+	return &RenderLabelsHandler{
+		labeller:  labeller,
+		renderers: map[label.Format]label.Renderer{label.FormatZPL: zpl, label.FormatPDF: pdf},
+	}
+}
+
+// Handle renders the labels for src in format with the named template, or
+// the default one if template is empty.
+func (h *RenderLabelsHandler) Handle(ctx context.Context, src labelling.Source, format label.Format, template string) ([]byte, error) {
+	renderer, ok := h.renderers[format]
+	if !ok {
+		return nil, label.ErrInvalidFormat
+	}
+	tmpl, err := h.labeller.Template(template)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := h.labeller.Labels(ctx, src, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	out, err := renderer.Render(tmpl, labels)
+	if err != nil {
+		return nil, fmt.Errorf("rendering labels: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,288 @@
+package labelling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+)
+
+// Source is what labels are made for: every line of an order, or packs of
+// the given weights cut from an inventory lot. Exactly one of OrderID and
+// LotID is set; Packs is only used for a lot.
+type Source struct {
+	OrderID *uuid.UUID
+	LotID   *uuid.UUID
+	Packs   []int64
+}
+
+// Job is how a print job records where its labels came from.
+func (s Source) Job() (label.Source, uuid.UUID) {
+	if s.OrderID != nil {
+		return label.SourceOrder, *s.OrderID
+	}
+	return label.SourceLot, *s.LotID
+}
+
+// Labeller works out what goes on each label: the product and its PLU
+// item's allergens and shelf life, the weight and price, the lots the meat
+// came from and whether their halal certificates cover them.
+type Labeller struct {
+	orderRepo       order.Repository
+	stockRepo       inventory.StockRepository
+	originRepo      traceability.OriginRepository
+	itemRepo        plu.Repository
+	layouts         barcode.Layouts
+	defaultTemplate string
+	currency        string
+	location        *time.Location
+}
+
+// NewLabeller creates a new Labeller. Prices are shown in currency, dates
+// in location, and labels use defaultTemplate unless another is asked for.
+func NewLabeller(
+	orderRepo order.Repository,
+	stockRepo inventory.StockRepository,
+	originRepo traceability.OriginRepository,
+	itemRepo plu.Repository,
+	layouts barcode.Layouts,
+	defaultTemplate, currency string,
+	location *time.Location,
+) *Labeller {
+	return &Labeller{
+		orderRepo:       orderRepo,
+		stockRepo:       stockRepo,
+		originRepo:      originRepo,
+		itemRepo:        itemRepo,
+		layouts:         layouts,
+		defaultTemplate: defaultTemplate,
+		currency:        currency,
+		location:        location,
+	}
+}
+
+// Template returns the named label template, or the default one if name is
+// empty.
+func (l *Labeller) Template(name string) (label.Template, error) {
+	if name == "" {
+		name = l.defaultTemplate
+	}
+	return label.FindTemplate(name)
+}
+
+// Labels returns the labels for src, packed as of now.
+func (l *Labeller) Labels(ctx context.Context, src Source, now time.Time) ([]label.Label, error) {
+	if src.OrderID != nil {
+		return l.orderLabels(ctx, *src.OrderID)
+	}
+	if src.LotID != nil {
+		return l.lotLabels(ctx, *src.LotID, src.Packs, now)
+	}
+	return nil, label.ErrNoLabels
+}
+
+// orderLabels makes one label per line of an order, packed the day it was
+// placed. A line's lot codes and use-by date come from the lots picked for
+// it, and it is halal only if every one of them is certified.
+func (l *Labeller) orderLabels(ctx context.Context, orderID uuid.UUID) ([]label.Label, error) {
+	o, err := l.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	consumed, err := l.originRepo.FindConsumedLots(ctx, o.ID())
+	if err != nil {
+		return nil, fmt.Errorf("finding consumed lots: %w", err)
+	}
+	lotsByLine := make(map[uuid.UUID][]traceability.ConsumedLot)
+	for _, c := range consumed {
+		lotsByLine[c.LineID] = append(lotsByLine[c.LineID], c)
+	}
+
+	packedOn := l.date(o.CreatedAt())
+	labels := make([]label.Label, 0, len(o.Lines()))
+	for _, line := range o.Lines() {
+		item, err := l.findItem(ctx, line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		lb := label.Label{
+			Description:     line.Description,
+			Grams:           line.Grams,
+			PricePerKgCents: line.PricePerKgCents,
+			TotalCents:      line.GrossCents,
+			Currency:        l.currency,
+			PackedOn:        packedOn,
+			Allergens:       []string{},
+		}
+
+		var codes []string
+		halal := len(lotsByLine[line.ID]) > 0
+		for _, c := range lotsByLine[line.ID] {
+			codes = append(codes, c.LotCode)
+			lot, err := l.stockRepo.FindLotByID(ctx, c.LotID)
+			if err != nil {
+				return nil, fmt.Errorf("finding lot %s: %w", c.LotCode, err)
+			}
+			if e := lot.ExpiresOn(); e != nil && (lb.UseBy == nil || e.Before(*lb.UseBy)) {
+				lb.UseBy = e
+			}
+			verified, err := l.halal(ctx, c.LotID, c.LotCode)
+			if err != nil {
+				return nil, err
+			}
+			halal = halal && verified
+		}
+		sort.Strings(codes)
+		lb.LotCode = strings.Join(codes, ", ")
+		lb.Halal = halal
+
+		if item != nil {
+			lb.Allergens = allergenNames(item.Allergens())
+			if lb.UseBy == nil {
+				lb.UseBy = item.UseBy(packedOn)
+			}
+			lb.Barcode = l.barcode(item, line.Grams, line.GrossCents)
+		}
+		labels = append(labels, lb)
+	}
+	if len(labels) == 0 {
+		return nil, label.ErrNoLabels
+	}
+	return labels, nil
+}
+
+// lotLabels makes a label for each pack weighed out of a lot, priced at its
+// PLU item's price.
+func (l *Labeller) lotLabels(ctx context.Context, lotID uuid.UUID, packs []int64, now time.Time) ([]label.Label, error) {
+	if len(packs) == 0 {
+		return nil, label.ErrNoLabels
+	}
+	var total int64
+	for _, g := range packs {
+		if g <= 0 {
+			return nil, label.ErrInvalidPackWeight
+		}
+		total += g
+	}
+
+	lot, err := l.stockRepo.FindLotByID(ctx, lotID)
+	if err != nil {
+		return nil, err
+	}
+	if total > lot.OnHandGrams() {
+		return nil, label.ErrPacksExceedLot
+	}
+	item, err := l.findItem(ctx, lot.ProductID())
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, label.ErrProductNotOnPLU
+	}
+	if !item.Active() {
+		return nil, plu.ErrItemInactive
+	}
+	halal, err := l.halal(ctx, lot.ID(), lot.Code())
+	if err != nil {
+		return nil, err
+	}
+
+	packedOn := l.date(now)
+	useBy := lot.ExpiresOn()
+	if useBy == nil {
+		useBy = item.UseBy(packedOn)
+	}
+	labels := make([]label.Label, 0, len(packs))
+	for _, g := range packs {
+		pack, err := item.PackOfWeight(g)
+		if err != nil {
+			return nil, err
+		}
+		// A lot marked down near its use-by date is labelled, and its
+		// barcode priced, at the reduced price.
+		priceCents := lot.MarkedDown(pack.PriceCents)
+		labels = append(labels, label.Label{
+			Description:     item.Description(),
+			Grams:           pack.Grams,
+			PricePerKgCents: lot.MarkedDown(item.PricePerKgCents()),
+			TotalCents:      priceCents,
+			Currency:        l.currency,
+			PackedOn:        packedOn,
+			UseBy:           useBy,
+			LotCode:         lot.Code(),
+			Allergens:       allergenNames(item.Allergens()),
+			Halal:           halal,
+			Barcode:         l.barcode(item, pack.Grams, priceCents),
+		})
+	}
+	return labels, nil
+}
+
+// findItem returns the PLU item a product is sold under, or nil if it has
+// none.
+func (l *Labeller) findItem(ctx context.Context, productID uuid.UUID) (*plu.Item, error) {
+	item, err := l.itemRepo.FindByProduct(ctx, productID)
+	if errors.Is(err, plu.ErrItemNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("finding PLU item: %w", err)
+	}
+	return item, nil
+}
+
+// halal reports whether a lot has a recorded origin covered by a registered
+// halal certificate.
+func (l *Labeller) halal(ctx context.Context, lotID uuid.UUID, lotCode string) (bool, error) {
+	traces, err := l.originRepo.FindTraces(ctx, lotCode)
+	if err != nil {
+		return false, fmt.Errorf("finding lot traces: %w", err)
+	}
+	for _, t := range traces {
+		if t.LotID == lotID {
+			return t.HalalVerified(), nil
+		}
+	}
+	return false, nil
+}
+
+// barcode encodes a pack's price, or its weight if the store has no price
+// layout, so tills charge what the label says. It is empty if neither
+// layout is configured or the value does not fit.
+func (l *Labeller) barcode(item *plu.Item, grams, priceCents int64) string {
+	if layout, err := l.layouts.For(barcode.Price); err == nil {
+		if code, err := layout.Encode(item.Code(), priceCents); err == nil {
+			return code
+		}
+	}
+	if layout, err := l.layouts.For(barcode.Weight); err == nil {
+		if code, err := layout.Encode(item.Code(), grams); err == nil {
+			return code
+		}
+	}
+	return ""
+}
+
+// date is the calendar day t falls on in the shop's time zone.
+func (l *Labeller) date(t time.Time) time.Time {
+	y, m, d := t.In(l.location).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func allergenNames(allergens []plu.Allergen) []string {
+	names := make([]string, len(allergens))
+	for i, a := range allergens {
+		names[i] = string(a)
+	}
+	return names
+}
@@ -0,0 +1,318 @@
+package labelling_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/labelling"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockOrderRepository) Create(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) Update(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *mockOrderRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+type mockStockRepository struct {
+	mock.Mock
+}
+
+func (m *mockStockRepository) ReceiveLot(ctx context.Context, lot *inventory.Lot, received *inventory.Movement) error {
+	args := m.Called(ctx, lot, received)
+	return args.Error(0)
+}
+
+func (m *mockStockRepository) FindLotByID(ctx context.Context, id uuid.UUID) (*inventory.Lot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Lot), args.Error(1)
+}
+
+func (m *mockStockRepository) FindLots(ctx context.Context, productID, branchID uuid.UUID) ([]*inventory.Lot, error) {
+	args := m.Called(ctx, productID, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Lot), args.Error(1)
+}
+
+func (m *mockStockRepository) RecordMovements(ctx context.Context, movements []*inventory.Movement) error {
+	args := m.Called(ctx, movements)
+	return args.Error(0)
+}
+
+func (m *mockStockRepository) TakeStock(ctx context.Context, branchID uuid.UUID, counts []inventory.Count, actorID *uuid.UUID, now time.Time) ([]*inventory.Movement, error) {
+	args := m.Called(ctx, branchID, counts, actorID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Movement), args.Error(1)
+}
+
+func (m *mockStockRepository) FindMovements(ctx context.Context, filter inventory.MovementFilter) ([]*inventory.Movement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Movement), args.Error(1)
+}
+
+func (m *mockStockRepository) StockLevels(ctx context.Context, branchID *uuid.UUID, now time.Time) ([]inventory.StockLevel, error) {
+	args := m.Called(ctx, branchID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.StockLevel), args.Error(1)
+}
+
+func (m *mockStockRepository) SetLowStockThreshold(ctx context.Context, productID uuid.UUID, grams int64) error {
+	args := m.Called(ctx, productID, grams)
+	return args.Error(0)
+}
+
+type mockOriginRepository struct {
+	mock.Mock
+}
+
+func (m *mockOriginRepository) SaveOrigin(ctx context.Context, o *traceability.Origin) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOriginRepository) FindTraces(ctx context.Context, lotCode string) ([]traceability.Trace, error) {
+	args := m.Called(ctx, lotCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]traceability.Trace), args.Error(1)
+}
+
+func (m *mockOriginRepository) FindConsumedLots(ctx context.Context, orderID uuid.UUID) ([]traceability.ConsumedLot, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]traceability.ConsumedLot), args.Error(1)
+}
+
+type mockItemRepository struct {
+	mock.Mock
+}
+
+func (m *mockItemRepository) Save(ctx context.Context, i *plu.Item) error {
+	args := m.Called(ctx, i)
+	return args.Error(0)
+}
+
+func (m *mockItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByCode(ctx context.Context, code int) (*plu.Item, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByProduct(ctx context.Context, productID uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindAll(ctx context.Context) ([]*plu.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*plu.Item), args.Error(1)
+}
+
+// --- Fixtures ---
+
+type labellerFixture struct {
+	orders  *mockOrderRepository
+	stock   *mockStockRepository
+	origins *mockOriginRepository
+	items   *mockItemRepository
+	labels  *labelling.Labeller
+}
+
+func newLabellerFixture(t *testing.T) *labellerFixture {
+	t.Helper()
+	layouts, err := barcode.ParseLayouts([]string{"20IIIIIWWWWWC", "21IIIIIPPPPPC"})
+	require.NoError(t, err)
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	f := &labellerFixture{
+		orders:  new(mockOrderRepository),
+		stock:   new(mockStockRepository),
+		origins: new(mockOriginRepository),
+		items:   new(mockItemRepository),
+	}
+	f.labels = labelling.NewLabeller(f.orders, f.stock, f.origins, f.items, layouts, "standard", "gbp", london)
+	return f
+}
+
+func newLabelledItem(t *testing.T, productID uuid.UUID) *plu.Item {
+	t.Helper()
+	now := time.Now()
+	i, err := plu.NewItem(uuid.New(), 123, productID, "Lamb mince", 1299, now)
+	require.NoError(t, err)
+	require.NoError(t, i.SetLabelling([]plu.Allergen{plu.AllergenSulphites, plu.AllergenCelery}, 3, now))
+	return i
+}
+
+// --- Tests ---
+
+func TestLabeller_LotPacks(t *testing.T) {
+	f := newLabellerFixture(t)
+	productID := uuid.New()
+	lot := inventory.ReconstructLot(uuid.New(), productID, uuid.New(), "LAMB-0412", 5000, 2000, nil, 0, 0, time.Now())
+	f.stock.On("FindLotByID", mock.Anything, lot.ID()).Return(lot, nil)
+	f.items.On("FindByProduct", mock.Anything, productID).Return(newLabelledItem(t, productID), nil)
+	f.origins.On("FindTraces", mock.Anything, "LAMB-0412").Return([]traceability.Trace{}, nil)
+
+	// 23:30 UTC on a summer night is already the next day in London.
+	now := time.Date(2026, 7, 1, 23, 30, 0, 0, time.UTC)
+	id := lot.ID()
+	labels, err := f.labels.Labels(context.Background(), labelling.Source{LotID: &id, Packs: []int64{1250, 500}}, now)
+
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	first := labels[0]
+	assert.Equal(t, "Lamb mince", first.Description)
+	assert.Equal(t, int64(1250), first.Grams)
+	assert.Equal(t, int64(1624), first.TotalCents)
+	assert.Equal(t, "2100123016242", first.Barcode)
+	assert.Equal(t, "LAMB-0412", first.LotCode)
+	assert.Equal(t, []string{"celery", "sulphites"}, first.Allergens)
+	assert.False(t, first.Halal)
+	assert.Equal(t, time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC), first.PackedOn)
+	require.NotNil(t, first.UseBy)
+	assert.Equal(t, time.Date(2026, 7, 5, 0, 0, 0, 0, time.UTC), *first.UseBy)
+	assert.Equal(t, int64(650), labels[1].TotalCents)
+}
+
+func TestLabeller_LotPacks_MarkedDown(t *testing.T) {
+	f := newLabellerFixture(t)
+	productID := uuid.New()
+	lot := inventory.ReconstructLot(uuid.New(), productID, uuid.New(), "LAMB-0412", 5000, 2000, nil, 0, 3000, time.Now())
+	f.stock.On("FindLotByID", mock.Anything, lot.ID()).Return(lot, nil)
+	f.items.On("FindByProduct", mock.Anything, productID).Return(newLabelledItem(t, productID), nil)
+	f.origins.On("FindTraces", mock.Anything, "LAMB-0412").Return([]traceability.Trace{}, nil)
+
+	id := lot.ID()
+	labels, err := f.labels.Labels(context.Background(), labelling.Source{LotID: &id, Packs: []int64{1250}}, time.Now())
+
+	require.NoError(t, err)
+	require.Len(t, labels, 1)
+	assert.Equal(t, int64(909), labels[0].PricePerKgCents)
+	assert.Equal(t, int64(1137), labels[0].TotalCents)
+	assert.Equal(t, "210012301137", labels[0].Barcode[:12], "the barcode carries the reduced price")
+}
+
+func TestLabeller_LotPacks_Rejected(t *testing.T) {
+	f := newLabellerFixture(t)
+	productID := uuid.New()
+	lot := inventory.ReconstructLot(uuid.New(), productID, uuid.New(), "LAMB-0412", 5000, 1000, nil, 0, 0, time.Now())
+	f.stock.On("FindLotByID", mock.Anything, lot.ID()).Return(lot, nil)
+	id := lot.ID()
+
+	_, err := f.labels.Labels(context.Background(), labelling.Source{LotID: &id}, time.Now())
+	assert.ErrorIs(t, err, label.ErrNoLabels)
+
+	_, err = f.labels.Labels(context.Background(), labelling.Source{LotID: &id, Packs: []int64{500, 0}}, time.Now())
+	assert.ErrorIs(t, err, label.ErrInvalidPackWeight)
+
+	_, err = f.labels.Labels(context.Background(), labelling.Source{LotID: &id, Packs: []int64{600, 600}}, time.Now())
+	assert.ErrorIs(t, err, label.ErrPacksExceedLot)
+
+	f.items.On("FindByProduct", mock.Anything, productID).Return(nil, plu.ErrItemNotFound)
+	_, err = f.labels.Labels(context.Background(), labelling.Source{LotID: &id, Packs: []int64{500}}, time.Now())
+	assert.ErrorIs(t, err, label.ErrProductNotOnPLU)
+}
+
+func TestLabeller_OrderLines(t *testing.T) {
+	f := newLabellerFixture(t)
+	lamb, beef := uuid.New(), uuid.New()
+	placed := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	o, err := order.NewOrder(uuid.New(), uuid.New(), uuid.New(), []order.Line{
+		{ProductID: lamb, Description: "Lamb mince", Grams: 1250, PricePerKgCents: 1299, NetCents: 1624, GrossCents: 1624},
+		{ProductID: beef, Description: "Beef brisket", Grams: 2000, PricePerKgCents: 1500, NetCents: 3000, GrossCents: 3000},
+	}, nil, placed)
+	require.NoError(t, err)
+
+	lotID := uuid.New()
+	expires := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+	f.orders.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	f.origins.On("FindConsumedLots", mock.Anything, o.ID()).Return([]traceability.ConsumedLot{
+		{LineID: o.Lines()[1].ID, ProductID: beef, LotID: lotID, LotCode: "BEEF-0412", Grams: 2000},
+	}, nil)
+	f.origins.On("FindTraces", mock.Anything, "BEEF-0412").Return([]traceability.Trace{}, nil)
+	f.stock.On("FindLotByID", mock.Anything, lotID).
+		Return(inventory.ReconstructLot(lotID, beef, uuid.New(), "BEEF-0412", 20000, 18000, &expires, 0, 0, placed), nil)
+	f.items.On("FindByProduct", mock.Anything, lamb).Return(newLabelledItem(t, lamb), nil)
+	f.items.On("FindByProduct", mock.Anything, beef).Return(nil, plu.ErrItemNotFound)
+
+	id := o.ID()
+	labels, err := f.labels.Labels(context.Background(), labelling.Source{OrderID: &id}, time.Now())
+
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	lambLabel, beefLabel := labels[0], labels[1]
+	assert.Equal(t, "2100123016242", lambLabel.Barcode)
+	assert.Equal(t, time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), *lambLabel.UseBy)
+	assert.Empty(t, lambLabel.LotCode)
+
+	assert.Equal(t, "BEEF-0412", beefLabel.LotCode)
+	assert.Equal(t, expires, *beefLabel.UseBy)
+	assert.Empty(t, beefLabel.Barcode)
+	assert.Empty(t, beefLabel.Allergens)
+	assert.Equal(t, int64(3000), beefLabel.TotalCents)
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// AdjustPointsCommand is the input for the adjust points use case. Points
+// may be negative to take points off.
+type AdjustPointsCommand struct {
+	CustomerID uuid.UUID
+	Points     int64
+	Note       string
+	ActorID    uuid.UUID
+}
+
+// AdjustPointsHandler lets managers credit or debit points by hand, e.g. as a
+// goodwill gesture, with a note saying why.
+type AdjustPointsHandler struct {
+	customerRepo customer.Repository
+	ledger       loyalty.Ledger
+	rewards      *pricing.Rewards
+	adminRepo    admin.Repository
+}
+
+// NewAdjustPointsHandler creates a new AdjustPointsHandler.
+func NewAdjustPointsHandler(customerRepo customer.Repository, ledger loyalty.Ledger, rewards *pricing.Rewards, adminRepo admin.Repository) *AdjustPointsHandler {
+	return &AdjustPointsHandler{customerRepo: customerRepo, ledger: ledger, rewards: rewards, adminRepo: adminRepo}
+}
+
+// Handle executes the adjust points use case. Credits expire like earned
+// points; a debit that would take the balance below zero is refused.
+func (h *AdjustPointsHandler) Handle(ctx context.Context, cmd AdjustPointsCommand) (*loyalty.Entry, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	if _, err := h.customerRepo.FindByID(ctx, cmd.CustomerID); err != nil {
+		return nil, err
+	}
+	rules, err := h.rewards.Rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if cmd.Points > 0 {
+		expiresAt = rules.ExpiresAt(now)
+	} else if _, err := h.rewards.Expire(ctx, cmd.CustomerID, now); err != nil {
+		return nil, err
+	}
+	e, err := loyalty.NewEntry(uuid.New(), cmd.CustomerID, loyalty.KindAdjust, cmd.Points, "", nil, expiresAt, cmd.Note, &cmd.ActorID, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.ledger.Append(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// AwardBirthdayBonusesCommand is the input for the award birthday bonuses
+// use case.
+type AwardBirthdayBonusesCommand struct {
+	Date string // YYYY-MM-DD; empty means today
+}
+
+// AwardBirthdayBonusesHandler credits the birthday bonus to every customer
+// whose birthday is on a given day. It is meant to run daily; each customer
+// gets the bonus at most once a year however often it runs.
+type AwardBirthdayBonusesHandler struct {
+	memberRepo loyalty.MemberRepository
+	ledger     loyalty.Ledger
+	rewards    *pricing.Rewards
+	location   *time.Location
+}
+
+// NewAwardBirthdayBonusesHandler creates a new AwardBirthdayBonusesHandler.
+func NewAwardBirthdayBonusesHandler(memberRepo loyalty.MemberRepository, ledger loyalty.Ledger, rewards *pricing.Rewards, location *time.Location) *AwardBirthdayBonusesHandler {
+	return &AwardBirthdayBonusesHandler{memberRepo: memberRepo, ledger: ledger, rewards: rewards, location: location}
+}
+
+// Handle executes the award birthday bonuses use case and returns how many
+// customers were awarded the bonus by this run. Days are the shop's
+// calendar days.
+func (h *AwardBirthdayBonusesHandler) Handle(ctx context.Context, cmd AwardBirthdayBonusesCommand) (int, error) {
+	now := time.Now()
+	date := now.In(h.location)
+	if cmd.Date != "" {
+		d, err := time.ParseInLocation(time.DateOnly, cmd.Date, h.location)
+		if err != nil {
+			return 0, loyalty.ErrInvalidDate
+		}
+		date = d
+	}
+
+	rules, err := h.rewards.Rules(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if rules.BirthdayBonus == 0 {
+		return 0, nil
+	}
+	customerIDs, err := h.memberRepo.FindByBirthday(ctx, date)
+	if err != nil {
+		return 0, fmt.Errorf("finding birthdays: %w", err)
+	}
+
+	year := strconv.Itoa(date.Year())
+	awarded := 0
+	for _, id := range customerIDs {
+		e, err := loyalty.NewEntry(uuid.New(), id, loyalty.KindBirthday, rules.BirthdayBonus, year, nil, rules.ExpiresAt(now), "", nil, now)
+		if err != nil {
+			return awarded, err
+		}
+		if err := h.ledger.Append(ctx, e); err != nil {
+			if errors.Is(err, loyalty.ErrDuplicateEntry) {
+				continue
+			}
+			return awarded, fmt.Errorf("awarding birthday bonus to customer %s: %w", id, err)
+		}
+		awarded++
+	}
+	return awarded, nil
+}
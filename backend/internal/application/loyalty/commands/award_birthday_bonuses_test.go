@@ -0,0 +1,163 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/loyalty/commands"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockLedger struct {
+	mock.Mock
+}
+
+func (m *mockLedger) Append(ctx context.Context, entries ...*loyalty.Entry) error {
+	return m.Called(ctx, entries).Error(0)
+}
+
+func (m *mockLedger) Entries(ctx context.Context, customerID uuid.UUID) ([]*loyalty.Entry, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*loyalty.Entry), args.Error(1)
+}
+
+func (m *mockLedger) Balance(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(loyalty.Balance), args.Error(1)
+}
+
+func (m *mockLedger) Rebuild(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(loyalty.Balance), args.Error(1)
+}
+
+func (m *mockLedger) CustomersWithExpiring(ctx context.Context, at time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type mockRulesRepository struct {
+	mock.Mock
+}
+
+func (m *mockRulesRepository) Find(ctx context.Context) (loyalty.Rules, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(loyalty.Rules), args.Error(1)
+}
+
+func (m *mockRulesRepository) Save(ctx context.Context, r loyalty.Rules) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRulesRepository) SetProductBonus(ctx context.Context, productID uuid.UUID, points int64) error {
+	return m.Called(ctx, productID, points).Error(0)
+}
+
+type mockMemberRepository struct {
+	mock.Mock
+}
+
+func (m *mockMemberRepository) SetBirthday(ctx context.Context, customerID uuid.UUID, b loyalty.Birthday) error {
+	return m.Called(ctx, customerID, b).Error(0)
+}
+
+func (m *mockMemberRepository) FindBirthday(ctx context.Context, customerID uuid.UUID) (*loyalty.Birthday, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*loyalty.Birthday), args.Error(1)
+}
+
+func (m *mockMemberRepository) FindByBirthday(ctx context.Context, date time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+// --- Tests ---
+
+func TestAwardBirthdayBonusesHandler_AwardsOncePerYear(t *testing.T) {
+	members := new(mockMemberRepository)
+	ledger := new(mockLedger)
+	rules := new(mockRulesRepository)
+	handler := commands.NewAwardBirthdayBonusesHandler(members, ledger, pricing.NewRewards(rules, ledger), time.UTC)
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	fresh, already := uuid.New(), uuid.New()
+
+	rules.On("Find", mock.Anything).Return(loyalty.Rules{UnitCents: 100, BirthdayBonus: 500, ExpiryDays: 90}, nil)
+	members.On("FindByBirthday", mock.Anything, date).Return([]uuid.UUID{fresh, already}, nil)
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return es[0].CustomerID == fresh && es[0].Kind == loyalty.KindBirthday && es[0].Points == 500 &&
+			es[0].Reference == "2026" && es[0].ExpiresAt != nil
+	})).Return(nil)
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return es[0].CustomerID == already
+	})).Return(loyalty.ErrDuplicateEntry)
+
+	awarded, err := handler.Handle(context.Background(), commands.AwardBirthdayBonusesCommand{Date: "2026-10-19"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, awarded)
+	ledger.AssertNumberOfCalls(t, "Append", 2)
+}
+
+func TestAwardBirthdayBonusesHandler_NoBonusConfigured(t *testing.T) {
+	members := new(mockMemberRepository)
+	ledger := new(mockLedger)
+	rules := new(mockRulesRepository)
+	handler := commands.NewAwardBirthdayBonusesHandler(members, ledger, pricing.NewRewards(rules, ledger), time.UTC)
+
+	rules.On("Find", mock.Anything).Return(loyalty.Rules{UnitCents: 100}, nil)
+
+	awarded, err := handler.Handle(context.Background(), commands.AwardBirthdayBonusesCommand{})
+
+	require.NoError(t, err)
+	assert.Zero(t, awarded)
+	members.AssertNotCalled(t, "FindByBirthday", mock.Anything, mock.Anything)
+}
+
+func TestAwardBirthdayBonusesHandler_InvalidDate(t *testing.T) {
+	handler := commands.NewAwardBirthdayBonusesHandler(new(mockMemberRepository), new(mockLedger), nil, time.UTC)
+
+	_, err := handler.Handle(context.Background(), commands.AwardBirthdayBonusesCommand{Date: "19/10/2026"})
+
+	assert.ErrorIs(t, err, loyalty.ErrInvalidDate)
+}
+
+func TestExpirePointsHandler(t *testing.T) {
+	ledger := new(mockLedger)
+	handler := commands.NewExpirePointsHandler(ledger, pricing.NewRewards(new(mockRulesRepository), ledger))
+	at := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	customerID := uuid.New()
+	lapsed := at.Add(-time.Hour)
+	earn := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindEarn, Points: 120, ExpiresAt: &lapsed}
+	redeem := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindRedeem, Points: -20}
+
+	ledger.On("CustomersWithExpiring", mock.Anything, at).Return([]uuid.UUID{customerID}, nil)
+	ledger.On("Entries", mock.Anything, customerID).Return([]*loyalty.Entry{earn, redeem}, nil)
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Points == -100 && *es[0].SourceID == earn.ID
+	})).Return(nil)
+
+	run, err := handler.Handle(context.Background(), commands.ExpirePointsCommand{At: at})
+
+	require.NoError(t, err)
+	assert.Equal(t, commands.ExpiryRun{Customers: 1, Points: 100}, run)
+}
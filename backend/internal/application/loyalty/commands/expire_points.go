@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// ExpirePointsCommand is the input for the expire points use case.
+type ExpirePointsCommand struct {
+	At time.Time
+}
+
+// ExpiryRun is what one run of the expire points use case took off.
+type ExpiryRun struct {
+	Customers int
+	Points    int64
+}
+
+// ExpirePointsHandler takes expired points off every customer's balance. It
+// is meant to run daily; running it more often, or twice, does no harm.
+type ExpirePointsHandler struct {
+	ledger  loyalty.Ledger
+	rewards *pricing.Rewards
+}
+
+// NewExpirePointsHandler creates a new ExpirePointsHandler.
+func NewExpirePointsHandler(ledger loyalty.Ledger, rewards *pricing.Rewards) *ExpirePointsHandler {
+	return &ExpirePointsHandler{ledger: ledger, rewards: rewards}
+}
+
+// Handle executes the expire points use case.
+func (h *ExpirePointsHandler) Handle(ctx context.Context, cmd ExpirePointsCommand) (ExpiryRun, error) {
+	customerIDs, err := h.ledger.CustomersWithExpiring(ctx, cmd.At)
+	if err != nil {
+		return ExpiryRun{}, fmt.Errorf("finding expiring points: %w", err)
+	}
+
+	var run ExpiryRun
+	for _, id := range customerIDs {
+		points, err := h.rewards.Expire(ctx, id, cmd.At)
+		if err != nil {
+			return run, fmt.Errorf("expiring points for customer %s: %w", id, err)
+		}
+		if points > 0 {
+			run.Customers++
+			run.Points += points
+		}
+	}
+	return run, nil
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// RebuildBalanceHandler recomputes a customer's balance from their ledger
+// entries, e.g. after a projection bug has been fixed.
+type RebuildBalanceHandler struct {
+	customerRepo customer.Repository
+	ledger       loyalty.Ledger
+}
+
+// NewRebuildBalanceHandler creates a new RebuildBalanceHandler.
+func NewRebuildBalanceHandler(customerRepo customer.Repository, ledger loyalty.Ledger) *RebuildBalanceHandler {
+	return &RebuildBalanceHandler{customerRepo: customerRepo, ledger: ledger}
+}
+
+// Handle executes the rebuild balance use case.
+func (h *RebuildBalanceHandler) Handle(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	if _, err := h.customerRepo.FindByID(ctx, customerID); err != nil {
+		return loyalty.Balance{}, err
+	}
+	b, err := h.ledger.Rebuild(ctx, customerID)
+	if err != nil {
+		return loyalty.Balance{}, fmt.Errorf("rebuilding loyalty balance: %w", err)
+	}
+	return b, nil
+}
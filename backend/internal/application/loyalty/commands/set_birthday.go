@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// SetBirthdayCommand is the input for the set birthday use case.
+type SetBirthdayCommand struct {
+	CustomerID uuid.UUID
+	Month      int
+	Day        int
+}
+
+// SetBirthdayHandler records the day a customer gets their birthday bonus.
+type SetBirthdayHandler struct {
+	memberRepo loyalty.MemberRepository
+}
+
+// NewSetBirthdayHandler creates a new SetBirthdayHandler.
+func NewSetBirthdayHandler(memberRepo loyalty.MemberRepository) *SetBirthdayHandler {
+	return &SetBirthdayHandler{memberRepo: memberRepo}
+}
+
+// Handle executes the set birthday use case. Only the day and month are
+// kept.
+func (h *SetBirthdayHandler) Handle(ctx context.Context, cmd SetBirthdayCommand) (loyalty.Birthday, error) {
+	b, err := loyalty.NewBirthday(cmd.Month, cmd.Day)
+	if err != nil {
+		return loyalty.Birthday{}, err
+	}
+	if err := h.memberRepo.SetBirthday(ctx, cmd.CustomerID, b); err != nil {
+		return loyalty.Birthday{}, fmt.Errorf("saving birthday: %w", err)
+	}
+	return b, nil
+}
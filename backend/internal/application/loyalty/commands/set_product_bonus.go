@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// SetProductBonusCommand is the input for the set product bonus use case.
+// Points are extra points per unit spent on the product; zero removes the
+// bonus.
+type SetProductBonusCommand struct {
+	ProductID uuid.UUID
+	Points    int64
+	ActorID   uuid.UUID
+}
+
+// SetProductBonusHandler makes a product earn more points than the base
+// rate, e.g. to push a new cut.
+type SetProductBonusHandler struct {
+	rulesRepo loyalty.RulesRepository
+	adminRepo admin.Repository
+}
+
+// NewSetProductBonusHandler creates a new SetProductBonusHandler.
+func NewSetProductBonusHandler(rulesRepo loyalty.RulesRepository, adminRepo admin.Repository) *SetProductBonusHandler {
+	return &SetProductBonusHandler{rulesRepo: rulesRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the set product bonus use case. Only managers may set
+// bonuses.
+func (h *SetProductBonusHandler) Handle(ctx context.Context, cmd SetProductBonusCommand) error {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return err
+	}
+	if cmd.Points < 0 {
+		return loyalty.ErrNegativeRule
+	}
+	if err := h.rulesRepo.SetProductBonus(ctx, cmd.ProductID, cmd.Points); err != nil {
+		return fmt.Errorf("setting product bonus: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// UpdateRulesCommand is the input for the update loyalty rules use case.
+type UpdateRulesCommand struct {
+	PointsPerUnit   int64
+	UnitCents       int64
+	PointValueCents int64
+	MinRedeemPoints int64
+	BirthdayBonus   int64
+	ExpiryDays      int
+	ActorID         uuid.UUID
+}
+
+// UpdateRulesHandler changes how customers earn and spend points. Points
+// already on the ledger keep the expiry they were given.
+type UpdateRulesHandler struct {
+	rulesRepo loyalty.RulesRepository
+	adminRepo admin.Repository
+}
+
+// NewUpdateRulesHandler creates a new UpdateRulesHandler.
+func NewUpdateRulesHandler(rulesRepo loyalty.RulesRepository, adminRepo admin.Repository) *UpdateRulesHandler {
+	return &UpdateRulesHandler{rulesRepo: rulesRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the update loyalty rules use case and returns the rules
+// now in force, product bonuses included. Only managers may change them.
+func (h *UpdateRulesHandler) Handle(ctx context.Context, cmd UpdateRulesCommand) (loyalty.Rules, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return loyalty.Rules{}, err
+	}
+	rules, err := loyalty.NewRules(loyalty.Rules{
+		PointsPerUnit:   cmd.PointsPerUnit,
+		UnitCents:       cmd.UnitCents,
+		PointValueCents: cmd.PointValueCents,
+		MinRedeemPoints: cmd.MinRedeemPoints,
+		BirthdayBonus:   cmd.BirthdayBonus,
+		ExpiryDays:      cmd.ExpiryDays,
+	})
+	if err != nil {
+		return loyalty.Rules{}, err
+	}
+
+	if err := h.rulesRepo.Save(ctx, rules); err != nil {
+		return loyalty.Rules{}, fmt.Errorf("saving loyalty rules: %w", err)
+	}
+	saved, err := h.rulesRepo.Find(ctx)
+	if err != nil {
+		return loyalty.Rules{}, fmt.Errorf("loading loyalty rules: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,119 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/loyalty/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+// --- Fixtures ---
+
+// actor returns an admin repository holding an admin of the given role,
+// and the admin's ID.
+func actor(t *testing.T, role admin.Role) (*mockAdminRepository, uuid.UUID) {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), string(role)+"@butchery.com", "$2a$10$hash", "Sam Ahmed", role)
+	require.NoError(t, err)
+	admins := new(mockAdminRepository)
+	admins.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return admins, a.ID()
+}
+
+// --- Tests ---
+
+func TestUpdateRules_SavesAndReturnsTheRules(t *testing.T) {
+	rules := new(mockRulesRepository)
+	saved := loyalty.Rules{PointsPerUnit: 1, UnitCents: 100, PointValueCents: 1, ExpiryDays: 365}
+	rules.On("Save", mock.Anything, saved).Return(nil)
+	rules.On("Find", mock.Anything).Return(saved, nil)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	got, err := commands.NewUpdateRulesHandler(rules, admins).Handle(context.Background(), commands.UpdateRulesCommand{
+		PointsPerUnit: 1, UnitCents: 100, PointValueCents: 1, ExpiryDays: 365, ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+	rules.AssertExpectations(t)
+}
+
+func TestUpdateRules_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	rules := new(mockRulesRepository)
+	admins, actorID := actor(t, admin.RoleStaff)
+
+	_, err := commands.NewUpdateRulesHandler(rules, admins).Handle(context.Background(), commands.UpdateRulesCommand{
+		PointsPerUnit: 100, UnitCents: 1, ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, loyalty.ErrManageNotAllowed)
+	rules.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestSetProductBonus_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	rules := new(mockRulesRepository)
+	admins, actorID := actor(t, admin.RoleStaff)
+
+	err := commands.NewSetProductBonusHandler(rules, admins).Handle(context.Background(), commands.SetProductBonusCommand{
+		ProductID: uuid.New(), Points: 5, ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, loyalty.ErrManageNotAllowed)
+	rules.AssertNotCalled(t, "SetProductBonus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdjustPoints_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	ledger := new(mockLedger)
+	admins, actorID := actor(t, admin.RoleCashier)
+
+	_, err := commands.NewAdjustPointsHandler(nil, ledger, nil, admins).Handle(context.Background(), commands.AdjustPointsCommand{
+		CustomerID: uuid.New(), Points: 1000, Note: "goodwill", ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, loyalty.ErrManageNotAllowed)
+	ledger.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
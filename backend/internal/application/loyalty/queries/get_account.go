@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// Account is a customer's points: the balance, what it is worth at
+// checkout, the entries behind it and the birthday on file, if any.
+type Account struct {
+	Balance    loyalty.Balance
+	ValueCents int64
+	Entries    []*loyalty.Entry
+	Birthday   *loyalty.Birthday
+}
+
+// GetAccountHandler returns a customer's loyalty account.
+type GetAccountHandler struct {
+	ledger     loyalty.Ledger
+	rulesRepo  loyalty.RulesRepository
+	memberRepo loyalty.MemberRepository
+}
+
+// NewGetAccountHandler creates a new GetAccountHandler.
+func NewGetAccountHandler(ledger loyalty.Ledger, rulesRepo loyalty.RulesRepository, memberRepo loyalty.MemberRepository) *GetAccountHandler {
+	return &GetAccountHandler{ledger: ledger, rulesRepo: rulesRepo, memberRepo: memberRepo}
+}
+
+// Handle returns the account, with entries oldest first.
+func (h *GetAccountHandler) Handle(ctx context.Context, customerID uuid.UUID) (*Account, error) {
+	balance, err := h.ledger.Balance(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := h.ledger.Entries(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := h.rulesRepo.Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading loyalty rules: %w", err)
+	}
+	birthday, err := h.memberRepo.FindBirthday(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		Balance:    balance,
+		ValueCents: balance.Points * rules.PointValueCents,
+		Entries:    entries,
+		Birthday:   birthday,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// GetRulesHandler returns the loyalty rules for the back office.
+type GetRulesHandler struct {
+	rulesRepo loyalty.RulesRepository
+}
+
+// NewGetRulesHandler creates a new GetRulesHandler.
+func NewGetRulesHandler(rulesRepo loyalty.RulesRepository) *GetRulesHandler {
+	return &GetRulesHandler{rulesRepo: rulesRepo}
+}
+
+// Handle returns the rules with every product bonus.
+func (h *GetRulesHandler) Handle(ctx context.Context) (loyalty.Rules, error) {
+	rules, err := h.rulesRepo.Find(ctx)
+	if err != nil {
+		return loyalty.Rules{}, fmt.Errorf("loading loyalty rules: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// AdvanceStageCommand is the input for the advance stage use case.
+type AdvanceStageCommand struct {
+	OrderID uuid.UUID
+	Stage   string
+	ActorID *uuid.UUID
+}
+
+// AdvanceStageHandler moves an order on through the cutting room, e.g.
+// from cutting to ready for collection. Customers watching the order see
+// the change as it happens.
+type AdvanceStageHandler struct {
+	orderRepo order.Repository
+	stockRepo inventory.ReservationRepository
+	rewards   *pricing.Rewards
+}
+
+// NewAdvanceStageHandler creates a new AdvanceStageHandler.
+func NewAdvanceStageHandler(orderRepo order.Repository, stockRepo inventory.ReservationRepository, rewards *pricing.Rewards) *AdvanceStageHandler {
+	return &AdvanceStageHandler{orderRepo: orderRepo, stockRepo: stockRepo, rewards: rewards}
+}
+
+// Handle executes the advance stage use case. Once an order is ready the
+// stock confirmed for it has left the shelf, so it is sold from the lots
+// it is taken from, as a till sale is, before the order moves on. Once it
+// is completed the customer earns points on what they paid for its lines,
+// less anything already refunded; they are taken back if the order cannot
+// be saved.
+func (h *AdvanceStageHandler) Handle(ctx context.Context, cmd AdvanceStageCommand) (*order.Order, error) {
+	stage, err := order.ParseStage(cmd.Stage)
+	if err != nil {
+		return nil, err
+	}
+	o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := o.Advance(stage, now); err != nil {
+		return nil, err
+	}
+	if stage == order.StageReady || stage == order.StageCompleted {
+		if err := h.fulfil(ctx, o.ID(), cmd.ActorID, now); err != nil {
+			return nil, err
+		}
+	}
+	var rules loyalty.Rules
+	var earned []*loyalty.Entry
+	if stage == order.StageCompleted {
+		if rules, err = h.rewards.Rules(ctx); err != nil {
+			return nil, err
+		}
+		if earned, err = h.earn(ctx, rules, o, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := saveOrder(ctx, h.orderRepo, o); err != nil {
+		_ = h.rewards.Reverse(ctx, rules, earned, "order could not be saved", now)
+		return nil, err
+	}
+	return o, nil
+}
+
+// earn credits the points a completed order earns. An order only ever
+// earns once, so completing it again after a failed save earns nothing
+// more.
+func (h *AdvanceStageHandler) earn(ctx context.Context, rules loyalty.Rules, o *order.Order, now time.Time) ([]*loyalty.Entry, error) {
+	toEarn := make([]loyalty.Line, 0, len(o.Lines()))
+	for _, l := range o.Lines() {
+		toEarn = append(toEarn, loyalty.Line{ProductID: l.ProductID, AmountCents: l.GrossCents - l.RefundedCents})
+	}
+	entries, err := h.rewards.Record(ctx, rules, o.ID(), o.CustomerID(), 0, rules.Earn(toEarn), now)
+	if err != nil {
+		if errors.Is(err, loyalty.ErrDuplicateEntry) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("earning loyalty points: %w", err)
+	}
+	return entries, nil
+}
+
+// fulfil sells the stock confirmed for an order. Stock already sold when
+// the order was made ready is not sold again on completion.
+func (h *AdvanceStageHandler) fulfil(ctx context.Context, orderID uuid.UUID, actorID *uuid.UUID, now time.Time) error {
+	holds, err := h.stockRepo.FindByOrder(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("finding stock reservations: %w", err)
+	}
+	for _, r := range holds {
+		if r.Status() != inventory.ReservationConfirmed {
+			continue
+		}
+		if err := r.Fulfil(now); err != nil {
+			return err
+		}
+		if err := h.stockRepo.Fulfil(ctx, r, actorID, now); err != nil {
+			if errors.Is(err, inventory.ErrInsufficientStock) {
+				return err
+			}
+			return fmt.Errorf("fulfilling stock reservation: %w", err)
+		}
+	}
+	return nil
+}
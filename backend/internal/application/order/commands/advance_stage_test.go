@@ -0,0 +1,118 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmedStock is stock confirmed for the fixture order.
+func confirmedStock(f *refundFixture) *inventory.Reservation {
+	orderID := f.order.ID()
+	return inventory.ReconstructReservation(uuid.New(), f.order.BranchID(), f.order.CustomerID(),
+		[]inventory.ReservationLine{{ProductID: f.order.Lines()[0].ProductID, Grams: f.order.Lines()[0].Grams}},
+		inventory.ReservationConfirmed, &orderID, time.Now(), time.Now())
+}
+
+func TestAdvanceStage_SavesOrder(t *testing.T) {
+	f := newRefundFixture(t)
+	stock := new(mockStockReservationRepository)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	h := commands.NewAdvanceStageHandler(f.orders, stock, f.rewards())
+
+	o, err := h.Handle(context.Background(), commands.AdvanceStageCommand{OrderID: f.order.ID(), Stage: "cutting"})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.StageCutting, o.Stage())
+	f.orders.AssertExpectations(t)
+	stock.AssertNotCalled(t, "FindByOrder", mock.Anything, mock.Anything)
+}
+
+func TestAdvanceStage_Ready_SellsTheConfirmedStock(t *testing.T) {
+	f := newRefundFixture(t)
+	stock := new(mockStockReservationRepository)
+	hold := confirmedStock(f)
+	actorID := uuid.New()
+	stock.On("FindByOrder", mock.Anything, f.order.ID()).Return([]*inventory.Reservation{hold}, nil)
+	stock.On("Fulfil", mock.Anything, hold, &actorID, mock.Anything).Return(nil)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	h := commands.NewAdvanceStageHandler(f.orders, stock, f.rewards())
+
+	o, err := h.Handle(context.Background(), commands.AdvanceStageCommand{OrderID: f.order.ID(), Stage: "ready", ActorID: &actorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.StageReady, o.Stage())
+	assert.Equal(t, inventory.ReservationFulfilled, hold.Status())
+	stock.AssertExpectations(t)
+}
+
+func TestAdvanceStage_Completed_EarnsPoints(t *testing.T) {
+	f := newRefundFixture(t)
+	stock := new(mockStockReservationRepository)
+	stock.On("FindByOrder", mock.Anything, f.order.ID()).Return([]*inventory.Reservation{}, nil)
+	f.rules.On("Find", mock.Anything).Return(loyalty.Rules{PointsPerUnit: 1, UnitCents: 100}, nil)
+	f.ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Kind == loyalty.KindEarn && es[0].Points == 39 && es[0].Reference == f.order.ID().String()
+	})).Return(nil)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	h := commands.NewAdvanceStageHandler(f.orders, stock, f.rewards())
+
+	o, err := h.Handle(context.Background(), commands.AdvanceStageCommand{OrderID: f.order.ID(), Stage: "completed"})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.StageCompleted, o.Stage())
+	f.ledger.AssertExpectations(t)
+}
+
+func TestAdvanceStage_NotSaved_TakesThePointsBack(t *testing.T) {
+	f := newRefundFixture(t)
+	stock := new(mockStockReservationRepository)
+	stock.On("FindByOrder", mock.Anything, f.order.ID()).Return([]*inventory.Reservation{}, nil)
+	f.rules.On("Find", mock.Anything).Return(loyalty.Rules{PointsPerUnit: 1, UnitCents: 100}, nil)
+	f.ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Kind == loyalty.KindEarn
+	})).Return(nil).Once()
+	f.ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Kind == loyalty.KindReversal && es[0].Points == -39
+	})).Return(nil).Once()
+	f.orders.On("Update", mock.Anything, f.order).Return(order.ErrConcurrentUpdate)
+	h := commands.NewAdvanceStageHandler(f.orders, stock, f.rewards())
+
+	_, err := h.Handle(context.Background(), commands.AdvanceStageCommand{OrderID: f.order.ID(), Stage: "completed"})
+
+	assert.ErrorIs(t, err, order.ErrConcurrentUpdate)
+	f.ledger.AssertExpectations(t)
+}
+
+func TestAdvanceStage_NotEnoughStock_LeavesTheOrder(t *testing.T) {
+	f := newRefundFixture(t)
+	stock := new(mockStockReservationRepository)
+	hold := confirmedStock(f)
+	stock.On("FindByOrder", mock.Anything, f.order.ID()).Return([]*inventory.Reservation{hold}, nil)
+	stock.On("Fulfil", mock.Anything, hold, mock.Anything, mock.Anything).Return(inventory.ErrInsufficientStock)
+	h := commands.NewAdvanceStageHandler(f.orders, stock, f.rewards())
+
+	_, err := h.Handle(context.Background(), commands.AdvanceStageCommand{OrderID: f.order.ID(), Stage: "completed"})
+
+	assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+	f.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAdvanceStage_Backwards_ReturnsError(t *testing.T) {
+	f := newRefundFixture(t)
+	h := commands.NewAdvanceStageHandler(f.orders, new(mockStockReservationRepository), f.rewards())
+
+	_, err := h.Handle(context.Background(), commands.AdvanceStageCommand{OrderID: f.order.ID(), Stage: "received"})
+
+	assert.ErrorIs(t, err, order.ErrStageBackwards)
+	f.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
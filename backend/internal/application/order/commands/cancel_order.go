@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// CancelOrderCommand is the input for the cancel order use case. CustomerID
+// is set when customers cancel their own orders; ActorID when staff do.
+type CancelOrderCommand struct {
+	OrderID    uuid.UUID
+	CustomerID *uuid.UUID
+	ActorID    *uuid.UUID
+}
+
+// CancelOrderHandler calls off an order the cutting room has not started
+// on. The stock confirmed for it goes back on sale, its slot is freed, the
+// hold on the customer's card is voided, and the promotions and points it
+// used are given back. An order already paid for is refunded instead.
+type CancelOrderHandler struct {
+	orderRepo   order.Repository
+	stockRepo   inventory.ReservationRepository
+	slotRepo    fulfilment.ReservationRepository
+	paymentRepo payment.Repository
+	gateway     payment.PaymentGateway
+	discounter  *pricing.Discounter
+	rewards     *pricing.Rewards
+	auditRepo   audit.Repository
+}
+
+// NewCancelOrderHandler creates a new CancelOrderHandler.
+func NewCancelOrderHandler(
+	orderRepo order.Repository,
+	stockRepo inventory.ReservationRepository,
+	slotRepo fulfilment.ReservationRepository,
+	paymentRepo payment.Repository,
+	gateway payment.PaymentGateway,
+	discounter *pricing.Discounter,
+	rewards *pricing.Rewards,
+	auditRepo audit.Repository,
+) *CancelOrderHandler {
+	return &CancelOrderHandler{
+		orderRepo:   orderRepo,
+		stockRepo:   stockRepo,
+		slotRepo:    slotRepo,
+		paymentRepo: paymentRepo,
+		gateway:     gateway,
+		discounter:  discounter,
+		rewards:     rewards,
+		auditRepo:   auditRepo,
+	}
+}
+
+// Handle executes the cancel order use case. Another customer's order is
+// reported as not found. The order is cancelled before anything is given
+// back, and cancelling it again finishes whatever was left undone.
+func (h *CancelOrderHandler) Handle(ctx context.Context, cmd CancelOrderCommand) (*order.Order, error) {
+	o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.CustomerID != nil && o.CustomerID() != *cmd.CustomerID {
+		return nil, order.ErrOrderNotFound
+	}
+
+	payments, err := h.paymentRepo.FindByOrderID(ctx, o.ID())
+	if err != nil {
+		return nil, fmt.Errorf("finding order payments: %w", err)
+	}
+	for _, p := range payments {
+		if p.CapturedCents() > 0 {
+			return nil, order.ErrOrderPaid
+		}
+	}
+
+	now := time.Now()
+	if o.Status() != order.StatusCancelled {
+		if err := o.Cancel(now); err != nil {
+			return nil, err
+		}
+		if err := saveOrder(ctx, h.orderRepo, o); err != nil {
+			return nil, err
+		}
+		if cmd.ActorID != nil {
+			e, err := audit.NewEntry(uuid.New(), *cmd.ActorID, "order.cancelled", "order", o.ID(), nil, now)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.auditRepo.Append(ctx, e); err != nil {
+				return nil, fmt.Errorf("recording audit entry: %w", err)
+			}
+		}
+	}
+
+	for _, p := range payments {
+		if p.Status() != payment.StatusAuthorized {
+			continue
+		}
+		if err := p.Void(now); err != nil {
+			return nil, err
+		}
+		if err := h.gateway.Void(ctx, p.ProviderRef(), "void:"+p.ID().String()); err != nil {
+			return nil, fmt.Errorf("voiding payment: %w", err)
+		}
+		if err := h.paymentRepo.Update(ctx, p); err != nil {
+			return nil, fmt.Errorf("updating payment: %w", err)
+		}
+	}
+
+	holds, err := h.stockRepo.FindByOrder(ctx, o.ID())
+	if err != nil {
+		return nil, fmt.Errorf("finding stock reservations: %w", err)
+	}
+	for _, r := range holds {
+		if r.Status() == inventory.ReservationReleased {
+			continue
+		}
+		if err := r.Cancel(); err != nil {
+			return nil, err
+		}
+		if err := h.stockRepo.Update(ctx, r); err != nil {
+			return nil, fmt.Errorf("releasing stock reservation: %w", err)
+		}
+	}
+
+	bookings, err := h.slotRepo.FindByOrder(ctx, o.ID())
+	if err != nil {
+		return nil, fmt.Errorf("finding slot reservations: %w", err)
+	}
+	for _, r := range bookings {
+		if r.Status() == fulfilment.ReservationReleased {
+			continue
+		}
+		r.Cancel()
+		if err := h.slotRepo.Update(ctx, r); err != nil {
+			return nil, fmt.Errorf("releasing slot reservation: %w", err)
+		}
+	}
+
+	if err := h.discounter.Release(ctx, o.ID()); err != nil {
+		return nil, fmt.Errorf("releasing promotions: %w", err)
+	}
+	rules, err := h.rewards.Rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.rewards.ReverseOrder(ctx, rules, o.CustomerID(), o.ID(), "order cancelled", now); err != nil {
+		return nil, fmt.Errorf("reversing loyalty points: %w", err)
+	}
+	return o, nil
+}
@@ -0,0 +1,267 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockStockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockStockReservationRepository) Reserve(ctx context.Context, r *inventory.Reservation, now time.Time) error {
+	return m.Called(ctx, r, now).Error(0)
+}
+
+func (m *mockStockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Reservation), args.Error(1)
+}
+
+func (m *mockStockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*inventory.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Reservation), args.Error(1)
+}
+
+func (m *mockStockReservationRepository) Update(ctx context.Context, r *inventory.Reservation) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockStockReservationRepository) Fulfil(ctx context.Context, r *inventory.Reservation, actorID *uuid.UUID, now time.Time) error {
+	return m.Called(ctx, r, actorID, now).Error(0)
+}
+
+type mockSlotReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockSlotReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, r *fulfilment.Reservation) error {
+	return m.Called(ctx, slot, r).Error(0)
+}
+
+func (m *mockSlotReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockSlotReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockSlotReservationRepository) Update(ctx context.Context, r *fulfilment.Reservation) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockSlotReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	args := m.Called(ctx, templateIDs, from, to, now)
+	return args.Get(0).(map[fulfilment.SlotKey]int64), args.Error(1)
+}
+
+func (m *mockSlotReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, templateIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+type mockRedemptionRepository struct {
+	mock.Mock
+}
+
+func (m *mockRedemptionRepository) Usage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]promotion.Usage, error) {
+	args := m.Called(ctx, promotionIDs, customerID)
+	return args.Get(0).(map[uuid.UUID]promotion.Usage), args.Error(1)
+}
+
+func (m *mockRedemptionRepository) Redeem(ctx context.Context, redemptions []promotion.Redemption) error {
+	return m.Called(ctx, redemptions).Error(0)
+}
+
+func (m *mockRedemptionRepository) Release(ctx context.Context, orderID uuid.UUID) error {
+	return m.Called(ctx, orderID).Error(0)
+}
+
+type mockLoyaltyLedger struct {
+	mock.Mock
+}
+
+func (m *mockLoyaltyLedger) Append(ctx context.Context, entries ...*loyalty.Entry) error {
+	return m.Called(ctx, entries).Error(0)
+}
+
+func (m *mockLoyaltyLedger) Entries(ctx context.Context, customerID uuid.UUID) ([]*loyalty.Entry, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*loyalty.Entry), args.Error(1)
+}
+
+func (m *mockLoyaltyLedger) Balance(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(loyalty.Balance), args.Error(1)
+}
+
+func (m *mockLoyaltyLedger) Rebuild(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(loyalty.Balance), args.Error(1)
+}
+
+func (m *mockLoyaltyLedger) CustomersWithExpiring(ctx context.Context, at time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type mockLoyaltyRulesRepository struct {
+	mock.Mock
+}
+
+func (m *mockLoyaltyRulesRepository) Find(ctx context.Context) (loyalty.Rules, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(loyalty.Rules), args.Error(1)
+}
+
+func (m *mockLoyaltyRulesRepository) Save(ctx context.Context, r loyalty.Rules) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockLoyaltyRulesRepository) SetProductBonus(ctx context.Context, productID uuid.UUID, points int64) error {
+	return m.Called(ctx, productID, points).Error(0)
+}
+
+// --- Fixtures ---
+
+// cancelFixture is the refund fixture's order before it has been paid
+// for: its payment is only authorized, its stock and slot are confirmed
+// for it, and 150 points were redeemed on it.
+type cancelFixture struct {
+	*refundFixture
+	stock       *mockStockReservationRepository
+	slots       *mockSlotReservationRepository
+	redemptions *mockRedemptionRepository
+	ledger      *mockLoyaltyLedger
+	rules       *mockLoyaltyRulesRepository
+	hold        *inventory.Reservation
+	booking     *fulfilment.Reservation
+	redeemed    *loyalty.Entry
+}
+
+func newCancelFixture(t *testing.T) *cancelFixture {
+	t.Helper()
+	now := time.Now()
+	f := &cancelFixture{
+		refundFixture: newRefundFixture(t),
+		stock:         new(mockStockReservationRepository),
+		slots:         new(mockSlotReservationRepository),
+		redemptions:   new(mockRedemptionRepository),
+		ledger:        new(mockLoyaltyLedger),
+		rules:         new(mockLoyaltyRulesRepository),
+	}
+	p, err := payment.NewPayment(uuid.New(), f.order.ID(), f.order.CustomerID(), "gbp", 3900, 1500, "fake", "checkout-1", now)
+	require.NoError(t, err)
+	require.NoError(t, p.Authorize("pi_1", now))
+	f.payment = p
+
+	orderID := f.order.ID()
+	f.hold = inventory.ReconstructReservation(uuid.New(), f.order.BranchID(), f.order.CustomerID(),
+		[]inventory.ReservationLine{{ProductID: f.order.Lines()[0].ProductID, Grams: 1500}},
+		inventory.ReservationConfirmed, &orderID, now.Add(-time.Minute), now.Add(-time.Hour))
+	f.booking = fulfilment.ReconstructReservation(uuid.New(), uuid.New(), f.order.CustomerID(), now.Add(24*time.Hour),
+		now.Add(25*time.Hour), 1, fulfilment.ReservationConfirmed, &orderID, now.Add(-time.Minute), now.Add(-time.Hour))
+	f.redeemed = &loyalty.Entry{ID: uuid.New(), CustomerID: f.order.CustomerID(), Kind: loyalty.KindRedeem, Points: -150, Reference: orderID.String()}
+	return f
+}
+
+func (f *cancelFixture) handler() *commands.CancelOrderHandler {
+	return commands.NewCancelOrderHandler(f.orders, f.stock, f.slots, f.payments, f.gateway,
+		pricing.NewDiscounter(nil, nil, f.redemptions, nil), pricing.NewRewards(f.rules, f.ledger), f.audit)
+}
+
+// --- Tests ---
+
+func TestCancelOrder_GivesEverythingBack(t *testing.T) {
+	f := newCancelFixture(t)
+	actorID := uuid.New()
+	f.payments.On("FindByOrderID", mock.Anything, f.order.ID()).Return([]*payment.Payment{f.payment}, nil)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	f.gateway.On("Void", mock.Anything, "pi_1", "void:"+f.payment.ID().String()).Return(nil)
+	f.payments.On("Update", mock.Anything, f.payment).Return(nil)
+	f.stock.On("FindByOrder", mock.Anything, f.order.ID()).Return([]*inventory.Reservation{f.hold}, nil)
+	f.stock.On("Update", mock.Anything, f.hold).Return(nil)
+	f.slots.On("FindByOrder", mock.Anything, f.order.ID()).Return([]*fulfilment.Reservation{f.booking}, nil)
+	f.slots.On("Update", mock.Anything, f.booking).Return(nil)
+	f.redemptions.On("Release", mock.Anything, f.order.ID()).Return(nil)
+	f.rules.On("Find", mock.Anything).Return(loyalty.Rules{UnitCents: 100, ExpiryDays: 30}, nil)
+	f.ledger.On("Entries", mock.Anything, f.order.CustomerID()).Return([]*loyalty.Entry{f.redeemed}, nil)
+	f.ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Points == 150 && *es[0].SourceID == f.redeemed.ID
+	})).Return(nil)
+
+	o, err := f.handler().Handle(context.Background(), commands.CancelOrderCommand{OrderID: f.order.ID(), ActorID: &actorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCancelled, o.Status())
+	assert.Equal(t, payment.StatusVoided, f.payment.Status())
+	assert.Equal(t, inventory.ReservationReleased, f.hold.Status())
+	assert.Equal(t, fulfilment.ReservationReleased, f.booking.Status())
+	assert.Equal(t, []string{"order.cancelled"}, auditActions(f.refundFixture))
+	f.gateway.AssertExpectations(t)
+	f.redemptions.AssertExpectations(t)
+	f.ledger.AssertExpectations(t)
+}
+
+func TestCancelOrder_Paid_ReturnsError(t *testing.T) {
+	f := newCancelFixture(t)
+	require.NoError(t, f.payment.Capture(3900, time.Now()))
+	f.payments.On("FindByOrderID", mock.Anything, f.order.ID()).Return([]*payment.Payment{f.payment}, nil)
+
+	_, err := f.handler().Handle(context.Background(), commands.CancelOrderCommand{OrderID: f.order.ID()})
+
+	assert.ErrorIs(t, err, order.ErrOrderPaid)
+	assert.Equal(t, order.StatusPlaced, f.order.Status())
+	f.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	f.stock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCancelOrder_AnotherCustomersOrder_NotFound(t *testing.T) {
+	f := newCancelFixture(t)
+	someoneElse := uuid.New()
+
+	_, err := f.handler().Handle(context.Background(), commands.CancelOrderCommand{OrderID: f.order.ID(), CustomerID: &someoneElse})
+
+	assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	f.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
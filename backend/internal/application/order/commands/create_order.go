@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// LineInput is one weighed product on an order at its shelf price. A
+// branch's own price for the product takes the place of the shelf price.
+// VAT is worked out from the product's tax category.
+type LineInput struct {
+	ProductID       uuid.UUID
+	Description     string
+	Grams           int64
+	PricePerKgCents int64
+}
+
+// CreateOrderCommand is the input for the create order use case. Coupons
+// are codes the customer has given; running promotions that need no code
+// apply regardless. RedeemPoints is how many loyalty points the customer
+// wants to spend, of which only as many as the order can absorb are taken.
+// Zone is set when the order is delivered, to the zone its address is in.
+// ActorID is nil when customers place orders themselves.
+type CreateOrderCommand struct {
+	CustomerID   uuid.UUID
+	BranchID     uuid.UUID
+	Lines        []LineInput
+	Coupons      []string
+	RedeemPoints int64
+	Zone         *delivery.Zone
+	ActorID      *uuid.UUID
+}
+
+// CreateOrderHandler records an order for a customer, e.g. one taken over
+// the phone or at the counter. It prices the order and nothing more; online
+// checkout, which holds the stock and slot first, goes through
+// PlaceOrderHandler.
+type CreateOrderHandler struct {
+	customerRepo customer.Repository
+	orderRepo    order.Repository
+	priceRepo    branch.PriceRepository
+	pricer       *pricing.Pricer
+	discounter   *pricing.Discounter
+	rewards      *pricing.Rewards
+}
+
+// NewCreateOrderHandler creates a new CreateOrderHandler.
+func NewCreateOrderHandler(customerRepo customer.Repository, orderRepo order.Repository, priceRepo branch.PriceRepository, pricer *pricing.Pricer, discounter *pricing.Discounter, rewards *pricing.Rewards) *CreateOrderHandler {
+	return &CreateOrderHandler{customerRepo: customerRepo, orderRepo: orderRepo, priceRepo: priceRepo, pricer: pricer, discounter: discounter, rewards: rewards}
+}
+
+// Handle executes the create order use case. Promotions and then redeemed
+// points come off each line's shelf price before VAT is worked out; points
+// are only earned once the order is handed over. A coupon that does not apply
+// is refused with the reason rather than silently dropped. A delivered
+// order must reach the zone's minimum order at shelf prices, and is
+// charged the zone's fee, less any promotion on it, taxed at the fee's
+// category.
+func (h *CreateOrderHandler) Handle(ctx context.Context, cmd CreateOrderCommand) (*order.Order, error) {
+	c, err := h.customerRepo.FindByID(ctx, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Lines, err = h.atBranchPrices(ctx, cmd.BranchID, cmd.Lines); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	customerID := c.ID()
+	cart := promotion.Cart{Lines: make([]promotion.Line, 0, len(cmd.Lines))}
+	for _, l := range cmd.Lines {
+		cart.Lines = append(cart.Lines, promotion.Line{ProductID: l.ProductID, Grams: l.Grams, PricePerKgCents: l.PricePerKgCents})
+	}
+	if cmd.Zone != nil {
+		var subtotal int64
+		for _, l := range cart.Lines {
+			subtotal += l.AmountCents()
+		}
+		if err := cmd.Zone.CheckMinimumOrder(subtotal); err != nil {
+			return nil, err
+		}
+		cart.DeliveryFeeCents = cmd.Zone.DeliveryFeeCents()
+	}
+	discounts, err := h.discounter.Discount(ctx, cart, &customerID, cmd.Coupons, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(discounts.Rejected) > 0 {
+		r := discounts.Rejected[0]
+		return nil, fmt.Errorf("coupon %s: %w", r.Code, r.Err)
+	}
+
+	rules, err := h.rewards.Rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]order.Line, 0, len(cmd.Lines))
+	toPay := make([]int64, 0, len(cmd.Lines))
+	for i, l := range cmd.Lines {
+		line := order.Line{
+			ProductID:       l.ProductID,
+			Description:     l.Description,
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+			DiscountCents:   discounts.LineCents[i],
+		}
+		lines = append(lines, line)
+		toPay = append(toPay, max(0, line.ShelfCents()-line.DiscountCents))
+	}
+	var redeemed loyalty.Redemption
+	if cmd.RedeemPoints > 0 {
+		if redeemed, err = rules.Redeem(cmd.RedeemPoints, toPay); err != nil {
+			return nil, err
+		}
+		for i := range lines {
+			lines[i].DiscountCents += redeemed.LineCents[i]
+			toPay[i] -= redeemed.LineCents[i]
+		}
+	}
+
+	toPrice := make([]pricing.Line, 0, len(cmd.Lines)+1)
+	for i, l := range cmd.Lines {
+		toPrice = append(toPrice, pricing.Line{ProductID: l.ProductID, AmountCents: toPay[i]})
+	}
+	if cmd.Zone != nil {
+		toPrice = append(toPrice, pricing.Line{
+			Category:    tax.Category(cmd.Zone.FeeTaxCategory()),
+			AmountCents: cart.DeliveryFeeCents - discounts.DeliveryCents,
+		})
+	}
+	priced, err := h.pricer.Price(ctx, toPrice, now)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range priced.Lines[:len(lines)] {
+		lines[i].TaxCategory = string(p.Category)
+		lines[i].VATRateBP = p.RateBP
+		lines[i].NetCents = p.NetCents
+		lines[i].VATCents = p.VATCents
+		lines[i].GrossCents = p.GrossCents
+	}
+
+	o, err := order.NewOrder(uuid.New(), c.ID(), cmd.BranchID, lines, cmd.ActorID, now)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Zone != nil {
+		fee := priced.Lines[len(lines)]
+		err := o.ChargeDelivery(order.DeliveryCharge{
+			ZoneID:        cmd.Zone.ID(),
+			TaxCategory:   string(fee.Category),
+			FeeCents:      cart.DeliveryFeeCents,
+			VATRateBP:     fee.RateBP,
+			DiscountCents: discounts.DeliveryCents,
+			NetCents:      fee.NetCents,
+			VATCents:      fee.VATCents,
+			GrossCents:    fee.GrossCents,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Redeeming first holds the promotions' uses and the customer's points
+	// while the order is saved; they are given back if it cannot be.
+	if err := h.discounter.Redeem(ctx, discounts, o.ID(), customerID, now); err != nil {
+		return nil, err
+	}
+	entries, err := h.rewards.Record(ctx, rules, o.ID(), customerID, redeemed.Points, 0, now)
+	if err != nil {
+		_ = h.discounter.Release(ctx, o.ID())
+		return nil, err
+	}
+	if err := h.orderRepo.Create(ctx, o); err != nil {
+		_ = h.discounter.Release(ctx, o.ID())
+		_ = h.rewards.Reverse(ctx, rules, entries, "order could not be saved", now)
+		return nil, fmt.Errorf("saving order: %w", err)
+	}
+	return o, nil
+}
+
+// atBranchPrices returns the lines with the branch's prices in place of
+// the shelf prices of the products it has its own price for.
+func (h *CreateOrderHandler) atBranchPrices(ctx context.Context, branchID uuid.UUID, lines []LineInput) ([]LineInput, error) {
+	productIDs := make([]uuid.UUID, 0, len(lines))
+	for _, l := range lines {
+		productIDs = append(productIDs, l.ProductID)
+	}
+	overrides, err := h.priceRepo.PricesFor(ctx, branchID, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("loading branch prices: %w", err)
+	}
+	priced := make([]LineInput, len(lines))
+	for i, l := range lines {
+		if p, ok := overrides[l.ProductID]; ok {
+			l.PricePerKgCents = p
+		}
+		priced[i] = l
+	}
+	return priced, nil
+}
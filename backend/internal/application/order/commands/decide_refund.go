@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// DecideRefundCommand is the input for the approve and reject refund use cases.
+type DecideRefundCommand struct {
+	OrderID  uuid.UUID
+	RefundID uuid.UUID
+	ActorID  uuid.UUID
+}
+
+// ApproveRefundHandler lets a manager approve a refund above the threshold,
+// which is then issued.
+type ApproveRefundHandler struct {
+	adminRepo admin.Repository
+	issuer    refundIssuer
+}
+
+// NewApproveRefundHandler creates a new ApproveRefundHandler.
+func NewApproveRefundHandler(
+	orderRepo order.Repository,
+	adminRepo admin.Repository,
+	paymentRepo payment.Repository,
+	gateway payment.PaymentGateway,
+	auditRepo audit.Repository,
+	rewards *pricing.Rewards,
+) *ApproveRefundHandler {
+	return &ApproveRefundHandler{
+		adminRepo: adminRepo,
+		issuer:    refundIssuer{orderRepo: orderRepo, paymentRepo: paymentRepo, gateway: gateway, auditRepo: auditRepo, rewards: rewards},
+	}
+}
+
+// Handle executes the approve refund use case. Approving a refund that was
+// approved but not issued, e.g. because the provider was unreachable, tries
+// to issue it again.
+func (h *ApproveRefundHandler) Handle(ctx context.Context, cmd DecideRefundCommand) (*order.Refund, error) {
+	actor, err := h.adminRepo.FindByID(ctx, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	canApprove := actor.HasPermission(admin.PermissionApproveRefunds)
+
+	o, err := h.issuer.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := o.Refund(cmd.RefundID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status() == order.RefundStatusApproved && canApprove {
+		return h.issuer.issue(ctx, o.ID(), r.ID(), actor.ID())
+	}
+
+	if _, err := o.ApproveRefund(cmd.RefundID, actor.ID(), canApprove, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := saveOrder(ctx, h.issuer.orderRepo, o); err != nil {
+		return nil, err
+	}
+	if err := recordRefund(ctx, h.issuer.auditRepo, "refund.approved", actor.ID(), r); err != nil {
+		return nil, err
+	}
+	return h.issuer.issue(ctx, o.ID(), r.ID(), actor.ID())
+}
+
+// RejectRefundHandler lets a manager turn down a refund above the threshold.
+type RejectRefundHandler struct {
+	orderRepo order.Repository
+	adminRepo admin.Repository
+	auditRepo audit.Repository
+}
+
+// NewRejectRefundHandler creates a new RejectRefundHandler.
+func NewRejectRefundHandler(orderRepo order.Repository, adminRepo admin.Repository, auditRepo audit.Repository) *RejectRefundHandler {
+	return &RejectRefundHandler{orderRepo: orderRepo, adminRepo: adminRepo, auditRepo: auditRepo}
+}
+
+// Handle executes the reject refund use case.
+func (h *RejectRefundHandler) Handle(ctx context.Context, cmd DecideRefundCommand) (*order.Refund, error) {
+	actor, err := h.adminRepo.FindByID(ctx, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := o.RejectRefund(cmd.RefundID, actor.ID(), actor.HasPermission(admin.PermissionApproveRefunds), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := saveOrder(ctx, h.orderRepo, o); err != nil {
+		return nil, err
+	}
+	if err := recordRefund(ctx, h.auditRepo, "refund.rejected", actor.ID(), r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,69 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingRefund has staff ask for the lamb line back, which is above the
+// fixture's threshold.
+func pendingRefund(t *testing.T, f *refundFixture) *order.Refund {
+	t.Helper()
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	lineID := f.order.Lines()[0].ID
+	r, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "quality", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, order.RefundStatusPendingApproval, r.Status())
+	return r
+}
+
+func TestApproveRefund_Manager_IssuesRefund(t *testing.T) {
+	f := newRefundFixture(t)
+	r := pendingRefund(t, f)
+	managerID := f.newAdmin(t, admin.RoleManager)
+	f.expectIssue(3000)
+
+	h := commands.NewApproveRefundHandler(f.orders, f.admins, f.payments, f.gateway, f.audit, f.rewards())
+	approved, err := h.Handle(context.Background(), commands.DecideRefundCommand{OrderID: f.order.ID(), RefundID: r.ID(), ActorID: managerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.RefundStatusIssued, approved.Status())
+	assert.Equal(t, managerID, *approved.DecidedBy())
+	assert.Equal(t, []string{"refund.requested", "refund.approved", "refund.issued"}, auditActions(f))
+}
+
+func TestApproveRefund_Staff_ReturnsError(t *testing.T) {
+	f := newRefundFixture(t)
+	r := pendingRefund(t, f)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+
+	h := commands.NewApproveRefundHandler(f.orders, f.admins, f.payments, f.gateway, f.audit, f.rewards())
+	_, err := h.Handle(context.Background(), commands.DecideRefundCommand{OrderID: f.order.ID(), RefundID: r.ID(), ActorID: staffID})
+
+	assert.ErrorIs(t, err, order.ErrApprovalNotPermitted)
+	f.gateway.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRejectRefund_Manager_ReleasesAmount(t *testing.T) {
+	f := newRefundFixture(t)
+	r := pendingRefund(t, f)
+	managerID := f.newAdmin(t, admin.RoleManager)
+
+	h := commands.NewRejectRefundHandler(f.orders, f.admins, f.audit)
+	rejected, err := h.Handle(context.Background(), commands.DecideRefundCommand{OrderID: f.order.ID(), RefundID: r.ID(), ActorID: managerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.RefundStatusRejected, rejected.Status())
+	assert.Equal(t, int64(3900), f.order.RefundableCents())
+	assert.Equal(t, []string{"refund.requested", "refund.rejected"}, auditActions(f))
+}
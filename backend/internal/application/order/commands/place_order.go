@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+)
+
+// PlaceOrderCommand is the input for the place order use case: the stock
+// and slot the customer held at checkout, and the coupons and points they
+// want to use. AddressID is the saved address to deliver to, required when
+// the slot is a delivery slot.
+type PlaceOrderCommand struct {
+	CustomerID         uuid.UUID
+	StockReservationID uuid.UUID
+	SlotReservationID  uuid.UUID
+	AddressID          *uuid.UUID
+	Coupons            []string
+	RedeemPoints       int64
+}
+
+// PlaceOrderHandler is online checkout. It turns the stock and slot a
+// customer has on hold into an order, priced as any other order is, and
+// confirms the slot and stock against it.
+type PlaceOrderHandler struct {
+	orders       *CreateOrderHandler
+	cancel       *CancelOrderHandler
+	stockRepo    inventory.ReservationRepository
+	slotRepo     fulfilment.ReservationRepository
+	scheduleRepo fulfilment.ScheduleRepository
+	pluRepo      plu.Repository
+	addressRepo  customer.AddressRepository
+	zoneRepo     delivery.ZoneRepository
+}
+
+// NewPlaceOrderHandler creates a new PlaceOrderHandler.
+func NewPlaceOrderHandler(
+	orders *CreateOrderHandler,
+	cancel *CancelOrderHandler,
+	stockRepo inventory.ReservationRepository,
+	slotRepo fulfilment.ReservationRepository,
+	scheduleRepo fulfilment.ScheduleRepository,
+	pluRepo plu.Repository,
+	addressRepo customer.AddressRepository,
+	zoneRepo delivery.ZoneRepository,
+) *PlaceOrderHandler {
+	return &PlaceOrderHandler{
+		orders:       orders,
+		cancel:       cancel,
+		stockRepo:    stockRepo,
+		slotRepo:     slotRepo,
+		scheduleRepo: scheduleRepo,
+		pluRepo:      pluRepo,
+		addressRepo:  addressRepo,
+		zoneRepo:     zoneRepo,
+	}
+}
+
+// Handle executes the place order use case. The order is for what the
+// stock hold holds, at its shelf or branch price, from the branch the slot
+// runs at. Both holds must be the customer's own, still on hold and not
+// expired; a hold that lapses or is taken while the order is being placed
+// has the order cancelled again. A delivery slot needs an address inside an
+// active zone, the zone the slot delivers to, and the order is charged
+// that zone's fee.
+func (h *PlaceOrderHandler) Handle(ctx context.Context, cmd PlaceOrderCommand) (*order.Order, error) {
+	now := time.Now()
+	stockHold, err := h.stockRepo.FindByID(ctx, cmd.StockReservationID)
+	if err != nil {
+		return nil, err
+	}
+	if stockHold.CustomerID() != cmd.CustomerID {
+		return nil, inventory.ErrReservationNotOwned
+	}
+	if stockHold.Status() != inventory.ReservationHeld {
+		return nil, inventory.ErrReservationNotHeld
+	}
+	if !stockHold.IsActive(now) {
+		return nil, inventory.ErrReservationExpired
+	}
+
+	slotHold, err := h.slotRepo.FindByID(ctx, cmd.SlotReservationID)
+	if err != nil {
+		return nil, err
+	}
+	if slotHold.CustomerID() != cmd.CustomerID {
+		return nil, fulfilment.ErrReservationNotOwned
+	}
+	if slotHold.Status() != fulfilment.ReservationHeld {
+		return nil, fulfilment.ErrReservationNotHeld
+	}
+	if !slotHold.IsActive(now) {
+		return nil, fulfilment.ErrReservationExpired
+	}
+	tmpl, err := h.scheduleRepo.FindSlotTemplateByID(ctx, slotHold.TemplateID())
+	if err != nil {
+		return nil, fmt.Errorf("loading slot template: %w", err)
+	}
+	if tmpl.BranchID() != stockHold.BranchID() {
+		return nil, order.ErrHoldsAtDifferentBranches
+	}
+	zone, err := h.deliveryZone(ctx, cmd, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]LineInput, 0, len(stockHold.Lines()))
+	for _, l := range stockHold.Lines() {
+		item, err := h.pluRepo.FindByProduct(ctx, l.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if !item.Active() {
+			return nil, plu.ErrItemInactive
+		}
+		lines = append(lines, LineInput{
+			ProductID:       l.ProductID,
+			Description:     item.Description(),
+			Grams:           l.Grams,
+			PricePerKgCents: item.PricePerKgCents(),
+		})
+	}
+
+	o, err := h.orders.Handle(ctx, CreateOrderCommand{
+		CustomerID:   cmd.CustomerID,
+		BranchID:     stockHold.BranchID(),
+		Lines:        lines,
+		Coupons:      cmd.Coupons,
+		RedeemPoints: cmd.RedeemPoints,
+		Zone:         zone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.confirm(ctx, o, slotHold, stockHold); err != nil {
+		if _, cancelErr := h.cancel.Handle(ctx, CancelOrderCommand{OrderID: o.ID(), CustomerID: &cmd.CustomerID}); cancelErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("cancelling order: %w", cancelErr))
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+// deliveryZone returns the zone a delivery slot's order is delivered in,
+// found from the customer's address, or nil for a collection slot.
+func (h *PlaceOrderHandler) deliveryZone(ctx context.Context, cmd PlaceOrderCommand, tmpl *fulfilment.SlotTemplate) (*delivery.Zone, error) {
+	if tmpl.Method() != fulfilment.MethodDelivery {
+		return nil, nil
+	}
+	if cmd.AddressID == nil {
+		return nil, order.ErrAddressRequired
+	}
+	address, err := h.addressRepo.FindByID(ctx, *cmd.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	if !address.BelongsTo(cmd.CustomerID) {
+		return nil, customer.ErrAddressNotFound
+	}
+
+	zones, err := h.zoneRepo.FindAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding delivery zones: %w", err)
+	}
+	var point *delivery.Point
+	if c := address.Coordinates(); c != nil {
+		point = &delivery.Point{Longitude: c.Longitude(), Latitude: c.Latitude()}
+	}
+	zone, err := delivery.FindZone(zones, address.Postcode().String(), point)
+	if err != nil {
+		return nil, err
+	}
+	if z := tmpl.ZoneID(); z != nil && *z != zone.ID() {
+		return nil, order.ErrSlotNotForAddress
+	}
+	return zone, nil
+}
+
+// confirm books the held slot for the order and sets the held stock aside
+// for it, so that neither lapses.
+func (h *PlaceOrderHandler) confirm(ctx context.Context, o *order.Order, slotHold *fulfilment.Reservation, stockHold *inventory.Reservation) error {
+	now := time.Now()
+	if err := slotHold.Confirm(o.ID(), now); err != nil {
+		return err
+	}
+	if err := h.slotRepo.Update(ctx, slotHold); err != nil {
+		return fmt.Errorf("confirming slot reservation: %w", err)
+	}
+	if err := stockHold.Confirm(o.ID(), now); err != nil {
+		return err
+	}
+	if err := h.stockRepo.Update(ctx, stockHold); err != nil {
+		return fmt.Errorf("confirming stock reservation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,573 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *mockCustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	return m.Called(ctx, c).Error(0)
+}
+
+func (m *mockCustomerRepository) FindByEmail(ctx context.Context, email customer.Email) (*customer.Customer, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) ExistsByEmail(ctx context.Context, email customer.Email) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockBranchPriceRepository struct {
+	mock.Mock
+}
+
+func (m *mockBranchPriceRepository) SetPrice(ctx context.Context, p *branch.PriceOverride) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockBranchPriceRepository) DeletePrice(ctx context.Context, branchID, productID uuid.UUID) error {
+	return m.Called(ctx, branchID, productID).Error(0)
+}
+
+func (m *mockBranchPriceRepository) FindPrices(ctx context.Context, branchID uuid.UUID) ([]*branch.PriceOverride, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*branch.PriceOverride), args.Error(1)
+}
+
+func (m *mockBranchPriceRepository) PricesFor(ctx context.Context, branchID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(ctx, branchID, productIDs)
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+type mockRateRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateRepository) Save(ctx context.Context, r *tax.Rate) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(tax.Table), args.Error(1)
+}
+
+type mockTaxProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockTaxProductRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	return m.Called(ctx, productID, category).Error(0)
+}
+
+func (m *mockTaxProductRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]tax.Category), args.Error(1)
+}
+
+type mockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionRepository) Save(ctx context.Context, p *promotion.Promotion) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockPromotionRepository) FindByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindAll(ctx context.Context) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindRunning(ctx context.Context, at time.Time) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+type mockPromotionProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionProductRepository) Classify(ctx context.Context, productID uuid.UUID, profile promotion.Profile) error {
+	return m.Called(ctx, productID, profile).Error(0)
+}
+
+func (m *mockPromotionProductRepository) FindProfiles(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]promotion.Profile, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]promotion.Profile), args.Error(1)
+}
+
+type mockOrderHistory struct {
+	mock.Mock
+}
+
+func (m *mockOrderHistory) CountByCustomer(ctx context.Context, customerID uuid.UUID) (int, error) {
+	args := m.Called(ctx, customerID)
+	return args.Int(0), args.Error(1)
+}
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	return m.Called(ctx, branchID, hours).Error(0)
+}
+
+func (m *mockScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.OpeningHours), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveClosure(ctx context.Context, closure *fulfilment.Closure) error {
+	return m.Called(ctx, closure).Error(0)
+}
+
+func (m *mockScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	args := m.Called(ctx, branchID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Closure), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSlotTemplate(ctx context.Context, template *fulfilment.SlotTemplate) error {
+	return m.Called(ctx, template).Error(0)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.SlotTemplate), args.Error(1)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.SlotTemplate), args.Error(1)
+}
+
+type mockItemRepository struct {
+	mock.Mock
+}
+
+func (m *mockItemRepository) Save(ctx context.Context, i *plu.Item) error {
+	return m.Called(ctx, i).Error(0)
+}
+
+func (m *mockItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByCode(ctx context.Context, code int) (*plu.Item, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByProduct(ctx context.Context, productID uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindAll(ctx context.Context) ([]*plu.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*plu.Item), args.Error(1)
+}
+
+type mockAddressRepository struct {
+	mock.Mock
+}
+
+func (m *mockAddressRepository) Save(ctx context.Context, a *customer.Address) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockAddressRepository) SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error {
+	return m.Called(ctx, customerID, addressID).Error(0)
+}
+
+type mockZoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	return m.Called(ctx, z).Error(0)
+}
+
+func (m *mockZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*delivery.Zone), args.Error(1)
+}
+
+func (m *mockZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*delivery.Zone), args.Error(1)
+}
+
+// --- Fixtures ---
+
+// checkoutFixture is a customer at checkout with 1.5kg of lamb held at
+// £20/kg, VAT included at 20%, and a slot held at the same branch.
+type checkoutFixture struct {
+	customers   *mockCustomerRepository
+	orders      *mockOrderRepository
+	stock       *mockStockReservationRepository
+	slots       *mockSlotReservationRepository
+	schedule    *mockScheduleRepository
+	items       *mockItemRepository
+	addresses   *mockAddressRepository
+	zones       *mockZoneRepository
+	payments    *mockPaymentRepository
+	redemptions *mockRedemptionRepository
+	ledger      *mockLoyaltyLedger
+	handler     *commands.PlaceOrderHandler
+	customerID  uuid.UUID
+	productID   uuid.UUID
+	stockHold   *inventory.Reservation
+	slotHold    *fulfilment.Reservation
+}
+
+func newCheckoutFixture(t *testing.T) *checkoutFixture {
+	t.Helper()
+	now := time.Now()
+	f := &checkoutFixture{
+		customers:   new(mockCustomerRepository),
+		orders:      new(mockOrderRepository),
+		stock:       new(mockStockReservationRepository),
+		slots:       new(mockSlotReservationRepository),
+		schedule:    new(mockScheduleRepository),
+		items:       new(mockItemRepository),
+		addresses:   new(mockAddressRepository),
+		zones:       new(mockZoneRepository),
+		payments:    new(mockPaymentRepository),
+		redemptions: new(mockRedemptionRepository),
+		ledger:      new(mockLoyaltyLedger),
+		customerID:  uuid.New(),
+		productID:   uuid.New(),
+	}
+	branchID, templateID := uuid.New(), uuid.New()
+
+	email, err := customer.NewEmail("box@example.com")
+	require.NoError(t, err)
+	f.customers.On("FindByID", mock.Anything, f.customerID).
+		Return(customer.ReconstructCustomer(f.customerID, email, "hash", "Amina", customer.PhoneNumber{}), nil)
+
+	prices := new(mockBranchPriceRepository)
+	prices.On("PricesFor", mock.Anything, branchID, mock.Anything).Return(map[uuid.UUID]int64{}, nil)
+	rates := new(mockRateRepository)
+	rates.On("FindAll", mock.Anything).Return(tax.Table{
+		tax.ReconstructRate(uuid.New(), tax.CategoryFreshMeat, 2000, time.Time{}, now),
+		tax.ReconstructRate(uuid.New(), tax.CategoryDelivery, 2000, time.Time{}, now),
+	}, nil)
+	categories := new(mockTaxProductRepository)
+	categories.On("FindCategories", mock.Anything, mock.Anything).Return(map[uuid.UUID]tax.Category{
+		f.productID: tax.CategoryFreshMeat,
+	}, nil)
+	promotions := new(mockPromotionRepository)
+	promotions.On("FindRunning", mock.Anything, mock.Anything).Return([]*promotion.Promotion{}, nil)
+	profiles := new(mockPromotionProductRepository)
+	profiles.On("FindProfiles", mock.Anything, mock.Anything).Return(map[uuid.UUID]promotion.Profile{}, nil)
+	history := new(mockOrderHistory)
+	history.On("CountByCustomer", mock.Anything, f.customerID).Return(1, nil)
+	rules := new(mockLoyaltyRulesRepository)
+	rules.On("Find", mock.Anything).Return(loyalty.Rules{UnitCents: 100}, nil)
+
+	pricer := pricing.NewPricer(rates, categories, tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}, time.UTC)
+	discounter := pricing.NewDiscounter(promotions, profiles, f.redemptions, history)
+	rewards := pricing.NewRewards(rules, f.ledger)
+	create := commands.NewCreateOrderHandler(f.customers, f.orders, prices, pricer, discounter, rewards)
+	cancel := commands.NewCancelOrderHandler(f.orders, f.stock, f.slots, f.payments, new(mockGateway), discounter, rewards, new(mockAuditRepository))
+	f.handler = commands.NewPlaceOrderHandler(create, cancel, f.stock, f.slots, f.schedule, f.items, f.addresses, f.zones)
+
+	f.stockHold = inventory.ReconstructReservation(uuid.New(), branchID, f.customerID,
+		[]inventory.ReservationLine{{ProductID: f.productID, Grams: 1500}},
+		inventory.ReservationHeld, nil, now.Add(10*time.Minute), now)
+	f.slotHold = fulfilment.ReconstructReservation(uuid.New(), templateID, f.customerID, now.Add(24*time.Hour),
+		now.Add(25*time.Hour), 1, fulfilment.ReservationHeld, nil, now.Add(10*time.Minute), now)
+	f.stock.On("FindByID", mock.Anything, f.stockHold.ID()).Return(f.stockHold, nil)
+	f.slots.On("FindByID", mock.Anything, f.slotHold.ID()).Return(f.slotHold, nil)
+	f.schedule.On("FindSlotTemplateByID", mock.Anything, templateID).Return(fulfilment.ReconstructSlotTemplate(templateID, branchID,
+		nil, fulfilment.MethodCollection, time.Monday, fulfilment.TimeOfDay{}, fulfilment.TimeOfDay{}, fulfilment.Capacity{}, true), nil)
+	f.items.On("FindByProduct", mock.Anything, f.productID).
+		Return(plu.ReconstructItem(uuid.New(), 1001, f.productID, "Lamb leg", 2000, nil, 3, true, now, now), nil)
+	return f
+}
+
+func (f *checkoutFixture) command() commands.PlaceOrderCommand {
+	return commands.PlaceOrderCommand{CustomerID: f.customerID, StockReservationID: f.stockHold.ID(), SlotReservationID: f.slotHold.ID()}
+}
+
+// deliveryCommand swaps the held slot for a delivery slot in zone and
+// delivers to a saved address at postcode.
+func (f *checkoutFixture) deliveryCommand(t *testing.T, zone *delivery.Zone, postcode string) commands.PlaceOrderCommand {
+	t.Helper()
+	now := time.Now()
+	templateID, zoneID := uuid.New(), zone.ID()
+	f.schedule.On("FindSlotTemplateByID", mock.Anything, templateID).Return(fulfilment.ReconstructSlotTemplate(templateID,
+		f.stockHold.BranchID(), &zoneID, fulfilment.MethodDelivery, time.Monday, fulfilment.TimeOfDay{}, fulfilment.TimeOfDay{},
+		fulfilment.Capacity{}, true), nil)
+	f.slotHold = fulfilment.ReconstructReservation(uuid.New(), templateID, f.customerID, now.Add(24*time.Hour),
+		now.Add(25*time.Hour), 1, fulfilment.ReservationHeld, nil, now.Add(10*time.Minute), now)
+	f.slots.On("FindByID", mock.Anything, f.slotHold.ID()).Return(f.slotHold, nil)
+
+	pc, err := customer.NewPostcode(postcode)
+	require.NoError(t, err)
+	address, err := customer.NewAddress(uuid.New(), f.customerID, "Home", "1 Brick Lane", "", "London", pc, nil)
+	require.NoError(t, err)
+	f.addresses.On("FindByID", mock.Anything, address.ID()).Return(address, nil)
+	f.zones.On("FindAll", mock.Anything, true).Return([]*delivery.Zone{zone}, nil)
+
+	addressID := address.ID()
+	cmd := f.command()
+	cmd.AddressID = &addressID
+	return cmd
+}
+
+// newZone is a zone covering E1 postcodes that charges £5 for delivery.
+func newZone(t *testing.T, minimumOrderCents int64) *delivery.Zone {
+	t.Helper()
+	z, err := delivery.NewZone(uuid.New(), "Whitechapel", nil, []string{"E1"}, 500, minimumOrderCents)
+	require.NoError(t, err)
+	return z
+}
+
+// --- Tests ---
+
+func TestPlaceOrder_BooksTheHeldSlotForTheOrder(t *testing.T) {
+	f := newCheckoutFixture(t)
+	f.orders.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.slots.On("Update", mock.Anything, f.slotHold).Return(nil)
+	f.stock.On("Update", mock.Anything, f.stockHold).Return(nil)
+
+	o, err := f.handler.Handle(context.Background(), f.command())
+
+	require.NoError(t, err)
+	require.Len(t, o.Lines(), 1)
+	assert.Equal(t, "Lamb leg", o.Lines()[0].Description)
+	assert.Equal(t, int64(3000), o.TotalCents())
+	assert.Nil(t, o.CreatedBy())
+	assert.Equal(t, fulfilment.ReservationConfirmed, f.slotHold.Status())
+	assert.Equal(t, o.ID(), *f.slotHold.OrderID())
+	assert.Equal(t, inventory.ReservationConfirmed, f.stockHold.Status())
+	assert.Equal(t, o.ID(), *f.stockHold.OrderID())
+}
+
+func TestPlaceOrder_SlotHoldExpired_RefusesTheOrder(t *testing.T) {
+	f := newCheckoutFixture(t)
+	now := time.Now()
+	expired := fulfilment.ReconstructReservation(uuid.New(), f.slotHold.TemplateID(), f.customerID, f.slotHold.SlotStartsAt(),
+		f.slotHold.SlotEndsAt(), 1, fulfilment.ReservationHeld, nil, now.Add(-time.Minute), now.Add(-time.Hour))
+	f.slots.On("FindByID", mock.Anything, expired.ID()).Return(expired, nil)
+	cmd := f.command()
+	cmd.SlotReservationID = expired.ID()
+
+	_, err := f.handler.Handle(context.Background(), cmd)
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationExpired)
+	f.orders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_SlotTakenWhilePlacing_CancelsTheOrder(t *testing.T) {
+	f := newCheckoutFixture(t)
+	var placed *order.Order
+	f.orders.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		placed = args.Get(1).(*order.Order)
+		f.orders.On("FindByID", mock.Anything, placed.ID()).Return(placed, nil)
+		// Another checkout books the same hold while this order is saved.
+		require.NoError(t, f.slotHold.Confirm(uuid.New(), time.Now()))
+	}).Return(nil)
+	f.orders.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.payments.On("FindByOrderID", mock.Anything, mock.Anything).Return([]*payment.Payment{}, nil)
+	f.stock.On("FindByOrder", mock.Anything, mock.Anything).Return([]*inventory.Reservation{}, nil)
+	f.slots.On("FindByOrder", mock.Anything, mock.Anything).Return([]*fulfilment.Reservation{}, nil)
+	f.redemptions.On("Release", mock.Anything, mock.Anything).Return(nil)
+	f.ledger.On("Entries", mock.Anything, f.customerID).Return([]*loyalty.Entry{}, nil)
+
+	_, err := f.handler.Handle(context.Background(), f.command())
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationNotHeld)
+	require.NotNil(t, placed)
+	assert.Equal(t, order.StatusCancelled, placed.Status())
+	f.slots.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_StockTakenWhilePlacing_GivesTheSlotBack(t *testing.T) {
+	f := newCheckoutFixture(t)
+	var placed *order.Order
+	f.orders.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		placed = args.Get(1).(*order.Order)
+		f.orders.On("FindByID", mock.Anything, placed.ID()).Return(placed, nil)
+		f.slots.On("FindByOrder", mock.Anything, placed.ID()).Return([]*fulfilment.Reservation{f.slotHold}, nil)
+		// Another checkout takes the same stock while this order is saved.
+		require.NoError(t, f.stockHold.Confirm(uuid.New(), time.Now()))
+	}).Return(nil)
+	f.slots.On("Update", mock.Anything, f.slotHold).Return(nil)
+	f.orders.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.payments.On("FindByOrderID", mock.Anything, mock.Anything).Return([]*payment.Payment{}, nil)
+	f.stock.On("FindByOrder", mock.Anything, mock.Anything).Return([]*inventory.Reservation{}, nil)
+	f.redemptions.On("Release", mock.Anything, mock.Anything).Return(nil)
+	f.ledger.On("Entries", mock.Anything, f.customerID).Return([]*loyalty.Entry{}, nil)
+
+	_, err := f.handler.Handle(context.Background(), f.command())
+
+	assert.ErrorIs(t, err, inventory.ErrReservationNotHeld)
+	require.NotNil(t, placed)
+	assert.Equal(t, order.StatusCancelled, placed.Status())
+	assert.Equal(t, fulfilment.ReservationReleased, f.slotHold.Status())
+	f.stock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_Delivery_ChargesTheZoneFee(t *testing.T) {
+	f := newCheckoutFixture(t)
+	zone := newZone(t, 2500)
+	f.orders.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.slots.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.stock.On("Update", mock.Anything, f.stockHold).Return(nil)
+
+	o, err := f.handler.Handle(context.Background(), f.deliveryCommand(t, zone, "E1 6AN"))
+
+	require.NoError(t, err)
+	require.NotNil(t, o.Delivery())
+	assert.Equal(t, zone.ID(), o.Delivery().ZoneID)
+	assert.Equal(t, "delivery", o.Delivery().TaxCategory)
+	assert.Equal(t, int64(500), o.Delivery().GrossCents)
+	assert.Equal(t, int64(83), o.Delivery().VATCents)
+	assert.Equal(t, int64(3500), o.TotalCents())
+}
+
+func TestPlaceOrder_AddressOutsideEveryZone_RefusesTheOrder(t *testing.T) {
+	f := newCheckoutFixture(t)
+
+	_, err := f.handler.Handle(context.Background(), f.deliveryCommand(t, newZone(t, 0), "N1 9GU"))
+
+	assert.ErrorIs(t, err, delivery.ErrAddressNotServed)
+	f.orders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_BelowZoneMinimum_RefusesTheOrder(t *testing.T) {
+	f := newCheckoutFixture(t)
+
+	_, err := f.handler.Handle(context.Background(), f.deliveryCommand(t, newZone(t, 5000), "E1 6AN"))
+
+	assert.ErrorIs(t, err, delivery.ErrBelowMinimumOrder)
+	f.orders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrder_DeliverySlotWithoutAddress_RefusesTheOrder(t *testing.T) {
+	f := newCheckoutFixture(t)
+	cmd := f.deliveryCommand(t, newZone(t, 0), "E1 6AN")
+	cmd.AddressID = nil
+
+	_, err := f.handler.Handle(context.Background(), cmd)
+
+	assert.ErrorIs(t, err, order.ErrAddressRequired)
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// refundIssuer pays approved refunds back through the payment an order was
+// captured with. The refund is saved on the order before any money moves and
+// the provider key is derived from its ID, so issuing it again after a
+// failure part-way through never refunds twice. The points the order earned
+// are taken back in proportion to what is refunded.
+type refundIssuer struct {
+	orderRepo   order.Repository
+	paymentRepo payment.Repository
+	gateway     payment.PaymentGateway
+	auditRepo   audit.Repository
+	rewards     *pricing.Rewards
+}
+
+// issue refunds an approved refund, records it as issued on a freshly loaded
+// order with a receipt number, takes back the points it earned and writes
+// the audit entry.
+func (ri refundIssuer) issue(ctx context.Context, orderID, refundID, actorID uuid.UUID) (*order.Refund, error) {
+	o, err := ri.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := o.Refund(refundID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status() != order.RefundStatusApproved {
+		return r, nil
+	}
+
+	p, err := ri.capturedPayment(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	key := "order-refund:" + r.ID().String()
+	pr, err := ri.paymentRepo.FindRefundByIdempotencyKey(ctx, p.ID(), key)
+	if err != nil {
+		return nil, fmt.Errorf("finding payment refund: %w", err)
+	}
+	if pr == nil {
+		pr, err = p.Refund(uuid.New(), r.AmountCents(), string(r.Reason()), key, &actorID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		pr.ProviderRef, err = ri.gateway.Refund(ctx, p.ProviderRef(), pr.AmountCents, "refund:"+p.ID().String()+":"+key)
+		if err != nil {
+			return nil, fmt.Errorf("refunding payment: %w", err)
+		}
+		if err := ri.paymentRepo.SaveRefund(ctx, p, pr); err != nil {
+			if errors.Is(err, payment.ErrConcurrentUpdate) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("saving payment refund: %w", err)
+		}
+	}
+
+	number, err := ri.orderRepo.NextReceiptNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("numbering refund receipt: %w", err)
+	}
+	now := time.Now()
+	if _, err := o.IssueRefund(r.ID(), p.ID(), pr.ID, number, now); err != nil {
+		return nil, err
+	}
+	if err := ri.takeBackPoints(ctx, o, r, now); err != nil {
+		return nil, err
+	}
+	if err := saveOrder(ctx, ri.orderRepo, o); err != nil {
+		return nil, err
+	}
+	if err := recordRefund(ctx, ri.auditRepo, "refund.issued", actorID, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// takeBackPoints reverses the share of the points the order earned that the
+// refund is of what it earned them on. Refunds issued before the order
+// earned were already left out of that. Each refund takes points back once,
+// however often it is issued again.
+func (ri refundIssuer) takeBackPoints(ctx context.Context, o *order.Order, r *order.Refund, now time.Time) error {
+	earned, err := ri.rewards.Earned(ctx, o.CustomerID(), o.ID())
+	if err != nil {
+		return fmt.Errorf("finding loyalty points earned: %w", err)
+	}
+	if earned == nil {
+		return nil
+	}
+	var ofCents int64
+	for _, l := range o.Lines() {
+		ofCents += l.GrossCents
+	}
+	for _, x := range o.Refunds() {
+		if x.IssuedAt() != nil && x.IssuedAt().Before(earned.CreatedAt) {
+			ofCents -= x.AmountCents()
+		}
+	}
+	err = ri.rewards.TakeBack(ctx, earned, "refund:"+r.ID().String(), r.AmountCents(), ofCents, "refund "+r.ReceiptNumber(), now)
+	if err != nil {
+		return fmt.Errorf("taking back loyalty points: %w", err)
+	}
+	return nil
+}
+
+// capturedPayment finds the payment an order was paid with. Only a payment
+// that has been captured can be refunded.
+func (ri refundIssuer) capturedPayment(ctx context.Context, orderID uuid.UUID) (*payment.Payment, error) {
+	payments, err := ri.paymentRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("finding order payment: %w", err)
+	}
+	for _, p := range payments {
+		if p.CapturedCents() > 0 {
+			return p, nil
+		}
+	}
+	return nil, payment.ErrNotCaptured
+}
+
+func saveOrder(ctx context.Context, repo order.Repository, o *order.Order) error {
+	if err := repo.Update(ctx, o); err != nil {
+		if errors.Is(err, order.ErrConcurrentUpdate) || errors.Is(err, order.ErrIdempotencyKeyReused) {
+			return err
+		}
+		return fmt.Errorf("saving order: %w", err)
+	}
+	return nil
+}
+
+// recordRefund writes an audit entry for a change to a refund against its order.
+func recordRefund(ctx context.Context, repo audit.Repository, action string, actorID uuid.UUID, r *order.Refund) error {
+	details := map[string]string{
+		"refund_id":    r.ID().String(),
+		"kind":         string(r.Kind()),
+		"reason":       string(r.Reason()),
+		"amount_cents": strconv.FormatInt(r.AmountCents(), 10),
+		"status":       string(r.Status()),
+	}
+	if r.LineID() != nil {
+		details["line_id"] = r.LineID().String()
+	}
+	if r.Note() != "" {
+		details["note"] = r.Note()
+	}
+	if r.ReceiptNumber() != "" {
+		details["receipt_number"] = r.ReceiptNumber()
+	}
+
+	e, err := audit.NewEntry(uuid.New(), actorID, action, "order", r.OrderID(), details, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := repo.Append(ctx, e); err != nil {
+		return fmt.Errorf("recording audit entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// RequestRefundCommand is the input for the request refund use case.
+// IdempotencyKey is chosen by the client; retrying with the same key returns
+// the original refund instead of refunding again.
+type RequestRefundCommand struct {
+	OrderID        uuid.UUID
+	Kind           string
+	LineID         *uuid.UUID
+	DeliveredGrams int64
+	Reason         string
+	Note           string
+	IdempotencyKey string
+	ActorID        uuid.UUID
+}
+
+// RequestRefundHandler refunds a line, a weight difference or a whole order.
+// Refunds above the approval threshold wait for a manager unless a manager
+// asks for them; the rest are issued straight away.
+type RequestRefundHandler struct {
+	adminRepo      admin.Repository
+	issuer         refundIssuer
+	thresholdCents int64
+}
+
+// NewRequestRefundHandler creates a new RequestRefundHandler.
+func NewRequestRefundHandler(
+	orderRepo order.Repository,
+	adminRepo admin.Repository,
+	paymentRepo payment.Repository,
+	gateway payment.PaymentGateway,
+	auditRepo audit.Repository,
+	rewards *pricing.Rewards,
+	thresholdCents int64,
+) *RequestRefundHandler {
+	return &RequestRefundHandler{
+		adminRepo:      adminRepo,
+		issuer:         refundIssuer{orderRepo: orderRepo, paymentRepo: paymentRepo, gateway: gateway, auditRepo: auditRepo, rewards: rewards},
+		thresholdCents: thresholdCents,
+	}
+}
+
+// Handle executes the request refund use case.
+func (h *RequestRefundHandler) Handle(ctx context.Context, cmd RequestRefundCommand) (*order.Refund, error) {
+	kind, err := order.NewRefundKind(cmd.Kind)
+	if err != nil {
+		return nil, err
+	}
+	reason, err := order.NewReason(cmd.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := h.issuer.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if existing := o.RefundByIdempotencyKey(strings.TrimSpace(cmd.IdempotencyKey)); existing != nil {
+		if !sameRequest(existing, kind, cmd.LineID, cmd.DeliveredGrams, reason) {
+			return nil, order.ErrIdempotencyKeyReused
+		}
+		return h.issuer.issue(ctx, o.ID(), existing.ID(), cmd.ActorID)
+	}
+
+	actor, err := h.adminRepo.FindByID(ctx, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := o.RequestRefund(uuid.New(), order.RefundRequest{
+		Kind:           kind,
+		LineID:         cmd.LineID,
+		DeliveredGrams: cmd.DeliveredGrams,
+		Reason:         reason,
+		Note:           cmd.Note,
+		IdempotencyKey: cmd.IdempotencyKey,
+		RequestedBy:    actor.ID(),
+	}, order.ApprovalPolicy{
+		ThresholdCents: h.thresholdCents,
+		CanApprove:     actor.HasPermission(admin.PermissionApproveRefunds),
+	}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveOrder(ctx, h.issuer.orderRepo, o); err != nil {
+		return nil, err
+	}
+	if err := recordRefund(ctx, h.issuer.auditRepo, "refund.requested", actor.ID(), r); err != nil {
+		return nil, err
+	}
+	if r.Status() == order.RefundStatusPendingApproval {
+		return r, nil
+	}
+	return h.issuer.issue(ctx, o.ID(), r.ID(), actor.ID())
+}
+
+func sameRequest(r *order.Refund, kind order.RefundKind, lineID *uuid.UUID, deliveredGrams int64, reason order.Reason) bool {
+	if r.Kind() != kind || r.Reason() != reason || r.DeliveredGrams() != deliveredGrams {
+		return false
+	}
+	if r.LineID() == nil || lineID == nil {
+		return r.LineID() == nil && lineID == nil
+	}
+	return *r.LineID() == *lineID
+}
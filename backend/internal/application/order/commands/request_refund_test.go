@@ -0,0 +1,456 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockOrderRepository) Create(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) Update(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *mockOrderRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+type mockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *mockPaymentRepository) Create(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *mockPaymentRepository) Update(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *mockPaymentRepository) FindByID(ctx context.Context, id uuid.UUID) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindByIdempotencyKey(ctx context.Context, key string) (*payment.Payment, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindByProviderRef(ctx context.Context, provider, providerRef string) (*payment.Payment, error) {
+	args := m.Called(ctx, provider, providerRef)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) SaveRefund(ctx context.Context, p *payment.Payment, r *payment.Refund) error {
+	args := m.Called(ctx, p, r)
+	return args.Error(0)
+}
+
+func (m *mockPaymentRepository) FindRefundByIdempotencyKey(ctx context.Context, paymentID uuid.UUID, key string) (*payment.Refund, error) {
+	args := m.Called(ctx, paymentID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Refund), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindRefunds(ctx context.Context, paymentID uuid.UUID) ([]*payment.Refund, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Refund), args.Error(1)
+}
+
+func (m *mockPaymentRepository) SaveWebhookEvent(ctx context.Context, provider, eventID string, p *payment.Payment) (bool, error) {
+	args := m.Called(ctx, provider, eventID, p)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockGateway struct {
+	mock.Mock
+}
+
+func (m *mockGateway) Name() string { return "fake" }
+
+func (m *mockGateway) Authorize(ctx context.Context, req payment.AuthorizeRequest) (*payment.Authorization, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Authorization), args.Error(1)
+}
+
+func (m *mockGateway) Capture(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) error {
+	args := m.Called(ctx, providerRef, amountCents, idempotencyKey)
+	return args.Error(0)
+}
+
+func (m *mockGateway) Void(ctx context.Context, providerRef string, idempotencyKey string) error {
+	args := m.Called(ctx, providerRef, idempotencyKey)
+	return args.Error(0)
+}
+
+func (m *mockGateway) Refund(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) (string, error) {
+	args := m.Called(ctx, providerRef, amountCents, idempotencyKey)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockGateway) ParseWebhook(payload []byte, signature string) (*payment.Event, error) {
+	args := m.Called(payload, signature)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Event), args.Error(1)
+}
+
+type mockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *mockAuditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *mockAuditRepository) FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*audit.Entry, error) {
+	args := m.Called(ctx, entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.Entry), args.Error(1)
+}
+
+// --- Fixtures ---
+
+// refundFixture is an order of 1.5kg lamb at £20/kg (3000) and 800g mince
+// at £11.25/kg (900), paid for with a captured payment.
+type refundFixture struct {
+	orders   *mockOrderRepository
+	admins   *mockAdminRepository
+	payments *mockPaymentRepository
+	gateway  *mockGateway
+	audit    *mockAuditRepository
+	ledger   *mockLoyaltyLedger
+	rules    *mockLoyaltyRulesRepository
+	order    *order.Order
+	payment  *payment.Payment
+}
+
+func newRefundFixture(t *testing.T) *refundFixture {
+	t.Helper()
+	now := time.Now()
+	o, err := order.NewOrder(uuid.New(), uuid.New(), uuid.New(), []order.Line{
+		{ProductID: uuid.New(), Grams: 1500, PricePerKgCents: 2000, NetCents: 3000, GrossCents: 3000},
+		{ProductID: uuid.New(), Grams: 800, PricePerKgCents: 1125, NetCents: 900, GrossCents: 900},
+	}, nil, now)
+	require.NoError(t, err)
+	p, err := payment.NewPayment(uuid.New(), o.ID(), o.CustomerID(), "gbp", 3900, 1500, "fake", "checkout-1", now)
+	require.NoError(t, err)
+	require.NoError(t, p.Authorize("pi_1", now))
+	require.NoError(t, p.Capture(3900, now))
+
+	f := &refundFixture{
+		orders:   new(mockOrderRepository),
+		admins:   new(mockAdminRepository),
+		payments: new(mockPaymentRepository),
+		gateway:  new(mockGateway),
+		audit:    new(mockAuditRepository),
+		ledger:   new(mockLoyaltyLedger),
+		rules:    new(mockLoyaltyRulesRepository),
+		order:    o,
+		payment:  p,
+	}
+	f.orders.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	f.audit.On("Append", mock.Anything, mock.AnythingOfType("*audit.Entry")).Return(nil)
+	return f
+}
+
+func (f *refundFixture) newAdmin(t *testing.T, role admin.Role) uuid.UUID {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "someone@butchery.com", "$2a$10$hash", "Someone", role)
+	require.NoError(t, err)
+	f.admins.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return a.ID()
+}
+
+// expectIssue sets up the calls made when a refund of amountCents is issued.
+func (f *refundFixture) expectIssue(amountCents int64) {
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	f.payments.On("FindByOrderID", mock.Anything, f.order.ID()).Return([]*payment.Payment{f.payment}, nil)
+	f.payments.On("FindRefundByIdempotencyKey", mock.Anything, f.payment.ID(), mock.AnythingOfType("string")).Return(nil, nil)
+	f.gateway.On("Refund", mock.Anything, "pi_1", amountCents, mock.AnythingOfType("string")).Return("re_1", nil)
+	f.payments.On("SaveRefund", mock.Anything, f.payment, mock.AnythingOfType("*payment.Refund")).Return(nil)
+	f.orders.On("NextReceiptNumber", mock.Anything).Return("RF-000001", nil)
+	f.ledger.On("Entries", mock.Anything, f.order.CustomerID()).Return([]*loyalty.Entry{}, nil).Maybe()
+}
+
+func (f *refundFixture) rewards() *pricing.Rewards {
+	return pricing.NewRewards(f.rules, f.ledger)
+}
+
+func (f *refundFixture) requestHandler() *commands.RequestRefundHandler {
+	return commands.NewRequestRefundHandler(f.orders, f.admins, f.payments, f.gateway, f.audit, f.rewards(), 1000)
+}
+
+func auditActions(f *refundFixture) []string {
+	var actions []string
+	for _, call := range f.audit.Calls {
+		actions = append(actions, call.Arguments.Get(1).(*audit.Entry).Action)
+	}
+	return actions
+}
+
+// --- Tests ---
+
+func TestRequestRefund_BelowThreshold_IssuesThroughPayment(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	f.expectIssue(900)
+	lineID := f.order.Lines()[1].ID
+
+	r, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "unavailable", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.RefundStatusIssued, r.Status())
+	assert.Equal(t, "RF-000001", r.ReceiptNumber())
+	assert.Equal(t, f.payment.ID(), *r.PaymentID())
+	assert.Equal(t, int64(900), f.payment.RefundedCents())
+	assert.Equal(t, order.StatusPartiallyRefunded, f.order.Status())
+	assert.Equal(t, []string{"refund.requested", "refund.issued"}, auditActions(f))
+	f.gateway.AssertCalled(t, "Refund", mock.Anything, "pi_1", int64(900), "refund:"+f.payment.ID().String()+":order-refund:"+r.ID().String())
+}
+
+func TestRequestRefund_TakesBackItsShareOfThePointsEarned(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	earned := &loyalty.Entry{ID: uuid.New(), CustomerID: f.order.CustomerID(), Kind: loyalty.KindEarn, Points: 39, Reference: f.order.ID().String()}
+	f.ledger.On("Entries", mock.Anything, f.order.CustomerID()).Return([]*loyalty.Entry{earned}, nil)
+	f.ledger.On("Balance", mock.Anything, f.order.CustomerID()).Return(loyalty.Balance{Points: 39}, nil)
+	f.ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Kind == loyalty.KindReversal && es[0].Points == -9 && *es[0].SourceID == earned.ID
+	})).Return(nil)
+	f.expectIssue(900)
+	lineID := f.order.Lines()[1].ID
+
+	r, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "unavailable", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.RefundStatusIssued, r.Status())
+	f.ledger.AssertCalled(t, "Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return es[0].Reference == "refund:"+r.ID().String()
+	}))
+}
+
+func TestRequestRefund_PointsAlreadySpent_TakesBackWhatIsLeft(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	earned := &loyalty.Entry{ID: uuid.New(), CustomerID: f.order.CustomerID(), Kind: loyalty.KindEarn, Points: 39, Reference: f.order.ID().String()}
+	f.ledger.On("Entries", mock.Anything, f.order.CustomerID()).Return([]*loyalty.Entry{earned}, nil)
+	f.ledger.On("Balance", mock.Anything, f.order.CustomerID()).Return(loyalty.Balance{Points: 4}, nil)
+	f.ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Points == -4
+	})).Return(nil)
+	f.expectIssue(900)
+	lineID := f.order.Lines()[1].ID
+
+	_, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "unavailable", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+
+	require.NoError(t, err)
+	f.ledger.AssertExpectations(t)
+}
+
+func TestRequestRefund_AboveThreshold_StaffWaitsForManager(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	lineID := f.order.Lines()[0].ID
+
+	r, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "quality", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.RefundStatusPendingApproval, r.Status())
+	assert.Equal(t, []string{"refund.requested"}, auditActions(f))
+	f.gateway.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRequestRefund_AboveThreshold_ManagerIssues(t *testing.T) {
+	f := newRefundFixture(t)
+	managerID := f.newAdmin(t, admin.RoleManager)
+	f.expectIssue(3900)
+
+	r, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "order", Reason: "late_delivery", IdempotencyKey: "refund-1", ActorID: managerID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, order.RefundStatusIssued, r.Status())
+	assert.Equal(t, order.StatusRefunded, f.order.Status())
+	assert.Equal(t, payment.StatusRefunded, f.payment.Status())
+}
+
+func TestRequestRefund_RetriedKey_ReturnsOriginalRefund(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	f.expectIssue(900)
+	lineID := f.order.Lines()[1].ID
+	cmd := commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "unavailable", IdempotencyKey: "refund-1", ActorID: staffID,
+	}
+	first, err := f.requestHandler().Handle(context.Background(), cmd)
+	require.NoError(t, err)
+
+	again, err := f.requestHandler().Handle(context.Background(), cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), again.ID())
+	f.gateway.AssertNumberOfCalls(t, "Refund", 1)
+
+	cmd.Reason = "quality"
+	_, err = f.requestHandler().Handle(context.Background(), cmd)
+	assert.ErrorIs(t, err, order.ErrIdempotencyKeyReused)
+}
+
+func TestRequestRefund_GatewayFails_RefundStaysApprovedForRetry(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	f.payments.On("FindByOrderID", mock.Anything, f.order.ID()).Return([]*payment.Payment{f.payment}, nil)
+	f.payments.On("FindRefundByIdempotencyKey", mock.Anything, f.payment.ID(), mock.AnythingOfType("string")).Return(nil, nil)
+	f.gateway.On("Refund", mock.Anything, "pi_1", int64(900), mock.AnythingOfType("string")).Return("", errors.New("provider unavailable"))
+	lineID := f.order.Lines()[1].ID
+
+	_, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "unavailable", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+
+	require.Error(t, err)
+	r := f.order.RefundByIdempotencyKey("refund-1")
+	require.NotNil(t, r)
+	assert.Equal(t, order.RefundStatusApproved, r.Status())
+	f.payments.AssertNotCalled(t, "SaveRefund", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRequestRefund_UncapturedOrder_ReturnsError(t *testing.T) {
+	f := newRefundFixture(t)
+	staffID := f.newAdmin(t, admin.RoleStaff)
+	f.orders.On("Update", mock.Anything, f.order).Return(nil)
+	f.payments.On("FindByOrderID", mock.Anything, f.order.ID()).Return([]*payment.Payment(nil), nil)
+	lineID := f.order.Lines()[1].ID
+
+	_, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "line", LineID: &lineID, Reason: "unavailable", IdempotencyKey: "refund-1", ActorID: staffID,
+	})
+
+	assert.ErrorIs(t, err, payment.ErrNotCaptured)
+}
+
+func TestRequestRefund_InvalidReason_ReturnsError(t *testing.T) {
+	f := newRefundFixture(t)
+
+	_, err := f.requestHandler().Handle(context.Background(), commands.RequestRefundCommand{
+		OrderID: f.order.ID(), Kind: "order", Reason: "changed_mind", IdempotencyKey: "refund-1",
+	})
+
+	assert.ErrorIs(t, err, order.ErrInvalidReason)
+	f.orders.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// GetOrderHandler returns an order with its lines and refunds.
+type GetOrderHandler struct {
+	orderRepo order.Repository
+}
+
+// NewGetOrderHandler creates a new GetOrderHandler.
+func NewGetOrderHandler(orderRepo order.Repository) *GetOrderHandler {
+	return &GetOrderHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the get order query.
+func (h *GetOrderHandler) Handle(ctx context.Context, orderID uuid.UUID) (*order.Order, error) {
+	return h.orderRepo.FindByID(ctx, orderID)
+}
@@ -0,0 +1,58 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// RefundReceipt is what the customer is given for an issued refund. Line is
+// nil for whole-order refunds.
+type RefundReceipt struct {
+	Order    *order.Order
+	Refund   *order.Refund
+	Line     *order.Line
+	Currency string
+}
+
+// GetRefundReceiptHandler returns the receipt for an issued refund.
+type GetRefundReceiptHandler struct {
+	orderRepo   order.Repository
+	paymentRepo payment.Repository
+}
+
+// NewGetRefundReceiptHandler creates a new GetRefundReceiptHandler.
+func NewGetRefundReceiptHandler(orderRepo order.Repository, paymentRepo payment.Repository) *GetRefundReceiptHandler {
+	return &GetRefundReceiptHandler{orderRepo: orderRepo, paymentRepo: paymentRepo}
+}
+
+// Handle executes the get refund receipt query.
+func (h *GetRefundReceiptHandler) Handle(ctx context.Context, orderID, refundID uuid.UUID) (*RefundReceipt, error) {
+	o, err := h.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := o.Refund(refundID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status() != order.RefundStatusIssued {
+		return nil, order.ErrRefundNotIssued
+	}
+
+	p, err := h.paymentRepo.FindByID(ctx, *r.PaymentID())
+	if err != nil {
+		return nil, fmt.Errorf("finding refunded payment: %w", err)
+	}
+
+	receipt := &RefundReceipt{Order: o, Refund: r, Currency: p.Currency()}
+	if r.LineID() != nil {
+		if l, ok := o.Line(*r.LineID()); ok {
+			receipt.Line = &l
+		}
+	}
+	return receipt, nil
+}
@@ -0,0 +1,34 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/tracking"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// WatchOrdersQuery is the input for the watch orders use case. A customer
+// watches their own orders; the cutting room watches every branch in
+// scope, or only BranchID. LastEventID is the last event the client got
+// before reconnecting, if any.
+type WatchOrdersQuery struct {
+	CustomerID  *uuid.UUID
+	BranchID    *uuid.UUID
+	LastEventID int64
+}
+
+// WatchOrdersHandler streams changes to orders as they happen.
+type WatchOrdersHandler struct {
+	tracker *tracking.Tracker
+}
+
+// NewWatchOrdersHandler creates a new WatchOrdersHandler.
+func NewWatchOrdersHandler(tracker *tracking.Tracker) *WatchOrdersHandler {
+	return &WatchOrdersHandler{tracker: tracker}
+}
+
+// Handle returns the order events until ctx is done; see Tracker.Watch.
+func (h *WatchOrdersHandler) Handle(ctx context.Context, q WatchOrdersQuery) (<-chan order.Event, error) {
+	return h.tracker.Watch(ctx, order.EventFilter{CustomerID: q.CustomerID, BranchID: q.BranchID, AfterID: q.LastEventID})
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// AuthorizePaymentCommand is the input for the authorize payment use case.
+// IdempotencyKey is chosen by the client; retrying with the same key returns
+// the original payment instead of authorizing the card again.
+type AuthorizePaymentCommand struct {
+	OrderID        uuid.UUID
+	CustomerID     uuid.UUID
+	PaymentMethod  string
+	IdempotencyKey string
+}
+
+// AuthorizePaymentHandler holds the estimated total of an order, plus a
+// margin for the difference between estimated and weighed prices, on the
+// customer's card.
+type AuthorizePaymentHandler struct {
+	orderRepo   order.Repository
+	paymentRepo payment.Repository
+	gateway     payment.PaymentGateway
+	currency    string
+	marginBP    int
+}
+
+// NewAuthorizePaymentHandler creates a new AuthorizePaymentHandler. Cards are
+// charged in currency and the hold is raised by marginBP basis points.
+func NewAuthorizePaymentHandler(
+	orderRepo order.Repository,
+	paymentRepo payment.Repository,
+	gateway payment.PaymentGateway,
+	currency string,
+	marginBP int,
+) *AuthorizePaymentHandler {
+	return &AuthorizePaymentHandler{orderRepo: orderRepo, paymentRepo: paymentRepo, gateway: gateway, currency: currency, marginBP: marginBP}
+}
+
+// Handle executes the authorize payment use case. The estimate is the
+// order's total as recorded; another customer's order is reported as not
+// found. A payment left pending by an earlier attempt that failed part-way
+// is authorized again under the same provider idempotency key, so the card
+// is never held twice.
+func (h *AuthorizePaymentHandler) Handle(ctx context.Context, cmd AuthorizePaymentCommand) (*payment.Payment, error) {
+	if strings.TrimSpace(cmd.PaymentMethod) == "" {
+		return nil, payment.ErrEmptyPaymentMethod
+	}
+
+	o, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.CustomerID() != cmd.CustomerID {
+		return nil, order.ErrOrderNotFound
+	}
+	estimatedCents := o.TotalCents()
+
+	p, err := h.paymentRepo.FindByIdempotencyKey(ctx, strings.TrimSpace(cmd.IdempotencyKey))
+	switch {
+	case err == nil:
+		if p.CustomerID() != cmd.CustomerID || p.OrderID() != cmd.OrderID || p.EstimatedCents() != estimatedCents {
+			return nil, payment.ErrIdempotencyKeyReused
+		}
+		if p.Status() == payment.StatusFailed {
+			return nil, fmt.Errorf("%w: %s", payment.ErrDeclined, p.FailureReason())
+		}
+		if p.Status() != payment.StatusPending {
+			return p, nil
+		}
+	case errors.Is(err, payment.ErrPaymentNotFound):
+		p, err = payment.NewPayment(uuid.New(), cmd.OrderID, cmd.CustomerID, h.currency, estimatedCents,
+			h.marginBP, h.gateway.Name(), cmd.IdempotencyKey, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := h.paymentRepo.Create(ctx, p); err != nil {
+			if errors.Is(err, payment.ErrIdempotencyKeyReused) {
+				return nil, payment.ErrAuthorizationInProgress
+			}
+			return nil, fmt.Errorf("saving payment: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("finding payment: %w", err)
+	}
+
+	auth, err := h.gateway.Authorize(ctx, payment.AuthorizeRequest{
+		PaymentID:      p.ID().String(),
+		AmountCents:    p.AuthorizedCents(),
+		Currency:       p.Currency(),
+		PaymentMethod:  strings.TrimSpace(cmd.PaymentMethod),
+		IdempotencyKey: "authorize:" + p.ID().String(),
+	})
+	if errors.Is(err, payment.ErrDeclined) {
+		declined := err
+		reason := strings.TrimPrefix(declined.Error(), payment.ErrDeclined.Error()+": ")
+		if err := p.Fail(reason, time.Now()); err != nil {
+			return nil, err
+		}
+		if err := updatePayment(ctx, h.paymentRepo, p); err != nil {
+			return nil, err
+		}
+		return nil, declined
+	}
+	if err != nil {
+		return nil, fmt.Errorf("authorizing payment: %w", err)
+	}
+
+	if err := p.Authorize(auth.ProviderRef, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updatePayment(ctx, h.paymentRepo, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func updatePayment(ctx context.Context, paymentRepo payment.Repository, p *payment.Payment) error {
+	if err := paymentRepo.Update(ctx, p); err != nil {
+		if errors.Is(err, payment.ErrConcurrentUpdate) {
+			return err
+		}
+		return fmt.Errorf("saving payment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *mockPaymentRepository) Create(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *mockPaymentRepository) Update(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *mockPaymentRepository) FindByID(ctx context.Context, id uuid.UUID) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindByIdempotencyKey(ctx context.Context, key string) (*payment.Payment, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindByProviderRef(ctx context.Context, provider, providerRef string) (*payment.Payment, error) {
+	args := m.Called(ctx, provider, providerRef)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *mockPaymentRepository) SaveRefund(ctx context.Context, p *payment.Payment, r *payment.Refund) error {
+	args := m.Called(ctx, p, r)
+	return args.Error(0)
+}
+
+func (m *mockPaymentRepository) FindRefundByIdempotencyKey(ctx context.Context, paymentID uuid.UUID, key string) (*payment.Refund, error) {
+	args := m.Called(ctx, paymentID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Refund), args.Error(1)
+}
+
+func (m *mockPaymentRepository) FindRefunds(ctx context.Context, paymentID uuid.UUID) ([]*payment.Refund, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Refund), args.Error(1)
+}
+
+func (m *mockPaymentRepository) SaveWebhookEvent(ctx context.Context, provider, eventID string, p *payment.Payment) (bool, error) {
+	args := m.Called(ctx, provider, eventID, p)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockOrderRepository) Create(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) Update(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *mockOrderRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+type mockGateway struct {
+	mock.Mock
+}
+
+func (m *mockGateway) Name() string { return "fake" }
+
+func (m *mockGateway) Authorize(ctx context.Context, req payment.AuthorizeRequest) (*payment.Authorization, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Authorization), args.Error(1)
+}
+
+func (m *mockGateway) Capture(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) error {
+	args := m.Called(ctx, providerRef, amountCents, idempotencyKey)
+	return args.Error(0)
+}
+
+func (m *mockGateway) Void(ctx context.Context, providerRef string, idempotencyKey string) error {
+	args := m.Called(ctx, providerRef, idempotencyKey)
+	return args.Error(0)
+}
+
+func (m *mockGateway) Refund(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) (string, error) {
+	args := m.Called(ctx, providerRef, amountCents, idempotencyKey)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockGateway) ParseWebhook(payload []byte, signature string) (*payment.Event, error) {
+	args := m.Called(payload, signature)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Event), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func newPendingPayment(t *testing.T, orderID, customerID uuid.UUID, estimatedCents int64) *payment.Payment {
+	t.Helper()
+	p, err := payment.NewPayment(uuid.New(), orderID, customerID, "gbp", estimatedCents, 1500, "fake", "checkout-1", time.Now())
+	require.NoError(t, err)
+	return p
+}
+
+func newAuthorizedPayment(t *testing.T, estimatedCents int64) *payment.Payment {
+	t.Helper()
+	p := newPendingPayment(t, uuid.New(), uuid.New(), estimatedCents)
+	require.NoError(t, p.Authorize("pi_1", time.Now()))
+	return p
+}
+
+// newOrder returns a customer's order worth totalCents, stored in orders.
+func newOrder(t *testing.T, orders *mockOrderRepository, customerID uuid.UUID, totalCents int64) *order.Order {
+	t.Helper()
+	o, err := order.NewOrder(uuid.New(), customerID, uuid.New(), []order.Line{
+		{ProductID: uuid.New(), Grams: 1000, PricePerKgCents: totalCents, NetCents: totalCents, GrossCents: totalCents},
+	}, nil, time.Now())
+	require.NoError(t, err)
+	orders.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	return o
+}
+
+// --- AuthorizePayment Tests ---
+
+func TestAuthorizePaymentHandler_Handle_HoldsOrderTotalPlusMargin(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, gw, "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+	cmd := commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	}
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(nil, payment.ErrPaymentNotFound)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	gw.On("Authorize", mock.Anything, mock.MatchedBy(func(req payment.AuthorizeRequest) bool {
+		return req.AmountCents == 11500 && req.Currency == "gbp" && req.IdempotencyKey == "authorize:"+req.PaymentID
+	})).Return(&payment.Authorization{ProviderRef: "pi_1"}, nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	p, err := h.Handle(context.Background(), cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusAuthorized, p.Status())
+	assert.Equal(t, int64(10000), p.EstimatedCents())
+	assert.Equal(t, "pi_1", p.ProviderRef())
+	assert.Equal(t, "fake", p.Provider())
+	gw.AssertExpectations(t)
+}
+
+func TestAuthorizePaymentHandler_Handle_AnotherCustomersOrder_ReturnsNotFound(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, gw, "gbp", 1500)
+	o := newOrder(t, orders, uuid.New(), 10000)
+
+	_, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: uuid.New(), PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	gw.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizePaymentHandler_Handle_UnknownOrder_ReturnsNotFound(t *testing.T) {
+	orders := new(mockOrderRepository)
+	h := commands.NewAuthorizePaymentHandler(orders, new(mockPaymentRepository), new(mockGateway), "gbp", 1500)
+	orders.On("FindByID", mock.Anything, mock.Anything).Return(nil, order.ErrOrderNotFound)
+
+	_, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: uuid.New(), CustomerID: uuid.New(), PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	assert.ErrorIs(t, err, order.ErrOrderNotFound)
+}
+
+func TestAuthorizePaymentHandler_Handle_SameKey_ReturnsOriginalPayment(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, gw, "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+	existing := newPendingPayment(t, o.ID(), customerID, 10000)
+	require.NoError(t, existing.Authorize("pi_1", time.Now()))
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(existing, nil)
+
+	p, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	require.NoError(t, err)
+	assert.Same(t, existing, p)
+	gw.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizePaymentHandler_Handle_SameKeyDifferentOrder_ReturnsError(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, new(mockGateway), "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+	existing := newPendingPayment(t, uuid.New(), customerID, 10000)
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(existing, nil)
+
+	_, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	assert.ErrorIs(t, err, payment.ErrIdempotencyKeyReused)
+}
+
+func TestAuthorizePaymentHandler_Handle_PendingPayment_RetriesWithSameProviderKey(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, gw, "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+	existing := newPendingPayment(t, o.ID(), customerID, 10000)
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(existing, nil)
+	gw.On("Authorize", mock.Anything, mock.MatchedBy(func(req payment.AuthorizeRequest) bool {
+		return req.IdempotencyKey == "authorize:"+existing.ID().String()
+	})).Return(&payment.Authorization{ProviderRef: "pi_1"}, nil)
+	repo.On("Update", mock.Anything, existing).Return(nil)
+
+	p, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusAuthorized, p.Status())
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizePaymentHandler_Handle_Declined_RecordsFailure(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, gw, "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(nil, payment.ErrPaymentNotFound)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	gw.On("Authorize", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: insufficient funds", payment.ErrDeclined))
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Status() == payment.StatusFailed && p.FailureReason() == "insufficient funds"
+	})).Return(nil)
+
+	_, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	assert.ErrorIs(t, err, payment.ErrDeclined)
+	repo.AssertExpectations(t)
+}
+
+func TestAuthorizePaymentHandler_Handle_GatewayError_LeavesPaymentPending(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, gw, "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(nil, payment.ErrPaymentNotFound)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	gw.On("Authorize", mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+	_, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, payment.ErrDeclined)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizePaymentHandler_Handle_ConcurrentSameKey_ReturnsInProgress(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	h := commands.NewAuthorizePaymentHandler(orders, repo, new(mockGateway), "gbp", 1500)
+	customerID := uuid.New()
+	o := newOrder(t, orders, customerID, 10000)
+
+	repo.On("FindByIdempotencyKey", mock.Anything, "checkout-1").Return(nil, payment.ErrPaymentNotFound)
+	repo.On("Create", mock.Anything, mock.Anything).Return(payment.ErrIdempotencyKeyReused)
+
+	_, err := h.Handle(context.Background(), commands.AuthorizePaymentCommand{
+		OrderID: o.ID(), CustomerID: customerID, PaymentMethod: "pm_card_visa", IdempotencyKey: "checkout-1",
+	})
+
+	assert.ErrorIs(t, err, payment.ErrAuthorizationInProgress)
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// CapturePaymentCommand is the input for the capture payment use case.
+type CapturePaymentCommand struct {
+	PaymentID uuid.UUID
+}
+
+// CapturePaymentHandler takes the weighed total of an order from the hold
+// placed at checkout and releases the rest.
+type CapturePaymentHandler struct {
+	orderRepo   order.Repository
+	paymentRepo payment.Repository
+	gateway     payment.PaymentGateway
+}
+
+// NewCapturePaymentHandler creates a new CapturePaymentHandler.
+func NewCapturePaymentHandler(orderRepo order.Repository, paymentRepo payment.Repository, gateway payment.PaymentGateway) *CapturePaymentHandler {
+	return &CapturePaymentHandler{orderRepo: orderRepo, paymentRepo: paymentRepo, gateway: gateway}
+}
+
+// Handle executes the capture payment use case. The amount is the total of
+// the order's weighed lines, which may not come to more than the hold, the
+// estimate plus its margin. Capturing a payment again returns it unchanged.
+func (h *CapturePaymentHandler) Handle(ctx context.Context, cmd CapturePaymentCommand) (*payment.Payment, error) {
+	p, err := h.paymentRepo.FindByID(ctx, cmd.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	o, err := h.orderRepo.FindByID(ctx, p.OrderID())
+	if err != nil {
+		return nil, err
+	}
+	amountCents := o.TotalCents()
+	if p.Status() == payment.StatusCaptured && p.CapturedCents() == amountCents {
+		return p, nil
+	}
+	if err := p.Capture(amountCents, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := h.gateway.Capture(ctx, p.ProviderRef(), amountCents, "capture:"+p.ID().String()); err != nil {
+		return nil, fmt.Errorf("capturing payment: %w", err)
+	}
+	if err := updatePayment(ctx, h.paymentRepo, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,88 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturePaymentHandler_Handle_CapturesWeighedAmount(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewCapturePaymentHandler(orders, repo, gw)
+	o := newOrder(t, orders, uuid.New(), 10800)
+	p := newPendingPayment(t, o.ID(), o.CustomerID(), 10000)
+	require.NoError(t, p.Authorize("pi_1", time.Now()))
+
+	repo.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+	gw.On("Capture", mock.Anything, "pi_1", int64(10800), "capture:"+p.ID().String()).Return(nil)
+	repo.On("Update", mock.Anything, p).Return(nil)
+
+	got, err := h.Handle(context.Background(), commands.CapturePaymentCommand{PaymentID: p.ID()})
+
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusCaptured, got.Status())
+	assert.Equal(t, int64(10800), got.CapturedCents())
+}
+
+func TestCapturePaymentHandler_Handle_OverAuthorization_DoesNotCallGateway(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewCapturePaymentHandler(orders, repo, gw)
+	o := newOrder(t, orders, uuid.New(), 11501)
+	p := newPendingPayment(t, o.ID(), o.CustomerID(), 10000)
+	require.NoError(t, p.Authorize("pi_1", time.Now()))
+
+	repo.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+
+	_, err := h.Handle(context.Background(), commands.CapturePaymentCommand{PaymentID: p.ID()})
+
+	assert.ErrorIs(t, err, payment.ErrCaptureExceedsAuthorization)
+	gw.AssertNotCalled(t, "Capture", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCapturePaymentHandler_Handle_AlreadyCaptured_IsNoOp(t *testing.T) {
+	orders := new(mockOrderRepository)
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewCapturePaymentHandler(orders, repo, gw)
+	o := newOrder(t, orders, uuid.New(), 10800)
+	p := newPendingPayment(t, o.ID(), o.CustomerID(), 10000)
+	require.NoError(t, p.Authorize("pi_1", time.Now()))
+	require.NoError(t, p.Capture(10800, p.UpdatedAt()))
+
+	repo.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+
+	got, err := h.Handle(context.Background(), commands.CapturePaymentCommand{PaymentID: p.ID()})
+
+	require.NoError(t, err)
+	assert.Same(t, p, got)
+	gw.AssertNotCalled(t, "Capture", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestVoidPaymentHandler_Handle_ReleasesHold(t *testing.T) {
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewVoidPaymentHandler(repo, gw)
+	p := newAuthorizedPayment(t, 10000)
+
+	repo.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+	gw.On("Void", mock.Anything, "pi_1", "void:"+p.ID().String()).Return(nil)
+	repo.On("Update", mock.Anything, p).Return(nil)
+
+	got, err := h.Handle(context.Background(), p.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusVoided, got.Status())
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// HandleWebhookCommand is the input for the handle webhook use case.
+type HandleWebhookCommand struct {
+	Payload   []byte
+	Signature string
+}
+
+// HandleWebhookHandler applies payment provider notifications, so that
+// payments changed outside the app, or whose request failed part-way, catch
+// up with the provider.
+type HandleWebhookHandler struct {
+	paymentRepo payment.Repository
+	gateway     payment.PaymentGateway
+}
+
+// NewHandleWebhookHandler creates a new HandleWebhookHandler.
+func NewHandleWebhookHandler(paymentRepo payment.Repository, gateway payment.PaymentGateway) *HandleWebhookHandler {
+	return &HandleWebhookHandler{paymentRepo: paymentRepo, gateway: gateway}
+}
+
+// Handle executes the handle webhook use case. Each event is applied at
+// most once however often the provider delivers it; events for payments
+// this app does not know are recorded and otherwise ignored.
+func (h *HandleWebhookHandler) Handle(ctx context.Context, cmd HandleWebhookCommand) error {
+	e, err := h.gateway.ParseWebhook(cmd.Payload, cmd.Signature)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			return err
+		}
+		return fmt.Errorf("parsing webhook: %w", err)
+	}
+
+	var changed *payment.Payment
+	if e.Type != payment.EventIgnored {
+		p, err := h.findPayment(ctx, e)
+		if err != nil && !errors.Is(err, payment.ErrPaymentNotFound) {
+			return fmt.Errorf("finding payment: %w", err)
+		}
+		if p != nil && p.ApplyEvent(*e, time.Now()) {
+			changed = p
+		}
+	}
+
+	if _, err := h.paymentRepo.SaveWebhookEvent(ctx, h.gateway.Name(), e.ID, changed); err != nil {
+		if errors.Is(err, payment.ErrConcurrentUpdate) {
+			return err
+		}
+		return fmt.Errorf("saving webhook event: %w", err)
+	}
+	return nil
+}
+
+// findPayment looks the payment up by the ID sent to the provider, which
+// is known even if the authorization never came back, and otherwise by the
+// provider's reference.
+func (h *HandleWebhookHandler) findPayment(ctx context.Context, e *payment.Event) (*payment.Payment, error) {
+	if id, err := uuid.Parse(e.PaymentID); err == nil {
+		return h.paymentRepo.FindByID(ctx, id)
+	}
+	if e.ProviderRef == "" {
+		return nil, payment.ErrPaymentNotFound
+	}
+	return h.paymentRepo.FindByProviderRef(ctx, h.gateway.Name(), e.ProviderRef)
+}
@@ -0,0 +1,80 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleWebhookHandler_Handle_AppliesEventToPayment(t *testing.T) {
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewHandleWebhookHandler(repo, gw)
+	p := newPendingPayment(t, uuid.New(), uuid.New(), 10000)
+	payload := []byte(`{}`)
+
+	gw.On("ParseWebhook", payload, "sig").Return(&payment.Event{
+		ID: "evt_1", Type: payment.EventAuthorized, PaymentID: p.ID().String(), ProviderRef: "pi_1", AmountCents: 11500,
+	}, nil)
+	repo.On("FindByID", mock.Anything, p.ID()).Return(p, nil)
+	repo.On("SaveWebhookEvent", mock.Anything, "fake", "evt_1", p).Return(true, nil)
+
+	err := h.Handle(context.Background(), commands.HandleWebhookCommand{Payload: payload, Signature: "sig"})
+
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusAuthorized, p.Status())
+	assert.Equal(t, "pi_1", p.ProviderRef())
+}
+
+func TestHandleWebhookHandler_Handle_UnchangedPayment_RecordsEventOnly(t *testing.T) {
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewHandleWebhookHandler(repo, gw)
+	p := newAuthorizedPayment(t, 10000)
+
+	gw.On("ParseWebhook", mock.Anything, "sig").Return(&payment.Event{
+		ID: "evt_1", Type: payment.EventAuthorized, ProviderRef: "pi_1",
+	}, nil)
+	repo.On("FindByProviderRef", mock.Anything, "fake", "pi_1").Return(p, nil)
+	repo.On("SaveWebhookEvent", mock.Anything, "fake", "evt_1", (*payment.Payment)(nil)).Return(true, nil)
+
+	err := h.Handle(context.Background(), commands.HandleWebhookCommand{Payload: []byte(`{}`), Signature: "sig"})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestHandleWebhookHandler_Handle_UnknownPayment_IsIgnored(t *testing.T) {
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewHandleWebhookHandler(repo, gw)
+
+	gw.On("ParseWebhook", mock.Anything, "sig").Return(&payment.Event{
+		ID: "evt_1", Type: payment.EventCaptured, ProviderRef: "pi_other", AmountCents: 100,
+	}, nil)
+	repo.On("FindByProviderRef", mock.Anything, "fake", "pi_other").Return(nil, payment.ErrPaymentNotFound)
+	repo.On("SaveWebhookEvent", mock.Anything, "fake", "evt_1", (*payment.Payment)(nil)).Return(true, nil)
+
+	err := h.Handle(context.Background(), commands.HandleWebhookCommand{Payload: []byte(`{}`), Signature: "sig"})
+
+	assert.NoError(t, err)
+}
+
+func TestHandleWebhookHandler_Handle_InvalidSignature_ReturnsError(t *testing.T) {
+	repo := new(mockPaymentRepository)
+	gw := new(mockGateway)
+	h := commands.NewHandleWebhookHandler(repo, gw)
+
+	gw.On("ParseWebhook", mock.Anything, "bad").Return(nil, payment.ErrInvalidSignature)
+
+	err := h.Handle(context.Background(), commands.HandleWebhookCommand{Payload: []byte(`{}`), Signature: "bad"})
+
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+	repo.AssertNotCalled(t, "SaveWebhookEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// VoidPaymentHandler releases the hold on a customer's card without taking
+// anything, e.g. when an order is cancelled before it is cut.
+type VoidPaymentHandler struct {
+	paymentRepo payment.Repository
+	gateway     payment.PaymentGateway
+}
+
+// NewVoidPaymentHandler creates a new VoidPaymentHandler.
+func NewVoidPaymentHandler(paymentRepo payment.Repository, gateway payment.PaymentGateway) *VoidPaymentHandler {
+	return &VoidPaymentHandler{paymentRepo: paymentRepo, gateway: gateway}
+}
+
+// Handle executes the void payment use case. Voiding a voided payment
+// returns it unchanged.
+func (h *VoidPaymentHandler) Handle(ctx context.Context, paymentID uuid.UUID) (*payment.Payment, error) {
+	p, err := h.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status() == payment.StatusVoided {
+		return p, nil
+	}
+	if err := p.Void(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := h.gateway.Void(ctx, p.ProviderRef(), "void:"+p.ID().String()); err != nil {
+		return nil, fmt.Errorf("voiding payment: %w", err)
+	}
+	if err := updatePayment(ctx, h.paymentRepo, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,38 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// PaymentDetail is a payment with the refunds made against it.
+type PaymentDetail struct {
+	Payment *payment.Payment
+	Refunds []*payment.Refund
+}
+
+// GetPaymentHandler returns a payment and its refunds.
+type GetPaymentHandler struct {
+	paymentRepo payment.Repository
+}
+
+// NewGetPaymentHandler creates a new GetPaymentHandler.
+func NewGetPaymentHandler(paymentRepo payment.Repository) *GetPaymentHandler {
+	return &GetPaymentHandler{paymentRepo: paymentRepo}
+}
+
+// Handle executes the get payment use case.
+func (h *GetPaymentHandler) Handle(ctx context.Context, paymentID uuid.UUID) (*PaymentDetail, error) {
+	p, err := h.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := h.paymentRepo.FindRefunds(ctx, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("finding refunds: %w", err)
+	}
+	return &PaymentDetail{Payment: p, Refunds: refunds}, nil
+}
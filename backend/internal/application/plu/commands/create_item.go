@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+)
+
+// CreateItemCommand is the input for the create PLU item use case.
+type CreateItemCommand struct {
+	Code            int
+	ProductID       uuid.UUID
+	Description     string
+	PricePerKgCents int64
+	Allergens       []plu.Allergen
+	ShelfLifeDays   int
+}
+
+// CreateItemHandler gives a product a PLU code for scales and barcodes.
+type CreateItemHandler struct {
+	itemRepo plu.Repository
+}
+
+// NewCreateItemHandler creates a new CreateItemHandler.
+func NewCreateItemHandler(itemRepo plu.Repository) *CreateItemHandler {
+	return &CreateItemHandler{itemRepo: itemRepo}
+}
+
+// Handle executes the create PLU item use case. A code already in use
+// returns ErrDuplicateCode.
+func (h *CreateItemHandler) Handle(ctx context.Context, cmd CreateItemCommand) (*plu.Item, error) {
+	now := time.Now()
+	i, err := plu.NewItem(uuid.New(), cmd.Code, cmd.ProductID, cmd.Description, cmd.PricePerKgCents, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.SetLabelling(cmd.Allergens, cmd.ShelfLifeDays, now); err != nil {
+		return nil, err
+	}
+	if err := h.itemRepo.Save(ctx, i); err != nil {
+		if errors.Is(err, plu.ErrDuplicateCode) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving PLU item: %w", err)
+	}
+	return i, nil
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+)
+
+// UpdateItemCommand is the input for the update PLU item use case.
+type UpdateItemCommand struct {
+	ItemID          uuid.UUID
+	Description     string
+	PricePerKgCents int64
+	Allergens       []plu.Allergen
+	ShelfLifeDays   int
+	Active          bool
+}
+
+// UpdateItemHandler changes a PLU item's description, price and labelling,
+// or withdraws it.
+type UpdateItemHandler struct {
+	itemRepo plu.Repository
+}
+
+// NewUpdateItemHandler creates a new UpdateItemHandler.
+func NewUpdateItemHandler(itemRepo plu.Repository) *UpdateItemHandler {
+	return &UpdateItemHandler{itemRepo: itemRepo}
+}
+
+// Handle executes the update PLU item use case.
+func (h *UpdateItemHandler) Handle(ctx context.Context, cmd UpdateItemCommand) (*plu.Item, error) {
+	i, err := h.itemRepo.FindByID(ctx, cmd.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := i.Update(cmd.Description, cmd.PricePerKgCents, cmd.Active, now); err != nil {
+		return nil, err
+	}
+	if err := i.SetLabelling(cmd.Allergens, cmd.ShelfLifeDays, now); err != nil {
+		return nil, err
+	}
+	if err := h.itemRepo.Save(ctx, i); err != nil {
+		return nil, fmt.Errorf("saving PLU item: %w", err)
+	}
+	return i, nil
+}
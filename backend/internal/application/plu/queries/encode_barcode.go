@@ -0,0 +1,54 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+)
+
+// EncodeBarcodeHandler works out the barcode to print on a weighed pack.
+type EncodeBarcodeHandler struct {
+	itemRepo plu.Repository
+	layouts  barcode.Layouts
+}
+
+// NewEncodeBarcodeHandler creates a new EncodeBarcodeHandler that lays out
+// codes with the store's layouts.
+func NewEncodeBarcodeHandler(itemRepo plu.Repository, layouts barcode.Layouts) *EncodeBarcodeHandler {
+	return &EncodeBarcodeHandler{itemRepo: itemRepo, layouts: layouts}
+}
+
+// Handle returns the code of a pack of grams of an item, embedding its
+// weight or its price as measure says.
+func (h *EncodeBarcodeHandler) Handle(ctx context.Context, itemID uuid.UUID, grams int64, measure barcode.Measure) (*Scan, error) {
+	i, err := h.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !i.Active() {
+		return nil, plu.ErrItemInactive
+	}
+	pack, err := i.PackOfWeight(grams)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := h.layouts.For(measure)
+	if err != nil {
+		return nil, err
+	}
+	value := pack.Grams
+	if measure == barcode.Price {
+		value = pack.PriceCents
+	}
+	code, err := layout.Encode(i.Code(), value)
+	if err != nil {
+		return nil, err
+	}
+	return &Scan{
+		Code: barcode.Code{Code: code, Layout: layout, Item: i.Code(), Measure: measure, Value: value},
+		Item: i,
+		Pack: pack,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+)
+
+// ListItemsHandler lists PLU items.
+type ListItemsHandler struct {
+	itemRepo plu.Repository
+}
+
+// NewListItemsHandler creates a new ListItemsHandler.
+func NewListItemsHandler(itemRepo plu.Repository) *ListItemsHandler {
+	return &ListItemsHandler{itemRepo: itemRepo}
+}
+
+// Handle returns every item in code order.
+func (h *ListItemsHandler) Handle(ctx context.Context) ([]*plu.Item, error) {
+	items, err := h.itemRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying PLU items: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+)
+
+// ResolveBarcodeHandler reads a scanned variable-measure barcode, for the
+// till and for weighing order lines.
+type ResolveBarcodeHandler struct {
+	itemRepo plu.Repository
+	layouts  barcode.Layouts
+}
+
+// NewResolveBarcodeHandler creates a new ResolveBarcodeHandler that reads
+// codes with the store's layouts.
+func NewResolveBarcodeHandler(itemRepo plu.Repository, layouts barcode.Layouts) *ResolveBarcodeHandler {
+	return &ResolveBarcodeHandler{itemRepo: itemRepo, layouts: layouts}
+}
+
+// Handle returns the item, weight and price of a scanned pack. Packs of
+// withdrawn items still scan. The weight of a price-embedded pack is
+// worked back from the item's current price.
+func (h *ResolveBarcodeHandler) Handle(ctx context.Context, code string) (*Scan, error) {
+	decoded, err := h.layouts.Decode(code)
+	if err != nil {
+		return nil, err
+	}
+	i, err := h.itemRepo.FindByCode(ctx, decoded.Item)
+	if err != nil {
+		return nil, err
+	}
+	var pack plu.Pack
+	if decoded.Measure == barcode.Weight {
+		if pack, err = i.PackOfWeight(decoded.Value); err != nil {
+			return nil, err
+		}
+	} else {
+		pack = i.PackOfPrice(decoded.Value)
+	}
+	return &Scan{Code: decoded, Item: i, Pack: pack}, nil
+}
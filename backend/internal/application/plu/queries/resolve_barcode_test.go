@@ -0,0 +1,131 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/plu/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockItemRepository struct {
+	mock.Mock
+}
+
+func (m *mockItemRepository) Save(ctx context.Context, i *plu.Item) error {
+	args := m.Called(ctx, i)
+	return args.Error(0)
+}
+
+func (m *mockItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByCode(ctx context.Context, code int) (*plu.Item, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByProduct(ctx context.Context, productID uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindAll(ctx context.Context) ([]*plu.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*plu.Item), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func newLayouts(t *testing.T) barcode.Layouts {
+	t.Helper()
+	layouts, err := barcode.ParseLayouts([]string{"20IIIIIWWWWWC", "22IIIIIVPPPPC"})
+	require.NoError(t, err)
+	return layouts
+}
+
+func newItem(t *testing.T) *plu.Item {
+	t.Helper()
+	i, err := plu.NewItem(uuid.New(), 123, uuid.New(), "Lamb mince", 1299, time.Now())
+	require.NoError(t, err)
+	return i
+}
+
+// --- Tests ---
+
+func TestEncodeThenResolve_RoundTrips(t *testing.T) {
+	repo := new(mockItemRepository)
+	layouts := newLayouts(t)
+	encode := queries.NewEncodeBarcodeHandler(repo, layouts)
+	resolve := queries.NewResolveBarcodeHandler(repo, layouts)
+
+	i := newItem(t)
+	repo.On("FindByID", mock.Anything, i.ID()).Return(i, nil)
+	repo.On("FindByCode", mock.Anything, 123).Return(i, nil)
+
+	for _, measure := range []barcode.Measure{barcode.Weight, barcode.Price} {
+		t.Run(string(measure), func(t *testing.T) {
+			encoded, err := encode.Handle(context.Background(), i.ID(), 1250, measure)
+			require.NoError(t, err)
+			assert.Equal(t, plu.Pack{Grams: 1250, PriceCents: 1624}, encoded.Pack)
+
+			scanned, err := resolve.Handle(context.Background(), encoded.Code.Code)
+			require.NoError(t, err)
+			assert.Equal(t, i, scanned.Item)
+			assert.Equal(t, measure, scanned.Code.Measure)
+			assert.Equal(t, encoded.Pack, scanned.Pack)
+		})
+	}
+}
+
+func TestResolveBarcode_UnknownItem(t *testing.T) {
+	repo := new(mockItemRepository)
+	handler := queries.NewResolveBarcodeHandler(repo, newLayouts(t))
+	repo.On("FindByCode", mock.Anything, 123).Return(nil, plu.ErrItemNotFound)
+
+	_, err := handler.Handle(context.Background(), "2000123012506")
+
+	assert.ErrorIs(t, err, plu.ErrItemNotFound)
+}
+
+func TestResolveBarcode_NotVariableMeasure(t *testing.T) {
+	handler := queries.NewResolveBarcodeHandler(new(mockItemRepository), newLayouts(t))
+
+	_, err := handler.Handle(context.Background(), "4006381333931")
+
+	assert.ErrorIs(t, err, barcode.ErrNoLayout)
+}
+
+func TestEncodeBarcode_WithdrawnItem(t *testing.T) {
+	repo := new(mockItemRepository)
+	handler := queries.NewEncodeBarcodeHandler(repo, newLayouts(t))
+	i := newItem(t)
+	require.NoError(t, i.Update("Lamb mince", 1299, false, time.Now()))
+	repo.On("FindByID", mock.Anything, i.ID()).Return(i, nil)
+
+	_, err := handler.Handle(context.Background(), i.ID(), 1250, barcode.Weight)
+
+	assert.ErrorIs(t, err, plu.ErrItemInactive)
+}
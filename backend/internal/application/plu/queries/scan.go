@@ -0,0 +1,13 @@
+package queries
+
+import (
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+)
+
+// Scan is a variable-measure barcode with the item and pack it stands for.
+type Scan struct {
+	Code barcode.Code
+	Item *plu.Item
+	Pack plu.Pack
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	pluqry "github.com/katerji/butchery-app/backend/internal/application/plu/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// AddSaleLineCommand is the input for the add sale line use case. An item
+// is either a scanned Barcode, or a PLUCode keyed in with the Grams the
+// counter scale shows.
+type AddSaleLineCommand struct {
+	SaleID  uuid.UUID
+	Barcode string
+	PLUCode int
+	Grams   int64
+	ActorID uuid.UUID
+}
+
+// AddSaleLineHandler rings an item up on a sale.
+type AddSaleLineHandler struct {
+	saleRepo  pos.SaleRepository
+	itemRepo  plu.Repository
+	priceRepo branch.PriceRepository
+	scanner   *pluqry.ResolveBarcodeHandler
+	pricer    *pricing.Pricer
+	adminRepo admin.Repository
+}
+
+// NewAddSaleLineHandler creates a new AddSaleLineHandler. Barcodes are read
+// by scanner.
+func NewAddSaleLineHandler(
+	saleRepo pos.SaleRepository,
+	itemRepo plu.Repository,
+	priceRepo branch.PriceRepository,
+	scanner *pluqry.ResolveBarcodeHandler,
+	pricer *pricing.Pricer,
+	adminRepo admin.Repository,
+) *AddSaleLineHandler {
+	return &AddSaleLineHandler{
+		saleRepo:  saleRepo,
+		itemRepo:  itemRepo,
+		priceRepo: priceRepo,
+		scanner:   scanner,
+		pricer:    pricer,
+		adminRepo: adminRepo,
+	}
+}
+
+// Handle executes the add sale line use case. Scanned packs are charged
+// what their barcode says, even if the item has since been withdrawn;
+// weighed items are charged at the branch's price for the product, or else
+// the item's current price, and must not be.
+func (h *AddSaleLineHandler) Handle(ctx context.Context, cmd AddSaleLineCommand) (*pos.Sale, error) {
+	if _, err := operator(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	s, err := h.saleRepo.FindByID(ctx, cmd.SaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var line pos.Line
+	if code := strings.TrimSpace(cmd.Barcode); code != "" {
+		scan, err := h.scanner.Handle(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		line = itemLine(scan.Item, scan.Pack, scan.Item.PricePerKgCents())
+		line.Barcode = code
+	} else {
+		item, err := h.itemRepo.FindByCode(ctx, cmd.PLUCode)
+		if err != nil {
+			return nil, err
+		}
+		if !item.Active() {
+			return nil, plu.ErrItemInactive
+		}
+		overrides, err := h.priceRepo.PricesFor(ctx, s.BranchID(), []uuid.UUID{item.ProductID()})
+		if err != nil {
+			return nil, fmt.Errorf("loading branch prices: %w", err)
+		}
+		price, ok := overrides[item.ProductID()]
+		if !ok {
+			price = item.PricePerKgCents()
+		}
+		pack, err := item.PackOfWeightAt(cmd.Grams, price)
+		if err != nil {
+			return nil, err
+		}
+		line = itemLine(item, pack, price)
+	}
+
+	now := time.Now()
+	if _, err := s.AddLine(line, now); err != nil {
+		return nil, err
+	}
+	if err := reprice(ctx, h.pricer, s, now); err != nil {
+		return nil, err
+	}
+	if err := h.saleRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrConcurrentUpdate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving sale: %w", err)
+	}
+	return s, nil
+}
+
+func itemLine(item *plu.Item, pack plu.Pack, pricePerKgCents int64) pos.Line {
+	return pos.Line{
+		ProductID:       item.ProductID(),
+		PLUCode:         item.Code(),
+		Description:     item.Description(),
+		Grams:           pack.Grams,
+		PricePerKgCents: pricePerKgCents,
+		AmountCents:     pack.PriceCents,
+	}
+}
@@ -0,0 +1,105 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pluqry "github.com/katerji/butchery-app/backend/internal/application/plu/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/pos/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newAddSaleLine returns a handler for an open sale and the PLU item 101,
+// lamb chops at 1800 a kilogram.
+func newAddSaleLine(t *testing.T) (*commands.AddSaleLineHandler, *pos.Sale, *plu.Item, uuid.UUID) {
+	t.Helper()
+	return newAddSaleLineAt(t, map[uuid.UUID]int64{})
+}
+
+// newAddSaleLineAt is newAddSaleLine at a branch with its own prices.
+func newAddSaleLineAt(t *testing.T, branchPrices map[uuid.UUID]int64) (*commands.AddSaleLineHandler, *pos.Sale, *plu.Item, uuid.UUID) {
+	t.Helper()
+	admins := new(mockAdminRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+	sale, err := pos.NewSale(uuid.New(), newSession(t), cashier.ID(), time.Now())
+	require.NoError(t, err)
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sales.On("Save", mock.Anything, sale).Return(nil)
+
+	item, err := plu.NewItem(uuid.New(), 101, lamb, "Lamb chops", 1800, time.Now())
+	require.NoError(t, err)
+	items := new(mockItemRepository)
+	items.On("FindByCode", mock.Anything, 101).Return(item, nil)
+	layouts, err := barcode.ParseLayouts([]string{"20IIIIIWWWWWC", "22IIIIIVPPPPC"})
+	require.NoError(t, err)
+
+	prices := new(mockPriceRepository)
+	prices.On("PricesFor", mock.Anything, sale.BranchID(), mock.Anything).Return(branchPrices, nil)
+
+	h := commands.NewAddSaleLineHandler(sales, items, prices, pluqry.NewResolveBarcodeHandler(items, layouts), newPricer(), admins)
+	return h, sale, item, cashier.ID()
+}
+
+func TestAddSaleLine_WeighedItem_PricesAtItemPrice(t *testing.T) {
+	h, sale, _, actorID := newAddSaleLine(t)
+
+	got, err := h.Handle(context.Background(), commands.AddSaleLineCommand{SaleID: sale.ID(), PLUCode: 101, Grams: 750, ActorID: actorID})
+
+	require.NoError(t, err)
+	require.Len(t, got.Lines(), 1)
+	l := got.Lines()[0]
+	assert.Equal(t, lamb, l.ProductID)
+	assert.Equal(t, int64(750), l.Grams)
+	assert.Equal(t, int64(1350), l.AmountCents)
+	assert.Equal(t, int64(1350), l.GrossCents)
+	assert.Equal(t, int64(225), l.VATCents)
+	assert.Equal(t, 2000, l.VATRateBP)
+}
+
+func TestAddSaleLine_WeighedItem_PricesAtBranchPrice(t *testing.T) {
+	h, sale, _, actorID := newAddSaleLineAt(t, map[uuid.UUID]int64{lamb: 2000})
+
+	got, err := h.Handle(context.Background(), commands.AddSaleLineCommand{SaleID: sale.ID(), PLUCode: 101, Grams: 750, ActorID: actorID})
+
+	require.NoError(t, err)
+	l := got.Lines()[0]
+	assert.Equal(t, int64(2000), l.PricePerKgCents)
+	assert.Equal(t, int64(1500), l.AmountCents)
+}
+
+func TestAddSaleLine_ScannedPack_ChargesPriceOnBarcode(t *testing.T) {
+	h, sale, _, actorID := newAddSaleLine(t)
+	layouts, err := barcode.ParseLayouts([]string{"22IIIIIVPPPPC"})
+	require.NoError(t, err)
+	layout, err := layouts.For(barcode.Price)
+	require.NoError(t, err)
+	code, err := layout.Encode(101, 999)
+	require.NoError(t, err)
+
+	got, err := h.Handle(context.Background(), commands.AddSaleLineCommand{SaleID: sale.ID(), Barcode: code, ActorID: actorID})
+
+	require.NoError(t, err)
+	l := got.Lines()[0]
+	assert.Equal(t, code, l.Barcode)
+	assert.Equal(t, int64(999), l.GrossCents)
+	assert.Equal(t, int64(555), l.Grams)
+}
+
+func TestAddSaleLine_WithdrawnItemKeyedIn_ReturnsError(t *testing.T) {
+	h, sale, item, actorID := newAddSaleLine(t)
+	require.NoError(t, item.Update(item.Description(), item.PricePerKgCents(), false, time.Now()))
+
+	_, err := h.Handle(context.Background(), commands.AddSaleLineCommand{SaleID: sale.ID(), PLUCode: 101, Grams: 750, ActorID: actorID})
+
+	assert.ErrorIs(t, err, plu.ErrItemInactive)
+	assert.Empty(t, sale.Lines())
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// CloseSessionCommand is the input for the close till session use case.
+type CloseSessionCommand struct {
+	SessionID        uuid.UUID
+	CountedCashCents int64
+	ActorID          uuid.UUID
+}
+
+// CloseSessionHandler cashes up a till at the end of a session.
+type CloseSessionHandler struct {
+	sessionRepo pos.SessionRepository
+	saleRepo    pos.SaleRepository
+	adminRepo   admin.Repository
+}
+
+// NewCloseSessionHandler creates a new CloseSessionHandler.
+func NewCloseSessionHandler(sessionRepo pos.SessionRepository, saleRepo pos.SaleRepository, adminRepo admin.Repository) *CloseSessionHandler {
+	return &CloseSessionHandler{sessionRepo: sessionRepo, saleRepo: saleRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the close till session use case and returns the
+// session's Z report, reconciling the cash counted in the drawer against
+// what it should hold.
+func (h *CloseSessionHandler) Handle(ctx context.Context, cmd CloseSessionCommand) (*pos.Report, error) {
+	actor, err := operator(ctx, h.adminRepo, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := h.saleRepo.FindBySession(ctx, s.ID())
+	if err != nil {
+		return nil, fmt.Errorf("finding session sales: %w", err)
+	}
+	if err := s.Close(cmd.CountedCashCents, sales, actor.ID(), time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.sessionRepo.Close(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrSessionClosed) || errors.Is(err, pos.ErrSalesInProgress) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving till session: %w", err)
+	}
+	report := pos.Summarise(s, sales)
+	return &report, nil
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// CreateTillCommand is the input for the create till use case.
+type CreateTillCommand struct {
+	BranchID uuid.UUID
+	Name     string
+}
+
+// CreateTillHandler registers a till at a branch's counter.
+type CreateTillHandler struct {
+	tillRepo pos.TillRepository
+}
+
+// NewCreateTillHandler creates a new CreateTillHandler.
+func NewCreateTillHandler(tillRepo pos.TillRepository) *CreateTillHandler {
+	return &CreateTillHandler{tillRepo: tillRepo}
+}
+
+// Handle executes the create till use case. A name already in use at the
+// branch returns ErrDuplicateTillName.
+func (h *CreateTillHandler) Handle(ctx context.Context, cmd CreateTillCommand) (*pos.Till, error) {
+	t, err := pos.NewTill(uuid.New(), cmd.BranchID, cmd.Name, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.tillRepo.Save(ctx, t); err != nil {
+		if errors.Is(err, pos.ErrDuplicateTillName) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving till: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// OpenSessionCommand is the input for the open till session use case.
+type OpenSessionCommand struct {
+	TillID     uuid.UUID
+	FloatCents int64
+	ActorID    uuid.UUID
+}
+
+// OpenSessionHandler starts a cashier's session on a till.
+type OpenSessionHandler struct {
+	tillRepo    pos.TillRepository
+	sessionRepo pos.SessionRepository
+	adminRepo   admin.Repository
+}
+
+// NewOpenSessionHandler creates a new OpenSessionHandler.
+func NewOpenSessionHandler(tillRepo pos.TillRepository, sessionRepo pos.SessionRepository, adminRepo admin.Repository) *OpenSessionHandler {
+	return &OpenSessionHandler{tillRepo: tillRepo, sessionRepo: sessionRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the open till session use case. A till whose last session
+// has not been closed returns ErrTillInUse.
+func (h *OpenSessionHandler) Handle(ctx context.Context, cmd OpenSessionCommand) (*pos.Session, error) {
+	actor, err := operator(ctx, h.adminRepo, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	t, err := h.tillRepo.FindByID(ctx, cmd.TillID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := pos.OpenSession(uuid.New(), t, cmd.FloatCents, actor.ID(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.sessionRepo.Open(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrTillInUse) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving till session: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// operator loads the admin working the till and checks they may.
+func operator(ctx context.Context, adminRepo admin.Repository, actorID uuid.UUID) (*admin.Admin, error) {
+	actor, err := adminRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasPermission(admin.PermissionOperateTill) {
+		return nil, pos.ErrTillNotPermitted
+	}
+	return actor, nil
+}
+
+// reprice prices a sale's live lines together, as a cart is, so that the
+// VAT on the receipt is what the calculator makes of the whole sale.
+func reprice(ctx context.Context, pricer *pricing.Pricer, s *pos.Sale, now time.Time) error {
+	live := s.LiveLines()
+	lines := make([]pricing.Line, 0, len(live))
+	for _, l := range live {
+		lines = append(lines, pricing.Line{ProductID: l.ProductID, AmountCents: l.AmountCents})
+	}
+	var prices []pos.LinePrice
+	if len(lines) > 0 {
+		res, err := pricer.Price(ctx, lines, now)
+		if err != nil {
+			return err
+		}
+		prices = make([]pos.LinePrice, 0, len(res.Lines))
+		for _, l := range res.Lines {
+			prices = append(prices, pos.LinePrice{
+				TaxCategory: string(l.Category),
+				VATRateBP:   l.RateBP,
+				NetCents:    l.NetCents,
+				VATCents:    l.VATCents,
+				GrossCents:  l.GrossCents,
+			})
+		}
+	}
+	return s.Reprice(prices)
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// ReturnSaleCommand is the input for the return sale use case.
+type ReturnSaleCommand struct {
+	SessionID     uuid.UUID
+	ReceiptNumber string
+	Items         []pos.ReturnItem
+	Method        pos.Method
+	Reference     string
+	Reason        string
+	ActorID       uuid.UUID
+}
+
+// ReturnSaleHandler refunds items a customer brings back with their receipt.
+type ReturnSaleHandler struct {
+	sessionRepo pos.SessionRepository
+	saleRepo    pos.SaleRepository
+	adminRepo   admin.Repository
+}
+
+// NewReturnSaleHandler creates a new ReturnSaleHandler.
+func NewReturnSaleHandler(sessionRepo pos.SessionRepository, saleRepo pos.SaleRepository, adminRepo admin.Repository) *ReturnSaleHandler {
+	return &ReturnSaleHandler{sessionRepo: sessionRepo, saleRepo: saleRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the return sale use case. The refund is paid out of the
+// session's drawer or back to the card. Returned meat is not put back into
+// stock: once it has left the counter it cannot be sold again.
+func (h *ReturnSaleHandler) Handle(ctx context.Context, cmd ReturnSaleCommand) (*pos.Sale, error) {
+	actor, err := operator(ctx, h.adminRepo, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := h.saleRepo.FindByReceiptNumber(ctx, cmd.ReceiptNumber)
+	if err != nil {
+		return nil, err
+	}
+	number, err := h.saleRepo.NextReceiptNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := pos.NewReturn(uuid.New(), original, session, actor.ID(), cmd.Items, cmd.Method, cmd.Reference, cmd.Reason, number, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.saleRepo.SaveReturn(ctx, original, ret); err != nil {
+		if errors.Is(err, pos.ErrConcurrentUpdate) || errors.Is(err, pos.ErrSessionClosed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving return: %w", err)
+	}
+	return ret, nil
+}
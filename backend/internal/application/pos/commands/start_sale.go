@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// StartSaleCommand is the input for the start sale use case.
+type StartSaleCommand struct {
+	SessionID uuid.UUID
+	ActorID   uuid.UUID
+}
+
+// StartSaleHandler starts ringing up a walk-in customer's purchase.
+type StartSaleHandler struct {
+	sessionRepo pos.SessionRepository
+	saleRepo    pos.SaleRepository
+	adminRepo   admin.Repository
+}
+
+// NewStartSaleHandler creates a new StartSaleHandler.
+func NewStartSaleHandler(sessionRepo pos.SessionRepository, saleRepo pos.SaleRepository, adminRepo admin.Repository) *StartSaleHandler {
+	return &StartSaleHandler{sessionRepo: sessionRepo, saleRepo: saleRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the start sale use case.
+func (h *StartSaleHandler) Handle(ctx context.Context, cmd StartSaleCommand) (*pos.Sale, error) {
+	actor, err := operator(ctx, h.adminRepo, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := pos.NewSale(uuid.New(), session, actor.ID(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.saleRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrSessionClosed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving sale: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// TakePaymentCommand is the input for the take payment use case. For cash,
+// AmountCents is what the customer handed over; for card, what the terminal
+// charged, with Reference its authorisation code.
+type TakePaymentCommand struct {
+	SaleID      uuid.UUID
+	Method      pos.Method
+	AmountCents int64
+	Reference   string
+	ActorID     uuid.UUID
+}
+
+// TakePaymentHandler takes payment for a sale, completing it once it is paid
+// in full.
+type TakePaymentHandler struct {
+	saleRepo  pos.SaleRepository
+	adminRepo admin.Repository
+}
+
+// NewTakePaymentHandler creates a new TakePaymentHandler.
+func NewTakePaymentHandler(saleRepo pos.SaleRepository, adminRepo admin.Repository) *TakePaymentHandler {
+	return &TakePaymentHandler{saleRepo: saleRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the take payment use case. The payment that clears the
+// balance numbers the receipt and takes the sale's stock from the branch,
+// the same lots online orders are fulfilled from. If the branch does not
+// have the stock, the payment is not recorded and inventory's
+// ErrInsufficientStock is returned.
+func (h *TakePaymentHandler) Handle(ctx context.Context, cmd TakePaymentCommand) (*pos.Sale, error) {
+	if _, err := operator(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	s, err := h.saleRepo.FindByID(ctx, cmd.SaleID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if _, err := s.Pay(cmd.Method, cmd.AmountCents, cmd.Reference, now); err != nil {
+		return nil, err
+	}
+
+	if s.BalanceCents() > 0 {
+		if err := h.saleRepo.Save(ctx, s); err != nil {
+			if errors.Is(err, pos.ErrConcurrentUpdate) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("saving sale: %w", err)
+		}
+		return s, nil
+	}
+
+	number, err := h.saleRepo.NextReceiptNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Complete(number, now); err != nil {
+		return nil, err
+	}
+	if err := h.saleRepo.Complete(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrConcurrentUpdate) || errors.Is(err, inventory.ErrInsufficientStock) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("completing sale: %w", err)
+	}
+	return s, nil
+}
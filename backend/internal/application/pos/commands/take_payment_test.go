@@ -0,0 +1,352 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pos/commands"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockSaleRepository struct {
+	mock.Mock
+}
+
+func (m *mockSaleRepository) Save(ctx context.Context, s *pos.Sale) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockSaleRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockSaleRepository) Complete(ctx context.Context, s *pos.Sale) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockSaleRepository) Void(ctx context.Context, s *pos.Sale) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockSaleRepository) SaveReturn(ctx context.Context, original, ret *pos.Sale) error {
+	return m.Called(ctx, original, ret).Error(0)
+}
+
+func (m *mockSaleRepository) FindByID(ctx context.Context, id uuid.UUID) (*pos.Sale, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pos.Sale), args.Error(1)
+}
+
+func (m *mockSaleRepository) FindByReceiptNumber(ctx context.Context, number string) (*pos.Sale, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pos.Sale), args.Error(1)
+}
+
+func (m *mockSaleRepository) FindBySession(ctx context.Context, sessionID uuid.UUID) ([]*pos.Sale, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pos.Sale), args.Error(1)
+}
+
+type mockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *mockSessionRepository) Open(ctx context.Context, s *pos.Session) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockSessionRepository) Close(ctx context.Context, s *pos.Session) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*pos.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pos.Session), args.Error(1)
+}
+
+func (m *mockSessionRepository) FindByTill(ctx context.Context, tillID uuid.UUID) ([]*pos.Session, error) {
+	args := m.Called(ctx, tillID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pos.Session), args.Error(1)
+}
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+type mockItemRepository struct {
+	mock.Mock
+}
+
+func (m *mockItemRepository) Save(ctx context.Context, i *plu.Item) error {
+	return m.Called(ctx, i).Error(0)
+}
+
+func (m *mockItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByCode(ctx context.Context, code int) (*plu.Item, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindByProduct(ctx context.Context, productID uuid.UUID) (*plu.Item, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*plu.Item), args.Error(1)
+}
+
+func (m *mockItemRepository) FindAll(ctx context.Context) ([]*plu.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*plu.Item), args.Error(1)
+}
+
+type mockRateRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateRepository) Save(ctx context.Context, r *tax.Rate) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(tax.Table), args.Error(1)
+}
+
+type mockProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockProductRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	return m.Called(ctx, productID, category).Error(0)
+}
+
+func (m *mockProductRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]tax.Category), args.Error(1)
+}
+
+type mockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *mockPriceRepository) SetPrice(ctx context.Context, p *branch.PriceOverride) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockPriceRepository) DeletePrice(ctx context.Context, branchID, productID uuid.UUID) error {
+	return m.Called(ctx, branchID, productID).Error(0)
+}
+
+func (m *mockPriceRepository) FindPrices(ctx context.Context, branchID uuid.UUID) ([]*branch.PriceOverride, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*branch.PriceOverride), args.Error(1)
+}
+
+func (m *mockPriceRepository) PricesFor(ctx context.Context, branchID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(ctx, branchID, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+// --- Fixtures ---
+
+var lamb = uuid.New()
+
+// newPricer prices lamb at 20% VAT included in its shelf price.
+func newPricer() *pricing.Pricer {
+	rates := new(mockRateRepository)
+	rates.On("FindAll", mock.Anything).Return(tax.Table{
+		tax.ReconstructRate(uuid.New(), tax.CategoryFreshMeat, 2000, time.Time{}, time.Now()),
+	}, nil)
+	products := new(mockProductRepository)
+	products.On("FindCategories", mock.Anything, mock.Anything).Return(map[uuid.UUID]tax.Category{
+		lamb: tax.CategoryFreshMeat,
+	}, nil)
+	return pricing.NewPricer(rates, products, tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}, time.UTC)
+}
+
+func newAdmin(t *testing.T, repo *mockAdminRepository, role admin.Role) *admin.Admin {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), string(role)+"@butchery.com", "$2a$10$hash", "Counter", role)
+	require.NoError(t, err)
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return a
+}
+
+func newSession(t *testing.T) *pos.Session {
+	t.Helper()
+	till, err := pos.NewTill(uuid.New(), uuid.New(), "Till 1", time.Now())
+	require.NoError(t, err)
+	s, err := pos.OpenSession(uuid.New(), till, 5000, uuid.New(), time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+// newSale rings up lamb for amountCents.
+func newSale(t *testing.T, session *pos.Session, amountCents int64) *pos.Sale {
+	t.Helper()
+	s, err := pos.NewSale(uuid.New(), session, uuid.New(), time.Now())
+	require.NoError(t, err)
+	_, err = s.AddLine(pos.Line{ProductID: lamb, PLUCode: 101, Description: "Lamb chops", Grams: 1000, PricePerKgCents: amountCents, AmountCents: amountCents}, time.Now())
+	require.NoError(t, err)
+	vat := amountCents / 6
+	require.NoError(t, s.Reprice([]pos.LinePrice{{TaxCategory: "fresh_meat", VATRateBP: 2000, NetCents: amountCents - vat, VATCents: vat, GrossCents: amountCents}}))
+	return s
+}
+
+// --- Tests ---
+
+func TestTakePayment_PartPayment_SavesOpenSale(t *testing.T) {
+	admins := new(mockAdminRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+	sale := newSale(t, newSession(t), 1200)
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sales.On("Save", mock.Anything, sale).Return(nil)
+
+	got, err := commands.NewTakePaymentHandler(sales, admins).Handle(context.Background(), commands.TakePaymentCommand{
+		SaleID: sale.ID(), Method: pos.MethodCard, AmountCents: 500, Reference: "A1", ActorID: cashier.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, pos.SaleOpen, got.Status())
+	assert.Equal(t, int64(700), got.BalanceCents())
+	sales.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestTakePayment_ClearsBalance_CompletesAndNumbersSale(t *testing.T) {
+	admins := new(mockAdminRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+	sale := newSale(t, newSession(t), 1200)
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sales.On("NextReceiptNumber", mock.Anything).Return("POS-000007", nil)
+	sales.On("Complete", mock.Anything, sale).Return(nil)
+
+	got, err := commands.NewTakePaymentHandler(sales, admins).Handle(context.Background(), commands.TakePaymentCommand{
+		SaleID: sale.ID(), Method: pos.MethodCash, AmountCents: 2000, ActorID: cashier.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, pos.SaleCompleted, got.Status())
+	assert.Equal(t, "POS-000007", got.ReceiptNumber())
+	assert.Equal(t, int64(800), got.ChangeCents())
+	sales.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestTakePayment_OutOfStock_ReturnsError(t *testing.T) {
+	admins := new(mockAdminRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+	sale := newSale(t, newSession(t), 1200)
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sales.On("NextReceiptNumber", mock.Anything).Return("POS-000007", nil)
+	sales.On("Complete", mock.Anything, sale).Return(inventory.ErrInsufficientStock)
+
+	_, err := commands.NewTakePaymentHandler(sales, admins).Handle(context.Background(), commands.TakePaymentCommand{
+		SaleID: sale.ID(), Method: pos.MethodCard, AmountCents: 1200, ActorID: cashier.ID(),
+	})
+
+	assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+}
+
+func TestTakePayment_StaffRole_ReturnsError(t *testing.T) {
+	admins := new(mockAdminRepository)
+	staff := newAdmin(t, admins, admin.RoleStaff)
+	sales := new(mockSaleRepository)
+
+	_, err := commands.NewTakePaymentHandler(sales, admins).Handle(context.Background(), commands.TakePaymentCommand{
+		SaleID: uuid.New(), Method: pos.MethodCash, AmountCents: 100, ActorID: staff.ID(),
+	})
+
+	assert.ErrorIs(t, err, pos.ErrTillNotPermitted)
+	sales.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// UpdateTillCommand is the input for the update till use case.
+type UpdateTillCommand struct {
+	TillID uuid.UUID
+	Name   string
+	Active bool
+}
+
+// UpdateTillHandler renames a till or takes it out of service.
+type UpdateTillHandler struct {
+	tillRepo pos.TillRepository
+}
+
+// NewUpdateTillHandler creates a new UpdateTillHandler.
+func NewUpdateTillHandler(tillRepo pos.TillRepository) *UpdateTillHandler {
+	return &UpdateTillHandler{tillRepo: tillRepo}
+}
+
+// Handle executes the update till use case.
+func (h *UpdateTillHandler) Handle(ctx context.Context, cmd UpdateTillCommand) (*pos.Till, error) {
+	t, err := h.tillRepo.FindByID(ctx, cmd.TillID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Update(cmd.Name, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.tillRepo.Save(ctx, t); err != nil {
+		if errors.Is(err, pos.ErrDuplicateTillName) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving till: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// VoidSaleCommand is the input for the void sale use case.
+type VoidSaleCommand struct {
+	SaleID  uuid.UUID
+	Reason  string
+	ActorID uuid.UUID
+}
+
+// VoidSaleHandler cancels a sale, handing back whatever was paid.
+type VoidSaleHandler struct {
+	sessionRepo pos.SessionRepository
+	saleRepo    pos.SaleRepository
+	adminRepo   admin.Repository
+}
+
+// NewVoidSaleHandler creates a new VoidSaleHandler.
+func NewVoidSaleHandler(sessionRepo pos.SessionRepository, saleRepo pos.SaleRepository, adminRepo admin.Repository) *VoidSaleHandler {
+	return &VoidSaleHandler{sessionRepo: sessionRepo, saleRepo: saleRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the void sale use case. Any cashier may void a sale that
+// is still being rung up. A paid sale takes a manager, and only while its
+// session is open, since its money comes out of that session's drawer; its
+// stock goes back on the shelf.
+func (h *VoidSaleHandler) Handle(ctx context.Context, cmd VoidSaleCommand) (*pos.Sale, error) {
+	actor, err := operator(ctx, h.adminRepo, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := h.saleRepo.FindByID(ctx, cmd.SaleID)
+	if err != nil {
+		return nil, err
+	}
+	if s.Status() == pos.SaleCompleted && s.Kind() == pos.KindSale {
+		if !actor.HasPermission(admin.PermissionVoidSales) {
+			return nil, pos.ErrVoidNotPermitted
+		}
+		session, err := h.sessionRepo.FindByID(ctx, s.SessionID())
+		if err != nil {
+			return nil, err
+		}
+		if !session.IsOpen() {
+			return nil, pos.ErrVoidFromOtherSession
+		}
+	}
+
+	if err := s.Void(cmd.Reason, actor.ID(), time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.saleRepo.Void(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrConcurrentUpdate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("voiding sale: %w", err)
+	}
+	return s, nil
+}
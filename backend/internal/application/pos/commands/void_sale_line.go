@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// VoidSaleLineCommand is the input for the void sale line use case.
+type VoidSaleLineCommand struct {
+	SaleID  uuid.UUID
+	LineID  uuid.UUID
+	ActorID uuid.UUID
+}
+
+// VoidSaleLineHandler takes an item off a sale before it is paid for.
+type VoidSaleLineHandler struct {
+	saleRepo  pos.SaleRepository
+	pricer    *pricing.Pricer
+	adminRepo admin.Repository
+}
+
+// NewVoidSaleLineHandler creates a new VoidSaleLineHandler.
+func NewVoidSaleLineHandler(saleRepo pos.SaleRepository, pricer *pricing.Pricer, adminRepo admin.Repository) *VoidSaleLineHandler {
+	return &VoidSaleLineHandler{saleRepo: saleRepo, pricer: pricer, adminRepo: adminRepo}
+}
+
+// Handle executes the void sale line use case. The voided line stays on the
+// sale for the Z report.
+func (h *VoidSaleLineHandler) Handle(ctx context.Context, cmd VoidSaleLineCommand) (*pos.Sale, error) {
+	if _, err := operator(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	s, err := h.saleRepo.FindByID(ctx, cmd.SaleID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := s.VoidLine(cmd.LineID, now); err != nil {
+		return nil, err
+	}
+	if err := reprice(ctx, h.pricer, s, now); err != nil {
+		return nil, err
+	}
+	if err := h.saleRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, pos.ErrConcurrentUpdate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving sale: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/application/pos/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func paidSale(t *testing.T, session *pos.Session) *pos.Sale {
+	t.Helper()
+	s := newSale(t, session, 1200)
+	_, err := s.Pay(pos.MethodCash, 1200, "", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, s.Complete("POS-000001", time.Now()))
+	return s
+}
+
+func TestVoidSale_OpenSale_AnyCashier(t *testing.T) {
+	admins := new(mockAdminRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+	sale := newSale(t, newSession(t), 1200)
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sales.On("Void", mock.Anything, sale).Return(nil)
+
+	got, err := commands.NewVoidSaleHandler(new(mockSessionRepository), sales, admins).Handle(context.Background(), commands.VoidSaleCommand{
+		SaleID: sale.ID(), Reason: "customer left", ActorID: cashier.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, pos.SaleVoided, got.Status())
+	assert.Equal(t, cashier.ID(), *got.VoidedBy())
+}
+
+func TestVoidSale_PaidSale_NeedsManager(t *testing.T) {
+	admins := new(mockAdminRepository)
+	cashier := newAdmin(t, admins, admin.RoleCashier)
+	manager := newAdmin(t, admins, admin.RoleManager)
+	session := newSession(t)
+	sale := paidSale(t, session)
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sales.On("Void", mock.Anything, sale).Return(nil)
+	sessions := new(mockSessionRepository)
+	sessions.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+	h := commands.NewVoidSaleHandler(sessions, sales, admins)
+
+	_, err := h.Handle(context.Background(), commands.VoidSaleCommand{SaleID: sale.ID(), Reason: "rung up twice", ActorID: cashier.ID()})
+	assert.ErrorIs(t, err, pos.ErrVoidNotPermitted)
+
+	got, err := h.Handle(context.Background(), commands.VoidSaleCommand{SaleID: sale.ID(), Reason: "rung up twice", ActorID: manager.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, pos.SaleVoided, got.Status())
+	sales.AssertNumberOfCalls(t, "Void", 1)
+}
+
+func TestVoidSale_PaidSaleFromClosedSession_ReturnsError(t *testing.T) {
+	admins := new(mockAdminRepository)
+	manager := newAdmin(t, admins, admin.RoleManager)
+	session := newSession(t)
+	sale := paidSale(t, session)
+	require.NoError(t, session.Close(6200, []*pos.Sale{sale}, manager.ID(), time.Now()))
+	sales := new(mockSaleRepository)
+	sales.On("FindByID", mock.Anything, sale.ID()).Return(sale, nil)
+	sessions := new(mockSessionRepository)
+	sessions.On("FindByID", mock.Anything, session.ID()).Return(session, nil)
+
+	_, err := commands.NewVoidSaleHandler(sessions, sales, admins).Handle(context.Background(), commands.VoidSaleCommand{
+		SaleID: sale.ID(), Reason: "rung up twice", ActorID: manager.ID(),
+	})
+
+	assert.ErrorIs(t, err, pos.ErrVoidFromOtherSession)
+	assert.Equal(t, pos.SaleCompleted, sale.Status())
+}
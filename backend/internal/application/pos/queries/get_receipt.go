@@ -0,0 +1,58 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// GetReceiptHandler prints the receipt for a sale or return, when it is
+// completed or when a customer asks for a copy.
+type GetReceiptHandler struct {
+	saleRepo  pos.SaleRepository
+	tillRepo  pos.TillRepository
+	adminRepo admin.Repository
+	renderer  pos.ReceiptRenderer
+}
+
+// NewGetReceiptHandler creates a new GetReceiptHandler.
+func NewGetReceiptHandler(saleRepo pos.SaleRepository, tillRepo pos.TillRepository, adminRepo admin.Repository, renderer pos.ReceiptRenderer) *GetReceiptHandler {
+	return &GetReceiptHandler{saleRepo: saleRepo, tillRepo: tillRepo, adminRepo: adminRepo, renderer: renderer}
+}
+
+// Handle renders the receipt. Sales that were never paid for have none.
+func (h *GetReceiptHandler) Handle(ctx context.Context, saleID uuid.UUID) ([]byte, error) {
+	s, err := h.saleRepo.FindByID(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+	if s.ReceiptNumber() == "" {
+		return nil, pos.ErrSaleNotCompleted
+	}
+	till, err := h.tillRepo.FindByID(ctx, s.TillID())
+	if err != nil {
+		return nil, err
+	}
+	cashier, err := h.adminRepo.FindByID(ctx, s.CashierID())
+	if err != nil {
+		return nil, err
+	}
+
+	r := pos.Receipt{Sale: s, TillName: till.Name(), CashierName: cashier.FullName()}
+	if s.OriginalSaleID() != nil {
+		original, err := h.saleRepo.FindByID(ctx, *s.OriginalSaleID())
+		if err != nil {
+			return nil, err
+		}
+		r.OriginalReceiptNumber = original.ReceiptNumber()
+	}
+
+	out, err := h.renderer.Render(r)
+	if err != nil {
+		return nil, fmt.Errorf("rendering receipt: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// GetSaleHandler returns a sale or return.
+type GetSaleHandler struct {
+	saleRepo pos.SaleRepository
+}
+
+// NewGetSaleHandler creates a new GetSaleHandler.
+func NewGetSaleHandler(saleRepo pos.SaleRepository) *GetSaleHandler {
+	return &GetSaleHandler{saleRepo: saleRepo}
+}
+
+// Handle returns the sale with its lines and payments.
+func (h *GetSaleHandler) Handle(ctx context.Context, id uuid.UUID) (*pos.Sale, error) {
+	return h.saleRepo.FindByID(ctx, id)
+}
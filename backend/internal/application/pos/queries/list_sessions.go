@@ -0,0 +1,33 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// ListSessionsHandler lists a till's sessions.
+type ListSessionsHandler struct {
+	tillRepo    pos.TillRepository
+	sessionRepo pos.SessionRepository
+}
+
+// NewListSessionsHandler creates a new ListSessionsHandler.
+func NewListSessionsHandler(tillRepo pos.TillRepository, sessionRepo pos.SessionRepository) *ListSessionsHandler {
+	return &ListSessionsHandler{tillRepo: tillRepo, sessionRepo: sessionRepo}
+}
+
+// Handle returns the till's sessions, newest first, so that the open one,
+// if any, comes first.
+func (h *ListSessionsHandler) Handle(ctx context.Context, tillID uuid.UUID) ([]*pos.Session, error) {
+	if _, err := h.tillRepo.FindByID(ctx, tillID); err != nil {
+		return nil, err
+	}
+	sessions, err := h.sessionRepo.FindByTill(ctx, tillID)
+	if err != nil {
+		return nil, fmt.Errorf("listing till sessions: %w", err)
+	}
+	return sessions, nil
+}
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// ListTillsHandler lists the tills at a branch, or at every branch.
+type ListTillsHandler struct {
+	tillRepo pos.TillRepository
+}
+
+// NewListTillsHandler creates a new ListTillsHandler.
+func NewListTillsHandler(tillRepo pos.TillRepository) *ListTillsHandler {
+	return &ListTillsHandler{tillRepo: tillRepo}
+}
+
+// Handle returns the tills by name.
+func (h *ListTillsHandler) Handle(ctx context.Context, branchID *uuid.UUID) ([]*pos.Till, error) {
+	tills, err := h.tillRepo.FindAll(ctx, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tills: %w", err)
+	}
+	return tills, nil
+}
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// SessionReportHandler reports on a till session's takings: the running X
+// report while it is open, and its Z report once it has been closed.
+type SessionReportHandler struct {
+	sessionRepo pos.SessionRepository
+	saleRepo    pos.SaleRepository
+}
+
+// NewSessionReportHandler creates a new SessionReportHandler.
+func NewSessionReportHandler(sessionRepo pos.SessionRepository, saleRepo pos.SaleRepository) *SessionReportHandler {
+	return &SessionReportHandler{sessionRepo: sessionRepo, saleRepo: saleRepo}
+}
+
+// Handle returns the session's report.
+func (h *SessionReportHandler) Handle(ctx context.Context, sessionID uuid.UUID) (*pos.Report, error) {
+	s, err := h.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := h.saleRepo.FindBySession(ctx, s.ID())
+	if err != nil {
+		return nil, fmt.Errorf("finding session sales: %w", err)
+	}
+	report := pos.Summarise(s, sales)
+	return &report, nil
+}
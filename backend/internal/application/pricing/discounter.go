@@ -0,0 +1,113 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// Segments customers fall into by their order history, for promotions
+// scoped to them.
+const (
+	SegmentNew       = "new"
+	SegmentReturning = "returning"
+)
+
+// OrderHistory counts a customer's orders.
+type OrderHistory interface {
+	CountByCustomer(ctx context.Context, customerID uuid.UUID) (int, error)
+}
+
+// Discounter runs the promotion engine over a cart with everything it needs
+// loaded: product profiles, the promotions running, how much they have been
+// used and who the customer is.
+type Discounter struct {
+	promotionRepo  promotion.Repository
+	productRepo    promotion.ProductRepository
+	redemptionRepo promotion.RedemptionRepository
+	orders         OrderHistory
+}
+
+// NewDiscounter creates a new Discounter.
+func NewDiscounter(promotionRepo promotion.Repository, productRepo promotion.ProductRepository, redemptionRepo promotion.RedemptionRepository, orders OrderHistory) *Discounter {
+	return &Discounter{promotionRepo: promotionRepo, productRepo: productRepo, redemptionRepo: redemptionRepo, orders: orders}
+}
+
+// Discount works out the promotions on a cart at the given time. Lines need
+// only their product, weight and price; their profiles are looked up here.
+// A nil customerID quotes for an anonymous shopper, who gets neither
+// segment-scoped nor first-order offers.
+func (d *Discounter) Discount(ctx context.Context, cart promotion.Cart, customerID *uuid.UUID, coupons []string, at time.Time) (promotion.Result, error) {
+	productIDs := make([]uuid.UUID, 0, len(cart.Lines))
+	for _, l := range cart.Lines {
+		productIDs = append(productIDs, l.ProductID)
+	}
+	profiles, err := d.productRepo.FindProfiles(ctx, productIDs)
+	if err != nil {
+		return promotion.Result{}, fmt.Errorf("finding product profiles: %w", err)
+	}
+	lines := make([]promotion.Line, len(cart.Lines))
+	for i, l := range cart.Lines {
+		p := profiles[l.ProductID]
+		l.Category, l.Species = p.Category, p.Species
+		lines[i] = l
+	}
+	cart.Lines = lines
+
+	promotions, err := d.promotionRepo.FindRunning(ctx, at)
+	if err != nil {
+		return promotion.Result{}, fmt.Errorf("loading promotions: %w", err)
+	}
+
+	pctx := promotion.Context{At: at, Coupons: coupons}
+	if customerID != nil {
+		count, err := d.orders.CountByCustomer(ctx, *customerID)
+		if err != nil {
+			return promotion.Result{}, err
+		}
+		pctx.Customer = promotion.Customer{ID: customerID, Segments: []string{SegmentReturning}}
+		if count == 0 {
+			pctx.Customer.Segments = []string{SegmentNew}
+			pctx.Customer.FirstOrder = true
+		}
+
+		if len(promotions) > 0 {
+			ids := make([]uuid.UUID, 0, len(promotions))
+			for _, p := range promotions {
+				ids = append(ids, p.ID())
+			}
+			if pctx.Usage, err = d.redemptionRepo.Usage(ctx, ids, *customerID); err != nil {
+				return promotion.Result{}, fmt.Errorf("loading promotion usage: %w", err)
+			}
+		}
+	}
+	return promotion.Apply(cart, promotions, pctx), nil
+}
+
+// Redeem records the promotions a result applied against an order, so they
+// count towards their limits. ErrUsageLimitReached means another order took
+// the last use since the cart was discounted.
+func (d *Discounter) Redeem(ctx context.Context, res promotion.Result, orderID, customerID uuid.UUID, at time.Time) error {
+	if len(res.Discounts) == 0 {
+		return nil
+	}
+	redemptions := make([]promotion.Redemption, 0, len(res.Discounts))
+	for _, disc := range res.Discounts {
+		redemptions = append(redemptions, promotion.Redemption{
+			PromotionID:   disc.PromotionID,
+			OrderID:       orderID,
+			CustomerID:    customerID,
+			DiscountCents: disc.TotalCents,
+			RedeemedAt:    at,
+		})
+	}
+	return d.redemptionRepo.Redeem(ctx, redemptions)
+}
+
+// Release gives back an order's redemptions.
+func (d *Discounter) Release(ctx context.Context, orderID uuid.UUID) error {
+	return d.redemptionRepo.Release(ctx, orderID)
+}
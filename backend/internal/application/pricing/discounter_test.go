@@ -0,0 +1,196 @@
+package pricing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionRepository) Save(ctx context.Context, p *promotion.Promotion) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockPromotionRepository) FindByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindAll(ctx context.Context) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindRunning(ctx context.Context, at time.Time) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+type mockPromotionProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionProductRepository) Classify(ctx context.Context, productID uuid.UUID, profile promotion.Profile) error {
+	return m.Called(ctx, productID, profile).Error(0)
+}
+
+func (m *mockPromotionProductRepository) FindProfiles(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]promotion.Profile, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]promotion.Profile), args.Error(1)
+}
+
+type mockRedemptionRepository struct {
+	mock.Mock
+}
+
+func (m *mockRedemptionRepository) Usage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]promotion.Usage, error) {
+	args := m.Called(ctx, promotionIDs, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]promotion.Usage), args.Error(1)
+}
+
+func (m *mockRedemptionRepository) Redeem(ctx context.Context, redemptions []promotion.Redemption) error {
+	return m.Called(ctx, redemptions).Error(0)
+}
+
+func (m *mockRedemptionRepository) Release(ctx context.Context, orderID uuid.UUID) error {
+	return m.Called(ctx, orderID).Error(0)
+}
+
+type mockOrderHistory struct {
+	mock.Mock
+}
+
+func (m *mockOrderHistory) CountByCustomer(ctx context.Context, customerID uuid.UUID) (int, error) {
+	args := m.Called(ctx, customerID)
+	return args.Int(0), args.Error(1)
+}
+
+// --- Tests ---
+
+type discountFixture struct {
+	discounter  *pricing.Discounter
+	redemptions *mockRedemptionRepository
+	orders      *mockOrderHistory
+	lamb        uuid.UUID
+}
+
+func newDiscountFixture(t *testing.T, running ...*promotion.Promotion) *discountFixture {
+	t.Helper()
+	f := &discountFixture{redemptions: new(mockRedemptionRepository), orders: new(mockOrderHistory), lamb: uuid.New()}
+	promotions := new(mockPromotionRepository)
+	promotions.On("FindRunning", mock.Anything, mock.Anything).Return(running, nil)
+	profiles := new(mockPromotionProductRepository)
+	profiles.On("FindProfiles", mock.Anything, []uuid.UUID{f.lamb}).Return(map[uuid.UUID]promotion.Profile{
+		f.lamb: {Category: "roasting", Species: "lamb"},
+	}, nil)
+	f.discounter = pricing.NewDiscounter(promotions, profiles, f.redemptions, f.orders)
+	return f
+}
+
+func newPromotion(t *testing.T, terms promotion.Terms) *promotion.Promotion {
+	t.Helper()
+	p, err := promotion.NewPromotion(uuid.New(), terms, time.Now())
+	require.NoError(t, err)
+	return p
+}
+
+func (f *discountFixture) cart() promotion.Cart {
+	return promotion.Cart{Lines: []promotion.Line{{ProductID: f.lamb, Grams: 1000, PricePerKgCents: 2000}}}
+}
+
+func TestDiscount_FirstOrderCustomerIsNew(t *testing.T) {
+	welcome := newPromotion(t, promotion.Terms{Name: "Welcome", Rule: promotion.Rule{Kind: promotion.KindFirstOrder, PercentBP: 2000}})
+	loyal := newPromotion(t, promotion.Terms{
+		Name:  "Regulars",
+		Rule:  promotion.Rule{Kind: promotion.KindFixed, AmountCents: 100},
+		Scope: promotion.Scope{Segments: []string{pricing.SegmentReturning}},
+	})
+	f := newDiscountFixture(t, welcome, loyal)
+	customerID := uuid.New()
+	f.orders.On("CountByCustomer", mock.Anything, customerID).Return(0, nil)
+	f.redemptions.On("Usage", mock.Anything, []uuid.UUID{welcome.ID(), loyal.ID()}, customerID).
+		Return(map[uuid.UUID]promotion.Usage{}, nil)
+
+	res, err := f.discounter.Discount(context.Background(), f.cart(), &customerID, nil, time.Now())
+
+	require.NoError(t, err)
+	require.Len(t, res.Discounts, 1)
+	assert.Equal(t, welcome.ID(), res.Discounts[0].PromotionID)
+	assert.Equal(t, int64(400), res.TotalCents)
+}
+
+func TestDiscount_ScopesByProductProfile(t *testing.T) {
+	lamb := newPromotion(t, promotion.Terms{
+		Name:  "Lamb weekend",
+		Rule:  promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000},
+		Scope: promotion.Scope{Species: []string{"lamb"}},
+	})
+	f := newDiscountFixture(t, lamb)
+
+	res, err := f.discounter.Discount(context.Background(), f.cart(), nil, nil, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{200}, res.LineCents)
+	f.orders.AssertNotCalled(t, "CountByCustomer", mock.Anything, mock.Anything)
+}
+
+func TestDiscount_UsageLimitsComeFromRedemptions(t *testing.T) {
+	once := newPromotion(t, promotion.Terms{
+		Name: "Once", Code: "ONCE", Rule: promotion.Rule{Kind: promotion.KindFixed, AmountCents: 100}, MaxUsesPerCustomer: 1,
+	})
+	f := newDiscountFixture(t, once)
+	customerID := uuid.New()
+	f.orders.On("CountByCustomer", mock.Anything, customerID).Return(3, nil)
+	f.redemptions.On("Usage", mock.Anything, mock.Anything, customerID).
+		Return(map[uuid.UUID]promotion.Usage{once.ID(): {Total: 9, ByCustomer: 1}}, nil)
+
+	res, err := f.discounter.Discount(context.Background(), f.cart(), &customerID, []string{"once"}, time.Now())
+
+	require.NoError(t, err)
+	assert.Empty(t, res.Discounts)
+	assert.Equal(t, []promotion.Rejection{{Code: "ONCE", Err: promotion.ErrUsageLimitReached}}, res.Rejected)
+}
+
+func TestRedeem_RecordsEachDiscount(t *testing.T) {
+	f := newDiscountFixture(t)
+	orderID, customerID, promotionID := uuid.New(), uuid.New(), uuid.New()
+	at := time.Now()
+	f.redemptions.On("Redeem", mock.Anything, []promotion.Redemption{
+		{PromotionID: promotionID, OrderID: orderID, CustomerID: customerID, DiscountCents: 350, RedeemedAt: at},
+	}).Return(promotion.ErrUsageLimitReached)
+
+	err := f.discounter.Redeem(context.Background(), promotion.Result{
+		Discounts: []promotion.Discount{{PromotionID: promotionID, TotalCents: 350}},
+	}, orderID, customerID, at)
+
+	assert.ErrorIs(t, err, promotion.ErrUsageLimitReached)
+	require.NoError(t, f.discounter.Redeem(context.Background(), promotion.Result{}, orderID, customerID, at),
+		"nothing to record without discounts")
+}
@@ -0,0 +1,79 @@
+// Package pricing prices sales with the shop's tax calculator. Cart quotes,
+// orders and invoices all go through it so their numbers never disagree.
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// Line is one amount to price. Product lines are taxed under their
+// product's category; charges such as delivery have no product and carry
+// their own category.
+type Line struct {
+	ProductID   uuid.UUID
+	Category    tax.Category
+	AmountCents int64
+}
+
+// Pricer looks up categories and the rates in force and runs the
+// calculator.
+type Pricer struct {
+	rateRepo    tax.RateRepository
+	productRepo tax.ProductRepository
+	calculator  tax.Calculator
+	location    *time.Location
+}
+
+// NewPricer creates a new Pricer. Rates change at midnight in location.
+func NewPricer(rateRepo tax.RateRepository, productRepo tax.ProductRepository, calculator tax.Calculator, location *time.Location) *Pricer {
+	return &Pricer{rateRepo: rateRepo, productRepo: productRepo, calculator: calculator, location: location}
+}
+
+// Calculator returns the calculator the shop prices with.
+func (p *Pricer) Calculator() tax.Calculator { return p.calculator }
+
+// Price taxes lines at the rates in force at the given time. A product
+// without a category returns ErrUnclassifiedProduct.
+func (p *Pricer) Price(ctx context.Context, lines []Line, at time.Time) (tax.Result, error) {
+	var productIDs []uuid.UUID
+	for _, l := range lines {
+		if l.ProductID != uuid.Nil {
+			productIDs = append(productIDs, l.ProductID)
+		}
+	}
+	categories := map[uuid.UUID]tax.Category{}
+	if len(productIDs) > 0 {
+		var err error
+		if categories, err = p.productRepo.FindCategories(ctx, productIDs); err != nil {
+			return tax.Result{}, fmt.Errorf("finding product tax categories: %w", err)
+		}
+	}
+	table, err := p.rateRepo.FindAll(ctx)
+	if err != nil {
+		return tax.Result{}, fmt.Errorf("loading tax rates: %w", err)
+	}
+
+	on := at.In(p.location)
+	items := make([]tax.Item, 0, len(lines))
+	for _, l := range lines {
+		category := l.Category
+		if l.ProductID != uuid.Nil {
+			c, ok := categories[l.ProductID]
+			if !ok {
+				return tax.Result{}, fmt.Errorf("product %s: %w", l.ProductID, tax.ErrUnclassifiedProduct)
+			}
+			category = c
+		}
+		rate, err := table.RateFor(category, on)
+		if err != nil {
+			return tax.Result{}, fmt.Errorf("%s: %w", category, err)
+		}
+		items = append(items, tax.Item{Category: category, RateBP: rate, AmountCents: l.AmountCents})
+	}
+	return p.calculator.Calculate(items)
+}
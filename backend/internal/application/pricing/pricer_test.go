@@ -0,0 +1,120 @@
+package pricing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockRateRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateRepository) Save(ctx context.Context, r *tax.Rate) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(tax.Table), args.Error(1)
+}
+
+type mockProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockProductRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	return m.Called(ctx, productID, category).Error(0)
+}
+
+func (m *mockProductRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]tax.Category), args.Error(1)
+}
+
+// --- Tests ---
+
+func day(s string) time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return t
+}
+
+var (
+	rates = tax.Table{
+		tax.ReconstructRate(uuid.New(), tax.CategoryFreshMeat, 0, day("2000-01-01"), time.Now()),
+		tax.ReconstructRate(uuid.New(), tax.CategoryPreparedFood, 2000, day("2000-01-01"), time.Now()),
+		tax.ReconstructRate(uuid.New(), tax.CategoryPreparedFood, 500, day("2026-11-01"), time.Now()),
+		tax.ReconstructRate(uuid.New(), tax.CategoryDelivery, 2000, day("2000-01-01"), time.Now()),
+	}
+	inclusive = tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}
+)
+
+func newPricer(categories map[uuid.UUID]tax.Category) *pricing.Pricer {
+	rateRepo := new(mockRateRepository)
+	rateRepo.On("FindAll", mock.Anything).Return(rates, nil)
+	productRepo := new(mockProductRepository)
+	productRepo.On("FindCategories", mock.Anything, mock.Anything).Return(categories, nil)
+	return pricing.NewPricer(rateRepo, productRepo, inclusive, time.UTC)
+}
+
+func TestPrice_UsesProductCategoriesAndCharges(t *testing.T) {
+	lamb, mince := uuid.New(), uuid.New()
+	p := newPricer(map[uuid.UUID]tax.Category{lamb: tax.CategoryFreshMeat, mince: tax.CategoryPreparedFood})
+
+	res, err := p.Price(context.Background(), []pricing.Line{
+		{ProductID: lamb, AmountCents: 3000},
+		{ProductID: mince, AmountCents: 900},
+		{Category: tax.CategoryDelivery, AmountCents: 499},
+	}, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	require.Len(t, res.Lines, 3)
+	assert.Equal(t, tax.CategoryFreshMeat, res.Lines[0].Category)
+	assert.Equal(t, int64(0), res.Lines[0].VATCents)
+	assert.Equal(t, int64(150), res.Lines[1].VATCents)
+	assert.Equal(t, int64(83), res.Lines[2].VATCents)
+	assert.Equal(t, int64(4399), res.GrossCents)
+}
+
+func TestPrice_UsesRateInForceOnTheDay(t *testing.T) {
+	mince := uuid.New()
+	p := newPricer(map[uuid.UUID]tax.Category{mince: tax.CategoryPreparedFood})
+
+	res, err := p.Price(context.Background(), []pricing.Line{{ProductID: mince, AmountCents: 1050}},
+		time.Date(2026, 11, 1, 0, 30, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, 500, res.Lines[0].RateBP)
+	assert.Equal(t, int64(50), res.VATCents)
+}
+
+func TestPrice_UnclassifiedProduct_ReturnsError(t *testing.T) {
+	p := newPricer(map[uuid.UUID]tax.Category{})
+
+	_, err := p.Price(context.Background(), []pricing.Line{{ProductID: uuid.New(), AmountCents: 100}}, time.Now())
+
+	assert.ErrorIs(t, err, tax.ErrUnclassifiedProduct)
+}
+
+func TestPrice_CategoryWithoutRate_ReturnsError(t *testing.T) {
+	p := newPricer(nil)
+
+	_, err := p.Price(context.Background(), []pricing.Line{{Category: "alcohol", AmountCents: 100}}, time.Now())
+
+	assert.ErrorIs(t, err, tax.ErrNoRate)
+}
@@ -0,0 +1,190 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// Rewards spends and earns loyalty points on orders.
+type Rewards struct {
+	rulesRepo loyalty.RulesRepository
+	ledger    loyalty.Ledger
+}
+
+// NewRewards creates a new Rewards.
+func NewRewards(rulesRepo loyalty.RulesRepository, ledger loyalty.Ledger) *Rewards {
+	return &Rewards{rulesRepo: rulesRepo, ledger: ledger}
+}
+
+// Rules returns the earn and redeem rules in force.
+func (rw *Rewards) Rules(ctx context.Context) (loyalty.Rules, error) {
+	rules, err := rw.rulesRepo.Find(ctx)
+	if err != nil {
+		return loyalty.Rules{}, fmt.Errorf("loading loyalty rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Expire takes off a customer's points that have expired by at and returns
+// how many there were. Expiring twice is harmless: each credit is only ever
+// expired once.
+func (rw *Rewards) Expire(ctx context.Context, customerID uuid.UUID, at time.Time) (int64, error) {
+	entries, err := rw.ledger.Entries(ctx, customerID)
+	if err != nil {
+		return 0, err
+	}
+	expire := loyalty.ExpireEntries(customerID, entries, at)
+	if len(expire) == 0 {
+		return 0, nil
+	}
+	if err := rw.ledger.Append(ctx, expire...); err != nil {
+		if errors.Is(err, loyalty.ErrDuplicateEntry) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var points int64
+	for _, e := range expire {
+		points -= e.Points
+	}
+	return points, nil
+}
+
+// Record writes the points an order redeemed and earned, after taking off
+// any that have expired so they cannot be spent. Either may be zero.
+// ErrInsufficientPoints means the customer does not have the points they
+// asked to redeem.
+func (rw *Rewards) Record(ctx context.Context, rules loyalty.Rules, orderID, customerID uuid.UUID, redeemed, earned int64, at time.Time) ([]*loyalty.Entry, error) {
+	var entries []*loyalty.Entry
+	if redeemed > 0 {
+		if _, err := rw.Expire(ctx, customerID, at); err != nil {
+			return nil, err
+		}
+		e, err := loyalty.NewEntry(uuid.New(), customerID, loyalty.KindRedeem, -redeemed, orderID.String(), nil, nil, "", nil, at)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if earned > 0 {
+		e, err := loyalty.NewEntry(uuid.New(), customerID, loyalty.KindEarn, earned, orderID.String(), nil, rules.ExpiresAt(at), "", nil, at)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if err := rw.ledger.Append(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Reverse undoes entries Record wrote, e.g. when the order could not be
+// saved. Points given back expire as if they had just been earned.
+func (rw *Rewards) Reverse(ctx context.Context, rules loyalty.Rules, entries []*loyalty.Entry, note string, at time.Time) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	reversals := make([]*loyalty.Entry, 0, len(entries))
+	for _, e := range entries {
+		r, err := e.Reverse(uuid.New(), rules.ExpiresAt(at), note, at)
+		if err != nil {
+			return err
+		}
+		reversals = append(reversals, r)
+	}
+	return rw.ledger.Append(ctx, reversals...)
+}
+
+// ReverseOrder undoes what is left of the points an order redeemed and
+// earned, e.g. when the order is cancelled.
+func (rw *Rewards) ReverseOrder(ctx context.Context, rules loyalty.Rules, customerID, orderID uuid.UUID, note string, at time.Time) error {
+	entries, err := rw.ledger.Entries(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	var undo []*loyalty.Entry
+	for _, e := range entries {
+		if e.Reference != orderID.String() || (e.Kind != loyalty.KindRedeem && e.Kind != loyalty.KindEarn) {
+			continue
+		}
+		switch left := pointsLeft(entries, e); {
+		case left == e.Points:
+			undo = append(undo, e)
+		case left != 0:
+			r, err := loyalty.NewEntry(uuid.New(), customerID, loyalty.KindReversal, -left, "", &e.ID, nil, note, nil, at)
+			if err != nil {
+				return err
+			}
+			if err := rw.ledger.Append(ctx, r); err != nil {
+				return err
+			}
+		}
+	}
+	return rw.Reverse(ctx, rules, undo, note, at)
+}
+
+// Earned returns the entry that credited the points an order earned, or nil
+// if it has not earned any.
+func (rw *Rewards) Earned(ctx context.Context, customerID, orderID uuid.UUID) (*loyalty.Entry, error) {
+	entries, err := rw.ledger.Entries(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Kind == loyalty.KindEarn && e.Reference == orderID.String() {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+// TakeBack reverses the share of the points earned that refundCents is of
+// ofCents, the amount they were earned on, once per reference. No more is
+// taken than is left of them, nor than the customer still holds, so a
+// refund is never held up by points already spent.
+func (rw *Rewards) TakeBack(ctx context.Context, earned *loyalty.Entry, reference string, refundCents, ofCents int64, note string, at time.Time) error {
+	if ofCents <= 0 || refundCents <= 0 {
+		return nil
+	}
+	entries, err := rw.ledger.Entries(ctx, earned.CustomerID)
+	if err != nil {
+		return err
+	}
+	balance, err := rw.ledger.Balance(ctx, earned.CustomerID)
+	if err != nil {
+		return err
+	}
+	points := min(earned.Points*min(refundCents, ofCents)/ofCents, pointsLeft(entries, earned), balance.Points)
+	if points <= 0 {
+		return nil
+	}
+	r, err := loyalty.NewEntry(uuid.New(), earned.CustomerID, loyalty.KindReversal, -points, reference, &earned.ID, nil, note, nil, at)
+	if err != nil {
+		return err
+	}
+	if err := rw.ledger.Append(ctx, r); err != nil && !errors.Is(err, loyalty.ErrDuplicateEntry) {
+		return err
+	}
+	return nil
+}
+
+// pointsLeft is what is left of an entry's points once the reversals and
+// expiries taken from it are counted.
+func pointsLeft(entries []*loyalty.Entry, e *loyalty.Entry) int64 {
+	left := e.Points
+	for _, x := range entries {
+		if x.SourceID != nil && *x.SourceID == e.ID {
+			left += x.Points
+		}
+	}
+	return left
+}
@@ -0,0 +1,164 @@
+package pricing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockLoyaltyLedger struct {
+	mock.Mock
+}
+
+func (m *mockLoyaltyLedger) Append(ctx context.Context, entries ...*loyalty.Entry) error {
+	return m.Called(ctx, entries).Error(0)
+}
+
+func (m *mockLoyaltyLedger) Entries(ctx context.Context, customerID uuid.UUID) ([]*loyalty.Entry, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*loyalty.Entry), args.Error(1)
+}
+
+func (m *mockLoyaltyLedger) Balance(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(loyalty.Balance), args.Error(1)
+}
+
+func (m *mockLoyaltyLedger) Rebuild(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(loyalty.Balance), args.Error(1)
+}
+
+func (m *mockLoyaltyLedger) CustomersWithExpiring(ctx context.Context, at time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type mockLoyaltyRulesRepository struct {
+	mock.Mock
+}
+
+func (m *mockLoyaltyRulesRepository) Find(ctx context.Context) (loyalty.Rules, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(loyalty.Rules), args.Error(1)
+}
+
+func (m *mockLoyaltyRulesRepository) Save(ctx context.Context, r loyalty.Rules) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockLoyaltyRulesRepository) SetProductBonus(ctx context.Context, productID uuid.UUID, points int64) error {
+	return m.Called(ctx, productID, points).Error(0)
+}
+
+// --- Tests ---
+
+func TestRewards_Record_ExpiresBeforeRedeeming(t *testing.T) {
+	ledger := new(mockLoyaltyLedger)
+	rw := pricing.NewRewards(new(mockLoyaltyRulesRepository), ledger)
+	customerID, orderID := uuid.New(), uuid.New()
+	at := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	rules := loyalty.Rules{UnitCents: 100, ExpiryDays: 30}
+
+	lapsed := at.Add(-time.Hour)
+	old := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindEarn, Points: 80, ExpiresAt: &lapsed}
+	ledger.On("Entries", mock.Anything, customerID).Return([]*loyalty.Entry{old}, nil)
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Kind == loyalty.KindExpire && es[0].Points == -80 && *es[0].SourceID == old.ID
+	})).Return(nil).Once()
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 2 && es[0].Kind == loyalty.KindRedeem && es[1].Kind == loyalty.KindEarn
+	})).Return(nil).Once()
+
+	entries, err := rw.Record(context.Background(), rules, orderID, customerID, 200, 15, at)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, int64(-200), entries[0].Points)
+	assert.Equal(t, orderID.String(), entries[0].Reference)
+	assert.Nil(t, entries[0].ExpiresAt)
+	assert.Equal(t, int64(15), entries[1].Points)
+	assert.Equal(t, at.AddDate(0, 0, 30), *entries[1].ExpiresAt)
+	ledger.AssertExpectations(t)
+}
+
+func TestRewards_Record_NothingToRecord(t *testing.T) {
+	ledger := new(mockLoyaltyLedger)
+	rw := pricing.NewRewards(new(mockLoyaltyRulesRepository), ledger)
+
+	entries, err := rw.Record(context.Background(), loyalty.Rules{UnitCents: 100}, uuid.New(), uuid.New(), 0, 0, time.Now())
+
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	ledger.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
+
+func TestRewards_Reverse(t *testing.T) {
+	ledger := new(mockLoyaltyLedger)
+	rw := pricing.NewRewards(new(mockLoyaltyRulesRepository), ledger)
+	customerID := uuid.New()
+	at := time.Now()
+	redeem := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindRedeem, Points: -200}
+	earn := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindEarn, Points: 15}
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 2 &&
+			es[0].Points == 200 && *es[0].SourceID == redeem.ID && es[0].ExpiresAt != nil &&
+			es[1].Points == -15 && *es[1].SourceID == earn.ID && es[1].ExpiresAt == nil
+	})).Return(nil)
+
+	err := rw.Reverse(context.Background(), loyalty.Rules{UnitCents: 100, ExpiryDays: 30}, []*loyalty.Entry{redeem, earn}, "order could not be saved", at)
+
+	require.NoError(t, err)
+	ledger.AssertExpectations(t)
+}
+
+func TestRewards_ReverseOrder_SkipsWhatIsAlreadyUndone(t *testing.T) {
+	ledger := new(mockLoyaltyLedger)
+	rw := pricing.NewRewards(new(mockLoyaltyRulesRepository), ledger)
+	customerID, orderID := uuid.New(), uuid.New()
+	redeem := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindRedeem, Points: -200, Reference: orderID.String()}
+	earn := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindEarn, Points: 15, Reference: orderID.String()}
+	undone := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindReversal, Points: -15, SourceID: &earn.ID}
+	other := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindEarn, Points: 40, Reference: uuid.NewString()}
+	ledger.On("Entries", mock.Anything, customerID).Return([]*loyalty.Entry{redeem, earn, undone, other}, nil)
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Points == 200 && *es[0].SourceID == redeem.ID
+	})).Return(nil)
+
+	err := rw.ReverseOrder(context.Background(), loyalty.Rules{UnitCents: 100, ExpiryDays: 30}, customerID, orderID, "order cancelled", time.Now())
+
+	require.NoError(t, err)
+	ledger.AssertExpectations(t)
+}
+
+func TestRewards_ReverseOrder_UndoesWhatARefundLeft(t *testing.T) {
+	ledger := new(mockLoyaltyLedger)
+	rw := pricing.NewRewards(new(mockLoyaltyRulesRepository), ledger)
+	customerID, orderID := uuid.New(), uuid.New()
+	earn := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindEarn, Points: 39, Reference: orderID.String()}
+	refunded := &loyalty.Entry{ID: uuid.New(), CustomerID: customerID, Kind: loyalty.KindReversal, Points: -9, Reference: "refund:1", SourceID: &earn.ID}
+	ledger.On("Entries", mock.Anything, customerID).Return([]*loyalty.Entry{earn, refunded}, nil)
+	ledger.On("Append", mock.Anything, mock.MatchedBy(func(es []*loyalty.Entry) bool {
+		return len(es) == 1 && es[0].Points == -30 && *es[0].SourceID == earn.ID && es[0].Reference == ""
+	})).Return(nil)
+
+	err := rw.ReverseOrder(context.Background(), loyalty.Rules{UnitCents: 100}, customerID, orderID, "order cancelled", time.Now())
+
+	require.NoError(t, err)
+	ledger.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// CreatePurchaseOrderCommand is the input for the create purchase order use case.
+type CreatePurchaseOrderCommand struct {
+	SupplierID uuid.UUID
+	BranchID   uuid.UUID
+	Fields     OrderFields
+	ActorID    uuid.UUID
+}
+
+// CreatePurchaseOrderHandler drafts a purchase order with an active supplier.
+type CreatePurchaseOrderHandler struct {
+	supplierRepo procurement.SupplierRepository
+	orderRepo    procurement.PurchaseOrderRepository
+}
+
+// NewCreatePurchaseOrderHandler creates a new CreatePurchaseOrderHandler.
+func NewCreatePurchaseOrderHandler(supplierRepo procurement.SupplierRepository, orderRepo procurement.PurchaseOrderRepository) *CreatePurchaseOrderHandler {
+	return &CreatePurchaseOrderHandler{supplierRepo: supplierRepo, orderRepo: orderRepo}
+}
+
+// Handle executes the create purchase order use case.
+func (h *CreatePurchaseOrderHandler) Handle(ctx context.Context, cmd CreatePurchaseOrderCommand) (*procurement.PurchaseOrder, error) {
+	lines, expectedOn, err := cmd.Fields.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := h.supplierRepo.FindByID(ctx, cmd.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsActive() {
+		return nil, procurement.ErrSupplierInactive
+	}
+
+	po, err := procurement.NewPurchaseOrder(uuid.New(), s.ID(), cmd.BranchID, lines, expectedOn, cmd.Fields.Notes, &cmd.ActorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.orderRepo.Create(ctx, po); err != nil {
+		return nil, fmt.Errorf("saving purchase order: %w", err)
+	}
+	return po, nil
+}
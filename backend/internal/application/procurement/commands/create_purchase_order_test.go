@@ -0,0 +1,104 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func validOrderFields() commands.OrderFields {
+	return commands.OrderFields{
+		Lines:      []commands.LineInput{{ProductID: uuid.New(), OrderedGrams: 20000, PricePerKgCents: 1200}},
+		ExpectedOn: "2026-11-02",
+		Notes:      "Weekly lamb",
+	}
+}
+
+func newDraftOrder(t *testing.T) *procurement.PurchaseOrder {
+	t.Helper()
+	po, err := procurement.NewPurchaseOrder(uuid.New(), uuid.New(), uuid.New(),
+		[]procurement.Line{{ProductID: uuid.New(), OrderedGrams: 20000, PricePerKgCents: 1200}}, nil, "", nil, time.Now())
+	require.NoError(t, err)
+	return po
+}
+
+func TestCreatePurchaseOrder_ActiveSupplier_CreatesDraft(t *testing.T) {
+	suppliers := new(mockSupplierRepository)
+	orders := new(mockPurchaseOrderRepository)
+	s := newTestSupplier(t)
+	suppliers.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	orders.On("Create", mock.Anything, mock.AnythingOfType("*procurement.PurchaseOrder")).Return(nil)
+
+	actorID := uuid.New()
+	handler := commands.NewCreatePurchaseOrderHandler(suppliers, orders)
+	po, err := handler.Handle(context.Background(), commands.CreatePurchaseOrderCommand{
+		SupplierID: s.ID(),
+		BranchID:   uuid.New(),
+		Fields:     validOrderFields(),
+		ActorID:    actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, procurement.StatusDraft, po.Status())
+	assert.Equal(t, &actorID, po.CreatedBy())
+	require.NotNil(t, po.ExpectedOn())
+	assert.Equal(t, "2026-11-02", po.ExpectedOn().Format(time.DateOnly))
+	orders.AssertExpectations(t)
+}
+
+func TestCreatePurchaseOrder_InactiveSupplier_ReturnsError(t *testing.T) {
+	suppliers := new(mockSupplierRepository)
+	orders := new(mockPurchaseOrderRepository)
+	s := newTestSupplier(t)
+	s.SetActive(false, time.Now())
+	suppliers.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+
+	handler := commands.NewCreatePurchaseOrderHandler(suppliers, orders)
+	_, err := handler.Handle(context.Background(), commands.CreatePurchaseOrderCommand{SupplierID: s.ID(), Fields: validOrderFields()})
+
+	assert.ErrorIs(t, err, procurement.ErrSupplierInactive)
+	orders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRevisePurchaseOrder_SentOrder_ReturnsError(t *testing.T) {
+	orders := new(mockPurchaseOrderRepository)
+	po := newDraftOrder(t)
+	require.NoError(t, po.Send(time.Now()))
+	orders.On("FindByID", mock.Anything, po.ID()).Return(po, nil)
+
+	handler := commands.NewRevisePurchaseOrderHandler(orders)
+	_, err := handler.Handle(context.Background(), commands.RevisePurchaseOrderCommand{PurchaseOrderID: po.ID(), Fields: validOrderFields()})
+
+	assert.ErrorIs(t, err, procurement.ErrNotDraft)
+	orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSendPurchaseOrder_ConcurrentUpdate_ReturnsError(t *testing.T) {
+	orders := new(mockPurchaseOrderRepository)
+	po := newDraftOrder(t)
+	orders.On("FindByID", mock.Anything, po.ID()).Return(po, nil)
+	orders.On("Update", mock.Anything, po).Return(procurement.ErrConcurrentUpdate)
+
+	handler := commands.NewSendPurchaseOrderHandler(orders)
+	_, err := handler.Handle(context.Background(), po.ID())
+
+	assert.ErrorIs(t, err, procurement.ErrConcurrentUpdate)
+}
+
+func TestClosePurchaseOrder_Draft_ReturnsError(t *testing.T) {
+	orders := new(mockPurchaseOrderRepository)
+	po := newDraftOrder(t)
+	orders.On("FindByID", mock.Anything, po.ID()).Return(po, nil)
+
+	handler := commands.NewClosePurchaseOrderHandler(orders)
+	_, err := handler.Handle(context.Background(), po.ID())
+
+	assert.ErrorIs(t, err, procurement.ErrInvalidTransition)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// CreateSupplierHandler adds a supplier.
+type CreateSupplierHandler struct {
+	supplierRepo procurement.SupplierRepository
+}
+
+// NewCreateSupplierHandler creates a new CreateSupplierHandler.
+func NewCreateSupplierHandler(supplierRepo procurement.SupplierRepository) *CreateSupplierHandler {
+	return &CreateSupplierHandler{supplierRepo: supplierRepo}
+}
+
+// Handle executes the create supplier use case.
+func (h *CreateSupplierHandler) Handle(ctx context.Context, f SupplierFields) (*procurement.Supplier, error) {
+	kind, certificates, err := f.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := procurement.NewSupplier(uuid.New(), f.Name, kind, f.Contacts, certificates, f.PaymentTermsDays, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.supplierRepo.Save(ctx, s); err != nil {
+		return nil, fmt.Errorf("saving supplier: %w", err)
+	}
+	return s, nil
+}
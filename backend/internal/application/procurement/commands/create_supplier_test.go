@@ -0,0 +1,171 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockSupplierRepository struct {
+	mock.Mock
+}
+
+func (m *mockSupplierRepository) Save(ctx context.Context, s *procurement.Supplier) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *mockSupplierRepository) FindByID(ctx context.Context, id uuid.UUID) (*procurement.Supplier, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*procurement.Supplier), args.Error(1)
+}
+
+func (m *mockSupplierRepository) FindAll(ctx context.Context, activeOnly bool) ([]*procurement.Supplier, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*procurement.Supplier), args.Error(1)
+}
+
+type mockPurchaseOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockPurchaseOrderRepository) Create(ctx context.Context, po *procurement.PurchaseOrder) error {
+	args := m.Called(ctx, po)
+	return args.Error(0)
+}
+
+func (m *mockPurchaseOrderRepository) Update(ctx context.Context, po *procurement.PurchaseOrder) error {
+	args := m.Called(ctx, po)
+	return args.Error(0)
+}
+
+func (m *mockPurchaseOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*procurement.PurchaseOrder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*procurement.PurchaseOrder), args.Error(1)
+}
+
+func (m *mockPurchaseOrderRepository) FindAll(ctx context.Context, filter procurement.Filter) ([]*procurement.PurchaseOrder, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*procurement.PurchaseOrder), args.Error(1)
+}
+
+func (m *mockPurchaseOrderRepository) SaveReceipt(ctx context.Context, po *procurement.PurchaseOrder, n *procurement.GoodsReceivedNote, lots []*inventory.Lot, received []*inventory.Movement) error {
+	args := m.Called(ctx, po, n, lots, received)
+	return args.Error(0)
+}
+
+func (m *mockPurchaseOrderRepository) FindReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]*procurement.GoodsReceivedNote, error) {
+	args := m.Called(ctx, purchaseOrderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*procurement.GoodsReceivedNote), args.Error(1)
+}
+
+func (m *mockPurchaseOrderRepository) FindPriceHistory(ctx context.Context, supplierID uuid.UUID, productID *uuid.UUID) ([]procurement.PricePoint, error) {
+	args := m.Called(ctx, supplierID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]procurement.PricePoint), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func validSupplierFields() commands.SupplierFields {
+	return commands.SupplierFields{
+		Name:             "Green Valley Farm",
+		Type:             "farm",
+		Contacts:         []procurement.Contact{{Name: "Amina Khan", Role: "Sales", Email: "amina@greenvalley.example"}},
+		Certificates:     []commands.CertificateInput{{Type: "halal", Number: "HMC-1042", ExpiresOn: "2027-03-31"}},
+		PaymentTermsDays: 30,
+	}
+}
+
+func newTestSupplier(t *testing.T) *procurement.Supplier {
+	t.Helper()
+	s, err := procurement.NewSupplier(uuid.New(), "Green Valley Farm", procurement.SupplierFarm, nil, nil, 30, time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+// --- CreateSupplier Tests ---
+
+func TestCreateSupplier_ValidInput_SavesSupplier(t *testing.T) {
+	repo := new(mockSupplierRepository)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*procurement.Supplier")).Return(nil)
+
+	handler := commands.NewCreateSupplierHandler(repo)
+	s, err := handler.Handle(context.Background(), validSupplierFields())
+
+	require.NoError(t, err)
+	assert.Equal(t, procurement.SupplierFarm, s.Type())
+	require.Len(t, s.Certificates(), 1)
+	require.NotNil(t, s.Certificates()[0].ExpiresOn)
+	assert.Equal(t, "2027-03-31", s.Certificates()[0].ExpiresOn.Format(time.DateOnly))
+	repo.AssertExpectations(t)
+}
+
+func TestCreateSupplier_InvalidCertificateDate_ReturnsError(t *testing.T) {
+	repo := new(mockSupplierRepository)
+	f := validSupplierFields()
+	f.Certificates[0].ExpiresOn = "31/03/2027"
+
+	handler := commands.NewCreateSupplierHandler(repo)
+	_, err := handler.Handle(context.Background(), f)
+
+	assert.ErrorIs(t, err, procurement.ErrInvalidDate)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+// --- UpdateSupplier Tests ---
+
+func TestUpdateSupplier_Deactivates(t *testing.T) {
+	repo := new(mockSupplierRepository)
+	s := newTestSupplier(t)
+	repo.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	repo.On("Save", mock.Anything, s).Return(nil)
+
+	f := validSupplierFields()
+	f.PaymentTermsDays = 14
+	handler := commands.NewUpdateSupplierHandler(repo)
+	updated, err := handler.Handle(context.Background(), commands.UpdateSupplierCommand{SupplierID: s.ID(), Fields: f, Active: false})
+
+	require.NoError(t, err)
+	assert.False(t, updated.IsActive())
+	assert.Equal(t, 14, updated.PaymentTermsDays())
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateSupplier_NotFound_ReturnsError(t *testing.T) {
+	repo := new(mockSupplierRepository)
+	id := uuid.New()
+	repo.On("FindByID", mock.Anything, id).Return(nil, procurement.ErrSupplierNotFound)
+
+	handler := commands.NewUpdateSupplierHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.UpdateSupplierCommand{SupplierID: id, Fields: validSupplierFields()})
+
+	assert.ErrorIs(t, err, procurement.ErrSupplierNotFound)
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// LineInput is one product to order.
+type LineInput struct {
+	ProductID       uuid.UUID
+	OrderedGrams    int64
+	PricePerKgCents int64
+}
+
+// OrderFields are the editable parts of a draft purchase order.
+type OrderFields struct {
+	Lines      []LineInput
+	ExpectedOn string // YYYY-MM-DD, optional
+	Notes      string
+}
+
+func (f OrderFields) parse() ([]procurement.Line, *time.Time, error) {
+	lines := make([]procurement.Line, 0, len(f.Lines))
+	for _, in := range f.Lines {
+		lines = append(lines, procurement.Line{
+			ProductID:       in.ProductID,
+			OrderedGrams:    in.OrderedGrams,
+			PricePerKgCents: in.PricePerKgCents,
+		})
+	}
+	if f.ExpectedOn == "" {
+		return lines, nil, nil
+	}
+	d, err := parseDate(f.ExpectedOn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lines, &d, nil
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// goodsReceivedReason is recorded on the received movement of every lot
+// created from a goods-received note.
+const goodsReceivedReason = "goods received"
+
+// ReceiptLineInput is one batch weighed in on arrival.
+type ReceiptLineInput struct {
+	LineID             uuid.UUID
+	LotCode            string
+	Grams              int64
+	TemperatureTenthsC int
+	ExpiresOn          string // YYYY-MM-DD, optional
+}
+
+// ReceiveGoodsCommand is the input for the receive goods use case.
+type ReceiveGoodsCommand struct {
+	PurchaseOrderID uuid.UUID
+	Lines           []ReceiptLineInput
+	Notes           string
+	ActorID         uuid.UUID
+}
+
+// ReceiveGoodsHandler records a delivery against a purchase order and
+// receives each batch into inventory as a lot in the order's branch.
+type ReceiveGoodsHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewReceiveGoodsHandler creates a new ReceiveGoodsHandler.
+func NewReceiveGoodsHandler(orderRepo procurement.PurchaseOrderRepository) *ReceiveGoodsHandler {
+	return &ReceiveGoodsHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the receive goods use case.
+func (h *ReceiveGoodsHandler) Handle(ctx context.Context, cmd ReceiveGoodsCommand) (*procurement.GoodsReceivedNote, error) {
+	lines := make([]procurement.ReceiptLine, 0, len(cmd.Lines))
+	for _, in := range cmd.Lines {
+		rl := procurement.ReceiptLine{
+			LineID:             in.LineID,
+			LotCode:            in.LotCode,
+			Grams:              in.Grams,
+			TemperatureTenthsC: in.TemperatureTenthsC,
+		}
+		if in.ExpiresOn != "" {
+			d, err := parseDate(in.ExpiresOn)
+			if err != nil {
+				return nil, err
+			}
+			rl.ExpiresOn = &d
+		}
+		lines = append(lines, rl)
+	}
+
+	po, err := h.orderRepo.FindByID(ctx, cmd.PurchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	n, err := po.Receive(uuid.New(), lines, cmd.Notes, &cmd.ActorID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[uuid.UUID]int64, len(po.Lines()))
+	for _, l := range po.Lines() {
+		prices[l.ID] = l.PricePerKgCents
+	}
+
+	lots := make([]*inventory.Lot, 0, len(n.Lines()))
+	received := make([]*inventory.Movement, 0, len(n.Lines()))
+	for _, rl := range n.Lines() {
+		lot, m, err := inventory.ReceiveLotFrom(rl.LotID, rl.ProductID, po.BranchID(), rl.LotCode, rl.Grams,
+			rl.ExpiresOn, goodsReceivedReason, n.ID(), &cmd.ActorID, now)
+		if err != nil {
+			return nil, err
+		}
+		// Lots are costed at the price agreed on the order, so that waste
+		// can be valued.
+		if err := lot.SetCost(prices[rl.LineID]); err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+		received = append(received, m)
+	}
+
+	if err := h.orderRepo.SaveReceipt(ctx, po, n, lots, received); err != nil {
+		if errors.Is(err, procurement.ErrConcurrentUpdate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving goods received note: %w", err)
+	}
+	return n, nil
+}
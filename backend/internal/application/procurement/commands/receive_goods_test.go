@@ -0,0 +1,77 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiveGoods_ReceivesEachBatchAsLot(t *testing.T) {
+	orders := new(mockPurchaseOrderRepository)
+	po := newDraftOrder(t)
+	require.NoError(t, po.Send(time.Now()))
+	orders.On("FindByID", mock.Anything, po.ID()).Return(po, nil)
+	orders.On("SaveReceipt", mock.Anything, po, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	actorID := uuid.New()
+	handler := commands.NewReceiveGoodsHandler(orders)
+	n, err := handler.Handle(context.Background(), commands.ReceiveGoodsCommand{
+		PurchaseOrderID: po.ID(),
+		Lines: []commands.ReceiptLineInput{
+			{LineID: po.Lines()[0].ID, LotCode: "GV-0412", Grams: 19400, TemperatureTenthsC: 31, ExpiresOn: "2026-10-30"},
+		},
+		ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, procurement.StatusPartiallyReceived, po.Status())
+	assert.Equal(t, int64(19400), po.Lines()[0].ReceivedGrams)
+
+	lots := orders.Calls[1].Arguments.Get(3).([]*inventory.Lot)
+	received := orders.Calls[1].Arguments.Get(4).([]*inventory.Movement)
+	require.Len(t, lots, 1)
+	assert.Equal(t, n.Lines()[0].LotID, lots[0].ID())
+	assert.Equal(t, po.Lines()[0].ProductID, lots[0].ProductID())
+	assert.Equal(t, po.BranchID(), lots[0].BranchID())
+	assert.Equal(t, "GV-0412", lots[0].Code())
+	require.NotNil(t, lots[0].ExpiresOn())
+	require.NotNil(t, received[0].ReferenceID())
+	assert.Equal(t, n.ID(), *received[0].ReferenceID())
+	assert.Equal(t, &actorID, received[0].ActorID())
+}
+
+func TestReceiveGoods_DraftOrder_ReturnsError(t *testing.T) {
+	orders := new(mockPurchaseOrderRepository)
+	po := newDraftOrder(t)
+	orders.On("FindByID", mock.Anything, po.ID()).Return(po, nil)
+
+	handler := commands.NewReceiveGoodsHandler(orders)
+	_, err := handler.Handle(context.Background(), commands.ReceiveGoodsCommand{
+		PurchaseOrderID: po.ID(),
+		Lines:           []commands.ReceiptLineInput{{LineID: po.Lines()[0].ID, LotCode: "GV-0412", Grams: 1000}},
+	})
+
+	assert.ErrorIs(t, err, procurement.ErrNotReceivable)
+	orders.AssertNotCalled(t, "SaveReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReceiveGoods_InvalidExpiry_ReturnsError(t *testing.T) {
+	orders := new(mockPurchaseOrderRepository)
+
+	handler := commands.NewReceiveGoodsHandler(orders)
+	_, err := handler.Handle(context.Background(), commands.ReceiveGoodsCommand{
+		PurchaseOrderID: uuid.New(),
+		Lines:           []commands.ReceiptLineInput{{LineID: uuid.New(), LotCode: "GV-0412", Grams: 1000, ExpiresOn: "soon"}},
+	})
+
+	assert.ErrorIs(t, err, procurement.ErrInvalidDate)
+	orders.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// RevisePurchaseOrderCommand is the input for the revise purchase order use case.
+type RevisePurchaseOrderCommand struct {
+	PurchaseOrderID uuid.UUID
+	Fields          OrderFields
+}
+
+// RevisePurchaseOrderHandler replaces the lines of a draft purchase order.
+type RevisePurchaseOrderHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewRevisePurchaseOrderHandler creates a new RevisePurchaseOrderHandler.
+func NewRevisePurchaseOrderHandler(orderRepo procurement.PurchaseOrderRepository) *RevisePurchaseOrderHandler {
+	return &RevisePurchaseOrderHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the revise purchase order use case.
+func (h *RevisePurchaseOrderHandler) Handle(ctx context.Context, cmd RevisePurchaseOrderCommand) (*procurement.PurchaseOrder, error) {
+	lines, expectedOn, err := cmd.Fields.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	po, err := h.orderRepo.FindByID(ctx, cmd.PurchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := po.Revise(lines, expectedOn, cmd.Fields.Notes, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := h.orderRepo.Update(ctx, po); err != nil {
+		if errors.Is(err, procurement.ErrConcurrentUpdate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving purchase order: %w", err)
+	}
+	return po, nil
+}
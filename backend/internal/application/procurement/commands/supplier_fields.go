@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// CertificateInput is an accreditation held by a supplier.
+type CertificateInput struct {
+	Type      string
+	Number    string
+	ExpiresOn string // YYYY-MM-DD, optional
+}
+
+// SupplierFields describe a supplier.
+type SupplierFields struct {
+	Name             string
+	Type             string
+	Contacts         []procurement.Contact
+	Certificates     []CertificateInput
+	PaymentTermsDays int
+}
+
+func (f SupplierFields) parse() (procurement.SupplierType, []procurement.Certificate, error) {
+	kind, err := procurement.NewSupplierType(f.Type)
+	if err != nil {
+		return "", nil, err
+	}
+	certificates := make([]procurement.Certificate, 0, len(f.Certificates))
+	for _, in := range f.Certificates {
+		c := procurement.Certificate{Type: in.Type, Number: in.Number}
+		if in.ExpiresOn != "" {
+			d, err := parseDate(in.ExpiresOn)
+			if err != nil {
+				return "", nil, err
+			}
+			c.ExpiresOn = &d
+		}
+		certificates = append(certificates, c)
+	}
+	return kind, certificates, nil
+}
+
+func parseDate(raw string) (time.Time, error) {
+	d, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, procurement.ErrInvalidDate
+	}
+	return d, nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// SendPurchaseOrderHandler marks a draft purchase order as sent to the supplier.
+type SendPurchaseOrderHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewSendPurchaseOrderHandler creates a new SendPurchaseOrderHandler.
+func NewSendPurchaseOrderHandler(orderRepo procurement.PurchaseOrderRepository) *SendPurchaseOrderHandler {
+	return &SendPurchaseOrderHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the send purchase order use case.
+func (h *SendPurchaseOrderHandler) Handle(ctx context.Context, purchaseOrderID uuid.UUID) (*procurement.PurchaseOrder, error) {
+	return transition(ctx, h.orderRepo, purchaseOrderID, (*procurement.PurchaseOrder).Send)
+}
+
+// ClosePurchaseOrderHandler closes a purchase order, writing off anything
+// still outstanding.
+type ClosePurchaseOrderHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewClosePurchaseOrderHandler creates a new ClosePurchaseOrderHandler.
+func NewClosePurchaseOrderHandler(orderRepo procurement.PurchaseOrderRepository) *ClosePurchaseOrderHandler {
+	return &ClosePurchaseOrderHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the close purchase order use case.
+func (h *ClosePurchaseOrderHandler) Handle(ctx context.Context, purchaseOrderID uuid.UUID) (*procurement.PurchaseOrder, error) {
+	return transition(ctx, h.orderRepo, purchaseOrderID, (*procurement.PurchaseOrder).Close)
+}
+
+func transition(
+	ctx context.Context,
+	orderRepo procurement.PurchaseOrderRepository,
+	purchaseOrderID uuid.UUID,
+	apply func(*procurement.PurchaseOrder, time.Time) error,
+) (*procurement.PurchaseOrder, error) {
+	po, err := orderRepo.FindByID(ctx, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(po, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := orderRepo.Update(ctx, po); err != nil {
+		if errors.Is(err, procurement.ErrConcurrentUpdate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving purchase order: %w", err)
+	}
+	return po, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// UpdateSupplierCommand is the input for the update supplier use case.
+type UpdateSupplierCommand struct {
+	SupplierID uuid.UUID
+	Fields     SupplierFields
+	Active     bool
+}
+
+// UpdateSupplierHandler changes a supplier's details or switches it on and off.
+type UpdateSupplierHandler struct {
+	supplierRepo procurement.SupplierRepository
+}
+
+// NewUpdateSupplierHandler creates a new UpdateSupplierHandler.
+func NewUpdateSupplierHandler(supplierRepo procurement.SupplierRepository) *UpdateSupplierHandler {
+	return &UpdateSupplierHandler{supplierRepo: supplierRepo}
+}
+
+// Handle executes the update supplier use case.
+func (h *UpdateSupplierHandler) Handle(ctx context.Context, cmd UpdateSupplierCommand) (*procurement.Supplier, error) {
+	kind, certificates, err := cmd.Fields.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := h.supplierRepo.FindByID(ctx, cmd.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	f := cmd.Fields
+	if err := s.Update(f.Name, kind, f.Contacts, certificates, f.PaymentTermsDays, now); err != nil {
+		return nil, err
+	}
+	s.SetActive(cmd.Active, now)
+
+	if err := h.supplierRepo.Save(ctx, s); err != nil {
+		return nil, fmt.Errorf("saving supplier: %w", err)
+	}
+	return s, nil
+}
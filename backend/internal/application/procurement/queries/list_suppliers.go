@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// ListSuppliersHandler lists suppliers.
+type ListSuppliersHandler struct {
+	supplierRepo procurement.SupplierRepository
+}
+
+// NewListSuppliersHandler creates a new ListSuppliersHandler.
+func NewListSuppliersHandler(supplierRepo procurement.SupplierRepository) *ListSuppliersHandler {
+	return &ListSuppliersHandler{supplierRepo: supplierRepo}
+}
+
+// Handle returns suppliers ordered by name, optionally only the active ones.
+func (h *ListSuppliersHandler) Handle(ctx context.Context, activeOnly bool) ([]*procurement.Supplier, error) {
+	suppliers, err := h.supplierRepo.FindAll(ctx, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("querying suppliers: %w", err)
+	}
+	return suppliers, nil
+}
@@ -0,0 +1,39 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// PriceHistoryQuery is the input for the price history use case.
+type PriceHistoryQuery struct {
+	SupplierID uuid.UUID
+	ProductID  *uuid.UUID
+}
+
+// PriceHistoryHandler lists the prices agreed with a supplier over time.
+type PriceHistoryHandler struct {
+	supplierRepo procurement.SupplierRepository
+	orderRepo    procurement.PurchaseOrderRepository
+}
+
+// NewPriceHistoryHandler creates a new PriceHistoryHandler.
+func NewPriceHistoryHandler(supplierRepo procurement.SupplierRepository, orderRepo procurement.PurchaseOrderRepository) *PriceHistoryHandler {
+	return &PriceHistoryHandler{supplierRepo: supplierRepo, orderRepo: orderRepo}
+}
+
+// Handle returns the supplier's prices on sent orders, newest first.
+func (h *PriceHistoryHandler) Handle(ctx context.Context, q PriceHistoryQuery) ([]procurement.PricePoint, error) {
+	if _, err := h.supplierRepo.FindByID(ctx, q.SupplierID); err != nil {
+		return nil, err
+	}
+
+	prices, err := h.orderRepo.FindPriceHistory(ctx, q.SupplierID, q.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("querying price history: %w", err)
+	}
+	return prices, nil
+}
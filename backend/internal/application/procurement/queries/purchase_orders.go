@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// ListPurchaseOrdersHandler lists purchase orders.
+type ListPurchaseOrdersHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewListPurchaseOrdersHandler creates a new ListPurchaseOrdersHandler.
+func NewListPurchaseOrdersHandler(orderRepo procurement.PurchaseOrderRepository) *ListPurchaseOrdersHandler {
+	return &ListPurchaseOrdersHandler{orderRepo: orderRepo}
+}
+
+// Handle returns purchase orders matching filter, most recently created first.
+func (h *ListPurchaseOrdersHandler) Handle(ctx context.Context, filter procurement.Filter) ([]*procurement.PurchaseOrder, error) {
+	orders, err := h.orderRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying purchase orders: %w", err)
+	}
+	return orders, nil
+}
+
+// GetPurchaseOrderHandler returns one purchase order.
+type GetPurchaseOrderHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewGetPurchaseOrderHandler creates a new GetPurchaseOrderHandler.
+func NewGetPurchaseOrderHandler(orderRepo procurement.PurchaseOrderRepository) *GetPurchaseOrderHandler {
+	return &GetPurchaseOrderHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the get purchase order query.
+func (h *GetPurchaseOrderHandler) Handle(ctx context.Context, purchaseOrderID uuid.UUID) (*procurement.PurchaseOrder, error) {
+	return h.orderRepo.FindByID(ctx, purchaseOrderID)
+}
+
+// ListReceiptsHandler lists the goods-received notes booked against a purchase order.
+type ListReceiptsHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewListReceiptsHandler creates a new ListReceiptsHandler.
+func NewListReceiptsHandler(orderRepo procurement.PurchaseOrderRepository) *ListReceiptsHandler {
+	return &ListReceiptsHandler{orderRepo: orderRepo}
+}
+
+// Handle returns the order's goods-received notes, oldest first.
+func (h *ListReceiptsHandler) Handle(ctx context.Context, purchaseOrderID uuid.UUID) ([]*procurement.GoodsReceivedNote, error) {
+	if _, err := h.orderRepo.FindByID(ctx, purchaseOrderID); err != nil {
+		return nil, err
+	}
+
+	receipts, err := h.orderRepo.FindReceipts(ctx, purchaseOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("querying goods received notes: %w", err)
+	}
+	return receipts, nil
+}
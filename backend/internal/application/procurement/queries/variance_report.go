@@ -0,0 +1,68 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// VarianceReportQuery is the input for the variance report use case. From
+// and To are inclusive YYYY-MM-DD dates on which the orders were sent.
+type VarianceReportQuery struct {
+	SupplierID *uuid.UUID
+	From       string
+	To         string
+}
+
+// VarianceReportHandler compares ordered and received amounts on settled
+// purchase orders.
+type VarianceReportHandler struct {
+	orderRepo procurement.PurchaseOrderRepository
+}
+
+// NewVarianceReportHandler creates a new VarianceReportHandler.
+func NewVarianceReportHandler(orderRepo procurement.PurchaseOrderRepository) *VarianceReportHandler {
+	return &VarianceReportHandler{orderRepo: orderRepo}
+}
+
+// Handle executes the variance report query.
+func (h *VarianceReportHandler) Handle(ctx context.Context, q VarianceReportQuery) (*procurement.VarianceReport, error) {
+	filter := procurement.Filter{
+		SupplierID: q.SupplierID,
+		Statuses:   []procurement.Status{procurement.StatusReceived, procurement.StatusClosed},
+	}
+	if q.From != "" {
+		from, err := parseDate(q.From)
+		if err != nil {
+			return nil, err
+		}
+		filter.SentFrom = &from
+	}
+	if q.To != "" {
+		to, err := parseDate(q.To)
+		if err != nil {
+			return nil, err
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.SentTo = &to
+	}
+
+	orders, err := h.orderRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying purchase orders: %w", err)
+	}
+
+	report := procurement.NewVarianceReport(orders)
+	return &report, nil
+}
+
+func parseDate(raw string) (time.Time, error) {
+	d, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, procurement.ErrInvalidDate
+	}
+	return d, nil
+}
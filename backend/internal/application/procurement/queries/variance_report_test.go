@@ -0,0 +1,107 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/procurement/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPurchaseOrderRepository struct {
+	mock.Mock
+}
+
+func (m *mockPurchaseOrderRepository) Create(ctx context.Context, po *procurement.PurchaseOrder) error {
+	args := m.Called(ctx, po)
+	return args.Error(0)
+}
+
+func (m *mockPurchaseOrderRepository) Update(ctx context.Context, po *procurement.PurchaseOrder) error {
+	args := m.Called(ctx, po)
+	return args.Error(0)
+}
+
+func (m *mockPurchaseOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*procurement.PurchaseOrder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*procurement.PurchaseOrder), args.Error(1)
+}
+
+func (m *mockPurchaseOrderRepository) FindAll(ctx context.Context, filter procurement.Filter) ([]*procurement.PurchaseOrder, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*procurement.PurchaseOrder), args.Error(1)
+}
+
+func (m *mockPurchaseOrderRepository) SaveReceipt(ctx context.Context, po *procurement.PurchaseOrder, n *procurement.GoodsReceivedNote, lots []*inventory.Lot, received []*inventory.Movement) error {
+	args := m.Called(ctx, po, n, lots, received)
+	return args.Error(0)
+}
+
+func (m *mockPurchaseOrderRepository) FindReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]*procurement.GoodsReceivedNote, error) {
+	args := m.Called(ctx, purchaseOrderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*procurement.GoodsReceivedNote), args.Error(1)
+}
+
+func (m *mockPurchaseOrderRepository) FindPriceHistory(ctx context.Context, supplierID uuid.UUID, productID *uuid.UUID) ([]procurement.PricePoint, error) {
+	args := m.Called(ctx, supplierID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]procurement.PricePoint), args.Error(1)
+}
+
+func TestVarianceReport_FiltersSettledOrdersInRange(t *testing.T) {
+	repo := new(mockPurchaseOrderRepository)
+	supplierID := uuid.New()
+	now := time.Now()
+	closed := procurement.ReconstructPurchaseOrder(uuid.New(), supplierID, uuid.New(), procurement.StatusClosed,
+		[]procurement.Line{{ID: uuid.New(), ProductID: uuid.New(), OrderedGrams: 10000, PricePerKgCents: 1200, ReceivedGrams: 9500}},
+		nil, "", nil, 3, now, &now, &now, now)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	repo.On("FindAll", mock.Anything, procurement.Filter{
+		SupplierID: &supplierID,
+		Statuses:   []procurement.Status{procurement.StatusReceived, procurement.StatusClosed},
+		SentFrom:   &from,
+		SentTo:     &to,
+	}).Return([]*procurement.PurchaseOrder{closed}, nil)
+
+	handler := queries.NewVarianceReportHandler(repo)
+	report, err := handler.Handle(context.Background(), queries.VarianceReportQuery{
+		SupplierID: &supplierID,
+		From:       "2026-10-01",
+		To:         "2026-10-31",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, report.Suppliers, 1)
+	assert.Equal(t, int64(-500), report.Suppliers[0].VarianceGrams())
+	assert.Equal(t, int64(-600), report.Suppliers[0].VarianceCents())
+	repo.AssertExpectations(t)
+}
+
+func TestVarianceReport_InvalidDate_ReturnsError(t *testing.T) {
+	repo := new(mockPurchaseOrderRepository)
+
+	handler := queries.NewVarianceReportHandler(repo)
+	_, err := handler.Handle(context.Background(), queries.VarianceReportQuery{From: "01/10/2026"})
+
+	assert.ErrorIs(t, err, procurement.ErrInvalidDate)
+	repo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// ClassifyProductCommand is the input for the classify product use case.
+type ClassifyProductCommand struct {
+	ProductID uuid.UUID
+	Category  string
+	Species   string
+	ActorID   uuid.UUID
+}
+
+// ClassifyProductHandler sets the category and species promotions can
+// target a catalog product by, e.g. so "10% off lamb" covers every lamb cut.
+type ClassifyProductHandler struct {
+	productRepo promotion.ProductRepository
+	adminRepo   admin.Repository
+}
+
+// NewClassifyProductHandler creates a new ClassifyProductHandler.
+func NewClassifyProductHandler(productRepo promotion.ProductRepository, adminRepo admin.Repository) *ClassifyProductHandler {
+	return &ClassifyProductHandler{productRepo: productRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the classify product use case. Only managers may
+// classify products. Species must be one the shop cuts.
+func (h *ClassifyProductHandler) Handle(ctx context.Context, cmd ClassifyProductCommand) (promotion.Profile, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return promotion.Profile{}, err
+	}
+	if species := strings.TrimSpace(cmd.Species); species != "" {
+		if _, err := carcass.NewSpecies(strings.ToLower(species)); err != nil {
+			return promotion.Profile{}, err
+		}
+	}
+	profile, err := promotion.NewProfile(cmd.Category, cmd.Species)
+	if err != nil {
+		return promotion.Profile{}, err
+	}
+
+	if err := h.productRepo.Classify(ctx, cmd.ProductID, profile); err != nil {
+		return promotion.Profile{}, fmt.Errorf("classifying product: %w", err)
+	}
+	return profile, nil
+}
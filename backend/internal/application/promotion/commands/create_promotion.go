@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// CreatePromotionCommand is the input for the create promotion use case.
+type CreatePromotionCommand struct {
+	Fields  PromotionFields
+	ActorID uuid.UUID
+}
+
+// CreatePromotionHandler creates promotions. They are active from the
+// start, within their dates.
+type CreatePromotionHandler struct {
+	promotionRepo promotion.Repository
+	adminRepo     admin.Repository
+}
+
+// NewCreatePromotionHandler creates a new CreatePromotionHandler.
+func NewCreatePromotionHandler(promotionRepo promotion.Repository, adminRepo admin.Repository) *CreatePromotionHandler {
+	return &CreatePromotionHandler{promotionRepo: promotionRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the create promotion use case. Only managers may create
+// promotions. A coupon code already in use returns ErrDuplicateCode.
+func (h *CreatePromotionHandler) Handle(ctx context.Context, cmd CreatePromotionCommand) (*promotion.Promotion, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	terms, err := cmd.Fields.terms()
+	if err != nil {
+		return nil, err
+	}
+	p, err := promotion.NewPromotion(uuid.New(), terms, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.promotionRepo.Save(ctx, p); err != nil {
+		if errors.Is(err, promotion.ErrDuplicateCode) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving promotion: %w", err)
+	}
+	return p, nil
+}
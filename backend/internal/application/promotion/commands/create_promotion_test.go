@@ -0,0 +1,279 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/promotion/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionRepository) Save(ctx context.Context, p *promotion.Promotion) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockPromotionRepository) FindByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindAll(ctx context.Context) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindRunning(ctx context.Context, at time.Time) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+type mockPromotionProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionProductRepository) Classify(ctx context.Context, productID uuid.UUID, profile promotion.Profile) error {
+	return m.Called(ctx, productID, profile).Error(0)
+}
+
+func (m *mockPromotionProductRepository) FindProfiles(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]promotion.Profile, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]promotion.Profile), args.Error(1)
+}
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+// --- Fixtures ---
+
+// actor returns an admin repository holding an admin of the given role,
+// and the admin's ID.
+func actor(t *testing.T, role admin.Role) (*mockAdminRepository, uuid.UUID) {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), string(role)+"@butchery.com", "$2a$10$hash", "Sam Ahmed", role)
+	require.NoError(t, err)
+	admins := new(mockAdminRepository)
+	admins.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return admins, a.ID()
+}
+
+// --- Tests ---
+
+func TestCreatePromotion_SavesPromotion(t *testing.T) {
+	repo := new(mockPromotionRepository)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*promotion.Promotion")).Return(nil)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	p, err := commands.NewCreatePromotionHandler(repo, admins).Handle(context.Background(), commands.CreatePromotionCommand{
+		Fields: commands.PromotionFields{
+			Name:      "Buy 2kg mince get 500g free",
+			Kind:      "free_weight",
+			BuyGrams:  2000,
+			FreeGrams: 500,
+			Species:   []string{"Beef"},
+			StartsAt:  "2026-10-24T00:00:00+01:00",
+			EndsAt:    "2026-10-26T00:00:00Z",
+			Stacking:  "exclusive",
+		},
+		ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, promotion.KindFreeWeight, p.Rule().Kind)
+	assert.Equal(t, []string{"beef"}, p.Scope().Species)
+	assert.Equal(t, time.Date(2026, 10, 23, 23, 0, 0, 0, time.UTC), p.Terms().StartsAt.UTC())
+	assert.Equal(t, promotion.StackingExclusive, p.Stacking())
+	repo.AssertExpectations(t)
+}
+
+func TestCreatePromotion_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields commands.PromotionFields
+		want   error
+	}{
+		{"bad kind", commands.PromotionFields{Name: "x", Kind: "bogof"}, promotion.ErrInvalidKind},
+		{"bad stacking", commands.PromotionFields{Name: "x", Kind: "free_delivery", Stacking: "always"}, promotion.ErrInvalidStacking},
+		{"bad time", commands.PromotionFields{Name: "x", Kind: "free_delivery", StartsAt: "Saturday"}, promotion.ErrInvalidTime},
+		{"unknown species", commands.PromotionFields{Name: "x", Kind: "free_delivery", Species: []string{"pork"}}, carcass.ErrInvalidSpecies},
+		{"unknown segment", commands.PromotionFields{Name: "x", Kind: "free_delivery", Segments: []string{"vip"}}, promotion.ErrUnknownSegment},
+		{"bad rule", commands.PromotionFields{Name: "x", Kind: "percentage"}, promotion.ErrInvalidPercent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockPromotionRepository)
+			admins, actorID := actor(t, admin.RoleManager)
+
+			_, err := commands.NewCreatePromotionHandler(repo, admins).Handle(context.Background(),
+				commands.CreatePromotionCommand{Fields: tt.fields, ActorID: actorID})
+
+			assert.ErrorIs(t, err, tt.want)
+			repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreatePromotion_DuplicateCode_ReturnsError(t *testing.T) {
+	repo := new(mockPromotionRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(promotion.ErrDuplicateCode)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	_, err := commands.NewCreatePromotionHandler(repo, admins).Handle(context.Background(), commands.CreatePromotionCommand{
+		Fields:  commands.PromotionFields{Name: "Lamb", Code: "lamb10", Kind: "percentage", PercentBP: 1000},
+		ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, promotion.ErrDuplicateCode)
+}
+
+func TestCreatePromotion_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	repo := new(mockPromotionRepository)
+	admins, actorID := actor(t, admin.RoleStaff)
+
+	_, err := commands.NewCreatePromotionHandler(repo, admins).Handle(context.Background(), commands.CreatePromotionCommand{
+		Fields:  commands.PromotionFields{Name: "Lamb", Kind: "percentage", PercentBP: 1000},
+		ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, promotion.ErrManageNotAllowed)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePromotion_ReplacesTermsAndSwitchesOff(t *testing.T) {
+	existing, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name: "Old", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery},
+	}, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	repo := new(mockPromotionRepository)
+	repo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+	repo.On("Save", mock.Anything, existing).Return(nil)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	p, err := commands.NewUpdatePromotionHandler(repo, admins).Handle(context.Background(), commands.UpdatePromotionCommand{
+		PromotionID: existing.ID(),
+		Fields:      commands.PromotionFields{Name: "Free delivery over £50", Kind: "free_delivery", MinSubtotalCents: 5000},
+		Active:      false,
+		ActorID:     actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Free delivery over £50", p.Name())
+	assert.Equal(t, int64(5000), p.Rule().MinSubtotalCents)
+	assert.False(t, p.IsActive())
+	repo.AssertExpectations(t)
+}
+
+func TestUpdatePromotion_NotFound_ReturnsError(t *testing.T) {
+	repo := new(mockPromotionRepository)
+	repo.On("FindByID", mock.Anything, mock.Anything).Return(nil, promotion.ErrPromotionNotFound)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	_, err := commands.NewUpdatePromotionHandler(repo, admins).Handle(context.Background(), commands.UpdatePromotionCommand{
+		PromotionID: uuid.New(),
+		Fields:      commands.PromotionFields{Name: "x", Kind: "free_delivery"},
+		ActorID:     actorID,
+	})
+
+	assert.ErrorIs(t, err, promotion.ErrPromotionNotFound)
+}
+
+func TestUpdatePromotion_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	repo := new(mockPromotionRepository)
+	admins, actorID := actor(t, admin.RoleStaff)
+
+	_, err := commands.NewUpdatePromotionHandler(repo, admins).Handle(context.Background(), commands.UpdatePromotionCommand{
+		PromotionID: uuid.New(),
+		Fields:      commands.PromotionFields{Name: "x", Kind: "free_delivery"},
+		ActorID:     actorID,
+	})
+
+	assert.ErrorIs(t, err, promotion.ErrManageNotAllowed)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestClassifyProduct(t *testing.T) {
+	repo := new(mockPromotionProductRepository)
+	productID := uuid.New()
+	repo.On("Classify", mock.Anything, productID, promotion.Profile{Category: "mince", Species: "beef"}).Return(nil)
+	admins, actorID := actor(t, admin.RoleManager)
+	handler := commands.NewClassifyProductHandler(repo, admins)
+
+	profile, err := handler.Handle(context.Background(), commands.ClassifyProductCommand{
+		ProductID: productID, Category: "Mince", Species: "Beef", ActorID: actorID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, promotion.Profile{Category: "mince", Species: "beef"}, profile)
+
+	_, err = handler.Handle(context.Background(), commands.ClassifyProductCommand{ProductID: productID, Species: "pork", ActorID: actorID})
+	assert.ErrorIs(t, err, carcass.ErrInvalidSpecies)
+	repo.AssertExpectations(t)
+}
+
+func TestClassifyProduct_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	repo := new(mockPromotionProductRepository)
+	admins, actorID := actor(t, admin.RoleCashier)
+
+	_, err := commands.NewClassifyProductHandler(repo, admins).Handle(context.Background(), commands.ClassifyProductCommand{
+		ProductID: uuid.New(), Category: "mince", ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, promotion.ErrManageNotAllowed)
+	repo.AssertNotCalled(t, "Classify", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// manager checks the admin making the change may manage pricing.
+func manager(ctx context.Context, adminRepo admin.Repository, actorID uuid.UUID) error {
+	actor, err := adminRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !actor.HasPermission(admin.PermissionManagePricing) {
+		return promotion.ErrManageNotAllowed
+	}
+	return nil
+}
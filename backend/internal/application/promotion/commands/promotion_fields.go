@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// PromotionFields describe a promotion. Which of the amounts are used
+// depends on Kind. StartsAt and EndsAt are RFC 3339 times and may be empty
+// for open-ended; Code may be empty for a promotion that needs no coupon.
+type PromotionFields struct {
+	Name               string
+	Code               string
+	Kind               string
+	PercentBP          int
+	AmountCents        int64
+	BuyGrams           int64
+	FreeGrams          int64
+	MinSubtotalCents   int64
+	ProductIDs         []uuid.UUID
+	Categories         []string
+	Species            []string
+	Segments           []string
+	StartsAt           string
+	EndsAt             string
+	MaxUses            int
+	MaxUsesPerCustomer int
+	Priority           int
+	Stacking           string
+}
+
+func (f PromotionFields) terms() (promotion.Terms, error) {
+	kind, err := promotion.NewKind(f.Kind)
+	if err != nil {
+		return promotion.Terms{}, err
+	}
+	var stacking promotion.Stacking
+	if strings.TrimSpace(f.Stacking) != "" {
+		if stacking, err = promotion.NewStacking(f.Stacking); err != nil {
+			return promotion.Terms{}, err
+		}
+	}
+	startsAt, err := parseTime(f.StartsAt)
+	if err != nil {
+		return promotion.Terms{}, err
+	}
+	endsAt, err := parseTime(f.EndsAt)
+	if err != nil {
+		return promotion.Terms{}, err
+	}
+	for _, s := range f.Species {
+		if _, err := carcass.NewSpecies(strings.ToLower(strings.TrimSpace(s))); err != nil {
+			return promotion.Terms{}, err
+		}
+	}
+	for _, s := range f.Segments {
+		if !slices.Contains([]string{pricing.SegmentNew, pricing.SegmentReturning}, strings.ToLower(strings.TrimSpace(s))) {
+			return promotion.Terms{}, promotion.ErrUnknownSegment
+		}
+	}
+
+	return promotion.Terms{
+		Name: f.Name,
+		Code: f.Code,
+		Rule: promotion.Rule{
+			Kind:             kind,
+			PercentBP:        f.PercentBP,
+			AmountCents:      f.AmountCents,
+			BuyGrams:         f.BuyGrams,
+			FreeGrams:        f.FreeGrams,
+			MinSubtotalCents: f.MinSubtotalCents,
+		},
+		Scope: promotion.Scope{
+			ProductIDs: f.ProductIDs,
+			Categories: f.Categories,
+			Species:    f.Species,
+			Segments:   f.Segments,
+		},
+		StartsAt:           startsAt,
+		EndsAt:             endsAt,
+		MaxUses:            f.MaxUses,
+		MaxUsesPerCustomer: f.MaxUsesPerCustomer,
+		Priority:           f.Priority,
+		Stacking:           stacking,
+	}, nil
+}
+
+func parseTime(raw string) (*time.Time, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(raw))
+	if err != nil {
+		return nil, promotion.ErrInvalidTime
+	}
+	return &t, nil
+}
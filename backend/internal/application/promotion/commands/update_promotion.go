@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// UpdatePromotionCommand is the input for the update promotion use case.
+type UpdatePromotionCommand struct {
+	PromotionID uuid.UUID
+	Fields      PromotionFields
+	Active      bool
+	ActorID     uuid.UUID
+}
+
+// UpdatePromotionHandler changes a promotion's terms or switches it on and
+// off. Redemptions so far still count towards its limits.
+type UpdatePromotionHandler struct {
+	promotionRepo promotion.Repository
+	adminRepo     admin.Repository
+}
+
+// NewUpdatePromotionHandler creates a new UpdatePromotionHandler.
+func NewUpdatePromotionHandler(promotionRepo promotion.Repository, adminRepo admin.Repository) *UpdatePromotionHandler {
+	return &UpdatePromotionHandler{promotionRepo: promotionRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the update promotion use case. Only managers may change
+// promotions.
+func (h *UpdatePromotionHandler) Handle(ctx context.Context, cmd UpdatePromotionCommand) (*promotion.Promotion, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	terms, err := cmd.Fields.terms()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := h.promotionRepo.FindByID(ctx, cmd.PromotionID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := p.Update(terms, now); err != nil {
+		return nil, err
+	}
+	p.SetActive(cmd.Active, now)
+
+	if err := h.promotionRepo.Save(ctx, p); err != nil {
+		if errors.Is(err, promotion.ErrDuplicateCode) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving promotion: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// ListPromotionsHandler returns promotions for the back office.
+type ListPromotionsHandler struct {
+	promotionRepo promotion.Repository
+}
+
+// NewListPromotionsHandler creates a new ListPromotionsHandler.
+func NewListPromotionsHandler(promotionRepo promotion.Repository) *ListPromotionsHandler {
+	return &ListPromotionsHandler{promotionRepo: promotionRepo}
+}
+
+// Handle returns every promotion, including inactive and finished ones,
+// newest first.
+func (h *ListPromotionsHandler) Handle(ctx context.Context) ([]*promotion.Promotion, error) {
+	promotions, err := h.promotionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("finding promotions: %w", err)
+	}
+	return promotions, nil
+}
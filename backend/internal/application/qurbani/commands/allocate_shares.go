@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// AllocateSharesHandler groups an offering's new bookings into animals.
+type AllocateSharesHandler struct {
+	offeringRepo  qurbani.OfferingRepository
+	bookingRepo   qurbani.BookingRepository
+	slaughterRepo qurbani.SlaughterRepository
+}
+
+// NewAllocateSharesHandler creates a new AllocateSharesHandler.
+func NewAllocateSharesHandler(offeringRepo qurbani.OfferingRepository, bookingRepo qurbani.BookingRepository, slaughterRepo qurbani.SlaughterRepository) *AllocateSharesHandler {
+	return &AllocateSharesHandler{offeringRepo: offeringRepo, bookingRepo: bookingRepo, slaughterRepo: slaughterRepo}
+}
+
+// Handle places every unallocated booking of the offering on an animal.
+// Running it again only places bookings made since.
+func (h *AllocateSharesHandler) Handle(ctx context.Context, offeringID uuid.UUID) (*qurbani.Allocation, error) {
+	o, err := h.offeringRepo.FindByID(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	animals, err := h.slaughterRepo.FindAnimals(ctx, qurbani.AnimalFilter{OfferingID: &offeringID})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani animals: %w", err)
+	}
+	bookings, err := h.bookingRepo.FindAll(ctx, qurbani.BookingFilter{OfferingID: &offeringID})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani bookings: %w", err)
+	}
+
+	alloc, err := qurbani.Allocate(o, animals, bookings, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(alloc.Bookings) == 0 {
+		return alloc, nil
+	}
+	if err := h.slaughterRepo.SaveAllocation(ctx, alloc); err != nil {
+		if errors.Is(err, qurbani.ErrAllocationConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving qurbani allocation: %w", err)
+	}
+	return alloc, nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// BookShareCommand is the input for the book qurbani share use case.
+// AddressID is one of the customer's addresses and is required for delivery.
+type BookShareCommand struct {
+	CustomerID   uuid.UUID
+	OfferingID   uuid.UUID
+	NiyyahName   string
+	Distribution string
+	AddressID    *uuid.UUID
+}
+
+// BookShareHandler books one share of a sacrifice for a customer.
+type BookShareHandler struct {
+	offeringRepo qurbani.OfferingRepository
+	bookingRepo  qurbani.BookingRepository
+	addressRepo  customer.AddressRepository
+}
+
+// NewBookShareHandler creates a new BookShareHandler.
+func NewBookShareHandler(offeringRepo qurbani.OfferingRepository, bookingRepo qurbani.BookingRepository, addressRepo customer.AddressRepository) *BookShareHandler {
+	return &BookShareHandler{offeringRepo: offeringRepo, bookingRepo: bookingRepo, addressRepo: addressRepo}
+}
+
+// Handle executes the book qurbani share use case.
+func (h *BookShareHandler) Handle(ctx context.Context, cmd BookShareCommand) (*qurbani.Booking, error) {
+	distribution, err := qurbani.NewDistribution(cmd.Distribution)
+	if err != nil {
+		return nil, err
+	}
+	o, err := h.offeringRepo.FindByID(ctx, cmd.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := qurbani.NewBooking(uuid.New(), o, cmd.CustomerID, cmd.NiyyahName, distribution, cmd.AddressID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if b.AddressID() != nil {
+		address, err := h.addressRepo.FindByID(ctx, *b.AddressID())
+		if err != nil {
+			return nil, err
+		}
+		if !address.BelongsTo(cmd.CustomerID) {
+			return nil, customer.ErrAddressNotFound
+		}
+	}
+
+	if err := h.bookingRepo.Book(ctx, o, b); err != nil {
+		if errors.Is(err, qurbani.ErrSoldOut) || errors.Is(err, qurbani.ErrOfferingClosed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("booking qurbani share: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,239 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/qurbani/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockOfferingRepository struct {
+	mock.Mock
+}
+
+func (m *mockOfferingRepository) Save(ctx context.Context, o *qurbani.Offering) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOfferingRepository) Update(ctx context.Context, o *qurbani.Offering) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOfferingRepository) FindByID(ctx context.Context, id uuid.UUID) (*qurbani.Offering, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*qurbani.Offering), args.Error(1)
+}
+
+func (m *mockOfferingRepository) FindAll(ctx context.Context, season string) ([]*qurbani.Offering, error) {
+	args := m.Called(ctx, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Offering), args.Error(1)
+}
+
+type mockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *mockBookingRepository) Book(ctx context.Context, o *qurbani.Offering, b *qurbani.Booking) error {
+	args := m.Called(ctx, o, b)
+	return args.Error(0)
+}
+
+func (m *mockBookingRepository) Update(ctx context.Context, b *qurbani.Booking) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *mockBookingRepository) FindByID(ctx context.Context, id uuid.UUID) (*qurbani.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*qurbani.Booking), args.Error(1)
+}
+
+func (m *mockBookingRepository) FindAll(ctx context.Context, filter qurbani.BookingFilter) ([]*qurbani.Booking, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Booking), args.Error(1)
+}
+
+func (m *mockBookingRepository) CountActive(ctx context.Context, offeringID uuid.UUID) (int, error) {
+	args := m.Called(ctx, offeringID)
+	return args.Int(0), args.Error(1)
+}
+
+type mockSlaughterRepository struct {
+	mock.Mock
+}
+
+func (m *mockSlaughterRepository) SaveAllocation(ctx context.Context, a *qurbani.Allocation) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockSlaughterRepository) FindAnimals(ctx context.Context, filter qurbani.AnimalFilter) ([]*qurbani.Animal, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Animal), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) SaveBatches(ctx context.Context, batches []*qurbani.Batch, animals []*qurbani.Animal) error {
+	args := m.Called(ctx, batches, animals)
+	return args.Error(0)
+}
+
+func (m *mockSlaughterRepository) FindBatchByID(ctx context.Context, id uuid.UUID) (*qurbani.Batch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*qurbani.Batch), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) FindBatches(ctx context.Context, season string) ([]*qurbani.Batch, error) {
+	args := m.Called(ctx, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Batch), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) LastBatchNumber(ctx context.Context, season string) (int, error) {
+	args := m.Called(ctx, season)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) CompleteBatch(ctx context.Context, b *qurbani.Batch, animals []*qurbani.Animal, bookings []*qurbani.Booking) error {
+	args := m.Called(ctx, b, animals, bookings)
+	return args.Error(0)
+}
+
+type mockAddressRepository struct {
+	mock.Mock
+}
+
+func (m *mockAddressRepository) Save(ctx context.Context, a *customer.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*customer.Address), args.Error(1)
+}
+
+func (m *mockAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockAddressRepository) SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error {
+	args := m.Called(ctx, customerID, addressID)
+	return args.Error(0)
+}
+
+func newOffering(t *testing.T, shareType qurbani.ShareType, animals int) *qurbani.Offering {
+	t.Helper()
+	o, err := qurbani.NewOffering(uuid.New(), "2027", shareType, 22000, animals, time.Now())
+	require.NoError(t, err)
+	return o
+}
+
+func newAddress(t *testing.T, customerID uuid.UUID) *customer.Address {
+	t.Helper()
+	postcode, err := customer.NewPostcode("E1 6AN")
+	require.NoError(t, err)
+	a, err := customer.NewAddress(uuid.New(), customerID, "Home", "1 Brick Lane", "", "London", postcode, nil)
+	require.NoError(t, err)
+	return a
+}
+
+// --- Tests ---
+
+func TestBookShareHandler_Delivery_ChecksAddressOwner(t *testing.T) {
+	offerings := new(mockOfferingRepository)
+	bookings := new(mockBookingRepository)
+	addresses := new(mockAddressRepository)
+	handler := commands.NewBookShareHandler(offerings, bookings, addresses)
+
+	o := newOffering(t, qurbani.ShareWholeSheep, 10)
+	customerID := uuid.New()
+	own, other := newAddress(t, customerID), newAddress(t, uuid.New())
+	offerings.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	addresses.On("FindByID", mock.Anything, own.ID()).Return(own, nil)
+	addresses.On("FindByID", mock.Anything, other.ID()).Return(other, nil)
+	bookings.On("Book", mock.Anything, o, mock.Anything).Return(nil).Once()
+
+	_, err := handler.Handle(context.Background(), commands.BookShareCommand{
+		CustomerID: customerID, OfferingID: o.ID(), NiyyahName: "Bilal", Distribution: "delivery", AddressID: ptr(other.ID()),
+	})
+	assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+
+	b, err := handler.Handle(context.Background(), commands.BookShareCommand{
+		CustomerID: customerID, OfferingID: o.ID(), NiyyahName: "Bilal", Distribution: "delivery", AddressID: ptr(own.ID()),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, qurbani.DistributionDelivery, b.Distribution())
+	assert.Equal(t, int64(22000), b.PriceCents())
+	bookings.AssertExpectations(t)
+}
+
+func TestBookShareHandler_SoldOut(t *testing.T) {
+	offerings := new(mockOfferingRepository)
+	bookings := new(mockBookingRepository)
+	handler := commands.NewBookShareHandler(offerings, bookings, new(mockAddressRepository))
+
+	o := newOffering(t, qurbani.ShareCow, 1)
+	offerings.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	bookings.On("Book", mock.Anything, o, mock.Anything).Return(qurbani.ErrSoldOut)
+
+	_, err := handler.Handle(context.Background(), commands.BookShareCommand{
+		CustomerID: uuid.New(), OfferingID: o.ID(), NiyyahName: "Bilal", Distribution: "charity",
+	})
+
+	assert.ErrorIs(t, err, qurbani.ErrSoldOut)
+}
+
+func TestBookShareHandler_InvalidDistribution(t *testing.T) {
+	handler := commands.NewBookShareHandler(new(mockOfferingRepository), new(mockBookingRepository), new(mockAddressRepository))
+
+	_, err := handler.Handle(context.Background(), commands.BookShareCommand{
+		CustomerID: uuid.New(), OfferingID: uuid.New(), NiyyahName: "Bilal", Distribution: "post",
+	})
+
+	assert.ErrorIs(t, err, qurbani.ErrInvalidDistribution)
+}
+
+func ptr[T any](v T) *T { return &v }
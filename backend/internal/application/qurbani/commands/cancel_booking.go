@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// CancelBookingCommand is the input for the cancel qurbani booking use case.
+// CustomerID is set when customers cancel their own booking, and nil when
+// staff cancel on their behalf.
+type CancelBookingCommand struct {
+	BookingID  uuid.UUID
+	CustomerID *uuid.UUID
+}
+
+// CancelBookingHandler withdraws a booking before the sacrifice.
+type CancelBookingHandler struct {
+	bookingRepo qurbani.BookingRepository
+}
+
+// NewCancelBookingHandler creates a new CancelBookingHandler.
+func NewCancelBookingHandler(bookingRepo qurbani.BookingRepository) *CancelBookingHandler {
+	return &CancelBookingHandler{bookingRepo: bookingRepo}
+}
+
+// Handle executes the cancel qurbani booking use case.
+func (h *CancelBookingHandler) Handle(ctx context.Context, cmd CancelBookingCommand) (*qurbani.Booking, error) {
+	b, err := h.bookingRepo.FindByID(ctx, cmd.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.CustomerID != nil && b.CustomerID() != *cmd.CustomerID {
+		return nil, qurbani.ErrBookingNotFound
+	}
+
+	if err := b.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.bookingRepo.Update(ctx, b); err != nil {
+		return nil, fmt.Errorf("updating qurbani booking: %w", err)
+	}
+	return b, nil
+}
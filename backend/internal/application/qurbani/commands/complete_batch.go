@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// CompleteBatchResult is the output of the complete slaughter batch use case.
+type CompleteBatchResult struct {
+	Batch       *qurbani.Batch
+	Slaughtered int
+	NotifyResult
+}
+
+// CompleteBatchHandler records that a batch has been slaughtered and tells
+// each customer their sacrifice is done.
+type CompleteBatchHandler struct {
+	slaughterRepo qurbani.SlaughterRepository
+	notifier      sacrificeNotifier
+}
+
+// NewCompleteBatchHandler creates a new CompleteBatchHandler.
+func NewCompleteBatchHandler(
+	slaughterRepo qurbani.SlaughterRepository,
+	offeringRepo qurbani.OfferingRepository,
+	bookingRepo qurbani.BookingRepository,
+	customerRepo customer.Repository,
+	sender qurbani.Notifier,
+) *CompleteBatchHandler {
+	return &CompleteBatchHandler{
+		slaughterRepo: slaughterRepo,
+		notifier: sacrificeNotifier{
+			offeringRepo: offeringRepo,
+			bookingRepo:  bookingRepo,
+			customerRepo: customerRepo,
+			sender:       sender,
+		},
+	}
+}
+
+// Handle executes the complete slaughter batch use case. The batch is
+// recorded as slaughtered even if some customers cannot be notified; they
+// can be notified again with NotifyBatchHandler.
+func (h *CompleteBatchHandler) Handle(ctx context.Context, batchID uuid.UUID) (*CompleteBatchResult, error) {
+	batch, err := h.slaughterRepo.FindBatchByID(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	animals, err := h.slaughterRepo.FindAnimals(ctx, qurbani.AnimalFilter{BatchID: &batchID})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani animals: %w", err)
+	}
+	bookings, err := h.notifier.bookingRepo.FindAll(ctx, qurbani.BookingFilter{BatchID: &batchID})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani bookings: %w", err)
+	}
+
+	done, err := batch.Complete(animals, bookings, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.slaughterRepo.CompleteBatch(ctx, batch, animals, done); err != nil {
+		if errors.Is(err, qurbani.ErrBatchAlreadyCompleted) || errors.Is(err, qurbani.ErrAllocationConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("completing slaughter batch: %w", err)
+	}
+
+	notified, err := h.notifier.notify(ctx, animals, done)
+	if err != nil {
+		return nil, err
+	}
+	return &CompleteBatchResult{Batch: batch, Slaughtered: len(done), NotifyResult: *notified}, nil
+}
@@ -0,0 +1,138 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/qurbani/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *mockCustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCustomerRepository) FindByEmail(ctx context.Context, email customer.Email) (*customer.Customer, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) ExistsByEmail(ctx context.Context, email customer.Email) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockNotifier struct {
+	mock.Mock
+}
+
+func (m *mockNotifier) SacrificeDone(ctx context.Context, n qurbani.Notice) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+func newCustomer(t *testing.T, email string) *customer.Customer {
+	t.Helper()
+	e, err := customer.NewEmail(email)
+	require.NoError(t, err)
+	phone, err := customer.NewPhoneNumber("+447700900123")
+	require.NoError(t, err)
+	c, err := customer.NewCustomer(uuid.New(), e, "$2a$10$hash", "Qurbani Customer", phone)
+	require.NoError(t, err)
+	return c
+}
+
+// --- Tests ---
+
+func TestCompleteBatchHandler_NotifiesCustomers(t *testing.T) {
+	offerings := new(mockOfferingRepository)
+	bookings := new(mockBookingRepository)
+	slaughter := new(mockSlaughterRepository)
+	customers := new(mockCustomerRepository)
+	sender := new(mockNotifier)
+	now := time.Now()
+
+	o := newOffering(t, qurbani.ShareCow, 1)
+	reached, unreachable := newCustomer(t, "reached@example.com"), newCustomer(t, "unreachable@example.com")
+	var booked []*qurbani.Booking
+	for _, c := range []*customer.Customer{reached, unreachable} {
+		b, err := qurbani.NewBooking(uuid.New(), o, c.ID(), "Niyyah for "+c.FullName(), qurbani.DistributionCharity, nil, now)
+		require.NoError(t, err)
+		booked = append(booked, b)
+	}
+	alloc, err := qurbani.Allocate(o, nil, booked, now)
+	require.NoError(t, err)
+	batches, err := qurbani.PlanBatches("2027", alloc.Animals, 0, now, 1, 0, now)
+	require.NoError(t, err)
+	batch := batches[0]
+
+	slaughter.On("FindBatchByID", mock.Anything, batch.ID()).Return(batch, nil)
+	slaughter.On("FindAnimals", mock.Anything, qurbani.AnimalFilter{BatchID: ptr(batch.ID())}).Return(alloc.Animals, nil)
+	bookings.On("FindAll", mock.Anything, qurbani.BookingFilter{BatchID: ptr(batch.ID())}).Return(booked, nil)
+	slaughter.On("CompleteBatch", mock.Anything, batch, alloc.Animals, booked).Return(nil)
+	offerings.On("FindByID", mock.Anything, o.ID()).Return(o, nil)
+	customers.On("FindByID", mock.Anything, reached.ID()).Return(reached, nil)
+	customers.On("FindByID", mock.Anything, unreachable.ID()).Return(unreachable, nil)
+	sender.On("SacrificeDone", mock.Anything, mock.MatchedBy(func(n qurbani.Notice) bool {
+		return n.Email == "reached@example.com" && n.AnimalNumber == 1 && n.ShareType == qurbani.ShareCow
+	})).Return(nil)
+	sender.On("SacrificeDone", mock.Anything, mock.MatchedBy(func(n qurbani.Notice) bool {
+		return n.Email == "unreachable@example.com"
+	})).Return(errors.New("mailbox full")).Once()
+	bookings.On("Update", mock.Anything, booked[0]).Return(nil).Once()
+
+	handler := commands.NewCompleteBatchHandler(slaughter, offerings, bookings, customers, sender)
+	result, err := handler.Handle(context.Background(), batch.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Slaughtered)
+	assert.Equal(t, 1, result.Notified)
+	assert.Equal(t, 1, result.Failed)
+	assert.NotNil(t, booked[0].NotifiedAt())
+	assert.Nil(t, booked[1].NotifiedAt())
+	bookings.AssertExpectations(t)
+
+	t.Run("the unreached customer is notified on retry", func(t *testing.T) {
+		status := qurbani.BookingSlaughtered
+		bookings.On("FindAll", mock.Anything, qurbani.BookingFilter{BatchID: ptr(batch.ID()), Status: &status}).Return(booked, nil)
+		sender.On("SacrificeDone", mock.Anything, mock.Anything).Return(nil)
+		bookings.On("Update", mock.Anything, booked[1]).Return(nil).Once()
+
+		retry := commands.NewNotifyBatchHandler(slaughter, offerings, bookings, customers, sender)
+		result, err := retry.Handle(context.Background(), batch.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, &commands.NotifyResult{Notified: 1}, result)
+		assert.NotNil(t, booked[1].NotifiedAt())
+	})
+
+	t.Run("a completed batch cannot be completed again", func(t *testing.T) {
+		_, err := handler.Handle(context.Background(), batch.ID())
+		assert.ErrorIs(t, err, qurbani.ErrBatchAlreadyCompleted)
+	})
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// CreateOfferingCommand is the input for the create qurbani offering use case.
+type CreateOfferingCommand struct {
+	Season     string
+	ShareType  string
+	PriceCents int64
+	Animals    int
+}
+
+// CreateOfferingHandler opens a share type for booking in a season.
+type CreateOfferingHandler struct {
+	offeringRepo qurbani.OfferingRepository
+}
+
+// NewCreateOfferingHandler creates a new CreateOfferingHandler.
+func NewCreateOfferingHandler(offeringRepo qurbani.OfferingRepository) *CreateOfferingHandler {
+	return &CreateOfferingHandler{offeringRepo: offeringRepo}
+}
+
+// Handle executes the create qurbani offering use case.
+func (h *CreateOfferingHandler) Handle(ctx context.Context, cmd CreateOfferingCommand) (*qurbani.Offering, error) {
+	shareType, err := qurbani.NewShareType(cmd.ShareType)
+	if err != nil {
+		return nil, err
+	}
+	o, err := qurbani.NewOffering(uuid.New(), cmd.Season, shareType, cmd.PriceCents, cmd.Animals, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.offeringRepo.Save(ctx, o); err != nil {
+		if errors.Is(err, qurbani.ErrDuplicateOffering) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving qurbani offering: %w", err)
+	}
+	return o, nil
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// NotifyResult counts the customers told their sacrifice is done, and those
+// who could not be reached and are left for the next attempt.
+type NotifyResult struct {
+	Notified int
+	Failed   int
+}
+
+// sacrificeNotifier tells customers their sacrifice is done and records it
+// on the booking, so each customer is told once.
+type sacrificeNotifier struct {
+	offeringRepo qurbani.OfferingRepository
+	bookingRepo  qurbani.BookingRepository
+	customerRepo customer.Repository
+	sender       qurbani.Notifier
+}
+
+// notify sends a notice for each slaughtered booking not yet notified.
+// animals are the animals the bookings were sacrificed on.
+func (n sacrificeNotifier) notify(ctx context.Context, animals []*qurbani.Animal, bookings []*qurbani.Booking) (*NotifyResult, error) {
+	numbers := make(map[uuid.UUID]int, len(animals))
+	for _, a := range animals {
+		numbers[a.ID()] = a.Number()
+	}
+	offerings := make(map[uuid.UUID]*qurbani.Offering)
+	customers := make(map[uuid.UUID]*customer.Customer)
+
+	result := &NotifyResult{}
+	for _, b := range bookings {
+		if b.Status() != qurbani.BookingSlaughtered || b.NotifiedAt() != nil {
+			continue
+		}
+		o, ok := offerings[b.OfferingID()]
+		if !ok {
+			var err error
+			if o, err = n.offeringRepo.FindByID(ctx, b.OfferingID()); err != nil {
+				return nil, fmt.Errorf("loading qurbani offering: %w", err)
+			}
+			offerings[o.ID()] = o
+		}
+		c, ok := customers[b.CustomerID()]
+		if !ok {
+			var err error
+			if c, err = n.customerRepo.FindByID(ctx, b.CustomerID()); err != nil {
+				return nil, fmt.Errorf("loading customer: %w", err)
+			}
+			customers[c.ID()] = c
+		}
+
+		notice := qurbani.Notice{
+			BookingID:     b.ID(),
+			CustomerID:    c.ID(),
+			Email:         c.Email().String(),
+			FullName:      c.FullName(),
+			NiyyahName:    b.NiyyahName(),
+			ShareType:     o.ShareType(),
+			Distribution:  b.Distribution(),
+			SlaughteredAt: *b.SlaughteredAt(),
+		}
+		if b.AnimalID() != nil {
+			notice.AnimalNumber = numbers[*b.AnimalID()]
+		}
+		if err := n.sender.SacrificeDone(ctx, notice); err != nil {
+			result.Failed++
+			continue
+		}
+
+		if err := b.MarkNotified(time.Now()); err != nil {
+			return nil, err
+		}
+		if err := n.bookingRepo.Update(ctx, b); err != nil {
+			return nil, fmt.Errorf("updating qurbani booking: %w", err)
+		}
+		result.Notified++
+	}
+	return result, nil
+}
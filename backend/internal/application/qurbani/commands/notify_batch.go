@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// NotifyBatchHandler retries notifying the customers of a completed batch
+// who have not been told their sacrifice is done.
+type NotifyBatchHandler struct {
+	slaughterRepo qurbani.SlaughterRepository
+	notifier      sacrificeNotifier
+}
+
+// NewNotifyBatchHandler creates a new NotifyBatchHandler.
+func NewNotifyBatchHandler(
+	slaughterRepo qurbani.SlaughterRepository,
+	offeringRepo qurbani.OfferingRepository,
+	bookingRepo qurbani.BookingRepository,
+	customerRepo customer.Repository,
+	sender qurbani.Notifier,
+) *NotifyBatchHandler {
+	return &NotifyBatchHandler{
+		slaughterRepo: slaughterRepo,
+		notifier: sacrificeNotifier{
+			offeringRepo: offeringRepo,
+			bookingRepo:  bookingRepo,
+			customerRepo: customerRepo,
+			sender:       sender,
+		},
+	}
+}
+
+// Handle executes the notify slaughter batch use case.
+func (h *NotifyBatchHandler) Handle(ctx context.Context, batchID uuid.UUID) (*NotifyResult, error) {
+	batch, err := h.slaughterRepo.FindBatchByID(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.CompletedAt() == nil {
+		return nil, qurbani.ErrBatchNotCompleted
+	}
+	animals, err := h.slaughterRepo.FindAnimals(ctx, qurbani.AnimalFilter{BatchID: &batchID})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani animals: %w", err)
+	}
+	status := qurbani.BookingSlaughtered
+	bookings, err := h.notifier.bookingRepo.FindAll(ctx, qurbani.BookingFilter{BatchID: &batchID, Status: &status})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani bookings: %w", err)
+	}
+	return h.notifier.notify(ctx, animals, bookings)
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// PlanBatchesCommand is the input for the plan slaughter batches use case.
+type PlanBatchesCommand struct {
+	Season          string
+	StartsAt        time.Time
+	AnimalsPerBatch int
+	IntervalMinutes int
+}
+
+// PlanBatchesHandler schedules the animals that have shares allocated to
+// them into slaughter batches.
+type PlanBatchesHandler struct {
+	slaughterRepo qurbani.SlaughterRepository
+}
+
+// NewPlanBatchesHandler creates a new PlanBatchesHandler.
+func NewPlanBatchesHandler(slaughterRepo qurbani.SlaughterRepository) *PlanBatchesHandler {
+	return &PlanBatchesHandler{slaughterRepo: slaughterRepo}
+}
+
+// Handle puts every animal of the season not yet in a batch into one,
+// numbering on from the season's last batch.
+func (h *PlanBatchesHandler) Handle(ctx context.Context, cmd PlanBatchesCommand) ([]*qurbani.Batch, error) {
+	waiting, err := h.slaughterRepo.FindAnimals(ctx, qurbani.AnimalFilter{Season: cmd.Season, Waiting: true})
+	if err != nil {
+		return nil, fmt.Errorf("loading qurbani animals: %w", err)
+	}
+	last, err := h.slaughterRepo.LastBatchNumber(ctx, cmd.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(cmd.IntervalMinutes) * time.Minute
+	batches, err := qurbani.PlanBatches(cmd.Season, waiting, last, cmd.StartsAt, cmd.AnimalsPerBatch, interval, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.slaughterRepo.SaveBatches(ctx, batches, waiting); err != nil {
+		if errors.Is(err, qurbani.ErrAllocationConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving slaughter batches: %w", err)
+	}
+	return batches, nil
+}
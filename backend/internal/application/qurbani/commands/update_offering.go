@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// UpdateOfferingCommand is the input for the update qurbani offering use case.
+type UpdateOfferingCommand struct {
+	OfferingID uuid.UUID
+	PriceCents int64
+	Animals    int
+	Active     bool
+}
+
+// UpdateOfferingHandler changes an offering's price and animals, and opens
+// or closes it for booking.
+type UpdateOfferingHandler struct {
+	offeringRepo qurbani.OfferingRepository
+	bookingRepo  qurbani.BookingRepository
+}
+
+// NewUpdateOfferingHandler creates a new UpdateOfferingHandler.
+func NewUpdateOfferingHandler(offeringRepo qurbani.OfferingRepository, bookingRepo qurbani.BookingRepository) *UpdateOfferingHandler {
+	return &UpdateOfferingHandler{offeringRepo: offeringRepo, bookingRepo: bookingRepo}
+}
+
+// Handle executes the update qurbani offering use case.
+func (h *UpdateOfferingHandler) Handle(ctx context.Context, cmd UpdateOfferingCommand) (*qurbani.Offering, error) {
+	o, err := h.offeringRepo.FindByID(ctx, cmd.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+	booked, err := h.bookingRepo.CountActive(ctx, o.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.Update(cmd.PriceCents, cmd.Animals, cmd.Active, booked, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.offeringRepo.Update(ctx, o); err != nil {
+		return nil, fmt.Errorf("updating qurbani offering: %w", err)
+	}
+	return o, nil
+}
@@ -0,0 +1,153 @@
+package queries
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// DistributionListQuery is the input for the distribution list use case.
+// Distribution defaults to charity.
+type DistributionListQuery struct {
+	Season       string
+	Distribution string
+	BatchID      *uuid.UUID
+}
+
+// DistributionLine is one share's meat to hand out. Customer is who to
+// contact about it; Address is set for deliveries to an address that still
+// exists.
+type DistributionLine struct {
+	Booking      *qurbani.Booking
+	ShareType    qurbani.ShareType
+	AnimalNumber int
+	BatchNumber  int
+	Customer     *customer.Customer
+	Address      *customer.Address
+}
+
+// DistributionListHandler lists where the meat of a season's sacrifices goes.
+type DistributionListHandler struct {
+	offeringRepo  qurbani.OfferingRepository
+	bookingRepo   qurbani.BookingRepository
+	slaughterRepo qurbani.SlaughterRepository
+	customerRepo  customer.Repository
+	addressRepo   customer.AddressRepository
+}
+
+// NewDistributionListHandler creates a new DistributionListHandler.
+func NewDistributionListHandler(
+	offeringRepo qurbani.OfferingRepository,
+	bookingRepo qurbani.BookingRepository,
+	slaughterRepo qurbani.SlaughterRepository,
+	customerRepo customer.Repository,
+	addressRepo customer.AddressRepository,
+) *DistributionListHandler {
+	return &DistributionListHandler{
+		offeringRepo:  offeringRepo,
+		bookingRepo:   bookingRepo,
+		slaughterRepo: slaughterRepo,
+		customerRepo:  customerRepo,
+		addressRepo:   addressRepo,
+	}
+}
+
+// Handle returns the shares going one way, by batch and animal, leaving out
+// cancelled bookings. Shares not yet on an animal come last.
+func (h *DistributionListHandler) Handle(ctx context.Context, q DistributionListQuery) ([]DistributionLine, error) {
+	raw := q.Distribution
+	if raw == "" {
+		raw = string(qurbani.DistributionCharity)
+	}
+	distribution, err := qurbani.NewDistribution(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	offerings, err := h.offeringRepo.FindAll(ctx, q.Season)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani offerings: %w", err)
+	}
+	animals, err := h.slaughterRepo.FindAnimals(ctx, qurbani.AnimalFilter{Season: q.Season, BatchID: q.BatchID})
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani animals: %w", err)
+	}
+	batches, err := h.slaughterRepo.FindBatches(ctx, q.Season)
+	if err != nil {
+		return nil, fmt.Errorf("querying slaughter batches: %w", err)
+	}
+	bookings, err := h.bookingRepo.FindAll(ctx, qurbani.BookingFilter{Season: q.Season, BatchID: q.BatchID, Distribution: &distribution})
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani bookings: %w", err)
+	}
+
+	shareTypes := make(map[uuid.UUID]qurbani.ShareType, len(offerings))
+	for _, o := range offerings {
+		shareTypes[o.ID()] = o.ShareType()
+	}
+	batchNumbers := make(map[uuid.UUID]int, len(batches))
+	for _, b := range batches {
+		batchNumbers[b.ID()] = b.Number()
+	}
+	byAnimal := make(map[uuid.UUID]*qurbani.Animal, len(animals))
+	for _, a := range animals {
+		byAnimal[a.ID()] = a
+	}
+	customers := make(map[uuid.UUID]*customer.Customer)
+
+	lines := make([]DistributionLine, 0, len(bookings))
+	for _, b := range bookings {
+		if b.Status() == qurbani.BookingCancelled {
+			continue
+		}
+		line := DistributionLine{Booking: b, ShareType: shareTypes[b.OfferingID()]}
+		if b.AnimalID() != nil {
+			if a := byAnimal[*b.AnimalID()]; a != nil {
+				line.AnimalNumber = a.Number()
+				if a.BatchID() != nil {
+					line.BatchNumber = batchNumbers[*a.BatchID()]
+				}
+			}
+		}
+		c, ok := customers[b.CustomerID()]
+		if !ok {
+			if c, err = h.customerRepo.FindByID(ctx, b.CustomerID()); err != nil {
+				return nil, fmt.Errorf("loading customer: %w", err)
+			}
+			customers[c.ID()] = c
+		}
+		line.Customer = c
+		if b.AddressID() != nil {
+			address, err := h.addressRepo.FindByID(ctx, *b.AddressID())
+			if err != nil && !errors.Is(err, customer.ErrAddressNotFound) {
+				return nil, fmt.Errorf("loading address: %w", err)
+			}
+			line.Address = address
+		}
+		lines = append(lines, line)
+	}
+
+	slices.SortStableFunc(lines, func(a, b DistributionLine) int {
+		return cmp.Or(
+			cmp.Compare(orLast(a.BatchNumber), orLast(b.BatchNumber)),
+			cmp.Compare(a.ShareType, b.ShareType),
+			cmp.Compare(orLast(a.AnimalNumber), orLast(b.AnimalNumber)),
+		)
+	})
+	return lines, nil
+}
+
+// orLast sorts an unset number after every set one.
+func orLast(n int) int {
+	if n == 0 {
+		return math.MaxInt
+	}
+	return n
+}
@@ -0,0 +1,223 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/qurbani/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockOfferingRepository struct {
+	mock.Mock
+}
+
+func (m *mockOfferingRepository) Save(ctx context.Context, o *qurbani.Offering) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOfferingRepository) Update(ctx context.Context, o *qurbani.Offering) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOfferingRepository) FindByID(ctx context.Context, id uuid.UUID) (*qurbani.Offering, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*qurbani.Offering), args.Error(1)
+}
+
+func (m *mockOfferingRepository) FindAll(ctx context.Context, season string) ([]*qurbani.Offering, error) {
+	args := m.Called(ctx, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Offering), args.Error(1)
+}
+
+type mockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *mockBookingRepository) Book(ctx context.Context, o *qurbani.Offering, b *qurbani.Booking) error {
+	args := m.Called(ctx, o, b)
+	return args.Error(0)
+}
+
+func (m *mockBookingRepository) Update(ctx context.Context, b *qurbani.Booking) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *mockBookingRepository) FindByID(ctx context.Context, id uuid.UUID) (*qurbani.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*qurbani.Booking), args.Error(1)
+}
+
+func (m *mockBookingRepository) FindAll(ctx context.Context, filter qurbani.BookingFilter) ([]*qurbani.Booking, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Booking), args.Error(1)
+}
+
+func (m *mockBookingRepository) CountActive(ctx context.Context, offeringID uuid.UUID) (int, error) {
+	args := m.Called(ctx, offeringID)
+	return args.Int(0), args.Error(1)
+}
+
+type mockSlaughterRepository struct {
+	mock.Mock
+}
+
+func (m *mockSlaughterRepository) SaveAllocation(ctx context.Context, a *qurbani.Allocation) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockSlaughterRepository) FindAnimals(ctx context.Context, filter qurbani.AnimalFilter) ([]*qurbani.Animal, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Animal), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) SaveBatches(ctx context.Context, batches []*qurbani.Batch, animals []*qurbani.Animal) error {
+	args := m.Called(ctx, batches, animals)
+	return args.Error(0)
+}
+
+func (m *mockSlaughterRepository) FindBatchByID(ctx context.Context, id uuid.UUID) (*qurbani.Batch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*qurbani.Batch), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) FindBatches(ctx context.Context, season string) ([]*qurbani.Batch, error) {
+	args := m.Called(ctx, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*qurbani.Batch), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) LastBatchNumber(ctx context.Context, season string) (int, error) {
+	args := m.Called(ctx, season)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockSlaughterRepository) CompleteBatch(ctx context.Context, b *qurbani.Batch, animals []*qurbani.Animal, bookings []*qurbani.Booking) error {
+	args := m.Called(ctx, b, animals, bookings)
+	return args.Error(0)
+}
+
+type mockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *mockCustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCustomerRepository) FindByEmail(ctx context.Context, email customer.Email) (*customer.Customer, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) ExistsByEmail(ctx context.Context, email customer.Email) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+// --- Tests ---
+
+func TestDistributionListHandler_CharityByBatchAndAnimal(t *testing.T) {
+	offerings := new(mockOfferingRepository)
+	bookings := new(mockBookingRepository)
+	slaughter := new(mockSlaughterRepository)
+	customers := new(mockCustomerRepository)
+	now := time.Now()
+
+	sheep, err := qurbani.NewOffering(uuid.New(), "2027", qurbani.ShareWholeSheep, 22000, 3, now)
+	require.NoError(t, err)
+	email, err := customer.NewEmail("giver@example.com")
+	require.NoError(t, err)
+	phone, err := customer.NewPhoneNumber("+447700900123")
+	require.NoError(t, err)
+	c, err := customer.NewCustomer(uuid.New(), email, "$2a$10$hash", "Charitable Giver", phone)
+	require.NoError(t, err)
+
+	var charity []*qurbani.Booking
+	for i, name := range []string{"First", "Second", "Third"} {
+		b, err := qurbani.NewBooking(uuid.New(), sheep, c.ID(), name, qurbani.DistributionCharity, nil, now.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+		charity = append(charity, b)
+	}
+	alloc, err := qurbani.Allocate(sheep, nil, charity[:2], now)
+	require.NoError(t, err)
+	// The second sheep goes in the first batch and the first in the second.
+	late, err := qurbani.PlanBatches("2027", alloc.Animals[1:], 0, now, 1, 0, now)
+	require.NoError(t, err)
+	early, err := qurbani.PlanBatches("2027", alloc.Animals[:1], 1, now, 1, 0, now)
+	require.NoError(t, err)
+
+	distribution := qurbani.DistributionCharity
+	offerings.On("FindAll", mock.Anything, "2027").Return([]*qurbani.Offering{sheep}, nil)
+	slaughter.On("FindAnimals", mock.Anything, qurbani.AnimalFilter{Season: "2027"}).Return(alloc.Animals, nil)
+	slaughter.On("FindBatches", mock.Anything, "2027").Return(append(late, early...), nil)
+	bookings.On("FindAll", mock.Anything, qurbani.BookingFilter{Season: "2027", Distribution: &distribution}).Return(charity, nil)
+	customers.On("FindByID", mock.Anything, c.ID()).Return(c, nil).Once()
+
+	handler := queries.NewDistributionListHandler(offerings, bookings, slaughter, customers, nil)
+	lines, err := handler.Handle(context.Background(), queries.DistributionListQuery{Season: "2027"})
+
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "Second", lines[0].Booking.NiyyahName())
+	assert.Equal(t, 1, lines[0].BatchNumber)
+	assert.Equal(t, 2, lines[0].AnimalNumber)
+	assert.Equal(t, "First", lines[1].Booking.NiyyahName())
+	assert.Equal(t, 2, lines[1].BatchNumber)
+	assert.Equal(t, "Third", lines[2].Booking.NiyyahName(), "unallocated shares come last")
+	assert.Equal(t, 0, lines[2].AnimalNumber)
+	assert.Equal(t, qurbani.ShareWholeSheep, lines[2].ShareType)
+	customers.AssertExpectations(t)
+}
+
+func TestDistributionListHandler_InvalidDistribution(t *testing.T) {
+	handler := queries.NewDistributionListHandler(nil, nil, nil, nil, nil)
+
+	_, err := handler.Handle(context.Background(), queries.DistributionListQuery{Season: "2027", Distribution: "post"})
+
+	assert.ErrorIs(t, err, qurbani.ErrInvalidDistribution)
+}
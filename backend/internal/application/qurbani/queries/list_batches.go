@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// ListBatchesHandler lists a season's slaughter batches.
+type ListBatchesHandler struct {
+	slaughterRepo qurbani.SlaughterRepository
+}
+
+// NewListBatchesHandler creates a new ListBatchesHandler.
+func NewListBatchesHandler(slaughterRepo qurbani.SlaughterRepository) *ListBatchesHandler {
+	return &ListBatchesHandler{slaughterRepo: slaughterRepo}
+}
+
+// Handle returns the season's batches in order.
+func (h *ListBatchesHandler) Handle(ctx context.Context, season string) ([]*qurbani.Batch, error) {
+	batches, err := h.slaughterRepo.FindBatches(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("querying slaughter batches: %w", err)
+	}
+	return batches, nil
+}
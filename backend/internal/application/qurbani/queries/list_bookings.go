@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// ListBookingsHandler lists qurbani bookings.
+type ListBookingsHandler struct {
+	bookingRepo qurbani.BookingRepository
+}
+
+// NewListBookingsHandler creates a new ListBookingsHandler.
+func NewListBookingsHandler(bookingRepo qurbani.BookingRepository) *ListBookingsHandler {
+	return &ListBookingsHandler{bookingRepo: bookingRepo}
+}
+
+// Handle returns bookings matching filter in the order they were made.
+func (h *ListBookingsHandler) Handle(ctx context.Context, filter qurbani.BookingFilter) ([]*qurbani.Booking, error) {
+	bookings, err := h.bookingRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani bookings: %w", err)
+	}
+	return bookings, nil
+}
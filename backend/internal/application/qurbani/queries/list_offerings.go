@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// OfferingAvailability is an offering with how many of its shares are booked.
+type OfferingAvailability struct {
+	Offering *qurbani.Offering
+	Booked   int
+}
+
+// Remaining is how many shares can still be booked.
+func (a OfferingAvailability) Remaining() int {
+	return max(a.Offering.Capacity()-a.Booked, 0)
+}
+
+// ListOfferingsHandler lists qurbani offerings with their availability.
+type ListOfferingsHandler struct {
+	offeringRepo qurbani.OfferingRepository
+	bookingRepo  qurbani.BookingRepository
+}
+
+// NewListOfferingsHandler creates a new ListOfferingsHandler.
+func NewListOfferingsHandler(offeringRepo qurbani.OfferingRepository, bookingRepo qurbani.BookingRepository) *ListOfferingsHandler {
+	return &ListOfferingsHandler{offeringRepo: offeringRepo, bookingRepo: bookingRepo}
+}
+
+// Handle returns the offerings for a season, or every season when it is
+// empty. activeOnly leaves out offerings closed for booking.
+func (h *ListOfferingsHandler) Handle(ctx context.Context, season string, activeOnly bool) ([]OfferingAvailability, error) {
+	offerings, err := h.offeringRepo.FindAll(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani offerings: %w", err)
+	}
+
+	out := make([]OfferingAvailability, 0, len(offerings))
+	for _, o := range offerings {
+		if activeOnly && !o.Active() {
+			continue
+		}
+		booked, err := h.bookingRepo.CountActive(ctx, o.ID())
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, OfferingAvailability{Offering: o, Booked: booked})
+	}
+	return out, nil
+}
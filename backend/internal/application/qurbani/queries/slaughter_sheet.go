@@ -0,0 +1,77 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// SheetAnimal is one animal on the slaughter sheet, with the batch it is in
+// and the bookings it is sacrificed for.
+type SheetAnimal struct {
+	Animal    *qurbani.Animal
+	ShareType qurbani.ShareType
+	Batch     *qurbani.Batch
+	Bookings  []*qurbani.Booking
+}
+
+// SlaughterSheetHandler lists a season's animals with the names each is
+// sacrificed for, for reading out the niyyah on the day.
+type SlaughterSheetHandler struct {
+	offeringRepo  qurbani.OfferingRepository
+	bookingRepo   qurbani.BookingRepository
+	slaughterRepo qurbani.SlaughterRepository
+}
+
+// NewSlaughterSheetHandler creates a new SlaughterSheetHandler.
+func NewSlaughterSheetHandler(offeringRepo qurbani.OfferingRepository, bookingRepo qurbani.BookingRepository, slaughterRepo qurbani.SlaughterRepository) *SlaughterSheetHandler {
+	return &SlaughterSheetHandler{offeringRepo: offeringRepo, bookingRepo: bookingRepo, slaughterRepo: slaughterRepo}
+}
+
+// Handle returns the season's animals by share type and number, or only
+// those in one batch when batchID is set.
+func (h *SlaughterSheetHandler) Handle(ctx context.Context, season string, batchID *uuid.UUID) ([]SheetAnimal, error) {
+	offerings, err := h.offeringRepo.FindAll(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani offerings: %w", err)
+	}
+	animals, err := h.slaughterRepo.FindAnimals(ctx, qurbani.AnimalFilter{Season: season, BatchID: batchID})
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani animals: %w", err)
+	}
+	bookings, err := h.bookingRepo.FindAll(ctx, qurbani.BookingFilter{Season: season, BatchID: batchID})
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani bookings: %w", err)
+	}
+	batches, err := h.slaughterRepo.FindBatches(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("querying slaughter batches: %w", err)
+	}
+
+	shareTypes := make(map[uuid.UUID]qurbani.ShareType, len(offerings))
+	for _, o := range offerings {
+		shareTypes[o.ID()] = o.ShareType()
+	}
+	byID := make(map[uuid.UUID]*qurbani.Batch, len(batches))
+	for _, b := range batches {
+		byID[b.ID()] = b
+	}
+	onAnimal := make(map[uuid.UUID][]*qurbani.Booking)
+	for _, b := range bookings {
+		if b.AnimalID() != nil && b.Status() != qurbani.BookingCancelled {
+			onAnimal[*b.AnimalID()] = append(onAnimal[*b.AnimalID()], b)
+		}
+	}
+
+	sheet := make([]SheetAnimal, 0, len(animals))
+	for _, a := range animals {
+		entry := SheetAnimal{Animal: a, ShareType: shareTypes[a.OfferingID()], Bookings: onAnimal[a.ID()]}
+		if a.BatchID() != nil {
+			entry.Batch = byID[*a.BatchID()]
+		}
+		sheet = append(sheet, entry)
+	}
+	return sheet, nil
+}
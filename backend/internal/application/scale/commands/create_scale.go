@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// CreateScaleCommand is the input for the create scale use case.
+type CreateScaleCommand struct {
+	Name      string
+	Protocol  scale.Protocol
+	Transport scale.Transport
+	Address   string
+	BaudRate  int
+}
+
+// CreateScaleHandler registers a weighing scale.
+type CreateScaleHandler struct {
+	scaleRepo scale.Repository
+}
+
+// NewCreateScaleHandler creates a new CreateScaleHandler.
+func NewCreateScaleHandler(scaleRepo scale.Repository) *CreateScaleHandler {
+	return &CreateScaleHandler{scaleRepo: scaleRepo}
+}
+
+// Handle executes the create scale use case. A name already in use returns
+// ErrDuplicateScale.
+func (h *CreateScaleHandler) Handle(ctx context.Context, cmd CreateScaleCommand) (*scale.Scale, error) {
+	s, err := scale.NewScale(uuid.New(), cmd.Name, cmd.Protocol, cmd.Transport, cmd.Address, cmd.BaudRate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.scaleRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, scale.ErrDuplicateScale) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving scale: %w", err)
+	}
+	return s, nil
+}
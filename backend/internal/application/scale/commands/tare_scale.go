@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/weighing"
+)
+
+// TareScaleHandler tares a scale from the back office, e.g. once the tray
+// is on it.
+type TareScaleHandler struct {
+	monitor *weighing.Monitor
+}
+
+// NewTareScaleHandler creates a new TareScaleHandler.
+func NewTareScaleHandler(monitor *weighing.Monitor) *TareScaleHandler {
+	return &TareScaleHandler{monitor: monitor}
+}
+
+// Handle executes the tare scale use case. Scales that are only tared on
+// their own keypad return ErrTareUnsupported.
+func (h *TareScaleHandler) Handle(ctx context.Context, scaleID uuid.UUID) error {
+	return h.monitor.Tare(ctx, scaleID)
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// UpdateScaleCommand is the input for the update scale use case.
+type UpdateScaleCommand struct {
+	ScaleID   uuid.UUID
+	Name      string
+	Protocol  scale.Protocol
+	Transport scale.Transport
+	Address   string
+	BaudRate  int
+	Active    bool
+}
+
+// UpdateScaleHandler renames, reconnects or disables a scale.
+type UpdateScaleHandler struct {
+	scaleRepo scale.Repository
+}
+
+// NewUpdateScaleHandler creates a new UpdateScaleHandler.
+func NewUpdateScaleHandler(scaleRepo scale.Repository) *UpdateScaleHandler {
+	return &UpdateScaleHandler{scaleRepo: scaleRepo}
+}
+
+// Handle executes the update scale use case. Screens already watching the
+// scale keep their connection until they reconnect.
+func (h *UpdateScaleHandler) Handle(ctx context.Context, cmd UpdateScaleCommand) (*scale.Scale, error) {
+	s, err := h.scaleRepo.FindByID(ctx, cmd.ScaleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Update(cmd.Name, cmd.Protocol, cmd.Transport, cmd.Address, cmd.BaudRate, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.scaleRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, scale.ErrDuplicateScale) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving scale: %w", err)
+	}
+	return s, nil
+}
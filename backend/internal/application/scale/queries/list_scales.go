@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// ListScalesHandler lists the weighing scales.
+type ListScalesHandler struct {
+	scaleRepo scale.Repository
+}
+
+// NewListScalesHandler creates a new ListScalesHandler.
+func NewListScalesHandler(scaleRepo scale.Repository) *ListScalesHandler {
+	return &ListScalesHandler{scaleRepo: scaleRepo}
+}
+
+// Handle returns every scale by name.
+func (h *ListScalesHandler) Handle(ctx context.Context) ([]*scale.Scale, error) {
+	scales, err := h.scaleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing scales: %w", err)
+	}
+	return scales, nil
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/weighing"
+)
+
+// WatchScaleHandler streams a scale's readings to a weighing screen.
+type WatchScaleHandler struct {
+	monitor *weighing.Monitor
+}
+
+// NewWatchScaleHandler creates a new WatchScaleHandler.
+func NewWatchScaleHandler(monitor *weighing.Monitor) *WatchScaleHandler {
+	return &WatchScaleHandler{monitor: monitor}
+}
+
+// Handle returns the scale's readings until ctx is done; see Monitor.Watch.
+func (h *WatchScaleHandler) Handle(ctx context.Context, scaleID uuid.UUID) (<-chan weighing.Event, error) {
+	return h.monitor.Watch(ctx, scaleID)
+}
@@ -0,0 +1,31 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/weighing"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// WeighHandler takes a single stable weight from a scale, for filling in
+// an order line.
+type WeighHandler struct {
+	monitor *weighing.Monitor
+	timeout time.Duration
+}
+
+// NewWeighHandler creates a new WeighHandler that waits up to timeout for
+// the weight to settle.
+func NewWeighHandler(monitor *weighing.Monitor, timeout time.Duration) *WeighHandler {
+	return &WeighHandler{monitor: monitor, timeout: timeout}
+}
+
+// Handle returns the scale's first stable reading, or ErrNotStable if it
+// does not settle in time.
+func (h *WeighHandler) Handle(ctx context.Context, scaleID uuid.UUID) (scale.Reading, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	return h.monitor.Stable(ctx, scaleID)
+}
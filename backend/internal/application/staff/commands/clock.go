@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// ClockCommand is the input for the use cases that change a member of
+// staff's open clock entry.
+type ClockCommand struct {
+	AdminID uuid.UUID
+}
+
+// clock applies change to a member of staff's open entry and stores it.
+func clock(ctx context.Context, clockRepo staff.ClockRepository, cmd ClockCommand, change func(*staff.ClockEntry, time.Time) error) (*staff.ClockEntry, error) {
+	e, err := clockRepo.FindOpen(ctx, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(e, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := clockRepo.Update(ctx, e); err != nil {
+		if errors.Is(err, staff.ErrEntryNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving clock entry: %w", err)
+	}
+	return e, nil
+}
+
+// StartBreakHandler starts a break for a member of staff who is clocked in.
+type StartBreakHandler struct {
+	clockRepo staff.ClockRepository
+}
+
+// NewStartBreakHandler creates a new StartBreakHandler.
+func NewStartBreakHandler(clockRepo staff.ClockRepository) *StartBreakHandler {
+	return &StartBreakHandler{clockRepo: clockRepo}
+}
+
+// Handle executes the start break use case.
+func (h *StartBreakHandler) Handle(ctx context.Context, cmd ClockCommand) (*staff.ClockEntry, error) {
+	return clock(ctx, h.clockRepo, cmd, (*staff.ClockEntry).StartBreak)
+}
+
+// EndBreakHandler ends a member of staff's break.
+type EndBreakHandler struct {
+	clockRepo staff.ClockRepository
+}
+
+// NewEndBreakHandler creates a new EndBreakHandler.
+func NewEndBreakHandler(clockRepo staff.ClockRepository) *EndBreakHandler {
+	return &EndBreakHandler{clockRepo: clockRepo}
+}
+
+// Handle executes the end break use case.
+func (h *EndBreakHandler) Handle(ctx context.Context, cmd ClockCommand) (*staff.ClockEntry, error) {
+	return clock(ctx, h.clockRepo, cmd, (*staff.ClockEntry).EndBreak)
+}
+
+// ClockOutHandler ends a member of staff's working time, and any break
+// they are on.
+type ClockOutHandler struct {
+	clockRepo staff.ClockRepository
+}
+
+// NewClockOutHandler creates a new ClockOutHandler.
+func NewClockOutHandler(clockRepo staff.ClockRepository) *ClockOutHandler {
+	return &ClockOutHandler{clockRepo: clockRepo}
+}
+
+// Handle executes the clock out use case.
+func (h *ClockOutHandler) Handle(ctx context.Context, cmd ClockCommand) (*staff.ClockEntry, error) {
+	return clock(ctx, h.clockRepo, cmd, (*staff.ClockEntry).ClockOut)
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// ClockInCommand is the input for the clock in use case. A nil BranchID
+// clocks in at the member of staff's home branch.
+type ClockInCommand struct {
+	AdminID  uuid.UUID
+	BranchID *uuid.UUID
+}
+
+// ClockInHandler starts a member of staff's working time.
+type ClockInHandler struct {
+	profileRepo staff.ProfileRepository
+	clockRepo   staff.ClockRepository
+}
+
+// NewClockInHandler creates a new ClockInHandler.
+func NewClockInHandler(profileRepo staff.ProfileRepository, clockRepo staff.ClockRepository) *ClockInHandler {
+	return &ClockInHandler{profileRepo: profileRepo, clockRepo: clockRepo}
+}
+
+// Handle executes the clock in use case.
+func (h *ClockInHandler) Handle(ctx context.Context, cmd ClockInCommand) (*staff.ClockEntry, error) {
+	p, err := h.profileRepo.FindByAdminID(ctx, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	branchID := uuid.Nil
+	if cmd.BranchID != nil {
+		branchID = *cmd.BranchID
+	}
+
+	e, err := staff.ClockIn(uuid.New(), p, branchID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.clockRepo.ClockIn(ctx, e); err != nil {
+		if errors.Is(err, staff.ErrAlreadyClockedIn) || errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving clock entry: %w", err)
+	}
+	return e, nil
+}
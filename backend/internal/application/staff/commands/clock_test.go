@@ -0,0 +1,101 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/staff/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockClockRepository struct {
+	mock.Mock
+}
+
+func (m *mockClockRepository) ClockIn(ctx context.Context, e *staff.ClockEntry) error {
+	return m.Called(ctx, e).Error(0)
+}
+
+func (m *mockClockRepository) Update(ctx context.Context, e *staff.ClockEntry) error {
+	return m.Called(ctx, e).Error(0)
+}
+
+func (m *mockClockRepository) FindOpen(ctx context.Context, adminID uuid.UUID) (*staff.ClockEntry, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*staff.ClockEntry), args.Error(1)
+}
+
+func (m *mockClockRepository) FindEntries(ctx context.Context, f staff.Filter) ([]*staff.ClockEntry, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.ClockEntry), args.Error(1)
+}
+
+// --- Tests ---
+
+func TestClockIn_AtHomeBranch(t *testing.T) {
+	profileRepo, clockRepo := new(mockProfileRepository), new(mockClockRepository)
+	branchID := uuid.New()
+	p := newStaffProfile(t, profileRepo, branchID, staff.PositionCashier)
+	clockRepo.On("ClockIn", mock.Anything, mock.AnythingOfType("*staff.ClockEntry")).Return(nil)
+
+	e, err := commands.NewClockInHandler(profileRepo, clockRepo).Handle(context.Background(), commands.ClockInCommand{AdminID: p.AdminID()})
+
+	require.NoError(t, err)
+	assert.Equal(t, branchID, e.BranchID())
+	assert.True(t, e.Open())
+}
+
+func TestClockIn_AlreadyClockedIn_ReturnsError(t *testing.T) {
+	profileRepo, clockRepo := new(mockProfileRepository), new(mockClockRepository)
+	p := newStaffProfile(t, profileRepo, uuid.New(), staff.PositionCashier)
+	clockRepo.On("ClockIn", mock.Anything, mock.Anything).Return(staff.ErrAlreadyClockedIn)
+
+	_, err := commands.NewClockInHandler(profileRepo, clockRepo).Handle(context.Background(), commands.ClockInCommand{AdminID: p.AdminID()})
+
+	assert.ErrorIs(t, err, staff.ErrAlreadyClockedIn)
+}
+
+func TestClock_BreakThenClockOut(t *testing.T) {
+	profileRepo, clockRepo := new(mockProfileRepository), new(mockClockRepository)
+	p := newStaffProfile(t, profileRepo, uuid.New(), staff.PositionButcher)
+	e, err := staff.ClockIn(uuid.New(), p, uuid.Nil, time.Now().Add(-4*time.Hour))
+	require.NoError(t, err)
+	clockRepo.On("FindOpen", mock.Anything, p.AdminID()).Return(e, nil)
+	clockRepo.On("Update", mock.Anything, e).Return(nil)
+	cmd := commands.ClockCommand{AdminID: p.AdminID()}
+
+	_, err = commands.NewStartBreakHandler(clockRepo).Handle(context.Background(), cmd)
+	require.NoError(t, err)
+	assert.True(t, e.OnBreak())
+	_, err = commands.NewStartBreakHandler(clockRepo).Handle(context.Background(), cmd)
+	assert.ErrorIs(t, err, staff.ErrAlreadyOnBreak)
+
+	_, err = commands.NewClockOutHandler(clockRepo).Handle(context.Background(), cmd)
+	require.NoError(t, err)
+	assert.False(t, e.Open())
+	assert.False(t, e.OnBreak(), "clocking out ends the break")
+	clockRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestClock_NotClockedIn_ReturnsNotFound(t *testing.T) {
+	clockRepo := new(mockClockRepository)
+	adminID := uuid.New()
+	clockRepo.On("FindOpen", mock.Anything, adminID).Return(nil, staff.ErrEntryNotFound)
+
+	_, err := commands.NewEndBreakHandler(clockRepo).Handle(context.Background(), commands.ClockCommand{AdminID: adminID})
+
+	assert.ErrorIs(t, err, staff.ErrEntryNotFound)
+}
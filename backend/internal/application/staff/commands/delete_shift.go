@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// DeleteShiftCommand is the input for the delete shift use case.
+type DeleteShiftCommand struct {
+	ShiftID uuid.UUID
+	ActorID uuid.UUID
+}
+
+// DeleteShiftHandler takes a shift off the rota.
+type DeleteShiftHandler struct {
+	adminRepo admin.Repository
+	shiftRepo staff.ShiftRepository
+}
+
+// NewDeleteShiftHandler creates a new DeleteShiftHandler.
+func NewDeleteShiftHandler(adminRepo admin.Repository, shiftRepo staff.ShiftRepository) *DeleteShiftHandler {
+	return &DeleteShiftHandler{adminRepo: adminRepo, shiftRepo: shiftRepo}
+}
+
+// Handle executes the delete shift use case.
+func (h *DeleteShiftHandler) Handle(ctx context.Context, cmd DeleteShiftCommand) error {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return err
+	}
+	if err := h.shiftRepo.Delete(ctx, cmd.ShiftID); err != nil {
+		if errors.Is(err, staff.ErrShiftNotFound) {
+			return err
+		}
+		return fmt.Errorf("deleting shift: %w", err)
+	}
+	return nil
+}
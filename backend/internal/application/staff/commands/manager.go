@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// manager checks the admin making the change may manage staff.
+func manager(ctx context.Context, adminRepo admin.Repository, actorID uuid.UUID) error {
+	actor, err := adminRepo.FindByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !actor.HasPermission(admin.PermissionManageStaff) {
+		return staff.ErrManageNotAllowed
+	}
+	return nil
+}
+
+// rostered loads the profile of the member of staff a shift is for,
+// wherever they are based, so that staff can be rostered to cover other
+// branches.
+func rostered(ctx context.Context, profileRepo staff.ProfileRepository, adminID uuid.UUID) (*staff.Profile, error) {
+	profiles, err := profileRepo.FindByAdminIDs(ctx, []uuid.UUID{adminID})
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, staff.ErrProfileNotFound
+	}
+	return profiles[0], nil
+}
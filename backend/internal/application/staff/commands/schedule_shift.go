@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// ScheduleShiftCommand is the input for the schedule shift use case. An
+// empty Position rosters the member of staff as what they are trained as.
+type ScheduleShiftCommand struct {
+	AdminID  uuid.UUID
+	BranchID uuid.UUID
+	Position staff.Position
+	StartsAt time.Time
+	EndsAt   time.Time
+	Break    time.Duration
+	Note     string
+	ActorID  uuid.UUID
+}
+
+// ScheduleShiftHandler adds a shift to a branch's rota. Clashes, missing
+// breaks and overtime do not stop a shift being rostered; the rota flags
+// them.
+type ScheduleShiftHandler struct {
+	adminRepo   admin.Repository
+	branchRepo  branch.Repository
+	profileRepo staff.ProfileRepository
+	shiftRepo   staff.ShiftRepository
+}
+
+// NewScheduleShiftHandler creates a new ScheduleShiftHandler.
+func NewScheduleShiftHandler(
+	adminRepo admin.Repository,
+	branchRepo branch.Repository,
+	profileRepo staff.ProfileRepository,
+	shiftRepo staff.ShiftRepository,
+) *ScheduleShiftHandler {
+	return &ScheduleShiftHandler{adminRepo: adminRepo, branchRepo: branchRepo, profileRepo: profileRepo, shiftRepo: shiftRepo}
+}
+
+// Handle executes the schedule shift use case.
+func (h *ScheduleShiftHandler) Handle(ctx context.Context, cmd ScheduleShiftCommand) (*staff.Shift, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	p, err := rostered(ctx, h.profileRepo, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	position := cmd.Position
+	if position == "" {
+		position = p.Position()
+	}
+
+	s, err := staff.NewShift(uuid.New(), p, cmd.BranchID, position, cmd.StartsAt, cmd.EndsAt, cmd.Break, cmd.Note,
+		cmd.ActorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+
+	if err := h.shiftRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving shift: %w", err)
+	}
+	return s, nil
+}
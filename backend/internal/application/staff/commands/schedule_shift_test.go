@@ -0,0 +1,255 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/staff/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+type mockBranchRepository struct {
+	mock.Mock
+}
+
+func (m *mockBranchRepository) Save(ctx context.Context, b *branch.Branch) error {
+	return m.Called(ctx, b).Error(0)
+}
+
+func (m *mockBranchRepository) FindByID(ctx context.Context, id uuid.UUID) (*branch.Branch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*branch.Branch), args.Error(1)
+}
+
+func (m *mockBranchRepository) FindAll(ctx context.Context, includeInactive bool) ([]*branch.Branch, error) {
+	args := m.Called(ctx, includeInactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*branch.Branch), args.Error(1)
+}
+
+type mockProfileRepository struct {
+	mock.Mock
+}
+
+func (m *mockProfileRepository) Save(ctx context.Context, p *staff.Profile) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockProfileRepository) FindByAdminID(ctx context.Context, adminID uuid.UUID) (*staff.Profile, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*staff.Profile), args.Error(1)
+}
+
+func (m *mockProfileRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*staff.Profile, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Profile), args.Error(1)
+}
+
+func (m *mockProfileRepository) FindByAdminIDs(ctx context.Context, adminIDs []uuid.UUID) ([]*staff.Profile, error) {
+	args := m.Called(ctx, adminIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Profile), args.Error(1)
+}
+
+type mockShiftRepository struct {
+	mock.Mock
+}
+
+func (m *mockShiftRepository) Save(ctx context.Context, s *staff.Shift) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockShiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockShiftRepository) FindByID(ctx context.Context, id uuid.UUID) (*staff.Shift, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*staff.Shift), args.Error(1)
+}
+
+func (m *mockShiftRepository) FindShifts(ctx context.Context, f staff.Filter) ([]*staff.Shift, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Shift), args.Error(1)
+}
+
+func (m *mockShiftRepository) FindStaffShifts(ctx context.Context, adminIDs []uuid.UUID, from, to time.Time) ([]*staff.Shift, error) {
+	args := m.Called(ctx, adminIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Shift), args.Error(1)
+}
+
+// --- Fixtures ---
+
+// newActor returns an admin with role known to repo.
+func newActor(t *testing.T, repo *mockAdminRepository, role admin.Role) *admin.Admin {
+	t.Helper()
+	id := uuid.New()
+	a, err := admin.NewAdmin(id, id.String()+"@butchery.com", "$2a$10$hash", "Admin", role)
+	require.NoError(t, err)
+	repo.On("FindByID", mock.Anything, id).Return(a, nil)
+	return a
+}
+
+// newStaffProfile returns the profile of a member of staff known to repo,
+// based at branchID.
+func newStaffProfile(t *testing.T, repo *mockProfileRepository, branchID uuid.UUID, position staff.Position) *staff.Profile {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "yusuf@butchery.com", "$2a$10$hash", "Yusuf Ali", admin.RoleStaff)
+	require.NoError(t, err)
+	p, err := staff.NewProfile(a, branchID, position, 1250, 2400, time.Now())
+	require.NoError(t, err)
+	repo.On("FindByAdminIDs", mock.Anything, []uuid.UUID{p.AdminID()}).Return([]*staff.Profile{p}, nil).Maybe()
+	repo.On("FindByAdminID", mock.Anything, p.AdminID()).Return(p, nil).Maybe()
+	return p
+}
+
+// newBranch returns a branch known to repo.
+func newBranch(t *testing.T, repo *mockBranchRepository) *branch.Branch {
+	t.Helper()
+	b, err := branch.NewBranch(uuid.New(), "Whitechapel", []string{"1 High Street"}, "", "", time.Now())
+	require.NoError(t, err)
+	repo.On("FindByID", mock.Anything, b.ID()).Return(b, nil).Maybe()
+	return b
+}
+
+// --- Tests ---
+
+func TestScheduleShift_DefaultsToTheirPosition(t *testing.T) {
+	adminRepo, branchRepo := new(mockAdminRepository), new(mockBranchRepository)
+	profileRepo, shiftRepo := new(mockProfileRepository), new(mockShiftRepository)
+	actor := newActor(t, adminRepo, admin.RoleManager)
+	b := newBranch(t, branchRepo)
+	p := newStaffProfile(t, profileRepo, b.ID(), staff.PositionButcher)
+	shiftRepo.On("Save", mock.Anything, mock.AnythingOfType("*staff.Shift")).Return(nil)
+
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	s, err := commands.NewScheduleShiftHandler(adminRepo, branchRepo, profileRepo, shiftRepo).Handle(context.Background(),
+		commands.ScheduleShiftCommand{
+			AdminID:  p.AdminID(),
+			BranchID: b.ID(),
+			StartsAt: startsAt,
+			EndsAt:   startsAt.Add(8 * time.Hour),
+			Break:    30 * time.Minute,
+			ActorID:  actor.ID(),
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, staff.PositionButcher, s.Position())
+	assert.Equal(t, 450, s.Minutes())
+	assert.Equal(t, actor.ID(), s.CreatedBy())
+	shiftRepo.AssertExpectations(t)
+}
+
+func TestScheduleShift_NotManager_IsRejected(t *testing.T) {
+	adminRepo, branchRepo := new(mockAdminRepository), new(mockBranchRepository)
+	profileRepo, shiftRepo := new(mockProfileRepository), new(mockShiftRepository)
+	actor := newActor(t, adminRepo, admin.RoleStaff)
+
+	_, err := commands.NewScheduleShiftHandler(adminRepo, branchRepo, profileRepo, shiftRepo).Handle(context.Background(),
+		commands.ScheduleShiftCommand{AdminID: uuid.New(), BranchID: uuid.New(), ActorID: actor.ID()})
+
+	assert.ErrorIs(t, err, staff.ErrManageNotAllowed)
+	shiftRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestScheduleShift_PositionNotTrainedFor_IsRejected(t *testing.T) {
+	adminRepo, branchRepo := new(mockAdminRepository), new(mockBranchRepository)
+	profileRepo, shiftRepo := new(mockProfileRepository), new(mockShiftRepository)
+	actor := newActor(t, adminRepo, admin.RoleManager)
+	b := newBranch(t, branchRepo)
+	p := newStaffProfile(t, profileRepo, b.ID(), staff.PositionCashier)
+
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	_, err := commands.NewScheduleShiftHandler(adminRepo, branchRepo, profileRepo, shiftRepo).Handle(context.Background(),
+		commands.ScheduleShiftCommand{
+			AdminID:  p.AdminID(),
+			BranchID: b.ID(),
+			Position: staff.PositionButcher,
+			StartsAt: startsAt,
+			EndsAt:   startsAt.Add(4 * time.Hour),
+			ActorID:  actor.ID(),
+		})
+
+	assert.ErrorIs(t, err, staff.ErrPositionNotAllowed)
+	shiftRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestScheduleShift_NoProfile_ReturnsNotFound(t *testing.T) {
+	adminRepo, branchRepo := new(mockAdminRepository), new(mockBranchRepository)
+	profileRepo, shiftRepo := new(mockProfileRepository), new(mockShiftRepository)
+	actor := newActor(t, adminRepo, admin.RoleManager)
+	adminID := uuid.New()
+	profileRepo.On("FindByAdminIDs", mock.Anything, []uuid.UUID{adminID}).Return([]*staff.Profile{}, nil)
+
+	_, err := commands.NewScheduleShiftHandler(adminRepo, branchRepo, profileRepo, shiftRepo).Handle(context.Background(),
+		commands.ScheduleShiftCommand{AdminID: adminID, BranchID: uuid.New(), ActorID: actor.ID()})
+
+	assert.ErrorIs(t, err, staff.ErrProfileNotFound)
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// SetProfileCommand is the input for the set profile use case. Role is
+// left as it is when empty.
+type SetProfileCommand struct {
+	AdminID           uuid.UUID
+	BranchID          uuid.UUID
+	Position          staff.Position
+	HourlyRateCents   int64
+	ContractedMinutes int // a week; zero for staff paid only for hours worked
+	Active            bool
+	Role              admin.Role
+	ActorID           uuid.UUID
+}
+
+// SetProfileHandler creates or updates the staff profile of an admin, so
+// that they can be rostered and clock in, and sets what their role lets
+// them do, e.g. making a new starter a cashier so they can work a till.
+type SetProfileHandler struct {
+	adminRepo   admin.Repository
+	branchRepo  branch.Repository
+	profileRepo staff.ProfileRepository
+}
+
+// NewSetProfileHandler creates a new SetProfileHandler.
+func NewSetProfileHandler(adminRepo admin.Repository, branchRepo branch.Repository, profileRepo staff.ProfileRepository) *SetProfileHandler {
+	return &SetProfileHandler{adminRepo: adminRepo, branchRepo: branchRepo, profileRepo: profileRepo}
+}
+
+// Handle executes the set profile use case.
+func (h *SetProfileHandler) Handle(ctx context.Context, cmd SetProfileCommand) (*staff.Profile, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	a, err := h.adminRepo.FindByID(ctx, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	changeRole := cmd.Role != "" && cmd.Role != a.Role()
+	if changeRole {
+		if err := a.AssignRole(cmd.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	p, err := h.profileRepo.FindByAdminID(ctx, cmd.AdminID)
+	switch {
+	case errors.Is(err, staff.ErrProfileNotFound):
+		p, err = staff.NewProfile(a, cmd.BranchID, cmd.Position, cmd.HourlyRateCents, cmd.ContractedMinutes, now)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+	if err := p.Update(cmd.BranchID, cmd.Position, cmd.HourlyRateCents, cmd.ContractedMinutes, cmd.Active, now); err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+
+	if err := h.profileRepo.Save(ctx, p); err != nil {
+		if errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving staff profile: %w", err)
+	}
+	if changeRole {
+		if err := h.adminRepo.SaveRole(ctx, a); err != nil {
+			return nil, fmt.Errorf("saving admin role: %w", err)
+		}
+	}
+	return p, nil
+}
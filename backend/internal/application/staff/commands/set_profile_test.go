@@ -0,0 +1,52 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/internal/application/staff/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetProfile_AssignsRole(t *testing.T) {
+	adminRepo, branchRepo, profileRepo := new(mockAdminRepository), new(mockBranchRepository), new(mockProfileRepository)
+	actor := newActor(t, adminRepo, admin.RoleManager)
+	starter := newActor(t, adminRepo, admin.RoleStaff)
+	b := newBranch(t, branchRepo)
+	profileRepo.On("FindByAdminID", mock.Anything, starter.ID()).Return(nil, staff.ErrProfileNotFound)
+	profileRepo.On("Save", mock.Anything, mock.AnythingOfType("*staff.Profile")).Return(nil)
+	adminRepo.On("SaveRole", mock.Anything, starter).Return(nil)
+
+	_, err := commands.NewSetProfileHandler(adminRepo, branchRepo, profileRepo).Handle(context.Background(), commands.SetProfileCommand{
+		AdminID:         starter.ID(),
+		BranchID:        b.ID(),
+		Position:        staff.PositionCashier,
+		HourlyRateCents: 1100,
+		Active:          true,
+		Role:            admin.RoleCashier,
+		ActorID:         actor.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, admin.RoleCashier, starter.Role())
+	adminRepo.AssertExpectations(t)
+}
+
+func TestSetProfile_MakingADriver_IsRejected(t *testing.T) {
+	adminRepo, branchRepo, profileRepo := new(mockAdminRepository), new(mockBranchRepository), new(mockProfileRepository)
+	actor := newActor(t, adminRepo, admin.RoleManager)
+	starter := newActor(t, adminRepo, admin.RoleStaff)
+
+	_, err := commands.NewSetProfileHandler(adminRepo, branchRepo, profileRepo).Handle(context.Background(), commands.SetProfileCommand{
+		AdminID: starter.ID(),
+		Role:    admin.RoleDriver,
+		ActorID: actor.ID(),
+	})
+
+	assert.ErrorIs(t, err, admin.ErrRoleNotAssignable)
+	adminRepo.AssertNotCalled(t, "SaveRole", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// UpdateShiftCommand is the input for the update shift use case. An empty
+// Position keeps the shift's position.
+type UpdateShiftCommand struct {
+	ShiftID  uuid.UUID
+	BranchID uuid.UUID
+	Position staff.Position
+	StartsAt time.Time
+	EndsAt   time.Time
+	Break    time.Duration
+	Note     string
+	ActorID  uuid.UUID
+}
+
+// UpdateShiftHandler changes when, where or as what a rostered shift is
+// worked.
+type UpdateShiftHandler struct {
+	adminRepo   admin.Repository
+	branchRepo  branch.Repository
+	profileRepo staff.ProfileRepository
+	shiftRepo   staff.ShiftRepository
+}
+
+// NewUpdateShiftHandler creates a new UpdateShiftHandler.
+func NewUpdateShiftHandler(
+	adminRepo admin.Repository,
+	branchRepo branch.Repository,
+	profileRepo staff.ProfileRepository,
+	shiftRepo staff.ShiftRepository,
+) *UpdateShiftHandler {
+	return &UpdateShiftHandler{adminRepo: adminRepo, branchRepo: branchRepo, profileRepo: profileRepo, shiftRepo: shiftRepo}
+}
+
+// Handle executes the update shift use case.
+func (h *UpdateShiftHandler) Handle(ctx context.Context, cmd UpdateShiftCommand) (*staff.Shift, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	s, err := h.shiftRepo.FindByID(ctx, cmd.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := rostered(ctx, h.profileRepo, s.AdminID())
+	if err != nil {
+		return nil, err
+	}
+	position := cmd.Position
+	if position == "" {
+		position = s.Position()
+	}
+
+	if err := s.Reschedule(p, cmd.BranchID, position, cmd.StartsAt, cmd.EndsAt, cmd.Break, cmd.Note, time.Now()); err != nil {
+		return nil, err
+	}
+	if _, err := h.branchRepo.FindByID(ctx, cmd.BranchID); err != nil {
+		return nil, err
+	}
+
+	if err := h.shiftRepo.Save(ctx, s); err != nil {
+		if errors.Is(err, branch.ErrOutOfScope) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving shift: %w", err)
+	}
+	return s, nil
+}
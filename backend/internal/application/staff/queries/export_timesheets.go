@@ -0,0 +1,63 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// ExportTimesheetsHandler exports timesheets as a file for payroll.
+type ExportTimesheetsHandler struct {
+	timesheets *TimesheetsHandler
+	branchRepo branch.Repository
+	renderers  map[staff.Format]staff.TimesheetRenderer
+	location   *time.Location
+}
+
+// NewExportTimesheetsHandler creates a new ExportTimesheetsHandler. Times
+// are given in location.
+func NewExportTimesheetsHandler(
+	timesheets *TimesheetsHandler,
+	branchRepo branch.Repository,
+	renderers map[staff.Format]staff.TimesheetRenderer,
+	location *time.Location,
+) *ExportTimesheetsHandler {
+	return &ExportTimesheetsHandler{timesheets: timesheets, branchRepo: branchRepo, renderers: renderers, location: location}
+}
+
+// Handle renders the timesheets.
+func (h *ExportTimesheetsHandler) Handle(ctx context.Context, q TimesheetsQuery, format staff.Format) ([]byte, error) {
+	renderer, ok := h.renderers[format]
+	if !ok {
+		return nil, staff.ErrUnsupportedFormat
+	}
+	sheets, period, err := h.timesheets.Handle(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := h.branchRepo.FindAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("loading branches: %w", err)
+	}
+	names := make(map[uuid.UUID]string, len(branches))
+	for _, b := range branches {
+		names[b.ID()] = b.Name()
+	}
+
+	out, err := renderer.Render(&staff.TimesheetReport{
+		Period:      period,
+		Location:    h.location,
+		Timesheets:  sheets,
+		BranchNames: names,
+		GeneratedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rendering timesheets: %w", err)
+	}
+	return out, nil
+}
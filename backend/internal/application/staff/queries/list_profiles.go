@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// ListProfilesQuery is the input for the list profiles use case. BranchID
+// limits the list to staff based at a branch.
+type ListProfilesQuery struct {
+	BranchID *uuid.UUID
+	ActorID  uuid.UUID
+}
+
+// ListProfilesHandler lists staff profiles with their pay, for managers.
+type ListProfilesHandler struct {
+	adminRepo   admin.Repository
+	profileRepo staff.ProfileRepository
+}
+
+// NewListProfilesHandler creates a new ListProfilesHandler.
+func NewListProfilesHandler(adminRepo admin.Repository, profileRepo staff.ProfileRepository) *ListProfilesHandler {
+	return &ListProfilesHandler{adminRepo: adminRepo, profileRepo: profileRepo}
+}
+
+// Handle returns the profiles by name.
+func (h *ListProfilesHandler) Handle(ctx context.Context, q ListProfilesQuery) ([]*staff.Profile, error) {
+	actor, err := h.adminRepo.FindByID(ctx, q.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasPermission(admin.PermissionManageStaff) {
+		return nil, staff.ErrManageNotAllowed
+	}
+	profiles, err := h.profileRepo.FindAll(ctx, q.BranchID)
+	if err != nil {
+		return nil, fmt.Errorf("listing staff profiles: %w", err)
+	}
+	return profiles, nil
+}
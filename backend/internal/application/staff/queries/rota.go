@@ -0,0 +1,137 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// RotaQuery is the input for the rota use case. Week is any day in the
+// week wanted.
+type RotaQuery struct {
+	BranchID uuid.UUID
+	Week     time.Time
+}
+
+// RotaHandler shows a branch's rota for a week with the conflicts in it:
+// staff double booked, shifts without long enough breaks, staff rostered
+// beyond their contracts, and fulfilment slots with fewer trained butchers
+// on shift than their bookings need.
+type RotaHandler struct {
+	profileRepo     staff.ProfileRepository
+	shiftRepo       staff.ShiftRepository
+	scheduleRepo    fulfilment.ScheduleRepository
+	reservationRepo fulfilment.ReservationRepository
+	policy          staff.Policy
+	location        *time.Location
+}
+
+// NewRotaHandler creates a new RotaHandler. Weeks start on Monday in
+// location.
+func NewRotaHandler(
+	profileRepo staff.ProfileRepository,
+	shiftRepo staff.ShiftRepository,
+	scheduleRepo fulfilment.ScheduleRepository,
+	reservationRepo fulfilment.ReservationRepository,
+	policy staff.Policy,
+	location *time.Location,
+) *RotaHandler {
+	return &RotaHandler{
+		profileRepo:     profileRepo,
+		shiftRepo:       shiftRepo,
+		scheduleRepo:    scheduleRepo,
+		reservationRepo: reservationRepo,
+		policy:          policy,
+		location:        location,
+	}
+}
+
+// Handle builds the rota.
+func (h *RotaHandler) Handle(ctx context.Context, q RotaQuery) (*staff.Rota, error) {
+	if err := branch.ScopeFrom(ctx).Check(q.BranchID); err != nil {
+		return nil, err
+	}
+	start := staff.WeekStart(q.Week, h.location)
+	end := start.AddDate(0, 0, 7)
+
+	here, err := h.shiftRepo.FindShifts(ctx, staff.Filter{BranchID: &q.BranchID, From: start, To: end})
+	if err != nil {
+		return nil, fmt.Errorf("loading shifts: %w", err)
+	}
+	seen := make(map[uuid.UUID]bool)
+	var adminIDs []uuid.UUID
+	for _, s := range here {
+		if !seen[s.AdminID()] {
+			seen[s.AdminID()] = true
+			adminIDs = append(adminIDs, s.AdminID())
+		}
+	}
+
+	var shifts []*staff.Shift
+	profiles := make(map[uuid.UUID]*staff.Profile)
+	if len(adminIDs) > 0 {
+		if shifts, err = h.shiftRepo.FindStaffShifts(ctx, adminIDs, start, end); err != nil {
+			return nil, fmt.Errorf("loading staff shifts: %w", err)
+		}
+		found, err := h.profileRepo.FindByAdminIDs(ctx, adminIDs)
+		if err != nil {
+			return nil, fmt.Errorf("loading staff profiles: %w", err)
+		}
+		for _, p := range found {
+			profiles[p.AdminID()] = p
+		}
+	}
+
+	demand, err := h.demand(ctx, q.BranchID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return h.policy.NewRota(q.BranchID, start, shifts, profiles, demand), nil
+}
+
+// demand returns the work booked into the branch's fulfilment slots in
+// [start, end).
+func (h *RotaHandler) demand(ctx context.Context, branchID uuid.UUID, start, end time.Time) ([]staff.Demand, error) {
+	templates, err := h.scheduleRepo.FindSlotTemplates(ctx, fulfilment.SlotTemplateFilter{BranchID: &branchID, ActiveOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("loading slot templates: %w", err)
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	templateIDs := make([]uuid.UUID, 0, len(templates))
+	for _, t := range templates {
+		templateIDs = append(templateIDs, t.ID())
+	}
+
+	hours, err := h.scheduleRepo.FindOpeningHours(ctx, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("loading opening hours: %w", err)
+	}
+	closures, err := h.scheduleRepo.FindClosures(ctx, branchID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("loading closures: %w", err)
+	}
+	usage, err := h.reservationRepo.UsageBySlot(ctx, templateIDs, start, end, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("loading slot usage: %w", err)
+	}
+
+	schedule := fulfilment.NewSchedule(branchID, hours, closures)
+	var demand []staff.Demand
+	for _, s := range schedule.Slots(templates, start, 7, h.location) {
+		d := staff.Demand{BranchID: branchID, StartsAt: s.StartsAt(), EndsAt: s.EndsAt()}
+		if s.Capacity().Unit() == fulfilment.CapacityUnitKg {
+			d.Grams = usage[s.Key()]
+		} else {
+			d.Orders = usage[s.Key()]
+		}
+		demand = append(demand, d)
+	}
+	return demand, nil
+}
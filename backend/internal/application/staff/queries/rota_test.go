@@ -0,0 +1,273 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/staff/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockProfileRepository struct {
+	mock.Mock
+}
+
+func (m *mockProfileRepository) Save(ctx context.Context, p *staff.Profile) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockProfileRepository) FindByAdminID(ctx context.Context, adminID uuid.UUID) (*staff.Profile, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*staff.Profile), args.Error(1)
+}
+
+func (m *mockProfileRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*staff.Profile, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Profile), args.Error(1)
+}
+
+func (m *mockProfileRepository) FindByAdminIDs(ctx context.Context, adminIDs []uuid.UUID) ([]*staff.Profile, error) {
+	args := m.Called(ctx, adminIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Profile), args.Error(1)
+}
+
+type mockShiftRepository struct {
+	mock.Mock
+}
+
+func (m *mockShiftRepository) Save(ctx context.Context, s *staff.Shift) error {
+	return m.Called(ctx, s).Error(0)
+}
+
+func (m *mockShiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockShiftRepository) FindByID(ctx context.Context, id uuid.UUID) (*staff.Shift, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*staff.Shift), args.Error(1)
+}
+
+func (m *mockShiftRepository) FindShifts(ctx context.Context, f staff.Filter) ([]*staff.Shift, error) {
+	args := m.Called(ctx, f)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Shift), args.Error(1)
+}
+
+func (m *mockShiftRepository) FindStaffShifts(ctx context.Context, adminIDs []uuid.UUID, from, to time.Time) ([]*staff.Shift, error) {
+	args := m.Called(ctx, adminIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*staff.Shift), args.Error(1)
+}
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	return m.Called(ctx, branchID, hours).Error(0)
+}
+
+func (m *mockScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.OpeningHours), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveClosure(ctx context.Context, closure *fulfilment.Closure) error {
+	return m.Called(ctx, closure).Error(0)
+}
+
+func (m *mockScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	args := m.Called(ctx, branchID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Closure), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSlotTemplate(ctx context.Context, tmpl *fulfilment.SlotTemplate) error {
+	return m.Called(ctx, tmpl).Error(0)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.SlotTemplate), args.Error(1)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.SlotTemplate), args.Error(1)
+}
+
+type mockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, r *fulfilment.Reservation) error {
+	return m.Called(ctx, slot, r).Error(0)
+}
+
+func (m *mockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) Update(ctx context.Context, r *fulfilment.Reservation) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	args := m.Called(ctx, templateIDs, from, to, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[fulfilment.SlotKey]int64), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, templateIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+// --- Fixtures ---
+
+var testPolicy = staff.Policy{BreakAfter: 6 * time.Hour, MinBreak: 20 * time.Minute, OrdersPerButcher: 2}
+
+// tuesday returns the given hour on Tuesday 10 November 2026, in the week
+// starting Monday the 9th.
+func tuesday(hour int) time.Time {
+	return time.Date(2026, 11, 10, hour, 0, 0, 0, time.UTC)
+}
+
+func newButcher(t *testing.T, branchID uuid.UUID) *staff.Profile {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "yusuf@butchery.com", "$2a$10$hash", "Yusuf Ali", admin.RoleStaff)
+	require.NoError(t, err)
+	p, err := staff.NewProfile(a, branchID, staff.PositionButcher, 1250, 0, time.Now())
+	require.NoError(t, err)
+	return p
+}
+
+func newShift(t *testing.T, p *staff.Profile, branchID uuid.UUID, from, to time.Time) *staff.Shift {
+	t.Helper()
+	s, err := staff.NewShift(uuid.New(), p, branchID, staff.PositionButcher, from, to, 30*time.Minute, "", uuid.New(), time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+// --- Tests ---
+
+func TestRota_FlagsClashesAndSlotsShortOfButchers(t *testing.T) {
+	profileRepo, shiftRepo := new(mockProfileRepository), new(mockShiftRepository)
+	scheduleRepo, reservationRepo := new(mockScheduleRepository), new(mockReservationRepository)
+
+	branchID, otherBranchID := uuid.New(), uuid.New()
+	yusuf := newButcher(t, branchID)
+	here := newShift(t, yusuf, branchID, tuesday(8), tuesday(17))
+	there := newShift(t, yusuf, otherBranchID, tuesday(16), tuesday(20))
+	weekStart := time.Date(2026, 11, 9, 0, 0, 0, 0, time.UTC)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	shiftRepo.On("FindShifts", mock.Anything, staff.Filter{BranchID: &branchID, From: weekStart, To: weekEnd}).
+		Return([]*staff.Shift{here}, nil)
+	shiftRepo.On("FindStaffShifts", mock.Anything, []uuid.UUID{yusuf.AdminID()}, weekStart, weekEnd).
+		Return([]*staff.Shift{here, there}, nil)
+	profileRepo.On("FindByAdminIDs", mock.Anything, []uuid.UUID{yusuf.AdminID()}).Return([]*staff.Profile{yusuf}, nil)
+
+	capacity, err := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 10)
+	require.NoError(t, err)
+	ten, err := fulfilment.ParseTimeOfDay("10:00")
+	require.NoError(t, err)
+	noon, err := fulfilment.ParseTimeOfDay("12:00")
+	require.NoError(t, err)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), branchID, nil, fulfilment.MethodCollection, time.Tuesday, ten, noon, capacity)
+	require.NoError(t, err)
+	hours, err := fulfilment.NewOpeningHours(branchID, time.Tuesday, ten, noon)
+	require.NoError(t, err)
+	scheduleRepo.On("FindSlotTemplates", mock.Anything, mock.Anything).Return([]*fulfilment.SlotTemplate{tmpl}, nil)
+	scheduleRepo.On("FindOpeningHours", mock.Anything, branchID).Return([]*fulfilment.OpeningHours{hours}, nil)
+	scheduleRepo.On("FindClosures", mock.Anything, branchID, weekStart, weekEnd).Return([]*fulfilment.Closure{}, nil)
+	reservationRepo.On("UsageBySlot", mock.Anything, []uuid.UUID{tmpl.ID()}, weekStart, weekEnd, mock.Anything).
+		Return(map[fulfilment.SlotKey]int64{tmpl.SlotOn(tuesday(0), time.UTC).Key(): 5}, nil)
+
+	rota, err := queries.NewRotaHandler(profileRepo, shiftRepo, scheduleRepo, reservationRepo, testPolicy, time.UTC).
+		Handle(context.Background(), queries.RotaQuery{BranchID: branchID, Week: tuesday(12)})
+
+	require.NoError(t, err)
+	assert.Equal(t, weekStart, rota.WeekStart)
+	require.Len(t, rota.Shifts, 1, "shifts at other branches are only used to find clashes")
+	require.Len(t, rota.Coverage, 1)
+	assert.Equal(t, int64(5), rota.Coverage[0].Orders)
+	assert.Equal(t, 3, rota.Coverage[0].Required)
+	assert.Equal(t, 1, rota.Coverage[0].Scheduled)
+
+	kinds := make([]staff.ConflictKind, 0, len(rota.Conflicts))
+	for _, c := range rota.Conflicts {
+		kinds = append(kinds, c.Kind)
+	}
+	assert.ElementsMatch(t, []staff.ConflictKind{staff.ConflictUnderstaffed, staff.ConflictDoubleBooked}, kinds)
+}
+
+func TestRota_NothingRostered_ShowsDemandOnly(t *testing.T) {
+	profileRepo, shiftRepo := new(mockProfileRepository), new(mockShiftRepository)
+	scheduleRepo, reservationRepo := new(mockScheduleRepository), new(mockReservationRepository)
+	branchID := uuid.New()
+	shiftRepo.On("FindShifts", mock.Anything, mock.Anything).Return([]*staff.Shift{}, nil)
+	scheduleRepo.On("FindSlotTemplates", mock.Anything, mock.Anything).Return([]*fulfilment.SlotTemplate{}, nil)
+
+	rota, err := queries.NewRotaHandler(profileRepo, shiftRepo, scheduleRepo, reservationRepo, testPolicy, time.UTC).
+		Handle(context.Background(), queries.RotaQuery{BranchID: branchID, Week: tuesday(12)})
+
+	require.NoError(t, err)
+	assert.Empty(t, rota.Shifts)
+	assert.Empty(t, rota.Conflicts)
+	shiftRepo.AssertNotCalled(t, "FindStaffShifts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,94 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// TimesheetsQuery is the input for the timesheets use case. From and To
+// are the first and last days covered; BranchID limits the timesheets to
+// staff based at a branch and AdminID to one member of staff.
+type TimesheetsQuery struct {
+	BranchID *uuid.UUID
+	AdminID  *uuid.UUID
+	From     time.Time
+	To       time.Time
+	ActorID  uuid.UUID
+}
+
+// TimesheetsHandler totals the time staff were rostered for and clocked
+// over a period, for payroll.
+type TimesheetsHandler struct {
+	adminRepo   admin.Repository
+	profileRepo staff.ProfileRepository
+	shiftRepo   staff.ShiftRepository
+	clockRepo   staff.ClockRepository
+	policy      staff.Policy
+	location    *time.Location
+}
+
+// NewTimesheetsHandler creates a new TimesheetsHandler. Days and weeks
+// start in location.
+func NewTimesheetsHandler(
+	adminRepo admin.Repository,
+	profileRepo staff.ProfileRepository,
+	shiftRepo staff.ShiftRepository,
+	clockRepo staff.ClockRepository,
+	policy staff.Policy,
+	location *time.Location,
+) *TimesheetsHandler {
+	return &TimesheetsHandler{
+		adminRepo:   adminRepo,
+		profileRepo: profileRepo,
+		shiftRepo:   shiftRepo,
+		clockRepo:   clockRepo,
+		policy:      policy,
+		location:    location,
+	}
+}
+
+// Handle returns a timesheet for each member of staff, by name. Time they
+// worked at branches outside the scope is left out.
+func (h *TimesheetsHandler) Handle(ctx context.Context, q TimesheetsQuery) ([]staff.Timesheet, staff.Period, error) {
+	actor, err := h.adminRepo.FindByID(ctx, q.ActorID)
+	if err != nil {
+		return nil, staff.Period{}, err
+	}
+	if !actor.HasPermission(admin.PermissionManageStaff) {
+		return nil, staff.Period{}, staff.ErrManageNotAllowed
+	}
+	period, err := staff.NewPeriod(q.From, q.To)
+	if err != nil {
+		return nil, staff.Period{}, err
+	}
+
+	profiles, err := h.profileRepo.FindAll(ctx, q.BranchID)
+	if err != nil {
+		return nil, period, fmt.Errorf("loading staff profiles: %w", err)
+	}
+	if q.AdminID != nil {
+		var only []*staff.Profile
+		for _, p := range profiles {
+			if p.AdminID() == *q.AdminID {
+				only = append(only, p)
+			}
+		}
+		profiles = only
+	}
+
+	f := staff.Filter{AdminID: q.AdminID, From: period.Start(h.location), To: period.End(h.location)}
+	entries, err := h.clockRepo.FindEntries(ctx, f)
+	if err != nil {
+		return nil, period, fmt.Errorf("loading clock entries: %w", err)
+	}
+	shifts, err := h.shiftRepo.FindShifts(ctx, f)
+	if err != nil {
+		return nil, period, fmt.Errorf("loading shifts: %w", err)
+	}
+	return h.policy.NewTimesheets(profiles, entries, shifts, h.location), period, nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// ChangePaymentMethodCommand is the input for the change payment method use case.
+type ChangePaymentMethodCommand struct {
+	SubscriptionID uuid.UUID
+	CustomerID     uuid.UUID
+	PaymentMethod  string
+}
+
+// ChangePaymentMethodHandler changes the card a subscription is charged to.
+// An order whose payment was declined is retried on the new card.
+type ChangePaymentMethodHandler struct {
+	subscriptionRepo subscription.Repository
+}
+
+// NewChangePaymentMethodHandler creates a new ChangePaymentMethodHandler.
+func NewChangePaymentMethodHandler(subscriptionRepo subscription.Repository) *ChangePaymentMethodHandler {
+	return &ChangePaymentMethodHandler{subscriptionRepo: subscriptionRepo}
+}
+
+// Handle executes the change payment method use case.
+func (h *ChangePaymentMethodHandler) Handle(ctx context.Context, cmd ChangePaymentMethodCommand) (*subscription.Subscription, error) {
+	s, err := findOwned(ctx, h.subscriptionRepo, cmd.SubscriptionID, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ChangePaymentMethod(cmd.PaymentMethod, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateSubscription(ctx, h.subscriptionRepo, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
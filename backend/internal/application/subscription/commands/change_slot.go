@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// ChangeSlotCommand is the input for the change slot use case.
+type ChangeSlotCommand struct {
+	SubscriptionID uuid.UUID
+	CustomerID     uuid.UUID
+	TemplateID     uuid.UUID
+}
+
+// ChangeSlotHandler moves a customer's future deliveries to another
+// fulfilment slot.
+type ChangeSlotHandler struct {
+	subscriptionRepo subscription.Repository
+	scheduleRepo     fulfilment.ScheduleRepository
+	settings         Settings
+}
+
+// NewChangeSlotHandler creates a new ChangeSlotHandler.
+func NewChangeSlotHandler(subscriptionRepo subscription.Repository, scheduleRepo fulfilment.ScheduleRepository, settings Settings) *ChangeSlotHandler {
+	return &ChangeSlotHandler{subscriptionRepo: subscriptionRepo, scheduleRepo: scheduleRepo, settings: settings}
+}
+
+// Handle executes the change slot use case. The next delivery moves to the
+// new slot's weekday in the same week, or the week after if its cutoff has
+// already passed.
+func (h *ChangeSlotHandler) Handle(ctx context.Context, cmd ChangeSlotCommand) (*subscription.Subscription, error) {
+	s, err := findOwned(ctx, h.subscriptionRepo, cmd.SubscriptionID, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := findSlotTemplate(ctx, h.scheduleRepo, cmd.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.ChangeSlot(tmpl.ID(), tmpl.Weekday(), now); err != nil {
+		return nil, err
+	}
+	h.settings.catchUp(s, tmpl, now)
+	if err := updateSubscription(ctx, h.subscriptionRepo, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
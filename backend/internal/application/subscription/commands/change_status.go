@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// PauseSubscriptionHandler stops orders being generated for a customer's
+// subscription until they resume it.
+type PauseSubscriptionHandler struct {
+	subscriptionRepo subscription.Repository
+}
+
+// NewPauseSubscriptionHandler creates a new PauseSubscriptionHandler.
+func NewPauseSubscriptionHandler(subscriptionRepo subscription.Repository) *PauseSubscriptionHandler {
+	return &PauseSubscriptionHandler{subscriptionRepo: subscriptionRepo}
+}
+
+// Handle executes the pause subscription use case.
+func (h *PauseSubscriptionHandler) Handle(ctx context.Context, subscriptionID, customerID uuid.UUID) (*subscription.Subscription, error) {
+	return transition(ctx, h.subscriptionRepo, subscriptionID, customerID, (*subscription.Subscription).Pause)
+}
+
+// CancelSubscriptionHandler ends a customer's subscription.
+type CancelSubscriptionHandler struct {
+	subscriptionRepo subscription.Repository
+}
+
+// NewCancelSubscriptionHandler creates a new CancelSubscriptionHandler.
+func NewCancelSubscriptionHandler(subscriptionRepo subscription.Repository) *CancelSubscriptionHandler {
+	return &CancelSubscriptionHandler{subscriptionRepo: subscriptionRepo}
+}
+
+// Handle executes the cancel subscription use case.
+func (h *CancelSubscriptionHandler) Handle(ctx context.Context, subscriptionID, customerID uuid.UUID) (*subscription.Subscription, error) {
+	return transition(ctx, h.subscriptionRepo, subscriptionID, customerID, (*subscription.Subscription).Cancel)
+}
+
+// ResumeSubscriptionHandler restarts a paused subscription, including one
+// paused after its payments kept failing.
+type ResumeSubscriptionHandler struct {
+	subscriptionRepo subscription.Repository
+	scheduleRepo     fulfilment.ScheduleRepository
+	settings         Settings
+}
+
+// NewResumeSubscriptionHandler creates a new ResumeSubscriptionHandler.
+func NewResumeSubscriptionHandler(subscriptionRepo subscription.Repository, scheduleRepo fulfilment.ScheduleRepository, settings Settings) *ResumeSubscriptionHandler {
+	return &ResumeSubscriptionHandler{subscriptionRepo: subscriptionRepo, scheduleRepo: scheduleRepo, settings: settings}
+}
+
+// Handle executes the resume subscription use case. Deliveries whose
+// cutoff passed while it was paused are not made up.
+func (h *ResumeSubscriptionHandler) Handle(ctx context.Context, subscriptionID, customerID uuid.UUID) (*subscription.Subscription, error) {
+	s, err := findOwned(ctx, h.subscriptionRepo, subscriptionID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := h.scheduleRepo.FindSlotTemplateByID(ctx, s.TemplateID())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.Resume(now); err != nil {
+		return nil, err
+	}
+	h.settings.catchUp(s, tmpl, now)
+	if err := updateSubscription(ctx, h.subscriptionRepo, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func transition(
+	ctx context.Context,
+	repo subscription.Repository,
+	subscriptionID, customerID uuid.UUID,
+	apply func(*subscription.Subscription, time.Time) error,
+) (*subscription.Subscription, error) {
+	s, err := findOwned(ctx, repo, subscriptionID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(s, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateSubscription(ctx, repo, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// CreateBoxCommand is the input for the create box use case.
+type CreateBoxCommand struct {
+	Name         string
+	Customizable bool
+	Items        []subscription.Item
+}
+
+// CreateBoxHandler adds a box customers can subscribe to.
+type CreateBoxHandler struct {
+	boxRepo subscription.BoxRepository
+}
+
+// NewCreateBoxHandler creates a new CreateBoxHandler.
+func NewCreateBoxHandler(boxRepo subscription.BoxRepository) *CreateBoxHandler {
+	return &CreateBoxHandler{boxRepo: boxRepo}
+}
+
+// Handle executes the create box use case.
+func (h *CreateBoxHandler) Handle(ctx context.Context, cmd CreateBoxCommand) (*subscription.Box, error) {
+	b, err := subscription.NewBox(uuid.New(), cmd.Name, cmd.Customizable, cmd.Items, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.boxRepo.Save(ctx, b); err != nil {
+		return nil, fmt.Errorf("saving box: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,509 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// RunRenewalsResult counts what a scheduler run did. Missed deliveries
+// had their cutoff pass before an order could be generated, e.g. while the
+// scheduler was not running. Declined payments will be retried; failed
+// renewals will not go ahead. Errored counts the subscriptions and renewals
+// that ran into an unexpected error and were failed so the run could go on.
+type RunRenewalsResult struct {
+	Generated int
+	Missed    int
+	Ordered   int
+	Declined  int
+	Failed    int
+	Paused    int
+	Notified  int
+	Errored   int
+}
+
+// RunRenewalsHandler is the subscription scheduler. It generates an order
+// for every delivery coming up to its cutoff, holds the slot and stock for
+// it, charges the customer's card and places the order once payment goes
+// through. Customers whose payment fails are told and charged again until
+// the cutoff. It is meant to run every few minutes; running it twice at
+// once or again straight away does no harm.
+type RunRenewalsHandler struct {
+	subscriptionRepo subscription.Repository
+	renewalRepo      subscription.RenewalRepository
+	boxRepo          subscription.BoxRepository
+	scheduleRepo     fulfilment.ScheduleRepository
+	slotRepo         fulfilment.ReservationRepository
+	stockRepo        inventory.ReservationRepository
+	orderRepo        order.Repository
+	paymentRepo      payment.Repository
+	gateway          payment.PaymentGateway
+	customerRepo     customer.Repository
+	pricer           *pricing.Pricer
+	notifier         subscription.Notifier
+	settings         Settings
+}
+
+// NewRunRenewalsHandler creates a new RunRenewalsHandler.
+func NewRunRenewalsHandler(
+	subscriptionRepo subscription.Repository,
+	renewalRepo subscription.RenewalRepository,
+	boxRepo subscription.BoxRepository,
+	scheduleRepo fulfilment.ScheduleRepository,
+	slotRepo fulfilment.ReservationRepository,
+	stockRepo inventory.ReservationRepository,
+	orderRepo order.Repository,
+	paymentRepo payment.Repository,
+	gateway payment.PaymentGateway,
+	customerRepo customer.Repository,
+	pricer *pricing.Pricer,
+	notifier subscription.Notifier,
+	settings Settings,
+) *RunRenewalsHandler {
+	return &RunRenewalsHandler{
+		subscriptionRepo: subscriptionRepo,
+		renewalRepo:      renewalRepo,
+		boxRepo:          boxRepo,
+		scheduleRepo:     scheduleRepo,
+		slotRepo:         slotRepo,
+		stockRepo:        stockRepo,
+		orderRepo:        orderRepo,
+		paymentRepo:      paymentRepo,
+		gateway:          gateway,
+		customerRepo:     customerRepo,
+		pricer:           pricer,
+		notifier:         notifier,
+		settings:         settings,
+	}
+}
+
+// Handle executes the run renewals use case as of at. A subscription or
+// renewal that runs into an unexpected error is failed, so that it cannot
+// hold up the others on every run, and the run carries on with the rest.
+// The errors are returned together at the end, along with the result.
+func (h *RunRenewalsHandler) Handle(ctx context.Context, at time.Time) (*RunRenewalsResult, error) {
+	result := &RunRenewalsResult{}
+	var errs []error
+
+	active := subscription.StatusActive
+	subs, err := h.subscriptionRepo.FindAll(ctx, subscription.Filter{Status: &active})
+	if err != nil {
+		return nil, fmt.Errorf("finding subscriptions: %w", err)
+	}
+	for _, s := range subs {
+		if r, err := h.generate(ctx, s, at, result); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", s.ID(), h.abandon(ctx, s.ID(), r, err, at, result)))
+		}
+	}
+
+	awaiting := subscription.RenewalAwaitingPayment
+	renewals, err := h.renewalRepo.FindAll(ctx, subscription.RenewalFilter{Status: &awaiting})
+	if err != nil {
+		return result, errors.Join(append(errs, fmt.Errorf("finding renewals: %w", err))...)
+	}
+	for _, r := range renewals {
+		switch {
+		case r.Overdue(at):
+			err = h.fail(ctx, r, "payment did not go through before the cutoff", at, result)
+		case r.Due(at):
+			err = h.charge(ctx, r, at, result)
+		default:
+			err = nil
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("renewal %s: %w", r.ID(), h.abandon(ctx, r.SubscriptionID(), r, err, at, result)))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// abandon fails whatever ran into err: the renewal, if one had been
+// generated and is still awaiting payment, or else the subscription's
+// next renewal, which counts against it as a failed order would. It
+// returns err along with anything that went wrong doing so.
+func (h *RunRenewalsHandler) abandon(ctx context.Context, subscriptionID uuid.UUID, r *subscription.Renewal, err error, at time.Time, result *RunRenewalsResult) error {
+	result.Errored++
+	if r != nil {
+		if r.Status() != subscription.RenewalAwaitingPayment {
+			return err
+		}
+		return errors.Join(err, h.fail(ctx, r, "the renewal could not be processed", at, result))
+	}
+
+	s, findErr := h.subscriptionRepo.FindByID(ctx, subscriptionID)
+	if findErr != nil {
+		return errors.Join(err, fmt.Errorf("loading subscription: %w", findErr))
+	}
+	if s.RenewalFailed(h.settings.MaxFailedRenewals, at) {
+		result.Paused++
+	}
+	return errors.Join(err, updateSubscription(ctx, h.subscriptionRepo, s))
+}
+
+// generate creates the renewal for a subscription's next delivery once it
+// is within GenerateAhead of its cutoff, and holds the slot and stock for
+// it. It returns the renewal once there is one, even with an error.
+func (h *RunRenewalsHandler) generate(ctx context.Context, s *subscription.Subscription, at time.Time, result *RunRenewalsResult) (*subscription.Renewal, error) {
+	tmpl, err := h.scheduleRepo.FindSlotTemplateByID(ctx, s.TemplateID())
+	if err != nil {
+		return nil, fmt.Errorf("loading slot template: %w", err)
+	}
+	missed := h.settings.catchUp(s, tmpl, at)
+	cutoff := h.settings.cutoff(tmpl, s.NextDelivery())
+	if at.Before(cutoff.Add(-h.settings.GenerateAhead)) {
+		if missed == 0 {
+			return nil, nil
+		}
+		if err := h.subscriptionRepo.Update(ctx, s); err != nil {
+			if errors.Is(err, subscription.ErrConcurrentUpdate) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("updating subscription: %w", err)
+		}
+		result.Missed += missed
+		return nil, nil
+	}
+
+	b, err := h.boxRepo.FindByID(ctx, s.BoxID())
+	if err != nil {
+		return nil, fmt.Errorf("loading box: %w", err)
+	}
+	cuts := b.Cuts(s.Swaps())
+	priced, priceErr := h.price(ctx, cuts, at)
+	if priceErr != nil && !errors.Is(priceErr, tax.ErrUnclassifiedProduct) && !errors.Is(priceErr, tax.ErrNoRate) {
+		return nil, priceErr
+	}
+
+	slot := tmpl.SlotOn(s.NextDelivery(), h.settings.Location)
+	r := subscription.NewRenewal(uuid.New(), s, tmpl.BranchID(), slot.StartsAt(), cutoff, uuid.New(), cuts, priced.GrossCents, at)
+	s.Advance(at)
+	if err := h.renewalRepo.Create(ctx, r, s); err != nil {
+		// Another run, or the customer, got there first.
+		if errors.Is(err, subscription.ErrDuplicateRenewal) || errors.Is(err, subscription.ErrConcurrentUpdate) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("saving renewal: %w", err)
+	}
+	result.Missed += missed
+	result.Generated++
+
+	if priceErr != nil {
+		return r, h.fail(ctx, r, "the box could not be priced: "+priceErr.Error(), at, result)
+	}
+	return r, h.hold(ctx, r, tmpl, slot, at, result)
+}
+
+// hold reserves the slot and stock for a renewal until its cutoff.
+func (h *RunRenewalsHandler) hold(ctx context.Context, r *subscription.Renewal, tmpl *fulfilment.SlotTemplate, slot fulfilment.Slot, at time.Time, result *RunRenewalsResult) error {
+	hours, err := h.scheduleRepo.FindOpeningHours(ctx, tmpl.BranchID())
+	if err != nil {
+		return fmt.Errorf("loading opening hours: %w", err)
+	}
+	closures, err := h.scheduleRepo.FindClosures(ctx, tmpl.BranchID(), slot.StartsAt(), slot.EndsAt())
+	if err != nil {
+		return fmt.Errorf("loading closures: %w", err)
+	}
+	if !fulfilment.NewSchedule(tmpl.BranchID(), hours, closures).Runs(tmpl, slot.StartsAt()) {
+		return h.fail(ctx, r, "the delivery slot does not run that day", at, result)
+	}
+
+	var grams int64
+	lines := make([]inventory.ReservationLine, 0, len(r.Cuts()))
+	for _, c := range r.Cuts() {
+		grams += c.Grams
+		lines = append(lines, inventory.ReservationLine{ProductID: c.ProductID, Grams: c.Grams})
+	}
+	holdFor := r.CutoffAt().Sub(at)
+
+	slotHold, err := fulfilment.NewReservation(uuid.New(), r.CustomerID(), slot, grams, holdFor, at)
+	if err != nil {
+		return err
+	}
+	if err := h.slotRepo.Reserve(ctx, slot, slotHold); err != nil {
+		if errors.Is(err, fulfilment.ErrSlotFull) {
+			return h.fail(ctx, r, "the delivery slot is full", at, result)
+		}
+		return fmt.Errorf("reserving slot: %w", err)
+	}
+	stockHold, err := inventory.NewReservation(uuid.New(), r.BranchID(), r.CustomerID(), lines, holdFor, at)
+	if err != nil {
+		return err
+	}
+	if err := h.stockRepo.Reserve(ctx, stockHold, at); err != nil {
+		if err := slotHold.Release(); err != nil {
+			return err
+		}
+		if err := h.slotRepo.Update(ctx, slotHold); err != nil {
+			return fmt.Errorf("releasing slot: %w", err)
+		}
+		if errors.Is(err, inventory.ErrInsufficientStock) {
+			return h.fail(ctx, r, "not enough stock for the box", at, result)
+		}
+		return fmt.Errorf("reserving stock: %w", err)
+	}
+
+	r.Reserved(slotHold.ID(), stockHold.ID(), at)
+	if err := h.renewalRepo.Update(ctx, r); err != nil {
+		return fmt.Errorf("updating renewal: %w", err)
+	}
+	return nil
+}
+
+// charge takes payment for a renewal and places its order, or records the
+// decline and when payment will be tried again.
+func (h *RunRenewalsHandler) charge(ctx context.Context, r *subscription.Renewal, at time.Time, result *RunRenewalsResult) error {
+	if r.SlotReservationID() == nil || r.StockReservationID() == nil {
+		return h.fail(ctx, r, "the slot and stock could not be held", at, result)
+	}
+	s, err := h.subscriptionRepo.FindByID(ctx, r.SubscriptionID())
+	if err != nil {
+		return fmt.Errorf("loading subscription: %w", err)
+	}
+
+	// Each attempt has its own key, so a run that stopped part-way picks up
+	// the same payment rather than charging twice.
+	key := fmt.Sprintf("subscription:%s:%d", r.ID(), r.Attempts()+1)
+	p, err := h.paymentRepo.FindByIdempotencyKey(ctx, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, payment.ErrPaymentNotFound):
+		p, err = payment.NewPayment(uuid.New(), r.OrderID(), r.CustomerID(), h.settings.Currency, r.TotalCents(),
+			h.settings.AuthorizationMarginBP, h.gateway.Name(), key, at)
+		if err != nil {
+			return err
+		}
+		if err := h.paymentRepo.Create(ctx, p); err != nil {
+			return fmt.Errorf("saving payment: %w", err)
+		}
+	default:
+		return fmt.Errorf("finding payment: %w", err)
+	}
+
+	if p.Status() == payment.StatusPending {
+		auth, err := h.gateway.Authorize(ctx, payment.AuthorizeRequest{
+			PaymentID:      p.ID().String(),
+			AmountCents:    p.AuthorizedCents(),
+			Currency:       p.Currency(),
+			PaymentMethod:  s.PaymentMethod(),
+			IdempotencyKey: "authorize:" + p.ID().String(),
+		})
+		switch {
+		case errors.Is(err, payment.ErrDeclined):
+			err = p.Fail(strings.TrimPrefix(err.Error(), payment.ErrDeclined.Error()+": "), at)
+		case err != nil:
+			return fmt.Errorf("authorizing payment: %w", err)
+		default:
+			err = p.Authorize(auth.ProviderRef, at)
+		}
+		if err != nil {
+			return err
+		}
+		if err := h.paymentRepo.Update(ctx, p); err != nil {
+			return fmt.Errorf("saving payment: %w", err)
+		}
+	}
+
+	if p.Status() == payment.StatusFailed {
+		retryAt, err := r.Declined(p.ID(), p.FailureReason(), h.settings.RetryIntervals, at)
+		if err != nil {
+			return err
+		}
+		if err := h.renewalRepo.Update(ctx, r); err != nil {
+			return fmt.Errorf("updating renewal: %w", err)
+		}
+		if retryAt == nil {
+			return h.failed(ctx, r, at, result)
+		}
+		result.Declined++
+		return h.notify(ctx, r, nil, at, result)
+	}
+
+	if err := h.place(ctx, r, at); err != nil {
+		return err
+	}
+	if err := r.Ordered(p.ID(), at); err != nil {
+		return err
+	}
+	if err := h.renewalRepo.Update(ctx, r); err != nil {
+		return fmt.Errorf("updating renewal: %w", err)
+	}
+	result.Ordered++
+	if s.FailedRenewals() > 0 {
+		s.RenewalPlaced(at)
+		if err := h.subscriptionRepo.Update(ctx, s); err != nil && !errors.Is(err, subscription.ErrConcurrentUpdate) {
+			return fmt.Errorf("updating subscription: %w", err)
+		}
+	}
+	return nil
+}
+
+// place creates a renewal's order, unless an earlier run already did, and
+// confirms the slot and stock held for it.
+func (h *RunRenewalsHandler) place(ctx context.Context, r *subscription.Renewal, at time.Time) error {
+	_, err := h.orderRepo.FindByID(ctx, r.OrderID())
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound):
+		priced, err := h.price(ctx, r.Cuts(), at)
+		if err != nil {
+			return err
+		}
+		lines := make([]order.Line, 0, len(r.Cuts()))
+		for i, c := range r.Cuts() {
+			p := priced.Lines[i]
+			lines = append(lines, order.Line{
+				ProductID:       c.ProductID,
+				Description:     c.Description,
+				TaxCategory:     string(p.Category),
+				Grams:           c.Grams,
+				PricePerKgCents: c.PricePerKgCents,
+				VATRateBP:       p.RateBP,
+				NetCents:        p.NetCents,
+				VATCents:        p.VATCents,
+				GrossCents:      p.GrossCents,
+			})
+		}
+		o, err := order.NewOrder(r.OrderID(), r.CustomerID(), r.BranchID(), lines, nil, at)
+		if err != nil {
+			return err
+		}
+		if err := h.orderRepo.Create(ctx, o); err != nil {
+			return fmt.Errorf("saving order: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("finding order: %w", err)
+	}
+
+	slotHold, err := h.slotRepo.FindByID(ctx, *r.SlotReservationID())
+	if err != nil {
+		return fmt.Errorf("loading slot reservation: %w", err)
+	}
+	if slotHold.Status() == fulfilment.ReservationHeld {
+		if err := slotHold.Confirm(r.OrderID(), at); err != nil {
+			return err
+		}
+		if err := h.slotRepo.Update(ctx, slotHold); err != nil {
+			return fmt.Errorf("confirming slot reservation: %w", err)
+		}
+	}
+	stockHold, err := h.stockRepo.FindByID(ctx, *r.StockReservationID())
+	if err != nil {
+		return fmt.Errorf("loading stock reservation: %w", err)
+	}
+	if stockHold.Status() == inventory.ReservationHeld {
+		if err := stockHold.Confirm(r.OrderID(), at); err != nil {
+			return err
+		}
+		if err := h.stockRepo.Update(ctx, stockHold); err != nil {
+			return fmt.Errorf("confirming stock reservation: %w", err)
+		}
+	}
+	return nil
+}
+
+// fail gives up on a renewal for reason.
+func (h *RunRenewalsHandler) fail(ctx context.Context, r *subscription.Renewal, reason string, at time.Time, result *RunRenewalsResult) error {
+	if err := r.Fail(reason, at); err != nil {
+		return err
+	}
+	if err := h.renewalRepo.Update(ctx, r); err != nil {
+		return fmt.Errorf("updating renewal: %w", err)
+	}
+	return h.failed(ctx, r, at, result)
+}
+
+// failed lets go of a failed renewal's holds, counts the failure against
+// its subscription and tells the customer. Holds already confirmed for the
+// renewal's order are left to it.
+func (h *RunRenewalsHandler) failed(ctx context.Context, r *subscription.Renewal, at time.Time, result *RunRenewalsResult) error {
+	result.Failed++
+	if id := r.SlotReservationID(); id != nil {
+		slotHold, err := h.slotRepo.FindByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("loading slot reservation: %w", err)
+		}
+		if slotHold.Status() == fulfilment.ReservationHeld {
+			if err := slotHold.Release(); err != nil {
+				return err
+			}
+			if err := h.slotRepo.Update(ctx, slotHold); err != nil {
+				return fmt.Errorf("releasing slot: %w", err)
+			}
+		}
+	}
+	if id := r.StockReservationID(); id != nil {
+		stockHold, err := h.stockRepo.FindByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("loading stock reservation: %w", err)
+		}
+		if stockHold.Status() == inventory.ReservationHeld {
+			if err := stockHold.Release(); err != nil {
+				return err
+			}
+			if err := h.stockRepo.Update(ctx, stockHold); err != nil {
+				return fmt.Errorf("releasing stock: %w", err)
+			}
+		}
+	}
+
+	s, err := h.subscriptionRepo.FindByID(ctx, r.SubscriptionID())
+	if err != nil {
+		return fmt.Errorf("loading subscription: %w", err)
+	}
+	paused := s.RenewalFailed(h.settings.MaxFailedRenewals, at)
+	if err := updateSubscription(ctx, h.subscriptionRepo, s); err != nil {
+		return err
+	}
+	if paused {
+		result.Paused++
+	}
+	return h.notify(ctx, r, &paused, at, result)
+}
+
+// notify tells the customer a renewal's payment was declined or that it
+// failed. paused is nil while payment will still be retried. A notice that
+// cannot be sent does not hold up the run.
+func (h *RunRenewalsHandler) notify(ctx context.Context, r *subscription.Renewal, paused *bool, at time.Time, result *RunRenewalsResult) error {
+	c, err := h.customerRepo.FindByID(ctx, r.CustomerID())
+	if err != nil {
+		return fmt.Errorf("loading customer: %w", err)
+	}
+	n := subscription.Notice{
+		SubscriptionID: r.SubscriptionID(),
+		RenewalID:      r.ID(),
+		CustomerID:     c.ID(),
+		Email:          c.Email().String(),
+		FullName:       c.FullName(),
+		DeliveryDate:   r.DeliveryDate(),
+		Reason:         r.FailureReason(),
+		Attempts:       r.Attempts(),
+		RetryAt:        r.NextAttemptAt(),
+	}
+	if paused != nil {
+		n.Paused = *paused
+	}
+	if err := h.notifier.RenewalFailed(ctx, n); err == nil {
+		result.Notified++
+	}
+	return nil
+}
+
+// price prices a box's cuts at their shelf prices.
+func (h *RunRenewalsHandler) price(ctx context.Context, cuts []subscription.Cut, at time.Time) (tax.Result, error) {
+	lines := make([]pricing.Line, 0, len(cuts))
+	for _, c := range cuts {
+		lines = append(lines, pricing.Line{ProductID: c.ProductID, AmountCents: order.PriceOf(c.Grams, c.PricePerKgCents)})
+	}
+	return h.pricer.Price(ctx, lines, at)
+}
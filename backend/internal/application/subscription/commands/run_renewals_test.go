@@ -0,0 +1,269 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockRenewalRepository struct {
+	mock.Mock
+}
+
+func (m *mockRenewalRepository) Create(ctx context.Context, r *subscription.Renewal, s *subscription.Subscription) error {
+	args := m.Called(ctx, r, s)
+	return args.Error(0)
+}
+
+func (m *mockRenewalRepository) Update(ctx context.Context, r *subscription.Renewal) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockRenewalRepository) FindByID(ctx context.Context, id uuid.UUID) (*subscription.Renewal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Renewal), args.Error(1)
+}
+
+func (m *mockRenewalRepository) FindAll(ctx context.Context, filter subscription.RenewalFilter) ([]*subscription.Renewal, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*subscription.Renewal), args.Error(1)
+}
+
+type mockSlotReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockSlotReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, r *fulfilment.Reservation) error {
+	args := m.Called(ctx, slot, r)
+	return args.Error(0)
+}
+
+func (m *mockSlotReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockSlotReservationRepository) Update(ctx context.Context, r *fulfilment.Reservation) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockSlotReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	args := m.Called(ctx, templateIDs, from, to, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[fulfilment.SlotKey]int64), args.Error(1)
+}
+
+func (m *mockSlotReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+func (m *mockSlotReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	args := m.Called(ctx, templateIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Reservation), args.Error(1)
+}
+
+type mockStockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockStockReservationRepository) Reserve(ctx context.Context, r *inventory.Reservation, now time.Time) error {
+	args := m.Called(ctx, r, now)
+	return args.Error(0)
+}
+
+func (m *mockStockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Reservation), args.Error(1)
+}
+
+func (m *mockStockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*inventory.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Reservation), args.Error(1)
+}
+
+func (m *mockStockReservationRepository) Update(ctx context.Context, r *inventory.Reservation) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockStockReservationRepository) Fulfil(ctx context.Context, r *inventory.Reservation, actorID *uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, r, actorID, now)
+	return args.Error(0)
+}
+
+type mockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *mockCustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCustomerRepository) FindByEmail(ctx context.Context, email customer.Email) (*customer.Customer, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+func (m *mockCustomerRepository) ExistsByEmail(ctx context.Context, email customer.Email) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockNotifier struct {
+	mock.Mock
+}
+
+func (m *mockNotifier) RenewalFailed(ctx context.Context, n subscription.Notice) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+// --- Fixtures ---
+
+var renewalSettings = commands.Settings{
+	Location:          time.UTC,
+	LeadTime:          2 * time.Hour,
+	GenerateAhead:     48 * time.Hour,
+	MaxFailedRenewals: 3,
+}
+
+// runRenewalsDeps are the repositories a run needs for the tests below;
+// those left out are never reached.
+type runRenewalsDeps struct {
+	subscriptions *mockSubscriptionRepository
+	renewals      *mockRenewalRepository
+	schedule      *mockScheduleRepository
+	slots         *mockSlotReservationRepository
+	stock         *mockStockReservationRepository
+	customers     *mockCustomerRepository
+	notifier      *mockNotifier
+}
+
+func newRunRenewals() (*commands.RunRenewalsHandler, runRenewalsDeps) {
+	d := runRenewalsDeps{
+		subscriptions: new(mockSubscriptionRepository),
+		renewals:      new(mockRenewalRepository),
+		schedule:      new(mockScheduleRepository),
+		slots:         new(mockSlotReservationRepository),
+		stock:         new(mockStockReservationRepository),
+		customers:     new(mockCustomerRepository),
+		notifier:      new(mockNotifier),
+	}
+	h := commands.NewRunRenewalsHandler(d.subscriptions, d.renewals, nil, d.schedule, d.slots, d.stock,
+		nil, nil, nil, d.customers, nil, d.notifier, renewalSettings)
+	return h, d
+}
+
+// --- Tests ---
+
+func TestRunRenewals_BrokenSubscription_DoesNotHoldUpTheRest(t *testing.T) {
+	handler, d := newRunRenewals()
+	broken, _ := newSubscription(t, uuid.New(), time.Now().UTC().AddDate(0, 0, 14))
+	fine, fineTmpl := newSubscription(t, uuid.New(), time.Now().UTC().AddDate(0, 0, 14))
+
+	d.subscriptions.On("FindAll", mock.Anything, mock.Anything).Return([]*subscription.Subscription{broken, fine}, nil)
+	d.schedule.On("FindSlotTemplateByID", mock.Anything, broken.TemplateID()).Return(nil, errors.New("connection reset"))
+	d.schedule.On("FindSlotTemplateByID", mock.Anything, fine.TemplateID()).Return(fineTmpl, nil)
+	d.subscriptions.On("FindByID", mock.Anything, broken.ID()).Return(broken, nil)
+	d.subscriptions.On("Update", mock.Anything, broken).Return(nil)
+	d.renewals.On("FindAll", mock.Anything, mock.Anything).Return([]*subscription.Renewal{}, nil)
+
+	result, err := handler.Handle(context.Background(), time.Now())
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "connection reset")
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.Errored)
+	assert.Equal(t, 1, broken.FailedRenewals())
+	assert.Equal(t, 0, fine.FailedRenewals())
+	d.schedule.AssertExpectations(t)
+	d.renewals.AssertExpectations(t)
+}
+
+func TestRunRenewals_OverdueRenewal_LeavesConfirmedHoldsAlone(t *testing.T) {
+	handler, d := newRunRenewals()
+	now := time.Now()
+	s, tmpl := newSubscription(t, uuid.New(), now.UTC().AddDate(0, 0, 7))
+	orderID := uuid.New()
+	r := subscription.NewRenewal(uuid.New(), s, tmpl.BranchID(), now.Add(time.Hour), now.Add(-time.Minute), orderID,
+		nil, 1800, now.Add(-48*time.Hour))
+	slotHold := fulfilment.ReconstructReservation(uuid.New(), tmpl.ID(), s.CustomerID(), now.Add(time.Hour), now.Add(3*time.Hour),
+		1, fulfilment.ReservationConfirmed, &orderID, now.Add(-time.Minute), now.Add(-48*time.Hour))
+	stockHold := inventory.ReconstructReservation(uuid.New(), tmpl.BranchID(), s.CustomerID(), nil,
+		inventory.ReservationConfirmed, &orderID, now.Add(-time.Minute), now.Add(-48*time.Hour))
+	r.Reserved(slotHold.ID(), stockHold.ID(), now.Add(-48*time.Hour))
+	email, err := customer.NewEmail("box@example.com")
+	require.NoError(t, err)
+	c := customer.ReconstructCustomer(s.CustomerID(), email, "hash", "Box Customer", customer.PhoneNumber{})
+
+	d.subscriptions.On("FindAll", mock.Anything, mock.Anything).Return([]*subscription.Subscription{}, nil)
+	d.renewals.On("FindAll", mock.Anything, mock.Anything).Return([]*subscription.Renewal{r}, nil)
+	d.renewals.On("Update", mock.Anything, r).Return(nil)
+	d.slots.On("FindByID", mock.Anything, slotHold.ID()).Return(slotHold, nil)
+	d.stock.On("FindByID", mock.Anything, stockHold.ID()).Return(stockHold, nil)
+	d.subscriptions.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	d.subscriptions.On("Update", mock.Anything, s).Return(nil)
+	d.customers.On("FindByID", mock.Anything, s.CustomerID()).Return(c, nil)
+	d.notifier.On("RenewalFailed", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := handler.Handle(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, subscription.RenewalFailed, r.Status())
+	assert.Equal(t, fulfilment.ReservationConfirmed, slotHold.Status())
+	assert.Equal(t, inventory.ReservationConfirmed, stockHold.Status())
+	d.slots.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	d.stock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// Settings say when orders are generated for subscription deliveries and
+// how declined payments are chased. A delivery's cutoff is LeadTime before
+// its slot starts, the same lead time checkout applies to slots, and its
+// order is generated GenerateAhead before that. A declined payment is
+// retried after each of RetryIntervals in turn, and a subscription is paused
+// once MaxFailedRenewals orders in a row have failed. Cards are charged in
+// Currency and held for AuthorizationMarginBP basis points over the total.
+type Settings struct {
+	Location              *time.Location
+	LeadTime              time.Duration
+	GenerateAhead         time.Duration
+	RetryIntervals        []time.Duration
+	MaxFailedRenewals     int
+	Currency              string
+	AuthorizationMarginBP int
+}
+
+// cutoff is when the delivery on date stops taking changes.
+func (s Settings) cutoff(tmpl *fulfilment.SlotTemplate, date time.Time) time.Time {
+	return tmpl.SlotOn(date, s.Location).StartsAt().Add(-s.LeadTime)
+}
+
+// catchUp moves a subscription's next delivery past dates whose cutoff has
+// gone by and returns how many were passed over.
+func (s Settings) catchUp(sub *subscription.Subscription, tmpl *fulfilment.SlotTemplate, now time.Time) int {
+	missed := 0
+	for !now.Before(s.cutoff(tmpl, sub.NextDelivery())) {
+		sub.Advance(now)
+		missed++
+	}
+	return missed
+}
+
+// findSlotTemplate loads a slot template a subscription can be delivered in.
+func findSlotTemplate(ctx context.Context, scheduleRepo fulfilment.ScheduleRepository, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	tmpl, err := scheduleRepo.FindSlotTemplateByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, fulfilment.ErrSlotTemplateNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("loading slot template: %w", err)
+	}
+	if !tmpl.IsActive() {
+		return nil, fulfilment.ErrSlotUnavailable
+	}
+	return tmpl, nil
+}
+
+// findOwned loads a customer's subscription. Another customer's
+// subscription is reported as not found.
+func findOwned(ctx context.Context, repo subscription.Repository, id, customerID uuid.UUID) (*subscription.Subscription, error) {
+	s, err := repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.CustomerID() != customerID {
+		return nil, subscription.ErrSubscriptionNotFound
+	}
+	return s, nil
+}
+
+func updateSubscription(ctx context.Context, repo subscription.Repository, s *subscription.Subscription) error {
+	if err := repo.Update(ctx, s); err != nil {
+		if errors.Is(err, subscription.ErrConcurrentUpdate) {
+			return err
+		}
+		return fmt.Errorf("updating subscription: %w", err)
+	}
+	return nil
+}
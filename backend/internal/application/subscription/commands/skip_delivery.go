@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// SkipDeliveryCommand is the input for the skip delivery use case.
+type SkipDeliveryCommand struct {
+	SubscriptionID uuid.UUID
+	CustomerID     uuid.UUID
+	Date           string // YYYY-MM-DD
+}
+
+// SkipDeliveryHandler leaves out one delivery of a customer's subscription,
+// e.g. while they are away.
+type SkipDeliveryHandler struct {
+	subscriptionRepo subscription.Repository
+	scheduleRepo     fulfilment.ScheduleRepository
+	settings         Settings
+}
+
+// NewSkipDeliveryHandler creates a new SkipDeliveryHandler.
+func NewSkipDeliveryHandler(subscriptionRepo subscription.Repository, scheduleRepo fulfilment.ScheduleRepository, settings Settings) *SkipDeliveryHandler {
+	return &SkipDeliveryHandler{subscriptionRepo: subscriptionRepo, scheduleRepo: scheduleRepo, settings: settings}
+}
+
+// Handle executes the skip delivery use case. A delivery can be skipped
+// until its cutoff, unless its order has already been generated.
+func (h *SkipDeliveryHandler) Handle(ctx context.Context, cmd SkipDeliveryCommand) (*subscription.Subscription, error) {
+	date, err := fulfilment.ParseDate(cmd.Date, h.settings.Location)
+	if err != nil {
+		return nil, err
+	}
+	s, err := findOwned(ctx, h.subscriptionRepo, cmd.SubscriptionID, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := h.scheduleRepo.FindSlotTemplateByID(ctx, s.TemplateID())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !now.Before(h.settings.cutoff(tmpl, date)) {
+		return nil, subscription.ErrPastCutoff
+	}
+	if err := s.Skip(date, now); err != nil {
+		return nil, err
+	}
+	if err := updateSubscription(ctx, h.subscriptionRepo, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
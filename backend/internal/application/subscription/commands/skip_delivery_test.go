@@ -0,0 +1,88 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubscription(t *testing.T, customerID uuid.UUID, first time.Time) (*subscription.Subscription, *fulfilment.SlotTemplate) {
+	t.Helper()
+	tmpl := newTemplate(t, first.Weekday())
+	s, err := subscription.NewSubscription(uuid.New(), customerID, newBox(t, false), subscription.CadenceWeekly,
+		tmpl.ID(), tmpl.Weekday(), first, "pm_card_visa", time.Now())
+	require.NoError(t, err)
+	return s, tmpl
+}
+
+func TestSkipDelivery_LaterDate(t *testing.T) {
+	subscriptionRepo := new(mockSubscriptionRepository)
+	scheduleRepo := new(mockScheduleRepository)
+	handler := commands.NewSkipDeliveryHandler(subscriptionRepo, scheduleRepo, settings)
+
+	customerID := uuid.New()
+	first := nextWeek()
+	s, tmpl := newSubscription(t, customerID, first)
+	skip := first.AddDate(0, 0, 7)
+	subscriptionRepo.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	subscriptionRepo.On("Update", mock.Anything, s).Return(nil)
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+
+	got, err := handler.Handle(context.Background(), commands.SkipDeliveryCommand{
+		SubscriptionID: s.ID(),
+		CustomerID:     customerID,
+		Date:           skip.Format(time.DateOnly),
+	})
+
+	require.NoError(t, err)
+	upcoming := got.Upcoming(2)
+	assert.Equal(t, first.Format(time.DateOnly), upcoming[0].Format(time.DateOnly))
+	assert.Equal(t, first.AddDate(0, 0, 14).Format(time.DateOnly), upcoming[1].Format(time.DateOnly))
+	subscriptionRepo.AssertExpectations(t)
+}
+
+func TestSkipDelivery_NotScheduled(t *testing.T) {
+	subscriptionRepo := new(mockSubscriptionRepository)
+	scheduleRepo := new(mockScheduleRepository)
+	handler := commands.NewSkipDeliveryHandler(subscriptionRepo, scheduleRepo, settings)
+
+	customerID := uuid.New()
+	first := nextWeek()
+	s, tmpl := newSubscription(t, customerID, first)
+	subscriptionRepo.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+
+	_, err := handler.Handle(context.Background(), commands.SkipDeliveryCommand{
+		SubscriptionID: s.ID(),
+		CustomerID:     customerID,
+		Date:           first.AddDate(0, 0, 3).Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrNotScheduled)
+	subscriptionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSkipDelivery_OtherCustomer(t *testing.T) {
+	subscriptionRepo := new(mockSubscriptionRepository)
+	handler := commands.NewSkipDeliveryHandler(subscriptionRepo, new(mockScheduleRepository), settings)
+
+	first := nextWeek()
+	s, _ := newSubscription(t, uuid.New(), first)
+	subscriptionRepo.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+
+	_, err := handler.Handle(context.Background(), commands.SkipDeliveryCommand{
+		SubscriptionID: s.ID(),
+		CustomerID:     uuid.New(),
+		Date:           first.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrSubscriptionNotFound)
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// SubscribeCommand is the input for the subscribe use case. PaymentMethod
+// is the provider's token for the card every order is charged to.
+type SubscribeCommand struct {
+	CustomerID    uuid.UUID
+	BoxID         uuid.UUID
+	Cadence       string
+	TemplateID    uuid.UUID
+	FirstDelivery string // YYYY-MM-DD
+	PaymentMethod string
+}
+
+// SubscribeHandler signs a customer up for regular deliveries of a box.
+type SubscribeHandler struct {
+	boxRepo          subscription.BoxRepository
+	subscriptionRepo subscription.Repository
+	scheduleRepo     fulfilment.ScheduleRepository
+	settings         Settings
+}
+
+// NewSubscribeHandler creates a new SubscribeHandler.
+func NewSubscribeHandler(boxRepo subscription.BoxRepository, subscriptionRepo subscription.Repository, scheduleRepo fulfilment.ScheduleRepository, settings Settings) *SubscribeHandler {
+	return &SubscribeHandler{boxRepo: boxRepo, subscriptionRepo: subscriptionRepo, scheduleRepo: scheduleRepo, settings: settings}
+}
+
+// Handle executes the subscribe use case. The first delivery must fall on
+// the slot's weekday and its cutoff must not have passed.
+func (h *SubscribeHandler) Handle(ctx context.Context, cmd SubscribeCommand) (*subscription.Subscription, error) {
+	cadence, err := subscription.NewCadence(cmd.Cadence)
+	if err != nil {
+		return nil, err
+	}
+	first, err := fulfilment.ParseDate(cmd.FirstDelivery, h.settings.Location)
+	if err != nil {
+		return nil, err
+	}
+	b, err := h.boxRepo.FindByID(ctx, cmd.BoxID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := findSlotTemplate(ctx, h.scheduleRepo, cmd.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s, err := subscription.NewSubscription(uuid.New(), cmd.CustomerID, b, cadence, tmpl.ID(), tmpl.Weekday(),
+		first, cmd.PaymentMethod, now)
+	if err != nil {
+		return nil, err
+	}
+	if !now.Before(h.settings.cutoff(tmpl, s.NextDelivery())) {
+		return nil, subscription.ErrPastCutoff
+	}
+
+	if err := h.subscriptionRepo.Create(ctx, s); err != nil {
+		return nil, fmt.Errorf("saving subscription: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,269 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockBoxRepository struct {
+	mock.Mock
+}
+
+func (m *mockBoxRepository) Save(ctx context.Context, b *subscription.Box) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *mockBoxRepository) FindByID(ctx context.Context, id uuid.UUID) (*subscription.Box, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Box), args.Error(1)
+}
+
+func (m *mockBoxRepository) FindAll(ctx context.Context, activeOnly bool) ([]*subscription.Box, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*subscription.Box), args.Error(1)
+}
+
+type mockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *mockSubscriptionRepository) Create(ctx context.Context, s *subscription.Subscription) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *mockSubscriptionRepository) Update(ctx context.Context, s *subscription.Subscription) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *mockSubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*subscription.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Subscription), args.Error(1)
+}
+
+func (m *mockSubscriptionRepository) FindAll(ctx context.Context, filter subscription.Filter) ([]*subscription.Subscription, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*subscription.Subscription), args.Error(1)
+}
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	args := m.Called(ctx, branchID, hours)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	args := m.Called(ctx, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.OpeningHours), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveClosure(ctx context.Context, closure *fulfilment.Closure) error {
+	args := m.Called(ctx, closure)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	args := m.Called(ctx, branchID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.Closure), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSlotTemplate(ctx context.Context, template *fulfilment.SlotTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fulfilment.SlotTemplate), args.Error(1)
+}
+
+func (m *mockScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fulfilment.SlotTemplate), args.Error(1)
+}
+
+// --- Fixtures ---
+
+var settings = commands.Settings{Location: time.UTC, LeadTime: 2 * time.Hour}
+
+func newBox(t *testing.T, customizable bool) *subscription.Box {
+	t.Helper()
+	lamb := subscription.Cut{ProductID: uuid.New(), Description: "Lamb shoulder", Grams: 1000, PricePerKgCents: 1800}
+	item := subscription.Item{Cut: lamb}
+	if customizable {
+		item.Substitutes = []subscription.Cut{{ProductID: uuid.New(), Description: "Lamb leg", Grams: 1000, PricePerKgCents: 2000}}
+	}
+	b, err := subscription.NewBox(uuid.New(), "Family box", customizable, []subscription.Item{item}, time.Now())
+	require.NoError(t, err)
+	return b
+}
+
+func newTemplate(t *testing.T, weekday time.Weekday) *fulfilment.SlotTemplate {
+	t.Helper()
+	from, err := fulfilment.ParseTimeOfDay("10:00")
+	require.NoError(t, err)
+	to, err := fulfilment.ParseTimeOfDay("12:00")
+	require.NoError(t, err)
+	capacity, err := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 10)
+	require.NoError(t, err)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), uuid.New(), nil, fulfilment.MethodCollection, weekday, from, to, capacity)
+	require.NoError(t, err)
+	return tmpl
+}
+
+// nextWeek returns a date a week from now, far enough ahead to clear the lead time.
+func nextWeek() time.Time {
+	return time.Now().UTC().AddDate(0, 0, 7)
+}
+
+// --- Tests ---
+
+func TestSubscribe_Success(t *testing.T) {
+	boxRepo := new(mockBoxRepository)
+	subscriptionRepo := new(mockSubscriptionRepository)
+	scheduleRepo := new(mockScheduleRepository)
+	handler := commands.NewSubscribeHandler(boxRepo, subscriptionRepo, scheduleRepo, settings)
+
+	b := newBox(t, false)
+	first := nextWeek()
+	tmpl := newTemplate(t, first.Weekday())
+	customerID := uuid.New()
+	boxRepo.On("FindByID", mock.Anything, b.ID()).Return(b, nil)
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+	subscriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*subscription.Subscription")).Return(nil)
+
+	s, err := handler.Handle(context.Background(), commands.SubscribeCommand{
+		CustomerID:    customerID,
+		BoxID:         b.ID(),
+		Cadence:       "fortnightly",
+		TemplateID:    tmpl.ID(),
+		FirstDelivery: first.Format(time.DateOnly),
+		PaymentMethod: "pm_card_visa",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, customerID, s.CustomerID())
+	assert.Equal(t, subscription.CadenceFortnightly, s.Cadence())
+	assert.Equal(t, subscription.StatusActive, s.Status())
+	assert.Equal(t, first.Format(time.DateOnly), s.NextDelivery().Format(time.DateOnly))
+	subscriptionRepo.AssertExpectations(t)
+}
+
+func TestSubscribe_WrongWeekday(t *testing.T) {
+	boxRepo := new(mockBoxRepository)
+	subscriptionRepo := new(mockSubscriptionRepository)
+	scheduleRepo := new(mockScheduleRepository)
+	handler := commands.NewSubscribeHandler(boxRepo, subscriptionRepo, scheduleRepo, settings)
+
+	b := newBox(t, false)
+	first := nextWeek()
+	tmpl := newTemplate(t, first.AddDate(0, 0, 1).Weekday())
+	boxRepo.On("FindByID", mock.Anything, b.ID()).Return(b, nil)
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+
+	_, err := handler.Handle(context.Background(), commands.SubscribeCommand{
+		CustomerID:    uuid.New(),
+		BoxID:         b.ID(),
+		Cadence:       "weekly",
+		TemplateID:    tmpl.ID(),
+		FirstDelivery: first.Format(time.DateOnly),
+		PaymentMethod: "pm_card_visa",
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrWrongWeekday)
+	subscriptionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSubscribe_PastCutoff(t *testing.T) {
+	boxRepo := new(mockBoxRepository)
+	subscriptionRepo := new(mockSubscriptionRepository)
+	scheduleRepo := new(mockScheduleRepository)
+	handler := commands.NewSubscribeHandler(boxRepo, subscriptionRepo, scheduleRepo, settings)
+
+	b := newBox(t, false)
+	first := time.Now().UTC().AddDate(0, 0, -1)
+	tmpl := newTemplate(t, first.Weekday())
+	boxRepo.On("FindByID", mock.Anything, b.ID()).Return(b, nil)
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+
+	_, err := handler.Handle(context.Background(), commands.SubscribeCommand{
+		CustomerID:    uuid.New(),
+		BoxID:         b.ID(),
+		Cadence:       "weekly",
+		TemplateID:    tmpl.ID(),
+		FirstDelivery: first.Format(time.DateOnly),
+		PaymentMethod: "pm_card_visa",
+	})
+
+	assert.ErrorIs(t, err, subscription.ErrPastCutoff)
+}
+
+func TestSubscribe_InvalidCadence(t *testing.T) {
+	handler := commands.NewSubscribeHandler(new(mockBoxRepository), new(mockSubscriptionRepository), new(mockScheduleRepository), settings)
+
+	_, err := handler.Handle(context.Background(), commands.SubscribeCommand{Cadence: "daily"})
+
+	assert.ErrorIs(t, err, subscription.ErrInvalidCadence)
+}
+
+func TestSubscribe_InactiveSlot(t *testing.T) {
+	boxRepo := new(mockBoxRepository)
+	scheduleRepo := new(mockScheduleRepository)
+	handler := commands.NewSubscribeHandler(boxRepo, new(mockSubscriptionRepository), scheduleRepo, settings)
+
+	b := newBox(t, false)
+	first := nextWeek()
+	tmpl := newTemplate(t, first.Weekday())
+	tmpl.Deactivate()
+	boxRepo.On("FindByID", mock.Anything, b.ID()).Return(b, nil)
+	scheduleRepo.On("FindSlotTemplateByID", mock.Anything, tmpl.ID()).Return(tmpl, nil)
+
+	_, err := handler.Handle(context.Background(), commands.SubscribeCommand{
+		BoxID:         b.ID(),
+		Cadence:       "weekly",
+		TemplateID:    tmpl.ID(),
+		FirstDelivery: first.Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, fulfilment.ErrSlotUnavailable)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// SwapCutCommand is the input for the swap cut use case. Swapping a cut for
+// itself puts it back.
+type SwapCutCommand struct {
+	SubscriptionID uuid.UUID
+	CustomerID     uuid.UUID
+	ProductID      uuid.UUID
+	SubstituteID   uuid.UUID
+}
+
+// SwapCutHandler swaps a cut in a customizable box for one of the
+// substitutes it offers, from the next order generated on.
+type SwapCutHandler struct {
+	subscriptionRepo subscription.Repository
+	boxRepo          subscription.BoxRepository
+}
+
+// NewSwapCutHandler creates a new SwapCutHandler.
+func NewSwapCutHandler(subscriptionRepo subscription.Repository, boxRepo subscription.BoxRepository) *SwapCutHandler {
+	return &SwapCutHandler{subscriptionRepo: subscriptionRepo, boxRepo: boxRepo}
+}
+
+// Handle executes the swap cut use case.
+func (h *SwapCutHandler) Handle(ctx context.Context, cmd SwapCutCommand) (*subscription.Subscription, error) {
+	s, err := findOwned(ctx, h.subscriptionRepo, cmd.SubscriptionID, cmd.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := h.boxRepo.FindByID(ctx, s.BoxID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Swap(b, cmd.ProductID, cmd.SubstituteID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateSubscription(ctx, h.subscriptionRepo, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// UpdateBoxCommand is the input for the update box use case.
+type UpdateBoxCommand struct {
+	BoxID        uuid.UUID
+	Name         string
+	Customizable bool
+	Items        []subscription.Item
+	Active       bool
+}
+
+// UpdateBoxHandler changes a box's contents or withdraws it. Existing
+// subscribers get the new contents from their next order.
+type UpdateBoxHandler struct {
+	boxRepo subscription.BoxRepository
+}
+
+// NewUpdateBoxHandler creates a new UpdateBoxHandler.
+func NewUpdateBoxHandler(boxRepo subscription.BoxRepository) *UpdateBoxHandler {
+	return &UpdateBoxHandler{boxRepo: boxRepo}
+}
+
+// Handle executes the update box use case.
+func (h *UpdateBoxHandler) Handle(ctx context.Context, cmd UpdateBoxCommand) (*subscription.Box, error) {
+	b, err := h.boxRepo.FindByID(ctx, cmd.BoxID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Update(cmd.Name, cmd.Customizable, cmd.Items, cmd.Active, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.boxRepo.Save(ctx, b); err != nil {
+		return nil, fmt.Errorf("saving box: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// ListBoxesHandler lists subscription boxes.
+type ListBoxesHandler struct {
+	boxRepo subscription.BoxRepository
+}
+
+// NewListBoxesHandler creates a new ListBoxesHandler.
+func NewListBoxesHandler(boxRepo subscription.BoxRepository) *ListBoxesHandler {
+	return &ListBoxesHandler{boxRepo: boxRepo}
+}
+
+// Handle returns boxes by name, only those open to new subscribers if
+// activeOnly is set.
+func (h *ListBoxesHandler) Handle(ctx context.Context, activeOnly bool) ([]*subscription.Box, error) {
+	boxes, err := h.boxRepo.FindAll(ctx, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("querying subscription boxes: %w", err)
+	}
+	return boxes, nil
+}
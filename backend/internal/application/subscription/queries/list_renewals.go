@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// ListRenewalsHandler lists the orders generated for subscriptions.
+type ListRenewalsHandler struct {
+	renewalRepo subscription.RenewalRepository
+}
+
+// NewListRenewalsHandler creates a new ListRenewalsHandler.
+func NewListRenewalsHandler(renewalRepo subscription.RenewalRepository) *ListRenewalsHandler {
+	return &ListRenewalsHandler{renewalRepo: renewalRepo}
+}
+
+// Handle returns renewals matching filter by delivery date.
+func (h *ListRenewalsHandler) Handle(ctx context.Context, filter subscription.RenewalFilter) ([]*subscription.Renewal, error) {
+	renewals, err := h.renewalRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying subscription renewals: %w", err)
+	}
+	return renewals, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// ListSubscriptionsHandler lists subscriptions.
+type ListSubscriptionsHandler struct {
+	subscriptionRepo subscription.Repository
+}
+
+// NewListSubscriptionsHandler creates a new ListSubscriptionsHandler.
+func NewListSubscriptionsHandler(subscriptionRepo subscription.Repository) *ListSubscriptionsHandler {
+	return &ListSubscriptionsHandler{subscriptionRepo: subscriptionRepo}
+}
+
+// Handle returns subscriptions matching filter, oldest first.
+func (h *ListSubscriptionsHandler) Handle(ctx context.Context, filter subscription.Filter) ([]*subscription.Subscription, error) {
+	subs, err := h.subscriptionRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querying subscriptions: %w", err)
+	}
+	return subs, nil
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// AssignProductCategoryCommand is the input for the assign product category
+// use case.
+type AssignProductCategoryCommand struct {
+	ProductID uuid.UUID
+	Category  string
+	ActorID   uuid.UUID
+}
+
+// AssignProductCategoryHandler sets the tax category a catalog product is
+// sold under. Orders placed afterwards are taxed under the new category;
+// existing orders keep what they were charged.
+type AssignProductCategoryHandler struct {
+	rateRepo    tax.RateRepository
+	productRepo tax.ProductRepository
+	adminRepo   admin.Repository
+}
+
+// NewAssignProductCategoryHandler creates a new AssignProductCategoryHandler.
+func NewAssignProductCategoryHandler(rateRepo tax.RateRepository, productRepo tax.ProductRepository, adminRepo admin.Repository) *AssignProductCategoryHandler {
+	return &AssignProductCategoryHandler{rateRepo: rateRepo, productRepo: productRepo, adminRepo: adminRepo}
+}
+
+// Handle returns ErrNoRate if the category has never had a rate, which
+// catches misspelt categories before any sale is priced with them. Only
+// managers may change a product's category.
+func (h *AssignProductCategoryHandler) Handle(ctx context.Context, cmd AssignProductCategoryCommand) (tax.Category, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return "", err
+	}
+	category, err := tax.NewCategory(cmd.Category)
+	if err != nil {
+		return "", err
+	}
+
+	table, err := h.rateRepo.FindAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading tax rates: %w", err)
+	}
+	if !slices.Contains(table.Categories(), category) {
+		return "", tax.ErrNoRate
+	}
+
+	if err := h.productRepo.AssignCategory(ctx, cmd.ProductID, category); err != nil {
+		return "", fmt.Errorf("assigning product tax category: %w", err)
+	}
+	return category, nil
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// CreateRateCommand is the input for the create rate use case.
+type CreateRateCommand struct {
+	Category      string
+	RateBP        int
+	EffectiveFrom string // YYYY-MM-DD
+	ActorID       uuid.UUID
+}
+
+// CreateRateHandler adds a rate to the rate table. Rate changes are
+// scheduled by adding the new rate with a future effective date.
+type CreateRateHandler struct {
+	rateRepo  tax.RateRepository
+	adminRepo admin.Repository
+}
+
+// NewCreateRateHandler creates a new CreateRateHandler.
+func NewCreateRateHandler(rateRepo tax.RateRepository, adminRepo admin.Repository) *CreateRateHandler {
+	return &CreateRateHandler{rateRepo: rateRepo, adminRepo: adminRepo}
+}
+
+// Handle executes the create rate use case. Only managers may change the
+// rate table.
+func (h *CreateRateHandler) Handle(ctx context.Context, cmd CreateRateCommand) (*tax.Rate, error) {
+	if err := manager(ctx, h.adminRepo, cmd.ActorID); err != nil {
+		return nil, err
+	}
+	category, err := tax.NewCategory(cmd.Category)
+	if err != nil {
+		return nil, err
+	}
+	effectiveFrom, err := time.Parse(time.DateOnly, cmd.EffectiveFrom)
+	if err != nil {
+		return nil, tax.ErrInvalidDate
+	}
+	r, err := tax.NewRate(uuid.New(), category, cmd.RateBP, effectiveFrom, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.rateRepo.Save(ctx, r); err != nil {
+		if errors.Is(err, tax.ErrDuplicateRate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving tax rate: %w", err)
+	}
+	return r, nil
+}
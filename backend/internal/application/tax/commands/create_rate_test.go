@@ -0,0 +1,206 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/tax/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockRateRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateRepository) Save(ctx context.Context, r *tax.Rate) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(tax.Table), args.Error(1)
+}
+
+type mockProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockProductRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	return m.Called(ctx, productID, category).Error(0)
+}
+
+func (m *mockProductRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]tax.Category), args.Error(1)
+}
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	return m.Called(ctx, a).Error(0)
+}
+
+// --- Fixtures ---
+
+// actor returns an admin repository holding an admin of the given role,
+// and the admin's ID.
+func actor(t *testing.T, role admin.Role) (*mockAdminRepository, uuid.UUID) {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), string(role)+"@butchery.com", "$2a$10$hash", "Sam Ahmed", role)
+	require.NoError(t, err)
+	admins := new(mockAdminRepository)
+	admins.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	return admins, a.ID()
+}
+
+// --- Tests ---
+
+func TestCreateRate_SavesRate(t *testing.T) {
+	repo := new(mockRateRepository)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*tax.Rate")).Return(nil)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	r, err := commands.NewCreateRateHandler(repo, admins).Handle(context.Background(), commands.CreateRateCommand{
+		Category: " Prepared_Food ", RateBP: 500, EffectiveFrom: "2027-01-01", ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, tax.CategoryPreparedFood, r.Category())
+	assert.Equal(t, 500, r.RateBP())
+	repo.AssertExpectations(t)
+}
+
+func TestCreateRate_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  commands.CreateRateCommand
+		want error
+	}{
+		{"bad category", commands.CreateRateCommand{Category: "hot food", EffectiveFrom: "2027-01-01"}, tax.ErrInvalidCategory},
+		{"bad rate", commands.CreateRateCommand{Category: "delivery", RateBP: 10001, EffectiveFrom: "2027-01-01"}, tax.ErrInvalidRate},
+		{"bad date", commands.CreateRateCommand{Category: "delivery", RateBP: 2000, EffectiveFrom: "1/1/2027"}, tax.ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockRateRepository)
+			admins, actorID := actor(t, admin.RoleManager)
+			tt.cmd.ActorID = actorID
+
+			_, err := commands.NewCreateRateHandler(repo, admins).Handle(context.Background(), tt.cmd)
+
+			assert.ErrorIs(t, err, tt.want)
+			repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateRate_Duplicate_ReturnsError(t *testing.T) {
+	repo := new(mockRateRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(tax.ErrDuplicateRate)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	_, err := commands.NewCreateRateHandler(repo, admins).Handle(context.Background(), commands.CreateRateCommand{
+		Category: "delivery", RateBP: 2000, EffectiveFrom: "2027-01-01", ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, tax.ErrDuplicateRate)
+}
+
+func TestCreateRate_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	repo := new(mockRateRepository)
+	admins, actorID := actor(t, admin.RoleStaff)
+
+	_, err := commands.NewCreateRateHandler(repo, admins).Handle(context.Background(), commands.CreateRateCommand{
+		Category: "delivery", RateBP: 0, EffectiveFrom: "2027-01-01", ActorID: actorID,
+	})
+
+	assert.ErrorIs(t, err, tax.ErrManageNotAllowed)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAssignProductCategory_KnownCategory_Assigns(t *testing.T) {
+	rates := new(mockRateRepository)
+	rates.On("FindAll", mock.Anything).Return(tax.Table{
+		tax.ReconstructRate(uuid.New(), tax.CategoryFreshMeat, 0, time.Time{}, time.Now()),
+	}, nil)
+	products := new(mockProductRepository)
+	productID := uuid.New()
+	products.On("AssignCategory", mock.Anything, productID, tax.CategoryFreshMeat).Return(nil)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	c, err := commands.NewAssignProductCategoryHandler(rates, products, admins).Handle(context.Background(),
+		commands.AssignProductCategoryCommand{ProductID: productID, Category: "fresh_meat", ActorID: actorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, tax.CategoryFreshMeat, c)
+	products.AssertExpectations(t)
+}
+
+func TestAssignProductCategory_CategoryWithoutRate_ReturnsError(t *testing.T) {
+	rates := new(mockRateRepository)
+	rates.On("FindAll", mock.Anything).Return(tax.Table{}, nil)
+	products := new(mockProductRepository)
+	admins, actorID := actor(t, admin.RoleManager)
+
+	_, err := commands.NewAssignProductCategoryHandler(rates, products, admins).Handle(context.Background(),
+		commands.AssignProductCategoryCommand{ProductID: uuid.New(), Category: "fresh_meet", ActorID: actorID})
+
+	assert.ErrorIs(t, err, tax.ErrNoRate)
+	products.AssertNotCalled(t, "AssignCategory", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAssignProductCategory_NotManager_ReturnsErrManageNotAllowed(t *testing.T) {
+	rates := new(mockRateRepository)
+	products := new(mockProductRepository)
+	admins, actorID := actor(t, admin.RoleCashier)
+
+	_, err := commands.NewAssignProductCategoryHandler(rates, products, admins).Handle(context.Background(),
+		commands.AssignProductCategoryCommand{ProductID: uuid.New(), Category: "fresh_meat", ActorID: actorID})
+
+	assert.ErrorIs(t, err, tax.ErrManageNotAllowed)
+	products.AssertNotCalled(t, "AssignCategory", mock.Anything, mock.Anything, mock.Anything)
+}
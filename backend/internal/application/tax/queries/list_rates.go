@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// ListRatesHandler returns the rate table for the back office.
+type ListRatesHandler struct {
+	rateRepo tax.RateRepository
+}
+
+// NewListRatesHandler creates a new ListRatesHandler.
+func NewListRatesHandler(rateRepo tax.RateRepository) *ListRatesHandler {
+	return &ListRatesHandler{rateRepo: rateRepo}
+}
+
+// Handle returns every rate, past and scheduled, ordered by category and
+// date.
+func (h *ListRatesHandler) Handle(ctx context.Context) (tax.Table, error) {
+	table, err := h.rateRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("finding tax rates: %w", err)
+	}
+	return table, nil
+}
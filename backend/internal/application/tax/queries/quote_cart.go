@@ -0,0 +1,127 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// CartLine is one weighed product in a cart at its shelf price.
+type CartLine struct {
+	ProductID       uuid.UUID
+	Grams           int64
+	PricePerKgCents int64
+}
+
+// QuoteCartQuery asks what a cart comes to. DeliveryZoneID adds the zone's
+// delivery fee; Coupons are codes the shopper has entered.
+type QuoteCartQuery struct {
+	Lines          []CartLine
+	DeliveryZoneID *uuid.UUID
+	Coupons        []string
+}
+
+// CartQuote is a cart priced line by line after promotions. Delivery is nil
+// for collection. LineDiscountCents and DeliveryDiscountCents are what all
+// promotions together took off each line and the delivery fee; Rejected
+// lists entered coupons that did not apply.
+type CartQuote struct {
+	Lines                 []tax.Line
+	Delivery              *tax.Line
+	Bands                 []tax.Band
+	Discounts             []promotion.Discount
+	Rejected              []promotion.Rejection
+	LineDiscountCents     []int64
+	DeliveryDiscountCents int64
+	DiscountCents         int64
+	NetCents              int64
+	VATCents              int64
+	GrossCents            int64
+}
+
+// QuoteCartHandler prices carts with the same pricer and discounter orders
+// are placed with, so a customer is charged what they were quoted.
+type QuoteCartHandler struct {
+	pricer     *pricing.Pricer
+	discounter *pricing.Discounter
+	zoneRepo   delivery.ZoneRepository
+}
+
+// NewQuoteCartHandler creates a new QuoteCartHandler.
+func NewQuoteCartHandler(pricer *pricing.Pricer, discounter *pricing.Discounter, zoneRepo delivery.ZoneRepository) *QuoteCartHandler {
+	return &QuoteCartHandler{pricer: pricer, discounter: discounter, zoneRepo: zoneRepo}
+}
+
+// Handle prices the cart at today's rates and promotions. Quotes are
+// anonymous, so offers for particular customers are left to checkout. An
+// inactive zone is treated as not found.
+func (h *QuoteCartHandler) Handle(ctx context.Context, q QuoteCartQuery) (*CartQuote, error) {
+	if len(q.Lines) == 0 {
+		return nil, order.ErrNoLines
+	}
+	cart := promotion.Cart{Lines: make([]promotion.Line, 0, len(q.Lines))}
+	for _, l := range q.Lines {
+		if l.Grams <= 0 {
+			return nil, order.ErrInvalidWeight
+		}
+		if l.PricePerKgCents < 0 {
+			return nil, order.ErrNegativePrice
+		}
+		cart.Lines = append(cart.Lines, promotion.Line{ProductID: l.ProductID, Grams: l.Grams, PricePerKgCents: l.PricePerKgCents})
+	}
+
+	var feeCategory tax.Category
+	if q.DeliveryZoneID != nil {
+		z, err := h.zoneRepo.FindByID(ctx, *q.DeliveryZoneID)
+		if err != nil {
+			return nil, err
+		}
+		if !z.IsActive() {
+			return nil, delivery.ErrZoneNotFound
+		}
+		feeCategory = tax.Category(z.FeeTaxCategory())
+		cart.DeliveryFeeCents = z.DeliveryFeeCents()
+	}
+
+	now := time.Now()
+	discounts, err := h.discounter.Discount(ctx, cart, nil, q.Coupons, now)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]pricing.Line, 0, len(q.Lines)+1)
+	for i, l := range cart.Lines {
+		lines = append(lines, pricing.Line{ProductID: l.ProductID, AmountCents: l.AmountCents() - discounts.LineCents[i]})
+	}
+	if q.DeliveryZoneID != nil {
+		lines = append(lines, pricing.Line{Category: feeCategory, AmountCents: cart.DeliveryFeeCents - discounts.DeliveryCents})
+	}
+
+	result, err := h.pricer.Price(ctx, lines, now)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &CartQuote{
+		Lines:                 result.Lines[:len(q.Lines)],
+		Bands:                 result.Bands,
+		Discounts:             discounts.Discounts,
+		Rejected:              discounts.Rejected,
+		LineDiscountCents:     discounts.LineCents,
+		DeliveryDiscountCents: discounts.DeliveryCents,
+		DiscountCents:         discounts.TotalCents,
+		NetCents:              result.NetCents,
+		VATCents:              result.VATCents,
+		GrossCents:            result.GrossCents,
+	}
+	if q.DeliveryZoneID != nil {
+		quote.Delivery = &result.Lines[len(q.Lines)]
+	}
+	return quote, nil
+}
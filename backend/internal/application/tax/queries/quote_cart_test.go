@@ -0,0 +1,300 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	"github.com/katerji/butchery-app/backend/internal/application/tax/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockRateRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateRepository) Save(ctx context.Context, r *tax.Rate) error {
+	return m.Called(ctx, r).Error(0)
+}
+
+func (m *mockRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(tax.Table), args.Error(1)
+}
+
+type mockProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockProductRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	return m.Called(ctx, productID, category).Error(0)
+}
+
+func (m *mockProductRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]tax.Category), args.Error(1)
+}
+
+type mockZoneRepository struct {
+	mock.Mock
+}
+
+func (m *mockZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	return m.Called(ctx, z).Error(0)
+}
+
+func (m *mockZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*delivery.Zone), args.Error(1)
+}
+
+func (m *mockZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*delivery.Zone), args.Error(1)
+}
+
+type mockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionRepository) Save(ctx context.Context, p *promotion.Promotion) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockPromotionRepository) FindByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindAll(ctx context.Context) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+func (m *mockPromotionRepository) FindRunning(ctx context.Context, at time.Time) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+type mockPromotionProductRepository struct {
+	mock.Mock
+}
+
+func (m *mockPromotionProductRepository) Classify(ctx context.Context, productID uuid.UUID, profile promotion.Profile) error {
+	return m.Called(ctx, productID, profile).Error(0)
+}
+
+func (m *mockPromotionProductRepository) FindProfiles(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]promotion.Profile, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]promotion.Profile), args.Error(1)
+}
+
+type mockRedemptionRepository struct {
+	mock.Mock
+}
+
+func (m *mockRedemptionRepository) Usage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]promotion.Usage, error) {
+	args := m.Called(ctx, promotionIDs, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]promotion.Usage), args.Error(1)
+}
+
+func (m *mockRedemptionRepository) Redeem(ctx context.Context, redemptions []promotion.Redemption) error {
+	return m.Called(ctx, redemptions).Error(0)
+}
+
+func (m *mockRedemptionRepository) Release(ctx context.Context, orderID uuid.UUID) error {
+	return m.Called(ctx, orderID).Error(0)
+}
+
+type mockOrderHistory struct {
+	mock.Mock
+}
+
+func (m *mockOrderHistory) CountByCustomer(ctx context.Context, customerID uuid.UUID) (int, error) {
+	args := m.Called(ctx, customerID)
+	return args.Int(0), args.Error(1)
+}
+
+// --- Tests ---
+
+type quoteFixture struct {
+	handler    *queries.QuoteCartHandler
+	zones      *mockZoneRepository
+	promotions *mockPromotionRepository
+	lamb       uuid.UUID
+	mince      uuid.UUID
+}
+
+func newQuoteFixture(rounding tax.Rounding, running ...*promotion.Promotion) *quoteFixture {
+	f := &quoteFixture{zones: new(mockZoneRepository), promotions: new(mockPromotionRepository), lamb: uuid.New(), mince: uuid.New()}
+	rates := new(mockRateRepository)
+	rates.On("FindAll", mock.Anything).Return(tax.Table{
+		tax.ReconstructRate(uuid.New(), tax.CategoryFreshMeat, 0, time.Time{}, time.Now()),
+		tax.ReconstructRate(uuid.New(), tax.CategoryPreparedFood, 2000, time.Time{}, time.Now()),
+		tax.ReconstructRate(uuid.New(), tax.CategoryDelivery, 2000, time.Time{}, time.Now()),
+	}, nil)
+	products := new(mockProductRepository)
+	products.On("FindCategories", mock.Anything, mock.Anything).Return(map[uuid.UUID]tax.Category{
+		f.lamb: tax.CategoryFreshMeat, f.mince: tax.CategoryPreparedFood,
+	}, nil)
+	pricer := pricing.NewPricer(rates, products, tax.Calculator{Mode: tax.ModeInclusive, Rounding: rounding}, time.UTC)
+	f.promotions.On("FindRunning", mock.Anything, mock.Anything).Return(running, nil)
+	profiles := new(mockPromotionProductRepository)
+	profiles.On("FindProfiles", mock.Anything, mock.Anything).Return(map[uuid.UUID]promotion.Profile{
+		f.lamb: {Species: "lamb"}, f.mince: {Category: "mince", Species: "beef"},
+	}, nil)
+	discounter := pricing.NewDiscounter(f.promotions, profiles, new(mockRedemptionRepository), new(mockOrderHistory))
+	f.handler = queries.NewQuoteCartHandler(pricer, discounter, f.zones)
+	return f
+}
+
+func TestQuoteCart_WithDelivery_TaxesFeeUnderZoneCategory(t *testing.T) {
+	f := newQuoteFixture(tax.RoundPerLine)
+	zone, err := delivery.NewZone(uuid.New(), "East", nil, []string{"E1"}, 499, 0)
+	require.NoError(t, err)
+	f.zones.On("FindByID", mock.Anything, zone.ID()).Return(zone, nil)
+	zoneID := zone.ID()
+
+	q, err := f.handler.Handle(context.Background(), queries.QuoteCartQuery{
+		Lines: []queries.CartLine{
+			{ProductID: f.lamb, Grams: 1500, PricePerKgCents: 2000},
+			{ProductID: f.mince, Grams: 800, PricePerKgCents: 1125},
+		},
+		DeliveryZoneID: &zoneID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, q.Lines, 2)
+	assert.Equal(t, int64(3000), q.Lines[0].GrossCents)
+	assert.Equal(t, int64(150), q.Lines[1].VATCents)
+	require.NotNil(t, q.Delivery)
+	assert.Equal(t, tax.CategoryDelivery, q.Delivery.Category)
+	assert.Equal(t, int64(83), q.Delivery.VATCents)
+	assert.Equal(t, int64(4399), q.GrossCents)
+	assert.Equal(t, int64(233), q.VATCents)
+	require.Len(t, q.Bands, 2)
+	assert.Equal(t, int64(233), q.Bands[1].VATCents)
+}
+
+func TestQuoteCart_WithPromotions_PricesWhatIsLeft(t *testing.T) {
+	tenOffLamb, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name:  "Lamb weekend",
+		Rule:  promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000},
+		Scope: promotion.Scope{Species: []string{"lamb"}},
+	}, time.Now())
+	require.NoError(t, err)
+	freeDelivery, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name: "Free delivery over £40",
+		Code: "SHIPFREE",
+		Rule: promotion.Rule{Kind: promotion.KindFreeDelivery, MinSubtotalCents: 4000},
+	}, time.Now())
+	require.NoError(t, err)
+	firstOrder, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name: "Welcome",
+		Rule: promotion.Rule{Kind: promotion.KindFirstOrder, AmountCents: 500},
+	}, time.Now())
+	require.NoError(t, err)
+	f := newQuoteFixture(tax.RoundPerLine, tenOffLamb, freeDelivery, firstOrder)
+	zone, err := delivery.NewZone(uuid.New(), "East", nil, []string{"E1"}, 499, 0)
+	require.NoError(t, err)
+	f.zones.On("FindByID", mock.Anything, zone.ID()).Return(zone, nil)
+	zoneID := zone.ID()
+
+	q, err := f.handler.Handle(context.Background(), queries.QuoteCartQuery{
+		Lines: []queries.CartLine{
+			{ProductID: f.lamb, Grams: 1500, PricePerKgCents: 2000},
+			{ProductID: f.mince, Grams: 800, PricePerKgCents: 1125},
+		},
+		DeliveryZoneID: &zoneID,
+		Coupons:        []string{"shipfree", "BOGUS"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, q.Discounts, 1, "the £36 left after the lamb offer misses free delivery; anonymous quotes get no welcome offer")
+	assert.Equal(t, int64(300), q.DiscountCents)
+	assert.Equal(t, []int64{300, 0}, q.LineDiscountCents)
+	assert.Equal(t, int64(2700), q.Lines[0].GrossCents)
+	assert.Equal(t, int64(499), q.Delivery.GrossCents)
+	assert.Equal(t, []promotion.Rejection{
+		{Code: "SHIPFREE", Err: promotion.ErrNotEligible},
+		{Code: "BOGUS", Err: promotion.ErrUnknownCoupon},
+	}, q.Rejected)
+	assert.Equal(t, int64(4099), q.GrossCents)
+}
+
+func TestQuoteCart_PerInvoiceRounding_RoundsOncePerRate(t *testing.T) {
+	f := newQuoteFixture(tax.RoundPerInvoice)
+	lines := make([]queries.CartLine, 3)
+	for i := range lines {
+		lines[i] = queries.CartLine{ProductID: f.mince, Grams: 1000, PricePerKgCents: 99}
+	}
+
+	q, err := f.handler.Handle(context.Background(), queries.QuoteCartQuery{Lines: lines})
+
+	require.NoError(t, err)
+	assert.Nil(t, q.Delivery)
+	assert.Equal(t, int64(50), q.VATCents, "per line would be 3 x 17")
+}
+
+func TestQuoteCart_Invalid_ReturnsError(t *testing.T) {
+	f := newQuoteFixture(tax.RoundPerLine)
+	f.zones.On("FindByID", mock.Anything, mock.Anything).Return(nil, delivery.ErrZoneNotFound)
+	missing := uuid.New()
+
+	tests := []struct {
+		name string
+		q    queries.QuoteCartQuery
+		want error
+	}{
+		{"empty cart", queries.QuoteCartQuery{}, order.ErrNoLines},
+		{"no weight", queries.QuoteCartQuery{Lines: []queries.CartLine{{ProductID: f.lamb}}}, order.ErrInvalidWeight},
+		{"unclassified", queries.QuoteCartQuery{Lines: []queries.CartLine{{ProductID: uuid.New(), Grams: 100}}},
+			tax.ErrUnclassifiedProduct},
+		{"unknown zone", queries.QuoteCartQuery{Lines: []queries.CartLine{{ProductID: f.lamb, Grams: 100}},
+			DeliveryZoneID: &missing}, delivery.ErrZoneNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.handler.Handle(context.Background(), tt.q)
+
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
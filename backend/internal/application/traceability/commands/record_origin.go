@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// RecordOriginCommand is the input for the record origin use case.
+type RecordOriginCommand struct {
+	LotID             uuid.UUID
+	Farm              string
+	SlaughteredOn     string // YYYY-MM-DD
+	CertificateNumber string
+}
+
+// RecordOriginHandler records where a lot that was bought in already cut came
+// from. Lots cut from a carcass get their origin from the carcass.
+type RecordOriginHandler struct {
+	originRepo traceability.OriginRepository
+}
+
+// NewRecordOriginHandler creates a new RecordOriginHandler.
+func NewRecordOriginHandler(originRepo traceability.OriginRepository) *RecordOriginHandler {
+	return &RecordOriginHandler{originRepo: originRepo}
+}
+
+// Handle executes the record origin use case.
+func (h *RecordOriginHandler) Handle(ctx context.Context, cmd RecordOriginCommand) (*traceability.Origin, error) {
+	slaughteredOn, err := parseDate(cmd.SlaughteredOn)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := traceability.NewOrigin(cmd.LotID, nil, cmd.Farm, slaughteredOn, cmd.CertificateNumber, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.originRepo.SaveOrigin(ctx, o); err != nil {
+		if errors.Is(err, traceability.ErrOriginAlreadyRecorded) || errors.Is(err, inventory.ErrLotNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving lot origin: %w", err)
+	}
+	return o, nil
+}
@@ -0,0 +1,64 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/traceability/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordOrigin_ValidInput_SavesOrigin(t *testing.T) {
+	repo := new(mockOriginRepository)
+	repo.On("SaveOrigin", mock.Anything, mock.Anything).Return(nil)
+
+	lotID := uuid.New()
+	handler := commands.NewRecordOriginHandler(repo)
+	o, err := handler.Handle(context.Background(), commands.RecordOriginCommand{
+		LotID:             lotID,
+		Farm:              "Green Valley Farm",
+		SlaughteredOn:     "2026-10-12",
+		CertificateNumber: "HMC-2026-0193",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, lotID, o.LotID())
+	assert.Nil(t, o.CarcassID())
+	repo.AssertExpectations(t)
+}
+
+func TestRecordOrigin_RepositoryErrors_AreReturned(t *testing.T) {
+	for _, want := range []error{traceability.ErrOriginAlreadyRecorded, inventory.ErrLotNotFound} {
+		repo := new(mockOriginRepository)
+		repo.On("SaveOrigin", mock.Anything, mock.Anything).Return(want)
+
+		handler := commands.NewRecordOriginHandler(repo)
+		_, err := handler.Handle(context.Background(), commands.RecordOriginCommand{
+			LotID:             uuid.New(),
+			Farm:              "Green Valley Farm",
+			SlaughteredOn:     "2026-10-12",
+			CertificateNumber: "HMC-2026-0193",
+		})
+
+		assert.ErrorIs(t, err, want)
+	}
+}
+
+func TestRecordOrigin_MissingFarm_ReturnsError(t *testing.T) {
+	repo := new(mockOriginRepository)
+
+	handler := commands.NewRecordOriginHandler(repo)
+	_, err := handler.Handle(context.Background(), commands.RecordOriginCommand{
+		LotID:             uuid.New(),
+		SlaughteredOn:     "2026-10-12",
+		CertificateNumber: "HMC-2026-0193",
+	})
+
+	assert.ErrorIs(t, err, traceability.ErrEmptyFarm)
+	repo.AssertNotCalled(t, "SaveOrigin", mock.Anything, mock.Anything)
+}
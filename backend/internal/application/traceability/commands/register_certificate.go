@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// RegisterCertificateCommand is the input for the register certificate use case.
+type RegisterCertificateCommand struct {
+	Issuer         string
+	Number         string
+	Slaughterhouse string
+	ValidFrom      string // YYYY-MM-DD
+	ValidUntil     string // YYYY-MM-DD
+	DocumentURL    string
+}
+
+// RegisterCertificateHandler registers a halal slaughter certificate.
+type RegisterCertificateHandler struct {
+	certificateRepo traceability.CertificateRepository
+}
+
+// NewRegisterCertificateHandler creates a new RegisterCertificateHandler.
+func NewRegisterCertificateHandler(certificateRepo traceability.CertificateRepository) *RegisterCertificateHandler {
+	return &RegisterCertificateHandler{certificateRepo: certificateRepo}
+}
+
+// Handle executes the register certificate use case.
+func (h *RegisterCertificateHandler) Handle(ctx context.Context, cmd RegisterCertificateCommand) (*traceability.Certificate, error) {
+	validFrom, err := parseDate(cmd.ValidFrom)
+	if err != nil {
+		return nil, err
+	}
+	validUntil, err := parseDate(cmd.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := traceability.NewCertificate(uuid.New(), cmd.Issuer, cmd.Number, cmd.Slaughterhouse,
+		validFrom, validUntil, cmd.DocumentURL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.certificateRepo.Save(ctx, c); err != nil {
+		if errors.Is(err, traceability.ErrDuplicateCertificate) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("saving halal certificate: %w", err)
+	}
+	return c, nil
+}
+
+func parseDate(raw string) (time.Time, error) {
+	d, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, traceability.ErrInvalidDate
+	}
+	return d, nil
+}
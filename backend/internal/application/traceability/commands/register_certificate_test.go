@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/traceability/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockCertificateRepository struct {
+	mock.Mock
+}
+
+func (m *mockCertificateRepository) Save(ctx context.Context, c *traceability.Certificate) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCertificateRepository) FindAll(ctx context.Context, expiringBy *time.Time) ([]*traceability.Certificate, error) {
+	args := m.Called(ctx, expiringBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*traceability.Certificate), args.Error(1)
+}
+
+type mockOriginRepository struct {
+	mock.Mock
+}
+
+func (m *mockOriginRepository) SaveOrigin(ctx context.Context, o *traceability.Origin) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOriginRepository) FindTraces(ctx context.Context, lotCode string) ([]traceability.Trace, error) {
+	args := m.Called(ctx, lotCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]traceability.Trace), args.Error(1)
+}
+
+func (m *mockOriginRepository) FindConsumedLots(ctx context.Context, orderID uuid.UUID) ([]traceability.ConsumedLot, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]traceability.ConsumedLot), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func validCertificate() commands.RegisterCertificateCommand {
+	return commands.RegisterCertificateCommand{
+		Issuer:         "Halal Monitoring Committee",
+		Number:         "HMC-2026-0193",
+		Slaughterhouse: "Hill Farm Abattoir",
+		ValidFrom:      "2026-01-01",
+		ValidUntil:     "2026-12-31",
+		DocumentURL:    "https://files.example.com/hmc-0193.pdf",
+	}
+}
+
+// --- Tests ---
+
+func TestRegisterCertificate_ValidInput_SavesCertificate(t *testing.T) {
+	repo := new(mockCertificateRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	handler := commands.NewRegisterCertificateHandler(repo)
+	c, err := handler.Handle(context.Background(), validCertificate())
+
+	require.NoError(t, err)
+	assert.Equal(t, "HMC-2026-0193", c.Number())
+	assert.Equal(t, "2026-12-31", c.ValidUntil().Format(time.DateOnly))
+	repo.AssertExpectations(t)
+}
+
+func TestRegisterCertificate_InvalidDate_ReturnsError(t *testing.T) {
+	repo := new(mockCertificateRepository)
+
+	cmd := validCertificate()
+	cmd.ValidUntil = "31/12/2026"
+	handler := commands.NewRegisterCertificateHandler(repo)
+	_, err := handler.Handle(context.Background(), cmd)
+
+	assert.ErrorIs(t, err, traceability.ErrInvalidDate)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRegisterCertificate_DuplicateNumber_ReturnsError(t *testing.T) {
+	repo := new(mockCertificateRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(traceability.ErrDuplicateCertificate)
+
+	handler := commands.NewRegisterCertificateHandler(repo)
+	_, err := handler.Handle(context.Background(), validCertificate())
+
+	assert.ErrorIs(t, err, traceability.ErrDuplicateCertificate)
+}
+
+func TestRegisterCertificate_RepositoryError_IsWrapped(t *testing.T) {
+	repo := new(mockCertificateRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	handler := commands.NewRegisterCertificateHandler(repo)
+	_, err := handler.Handle(context.Background(), validCertificate())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "saving halal certificate")
+}
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// ConsumedLotsHandler lists the lots an order's lines were picked from.
+type ConsumedLotsHandler struct {
+	originRepo traceability.OriginRepository
+}
+
+// NewConsumedLotsHandler creates a new ConsumedLotsHandler.
+func NewConsumedLotsHandler(originRepo traceability.OriginRepository) *ConsumedLotsHandler {
+	return &ConsumedLotsHandler{originRepo: originRepo}
+}
+
+// Handle executes the consumed lots use case.
+func (h *ConsumedLotsHandler) Handle(ctx context.Context, orderID uuid.UUID) ([]traceability.ConsumedLot, error) {
+	lots, err := h.originRepo.FindConsumedLots(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("finding consumed lots: %w", err)
+	}
+	return lots, nil
+}
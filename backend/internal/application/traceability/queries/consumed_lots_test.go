@@ -0,0 +1,43 @@
+package queries_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumedLots_ReturnsTheLotsOfEachLine(t *testing.T) {
+	repo := new(mockOriginRepository)
+	orderID, lineID := uuid.New(), uuid.New()
+	repo.On("FindConsumedLots", mock.Anything, orderID).Return([]traceability.ConsumedLot{
+		{LineID: lineID, ProductID: uuid.New(), LotID: uuid.New(), LotCode: "LAMB-0412", Grams: 1200},
+		{LineID: lineID, ProductID: uuid.New(), LotID: uuid.New(), LotCode: "LAMB-0415", Grams: 300},
+	}, nil)
+
+	handler := queries.NewConsumedLotsHandler(repo)
+	lots, err := handler.Handle(context.Background(), orderID)
+
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	assert.Equal(t, lineID, lots[1].LineID)
+	assert.Equal(t, int64(300), lots[1].Grams)
+}
+
+func TestConsumedLots_RepositoryError_IsReturned(t *testing.T) {
+	repo := new(mockOriginRepository)
+	orderID := uuid.New()
+	failure := errors.New("connection reset")
+	repo.On("FindConsumedLots", mock.Anything, orderID).Return(nil, failure)
+
+	handler := queries.NewConsumedLotsHandler(repo)
+	_, err := handler.Handle(context.Background(), orderID)
+
+	assert.ErrorIs(t, err, failure)
+}
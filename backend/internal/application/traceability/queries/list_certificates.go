@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// ListCertificatesQuery is the input for the list certificates use case.
+type ListCertificatesQuery struct {
+	// ExpiringOnly limits the list to certificates that have expired or
+	// lapse within the warning window.
+	ExpiringOnly bool
+}
+
+// CertificateListing is a certificate with its expiry status as of today.
+type CertificateListing struct {
+	Certificate *traceability.Certificate
+	Expiry      traceability.Expiry
+}
+
+// ListCertificatesHandler lists halal certificates and flags those nearing expiry.
+type ListCertificatesHandler struct {
+	certificateRepo traceability.CertificateRepository
+	expiryWarning   time.Duration
+}
+
+// NewListCertificatesHandler creates a new ListCertificatesHandler.
+// Certificates lapsing within expiryWarning are flagged as expiring.
+func NewListCertificatesHandler(certificateRepo traceability.CertificateRepository, expiryWarning time.Duration) *ListCertificatesHandler {
+	return &ListCertificatesHandler{certificateRepo: certificateRepo, expiryWarning: expiryWarning}
+}
+
+// Handle executes the list certificates use case.
+func (h *ListCertificatesHandler) Handle(ctx context.Context, q ListCertificatesQuery) ([]CertificateListing, error) {
+	now := time.Now()
+
+	var expiringBy *time.Time
+	if q.ExpiringOnly {
+		by := now.Add(h.expiryWarning)
+		expiringBy = &by
+	}
+
+	certificates, err := h.certificateRepo.FindAll(ctx, expiringBy)
+	if err != nil {
+		return nil, fmt.Errorf("finding halal certificates: %w", err)
+	}
+
+	listings := make([]CertificateListing, 0, len(certificates))
+	for _, c := range certificates {
+		listings = append(listings, CertificateListing{Certificate: c, Expiry: c.Expiry(now, h.expiryWarning)})
+	}
+	return listings, nil
+}
@@ -0,0 +1,98 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockCertificateRepository struct {
+	mock.Mock
+}
+
+func (m *mockCertificateRepository) Save(ctx context.Context, c *traceability.Certificate) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *mockCertificateRepository) FindAll(ctx context.Context, expiringBy *time.Time) ([]*traceability.Certificate, error) {
+	args := m.Called(ctx, expiringBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*traceability.Certificate), args.Error(1)
+}
+
+type mockOriginRepository struct {
+	mock.Mock
+}
+
+func (m *mockOriginRepository) SaveOrigin(ctx context.Context, o *traceability.Origin) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *mockOriginRepository) FindTraces(ctx context.Context, lotCode string) ([]traceability.Trace, error) {
+	args := m.Called(ctx, lotCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]traceability.Trace), args.Error(1)
+}
+
+func (m *mockOriginRepository) FindConsumedLots(ctx context.Context, orderID uuid.UUID) ([]traceability.ConsumedLot, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]traceability.ConsumedLot), args.Error(1)
+}
+
+// --- Fixtures ---
+
+func certificateUntil(t *testing.T, validUntil time.Time) *traceability.Certificate {
+	t.Helper()
+	c, err := traceability.NewCertificate(uuid.New(), "HMC", "HMC-"+uuid.NewString()[:8], "Hill Farm Abattoir",
+		validUntil.AddDate(-1, 0, 0), validUntil, "", time.Now())
+	require.NoError(t, err)
+	return c
+}
+
+// --- Tests ---
+
+func TestListCertificates_FlagsCertificatesNearingExpiry(t *testing.T) {
+	repo := new(mockCertificateRepository)
+	soon := certificateUntil(t, time.Now().AddDate(0, 0, 10))
+	later := certificateUntil(t, time.Now().AddDate(0, 3, 0))
+	repo.On("FindAll", mock.Anything, (*time.Time)(nil)).Return([]*traceability.Certificate{soon, later}, nil)
+
+	handler := queries.NewListCertificatesHandler(repo, 30*24*time.Hour)
+	listings, err := handler.Handle(context.Background(), queries.ListCertificatesQuery{})
+
+	require.NoError(t, err)
+	require.Len(t, listings, 2)
+	assert.Equal(t, traceability.ExpiryExpiring, listings[0].Expiry)
+	assert.Equal(t, traceability.ExpiryValid, listings[1].Expiry)
+}
+
+func TestListCertificates_ExpiringOnly_FiltersByWarningWindow(t *testing.T) {
+	repo := new(mockCertificateRepository)
+	repo.On("FindAll", mock.Anything, mock.MatchedBy(func(by *time.Time) bool {
+		return by != nil && by.After(time.Now().Add(29*24*time.Hour)) && by.Before(time.Now().Add(31*24*time.Hour))
+	})).Return([]*traceability.Certificate{}, nil)
+
+	handler := queries.NewListCertificatesHandler(repo, 30*24*time.Hour)
+	_, err := handler.Handle(context.Background(), queries.ListCertificatesQuery{ExpiringOnly: true})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
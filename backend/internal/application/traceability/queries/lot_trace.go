@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// LotTraceHandler looks up where the lots with a given code came from.
+type LotTraceHandler struct {
+	originRepo traceability.OriginRepository
+}
+
+// NewLotTraceHandler creates a new LotTraceHandler.
+func NewLotTraceHandler(originRepo traceability.OriginRepository) *LotTraceHandler {
+	return &LotTraceHandler{originRepo: originRepo}
+}
+
+// Handle executes the lot trace use case. It returns
+// traceability.ErrTraceNotFound when no lot with the code has an origin.
+func (h *LotTraceHandler) Handle(ctx context.Context, lotCode string) ([]traceability.Trace, error) {
+	lotCode = strings.TrimSpace(lotCode)
+	if lotCode == "" {
+		return nil, traceability.ErrTraceNotFound
+	}
+
+	traces, err := h.originRepo.FindTraces(ctx, lotCode)
+	if err != nil {
+		return nil, fmt.Errorf("finding lot traces: %w", err)
+	}
+	if len(traces) == 0 {
+		return nil, traceability.ErrTraceNotFound
+	}
+	return traces, nil
+}
@@ -0,0 +1,51 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLotTrace_ReturnsTheLotsWithTheCode(t *testing.T) {
+	repo := new(mockOriginRepository)
+	lotID := uuid.New()
+	origin := traceability.ReconstructOrigin(lotID, nil, "Hill Farm", time.Now().AddDate(0, 0, -3), "HMC-0042", time.Now())
+	repo.On("FindTraces", mock.Anything, "KILL-07").Return([]traceability.Trace{
+		{LotID: lotID, LotCode: "KILL-07", ProductID: uuid.New(), ReceivedAt: time.Now(), Origin: origin},
+	}, nil)
+
+	handler := queries.NewLotTraceHandler(repo)
+	traces, err := handler.Handle(context.Background(), " KILL-07 ")
+
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	assert.Equal(t, "Hill Farm", traces[0].Origin.Farm())
+	assert.False(t, traces[0].HalalVerified())
+}
+
+func TestLotTrace_UnknownCode_ReturnsNotFound(t *testing.T) {
+	repo := new(mockOriginRepository)
+	repo.On("FindTraces", mock.Anything, "KILL-07").Return([]traceability.Trace{}, nil)
+
+	handler := queries.NewLotTraceHandler(repo)
+	_, err := handler.Handle(context.Background(), " KILL-07 ")
+
+	assert.ErrorIs(t, err, traceability.ErrTraceNotFound)
+}
+
+func TestLotTrace_BlankCode_ReturnsNotFound(t *testing.T) {
+	repo := new(mockOriginRepository)
+
+	handler := queries.NewLotTraceHandler(repo)
+	_, err := handler.Handle(context.Background(), "  ")
+
+	assert.ErrorIs(t, err, traceability.ErrTraceNotFound)
+	repo.AssertNotCalled(t, "FindTraces", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,203 @@
+// Package tracking streams order events to customers following their
+// orders and to the cutting-room board.
+package tracking
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// watcherBuffer is how many events a slow watcher may fall behind by
+// before it is dropped. A dropped watcher's stream ends, and its client
+// reconnects and replays what it missed.
+const watcherBuffer = 64
+
+// replayPage is how many missed events are read at a time.
+const replayPage = 200
+
+// Tracker keeps one subscription to the event feed open while anyone is
+// watching and fans its events out to every watcher that they match. The
+// subscription is opened for the first watcher and closed when the last
+// one leaves or it is lost, which ends every watcher's stream.
+type Tracker struct {
+	eventRepo order.EventRepository
+	feed      order.EventFeed
+
+	mu  sync.Mutex
+	sub *subscription
+}
+
+// subscription is an open feed subscription and the watchers of it.
+type subscription struct {
+	feed     order.EventSubscription
+	stop     context.CancelFunc
+	watchers map[*watcher]struct{}
+}
+
+// watcher is one stream's filter and the channel live events go to.
+type watcher struct {
+	filter order.EventFilter
+	scope  branch.Scope
+	events chan order.Event
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker(eventRepo order.EventRepository, feed order.EventFeed) *Tracker {
+	return &Tracker{eventRepo: eventRepo, feed: feed}
+}
+
+// Watch streams the events matching f at branches in the scope of ctx:
+// first those recorded after f.AfterID, then new ones as they are
+// recorded. The channel is closed once ctx is done, or early if the feed
+// is lost or the caller falls too far behind; either way the caller can
+// watch again from the last event it got without missing any.
+func (t *Tracker) Watch(ctx context.Context, f order.EventFilter) (<-chan order.Event, error) {
+	w := &watcher{filter: f, scope: branch.ScopeFrom(ctx), events: make(chan order.Event, watcherBuffer)}
+	// Join before replaying, so nothing recorded in between is missed.
+	sub, err := t.join(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	context.AfterFunc(ctx, func() { t.leave(sub, w) })
+
+	var missed []order.Event
+	if f.AfterID > 0 {
+		if missed, err = t.replay(ctx, f); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	// Live events may repeat replayed ones, but are not skipped for
+	// being older than the last one sent: the feed need not deliver them
+	// in order.
+	replayed := make(map[int64]struct{}, len(missed))
+	for _, e := range missed {
+		replayed[e.ID] = struct{}{}
+	}
+
+	out := make(chan order.Event)
+	go func() {
+		defer close(out)
+		defer cancel()
+		send := func(e order.Event) bool {
+			select {
+			case out <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for _, e := range missed {
+			if !send(e) {
+				return
+			}
+		}
+		for e := range w.events {
+			if _, ok := replayed[e.ID]; ok {
+				continue
+			}
+			if !send(e) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// replay reads every event matching f recorded after f.AfterID.
+func (t *Tracker) replay(ctx context.Context, f order.EventFilter) ([]order.Event, error) {
+	var missed []order.Event
+	for {
+		page, err := t.eventRepo.FindEvents(ctx, f, replayPage)
+		if err != nil {
+			return nil, fmt.Errorf("finding missed order events: %w", err)
+		}
+		missed = append(missed, page...)
+		if len(page) < replayPage {
+			return missed, nil
+		}
+		f.AfterID = page[len(page)-1].ID
+	}
+}
+
+// join adds w to the open subscription, opening one if nobody is
+// watching yet.
+func (t *Tracker) join(ctx context.Context, w *watcher) (*subscription, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sub == nil {
+		feed, err := t.feed.Listen(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listening for order events: %w", err)
+		}
+		runCtx, stop := context.WithCancel(context.Background())
+		t.sub = &subscription{feed: feed, stop: stop, watchers: make(map[*watcher]struct{})}
+		go t.run(runCtx, t.sub)
+	}
+	t.sub.watchers[w] = struct{}{}
+	return t.sub, nil
+}
+
+// run reads the subscription until it is stopped or lost.
+func (t *Tracker) run(ctx context.Context, sub *subscription) {
+	for {
+		e, err := sub.feed.Next(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			t.mu.Lock()
+			for w := range sub.watchers {
+				t.drop(sub, w)
+			}
+			t.mu.Unlock()
+			return
+		}
+		t.publish(sub, e)
+	}
+}
+
+// publish sends e to every watcher it matches, dropping those with no room
+// for it.
+func (t *Tracker) publish(sub *subscription, e order.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for w := range sub.watchers {
+		if !w.filter.Matches(e) || !w.scope.Allows(e.BranchID) {
+			continue
+		}
+		select {
+		case w.events <- e:
+		default:
+			t.drop(sub, w)
+		}
+	}
+}
+
+// leave stops sending to w.
+func (t *Tracker) leave(sub *subscription, w *watcher) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := sub.watchers[w]; ok {
+		t.drop(sub, w)
+	}
+}
+
+// drop removes w and closes its channel, closing the subscription after
+// its last watcher, with t.mu held.
+func (t *Tracker) drop(sub *subscription, w *watcher) {
+	delete(sub.watchers, w)
+	close(w.events)
+	if len(sub.watchers) > 0 || t.sub != sub {
+		return
+	}
+	t.sub = nil
+	sub.stop()
+	sub.feed.Close()
+}
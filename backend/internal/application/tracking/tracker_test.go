@@ -0,0 +1,202 @@
+package tracking_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/tracking"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockEventRepository struct {
+	mock.Mock
+}
+
+func (m *mockEventRepository) FindEvents(ctx context.Context, f order.EventFilter, limit int) ([]order.Event, error) {
+	args := m.Called(ctx, f, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]order.Event), args.Error(1)
+}
+
+// fakeFeed hands out one subscription at a time, counting how many it
+// has opened.
+type fakeFeed struct {
+	mu     sync.Mutex
+	opened int
+	sub    *fakeSubscription
+}
+
+func (f *fakeFeed) Listen(context.Context) (order.EventSubscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.opened++
+	f.sub = &fakeSubscription{events: make(chan result, 16), closed: make(chan struct{})}
+	return f.sub, nil
+}
+
+func (f *fakeFeed) current() *fakeSubscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sub
+}
+
+// fakeSubscription hands out whatever is sent on its events channel.
+type fakeSubscription struct {
+	events chan result
+	once   sync.Once
+	closed chan struct{}
+}
+
+type result struct {
+	event order.Event
+	err   error
+}
+
+func (s *fakeSubscription) Next(ctx context.Context) (order.Event, error) {
+	select {
+	case <-ctx.Done():
+		return order.Event{}, ctx.Err()
+	case r := <-s.events:
+		return r.event, r.err
+	}
+}
+
+func (s *fakeSubscription) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	return nil
+}
+
+// --- Fixtures ---
+
+func receive(t *testing.T, events <-chan order.Event) order.Event {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		require.True(t, ok, "stream ended")
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return order.Event{}
+	}
+}
+
+func waitClosed(t *testing.T, events <-chan order.Event) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("stream still open")
+		}
+	}
+}
+
+// --- Tests ---
+
+func TestWatch_ReplaysMissedEventsThenLive(t *testing.T) {
+	customerID := uuid.New()
+	repo := new(mockEventRepository)
+	repo.On("FindEvents", mock.Anything, order.EventFilter{CustomerID: &customerID, AfterID: 3}, 200).
+		Return([]order.Event{{ID: 4, CustomerID: customerID}, {ID: 6, CustomerID: customerID}}, nil)
+	feed := &fakeFeed{}
+	tracker := tracking.NewTracker(repo, feed)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := tracker.Watch(ctx, order.EventFilter{CustomerID: &customerID, AfterID: 3})
+	require.NoError(t, err)
+
+	sub := feed.current()
+	sub.events <- result{event: order.Event{ID: 6, CustomerID: customerID}}
+	sub.events <- result{event: order.Event{ID: 7, CustomerID: uuid.New()}}
+	sub.events <- result{event: order.Event{ID: 8, CustomerID: customerID}}
+
+	assert.Equal(t, int64(4), receive(t, events).ID)
+	assert.Equal(t, int64(6), receive(t, events).ID)
+	assert.Equal(t, int64(8), receive(t, events).ID, "replayed and other customers' events are skipped")
+}
+
+func TestWatch_SharesOneSubscriptionAndScopesBranches(t *testing.T) {
+	branchA, branchB := uuid.New(), uuid.New()
+	feed := &fakeFeed{}
+	tracker := tracking.NewTracker(new(mockEventRepository), feed)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	all, err := tracker.Watch(ctx, order.EventFilter{})
+	require.NoError(t, err)
+	onlyA, err := tracker.Watch(branch.WithScope(ctx, branch.Only(branchA)), order.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, feed.opened)
+
+	sub := feed.current()
+	sub.events <- result{event: order.Event{ID: 1, BranchID: branchB}}
+	sub.events <- result{event: order.Event{ID: 2, BranchID: branchA}}
+
+	assert.Equal(t, int64(1), receive(t, all).ID)
+	assert.Equal(t, int64(2), receive(t, all).ID)
+	assert.Equal(t, int64(2), receive(t, onlyA).ID)
+
+	cancel()
+	waitClosed(t, all)
+	waitClosed(t, onlyA)
+	select {
+	case <-sub.closed:
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed after its last watcher left")
+	}
+}
+
+func TestWatch_LostFeed_EndsStreamsAndReopens(t *testing.T) {
+	feed := &fakeFeed{}
+	tracker := tracking.NewTracker(new(mockEventRepository), feed)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := tracker.Watch(ctx, order.EventFilter{})
+	require.NoError(t, err)
+	feed.current().events <- result{err: errors.New("connection reset")}
+	waitClosed(t, events)
+
+	_, err = tracker.Watch(ctx, order.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, feed.opened)
+}
+
+func TestWatch_LiveEventsOutOfOrder_AreAllDelivered(t *testing.T) {
+	customerID := uuid.New()
+	repo := new(mockEventRepository)
+	repo.On("FindEvents", mock.Anything, order.EventFilter{CustomerID: &customerID, AfterID: 3}, 200).
+		Return([]order.Event{{ID: 4, CustomerID: customerID}}, nil)
+	feed := &fakeFeed{}
+	tracker := tracking.NewTracker(repo, feed)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := tracker.Watch(ctx, order.EventFilter{CustomerID: &customerID, AfterID: 3})
+	require.NoError(t, err)
+
+	sub := feed.current()
+	sub.events <- result{event: order.Event{ID: 6, CustomerID: customerID}}
+	sub.events <- result{event: order.Event{ID: 4, CustomerID: customerID}}
+	sub.events <- result{event: order.Event{ID: 5, CustomerID: customerID}}
+
+	assert.Equal(t, int64(4), receive(t, events).ID)
+	assert.Equal(t, int64(6), receive(t, events).ID)
+	assert.Equal(t, int64(5), receive(t, events).ID, "an event arriving after a later one is still sent")
+}
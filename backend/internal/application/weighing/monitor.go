@@ -0,0 +1,230 @@
+// Package weighing shares scale connections between the screens and
+// requests reading them.
+package weighing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// eventBuffer is how many events a slow watcher may fall behind by before
+// it misses readings.
+const eventBuffer = 16
+
+// Event is a reading from a watched scale, or the error that interrupted
+// it.
+type Event struct {
+	Reading scale.Reading
+	Err     error
+}
+
+// Monitor keeps one session open per scale that anyone is watching and
+// fans its readings out to every watcher. A scale's session is opened for
+// its first watcher and closed when its last one leaves.
+type Monitor struct {
+	scaleRepo scale.Repository
+	connector scale.Connector
+	window    time.Duration
+	tolerance int64
+
+	mu    sync.Mutex
+	feeds map[uuid.UUID]*feed
+}
+
+// feed is an open session and the channels its readings go to.
+type feed struct {
+	session  scale.Session
+	stop     context.CancelFunc
+	watchers map[chan Event]struct{}
+}
+
+// NewMonitor creates a new Monitor. A reading is only stable once the
+// weight has stayed within toleranceGrams for window; see scale.Stabiliser.
+func NewMonitor(scaleRepo scale.Repository, connector scale.Connector, window time.Duration, toleranceGrams int64) *Monitor {
+	return &Monitor{
+		scaleRepo: scaleRepo,
+		connector: connector,
+		window:    window,
+		tolerance: toleranceGrams,
+		feeds:     make(map[uuid.UUID]*feed),
+	}
+}
+
+// Watch streams a scale's readings until ctx is done. Overloads are sent
+// as events and the stream carries on; if the connection is lost the error
+// is sent and the channel closed. The channel is also closed once ctx is
+// done.
+func (m *Monitor) Watch(ctx context.Context, scaleID uuid.UUID) (<-chan Event, error) {
+	f, err := m.feed(ctx, scaleID)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan Event, eventBuffer)
+	f.watchers[events] = struct{}{}
+	m.mu.Unlock()
+
+	context.AfterFunc(ctx, func() { m.leave(scaleID, f, events) })
+	return events, nil
+}
+
+// Stable waits for the scale's next stable reading. It returns ErrNotStable
+// if ctx is done first, or the error that kept the scale from being read.
+func (m *Monitor) Stable(ctx context.Context, scaleID uuid.UUID) (scale.Reading, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := m.Watch(ctx, scaleID)
+	if err != nil {
+		return scale.Reading{}, err
+	}
+	var last error
+	for e := range events {
+		last = e.Err
+		if e.Err == nil && e.Reading.Stable {
+			return e.Reading, nil
+		}
+	}
+	if last != nil {
+		return scale.Reading{}, last
+	}
+	return scale.Reading{}, scale.ErrNotStable
+}
+
+// Tare tares a scale, through the session already open to it if it is
+// being watched.
+func (m *Monitor) Tare(ctx context.Context, scaleID uuid.UUID) error {
+	s, err := m.activeScale(ctx, scaleID)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	f, ok := m.feeds[scaleID]
+	m.mu.Unlock()
+	if ok {
+		return f.session.Tare(ctx)
+	}
+
+	session, err := m.connector.Open(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	return session.Tare(ctx)
+}
+
+// feed returns the scale's feed, opening a session if nobody is watching
+// it yet, with m.mu held.
+func (m *Monitor) feed(ctx context.Context, scaleID uuid.UUID) (*feed, error) {
+	m.mu.Lock()
+	if f, ok := m.feeds[scaleID]; ok {
+		return f, nil
+	}
+	m.mu.Unlock()
+
+	s, err := m.activeScale(ctx, scaleID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := m.connector.Open(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if f, ok := m.feeds[scaleID]; ok {
+		// Someone else connected while this session was opening.
+		session.Close()
+		return f, nil
+	}
+	runCtx, stop := context.WithCancel(context.Background())
+	f := &feed{session: session, stop: stop, watchers: make(map[chan Event]struct{})}
+	m.feeds[scaleID] = f
+	go m.run(runCtx, scaleID, f)
+	return f, nil
+}
+
+func (m *Monitor) activeScale(ctx context.Context, scaleID uuid.UUID) (*scale.Scale, error) {
+	s, err := m.scaleRepo.FindByID(ctx, scaleID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Active() {
+		return nil, scale.ErrScaleInactive
+	}
+	return s, nil
+}
+
+// run reads the session until it is stopped or fails.
+func (m *Monitor) run(ctx context.Context, scaleID uuid.UUID, f *feed) {
+	stabiliser := scale.NewStabiliser(m.window, m.tolerance)
+	for {
+		r, err := f.session.Next(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err == nil:
+			m.publish(f, Event{Reading: stabiliser.Observe(r)})
+		case errors.Is(err, scale.ErrOverload), errors.Is(err, scale.ErrUnderload):
+			stabiliser = scale.NewStabiliser(m.window, m.tolerance)
+			m.publish(f, Event{Err: err})
+		default:
+			m.fail(scaleID, f, err)
+			return
+		}
+	}
+}
+
+// publish sends e to every watcher with room for it.
+func (m *Monitor) publish(f *feed, e Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for events := range f.watchers {
+		select {
+		case events <- e:
+		default:
+		}
+	}
+}
+
+// fail tells every watcher why the feed ended and closes it.
+func (m *Monitor) fail(scaleID uuid.UUID, f *feed, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for events := range f.watchers {
+		select {
+		case events <- Event{Err: err}:
+		default:
+		}
+		close(events)
+		delete(f.watchers, events)
+	}
+	m.close(scaleID, f)
+}
+
+// leave stops sending to events, closing the feed after its last watcher.
+func (m *Monitor) leave(scaleID uuid.UUID, f *feed, events chan Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := f.watchers[events]; ok {
+		delete(f.watchers, events)
+		close(events)
+	}
+	if len(f.watchers) == 0 {
+		m.close(scaleID, f)
+	}
+}
+
+// close stops the feed and closes its session, with m.mu held.
+func (m *Monitor) close(scaleID uuid.UUID, f *feed) {
+	if m.feeds[scaleID] != f {
+		return
+	}
+	delete(m.feeds, scaleID)
+	f.stop()
+	f.session.Close()
+}
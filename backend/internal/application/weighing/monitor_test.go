@@ -0,0 +1,235 @@
+package weighing_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/application/weighing"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockScaleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScaleRepository) Save(ctx context.Context, s *scale.Scale) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *mockScaleRepository) FindByID(ctx context.Context, id uuid.UUID) (*scale.Scale, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*scale.Scale), args.Error(1)
+}
+
+func (m *mockScaleRepository) FindAll(ctx context.Context) ([]*scale.Scale, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*scale.Scale), args.Error(1)
+}
+
+// fakeSession hands out whatever is sent on its readings channel.
+type fakeSession struct {
+	readings chan result
+	tares    int
+	mu       sync.Mutex
+	closed   bool
+}
+
+type result struct {
+	reading scale.Reading
+	err     error
+}
+
+func (s *fakeSession) Next(ctx context.Context) (scale.Reading, error) {
+	select {
+	case <-ctx.Done():
+		return scale.Reading{}, ctx.Err()
+	case r := <-s.readings:
+		return r.reading, r.err
+	}
+}
+
+func (s *fakeSession) Tare(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tares++
+	return nil
+}
+
+func (s *fakeSession) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSession) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+type fakeConnector struct {
+	mu       sync.Mutex
+	sessions []*fakeSession
+}
+
+func (c *fakeConnector) Open(context.Context, *scale.Scale) (scale.Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := &fakeSession{readings: make(chan result)}
+	c.sessions = append(c.sessions, s)
+	return s, nil
+}
+
+func (c *fakeConnector) opened() []*fakeSession {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*fakeSession(nil), c.sessions...)
+}
+
+// --- Fixtures ---
+
+func activeScale(t *testing.T) *scale.Scale {
+	t.Helper()
+	s, err := scale.NewScale(uuid.New(), "Counter", scale.ProtocolMTSICS, scale.TransportTCP, "10.0.0.5:4001", 0, time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+func newMonitor(t *testing.T) (*weighing.Monitor, *fakeConnector, *scale.Scale) {
+	t.Helper()
+	s := activeScale(t)
+	repo := new(mockScaleRepository)
+	repo.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	connector := &fakeConnector{}
+	return weighing.NewMonitor(repo, connector, 0, 2), connector, s
+}
+
+func next(t *testing.T, events <-chan weighing.Event) weighing.Event {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		require.True(t, ok, "events closed")
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return weighing.Event{}
+	}
+}
+
+// --- Tests ---
+
+func TestMonitor_SharesOneSessionBetweenWatchers(t *testing.T) {
+	monitor, connector, s := newMonitor(t)
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+
+	first, err := monitor.Watch(ctx1, s.ID())
+	require.NoError(t, err)
+	second, err := monitor.Watch(ctx2, s.ID())
+	require.NoError(t, err)
+	require.Len(t, connector.opened(), 1)
+	session := connector.opened()[0]
+
+	session.readings <- result{reading: scale.Reading{Grams: 1250, Stable: true}}
+	assert.Equal(t, int64(1250), next(t, first).Reading.Grams)
+	assert.Equal(t, int64(1250), next(t, second).Reading.Grams)
+
+	session.readings <- result{err: scale.ErrOverload}
+	assert.ErrorIs(t, next(t, first).Err, scale.ErrOverload)
+	assert.ErrorIs(t, next(t, second).Err, scale.ErrOverload)
+
+	cancel1()
+	assert.Eventually(t, func() bool { _, ok := <-first; return !ok }, time.Second, time.Millisecond)
+	assert.False(t, session.isClosed())
+
+	cancel2()
+	assert.Eventually(t, session.isClosed, time.Second, time.Millisecond)
+}
+
+func TestMonitor_ClosesWatchersWhenConnectionIsLost(t *testing.T) {
+	monitor, connector, s := newMonitor(t)
+	events, err := monitor.Watch(context.Background(), s.ID())
+	require.NoError(t, err)
+	session := connector.opened()[0]
+
+	session.readings <- result{err: scale.ErrScaleDisconnected}
+	assert.ErrorIs(t, next(t, events).Err, scale.ErrScaleDisconnected)
+	_, ok := <-events
+	assert.False(t, ok)
+	assert.True(t, session.isClosed())
+
+	_, err = monitor.Watch(context.Background(), s.ID())
+	require.NoError(t, err)
+	assert.Len(t, connector.opened(), 2)
+}
+
+func TestMonitor_StableWaitsForSettledWeight(t *testing.T) {
+	monitor, connector, s := newMonitor(t)
+	got := make(chan scale.Reading, 1)
+	go func() {
+		r, err := monitor.Stable(context.Background(), s.ID())
+		assert.NoError(t, err)
+		got <- r
+	}()
+
+	require.Eventually(t, func() bool { return len(connector.opened()) == 1 }, time.Second, time.Millisecond)
+	session := connector.opened()[0]
+	session.readings <- result{reading: scale.Reading{Grams: 1240}}
+	session.readings <- result{reading: scale.Reading{Grams: 1250, TareGrams: 300, Stable: true}}
+
+	select {
+	case r := <-got:
+		assert.Equal(t, int64(1250), r.Grams)
+		assert.Equal(t, int64(300), r.TareGrams)
+	case <-time.After(time.Second):
+		t.Fatal("no stable reading")
+	}
+	assert.Eventually(t, session.isClosed, time.Second, time.Millisecond)
+}
+
+func TestMonitor_StableGivesUpWhenWeightKeepsMoving(t *testing.T) {
+	monitor, connector, s := newMonitor(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := monitor.Stable(ctx, s.ID())
+	assert.ErrorIs(t, err, scale.ErrNotStable)
+	assert.Eventually(t, connector.opened()[0].isClosed, time.Second, time.Millisecond)
+}
+
+func TestMonitor_RefusesDisabledScale(t *testing.T) {
+	s := activeScale(t)
+	require.NoError(t, s.Update(s.Name(), s.Protocol(), s.Transport(), s.Address(), s.BaudRate(), false, time.Now()))
+	repo := new(mockScaleRepository)
+	repo.On("FindByID", mock.Anything, s.ID()).Return(s, nil)
+	connector := &fakeConnector{}
+	monitor := weighing.NewMonitor(repo, connector, 0, 2)
+
+	_, err := monitor.Watch(context.Background(), s.ID())
+	assert.ErrorIs(t, err, scale.ErrScaleInactive)
+	assert.ErrorIs(t, monitor.Tare(context.Background(), s.ID()), scale.ErrScaleInactive)
+	assert.Empty(t, connector.opened())
+}
+
+func TestMonitor_TaresThroughOpenSession(t *testing.T) {
+	monitor, connector, s := newMonitor(t)
+	_, err := monitor.Watch(context.Background(), s.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, monitor.Tare(context.Background(), s.ID()))
+	require.Len(t, connector.opened(), 1)
+	assert.Equal(t, 1, connector.opened()[0].tares)
+}
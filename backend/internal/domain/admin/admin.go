@@ -6,35 +6,142 @@ import (
 	"github.com/google/uuid"
 )
 
-// Admin represents a butchery administrator (back-office user).
+// Role decides what an administrator is allowed to do.
+type Role string
+
+const (
+	RoleStaff   Role = "staff"
+	RoleCashier Role = "cashier"
+	RoleManager Role = "manager"
+	// RoleDriver signs delivery drivers in to see and work through the runs
+	// they are given.
+	RoleDriver Role = "driver"
+)
+
+// Permission is an action only some roles may take.
+type Permission string
+
+const (
+	// PermissionApproveRefunds allows issuing refunds above the approval
+	// threshold and approving or rejecting refunds waiting for a manager.
+	PermissionApproveRefunds Permission = "approve_refunds"
+	// PermissionOperateTill allows opening and closing till sessions and
+	// ringing up, taking payment for and returning in-store sales.
+	PermissionOperateTill Permission = "operate_till"
+	// PermissionVoidSales allows voiding a sale after it has been paid for.
+	PermissionVoidSales Permission = "void_sales"
+	// PermissionManageBranches allows changing the details and prices of
+	// branches and which branches admins are assigned to.
+	PermissionManageBranches Permission = "manage_branches"
+	// PermissionManageStaff allows changing staff profiles and rotas and
+	// seeing timesheets.
+	PermissionManageStaff Permission = "manage_staff"
+	// PermissionDispatchDeliveries allows taking on drivers and planning,
+	// routing and assigning delivery runs.
+	PermissionDispatchDeliveries Permission = "dispatch_deliveries"
+	// PermissionManagePricing allows changing VAT rates and product tax
+	// categories, promotions and the loyalty scheme, and adjusting
+	// customers' points by hand.
+	PermissionManagePricing Permission = "manage_pricing"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleStaff:   nil,
+	RoleCashier: {PermissionOperateTill},
+	RoleManager: {PermissionApproveRefunds, PermissionOperateTill, PermissionVoidSales, PermissionManageBranches, PermissionManageStaff, PermissionDispatchDeliveries, PermissionManagePricing},
+	RoleDriver:  nil,
+}
+
+// NewRole validates a raw role.
+func NewRole(raw string) (Role, error) {
+	r := Role(raw)
+	if _, ok := rolePermissions[r]; !ok {
+		return "", ErrInvalidRole
+	}
+	return r, nil
+}
+
+// Admin represents a butchery administrator (back-office user). An admin
+// works at the branches they are assigned to, or at every branch.
 type Admin struct {
 	id           uuid.UUID
 	email        string
 	passwordHash string
 	fullName     string
+	role         Role
+	allBranches  bool
+	branchIDs    []uuid.UUID
 }
 
 // NewAdmin creates an Admin entity with validation.
-func NewAdmin(id uuid.UUID, email, passwordHash, fullName string) (*Admin, error) {
+func NewAdmin(id uuid.UUID, email, passwordHash, fullName string, role Role) (*Admin, error) {
 	if !isValidEmail(email) {
 		return nil, ErrInvalidEmail
 	}
 	if strings.TrimSpace(fullName) == "" {
 		return nil, ErrEmptyFullName
 	}
+	if _, err := NewRole(string(role)); err != nil {
+		return nil, err
+	}
 
 	return &Admin{
 		id:           id,
 		email:        email,
 		passwordHash: passwordHash,
 		fullName:     fullName,
+		role:         role,
 	}, nil
 }
 
-func (a *Admin) ID() uuid.UUID       { return a.id }
-func (a *Admin) Email() string        { return a.email }
-func (a *Admin) PasswordHash() string { return a.passwordHash }
-func (a *Admin) FullName() string     { return a.fullName }
+func (a *Admin) ID() uuid.UUID          { return a.id }
+func (a *Admin) Email() string          { return a.email }
+func (a *Admin) PasswordHash() string   { return a.passwordHash }
+func (a *Admin) FullName() string       { return a.fullName }
+func (a *Admin) Role() Role             { return a.role }
+func (a *Admin) AllBranches() bool      { return a.allBranches }
+func (a *Admin) BranchIDs() []uuid.UUID { return a.branchIDs }
+
+// AssignBranches sets the branches the admin works at. An admin of every
+// branch has no individual branches.
+func (a *Admin) AssignBranches(all bool, branchIDs []uuid.UUID) {
+	a.allBranches = all
+	a.branchIDs = nil
+	if all {
+		return
+	}
+	seen := make(map[uuid.UUID]bool, len(branchIDs))
+	for _, id := range branchIDs {
+		if !seen[id] {
+			seen[id] = true
+			a.branchIDs = append(a.branchIDs, id)
+		}
+	}
+}
+
+// AssignRole changes what the admin is allowed to do. Drivers are taken on
+// and let go through dispatch, so no one is made a driver, or stops being
+// one, this way.
+func (a *Admin) AssignRole(role Role) error {
+	if _, err := NewRole(string(role)); err != nil {
+		return err
+	}
+	if role != a.role && (role == RoleDriver || a.role == RoleDriver) {
+		return ErrRoleNotAssignable
+	}
+	a.role = role
+	return nil
+}
+
+// HasPermission reports whether the admin's role grants p.
+func (a *Admin) HasPermission(p Permission) bool {
+	for _, granted := range rolePermissions[a.role] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
 
 func isValidEmail(email string) bool {
 	if email == "" {
@@ -12,7 +12,7 @@ import (
 func TestNewAdmin_ValidInputs_CreatesAdmin(t *testing.T) {
 	id := uuid.New()
 
-	a, err := admin.NewAdmin(id, "admin@butchery.com", "$2a$10$hashedpassword", "Butchery Admin")
+	a, err := admin.NewAdmin(id, "admin@butchery.com", "$2a$10$hashedpassword", "Butchery Admin", admin.RoleStaff)
 
 	require.NoError(t, err)
 	assert.Equal(t, id, a.ID())
@@ -34,18 +34,85 @@ func TestNewAdmin_InvalidEmail_ReturnsError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := admin.NewAdmin(uuid.New(), tt.email, "$2a$10$hash", "Admin")
+			_, err := admin.NewAdmin(uuid.New(), tt.email, "$2a$10$hash", "Admin", admin.RoleStaff)
 			assert.ErrorIs(t, err, admin.ErrInvalidEmail)
 		})
 	}
 }
 
 func TestNewAdmin_EmptyFullName_ReturnsError(t *testing.T) {
-	_, err := admin.NewAdmin(uuid.New(), "admin@butchery.com", "$2a$10$hash", "")
+	_, err := admin.NewAdmin(uuid.New(), "admin@butchery.com", "$2a$10$hash", "", admin.RoleStaff)
 	assert.ErrorIs(t, err, admin.ErrEmptyFullName)
 }
 
 func TestNewAdmin_WhitespaceOnlyFullName_ReturnsError(t *testing.T) {
-	_, err := admin.NewAdmin(uuid.New(), "admin@butchery.com", "$2a$10$hash", "   ")
+	_, err := admin.NewAdmin(uuid.New(), "admin@butchery.com", "$2a$10$hash", "   ", admin.RoleStaff)
 	assert.ErrorIs(t, err, admin.ErrEmptyFullName)
 }
+
+func TestNewAdmin_InvalidRole_ReturnsError(t *testing.T) {
+	_, err := admin.NewAdmin(uuid.New(), "admin@butchery.com", "$2a$10$hash", "Admin", "owner")
+	assert.ErrorIs(t, err, admin.ErrInvalidRole)
+}
+
+func TestAdmin_HasPermission_OnlyManagersApproveRefunds(t *testing.T) {
+	staff, err := admin.NewAdmin(uuid.New(), "staff@butchery.com", "$2a$10$hash", "Staff", admin.RoleStaff)
+	require.NoError(t, err)
+	manager, err := admin.NewAdmin(uuid.New(), "manager@butchery.com", "$2a$10$hash", "Manager", admin.RoleManager)
+	require.NoError(t, err)
+
+	assert.False(t, staff.HasPermission(admin.PermissionApproveRefunds))
+	assert.True(t, manager.HasPermission(admin.PermissionApproveRefunds))
+}
+
+func TestAdmin_HasPermission_CashiersOperateTillsButOnlyManagersVoidSales(t *testing.T) {
+	cashier, err := admin.NewAdmin(uuid.New(), "till@butchery.com", "$2a$10$hash", "Cashier", admin.RoleCashier)
+	require.NoError(t, err)
+	manager, err := admin.NewAdmin(uuid.New(), "manager@butchery.com", "$2a$10$hash", "Manager", admin.RoleManager)
+	require.NoError(t, err)
+
+	assert.True(t, cashier.HasPermission(admin.PermissionOperateTill))
+	assert.False(t, cashier.HasPermission(admin.PermissionVoidSales))
+	assert.False(t, cashier.HasPermission(admin.PermissionApproveRefunds))
+	assert.True(t, manager.HasPermission(admin.PermissionOperateTill))
+	assert.True(t, manager.HasPermission(admin.PermissionVoidSales))
+}
+
+func TestAdmin_HasPermission_OnlyManagersManagePricing(t *testing.T) {
+	staff, err := admin.NewAdmin(uuid.New(), "staff@butchery.com", "$2a$10$hash", "Staff", admin.RoleStaff)
+	require.NoError(t, err)
+	manager, err := admin.NewAdmin(uuid.New(), "manager@butchery.com", "$2a$10$hash", "Manager", admin.RoleManager)
+	require.NoError(t, err)
+
+	assert.False(t, staff.HasPermission(admin.PermissionManagePricing))
+	assert.True(t, manager.HasPermission(admin.PermissionManagePricing))
+}
+
+func TestAdmin_AssignBranches(t *testing.T) {
+	a, err := admin.NewAdmin(uuid.New(), "till@butchery.com", "$2a$10$hash", "Cashier", admin.RoleCashier)
+	require.NoError(t, err)
+	east, west := uuid.New(), uuid.New()
+
+	a.AssignBranches(false, []uuid.UUID{east, west, east})
+	assert.False(t, a.AllBranches())
+	assert.Equal(t, []uuid.UUID{east, west}, a.BranchIDs())
+
+	a.AssignBranches(true, []uuid.UUID{east})
+	assert.True(t, a.AllBranches())
+	assert.Empty(t, a.BranchIDs())
+}
+
+func TestAdmin_AssignRole(t *testing.T) {
+	a, err := admin.NewAdmin(uuid.New(), "new@butchery.com", "$2a$10$hash", "New Starter", admin.RoleStaff)
+	require.NoError(t, err)
+
+	require.NoError(t, a.AssignRole(admin.RoleCashier))
+	assert.Equal(t, admin.RoleCashier, a.Role())
+	assert.ErrorIs(t, a.AssignRole("owner"), admin.ErrInvalidRole)
+	assert.ErrorIs(t, a.AssignRole(admin.RoleDriver), admin.ErrRoleNotAssignable)
+	assert.Equal(t, admin.RoleCashier, a.Role())
+
+	driver, err := admin.NewAdmin(uuid.New(), "van@butchery.com", "$2a$10$hash", "Driver", admin.RoleDriver)
+	require.NoError(t, err)
+	assert.ErrorIs(t, driver.AssignRole(admin.RoleStaff), admin.ErrRoleNotAssignable)
+}
@@ -7,4 +7,6 @@ var (
 	ErrEmptyFullName      = errors.New("full name must not be empty")
 	ErrAdminNotFound      = errors.New("admin not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidRole        = errors.New("role must be one of staff, cashier, manager or driver")
+	ErrRoleNotAssignable  = errors.New("drivers are taken on and let go through dispatch")
 )
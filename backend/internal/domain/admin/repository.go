@@ -10,4 +10,11 @@ import (
 type Repository interface {
 	FindByEmail(ctx context.Context, email string) (*Admin, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*Admin, error)
+	// FindAll returns admins by name. Admins whose requests are scoped to
+	// some branches only see admins assigned to one of them.
+	FindAll(ctx context.Context) ([]*Admin, error)
+	// SaveBranches replaces the branches the admin is assigned to.
+	SaveBranches(ctx context.Context, a *Admin) error
+	// SaveRole stores the admin's role.
+	SaveRole(ctx context.Context, a *Admin) error
 }
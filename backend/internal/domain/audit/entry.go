@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry records that an admin did something to a record, with the details
+// needed to reconstruct what happened later. Entries are never changed or
+// removed once written.
+type Entry struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	Details    map[string]string
+	CreatedAt  time.Time
+}
+
+// NewEntry creates an audit entry. Action and EntityType are dotted and
+// snake-cased names such as "refund.issued" and "order".
+func NewEntry(id, actorID uuid.UUID, action, entityType string, entityID uuid.UUID, details map[string]string, now time.Time) (*Entry, error) {
+	action = strings.TrimSpace(action)
+	if action == "" {
+		return nil, ErrEmptyAction
+	}
+	entityType = strings.TrimSpace(entityType)
+	if entityType == "" {
+		return nil, ErrEmptyEntityType
+	}
+	return &Entry{
+		ID:         id,
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    details,
+		CreatedAt:  now,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntry_ValidInputs_CreatesEntry(t *testing.T) {
+	actorID, entityID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	e, err := audit.NewEntry(uuid.New(), actorID, " refund.issued ", "order", entityID, map[string]string{"amount_cents": "500"}, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "refund.issued", e.Action)
+	assert.Equal(t, actorID, e.ActorID)
+	assert.Equal(t, entityID, e.EntityID)
+	assert.Equal(t, "500", e.Details["amount_cents"])
+	assert.Equal(t, now, e.CreatedAt)
+}
+
+func TestNewEntry_MissingNames_ReturnsError(t *testing.T) {
+	_, err := audit.NewEntry(uuid.New(), uuid.New(), " ", "order", uuid.New(), nil, time.Now())
+	assert.ErrorIs(t, err, audit.ErrEmptyAction)
+
+	_, err = audit.NewEntry(uuid.New(), uuid.New(), "refund.issued", "", uuid.New(), nil, time.Now())
+	assert.ErrorIs(t, err, audit.ErrEmptyEntityType)
+}
@@ -0,0 +1,8 @@
+package audit
+
+import "errors"
+
+var (
+	ErrEmptyAction     = errors.New("audit action must not be empty")
+	ErrEmptyEntityType = errors.New("audit entity type must not be empty")
+)
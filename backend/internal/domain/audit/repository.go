@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to the audit log. It can only be appended to.
+type Repository interface {
+	Append(ctx context.Context, e *Entry) error
+	// FindByEntity returns the entries recorded against one record, oldest first.
+	FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*Entry, error)
+}
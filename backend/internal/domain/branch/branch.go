@@ -0,0 +1,88 @@
+package branch
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Branch is one of the shops: where stock is held, tills are run and
+// orders are collected from or delivered out of. Its trading hours are the
+// fulfilment schedule's opening hours for the branch.
+type Branch struct {
+	id        uuid.UUID
+	name      string
+	address   []string
+	phone     string
+	email     string
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewBranch validates and creates an active branch.
+func NewBranch(id uuid.UUID, name string, address []string, phone, email string, now time.Time) (*Branch, error) {
+	b := &Branch{id: id, createdAt: now}
+	if err := b.Update(name, address, phone, email, true, now); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ReconstructBranch reconstructs a Branch from persistence without
+// validation.
+func ReconstructBranch(id uuid.UUID, name string, address []string, phone, email string, active bool, createdAt, updatedAt time.Time) *Branch {
+	return &Branch{
+		id:        id,
+		name:      name,
+		address:   address,
+		phone:     phone,
+		email:     email,
+		active:    active,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (b *Branch) ID() uuid.UUID        { return b.id }
+func (b *Branch) Name() string         { return b.name }
+func (b *Branch) Address() []string    { return b.address }
+func (b *Branch) Phone() string        { return b.phone }
+func (b *Branch) Email() string        { return b.email }
+func (b *Branch) Active() bool         { return b.active }
+func (b *Branch) CreatedAt() time.Time { return b.createdAt }
+func (b *Branch) UpdatedAt() time.Time { return b.updatedAt }
+
+// Update changes the branch's details. Blank address lines are dropped.
+// A closed branch keeps its history but no longer shows to customers.
+func (b *Branch) Update(name string, address []string, phone, email string, active bool, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	lines := make([]string, 0, len(address))
+	for _, l := range address {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) == 0 {
+		return ErrEmptyAddress
+	}
+	email = strings.TrimSpace(email)
+	if email != "" {
+		local, domain, ok := strings.Cut(email, "@")
+		if !ok || local == "" || domain == "" {
+			return ErrInvalidEmail
+		}
+	}
+
+	b.name = name
+	b.address = lines
+	b.phone = strings.TrimSpace(phone)
+	b.email = email
+	b.active = active
+	b.updatedAt = now
+	return nil
+}
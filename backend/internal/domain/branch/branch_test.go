@@ -0,0 +1,62 @@
+package branch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBranch_TrimsDetails(t *testing.T) {
+	b, err := branch.NewBranch(uuid.New(), "  Whitechapel ", []string{"1 High Street", " ", "London E1 6AN"}, " 020 7946 0000", "e1@butchery.com", time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Whitechapel", b.Name())
+	assert.Equal(t, []string{"1 High Street", "London E1 6AN"}, b.Address())
+	assert.Equal(t, "020 7946 0000", b.Phone())
+	assert.True(t, b.Active())
+}
+
+func TestNewBranch_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name    string
+		bName   string
+		address []string
+		email   string
+		want    error
+	}{
+		{"no name", " ", []string{"1 High Street"}, "", branch.ErrEmptyName},
+		{"no address", "Whitechapel", []string{" "}, "", branch.ErrEmptyAddress},
+		{"bad email", "Whitechapel", []string{"1 High Street"}, "e1.butchery.com", branch.ErrInvalidEmail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := branch.NewBranch(uuid.New(), tt.bName, tt.address, "", tt.email, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestNewPriceOverride_NegativePrice_ReturnsError(t *testing.T) {
+	_, err := branch.NewPriceOverride(uuid.New(), uuid.New(), -1, time.Now())
+	assert.ErrorIs(t, err, branch.ErrNegativePrice)
+}
+
+func TestScope(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	assert.True(t, branch.ScopeFrom(context.Background()).All(), "unscoped contexts see every branch")
+
+	ctx := branch.WithScope(context.Background(), branch.Only(a))
+	s := branch.ScopeFrom(ctx)
+	assert.False(t, s.All())
+	assert.True(t, s.Allows(a))
+	assert.False(t, s.Allows(b))
+	assert.ErrorIs(t, s.Check(b), branch.ErrOutOfScope)
+	assert.NoError(t, branch.AllBranches().Check(b))
+	assert.False(t, branch.Only().Allows(a), "an admin with no branches sees none")
+}
@@ -0,0 +1,16 @@
+package branch
+
+import "errors"
+
+var (
+	ErrBranchNotFound   = errors.New("branch not found")
+	ErrEmptyName        = errors.New("branch name must not be empty")
+	ErrDuplicateName    = errors.New("another branch already has that name")
+	ErrEmptyAddress     = errors.New("branch address must not be empty")
+	ErrInvalidEmail     = errors.New("invalid email format")
+	ErrProductRequired  = errors.New("price override must be for a product")
+	ErrNegativePrice    = errors.New("price must not be negative")
+	ErrPriceNotFound    = errors.New("branch has no price override for the product")
+	ErrOutOfScope       = errors.New("branch is not one the admin is assigned to")
+	ErrManageNotAllowed = errors.New("only managers may manage branches and their staff")
+)
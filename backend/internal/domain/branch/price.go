@@ -0,0 +1,38 @@
+package branch
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceOverride is what a branch charges a kilogram for a product instead
+// of the product's usual price, e.g. where rents are higher.
+type PriceOverride struct {
+	branchID        uuid.UUID
+	productID       uuid.UUID
+	pricePerKgCents int64
+	updatedAt       time.Time
+}
+
+// NewPriceOverride validates and creates a PriceOverride.
+func NewPriceOverride(branchID, productID uuid.UUID, pricePerKgCents int64, now time.Time) (*PriceOverride, error) {
+	if productID == uuid.Nil {
+		return nil, ErrProductRequired
+	}
+	if pricePerKgCents < 0 {
+		return nil, ErrNegativePrice
+	}
+	return &PriceOverride{branchID: branchID, productID: productID, pricePerKgCents: pricePerKgCents, updatedAt: now}, nil
+}
+
+// ReconstructPriceOverride reconstructs a PriceOverride from persistence
+// without validation.
+func ReconstructPriceOverride(branchID, productID uuid.UUID, pricePerKgCents int64, updatedAt time.Time) *PriceOverride {
+	return &PriceOverride{branchID: branchID, productID: productID, pricePerKgCents: pricePerKgCents, updatedAt: updatedAt}
+}
+
+func (p *PriceOverride) BranchID() uuid.UUID    { return p.branchID }
+func (p *PriceOverride) ProductID() uuid.UUID   { return p.productID }
+func (p *PriceOverride) PricePerKgCents() int64 { return p.pricePerKgCents }
+func (p *PriceOverride) UpdatedAt() time.Time   { return p.updatedAt }
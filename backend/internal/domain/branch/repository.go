@@ -0,0 +1,34 @@
+package branch
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to branches. Reads only return branches in
+// the context's scope.
+type Repository interface {
+	// Save stores a branch, returning ErrDuplicateName if another branch
+	// has the same name and ErrOutOfScope if it is not in scope, which a
+	// new branch only is for admins of every branch.
+	Save(ctx context.Context, b *Branch) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Branch, error)
+	// FindAll returns branches by name, closed ones only if asked for.
+	FindAll(ctx context.Context, includeInactive bool) ([]*Branch, error)
+}
+
+// PriceRepository provides access to branch price overrides.
+type PriceRepository interface {
+	// SetPrice stores an override, replacing any the branch had for the
+	// product.
+	SetPrice(ctx context.Context, p *PriceOverride) error
+	// DeletePrice removes an override, returning ErrPriceNotFound if the
+	// branch had none for the product.
+	DeletePrice(ctx context.Context, branchID, productID uuid.UUID) error
+	// FindPrices returns a branch's overrides.
+	FindPrices(ctx context.Context, branchID uuid.UUID) ([]*PriceOverride, error)
+	// PricesFor returns the price a kilogram the branch charges for each of
+	// the products it has an override for.
+	PricesFor(ctx context.Context, branchID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+}
@@ -0,0 +1,65 @@
+package branch
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Scope is the branches whose data a request may see and change. An
+// admin's requests are scoped to the branches they are assigned to;
+// customers and background jobs are not scoped.
+type Scope struct {
+	all bool
+	ids []uuid.UUID
+}
+
+// AllBranches is the scope of an admin who works across every branch.
+func AllBranches() Scope { return Scope{all: true} }
+
+// Only is the scope of an admin assigned to the given branches, which may
+// be none.
+func Only(ids ...uuid.UUID) Scope { return Scope{ids: append([]uuid.UUID{}, ids...)} }
+
+// All reports whether the scope covers every branch.
+func (s Scope) All() bool { return s.all }
+
+// IDs returns the branches in scope. It is meaningless if All is true.
+func (s Scope) IDs() []uuid.UUID { return s.ids }
+
+// Allows reports whether branchID is in scope.
+func (s Scope) Allows(branchID uuid.UUID) bool {
+	if s.all {
+		return true
+	}
+	for _, id := range s.ids {
+		if id == branchID {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns ErrOutOfScope unless branchID is in scope.
+func (s Scope) Check(branchID uuid.UUID) error {
+	if !s.Allows(branchID) {
+		return ErrOutOfScope
+	}
+	return nil
+}
+
+type scopeKey struct{}
+
+// WithScope returns a context whose data access is limited to s.
+func WithScope(ctx context.Context, s Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// ScopeFrom returns the scope of ctx, or AllBranches if it has none.
+// Repositories apply it to every query that touches branch data.
+func ScopeFrom(ctx context.Context) Scope {
+	if s, ok := ctx.Value(scopeKey{}).(Scope); ok {
+		return s
+	}
+	return AllBranches()
+}
@@ -0,0 +1,253 @@
+package business
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Details are a business's company and billing details, printed on its
+// statements.
+type Details struct {
+	Name           string
+	CompanyNumber  string
+	VATNumber      string
+	BillingEmail   string
+	BillingAddress string
+}
+
+// Terms are the credit a business is given. Orders are charged to the
+// account and due TermsDays after they are placed, and the account may owe
+// at most CreditLimitCents, counting orders waiting for approval. Orders
+// over ApprovalThresholdCents need an approver's sign-off; with no
+// threshold none do.
+type Terms struct {
+	CreditLimitCents       int64
+	TermsDays              int
+	ApprovalThresholdCents *int64
+}
+
+// Tier is the price per kilogram for a line of at least MinGrams.
+type Tier struct {
+	MinGrams        int64
+	PricePerKgCents int64
+}
+
+// Price is what a business pays for a product, cheaper per kilogram the
+// more it buys in one line.
+type Price struct {
+	ProductID   uuid.UUID
+	Description string
+	Tiers       []Tier
+}
+
+// PerKgFor returns the price per kilogram of a line of grams.
+func (p Price) PerKgFor(grams int64) int64 {
+	price := p.Tiers[0].PricePerKgCents
+	for _, t := range p.Tiers[1:] {
+		if grams < t.MinGrams {
+			break
+		}
+		price = t.PricePerKgCents
+	}
+	return price
+}
+
+// Account is a wholesale customer such as a restaurant or caterer. Its
+// members order on credit at the account's own prices, and what it owes is
+// kept as a balance moved by charges and payments. HeldCents is what
+// orders waiting for approval would add. Version guards against two
+// orders or payments moving the balance at once.
+type Account struct {
+	id           uuid.UUID
+	details      Details
+	terms        Terms
+	prices       []Price
+	balanceCents int64
+	heldCents    int64
+	active       bool
+	version      int
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewAccount validates and creates an active account with an empty price
+// list.
+func NewAccount(id uuid.UUID, details Details, terms Terms, now time.Time) (*Account, error) {
+	a := &Account{id: id, prices: []Price{}, version: 1, createdAt: now}
+	if err := a.Update(details, terms, true, now); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ReconstructAccount reconstructs an Account from persistence without validation.
+func ReconstructAccount(
+	id uuid.UUID,
+	details Details,
+	terms Terms,
+	prices []Price,
+	balanceCents, heldCents int64,
+	active bool,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Account {
+	return &Account{
+		id:           id,
+		details:      details,
+		terms:        terms,
+		prices:       prices,
+		balanceCents: balanceCents,
+		heldCents:    heldCents,
+		active:       active,
+		version:      version,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (a *Account) ID() uuid.UUID        { return a.id }
+func (a *Account) Details() Details     { return a.details }
+func (a *Account) Terms() Terms         { return a.terms }
+func (a *Account) Prices() []Price      { return a.prices }
+func (a *Account) BalanceCents() int64  { return a.balanceCents }
+func (a *Account) HeldCents() int64     { return a.heldCents }
+func (a *Account) Active() bool         { return a.active }
+func (a *Account) Version() int         { return a.version }
+func (a *Account) CreatedAt() time.Time { return a.createdAt }
+func (a *Account) UpdatedAt() time.Time { return a.updatedAt }
+
+// AvailableCreditCents is how much more the account may order on credit.
+func (a *Account) AvailableCreditCents() int64 {
+	return a.terms.CreditLimitCents - a.balanceCents - a.heldCents
+}
+
+// Update replaces the account's details and terms. An inactive account is
+// on hold and cannot order; lowering the credit limit below what is owed
+// stops new orders until payments come in.
+func (a *Account) Update(details Details, terms Terms, active bool, now time.Time) error {
+	details.Name = strings.TrimSpace(details.Name)
+	if details.Name == "" {
+		return ErrEmptyName
+	}
+	details.BillingEmail = strings.TrimSpace(details.BillingEmail)
+	if details.BillingEmail == "" {
+		return ErrEmptyBillingEmail
+	}
+	details.CompanyNumber = strings.TrimSpace(details.CompanyNumber)
+	details.VATNumber = strings.TrimSpace(details.VATNumber)
+	details.BillingAddress = strings.TrimSpace(details.BillingAddress)
+	if terms.CreditLimitCents < 0 {
+		return ErrNegativeCreditLimit
+	}
+	if terms.TermsDays < 1 || terms.TermsDays > 120 {
+		return ErrInvalidTerms
+	}
+	if terms.ApprovalThresholdCents != nil && *terms.ApprovalThresholdCents < 0 {
+		return ErrNegativeThreshold
+	}
+
+	a.details = details
+	a.terms = terms
+	a.active = active
+	a.updatedAt = now
+	return nil
+}
+
+// SetPrices replaces the account's price list. Orders already placed keep
+// the prices they were placed at.
+func (a *Account) SetPrices(prices []Price, now time.Time) error {
+	seen := make(map[uuid.UUID]bool, len(prices))
+	cleaned := make([]Price, 0, len(prices))
+	for _, p := range prices {
+		if seen[p.ProductID] {
+			return ErrDuplicatePrice
+		}
+		seen[p.ProductID] = true
+		if len(p.Tiers) == 0 || p.Tiers[0].MinGrams != 0 {
+			return ErrInvalidTiers
+		}
+		for i, t := range p.Tiers {
+			if t.PricePerKgCents < 0 {
+				return ErrNegativePrice
+			}
+			if i > 0 && t.MinGrams <= p.Tiers[i-1].MinGrams {
+				return ErrInvalidTiers
+			}
+		}
+		p.Description = strings.TrimSpace(p.Description)
+		cleaned = append(cleaned, p)
+	}
+	a.prices = cleaned
+	a.updatedAt = now
+	return nil
+}
+
+// Price returns the account's price for a product.
+func (a *Account) Price(productID uuid.UUID) (Price, error) {
+	for _, p := range a.prices {
+		if p.ProductID == productID {
+			return p, nil
+		}
+	}
+	return Price{}, ErrNotOnPriceList
+}
+
+// NeedsApproval reports whether an order of totalCents must wait for an
+// approver.
+func (a *Account) NeedsApproval(totalCents int64) bool {
+	return a.terms.ApprovalThresholdCents != nil && totalCents > *a.terms.ApprovalThresholdCents
+}
+
+// DueOn returns when a charge made on date falls due.
+func (a *Account) DueOn(date time.Time) time.Time {
+	return Day(date).AddDate(0, 0, a.terms.TermsDays)
+}
+
+// Hold sets credit aside for an order waiting for approval.
+func (a *Account) Hold(amountCents int64, now time.Time) error {
+	if err := a.checkCredit(amountCents); err != nil {
+		return err
+	}
+	a.heldCents += amountCents
+	a.updatedAt = now
+	return nil
+}
+
+// Release gives back credit held for an order.
+func (a *Account) Release(amountCents int64, now time.Time) {
+	a.heldCents = max(0, a.heldCents-amountCents)
+	a.updatedAt = now
+}
+
+// Charge adds an order to what the account owes.
+func (a *Account) Charge(amountCents int64, now time.Time) error {
+	if err := a.checkCredit(amountCents); err != nil {
+		return err
+	}
+	a.balanceCents += amountCents
+	a.updatedAt = now
+	return nil
+}
+
+// Pay takes a payment received off what the account owes. Paying more than
+// is owed leaves the account in credit.
+func (a *Account) Pay(amountCents int64, now time.Time) error {
+	if amountCents <= 0 {
+		return ErrInvalidAmount
+	}
+	a.balanceCents -= amountCents
+	a.updatedAt = now
+	return nil
+}
+
+func (a *Account) checkCredit(amountCents int64) error {
+	if !a.active {
+		return ErrAccountInactive
+	}
+	if amountCents > a.AvailableCreditCents() {
+		return ErrCreditLimitExceeded
+	}
+	return nil
+}
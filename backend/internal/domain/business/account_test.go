@@ -0,0 +1,98 @@
+package business_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newAccount(t *testing.T, creditLimitCents int64, threshold *int64) *business.Account {
+	t.Helper()
+	a, err := business.NewAccount(uuid.New(), business.Details{
+		Name:         " The Grill House ",
+		BillingEmail: "accounts@grillhouse.example",
+	}, business.Terms{CreditLimitCents: creditLimitCents, TermsDays: 30, ApprovalThresholdCents: threshold}, now)
+	require.NoError(t, err)
+	return a
+}
+
+func TestNewAccount(t *testing.T) {
+	a := newAccount(t, 100000, nil)
+	assert.Equal(t, "The Grill House", a.Details().Name)
+	assert.True(t, a.Active())
+	assert.Equal(t, int64(100000), a.AvailableCreditCents())
+
+	_, err := business.NewAccount(uuid.New(), business.Details{Name: "Caterers"},
+		business.Terms{TermsDays: 30}, now)
+	assert.ErrorIs(t, err, business.ErrEmptyBillingEmail)
+
+	_, err = business.NewAccount(uuid.New(), business.Details{Name: "Caterers", BillingEmail: "a@b.example"},
+		business.Terms{TermsDays: 0}, now)
+	assert.ErrorIs(t, err, business.ErrInvalidTerms)
+}
+
+func TestAccount_SetPrices(t *testing.T) {
+	a := newAccount(t, 0, nil)
+	mince := uuid.New()
+
+	err := a.SetPrices([]business.Price{{ProductID: mince, Tiers: []business.Tier{
+		{MinGrams: 0, PricePerKgCents: 900},
+		{MinGrams: 5000, PricePerKgCents: 800},
+		{MinGrams: 20000, PricePerKgCents: 700},
+	}}}, now)
+	require.NoError(t, err)
+
+	price, err := a.Price(mince)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), price.PerKgFor(4999))
+	assert.Equal(t, int64(800), price.PerKgFor(5000))
+	assert.Equal(t, int64(700), price.PerKgFor(25000))
+
+	_, err = a.Price(uuid.New())
+	assert.ErrorIs(t, err, business.ErrNotOnPriceList)
+
+	tests := []struct {
+		name  string
+		tiers []business.Tier
+	}{
+		{"no tiers", nil},
+		{"first tier above zero", []business.Tier{{MinGrams: 1000, PricePerKgCents: 900}}},
+		{"tiers out of order", []business.Tier{{MinGrams: 0, PricePerKgCents: 900}, {MinGrams: 0, PricePerKgCents: 800}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.SetPrices([]business.Price{{ProductID: mince, Tiers: tt.tiers}}, now)
+			assert.ErrorIs(t, err, business.ErrInvalidTiers)
+		})
+	}
+}
+
+func TestAccount_Credit(t *testing.T) {
+	a := newAccount(t, 10000, nil)
+
+	require.NoError(t, a.Hold(4000, now))
+	require.NoError(t, a.Charge(5000, now))
+	assert.Equal(t, int64(1000), a.AvailableCreditCents())
+	assert.ErrorIs(t, a.Charge(1001, now), business.ErrCreditLimitExceeded)
+
+	a.Release(4000, now)
+	require.NoError(t, a.Pay(2000, now))
+	assert.Equal(t, int64(3000), a.BalanceCents())
+	assert.Equal(t, int64(7000), a.AvailableCreditCents())
+
+	require.NoError(t, a.Update(a.Details(), a.Terms(), false, now))
+	assert.ErrorIs(t, a.Charge(100, now), business.ErrAccountInactive)
+	assert.ErrorIs(t, a.Pay(0, now), business.ErrInvalidAmount)
+}
+
+func TestAccount_DueOn(t *testing.T) {
+	a := newAccount(t, 0, nil)
+	due := a.DueOn(time.Date(2026, 1, 31, 22, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), due)
+}
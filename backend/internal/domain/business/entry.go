@@ -0,0 +1,51 @@
+package business
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry is a movement on a business's account: an order charged to it or a
+// payment received against it. Charges are positive and payments negative,
+// so an account's balance is the sum of its entries.
+type Entry struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Type        EntryType
+	AmountCents int64
+	OrderID     *uuid.UUID
+	Reference   string
+	DueOn       *time.Time
+	PostedAt    time.Time
+}
+
+// NewCharge records an order placed on account, due on the account's terms
+// from today, the calendar date in the shop's time zone.
+func NewCharge(id uuid.UUID, a *Account, o *Order, today, now time.Time) *Entry {
+	orderID := o.OrderID()
+	due := a.DueOn(today)
+	return &Entry{
+		ID:          id,
+		AccountID:   a.ID(),
+		Type:        EntryCharge,
+		AmountCents: o.TotalCents(),
+		OrderID:     &orderID,
+		DueOn:       &due,
+		PostedAt:    now,
+	}
+}
+
+// NewPayment records a payment received, such as a bank transfer.
+// reference is how the business identified it.
+func NewPayment(id uuid.UUID, a *Account, amountCents int64, reference string, now time.Time) *Entry {
+	return &Entry{
+		ID:          id,
+		AccountID:   a.ID(),
+		Type:        EntryPayment,
+		AmountCents: -amountCents,
+		Reference:   strings.TrimSpace(reference),
+		PostedAt:    now,
+	}
+}
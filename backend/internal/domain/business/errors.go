@@ -0,0 +1,30 @@
+package business
+
+import "errors"
+
+var (
+	ErrInvalidRole            = errors.New("role must be one of buyer or approver")
+	ErrInvalidOrderStatus     = errors.New("order status must be one of pending_approval, placed or rejected")
+	ErrEmptyName              = errors.New("business name must not be empty")
+	ErrEmptyBillingEmail      = errors.New("billing email must not be empty")
+	ErrNegativeCreditLimit    = errors.New("credit limit must not be negative")
+	ErrInvalidTerms           = errors.New("payment terms must be between 1 and 120 days")
+	ErrNegativeThreshold      = errors.New("approval threshold must not be negative")
+	ErrInvalidTiers           = errors.New("a price needs tiers in increasing order of weight, the first from zero grams")
+	ErrNegativePrice          = errors.New("price must not be negative")
+	ErrDuplicatePrice         = errors.New("a product can appear on a price list only once")
+	ErrNotOnPriceList         = errors.New("product is not on the account's price list")
+	ErrBranchRequired         = errors.New("branch is required")
+	ErrNoLines                = errors.New("an order must have at least one line")
+	ErrInvalidWeight          = errors.New("weight must be greater than zero")
+	ErrInvalidAmount          = errors.New("amount must be greater than zero")
+	ErrAccountInactive        = errors.New("business account is on hold")
+	ErrCreditLimitExceeded    = errors.New("order would take the account over its credit limit")
+	ErrNotApprover            = errors.New("only the account's approvers can approve orders")
+	ErrOrderNotPending        = errors.New("order is not waiting for approval")
+	ErrAccountNotFound        = errors.New("business account not found")
+	ErrMemberNotFound         = errors.New("customer is not a member of a business account")
+	ErrMemberOfAnotherAccount = errors.New("customer is already a member of another business account")
+	ErrOrderNotFound          = errors.New("business order not found")
+	ErrConcurrentUpdate       = errors.New("business account was changed by someone else; reload and try again")
+)
@@ -0,0 +1,19 @@
+package business
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Member is a customer who buys for a business. A customer belongs to at
+// most one business.
+type Member struct {
+	AccountID  uuid.UUID
+	CustomerID uuid.UUID
+	Role       Role
+	CreatedAt  time.Time
+}
+
+// CanApprove reports whether the member may approve orders.
+func (m Member) CanApprove() bool { return m.Role == RoleApprover }
@@ -0,0 +1,155 @@
+package business
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Line is the weight of one product ordered at the account's price for it.
+type Line struct {
+	ProductID       uuid.UUID
+	Description     string
+	Grams           int64
+	PricePerKgCents int64
+}
+
+// Order is an order a member places on their business's account. It is
+// placed straight away unless it needs an approver's sign-off, in which
+// case credit is held for it until it is approved or rejected. The sale is
+// recorded as an order under OrderID once it is placed.
+type Order struct {
+	id           uuid.UUID
+	accountID    uuid.UUID
+	placedBy     uuid.UUID
+	branchID     uuid.UUID
+	orderID      uuid.UUID
+	lines        []Line
+	totalCents   int64
+	status       OrderStatus
+	decidedBy    *uuid.UUID
+	decidedAt    *time.Time
+	rejectReason string
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewOrder creates an order of lines coming to totalCents with VAT. It
+// waits for approval if the account requires it for the amount and the
+// member placing it is not an approver themselves.
+func NewOrder(id, orderID uuid.UUID, a *Account, placedBy Member, branchID uuid.UUID, lines []Line, totalCents int64, now time.Time) (*Order, error) {
+	if branchID == uuid.Nil {
+		return nil, ErrBranchRequired
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+	cleaned := make([]Line, 0, len(lines))
+	for _, l := range lines {
+		if l.Grams <= 0 {
+			return nil, ErrInvalidWeight
+		}
+		if l.PricePerKgCents < 0 {
+			return nil, ErrNegativePrice
+		}
+		l.Description = strings.TrimSpace(l.Description)
+		cleaned = append(cleaned, l)
+	}
+
+	status := OrderPlaced
+	if a.NeedsApproval(totalCents) && !placedBy.CanApprove() {
+		status = OrderPendingApproval
+	}
+	return &Order{
+		id:         id,
+		accountID:  a.ID(),
+		placedBy:   placedBy.CustomerID,
+		branchID:   branchID,
+		orderID:    orderID,
+		lines:      cleaned,
+		totalCents: totalCents,
+		status:     status,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// ReconstructOrder reconstructs an Order from persistence without validation.
+func ReconstructOrder(
+	id, accountID, placedBy, branchID, orderID uuid.UUID,
+	lines []Line,
+	totalCents int64,
+	status OrderStatus,
+	decidedBy *uuid.UUID,
+	decidedAt *time.Time,
+	rejectReason string,
+	createdAt, updatedAt time.Time,
+) *Order {
+	return &Order{
+		id:           id,
+		accountID:    accountID,
+		placedBy:     placedBy,
+		branchID:     branchID,
+		orderID:      orderID,
+		lines:        lines,
+		totalCents:   totalCents,
+		status:       status,
+		decidedBy:    decidedBy,
+		decidedAt:    decidedAt,
+		rejectReason: rejectReason,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (o *Order) ID() uuid.UUID         { return o.id }
+func (o *Order) AccountID() uuid.UUID  { return o.accountID }
+func (o *Order) PlacedBy() uuid.UUID   { return o.placedBy }
+func (o *Order) BranchID() uuid.UUID   { return o.branchID }
+func (o *Order) OrderID() uuid.UUID    { return o.orderID }
+func (o *Order) Lines() []Line         { return o.lines }
+func (o *Order) TotalCents() int64     { return o.totalCents }
+func (o *Order) Status() OrderStatus   { return o.status }
+func (o *Order) DecidedBy() *uuid.UUID { return o.decidedBy }
+func (o *Order) DecidedAt() *time.Time { return o.decidedAt }
+func (o *Order) RejectReason() string  { return o.rejectReason }
+func (o *Order) CreatedAt() time.Time  { return o.createdAt }
+func (o *Order) UpdatedAt() time.Time  { return o.updatedAt }
+
+// Approve places an order that was waiting for approval. totalCents is the
+// order priced again at the time of approval.
+func (o *Order) Approve(approver Member, totalCents int64, now time.Time) error {
+	if err := o.decide(approver); err != nil {
+		return err
+	}
+	o.status = OrderPlaced
+	o.totalCents = totalCents
+	o.decidedBy = &approver.CustomerID
+	o.decidedAt = &now
+	o.updatedAt = now
+	return nil
+}
+
+// Reject turns down an order that was waiting for approval.
+func (o *Order) Reject(approver Member, reason string, now time.Time) error {
+	if err := o.decide(approver); err != nil {
+		return err
+	}
+	o.status = OrderRejected
+	o.rejectReason = strings.TrimSpace(reason)
+	o.decidedBy = &approver.CustomerID
+	o.decidedAt = &now
+	o.updatedAt = now
+	return nil
+}
+
+func (o *Order) decide(approver Member) error {
+	if approver.AccountID != o.accountID || !approver.CanApprove() {
+		return ErrNotApprover
+	}
+	if o.status != OrderPendingApproval {
+		return ErrOrderNotPending
+	}
+	return nil
+}
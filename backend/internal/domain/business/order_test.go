@@ -0,0 +1,83 @@
+package business_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrder(t *testing.T, a *business.Account, role business.Role, totalCents int64) *business.Order {
+	t.Helper()
+	placedBy := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: role}
+	o, err := business.NewOrder(uuid.New(), uuid.New(), a, placedBy, uuid.New(), []business.Line{
+		{ProductID: uuid.New(), Description: "Lamb mince", Grams: 10000, PricePerKgCents: 800},
+	}, totalCents, now)
+	require.NoError(t, err)
+	return o
+}
+
+func TestNewOrder_Approval(t *testing.T) {
+	threshold := int64(50000)
+	a := newAccount(t, 1000000, &threshold)
+
+	assert.Equal(t, business.OrderPlaced, newOrder(t, a, business.RoleBuyer, 50000).Status())
+	assert.Equal(t, business.OrderPendingApproval, newOrder(t, a, business.RoleBuyer, 50001).Status())
+	assert.Equal(t, business.OrderPlaced, newOrder(t, a, business.RoleApprover, 50001).Status())
+
+	noThreshold := newAccount(t, 1000000, nil)
+	assert.Equal(t, business.OrderPlaced, newOrder(t, noThreshold, business.RoleBuyer, 900000).Status())
+}
+
+func TestNewOrder_Invalid(t *testing.T) {
+	a := newAccount(t, 0, nil)
+	m := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: business.RoleBuyer}
+
+	_, err := business.NewOrder(uuid.New(), uuid.New(), a, m, uuid.New(), nil, 0, now)
+	assert.ErrorIs(t, err, business.ErrNoLines)
+
+	_, err = business.NewOrder(uuid.New(), uuid.New(), a, m, uuid.Nil,
+		[]business.Line{{ProductID: uuid.New(), Grams: 1000}}, 0, now)
+	assert.ErrorIs(t, err, business.ErrBranchRequired)
+
+	_, err = business.NewOrder(uuid.New(), uuid.New(), a, m, uuid.New(),
+		[]business.Line{{ProductID: uuid.New(), Grams: 0}}, 0, now)
+	assert.ErrorIs(t, err, business.ErrInvalidWeight)
+}
+
+func TestOrder_Decide(t *testing.T) {
+	threshold := int64(0)
+	a := newAccount(t, 1000000, &threshold)
+
+	t.Run("approver approves", func(t *testing.T) {
+		o := newOrder(t, a, business.RoleBuyer, 8000)
+		approver := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: business.RoleApprover}
+
+		require.NoError(t, o.Approve(approver, 8100, now.Add(time.Hour)))
+		assert.Equal(t, business.OrderPlaced, o.Status())
+		assert.Equal(t, int64(8100), o.TotalCents())
+		assert.Equal(t, approver.CustomerID, *o.DecidedBy())
+		assert.ErrorIs(t, o.Reject(approver, "too much", now), business.ErrOrderNotPending)
+	})
+
+	t.Run("buyers and other accounts cannot decide", func(t *testing.T) {
+		o := newOrder(t, a, business.RoleBuyer, 8000)
+		buyer := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: business.RoleBuyer}
+		outsider := business.Member{AccountID: uuid.New(), CustomerID: uuid.New(), Role: business.RoleApprover}
+
+		assert.ErrorIs(t, o.Approve(buyer, 8000, now), business.ErrNotApprover)
+		assert.ErrorIs(t, o.Reject(outsider, "no", now), business.ErrNotApprover)
+	})
+
+	t.Run("approver rejects", func(t *testing.T) {
+		o := newOrder(t, a, business.RoleBuyer, 8000)
+		approver := business.Member{AccountID: a.ID(), CustomerID: uuid.New(), Role: business.RoleApprover}
+
+		require.NoError(t, o.Reject(approver, " over budget ", now))
+		assert.Equal(t, business.OrderRejected, o.Status())
+		assert.Equal(t, "over budget", o.RejectReason())
+	})
+}
@@ -0,0 +1,56 @@
+package business
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderFilter narrows an order query. Nil fields are not filtered on.
+type OrderFilter struct {
+	AccountID *uuid.UUID
+	Status    *OrderStatus
+}
+
+// AccountRepository provides access to business accounts.
+type AccountRepository interface {
+	Create(ctx context.Context, a *Account) error
+	// Update stores a changed account. It returns ErrConcurrentUpdate if the
+	// account has changed since it was loaded.
+	Update(ctx context.Context, a *Account) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Account, error)
+	// FindAll returns accounts by name.
+	FindAll(ctx context.Context) ([]*Account, error)
+}
+
+// MemberRepository provides access to the customers who buy for each
+// business.
+type MemberRepository interface {
+	// Save adds a member or changes their role. It returns
+	// ErrMemberOfAnotherAccount if the customer already buys for another
+	// business.
+	Save(ctx context.Context, m Member) error
+	// Remove returns ErrMemberNotFound if the customer is not a member of
+	// the account.
+	Remove(ctx context.Context, accountID, customerID uuid.UUID) error
+	FindByCustomer(ctx context.Context, customerID uuid.UUID) (Member, error)
+	// FindByAccount returns an account's members, earliest added first.
+	FindByAccount(ctx context.Context, accountID uuid.UUID) ([]Member, error)
+}
+
+// Ledger stores the movements on business accounts together with what
+// caused them.
+type Ledger interface {
+	// Record stores an account's changed balance with the order and entry
+	// that changed it in one transaction; either may be nil. The order is
+	// inserted or updated. It returns ErrConcurrentUpdate if the account
+	// has changed since it was loaded.
+	Record(ctx context.Context, a *Account, o *Order, e *Entry) error
+	// Entries returns an account's entries posted before the given time,
+	// oldest first.
+	Entries(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*Entry, error)
+	FindOrder(ctx context.Context, id uuid.UUID) (*Order, error)
+	// FindOrders returns orders, newest first.
+	FindOrders(ctx context.Context, filter OrderFilter) ([]*Order, error)
+}
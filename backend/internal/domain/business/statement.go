@@ -0,0 +1,54 @@
+package business
+
+import "time"
+
+// StatementLine is an entry on a statement with the balance after it.
+type StatementLine struct {
+	Entry        *Entry
+	BalanceCents int64
+}
+
+// Statement is what happened on an account between From and To: the
+// balance brought forward, every charge and payment, and what was owed at
+// the end. OverdueCents is the part of the closing balance that was past
+// due by To; payments settle the oldest charges first.
+type Statement struct {
+	Account      *Account
+	From         time.Time
+	To           time.Time
+	OpeningCents int64
+	Lines        []StatementLine
+	ChargedCents int64
+	PaidCents    int64
+	ClosingCents int64
+	OverdueCents int64
+}
+
+// NewStatement builds a statement for [from, to) from the account's entries
+// posted before to, oldest first.
+func NewStatement(a *Account, from, to time.Time, entries []*Entry) Statement {
+	s := Statement{Account: a, From: from, To: to, Lines: []StatementLine{}}
+	var notYetDue int64
+	for _, e := range entries {
+		if !e.PostedAt.Before(to) {
+			continue
+		}
+		if e.Type == EntryCharge && e.DueOn != nil && !e.DueOn.Before(Day(to)) {
+			notYetDue += e.AmountCents
+		}
+		if e.PostedAt.Before(from) {
+			s.OpeningCents += e.AmountCents
+			continue
+		}
+		if e.Type == EntryCharge {
+			s.ChargedCents += e.AmountCents
+		} else {
+			s.PaidCents -= e.AmountCents
+		}
+		s.ClosingCents = s.OpeningCents + s.ChargedCents - s.PaidCents
+		s.Lines = append(s.Lines, StatementLine{Entry: e, BalanceCents: s.ClosingCents})
+	}
+	s.ClosingCents = s.OpeningCents + s.ChargedCents - s.PaidCents
+	s.OverdueCents = max(0, s.ClosingCents-notYetDue)
+	return s
+}
@@ -0,0 +1,41 @@
+package business_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatement(t *testing.T) {
+	a := newAccount(t, 1000000, nil)
+	charge := func(amount int64, at time.Time) *business.Entry {
+		o := newOrder(t, a, business.RoleBuyer, amount)
+		return business.NewCharge(uuid.New(), a, o, at, at)
+	}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	entries := []*business.Entry{
+		charge(20000, time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)), // due 19 Feb
+		charge(30000, time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC)), // due 17 Mar
+		business.NewPayment(uuid.New(), a, 15000, "BACS 1", time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)),
+		charge(40000, time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)), // due 19 Apr
+		charge(5000, time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC)),   // after the period
+	}
+
+	s := business.NewStatement(a, from, to, entries)
+
+	assert.Equal(t, int64(50000), s.OpeningCents)
+	assert.Equal(t, int64(40000), s.ChargedCents)
+	assert.Equal(t, int64(15000), s.PaidCents)
+	assert.Equal(t, int64(75000), s.ClosingCents)
+	require.Len(t, s.Lines, 2)
+	assert.Equal(t, int64(35000), s.Lines[0].BalanceCents)
+	assert.Equal(t, int64(75000), s.Lines[1].BalanceCents)
+	// Of the 75000 owed, 40000 is not yet due; the rest of the older charges is.
+	assert.Equal(t, int64(35000), s.OverdueCents)
+}
@@ -0,0 +1,56 @@
+package business
+
+import "time"
+
+// Role is what a member may do on their business's account. Buyers place
+// orders; approvers also approve orders that need it.
+type Role string
+
+const (
+	RoleBuyer    Role = "buyer"
+	RoleApprover Role = "approver"
+)
+
+// NewRole validates a raw role.
+func NewRole(raw string) (Role, error) {
+	switch r := Role(raw); r {
+	case RoleBuyer, RoleApprover:
+		return r, nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
+
+// OrderStatus is where an order on account is.
+type OrderStatus string
+
+const (
+	OrderPendingApproval OrderStatus = "pending_approval"
+	OrderPlaced          OrderStatus = "placed"
+	OrderRejected        OrderStatus = "rejected"
+)
+
+// NewOrderStatus validates a raw order status.
+func NewOrderStatus(raw string) (OrderStatus, error) {
+	switch s := OrderStatus(raw); s {
+	case OrderPendingApproval, OrderPlaced, OrderRejected:
+		return s, nil
+	default:
+		return "", ErrInvalidOrderStatus
+	}
+}
+
+// EntryType is what moved an account's balance.
+type EntryType string
+
+const (
+	EntryCharge  EntryType = "charge"
+	EntryPayment EntryType = "payment"
+)
+
+// Day returns the calendar date of t as midnight UTC, the form in which due
+// dates are kept and compared.
+func Day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
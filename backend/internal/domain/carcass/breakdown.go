@@ -0,0 +1,56 @@
+package carcass
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cut is a primal or retail cut taken from a carcass. Primal groups cuts for
+// yield reporting, e.g. "forequarter" or "leg". Each cut is received into
+// inventory as its own lot.
+type Cut struct {
+	LotID     uuid.UUID
+	Primal    string
+	ProductID uuid.UUID
+	Grams     int64
+	ExpiresOn *time.Time
+}
+
+// Breakdown is the record of how a carcass was cut up.
+type Breakdown struct {
+	carcassID  uuid.UUID
+	cuts       []Cut
+	trimGrams  int64
+	wasteGrams int64
+	recordedBy *uuid.UUID
+	recordedAt time.Time
+}
+
+// ReconstructBreakdown reconstructs a Breakdown from persistence without validation.
+func ReconstructBreakdown(carcassID uuid.UUID, cuts []Cut, trimGrams, wasteGrams int64, recordedBy *uuid.UUID, recordedAt time.Time) *Breakdown {
+	return &Breakdown{
+		carcassID:  carcassID,
+		cuts:       cuts,
+		trimGrams:  trimGrams,
+		wasteGrams: wasteGrams,
+		recordedBy: recordedBy,
+		recordedAt: recordedAt,
+	}
+}
+
+func (b *Breakdown) CarcassID() uuid.UUID   { return b.carcassID }
+func (b *Breakdown) Cuts() []Cut            { return b.cuts }
+func (b *Breakdown) TrimGrams() int64       { return b.trimGrams }
+func (b *Breakdown) WasteGrams() int64      { return b.wasteGrams }
+func (b *Breakdown) RecordedBy() *uuid.UUID { return b.recordedBy }
+func (b *Breakdown) RecordedAt() time.Time  { return b.recordedAt }
+
+// SaleableGrams is the total weight of the cuts.
+func (b *Breakdown) SaleableGrams() int64 {
+	var total int64
+	for _, c := range b.cuts {
+		total += c.Grams
+	}
+	return total
+}
@@ -0,0 +1,173 @@
+package carcass
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is where a carcass is in the cutting room.
+type Status string
+
+const (
+	StatusReceived   Status = "received"
+	StatusBrokenDown Status = "broken_down"
+)
+
+// Carcass is a whole or half animal bought in for breaking down. Weights are
+// in grams and cost in cents.
+type Carcass struct {
+	id                  uuid.UUID
+	branchID            uuid.UUID
+	tag                 string
+	species             Species
+	hangingWeightGrams  int64
+	costCents           int64
+	supplier            string
+	slaughteredOn       time.Time
+	halalCertificateRef string
+	status              Status
+	receivedAt          time.Time
+	updatedAt           time.Time
+}
+
+// NewCarcass validates and records a carcass intake. supplier is the farm the
+// animal came from. tag is the kill number or ear tag printed on the carcass
+// label; it becomes the lot code of every cut taken from it.
+func NewCarcass(
+	id, branchID uuid.UUID,
+	tag string,
+	species Species,
+	hangingWeightGrams, costCents int64,
+	supplier string,
+	slaughteredOn time.Time,
+	halalCertificateRef string,
+	now time.Time,
+) (*Carcass, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, ErrEmptyTag
+	}
+	supplier = strings.TrimSpace(supplier)
+	if supplier == "" {
+		return nil, ErrEmptySupplier
+	}
+	halalCertificateRef = strings.TrimSpace(halalCertificateRef)
+	if halalCertificateRef == "" {
+		return nil, ErrEmptyHalalCertificate
+	}
+	if hangingWeightGrams <= 0 {
+		return nil, ErrInvalidWeight
+	}
+	if costCents < 0 {
+		return nil, ErrNegativeCost
+	}
+	if slaughteredOn.After(now) {
+		return nil, ErrSlaughterDateInFuture
+	}
+
+	return &Carcass{
+		id:                  id,
+		branchID:            branchID,
+		tag:                 tag,
+		species:             species,
+		hangingWeightGrams:  hangingWeightGrams,
+		costCents:           costCents,
+		supplier:            supplier,
+		slaughteredOn:       slaughteredOn,
+		halalCertificateRef: halalCertificateRef,
+		status:              StatusReceived,
+		receivedAt:          now,
+		updatedAt:           now,
+	}, nil
+}
+
+// ReconstructCarcass reconstructs a Carcass from persistence without validation.
+func ReconstructCarcass(
+	id, branchID uuid.UUID,
+	tag string,
+	species Species,
+	hangingWeightGrams, costCents int64,
+	supplier string,
+	slaughteredOn time.Time,
+	halalCertificateRef string,
+	status Status,
+	receivedAt, updatedAt time.Time,
+) *Carcass {
+	return &Carcass{
+		id:                  id,
+		branchID:            branchID,
+		tag:                 tag,
+		species:             species,
+		hangingWeightGrams:  hangingWeightGrams,
+		costCents:           costCents,
+		supplier:            supplier,
+		slaughteredOn:       slaughteredOn,
+		halalCertificateRef: halalCertificateRef,
+		status:              status,
+		receivedAt:          receivedAt,
+		updatedAt:           updatedAt,
+	}
+}
+
+func (c *Carcass) ID() uuid.UUID               { return c.id }
+func (c *Carcass) BranchID() uuid.UUID         { return c.branchID }
+func (c *Carcass) Tag() string                 { return c.tag }
+func (c *Carcass) Species() Species            { return c.species }
+func (c *Carcass) HangingWeightGrams() int64   { return c.hangingWeightGrams }
+func (c *Carcass) CostCents() int64            { return c.costCents }
+func (c *Carcass) Supplier() string            { return c.supplier }
+func (c *Carcass) SlaughteredOn() time.Time    { return c.slaughteredOn }
+func (c *Carcass) HalalCertificateRef() string { return c.halalCertificateRef }
+func (c *Carcass) Status() Status              { return c.status }
+func (c *Carcass) ReceivedAt() time.Time       { return c.receivedAt }
+func (c *Carcass) UpdatedAt() time.Time        { return c.updatedAt }
+
+// BreakDown records how the carcass was cut up. Every cut is given the ID of
+// the inventory lot it will be received into. Whatever the cuts, trim and
+// waste do not account for is shrink, mostly moisture lost while hanging.
+func (c *Carcass) BreakDown(cuts []Cut, trimGrams, wasteGrams int64, actorID *uuid.UUID, now time.Time) (*Breakdown, error) {
+	if c.status != StatusReceived {
+		return nil, ErrAlreadyBrokenDown
+	}
+	if len(cuts) == 0 {
+		return nil, ErrNoCuts
+	}
+	if trimGrams < 0 || wasteGrams < 0 {
+		return nil, ErrNegativeWeight
+	}
+
+	total := trimGrams + wasteGrams
+	recorded := make([]Cut, 0, len(cuts))
+	for _, cut := range cuts {
+		cut.Primal = normalisePrimal(cut.Primal)
+		if cut.Primal == "" {
+			return nil, ErrEmptyPrimal
+		}
+		if cut.Grams <= 0 {
+			return nil, ErrInvalidWeight
+		}
+		cut.LotID = uuid.New()
+		total += cut.Grams
+		recorded = append(recorded, cut)
+	}
+	if total > c.hangingWeightGrams {
+		return nil, ErrBreakdownExceedsCarcass
+	}
+
+	c.status = StatusBrokenDown
+	c.updatedAt = now
+	return &Breakdown{
+		carcassID:  c.id,
+		cuts:       recorded,
+		trimGrams:  trimGrams,
+		wasteGrams: wasteGrams,
+		recordedBy: actorID,
+		recordedAt: now,
+	}, nil
+}
+
+func normalisePrimal(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
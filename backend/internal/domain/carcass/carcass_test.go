@@ -0,0 +1,125 @@
+package carcass_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func slaughterDate() time.Time {
+	return time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+}
+
+func newCarcass(t *testing.T, hangingGrams, costCents int64) *carcass.Carcass {
+	t.Helper()
+	c, err := carcass.NewCarcass(uuid.New(), uuid.New(), "KILL-1042", carcass.SpeciesLamb,
+		hangingGrams, costCents, "Green Valley Farm", slaughterDate(), "HMC-2026-0193", time.Now())
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewSpecies(t *testing.T) {
+	s, err := carcass.NewSpecies("goat")
+	require.NoError(t, err)
+	assert.Equal(t, carcass.SpeciesGoat, s)
+
+	_, err = carcass.NewSpecies("pork")
+	assert.ErrorIs(t, err, carcass.ErrInvalidSpecies)
+}
+
+func TestNewCarcass_ValidInput_IsReceived(t *testing.T) {
+	c := newCarcass(t, 20000, 15000)
+
+	assert.Equal(t, "KILL-1042", c.Tag())
+	assert.Equal(t, carcass.StatusReceived, c.Status())
+	assert.Equal(t, "HMC-2026-0193", c.HalalCertificateRef())
+}
+
+func TestNewCarcass_InvalidInputs_ReturnError(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		tag      string
+		grams    int64
+		cost     int64
+		supplier string
+		cert     string
+		killed   time.Time
+		want     error
+	}{
+		{"empty tag", " ", 20000, 0, "Farm", "HMC", slaughterDate(), carcass.ErrEmptyTag},
+		{"empty supplier", "T", 20000, 0, "", "HMC", slaughterDate(), carcass.ErrEmptySupplier},
+		{"missing certificate", "T", 20000, 0, "Farm", "", slaughterDate(), carcass.ErrEmptyHalalCertificate},
+		{"zero weight", "T", 0, 0, "Farm", "HMC", slaughterDate(), carcass.ErrInvalidWeight},
+		{"negative cost", "T", 20000, -1, "Farm", "HMC", slaughterDate(), carcass.ErrNegativeCost},
+		{"slaughtered tomorrow", "T", 20000, 0, "Farm", "HMC", now.AddDate(0, 0, 1), carcass.ErrSlaughterDateInFuture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := carcass.NewCarcass(uuid.New(), uuid.New(), tt.tag, carcass.SpeciesBeef,
+				tt.grams, tt.cost, tt.supplier, tt.killed, tt.cert, now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestCarcass_BreakDown(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("records cuts and marks carcass broken down", func(t *testing.T) {
+		c := newCarcass(t, 20000, 15000)
+
+		b, err := c.BreakDown([]carcass.Cut{
+			{Primal: " Leg ", ProductID: productID, Grams: 6000},
+			{Primal: "shoulder", ProductID: uuid.New(), Grams: 5000},
+		}, 2000, 1500, nil, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, carcass.StatusBrokenDown, c.Status())
+		assert.Equal(t, int64(11000), b.SaleableGrams())
+		require.Len(t, b.Cuts(), 2)
+		assert.Equal(t, "leg", b.Cuts()[0].Primal)
+		assert.NotEqual(t, uuid.Nil, b.Cuts()[0].LotID)
+		assert.NotEqual(t, b.Cuts()[0].LotID, b.Cuts()[1].LotID)
+	})
+
+	t.Run("cannot break down twice", func(t *testing.T) {
+		c := newCarcass(t, 20000, 15000)
+		_, err := c.BreakDown([]carcass.Cut{{Primal: "leg", ProductID: productID, Grams: 6000}}, 0, 0, nil, time.Now())
+		require.NoError(t, err)
+
+		_, err = c.BreakDown([]carcass.Cut{{Primal: "leg", ProductID: productID, Grams: 6000}}, 0, 0, nil, time.Now())
+
+		assert.ErrorIs(t, err, carcass.ErrAlreadyBrokenDown)
+	})
+
+	t.Run("invalid breakdowns are rejected", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			cuts  []carcass.Cut
+			trim  int64
+			waste int64
+			want  error
+		}{
+			{"no cuts", nil, 0, 0, carcass.ErrNoCuts},
+			{"empty primal", []carcass.Cut{{Primal: " ", ProductID: productID, Grams: 100}}, 0, 0, carcass.ErrEmptyPrimal},
+			{"zero weight cut", []carcass.Cut{{Primal: "leg", ProductID: productID}}, 0, 0, carcass.ErrInvalidWeight},
+			{"negative trim", []carcass.Cut{{Primal: "leg", ProductID: productID, Grams: 100}}, -1, 0, carcass.ErrNegativeWeight},
+			{"heavier than carcass", []carcass.Cut{{Primal: "leg", ProductID: productID, Grams: 19000}}, 1000, 1, carcass.ErrBreakdownExceedsCarcass},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				c := newCarcass(t, 20000, 15000)
+
+				_, err := c.BreakDown(tt.cuts, tt.trim, tt.waste, nil, time.Now())
+
+				assert.ErrorIs(t, err, tt.want)
+				assert.Equal(t, carcass.StatusReceived, c.Status())
+			})
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package carcass
+
+import "errors"
+
+var (
+	ErrInvalidSpecies          = errors.New("species must be one of beef, veal, lamb, mutton, goat or chicken")
+	ErrEmptyTag                = errors.New("carcass tag must not be empty")
+	ErrEmptySupplier           = errors.New("supplier must not be empty")
+	ErrEmptyHalalCertificate   = errors.New("halal certificate reference must not be empty")
+	ErrInvalidWeight           = errors.New("weight must be greater than zero")
+	ErrNegativeWeight          = errors.New("trim and waste weights must not be negative")
+	ErrNegativeCost            = errors.New("cost must not be negative")
+	ErrSlaughterDateInFuture   = errors.New("slaughter date must not be in the future")
+	ErrInvalidDate             = errors.New("date must be in YYYY-MM-DD format")
+	ErrEmptyPrimal             = errors.New("primal name must not be empty")
+	ErrNoCuts                  = errors.New("breakdown must contain at least one cut")
+	ErrBreakdownExceedsCarcass = errors.New("cuts, trim and waste weigh more than the carcass")
+	ErrAlreadyBrokenDown       = errors.New("carcass has already been broken down")
+	ErrNotBrokenDown           = errors.New("carcass has not been broken down yet")
+	ErrInvalidPercentage       = errors.New("percentages must be between 0 and 100")
+	ErrInvalidYieldTemplate    = errors.New("expected yield, trim and waste must not add up to more than 100%")
+	ErrInvalidPrimalYields     = errors.New("expected primal yields must not add up to more than the expected yield")
+	ErrDuplicatePrimal         = errors.New("yield template lists the same primal more than once")
+	ErrInvalidValueFactor      = errors.New("value factor must not be negative")
+	ErrDuplicateTag            = errors.New("a carcass with this tag already exists")
+	ErrCarcassNotFound         = errors.New("carcass not found")
+	ErrYieldTemplateNotFound   = errors.New("yield template not found")
+)
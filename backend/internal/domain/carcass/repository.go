@@ -0,0 +1,38 @@
+package carcass
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// Filter narrows FindAll. Nil fields are not filtered on.
+type Filter struct {
+	Species  *Species
+	BranchID *uuid.UUID
+	Status   *Status
+}
+
+// CarcassRepository provides access to carcasses and their breakdowns.
+type CarcassRepository interface {
+	// Save stores a new carcass intake. It returns ErrDuplicateTag if the tag
+	// is already in use.
+	Save(ctx context.Context, c *Carcass) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Carcass, error)
+	// FindAll returns carcasses, most recently received first.
+	FindAll(ctx context.Context, filter Filter) ([]*Carcass, error)
+	// SaveBreakdown stores a breakdown together with the inventory lots its
+	// cuts were received into and their origins, in one transaction. It
+	// returns ErrAlreadyBrokenDown if the carcass was broken down concurrently.
+	SaveBreakdown(ctx context.Context, c *Carcass, b *Breakdown, lots []*inventory.Lot, received []*inventory.Movement, origins []*traceability.Origin) error
+	FindBreakdown(ctx context.Context, carcassID uuid.UUID) (*Breakdown, error)
+}
+
+// YieldTemplateRepository provides access to expected yields per species.
+type YieldTemplateRepository interface {
+	SaveYieldTemplate(ctx context.Context, t *YieldTemplate) error
+	FindYieldTemplate(ctx context.Context, species Species) (*YieldTemplate, error)
+	FindYieldTemplates(ctx context.Context) ([]*YieldTemplate, error)
+}
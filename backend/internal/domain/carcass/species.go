@@ -0,0 +1,25 @@
+package carcass
+
+// Species is the kind of animal a carcass comes from.
+type Species string
+
+const (
+	SpeciesBeef    Species = "beef"
+	SpeciesVeal    Species = "veal"
+	SpeciesLamb    Species = "lamb"
+	SpeciesMutton  Species = "mutton"
+	SpeciesGoat    Species = "goat"
+	SpeciesChicken Species = "chicken"
+)
+
+// NewSpecies validates a raw species.
+func NewSpecies(raw string) (Species, error) {
+	switch s := Species(raw); s {
+	case SpeciesBeef, SpeciesVeal, SpeciesLamb, SpeciesMutton, SpeciesGoat, SpeciesChicken:
+		return s, nil
+	default:
+		return "", ErrInvalidSpecies
+	}
+}
+
+func (s Species) String() string { return string(s) }
@@ -0,0 +1,285 @@
+package carcass
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Percentages are held in basis points: 10000 is 100%.
+const fullBasisPoints = 10000
+
+// DefaultValueFactor is the value factor of a primal that is worth the
+// carcass average per kg.
+const DefaultValueFactor = 100
+
+// PrimalYield is the share of the carcass a primal is expected to yield.
+// ValueFactor weights how much a kg of the primal is worth relative to the
+// carcass average (100), and is used to share the carcass cost between cuts:
+// a fillet at 300 carries three times the cost per kg of a primal at 100.
+type PrimalYield struct {
+	Name        string
+	ExpectedBP  int64
+	ValueFactor int64
+}
+
+// YieldTemplate is what a carcass of a species is expected to break down into.
+type YieldTemplate struct {
+	species         Species
+	expectedYieldBP int64
+	expectedTrimBP  int64
+	expectedWasteBP int64
+	primals         []PrimalYield
+	updatedAt       time.Time
+}
+
+// NewYieldTemplate validates a yield template. A primal value factor of zero
+// is taken as DefaultValueFactor.
+func NewYieldTemplate(species Species, yieldBP, trimBP, wasteBP int64, primals []PrimalYield, now time.Time) (*YieldTemplate, error) {
+	for _, bp := range []int64{yieldBP, trimBP, wasteBP} {
+		if bp < 0 || bp > fullBasisPoints {
+			return nil, ErrInvalidPercentage
+		}
+	}
+	if yieldBP+trimBP+wasteBP > fullBasisPoints {
+		return nil, ErrInvalidYieldTemplate
+	}
+
+	seen := make(map[string]bool, len(primals))
+	normalised := make([]PrimalYield, 0, len(primals))
+	var primalTotal int64
+	for _, p := range primals {
+		p.Name = normalisePrimal(p.Name)
+		if p.Name == "" {
+			return nil, ErrEmptyPrimal
+		}
+		if seen[p.Name] {
+			return nil, ErrDuplicatePrimal
+		}
+		seen[p.Name] = true
+		if p.ExpectedBP < 0 || p.ExpectedBP > fullBasisPoints {
+			return nil, ErrInvalidPercentage
+		}
+		if p.ValueFactor < 0 {
+			return nil, ErrInvalidValueFactor
+		}
+		if p.ValueFactor == 0 {
+			p.ValueFactor = DefaultValueFactor
+		}
+		primalTotal += p.ExpectedBP
+		normalised = append(normalised, p)
+	}
+	if primalTotal > yieldBP {
+		return nil, ErrInvalidPrimalYields
+	}
+
+	return &YieldTemplate{
+		species:         species,
+		expectedYieldBP: yieldBP,
+		expectedTrimBP:  trimBP,
+		expectedWasteBP: wasteBP,
+		primals:         normalised,
+		updatedAt:       now,
+	}, nil
+}
+
+// ReconstructYieldTemplate reconstructs a YieldTemplate from persistence without validation.
+func ReconstructYieldTemplate(species Species, yieldBP, trimBP, wasteBP int64, primals []PrimalYield, updatedAt time.Time) *YieldTemplate {
+	return &YieldTemplate{
+		species:         species,
+		expectedYieldBP: yieldBP,
+		expectedTrimBP:  trimBP,
+		expectedWasteBP: wasteBP,
+		primals:         primals,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (t *YieldTemplate) Species() Species       { return t.species }
+func (t *YieldTemplate) ExpectedYieldBP() int64 { return t.expectedYieldBP }
+func (t *YieldTemplate) ExpectedTrimBP() int64  { return t.expectedTrimBP }
+func (t *YieldTemplate) ExpectedWasteBP() int64 { return t.expectedWasteBP }
+func (t *YieldTemplate) Primals() []PrimalYield { return t.primals }
+func (t *YieldTemplate) UpdatedAt() time.Time   { return t.updatedAt }
+
+func (t *YieldTemplate) primal(name string) (PrimalYield, bool) {
+	if t == nil {
+		return PrimalYield{}, false
+	}
+	for _, p := range t.primals {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return PrimalYield{}, false
+}
+
+// CutYield is one cut's share of the carcass weight and cost.
+type CutYield struct {
+	LotID          uuid.UUID
+	ProductID      uuid.UUID
+	Primal         string
+	Grams          int64
+	YieldBP        int64
+	CostCents      int64
+	CostPerKgCents int64
+}
+
+// PrimalReport compares a primal's actual yield with the template. ExpectedBP
+// is nil when the template does not list the primal.
+type PrimalReport struct {
+	Name       string
+	Grams      int64
+	YieldBP    int64
+	ExpectedBP *int64
+}
+
+// Expected is the template's expectation for a whole carcass.
+type Expected struct {
+	YieldBP int64
+	TrimBP  int64
+	WasteBP int64
+}
+
+// YieldReport describes how well a carcass broke down and what each cut cost.
+type YieldReport struct {
+	CarcassID     uuid.UUID
+	Species       Species
+	HangingGrams  int64
+	SaleableGrams int64
+	TrimGrams     int64
+	WasteGrams    int64
+	ShrinkGrams   int64
+	YieldBP       int64
+	TrimBP        int64
+	WasteBP       int64
+	ShrinkBP      int64
+	// Expected is nil when there is no yield template for the species.
+	Expected               *Expected
+	CostCents              int64
+	CostPerSaleableKgCents int64
+	Cuts                   []CutYield
+	Primals                []PrimalReport
+}
+
+// NewYieldReport builds the yield report for a broken-down carcass. The
+// carcass cost is shared between the cuts in proportion to their weight times
+// their primal's value factor, so the cut costs always add up to the carcass
+// cost. tmpl may be nil.
+func NewYieldReport(c *Carcass, b *Breakdown, tmpl *YieldTemplate) YieldReport {
+	hanging := c.HangingWeightGrams()
+	saleable := b.SaleableGrams()
+	shrink := hanging - saleable - b.TrimGrams() - b.WasteGrams()
+
+	report := YieldReport{
+		CarcassID:              c.ID(),
+		Species:                c.Species(),
+		HangingGrams:           hanging,
+		SaleableGrams:          saleable,
+		TrimGrams:              b.TrimGrams(),
+		WasteGrams:             b.WasteGrams(),
+		ShrinkGrams:            shrink,
+		YieldBP:                basisPoints(saleable, hanging),
+		TrimBP:                 basisPoints(b.TrimGrams(), hanging),
+		WasteBP:                basisPoints(b.WasteGrams(), hanging),
+		ShrinkBP:               basisPoints(shrink, hanging),
+		CostCents:              c.CostCents(),
+		CostPerSaleableKgCents: perKg(c.CostCents(), saleable),
+	}
+	if tmpl != nil {
+		report.Expected = &Expected{
+			YieldBP: tmpl.ExpectedYieldBP(),
+			TrimBP:  tmpl.ExpectedTrimBP(),
+			WasteBP: tmpl.ExpectedWasteBP(),
+		}
+	}
+
+	report.Cuts = allocateCost(b.Cuts(), c.CostCents(), hanging, tmpl)
+	report.Primals = primalReports(b.Cuts(), hanging, tmpl)
+	return report
+}
+
+func allocateCost(cuts []Cut, costCents, hanging int64, tmpl *YieldTemplate) []CutYield {
+	weights := make([]int64, len(cuts))
+	var totalWeight int64
+	for i, cut := range cuts {
+		factor := int64(DefaultValueFactor)
+		if p, ok := tmpl.primal(cut.Primal); ok {
+			factor = p.ValueFactor
+		}
+		weights[i] = cut.Grams * factor
+		totalWeight += weights[i]
+	}
+
+	yields := make([]CutYield, len(cuts))
+	var allocated int64
+	for i, cut := range cuts {
+		var cost int64
+		switch {
+		case totalWeight == 0:
+		case i == len(cuts)-1:
+			// The last cut takes the rounding remainder.
+			cost = costCents - allocated
+		default:
+			cost = costCents * weights[i] / totalWeight
+		}
+		allocated += cost
+
+		yields[i] = CutYield{
+			LotID:          cut.LotID,
+			ProductID:      cut.ProductID,
+			Primal:         cut.Primal,
+			Grams:          cut.Grams,
+			YieldBP:        basisPoints(cut.Grams, hanging),
+			CostCents:      cost,
+			CostPerKgCents: perKg(cost, cut.Grams),
+		}
+	}
+	return yields
+}
+
+// primalReports lists the template's primals in template order, followed by
+// any other primals in the order they were cut.
+func primalReports(cuts []Cut, hanging int64, tmpl *YieldTemplate) []PrimalReport {
+	grams := make(map[string]int64)
+	var names []string
+	if tmpl != nil {
+		for _, p := range tmpl.Primals() {
+			names = append(names, p.Name)
+			grams[p.Name] = 0
+		}
+	}
+	for _, cut := range cuts {
+		if _, ok := grams[cut.Primal]; !ok {
+			names = append(names, cut.Primal)
+		}
+		grams[cut.Primal] += cut.Grams
+	}
+
+	reports := make([]PrimalReport, 0, len(names))
+	for _, name := range names {
+		r := PrimalReport{Name: name, Grams: grams[name], YieldBP: basisPoints(grams[name], hanging)}
+		if p, ok := tmpl.primal(name); ok {
+			expected := p.ExpectedBP
+			r.ExpectedBP = &expected
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// basisPoints returns part/whole in basis points, rounded to the nearest.
+func basisPoints(part, whole int64) int64 {
+	if whole == 0 {
+		return 0
+	}
+	return (part*fullBasisPoints + whole/2) / whole
+}
+
+// perKg returns cents per kg for an amount spread over grams, rounded to the nearest cent.
+func perKg(cents, grams int64) int64 {
+	if grams == 0 {
+		return 0
+	}
+	return (cents*1000 + grams/2) / grams
+}
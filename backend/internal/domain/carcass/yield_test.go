@@ -0,0 +1,118 @@
+package carcass_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lambTemplate(t *testing.T) *carcass.YieldTemplate {
+	t.Helper()
+	tmpl, err := carcass.NewYieldTemplate(carcass.SpeciesLamb, 6000, 2000, 1000, []carcass.PrimalYield{
+		{Name: "Leg", ExpectedBP: 3000, ValueFactor: 150},
+		{Name: "shoulder", ExpectedBP: 2500},
+		{Name: "loin", ExpectedBP: 500, ValueFactor: 200},
+	}, time.Now())
+	require.NoError(t, err)
+	return tmpl
+}
+
+func TestNewYieldTemplate_NormalisesPrimals(t *testing.T) {
+	tmpl := lambTemplate(t)
+
+	require.Len(t, tmpl.Primals(), 3)
+	assert.Equal(t, "leg", tmpl.Primals()[0].Name)
+	assert.Equal(t, int64(carcass.DefaultValueFactor), tmpl.Primals()[1].ValueFactor)
+}
+
+func TestNewYieldTemplate_InvalidInputs_ReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		yield   int64
+		trim    int64
+		waste   int64
+		primals []carcass.PrimalYield
+		want    error
+	}{
+		{"percentage over 100", 10001, 0, 0, nil, carcass.ErrInvalidPercentage},
+		{"negative percentage", 6000, -1, 0, nil, carcass.ErrInvalidPercentage},
+		{"adds up to over 100", 6000, 3000, 1500, nil, carcass.ErrInvalidYieldTemplate},
+		{"primals exceed yield", 6000, 0, 0, []carcass.PrimalYield{{Name: "leg", ExpectedBP: 4000}, {Name: "loin", ExpectedBP: 2500}}, carcass.ErrInvalidPrimalYields},
+		{"duplicate primal", 6000, 0, 0, []carcass.PrimalYield{{Name: "leg"}, {Name: "LEG"}}, carcass.ErrDuplicatePrimal},
+		{"empty primal", 6000, 0, 0, []carcass.PrimalYield{{Name: ""}}, carcass.ErrEmptyPrimal},
+		{"negative value factor", 6000, 0, 0, []carcass.PrimalYield{{Name: "leg", ValueFactor: -1}}, carcass.ErrInvalidValueFactor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := carcass.NewYieldTemplate(carcass.SpeciesLamb, tt.yield, tt.trim, tt.waste, tt.primals, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestNewYieldReport_WithTemplate(t *testing.T) {
+	c := newCarcass(t, 20000, 16000)
+	b, err := c.BreakDown([]carcass.Cut{
+		{Primal: "leg", ProductID: uuid.New(), Grams: 6000},
+		{Primal: "shoulder", ProductID: uuid.New(), Grams: 4000},
+		{Primal: "neck", ProductID: uuid.New(), Grams: 1000},
+	}, 4000, 2000, nil, time.Now())
+	require.NoError(t, err)
+
+	report := carcass.NewYieldReport(c, b, lambTemplate(t))
+
+	assert.Equal(t, int64(11000), report.SaleableGrams)
+	assert.Equal(t, int64(3000), report.ShrinkGrams)
+	assert.Equal(t, int64(5500), report.YieldBP)
+	assert.Equal(t, int64(2000), report.TrimBP)
+	assert.Equal(t, int64(1000), report.WasteBP)
+	assert.Equal(t, int64(1500), report.ShrinkBP)
+	require.NotNil(t, report.Expected)
+	assert.Equal(t, int64(6000), report.Expected.YieldBP)
+	assert.Equal(t, int64(1455), report.CostPerSaleableKgCents)
+
+	// Cost is shared by grams x value factor: leg 6000x150, shoulder 4000x100, neck 1000x100.
+	require.Len(t, report.Cuts, 3)
+	assert.Equal(t, int64(10285), report.Cuts[0].CostCents)
+	assert.Equal(t, int64(4571), report.Cuts[1].CostCents)
+	assert.Equal(t, int64(1144), report.Cuts[2].CostCents)
+	assert.Equal(t, int64(1714), report.Cuts[0].CostPerKgCents)
+	assert.Equal(t, int64(1143), report.Cuts[1].CostPerKgCents)
+
+	var total int64
+	for _, cut := range report.Cuts {
+		total += cut.CostCents
+	}
+	assert.Equal(t, c.CostCents(), total)
+
+	// Template primals first, then primals the template does not know.
+	names := make([]string, 0, len(report.Primals))
+	for _, p := range report.Primals {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"leg", "shoulder", "loin", "neck"}, names)
+	assert.Equal(t, int64(3000), report.Primals[0].YieldBP)
+	require.NotNil(t, report.Primals[2].ExpectedBP)
+	assert.Equal(t, int64(0), report.Primals[2].Grams)
+	assert.Nil(t, report.Primals[3].ExpectedBP)
+}
+
+func TestNewYieldReport_WithoutTemplate_SharesCostByWeight(t *testing.T) {
+	c := newCarcass(t, 10000, 9000)
+	b, err := c.BreakDown([]carcass.Cut{
+		{Primal: "leg", ProductID: uuid.New(), Grams: 3000},
+		{Primal: "shoulder", ProductID: uuid.New(), Grams: 3000},
+	}, 0, 0, nil, time.Now())
+	require.NoError(t, err)
+
+	report := carcass.NewYieldReport(c, b, nil)
+
+	assert.Nil(t, report.Expected)
+	assert.Equal(t, int64(1500), report.CostPerSaleableKgCents)
+	assert.Equal(t, report.Cuts[0].CostPerKgCents, report.Cuts[1].CostPerKgCents)
+	assert.Equal(t, int64(4500), report.Cuts[0].CostCents)
+}
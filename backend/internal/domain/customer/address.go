@@ -0,0 +1,138 @@
+package customer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Coordinates is a WGS84 position, typically captured from a map pin when the
+// customer saves an address.
+type Coordinates struct {
+	latitude  float64
+	longitude float64
+}
+
+// NewCoordinates validates a latitude/longitude pair.
+func NewCoordinates(latitude, longitude float64) (Coordinates, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return Coordinates{}, ErrInvalidCoordinates
+	}
+	return Coordinates{latitude: latitude, longitude: longitude}, nil
+}
+
+func (c Coordinates) Latitude() float64  { return c.latitude }
+func (c Coordinates) Longitude() float64 { return c.longitude }
+
+// Postcode is a value object holding a postcode in upper case with single spaces.
+type Postcode struct {
+	value string
+}
+
+// NewPostcode normalises a raw postcode.
+func NewPostcode(raw string) (Postcode, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(raw), " "))
+	if normalized == "" {
+		return Postcode{}, ErrInvalidPostcode
+	}
+	return Postcode{value: normalized}, nil
+}
+
+func (p Postcode) String() string { return p.value }
+
+// Address is an entry in a customer's address book.
+type Address struct {
+	id          uuid.UUID
+	customerID  uuid.UUID
+	label       string
+	line1       string
+	line2       string
+	city        string
+	postcode    Postcode
+	coordinates *Coordinates
+	isDefault   bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewAddress creates a new Address with invariant validation. Coordinates are
+// optional but required for delivery to zones drawn as polygons.
+func NewAddress(id, customerID uuid.UUID, label, line1, line2, city string, postcode Postcode, coordinates *Coordinates) (*Address, error) {
+	a := &Address{id: id, customerID: customerID}
+	if err := a.Update(label, line1, line2, city, postcode, coordinates); err != nil {
+		return nil, err
+	}
+	a.createdAt = a.updatedAt
+	return a, nil
+}
+
+// ReconstructAddress reconstructs an Address from persistence without validation.
+func ReconstructAddress(
+	id, customerID uuid.UUID,
+	label, line1, line2, city string,
+	postcode Postcode,
+	coordinates *Coordinates,
+	isDefault bool,
+	createdAt, updatedAt time.Time,
+) *Address {
+	return &Address{
+		id:          id,
+		customerID:  customerID,
+		label:       label,
+		line1:       line1,
+		line2:       line2,
+		city:        city,
+		postcode:    postcode,
+		coordinates: coordinates,
+		isDefault:   isDefault,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// Update replaces the address details.
+func (a *Address) Update(label, line1, line2, city string, postcode Postcode, coordinates *Coordinates) error {
+	line1 = strings.TrimSpace(line1)
+	if line1 == "" {
+		return ErrEmptyAddressLine
+	}
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return ErrEmptyCity
+	}
+	if postcode.value == "" {
+		return ErrInvalidPostcode
+	}
+
+	a.label = strings.TrimSpace(label)
+	a.line1 = line1
+	a.line2 = strings.TrimSpace(line2)
+	a.city = city
+	a.postcode = postcode
+	a.coordinates = coordinates
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// MarkDefault makes this the customer's default address.
+func (a *Address) MarkDefault() {
+	a.isDefault = true
+}
+
+// BelongsTo reports whether the address is in the given customer's address book.
+func (a *Address) BelongsTo(customerID uuid.UUID) bool {
+	return a.customerID == customerID
+}
+
+func (a *Address) ID() uuid.UUID             { return a.id }
+func (a *Address) CustomerID() uuid.UUID     { return a.customerID }
+func (a *Address) Label() string             { return a.label }
+func (a *Address) Line1() string             { return a.line1 }
+func (a *Address) Line2() string             { return a.line2 }
+func (a *Address) City() string              { return a.city }
+func (a *Address) Postcode() Postcode        { return a.postcode }
+func (a *Address) Coordinates() *Coordinates { return a.coordinates }
+func (a *Address) IsDefault() bool           { return a.isDefault }
+func (a *Address) CreatedAt() time.Time      { return a.createdAt }
+func (a *Address) UpdatedAt() time.Time      { return a.updatedAt }
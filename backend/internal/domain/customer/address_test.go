@@ -0,0 +1,97 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPostcode_NormalisesCaseAndSpacing(t *testing.T) {
+	p, err := customer.NewPostcode("  sw1a   1aa ")
+
+	require.NoError(t, err)
+	assert.Equal(t, "SW1A 1AA", p.String())
+}
+
+func TestNewPostcode_Empty_ReturnsError(t *testing.T) {
+	_, err := customer.NewPostcode("   ")
+
+	assert.ErrorIs(t, err, customer.ErrInvalidPostcode)
+}
+
+func TestNewCoordinates_OutOfRange_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lng float64
+	}{
+		{"latitude too high", 91, 0},
+		{"latitude too low", -91, 0},
+		{"longitude too high", 0, 181},
+		{"longitude too low", 0, -181},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := customer.NewCoordinates(tt.lat, tt.lng)
+			assert.ErrorIs(t, err, customer.ErrInvalidCoordinates)
+		})
+	}
+}
+
+func TestNewAddress_ValidInputs_CreatesAddress(t *testing.T) {
+	id, customerID := uuid.New(), uuid.New()
+	postcode, _ := customer.NewPostcode("sw1a 1aa")
+	coords, _ := customer.NewCoordinates(51.5014, -0.1419)
+
+	a, err := customer.NewAddress(id, customerID, " Home ", "1 High Street", "", "London", postcode, &coords)
+
+	require.NoError(t, err)
+	assert.Equal(t, id, a.ID())
+	assert.Equal(t, "Home", a.Label())
+	assert.Equal(t, "SW1A 1AA", a.Postcode().String())
+	assert.InDelta(t, -0.1419, a.Coordinates().Longitude(), 1e-9)
+	assert.False(t, a.IsDefault())
+	assert.True(t, a.BelongsTo(customerID))
+	assert.False(t, a.BelongsTo(uuid.New()))
+	assert.False(t, a.CreatedAt().IsZero())
+}
+
+func TestNewAddress_MissingFields_ReturnsError(t *testing.T) {
+	postcode, _ := customer.NewPostcode("E1 6AN")
+
+	tests := []struct {
+		name     string
+		line1    string
+		city     string
+		postcode customer.Postcode
+		wantErr  error
+	}{
+		{"empty line 1", " ", "London", postcode, customer.ErrEmptyAddressLine},
+		{"empty city", "1 High Street", "", postcode, customer.ErrEmptyCity},
+		{"zero postcode", "1 High Street", "London", customer.Postcode{}, customer.ErrInvalidPostcode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := customer.NewAddress(uuid.New(), uuid.New(), "", tt.line1, "", tt.city, tt.postcode, nil)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAddress_Update_ReplacesDetails(t *testing.T) {
+	postcode, _ := customer.NewPostcode("E1 6AN")
+	a, err := customer.NewAddress(uuid.New(), uuid.New(), "Home", "1 High Street", "", "London", postcode, nil)
+	require.NoError(t, err)
+
+	newPostcode, _ := customer.NewPostcode("N1 9GU")
+	require.NoError(t, a.Update("Work", "2 Low Road", "Floor 3", "London", newPostcode, nil))
+
+	assert.Equal(t, "Work", a.Label())
+	assert.Equal(t, "Floor 3", a.Line2())
+	assert.Equal(t, "N1 9GU", a.Postcode().String())
+	assert.Nil(t, a.Coordinates())
+}
@@ -10,4 +10,9 @@ var (
 	ErrCustomerNotFound   = errors.New("customer not found")
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrEmptyAddressLine   = errors.New("address line 1 must not be empty")
+	ErrEmptyCity          = errors.New("city must not be empty")
+	ErrInvalidPostcode    = errors.New("postcode must not be empty")
+	ErrInvalidCoordinates = errors.New("latitude must be between -90 and 90 and longitude between -180 and 180")
+	ErrAddressNotFound    = errors.New("address not found")
 )
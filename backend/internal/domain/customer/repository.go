@@ -13,3 +13,13 @@ type Repository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*Customer, error)
 	ExistsByEmail(ctx context.Context, email Email) (bool, error)
 }
+
+// AddressRepository provides access to customer address books.
+type AddressRepository interface {
+	Save(ctx context.Context, address *Address) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Address, error)
+	FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*Address, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetDefault makes addressID the customer's only default address.
+	SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error
+}
@@ -0,0 +1,17 @@
+package delivery
+
+import "errors"
+
+var (
+	ErrEmptyZoneName         = errors.New("zone name must not be empty")
+	ErrMissingZoneArea       = errors.New("zone must have a polygon or at least one postcode prefix")
+	ErrInvalidGeoJSON        = errors.New("zone area must be a GeoJSON Polygon or MultiPolygon")
+	ErrInvalidPolygon        = errors.New("polygon rings must be closed and have at least four positions")
+	ErrInvalidPostcodePrefix = errors.New("postcode prefixes must contain only letters and digits")
+	ErrNegativeDeliveryFee   = errors.New("delivery fee must not be negative")
+	ErrNegativeMinimumOrder  = errors.New("minimum order must not be negative")
+	ErrEmptyTaxCategory      = errors.New("delivery fee tax category must not be empty")
+	ErrZoneNotFound          = errors.New("delivery zone not found")
+	ErrAddressNotServed      = errors.New("address is outside all active delivery zones")
+	ErrBelowMinimumOrder     = errors.New("order total is below the zone's minimum order")
+)
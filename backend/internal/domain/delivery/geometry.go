@@ -0,0 +1,157 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Point is a WGS84 position. GeoJSON orders coordinates longitude first.
+type Point struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// ring is a closed linear ring whose first and last positions are equal.
+type ring []Point
+
+// contains uses the even-odd ray casting rule. Points lying exactly on an edge
+// may be reported on either side.
+func (r ring) contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// polygon is an exterior ring followed by zero or more holes.
+type polygon []ring
+
+func (pg polygon) contains(p Point) bool {
+	if !pg[0].contains(p) {
+		return false
+	}
+	for _, hole := range pg[1:] {
+		if hole.contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Area is the region covered by a delivery zone, stored as a MultiPolygon.
+type Area struct {
+	polygons []polygon
+}
+
+// ParseArea parses a GeoJSON Polygon or MultiPolygon geometry, optionally
+// wrapped in a Feature.
+func ParseArea(raw []byte) (*Area, error) {
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+		Geometry    json.RawMessage `json:"geometry"`
+	}
+	if err := json.Unmarshal(raw, &geom); err != nil {
+		return nil, ErrInvalidGeoJSON
+	}
+
+	var multi [][][][]float64
+	switch geom.Type {
+	case "Feature":
+		if len(geom.Geometry) == 0 {
+			return nil, ErrInvalidGeoJSON
+		}
+		return ParseArea(geom.Geometry)
+	case "Polygon":
+		var single [][][]float64
+		if err := json.Unmarshal(geom.Coordinates, &single); err != nil {
+			return nil, ErrInvalidGeoJSON
+		}
+		multi = [][][][]float64{single}
+	case "MultiPolygon":
+		if err := json.Unmarshal(geom.Coordinates, &multi); err != nil {
+			return nil, ErrInvalidGeoJSON
+		}
+	default:
+		return nil, ErrInvalidGeoJSON
+	}
+
+	if len(multi) == 0 {
+		return nil, ErrInvalidPolygon
+	}
+	area := &Area{polygons: make([]polygon, 0, len(multi))}
+	for _, rawPolygon := range multi {
+		pg, err := newPolygon(rawPolygon)
+		if err != nil {
+			return nil, err
+		}
+		area.polygons = append(area.polygons, pg)
+	}
+	return area, nil
+}
+
+func newPolygon(rings [][][]float64) (polygon, error) {
+	if len(rings) == 0 {
+		return nil, ErrInvalidPolygon
+	}
+	pg := make(polygon, 0, len(rings))
+	for _, positions := range rings {
+		if len(positions) < 4 {
+			return nil, ErrInvalidPolygon
+		}
+		r := make(ring, 0, len(positions))
+		for _, pos := range positions {
+			if len(pos) < 2 {
+				return nil, ErrInvalidGeoJSON
+			}
+			lng, lat := pos[0], pos[1]
+			if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+				return nil, fmt.Errorf("%w: position [%g, %g] is out of range", ErrInvalidGeoJSON, lng, lat)
+			}
+			r = append(r, Point{Longitude: lng, Latitude: lat})
+		}
+		if r[0] != r[len(r)-1] {
+			return nil, ErrInvalidPolygon
+		}
+		pg = append(pg, r)
+	}
+	return pg, nil
+}
+
+// Contains reports whether p lies inside any polygon of the area.
+func (a *Area) Contains(p Point) bool {
+	for _, pg := range a.polygons {
+		if pg.contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// GeoJSON encodes the area as a GeoJSON MultiPolygon geometry.
+func (a *Area) GeoJSON() []byte {
+	coords := make([][][][]float64, 0, len(a.polygons))
+	for _, pg := range a.polygons {
+		rings := make([][][]float64, 0, len(pg))
+		for _, r := range pg {
+			positions := make([][]float64, 0, len(r))
+			for _, p := range r {
+				positions = append(positions, []float64{p.Longitude, p.Latitude})
+			}
+			rings = append(rings, positions)
+		}
+		coords = append(coords, rings)
+	}
+
+	// Marshalling plain float slices cannot fail.
+	out, _ := json.Marshal(struct {
+		Type        string          `json:"type"`
+		Coordinates [][][][]float64 `json:"coordinates"`
+	}{Type: "MultiPolygon", Coordinates: coords})
+	return out
+}
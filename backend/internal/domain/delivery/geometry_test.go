@@ -0,0 +1,105 @@
+package delivery_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// squareWithHole is a 10x10 square at the origin with a 2x2 hole in the middle.
+const squareWithHole = `{
+	"type": "Polygon",
+	"coordinates": [
+		[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+		[[4, 4], [6, 4], [6, 6], [4, 6], [4, 4]]
+	]
+}`
+
+func TestParseArea_Polygon_ContainsPoints(t *testing.T) {
+	area, err := delivery.ParseArea([]byte(squareWithHole))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		point delivery.Point
+		want  bool
+	}{
+		{"inside", delivery.Point{Longitude: 2, Latitude: 2}, true},
+		{"inside near edge", delivery.Point{Longitude: 9.99, Latitude: 0.01}, true},
+		{"in hole", delivery.Point{Longitude: 5, Latitude: 5}, false},
+		{"outside east", delivery.Point{Longitude: 11, Latitude: 5}, false},
+		{"outside south", delivery.Point{Longitude: 5, Latitude: -1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, area.Contains(tt.point))
+		})
+	}
+}
+
+func TestParseArea_ConcavePolygon(t *testing.T) {
+	// A "U" shape: the notch between the arms is outside.
+	area, err := delivery.ParseArea([]byte(`{"type":"Polygon","coordinates":[
+		[[0,0],[3,0],[3,3],[2,3],[2,1],[1,1],[1,3],[0,3],[0,0]]
+	]}`))
+	require.NoError(t, err)
+
+	assert.True(t, area.Contains(delivery.Point{Longitude: 0.5, Latitude: 2.5}))
+	assert.True(t, area.Contains(delivery.Point{Longitude: 2.5, Latitude: 2.5}))
+	assert.False(t, area.Contains(delivery.Point{Longitude: 1.5, Latitude: 2.5}))
+}
+
+func TestParseArea_MultiPolygonInFeature(t *testing.T) {
+	area, err := delivery.ParseArea([]byte(`{"type":"Feature","properties":{},"geometry":{
+		"type":"MultiPolygon","coordinates":[
+			[[[0,0],[1,0],[1,1],[0,1],[0,0]]],
+			[[[5,5],[6,5],[6,6],[5,6],[5,5]]]
+		]}}`))
+	require.NoError(t, err)
+
+	assert.True(t, area.Contains(delivery.Point{Longitude: 0.5, Latitude: 0.5}))
+	assert.True(t, area.Contains(delivery.Point{Longitude: 5.5, Latitude: 5.5}))
+	assert.False(t, area.Contains(delivery.Point{Longitude: 3, Latitude: 3}))
+}
+
+func TestParseArea_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr error
+	}{
+		{"not json", `polygon`, delivery.ErrInvalidGeoJSON},
+		{"point geometry", `{"type":"Point","coordinates":[0,0]}`, delivery.ErrInvalidGeoJSON},
+		{"feature without geometry", `{"type":"Feature"}`, delivery.ErrInvalidGeoJSON},
+		{"too few positions", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`, delivery.ErrInvalidPolygon},
+		{"unclosed ring", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`, delivery.ErrInvalidPolygon},
+		{"no rings", `{"type":"Polygon","coordinates":[]}`, delivery.ErrInvalidPolygon},
+		{"latitude out of range", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,95],[0,0]]]}`, delivery.ErrInvalidGeoJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := delivery.ParseArea([]byte(tt.raw))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestArea_GeoJSON_RoundTrips(t *testing.T) {
+	area, err := delivery.ParseArea([]byte(squareWithHole))
+	require.NoError(t, err)
+
+	encoded := area.GeoJSON()
+	var geom map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &geom))
+	assert.Equal(t, "MultiPolygon", geom["type"])
+
+	again, err := delivery.ParseArea(encoded)
+	require.NoError(t, err)
+	assert.False(t, again.Contains(delivery.Point{Longitude: 5, Latitude: 5}))
+	assert.True(t, again.Contains(delivery.Point{Longitude: 1, Latitude: 1}))
+}
@@ -0,0 +1,15 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ZoneRepository provides access to delivery zone persistence.
+type ZoneRepository interface {
+	Save(ctx context.Context, zone *Zone) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Zone, error)
+	// FindAll returns zones ordered by name.
+	FindAll(ctx context.Context, activeOnly bool) ([]*Zone, error)
+}
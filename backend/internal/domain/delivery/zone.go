@@ -0,0 +1,189 @@
+package delivery
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// DefaultFeeTaxCategory is the tax category delivery fees are charged
+// under unless a zone says otherwise.
+const DefaultFeeTaxCategory = "delivery"
+
+// polygonMatchScore ranks a polygon match above any postcode prefix match.
+const polygonMatchScore = 1 << 16
+
+// Zone is an admin-managed delivery area with its own pricing rules. A zone
+// covers an address when the address lies inside its polygon or its postcode
+// starts with one of its prefixes.
+type Zone struct {
+	id                uuid.UUID
+	name              string
+	area              *Area
+	postcodePrefixes  []string
+	deliveryFeeCents  int64
+	minimumOrderCents int64
+	feeTaxCategory    string
+	active            bool
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+// NewZone creates a new active Zone with invariant validation.
+func NewZone(id uuid.UUID, name string, area *Area, postcodePrefixes []string, deliveryFeeCents, minimumOrderCents int64) (*Zone, error) {
+	z := &Zone{id: id, feeTaxCategory: DefaultFeeTaxCategory, active: true}
+	if err := z.Update(name, area, postcodePrefixes, deliveryFeeCents, minimumOrderCents); err != nil {
+		return nil, err
+	}
+	z.createdAt = z.updatedAt
+	return z, nil
+}
+
+// ReconstructZone reconstructs a Zone from persistence without validation.
+func ReconstructZone(
+	id uuid.UUID,
+	name string,
+	area *Area,
+	postcodePrefixes []string,
+	deliveryFeeCents, minimumOrderCents int64,
+	feeTaxCategory string,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Zone {
+	return &Zone{
+		id:                id,
+		name:              name,
+		area:              area,
+		postcodePrefixes:  postcodePrefixes,
+		deliveryFeeCents:  deliveryFeeCents,
+		minimumOrderCents: minimumOrderCents,
+		feeTaxCategory:    feeTaxCategory,
+		active:            active,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+	}
+}
+
+// Update replaces the zone's definition and pricing.
+func (z *Zone) Update(name string, area *Area, postcodePrefixes []string, deliveryFeeCents, minimumOrderCents int64) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyZoneName
+	}
+	if deliveryFeeCents < 0 {
+		return ErrNegativeDeliveryFee
+	}
+	if minimumOrderCents < 0 {
+		return ErrNegativeMinimumOrder
+	}
+
+	prefixes := make([]string, 0, len(postcodePrefixes))
+	seen := make(map[string]bool, len(postcodePrefixes))
+	for _, raw := range postcodePrefixes {
+		prefix := NormalisePostcode(raw)
+		if prefix == "" || strings.IndexFunc(prefix, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) >= 0 {
+			return ErrInvalidPostcodePrefix
+		}
+		if !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if area == nil && len(prefixes) == 0 {
+		return ErrMissingZoneArea
+	}
+
+	z.name = name
+	z.area = area
+	z.postcodePrefixes = prefixes
+	z.deliveryFeeCents = deliveryFeeCents
+	z.minimumOrderCents = minimumOrderCents
+	z.updatedAt = time.Now()
+	return nil
+}
+
+// SetFeeTaxCategory sets the tax category the zone's delivery fee is
+// charged under.
+func (z *Zone) SetFeeTaxCategory(category string) error {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return ErrEmptyTaxCategory
+	}
+	z.feeTaxCategory = category
+	z.updatedAt = time.Now()
+	return nil
+}
+
+// SetActive enables or disables delivery to the zone.
+func (z *Zone) SetActive(active bool) {
+	z.active = active
+	z.updatedAt = time.Now()
+}
+
+// CheckMinimumOrder returns ErrBelowMinimumOrder if subtotalCents does not
+// reach the zone's minimum order value.
+func (z *Zone) CheckMinimumOrder(subtotalCents int64) error {
+	if subtotalCents < z.minimumOrderCents {
+		return ErrBelowMinimumOrder
+	}
+	return nil
+}
+
+// matchScore returns how specifically the zone covers a location, or zero if it
+// does not. point may be nil when the address has no coordinates.
+func (z *Zone) matchScore(postcode string, point *Point) int {
+	if point != nil && z.area != nil && z.area.Contains(*point) {
+		return polygonMatchScore
+	}
+	best := 0
+	for _, prefix := range z.postcodePrefixes {
+		if strings.HasPrefix(postcode, prefix) && len(prefix) > best {
+			best = len(prefix)
+		}
+	}
+	return best
+}
+
+func (z *Zone) ID() uuid.UUID              { return z.id }
+func (z *Zone) Name() string               { return z.name }
+func (z *Zone) Area() *Area                { return z.area }
+func (z *Zone) PostcodePrefixes() []string { return z.postcodePrefixes }
+func (z *Zone) DeliveryFeeCents() int64    { return z.deliveryFeeCents }
+func (z *Zone) MinimumOrderCents() int64   { return z.minimumOrderCents }
+func (z *Zone) FeeTaxCategory() string     { return z.feeTaxCategory }
+func (z *Zone) IsActive() bool             { return z.active }
+func (z *Zone) CreatedAt() time.Time       { return z.createdAt }
+func (z *Zone) UpdatedAt() time.Time       { return z.updatedAt }
+
+// NormalisePostcode upper-cases a postcode and strips all whitespace so that
+// "sw1a 1aa" and "SW1A1AA" compare equal.
+func NormalisePostcode(raw string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+}
+
+// FindZone returns the active zone that serves an address. A polygon match is
+// preferred over a postcode match, and a longer postcode prefix over a shorter
+// one, so a small zone can carve out different pricing inside a broad one.
+// Ties go to the zone listed first.
+func FindZone(zones []*Zone, postcode string, point *Point) (*Zone, error) {
+	normalized := NormalisePostcode(postcode)
+
+	var best *Zone
+	bestScore := 0
+	for _, z := range zones {
+		if !z.active {
+			continue
+		}
+		if score := z.matchScore(normalized, point); score > bestScore {
+			best, bestScore = z, score
+		}
+	}
+	if best == nil {
+		return nil, ErrAddressNotServed
+	}
+	return best, nil
+}
@@ -0,0 +1,111 @@
+package delivery_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustArea(t *testing.T, raw string) *delivery.Area {
+	t.Helper()
+	area, err := delivery.ParseArea([]byte(raw))
+	require.NoError(t, err)
+	return area
+}
+
+func mustZone(t *testing.T, name string, area *delivery.Area, prefixes []string, fee int64) *delivery.Zone {
+	t.Helper()
+	z, err := delivery.NewZone(uuid.New(), name, area, prefixes, fee, 2000)
+	require.NoError(t, err)
+	return z
+}
+
+func TestNewZone_NormalisesPrefixes(t *testing.T) {
+	z, err := delivery.NewZone(uuid.New(), " Central ", nil, []string{"sw1a ", "SW1A", "e 1"}, 499, 2500)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Central", z.Name())
+	assert.Equal(t, []string{"SW1A", "E1"}, z.PostcodePrefixes())
+	assert.Equal(t, int64(499), z.DeliveryFeeCents())
+	assert.Equal(t, int64(2500), z.MinimumOrderCents())
+	assert.True(t, z.IsActive())
+}
+
+func TestNewZone_InvalidInputs_ReturnError(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneName string
+		prefixes []string
+		fee      int64
+		minimum  int64
+		wantErr  error
+	}{
+		{"empty name", " ", []string{"E1"}, 0, 0, delivery.ErrEmptyZoneName},
+		{"no area or prefixes", "Central", nil, 0, 0, delivery.ErrMissingZoneArea},
+		{"blank prefix", "Central", []string{" "}, 0, 0, delivery.ErrInvalidPostcodePrefix},
+		{"punctuation in prefix", "Central", []string{"E1-"}, 0, 0, delivery.ErrInvalidPostcodePrefix},
+		{"negative fee", "Central", []string{"E1"}, -1, 0, delivery.ErrNegativeDeliveryFee},
+		{"negative minimum", "Central", []string{"E1"}, 0, -1, delivery.ErrNegativeMinimumOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := delivery.NewZone(uuid.New(), tt.zoneName, nil, tt.prefixes, tt.fee, tt.minimum)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestZone_CheckMinimumOrder(t *testing.T) {
+	z := mustZone(t, "Central", nil, []string{"E1"}, 0)
+
+	assert.ErrorIs(t, z.CheckMinimumOrder(1999), delivery.ErrBelowMinimumOrder)
+	assert.NoError(t, z.CheckMinimumOrder(2000))
+}
+
+func TestFindZone(t *testing.T) {
+	city := mustZone(t, "City", nil, []string{"E"}, 699)
+	eastEnd := mustZone(t, "East End", nil, []string{"E1"}, 399)
+	shop := mustZone(t, "Around the shop", mustArea(t, `{"type":"Polygon","coordinates":[
+		[[-0.08,51.51],[-0.06,51.51],[-0.06,51.53],[-0.08,51.53],[-0.08,51.51]]
+	]}`), nil, 0)
+	inactive := mustZone(t, "Closed", nil, []string{"N1"}, 0)
+	inactive.SetActive(false)
+
+	zones := []*delivery.Zone{city, eastEnd, shop, inactive}
+	nearShop := &delivery.Point{Longitude: -0.07, Latitude: 51.52}
+	farAway := &delivery.Point{Longitude: 1, Latitude: 50}
+
+	tests := []struct {
+		name     string
+		postcode string
+		point    *delivery.Point
+		want     *delivery.Zone
+	}{
+		{"broad prefix", "e20 1aa", nil, city},
+		{"longest prefix wins", "E1 6AN", nil, eastEnd},
+		{"polygon beats prefix", "E1 6AN", nearShop, shop},
+		{"falls back to prefix outside polygon", "e16an", farAway, eastEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, err := delivery.FindZone(zones, tt.postcode, tt.point)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.ID(), z.ID())
+		})
+	}
+
+	t.Run("inactive zones are ignored", func(t *testing.T) {
+		_, err := delivery.FindZone(zones, "N1 9GU", nil)
+		assert.ErrorIs(t, err, delivery.ErrAddressNotServed)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := delivery.FindZone(zones, "SW1A 1AA", farAway)
+		assert.ErrorIs(t, err, delivery.ErrAddressNotServed)
+	})
+}
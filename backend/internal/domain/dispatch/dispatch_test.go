@@ -0,0 +1,220 @@
+package dispatch_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 11, 9, 9, 0, 0, 0, time.UTC)
+
+func newDriver(t *testing.T, branchID uuid.UUID) *dispatch.Driver {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "driver@butchery.com", "$2a$10$hash", "Bilal Khan", admin.RoleDriver)
+	require.NoError(t, err)
+	d, err := dispatch.NewDriver(a, branchID, "07700 900123", "Van LD26 BUT", now)
+	require.NoError(t, err)
+	return d
+}
+
+// newStop creates a stop at the given position, or without coordinates
+// when lat and lon are both zero.
+func newStop(t *testing.T, lat, lon float64) *dispatch.Stop {
+	t.Helper()
+	postcode, err := customer.NewPostcode("e1 6an")
+	require.NoError(t, err)
+	var coords *customer.Coordinates
+	if lat != 0 || lon != 0 {
+		c, err := customer.NewCoordinates(lat, lon)
+		require.NoError(t, err)
+		coords = &c
+	}
+	addr, err := customer.NewAddress(uuid.New(), uuid.New(), "Home", "1 Brick Lane", "", "London", postcode, coords)
+	require.NoError(t, err)
+	return dispatch.NewStop(uuid.New(), uuid.New(), addr)
+}
+
+func newRun(t *testing.T, branchID uuid.UUID, start *delivery.Point, stops ...*dispatch.Stop) *dispatch.Run {
+	t.Helper()
+	r, err := dispatch.NewRun(uuid.New(), branchID, uuid.New(), now.Add(2*time.Hour), now.Add(4*time.Hour), start, uuid.New(), now)
+	require.NoError(t, err)
+	for _, s := range stops {
+		require.NoError(t, r.AddStop(s, now))
+	}
+	return r
+}
+
+func photo(t *testing.T, stopID uuid.UUID) *dispatch.Proof {
+	t.Helper()
+	p, err := dispatch.NewProof(stopID, dispatch.ProofPhoto, "image/jpeg", []byte{0xff, 0xd8, 0xff}, now)
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewDriver_RequiresDriverAccountAndVehicle(t *testing.T) {
+	staffAccount, err := admin.NewAdmin(uuid.New(), "staff@butchery.com", "$2a$10$hash", "Yusuf Ali", admin.RoleStaff)
+	require.NoError(t, err)
+	_, err = dispatch.NewDriver(staffAccount, uuid.New(), "", "Van", now)
+	assert.ErrorIs(t, err, dispatch.ErrNotADriver)
+
+	d := newDriver(t, uuid.New())
+	assert.ErrorIs(t, d.Update(d.BranchID(), "", "  ", true, now), dispatch.ErrEmptyVehicle)
+	assert.ErrorIs(t, d.Update(uuid.Nil, "", "Van", true, now), dispatch.ErrBranchRequired)
+}
+
+func TestPlanRoute_FromStart_VisitsNearestFirstWithoutCrossing(t *testing.T) {
+	shop := delivery.Point{Longitude: 0, Latitude: 0}
+	// Four points along a line east of the shop, given out of order.
+	points := []delivery.Point{
+		{Longitude: 0.03, Latitude: 0},
+		{Longitude: 0.01, Latitude: 0},
+		{Longitude: 0.04, Latitude: 0},
+		{Longitude: 0.02, Latitude: 0},
+	}
+
+	order, metres := dispatch.PlanRoute(&shop, points)
+
+	assert.Equal(t, []int{1, 3, 0, 2}, order)
+	assert.InDelta(t, dispatch.Distance(shop, points[2]), metres, 1)
+}
+
+func TestPlanRoute_TwoOptRemovesCrossingLeftByNearestNeighbour(t *testing.T) {
+	shop := delivery.Point{Longitude: 0, Latitude: 0}
+	// Nearest neighbour goes to b, then the closer c, then back across to
+	// d; visiting b, d, c is shorter.
+	points := []delivery.Point{
+		{Longitude: 0.010, Latitude: 0},     // b
+		{Longitude: 0.011, Latitude: 0.012}, // c
+		{Longitude: 0.020, Latitude: 0},     // d
+		{Longitude: 0.021, Latitude: 0.013}, // e
+	}
+	nearest := dispatch.Distance(shop, points[0]) + dispatch.Distance(points[0], points[1]) +
+		dispatch.Distance(points[1], points[3]) + dispatch.Distance(points[3], points[2])
+
+	order, metres := dispatch.PlanRoute(&shop, points)
+
+	assert.Len(t, order, 4)
+	assert.Less(t, metres, nearest)
+	total := dispatch.Distance(shop, points[order[0]])
+	for i := 1; i < len(order); i++ {
+		total += dispatch.Distance(points[order[i-1]], points[order[i]])
+	}
+	assert.InDelta(t, total, metres, 1e-6)
+}
+
+func TestPlanRoute_WithoutStart_PicksBestEnd(t *testing.T) {
+	points := []delivery.Point{
+		{Longitude: 0.02, Latitude: 51.5},
+		{Longitude: 0.00, Latitude: 51.5},
+		{Longitude: 0.01, Latitude: 51.5},
+	}
+
+	order, metres := dispatch.PlanRoute(nil, points)
+
+	assert.Contains(t, [][]int{{1, 2, 0}, {0, 2, 1}}, order)
+	assert.InDelta(t, dispatch.Distance(points[0], points[1]), metres, 1)
+}
+
+func TestDistance_OneDegreeOfLatitude(t *testing.T) {
+	d := dispatch.Distance(delivery.Point{Latitude: 51}, delivery.Point{Latitude: 52})
+	assert.InDelta(t, 2*math.Pi*6371000/360, d, 1)
+}
+
+func TestRun_Optimise_PutsStopsWithoutCoordinatesLast(t *testing.T) {
+	far := newStop(t, 51.52, -0.05)
+	unlocated := newStop(t, 0, 0)
+	near := newStop(t, 51.51, -0.07)
+	r := newRun(t, uuid.New(), &delivery.Point{Longitude: -0.072, Latitude: 51.515}, far, unlocated, near)
+
+	require.NoError(t, r.Optimise(now))
+
+	stops := r.Stops()
+	assert.Equal(t, []uuid.UUID{near.ID(), far.ID(), unlocated.ID()}, []uuid.UUID{stops[0].ID(), stops[1].ID(), stops[2].ID()})
+	assert.Equal(t, []int{1, 2, 3}, []int{stops[0].Sequence(), stops[1].Sequence(), stops[2].Sequence()})
+	assert.Positive(t, r.DistanceMetres())
+}
+
+func TestRun_AddStop_RejectsOrderTwice(t *testing.T) {
+	s := newStop(t, 51.5, -0.07)
+	r := newRun(t, uuid.New(), nil, s)
+
+	again := dispatch.ReconstructStop(uuid.New(), s.OrderID(), s.CustomerID(), nil, nil, "", nil, 0, dispatch.StopPending, "", "", nil, nil)
+	assert.ErrorIs(t, r.AddStop(again, now), dispatch.ErrOrderAlreadyRouted)
+}
+
+func TestRun_AssignDriver_ChecksBranchAndActive(t *testing.T) {
+	branchID := uuid.New()
+	r := newRun(t, branchID, nil, newStop(t, 51.5, -0.07))
+
+	assert.ErrorIs(t, r.AssignDriver(newDriver(t, uuid.New()), now), dispatch.ErrDriverWrongBranch)
+	inactive := newDriver(t, branchID)
+	require.NoError(t, inactive.Update(branchID, "", "Van", false, now))
+	assert.ErrorIs(t, r.AssignDriver(inactive, now), dispatch.ErrDriverInactive)
+
+	d := newDriver(t, branchID)
+	require.NoError(t, r.AssignDriver(d, now))
+	assert.Equal(t, d.AdminID(), *r.DriverID())
+}
+
+func TestRun_StartAndDeliver_CompletesRunWhenNoStopsPending(t *testing.T) {
+	branchID := uuid.New()
+	first, second := newStop(t, 51.5, -0.07), newStop(t, 51.51, -0.06)
+	r := newRun(t, branchID, nil, first, second)
+	d := newDriver(t, branchID)
+
+	assert.ErrorIs(t, r.StartRun(d.AdminID(), now), dispatch.ErrNoDriver)
+	require.NoError(t, r.AssignDriver(d, now))
+	assert.ErrorIs(t, r.StartRun(uuid.New(), now), dispatch.ErrNotRunDriver)
+	assert.ErrorIs(t, r.Deliver(first.ID(), d.AdminID(), "", []*dispatch.Proof{photo(t, first.ID())}, now), dispatch.ErrRunNotStarted)
+	require.NoError(t, r.StartRun(d.AdminID(), now))
+	assert.ErrorIs(t, r.AddStop(newStop(t, 51.5, -0.07), now), dispatch.ErrRunNotPlanned)
+
+	assert.ErrorIs(t, r.Deliver(first.ID(), d.AdminID(), "Amina", nil, now), dispatch.ErrProofRequired)
+	require.NoError(t, r.Deliver(first.ID(), d.AdminID(), " Amina ", []*dispatch.Proof{photo(t, first.ID())}, now))
+	assert.Equal(t, dispatch.StopDelivered, first.Status())
+	assert.Equal(t, "Amina", first.RecipientName())
+	assert.Equal(t, []dispatch.ProofKind{dispatch.ProofPhoto}, first.Proofs())
+	assert.ErrorIs(t, r.Fail(first.ID(), d.AdminID(), "Not in", nil, now), dispatch.ErrStopCompleted)
+	assert.Equal(t, dispatch.RunStarted, r.Status())
+
+	assert.ErrorIs(t, r.Fail(second.ID(), d.AdminID(), " ", nil, now), dispatch.ErrFailureReason)
+	require.NoError(t, r.Fail(second.ID(), d.AdminID(), "No one home", nil, now.Add(time.Hour)))
+	assert.Equal(t, dispatch.RunCompleted, r.Status())
+	assert.Equal(t, now.Add(time.Hour), *r.CompletedAt())
+}
+
+func TestRun_PositionOf_CountsPendingStopsAhead(t *testing.T) {
+	branchID := uuid.New()
+	stops := []*dispatch.Stop{newStop(t, 51.5, -0.07), newStop(t, 51.51, -0.06), newStop(t, 51.52, -0.05)}
+	r := newRun(t, branchID, nil, stops...)
+	d := newDriver(t, branchID)
+	require.NoError(t, r.AssignDriver(d, now))
+	require.NoError(t, r.StartRun(d.AdminID(), now))
+	require.NoError(t, r.Deliver(stops[0].ID(), d.AdminID(), "", []*dispatch.Proof{photo(t, stops[0].ID())}, now))
+
+	p, err := r.PositionOf(stops[2].OrderID())
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.Stop.Sequence())
+	assert.Equal(t, 3, p.Total)
+	assert.Equal(t, 1, p.Ahead)
+
+	_, err = r.PositionOf(uuid.New())
+	assert.ErrorIs(t, err, dispatch.ErrOrderNotOnRun)
+}
+
+func TestNewProof_ValidatesTypeAndSize(t *testing.T) {
+	_, err := dispatch.NewProof(uuid.New(), dispatch.ProofSignature, "image/gif", []byte("GIF89a"), now)
+	assert.ErrorIs(t, err, dispatch.ErrUnsupportedProof)
+	_, err = dispatch.NewProof(uuid.New(), dispatch.ProofSignature, "image/png", make([]byte, dispatch.MaxProofBytes+1), now)
+	assert.ErrorIs(t, err, dispatch.ErrProofTooLarge)
+	_, err = dispatch.NewProof(uuid.New(), "video", "image/png", []byte{1}, now)
+	assert.ErrorIs(t, err, dispatch.ErrInvalidProofKind)
+}
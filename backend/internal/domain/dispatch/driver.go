@@ -0,0 +1,95 @@
+package dispatch
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+)
+
+// minPasswordLength is the shortest password a driver account may have.
+const minPasswordLength = 8
+
+// ValidatePassword checks a new driver account's password is long enough.
+func ValidatePassword(raw string) error {
+	if len(raw) < minPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// Driver is the profile of an admin account with the driver role: the
+// branch they deliver from, the vehicle they drive and a number customers
+// can reach them on while out on a run.
+type Driver struct {
+	adminID   uuid.UUID
+	fullName  string
+	branchID  uuid.UUID
+	phone     string
+	vehicle   string
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewDriver validates and creates an active driver profile for a.
+func NewDriver(a *admin.Admin, branchID uuid.UUID, phone, vehicle string, now time.Time) (*Driver, error) {
+	if a.Role() != admin.RoleDriver {
+		return nil, ErrNotADriver
+	}
+	d := &Driver{adminID: a.ID(), fullName: a.FullName(), createdAt: now}
+	if err := d.Update(branchID, phone, vehicle, true, now); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ReconstructDriver reconstructs a Driver from persistence without
+// validation.
+func ReconstructDriver(
+	adminID uuid.UUID,
+	fullName string,
+	branchID uuid.UUID,
+	phone, vehicle string,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Driver {
+	return &Driver{
+		adminID:   adminID,
+		fullName:  fullName,
+		branchID:  branchID,
+		phone:     phone,
+		vehicle:   vehicle,
+		active:    active,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (d *Driver) AdminID() uuid.UUID   { return d.adminID }
+func (d *Driver) FullName() string     { return d.fullName }
+func (d *Driver) BranchID() uuid.UUID  { return d.branchID }
+func (d *Driver) Phone() string        { return d.phone }
+func (d *Driver) Vehicle() string      { return d.vehicle }
+func (d *Driver) Active() bool         { return d.active }
+func (d *Driver) CreatedAt() time.Time { return d.createdAt }
+func (d *Driver) UpdatedAt() time.Time { return d.updatedAt }
+
+// Update changes the driver's profile. Inactive drivers keep the runs they
+// drove but can no longer be given new ones.
+func (d *Driver) Update(branchID uuid.UUID, phone, vehicle string, active bool, now time.Time) error {
+	if branchID == uuid.Nil {
+		return ErrBranchRequired
+	}
+	vehicle = strings.TrimSpace(vehicle)
+	if vehicle == "" {
+		return ErrEmptyVehicle
+	}
+	d.branchID = branchID
+	d.phone = strings.TrimSpace(phone)
+	d.vehicle = vehicle
+	d.active = active
+	d.updatedAt = now
+	return nil
+}
@@ -0,0 +1,37 @@
+package dispatch
+
+import "errors"
+
+var (
+	ErrDriverNotFound      = errors.New("driver not found")
+	ErrNotADriver          = errors.New("admin is not a driver")
+	ErrDriverInactive      = errors.New("driver is no longer active")
+	ErrDriverWrongBranch   = errors.New("driver is based at another branch")
+	ErrBranchRequired      = errors.New("driver must have a home branch")
+	ErrEmptyVehicle        = errors.New("vehicle must not be empty")
+	ErrInvalidPassword     = errors.New("password must be at least 8 characters")
+	ErrEmailTaken          = errors.New("an admin with that email already exists")
+	ErrRunNotFound         = errors.New("delivery run not found")
+	ErrConcurrentUpdate    = errors.New("delivery run was changed by someone else; reload and try again")
+	ErrInvalidSlot         = errors.New("run slot must end after it starts")
+	ErrNoOrders            = errors.New("no confirmed delivery orders are waiting for that zone and slot")
+	ErrRunNotPlanned       = errors.New("run can only be changed before it starts")
+	ErrRunNotStarted       = errors.New("run has not started")
+	ErrNoDriver            = errors.New("run has no driver")
+	ErrEmptyRun            = errors.New("run has no stops")
+	ErrNotRunDriver        = errors.New("run is assigned to another driver")
+	ErrStopNotFound        = errors.New("stop not found")
+	ErrStopCompleted       = errors.New("stop has already been delivered or failed")
+	ErrOrderAlreadyRouted  = errors.New("order is already on a delivery run")
+	ErrOrderNotOnRun       = errors.New("order is not on a delivery run")
+	ErrOrderWrongBranch    = errors.New("order is for another branch")
+	ErrNoAddress           = errors.New("customer has no delivery address")
+	ErrAddressNotCustomers = errors.New("address does not belong to the order's customer")
+	ErrProofRequired       = errors.New("a delivered stop needs a photo or signature as proof")
+	ErrFailureReason       = errors.New("a failed stop needs a reason")
+	ErrInvalidProofKind    = errors.New("proof must be a photo or signature")
+	ErrUnsupportedProof    = errors.New("proof must be a JPEG, PNG or WebP image")
+	ErrProofTooLarge       = errors.New("proof must be at most 5 MB")
+	ErrProofNotFound       = errors.New("proof of delivery not found")
+	ErrDispatchNotAllowed  = errors.New("only managers may manage drivers and delivery runs")
+)
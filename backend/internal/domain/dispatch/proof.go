@@ -0,0 +1,68 @@
+package dispatch
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProofKind is what a proof of delivery shows.
+type ProofKind string
+
+const (
+	// ProofPhoto is a photo of the order where it was left.
+	ProofPhoto ProofKind = "photo"
+	// ProofSignature is the recipient's signature, drawn on the driver's
+	// phone.
+	ProofSignature ProofKind = "signature"
+)
+
+// ParseProofKind validates a raw proof kind.
+func ParseProofKind(raw string) (ProofKind, error) {
+	switch k := ProofKind(raw); k {
+	case ProofPhoto, ProofSignature:
+		return k, nil
+	}
+	return "", ErrInvalidProofKind
+}
+
+// MaxProofBytes is the largest image accepted as proof of delivery.
+const MaxProofBytes = 5 << 20
+
+var proofContentTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/webp": true}
+
+// Proof is an image a driver uploads when they deliver, or fail to deliver,
+// a stop. A stop has at most one proof of each kind; uploading another
+// replaces it.
+type Proof struct {
+	stopID      uuid.UUID
+	kind        ProofKind
+	contentType string
+	data        []byte
+	createdAt   time.Time
+}
+
+// NewProof validates an uploaded proof of delivery.
+func NewProof(stopID uuid.UUID, kind ProofKind, contentType string, data []byte, now time.Time) (*Proof, error) {
+	if _, err := ParseProofKind(string(kind)); err != nil {
+		return nil, err
+	}
+	if !proofContentTypes[contentType] || len(data) == 0 {
+		return nil, ErrUnsupportedProof
+	}
+	if len(data) > MaxProofBytes {
+		return nil, ErrProofTooLarge
+	}
+	return &Proof{stopID: stopID, kind: kind, contentType: contentType, data: data, createdAt: now}, nil
+}
+
+// ReconstructProof reconstructs a Proof from persistence without validation.
+func ReconstructProof(stopID uuid.UUID, kind ProofKind, contentType string, data []byte, createdAt time.Time) *Proof {
+	return &Proof{stopID: stopID, kind: kind, contentType: contentType, data: data, createdAt: createdAt}
+}
+
+func (p *Proof) StopID() uuid.UUID    { return p.stopID }
+func (p *Proof) Kind() ProofKind      { return p.kind }
+func (p *Proof) ContentType() string  { return p.contentType }
+func (p *Proof) Data() []byte         { return p.data }
+func (p *Proof) CreatedAt() time.Time { return p.createdAt }
@@ -0,0 +1,60 @@
+package dispatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+)
+
+// DriverRepository provides access to drivers. Drivers based at branches
+// outside the scope are not returned.
+type DriverRepository interface {
+	// Create stores a new driver together with the admin account they sign
+	// in with. It returns ErrEmailTaken if the account's email is in use.
+	Create(ctx context.Context, account *admin.Admin, d *Driver) error
+	// Save updates a driver based at a branch in scope.
+	Save(ctx context.Context, d *Driver) error
+	FindByAdminID(ctx context.Context, adminID uuid.UUID) (*Driver, error)
+	// FindAll returns the drivers based at a branch, or at every branch in
+	// scope, by name.
+	FindAll(ctx context.Context, branchID *uuid.UUID) ([]*Driver, error)
+}
+
+// RunFilter narrows runs to a branch, a driver or a status, and to slots
+// starting from From up to but not including To.
+type RunFilter struct {
+	BranchID *uuid.UUID
+	DriverID *uuid.UUID
+	Status   *RunStatus
+	From     time.Time
+	To       time.Time
+}
+
+// RunRepository provides access to delivery runs with their stops. Runs at
+// branches outside the scope are not returned.
+type RunRepository interface {
+	// Create stores a new run at a branch in scope. It returns
+	// ErrOrderAlreadyRouted if one of its orders is on another run.
+	Create(ctx context.Context, r *Run) error
+	// Update stores a changed run and its stops. It returns
+	// ErrConcurrentUpdate if the run was changed since it was loaded and
+	// ErrOrderAlreadyRouted if an added order is on another run.
+	Update(ctx context.Context, r *Run) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Run, error)
+	// FindByOrderID returns the run an order is on, at any branch.
+	FindByOrderID(ctx context.Context, orderID uuid.UUID) (*Run, error)
+	// FindRuns returns runs by slot start.
+	FindRuns(ctx context.Context, f RunFilter) ([]*Run, error)
+	// RoutedOrders returns which of the given orders are already on a run.
+	RoutedOrders(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// ProofRepository provides access to proofs of delivery. Proofs for runs
+// at branches outside the scope are not returned.
+type ProofRepository interface {
+	// Save stores a proof, replacing the stop's proof of the same kind.
+	Save(ctx context.Context, p *Proof) error
+	Find(ctx context.Context, stopID uuid.UUID, kind ProofKind) (*Proof, error)
+}
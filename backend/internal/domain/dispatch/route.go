@@ -0,0 +1,121 @@
+package dispatch
+
+import (
+	"math"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// earthRadiusMetres is the mean radius of the earth.
+const earthRadiusMetres = 6371000
+
+// Distance returns the great-circle distance between a and b in metres.
+func Distance(a, b delivery.Point) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMetres * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// PlanRoute orders points into a short path that visits each once,
+// starting from start when given and ending at the last stop. It builds a
+// nearest-neighbour path, from start or else from whichever point gives
+// the shortest one, and then improves it with 2-opt until no reversal of a
+// section makes it shorter. It returns the indexes of points in the order
+// to visit them and the path's length in metres.
+func PlanRoute(start *delivery.Point, points []delivery.Point) ([]int, float64) {
+	if len(points) == 0 {
+		return nil, 0
+	}
+
+	var best []int
+	bestLength := math.Inf(1)
+	firsts := []int{-1}
+	if start == nil {
+		firsts = firsts[:0]
+		for i := range points {
+			firsts = append(firsts, i)
+		}
+	}
+	for _, first := range firsts {
+		path := nearestNeighbour(start, points, first)
+		twoOpt(start, points, path)
+		if length := pathLength(start, points, path); length < bestLength-1e-9 {
+			best, bestLength = path, length
+		}
+	}
+	return best, bestLength
+}
+
+// nearestNeighbour visits the closest unvisited point next, from start or,
+// when first is not -1, from points[first].
+func nearestNeighbour(start *delivery.Point, points []delivery.Point, first int) []int {
+	visited := make([]bool, len(points))
+	path := make([]int, 0, len(points))
+	var at delivery.Point
+	if first >= 0 {
+		visited[first] = true
+		path = append(path, first)
+		at = points[first]
+	} else {
+		at = *start
+	}
+	for len(path) < len(points) {
+		next, nearest := -1, math.Inf(1)
+		for i, p := range points {
+			if d := Distance(at, p); !visited[i] && d < nearest {
+				next, nearest = i, d
+			}
+		}
+		visited[next] = true
+		path = append(path, next)
+		at = points[next]
+	}
+	return path
+}
+
+// twoOpt reverses sections of path in place while that shortens it. Only
+// the legs either side of a section change when it is reversed; a path
+// without a start or a section running to its end has one leg fewer.
+func twoOpt(start *delivery.Point, points []delivery.Point, path []int) {
+	leg := func(i, j int) float64 {
+		if j >= len(path) {
+			return 0
+		}
+		if i < 0 {
+			if start == nil {
+				return 0
+			}
+			return Distance(*start, points[path[j]])
+		}
+		return Distance(points[path[i]], points[path[j]])
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(path)-1; i++ {
+			for k := i + 1; k < len(path); k++ {
+				before := leg(i-1, i) + leg(k, k+1)
+				after := leg(i-1, k) + leg(i, k+1)
+				if after < before-1e-6 {
+					for l, r := i, k; l < r; l, r = l+1, r-1 {
+						path[l], path[r] = path[r], path[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+}
+
+func pathLength(start *delivery.Point, points []delivery.Point, path []int) float64 {
+	length := 0.0
+	if start != nil {
+		length += Distance(*start, points[path[0]])
+	}
+	for i := 1; i < len(path); i++ {
+		length += Distance(points[path[i-1]], points[path[i]])
+	}
+	return length
+}
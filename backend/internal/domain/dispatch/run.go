@@ -0,0 +1,425 @@
+package dispatch
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// StopStatus is how far a stop on a run has got.
+type StopStatus string
+
+const (
+	StopPending   StopStatus = "pending"
+	StopDelivered StopStatus = "delivered"
+	StopFailed    StopStatus = "failed"
+)
+
+// Stop is an order to be delivered on a run. Its address is copied from
+// the customer's address book when the order is added, so that later
+// changes there do not move a delivery already under way.
+type Stop struct {
+	id            uuid.UUID
+	orderID       uuid.UUID
+	customerID    uuid.UUID
+	addressID     *uuid.UUID
+	addressLines  []string
+	postcode      string
+	point         *delivery.Point
+	sequence      int
+	status        StopStatus
+	recipientName string
+	failureReason string
+	proofs        []ProofKind
+	completedAt   *time.Time
+}
+
+// NewStop creates a pending stop delivering orderID to addr.
+func NewStop(id, orderID uuid.UUID, addr *customer.Address) *Stop {
+	addressID := addr.ID()
+	s := &Stop{
+		id:         id,
+		orderID:    orderID,
+		customerID: addr.CustomerID(),
+		addressID:  &addressID,
+		postcode:   addr.Postcode().String(),
+		status:     StopPending,
+	}
+	for _, line := range []string{addr.Line1(), addr.Line2(), addr.City()} {
+		if line = strings.TrimSpace(line); line != "" {
+			s.addressLines = append(s.addressLines, line)
+		}
+	}
+	if c := addr.Coordinates(); c != nil {
+		s.point = &delivery.Point{Longitude: c.Longitude(), Latitude: c.Latitude()}
+	}
+	return s
+}
+
+// ReconstructStop reconstructs a Stop from persistence without validation.
+func ReconstructStop(
+	id, orderID, customerID uuid.UUID,
+	addressID *uuid.UUID,
+	addressLines []string,
+	postcode string,
+	point *delivery.Point,
+	sequence int,
+	status StopStatus,
+	recipientName, failureReason string,
+	proofs []ProofKind,
+	completedAt *time.Time,
+) *Stop {
+	return &Stop{
+		id:            id,
+		orderID:       orderID,
+		customerID:    customerID,
+		addressID:     addressID,
+		addressLines:  addressLines,
+		postcode:      postcode,
+		point:         point,
+		sequence:      sequence,
+		status:        status,
+		recipientName: recipientName,
+		failureReason: failureReason,
+		proofs:        proofs,
+		completedAt:   completedAt,
+	}
+}
+
+func (s *Stop) ID() uuid.UUID           { return s.id }
+func (s *Stop) OrderID() uuid.UUID      { return s.orderID }
+func (s *Stop) CustomerID() uuid.UUID   { return s.customerID }
+func (s *Stop) AddressID() *uuid.UUID   { return s.addressID }
+func (s *Stop) AddressLines() []string  { return s.addressLines }
+func (s *Stop) Postcode() string        { return s.postcode }
+func (s *Stop) Point() *delivery.Point  { return s.point }
+func (s *Stop) Sequence() int           { return s.sequence }
+func (s *Stop) Status() StopStatus      { return s.status }
+func (s *Stop) RecipientName() string   { return s.recipientName }
+func (s *Stop) FailureReason() string   { return s.failureReason }
+func (s *Stop) Proofs() []ProofKind     { return s.proofs }
+func (s *Stop) CompletedAt() *time.Time { return s.completedAt }
+
+// RunStatus is the lifecycle state of a delivery run.
+type RunStatus string
+
+const (
+	// RunPlanned runs can still have stops added, removed and reordered.
+	RunPlanned RunStatus = "planned"
+	// RunStarted runs have left the shop with their driver.
+	RunStarted RunStatus = "started"
+	// RunCompleted runs have had every stop delivered or failed.
+	RunCompleted RunStatus = "completed"
+)
+
+// Run is a batch of delivery orders for one zone and slot that a driver
+// takes out together, in the order of its stops. A run may start from a
+// fixed point, usually the shop, which its route is planned from.
+type Run struct {
+	id             uuid.UUID
+	branchID       uuid.UUID
+	zoneID         uuid.UUID
+	slotStartsAt   time.Time
+	slotEndsAt     time.Time
+	start          *delivery.Point
+	driverID       *uuid.UUID
+	status         RunStatus
+	stops          []*Stop
+	distanceMetres int
+	createdBy      uuid.UUID
+	version        int
+	createdAt      time.Time
+	updatedAt      time.Time
+	startedAt      *time.Time
+	completedAt    *time.Time
+}
+
+// NewRun creates an empty planned run for the slot.
+func NewRun(id, branchID, zoneID uuid.UUID, slotStartsAt, slotEndsAt time.Time, start *delivery.Point, createdBy uuid.UUID, now time.Time) (*Run, error) {
+	if !slotEndsAt.After(slotStartsAt) {
+		return nil, ErrInvalidSlot
+	}
+	return &Run{
+		id:           id,
+		branchID:     branchID,
+		zoneID:       zoneID,
+		slotStartsAt: slotStartsAt,
+		slotEndsAt:   slotEndsAt,
+		start:        start,
+		status:       RunPlanned,
+		createdBy:    createdBy,
+		version:      1,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+// ReconstructRun reconstructs a Run from persistence without validation.
+func ReconstructRun(
+	id, branchID, zoneID uuid.UUID,
+	slotStartsAt, slotEndsAt time.Time,
+	start *delivery.Point,
+	driverID *uuid.UUID,
+	status RunStatus,
+	stops []*Stop,
+	distanceMetres int,
+	createdBy uuid.UUID,
+	version int,
+	createdAt, updatedAt time.Time,
+	startedAt, completedAt *time.Time,
+) *Run {
+	return &Run{
+		id:             id,
+		branchID:       branchID,
+		zoneID:         zoneID,
+		slotStartsAt:   slotStartsAt,
+		slotEndsAt:     slotEndsAt,
+		start:          start,
+		driverID:       driverID,
+		status:         status,
+		stops:          stops,
+		distanceMetres: distanceMetres,
+		createdBy:      createdBy,
+		version:        version,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+		startedAt:      startedAt,
+		completedAt:    completedAt,
+	}
+}
+
+func (r *Run) ID() uuid.UUID           { return r.id }
+func (r *Run) BranchID() uuid.UUID     { return r.branchID }
+func (r *Run) ZoneID() uuid.UUID       { return r.zoneID }
+func (r *Run) SlotStartsAt() time.Time { return r.slotStartsAt }
+func (r *Run) SlotEndsAt() time.Time   { return r.slotEndsAt }
+func (r *Run) Start() *delivery.Point  { return r.start }
+func (r *Run) DriverID() *uuid.UUID    { return r.driverID }
+func (r *Run) Status() RunStatus       { return r.status }
+func (r *Run) Stops() []*Stop          { return r.stops }
+func (r *Run) DistanceMetres() int     { return r.distanceMetres }
+func (r *Run) CreatedBy() uuid.UUID    { return r.createdBy }
+func (r *Run) Version() int            { return r.version }
+func (r *Run) CreatedAt() time.Time    { return r.createdAt }
+func (r *Run) UpdatedAt() time.Time    { return r.updatedAt }
+func (r *Run) StartedAt() *time.Time   { return r.startedAt }
+func (r *Run) CompletedAt() *time.Time { return r.completedAt }
+
+// AddStop adds s to the end of a planned run.
+func (r *Run) AddStop(s *Stop, now time.Time) error {
+	if r.status != RunPlanned {
+		return ErrRunNotPlanned
+	}
+	for _, existing := range r.stops {
+		if existing.orderID == s.orderID {
+			return ErrOrderAlreadyRouted
+		}
+	}
+	s.sequence = len(r.stops) + 1
+	r.stops = append(r.stops, s)
+	r.updatedAt = now
+	return nil
+}
+
+// RemoveStop takes a stop off a planned run. The stops after it move up.
+func (r *Run) RemoveStop(stopID uuid.UUID, now time.Time) error {
+	if r.status != RunPlanned {
+		return ErrRunNotPlanned
+	}
+	for i, s := range r.stops {
+		if s.id == stopID {
+			r.stops = append(r.stops[:i:i], r.stops[i+1:]...)
+			r.resequence(r.stops)
+			r.updatedAt = now
+			return nil
+		}
+	}
+	return ErrStopNotFound
+}
+
+// Optimise reorders a planned run's stops along the shortest route
+// PlanRoute finds between their coordinates. Stops whose address has no
+// coordinates cannot be routed and go last, in the order they were in.
+func (r *Run) Optimise(now time.Time) error {
+	if r.status != RunPlanned {
+		return ErrRunNotPlanned
+	}
+	var located, unlocated []*Stop
+	var points []delivery.Point
+	for _, s := range r.stops {
+		if s.point == nil {
+			unlocated = append(unlocated, s)
+			continue
+		}
+		located = append(located, s)
+		points = append(points, *s.point)
+	}
+
+	order, metres := PlanRoute(r.start, points)
+	ordered := make([]*Stop, 0, len(r.stops))
+	for _, i := range order {
+		ordered = append(ordered, located[i])
+	}
+	r.resequence(append(ordered, unlocated...))
+	r.distanceMetres = int(math.Round(metres))
+	r.updatedAt = now
+	return nil
+}
+
+func (r *Run) resequence(stops []*Stop) {
+	for i, s := range stops {
+		s.sequence = i + 1
+	}
+	r.stops = stops
+}
+
+// AssignDriver gives a planned run to an active driver of its branch.
+func (r *Run) AssignDriver(d *Driver, now time.Time) error {
+	if r.status != RunPlanned {
+		return ErrRunNotPlanned
+	}
+	if !d.active {
+		return ErrDriverInactive
+	}
+	if d.branchID != r.branchID {
+		return ErrDriverWrongBranch
+	}
+	driverID := d.adminID
+	r.driverID = &driverID
+	r.updatedAt = now
+	return nil
+}
+
+// StartRun marks the run as out for delivery. Only its driver may start it.
+func (r *Run) StartRun(driverID uuid.UUID, now time.Time) error {
+	if r.status != RunPlanned {
+		return ErrRunNotPlanned
+	}
+	if err := r.checkDriver(driverID); err != nil {
+		return err
+	}
+	if len(r.stops) == 0 {
+		return ErrEmptyRun
+	}
+	r.status = RunStarted
+	r.startedAt = &now
+	r.updatedAt = now
+	return nil
+}
+
+func (r *Run) checkDriver(driverID uuid.UUID) error {
+	if r.driverID == nil {
+		return ErrNoDriver
+	}
+	if *r.driverID != driverID {
+		return ErrNotRunDriver
+	}
+	return nil
+}
+
+// Deliver records that the driver handed over a stop's order, proven by
+// at least one photo or signature.
+func (r *Run) Deliver(stopID, driverID uuid.UUID, recipientName string, proofs []*Proof, now time.Time) error {
+	if len(proofs) == 0 {
+		return ErrProofRequired
+	}
+	s, err := r.pendingStop(stopID, driverID)
+	if err != nil {
+		return err
+	}
+	s.status = StopDelivered
+	s.recipientName = strings.TrimSpace(recipientName)
+	r.complete(s, proofs, now)
+	return nil
+}
+
+// Fail records that the driver could not deliver a stop's order, and why.
+// A photo, say of the door no one answered, is optional.
+func (r *Run) Fail(stopID, driverID uuid.UUID, reason string, proofs []*Proof, now time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrFailureReason
+	}
+	s, err := r.pendingStop(stopID, driverID)
+	if err != nil {
+		return err
+	}
+	s.status = StopFailed
+	s.failureReason = reason
+	r.complete(s, proofs, now)
+	return nil
+}
+
+func (r *Run) pendingStop(stopID, driverID uuid.UUID) (*Stop, error) {
+	if r.status != RunStarted {
+		return nil, ErrRunNotStarted
+	}
+	if err := r.checkDriver(driverID); err != nil {
+		return nil, err
+	}
+	s := r.Stop(stopID)
+	if s == nil {
+		return nil, ErrStopNotFound
+	}
+	if s.status != StopPending {
+		return nil, ErrStopCompleted
+	}
+	return s, nil
+}
+
+// complete closes a stop with its proofs, and the run once it has no stops
+// left pending.
+func (r *Run) complete(s *Stop, proofs []*Proof, now time.Time) {
+	for _, p := range proofs {
+		s.proofs = append(s.proofs, p.kind)
+	}
+	s.completedAt = &now
+	r.updatedAt = now
+	for _, other := range r.stops {
+		if other.status == StopPending {
+			return
+		}
+	}
+	r.status = RunCompleted
+	r.completedAt = &now
+}
+
+// Stop returns the stop with the given ID, or nil if the run has none.
+func (r *Run) Stop(stopID uuid.UUID) *Stop {
+	for _, s := range r.stops {
+		if s.id == stopID {
+			return s
+		}
+	}
+	return nil
+}
+
+// Position is where an order is on its run: its stop, how many stops the
+// run has and how many stops before it the driver still has to make.
+type Position struct {
+	Run   *Run
+	Stop  *Stop
+	Total int
+	Ahead int
+}
+
+// PositionOf returns where orderID is on the run.
+func (r *Run) PositionOf(orderID uuid.UUID) (*Position, error) {
+	for _, s := range r.stops {
+		if s.orderID != orderID {
+			continue
+		}
+		p := &Position{Run: r, Stop: s, Total: len(r.stops)}
+		for _, before := range r.stops[:s.sequence-1] {
+			if before.status == StopPending {
+				p.Ahead++
+			}
+		}
+		return p, nil
+	}
+	return nil, ErrOrderNotOnRun
+}
@@ -0,0 +1,23 @@
+package fulfilment
+
+import "errors"
+
+var (
+	ErrInvalidMethod        = errors.New("fulfilment method must be 'delivery' or 'collection'")
+	ErrInvalidCapacityUnit  = errors.New("capacity unit must be 'orders' or 'kg'")
+	ErrInvalidCapacity      = errors.New("capacity must be greater than zero")
+	ErrInvalidTimeOfDay     = errors.New("time of day must be in HH:MM format")
+	ErrInvalidWeekday       = errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+	ErrInvalidTimeRange     = errors.New("end time must be after start time")
+	ErrDuplicateWeekday     = errors.New("opening hours contain the same weekday more than once")
+	ErrMissingZone          = errors.New("delivery slot templates must specify a delivery zone")
+	ErrInvalidWeight        = errors.New("weight must be greater than zero for kg-capacity slots")
+	ErrInvalidDate          = errors.New("date must be in YYYY-MM-DD format")
+	ErrSlotTemplateNotFound = errors.New("slot template not found")
+	ErrSlotUnavailable      = errors.New("slot is not available on the requested date")
+	ErrSlotFull             = errors.New("slot has no remaining capacity")
+	ErrReservationNotFound  = errors.New("slot reservation not found")
+	ErrReservationExpired   = errors.New("slot reservation hold has expired")
+	ErrReservationNotHeld   = errors.New("slot reservation is not on hold")
+	ErrReservationNotOwned  = errors.New("slot reservation belongs to another customer")
+)
@@ -0,0 +1,77 @@
+package fulfilment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OpeningHours are a branch's trading hours for one day of the week.
+type OpeningHours struct {
+	branchID uuid.UUID
+	weekday  time.Weekday
+	opensAt  TimeOfDay
+	closesAt TimeOfDay
+}
+
+// NewOpeningHours creates OpeningHours with validation.
+func NewOpeningHours(branchID uuid.UUID, weekday time.Weekday, opensAt, closesAt TimeOfDay) (*OpeningHours, error) {
+	if err := validateWeekday(weekday); err != nil {
+		return nil, err
+	}
+	if closesAt.Minutes() <= opensAt.Minutes() {
+		return nil, ErrInvalidTimeRange
+	}
+
+	return &OpeningHours{
+		branchID: branchID,
+		weekday:  weekday,
+		opensAt:  opensAt,
+		closesAt: closesAt,
+	}, nil
+}
+
+func (h *OpeningHours) BranchID() uuid.UUID   { return h.branchID }
+func (h *OpeningHours) Weekday() time.Weekday { return h.weekday }
+func (h *OpeningHours) OpensAt() TimeOfDay    { return h.opensAt }
+func (h *OpeningHours) ClosesAt() TimeOfDay   { return h.closesAt }
+
+// Covers reports whether the window [start, end) falls inside these hours.
+func (h *OpeningHours) Covers(start, end TimeOfDay) bool {
+	return start.Minutes() >= h.opensAt.Minutes() && end.Minutes() <= h.closesAt.Minutes()
+}
+
+// Closure marks a date on which a branch does not trade (e.g. a public holiday).
+type Closure struct {
+	id       uuid.UUID
+	branchID uuid.UUID
+	date     time.Time
+	reason   string
+}
+
+// NewClosure creates a Closure for the calendar date of date.
+func NewClosure(id, branchID uuid.UUID, date time.Time, reason string) *Closure {
+	y, m, d := date.Date()
+	return &Closure{
+		id:       id,
+		branchID: branchID,
+		date:     time.Date(y, m, d, 0, 0, 0, 0, date.Location()),
+		reason:   reason,
+	}
+}
+
+func (c *Closure) ID() uuid.UUID       { return c.id }
+func (c *Closure) BranchID() uuid.UUID { return c.branchID }
+func (c *Closure) Date() time.Time     { return c.date }
+func (c *Closure) Reason() string      { return c.reason }
+
+// IsOn reports whether the closure falls on the calendar date of t.
+func (c *Closure) IsOn(t time.Time) bool {
+	return sameDate(c.date, t)
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
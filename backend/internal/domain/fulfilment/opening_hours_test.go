@@ -0,0 +1,59 @@
+package fulfilment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, raw string) fulfilment.TimeOfDay {
+	t.Helper()
+	tod, err := fulfilment.ParseTimeOfDay(raw)
+	require.NoError(t, err)
+	return tod
+}
+
+func TestNewOpeningHours_ValidInputs_CreatesHours(t *testing.T) {
+	branchID := uuid.New()
+
+	h, err := fulfilment.NewOpeningHours(branchID, time.Monday, mustTime(t, "08:00"), mustTime(t, "18:00"))
+
+	require.NoError(t, err)
+	assert.Equal(t, branchID, h.BranchID())
+	assert.Equal(t, time.Monday, h.Weekday())
+	assert.Equal(t, "08:00", h.OpensAt().String())
+	assert.Equal(t, "18:00", h.ClosesAt().String())
+}
+
+func TestNewOpeningHours_ClosesBeforeOpens_ReturnsError(t *testing.T) {
+	_, err := fulfilment.NewOpeningHours(uuid.New(), time.Monday, mustTime(t, "18:00"), mustTime(t, "08:00"))
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidTimeRange)
+}
+
+func TestNewOpeningHours_InvalidWeekday_ReturnsError(t *testing.T) {
+	_, err := fulfilment.NewOpeningHours(uuid.New(), time.Weekday(7), mustTime(t, "08:00"), mustTime(t, "18:00"))
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidWeekday)
+}
+
+func TestOpeningHours_Covers(t *testing.T) {
+	h, _ := fulfilment.NewOpeningHours(uuid.New(), time.Monday, mustTime(t, "08:00"), mustTime(t, "18:00"))
+
+	assert.True(t, h.Covers(mustTime(t, "08:00"), mustTime(t, "10:00")))
+	assert.True(t, h.Covers(mustTime(t, "16:00"), mustTime(t, "18:00")))
+	assert.False(t, h.Covers(mustTime(t, "07:00"), mustTime(t, "09:00")))
+	assert.False(t, h.Covers(mustTime(t, "17:00"), mustTime(t, "19:00")))
+}
+
+func TestClosure_IsOn_MatchesCalendarDate(t *testing.T) {
+	c := fulfilment.NewClosure(uuid.New(), uuid.New(), time.Date(2026, 6, 16, 15, 0, 0, 0, time.UTC), "Eid al-Adha")
+
+	assert.True(t, c.IsOn(time.Date(2026, 6, 16, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, c.IsOn(time.Date(2026, 6, 17, 9, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Eid al-Adha", c.Reason())
+}
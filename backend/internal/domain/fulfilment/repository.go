@@ -0,0 +1,46 @@
+package fulfilment
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlotTemplateFilter narrows FindSlotTemplates. Nil fields are not filtered on.
+type SlotTemplateFilter struct {
+	Method     *Method
+	BranchID   *uuid.UUID
+	ZoneID     *uuid.UUID
+	ActiveOnly bool
+}
+
+// ScheduleRepository provides access to opening hours, closures and slot templates.
+type ScheduleRepository interface {
+	ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*OpeningHours) error
+	FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*OpeningHours, error)
+	SaveClosure(ctx context.Context, closure *Closure) error
+	FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*Closure, error)
+	SaveSlotTemplate(ctx context.Context, template *SlotTemplate) error
+	FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*SlotTemplate, error)
+	FindSlotTemplates(ctx context.Context, filter SlotTemplateFilter) ([]*SlotTemplate, error)
+}
+
+// ReservationRepository provides access to slot reservation persistence.
+type ReservationRepository interface {
+	// Reserve stores the reservation only if the slot still has capacity for
+	// it, counting confirmed bookings and unexpired holds. Implementations must
+	// serialise concurrent reservations for the same slot and return
+	// ErrSlotFull when capacity is exhausted.
+	Reserve(ctx context.Context, slot Slot, reservation *Reservation) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Reservation, error)
+	// FindByOrder returns the reservations confirmed for an order.
+	FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*Reservation, error)
+	Update(ctx context.Context, reservation *Reservation) error
+	// UsageBySlot returns the capacity taken by active reservations for the
+	// templates' slots starting within [from, to).
+	UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[SlotKey]int64, error)
+	// FindConfirmed returns the confirmed reservations for the templates'
+	// slots starting within [from, to), by slot start.
+	FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*Reservation, error)
+}
@@ -0,0 +1,140 @@
+package fulfilment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationStatus is the lifecycle state of a slot reservation.
+type ReservationStatus string
+
+const (
+	ReservationHeld      ReservationStatus = "held"
+	ReservationConfirmed ReservationStatus = "confirmed"
+	ReservationReleased  ReservationStatus = "released"
+)
+
+// Reservation claims capacity in a slot for a customer. It starts as a hold
+// that lapses at expiresAt unless checkout confirms it against an order.
+type Reservation struct {
+	id           uuid.UUID
+	templateID   uuid.UUID
+	customerID   uuid.UUID
+	slotStartsAt time.Time
+	slotEndsAt   time.Time
+	quantity     int64
+	status       ReservationStatus
+	orderID      *uuid.UUID
+	expiresAt    time.Time
+	createdAt    time.Time
+}
+
+// NewReservation places a hold on slot. For kg-capacity slots the hold takes
+// weightGrams of capacity; for order-capacity slots it takes one order.
+func NewReservation(id, customerID uuid.UUID, slot Slot, weightGrams int64, holdTTL time.Duration, now time.Time) (*Reservation, error) {
+	quantity := int64(1)
+	if slot.Capacity().Unit() == CapacityUnitKg {
+		if weightGrams <= 0 {
+			return nil, ErrInvalidWeight
+		}
+		quantity = weightGrams
+	}
+
+	return &Reservation{
+		id:           id,
+		templateID:   slot.TemplateID(),
+		customerID:   customerID,
+		slotStartsAt: slot.StartsAt(),
+		slotEndsAt:   slot.EndsAt(),
+		quantity:     quantity,
+		status:       ReservationHeld,
+		expiresAt:    now.Add(holdTTL),
+		createdAt:    now,
+	}, nil
+}
+
+// ReconstructReservation reconstructs a Reservation from persistence without validation.
+func ReconstructReservation(
+	id, templateID, customerID uuid.UUID,
+	slotStartsAt, slotEndsAt time.Time,
+	quantity int64,
+	status ReservationStatus,
+	orderID *uuid.UUID,
+	expiresAt, createdAt time.Time,
+) *Reservation {
+	return &Reservation{
+		id:           id,
+		templateID:   templateID,
+		customerID:   customerID,
+		slotStartsAt: slotStartsAt,
+		slotEndsAt:   slotEndsAt,
+		quantity:     quantity,
+		status:       status,
+		orderID:      orderID,
+		expiresAt:    expiresAt,
+		createdAt:    createdAt,
+	}
+}
+
+func (r *Reservation) ID() uuid.UUID             { return r.id }
+func (r *Reservation) TemplateID() uuid.UUID     { return r.templateID }
+func (r *Reservation) CustomerID() uuid.UUID     { return r.customerID }
+func (r *Reservation) SlotStartsAt() time.Time   { return r.slotStartsAt }
+func (r *Reservation) SlotEndsAt() time.Time     { return r.slotEndsAt }
+func (r *Reservation) Quantity() int64           { return r.quantity }
+func (r *Reservation) Status() ReservationStatus { return r.status }
+func (r *Reservation) OrderID() *uuid.UUID       { return r.orderID }
+func (r *Reservation) ExpiresAt() time.Time      { return r.expiresAt }
+func (r *Reservation) CreatedAt() time.Time      { return r.createdAt }
+
+// SlotKey returns the key of the slot this reservation occupies.
+func (r *Reservation) SlotKey() SlotKey {
+	return SlotKey{TemplateID: r.templateID, StartsAt: r.slotStartsAt.Unix()}
+}
+
+// IsActive reports whether the reservation still counts against slot capacity.
+func (r *Reservation) IsActive(now time.Time) bool {
+	switch r.status {
+	case ReservationConfirmed:
+		return true
+	case ReservationHeld:
+		return now.Before(r.expiresAt)
+	default:
+		return false
+	}
+}
+
+// Confirm converts a live hold into a confirmed booking for an order.
+func (r *Reservation) Confirm(orderID uuid.UUID, now time.Time) error {
+	if r.status != ReservationHeld {
+		return ErrReservationNotHeld
+	}
+	if !now.Before(r.expiresAt) {
+		return ErrReservationExpired
+	}
+	r.status = ReservationConfirmed
+	r.orderID = &orderID
+	return nil
+}
+
+// Release gives a held slot's capacity back. Releasing twice is a no-op;
+// a confirmed booking cannot be released, as its order still needs the slot.
+func (r *Reservation) Release() error {
+	switch r.status {
+	case ReservationHeld:
+		r.status = ReservationReleased
+	case ReservationReleased:
+	default:
+		return ErrReservationNotHeld
+	}
+	return nil
+}
+
+// Cancel gives the slot back when the order it was booked for is
+// cancelled. A hold not yet confirmed is released as well.
+func (r *Reservation) Cancel() {
+	if r.status == ReservationHeld || r.status == ReservationConfirmed {
+		r.status = ReservationReleased
+	}
+}
@@ -0,0 +1,123 @@
+package fulfilment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKgSlot(t *testing.T) fulfilment.Slot {
+	t.Helper()
+	capacity, err := fulfilment.NewCapacity(fulfilment.CapacityUnitKg, 30000)
+	require.NoError(t, err)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), uuid.New(), nil, fulfilment.MethodCollection,
+		time.Monday, mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+	require.NoError(t, err)
+	return tmpl.SlotOn(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.UTC)
+}
+
+func TestNewReservation_OrdersCapacity_TakesOneOrder(t *testing.T) {
+	slot := newCollectionTemplate(t, uuid.New(), time.Monday, "10:00", "12:00").
+		SlotOn(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.UTC)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	customerID := uuid.New()
+
+	r, err := fulfilment.NewReservation(uuid.New(), customerID, slot, 2500, 15*time.Minute, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), r.Quantity())
+	assert.Equal(t, customerID, r.CustomerID())
+	assert.Equal(t, fulfilment.ReservationHeld, r.Status())
+	assert.Equal(t, now.Add(15*time.Minute), r.ExpiresAt())
+	assert.Equal(t, slot.Key(), r.SlotKey())
+}
+
+func TestNewReservation_KgCapacity_TakesWeight(t *testing.T) {
+	r, err := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 2500, 15*time.Minute, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), r.Quantity())
+}
+
+func TestNewReservation_KgCapacityWithoutWeight_ReturnsError(t *testing.T) {
+	_, err := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 0, 15*time.Minute, time.Now())
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidWeight)
+}
+
+func TestReservation_IsActive(t *testing.T) {
+	now := time.Now()
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, now)
+
+	assert.True(t, r.IsActive(now.Add(14*time.Minute)))
+	assert.False(t, r.IsActive(now.Add(15*time.Minute)))
+
+	require.NoError(t, r.Release())
+	assert.False(t, r.IsActive(now))
+}
+
+func TestReservation_Confirm_LiveHold_Confirms(t *testing.T) {
+	now := time.Now()
+	orderID := uuid.New()
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, now)
+
+	err := r.Confirm(orderID, now.Add(time.Minute))
+
+	require.NoError(t, err)
+	assert.Equal(t, fulfilment.ReservationConfirmed, r.Status())
+	assert.Equal(t, orderID, *r.OrderID())
+	assert.True(t, r.IsActive(now.Add(time.Hour)))
+}
+
+func TestReservation_Confirm_ExpiredHold_ReturnsError(t *testing.T) {
+	now := time.Now()
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, now)
+
+	err := r.Confirm(uuid.New(), now.Add(16*time.Minute))
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationExpired)
+}
+
+func TestReservation_Confirm_Released_ReturnsError(t *testing.T) {
+	now := time.Now()
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, now)
+	require.NoError(t, r.Release())
+
+	err := r.Confirm(uuid.New(), now)
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationNotHeld)
+}
+
+func TestReservation_Release_Confirmed_ReturnsError(t *testing.T) {
+	now := time.Now()
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, now)
+	require.NoError(t, r.Confirm(uuid.New(), now))
+
+	err := r.Release()
+
+	assert.ErrorIs(t, err, fulfilment.ErrReservationNotHeld)
+	assert.Equal(t, fulfilment.ReservationConfirmed, r.Status())
+}
+
+func TestReservation_Release_Twice_IsNoOp(t *testing.T) {
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, time.Now())
+
+	require.NoError(t, r.Release())
+	require.NoError(t, r.Release())
+	assert.Equal(t, fulfilment.ReservationReleased, r.Status())
+}
+
+func TestReservation_Cancel_Confirmed_GivesSlotBack(t *testing.T) {
+	now := time.Now()
+	r, _ := fulfilment.NewReservation(uuid.New(), uuid.New(), newKgSlot(t), 1000, 15*time.Minute, now)
+	require.NoError(t, r.Confirm(uuid.New(), now))
+
+	r.Cancel()
+
+	assert.Equal(t, fulfilment.ReservationReleased, r.Status())
+	assert.False(t, r.IsActive(now))
+}
@@ -0,0 +1,125 @@
+package fulfilment
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Slot is a concrete occurrence of a SlotTemplate on a specific date.
+type Slot struct {
+	templateID uuid.UUID
+	branchID   uuid.UUID
+	zoneID     *uuid.UUID
+	method     Method
+	startsAt   time.Time
+	endsAt     time.Time
+	capacity   Capacity
+}
+
+// SlotKey identifies a slot independently of its time zone representation.
+type SlotKey struct {
+	TemplateID uuid.UUID
+	StartsAt   int64 // unix seconds
+}
+
+// SlotOn returns the slot the template produces on the calendar date of date.
+func (t *SlotTemplate) SlotOn(date time.Time, loc *time.Location) Slot {
+	return Slot{
+		templateID: t.id,
+		branchID:   t.branchID,
+		zoneID:     t.zoneID,
+		method:     t.method,
+		startsAt:   t.startsAt.On(date, loc),
+		endsAt:     t.endsAt.On(date, loc),
+		capacity:   t.capacity,
+	}
+}
+
+func (s Slot) TemplateID() uuid.UUID { return s.templateID }
+func (s Slot) BranchID() uuid.UUID   { return s.branchID }
+func (s Slot) ZoneID() *uuid.UUID    { return s.zoneID }
+func (s Slot) Method() Method        { return s.method }
+func (s Slot) StartsAt() time.Time   { return s.startsAt }
+func (s Slot) EndsAt() time.Time     { return s.endsAt }
+func (s Slot) Capacity() Capacity    { return s.capacity }
+func (s Slot) Key() SlotKey          { return SlotKey{TemplateID: s.templateID, StartsAt: s.startsAt.Unix()} }
+
+// Schedule combines a branch's opening hours and closures to decide on which
+// dates its slot templates actually run.
+type Schedule struct {
+	branchID uuid.UUID
+	hours    map[time.Weekday]*OpeningHours
+	closures []*Closure
+}
+
+// NewSchedule creates a Schedule for a branch. A weekday without opening
+// hours is treated as closed.
+func NewSchedule(branchID uuid.UUID, hours []*OpeningHours, closures []*Closure) *Schedule {
+	byDay := make(map[time.Weekday]*OpeningHours, len(hours))
+	for _, h := range hours {
+		byDay[h.Weekday()] = h
+	}
+	return &Schedule{branchID: branchID, hours: byDay, closures: closures}
+}
+
+func (s *Schedule) BranchID() uuid.UUID { return s.branchID }
+
+// IsClosedOn reports whether the branch does not trade on the date of t.
+func (s *Schedule) IsClosedOn(t time.Time) bool {
+	if _, ok := s.hours[t.Weekday()]; !ok {
+		return true
+	}
+	for _, c := range s.closures {
+		if c.IsOn(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs reports whether the template produces a slot on the date of date.
+func (s *Schedule) Runs(t *SlotTemplate, date time.Time) bool {
+	if !t.IsActive() || t.BranchID() != s.branchID || t.Weekday() != date.Weekday() {
+		return false
+	}
+	if s.IsClosedOn(date) {
+		return false
+	}
+	return s.hours[date.Weekday()].Covers(t.StartsAt(), t.EndsAt())
+}
+
+// Slots generates the slots the templates produce over days calendar days
+// starting at the date of from, ordered by start time.
+func (s *Schedule) Slots(templates []*SlotTemplate, from time.Time, days int, loc *time.Location) []Slot {
+	start := from.In(loc)
+	var slots []Slot
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i)
+		for _, t := range templates {
+			if s.Runs(t, date) {
+				slots = append(slots, t.SlotOn(date, loc))
+			}
+		}
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].startsAt.Before(slots[j].startsAt)
+	})
+	return slots
+}
+
+// Availability is a slot together with how much of its capacity is taken.
+type Availability struct {
+	Slot Slot
+	Used int64
+}
+
+// Remaining returns the capacity still available, never negative.
+func (a Availability) Remaining() int64 {
+	remaining := a.Slot.Capacity().Amount() - a.Used
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
@@ -0,0 +1,93 @@
+package fulfilment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlotTemplate is a recurring weekly fulfilment window from which concrete
+// slots are generated. Delivery templates belong to a delivery zone;
+// collection templates are picked up at the branch.
+type SlotTemplate struct {
+	id       uuid.UUID
+	branchID uuid.UUID
+	zoneID   *uuid.UUID
+	method   Method
+	weekday  time.Weekday
+	startsAt TimeOfDay
+	endsAt   TimeOfDay
+	capacity Capacity
+	active   bool
+}
+
+// NewSlotTemplate creates an active SlotTemplate with validation.
+func NewSlotTemplate(
+	id, branchID uuid.UUID,
+	zoneID *uuid.UUID,
+	method Method,
+	weekday time.Weekday,
+	startsAt, endsAt TimeOfDay,
+	capacity Capacity,
+) (*SlotTemplate, error) {
+	if err := validateWeekday(weekday); err != nil {
+		return nil, err
+	}
+	if endsAt.Minutes() <= startsAt.Minutes() {
+		return nil, ErrInvalidTimeRange
+	}
+	if method == MethodDelivery && zoneID == nil {
+		return nil, ErrMissingZone
+	}
+	if method == MethodCollection {
+		zoneID = nil
+	}
+
+	return &SlotTemplate{
+		id:       id,
+		branchID: branchID,
+		zoneID:   zoneID,
+		method:   method,
+		weekday:  weekday,
+		startsAt: startsAt,
+		endsAt:   endsAt,
+		capacity: capacity,
+		active:   true,
+	}, nil
+}
+
+// ReconstructSlotTemplate reconstructs a SlotTemplate from persistence without validation.
+func ReconstructSlotTemplate(
+	id, branchID uuid.UUID,
+	zoneID *uuid.UUID,
+	method Method,
+	weekday time.Weekday,
+	startsAt, endsAt TimeOfDay,
+	capacity Capacity,
+	active bool,
+) *SlotTemplate {
+	return &SlotTemplate{
+		id:       id,
+		branchID: branchID,
+		zoneID:   zoneID,
+		method:   method,
+		weekday:  weekday,
+		startsAt: startsAt,
+		endsAt:   endsAt,
+		capacity: capacity,
+		active:   active,
+	}
+}
+
+func (t *SlotTemplate) ID() uuid.UUID         { return t.id }
+func (t *SlotTemplate) BranchID() uuid.UUID   { return t.branchID }
+func (t *SlotTemplate) ZoneID() *uuid.UUID    { return t.zoneID }
+func (t *SlotTemplate) Method() Method        { return t.method }
+func (t *SlotTemplate) Weekday() time.Weekday { return t.weekday }
+func (t *SlotTemplate) StartsAt() TimeOfDay   { return t.startsAt }
+func (t *SlotTemplate) EndsAt() TimeOfDay     { return t.endsAt }
+func (t *SlotTemplate) Capacity() Capacity    { return t.capacity }
+func (t *SlotTemplate) IsActive() bool        { return t.active }
+
+// Deactivate stops the template producing new slots. Existing reservations are kept.
+func (t *SlotTemplate) Deactivate() { t.active = false }
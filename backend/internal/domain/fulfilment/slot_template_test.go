@@ -0,0 +1,67 @@
+package fulfilment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlotTemplate_Delivery_CreatesActiveTemplate(t *testing.T) {
+	id, branchID, zoneID := uuid.New(), uuid.New(), uuid.New()
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 8)
+
+	tmpl, err := fulfilment.NewSlotTemplate(id, branchID, &zoneID, fulfilment.MethodDelivery,
+		time.Friday, mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+
+	require.NoError(t, err)
+	assert.Equal(t, id, tmpl.ID())
+	assert.Equal(t, branchID, tmpl.BranchID())
+	assert.Equal(t, zoneID, *tmpl.ZoneID())
+	assert.Equal(t, fulfilment.MethodDelivery, tmpl.Method())
+	assert.Equal(t, time.Friday, tmpl.Weekday())
+	assert.Equal(t, int64(8), tmpl.Capacity().Amount())
+	assert.True(t, tmpl.IsActive())
+}
+
+func TestNewSlotTemplate_DeliveryWithoutZone_ReturnsError(t *testing.T) {
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 8)
+
+	_, err := fulfilment.NewSlotTemplate(uuid.New(), uuid.New(), nil, fulfilment.MethodDelivery,
+		time.Friday, mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+
+	assert.ErrorIs(t, err, fulfilment.ErrMissingZone)
+}
+
+func TestNewSlotTemplate_CollectionDropsZone(t *testing.T) {
+	zoneID := uuid.New()
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitKg, 40000)
+
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), uuid.New(), &zoneID, fulfilment.MethodCollection,
+		time.Friday, mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+
+	require.NoError(t, err)
+	assert.Nil(t, tmpl.ZoneID())
+}
+
+func TestNewSlotTemplate_EndBeforeStart_ReturnsError(t *testing.T) {
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 8)
+
+	_, err := fulfilment.NewSlotTemplate(uuid.New(), uuid.New(), nil, fulfilment.MethodCollection,
+		time.Friday, mustTime(t, "12:00"), mustTime(t, "12:00"), capacity)
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidTimeRange)
+}
+
+func TestSlotTemplate_Deactivate(t *testing.T) {
+	capacity, _ := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 8)
+	tmpl, _ := fulfilment.NewSlotTemplate(uuid.New(), uuid.New(), nil, fulfilment.MethodCollection,
+		time.Friday, mustTime(t, "10:00"), mustTime(t, "12:00"), capacity)
+
+	tmpl.Deactivate()
+
+	assert.False(t, tmpl.IsActive())
+}
@@ -0,0 +1,103 @@
+package fulfilment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCollectionTemplate(t *testing.T, branchID uuid.UUID, weekday time.Weekday, start, end string) *fulfilment.SlotTemplate {
+	t.Helper()
+	capacity, err := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 5)
+	require.NoError(t, err)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), branchID, nil, fulfilment.MethodCollection,
+		weekday, mustTime(t, start), mustTime(t, end), capacity)
+	require.NoError(t, err)
+	return tmpl
+}
+
+func weekdayHours(t *testing.T, branchID uuid.UUID) []*fulfilment.OpeningHours {
+	t.Helper()
+	var hours []*fulfilment.OpeningHours
+	for _, d := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday} {
+		h, err := fulfilment.NewOpeningHours(branchID, d, mustTime(t, "08:00"), mustTime(t, "18:00"))
+		require.NoError(t, err)
+		hours = append(hours, h)
+	}
+	return hours
+}
+
+func TestSchedule_Slots_GeneratesOccurrencesInOrder(t *testing.T) {
+	branchID := uuid.New()
+	monday := newCollectionTemplate(t, branchID, time.Monday, "10:00", "12:00")
+	mondayEarly := newCollectionTemplate(t, branchID, time.Monday, "08:00", "10:00")
+	schedule := fulfilment.NewSchedule(branchID, weekdayHours(t, branchID), nil)
+
+	// 2026-03-02 is a Monday; two weeks covers two Mondays.
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	slots := schedule.Slots([]*fulfilment.SlotTemplate{monday, mondayEarly}, from, 14, time.UTC)
+
+	require.Len(t, slots, 4)
+	assert.Equal(t, mondayEarly.ID(), slots[0].TemplateID())
+	assert.Equal(t, time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), slots[0].StartsAt())
+	assert.Equal(t, monday.ID(), slots[1].TemplateID())
+	assert.Equal(t, time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC), slots[3].StartsAt())
+	assert.Equal(t, time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC), slots[3].EndsAt())
+}
+
+func TestSchedule_Slots_SkipsClosures(t *testing.T) {
+	branchID := uuid.New()
+	tmpl := newCollectionTemplate(t, branchID, time.Monday, "10:00", "12:00")
+	closure := fulfilment.NewClosure(uuid.New(), branchID, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), "Holiday")
+	schedule := fulfilment.NewSchedule(branchID, weekdayHours(t, branchID), []*fulfilment.Closure{closure})
+
+	slots := schedule.Slots([]*fulfilment.SlotTemplate{tmpl}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 14, time.UTC)
+
+	require.Len(t, slots, 1)
+	assert.Equal(t, 9, slots[0].StartsAt().Day())
+}
+
+func TestSchedule_Slots_SkipsDaysWithoutOpeningHours(t *testing.T) {
+	branchID := uuid.New()
+	tmpl := newCollectionTemplate(t, branchID, time.Sunday, "10:00", "12:00")
+	schedule := fulfilment.NewSchedule(branchID, weekdayHours(t, branchID), nil)
+
+	slots := schedule.Slots([]*fulfilment.SlotTemplate{tmpl}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 14, time.UTC)
+
+	assert.Empty(t, slots)
+}
+
+func TestSchedule_Slots_SkipsTemplatesOutsideOpeningHours(t *testing.T) {
+	branchID := uuid.New()
+	tmpl := newCollectionTemplate(t, branchID, time.Monday, "17:00", "19:00")
+	schedule := fulfilment.NewSchedule(branchID, weekdayHours(t, branchID), nil)
+
+	slots := schedule.Slots([]*fulfilment.SlotTemplate{tmpl}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 7, time.UTC)
+
+	assert.Empty(t, slots)
+}
+
+func TestSchedule_Slots_SkipsInactiveAndOtherBranchTemplates(t *testing.T) {
+	branchID := uuid.New()
+	inactive := newCollectionTemplate(t, branchID, time.Monday, "10:00", "12:00")
+	inactive.Deactivate()
+	otherBranch := newCollectionTemplate(t, uuid.New(), time.Monday, "10:00", "12:00")
+	schedule := fulfilment.NewSchedule(branchID, weekdayHours(t, branchID), nil)
+
+	slots := schedule.Slots([]*fulfilment.SlotTemplate{inactive, otherBranch}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 7, time.UTC)
+
+	assert.Empty(t, slots)
+}
+
+func TestAvailability_Remaining_NeverNegative(t *testing.T) {
+	branchID := uuid.New()
+	tmpl := newCollectionTemplate(t, branchID, time.Monday, "10:00", "12:00")
+	slot := tmpl.SlotOn(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	assert.Equal(t, int64(3), fulfilment.Availability{Slot: slot, Used: 2}.Remaining())
+	assert.Equal(t, int64(0), fulfilment.Availability{Slot: slot, Used: 7}.Remaining())
+}
@@ -0,0 +1,104 @@
+package fulfilment
+
+import (
+	"fmt"
+	"time"
+)
+
+// Method is how an order reaches the customer.
+type Method string
+
+const (
+	MethodDelivery   Method = "delivery"
+	MethodCollection Method = "collection"
+)
+
+// NewMethod validates a raw fulfilment method.
+func NewMethod(raw string) (Method, error) {
+	m := Method(raw)
+	if m != MethodDelivery && m != MethodCollection {
+		return "", ErrInvalidMethod
+	}
+	return m, nil
+}
+
+func (m Method) String() string { return string(m) }
+
+// CapacityUnit is what a slot's capacity is measured in.
+type CapacityUnit string
+
+const (
+	CapacityUnitOrders CapacityUnit = "orders"
+	CapacityUnitKg     CapacityUnit = "kg"
+)
+
+// Capacity is a value object describing how much a slot can take. Kg
+// capacity is stored in grams so that reservations never deal in fractions.
+type Capacity struct {
+	unit   CapacityUnit
+	amount int64
+}
+
+// NewCapacity validates a capacity. For CapacityUnitKg the amount is in grams.
+func NewCapacity(unit CapacityUnit, amount int64) (Capacity, error) {
+	if unit != CapacityUnitOrders && unit != CapacityUnitKg {
+		return Capacity{}, ErrInvalidCapacityUnit
+	}
+	if amount <= 0 {
+		return Capacity{}, ErrInvalidCapacity
+	}
+	return Capacity{unit: unit, amount: amount}, nil
+}
+
+func (c Capacity) Unit() CapacityUnit { return c.unit }
+func (c Capacity) Amount() int64      { return c.amount }
+
+// TimeOfDay is a wall-clock time expressed as minutes since midnight.
+type TimeOfDay struct {
+	minutes int
+}
+
+// ParseTimeOfDay parses a time in HH:MM (24-hour) format.
+func ParseTimeOfDay(raw string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04", raw)
+	if err != nil {
+		return TimeOfDay{}, ErrInvalidTimeOfDay
+	}
+	return TimeOfDay{minutes: t.Hour()*60 + t.Minute()}, nil
+}
+
+// NewTimeOfDay creates a TimeOfDay from minutes since midnight.
+func NewTimeOfDay(minutes int) (TimeOfDay, error) {
+	if minutes < 0 || minutes >= 24*60 {
+		return TimeOfDay{}, ErrInvalidTimeOfDay
+	}
+	return TimeOfDay{minutes: minutes}, nil
+}
+
+func (t TimeOfDay) Minutes() int { return t.minutes }
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.minutes/60, t.minutes%60)
+}
+
+// On returns the instant at this time of day on the given date in loc.
+func (t TimeOfDay) On(date time.Time, loc *time.Location) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, t.minutes/60, t.minutes%60, 0, 0, loc)
+}
+
+// ParseDate parses a calendar date in YYYY-MM-DD format in loc.
+func ParseDate(raw string, loc *time.Location) (time.Time, error) {
+	d, err := time.ParseInLocation(time.DateOnly, raw, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return d, nil
+}
+
+func validateWeekday(weekday time.Weekday) error {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return ErrInvalidWeekday
+	}
+	return nil
+}
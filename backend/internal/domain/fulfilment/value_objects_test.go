@@ -0,0 +1,82 @@
+package fulfilment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Method ---
+
+func TestNewMethod_ValidValues_CreatesMethod(t *testing.T) {
+	m, err := fulfilment.NewMethod("delivery")
+	require.NoError(t, err)
+	assert.Equal(t, fulfilment.MethodDelivery, m)
+
+	m, err = fulfilment.NewMethod("collection")
+	require.NoError(t, err)
+	assert.Equal(t, fulfilment.MethodCollection, m)
+}
+
+func TestNewMethod_Unknown_ReturnsError(t *testing.T) {
+	_, err := fulfilment.NewMethod("drone")
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidMethod)
+}
+
+// --- Capacity ---
+
+func TestNewCapacity_ValidInputs_CreatesCapacity(t *testing.T) {
+	c, err := fulfilment.NewCapacity(fulfilment.CapacityUnitKg, 50000)
+
+	require.NoError(t, err)
+	assert.Equal(t, fulfilment.CapacityUnitKg, c.Unit())
+	assert.Equal(t, int64(50000), c.Amount())
+}
+
+func TestNewCapacity_InvalidInputs_ReturnError(t *testing.T) {
+	_, err := fulfilment.NewCapacity("boxes", 10)
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidCapacityUnit)
+
+	_, err = fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, 0)
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidCapacity)
+}
+
+// --- TimeOfDay ---
+
+func TestParseTimeOfDay_Valid_ParsesMinutes(t *testing.T) {
+	tod, err := fulfilment.ParseTimeOfDay("09:30")
+
+	require.NoError(t, err)
+	assert.Equal(t, 570, tod.Minutes())
+	assert.Equal(t, "09:30", tod.String())
+}
+
+func TestParseTimeOfDay_InvalidFormats_ReturnError(t *testing.T) {
+	tests := []string{"", "9", "24:00", "12:60", "noon"}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			_, err := fulfilment.ParseTimeOfDay(raw)
+			assert.ErrorIs(t, err, fulfilment.ErrInvalidTimeOfDay)
+		})
+	}
+}
+
+func TestTimeOfDay_On_ReturnsInstantOnDate(t *testing.T) {
+	tod, _ := fulfilment.ParseTimeOfDay("14:15")
+	date := time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC)
+
+	got := tod.On(date, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 3, 7, 14, 15, 0, 0, time.UTC), got)
+}
+
+func TestParseDate_Invalid_ReturnsError(t *testing.T) {
+	_, err := fulfilment.ParseDate("07/03/2026", time.UTC)
+
+	assert.ErrorIs(t, err, fulfilment.ErrInvalidDate)
+}
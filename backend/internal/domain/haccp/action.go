@@ -0,0 +1,41 @@
+package haccp
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CorrectiveAction is what staff did about a unit, such as moving stock to
+// another chiller or calling an engineer. It resolves AlertID, if given.
+type CorrectiveAction struct {
+	id      uuid.UUID
+	unitID  uuid.UUID
+	alertID *uuid.UUID
+	action  string
+	takenBy uuid.UUID
+	takenAt time.Time
+}
+
+// NewCorrectiveAction validates and records a corrective action.
+func NewCorrectiveAction(id, unitID uuid.UUID, alertID *uuid.UUID, action string, takenBy uuid.UUID, now time.Time) (*CorrectiveAction, error) {
+	action = strings.TrimSpace(action)
+	if action == "" {
+		return nil, ErrEmptyAction
+	}
+	return &CorrectiveAction{id: id, unitID: unitID, alertID: alertID, action: action, takenBy: takenBy, takenAt: now}, nil
+}
+
+// ReconstructCorrectiveAction reconstructs a CorrectiveAction from
+// persistence without validation.
+func ReconstructCorrectiveAction(id, unitID uuid.UUID, alertID *uuid.UUID, action string, takenBy uuid.UUID, takenAt time.Time) *CorrectiveAction {
+	return &CorrectiveAction{id: id, unitID: unitID, alertID: alertID, action: action, takenBy: takenBy, takenAt: takenAt}
+}
+
+func (c *CorrectiveAction) ID() uuid.UUID       { return c.id }
+func (c *CorrectiveAction) UnitID() uuid.UUID   { return c.unitID }
+func (c *CorrectiveAction) AlertID() *uuid.UUID { return c.alertID }
+func (c *CorrectiveAction) Action() string      { return c.action }
+func (c *CorrectiveAction) TakenBy() uuid.UUID  { return c.takenBy }
+func (c *CorrectiveAction) TakenAt() time.Time  { return c.takenAt }
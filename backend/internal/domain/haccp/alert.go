@@ -0,0 +1,89 @@
+package haccp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertKind is why an alarm was raised.
+type AlertKind string
+
+const (
+	// AlertOutOfRange is raised by a reading outside the unit's safe range.
+	AlertOutOfRange AlertKind = "out_of_range"
+	// AlertMissing is raised when a unit goes longer than it should without
+	// a reading.
+	AlertMissing AlertKind = "missing"
+)
+
+// Alert is an alarm on a unit. It stays open until a corrective action is
+// recorded against it; while it is open, the unit raises no other alarm of
+// the same kind. Out of range alerts carry the reading that raised them.
+type Alert struct {
+	id         uuid.UUID
+	unitID     uuid.UUID
+	kind       AlertKind
+	readingID  *uuid.UUID
+	celsius    *float64
+	raisedAt   time.Time
+	resolvedAt *time.Time
+	resolvedBy *uuid.UUID
+}
+
+// NewOutOfRangeAlert raises an alarm for a reading outside its unit's safe
+// range.
+func NewOutOfRangeAlert(id uuid.UUID, r *Reading, now time.Time) *Alert {
+	readingID, celsius := r.ID(), r.Celsius()
+	return &Alert{id: id, unitID: r.UnitID(), kind: AlertOutOfRange, readingID: &readingID, celsius: &celsius, raisedAt: now}
+}
+
+// NewMissingAlert raises an alarm for a unit that is overdue a reading.
+func NewMissingAlert(id, unitID uuid.UUID, now time.Time) *Alert {
+	return &Alert{id: id, unitID: unitID, kind: AlertMissing, raisedAt: now}
+}
+
+// ReconstructAlert reconstructs an Alert from persistence without
+// validation.
+func ReconstructAlert(
+	id, unitID uuid.UUID,
+	kind AlertKind,
+	readingID *uuid.UUID,
+	celsius *float64,
+	raisedAt time.Time,
+	resolvedAt *time.Time,
+	resolvedBy *uuid.UUID,
+) *Alert {
+	return &Alert{
+		id:         id,
+		unitID:     unitID,
+		kind:       kind,
+		readingID:  readingID,
+		celsius:    celsius,
+		raisedAt:   raisedAt,
+		resolvedAt: resolvedAt,
+		resolvedBy: resolvedBy,
+	}
+}
+
+func (a *Alert) ID() uuid.UUID          { return a.id }
+func (a *Alert) UnitID() uuid.UUID      { return a.unitID }
+func (a *Alert) Kind() AlertKind        { return a.kind }
+func (a *Alert) ReadingID() *uuid.UUID  { return a.readingID }
+func (a *Alert) Celsius() *float64      { return a.celsius }
+func (a *Alert) RaisedAt() time.Time    { return a.raisedAt }
+func (a *Alert) ResolvedAt() *time.Time { return a.resolvedAt }
+func (a *Alert) ResolvedBy() *uuid.UUID { return a.resolvedBy }
+
+// Open reports whether the alert is still waiting for a corrective action.
+func (a *Alert) Open() bool { return a.resolvedAt == nil }
+
+// Resolve closes the alert once by has recorded what was done about it.
+func (a *Alert) Resolve(by uuid.UUID, now time.Time) error {
+	if !a.Open() {
+		return ErrAlertResolved
+	}
+	a.resolvedAt = &now
+	a.resolvedBy = &by
+	return nil
+}
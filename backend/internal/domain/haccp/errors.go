@@ -0,0 +1,23 @@
+package haccp
+
+import "errors"
+
+var (
+	ErrBranchRequired          = errors.New("branch is required")
+	ErrEmptyUnitName           = errors.New("unit name must not be empty")
+	ErrInvalidKind             = errors.New("unit kind must be chiller, freezer or display")
+	ErrInvalidRange            = errors.New("minimum temperature must be below the maximum, both between -50 and 50 C")
+	ErrInvalidCheckInterval    = errors.New("units must be checked at least once a day, and no more than once a minute")
+	ErrUnitNotFound            = errors.New("unit not found")
+	ErrUnitInactive            = errors.New("unit is out of service")
+	ErrDuplicateUnit           = errors.New("the branch already has a unit with that name")
+	ErrDuplicateSensor         = errors.New("another unit already has that sensor")
+	ErrImplausibleTemperature  = errors.New("temperature must be between -100 and 100 C")
+	ErrReadingInFuture         = errors.New("reading must not be taken in the future")
+	ErrInvalidSensorKey        = errors.New("invalid sensor key")
+	ErrAlertNotFound           = errors.New("alert not found")
+	ErrAlertResolved           = errors.New("alert has already been resolved")
+	ErrEmptyAction             = errors.New("corrective action must be described")
+	ErrInvalidReportPeriod     = errors.New("report period must end on or after its start, and cover at most a year")
+	ErrUnsupportedReportFormat = errors.New("report format must be pdf or csv")
+)
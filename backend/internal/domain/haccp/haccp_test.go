@@ -0,0 +1,101 @@
+package haccp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChiller(t *testing.T, now time.Time) *haccp.Unit {
+	t.Helper()
+	u, err := haccp.NewUnit(uuid.New(), uuid.New(), "Walk-in chiller", haccp.KindChiller, 0, 5, "", 12*time.Hour, now)
+	require.NoError(t, err)
+	return u
+}
+
+func TestNewUnit_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name       string
+		unitName   string
+		kind       haccp.Kind
+		min, max   float64
+		checkEvery time.Duration
+		want       error
+	}{
+		{"no name", " ", haccp.KindChiller, 0, 5, time.Hour, haccp.ErrEmptyUnitName},
+		{"unknown kind", "Chiller", "fridge", 0, 5, time.Hour, haccp.ErrInvalidKind},
+		{"range reversed", "Freezer", haccp.KindFreezer, -18, -25, time.Hour, haccp.ErrInvalidRange},
+		{"range too cold", "Freezer", haccp.KindFreezer, -80, -18, time.Hour, haccp.ErrInvalidRange},
+		{"checked too rarely", "Chiller", haccp.KindChiller, 0, 5, 48 * time.Hour, haccp.ErrInvalidCheckInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := haccp.NewUnit(uuid.New(), uuid.New(), tt.unitName, tt.kind, tt.min, tt.max, "", tt.checkEvery, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestUnit_Record(t *testing.T) {
+	now := time.Now()
+	u := newChiller(t, now)
+
+	r, err := u.Record(uuid.New(), 4.96, haccp.SourceSensor, nil, "", now, now)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, r.Celsius(), "readings are kept to a tenth of a degree")
+	assert.True(t, r.InRange())
+
+	r, err = u.Record(uuid.New(), 7.2, haccp.SourceSensor, nil, "", now, now)
+	require.NoError(t, err)
+	assert.False(t, r.InRange())
+
+	_, err = u.Record(uuid.New(), 250, haccp.SourceSensor, nil, "", now, now)
+	assert.ErrorIs(t, err, haccp.ErrImplausibleTemperature)
+	_, err = u.Record(uuid.New(), 3, haccp.SourceSensor, nil, "", now.Add(time.Hour), now)
+	assert.ErrorIs(t, err, haccp.ErrReadingInFuture)
+
+	require.NoError(t, u.Update(u.Name(), u.Kind(), 0, 5, "", 12*time.Hour, false, now))
+	_, err = u.Record(uuid.New(), 3, haccp.SourceManual, nil, "", now, now)
+	assert.ErrorIs(t, err, haccp.ErrUnitInactive)
+}
+
+func TestUnit_Overdue(t *testing.T) {
+	added := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	u := newChiller(t, added)
+
+	assert.False(t, u.Overdue(nil, added.Add(11*time.Hour)))
+	assert.True(t, u.Overdue(nil, added.Add(13*time.Hour)), "units never read are counted from when they were added")
+
+	last := added.Add(10 * time.Hour)
+	assert.False(t, u.Overdue(&last, added.Add(13*time.Hour)))
+	assert.True(t, u.Overdue(&last, added.Add(23*time.Hour)))
+}
+
+func TestAlert_Resolve(t *testing.T) {
+	now := time.Now()
+	a := haccp.NewMissingAlert(uuid.New(), uuid.New(), now)
+	require.True(t, a.Open())
+
+	require.NoError(t, a.Resolve(uuid.New(), now))
+	assert.False(t, a.Open())
+	assert.ErrorIs(t, a.Resolve(uuid.New(), now), haccp.ErrAlertResolved)
+}
+
+func TestNewPeriod(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	p, err := haccp.NewPeriod(from, from.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	loc := time.FixedZone("BST", 3600)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, loc), p.Start(loc))
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, loc), p.End(loc))
+
+	_, err = haccp.NewPeriod(from, from.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, haccp.ErrInvalidReportPeriod)
+	_, err = haccp.NewPeriod(from, from.AddDate(2, 0, 0))
+	assert.ErrorIs(t, err, haccp.ErrInvalidReportPeriod)
+}
@@ -0,0 +1,45 @@
+package haccp
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notice tells a branch's staff that a unit has raised an alarm. Celsius
+// is the reading that raised an out of range alarm; LastReadingAt is when
+// an overdue unit was last read, or nil if it never has been.
+type Notice struct {
+	AlertID       uuid.UUID
+	Kind          AlertKind
+	UnitID        uuid.UUID
+	UnitName      string
+	BranchID      uuid.UUID
+	Celsius       *float64
+	MinCelsius    float64
+	MaxCelsius    float64
+	LastReadingAt *time.Time
+	RaisedAt      time.Time
+}
+
+// NewNotice describes alert on u.
+func NewNotice(a *Alert, u *Unit, lastReadingAt *time.Time) Notice {
+	return Notice{
+		AlertID:       a.ID(),
+		Kind:          a.Kind(),
+		UnitID:        u.ID(),
+		UnitName:      u.Name(),
+		BranchID:      u.BranchID(),
+		Celsius:       a.Celsius(),
+		MinCelsius:    u.MinCelsius(),
+		MaxCelsius:    u.MaxCelsius(),
+		LastReadingAt: lastReadingAt,
+		RaisedAt:      a.RaisedAt(),
+	}
+}
+
+// Notifier sends alarms to staff.
+type Notifier interface {
+	AlertRaised(ctx context.Context, n Notice) error
+}
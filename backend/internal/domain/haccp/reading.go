@@ -0,0 +1,66 @@
+package haccp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Source is how a reading was taken.
+type Source string
+
+const (
+	// SourceManual is a reading taken by hand and entered by RecordedBy.
+	SourceManual Source = "manual"
+	// SourceSensor is a reading pushed by the unit's sensor.
+	SourceSensor Source = "sensor"
+)
+
+// Reading is a unit's temperature at TakenAt. InRange is whether it was
+// within the unit's safe range when taken; later changes to the range do
+// not change it.
+type Reading struct {
+	id         uuid.UUID
+	unitID     uuid.UUID
+	celsius    float64
+	source     Source
+	recordedBy *uuid.UUID
+	note       string
+	inRange    bool
+	takenAt    time.Time
+	createdAt  time.Time
+}
+
+// ReconstructReading reconstructs a Reading from persistence without
+// validation.
+func ReconstructReading(
+	id, unitID uuid.UUID,
+	celsius float64,
+	source Source,
+	recordedBy *uuid.UUID,
+	note string,
+	inRange bool,
+	takenAt, createdAt time.Time,
+) *Reading {
+	return &Reading{
+		id:         id,
+		unitID:     unitID,
+		celsius:    celsius,
+		source:     source,
+		recordedBy: recordedBy,
+		note:       note,
+		inRange:    inRange,
+		takenAt:    takenAt,
+		createdAt:  createdAt,
+	}
+}
+
+func (r *Reading) ID() uuid.UUID          { return r.id }
+func (r *Reading) UnitID() uuid.UUID      { return r.unitID }
+func (r *Reading) Celsius() float64       { return r.celsius }
+func (r *Reading) Source() Source         { return r.source }
+func (r *Reading) RecordedBy() *uuid.UUID { return r.recordedBy }
+func (r *Reading) Note() string           { return r.note }
+func (r *Reading) InRange() bool          { return r.inRange }
+func (r *Reading) TakenAt() time.Time     { return r.takenAt }
+func (r *Reading) CreatedAt() time.Time   { return r.createdAt }
@@ -0,0 +1,79 @@
+package haccp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format is a file format reports are exported in.
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat validates a raw report format.
+func ParseFormat(raw string) (Format, error) {
+	switch f := Format(raw); f {
+	case FormatPDF, FormatCSV:
+		return f, nil
+	}
+	return "", ErrUnsupportedReportFormat
+}
+
+// maxReportDays is the longest period a report may cover.
+const maxReportDays = 366
+
+// Period is the days a report covers, From to To inclusive, as dates in
+// the shop's time zone.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewPeriod validates a report period.
+func NewPeriod(from, to time.Time) (Period, error) {
+	if to.Before(from) || to.Sub(from) >= maxReportDays*24*time.Hour {
+		return Period{}, ErrInvalidReportPeriod
+	}
+	return Period{From: from, To: to}, nil
+}
+
+// Start returns when the period's first day starts in loc.
+func (p Period) Start(loc *time.Location) time.Time {
+	return time.Date(p.From.Year(), p.From.Month(), p.From.Day(), 0, 0, 0, 0, loc)
+}
+
+// End returns when the period's last day ends in loc.
+func (p Period) End(loc *time.Location) time.Time {
+	return time.Date(p.To.Year(), p.To.Month(), p.To.Day()+1, 0, 0, 0, 0, loc)
+}
+
+// Report is the temperature record of every unit at a branch, or at every
+// branch, over a period, in the form an inspector asks for it. Staff maps
+// the admins who took readings and actions to their names.
+type Report struct {
+	Period      Period
+	BranchName  string
+	Location    *time.Location
+	Units       []UnitLog
+	Staff       map[uuid.UUID]string
+	GeneratedAt time.Time
+}
+
+// UnitLog is what happened to one unit over a report's period, each in the
+// order it happened.
+type UnitLog struct {
+	Unit       *Unit
+	BranchName string
+	Readings   []*Reading
+	Alerts     []*Alert
+	Actions    []*CorrectiveAction
+}
+
+// ReportRenderer exports a report as a file.
+type ReportRenderer interface {
+	Render(r *Report) ([]byte, error)
+}
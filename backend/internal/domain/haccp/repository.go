@@ -0,0 +1,57 @@
+package haccp
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UnitRepository provides access to units.
+type UnitRepository interface {
+	// Save inserts or updates a unit at a branch in scope. It returns
+	// ErrDuplicateUnit if the branch has another unit with its name, and
+	// ErrDuplicateSensor if another unit has its sensor.
+	Save(ctx context.Context, u *Unit) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Unit, error)
+	// FindBySensor finds the unit a sensor reads.
+	FindBySensor(ctx context.Context, sensorID string) (*Unit, error)
+	// FindAll returns the units at a branch, or at every branch in scope,
+	// by name.
+	FindAll(ctx context.Context, branchID *uuid.UUID) ([]*Unit, error)
+}
+
+// Filter narrows the records returned by LogRepository to a branch or a
+// unit, and to those from From up to but not including To. OpenOnly only
+// applies to alerts.
+type Filter struct {
+	BranchID *uuid.UUID
+	UnitID   *uuid.UUID
+	From     *time.Time
+	To       *time.Time
+	OpenOnly bool
+}
+
+// LogRepository provides access to readings, alerts and corrective actions.
+// Records of units outside the scope are not returned.
+type LogRepository interface {
+	// SaveReading stores a reading and, if alert is not nil, raises it
+	// with it. It reports whether the alert was raised: it is not while
+	// the unit has an open alert of the same kind.
+	SaveReading(ctx context.Context, r *Reading, alert *Alert) (bool, error)
+	// RaiseAlert stores an alert unless the unit has an open alert of the
+	// same kind, and reports whether it did.
+	RaiseAlert(ctx context.Context, a *Alert) (bool, error)
+	FindAlertByID(ctx context.Context, id uuid.UUID) (*Alert, error)
+	// SaveAction stores a corrective action and the alert it resolved, if
+	// any. It returns ErrAlertResolved if the alert was resolved first.
+	SaveAction(ctx context.Context, c *CorrectiveAction, resolved *Alert) error
+	// LastReadings returns when each unit that has been read was last read.
+	LastReadings(ctx context.Context) (map[uuid.UUID]time.Time, error)
+	// FindReadings returns readings by when they were taken.
+	FindReadings(ctx context.Context, f Filter) ([]*Reading, error)
+	// FindAlerts returns alerts, newest first.
+	FindAlerts(ctx context.Context, f Filter) ([]*Alert, error)
+	// FindActions returns corrective actions by when they were taken.
+	FindActions(ctx context.Context, f Filter) ([]*CorrectiveAction, error)
+}
@@ -0,0 +1,202 @@
+package haccp
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is what a unit keeps cold.
+type Kind string
+
+const (
+	KindChiller Kind = "chiller"
+	KindFreezer Kind = "freezer"
+	KindDisplay Kind = "display"
+)
+
+// ParseKind validates a raw unit kind.
+func ParseKind(raw string) (Kind, error) {
+	switch k := Kind(raw); k {
+	case KindChiller, KindFreezer, KindDisplay:
+		return k, nil
+	}
+	return "", ErrInvalidKind
+}
+
+// Limits on a unit's safe range and how often it must be checked. Paper
+// records are filled in twice a day, so once a day is the least allowed.
+const (
+	minSetting      = -50
+	maxSetting      = 50
+	minCheckEvery   = time.Minute
+	maxCheckEvery   = 24 * time.Hour
+	maxTemperature  = 100
+	futureTolerance = 5 * time.Minute
+)
+
+// Unit is a chiller, freezer or display counter at a branch whose
+// temperature is recorded for food safety inspections. Readings outside
+// its safe range raise an alarm, as does going longer than
+// CheckEvery without one. Units with a SensorID are read by a sensor that
+// pushes its readings; others are read by hand.
+type Unit struct {
+	id         uuid.UUID
+	branchID   uuid.UUID
+	name       string
+	kind       Kind
+	minCelsius float64
+	maxCelsius float64
+	sensorID   string
+	checkEvery time.Duration
+	active     bool
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// NewUnit validates and creates a unit in service.
+func NewUnit(
+	id, branchID uuid.UUID,
+	name string,
+	kind Kind,
+	minCelsius, maxCelsius float64,
+	sensorID string,
+	checkEvery time.Duration,
+	now time.Time,
+) (*Unit, error) {
+	if branchID == uuid.Nil {
+		return nil, ErrBranchRequired
+	}
+	u := &Unit{id: id, branchID: branchID, createdAt: now}
+	if err := u.Update(name, kind, minCelsius, maxCelsius, sensorID, checkEvery, true, now); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ReconstructUnit reconstructs a Unit from persistence without validation.
+func ReconstructUnit(
+	id, branchID uuid.UUID,
+	name string,
+	kind Kind,
+	minCelsius, maxCelsius float64,
+	sensorID string,
+	checkEvery time.Duration,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Unit {
+	return &Unit{
+		id:         id,
+		branchID:   branchID,
+		name:       name,
+		kind:       kind,
+		minCelsius: minCelsius,
+		maxCelsius: maxCelsius,
+		sensorID:   sensorID,
+		checkEvery: checkEvery,
+		active:     active,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (u *Unit) ID() uuid.UUID             { return u.id }
+func (u *Unit) BranchID() uuid.UUID       { return u.branchID }
+func (u *Unit) Name() string              { return u.name }
+func (u *Unit) Kind() Kind                { return u.kind }
+func (u *Unit) MinCelsius() float64       { return u.minCelsius }
+func (u *Unit) MaxCelsius() float64       { return u.maxCelsius }
+func (u *Unit) SensorID() string          { return u.sensorID }
+func (u *Unit) CheckEvery() time.Duration { return u.checkEvery }
+func (u *Unit) Active() bool              { return u.active }
+func (u *Unit) CreatedAt() time.Time      { return u.createdAt }
+func (u *Unit) UpdatedAt() time.Time      { return u.updatedAt }
+
+// Update changes the unit's details. Taking a unit out of service stops
+// its readings being taken and its missing readings being alarmed.
+func (u *Unit) Update(
+	name string,
+	kind Kind,
+	minCelsius, maxCelsius float64,
+	sensorID string,
+	checkEvery time.Duration,
+	active bool,
+	now time.Time,
+) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyUnitName
+	}
+	if _, err := ParseKind(string(kind)); err != nil {
+		return err
+	}
+	minCelsius, maxCelsius = round(minCelsius), round(maxCelsius)
+	if minCelsius >= maxCelsius || minCelsius < minSetting || maxCelsius > maxSetting {
+		return ErrInvalidRange
+	}
+	if checkEvery < minCheckEvery || checkEvery > maxCheckEvery {
+		return ErrInvalidCheckInterval
+	}
+	u.name = name
+	u.kind = kind
+	u.minCelsius = minCelsius
+	u.maxCelsius = maxCelsius
+	u.sensorID = strings.TrimSpace(sensorID)
+	u.checkEvery = checkEvery
+	u.active = active
+	u.updatedAt = now
+	return nil
+}
+
+// InRange reports whether celsius is within the unit's safe range.
+func (u *Unit) InRange(celsius float64) bool {
+	return celsius >= u.minCelsius && celsius <= u.maxCelsius
+}
+
+// Overdue reports whether the unit is in service and has gone longer than
+// CheckEvery without a reading at now. last is when it was last read, or
+// nil if it never has been, in which case time is counted from when the
+// unit was added.
+func (u *Unit) Overdue(last *time.Time, now time.Time) bool {
+	if !u.active {
+		return false
+	}
+	since := u.createdAt
+	if last != nil && last.After(since) {
+		since = *last
+	}
+	return now.Sub(since) > u.checkEvery
+}
+
+// Record takes a reading of the unit. Temperatures are kept to a tenth of
+// a degree.
+func (u *Unit) Record(id uuid.UUID, celsius float64, source Source, recordedBy *uuid.UUID, note string, takenAt, now time.Time) (*Reading, error) {
+	if !u.active {
+		return nil, ErrUnitInactive
+	}
+	if math.IsNaN(celsius) || math.Abs(celsius) > maxTemperature {
+		return nil, ErrImplausibleTemperature
+	}
+	if takenAt.After(now.Add(futureTolerance)) {
+		return nil, ErrReadingInFuture
+	}
+	celsius = round(celsius)
+	return &Reading{
+		id:         id,
+		unitID:     u.id,
+		celsius:    celsius,
+		source:     source,
+		recordedBy: recordedBy,
+		note:       strings.TrimSpace(note),
+		inRange:    u.InRange(celsius),
+		takenAt:    takenAt,
+		createdAt:  now,
+	}, nil
+}
+
+// round rounds celsius to a tenth of a degree.
+func round(celsius float64) float64 {
+	return math.Round(celsius*10) / 10
+}
@@ -0,0 +1,31 @@
+package inventory
+
+import "errors"
+
+var (
+	ErrInvalidWeight          = errors.New("weight must be greater than zero grams")
+	ErrInvalidCount           = errors.New("counted weight must not be negative")
+	ErrInvalidThreshold       = errors.New("low-stock threshold must not be negative")
+	ErrEmptyLotCode           = errors.New("lot code must not be empty")
+	ErrInvalidDate            = errors.New("date must be in YYYY-MM-DD format")
+	ErrInvalidMovementType    = errors.New("movement type must be one of received, cut, sold, wasted or adjusted")
+	ErrInvalidMovementDelta   = errors.New("movement quantity has the wrong sign for its type")
+	ErrMovementTypeNotAllowed = errors.New("movement type cannot be recorded manually")
+	ErrInsufficientStock      = errors.New("not enough stock available")
+	ErrLotNotFound            = errors.New("lot not found")
+	ErrLotBranchMismatch      = errors.New("lot belongs to a different branch")
+	ErrEmptyReservation       = errors.New("stock reservation must contain at least one line")
+	ErrReservationNotFound    = errors.New("stock reservation not found")
+	ErrReservationNotActive   = errors.New("stock reservation is no longer active")
+	ErrReservationNotHeld     = errors.New("stock reservation is not on hold")
+	ErrReservationExpired     = errors.New("stock reservation hold has expired")
+	ErrReservationNotOwned    = errors.New("stock reservation belongs to another customer")
+	ErrInvalidCost            = errors.New("cost must not be negative")
+	ErrInvalidMarkdown        = errors.New("markdown must be between 1 and 9999 basis points")
+	ErrInvalidWasteReason     = errors.New("waste reason must be one of trim, expired, damaged or returned")
+	ErrProposalNotFound       = errors.New("expiry proposal not found")
+	ErrProposalDecided        = errors.New("expiry proposal has already been accepted or dismissed")
+	ErrInvalidProposalStatus  = errors.New("proposal status must be one of proposed, accepted or dismissed")
+	ErrInvalidShrinkageGroup  = errors.New("shrinkage must be grouped by product, species or branch")
+	ErrInvalidPeriod          = errors.New("period must not end before it starts")
+)
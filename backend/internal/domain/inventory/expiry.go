@@ -0,0 +1,238 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProposalKind is what an expiry scan suggests doing with a lot.
+type ProposalKind string
+
+const (
+	ProposalMarkdown ProposalKind = "markdown"
+	ProposalWaste    ProposalKind = "waste"
+)
+
+// ProposalStatus is the lifecycle state of an expiry proposal.
+type ProposalStatus string
+
+const (
+	ProposalProposed  ProposalStatus = "proposed"
+	ProposalAccepted  ProposalStatus = "accepted"
+	ProposalDismissed ProposalStatus = "dismissed"
+)
+
+// NewProposalStatus validates a raw proposal status.
+func NewProposalStatus(raw string) (ProposalStatus, error) {
+	switch s := ProposalStatus(raw); s {
+	case ProposalProposed, ProposalAccepted, ProposalDismissed:
+		return s, nil
+	default:
+		return "", ErrInvalidProposalStatus
+	}
+}
+
+// ExpiryPolicy decides what to propose for stock near its use-by date.
+// Lots are marked down by MarkdownBP basis points over the last MarkdownDays
+// days up to and including their use-by date, and wasted after it. A zero
+// MarkdownDays turns markdowns off.
+type ExpiryPolicy struct {
+	MarkdownDays int
+	MarkdownBP   int
+}
+
+// Horizon returns the day before which a lot's use-by date must fall for
+// the policy to propose anything on today.
+func (p ExpiryPolicy) Horizon(today time.Time) time.Time {
+	return calendarDay(today).AddDate(0, 0, p.MarkdownDays)
+}
+
+// Propose returns what to do with lot on today, the shop's calendar day,
+// or nil if it can stay on sale as it is. Lots that are out of stock, have
+// no use-by date, or are already marked down at least as far are left
+// alone.
+func (p ExpiryPolicy) Propose(id uuid.UUID, lot *Lot, today, now time.Time) *ExpiryProposal {
+	if lot.onHandGrams <= 0 || lot.expiresOn == nil {
+		return nil
+	}
+
+	useBy, day := calendarDay(*lot.expiresOn), calendarDay(today)
+	var kind ProposalKind
+	switch {
+	case useBy.Before(day):
+		kind = ProposalWaste
+	case useBy.Before(p.Horizon(day)) && p.MarkdownBP > lot.markdownBP:
+		kind = ProposalMarkdown
+	default:
+		return nil
+	}
+
+	proposal := &ExpiryProposal{
+		id:          id,
+		lotID:       lot.id,
+		productID:   lot.productID,
+		branchID:    lot.branchID,
+		kind:        kind,
+		useBy:       useBy,
+		onHandGrams: lot.onHandGrams,
+		status:      ProposalProposed,
+		createdAt:   now,
+	}
+	if kind == ProposalMarkdown {
+		proposal.markdownBP = p.MarkdownBP
+	}
+	return proposal
+}
+
+// calendarDay returns t's date as midnight UTC, the form use-by dates are
+// kept in.
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ExpiryProposal is a markdown or write-off that an expiry scan suggests
+// for a lot, waiting for staff to accept or dismiss it. A lot gets at most
+// one proposal of each kind.
+type ExpiryProposal struct {
+	id          uuid.UUID
+	lotID       uuid.UUID
+	productID   uuid.UUID
+	branchID    uuid.UUID
+	kind        ProposalKind
+	useBy       time.Time
+	onHandGrams int64
+	markdownBP  int
+	status      ProposalStatus
+	wastedGrams int64
+	createdAt   time.Time
+	decidedAt   *time.Time
+	decidedBy   *uuid.UUID
+}
+
+// ReconstructExpiryProposal reconstructs an ExpiryProposal from persistence
+// without validation.
+func ReconstructExpiryProposal(
+	id, lotID, productID, branchID uuid.UUID,
+	kind ProposalKind,
+	useBy time.Time,
+	onHandGrams int64,
+	markdownBP int,
+	status ProposalStatus,
+	wastedGrams int64,
+	createdAt time.Time,
+	decidedAt *time.Time,
+	decidedBy *uuid.UUID,
+) *ExpiryProposal {
+	return &ExpiryProposal{
+		id:          id,
+		lotID:       lotID,
+		productID:   productID,
+		branchID:    branchID,
+		kind:        kind,
+		useBy:       useBy,
+		onHandGrams: onHandGrams,
+		markdownBP:  markdownBP,
+		status:      status,
+		wastedGrams: wastedGrams,
+		createdAt:   createdAt,
+		decidedAt:   decidedAt,
+		decidedBy:   decidedBy,
+	}
+}
+
+// Accept carries the proposal out on lot. A markdown reduces the lot's
+// price; a write-off returns the wasted movement for whatever is still on
+// hand, or nil if the lot has sold out since the scan.
+func (p *ExpiryProposal) Accept(lot *Lot, actorID uuid.UUID, now time.Time) (*Movement, error) {
+	if p.status != ProposalProposed {
+		return nil, ErrProposalDecided
+	}
+	if lot.id != p.lotID {
+		return nil, ErrLotNotFound
+	}
+
+	var wasted *Movement
+	switch p.kind {
+	case ProposalMarkdown:
+		if err := lot.MarkDown(p.markdownBP); err != nil {
+			return nil, err
+		}
+	case ProposalWaste:
+		if lot.onHandGrams > 0 {
+			m, err := lot.Waste(lot.onHandGrams, WasteExpired, &p.id, &actorID, now)
+			if err != nil {
+				return nil, err
+			}
+			wasted = m
+			p.wastedGrams = lot.onHandGrams
+		}
+	}
+
+	p.decide(ProposalAccepted, actorID, now)
+	return wasted, nil
+}
+
+// Dismiss leaves the lot as it is.
+func (p *ExpiryProposal) Dismiss(actorID uuid.UUID, now time.Time) error {
+	if p.status != ProposalProposed {
+		return ErrProposalDecided
+	}
+	p.decide(ProposalDismissed, actorID, now)
+	return nil
+}
+
+func (p *ExpiryProposal) decide(status ProposalStatus, actorID uuid.UUID, now time.Time) {
+	p.status = status
+	p.decidedAt = &now
+	p.decidedBy = &actorID
+}
+
+func (p *ExpiryProposal) ID() uuid.UUID          { return p.id }
+func (p *ExpiryProposal) LotID() uuid.UUID       { return p.lotID }
+func (p *ExpiryProposal) ProductID() uuid.UUID   { return p.productID }
+func (p *ExpiryProposal) BranchID() uuid.UUID    { return p.branchID }
+func (p *ExpiryProposal) Kind() ProposalKind     { return p.kind }
+func (p *ExpiryProposal) UseBy() time.Time       { return p.useBy }
+func (p *ExpiryProposal) OnHandGrams() int64     { return p.onHandGrams }
+func (p *ExpiryProposal) MarkdownBP() int        { return p.markdownBP }
+func (p *ExpiryProposal) Status() ProposalStatus { return p.status }
+func (p *ExpiryProposal) WastedGrams() int64     { return p.wastedGrams }
+func (p *ExpiryProposal) CreatedAt() time.Time   { return p.createdAt }
+func (p *ExpiryProposal) DecidedAt() *time.Time  { return p.decidedAt }
+func (p *ExpiryProposal) DecidedBy() *uuid.UUID  { return p.decidedBy }
+
+// ExpiryNotice tells a branch's staff that a lot is reaching or has passed
+// its use-by date and what the scan proposes doing with it.
+type ExpiryNotice struct {
+	ProposalID  uuid.UUID
+	Kind        ProposalKind
+	LotID       uuid.UUID
+	LotCode     string
+	ProductID   uuid.UUID
+	BranchID    uuid.UUID
+	UseBy       time.Time
+	OnHandGrams int64
+	MarkdownBP  int
+}
+
+// NewExpiryNotice describes proposal p for lot.
+func NewExpiryNotice(p *ExpiryProposal, lot *Lot) ExpiryNotice {
+	return ExpiryNotice{
+		ProposalID:  p.id,
+		Kind:        p.kind,
+		LotID:       lot.id,
+		LotCode:     lot.code,
+		ProductID:   lot.productID,
+		BranchID:    lot.branchID,
+		UseBy:       p.useBy,
+		OnHandGrams: p.onHandGrams,
+		MarkdownBP:  p.markdownBP,
+	}
+}
+
+// Notifier sends use-by alerts to staff.
+type Notifier interface {
+	UseByReached(ctx context.Context, n ExpiryNotice) error
+}
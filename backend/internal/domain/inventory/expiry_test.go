@@ -0,0 +1,124 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiryPolicy_Propose(t *testing.T) {
+	policy := inventory.ExpiryPolicy{MarkdownDays: 2, MarkdownBP: 3000}
+	// Late evening in the shop's time zone is still the 10th.
+	today := time.Date(2026, 11, 10, 22, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		useBy *time.Time
+		want  inventory.ProposalKind
+	}{
+		{"past its use-by date", date(9), inventory.ProposalWaste},
+		{"on its use-by date", date(10), inventory.ProposalMarkdown},
+		{"the day before its use-by date", date(11), inventory.ProposalMarkdown},
+		{"beyond the markdown days", date(12), ""},
+		{"without a use-by date", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lot := newLot(t, 2400, tt.useBy, now)
+
+			p := policy.Propose(uuid.New(), lot, today, now)
+
+			if tt.want == "" {
+				assert.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			assert.Equal(t, tt.want, p.Kind())
+			assert.Equal(t, inventory.ProposalProposed, p.Status())
+			assert.Equal(t, int64(2400), p.OnHandGrams())
+			assert.Equal(t, *tt.useBy, p.UseBy())
+		})
+	}
+
+	t.Run("a lot already marked down is not marked down again", func(t *testing.T) {
+		lot := newLot(t, 2400, date(10), now)
+		require.NoError(t, lot.MarkDown(3000))
+
+		assert.Nil(t, policy.Propose(uuid.New(), lot, today, now))
+	})
+
+	t.Run("without markdown days only expired stock is proposed", func(t *testing.T) {
+		off := inventory.ExpiryPolicy{}
+		assert.Nil(t, off.Propose(uuid.New(), newLot(t, 2400, date(10), now), today, now))
+		assert.NotNil(t, off.Propose(uuid.New(), newLot(t, 2400, date(9), now), today, now))
+	})
+
+	assert.Equal(t, *date(12), policy.Horizon(today))
+}
+
+func TestExpiryProposal_Accept(t *testing.T) {
+	policy := inventory.ExpiryPolicy{MarkdownDays: 1, MarkdownBP: 2500}
+	today := *date(10)
+	actor := uuid.New()
+
+	t.Run("markdown reduces the lot's price", func(t *testing.T) {
+		lot := newLot(t, 2400, date(10), today)
+		p := policy.Propose(uuid.New(), lot, today, today)
+		require.NotNil(t, p)
+
+		wasted, err := p.Accept(lot, actor, time.Now())
+
+		require.NoError(t, err)
+		assert.Nil(t, wasted)
+		assert.Equal(t, 2500, lot.MarkdownBP())
+		assert.Equal(t, inventory.ProposalAccepted, p.Status())
+		assert.Equal(t, &actor, p.DecidedBy())
+	})
+
+	t.Run("write-off wastes what is left as expired", func(t *testing.T) {
+		lot := newLot(t, 2400, date(9), today)
+		p := policy.Propose(uuid.New(), lot, today, today)
+		require.NotNil(t, p)
+
+		wasted, err := p.Accept(lot, actor, time.Now())
+
+		require.NoError(t, err)
+		require.NotNil(t, wasted)
+		assert.Equal(t, int64(-2400), wasted.DeltaGrams())
+		assert.Equal(t, "expired", wasted.Reason())
+		assert.Equal(t, p.ID(), *wasted.ReferenceID())
+		assert.Equal(t, int64(2400), p.WastedGrams())
+
+		_, err = p.Accept(lot, actor, time.Now())
+		assert.ErrorIs(t, err, inventory.ErrProposalDecided)
+		assert.ErrorIs(t, p.Dismiss(actor, time.Now()), inventory.ErrProposalDecided)
+	})
+
+	t.Run("write-off of a lot sold out since the scan wastes nothing", func(t *testing.T) {
+		lot := newLot(t, 2400, date(9), today)
+		p := policy.Propose(uuid.New(), lot, today, today)
+		require.NotNil(t, p)
+		sold := inventory.ReconstructLot(lot.ID(), lot.ProductID(), lot.BranchID(), lot.Code(), 2400, 0, lot.ExpiresOn(), 0, 0, today)
+
+		wasted, err := p.Accept(sold, actor, time.Now())
+
+		require.NoError(t, err)
+		assert.Nil(t, wasted)
+		assert.Equal(t, inventory.ProposalAccepted, p.Status())
+	})
+
+	t.Run("dismissal leaves the lot alone", func(t *testing.T) {
+		lot := newLot(t, 2400, date(9), today)
+		p := policy.Propose(uuid.New(), lot, today, today)
+		require.NotNil(t, p)
+
+		require.NoError(t, p.Dismiss(actor, time.Now()))
+		assert.Equal(t, inventory.ProposalDismissed, p.Status())
+		assert.Equal(t, int64(2400), lot.OnHandGrams())
+	})
+}
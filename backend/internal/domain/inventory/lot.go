@@ -0,0 +1,205 @@
+package inventory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lot is a batch of one product received into one branch, such as a delivery
+// from a single supplier or a single carcass. expiresOn is its use-by date.
+// costPerKgCents is what the stock cost, so that losses can be valued, and
+// markdownBP is how far its selling price has been reduced as it nears its
+// use-by date.
+type Lot struct {
+	id             uuid.UUID
+	productID      uuid.UUID
+	branchID       uuid.UUID
+	code           string
+	receivedGrams  int64
+	onHandGrams    int64
+	expiresOn      *time.Time
+	costPerKgCents int64
+	markdownBP     int
+	receivedAt     time.Time
+}
+
+// ReceiveLot creates a lot and the ledger entry that brings its stock in.
+func ReceiveLot(id, productID, branchID uuid.UUID, code string, grams int64, expiresOn *time.Time, actorID *uuid.UUID, now time.Time) (*Lot, *Movement, error) {
+	return receiveLot(id, productID, branchID, code, grams, expiresOn, "", nil, actorID, now)
+}
+
+// ReceiveLotFrom is ReceiveLot for stock with a recorded source, such as cuts
+// from a carcass breakdown or a delivery against a purchase order. The
+// received movement carries reason and references the source.
+func ReceiveLotFrom(id, productID, branchID uuid.UUID, code string, grams int64, expiresOn *time.Time, reason string, sourceID uuid.UUID, actorID *uuid.UUID, now time.Time) (*Lot, *Movement, error) {
+	return receiveLot(id, productID, branchID, code, grams, expiresOn, reason, &sourceID, actorID, now)
+}
+
+func receiveLot(id, productID, branchID uuid.UUID, code string, grams int64, expiresOn *time.Time, reason string, referenceID, actorID *uuid.UUID, now time.Time) (*Lot, *Movement, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, nil, ErrEmptyLotCode
+	}
+	if grams <= 0 {
+		return nil, nil, ErrInvalidWeight
+	}
+
+	lot := &Lot{
+		id:            id,
+		productID:     productID,
+		branchID:      branchID,
+		code:          code,
+		receivedGrams: grams,
+		onHandGrams:   grams,
+		expiresOn:     expiresOn,
+		receivedAt:    now,
+	}
+	m, err := NewMovement(uuid.New(), lot, MovementReceived, grams, reason, referenceID, actorID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lot, m, nil
+}
+
+// ReconstructLot reconstructs a Lot from persistence without validation.
+func ReconstructLot(
+	id, productID, branchID uuid.UUID,
+	code string,
+	receivedGrams, onHandGrams int64,
+	expiresOn *time.Time,
+	costPerKgCents int64,
+	markdownBP int,
+	receivedAt time.Time,
+) *Lot {
+	return &Lot{
+		id:             id,
+		productID:      productID,
+		branchID:       branchID,
+		code:           code,
+		receivedGrams:  receivedGrams,
+		onHandGrams:    onHandGrams,
+		expiresOn:      expiresOn,
+		costPerKgCents: costPerKgCents,
+		markdownBP:     markdownBP,
+		receivedAt:     receivedAt,
+	}
+}
+
+// Count is the weight counted for one lot during a stock take.
+type Count struct {
+	LotID        uuid.UUID
+	CountedGrams int64
+}
+
+// Count returns the adjustment that brings the lot's on-hand stock to the
+// weight counted during a stock take, or nil if the count matches.
+func (l *Lot) Count(countedGrams int64, actorID *uuid.UUID, now time.Time) (*Movement, error) {
+	if countedGrams < 0 {
+		return nil, ErrInvalidCount
+	}
+	delta := countedGrams - l.onHandGrams
+	if delta == 0 {
+		return nil, nil
+	}
+	return NewMovement(uuid.New(), l, MovementAdjusted, delta, "stock take", nil, actorID, now)
+}
+
+// SetCost records what the lot cost per kilogram.
+func (l *Lot) SetCost(perKgCents int64) error {
+	if perKgCents < 0 {
+		return ErrInvalidCost
+	}
+	l.costPerKgCents = perKgCents
+	return nil
+}
+
+// CostOf returns what grams of the lot cost, rounded to the nearest cent.
+func (l *Lot) CostOf(grams int64) int64 {
+	return (grams*l.costPerKgCents + 500) / 1000
+}
+
+// SetUseBy changes the lot's use-by date, or clears it when useBy is nil.
+func (l *Lot) SetUseBy(useBy *time.Time) {
+	l.expiresOn = useBy
+}
+
+// MarkDown reduces the lot's selling price by bp basis points.
+func (l *Lot) MarkDown(bp int) error {
+	if bp <= 0 || bp >= 10000 {
+		return ErrInvalidMarkdown
+	}
+	l.markdownBP = bp
+	return nil
+}
+
+// MarkedDown returns priceCents less the lot's markdown, rounded to the
+// nearest cent.
+func (l *Lot) MarkedDown(priceCents int64) int64 {
+	if l.markdownBP == 0 {
+		return priceCents
+	}
+	return (priceCents*int64(10000-l.markdownBP) + 5000) / 10000
+}
+
+func (l *Lot) ID() uuid.UUID         { return l.id }
+func (l *Lot) ProductID() uuid.UUID  { return l.productID }
+func (l *Lot) BranchID() uuid.UUID   { return l.branchID }
+func (l *Lot) Code() string          { return l.code }
+func (l *Lot) ReceivedGrams() int64  { return l.receivedGrams }
+func (l *Lot) OnHandGrams() int64    { return l.onHandGrams }
+func (l *Lot) ExpiresOn() *time.Time { return l.expiresOn }
+func (l *Lot) CostPerKgCents() int64 { return l.costPerKgCents }
+func (l *Lot) MarkdownBP() int       { return l.markdownBP }
+func (l *Lot) ReceivedAt() time.Time { return l.receivedAt }
+
+// Allocation is the part of a sale taken from one lot.
+type Allocation struct {
+	Lot   *Lot
+	Grams int64
+}
+
+// AllocateFEFO picks stock for a sale first-expired-first-out: lots with the
+// earliest expiry are used first, lots without an expiry last, and ties go to
+// the oldest receipt.
+func AllocateFEFO(lots []*Lot, grams int64) ([]Allocation, error) {
+	if grams <= 0 {
+		return nil, ErrInvalidWeight
+	}
+
+	ordered := make([]*Lot, len(lots))
+	copy(ordered, lots)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		switch {
+		case a.expiresOn == nil && b.expiresOn != nil:
+			return false
+		case a.expiresOn != nil && b.expiresOn == nil:
+			return true
+		case a.expiresOn != nil && !a.expiresOn.Equal(*b.expiresOn):
+			return a.expiresOn.Before(*b.expiresOn)
+		default:
+			return a.receivedAt.Before(b.receivedAt)
+		}
+	})
+
+	var allocations []Allocation
+	remaining := grams
+	for _, lot := range ordered {
+		if remaining == 0 {
+			break
+		}
+		if lot.onHandGrams <= 0 {
+			continue
+		}
+		take := min(lot.onHandGrams, remaining)
+		allocations = append(allocations, Allocation{Lot: lot, Grams: take})
+		remaining -= take
+	}
+	if remaining > 0 {
+		return nil, ErrInsufficientStock
+	}
+	return allocations, nil
+}
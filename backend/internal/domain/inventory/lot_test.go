@@ -0,0 +1,144 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLot(t *testing.T, grams int64, expiresOn *time.Time, receivedAt time.Time) *inventory.Lot {
+	t.Helper()
+	lot, _, err := inventory.ReceiveLot(uuid.New(), uuid.New(), uuid.New(), "LOT-1", grams, expiresOn, nil, receivedAt)
+	require.NoError(t, err)
+	return lot
+}
+
+func date(day int) *time.Time {
+	d := time.Date(2026, 11, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestReceiveLot_CreatesLotAndReceivedMovement(t *testing.T) {
+	actor := uuid.New()
+	now := time.Now()
+
+	lot, m, err := inventory.ReceiveLot(uuid.New(), uuid.New(), uuid.New(), " HAL-0412 ", 12500, date(20), &actor, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "HAL-0412", lot.Code())
+	assert.Equal(t, int64(12500), lot.OnHandGrams())
+	assert.Equal(t, int64(12500), lot.ReceivedGrams())
+	assert.Equal(t, inventory.MovementReceived, m.Type())
+	assert.Equal(t, int64(12500), m.DeltaGrams())
+	assert.Equal(t, lot.ID(), m.LotID())
+	assert.Equal(t, &actor, m.ActorID())
+}
+
+func TestReceiveLotFrom_ReferencesSource(t *testing.T) {
+	sourceID := uuid.New()
+
+	_, m, err := inventory.ReceiveLotFrom(uuid.New(), uuid.New(), uuid.New(), "KILL-77", 8000, nil, "carcass breakdown", sourceID, nil, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "carcass breakdown", m.Reason())
+	require.NotNil(t, m.ReferenceID())
+	assert.Equal(t, sourceID, *m.ReferenceID())
+}
+
+func TestReceiveLot_InvalidInputs_ReturnError(t *testing.T) {
+	_, _, err := inventory.ReceiveLot(uuid.New(), uuid.New(), uuid.New(), "", 100, nil, nil, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrEmptyLotCode)
+
+	_, _, err = inventory.ReceiveLot(uuid.New(), uuid.New(), uuid.New(), "LOT", 0, nil, nil, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrInvalidWeight)
+}
+
+func TestLot_Count(t *testing.T) {
+	lot := newLot(t, 5000, nil, time.Now())
+
+	t.Run("shortfall records negative adjustment", func(t *testing.T) {
+		m, err := lot.Count(4750, nil, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, inventory.MovementAdjusted, m.Type())
+		assert.Equal(t, int64(-250), m.DeltaGrams())
+		assert.Equal(t, "stock take", m.Reason())
+	})
+
+	t.Run("surplus records positive adjustment", func(t *testing.T) {
+		m, err := lot.Count(5100, nil, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), m.DeltaGrams())
+	})
+
+	t.Run("matching count records nothing", func(t *testing.T) {
+		m, err := lot.Count(5000, nil, time.Now())
+
+		require.NoError(t, err)
+		assert.Nil(t, m)
+	})
+
+	t.Run("negative count is rejected", func(t *testing.T) {
+		_, err := lot.Count(-1, nil, time.Now())
+
+		assert.ErrorIs(t, err, inventory.ErrInvalidCount)
+	})
+}
+
+func TestLot_CostAndMarkdown(t *testing.T) {
+	lot := newLot(t, 5000, nil, time.Now())
+
+	require.NoError(t, lot.SetCost(1250))
+	assert.Equal(t, int64(1563), lot.CostOf(1250), "costs round to the nearest cent")
+	assert.ErrorIs(t, lot.SetCost(-1), inventory.ErrInvalidCost)
+
+	assert.Equal(t, int64(1624), lot.MarkedDown(1624), "a lot not marked down sells at full price")
+	require.NoError(t, lot.MarkDown(3000))
+	assert.Equal(t, int64(1137), lot.MarkedDown(1624))
+	assert.ErrorIs(t, lot.MarkDown(0), inventory.ErrInvalidMarkdown)
+	assert.ErrorIs(t, lot.MarkDown(10000), inventory.ErrInvalidMarkdown)
+	assert.Equal(t, 3000, lot.MarkdownBP())
+}
+
+func TestAllocateFEFO(t *testing.T) {
+	now := time.Now()
+	noExpiry := newLot(t, 1000, nil, now.Add(-72*time.Hour))
+	late := newLot(t, 1000, date(25), now.Add(-48*time.Hour))
+	early := newLot(t, 600, date(20), now)
+	empty := newLot(t, 1000, date(10), now)
+	emptyLot := inventory.ReconstructLot(empty.ID(), empty.ProductID(), empty.BranchID(), "EMPTY", 1000, 0, empty.ExpiresOn(), 0, 0, now)
+
+	lots := []*inventory.Lot{noExpiry, late, emptyLot, early}
+
+	t.Run("earliest expiry first, no expiry last", func(t *testing.T) {
+		allocations, err := inventory.AllocateFEFO(lots, 2000)
+
+		require.NoError(t, err)
+		require.Len(t, allocations, 3)
+		assert.Equal(t, early.ID(), allocations[0].Lot.ID())
+		assert.Equal(t, int64(600), allocations[0].Grams)
+		assert.Equal(t, late.ID(), allocations[1].Lot.ID())
+		assert.Equal(t, int64(1000), allocations[1].Grams)
+		assert.Equal(t, noExpiry.ID(), allocations[2].Lot.ID())
+		assert.Equal(t, int64(400), allocations[2].Grams)
+	})
+
+	t.Run("small sale comes from one lot", func(t *testing.T) {
+		allocations, err := inventory.AllocateFEFO(lots, 250)
+
+		require.NoError(t, err)
+		require.Len(t, allocations, 1)
+		assert.Equal(t, early.ID(), allocations[0].Lot.ID())
+	})
+
+	t.Run("not enough stock", func(t *testing.T) {
+		_, err := inventory.AllocateFEFO(lots, 2601)
+
+		assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+	})
+}
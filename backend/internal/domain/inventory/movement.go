@@ -0,0 +1,120 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovementType classifies an entry in the stock ledger.
+type MovementType string
+
+const (
+	MovementReceived MovementType = "received"
+	MovementCut      MovementType = "cut"
+	MovementSold     MovementType = "sold"
+	MovementWasted   MovementType = "wasted"
+	MovementAdjusted MovementType = "adjusted"
+)
+
+// NewMovementType validates a raw movement type.
+func NewMovementType(raw string) (MovementType, error) {
+	switch t := MovementType(raw); t {
+	case MovementReceived, MovementCut, MovementSold, MovementWasted, MovementAdjusted:
+		return t, nil
+	default:
+		return "", ErrInvalidMovementType
+	}
+}
+
+// IsManual reports whether staff may record the movement directly. Receipts
+// come from creating a lot and sales from fulfilling a reservation.
+func (t MovementType) IsManual() bool {
+	return t == MovementCut || t == MovementWasted || t == MovementAdjusted
+}
+
+// validDelta reports whether deltaGrams has the sign the type requires.
+// Cutting can both consume a primal and produce cuts, so either sign is allowed.
+func (t MovementType) validDelta(deltaGrams int64) bool {
+	switch t {
+	case MovementReceived:
+		return deltaGrams > 0
+	case MovementSold, MovementWasted:
+		return deltaGrams < 0
+	default:
+		return deltaGrams != 0
+	}
+}
+
+// Movement is an immutable entry in the stock ledger. On-hand stock for a lot
+// is the sum of its movements.
+type Movement struct {
+	id          uuid.UUID
+	lotID       uuid.UUID
+	productID   uuid.UUID
+	branchID    uuid.UUID
+	kind        MovementType
+	deltaGrams  int64
+	reason      string
+	referenceID *uuid.UUID
+	actorID     *uuid.UUID
+	occurredAt  time.Time
+}
+
+// NewMovement creates a ledger entry against lot. referenceID links the entry
+// to what caused it, such as an order; actorID is the staff member, if any.
+func NewMovement(id uuid.UUID, lot *Lot, kind MovementType, deltaGrams int64, reason string, referenceID, actorID *uuid.UUID, now time.Time) (*Movement, error) {
+	if _, err := NewMovementType(string(kind)); err != nil {
+		return nil, err
+	}
+	if !kind.validDelta(deltaGrams) {
+		return nil, ErrInvalidMovementDelta
+	}
+
+	return &Movement{
+		id:          id,
+		lotID:       lot.id,
+		productID:   lot.productID,
+		branchID:    lot.branchID,
+		kind:        kind,
+		deltaGrams:  deltaGrams,
+		reason:      reason,
+		referenceID: referenceID,
+		actorID:     actorID,
+		occurredAt:  now,
+	}, nil
+}
+
+// ReconstructMovement reconstructs a Movement from persistence without validation.
+func ReconstructMovement(
+	id, lotID, productID, branchID uuid.UUID,
+	kind MovementType,
+	deltaGrams int64,
+	reason string,
+	referenceID, actorID *uuid.UUID,
+	occurredAt time.Time,
+) *Movement {
+	return &Movement{
+		id:          id,
+		lotID:       lotID,
+		productID:   productID,
+		branchID:    branchID,
+		kind:        kind,
+		deltaGrams:  deltaGrams,
+		reason:      reason,
+		referenceID: referenceID,
+		actorID:     actorID,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (m *Movement) ID() uuid.UUID           { return m.id }
+func (m *Movement) LotID() uuid.UUID        { return m.lotID }
+func (m *Movement) ProductID() uuid.UUID    { return m.productID }
+func (m *Movement) BranchID() uuid.UUID     { return m.branchID }
+func (m *Movement) Type() MovementType      { return m.kind }
+func (m *Movement) DeltaGrams() int64       { return m.deltaGrams }
+func (m *Movement) Reason() string          { return m.reason }
+func (m *Movement) ReferenceID() *uuid.UUID { return m.referenceID }
+func (m *Movement) ActorID() *uuid.UUID     { return m.actorID }
+func (m *Movement) OccurredAt() time.Time   { return m.occurredAt }
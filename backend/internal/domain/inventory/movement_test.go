@@ -0,0 +1,57 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMovementType(t *testing.T) {
+	mt, err := inventory.NewMovementType("wasted")
+	require.NoError(t, err)
+	assert.Equal(t, inventory.MovementWasted, mt)
+	assert.True(t, mt.IsManual())
+	assert.False(t, inventory.MovementSold.IsManual())
+	assert.False(t, inventory.MovementReceived.IsManual())
+
+	_, err = inventory.NewMovementType("stolen")
+	assert.ErrorIs(t, err, inventory.ErrInvalidMovementType)
+}
+
+func TestNewMovement_SignMustMatchType(t *testing.T) {
+	lot := newLot(t, 5000, nil, time.Now())
+
+	tests := []struct {
+		name  string
+		kind  inventory.MovementType
+		delta int64
+		valid bool
+	}{
+		{"received positive", inventory.MovementReceived, 100, true},
+		{"received negative", inventory.MovementReceived, -100, false},
+		{"sold negative", inventory.MovementSold, -100, true},
+		{"sold positive", inventory.MovementSold, 100, false},
+		{"wasted positive", inventory.MovementWasted, 100, false},
+		{"cut either way", inventory.MovementCut, 100, true},
+		{"cut consuming", inventory.MovementCut, -100, true},
+		{"adjusted zero", inventory.MovementAdjusted, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := inventory.NewMovement(uuid.New(), lot, tt.kind, tt.delta, "", nil, nil, time.Now())
+			if tt.valid {
+				require.NoError(t, err)
+				assert.Equal(t, lot.ID(), m.LotID())
+				assert.Equal(t, lot.ProductID(), m.ProductID())
+				assert.Equal(t, tt.delta, m.DeltaGrams())
+			} else {
+				assert.ErrorIs(t, err, inventory.ErrInvalidMovementDelta)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovementFilter narrows a ledger query. Nil fields are not filtered on.
+type MovementFilter struct {
+	LotID     *uuid.UUID
+	ProductID *uuid.UUID
+	BranchID  *uuid.UUID
+	Limit     int
+}
+
+// StockRepository provides access to lots, the stock ledger and stock settings.
+type StockRepository interface {
+	// ReceiveLot stores a new lot together with its received movement.
+	ReceiveLot(ctx context.Context, lot *Lot, received *Movement) error
+	FindLotByID(ctx context.Context, id uuid.UUID) (*Lot, error)
+	FindLots(ctx context.Context, productID, branchID uuid.UUID) ([]*Lot, error)
+	// RecordMovements appends movements to the ledger and applies them to
+	// their lots in one transaction. It returns ErrInsufficientStock if any
+	// lot would go below zero.
+	RecordMovements(ctx context.Context, movements []*Movement) error
+	// TakeStock locks the counted lots at a branch, works out each one's
+	// adjustment from its on-hand stock as it stands and records them, in
+	// one transaction, so stock sold or cut while the count was made is not
+	// counted twice. It returns the adjustments; lots whose count matches
+	// get none. It returns ErrLotBranchMismatch if a lot is elsewhere.
+	TakeStock(ctx context.Context, branchID uuid.UUID, counts []Count, actorID *uuid.UUID, now time.Time) ([]*Movement, error)
+	// FindMovements returns ledger entries, newest first.
+	FindMovements(ctx context.Context, filter MovementFilter) ([]*Movement, error)
+	// StockLevels returns per-product stock for a branch, or for all branches
+	// when branchID is nil. Reservations are counted as active as of now.
+	StockLevels(ctx context.Context, branchID *uuid.UUID, now time.Time) ([]StockLevel, error)
+	SetLowStockThreshold(ctx context.Context, productID uuid.UUID, grams int64) error
+}
+
+// ReservationRepository provides access to stock reservations.
+type ReservationRepository interface {
+	// Reserve stores the reservation if every line fits in the stock that is
+	// available as of now, and returns ErrInsufficientStock otherwise. It
+	// must be safe against concurrent reservations of the same products.
+	Reserve(ctx context.Context, r *Reservation, now time.Time) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Reservation, error)
+	// FindByOrder returns the reservations confirmed for an order, whatever
+	// has happened to them since.
+	FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*Reservation, error)
+	Update(ctx context.Context, r *Reservation) error
+	// Fulfil stores a fulfilled reservation and records sold movements for
+	// its lines, taking stock from lots first-expired-first-out.
+	Fulfil(ctx context.Context, r *Reservation, actorID *uuid.UUID, now time.Time) error
+}
+
+// ProposalFilter narrows an expiry proposal query. Nil fields are not
+// filtered on.
+type ProposalFilter struct {
+	Status   *ProposalStatus
+	BranchID *uuid.UUID
+}
+
+// WasteRepository provides access to use-by dates, expiry proposals and the
+// ledger totals shrinkage is reported from.
+type WasteRepository interface {
+	// ExpiringLots returns lots with stock on hand whose use-by date is
+	// before the given day.
+	ExpiringLots(ctx context.Context, before time.Time) ([]*Lot, error)
+	// UpdateLot stores a lot's use-by date, cost and markdown.
+	UpdateLot(ctx context.Context, lot *Lot) error
+	// SaveProposal stores a new proposal and reports whether it was stored;
+	// it is not if the lot already has a proposal of the same kind.
+	SaveProposal(ctx context.Context, p *ExpiryProposal) (bool, error)
+	FindProposalByID(ctx context.Context, id uuid.UUID) (*ExpiryProposal, error)
+	// FindProposals returns proposals matching filter, newest first.
+	FindProposals(ctx context.Context, filter ProposalFilter) ([]*ExpiryProposal, error)
+	// DecideProposal stores an accepted or dismissed proposal together with
+	// the lot's markdown and the movement that wasted it, if any, in one
+	// transaction. It returns ErrProposalDecided if the proposal was decided
+	// in the meantime.
+	DecideProposal(ctx context.Context, p *ExpiryProposal, lot *Lot, wasted *Movement) error
+	// LedgerTotals sums the ledger by product, branch, species, type and
+	// reason.
+	LedgerTotals(ctx context.Context, filter LedgerFilter) ([]LedgerTotal, error)
+}
@@ -0,0 +1,163 @@
+package inventory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationStatus is the lifecycle state of a stock reservation.
+type ReservationStatus string
+
+const (
+	ReservationHeld      ReservationStatus = "held"
+	ReservationConfirmed ReservationStatus = "confirmed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationFulfilled ReservationStatus = "fulfilled"
+)
+
+// ReservationLine is the weight of one product held by a reservation.
+type ReservationLine struct {
+	ProductID uuid.UUID
+	Grams     int64
+}
+
+// Reservation is a soft claim on stock made at checkout. It does not move
+// stock between lots; it only lowers what is available to other customers
+// until it is released, lapses, or is fulfilled as a sale.
+type Reservation struct {
+	id         uuid.UUID
+	branchID   uuid.UUID
+	customerID uuid.UUID
+	lines      []ReservationLine
+	status     ReservationStatus
+	orderID    *uuid.UUID
+	expiresAt  time.Time
+	createdAt  time.Time
+}
+
+// NewReservation places a hold on stock. Lines for the same product are merged
+// and sorted by product so that stock rows are always locked in the same order.
+func NewReservation(id, branchID, customerID uuid.UUID, lines []ReservationLine, holdTTL time.Duration, now time.Time) (*Reservation, error) {
+	if len(lines) == 0 {
+		return nil, ErrEmptyReservation
+	}
+
+	byProduct := make(map[uuid.UUID]int64, len(lines))
+	for _, l := range lines {
+		if l.Grams <= 0 {
+			return nil, ErrInvalidWeight
+		}
+		byProduct[l.ProductID] += l.Grams
+	}
+	merged := make([]ReservationLine, 0, len(byProduct))
+	for productID, grams := range byProduct {
+		merged = append(merged, ReservationLine{ProductID: productID, Grams: grams})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].ProductID.String() < merged[j].ProductID.String()
+	})
+
+	return &Reservation{
+		id:         id,
+		branchID:   branchID,
+		customerID: customerID,
+		lines:      merged,
+		status:     ReservationHeld,
+		expiresAt:  now.Add(holdTTL),
+		createdAt:  now,
+	}, nil
+}
+
+// ReconstructReservation reconstructs a Reservation from persistence without validation.
+func ReconstructReservation(
+	id, branchID, customerID uuid.UUID,
+	lines []ReservationLine,
+	status ReservationStatus,
+	orderID *uuid.UUID,
+	expiresAt, createdAt time.Time,
+) *Reservation {
+	return &Reservation{
+		id:         id,
+		branchID:   branchID,
+		customerID: customerID,
+		lines:      lines,
+		status:     status,
+		orderID:    orderID,
+		expiresAt:  expiresAt,
+		createdAt:  createdAt,
+	}
+}
+
+func (r *Reservation) ID() uuid.UUID             { return r.id }
+func (r *Reservation) BranchID() uuid.UUID       { return r.branchID }
+func (r *Reservation) CustomerID() uuid.UUID     { return r.customerID }
+func (r *Reservation) Lines() []ReservationLine  { return r.lines }
+func (r *Reservation) Status() ReservationStatus { return r.status }
+func (r *Reservation) OrderID() *uuid.UUID       { return r.orderID }
+func (r *Reservation) ExpiresAt() time.Time      { return r.expiresAt }
+func (r *Reservation) CreatedAt() time.Time      { return r.createdAt }
+
+// IsActive reports whether the reservation still holds back stock.
+func (r *Reservation) IsActive(now time.Time) bool {
+	switch r.status {
+	case ReservationConfirmed:
+		return true
+	case ReservationHeld:
+		return now.Before(r.expiresAt)
+	default:
+		return false
+	}
+}
+
+// Confirm converts a live hold into a reservation for a placed order, which
+// no longer lapses.
+func (r *Reservation) Confirm(orderID uuid.UUID, now time.Time) error {
+	if r.status != ReservationHeld {
+		return ErrReservationNotHeld
+	}
+	if !now.Before(r.expiresAt) {
+		return ErrReservationExpired
+	}
+	r.status = ReservationConfirmed
+	r.orderID = &orderID
+	return nil
+}
+
+// Release gives held stock back. Releasing twice is a no-op; stock
+// confirmed for an order cannot be released, as the order still needs it.
+func (r *Reservation) Release() error {
+	switch r.status {
+	case ReservationHeld:
+		r.status = ReservationReleased
+	case ReservationReleased:
+	default:
+		return ErrReservationNotHeld
+	}
+	return nil
+}
+
+// Cancel gives stock back when the order it was confirmed for is
+// cancelled. A hold not yet confirmed is released as well; stock already
+// sold cannot be given back.
+func (r *Reservation) Cancel() error {
+	switch r.status {
+	case ReservationHeld, ReservationConfirmed:
+		r.status = ReservationReleased
+	case ReservationReleased:
+	default:
+		return ErrReservationNotHeld
+	}
+	return nil
+}
+
+// Fulfil marks the reserved stock as sold. The caller records the matching
+// sold movements.
+func (r *Reservation) Fulfil(now time.Time) error {
+	if !r.IsActive(now) {
+		return ErrReservationNotActive
+	}
+	r.status = ReservationFulfilled
+	return nil
+}
@@ -0,0 +1,114 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReservation(t *testing.T, now time.Time) *inventory.Reservation {
+	t.Helper()
+	r, err := inventory.NewReservation(uuid.New(), uuid.New(), uuid.New(),
+		[]inventory.ReservationLine{{ProductID: uuid.New(), Grams: 500}}, 30*time.Minute, now)
+	require.NoError(t, err)
+	return r
+}
+
+func TestNewReservation_MergesAndSortsLines(t *testing.T) {
+	a := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	b := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now()
+
+	r, err := inventory.NewReservation(uuid.New(), uuid.New(), uuid.New(), []inventory.ReservationLine{
+		{ProductID: a, Grams: 500},
+		{ProductID: b, Grams: 250},
+		{ProductID: a, Grams: 750},
+	}, 30*time.Minute, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, []inventory.ReservationLine{{ProductID: b, Grams: 250}, {ProductID: a, Grams: 1250}}, r.Lines())
+	assert.Equal(t, inventory.ReservationHeld, r.Status())
+	assert.Equal(t, now.Add(30*time.Minute), r.ExpiresAt())
+}
+
+func TestNewReservation_InvalidLines_ReturnError(t *testing.T) {
+	_, err := inventory.NewReservation(uuid.New(), uuid.New(), uuid.New(), nil, time.Minute, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrEmptyReservation)
+
+	_, err = inventory.NewReservation(uuid.New(), uuid.New(), uuid.New(),
+		[]inventory.ReservationLine{{ProductID: uuid.New(), Grams: 0}}, time.Minute, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrInvalidWeight)
+}
+
+func TestReservation_Lifecycle(t *testing.T) {
+	now := time.Now()
+
+	t.Run("hold lapses at expiry", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		assert.True(t, r.IsActive(now))
+		assert.False(t, r.IsActive(now.Add(30*time.Minute)))
+	})
+
+	t.Run("confirmed reservation does not lapse", func(t *testing.T) {
+		r := newReservation(t, now)
+		orderID := uuid.New()
+
+		require.NoError(t, r.Confirm(orderID, now))
+		assert.Equal(t, &orderID, r.OrderID())
+		assert.True(t, r.IsActive(now.Add(24*time.Hour)))
+		assert.ErrorIs(t, r.Confirm(orderID, now), inventory.ErrReservationNotHeld)
+	})
+
+	t.Run("expired hold cannot be confirmed", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		assert.ErrorIs(t, r.Confirm(uuid.New(), now.Add(time.Hour)), inventory.ErrReservationExpired)
+	})
+
+	t.Run("released reservation cannot be fulfilled", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		require.NoError(t, r.Release())
+		require.NoError(t, r.Release())
+		assert.False(t, r.IsActive(now))
+		assert.ErrorIs(t, r.Fulfil(now), inventory.ErrReservationNotActive)
+	})
+
+	t.Run("fulfilled reservation cannot be released", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		require.NoError(t, r.Fulfil(now))
+		assert.Equal(t, inventory.ReservationFulfilled, r.Status())
+		assert.ErrorIs(t, r.Release(), inventory.ErrReservationNotHeld)
+	})
+
+	t.Run("confirmed reservation cannot be released", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		require.NoError(t, r.Confirm(uuid.New(), now))
+		assert.ErrorIs(t, r.Release(), inventory.ErrReservationNotHeld)
+		assert.Equal(t, inventory.ReservationConfirmed, r.Status())
+	})
+
+	t.Run("confirmed reservation is given back when its order is cancelled", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		require.NoError(t, r.Confirm(uuid.New(), now))
+		require.NoError(t, r.Cancel())
+		require.NoError(t, r.Cancel(), "cancelling twice is a no-op")
+		assert.Equal(t, inventory.ReservationReleased, r.Status())
+		assert.False(t, r.IsActive(now))
+	})
+
+	t.Run("sold stock cannot be given back", func(t *testing.T) {
+		r := newReservation(t, now)
+
+		require.NoError(t, r.Fulfil(now))
+		assert.ErrorIs(t, r.Cancel(), inventory.ErrReservationNotHeld)
+	})
+}
@@ -0,0 +1,164 @@
+package inventory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShrinkageGroup is what a shrinkage report breaks losses down by.
+type ShrinkageGroup string
+
+const (
+	ShrinkageByProduct ShrinkageGroup = "product"
+	ShrinkageBySpecies ShrinkageGroup = "species"
+	ShrinkageByBranch  ShrinkageGroup = "branch"
+)
+
+// ParseShrinkageGroup validates a raw shrinkage grouping.
+func ParseShrinkageGroup(raw string) (ShrinkageGroup, error) {
+	switch g := ShrinkageGroup(raw); g {
+	case ShrinkageByProduct, ShrinkageBySpecies, ShrinkageByBranch:
+		return g, nil
+	default:
+		return "", ErrInvalidShrinkageGroup
+	}
+}
+
+// LedgerFilter narrows the ledger a shrinkage report is built from to
+// movements in [From, To), at one branch when BranchID is set.
+type LedgerFilter struct {
+	From     time.Time
+	To       time.Time
+	BranchID *uuid.UUID
+}
+
+// LedgerTotal sums the ledger movements of one type and reason for a
+// product at a branch. Grams is the signed sum of their deltas and
+// CostCents what that weight cost at each lot's cost per kilogram. Species
+// is the animal the stock came from, or empty if it is not known.
+type LedgerTotal struct {
+	ProductID uuid.UUID
+	BranchID  uuid.UUID
+	Species   string
+	Type      MovementType
+	Reason    string
+	Grams     int64
+	CostCents int64
+}
+
+// ShrinkageLine is the stock that came in and went out for one product,
+// species or branch. Sold and wasted weights are positive; cut and adjusted
+// weights are the net of both signs, negative when stock was lost. Wasted
+// weight is broken down by reason.
+type ShrinkageLine struct {
+	Key            string
+	ReceivedGrams  int64
+	CutGrams       int64
+	SoldGrams      int64
+	WastedGrams    int64
+	AdjustedGrams  int64
+	WastedByReason map[string]int64
+	ReceivedCents  int64
+	SoldCents      int64
+	WastedCents    int64
+	AdjustedCents  int64
+}
+
+// NetGrams returns how much the stock on hand changed by, which reconciles
+// with the ledger.
+func (l ShrinkageLine) NetGrams() int64 {
+	return l.ReceivedGrams + l.CutGrams - l.SoldGrams - l.WastedGrams + l.AdjustedGrams
+}
+
+// ShrinkGrams returns the stock lost other than by selling or cutting it:
+// what was wasted plus what stock takes found missing.
+func (l ShrinkageLine) ShrinkGrams() int64 {
+	return l.WastedGrams - l.AdjustedGrams
+}
+
+// ShrinkCents returns what the stock lost cost.
+func (l ShrinkageLine) ShrinkCents() int64 {
+	return l.WastedCents - l.AdjustedCents
+}
+
+// ShrinkBP returns the stock lost as a share of the stock received, in
+// basis points, or zero if none was received.
+func (l ShrinkageLine) ShrinkBP() int64 {
+	if l.ReceivedGrams <= 0 {
+		return 0
+	}
+	return l.ShrinkGrams() * 10000 / l.ReceivedGrams
+}
+
+func (l *ShrinkageLine) add(t LedgerTotal) {
+	switch t.Type {
+	case MovementReceived:
+		l.ReceivedGrams += t.Grams
+		l.ReceivedCents += t.CostCents
+	case MovementCut:
+		l.CutGrams += t.Grams
+	case MovementSold:
+		l.SoldGrams -= t.Grams
+		l.SoldCents -= t.CostCents
+	case MovementWasted:
+		l.WastedGrams -= t.Grams
+		l.WastedCents -= t.CostCents
+		if l.WastedByReason == nil {
+			l.WastedByReason = make(map[string]int64)
+		}
+		l.WastedByReason[t.Reason] -= t.Grams
+	case MovementAdjusted:
+		l.AdjustedGrams += t.Grams
+		l.AdjustedCents += t.CostCents
+	}
+}
+
+// ShrinkageReport breaks stock losses down by product, species or branch.
+// Lines are ordered by what their losses cost, largest first; Total sums
+// every line.
+type ShrinkageReport struct {
+	GroupBy ShrinkageGroup
+	Lines   []ShrinkageLine
+	Total   ShrinkageLine
+}
+
+// NewShrinkageReport groups ledger totals by.
+func NewShrinkageReport(by ShrinkageGroup, totals []LedgerTotal) ShrinkageReport {
+	report := ShrinkageReport{GroupBy: by}
+	lines := make(map[string]*ShrinkageLine)
+	var keys []string
+	for _, t := range totals {
+		var key string
+		switch by {
+		case ShrinkageBySpecies:
+			key = t.Species
+		case ShrinkageByBranch:
+			key = t.BranchID.String()
+		default:
+			key = t.ProductID.String()
+		}
+		line, ok := lines[key]
+		if !ok {
+			line = &ShrinkageLine{Key: key}
+			lines[key] = line
+			keys = append(keys, key)
+		}
+		line.add(t)
+		report.Total.add(t)
+	}
+
+	report.Lines = make([]ShrinkageLine, 0, len(keys))
+	for _, key := range keys {
+		report.Lines = append(report.Lines, *lines[key])
+	}
+	sort.SliceStable(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if a.ShrinkCents() != b.ShrinkCents() {
+			return a.ShrinkCents() > b.ShrinkCents()
+		}
+		return a.Key < b.Key
+	})
+	return report
+}
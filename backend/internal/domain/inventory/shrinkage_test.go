@@ -0,0 +1,55 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShrinkageReport(t *testing.T) {
+	lamb, beef, branchID := uuid.New(), uuid.New(), uuid.New()
+	totals := []inventory.LedgerTotal{
+		{ProductID: lamb, BranchID: branchID, Species: "lamb", Type: inventory.MovementReceived, Grams: 20000, CostCents: 24000},
+		{ProductID: lamb, BranchID: branchID, Species: "lamb", Type: inventory.MovementSold, Grams: -15000, CostCents: -18000},
+		{ProductID: lamb, BranchID: branchID, Species: "lamb", Type: inventory.MovementWasted, Reason: "trim", Grams: -800, CostCents: -960},
+		{ProductID: lamb, BranchID: branchID, Species: "lamb", Type: inventory.MovementWasted, Reason: "expired", Grams: -1200, CostCents: -1440},
+		{ProductID: lamb, BranchID: branchID, Species: "lamb", Type: inventory.MovementAdjusted, Reason: "stock take", Grams: -300, CostCents: -360},
+		{ProductID: beef, BranchID: branchID, Species: "beef", Type: inventory.MovementReceived, Grams: 10000, CostCents: 15000},
+		{ProductID: beef, BranchID: branchID, Species: "beef", Type: inventory.MovementWasted, Reason: "damaged", Grams: -500, CostCents: -750},
+	}
+
+	report := inventory.NewShrinkageReport(inventory.ShrinkageBySpecies, totals)
+
+	require.Len(t, report.Lines, 2)
+	first := report.Lines[0]
+	assert.Equal(t, "lamb", first.Key, "the costliest losses come first")
+	assert.Equal(t, int64(2000), first.WastedGrams)
+	assert.Equal(t, map[string]int64{"trim": 800, "expired": 1200}, first.WastedByReason)
+	assert.Equal(t, int64(2300), first.ShrinkGrams())
+	assert.Equal(t, int64(2760), first.ShrinkCents())
+	assert.Equal(t, int64(1150), first.ShrinkBP())
+	assert.Equal(t, int64(2700), first.NetGrams(), "net change reconciles with the ledger")
+
+	assert.Equal(t, int64(2800), report.Total.ShrinkGrams())
+	assert.Equal(t, int64(3510), report.Total.ShrinkCents())
+	assert.Equal(t, int64(30000), report.Total.ReceivedGrams)
+
+	byBranch := inventory.NewShrinkageReport(inventory.ShrinkageByBranch, totals)
+	require.Len(t, byBranch.Lines, 1)
+	assert.Equal(t, branchID.String(), byBranch.Lines[0].Key)
+	line := byBranch.Lines[0]
+	line.Key = ""
+	assert.Equal(t, report.Total, line, "one branch's line is the whole report")
+}
+
+func TestParseShrinkageGroup(t *testing.T) {
+	g, err := inventory.ParseShrinkageGroup("species")
+	require.NoError(t, err)
+	assert.Equal(t, inventory.ShrinkageBySpecies, g)
+
+	_, err = inventory.ParseShrinkageGroup("supplier")
+	assert.ErrorIs(t, err, inventory.ErrInvalidShrinkageGroup)
+}
@@ -0,0 +1,24 @@
+package inventory
+
+import "github.com/google/uuid"
+
+// StockLevel summarises a product's stock in a branch.
+type StockLevel struct {
+	ProductID              uuid.UUID
+	BranchID               uuid.UUID
+	OnHandGrams            int64
+	ReservedGrams          int64
+	LowStockThresholdGrams int64
+}
+
+// AvailableGrams is the stock that can still be promised to new orders. It is
+// negative when stock was wasted or adjusted below what is already reserved.
+func (s StockLevel) AvailableGrams() int64 {
+	return s.OnHandGrams - s.ReservedGrams
+}
+
+// IsLow reports whether available stock has fallen to the product's
+// low-stock threshold. Products without a threshold are never low.
+func (s StockLevel) IsLow() bool {
+	return s.LowStockThresholdGrams > 0 && s.AvailableGrams() <= s.LowStockThresholdGrams
+}
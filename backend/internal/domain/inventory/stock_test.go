@@ -0,0 +1,29 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     inventory.StockLevel
+		available int64
+		low       bool
+	}{
+		{"plenty", inventory.StockLevel{OnHandGrams: 10000, ReservedGrams: 2000, LowStockThresholdGrams: 3000}, 8000, false},
+		{"at threshold", inventory.StockLevel{OnHandGrams: 5000, ReservedGrams: 2000, LowStockThresholdGrams: 3000}, 3000, true},
+		{"over-reserved", inventory.StockLevel{OnHandGrams: 1000, ReservedGrams: 1500, LowStockThresholdGrams: 1}, -500, true},
+		{"no threshold", inventory.StockLevel{OnHandGrams: 0}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.available, tt.level.AvailableGrams())
+			assert.Equal(t, tt.low, tt.level.IsLow())
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WasteReason is why stock was thrown away. It is kept as the reason of the
+// wasted movement, so the ledger can be reported on by reason.
+type WasteReason string
+
+const (
+	WasteTrim     WasteReason = "trim"
+	WasteExpired  WasteReason = "expired"
+	WasteDamaged  WasteReason = "damaged"
+	WasteReturned WasteReason = "returned"
+)
+
+// ParseWasteReason validates a raw waste reason.
+func ParseWasteReason(raw string) (WasteReason, error) {
+	switch r := WasteReason(raw); r {
+	case WasteTrim, WasteExpired, WasteDamaged, WasteReturned:
+		return r, nil
+	default:
+		return "", ErrInvalidWasteReason
+	}
+}
+
+// Waste returns the ledger entry that writes grams of the lot off for
+// reason. referenceID links it to what caused it, such as an expiry
+// proposal or a customer return.
+func (l *Lot) Waste(grams int64, reason WasteReason, referenceID, actorID *uuid.UUID, now time.Time) (*Movement, error) {
+	if grams <= 0 {
+		return nil, ErrInvalidWeight
+	}
+	if _, err := ParseWasteReason(string(reason)); err != nil {
+		return nil, err
+	}
+	if grams > l.onHandGrams {
+		return nil, ErrInsufficientStock
+	}
+	return NewMovement(uuid.New(), l, MovementWasted, -grams, string(reason), referenceID, actorID, now)
+}
@@ -0,0 +1,38 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWasteReason(t *testing.T) {
+	r, err := inventory.ParseWasteReason("damaged")
+	require.NoError(t, err)
+	assert.Equal(t, inventory.WasteDamaged, r)
+
+	_, err = inventory.ParseWasteReason("lost")
+	assert.ErrorIs(t, err, inventory.ErrInvalidWasteReason)
+}
+
+func TestLot_Waste(t *testing.T) {
+	lot := newLot(t, 2000, nil, time.Now())
+	actor := uuid.New()
+
+	m, err := lot.Waste(800, inventory.WasteTrim, nil, &actor, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, inventory.MovementWasted, m.Type())
+	assert.Equal(t, int64(-800), m.DeltaGrams())
+	assert.Equal(t, "trim", m.Reason())
+
+	_, err = lot.Waste(0, inventory.WasteTrim, nil, &actor, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrInvalidWeight)
+	_, err = lot.Waste(500, "spilled", nil, &actor, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrInvalidWasteReason)
+	_, err = lot.Waste(2500, inventory.WasteDamaged, nil, &actor, time.Now())
+	assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+}
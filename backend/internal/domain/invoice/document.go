@@ -0,0 +1,338 @@
+package invoice
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is the type of a tax document.
+type Kind string
+
+const (
+	KindInvoice Kind = "invoice"
+	// KindCreditNote reverses part of an invoice when a refund is issued.
+	KindCreditNote Kind = "credit_note"
+)
+
+// prefix starts the number of every document of the kind.
+func (k Kind) prefix() string {
+	if k == KindCreditNote {
+		return "CN"
+	}
+	return "INV"
+}
+
+// Party is the seller or buyer printed on a document.
+type Party struct {
+	Name          string
+	Address       []string
+	VATNumber     string
+	CompanyNumber string
+	Email         string
+}
+
+// LineInput is a weighed product to put on a document. GrossCents is what
+// was charged or credited for it, of which VATCents is VAT at VATRateBP
+// basis points, as the tax calculator priced it. Documents never work VAT
+// out for themselves, so they always agree with the order they are for.
+type LineInput struct {
+	Description     string
+	Grams           int64
+	PricePerKgCents int64
+	VATRateBP       int
+	VATCents        int64
+	GrossCents      int64
+}
+
+// Line is a document line with its VAT worked out.
+type Line struct {
+	Description     string
+	Grams           int64
+	PricePerKgCents int64
+	VATRateBP       int
+	NetCents        int64
+	VATCents        int64
+	GrossCents      int64
+}
+
+// VATBand totals the lines charged at one VAT rate.
+type VATBand struct {
+	RateBP     int
+	NetCents   int64
+	VATCents   int64
+	GrossCents int64
+}
+
+// Document is an invoice or credit note. It is numbered from a gap-free
+// series per branch, kind and fiscal year when it is issued, and never
+// changes afterwards; the rendered PDF is kept alongside it so the copy the
+// customer got can always be produced again.
+type Document struct {
+	id                uuid.UUID
+	kind              Kind
+	number            string
+	branchID          uuid.UUID
+	fiscalYear        int
+	sequence          int64
+	orderID           uuid.UUID
+	customerID        uuid.UUID
+	refundID          *uuid.UUID
+	creditedInvoiceID *uuid.UUID
+	creditedNumber    string
+	seller            Party
+	buyer             Party
+	lines             []Line
+	currency          string
+	issuedAt          time.Time
+	pdf               []byte
+	checksum          string
+}
+
+// NewInvoice creates an unnumbered invoice for an order.
+func NewInvoice(
+	id, branchID, orderID, customerID uuid.UUID,
+	seller, buyer Party,
+	lines []LineInput,
+	currency string,
+	fiscalYear int,
+	issuedAt time.Time,
+) (*Document, error) {
+	if branchID == uuid.Nil {
+		return nil, ErrBranchRequired
+	}
+	built, err := buildLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		id:         id,
+		kind:       KindInvoice,
+		branchID:   branchID,
+		fiscalYear: fiscalYear,
+		orderID:    orderID,
+		customerID: customerID,
+		seller:     seller,
+		buyer:      buyer,
+		lines:      built,
+		currency:   strings.ToLower(currency),
+		issuedAt:   issuedAt,
+	}, nil
+}
+
+// NewCreditNote creates an unnumbered credit note against an invoice for a
+// refund. It is issued by the invoice's branch to the same buyer.
+func NewCreditNote(
+	id uuid.UUID,
+	inv *Document,
+	refundID uuid.UUID,
+	lines []LineInput,
+	fiscalYear int,
+	issuedAt time.Time,
+) (*Document, error) {
+	if inv.kind != KindInvoice {
+		return nil, ErrNotAnInvoice
+	}
+	built, err := buildLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	if total(built) > inv.GrossCents() {
+		return nil, ErrCreditExceedsInvoice
+	}
+	invoiceID := inv.id
+	return &Document{
+		id:                id,
+		kind:              KindCreditNote,
+		branchID:          inv.branchID,
+		fiscalYear:        fiscalYear,
+		orderID:           inv.orderID,
+		customerID:        inv.customerID,
+		refundID:          &refundID,
+		creditedInvoiceID: &invoiceID,
+		creditedNumber:    inv.number,
+		seller:            inv.seller,
+		buyer:             inv.buyer,
+		lines:             built,
+		currency:          inv.currency,
+		issuedAt:          issuedAt,
+	}, nil
+}
+
+// ReconstructDocument reconstructs a Document from persistence without
+// validation.
+func ReconstructDocument(
+	id uuid.UUID,
+	kind Kind,
+	number string,
+	branchID uuid.UUID,
+	fiscalYear int,
+	sequence int64,
+	orderID, customerID uuid.UUID,
+	refundID, creditedInvoiceID *uuid.UUID,
+	creditedNumber string,
+	seller, buyer Party,
+	lines []Line,
+	currency string,
+	issuedAt time.Time,
+	pdf []byte,
+	checksum string,
+) *Document {
+	return &Document{
+		id:                id,
+		kind:              kind,
+		number:            number,
+		branchID:          branchID,
+		fiscalYear:        fiscalYear,
+		sequence:          sequence,
+		orderID:           orderID,
+		customerID:        customerID,
+		refundID:          refundID,
+		creditedInvoiceID: creditedInvoiceID,
+		creditedNumber:    creditedNumber,
+		seller:            seller,
+		buyer:             buyer,
+		lines:             lines,
+		currency:          currency,
+		issuedAt:          issuedAt,
+		pdf:               pdf,
+		checksum:          checksum,
+	}
+}
+
+func (d *Document) ID() uuid.UUID                 { return d.id }
+func (d *Document) Kind() Kind                    { return d.kind }
+func (d *Document) Number() string                { return d.number }
+func (d *Document) BranchID() uuid.UUID           { return d.branchID }
+func (d *Document) FiscalYear() int               { return d.fiscalYear }
+func (d *Document) Sequence() int64               { return d.sequence }
+func (d *Document) OrderID() uuid.UUID            { return d.orderID }
+func (d *Document) CustomerID() uuid.UUID         { return d.customerID }
+func (d *Document) RefundID() *uuid.UUID          { return d.refundID }
+func (d *Document) CreditedInvoiceID() *uuid.UUID { return d.creditedInvoiceID }
+func (d *Document) CreditedNumber() string        { return d.creditedNumber }
+func (d *Document) Seller() Party                 { return d.seller }
+func (d *Document) Buyer() Party                  { return d.buyer }
+func (d *Document) Lines() []Line                 { return d.lines }
+func (d *Document) Currency() string              { return d.currency }
+func (d *Document) IssuedAt() time.Time           { return d.issuedAt }
+
+// PDF is the stored rendering of the document. It is nil on documents
+// loaded in a listing.
+func (d *Document) PDF() []byte { return d.pdf }
+
+// Checksum is the hex SHA-256 of the stored PDF.
+func (d *Document) Checksum() string { return d.checksum }
+
+// NetCents is the document total before VAT.
+func (d *Document) NetCents() int64 {
+	var net int64
+	for _, l := range d.lines {
+		net += l.NetCents
+	}
+	return net
+}
+
+// VATCents is the VAT charged or credited on the document.
+func (d *Document) VATCents() int64 {
+	var vat int64
+	for _, l := range d.lines {
+		vat += l.VATCents
+	}
+	return vat
+}
+
+// GrossCents is the document total including VAT.
+func (d *Document) GrossCents() int64 { return total(d.lines) }
+
+// VATBands totals the lines by VAT rate, lowest rate first.
+func (d *Document) VATBands() []VATBand {
+	byRate := make(map[int]*VATBand)
+	for _, l := range d.lines {
+		b, ok := byRate[l.VATRateBP]
+		if !ok {
+			b = &VATBand{RateBP: l.VATRateBP}
+			byRate[l.VATRateBP] = b
+		}
+		b.NetCents += l.NetCents
+		b.VATCents += l.VATCents
+		b.GrossCents += l.GrossCents
+	}
+	bands := make([]VATBand, 0, len(byRate))
+	for _, b := range byRate {
+		bands = append(bands, *b)
+	}
+	slices.SortFunc(bands, func(a, b VATBand) int { return a.RateBP - b.RateBP })
+	return bands
+}
+
+// AssignNumber gives the document the next number in its series.
+func (d *Document) AssignNumber(sequence int64) {
+	d.sequence = sequence
+	d.number = FormatNumber(d.kind, d.branchID, d.fiscalYear, sequence)
+}
+
+// AttachPDF keeps the rendered document and its checksum.
+func (d *Document) AttachPDF(pdf []byte) {
+	sum := sha256.Sum256(pdf)
+	d.pdf = pdf
+	d.checksum = hex.EncodeToString(sum[:])
+}
+
+// FormatNumber formats a document number, e.g. INV-3F2A9C01-2026-000042.
+// Branches have no short codes, so the first block of the branch ID keeps
+// numbers from different branches apart.
+func FormatNumber(kind Kind, branchID uuid.UUID, fiscalYear int, sequence int64) string {
+	branch := strings.ToUpper(strings.SplitN(branchID.String(), "-", 2)[0])
+	return fmt.Sprintf("%s-%s-%d-%06d", kind.prefix(), branch, fiscalYear, sequence)
+}
+
+// FiscalYear is the year in which the fiscal year containing t starts,
+// given the month fiscal years start in.
+func FiscalYear(t time.Time, startMonth time.Month) int {
+	if t.Month() < startMonth {
+		return t.Year() - 1
+	}
+	return t.Year()
+}
+
+func buildLines(in []LineInput) ([]Line, error) {
+	if len(in) == 0 {
+		return nil, ErrNoLines
+	}
+	lines := make([]Line, 0, len(in))
+	for _, l := range in {
+		if l.VATRateBP < 0 || l.VATRateBP > 10000 {
+			return nil, ErrInvalidVATRate
+		}
+		if l.Grams < 0 || l.PricePerKgCents < 0 || l.GrossCents < 0 || l.VATCents < 0 {
+			return nil, ErrNegativeAmount
+		}
+		if l.VATCents > l.GrossCents {
+			return nil, ErrVATExceedsTotal
+		}
+		lines = append(lines, Line{
+			Description:     strings.TrimSpace(l.Description),
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+			VATRateBP:       l.VATRateBP,
+			NetCents:        l.GrossCents - l.VATCents,
+			VATCents:        l.VATCents,
+			GrossCents:      l.GrossCents,
+		})
+	}
+	return lines, nil
+}
+
+func total(lines []Line) int64 {
+	var gross int64
+	for _, l := range lines {
+		gross += l.GrossCents
+	}
+	return gross
+}
@@ -0,0 +1,111 @@
+package invoice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var branchID = uuid.MustParse("3f2a9c01-0000-4000-8000-000000000001")
+
+// newInvoice invoices 1.5kg lamb at £20/kg with no VAT and a £12 bag of
+// charcoal at 20% VAT.
+func newInvoice(t *testing.T) *invoice.Document {
+	t.Helper()
+	d, err := invoice.NewInvoice(uuid.New(), branchID, uuid.New(), uuid.New(),
+		invoice.Party{Name: "Butchery", VATNumber: "GB123456789"}, invoice.Party{Name: "Le Bistro"},
+		[]invoice.LineInput{
+			{Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 2000, GrossCents: 3000},
+			{Description: "Charcoal", VATRateBP: 2000, VATCents: 200, GrossCents: 1200},
+		}, "GBP", 2026, time.Now())
+	require.NoError(t, err)
+	return d
+}
+
+func TestNewInvoice_TotalsVAT(t *testing.T) {
+	d := newInvoice(t)
+
+	assert.Equal(t, invoice.KindInvoice, d.Kind())
+	assert.Equal(t, "gbp", d.Currency())
+	assert.Equal(t, int64(4200), d.GrossCents())
+	assert.Equal(t, int64(200), d.VATCents())
+	assert.Equal(t, int64(4000), d.NetCents())
+	assert.Equal(t, []invoice.VATBand{
+		{RateBP: 0, NetCents: 3000, GrossCents: 3000},
+		{RateBP: 2000, NetCents: 1000, VATCents: 200, GrossCents: 1200},
+	}, d.VATBands())
+}
+
+func TestNewInvoice_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch uuid.UUID
+		lines  []invoice.LineInput
+		want   error
+	}{
+		{"no branch", uuid.Nil, []invoice.LineInput{{GrossCents: 100}}, invoice.ErrBranchRequired},
+		{"no lines", branchID, nil, invoice.ErrNoLines},
+		{"VAT over 100%", branchID, []invoice.LineInput{{GrossCents: 100, VATRateBP: 10001}}, invoice.ErrInvalidVATRate},
+		{"negative amount", branchID, []invoice.LineInput{{GrossCents: -1}}, invoice.ErrNegativeAmount},
+		{"VAT over total", branchID, []invoice.LineInput{{GrossCents: 100, VATRateBP: 2000, VATCents: 101}}, invoice.ErrVATExceedsTotal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := invoice.NewInvoice(uuid.New(), tt.branch, uuid.New(), uuid.New(),
+				invoice.Party{}, invoice.Party{}, tt.lines, "gbp", 2026, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestAssignNumber_FormatsPerBranchAndYear(t *testing.T) {
+	d := newInvoice(t)
+
+	d.AssignNumber(42)
+
+	assert.Equal(t, "INV-3F2A9C01-2026-000042", d.Number())
+	assert.Equal(t, int64(42), d.Sequence())
+}
+
+func TestNewCreditNote_CopiesInvoiceParties(t *testing.T) {
+	inv := newInvoice(t)
+	inv.AssignNumber(7)
+	refundID := uuid.New()
+
+	cn, err := invoice.NewCreditNote(uuid.New(), inv, refundID, []invoice.LineInput{
+		{Description: "Lamb shoulder", Grams: 250, PricePerKgCents: 2000, GrossCents: 500},
+	}, 2027, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, invoice.KindCreditNote, cn.Kind())
+	assert.Equal(t, "INV-3F2A9C01-2026-000007", cn.CreditedNumber())
+	assert.Equal(t, inv.Buyer(), cn.Buyer())
+	assert.Equal(t, &refundID, cn.RefundID())
+	cn.AssignNumber(1)
+	assert.Equal(t, "CN-3F2A9C01-2027-000001", cn.Number())
+
+	_, err = invoice.NewCreditNote(uuid.New(), cn, refundID, []invoice.LineInput{{GrossCents: 1}}, 2027, time.Now())
+	assert.ErrorIs(t, err, invoice.ErrNotAnInvoice)
+
+	_, err = invoice.NewCreditNote(uuid.New(), inv, refundID, []invoice.LineInput{{GrossCents: 4201}}, 2027, time.Now())
+	assert.ErrorIs(t, err, invoice.ErrCreditExceedsInvoice)
+}
+
+func TestAttachPDF_RecordsChecksum(t *testing.T) {
+	d := newInvoice(t)
+
+	d.AttachPDF([]byte("%PDF-1.4"))
+
+	assert.Equal(t, []byte("%PDF-1.4"), d.PDF())
+	assert.Len(t, d.Checksum(), 64)
+}
+
+func TestFiscalYear(t *testing.T) {
+	assert.Equal(t, 2025, invoice.FiscalYear(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), time.April))
+	assert.Equal(t, 2026, invoice.FiscalYear(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), time.April))
+	assert.Equal(t, 2026, invoice.FiscalYear(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.January))
+}
@@ -0,0 +1,16 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrBranchRequired       = errors.New("an invoice must be issued by a branch")
+	ErrNoLines              = errors.New("document must contain at least one line")
+	ErrInvalidVATRate       = errors.New("VAT rate must be between 0 and 10000 basis points")
+	ErrNegativeAmount       = errors.New("weights and amounts must not be negative")
+	ErrVATExceedsTotal      = errors.New("a line's VAT must not exceed its total")
+	ErrNotAnInvoice         = errors.New("a credit note can only be raised against an invoice")
+	ErrCreditExceedsInvoice = errors.New("credit note must not exceed the invoice it credits")
+	ErrDocumentNotFound     = errors.New("invoice not found")
+	ErrInvoiceRequired      = errors.New("the order must be invoiced before a credit note can be issued")
+	ErrAlreadyIssued        = errors.New("a document has already been issued for this order or refund")
+)
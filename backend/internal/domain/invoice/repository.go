@@ -0,0 +1,36 @@
+package invoice
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Renderer turns a numbered document into the PDF given to the customer. It
+// must not depend on anything but the document, so that the same document
+// always renders to the same bytes.
+type Renderer interface {
+	Render(d *Document) ([]byte, error)
+}
+
+// Repository provides access to issued invoices and credit notes.
+type Repository interface {
+	// Issue takes the next number in the document's series, renders it and
+	// stores it in one transaction, so a number is only used once the
+	// document exists and numbers have no gaps. It returns ErrAlreadyIssued
+	// if the order already has an invoice, or the refund a credit note.
+	Issue(ctx context.Context, d *Document, renderer Renderer) error
+	// FindByID returns a document together with its PDF.
+	FindByID(ctx context.Context, id uuid.UUID) (*Document, error)
+	// FindInvoiceByOrderID returns ErrDocumentNotFound if the order has not
+	// been invoiced.
+	FindInvoiceByOrderID(ctx context.Context, orderID uuid.UUID) (*Document, error)
+	// FindByRefundID returns ErrDocumentNotFound if no credit note has been
+	// issued for the refund.
+	FindByRefundID(ctx context.Context, refundID uuid.UUID) (*Document, error)
+	// ListByOrderID returns an order's documents, oldest first, without PDFs.
+	ListByOrderID(ctx context.Context, orderID uuid.UUID) ([]*Document, error)
+	// ListByCustomerID returns a customer's documents, newest first, without
+	// PDFs.
+	ListByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*Document, error)
+}
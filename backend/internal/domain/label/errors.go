@@ -0,0 +1,22 @@
+package label
+
+import "errors"
+
+var (
+	ErrNoLabels          = errors.New("there is nothing to label")
+	ErrTemplateNotFound  = errors.New("label template not found")
+	ErrInvalidFormat     = errors.New("label format must be zpl or pdf")
+	ErrEmptyPrinterName  = errors.New("printer name must not be empty")
+	ErrInvalidAddress    = errors.New("printer address must be a host, optionally with a port")
+	ErrPrinterNotFound   = errors.New("printer not found")
+	ErrPrinterInactive   = errors.New("printer is disabled")
+	ErrDuplicatePrinter  = errors.New("another printer already has that name")
+	ErrEmptyJob          = errors.New("print job must contain at least one label")
+	ErrJobNotFound       = errors.New("print job not found")
+	ErrJobNotFailed      = errors.New("only a failed print job can be retried")
+	ErrNoJobDue          = errors.New("no print job is due")
+	ErrInvalidJobStatus  = errors.New("print job status must be one of queued, printed or failed")
+	ErrInvalidPackWeight = errors.New("pack weight must be greater than zero")
+	ErrPacksExceedLot    = errors.New("packs weigh more than the lot has on hand")
+	ErrProductNotOnPLU   = errors.New("product has no PLU item to label it with")
+)
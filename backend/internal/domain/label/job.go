@@ -0,0 +1,161 @@
+package label
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus is where a print job is in the queue.
+type JobStatus string
+
+const (
+	JobQueued  JobStatus = "queued"
+	JobPrinted JobStatus = "printed"
+	JobFailed  JobStatus = "failed"
+)
+
+// ParseJobStatus parses a print job status.
+func ParseJobStatus(s string) (JobStatus, error) {
+	switch st := JobStatus(s); st {
+	case JobQueued, JobPrinted, JobFailed:
+		return st, nil
+	}
+	return "", ErrInvalidJobStatus
+}
+
+// Source is what a print job's labels were made from.
+type Source string
+
+const (
+	SourceOrder Source = "order"
+	SourceLot   Source = "lot"
+)
+
+// Job is a batch of labels rendered to ZPL and waiting to be sent to a
+// printer. A queued job is sent once NextAttemptAt has passed; every send
+// counts as an attempt, and a job that keeps failing is given up on.
+type Job struct {
+	id            uuid.UUID
+	printerID     uuid.UUID
+	source        Source
+	sourceID      uuid.UUID
+	template      string
+	labels        int
+	payload       []byte
+	status        JobStatus
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	printedAt     *time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewJob queues labels rendered for a printer, due straight away.
+func NewJob(id, printerID uuid.UUID, source Source, sourceID uuid.UUID, template string, labels int, payload []byte, now time.Time) (*Job, error) {
+	if labels <= 0 || len(payload) == 0 {
+		return nil, ErrEmptyJob
+	}
+	return &Job{
+		id:            id,
+		printerID:     printerID,
+		source:        source,
+		sourceID:      sourceID,
+		template:      template,
+		labels:        labels,
+		payload:       payload,
+		status:        JobQueued,
+		nextAttemptAt: now,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// ReconstructJob reconstructs a Job from persistence without validation.
+func ReconstructJob(
+	id, printerID uuid.UUID,
+	source Source,
+	sourceID uuid.UUID,
+	template string,
+	labels int,
+	payload []byte,
+	status JobStatus,
+	attempts int,
+	lastError string,
+	nextAttemptAt time.Time,
+	printedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *Job {
+	return &Job{
+		id:            id,
+		printerID:     printerID,
+		source:        source,
+		sourceID:      sourceID,
+		template:      template,
+		labels:        labels,
+		payload:       payload,
+		status:        status,
+		attempts:      attempts,
+		lastError:     lastError,
+		nextAttemptAt: nextAttemptAt,
+		printedAt:     printedAt,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+func (j *Job) ID() uuid.UUID            { return j.id }
+func (j *Job) PrinterID() uuid.UUID     { return j.printerID }
+func (j *Job) Source() Source           { return j.source }
+func (j *Job) SourceID() uuid.UUID      { return j.sourceID }
+func (j *Job) Template() string         { return j.template }
+func (j *Job) Labels() int              { return j.labels }
+func (j *Job) Payload() []byte          { return j.payload }
+func (j *Job) Status() JobStatus        { return j.status }
+func (j *Job) Attempts() int            { return j.attempts }
+func (j *Job) LastError() string        { return j.lastError }
+func (j *Job) NextAttemptAt() time.Time { return j.nextAttemptAt }
+func (j *Job) PrintedAt() *time.Time    { return j.printedAt }
+func (j *Job) CreatedAt() time.Time     { return j.createdAt }
+func (j *Job) UpdatedAt() time.Time     { return j.updatedAt }
+
+// Attempt starts sending the job. It is not due again until lease has
+// passed, so a sender that dies part way through does not hold it forever.
+func (j *Job) Attempt(lease time.Duration, now time.Time) {
+	j.attempts++
+	j.nextAttemptAt = now.Add(lease)
+	j.updatedAt = now
+}
+
+// Printed records that the printer took the job.
+func (j *Job) Printed(now time.Time) {
+	j.status = JobPrinted
+	j.lastError = ""
+	j.printedAt = &now
+	j.updatedAt = now
+}
+
+// Failed records why an attempt failed. The job is tried again after
+// retryAfter unless it has had maxAttempts, in which case it is given up on.
+func (j *Job) Failed(reason string, retryAfter time.Duration, maxAttempts int, now time.Time) {
+	j.lastError = reason
+	if j.attempts >= maxAttempts {
+		j.status = JobFailed
+	} else {
+		j.nextAttemptAt = now.Add(retryAfter)
+	}
+	j.updatedAt = now
+}
+
+// Retry puts a failed job back in the queue with its attempts reset.
+func (j *Job) Retry(now time.Time) error {
+	if j.status != JobFailed {
+		return ErrJobNotFailed
+	}
+	j.status = JobQueued
+	j.attempts = 0
+	j.nextAttemptAt = now
+	j.updatedAt = now
+	return nil
+}
@@ -0,0 +1,44 @@
+package label_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_RetriesUntilMaxAttempts(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	_, err := label.NewJob(uuid.New(), uuid.New(), label.SourceLot, uuid.New(), "standard", 0, []byte("^XA^XZ"), now)
+	assert.ErrorIs(t, err, label.ErrEmptyJob)
+
+	j, err := label.NewJob(uuid.New(), uuid.New(), label.SourceLot, uuid.New(), "standard", 2, []byte("^XA^XZ"), now)
+	require.NoError(t, err)
+	assert.Equal(t, label.JobQueued, j.Status())
+	assert.Equal(t, now, j.NextAttemptAt())
+
+	j.Attempt(time.Minute, now)
+	assert.Equal(t, now.Add(time.Minute), j.NextAttemptAt(), "leased while sending")
+	j.Failed("connection refused", 30*time.Second, 2, now)
+	assert.Equal(t, label.JobQueued, j.Status())
+	assert.Equal(t, now.Add(30*time.Second), j.NextAttemptAt())
+	assert.ErrorIs(t, j.Retry(now), label.ErrJobNotFailed)
+
+	j.Attempt(time.Minute, now)
+	j.Failed("connection refused", 30*time.Second, 2, now)
+	assert.Equal(t, label.JobFailed, j.Status())
+	assert.Equal(t, "connection refused", j.LastError())
+
+	require.NoError(t, j.Retry(now))
+	assert.Equal(t, label.JobQueued, j.Status())
+	assert.Zero(t, j.Attempts())
+
+	j.Attempt(time.Minute, now)
+	j.Printed(now)
+	assert.Equal(t, label.JobPrinted, j.Status())
+	assert.Empty(t, j.LastError())
+	require.NotNil(t, j.PrintedAt())
+}
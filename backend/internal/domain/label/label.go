@@ -0,0 +1,89 @@
+package label
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Format is what labels are rendered to: ZPL for thermal label printers or
+// PDF for office printers.
+type Format string
+
+const (
+	FormatZPL Format = "zpl"
+	FormatPDF Format = "pdf"
+)
+
+// Label is what is printed on one pack. PackedOn and UseBy are dates in the
+// shop's time zone. Barcode is the pack's EAN-13, empty if the product has
+// no PLU code to build one from.
+type Label struct {
+	Description     string
+	Grams           int64
+	PricePerKgCents int64
+	TotalCents      int64
+	Currency        string
+	PackedOn        time.Time
+	UseBy           *time.Time
+	LotCode         string
+	Allergens       []string
+	Halal           bool
+	Barcode         string
+}
+
+// Text is what a label says for a field, or "" if it has nothing to say.
+// The halal field is only the word for the logo; renderers draw the badge.
+func (l Label) Text(f Field) string {
+	switch f {
+	case FieldDescription:
+		return l.Description
+	case FieldWeight:
+		return fmt.Sprintf("%d.%03d kg", l.Grams/1000, l.Grams%1000)
+	case FieldPricePerKg:
+		return formatMoney(l.PricePerKgCents, l.Currency) + "/kg"
+	case FieldTotal:
+		return formatMoney(l.TotalCents, l.Currency)
+	case FieldPackedOn:
+		return "Packed " + l.PackedOn.Format(dateLayout)
+	case FieldUseBy:
+		if l.UseBy == nil {
+			return ""
+		}
+		return "Use by " + l.UseBy.Format(dateLayout)
+	case FieldLotCode:
+		if l.LotCode == "" {
+			return ""
+		}
+		return "Lot " + l.LotCode
+	case FieldAllergens:
+		if len(l.Allergens) == 0 {
+			return "No allergens"
+		}
+		return "Contains: " + strings.Join(l.Allergens, ", ")
+	case FieldHalal:
+		if !l.Halal {
+			return ""
+		}
+		return "HALAL"
+	case FieldBarcode:
+		return l.Barcode
+	}
+	return ""
+}
+
+const dateLayout = "02 Jan 2006"
+
+// formatMoney formats cents with the currency's symbol, e.g. £12.99.
+func formatMoney(cents int64, currency string) string {
+	symbol := strings.ToUpper(currency) + " "
+	switch strings.ToLower(currency) {
+	case "gbp":
+		symbol = "£"
+	case "eur":
+		symbol = "€"
+	case "usd":
+		symbol = "$"
+	}
+	return fmt.Sprintf("%s%d.%02d", symbol, cents/100, cents%100)
+}
@@ -0,0 +1,62 @@
+package label_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel_Text(t *testing.T) {
+	useBy := time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)
+	l := label.Label{
+		Description:     "Lamb mince",
+		Grams:           1250,
+		PricePerKgCents: 1299,
+		TotalCents:      1624,
+		Currency:        "gbp",
+		PackedOn:        time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		UseBy:           &useBy,
+		LotCode:         "L-1019",
+		Allergens:       []string{"gluten", "sulphites"},
+		Halal:           true,
+		Barcode:         "2100123016242",
+	}
+
+	for field, want := range map[label.Field]string{
+		label.FieldDescription: "Lamb mince",
+		label.FieldWeight:      "1.250 kg",
+		label.FieldPricePerKg:  "£12.99/kg",
+		label.FieldTotal:       "£16.24",
+		label.FieldPackedOn:    "Packed 19 Oct 2026",
+		label.FieldUseBy:       "Use by 22 Oct 2026",
+		label.FieldLotCode:     "Lot L-1019",
+		label.FieldAllergens:   "Contains: gluten, sulphites",
+		label.FieldHalal:       "HALAL",
+		label.FieldBarcode:     "2100123016242",
+	} {
+		assert.Equal(t, want, l.Text(field), field)
+	}
+
+	bare := label.Label{Description: "Bones", Currency: "gbp"}
+	assert.Empty(t, bare.Text(label.FieldUseBy))
+	assert.Empty(t, bare.Text(label.FieldLotCode))
+	assert.Empty(t, bare.Text(label.FieldHalal))
+	assert.Equal(t, "No allergens", bare.Text(label.FieldAllergens))
+}
+
+func TestTemplates_FitTheirLabels(t *testing.T) {
+	for _, tmpl := range label.Templates {
+		found, err := label.FindTemplate(tmpl.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, tmpl.Name, found.Name)
+		for _, e := range tmpl.Elements {
+			assert.LessOrEqual(t, e.X+e.Width, tmpl.WidthMM, "%s %s", tmpl.Name, e.Field)
+			assert.LessOrEqual(t, e.Y+e.Height*float64(max(e.Lines, 1)), tmpl.HeightMM, "%s %s", tmpl.Name, e.Field)
+		}
+	}
+
+	_, err := label.FindTemplate("tiny")
+	assert.ErrorIs(t, err, label.ErrTemplateNotFound)
+}
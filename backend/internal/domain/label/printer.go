@@ -0,0 +1,88 @@
+package label
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RawPort is the port label printers take raw print data on.
+const RawPort = 9100
+
+// Printer is a label printer on the shop's network that prints whatever ZPL
+// is sent to its raw port.
+type Printer struct {
+	id        uuid.UUID
+	name      string
+	address   string
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewPrinter validates and creates an active printer.
+func NewPrinter(id uuid.UUID, name, address string, now time.Time) (*Printer, error) {
+	p := &Printer{id: id, createdAt: now}
+	if err := p.Update(name, address, true, now); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ReconstructPrinter reconstructs a Printer from persistence without validation.
+func ReconstructPrinter(id uuid.UUID, name, address string, active bool, createdAt, updatedAt time.Time) *Printer {
+	return &Printer{
+		id:        id,
+		name:      name,
+		address:   address,
+		active:    active,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (p *Printer) ID() uuid.UUID        { return p.id }
+func (p *Printer) Name() string         { return p.name }
+func (p *Printer) Address() string      { return p.address }
+func (p *Printer) Active() bool         { return p.active }
+func (p *Printer) CreatedAt() time.Time { return p.createdAt }
+func (p *Printer) UpdatedAt() time.Time { return p.updatedAt }
+
+// Update renames, moves or disables the printer. An address without a port
+// is on RawPort.
+func (p *Printer) Update(name, address string, active bool, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyPrinterName
+	}
+	address, err := normaliseAddress(address)
+	if err != nil {
+		return err
+	}
+	p.name = name
+	p.address = address
+	p.active = active
+	p.updatedAt = now
+	return nil
+}
+
+func normaliseAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", ErrInvalidAddress
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host, port = address, strconv.Itoa(RawPort)
+	}
+	if host == "" || strings.ContainsAny(host, "/ ") {
+		return "", ErrInvalidAddress
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", ErrInvalidAddress
+	}
+	return net.JoinHostPort(host, port), nil
+}
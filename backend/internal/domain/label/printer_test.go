@@ -0,0 +1,43 @@
+package label_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrinter_Address(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		address string
+		want    string
+		err     error
+	}{
+		{address: "192.168.1.40", want: "192.168.1.40:9100"},
+		{address: " counter-zebra.local:6101 ", want: "counter-zebra.local:6101"},
+		{address: "fe80::1", want: "[fe80::1]:9100"},
+		{address: "[fe80::1]:9100", want: "[fe80::1]:9100"},
+		{address: "", err: label.ErrInvalidAddress},
+		{address: "printer:0", err: label.ErrInvalidAddress},
+		{address: "http://printer/", err: label.ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			p, err := label.NewPrinter(uuid.New(), "Counter", tt.address, now)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.Address())
+			assert.True(t, p.Active())
+		})
+	}
+
+	_, err := label.NewPrinter(uuid.New(), " ", "10.0.0.5", now)
+	assert.ErrorIs(t, err, label.ErrEmptyPrinterName)
+}
@@ -0,0 +1,44 @@
+package label
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobFilter narrows a print job query. Nil fields are not filtered on.
+type JobFilter struct {
+	PrinterID *uuid.UUID
+	Status    *JobStatus
+	Limit     int
+}
+
+// PrinterRepository provides access to label printers.
+type PrinterRepository interface {
+	// Save inserts or updates a printer. It returns ErrDuplicatePrinter if
+	// another printer has its name.
+	Save(ctx context.Context, p *Printer) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Printer, error)
+	// FindAll returns every printer by name.
+	FindAll(ctx context.Context) ([]*Printer, error)
+}
+
+// JobRepository provides access to the print queue.
+type JobRepository interface {
+	// Save inserts or updates a job.
+	Save(ctx context.Context, j *Job) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	// FindAll returns jobs, newest first.
+	FindAll(ctx context.Context, filter JobFilter) ([]*Job, error)
+	// ClaimNext makes the oldest queued job that is due as of now an
+	// Attempt with the given lease and returns it, so that concurrent
+	// senders never claim the same job. It returns ErrNoJobDue when the
+	// queue has nothing due.
+	ClaimNext(ctx context.Context, lease time.Duration, now time.Time) (*Job, error)
+}
+
+// Transport sends print data to a printer at a network address.
+type Transport interface {
+	Send(ctx context.Context, address string, payload []byte) error
+}
@@ -0,0 +1,90 @@
+package label
+
+// Field is one piece of information on a label.
+type Field string
+
+const (
+	FieldDescription Field = "description"
+	FieldWeight      Field = "weight"
+	FieldPricePerKg  Field = "price_per_kg"
+	FieldTotal       Field = "total"
+	FieldPackedOn    Field = "packed_on"
+	FieldUseBy       Field = "use_by"
+	FieldLotCode     Field = "lot_code"
+	FieldAllergens   Field = "allergens"
+	FieldHalal       Field = "halal"
+	FieldBarcode     Field = "barcode"
+)
+
+// Element places a field on a label. Positions and sizes are in
+// millimetres from the top left corner. Height is the height of the text,
+// of the halal badge or of the barcode's bars; text wider than Width wraps
+// onto at most Lines lines.
+type Element struct {
+	Field  Field
+	X, Y   float64
+	Width  float64
+	Height float64
+	Lines  int
+	Bold   bool
+}
+
+// Template lays out a label of a given size. Every renderer draws the same
+// template, so a pack label looks alike whichever printer it comes out of.
+type Template struct {
+	Name     string
+	WidthMM  float64
+	HeightMM float64
+	Elements []Element
+}
+
+// Templates are the label layouts on offer: a 60 mm square label for the
+// counter's thermal printers and a 100 by 70 mm one for large packs and
+// boxed orders.
+var Templates = []Template{
+	{
+		Name: "standard", WidthMM: 60, HeightMM: 60,
+		Elements: []Element{
+			{Field: FieldDescription, X: 2, Y: 2, Width: 56, Height: 4.5, Lines: 2, Bold: true},
+			{Field: FieldWeight, X: 2, Y: 13, Width: 28, Height: 3.5, Lines: 1},
+			{Field: FieldPricePerKg, X: 2, Y: 17.5, Width: 28, Height: 3.5, Lines: 1},
+			{Field: FieldTotal, X: 30, Y: 13, Width: 28, Height: 7, Lines: 1, Bold: true},
+			{Field: FieldPackedOn, X: 2, Y: 22.5, Width: 40, Height: 3, Lines: 1},
+			{Field: FieldUseBy, X: 2, Y: 26.5, Width: 40, Height: 3.5, Lines: 1, Bold: true},
+			{Field: FieldLotCode, X: 2, Y: 31, Width: 40, Height: 3, Lines: 1},
+			{Field: FieldAllergens, X: 2, Y: 35, Width: 40, Height: 2.5, Lines: 2},
+			{Field: FieldHalal, X: 44, Y: 23, Width: 14, Height: 14},
+			{Field: FieldBarcode, X: 7, Y: 42, Width: 46, Height: 12},
+		},
+	},
+	{
+		Name: "large", WidthMM: 100, HeightMM: 70,
+		Elements: []Element{
+			{Field: FieldDescription, X: 3, Y: 3, Width: 94, Height: 6, Lines: 2, Bold: true},
+			{Field: FieldWeight, X: 3, Y: 18, Width: 45, Height: 4.5, Lines: 1},
+			{Field: FieldPricePerKg, X: 3, Y: 24, Width: 45, Height: 4.5, Lines: 1},
+			{Field: FieldTotal, X: 55, Y: 18, Width: 42, Height: 10, Lines: 1, Bold: true},
+			{Field: FieldPackedOn, X: 3, Y: 31, Width: 60, Height: 3.5, Lines: 1},
+			{Field: FieldUseBy, X: 3, Y: 36, Width: 60, Height: 4.5, Lines: 1, Bold: true},
+			{Field: FieldLotCode, X: 3, Y: 42, Width: 60, Height: 3.5, Lines: 1},
+			{Field: FieldAllergens, X: 3, Y: 47, Width: 60, Height: 3, Lines: 2},
+			{Field: FieldHalal, X: 77, Y: 31, Width: 20, Height: 20},
+			{Field: FieldBarcode, X: 66, Y: 54, Width: 31, Height: 9},
+		},
+	},
+}
+
+// FindTemplate returns the template with the given name.
+func FindTemplate(name string) (Template, error) {
+	for _, t := range Templates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Template{}, ErrTemplateNotFound
+}
+
+// Renderer draws labels on a template, one label per page or per print.
+type Renderer interface {
+	Render(t Template, labels []Label) ([]byte, error)
+}
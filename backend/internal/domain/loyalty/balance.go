@@ -0,0 +1,140 @@
+package loyalty
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Balance is the projection of a customer's ledger. Points is what the
+// customer can spend; the other totals are gross, so adjustments and
+// reversals show only in Points.
+type Balance struct {
+	CustomerID     uuid.UUID
+	Points         int64
+	EarnedPoints   int64
+	RedeemedPoints int64
+	ExpiredPoints  int64
+	Entries        int
+	UpdatedAt      time.Time
+}
+
+// Apply moves the balance by one entry.
+func (b Balance) Apply(e *Entry) Balance {
+	b.Points += e.Points
+	switch e.Kind {
+	case KindEarn, KindBirthday:
+		b.EarnedPoints += e.Points
+	case KindRedeem:
+		b.RedeemedPoints -= e.Points
+	case KindExpire:
+		b.ExpiredPoints -= e.Points
+	}
+	b.Entries++
+	if e.CreatedAt.After(b.UpdatedAt) {
+		b.UpdatedAt = e.CreatedAt
+	}
+	return b
+}
+
+// Project rebuilds a customer's balance from their entries.
+func Project(customerID uuid.UUID, entries []*Entry) Balance {
+	b := Balance{CustomerID: customerID}
+	for _, e := range entries {
+		b = b.Apply(e)
+	}
+	return b
+}
+
+// Expiry is what is left of one credit once its points have expired.
+type Expiry struct {
+	SourceID uuid.UUID
+	Points   int64
+}
+
+// Expiring works out which credits have expired by at with points still on
+// them, given a customer's entries oldest first. Points are spent from the
+// credit that expires soonest, so a customer never loses points they could
+// have spent instead; expire and reversal entries take off the credit they
+// name.
+func Expiring(entries []*Entry, at time.Time) []Expiry {
+	type credit struct {
+		id        uuid.UUID
+		left      int64
+		expiresAt *time.Time
+	}
+	var credits []*credit
+	byID := map[uuid.UUID]*credit{}
+
+	spend := func(points int64) {
+		open := make([]*credit, 0, len(credits))
+		for _, c := range credits {
+			if c.left > 0 {
+				open = append(open, c)
+			}
+		}
+		slices.SortStableFunc(open, func(a, b *credit) int {
+			switch {
+			case a.expiresAt == nil && b.expiresAt == nil:
+				return 0
+			case a.expiresAt == nil:
+				return 1
+			case b.expiresAt == nil:
+				return -1
+			}
+			return a.expiresAt.Compare(*b.expiresAt)
+		})
+		for _, c := range open {
+			if points == 0 {
+				return
+			}
+			take := min(points, c.left)
+			c.left -= take
+			points -= take
+		}
+	}
+
+	for _, e := range entries {
+		switch {
+		case e.Points > 0:
+			c := &credit{id: e.ID, left: e.Points, expiresAt: e.ExpiresAt}
+			credits = append(credits, c)
+			byID[e.ID] = c
+		case e.SourceID != nil && byID[*e.SourceID] != nil:
+			c := byID[*e.SourceID]
+			take := min(-e.Points, c.left)
+			c.left -= take
+			spend(-e.Points - take)
+		default:
+			spend(-e.Points)
+		}
+	}
+
+	var expired []Expiry
+	for _, c := range credits {
+		if c.left > 0 && c.expiresAt != nil && !c.expiresAt.After(at) {
+			expired = append(expired, Expiry{SourceID: c.id, Points: c.left})
+		}
+	}
+	return expired
+}
+
+// ExpireEntries creates the entries that take off the points expired by at,
+// one for each credit they came from.
+func ExpireEntries(customerID uuid.UUID, entries []*Entry, at time.Time) []*Entry {
+	expiring := Expiring(entries, at)
+	out := make([]*Entry, 0, len(expiring))
+	for _, x := range expiring {
+		sourceID := x.SourceID
+		out = append(out, &Entry{
+			ID:         uuid.New(),
+			CustomerID: customerID,
+			Kind:       KindExpire,
+			Points:     -x.Points,
+			SourceID:   &sourceID,
+			CreatedAt:  at,
+		})
+	}
+	return out
+}
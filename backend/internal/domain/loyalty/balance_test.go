@@ -0,0 +1,94 @@
+package loyalty_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entry(t *testing.T, customerID uuid.UUID, kind loyalty.Kind, points int64, source *loyalty.Entry, expiresAt *time.Time, at time.Time) *loyalty.Entry {
+	t.Helper()
+	var sourceID *uuid.UUID
+	if source != nil {
+		sourceID = &source.ID
+	}
+	e, err := loyalty.NewEntry(uuid.New(), customerID, kind, points, "", sourceID, expiresAt, "note", nil, at)
+	require.NoError(t, err)
+	return e
+}
+
+func TestProject(t *testing.T) {
+	customerID := uuid.New()
+	earn := entry(t, customerID, loyalty.KindEarn, 300, nil, nil, now)
+	entries := []*loyalty.Entry{
+		earn,
+		entry(t, customerID, loyalty.KindBirthday, 100, nil, nil, now.Add(time.Hour)),
+		entry(t, customerID, loyalty.KindRedeem, -250, nil, nil, now.Add(2*time.Hour)),
+		entry(t, customerID, loyalty.KindAdjust, -20, nil, nil, now.Add(3*time.Hour)),
+		entry(t, customerID, loyalty.KindExpire, -30, earn, nil, now.Add(4*time.Hour)),
+	}
+
+	b := loyalty.Project(customerID, entries)
+
+	assert.Equal(t, int64(100), b.Points)
+	assert.Equal(t, int64(400), b.EarnedPoints)
+	assert.Equal(t, int64(250), b.RedeemedPoints)
+	assert.Equal(t, int64(30), b.ExpiredPoints)
+	assert.Equal(t, 5, b.Entries)
+	assert.Equal(t, now.Add(4*time.Hour), b.UpdatedAt)
+
+	var incremental loyalty.Balance
+	incremental.CustomerID = customerID
+	for _, e := range entries {
+		incremental = incremental.Apply(e)
+	}
+	assert.Equal(t, b, incremental, "applying entries one at a time matches a rebuild")
+}
+
+func TestExpiring(t *testing.T) {
+	customerID := uuid.New()
+	soon := now.AddDate(0, 1, 0)
+	later := now.AddDate(0, 6, 0)
+
+	t.Run("spending comes off the credit that expires first", func(t *testing.T) {
+		late := entry(t, customerID, loyalty.KindEarn, 200, nil, &later, now)
+		early := entry(t, customerID, loyalty.KindEarn, 100, nil, &soon, now.Add(time.Minute))
+		entries := []*loyalty.Entry{late, early, entry(t, customerID, loyalty.KindRedeem, -80, nil, nil, now.Add(time.Hour))}
+
+		assert.Empty(t, loyalty.Expiring(entries, soon.Add(-time.Second)))
+		assert.Equal(t, []loyalty.Expiry{{SourceID: early.ID, Points: 20}}, loyalty.Expiring(entries, soon))
+		assert.Equal(t, []loyalty.Expiry{{SourceID: late.ID, Points: 200}, {SourceID: early.ID, Points: 20}},
+			loyalty.Expiring(entries, later))
+	})
+
+	t.Run("expired credits are not expired twice", func(t *testing.T) {
+		earn := entry(t, customerID, loyalty.KindEarn, 100, nil, &soon, now)
+		entries := []*loyalty.Entry{earn, entry(t, customerID, loyalty.KindExpire, -100, earn, nil, soon.Add(time.Hour))}
+
+		assert.Empty(t, loyalty.Expiring(entries, later))
+	})
+
+	t.Run("points that never expire stay", func(t *testing.T) {
+		entries := []*loyalty.Entry{entry(t, customerID, loyalty.KindAdjust, 50, nil, nil, now)}
+
+		assert.Empty(t, loyalty.Expiring(entries, later))
+	})
+}
+
+func TestExpireEntries(t *testing.T) {
+	customerID := uuid.New()
+	soon := now.AddDate(0, 1, 0)
+	earn := entry(t, customerID, loyalty.KindEarn, 100, nil, &soon, now)
+
+	out := loyalty.ExpireEntries(customerID, []*loyalty.Entry{earn}, soon)
+
+	require.Len(t, out, 1)
+	assert.Equal(t, loyalty.KindExpire, out[0].Kind)
+	assert.Equal(t, int64(-100), out[0].Points)
+	assert.Equal(t, earn.ID, *out[0].SourceID)
+	assert.Equal(t, soon, out[0].CreatedAt)
+}
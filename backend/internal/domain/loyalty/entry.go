@@ -0,0 +1,100 @@
+package loyalty
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is what moved a customer's points.
+type Kind string
+
+const (
+	KindEarn     Kind = "earn"
+	KindBirthday Kind = "birthday"
+	KindRedeem   Kind = "redeem"
+	KindExpire   Kind = "expire"
+	KindAdjust   Kind = "adjust"
+	KindReversal Kind = "reversal"
+)
+
+// Entry is one movement on a customer's points ledger. Entries are never
+// changed or removed once written; a mistake is undone by a reversal entry
+// that points back at it through SourceID, and points that expire are taken
+// off by an expire entry naming the credit they came from.
+//
+// Reference makes earning, redeeming and birthday bonuses idempotent: a
+// customer has at most one entry of each of those kinds per reference, such
+// as the order ID or the year of the birthday.
+type Entry struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Kind       Kind
+	Points     int64
+	Reference  string
+	SourceID   *uuid.UUID
+	ExpiresAt  *time.Time
+	Note       string
+	CreatedBy  *uuid.UUID
+	CreatedAt  time.Time
+}
+
+// Credit reports whether the entry adds points.
+func (e *Entry) Credit() bool { return e.Points > 0 }
+
+// NewEntry creates a ledger entry. Points are signed: earn and birthday
+// entries add points, redeem and expire entries take them off, and
+// adjustments and reversals may do either. Only credits can expire.
+func NewEntry(id, customerID uuid.UUID, kind Kind, points int64, reference string, sourceID *uuid.UUID, expiresAt *time.Time, note string, createdBy *uuid.UUID, now time.Time) (*Entry, error) {
+	switch kind {
+	case KindEarn, KindBirthday:
+		if points <= 0 {
+			return nil, ErrInvalidPoints
+		}
+	case KindRedeem, KindExpire:
+		if points >= 0 {
+			return nil, ErrInvalidPoints
+		}
+	case KindAdjust, KindReversal:
+		if points == 0 {
+			return nil, ErrInvalidPoints
+		}
+	default:
+		return nil, ErrInvalidKind
+	}
+	if (kind == KindExpire || kind == KindReversal) && sourceID == nil {
+		return nil, ErrSourceRequired
+	}
+	note = strings.TrimSpace(note)
+	if kind == KindAdjust && note == "" {
+		return nil, ErrNoteRequired
+	}
+	if expiresAt != nil && (points < 0 || !expiresAt.After(now)) {
+		return nil, ErrInvalidExpiry
+	}
+	return &Entry{
+		ID:         id,
+		CustomerID: customerID,
+		Kind:       kind,
+		Points:     points,
+		Reference:  strings.TrimSpace(reference),
+		SourceID:   sourceID,
+		ExpiresAt:  expiresAt,
+		Note:       note,
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+	}, nil
+}
+
+// Reverse creates the entry that undoes e, e.g. points redeemed on an order
+// that could not be saved. Points given back carry the expiry passed in.
+func (e *Entry) Reverse(id uuid.UUID, expiresAt *time.Time, note string, now time.Time) (*Entry, error) {
+	if e.Kind == KindReversal || e.Kind == KindExpire {
+		return nil, ErrNotReversible
+	}
+	if e.Points > 0 {
+		expiresAt = nil
+	}
+	return NewEntry(id, e.CustomerID, KindReversal, -e.Points, "", &e.ID, expiresAt, note, nil, now)
+}
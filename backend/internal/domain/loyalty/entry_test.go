@@ -0,0 +1,75 @@
+package loyalty_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func TestNewEntry(t *testing.T) {
+	customerID := uuid.New()
+	expires := now.AddDate(1, 0, 0)
+
+	e, err := loyalty.NewEntry(uuid.New(), customerID, loyalty.KindEarn, 120, " order-1 ", nil, &expires, "", nil, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, customerID, e.CustomerID)
+	assert.Equal(t, "order-1", e.Reference)
+	assert.True(t, e.Credit())
+	assert.Equal(t, now, e.CreatedAt)
+}
+
+func TestNewEntry_Validation(t *testing.T) {
+	source := uuid.New()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		kind      loyalty.Kind
+		points    int64
+		sourceID  *uuid.UUID
+		expiresAt *time.Time
+		note      string
+		want      error
+	}{
+		{"unknown kind", "gift", 10, nil, nil, "", loyalty.ErrInvalidKind},
+		{"earn must add", loyalty.KindEarn, -10, nil, nil, "", loyalty.ErrInvalidPoints},
+		{"redeem must take off", loyalty.KindRedeem, 10, nil, nil, "", loyalty.ErrInvalidPoints},
+		{"zero adjustment", loyalty.KindAdjust, 0, nil, nil, "goodwill", loyalty.ErrInvalidPoints},
+		{"adjustment without note", loyalty.KindAdjust, 10, nil, nil, " ", loyalty.ErrNoteRequired},
+		{"expire without source", loyalty.KindExpire, -10, nil, nil, "", loyalty.ErrSourceRequired},
+		{"reversal without source", loyalty.KindReversal, 10, nil, nil, "", loyalty.ErrSourceRequired},
+		{"debit with expiry", loyalty.KindExpire, -10, &source, &future, "", loyalty.ErrInvalidExpiry},
+		{"expiry in the past", loyalty.KindBirthday, 10, nil, &past, "", loyalty.ErrInvalidExpiry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loyalty.NewEntry(uuid.New(), uuid.New(), tt.kind, tt.points, "", tt.sourceID, tt.expiresAt, tt.note, nil, now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestEntry_Reverse(t *testing.T) {
+	expires := now.AddDate(1, 0, 0)
+	redeem, err := loyalty.NewEntry(uuid.New(), uuid.New(), loyalty.KindRedeem, -500, "order-1", nil, nil, "", nil, now)
+	require.NoError(t, err)
+
+	r, err := redeem.Reverse(uuid.New(), &expires, "order not saved", now)
+
+	require.NoError(t, err)
+	assert.Equal(t, loyalty.KindReversal, r.Kind)
+	assert.Equal(t, int64(500), r.Points)
+	assert.Equal(t, redeem.ID, *r.SourceID)
+	assert.Equal(t, &expires, r.ExpiresAt)
+
+	_, err = r.Reverse(uuid.New(), nil, "", now)
+	assert.ErrorIs(t, err, loyalty.ErrNotReversible)
+}
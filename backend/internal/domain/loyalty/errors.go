@@ -0,0 +1,23 @@
+package loyalty
+
+import "errors"
+
+var (
+	ErrInvalidKind        = errors.New("entry kind must be one of earn, birthday, redeem, expire, adjust or reversal")
+	ErrInvalidPoints      = errors.New("points must be non-zero and have the sign the entry kind requires")
+	ErrSourceRequired     = errors.New("expire and reversal entries must name the entry they take points from")
+	ErrNoteRequired       = errors.New("a note is required for adjustments")
+	ErrInvalidExpiry      = errors.New("only credits can expire, and only after they are made")
+	ErrNotReversible      = errors.New("expire and reversal entries cannot be reversed")
+	ErrInvalidUnit        = errors.New("the currency unit points are earned per must be greater than zero")
+	ErrNegativeRule       = errors.New("earn and redeem rates, the minimum and the birthday bonus must not be negative")
+	ErrInvalidExpiryDays  = errors.New("expiry days must not be negative")
+	ErrInvalidBirthday    = errors.New("birthday must be a real day and month")
+	ErrInvalidDate        = errors.New("date must be in YYYY-MM-DD format")
+	ErrRedemptionDisabled = errors.New("points cannot be redeemed at the moment")
+	ErrBelowMinimum       = errors.New("fewer points than the minimum that can be redeemed at once")
+	ErrNothingToRedeem    = errors.New("the order is too small to redeem points against")
+	ErrInsufficientPoints = errors.New("customer does not have enough points")
+	ErrDuplicateEntry     = errors.New("points were already recorded for that reference")
+	ErrManageNotAllowed   = errors.New("only managers may change the loyalty scheme or adjust points")
+)
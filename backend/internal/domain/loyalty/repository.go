@@ -0,0 +1,51 @@
+package loyalty
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ledger is the append-only store of points entries and the balance
+// projected from them.
+type Ledger interface {
+	// Append writes entries and moves their customers' balances in one
+	// transaction. Each balance is locked while it moves, and entries that
+	// would leave it below zero return ErrInsufficientPoints and write
+	// nothing. An entry whose reference or source was already used returns
+	// ErrDuplicateEntry.
+	Append(ctx context.Context, entries ...*Entry) error
+	// Entries returns a customer's entries, oldest first.
+	Entries(ctx context.Context, customerID uuid.UUID) ([]*Entry, error)
+	// Balance returns a customer's projected balance; customers with no
+	// entries have an empty one.
+	Balance(ctx context.Context, customerID uuid.UUID) (Balance, error)
+	// Rebuild recomputes a customer's balance from their entries and
+	// replaces the projection with it.
+	Rebuild(ctx context.Context, customerID uuid.UUID) (Balance, error)
+	// CustomersWithExpiring returns the customers holding credits that
+	// expire by at.
+	CustomersWithExpiring(ctx context.Context, at time.Time) ([]uuid.UUID, error)
+}
+
+// RulesRepository stores the earn and redeem rules.
+type RulesRepository interface {
+	// Find returns the rules with every product bonus.
+	Find(ctx context.Context) (Rules, error)
+	// Save replaces the rules, leaving product bonuses as they are.
+	Save(ctx context.Context, r Rules) error
+	// SetProductBonus sets the extra points per unit a product earns; zero
+	// removes the bonus.
+	SetProductBonus(ctx context.Context, productID uuid.UUID, points int64) error
+}
+
+// MemberRepository stores what loyalty needs to know about customers
+// beyond their account.
+type MemberRepository interface {
+	SetBirthday(ctx context.Context, customerID uuid.UUID, b Birthday) error
+	// FindBirthday returns nil when the customer has not given one.
+	FindBirthday(ctx context.Context, customerID uuid.UUID) (*Birthday, error)
+	// FindByBirthday returns the customers whose birthday falls on date.
+	FindByBirthday(ctx context.Context, date time.Time) ([]uuid.UUID, error)
+}
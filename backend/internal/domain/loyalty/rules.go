@@ -0,0 +1,134 @@
+package loyalty
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// Rules say how customers earn and spend points. Customers earn
+// PointsPerUnit for every UnitCents they pay, plus any bonus set on the
+// products they buy, and BirthdayBonus once a year on their birthday. At
+// checkout each point is worth PointValueCents, and at least MinRedeemPoints
+// must be spent at once. Points expire ExpiryDays after they are earned, or
+// never when ExpiryDays is zero.
+type Rules struct {
+	PointsPerUnit   int64
+	UnitCents       int64
+	PointValueCents int64
+	MinRedeemPoints int64
+	BirthdayBonus   int64
+	ExpiryDays      int
+	ProductBonuses  map[uuid.UUID]int64
+}
+
+// NewRules validates earn and redeem rules. Product bonuses are set one
+// product at a time and are not part of the check.
+func NewRules(r Rules) (Rules, error) {
+	if r.UnitCents <= 0 {
+		return Rules{}, ErrInvalidUnit
+	}
+	if r.PointsPerUnit < 0 || r.PointValueCents < 0 || r.MinRedeemPoints < 0 || r.BirthdayBonus < 0 {
+		return Rules{}, ErrNegativeRule
+	}
+	if r.ExpiryDays < 0 {
+		return Rules{}, ErrInvalidExpiryDays
+	}
+	return r, nil
+}
+
+// Line is what was paid for one product on an order.
+type Line struct {
+	ProductID   uuid.UUID
+	AmountCents int64
+}
+
+// Earn works out the points an order earns. The base rate applies to the
+// order as a whole and product bonuses line by line; part units earn
+// nothing.
+func (r Rules) Earn(lines []Line) int64 {
+	var total, bonus int64
+	for _, l := range lines {
+		if l.AmountCents <= 0 {
+			continue
+		}
+		total += l.AmountCents
+		bonus += l.AmountCents * r.ProductBonuses[l.ProductID] / r.UnitCents
+	}
+	return total*r.PointsPerUnit/r.UnitCents + bonus
+}
+
+// ExpiresAt is when points credited at now expire, or nil if they never do.
+func (r Rules) ExpiresAt(now time.Time) *time.Time {
+	if r.ExpiryDays == 0 {
+		return nil
+	}
+	t := now.AddDate(0, 0, r.ExpiryDays)
+	return &t
+}
+
+// Redemption is what spending points took off an order: LineCents per line
+// and TotalCents overall.
+type Redemption struct {
+	Points     int64
+	LineCents  []int64
+	TotalCents int64
+}
+
+// Redeem works out the discount for spending points on an order whose lines
+// are still to pay lineCents. Only as many points are spent as the order
+// can absorb, so a customer asking for more than that keeps the rest; the
+// discount is spread across the lines in proportion.
+func (r Rules) Redeem(points int64, lineCents []int64) (Redemption, error) {
+	if points <= 0 {
+		return Redemption{}, ErrInvalidPoints
+	}
+	if r.PointValueCents == 0 {
+		return Redemption{}, ErrRedemptionDisabled
+	}
+	if points < r.MinRedeemPoints {
+		return Redemption{}, ErrBelowMinimum
+	}
+	var payable int64
+	for _, c := range lineCents {
+		payable += c
+	}
+	points = min(points, payable/r.PointValueCents)
+	if points == 0 || points < r.MinRedeemPoints {
+		return Redemption{}, ErrNothingToRedeem
+	}
+	total := points * r.PointValueCents
+	return Redemption{Points: points, LineCents: tax.Allocate(total, lineCents), TotalCents: total}, nil
+}
+
+// Birthday is a customer's day and month of birth.
+type Birthday struct {
+	Month time.Month
+	Day   int
+}
+
+// NewBirthday validates a day and month, allowing 29 February.
+func NewBirthday(month, day int) (Birthday, error) {
+	if month < 1 || month > 12 || day < 1 {
+		return Birthday{}, ErrInvalidBirthday
+	}
+	// 2000 was a leap year, so this accepts 29 February.
+	if t := time.Date(2000, time.Month(month), day, 0, 0, 0, 0, time.UTC); t.Day() != day {
+		return Birthday{}, ErrInvalidBirthday
+	}
+	return Birthday{Month: time.Month(month), Day: day}, nil
+}
+
+// On reports whether the birthday falls on date's calendar day. Birthdays on
+// 29 February fall on 28 February in other years.
+func (b Birthday) On(date time.Time) bool {
+	if b.Month == time.February && b.Day == 29 && !isLeap(date.Year()) {
+		return date.Month() == time.February && date.Day() == 28
+	}
+	return date.Month() == b.Month && date.Day() == b.Day
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,94 @@
+package loyalty_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRules_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules loyalty.Rules
+		want  error
+	}{
+		{"zero unit", loyalty.Rules{PointsPerUnit: 1}, loyalty.ErrInvalidUnit},
+		{"negative rate", loyalty.Rules{UnitCents: 100, PointsPerUnit: -1}, loyalty.ErrNegativeRule},
+		{"negative bonus", loyalty.Rules{UnitCents: 100, BirthdayBonus: -1}, loyalty.ErrNegativeRule},
+		{"negative expiry", loyalty.Rules{UnitCents: 100, ExpiryDays: -1}, loyalty.ErrInvalidExpiryDays},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loyalty.NewRules(tt.rules)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestRules_Earn(t *testing.T) {
+	lamb := uuid.New()
+	r := loyalty.Rules{PointsPerUnit: 1, UnitCents: 100, ProductBonuses: map[uuid.UUID]int64{lamb: 2}}
+
+	points := r.Earn([]loyalty.Line{
+		{ProductID: lamb, AmountCents: 1550},
+		{ProductID: uuid.New(), AmountCents: 460},
+		{ProductID: uuid.New(), AmountCents: 0},
+	})
+
+	// 20.10 earns 20 at the base rate and the lamb's 15.50 another 31.
+	assert.Equal(t, int64(51), points)
+}
+
+func TestRules_ExpiresAt(t *testing.T) {
+	assert.Nil(t, loyalty.Rules{}.ExpiresAt(now))
+	assert.Equal(t, now.AddDate(0, 0, 365), *loyalty.Rules{ExpiryDays: 365}.ExpiresAt(now))
+}
+
+func TestRules_Redeem(t *testing.T) {
+	r := loyalty.Rules{UnitCents: 100, PointValueCents: 1, MinRedeemPoints: 100}
+
+	t.Run("spread across lines", func(t *testing.T) {
+		red, err := r.Redeem(500, []int64{3000, 1000})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), red.Points)
+		assert.Equal(t, []int64{375, 125}, red.LineCents)
+		assert.Equal(t, int64(500), red.TotalCents)
+	})
+
+	t.Run("capped at what is left to pay", func(t *testing.T) {
+		red, err := r.Redeem(5000, []int64{1001, 1000})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2001), red.Points)
+		assert.Equal(t, []int64{1001, 1000}, red.LineCents)
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		_, err := r.Redeem(50, []int64{3000})
+		assert.ErrorIs(t, err, loyalty.ErrBelowMinimum)
+
+		_, err = r.Redeem(200, []int64{50})
+		assert.ErrorIs(t, err, loyalty.ErrNothingToRedeem)
+
+		_, err = loyalty.Rules{UnitCents: 100}.Redeem(200, []int64{3000})
+		assert.ErrorIs(t, err, loyalty.ErrRedemptionDisabled)
+	})
+}
+
+func TestBirthday(t *testing.T) {
+	_, err := loyalty.NewBirthday(2, 30)
+	assert.ErrorIs(t, err, loyalty.ErrInvalidBirthday)
+	_, err = loyalty.NewBirthday(13, 1)
+	assert.ErrorIs(t, err, loyalty.ErrInvalidBirthday)
+
+	leapling, err := loyalty.NewBirthday(2, 29)
+	require.NoError(t, err)
+	assert.True(t, leapling.On(time.Date(2027, 2, 28, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, leapling.On(time.Date(2028, 2, 28, 9, 0, 0, 0, time.UTC)))
+	assert.True(t, leapling.On(time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC)))
+}
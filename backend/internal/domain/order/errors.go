@@ -0,0 +1,38 @@
+package order
+
+import "errors"
+
+var (
+	ErrBranchRequired           = errors.New("order must be placed at a branch")
+	ErrNoLines                  = errors.New("order must contain at least one line")
+	ErrInvalidWeight            = errors.New("weight must be greater than zero")
+	ErrNegativePrice            = errors.New("price must not be negative")
+	ErrInvalidVATRate           = errors.New("VAT rate must be between 0 and 10000 basis points")
+	ErrUnbalancedLine           = errors.New("a line's net amount and VAT must add up to its total")
+	ErrInvalidDiscount          = errors.New("a line's discount must be between zero and its shelf price")
+	ErrOrderNotFound            = errors.New("order not found")
+	ErrInvalidRefundKind        = errors.New("refund kind must be one of line, weight_difference or order")
+	ErrInvalidReason            = errors.New("reason must be one of short_weight, unavailable, quality, late_delivery or other")
+	ErrNoteRequired             = errors.New("a note is required when the reason is other")
+	ErrEmptyIdempotencyKey      = errors.New("idempotency key must not be empty")
+	ErrLineRequired             = errors.New("line and weight-difference refunds must name an order line")
+	ErrUnknownLine              = errors.New("line does not belong to the order")
+	ErrInvalidDeliveredWeight   = errors.New("delivered weight must be at least zero and less than the weight paid for")
+	ErrNothingToRefund          = errors.New("nothing is left to refund")
+	ErrRefundNotFound           = errors.New("refund not found")
+	ErrApprovalNotPermitted     = errors.New("approving refunds requires the manager permission")
+	ErrRefundNotPending         = errors.New("refund is not waiting for approval")
+	ErrRefundNotApproved        = errors.New("only an approved refund can be issued")
+	ErrRefundNotIssued          = errors.New("refund has not been issued")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used for a different refund")
+	ErrConcurrentUpdate         = errors.New("order was changed by someone else; reload and try again")
+	ErrInvalidStage             = errors.New("stage must be one of received, cutting, ready or completed")
+	ErrStageBackwards           = errors.New("order is already at or past that stage")
+	ErrCannotCancel             = errors.New("only an order not yet cut with nothing refunded can be cancelled")
+	ErrOrderCancelled           = errors.New("order has been cancelled")
+	ErrOrderPaid                = errors.New("order has been paid for; refund it instead")
+	ErrHoldsAtDifferentBranches = errors.New("stock and slot are held at different branches")
+	ErrZoneRequired             = errors.New("a delivery charge must name the zone delivered to")
+	ErrAddressRequired          = errors.New("a delivery address is required for a delivery slot")
+	ErrSlotNotForAddress        = errors.New("the slot does not deliver to that address")
+)
@@ -0,0 +1,81 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery is what happened to an order on its delivery run, as recorded
+// in an Event.
+type Delivery string
+
+const (
+	DeliveryOutForDelivery Delivery = "out_for_delivery"
+	DeliveryDelivered      Delivery = "delivered"
+	DeliveryFailed         Delivery = "failed"
+)
+
+// Event is a change to an order that its customer or the cutting room
+// follows: it was placed, moved on a stage, was refunded, or went out for
+// or reached delivery. Status and Stage are the order's as of the event;
+// Delivery is only set for events on its delivery run. Event IDs increase
+// in the order their transactions commit, so a client that has seen one
+// has seen every event before it and can ask for everything after it.
+type Event struct {
+	ID         int64
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	BranchID   uuid.UUID
+	Status     Status
+	Stage      Stage
+	Delivery   Delivery
+	At         time.Time
+}
+
+// EventFilter selects the events of one customer's orders or, for the
+// cutting room, of one branch. Both are optional; AfterID skips the events
+// already seen.
+type EventFilter struct {
+	CustomerID *uuid.UUID
+	BranchID   *uuid.UUID
+	AfterID    int64
+}
+
+// Matches reports whether e is selected by f.
+func (f EventFilter) Matches(e Event) bool {
+	if e.ID <= f.AfterID {
+		return false
+	}
+	if f.CustomerID != nil && e.CustomerID != *f.CustomerID {
+		return false
+	}
+	if f.BranchID != nil && e.BranchID != *f.BranchID {
+		return false
+	}
+	return true
+}
+
+// EventRepository reads the events recorded as orders change. Events are
+// recorded by the database itself, whichever instance made the change.
+type EventRepository interface {
+	// FindEvents returns up to limit events in scope matching f, oldest
+	// first.
+	FindEvents(ctx context.Context, f EventFilter, limit int) ([]Event, error)
+}
+
+// EventFeed listens for order events as they are recorded, by any
+// instance of the API.
+type EventFeed interface {
+	Listen(ctx context.Context) (EventSubscription, error)
+}
+
+// EventSubscription is an open feed of order events. Next and Close may
+// be called from different goroutines.
+type EventSubscription interface {
+	// Next blocks until the next event is recorded. It returns an error
+	// once the subscription is lost, after which it must be closed.
+	Next(ctx context.Context) (Event, error)
+	Close() error
+}
@@ -0,0 +1,34 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilter_Matches(t *testing.T) {
+	customerID, branchID := uuid.New(), uuid.New()
+	e := order.Event{ID: 7, OrderID: uuid.New(), CustomerID: customerID, BranchID: branchID}
+	other := uuid.New()
+
+	tests := []struct {
+		name   string
+		filter order.EventFilter
+		want   bool
+	}{
+		{"everything", order.EventFilter{}, true},
+		{"customer", order.EventFilter{CustomerID: &customerID}, true},
+		{"another customer", order.EventFilter{CustomerID: &other}, false},
+		{"branch", order.EventFilter{BranchID: &branchID}, true},
+		{"another branch", order.EventFilter{BranchID: &other}, false},
+		{"not yet seen", order.EventFilter{AfterID: 6}, true},
+		{"already seen", order.EventFilter{AfterID: 7}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(e))
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package order
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the refund state of an order, or cancelled for an order called
+// off before it was cut.
+type Status string
+
+const (
+	StatusPlaced            Status = "placed"
+	StatusPartiallyRefunded Status = "partially_refunded"
+	StatusRefunded          Status = "refunded"
+	StatusCancelled         Status = "cancelled"
+)
+
+// Stage is how far the cutting room has got with an order. An order only
+// ever moves forward through the stages, though it may skip some.
+type Stage string
+
+const (
+	StageReceived  Stage = "received"
+	StageCutting   Stage = "cutting"
+	StageReady     Stage = "ready"
+	StageCompleted Stage = "completed"
+)
+
+// stageOrder ranks the stages in the order an order goes through them.
+var stageOrder = map[Stage]int{StageReceived: 0, StageCutting: 1, StageReady: 2, StageCompleted: 3}
+
+// ParseStage returns the stage named s.
+func ParseStage(s string) (Stage, error) {
+	st := Stage(strings.TrimSpace(s))
+	if _, ok := stageOrder[st]; !ok {
+		return "", ErrInvalidStage
+	}
+	return st, nil
+}
+
+// Line is the weight of one product sold and its shelf price per
+// kilogram, which includes VAT or not depending on how the shop prices.
+// NetCents, VATCents and GrossCents are the line as the tax calculator
+// priced it when the order was placed, at VATRateBP basis points for its
+// TaxCategory, after DiscountCents of promotions came off its shelf price.
+// RefundedCents is how much of the line has been refunded so far, including
+// its share of whole-order refunds.
+type Line struct {
+	ID              uuid.UUID
+	ProductID       uuid.UUID
+	Description     string
+	TaxCategory     string
+	Grams           int64
+	PricePerKgCents int64
+	VATRateBP       int
+	DiscountCents   int64
+	NetCents        int64
+	VATCents        int64
+	GrossCents      int64
+	RefundedCents   int64
+}
+
+// ShelfCents is the line's weight at its shelf price, before the tax
+// calculator has added or split out VAT.
+func (l Line) ShelfCents() int64 { return PriceOf(l.Grams, l.PricePerKgCents) }
+
+// TotalCents is what the customer paid for the line.
+func (l Line) TotalCents() int64 { return l.GrossCents }
+
+// valueOf is the share of what was paid for the line that grams of it
+// account for.
+func (l Line) valueOf(grams int64) int64 {
+	return (l.GrossCents*grams + l.Grams/2) / l.Grams
+}
+
+// PriceOf prices a weight at a per-kilogram rate, rounded to the nearest cent.
+func PriceOf(grams, pricePerKgCents int64) int64 {
+	return (grams*pricePerKgCents + 500) / 1000
+}
+
+// DeliveryCharge is the fee for delivering an order to the zone its address
+// is in. It is priced as a line is: DiscountCents of promotions came off
+// FeeCents before the tax calculator split what was left into NetCents and
+// VATCents at VATRateBP basis points for TaxCategory.
+type DeliveryCharge struct {
+	ZoneID        uuid.UUID
+	TaxCategory   string
+	FeeCents      int64
+	VATRateBP     int
+	DiscountCents int64
+	NetCents      int64
+	VATCents      int64
+	GrossCents    int64
+}
+
+// Order is a customer's order of weighed products. It records every refund
+// against it, so what can still be refunded is always known from the order
+// alone. Version guards against two people changing it at once.
+type Order struct {
+	id            uuid.UUID
+	customerID    uuid.UUID
+	branchID      uuid.UUID
+	status        Status
+	stage         Stage
+	lines         []Line
+	delivery      *DeliveryCharge
+	refunds       []*Refund
+	refundedCents int64
+	createdBy     *uuid.UUID
+	version       int
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewOrder creates a placed order at a branch, received by the cutting
+// room. Line IDs are assigned here.
+func NewOrder(id, customerID, branchID uuid.UUID, lines []Line, createdBy *uuid.UUID, now time.Time) (*Order, error) {
+	if branchID == uuid.Nil {
+		return nil, ErrBranchRequired
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	placed := make([]Line, 0, len(lines))
+	for _, l := range lines {
+		if l.Grams <= 0 {
+			return nil, ErrInvalidWeight
+		}
+		if l.PricePerKgCents < 0 {
+			return nil, ErrNegativePrice
+		}
+		if l.VATRateBP < 0 || l.VATRateBP > 10000 {
+			return nil, ErrInvalidVATRate
+		}
+		if l.DiscountCents < 0 || l.DiscountCents > l.ShelfCents() {
+			return nil, ErrInvalidDiscount
+		}
+		if l.NetCents < 0 || l.VATCents < 0 || l.NetCents+l.VATCents != l.GrossCents {
+			return nil, ErrUnbalancedLine
+		}
+		placed = append(placed, Line{
+			ID:              uuid.New(),
+			ProductID:       l.ProductID,
+			Description:     strings.TrimSpace(l.Description),
+			TaxCategory:     l.TaxCategory,
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+			VATRateBP:       l.VATRateBP,
+			DiscountCents:   l.DiscountCents,
+			NetCents:        l.NetCents,
+			VATCents:        l.VATCents,
+			GrossCents:      l.GrossCents,
+		})
+	}
+
+	return &Order{
+		id:         id,
+		customerID: customerID,
+		branchID:   branchID,
+		status:     StatusPlaced,
+		stage:      StageReceived,
+		lines:      placed,
+		createdBy:  createdBy,
+		version:    1,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// ReconstructOrder reconstructs an Order from persistence without validation.
+func ReconstructOrder(
+	id, customerID, branchID uuid.UUID,
+	status Status,
+	stage Stage,
+	lines []Line,
+	delivery *DeliveryCharge,
+	refunds []*Refund,
+	refundedCents int64,
+	createdBy *uuid.UUID,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Order {
+	return &Order{
+		id:            id,
+		customerID:    customerID,
+		branchID:      branchID,
+		status:        status,
+		stage:         stage,
+		lines:         lines,
+		delivery:      delivery,
+		refunds:       refunds,
+		refundedCents: refundedCents,
+		createdBy:     createdBy,
+		version:       version,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+func (o *Order) ID() uuid.UUID             { return o.id }
+func (o *Order) CustomerID() uuid.UUID     { return o.customerID }
+func (o *Order) BranchID() uuid.UUID       { return o.branchID }
+func (o *Order) Status() Status            { return o.status }
+func (o *Order) Stage() Stage              { return o.stage }
+func (o *Order) Lines() []Line             { return o.lines }
+func (o *Order) Delivery() *DeliveryCharge { return o.delivery }
+func (o *Order) Refunds() []*Refund        { return o.refunds }
+func (o *Order) RefundedCents() int64      { return o.refundedCents }
+func (o *Order) CreatedBy() *uuid.UUID     { return o.createdBy }
+func (o *Order) Version() int              { return o.version }
+func (o *Order) CreatedAt() time.Time      { return o.createdAt }
+func (o *Order) UpdatedAt() time.Time      { return o.updatedAt }
+
+// TotalCents is what the customer paid for the order, delivery included.
+func (o *Order) TotalCents() int64 {
+	var total int64
+	for _, l := range o.lines {
+		total += l.TotalCents()
+	}
+	if o.delivery != nil {
+		total += o.delivery.GrossCents
+	}
+	return total
+}
+
+// ChargeDelivery adds the delivery fee to an order being placed. The charge
+// is checked as a line is.
+func (o *Order) ChargeDelivery(c DeliveryCharge) error {
+	if c.ZoneID == uuid.Nil {
+		return ErrZoneRequired
+	}
+	if c.FeeCents < 0 {
+		return ErrNegativePrice
+	}
+	if c.VATRateBP < 0 || c.VATRateBP > 10000 {
+		return ErrInvalidVATRate
+	}
+	if c.DiscountCents < 0 || c.DiscountCents > c.FeeCents {
+		return ErrInvalidDiscount
+	}
+	if c.NetCents < 0 || c.VATCents < 0 || c.NetCents+c.VATCents != c.GrossCents {
+		return ErrUnbalancedLine
+	}
+	o.delivery = &c
+	return nil
+}
+
+// Advance moves the order on to a later stage. It returns ErrStageBackwards
+// if the order is already at or past that stage.
+func (o *Order) Advance(to Stage, now time.Time) error {
+	rank, ok := stageOrder[to]
+	if !ok {
+		return ErrInvalidStage
+	}
+	if o.status == StatusCancelled {
+		return ErrOrderCancelled
+	}
+	if rank <= stageOrder[o.stage] {
+		return ErrStageBackwards
+	}
+	o.stage = to
+	o.updatedAt = now
+	return nil
+}
+
+// Cancel calls the order off. Only an order the cutting room has not
+// started on and that nothing has been refunded against can be cancelled;
+// cancelling twice is a no-op.
+func (o *Order) Cancel(now time.Time) error {
+	if o.status == StatusCancelled {
+		return nil
+	}
+	if o.status != StatusPlaced || o.stage != StageReceived || len(o.refunds) > 0 {
+		return ErrCannotCancel
+	}
+	o.status = StatusCancelled
+	o.updatedAt = now
+	return nil
+}
+
+// Line returns the order line with the given ID.
+func (o *Order) Line(id uuid.UUID) (Line, bool) {
+	for _, l := range o.lines {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return Line{}, false
+}
+
+// Refund returns the refund with the given ID.
+func (o *Order) Refund(id uuid.UUID) (*Refund, error) {
+	for _, r := range o.refunds {
+		if r.id == id {
+			return r, nil
+		}
+	}
+	return nil, ErrRefundNotFound
+}
+
+// RefundByIdempotencyKey returns the refund requested with key, or nil if
+// there is none.
+func (o *Order) RefundByIdempotencyKey(key string) *Refund {
+	for _, r := range o.refunds {
+		if r.idempotencyKey == key {
+			return r
+		}
+	}
+	return nil
+}
+
+// RefundableCents is how much of the order can still be refunded. Refunds
+// waiting for approval or to be issued count against it already.
+func (o *Order) RefundableCents() int64 {
+	if o.status == StatusCancelled {
+		return 0
+	}
+	refundable := o.TotalCents() - o.refundedCents
+	for _, r := range o.refunds {
+		if r.IsOpen() {
+			refundable -= r.amountCents
+		}
+	}
+	return max(refundable, 0)
+}
+
+// lineRefundableCents is how much of a line can still be refunded on its own.
+func (o *Order) lineRefundableCents(l Line) int64 {
+	refundable := l.TotalCents() - l.RefundedCents
+	for _, r := range o.refunds {
+		if r.IsOpen() && r.lineID != nil && *r.lineID == l.ID {
+			refundable -= r.amountCents
+		}
+	}
+	return max(refundable, 0)
+}
@@ -0,0 +1,151 @@
+package order_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOrder creates an order of 1.5kg lamb at £20/kg (3000) and 800g mince
+// at £11.25/kg (900), both zero-rated.
+func newOrder(t *testing.T) *order.Order {
+	t.Helper()
+	o, err := order.NewOrder(uuid.New(), uuid.New(), uuid.New(), []order.Line{
+		{ProductID: uuid.New(), Description: " Lamb shoulder ", TaxCategory: "fresh_meat", Grams: 1500, PricePerKgCents: 2000,
+			NetCents: 3000, GrossCents: 3000},
+		{ProductID: uuid.New(), TaxCategory: "fresh_meat", Grams: 800, PricePerKgCents: 1125, NetCents: 900, GrossCents: 900},
+	}, nil, time.Now())
+	require.NoError(t, err)
+	return o
+}
+
+func TestNewOrder_AssignsLineIDs(t *testing.T) {
+	o := newOrder(t)
+
+	assert.Equal(t, order.StatusPlaced, o.Status())
+	assert.Equal(t, order.StageReceived, o.Stage())
+	assert.NotEqual(t, uuid.Nil, o.Lines()[0].ID)
+	assert.Equal(t, "Lamb shoulder", o.Lines()[0].Description)
+	assert.Equal(t, int64(3900), o.TotalCents())
+	assert.Equal(t, int64(3900), o.RefundableCents())
+	assert.Equal(t, 1, o.Version())
+}
+
+func TestNewOrder_InvalidLines_ReturnError(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []order.Line
+		want  error
+	}{
+		{"no lines", nil, order.ErrNoLines},
+		{"zero weight", []order.Line{{ProductID: uuid.New(), PricePerKgCents: 100}}, order.ErrInvalidWeight},
+		{"negative price", []order.Line{{ProductID: uuid.New(), Grams: 1000, PricePerKgCents: -1}}, order.ErrNegativePrice},
+		{"VAT over 100%", []order.Line{{ProductID: uuid.New(), Grams: 1000, VATRateBP: 10001}}, order.ErrInvalidVATRate},
+		{"unbalanced", []order.Line{{ProductID: uuid.New(), Grams: 1000, NetCents: 100, VATCents: 20, GrossCents: 100}}, order.ErrUnbalancedLine},
+		{"discount over shelf price", []order.Line{{ProductID: uuid.New(), Grams: 1000, PricePerKgCents: 100, DiscountCents: 101}}, order.ErrInvalidDiscount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := order.NewOrder(uuid.New(), uuid.New(), uuid.New(), tt.lines, nil, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestNewOrder_NoBranch_ReturnsError(t *testing.T) {
+	_, err := order.NewOrder(uuid.New(), uuid.New(), uuid.Nil, []order.Line{
+		{ProductID: uuid.New(), Grams: 1000, PricePerKgCents: 100},
+	}, nil, time.Now())
+	assert.ErrorIs(t, err, order.ErrBranchRequired)
+}
+
+func TestOrder_ChargeDelivery_AddsToTotal(t *testing.T) {
+	o := newOrder(t)
+
+	err := o.ChargeDelivery(order.DeliveryCharge{ZoneID: uuid.New(), TaxCategory: "delivery", FeeCents: 500, VATRateBP: 2000,
+		NetCents: 417, VATCents: 83, GrossCents: 500})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4400), o.TotalCents())
+	assert.Equal(t, int64(4400), o.RefundableCents())
+}
+
+func TestOrder_ChargeDelivery_Invalid_ReturnsError(t *testing.T) {
+	zoneID := uuid.New()
+	tests := []struct {
+		name   string
+		charge order.DeliveryCharge
+		want   error
+	}{
+		{"no zone", order.DeliveryCharge{FeeCents: 500, NetCents: 500, GrossCents: 500}, order.ErrZoneRequired},
+		{"negative fee", order.DeliveryCharge{ZoneID: zoneID, FeeCents: -1}, order.ErrNegativePrice},
+		{"discount over fee", order.DeliveryCharge{ZoneID: zoneID, FeeCents: 500, DiscountCents: 501}, order.ErrInvalidDiscount},
+		{"unbalanced", order.DeliveryCharge{ZoneID: zoneID, FeeCents: 500, NetCents: 400, VATCents: 80, GrossCents: 500}, order.ErrUnbalancedLine},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOrder(t)
+			assert.ErrorIs(t, o.ChargeDelivery(tt.charge), tt.want)
+			assert.Nil(t, o.Delivery())
+		})
+	}
+}
+
+func TestOrder_Advance_MovesForwardOnly(t *testing.T) {
+	o := newOrder(t)
+
+	require.NoError(t, o.Advance(order.StageCutting, time.Now()))
+	require.NoError(t, o.Advance(order.StageCompleted, time.Now()), "stages may be skipped")
+	assert.Equal(t, order.StageCompleted, o.Stage())
+
+	assert.ErrorIs(t, o.Advance(order.StageReady, time.Now()), order.ErrStageBackwards)
+	assert.ErrorIs(t, o.Advance(order.StageCompleted, time.Now()), order.ErrStageBackwards)
+	assert.ErrorIs(t, o.Advance("packed", time.Now()), order.ErrInvalidStage)
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	t.Run("received order is cancelled and can no longer move on", func(t *testing.T) {
+		o := newOrder(t)
+
+		require.NoError(t, o.Cancel(time.Now()))
+		require.NoError(t, o.Cancel(time.Now()), "cancelling twice is a no-op")
+
+		assert.Equal(t, order.StatusCancelled, o.Status())
+		assert.Equal(t, int64(0), o.RefundableCents())
+		assert.ErrorIs(t, o.Advance(order.StageCutting, time.Now()), order.ErrOrderCancelled)
+	})
+
+	t.Run("order being cut cannot be cancelled", func(t *testing.T) {
+		o := newOrder(t)
+		require.NoError(t, o.Advance(order.StageCutting, time.Now()))
+
+		assert.ErrorIs(t, o.Cancel(time.Now()), order.ErrCannotCancel)
+		assert.Equal(t, order.StatusPlaced, o.Status())
+	})
+}
+
+func TestParseStage(t *testing.T) {
+	st, err := order.ParseStage(" ready ")
+	require.NoError(t, err)
+	assert.Equal(t, order.StageReady, st)
+
+	_, err = order.ParseStage("packed")
+	assert.ErrorIs(t, err, order.ErrInvalidStage)
+}
+
+func TestLine_ShelfAndTotal(t *testing.T) {
+	l := order.Line{Grams: 500, PricePerKgCents: 2500, VATRateBP: 2000, NetCents: 1250, VATCents: 250, GrossCents: 1500}
+
+	assert.Equal(t, int64(1250), l.ShelfCents(), "shelf prices can exclude VAT")
+	assert.Equal(t, int64(1500), l.TotalCents())
+}
+
+func TestPriceOf_RoundsToNearestCent(t *testing.T) {
+	assert.Equal(t, int64(1332), order.PriceOf(1333, 999))
+	assert.Equal(t, int64(1), order.PriceOf(1, 500))
+	assert.Equal(t, int64(0), order.PriceOf(1, 499))
+}
@@ -0,0 +1,339 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundKind is what a refund is for.
+type RefundKind string
+
+const (
+	// RefundKindLine refunds whatever is left of one order line, e.g. a cut
+	// that was unavailable.
+	RefundKindLine RefundKind = "line"
+	// RefundKindWeightDifference refunds the price of the weight a line was
+	// short of what the customer paid for.
+	RefundKindWeightDifference RefundKind = "weight_difference"
+	// RefundKindOrder refunds whatever is left of the whole order.
+	RefundKindOrder RefundKind = "order"
+)
+
+// NewRefundKind validates a raw refund kind.
+func NewRefundKind(raw string) (RefundKind, error) {
+	switch k := RefundKind(raw); k {
+	case RefundKindLine, RefundKindWeightDifference, RefundKindOrder:
+		return k, nil
+	default:
+		return "", ErrInvalidRefundKind
+	}
+}
+
+// Reason is the reason code recorded against a refund.
+type Reason string
+
+const (
+	ReasonShortWeight  Reason = "short_weight"
+	ReasonUnavailable  Reason = "unavailable"
+	ReasonQuality      Reason = "quality"
+	ReasonLateDelivery Reason = "late_delivery"
+	ReasonOther        Reason = "other"
+)
+
+// NewReason validates a raw reason code.
+func NewReason(raw string) (Reason, error) {
+	switch r := Reason(raw); r {
+	case ReasonShortWeight, ReasonUnavailable, ReasonQuality, ReasonLateDelivery, ReasonOther:
+		return r, nil
+	default:
+		return "", ErrInvalidReason
+	}
+}
+
+// RefundStatus is the lifecycle state of a refund.
+type RefundStatus string
+
+const (
+	// RefundStatusPendingApproval refunds are above the approval threshold
+	// and wait for a manager.
+	RefundStatusPendingApproval RefundStatus = "pending_approval"
+	// RefundStatusApproved refunds are cleared to be sent to the payment
+	// provider but have not been confirmed yet.
+	RefundStatusApproved RefundStatus = "approved"
+	RefundStatusIssued   RefundStatus = "issued"
+	RefundStatusRejected RefundStatus = "rejected"
+)
+
+// RefundRequest describes a refund an admin is asking for. LineID is
+// required for line and weight-difference refunds; DeliveredGrams is the
+// weight the customer actually got for a weight-difference refund.
+type RefundRequest struct {
+	Kind           RefundKind
+	LineID         *uuid.UUID
+	DeliveredGrams int64
+	Reason         Reason
+	Note           string
+	IdempotencyKey string
+	RequestedBy    uuid.UUID
+}
+
+// ApprovalPolicy decides whether a refund needs a manager. Refunds above
+// ThresholdCents wait for approval unless the requester may approve them.
+type ApprovalPolicy struct {
+	ThresholdCents int64
+	CanApprove     bool
+}
+
+// Refund is money returned to the customer against an order. Its amount is
+// worked out by the order when it is requested. Once issued it carries the
+// payment refund that moved the money and a receipt number.
+type Refund struct {
+	id              uuid.UUID
+	orderID         uuid.UUID
+	kind            RefundKind
+	lineID          *uuid.UUID
+	deliveredGrams  int64
+	reason          Reason
+	note            string
+	amountCents     int64
+	status          RefundStatus
+	idempotencyKey  string
+	requestedBy     uuid.UUID
+	decidedBy       *uuid.UUID
+	paymentID       *uuid.UUID
+	paymentRefundID *uuid.UUID
+	receiptNumber   string
+	createdAt       time.Time
+	decidedAt       *time.Time
+	issuedAt        *time.Time
+}
+
+// ReconstructRefund reconstructs a Refund from persistence without validation.
+func ReconstructRefund(
+	id, orderID uuid.UUID,
+	kind RefundKind,
+	lineID *uuid.UUID,
+	deliveredGrams int64,
+	reason Reason,
+	note string,
+	amountCents int64,
+	status RefundStatus,
+	idempotencyKey string,
+	requestedBy uuid.UUID,
+	decidedBy, paymentID, paymentRefundID *uuid.UUID,
+	receiptNumber string,
+	createdAt time.Time,
+	decidedAt, issuedAt *time.Time,
+) *Refund {
+	return &Refund{
+		id:              id,
+		orderID:         orderID,
+		kind:            kind,
+		lineID:          lineID,
+		deliveredGrams:  deliveredGrams,
+		reason:          reason,
+		note:            note,
+		amountCents:     amountCents,
+		status:          status,
+		idempotencyKey:  idempotencyKey,
+		requestedBy:     requestedBy,
+		decidedBy:       decidedBy,
+		paymentID:       paymentID,
+		paymentRefundID: paymentRefundID,
+		receiptNumber:   receiptNumber,
+		createdAt:       createdAt,
+		decidedAt:       decidedAt,
+		issuedAt:        issuedAt,
+	}
+}
+
+func (r *Refund) ID() uuid.UUID               { return r.id }
+func (r *Refund) OrderID() uuid.UUID          { return r.orderID }
+func (r *Refund) Kind() RefundKind            { return r.kind }
+func (r *Refund) LineID() *uuid.UUID          { return r.lineID }
+func (r *Refund) DeliveredGrams() int64       { return r.deliveredGrams }
+func (r *Refund) Reason() Reason              { return r.reason }
+func (r *Refund) Note() string                { return r.note }
+func (r *Refund) AmountCents() int64          { return r.amountCents }
+func (r *Refund) Status() RefundStatus        { return r.status }
+func (r *Refund) IdempotencyKey() string      { return r.idempotencyKey }
+func (r *Refund) RequestedBy() uuid.UUID      { return r.requestedBy }
+func (r *Refund) DecidedBy() *uuid.UUID       { return r.decidedBy }
+func (r *Refund) PaymentID() *uuid.UUID       { return r.paymentID }
+func (r *Refund) PaymentRefundID() *uuid.UUID { return r.paymentRefundID }
+func (r *Refund) ReceiptNumber() string       { return r.receiptNumber }
+func (r *Refund) CreatedAt() time.Time        { return r.createdAt }
+func (r *Refund) DecidedAt() *time.Time       { return r.decidedAt }
+func (r *Refund) IssuedAt() *time.Time        { return r.issuedAt }
+
+// IsOpen reports whether the refund still holds back part of the order
+// without having been paid out.
+func (r *Refund) IsOpen() bool {
+	return r.status == RefundStatusPendingApproval || r.status == RefundStatusApproved
+}
+
+// ReceiptNumber formats the sequence number of an issued refund's receipt.
+func ReceiptNumber(seq int64) string {
+	return fmt.Sprintf("RF-%06d", seq)
+}
+
+// RequestRefund works out the amount of a refund and records it on the
+// order. A refund over the policy's threshold waits for a manager unless the
+// requester is one; anything else is approved straight away and still has
+// to be issued.
+func (o *Order) RequestRefund(id uuid.UUID, req RefundRequest, policy ApprovalPolicy, now time.Time) (*Refund, error) {
+	key := strings.TrimSpace(req.IdempotencyKey)
+	if key == "" {
+		return nil, ErrEmptyIdempotencyKey
+	}
+	if _, err := NewRefundKind(string(req.Kind)); err != nil {
+		return nil, err
+	}
+	if _, err := NewReason(string(req.Reason)); err != nil {
+		return nil, err
+	}
+	note := strings.TrimSpace(req.Note)
+	if req.Reason == ReasonOther && note == "" {
+		return nil, ErrNoteRequired
+	}
+
+	amount := o.RefundableCents()
+	var lineID *uuid.UUID
+	var deliveredGrams int64
+	if req.Kind != RefundKindOrder {
+		if req.LineID == nil {
+			return nil, ErrLineRequired
+		}
+		l, ok := o.Line(*req.LineID)
+		if !ok {
+			return nil, ErrUnknownLine
+		}
+		lineID = &l.ID
+		lineAmount := o.lineRefundableCents(l)
+		if req.Kind == RefundKindWeightDifference {
+			if req.DeliveredGrams < 0 || req.DeliveredGrams >= l.Grams {
+				return nil, ErrInvalidDeliveredWeight
+			}
+			deliveredGrams = req.DeliveredGrams
+			lineAmount = min(lineAmount, l.TotalCents()-l.valueOf(deliveredGrams))
+		}
+		amount = min(amount, lineAmount)
+	}
+	if amount <= 0 {
+		return nil, ErrNothingToRefund
+	}
+
+	r := &Refund{
+		id:             id,
+		orderID:        o.id,
+		kind:           req.Kind,
+		lineID:         lineID,
+		deliveredGrams: deliveredGrams,
+		reason:         req.Reason,
+		note:           note,
+		amountCents:    amount,
+		status:         RefundStatusPendingApproval,
+		idempotencyKey: key,
+		requestedBy:    req.RequestedBy,
+		createdAt:      now,
+	}
+	if amount <= policy.ThresholdCents || policy.CanApprove {
+		r.status = RefundStatusApproved
+		r.decidedBy = &req.RequestedBy
+		r.decidedAt = &now
+	}
+
+	o.refunds = append(o.refunds, r)
+	o.updatedAt = now
+	return r, nil
+}
+
+// ApproveRefund clears a refund waiting for a manager to be issued.
+func (o *Order) ApproveRefund(refundID, approverID uuid.UUID, canApprove bool, now time.Time) (*Refund, error) {
+	r, err := o.pendingRefund(refundID, canApprove)
+	if err != nil {
+		return nil, err
+	}
+	r.status = RefundStatusApproved
+	r.decidedBy = &approverID
+	r.decidedAt = &now
+	o.updatedAt = now
+	return r, nil
+}
+
+// RejectRefund turns down a refund waiting for a manager, releasing the
+// amount it held back.
+func (o *Order) RejectRefund(refundID, approverID uuid.UUID, canApprove bool, now time.Time) (*Refund, error) {
+	r, err := o.pendingRefund(refundID, canApprove)
+	if err != nil {
+		return nil, err
+	}
+	r.status = RefundStatusRejected
+	r.decidedBy = &approverID
+	r.decidedAt = &now
+	o.updatedAt = now
+	return r, nil
+}
+
+func (o *Order) pendingRefund(refundID uuid.UUID, canApprove bool) (*Refund, error) {
+	if !canApprove {
+		return nil, ErrApprovalNotPermitted
+	}
+	r, err := o.Refund(refundID)
+	if err != nil {
+		return nil, err
+	}
+	if r.status != RefundStatusPendingApproval {
+		return nil, ErrRefundNotPending
+	}
+	return r, nil
+}
+
+// IssueRefund records that an approved refund has been paid back through
+// the given payment. The amount is taken off its line, or spread over the
+// lines in order for a whole-order refund, and the order's status follows
+// what has been refunded in total.
+func (o *Order) IssueRefund(refundID, paymentID, paymentRefundID uuid.UUID, receiptNumber string, now time.Time) (*Refund, error) {
+	r, err := o.Refund(refundID)
+	if err != nil {
+		return nil, err
+	}
+	if r.status != RefundStatusApproved {
+		return nil, ErrRefundNotApproved
+	}
+
+	lines := append([]Line(nil), o.lines...)
+	remaining := r.amountCents
+	for i := range lines {
+		if remaining == 0 {
+			break
+		}
+		if r.lineID != nil && lines[i].ID != *r.lineID {
+			continue
+		}
+		share := remaining
+		if r.lineID == nil {
+			share = min(remaining, o.lineRefundableCents(lines[i]))
+		}
+		lines[i].RefundedCents += share
+		remaining -= share
+	}
+
+	o.lines = lines
+	o.refundedCents += r.amountCents
+	o.status = StatusPartiallyRefunded
+	if o.refundedCents >= o.TotalCents() {
+		o.status = StatusRefunded
+	}
+	o.updatedAt = now
+
+	r.status = RefundStatusIssued
+	r.paymentID = &paymentID
+	r.paymentRefundID = &paymentRefundID
+	r.receiptNumber = receiptNumber
+	r.issuedAt = &now
+	return r, nil
+}
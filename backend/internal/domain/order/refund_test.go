@@ -0,0 +1,221 @@
+package order_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var autoApprove = order.ApprovalPolicy{ThresholdCents: 10000}
+
+func lineRequest(kind order.RefundKind, lineID uuid.UUID, key string) order.RefundRequest {
+	return order.RefundRequest{
+		Kind: kind, LineID: &lineID, Reason: order.ReasonUnavailable, IdempotencyKey: key, RequestedBy: uuid.New(),
+	}
+}
+
+func TestOrder_RequestRefund_Amounts(t *testing.T) {
+	t.Run("line refund returns the whole line", func(t *testing.T) {
+		o := newOrder(t)
+
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[1].ID, "k1"), autoApprove, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(900), r.AmountCents())
+		assert.Equal(t, order.RefundStatusApproved, r.Status())
+		assert.Equal(t, int64(3000), o.RefundableCents(), "an approved refund holds its amount back")
+	})
+
+	t.Run("weight difference refunds the missing grams", func(t *testing.T) {
+		o := newOrder(t)
+		req := lineRequest(order.RefundKindWeightDifference, o.Lines()[0].ID, "k1")
+		req.Reason = order.ReasonShortWeight
+		req.DeliveredGrams = 1320
+
+		r, err := o.RequestRefund(uuid.New(), req, autoApprove, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(360), r.AmountCents())
+		assert.Equal(t, int64(1320), r.DeliveredGrams())
+	})
+
+	t.Run("whole order refunds whatever is left", func(t *testing.T) {
+		o := newOrder(t)
+		_, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[1].ID, "k1"), autoApprove, time.Now())
+		require.NoError(t, err)
+
+		r, err := o.RequestRefund(uuid.New(), order.RefundRequest{
+			Kind: order.RefundKindOrder, Reason: order.ReasonQuality, IdempotencyKey: "k2", RequestedBy: uuid.New(),
+		}, autoApprove, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3000), r.AmountCents())
+		assert.Nil(t, r.LineID())
+	})
+
+	t.Run("a refunded line cannot be refunded again", func(t *testing.T) {
+		o := newOrder(t)
+		lineID := o.Lines()[1].ID
+		_, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, lineID, "k1"), autoApprove, time.Now())
+		require.NoError(t, err)
+
+		_, err = o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, lineID, "k2"), autoApprove, time.Now())
+
+		assert.ErrorIs(t, err, order.ErrNothingToRefund)
+	})
+}
+
+func TestOrder_RequestRefund_InvalidRequests_ReturnError(t *testing.T) {
+	o := newOrder(t)
+	lineID := o.Lines()[0].ID
+	unknown := uuid.New()
+
+	tests := []struct {
+		name string
+		req  order.RefundRequest
+		want error
+	}{
+		{"no key", order.RefundRequest{Kind: order.RefundKindOrder, Reason: order.ReasonQuality}, order.ErrEmptyIdempotencyKey},
+		{"bad kind", order.RefundRequest{Kind: "partial", Reason: order.ReasonQuality, IdempotencyKey: "k"}, order.ErrInvalidRefundKind},
+		{"bad reason", order.RefundRequest{Kind: order.RefundKindOrder, Reason: "whim", IdempotencyKey: "k"}, order.ErrInvalidReason},
+		{"other without note", order.RefundRequest{Kind: order.RefundKindOrder, Reason: order.ReasonOther, Note: " ", IdempotencyKey: "k"}, order.ErrNoteRequired},
+		{"line missing", order.RefundRequest{Kind: order.RefundKindLine, Reason: order.ReasonQuality, IdempotencyKey: "k"}, order.ErrLineRequired},
+		{"unknown line", order.RefundRequest{Kind: order.RefundKindLine, LineID: &unknown, Reason: order.ReasonQuality, IdempotencyKey: "k"}, order.ErrUnknownLine},
+		{"nothing short", order.RefundRequest{Kind: order.RefundKindWeightDifference, LineID: &lineID, DeliveredGrams: 1500, Reason: order.ReasonShortWeight, IdempotencyKey: "k"}, order.ErrInvalidDeliveredWeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := o.RequestRefund(uuid.New(), tt.req, autoApprove, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+	assert.Empty(t, o.Refunds())
+}
+
+func TestOrder_RequestRefund_AboveThreshold_WaitsForManager(t *testing.T) {
+	policy := order.ApprovalPolicy{ThresholdCents: 1000}
+
+	t.Run("staff", func(t *testing.T) {
+		o := newOrder(t)
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[0].ID, "k1"), policy, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, order.RefundStatusPendingApproval, r.Status())
+		assert.Nil(t, r.DecidedBy())
+	})
+
+	t.Run("manager", func(t *testing.T) {
+		o := newOrder(t)
+		policy.CanApprove = true
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[0].ID, "k1"), policy, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, order.RefundStatusApproved, r.Status())
+		assert.Equal(t, r.RequestedBy(), *r.DecidedBy())
+	})
+}
+
+func TestOrder_ApproveAndRejectRefund(t *testing.T) {
+	policy := order.ApprovalPolicy{ThresholdCents: 1000}
+	managerID := uuid.New()
+
+	t.Run("approving needs the permission", func(t *testing.T) {
+		o := newOrder(t)
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[0].ID, "k1"), policy, time.Now())
+		require.NoError(t, err)
+
+		_, err = o.ApproveRefund(r.ID(), uuid.New(), false, time.Now())
+		assert.ErrorIs(t, err, order.ErrApprovalNotPermitted)
+
+		_, err = o.ApproveRefund(r.ID(), managerID, true, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, order.RefundStatusApproved, r.Status())
+		assert.Equal(t, managerID, *r.DecidedBy())
+
+		_, err = o.RejectRefund(r.ID(), managerID, true, time.Now())
+		assert.ErrorIs(t, err, order.ErrRefundNotPending)
+	})
+
+	t.Run("rejecting releases the amount", func(t *testing.T) {
+		o := newOrder(t)
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[0].ID, "k1"), policy, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, int64(900), o.RefundableCents())
+
+		_, err = o.RejectRefund(r.ID(), managerID, true, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, order.RefundStatusRejected, r.Status())
+		assert.Equal(t, int64(3900), o.RefundableCents())
+	})
+
+	t.Run("unknown refund", func(t *testing.T) {
+		o := newOrder(t)
+		_, err := o.ApproveRefund(uuid.New(), managerID, true, time.Now())
+		assert.ErrorIs(t, err, order.ErrRefundNotFound)
+	})
+}
+
+func TestOrder_IssueRefund(t *testing.T) {
+	t.Run("line refund", func(t *testing.T) {
+		o := newOrder(t)
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[1].ID, "k1"), autoApprove, time.Now())
+		require.NoError(t, err)
+		paymentID, paymentRefundID := uuid.New(), uuid.New()
+
+		_, err = o.IssueRefund(r.ID(), paymentID, paymentRefundID, "RF-000001", time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, order.RefundStatusIssued, r.Status())
+		assert.Equal(t, "RF-000001", r.ReceiptNumber())
+		assert.Equal(t, paymentRefundID, *r.PaymentRefundID())
+		assert.NotNil(t, r.IssuedAt())
+		assert.Equal(t, int64(900), o.Lines()[1].RefundedCents)
+		assert.Equal(t, int64(900), o.RefundedCents())
+		assert.Equal(t, order.StatusPartiallyRefunded, o.Status())
+		assert.Equal(t, int64(3000), o.RefundableCents())
+
+		_, err = o.IssueRefund(r.ID(), paymentID, paymentRefundID, "RF-000002", time.Now())
+		assert.ErrorIs(t, err, order.ErrRefundNotApproved)
+	})
+
+	t.Run("whole order refund spreads over the lines", func(t *testing.T) {
+		o := newOrder(t)
+		req := lineRequest(order.RefundKindWeightDifference, o.Lines()[0].ID, "k1")
+		req.DeliveredGrams = 1000
+		partial, err := o.RequestRefund(uuid.New(), req, autoApprove, time.Now())
+		require.NoError(t, err)
+		_, err = o.IssueRefund(partial.ID(), uuid.New(), uuid.New(), "RF-000001", time.Now())
+		require.NoError(t, err)
+
+		r, err := o.RequestRefund(uuid.New(), order.RefundRequest{
+			Kind: order.RefundKindOrder, Reason: order.ReasonLateDelivery, IdempotencyKey: "k2", RequestedBy: uuid.New(),
+		}, autoApprove, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, int64(2900), r.AmountCents())
+		_, err = o.IssueRefund(r.ID(), uuid.New(), uuid.New(), "RF-000002", time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3000), o.Lines()[0].RefundedCents)
+		assert.Equal(t, int64(900), o.Lines()[1].RefundedCents)
+		assert.Equal(t, order.StatusRefunded, o.Status())
+		assert.Zero(t, o.RefundableCents())
+	})
+
+	t.Run("pending refund cannot be issued", func(t *testing.T) {
+		o := newOrder(t)
+		r, err := o.RequestRefund(uuid.New(), lineRequest(order.RefundKindLine, o.Lines()[0].ID, "k1"), order.ApprovalPolicy{}, time.Now())
+		require.NoError(t, err)
+
+		_, err = o.IssueRefund(r.ID(), uuid.New(), uuid.New(), "RF-000001", time.Now())
+		assert.ErrorIs(t, err, order.ErrRefundNotApproved)
+	})
+}
+
+func TestReceiptNumber_IsZeroPadded(t *testing.T) {
+	assert.Equal(t, "RF-000042", order.ReceiptNumber(42))
+}
@@ -0,0 +1,20 @@
+package order
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to orders together with their lines and refunds.
+type Repository interface {
+	Create(ctx context.Context, o *Order) error
+	// Update stores a changed order, including its lines and refunds. It
+	// returns ErrConcurrentUpdate if the order has changed since it was
+	// loaded, and ErrIdempotencyKeyReused if a new refund reuses a key.
+	Update(ctx context.Context, o *Order) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Order, error)
+	// NextReceiptNumber reserves the next refund receipt number. Numbers
+	// are unique but may skip if a refund is not issued after all.
+	NextReceiptNumber(ctx context.Context) (string, error)
+}
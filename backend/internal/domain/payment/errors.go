@@ -0,0 +1,22 @@
+package payment
+
+import "errors"
+
+var (
+	ErrInvalidAmount               = errors.New("amount must be greater than zero")
+	ErrInvalidCurrency             = errors.New("currency must be a three-letter ISO 4217 code")
+	ErrInvalidMargin               = errors.New("authorization margin must not be negative")
+	ErrEmptyIdempotencyKey         = errors.New("idempotency key must not be empty")
+	ErrEmptyPaymentMethod          = errors.New("payment method must not be empty")
+	ErrDeclined                    = errors.New("payment was declined")
+	ErrNotAuthorized               = errors.New("payment is not authorized")
+	ErrNotCaptured                 = errors.New("only a captured payment can be refunded")
+	ErrAlreadyCaptured             = errors.New("payment has already been captured for a different amount")
+	ErrCaptureExceedsAuthorization = errors.New("capture amount exceeds the authorized amount")
+	ErrRefundExceedsCaptured       = errors.New("refund amount exceeds what is left of the captured amount")
+	ErrAuthorizationInProgress     = errors.New("an authorization with this idempotency key is still in progress")
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used for a different request")
+	ErrInvalidSignature            = errors.New("webhook signature is missing or invalid")
+	ErrPaymentNotFound             = errors.New("payment not found")
+	ErrConcurrentUpdate            = errors.New("payment was changed by someone else; reload and try again")
+)
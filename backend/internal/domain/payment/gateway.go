@@ -0,0 +1,64 @@
+package payment
+
+import "context"
+
+// PaymentGateway is the port to a card payment provider. Every call carries
+// an idempotency key so that a retried request is applied at most once by
+// the provider. A declined card is reported as an error wrapping ErrDeclined.
+type PaymentGateway interface {
+	// Name identifies the provider, e.g. "stripe".
+	Name() string
+	// Authorize places a hold on the customer's payment method.
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Authorization, error)
+	// Capture takes up to the authorized amount of a held payment and
+	// releases the rest.
+	Capture(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) error
+	// Void releases a held payment without taking anything.
+	Void(ctx context.Context, providerRef string, idempotencyKey string) error
+	// Refund returns part or all of a captured payment and returns the
+	// provider's reference for the refund.
+	Refund(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) (string, error)
+	// ParseWebhook verifies a webhook's signature and decodes it. It returns
+	// ErrInvalidSignature if the payload was not sent by the provider.
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+}
+
+// AuthorizeRequest is a request to hold an amount on a payment method.
+type AuthorizeRequest struct {
+	PaymentID      string
+	AmountCents    int64
+	Currency       string
+	PaymentMethod  string
+	IdempotencyKey string
+}
+
+// Authorization is the provider's answer to a successful authorization.
+type Authorization struct {
+	ProviderRef string
+}
+
+// EventType is a provider notification, mapped onto the payment lifecycle.
+type EventType string
+
+const (
+	EventAuthorized EventType = "authorized"
+	EventCaptured   EventType = "captured"
+	EventVoided     EventType = "voided"
+	EventFailed     EventType = "failed"
+	EventRefunded   EventType = "refunded"
+	// EventIgnored is any notification the payment lifecycle does not track.
+	EventIgnored EventType = "ignored"
+)
+
+// Event is a verified webhook notification. PaymentID is set when the
+// provider echoes back the ID sent with the authorization. AmountCents is
+// the amount held for EventAuthorized, taken for EventCaptured and the total
+// refunded so far for EventRefunded.
+type Event struct {
+	ID          string
+	Type        EventType
+	PaymentID   string
+	ProviderRef string
+	AmountCents int64
+	Reason      string
+}
@@ -0,0 +1,305 @@
+package payment
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle state of a payment.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusAuthorized Status = "authorized"
+	StatusCaptured   Status = "captured"
+	StatusRefunded   Status = "refunded"
+	StatusVoided     Status = "voided"
+	StatusFailed     Status = "failed"
+)
+
+// AuthorizationAmount is the amount held for an order whose estimated total
+// is estimatedCents. Weighed goods rarely come to exactly the estimate, so
+// the hold is raised by marginBP basis points, rounded up to the next cent.
+func AuthorizationAmount(estimatedCents int64, marginBP int) int64 {
+	return estimatedCents + (estimatedCents*int64(marginBP)+9999)/10000
+}
+
+// Payment is a card payment for one order. The customer's card is
+// authorized for the estimated total plus a margin when they check out and
+// captured for the weighed total once the order has been cut. Version
+// guards against the same payment being changed twice at once, e.g. by an
+// admin and a provider webhook.
+type Payment struct {
+	id              uuid.UUID
+	orderID         uuid.UUID
+	customerID      uuid.UUID
+	currency        string
+	estimatedCents  int64
+	authorizedCents int64
+	capturedCents   int64
+	refundedCents   int64
+	status          Status
+	provider        string
+	providerRef     string
+	idempotencyKey  string
+	failureReason   string
+	version         int
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewPayment creates a pending payment for an order. The amount to
+// authorize is the estimated total raised by marginBP basis points.
+func NewPayment(
+	id, orderID, customerID uuid.UUID,
+	currency string,
+	estimatedCents int64,
+	marginBP int,
+	provider string,
+	idempotencyKey string,
+	now time.Time,
+) (*Payment, error) {
+	if estimatedCents <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if marginBP < 0 {
+		return nil, ErrInvalidMargin
+	}
+	currency = strings.ToLower(strings.TrimSpace(currency))
+	if !validCurrency(currency) {
+		return nil, ErrInvalidCurrency
+	}
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if idempotencyKey == "" {
+		return nil, ErrEmptyIdempotencyKey
+	}
+	return &Payment{
+		id:              id,
+		orderID:         orderID,
+		customerID:      customerID,
+		currency:        currency,
+		estimatedCents:  estimatedCents,
+		authorizedCents: AuthorizationAmount(estimatedCents, marginBP),
+		status:          StatusPending,
+		provider:        provider,
+		idempotencyKey:  idempotencyKey,
+		version:         1,
+		createdAt:       now,
+		updatedAt:       now,
+	}, nil
+}
+
+// ReconstructPayment reconstructs a Payment from persistence without validation.
+func ReconstructPayment(
+	id, orderID, customerID uuid.UUID,
+	currency string,
+	estimatedCents, authorizedCents, capturedCents, refundedCents int64,
+	status Status,
+	provider, providerRef, idempotencyKey, failureReason string,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Payment {
+	return &Payment{
+		id:              id,
+		orderID:         orderID,
+		customerID:      customerID,
+		currency:        currency,
+		estimatedCents:  estimatedCents,
+		authorizedCents: authorizedCents,
+		capturedCents:   capturedCents,
+		refundedCents:   refundedCents,
+		status:          status,
+		provider:        provider,
+		providerRef:     providerRef,
+		idempotencyKey:  idempotencyKey,
+		failureReason:   failureReason,
+		version:         version,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (p *Payment) ID() uuid.UUID          { return p.id }
+func (p *Payment) OrderID() uuid.UUID     { return p.orderID }
+func (p *Payment) CustomerID() uuid.UUID  { return p.customerID }
+func (p *Payment) Currency() string       { return p.currency }
+func (p *Payment) EstimatedCents() int64  { return p.estimatedCents }
+func (p *Payment) AuthorizedCents() int64 { return p.authorizedCents }
+func (p *Payment) CapturedCents() int64   { return p.capturedCents }
+func (p *Payment) RefundedCents() int64   { return p.refundedCents }
+func (p *Payment) Status() Status         { return p.status }
+func (p *Payment) Provider() string       { return p.provider }
+func (p *Payment) ProviderRef() string    { return p.providerRef }
+func (p *Payment) IdempotencyKey() string { return p.idempotencyKey }
+func (p *Payment) FailureReason() string  { return p.failureReason }
+func (p *Payment) Version() int           { return p.version }
+func (p *Payment) CreatedAt() time.Time   { return p.createdAt }
+func (p *Payment) UpdatedAt() time.Time   { return p.updatedAt }
+
+// RefundableCents is how much of the captured amount has not been refunded.
+func (p *Payment) RefundableCents() int64 { return p.capturedCents - p.refundedCents }
+
+// Authorize records that the provider is holding the authorized amount.
+func (p *Payment) Authorize(providerRef string, now time.Time) error {
+	if p.status != StatusPending {
+		return ErrNotAuthorized
+	}
+	p.status = StatusAuthorized
+	p.providerRef = providerRef
+	p.updatedAt = now
+	return nil
+}
+
+// Fail records that the provider declined the authorization.
+func (p *Payment) Fail(reason string, now time.Time) error {
+	if p.status != StatusPending {
+		return ErrNotAuthorized
+	}
+	p.status = StatusFailed
+	p.failureReason = reason
+	p.updatedAt = now
+	return nil
+}
+
+// Capture takes the weighed total, which may not exceed the authorized
+// amount. Capturing the same amount again is a no-op.
+func (p *Payment) Capture(amountCents int64, now time.Time) error {
+	if p.status == StatusCaptured && p.capturedCents == amountCents {
+		return nil
+	}
+	if p.status == StatusCaptured || p.status == StatusRefunded {
+		return ErrAlreadyCaptured
+	}
+	if p.status != StatusAuthorized {
+		return ErrNotAuthorized
+	}
+	if amountCents <= 0 {
+		return ErrInvalidAmount
+	}
+	if amountCents > p.authorizedCents {
+		return ErrCaptureExceedsAuthorization
+	}
+	p.status = StatusCaptured
+	p.capturedCents = amountCents
+	p.updatedAt = now
+	return nil
+}
+
+// Void releases the hold. Voiding a voided payment is a no-op.
+func (p *Payment) Void(now time.Time) error {
+	if p.status == StatusVoided {
+		return nil
+	}
+	if p.status != StatusAuthorized {
+		return ErrNotAuthorized
+	}
+	p.status = StatusVoided
+	p.updatedAt = now
+	return nil
+}
+
+// Refund returns part or all of the captured amount. The payment becomes
+// refunded once nothing captured is left.
+func (p *Payment) Refund(refundID uuid.UUID, amountCents int64, reason, idempotencyKey string, createdBy *uuid.UUID, now time.Time) (*Refund, error) {
+	if p.status != StatusCaptured {
+		return nil, ErrNotCaptured
+	}
+	if amountCents <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if amountCents > p.RefundableCents() {
+		return nil, ErrRefundExceedsCaptured
+	}
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if idempotencyKey == "" {
+		return nil, ErrEmptyIdempotencyKey
+	}
+	p.refundedCents += amountCents
+	if p.refundedCents == p.capturedCents {
+		p.status = StatusRefunded
+	}
+	p.updatedAt = now
+	return &Refund{
+		ID:             refundID,
+		PaymentID:      p.id,
+		AmountCents:    amountCents,
+		Reason:         strings.TrimSpace(reason),
+		IdempotencyKey: idempotencyKey,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+	}, nil
+}
+
+// ApplyEvent brings the payment in line with a provider notification and
+// reports whether anything changed. Notifications that arrive late or out of
+// order, and any that the payment has already moved past, are ignored, so
+// applying the same event twice is harmless.
+func (p *Payment) ApplyEvent(e Event, now time.Time) bool {
+	switch e.Type {
+	case EventAuthorized:
+		if p.status != StatusPending {
+			return false
+		}
+		if e.AmountCents > 0 {
+			p.authorizedCents = e.AmountCents
+		}
+		p.status = StatusAuthorized
+		p.providerRef = e.ProviderRef
+	case EventFailed:
+		if p.status != StatusPending {
+			return false
+		}
+		p.status = StatusFailed
+		p.failureReason = e.Reason
+	case EventCaptured:
+		if p.status != StatusAuthorized || e.AmountCents <= 0 {
+			return false
+		}
+		p.status = StatusCaptured
+		p.capturedCents = e.AmountCents
+	case EventVoided:
+		if p.status != StatusPending && p.status != StatusAuthorized {
+			return false
+		}
+		p.status = StatusVoided
+	case EventRefunded:
+		if p.status != StatusCaptured || e.AmountCents <= p.refundedCents {
+			return false
+		}
+		p.refundedCents = min(e.AmountCents, p.capturedCents)
+		if p.refundedCents == p.capturedCents {
+			p.status = StatusRefunded
+		}
+	default:
+		return false
+	}
+	p.updatedAt = now
+	return true
+}
+
+func validCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Refund is money returned against a captured payment. ProviderRef is set
+// once the provider has accepted it.
+type Refund struct {
+	ID             uuid.UUID
+	PaymentID      uuid.UUID
+	AmountCents    int64
+	Reason         string
+	IdempotencyKey string
+	ProviderRef    string
+	CreatedBy      *uuid.UUID
+	CreatedAt      time.Time
+}
@@ -0,0 +1,134 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthorizedPayment(t *testing.T, estimatedCents int64, marginBP int) *payment.Payment {
+	t.Helper()
+	now := time.Now()
+	p, err := payment.NewPayment(uuid.New(), uuid.New(), uuid.New(), "gbp", estimatedCents, marginBP, "fake", "checkout-1", now)
+	require.NoError(t, err)
+	require.NoError(t, p.Authorize("pi_1", now))
+	return p
+}
+
+func TestAuthorizationAmount_RoundsMarginUp(t *testing.T) {
+	assert.Equal(t, int64(11500), payment.AuthorizationAmount(10000, 1500))
+	assert.Equal(t, int64(1152), payment.AuthorizationAmount(1001, 1500))
+	assert.Equal(t, int64(1001), payment.AuthorizationAmount(1001, 0))
+}
+
+func TestNewPayment_AuthorizesEstimatePlusMargin(t *testing.T) {
+	p, err := payment.NewPayment(uuid.New(), uuid.New(), uuid.New(), " GBP ", 4000, 1000, "fake", " key ", time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusPending, p.Status())
+	assert.Equal(t, "gbp", p.Currency())
+	assert.Equal(t, "key", p.IdempotencyKey())
+	assert.Equal(t, int64(4400), p.AuthorizedCents())
+	assert.Equal(t, 1, p.Version())
+}
+
+func TestNewPayment_InvalidInput_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name      string
+		currency  string
+		estimated int64
+		margin    int
+		key       string
+		want      error
+	}{
+		{"zero amount", "gbp", 0, 0, "k", payment.ErrInvalidAmount},
+		{"negative margin", "gbp", 100, -1, "k", payment.ErrInvalidMargin},
+		{"bad currency", "pounds", 100, 0, "k", payment.ErrInvalidCurrency},
+		{"empty key", "gbp", 100, 0, " ", payment.ErrEmptyIdempotencyKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := payment.NewPayment(uuid.New(), uuid.New(), uuid.New(), tt.currency, tt.estimated, tt.margin, "fake", tt.key, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestPayment_Capture_WeighedAmountUpToAuthorization(t *testing.T) {
+	p := newAuthorizedPayment(t, 10000, 1500)
+
+	assert.ErrorIs(t, p.Capture(11501, time.Now()), payment.ErrCaptureExceedsAuthorization)
+	require.NoError(t, p.Capture(11200, time.Now()))
+	assert.Equal(t, payment.StatusCaptured, p.Status())
+	assert.Equal(t, int64(11200), p.CapturedCents())
+
+	assert.NoError(t, p.Capture(11200, time.Now()), "capturing the same amount again is a no-op")
+	assert.ErrorIs(t, p.Capture(9000, time.Now()), payment.ErrAlreadyCaptured)
+}
+
+func TestPayment_Capture_NotAuthorized_ReturnsError(t *testing.T) {
+	p, err := payment.NewPayment(uuid.New(), uuid.New(), uuid.New(), "gbp", 1000, 0, "fake", "k", time.Now())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, p.Capture(1000, time.Now()), payment.ErrNotAuthorized)
+}
+
+func TestPayment_Void(t *testing.T) {
+	p := newAuthorizedPayment(t, 1000, 0)
+
+	require.NoError(t, p.Void(time.Now()))
+	assert.Equal(t, payment.StatusVoided, p.Status())
+	assert.NoError(t, p.Void(time.Now()))
+	assert.ErrorIs(t, p.Capture(1000, time.Now()), payment.ErrNotAuthorized)
+}
+
+func TestPayment_Refund_PartialThenFull(t *testing.T) {
+	p := newAuthorizedPayment(t, 5000, 0)
+	require.NoError(t, p.Capture(5000, time.Now()))
+
+	r, err := p.Refund(uuid.New(), 2000, " short weight ", "r1", nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "short weight", r.Reason)
+	assert.Equal(t, payment.StatusCaptured, p.Status())
+	assert.Equal(t, int64(3000), p.RefundableCents())
+
+	_, err = p.Refund(uuid.New(), 3001, "", "r2", nil, time.Now())
+	assert.ErrorIs(t, err, payment.ErrRefundExceedsCaptured)
+
+	_, err = p.Refund(uuid.New(), 3000, "", "r2", nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusRefunded, p.Status())
+}
+
+func TestPayment_Refund_NotCaptured_ReturnsError(t *testing.T) {
+	p := newAuthorizedPayment(t, 5000, 0)
+
+	_, err := p.Refund(uuid.New(), 100, "", "r1", nil, time.Now())
+	assert.ErrorIs(t, err, payment.ErrNotCaptured)
+}
+
+func TestPayment_ApplyEvent(t *testing.T) {
+	now := time.Now()
+	p, err := payment.NewPayment(uuid.New(), uuid.New(), uuid.New(), "gbp", 1000, 1000, "fake", "k", now)
+	require.NoError(t, err)
+
+	assert.True(t, p.ApplyEvent(payment.Event{Type: payment.EventAuthorized, ProviderRef: "pi_1", AmountCents: 1100}, now))
+	assert.Equal(t, payment.StatusAuthorized, p.Status())
+	assert.Equal(t, "pi_1", p.ProviderRef())
+	assert.False(t, p.ApplyEvent(payment.Event{Type: payment.EventAuthorized, AmountCents: 1100}, now), "replayed event")
+	assert.False(t, p.ApplyEvent(payment.Event{Type: payment.EventFailed}, now), "late failure")
+
+	assert.True(t, p.ApplyEvent(payment.Event{Type: payment.EventCaptured, AmountCents: 1050}, now))
+	assert.Equal(t, int64(1050), p.CapturedCents())
+	assert.False(t, p.ApplyEvent(payment.Event{Type: payment.EventVoided}, now))
+
+	assert.True(t, p.ApplyEvent(payment.Event{Type: payment.EventRefunded, AmountCents: 50}, now))
+	assert.False(t, p.ApplyEvent(payment.Event{Type: payment.EventRefunded, AmountCents: 50}, now))
+	assert.True(t, p.ApplyEvent(payment.Event{Type: payment.EventRefunded, AmountCents: 1050}, now))
+	assert.Equal(t, payment.StatusRefunded, p.Status())
+	assert.False(t, p.ApplyEvent(payment.Event{Type: payment.EventIgnored}, now))
+}
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to payments, their refunds and the provider
+// webhook events that have already been handled.
+type Repository interface {
+	// Create inserts a new payment. It returns ErrIdempotencyKeyReused if a
+	// payment with the same idempotency key already exists.
+	Create(ctx context.Context, p *Payment) error
+	// Update stores a changed payment. It returns ErrConcurrentUpdate if the
+	// payment has changed since it was loaded.
+	Update(ctx context.Context, p *Payment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Payment, error)
+	FindByIdempotencyKey(ctx context.Context, key string) (*Payment, error)
+	FindByProviderRef(ctx context.Context, provider, providerRef string) (*Payment, error)
+	// FindByOrderID returns every payment attempted for an order, newest first.
+	FindByOrderID(ctx context.Context, orderID uuid.UUID) ([]*Payment, error)
+	// SaveRefund stores a refund together with the updated payment in one
+	// transaction. It returns ErrConcurrentUpdate if the payment has changed
+	// since it was loaded.
+	SaveRefund(ctx context.Context, p *Payment, r *Refund) error
+	// FindRefundByIdempotencyKey returns nil, nil if no refund has the key.
+	FindRefundByIdempotencyKey(ctx context.Context, paymentID uuid.UUID, key string) (*Refund, error)
+	// FindRefunds returns a payment's refunds, oldest first.
+	FindRefunds(ctx context.Context, paymentID uuid.UUID) ([]*Refund, error)
+	// SaveWebhookEvent records a provider event as handled, together with the
+	// payment it changed, if any, in one transaction. It returns false without
+	// storing anything if the event has been handled before.
+	SaveWebhookEvent(ctx context.Context, provider, eventID string, p *Payment) (bool, error)
+}
@@ -0,0 +1,33 @@
+package plu
+
+// Allergen is one of the 14 allergens food businesses must declare when
+// a product contains it.
+type Allergen string
+
+const (
+	AllergenCelery      Allergen = "celery"
+	AllergenGluten      Allergen = "gluten"
+	AllergenCrustaceans Allergen = "crustaceans"
+	AllergenEggs        Allergen = "eggs"
+	AllergenFish        Allergen = "fish"
+	AllergenLupin       Allergen = "lupin"
+	AllergenMilk        Allergen = "milk"
+	AllergenMolluscs    Allergen = "molluscs"
+	AllergenMustard     Allergen = "mustard"
+	AllergenNuts        Allergen = "nuts"
+	AllergenPeanuts     Allergen = "peanuts"
+	AllergenSesame      Allergen = "sesame"
+	AllergenSoya        Allergen = "soya"
+	AllergenSulphites   Allergen = "sulphites"
+)
+
+// Valid reports whether a is one of the declarable allergens.
+func (a Allergen) Valid() bool {
+	switch a {
+	case AllergenCelery, AllergenGluten, AllergenCrustaceans, AllergenEggs, AllergenFish, AllergenLupin,
+		AllergenMilk, AllergenMolluscs, AllergenMustard, AllergenNuts, AllergenPeanuts, AllergenSesame,
+		AllergenSoya, AllergenSulphites:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package plu
+
+import "errors"
+
+var (
+	ErrInvalidCode       = errors.New("PLU code must be between 1 and 99999")
+	ErrProductRequired   = errors.New("product is required")
+	ErrEmptyDescription  = errors.New("description must not be empty")
+	ErrNegativePrice     = errors.New("price must not be negative")
+	ErrInvalidWeight     = errors.New("weight must be greater than zero")
+	ErrUnknownAllergen   = errors.New("allergen is not one of the 14 that must be declared")
+	ErrNegativeShelfLife = errors.New("shelf life must not be negative")
+	ErrItemInactive      = errors.New("PLU item is withdrawn")
+	ErrItemNotFound      = errors.New("PLU item not found")
+	ErrDuplicateCode     = errors.New("another item already has that PLU code")
+)
@@ -0,0 +1,162 @@
+package plu
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCode is the largest PLU code, the five digits scales and
+// variable-measure barcodes give the item number.
+const MaxCode = 99999
+
+// Item is a price look-up entry: the number a scale or barcode uses for a
+// product sold by weight, with the shelf price per kilogram packs of it are
+// priced at. Its allergens and shelf life are printed on pack labels.
+type Item struct {
+	id              uuid.UUID
+	code            int
+	productID       uuid.UUID
+	description     string
+	pricePerKgCents int64
+	allergens       []Allergen
+	shelfLifeDays   int
+	active          bool
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewItem validates and creates an active item.
+func NewItem(id uuid.UUID, code int, productID uuid.UUID, description string, pricePerKgCents int64, now time.Time) (*Item, error) {
+	if code < 1 || code > MaxCode {
+		return nil, ErrInvalidCode
+	}
+	if productID == uuid.Nil {
+		return nil, ErrProductRequired
+	}
+	i := &Item{id: id, code: code, productID: productID, allergens: []Allergen{}, createdAt: now}
+	if err := i.Update(description, pricePerKgCents, true, now); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// ReconstructItem reconstructs an Item from persistence without validation.
+func ReconstructItem(
+	id uuid.UUID,
+	code int,
+	productID uuid.UUID,
+	description string,
+	pricePerKgCents int64,
+	allergens []Allergen,
+	shelfLifeDays int,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Item {
+	return &Item{
+		id:              id,
+		code:            code,
+		productID:       productID,
+		description:     description,
+		pricePerKgCents: pricePerKgCents,
+		allergens:       allergens,
+		shelfLifeDays:   shelfLifeDays,
+		active:          active,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (i *Item) ID() uuid.UUID          { return i.id }
+func (i *Item) Code() int              { return i.code }
+func (i *Item) ProductID() uuid.UUID   { return i.productID }
+func (i *Item) Description() string    { return i.description }
+func (i *Item) PricePerKgCents() int64 { return i.pricePerKgCents }
+func (i *Item) Allergens() []Allergen  { return i.allergens }
+func (i *Item) ShelfLifeDays() int     { return i.shelfLifeDays }
+func (i *Item) Active() bool           { return i.active }
+func (i *Item) CreatedAt() time.Time   { return i.createdAt }
+func (i *Item) UpdatedAt() time.Time   { return i.updatedAt }
+
+// Update changes the item's description and price. A withdrawn item can no
+// longer be packed, but packs already labelled still scan.
+func (i *Item) Update(description string, pricePerKgCents int64, active bool, now time.Time) error {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return ErrEmptyDescription
+	}
+	if pricePerKgCents < 0 {
+		return ErrNegativePrice
+	}
+	i.description = description
+	i.pricePerKgCents = pricePerKgCents
+	i.active = active
+	i.updatedAt = now
+	return nil
+}
+
+// SetLabelling changes what pack labels say about the item: the allergens
+// it contains and how many days a pack keeps after packing. A shelf life of
+// zero means packs are only dated by the lot they are cut from.
+func (i *Item) SetLabelling(allergens []Allergen, shelfLifeDays int, now time.Time) error {
+	if shelfLifeDays < 0 {
+		return ErrNegativeShelfLife
+	}
+	set := make([]Allergen, 0, len(allergens))
+	for _, a := range allergens {
+		if !a.Valid() {
+			return ErrUnknownAllergen
+		}
+		if !slices.Contains(set, a) {
+			set = append(set, a)
+		}
+	}
+	slices.Sort(set)
+	i.allergens = set
+	i.shelfLifeDays = shelfLifeDays
+	i.updatedAt = now
+	return nil
+}
+
+// UseBy returns the date a pack packed at packedAt must be used by, or nil
+// if the item has no shelf life set.
+func (i *Item) UseBy(packedAt time.Time) *time.Time {
+	if i.shelfLifeDays == 0 {
+		return nil
+	}
+	d := packedAt.AddDate(0, 0, i.shelfLifeDays)
+	return &d
+}
+
+// Pack is a weighed pack of an item and what it costs.
+type Pack struct {
+	Grams      int64
+	PriceCents int64
+}
+
+// PackOfWeight prices a pack of grams at the item's price, rounded to the
+// nearest cent as order lines are.
+func (i *Item) PackOfWeight(grams int64) (Pack, error) {
+	return i.PackOfWeightAt(grams, i.pricePerKgCents)
+}
+
+// PackOfWeightAt prices a pack of grams at pricePerKgCents instead of the
+// item's price, e.g. what a branch charges for it.
+func (i *Item) PackOfWeightAt(grams, pricePerKgCents int64) (Pack, error) {
+	if grams <= 0 {
+		return Pack{}, ErrInvalidWeight
+	}
+	return Pack{Grams: grams, PriceCents: (grams*pricePerKgCents + 500) / 1000}, nil
+}
+
+// PackOfPrice works out the weight of a pack from the price printed on it.
+// The weight is zero if the item has no price to work back from.
+func (i *Item) PackOfPrice(priceCents int64) Pack {
+	p := Pack{PriceCents: priceCents}
+	if i.pricePerKgCents > 0 {
+		p.Grams = (priceCents*1000 + i.pricePerKgCents/2) / i.pricePerKgCents
+	}
+	return p
+}
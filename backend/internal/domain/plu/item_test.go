@@ -0,0 +1,76 @@
+package plu_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func TestNewItem(t *testing.T) {
+	productID := uuid.New()
+	tests := []struct {
+		name        string
+		code        int
+		productID   uuid.UUID
+		description string
+		price       int64
+		wantErr     error
+	}{
+		{"valid", 123, productID, " Lamb mince ", 1200, nil},
+		{"code zero", 0, productID, "Lamb mince", 1200, plu.ErrInvalidCode},
+		{"code too long", 100000, productID, "Lamb mince", 1200, plu.ErrInvalidCode},
+		{"no product", 123, uuid.Nil, "Lamb mince", 1200, plu.ErrProductRequired},
+		{"no description", 123, productID, " ", 1200, plu.ErrEmptyDescription},
+		{"negative price", 123, productID, "Lamb mince", -1, plu.ErrNegativePrice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := plu.NewItem(uuid.New(), tt.code, tt.productID, tt.description, tt.price, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Lamb mince", i.Description())
+			assert.True(t, i.Active())
+		})
+	}
+}
+
+func TestItem_Pack(t *testing.T) {
+	i, err := plu.NewItem(uuid.New(), 123, uuid.New(), "Lamb mince", 1299, now)
+	require.NoError(t, err)
+
+	p, err := i.PackOfWeight(1250)
+	require.NoError(t, err)
+	assert.Equal(t, plu.Pack{Grams: 1250, PriceCents: 1624}, p)
+
+	_, err = i.PackOfWeight(0)
+	assert.ErrorIs(t, err, plu.ErrInvalidWeight)
+
+	assert.Equal(t, plu.Pack{Grams: 1250, PriceCents: 1624}, i.PackOfPrice(1624))
+
+	free := plu.ReconstructItem(uuid.New(), 124, uuid.New(), "Bones", 0, nil, 0, true, now, now)
+	assert.Equal(t, plu.Pack{PriceCents: 0}, free.PackOfPrice(0))
+}
+
+func TestItem_SetLabelling(t *testing.T) {
+	i, err := plu.NewItem(uuid.New(), 200, uuid.New(), "Lamb merguez", 1599, now)
+	require.NoError(t, err)
+	assert.Empty(t, i.Allergens())
+	assert.Nil(t, i.UseBy(now), "no shelf life set")
+
+	require.NoError(t, i.SetLabelling([]plu.Allergen{plu.AllergenSulphites, plu.AllergenGluten, plu.AllergenSulphites}, 3, now))
+	assert.Equal(t, []plu.Allergen{plu.AllergenGluten, plu.AllergenSulphites}, i.Allergens())
+	require.NotNil(t, i.UseBy(now))
+	assert.Equal(t, now.AddDate(0, 0, 3), *i.UseBy(now))
+
+	assert.ErrorIs(t, i.SetLabelling([]plu.Allergen{"pork"}, 3, now), plu.ErrUnknownAllergen)
+	assert.ErrorIs(t, i.SetLabelling(nil, -1, now), plu.ErrNegativeShelfLife)
+}
@@ -0,0 +1,21 @@
+package plu
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to PLU items.
+type Repository interface {
+	// Save inserts or updates an item. It returns ErrDuplicateCode if
+	// another item has its code.
+	Save(ctx context.Context, i *Item) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Item, error)
+	FindByCode(ctx context.Context, code int) (*Item, error)
+	// FindByProduct returns the item a product is labelled under,
+	// preferring an active item and then the lowest code.
+	FindByProduct(ctx context.Context, productID uuid.UUID) (*Item, error)
+	// FindAll returns every item in code order.
+	FindAll(ctx context.Context) ([]*Item, error)
+}
@@ -0,0 +1,42 @@
+package pos
+
+import "errors"
+
+var (
+	ErrTillNotFound         = errors.New("till not found")
+	ErrEmptyTillName        = errors.New("till name must not be empty")
+	ErrBranchRequired       = errors.New("till must belong to a branch")
+	ErrDuplicateTillName    = errors.New("a till with this name already exists at the branch")
+	ErrTillInactive         = errors.New("till is disabled")
+	ErrTillInUse            = errors.New("till already has an open session")
+	ErrSessionNotFound      = errors.New("till session not found")
+	ErrSessionClosed        = errors.New("till session is closed")
+	ErrInvalidFloat         = errors.New("cash float must not be negative")
+	ErrInvalidCount         = errors.New("counted cash must not be negative")
+	ErrSalesInProgress      = errors.New("till session still has a sale being rung up")
+	ErrSaleNotFound         = errors.New("sale not found")
+	ErrSaleNotOpen          = errors.New("sale is no longer being rung up")
+	ErrSaleNotCompleted     = errors.New("sale has not been paid for")
+	ErrSaleVoided           = errors.New("sale has been voided")
+	ErrSaleReturned         = errors.New("sale has returns against it and cannot be voided")
+	ErrVoidFromOtherSession = errors.New("a paid sale can only be voided in the till session it was taken in")
+	ErrLineNotFound         = errors.New("sale line not found")
+	ErrLineVoided           = errors.New("sale line has been voided")
+	ErrInvalidWeight        = errors.New("weight must be greater than zero")
+	ErrNegativePrice        = errors.New("price must not be negative")
+	ErrUnbalancedLine       = errors.New("line net and VAT must add up to its gross")
+	ErrNoLines              = errors.New("sale has no items")
+	ErrInvalidMethod        = errors.New("payment method must be one of cash or card")
+	ErrInvalidAmount        = errors.New("payment amount must be greater than zero")
+	ErrPaymentsTaken        = errors.New("items cannot be voided once payment has been taken; void the sale instead")
+	ErrNothingDue           = errors.New("sale has nothing left to pay")
+	ErrBalanceDue           = errors.New("sale has not been paid in full")
+	ErrOverpayment          = errors.New("card payment is more than the balance due")
+	ErrReturnTooLarge       = errors.New("more is being returned than is left on the line")
+	ErrNotAReturn           = errors.New("returns can only be taken against sales")
+	ErrReturnNotVoidable    = errors.New("returns cannot be voided")
+	ErrReasonRequired       = errors.New("a reason is required")
+	ErrTillNotPermitted     = errors.New("only cashiers and managers may operate tills")
+	ErrVoidNotPermitted     = errors.New("only managers may void a paid sale")
+	ErrConcurrentUpdate     = errors.New("sale was changed by someone else; reload and try again")
+)
@@ -0,0 +1,125 @@
+package pos
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Band totals the sales taken at one VAT rate, less what was returned.
+type Band struct {
+	RateBP     int
+	NetCents   int64
+	VATCents   int64
+	GrossCents int64
+}
+
+// Report is a till session's takings. Once the session is closed it is the
+// Z report: the cash the drawer should hold, the cash counted in it and the
+// difference between them.
+type Report struct {
+	SessionID         uuid.UUID
+	TillID            uuid.UUID
+	BranchID          uuid.UUID
+	OpenedAt          time.Time
+	ClosedAt          *time.Time
+	FloatCents        int64
+	SalesCount        int
+	SalesCents        int64
+	ReturnsCount      int
+	ReturnsCents      int64
+	VoidedSalesCount  int
+	VoidedSalesCents  int64
+	VoidedLinesCount  int
+	VoidedLinesCents  int64
+	CashSalesCents    int64
+	CardSalesCents    int64
+	CashRefundsCents  int64
+	CardRefundsCents  int64
+	Bands             []Band
+	ExpectedCashCents int64
+	CountedCashCents  *int64
+	VarianceCents     *int64
+}
+
+// Summarise reports on a session from the sales and returns taken in it.
+// Only completed sales count towards takings: the money for a voided sale
+// was handed back. Voided lines are valued at their shelf price.
+func Summarise(session *Session, sales []*Sale) Report {
+	r := Report{
+		SessionID:  session.id,
+		TillID:     session.tillID,
+		BranchID:   session.branchID,
+		OpenedAt:   session.openedAt,
+		ClosedAt:   session.closedAt,
+		FloatCents: session.floatCents,
+	}
+	bands := map[int]*Band{}
+	for _, s := range sales {
+		if s.sessionID != session.id {
+			continue
+		}
+		for _, l := range s.lines {
+			if l.Voided {
+				r.VoidedLinesCount++
+				r.VoidedLinesCents += l.AmountCents
+			}
+		}
+		switch {
+		case s.status == SaleVoided:
+			r.VoidedSalesCount++
+			r.VoidedSalesCents += s.TotalCents()
+			continue
+		case s.status != SaleCompleted:
+			continue
+		}
+
+		sign := int64(1)
+		if s.kind == KindReturn {
+			sign = -1
+			r.ReturnsCount++
+			r.ReturnsCents += s.TotalCents()
+		} else {
+			r.SalesCount++
+			r.SalesCents += s.TotalCents()
+		}
+		for _, l := range s.LiveLines() {
+			b, ok := bands[l.VATRateBP]
+			if !ok {
+				b = &Band{RateBP: l.VATRateBP}
+				bands[l.VATRateBP] = b
+			}
+			b.NetCents += sign * l.NetCents
+			b.VATCents += sign * l.VATCents
+			b.GrossCents += sign * l.GrossCents
+		}
+		for _, p := range s.payments {
+			switch {
+			case s.kind == KindSale && p.Method == MethodCash:
+				r.CashSalesCents += p.AmountCents
+			case s.kind == KindSale:
+				r.CardSalesCents += p.AmountCents
+			case p.Method == MethodCash:
+				r.CashRefundsCents += p.AmountCents
+			default:
+				r.CardRefundsCents += p.AmountCents
+			}
+		}
+	}
+
+	r.Bands = make([]Band, 0, len(bands))
+	for _, b := range bands {
+		r.Bands = append(r.Bands, *b)
+	}
+	sort.Slice(r.Bands, func(i, j int) bool { return r.Bands[i].RateBP < r.Bands[j].RateBP })
+
+	r.ExpectedCashCents = r.FloatCents + r.CashSalesCents - r.CashRefundsCents
+	if session.countedCashCents != nil {
+		counted := *session.countedCashCents
+		variance := counted - r.ExpectedCashCents
+		r.CountedCashCents = &counted
+		r.VarianceCents = &variance
+	}
+	return r
+}
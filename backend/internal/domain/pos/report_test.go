@@ -0,0 +1,95 @@
+package pos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarise_ReconcilesCountedCashAgainstTakings(t *testing.T) {
+	session := openSession(t, 10000)
+
+	// Paid 1000 on card and 1400 in cash from a 2000 note.
+	split := ringUp(t, session, 1200, 1200)
+	_, err := split.Pay(pos.MethodCard, 1000, "", time.Now())
+	require.NoError(t, err)
+	_, err = split.Pay(pos.MethodCash, 2000, "", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, split.Complete("POS-000001", time.Now()))
+
+	// A line voided before payment, then the rest paid in cash.
+	cash := ringUp(t, session, 600, 300)
+	require.NoError(t, cash.VoidLine(cash.Lines()[1].ID, time.Now()))
+	reprice(t, cash)
+	_, err = cash.Pay(pos.MethodCash, 600, "", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, cash.Complete("POS-000002", time.Now()))
+
+	// A paid sale voided: the cash went back to the customer.
+	voided := ringUp(t, session, 900)
+	_, err = voided.Pay(pos.MethodCash, 900, "", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, voided.Complete("POS-000003", time.Now()))
+	require.NoError(t, voided.Void("rung up twice", uuid.New(), time.Now()))
+
+	ret, err := pos.NewReturn(uuid.New(), cash, session, uuid.New(), []pos.ReturnItem{{LineID: cash.Lines()[0].ID}}, pos.MethodCash, "", "off", "POS-000004", time.Now())
+	require.NoError(t, err)
+
+	otherSession := completedSale(t, openSession(t, 0), 5000)
+
+	require.NoError(t, session.Close(10390, []*pos.Sale{split, cash, voided, ret}, uuid.New(), time.Now()))
+	r := pos.Summarise(session, []*pos.Sale{split, cash, voided, ret, otherSession})
+
+	assert.Equal(t, 2, r.SalesCount)
+	assert.Equal(t, int64(3000), r.SalesCents)
+	assert.Equal(t, 1, r.ReturnsCount)
+	assert.Equal(t, int64(600), r.ReturnsCents)
+	assert.Equal(t, 1, r.VoidedSalesCount)
+	assert.Equal(t, int64(900), r.VoidedSalesCents)
+	assert.Equal(t, 1, r.VoidedLinesCount)
+	assert.Equal(t, int64(300), r.VoidedLinesCents)
+	assert.Equal(t, int64(2000), r.CashSalesCents)
+	assert.Equal(t, int64(1000), r.CardSalesCents)
+	assert.Equal(t, int64(600), r.CashRefundsCents)
+	assert.Equal(t, []pos.Band{{RateBP: 2000, NetCents: 2000, VATCents: 400, GrossCents: 2400}}, r.Bands)
+	assert.Equal(t, int64(11400), r.ExpectedCashCents)
+	require.NotNil(t, r.VarianceCents)
+	assert.Equal(t, int64(10390), *r.CountedCashCents)
+	assert.Equal(t, int64(-1010), *r.VarianceCents)
+}
+
+func TestSession_Close(t *testing.T) {
+	session := openSession(t, 5000)
+	open := ringUp(t, session, 500)
+
+	assert.ErrorIs(t, session.Close(5000, []*pos.Sale{open}, uuid.New(), time.Now()), pos.ErrSalesInProgress)
+	assert.ErrorIs(t, session.Close(-1, nil, uuid.New(), time.Now()), pos.ErrInvalidCount)
+
+	require.NoError(t, open.Void("walked off", uuid.New(), time.Now()))
+	require.NoError(t, session.Close(5000, []*pos.Sale{open}, uuid.New(), time.Now()))
+	assert.False(t, session.IsOpen())
+	assert.ErrorIs(t, session.Close(5000, nil, uuid.New(), time.Now()), pos.ErrSessionClosed)
+
+	_, err := pos.NewSale(uuid.New(), session, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, pos.ErrSessionClosed)
+}
+
+func TestOpenSession_RequiresActiveTillAndFloat(t *testing.T) {
+	till, err := pos.NewTill(uuid.New(), uuid.New(), " Till 1 ", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "Till 1", till.Name())
+
+	_, err = pos.OpenSession(uuid.New(), till, -1, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, pos.ErrInvalidFloat)
+
+	require.NoError(t, till.Update("Till 1", false, time.Now()))
+	_, err = pos.OpenSession(uuid.New(), till, 0, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, pos.ErrTillInactive)
+
+	_, err = pos.NewTill(uuid.New(), uuid.Nil, "Till 2", time.Now())
+	assert.ErrorIs(t, err, pos.ErrBranchRequired)
+}
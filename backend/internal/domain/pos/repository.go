@@ -0,0 +1,70 @@
+package pos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Receipt is what is printed for the customer when a sale or return is
+// completed, or reprinted later.
+type Receipt struct {
+	Sale                  *Sale
+	TillName              string
+	CashierName           string
+	OriginalReceiptNumber string
+}
+
+// ReceiptRenderer prints a receipt for the counter's receipt printer.
+type ReceiptRenderer interface {
+	Render(r Receipt) ([]byte, error)
+}
+
+// TillRepository provides access to tills.
+type TillRepository interface {
+	// Save stores a till, returning ErrDuplicateTillName if another till at
+	// the branch has the same name.
+	Save(ctx context.Context, t *Till) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Till, error)
+	// FindAll returns the tills at a branch, or at every branch when
+	// branchID is nil, by name.
+	FindAll(ctx context.Context, branchID *uuid.UUID) ([]*Till, error)
+}
+
+// SessionRepository provides access to till sessions.
+type SessionRepository interface {
+	// Open stores a new session, returning ErrTillInUse if its till already
+	// has one open.
+	Open(ctx context.Context, s *Session) error
+	// Close stores a closed session, returning ErrSessionClosed if it has
+	// already been closed and ErrSalesInProgress if a sale was started in it
+	// meanwhile.
+	Close(ctx context.Context, s *Session) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	// FindByTill returns a till's sessions, newest first.
+	FindByTill(ctx context.Context, tillID uuid.UUID) ([]*Session, error)
+}
+
+// SaleRepository provides access to till sales and returns.
+type SaleRepository interface {
+	// Save stores a sale that is being rung up, or one voided before it
+	// was paid for.
+	Save(ctx context.Context, s *Sale) error
+	// NextReceiptNumber reserves the next till receipt number.
+	NextReceiptNumber(ctx context.Context) (string, error)
+	// Complete stores a completed sale and records sold movements for its
+	// lines, taking stock from the branch's lots first-expired-first-out.
+	// It returns inventory.ErrInsufficientStock if the branch has too little.
+	Complete(ctx context.Context, s *Sale) error
+	// Void stores a voided sale. If it had been completed, the stock it
+	// took goes back to the lots it came from.
+	Void(ctx context.Context, s *Sale) error
+	// SaveReturn stores a return together with the sale it was taken
+	// against.
+	SaveReturn(ctx context.Context, original, ret *Sale) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Sale, error)
+	FindByReceiptNumber(ctx context.Context, number string) (*Sale, error)
+	// FindBySession returns the sales and returns taken in a session, oldest
+	// first.
+	FindBySession(ctx context.Context, sessionID uuid.UUID) ([]*Sale, error)
+}
@@ -0,0 +1,560 @@
+package pos
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind tells a sale from money handed back over the counter.
+type Kind string
+
+const (
+	KindSale   Kind = "sale"
+	KindReturn Kind = "return"
+)
+
+// SaleStatus is where a sale is between the first item and the receipt.
+type SaleStatus string
+
+const (
+	SaleOpen      SaleStatus = "open"
+	SaleCompleted SaleStatus = "completed"
+	SaleVoided    SaleStatus = "voided"
+)
+
+// Method is how a customer paid or was refunded.
+type Method string
+
+const (
+	MethodCash Method = "cash"
+	MethodCard Method = "card"
+)
+
+// NewMethod validates a raw payment method.
+func NewMethod(raw string) (Method, error) {
+	switch m := Method(raw); m {
+	case MethodCash, MethodCard:
+		return m, nil
+	default:
+		return "", ErrInvalidMethod
+	}
+}
+
+// ReceiptNumber formats a till receipt number from its sequence.
+func ReceiptNumber(seq int64) string {
+	return fmt.Sprintf("POS-%06d", seq)
+}
+
+// Line is an item rung up on a sale: a weighed or scanned pack of a PLU
+// item. AmountCents is its shelf price, from the pack's barcode or its
+// weight at PricePerKgCents. NetCents, VATCents and GrossCents are the
+// line as the tax calculator last priced the sale, at VATRateBP for its
+// TaxCategory; voided lines are not priced. On a sale, ReturnedGrams and
+// ReturnedCents are how much has been brought back; on a return,
+// OriginalLineID is the sale line brought back.
+type Line struct {
+	ID              uuid.UUID
+	ProductID       uuid.UUID
+	PLUCode         int
+	Description     string
+	Barcode         string
+	Grams           int64
+	PricePerKgCents int64
+	AmountCents     int64
+	TaxCategory     string
+	VATRateBP       int
+	NetCents        int64
+	VATCents        int64
+	GrossCents      int64
+	Voided          bool
+	ReturnedGrams   int64
+	ReturnedCents   int64
+	OriginalLineID  *uuid.UUID
+}
+
+// LinePrice is how the tax calculator priced a line.
+type LinePrice struct {
+	TaxCategory string
+	VATRateBP   int
+	NetCents    int64
+	VATCents    int64
+	GrossCents  int64
+}
+
+// Payment is money taken for a sale, or handed back for a return. Cash
+// tendered over the balance is given back as change, so AmountCents is
+// what stays in the drawer. Reference is the card terminal's authorisation
+// code.
+type Payment struct {
+	ID            uuid.UUID
+	Method        Method
+	AmountCents   int64
+	TenderedCents int64
+	ChangeCents   int64
+	Reference     string
+	TakenAt       time.Time
+}
+
+// ReturnItem is part of a sale line brought back. Zero grams returns all
+// that is left of the line.
+type ReturnItem struct {
+	LineID uuid.UUID
+	Grams  int64
+}
+
+// Sale is a walk-in customer's purchase at a till, rung up item by item and
+// completed once it is paid for in full, or money handed back against an
+// earlier sale. Version guards against two tills changing it at once.
+type Sale struct {
+	id             uuid.UUID
+	kind           Kind
+	sessionID      uuid.UUID
+	tillID         uuid.UUID
+	branchID       uuid.UUID
+	cashierID      uuid.UUID
+	status         SaleStatus
+	receiptNumber  string
+	originalSaleID *uuid.UUID
+	lines          []Line
+	payments       []Payment
+	reason         string
+	voidedBy       *uuid.UUID
+	version        int
+	createdAt      time.Time
+	completedAt    *time.Time
+	voidedAt       *time.Time
+	updatedAt      time.Time
+}
+
+// NewSale starts an empty sale in an open session.
+func NewSale(id uuid.UUID, session *Session, cashierID uuid.UUID, now time.Time) (*Sale, error) {
+	if !session.IsOpen() {
+		return nil, ErrSessionClosed
+	}
+	return &Sale{
+		id:        id,
+		kind:      KindSale,
+		sessionID: session.id,
+		tillID:    session.tillID,
+		branchID:  session.branchID,
+		cashierID: cashierID,
+		status:    SaleOpen,
+		lines:     []Line{},
+		payments:  []Payment{},
+		version:   1,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// NewReturn hands money back for items of a completed sale, in an open
+// session, and marks them returned on the original. It is complete as soon
+// as it is made: the refund is paid out in full by method.
+func NewReturn(
+	id uuid.UUID,
+	original *Sale,
+	session *Session,
+	cashierID uuid.UUID,
+	items []ReturnItem,
+	method Method,
+	reference, reason, receiptNumber string,
+	now time.Time,
+) (*Sale, error) {
+	if !session.IsOpen() {
+		return nil, ErrSessionClosed
+	}
+	if original.kind != KindSale {
+		return nil, ErrNotAReturn
+	}
+	switch original.status {
+	case SaleVoided:
+		return nil, ErrSaleVoided
+	case SaleOpen:
+		return nil, ErrSaleNotCompleted
+	}
+	if _, err := NewMethod(string(method)); err != nil {
+		return nil, err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	if len(items) == 0 {
+		return nil, ErrNoLines
+	}
+
+	// Work on a copy so a rejected item leaves the original untouched.
+	updated := make([]Line, len(original.lines))
+	copy(updated, original.lines)
+	returned := make([]Line, 0, len(items))
+	var total int64
+	for _, it := range items {
+		i := lineIndex(updated, it.LineID)
+		if i < 0 {
+			return nil, ErrLineNotFound
+		}
+		l := &updated[i]
+		if l.Voided {
+			return nil, ErrLineVoided
+		}
+		left := l.Grams - l.ReturnedGrams
+		grams := it.Grams
+		if grams == 0 {
+			grams = left
+		}
+		if grams < 0 {
+			return nil, ErrInvalidWeight
+		}
+		if grams == 0 || grams > left {
+			return nil, ErrReturnTooLarge
+		}
+
+		// The last of a line gives back whatever is left of what was paid,
+		// so that returning it in parts never refunds a cent more or less.
+		gross := l.GrossCents - l.ReturnedCents
+		if grams < left {
+			gross = (l.GrossCents*grams + l.Grams/2) / l.Grams
+		}
+		var vat int64
+		if l.GrossCents > 0 {
+			vat = (l.VATCents*gross + l.GrossCents/2) / l.GrossCents
+		}
+		l.ReturnedGrams += grams
+		l.ReturnedCents += gross
+		total += gross
+
+		originalID := l.ID
+		returned = append(returned, Line{
+			ID:              uuid.New(),
+			ProductID:       l.ProductID,
+			PLUCode:         l.PLUCode,
+			Description:     l.Description,
+			Barcode:         l.Barcode,
+			Grams:           grams,
+			PricePerKgCents: l.PricePerKgCents,
+			AmountCents:     gross,
+			TaxCategory:     l.TaxCategory,
+			VATRateBP:       l.VATRateBP,
+			NetCents:        gross - vat,
+			VATCents:        vat,
+			GrossCents:      gross,
+			OriginalLineID:  &originalID,
+		})
+	}
+	original.lines = updated
+	original.updatedAt = now
+
+	originalID := original.id
+	return &Sale{
+		id:             id,
+		kind:           KindReturn,
+		sessionID:      session.id,
+		tillID:         session.tillID,
+		branchID:       session.branchID,
+		cashierID:      cashierID,
+		status:         SaleCompleted,
+		receiptNumber:  receiptNumber,
+		originalSaleID: &originalID,
+		lines:          returned,
+		payments: []Payment{{
+			ID:            uuid.New(),
+			Method:        method,
+			AmountCents:   total,
+			TenderedCents: total,
+			Reference:     strings.TrimSpace(reference),
+			TakenAt:       now,
+		}},
+		reason:      reason,
+		version:     1,
+		createdAt:   now,
+		completedAt: &now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstructSale reconstructs a Sale from persistence without validation.
+func ReconstructSale(
+	id uuid.UUID,
+	kind Kind,
+	sessionID, tillID, branchID, cashierID uuid.UUID,
+	status SaleStatus,
+	receiptNumber string,
+	originalSaleID *uuid.UUID,
+	lines []Line,
+	payments []Payment,
+	reason string,
+	voidedBy *uuid.UUID,
+	version int,
+	createdAt time.Time,
+	completedAt, voidedAt *time.Time,
+	updatedAt time.Time,
+) *Sale {
+	return &Sale{
+		id:             id,
+		kind:           kind,
+		sessionID:      sessionID,
+		tillID:         tillID,
+		branchID:       branchID,
+		cashierID:      cashierID,
+		status:         status,
+		receiptNumber:  receiptNumber,
+		originalSaleID: originalSaleID,
+		lines:          lines,
+		payments:       payments,
+		reason:         reason,
+		voidedBy:       voidedBy,
+		version:        version,
+		createdAt:      createdAt,
+		completedAt:    completedAt,
+		voidedAt:       voidedAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+func (s *Sale) ID() uuid.UUID              { return s.id }
+func (s *Sale) Kind() Kind                 { return s.kind }
+func (s *Sale) SessionID() uuid.UUID       { return s.sessionID }
+func (s *Sale) TillID() uuid.UUID          { return s.tillID }
+func (s *Sale) BranchID() uuid.UUID        { return s.branchID }
+func (s *Sale) CashierID() uuid.UUID       { return s.cashierID }
+func (s *Sale) Status() SaleStatus         { return s.status }
+func (s *Sale) ReceiptNumber() string      { return s.receiptNumber }
+func (s *Sale) OriginalSaleID() *uuid.UUID { return s.originalSaleID }
+func (s *Sale) Lines() []Line              { return s.lines }
+func (s *Sale) Payments() []Payment        { return s.payments }
+func (s *Sale) Reason() string             { return s.reason }
+func (s *Sale) VoidedBy() *uuid.UUID       { return s.voidedBy }
+func (s *Sale) Version() int               { return s.version }
+func (s *Sale) CreatedAt() time.Time       { return s.createdAt }
+func (s *Sale) CompletedAt() *time.Time    { return s.completedAt }
+func (s *Sale) VoidedAt() *time.Time       { return s.voidedAt }
+func (s *Sale) UpdatedAt() time.Time       { return s.updatedAt }
+
+// LiveLines are the lines that have not been voided, which are what the
+// customer pays for.
+func (s *Sale) LiveLines() []Line {
+	live := make([]Line, 0, len(s.lines))
+	for _, l := range s.lines {
+		if !l.Voided {
+			live = append(live, l)
+		}
+	}
+	return live
+}
+
+// TotalCents is what the customer pays, or is paid back for a return.
+func (s *Sale) TotalCents() int64 {
+	var total int64
+	for _, l := range s.LiveLines() {
+		total += l.GrossCents
+	}
+	return total
+}
+
+// PaidCents is how much of the total has been paid so far.
+func (s *Sale) PaidCents() int64 {
+	var paid int64
+	for _, p := range s.payments {
+		paid += p.AmountCents
+	}
+	return paid
+}
+
+// BalanceCents is what is still to be paid.
+func (s *Sale) BalanceCents() int64 { return s.TotalCents() - s.PaidCents() }
+
+// ChangeCents is the change given from cash tendered.
+func (s *Sale) ChangeCents() int64 {
+	var change int64
+	for _, p := range s.payments {
+		change += p.ChangeCents
+	}
+	return change
+}
+
+// HasReturns reports whether anything of the sale has been brought back.
+func (s *Sale) HasReturns() bool {
+	for _, l := range s.lines {
+		if l.ReturnedGrams > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AddLine rings up an item. The sale must be repriced afterwards.
+func (s *Sale) AddLine(l Line, now time.Time) (Line, error) {
+	if s.status != SaleOpen {
+		return Line{}, ErrSaleNotOpen
+	}
+	if l.Grams <= 0 {
+		return Line{}, ErrInvalidWeight
+	}
+	if l.PricePerKgCents < 0 || l.AmountCents < 0 {
+		return Line{}, ErrNegativePrice
+	}
+	added := Line{
+		ID:              uuid.New(),
+		ProductID:       l.ProductID,
+		PLUCode:         l.PLUCode,
+		Description:     strings.TrimSpace(l.Description),
+		Barcode:         l.Barcode,
+		Grams:           l.Grams,
+		PricePerKgCents: l.PricePerKgCents,
+		AmountCents:     l.AmountCents,
+	}
+	s.lines = append(s.lines, added)
+	s.updatedAt = now
+	return added, nil
+}
+
+// VoidLine takes an item off the sale before it is paid for. The sale must
+// be repriced afterwards.
+func (s *Sale) VoidLine(lineID uuid.UUID, now time.Time) error {
+	if s.status != SaleOpen {
+		return ErrSaleNotOpen
+	}
+	if len(s.payments) > 0 {
+		return ErrPaymentsTaken
+	}
+	i := lineIndex(s.lines, lineID)
+	if i < 0 {
+		return ErrLineNotFound
+	}
+	if s.lines[i].Voided {
+		return ErrLineVoided
+	}
+	s.lines[i].Voided = true
+	s.lines[i].TaxCategory, s.lines[i].VATRateBP = "", 0
+	s.lines[i].NetCents, s.lines[i].VATCents, s.lines[i].GrossCents = 0, 0, 0
+	s.updatedAt = now
+	return nil
+}
+
+// Reprice stores how the tax calculator priced the live lines, given in
+// the order LiveLines returns them.
+func (s *Sale) Reprice(prices []LinePrice) error {
+	if s.status != SaleOpen {
+		return ErrSaleNotOpen
+	}
+	live := 0
+	for _, l := range s.lines {
+		if !l.Voided {
+			live++
+		}
+	}
+	if len(prices) != live {
+		return fmt.Errorf("repricing sale %s: %d prices for %d lines", s.id, len(prices), live)
+	}
+	for _, p := range prices {
+		if p.NetCents < 0 || p.VATCents < 0 || p.NetCents+p.VATCents != p.GrossCents {
+			return ErrUnbalancedLine
+		}
+	}
+	next := 0
+	for i := range s.lines {
+		if s.lines[i].Voided {
+			continue
+		}
+		p := prices[next]
+		next++
+		s.lines[i].TaxCategory = p.TaxCategory
+		s.lines[i].VATRateBP = p.VATRateBP
+		s.lines[i].NetCents = p.NetCents
+		s.lines[i].VATCents = p.VATCents
+		s.lines[i].GrossCents = p.GrossCents
+	}
+	return nil
+}
+
+// Pay takes a payment towards the balance. Cash tendered over the balance
+// is given back as change; a card payment may not be more than the
+// balance. Once the balance is paid the sale is ready to complete.
+func (s *Sale) Pay(method Method, cents int64, reference string, now time.Time) (Payment, error) {
+	if s.status != SaleOpen {
+		return Payment{}, ErrSaleNotOpen
+	}
+	if _, err := NewMethod(string(method)); err != nil {
+		return Payment{}, err
+	}
+	if len(s.LiveLines()) == 0 {
+		return Payment{}, ErrNoLines
+	}
+	if cents <= 0 {
+		return Payment{}, ErrInvalidAmount
+	}
+	balance := s.BalanceCents()
+	if balance <= 0 {
+		return Payment{}, ErrNothingDue
+	}
+
+	p := Payment{ID: uuid.New(), Method: method, AmountCents: cents, TenderedCents: cents, TakenAt: now}
+	switch method {
+	case MethodCash:
+		p.AmountCents = min(cents, balance)
+		p.ChangeCents = cents - p.AmountCents
+	case MethodCard:
+		if cents > balance {
+			return Payment{}, ErrOverpayment
+		}
+		p.Reference = strings.TrimSpace(reference)
+	}
+	s.payments = append(s.payments, p)
+	s.updatedAt = now
+	return p, nil
+}
+
+// Complete closes a paid sale under its receipt number.
+func (s *Sale) Complete(receiptNumber string, now time.Time) error {
+	if s.status != SaleOpen {
+		return ErrSaleNotOpen
+	}
+	if len(s.LiveLines()) == 0 {
+		return ErrNoLines
+	}
+	if s.BalanceCents() > 0 {
+		return ErrBalanceDue
+	}
+	s.status = SaleCompleted
+	s.receiptNumber = receiptNumber
+	s.completedAt = &now
+	s.updatedAt = now
+	return nil
+}
+
+// Void cancels a sale. Payments on it are handed back to the customer. A
+// completed sale can no longer be voided once anything has been returned
+// against it, and returns themselves cannot be voided.
+func (s *Sale) Void(reason string, actorID uuid.UUID, now time.Time) error {
+	if s.kind == KindReturn {
+		return ErrReturnNotVoidable
+	}
+	if s.status == SaleVoided {
+		return ErrSaleVoided
+	}
+	if s.HasReturns() {
+		return ErrSaleReturned
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+	s.status = SaleVoided
+	s.reason = reason
+	s.voidedBy = &actorID
+	s.voidedAt = &now
+	s.updatedAt = now
+	return nil
+}
+
+func lineIndex(lines []Line, id uuid.UUID) int {
+	for i, l := range lines {
+		if l.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,175 @@
+package pos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openSession(t *testing.T, floatCents int64) *pos.Session {
+	t.Helper()
+	till, err := pos.NewTill(uuid.New(), uuid.New(), "Till 1", time.Now())
+	require.NoError(t, err)
+	s, err := pos.OpenSession(uuid.New(), till, floatCents, uuid.New(), time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+// ringUp starts a sale with lines priced at their shelf price at 20% VAT
+// included.
+func ringUp(t *testing.T, session *pos.Session, amounts ...int64) *pos.Sale {
+	t.Helper()
+	s, err := pos.NewSale(uuid.New(), session, uuid.New(), time.Now())
+	require.NoError(t, err)
+	for _, a := range amounts {
+		_, err := s.AddLine(pos.Line{ProductID: uuid.New(), PLUCode: 101, Description: "Lamb chops", Grams: 1000, PricePerKgCents: a, AmountCents: a}, time.Now())
+		require.NoError(t, err)
+	}
+	reprice(t, s)
+	return s
+}
+
+func reprice(t *testing.T, s *pos.Sale) {
+	t.Helper()
+	var prices []pos.LinePrice
+	for _, l := range s.LiveLines() {
+		vat := (l.AmountCents + 3) / 6
+		prices = append(prices, pos.LinePrice{TaxCategory: "standard", VATRateBP: 2000, NetCents: l.AmountCents - vat, VATCents: vat, GrossCents: l.AmountCents})
+	}
+	require.NoError(t, s.Reprice(prices))
+}
+
+func completedSale(t *testing.T, session *pos.Session, amounts ...int64) *pos.Sale {
+	t.Helper()
+	s := ringUp(t, session, amounts...)
+	_, err := s.Pay(pos.MethodCard, s.TotalCents(), "AUTH1", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, s.Complete("POS-000001", time.Now()))
+	return s
+}
+
+func TestSale_SplitPaymentGivesChangeFromCash(t *testing.T) {
+	s := ringUp(t, openSession(t, 0), 1250, 800)
+
+	card, err := s.Pay(pos.MethodCard, 1000, " 0042 ", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "0042", card.Reference)
+	assert.ErrorIs(t, s.Complete("POS-000001", time.Now()), pos.ErrBalanceDue)
+
+	cash, err := s.Pay(pos.MethodCash, 2000, "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1050), cash.AmountCents)
+	assert.Equal(t, int64(950), cash.ChangeCents)
+	assert.Equal(t, int64(0), s.BalanceCents())
+
+	_, err = s.Pay(pos.MethodCash, 100, "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrNothingDue)
+
+	require.NoError(t, s.Complete("POS-000001", time.Now()))
+	assert.Equal(t, pos.SaleCompleted, s.Status())
+	assert.Equal(t, "POS-000001", s.ReceiptNumber())
+}
+
+func TestSale_Pay_RejectsCardOverBalanceAndEmptySales(t *testing.T) {
+	s := ringUp(t, openSession(t, 0), 1250)
+	_, err := s.Pay(pos.MethodCard, 1251, "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrOverpayment)
+	_, err = s.Pay(pos.MethodCash, 0, "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrInvalidAmount)
+	_, err = s.Pay("cheque", 100, "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrInvalidMethod)
+
+	empty := ringUp(t, openSession(t, 0))
+	_, err = empty.Pay(pos.MethodCash, 100, "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrNoLines)
+}
+
+func TestSale_VoidLine(t *testing.T) {
+	s := ringUp(t, openSession(t, 0), 1250, 800)
+	voided := s.Lines()[0].ID
+
+	require.NoError(t, s.VoidLine(voided, time.Now()))
+	reprice(t, s)
+	assert.Equal(t, int64(800), s.TotalCents())
+	assert.Len(t, s.LiveLines(), 1)
+	assert.ErrorIs(t, s.VoidLine(voided, time.Now()), pos.ErrLineVoided)
+	assert.ErrorIs(t, s.VoidLine(uuid.New(), time.Now()), pos.ErrLineNotFound)
+
+	_, err := s.Pay(pos.MethodCash, 500, "", time.Now())
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.VoidLine(s.Lines()[1].ID, time.Now()), pos.ErrPaymentsTaken)
+}
+
+func TestSale_Void(t *testing.T) {
+	session := openSession(t, 0)
+
+	open := ringUp(t, session, 1250)
+	assert.ErrorIs(t, open.Void(" ", uuid.New(), time.Now()), pos.ErrReasonRequired)
+	require.NoError(t, open.Void("customer changed their mind", uuid.New(), time.Now()))
+	assert.Equal(t, pos.SaleVoided, open.Status())
+	assert.ErrorIs(t, open.Void("again", uuid.New(), time.Now()), pos.ErrSaleVoided)
+	_, err := open.AddLine(pos.Line{Grams: 100}, time.Now())
+	assert.ErrorIs(t, err, pos.ErrSaleNotOpen)
+
+	returned := completedSale(t, session, 1250)
+	_, err = pos.NewReturn(uuid.New(), returned, session, uuid.New(), []pos.ReturnItem{{LineID: returned.Lines()[0].ID, Grams: 100}}, pos.MethodCash, "", "off", "POS-000002", time.Now())
+	require.NoError(t, err)
+	assert.ErrorIs(t, returned.Void("mistake", uuid.New(), time.Now()), pos.ErrSaleReturned)
+}
+
+func TestNewReturn_RefundsInPartsWithoutDrift(t *testing.T) {
+	session := openSession(t, 0)
+	original := completedSale(t, session, 1001)
+	lineID := original.Lines()[0].ID
+
+	first, err := pos.NewReturn(uuid.New(), original, session, uuid.New(), []pos.ReturnItem{{LineID: lineID, Grams: 333}}, pos.MethodCash, "", "off smell", "POS-000002", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, pos.KindReturn, first.Kind())
+	assert.Equal(t, pos.SaleCompleted, first.Status())
+	assert.Equal(t, int64(333), first.TotalCents())
+	assert.Equal(t, int64(333), first.Payments()[0].AmountCents)
+	assert.Equal(t, &lineID, first.Lines()[0].OriginalLineID)
+
+	_, err = pos.NewReturn(uuid.New(), original, session, uuid.New(), []pos.ReturnItem{{LineID: lineID, Grams: 668}}, pos.MethodCash, "", "off smell", "POS-000003", time.Now())
+	assert.ErrorIs(t, err, pos.ErrReturnTooLarge)
+	assert.Equal(t, int64(333), original.Lines()[0].ReturnedGrams, "a rejected return leaves the sale untouched")
+
+	rest, err := pos.NewReturn(uuid.New(), original, session, uuid.New(), []pos.ReturnItem{{LineID: lineID}}, pos.MethodCard, "R9", "off smell", "POS-000003", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(667), rest.Lines()[0].Grams)
+	assert.Equal(t, int64(1001), first.TotalCents()+rest.TotalCents())
+	assert.Equal(t, original.Lines()[0].VATCents, first.Lines()[0].VATCents+rest.Lines()[0].VATCents)
+
+	_, err = pos.NewReturn(uuid.New(), original, session, uuid.New(), []pos.ReturnItem{{LineID: lineID}}, pos.MethodCash, "", "again", "POS-000004", time.Now())
+	assert.ErrorIs(t, err, pos.ErrReturnTooLarge)
+}
+
+func TestNewReturn_RejectsUnpaidVoidedAndReturnedSales(t *testing.T) {
+	session := openSession(t, 0)
+	item := func(s *pos.Sale) []pos.ReturnItem { return []pos.ReturnItem{{LineID: s.Lines()[0].ID}} }
+
+	open := ringUp(t, session, 500)
+	_, err := pos.NewReturn(uuid.New(), open, session, uuid.New(), item(open), pos.MethodCash, "", "off", "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrSaleNotCompleted)
+
+	voided := completedSale(t, session, 500)
+	require.NoError(t, voided.Void("rung up twice", uuid.New(), time.Now()))
+	_, err = pos.NewReturn(uuid.New(), voided, session, uuid.New(), item(voided), pos.MethodCash, "", "off", "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrSaleVoided)
+
+	sale := completedSale(t, session, 500)
+	ret, err := pos.NewReturn(uuid.New(), sale, session, uuid.New(), item(sale), pos.MethodCash, "", "off", "", time.Now())
+	require.NoError(t, err)
+	_, err = pos.NewReturn(uuid.New(), ret, session, uuid.New(), item(ret), pos.MethodCash, "", "off", "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrNotAReturn)
+	assert.ErrorIs(t, ret.Void("mistake", uuid.New(), time.Now()), pos.ErrReturnNotVoidable)
+
+	_, err = pos.NewReturn(uuid.New(), sale, session, uuid.New(), nil, pos.MethodCash, "", "off", "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrNoLines)
+	_, err = pos.NewReturn(uuid.New(), sale, session, uuid.New(), item(sale), pos.MethodCash, "", "", "", time.Now())
+	assert.ErrorIs(t, err, pos.ErrReasonRequired)
+}
@@ -0,0 +1,161 @@
+package pos
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Till is a cash drawer at a branch's counter. Cashiers ring sales up on it
+// during sessions, one at a time.
+type Till struct {
+	id        uuid.UUID
+	branchID  uuid.UUID
+	name      string
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewTill validates and creates an active till.
+func NewTill(id, branchID uuid.UUID, name string, now time.Time) (*Till, error) {
+	if branchID == uuid.Nil {
+		return nil, ErrBranchRequired
+	}
+	t := &Till{id: id, branchID: branchID, createdAt: now}
+	if err := t.Update(name, true, now); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ReconstructTill reconstructs a Till from persistence without validation.
+func ReconstructTill(id, branchID uuid.UUID, name string, active bool, createdAt, updatedAt time.Time) *Till {
+	return &Till{id: id, branchID: branchID, name: name, active: active, createdAt: createdAt, updatedAt: updatedAt}
+}
+
+func (t *Till) ID() uuid.UUID        { return t.id }
+func (t *Till) BranchID() uuid.UUID  { return t.branchID }
+func (t *Till) Name() string         { return t.name }
+func (t *Till) Active() bool         { return t.active }
+func (t *Till) CreatedAt() time.Time { return t.createdAt }
+func (t *Till) UpdatedAt() time.Time { return t.updatedAt }
+
+// Update renames the till or takes it out of service. A disabled till's
+// open session can still be closed.
+func (t *Till) Update(name string, active bool, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyTillName
+	}
+	t.name = name
+	t.active = active
+	t.updatedAt = now
+	return nil
+}
+
+// SessionStatus is whether a till session is still taking sales.
+type SessionStatus string
+
+const (
+	SessionOpen   SessionStatus = "open"
+	SessionClosed SessionStatus = "closed"
+)
+
+// Session is a cashier's shift on a till, from putting the float in the
+// drawer to counting the drawer at close. Everything rung up in between is
+// reconciled against the count in the session's Z report.
+type Session struct {
+	id               uuid.UUID
+	tillID           uuid.UUID
+	branchID         uuid.UUID
+	status           SessionStatus
+	floatCents       int64
+	countedCashCents *int64
+	openedBy         uuid.UUID
+	closedBy         *uuid.UUID
+	openedAt         time.Time
+	closedAt         *time.Time
+}
+
+// OpenSession starts a session on an active till with floatCents of cash
+// in the drawer.
+func OpenSession(id uuid.UUID, till *Till, floatCents int64, openedBy uuid.UUID, now time.Time) (*Session, error) {
+	if !till.active {
+		return nil, ErrTillInactive
+	}
+	if floatCents < 0 {
+		return nil, ErrInvalidFloat
+	}
+	return &Session{
+		id:         id,
+		tillID:     till.id,
+		branchID:   till.branchID,
+		status:     SessionOpen,
+		floatCents: floatCents,
+		openedBy:   openedBy,
+		openedAt:   now,
+	}, nil
+}
+
+// ReconstructSession reconstructs a Session from persistence without
+// validation.
+func ReconstructSession(
+	id, tillID, branchID uuid.UUID,
+	status SessionStatus,
+	floatCents int64,
+	countedCashCents *int64,
+	openedBy uuid.UUID,
+	closedBy *uuid.UUID,
+	openedAt time.Time,
+	closedAt *time.Time,
+) *Session {
+	return &Session{
+		id:               id,
+		tillID:           tillID,
+		branchID:         branchID,
+		status:           status,
+		floatCents:       floatCents,
+		countedCashCents: countedCashCents,
+		openedBy:         openedBy,
+		closedBy:         closedBy,
+		openedAt:         openedAt,
+		closedAt:         closedAt,
+	}
+}
+
+func (s *Session) ID() uuid.UUID            { return s.id }
+func (s *Session) TillID() uuid.UUID        { return s.tillID }
+func (s *Session) BranchID() uuid.UUID      { return s.branchID }
+func (s *Session) Status() SessionStatus    { return s.status }
+func (s *Session) FloatCents() int64        { return s.floatCents }
+func (s *Session) CountedCashCents() *int64 { return s.countedCashCents }
+func (s *Session) OpenedBy() uuid.UUID      { return s.openedBy }
+func (s *Session) ClosedBy() *uuid.UUID     { return s.closedBy }
+func (s *Session) OpenedAt() time.Time      { return s.openedAt }
+func (s *Session) ClosedAt() *time.Time     { return s.closedAt }
+
+// IsOpen reports whether the session is still taking sales.
+func (s *Session) IsOpen() bool { return s.status == SessionOpen }
+
+// Close ends the session with the cash counted in the drawer. Sales still
+// being rung up must be paid for or voided first.
+func (s *Session) Close(countedCashCents int64, sales []*Sale, closedBy uuid.UUID, now time.Time) error {
+	if !s.IsOpen() {
+		return ErrSessionClosed
+	}
+	if countedCashCents < 0 {
+		return ErrInvalidCount
+	}
+	for _, sale := range sales {
+		if sale.sessionID == s.id && sale.status == SaleOpen {
+			return ErrSalesInProgress
+		}
+	}
+	s.status = SessionClosed
+	s.countedCashCents = &countedCashCents
+	s.closedBy = &closedBy
+	s.closedAt = &now
+	return nil
+}
@@ -0,0 +1,28 @@
+package procurement
+
+import "errors"
+
+var (
+	ErrEmptySupplierName          = errors.New("supplier name must not be empty")
+	ErrInvalidSupplierType        = errors.New("supplier type must be one of farm, abattoir or wholesaler")
+	ErrEmptyContactName           = errors.New("contact name must not be empty")
+	ErrInvalidContactEmail        = errors.New("contact email is not a valid email address")
+	ErrInvalidSupplierCertificate = errors.New("supplier certificates need a type and a number")
+	ErrInvalidPaymentTerms        = errors.New("payment terms must be between 0 and 180 days")
+	ErrSupplierNotFound           = errors.New("supplier not found")
+	ErrSupplierInactive           = errors.New("supplier is inactive")
+	ErrNoLines                    = errors.New("purchase order must contain at least one line")
+	ErrInvalidWeight              = errors.New("weight must be greater than zero")
+	ErrNegativePrice              = errors.New("price must not be negative")
+	ErrDuplicateProduct           = errors.New("purchase order lists the same product more than once")
+	ErrInvalidDate                = errors.New("date must be in YYYY-MM-DD format")
+	ErrInvalidStatus              = errors.New("status must be one of draft, sent, partially_received, received or closed")
+	ErrNotDraft                   = errors.New("only a draft purchase order can be changed")
+	ErrInvalidTransition          = errors.New("purchase order cannot move to that status from its current one")
+	ErrNotReceivable              = errors.New("goods can only be received against a sent purchase order")
+	ErrUnknownLine                = errors.New("receipt line does not belong to the purchase order")
+	ErrEmptyLotCode               = errors.New("lot code must not be empty")
+	ErrInvalidTemperature         = errors.New("temperature must be between -30 and 30 degrees Celsius")
+	ErrPurchaseOrderNotFound      = errors.New("purchase order not found")
+	ErrConcurrentUpdate           = errors.New("purchase order was changed by someone else; reload and try again")
+)
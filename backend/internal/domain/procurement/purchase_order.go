@@ -0,0 +1,291 @@
+package procurement
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle state of a purchase order.
+type Status string
+
+const (
+	StatusDraft             Status = "draft"
+	StatusSent              Status = "sent"
+	StatusPartiallyReceived Status = "partially_received"
+	StatusReceived          Status = "received"
+	StatusClosed            Status = "closed"
+)
+
+// NewStatus validates a raw purchase order status.
+func NewStatus(raw string) (Status, error) {
+	switch s := Status(raw); s {
+	case StatusDraft, StatusSent, StatusPartiallyReceived, StatusReceived, StatusClosed:
+		return s, nil
+	default:
+		return "", ErrInvalidStatus
+	}
+}
+
+// Line is the weight of one product ordered from a supplier and the agreed
+// price per kilogram. ReceivedGrams accumulates across goods-received notes.
+type Line struct {
+	ID              uuid.UUID
+	ProductID       uuid.UUID
+	OrderedGrams    int64
+	PricePerKgCents int64
+	ReceivedGrams   int64
+}
+
+// OutstandingGrams is how much of the line has still to arrive.
+func (l Line) OutstandingGrams() int64 {
+	if l.ReceivedGrams >= l.OrderedGrams {
+		return 0
+	}
+	return l.OrderedGrams - l.ReceivedGrams
+}
+
+// OrderedCents is the cost of the line as ordered.
+func (l Line) OrderedCents() int64 { return costOf(l.OrderedGrams, l.PricePerKgCents) }
+
+// ReceivedCents is the cost of what has arrived so far.
+func (l Line) ReceivedCents() int64 { return costOf(l.ReceivedGrams, l.PricePerKgCents) }
+
+// costOf prices a weight at a per-kilogram rate, rounded to the nearest cent.
+func costOf(grams, pricePerKgCents int64) int64 {
+	return (grams*pricePerKgCents + 500) / 1000
+}
+
+// PurchaseOrder is an order placed with a supplier for delivery to one
+// branch. It is edited as a draft, sent to the supplier, received against
+// with goods-received notes and finally closed, which also settles any
+// shortfall. Version guards against two people changing it at once.
+type PurchaseOrder struct {
+	id         uuid.UUID
+	supplierID uuid.UUID
+	branchID   uuid.UUID
+	status     Status
+	lines      []Line
+	expectedOn *time.Time
+	notes      string
+	createdBy  *uuid.UUID
+	version    int
+	createdAt  time.Time
+	sentAt     *time.Time
+	closedAt   *time.Time
+	updatedAt  time.Time
+}
+
+// NewPurchaseOrder creates a draft purchase order. Line IDs are assigned here.
+func NewPurchaseOrder(
+	id, supplierID, branchID uuid.UUID,
+	lines []Line,
+	expectedOn *time.Time,
+	notes string,
+	createdBy *uuid.UUID,
+	now time.Time,
+) (*PurchaseOrder, error) {
+	po := &PurchaseOrder{
+		id:         id,
+		supplierID: supplierID,
+		branchID:   branchID,
+		status:     StatusDraft,
+		createdBy:  createdBy,
+		version:    1,
+		createdAt:  now,
+	}
+	if err := po.Revise(lines, expectedOn, notes, now); err != nil {
+		return nil, err
+	}
+	return po, nil
+}
+
+// ReconstructPurchaseOrder reconstructs a PurchaseOrder from persistence without validation.
+func ReconstructPurchaseOrder(
+	id, supplierID, branchID uuid.UUID,
+	status Status,
+	lines []Line,
+	expectedOn *time.Time,
+	notes string,
+	createdBy *uuid.UUID,
+	version int,
+	createdAt time.Time,
+	sentAt, closedAt *time.Time,
+	updatedAt time.Time,
+) *PurchaseOrder {
+	return &PurchaseOrder{
+		id:         id,
+		supplierID: supplierID,
+		branchID:   branchID,
+		status:     status,
+		lines:      lines,
+		expectedOn: expectedOn,
+		notes:      notes,
+		createdBy:  createdBy,
+		version:    version,
+		createdAt:  createdAt,
+		sentAt:     sentAt,
+		closedAt:   closedAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (po *PurchaseOrder) ID() uuid.UUID          { return po.id }
+func (po *PurchaseOrder) SupplierID() uuid.UUID  { return po.supplierID }
+func (po *PurchaseOrder) BranchID() uuid.UUID    { return po.branchID }
+func (po *PurchaseOrder) Status() Status         { return po.status }
+func (po *PurchaseOrder) Lines() []Line          { return po.lines }
+func (po *PurchaseOrder) ExpectedOn() *time.Time { return po.expectedOn }
+func (po *PurchaseOrder) Notes() string          { return po.notes }
+func (po *PurchaseOrder) CreatedBy() *uuid.UUID  { return po.createdBy }
+func (po *PurchaseOrder) Version() int           { return po.version }
+func (po *PurchaseOrder) CreatedAt() time.Time   { return po.createdAt }
+func (po *PurchaseOrder) SentAt() *time.Time     { return po.sentAt }
+func (po *PurchaseOrder) ClosedAt() *time.Time   { return po.closedAt }
+func (po *PurchaseOrder) UpdatedAt() time.Time   { return po.updatedAt }
+
+// TotalCents is the cost of the order as placed.
+func (po *PurchaseOrder) TotalCents() int64 {
+	var total int64
+	for _, l := range po.lines {
+		total += l.OrderedCents()
+	}
+	return total
+}
+
+// Revise replaces the lines, expected delivery date and notes of a draft.
+func (po *PurchaseOrder) Revise(lines []Line, expectedOn *time.Time, notes string, now time.Time) error {
+	if po.status != StatusDraft {
+		return ErrNotDraft
+	}
+	if len(lines) == 0 {
+		return ErrNoLines
+	}
+
+	seen := make(map[uuid.UUID]bool, len(lines))
+	revised := make([]Line, 0, len(lines))
+	for _, l := range lines {
+		if l.OrderedGrams <= 0 {
+			return ErrInvalidWeight
+		}
+		if l.PricePerKgCents < 0 {
+			return ErrNegativePrice
+		}
+		if seen[l.ProductID] {
+			return ErrDuplicateProduct
+		}
+		seen[l.ProductID] = true
+		revised = append(revised, Line{
+			ID:              uuid.New(),
+			ProductID:       l.ProductID,
+			OrderedGrams:    l.OrderedGrams,
+			PricePerKgCents: l.PricePerKgCents,
+		})
+	}
+
+	po.lines = revised
+	po.expectedOn = expectedOn
+	po.notes = strings.TrimSpace(notes)
+	po.updatedAt = now
+	return nil
+}
+
+// Send marks a draft as placed with the supplier. Its lines and prices are
+// fixed from then on.
+func (po *PurchaseOrder) Send(now time.Time) error {
+	if po.status != StatusDraft {
+		return ErrInvalidTransition
+	}
+	po.status = StatusSent
+	po.sentAt = &now
+	po.updatedAt = now
+	return nil
+}
+
+// Close ends the order. Anything still outstanding is no longer expected and
+// shows up as a shortfall in the variance report. A draft cannot be closed
+// because nothing was ever ordered.
+func (po *PurchaseOrder) Close(now time.Time) error {
+	switch po.status {
+	case StatusSent, StatusPartiallyReceived, StatusReceived:
+	default:
+		return ErrInvalidTransition
+	}
+	po.status = StatusClosed
+	po.closedAt = &now
+	po.updatedAt = now
+	return nil
+}
+
+// IsSettled reports whether the order's received amounts are final.
+func (po *PurchaseOrder) IsSettled() bool {
+	return po.status == StatusReceived || po.status == StatusClosed
+}
+
+// Receive books a delivery against the order and returns its goods-received
+// note. Each receipt line names the order line it fulfils; the product comes
+// from that line. Deliveries may exceed what was ordered. The order becomes
+// received once nothing is outstanding and partially received until then.
+func (po *PurchaseOrder) Receive(
+	id uuid.UUID,
+	lines []ReceiptLine,
+	notes string,
+	receivedBy *uuid.UUID,
+	now time.Time,
+) (*GoodsReceivedNote, error) {
+	if po.status != StatusSent && po.status != StatusPartiallyReceived {
+		return nil, ErrNotReceivable
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	index := make(map[uuid.UUID]int, len(po.lines))
+	for i, l := range po.lines {
+		index[l.ID] = i
+	}
+
+	received := make([]ReceiptLine, 0, len(lines))
+	updated := append([]Line(nil), po.lines...)
+	for _, rl := range lines {
+		i, ok := index[rl.LineID]
+		if !ok {
+			return nil, ErrUnknownLine
+		}
+		rl.LotCode = strings.TrimSpace(rl.LotCode)
+		if rl.LotCode == "" {
+			return nil, ErrEmptyLotCode
+		}
+		if rl.Grams <= 0 {
+			return nil, ErrInvalidWeight
+		}
+		if rl.TemperatureTenthsC < minTemperatureTenthsC || rl.TemperatureTenthsC > maxTemperatureTenthsC {
+			return nil, ErrInvalidTemperature
+		}
+		rl.ProductID = updated[i].ProductID
+		rl.LotID = uuid.New()
+		updated[i].ReceivedGrams += rl.Grams
+		received = append(received, rl)
+	}
+
+	po.lines = updated
+	po.status = StatusReceived
+	for _, l := range po.lines {
+		if l.OutstandingGrams() > 0 {
+			po.status = StatusPartiallyReceived
+			break
+		}
+	}
+	po.updatedAt = now
+
+	return &GoodsReceivedNote{
+		id:              id,
+		purchaseOrderID: po.id,
+		branchID:        po.branchID,
+		lines:           received,
+		notes:           strings.TrimSpace(notes),
+		receivedBy:      receivedBy,
+		receivedAt:      now,
+	}, nil
+}
@@ -0,0 +1,141 @@
+package procurement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSentOrder(t *testing.T, lines ...procurement.Line) *procurement.PurchaseOrder {
+	t.Helper()
+	now := time.Now()
+	po, err := procurement.NewPurchaseOrder(uuid.New(), uuid.New(), uuid.New(), lines, nil, "", nil, now)
+	require.NoError(t, err)
+	require.NoError(t, po.Send(now))
+	return po
+}
+
+func TestNewPurchaseOrder_AssignsLineIDs(t *testing.T) {
+	productID := uuid.New()
+	po, err := procurement.NewPurchaseOrder(uuid.New(), uuid.New(), uuid.New(),
+		[]procurement.Line{{ProductID: productID, OrderedGrams: 20000, PricePerKgCents: 1250}}, nil, " weekly lamb ", nil, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, procurement.StatusDraft, po.Status())
+	assert.NotEqual(t, uuid.Nil, po.Lines()[0].ID)
+	assert.Equal(t, "weekly lamb", po.Notes())
+	assert.Equal(t, int64(25000), po.TotalCents())
+}
+
+func TestNewPurchaseOrder_InvalidLines_ReturnError(t *testing.T) {
+	productID := uuid.New()
+	tests := []struct {
+		name  string
+		lines []procurement.Line
+		want  error
+	}{
+		{"no lines", nil, procurement.ErrNoLines},
+		{"zero weight", []procurement.Line{{ProductID: productID, PricePerKgCents: 100}}, procurement.ErrInvalidWeight},
+		{"negative price", []procurement.Line{{ProductID: productID, OrderedGrams: 1000, PricePerKgCents: -1}}, procurement.ErrNegativePrice},
+		{"duplicate product", []procurement.Line{
+			{ProductID: productID, OrderedGrams: 1000},
+			{ProductID: productID, OrderedGrams: 2000},
+		}, procurement.ErrDuplicateProduct},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := procurement.NewPurchaseOrder(uuid.New(), uuid.New(), uuid.New(), tt.lines, nil, "", nil, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestPurchaseOrder_Transitions(t *testing.T) {
+	now := time.Now()
+	line := procurement.Line{ProductID: uuid.New(), OrderedGrams: 1000, PricePerKgCents: 900}
+
+	t.Run("sent order is no longer editable", func(t *testing.T) {
+		po := newSentOrder(t, line)
+
+		assert.NotNil(t, po.SentAt())
+		assert.ErrorIs(t, po.Revise([]procurement.Line{line}, nil, "", now), procurement.ErrNotDraft)
+		assert.ErrorIs(t, po.Send(now), procurement.ErrInvalidTransition)
+	})
+
+	t.Run("draft cannot be received or closed", func(t *testing.T) {
+		po, err := procurement.NewPurchaseOrder(uuid.New(), uuid.New(), uuid.New(), []procurement.Line{line}, nil, "", nil, now)
+		require.NoError(t, err)
+
+		_, err = po.Receive(uuid.New(), []procurement.ReceiptLine{{LineID: po.Lines()[0].ID, LotCode: "L1", Grams: 1000}}, "", nil, now)
+		assert.ErrorIs(t, err, procurement.ErrNotReceivable)
+		assert.ErrorIs(t, po.Close(now), procurement.ErrInvalidTransition)
+	})
+
+	t.Run("closed order cannot be received", func(t *testing.T) {
+		po := newSentOrder(t, line)
+		require.NoError(t, po.Close(now))
+
+		_, err := po.Receive(uuid.New(), []procurement.ReceiptLine{{LineID: po.Lines()[0].ID, LotCode: "L1", Grams: 1000}}, "", nil, now)
+		assert.ErrorIs(t, err, procurement.ErrNotReceivable)
+		assert.ErrorIs(t, po.Close(now), procurement.ErrInvalidTransition)
+	})
+}
+
+func TestPurchaseOrder_Receive(t *testing.T) {
+	now := time.Now()
+	lamb := procurement.Line{ProductID: uuid.New(), OrderedGrams: 20000, PricePerKgCents: 1200}
+	beef := procurement.Line{ProductID: uuid.New(), OrderedGrams: 10000, PricePerKgCents: 1500}
+
+	t.Run("partial then full delivery", func(t *testing.T) {
+		po := newSentOrder(t, lamb, beef)
+		lambID, beefID := po.Lines()[0].ID, po.Lines()[1].ID
+
+		grn, err := po.Receive(uuid.New(), []procurement.ReceiptLine{
+			{LineID: lambID, LotCode: " GV-0412 ", Grams: 19500, TemperatureTenthsC: 32},
+		}, "", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, procurement.StatusPartiallyReceived, po.Status())
+		assert.Equal(t, lamb.ProductID, grn.Lines()[0].ProductID)
+		assert.Equal(t, "GV-0412", grn.Lines()[0].LotCode)
+		assert.NotEqual(t, uuid.Nil, grn.Lines()[0].LotID)
+		assert.Equal(t, po.BranchID(), grn.BranchID())
+
+		_, err = po.Receive(uuid.New(), []procurement.ReceiptLine{
+			{LineID: lambID, LotCode: "GV-0413", Grams: 600, TemperatureTenthsC: 30},
+			{LineID: beefID, LotCode: "GV-0414", Grams: 10000, TemperatureTenthsC: 25},
+		}, "", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, procurement.StatusReceived, po.Status())
+		assert.Equal(t, int64(20100), po.Lines()[0].ReceivedGrams)
+		assert.Equal(t, int64(0), po.Lines()[0].OutstandingGrams())
+	})
+
+	t.Run("invalid receipt lines leave the order untouched", func(t *testing.T) {
+		po := newSentOrder(t, lamb)
+		lineID := po.Lines()[0].ID
+
+		tests := []struct {
+			name string
+			line procurement.ReceiptLine
+			want error
+		}{
+			{"unknown line", procurement.ReceiptLine{LineID: uuid.New(), LotCode: "L1", Grams: 100}, procurement.ErrUnknownLine},
+			{"no lot code", procurement.ReceiptLine{LineID: lineID, LotCode: " ", Grams: 100}, procurement.ErrEmptyLotCode},
+			{"zero weight", procurement.ReceiptLine{LineID: lineID, LotCode: "L1"}, procurement.ErrInvalidWeight},
+			{"implausible temperature", procurement.ReceiptLine{LineID: lineID, LotCode: "L1", Grams: 100, TemperatureTenthsC: 450}, procurement.ErrInvalidTemperature},
+		}
+		for _, tt := range tests {
+			_, err := po.Receive(uuid.New(), []procurement.ReceiptLine{
+				{LineID: lineID, LotCode: "L0", Grams: 5000},
+				tt.line,
+			}, "", nil, now)
+			assert.ErrorIs(t, err, tt.want, tt.name)
+		}
+		assert.Equal(t, procurement.StatusSent, po.Status())
+		assert.Equal(t, int64(0), po.Lines()[0].ReceivedGrams)
+	})
+}
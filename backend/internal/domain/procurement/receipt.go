@@ -0,0 +1,66 @@
+package procurement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Arrival temperatures are recorded in tenths of a degree Celsius. Readings
+// outside this range are taken to be keying mistakes rather than deliveries.
+const (
+	minTemperatureTenthsC = -300
+	maxTemperatureTenthsC = 300
+)
+
+// ReceiptLine is one batch delivered against a purchase order line: the
+// weight that actually arrived, its temperature on arrival and the lot it
+// was received into.
+type ReceiptLine struct {
+	LineID             uuid.UUID
+	ProductID          uuid.UUID
+	LotID              uuid.UUID
+	LotCode            string
+	Grams              int64
+	TemperatureTenthsC int
+	ExpiresOn          *time.Time
+}
+
+// GoodsReceivedNote records a delivery from a supplier. Each of its lines
+// becomes an inventory lot.
+type GoodsReceivedNote struct {
+	id              uuid.UUID
+	purchaseOrderID uuid.UUID
+	branchID        uuid.UUID
+	lines           []ReceiptLine
+	notes           string
+	receivedBy      *uuid.UUID
+	receivedAt      time.Time
+}
+
+// ReconstructGoodsReceivedNote reconstructs a GoodsReceivedNote from persistence without validation.
+func ReconstructGoodsReceivedNote(
+	id, purchaseOrderID, branchID uuid.UUID,
+	lines []ReceiptLine,
+	notes string,
+	receivedBy *uuid.UUID,
+	receivedAt time.Time,
+) *GoodsReceivedNote {
+	return &GoodsReceivedNote{
+		id:              id,
+		purchaseOrderID: purchaseOrderID,
+		branchID:        branchID,
+		lines:           lines,
+		notes:           notes,
+		receivedBy:      receivedBy,
+		receivedAt:      receivedAt,
+	}
+}
+
+func (n *GoodsReceivedNote) ID() uuid.UUID              { return n.id }
+func (n *GoodsReceivedNote) PurchaseOrderID() uuid.UUID { return n.purchaseOrderID }
+func (n *GoodsReceivedNote) BranchID() uuid.UUID        { return n.branchID }
+func (n *GoodsReceivedNote) Lines() []ReceiptLine       { return n.lines }
+func (n *GoodsReceivedNote) Notes() string              { return n.notes }
+func (n *GoodsReceivedNote) ReceivedBy() *uuid.UUID     { return n.receivedBy }
+func (n *GoodsReceivedNote) ReceivedAt() time.Time      { return n.receivedAt }
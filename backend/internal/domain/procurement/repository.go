@@ -0,0 +1,51 @@
+package procurement
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// Filter narrows FindAll. Nil or empty fields are not filtered on. SentFrom
+// (inclusive) and SentTo (exclusive) bound when the order was sent, so drafts
+// never match them.
+type Filter struct {
+	SupplierID *uuid.UUID
+	BranchID   *uuid.UUID
+	Statuses   []Status
+	SentFrom   *time.Time
+	SentTo     *time.Time
+}
+
+// SupplierRepository provides access to suppliers.
+type SupplierRepository interface {
+	// Save inserts or updates a supplier.
+	Save(ctx context.Context, s *Supplier) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Supplier, error)
+	// FindAll returns suppliers ordered by name.
+	FindAll(ctx context.Context, activeOnly bool) ([]*Supplier, error)
+}
+
+// PurchaseOrderRepository provides access to purchase orders, their
+// goods-received notes and the prices paid to suppliers.
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, po *PurchaseOrder) error
+	// Update stores a changed purchase order, including its lines. It returns
+	// ErrConcurrentUpdate if the order has changed since it was loaded.
+	Update(ctx context.Context, po *PurchaseOrder) error
+	FindByID(ctx context.Context, id uuid.UUID) (*PurchaseOrder, error)
+	// FindAll returns purchase orders, most recently created first.
+	FindAll(ctx context.Context, filter Filter) ([]*PurchaseOrder, error)
+	// SaveReceipt stores a goods-received note together with the updated
+	// purchase order and the inventory lots its lines were received into, in
+	// one transaction. It returns ErrConcurrentUpdate if the order has changed
+	// since it was loaded.
+	SaveReceipt(ctx context.Context, po *PurchaseOrder, n *GoodsReceivedNote, lots []*inventory.Lot, received []*inventory.Movement) error
+	// FindReceipts returns the goods-received notes for an order, oldest first.
+	FindReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]*GoodsReceivedNote, error)
+	// FindPriceHistory returns the prices a supplier has been paid on sent
+	// orders, newest first, optionally for one product.
+	FindPriceHistory(ctx context.Context, supplierID uuid.UUID, productID *uuid.UUID) ([]PricePoint, error)
+}
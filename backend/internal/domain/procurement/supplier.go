@@ -0,0 +1,174 @@
+package procurement
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SupplierType is what kind of business a supplier is.
+type SupplierType string
+
+const (
+	SupplierFarm       SupplierType = "farm"
+	SupplierAbattoir   SupplierType = "abattoir"
+	SupplierWholesaler SupplierType = "wholesaler"
+)
+
+// NewSupplierType parses a supplier type.
+func NewSupplierType(raw string) (SupplierType, error) {
+	switch t := SupplierType(strings.ToLower(strings.TrimSpace(raw))); t {
+	case SupplierFarm, SupplierAbattoir, SupplierWholesaler:
+		return t, nil
+	default:
+		return "", ErrInvalidSupplierType
+	}
+}
+
+const maxPaymentTermsDays = 180
+
+// Contact is a person to talk to at a supplier.
+type Contact struct {
+	Name  string
+	Role  string
+	Email string
+	Phone string
+}
+
+// Certificate is an accreditation held by a supplier, such as a halal
+// certificate or a food business approval number.
+type Certificate struct {
+	Type      string
+	Number    string
+	ExpiresOn *time.Time
+}
+
+// Supplier is a farm, abattoir or wholesaler the shop buys from. Payment
+// terms are the number of days after delivery that invoices fall due.
+type Supplier struct {
+	id               uuid.UUID
+	name             string
+	supplierType     SupplierType
+	contacts         []Contact
+	certificates     []Certificate
+	paymentTermsDays int
+	active           bool
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+// NewSupplier validates and creates an active supplier.
+func NewSupplier(
+	id uuid.UUID,
+	name string,
+	supplierType SupplierType,
+	contacts []Contact,
+	certificates []Certificate,
+	paymentTermsDays int,
+	now time.Time,
+) (*Supplier, error) {
+	s := &Supplier{id: id, active: true, createdAt: now}
+	if err := s.Update(name, supplierType, contacts, certificates, paymentTermsDays, now); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ReconstructSupplier reconstructs a Supplier from persistence without validation.
+func ReconstructSupplier(
+	id uuid.UUID,
+	name string,
+	supplierType SupplierType,
+	contacts []Contact,
+	certificates []Certificate,
+	paymentTermsDays int,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Supplier {
+	return &Supplier{
+		id:               id,
+		name:             name,
+		supplierType:     supplierType,
+		contacts:         contacts,
+		certificates:     certificates,
+		paymentTermsDays: paymentTermsDays,
+		active:           active,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+	}
+}
+
+func (s *Supplier) ID() uuid.UUID               { return s.id }
+func (s *Supplier) Name() string                { return s.name }
+func (s *Supplier) Type() SupplierType          { return s.supplierType }
+func (s *Supplier) Contacts() []Contact         { return s.contacts }
+func (s *Supplier) Certificates() []Certificate { return s.certificates }
+func (s *Supplier) PaymentTermsDays() int       { return s.paymentTermsDays }
+func (s *Supplier) IsActive() bool              { return s.active }
+func (s *Supplier) CreatedAt() time.Time        { return s.createdAt }
+func (s *Supplier) UpdatedAt() time.Time        { return s.updatedAt }
+
+// Update replaces the supplier's details.
+func (s *Supplier) Update(
+	name string,
+	supplierType SupplierType,
+	contacts []Contact,
+	certificates []Certificate,
+	paymentTermsDays int,
+	now time.Time,
+) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptySupplierName
+	}
+	if _, err := NewSupplierType(string(supplierType)); err != nil {
+		return err
+	}
+	if paymentTermsDays < 0 || paymentTermsDays > maxPaymentTermsDays {
+		return ErrInvalidPaymentTerms
+	}
+
+	cleanContacts := make([]Contact, 0, len(contacts))
+	for _, c := range contacts {
+		c.Name = strings.TrimSpace(c.Name)
+		c.Role = strings.TrimSpace(c.Role)
+		c.Email = strings.TrimSpace(c.Email)
+		c.Phone = strings.TrimSpace(c.Phone)
+		if c.Name == "" {
+			return ErrEmptyContactName
+		}
+		if c.Email != "" {
+			if _, err := mail.ParseAddress(c.Email); err != nil {
+				return ErrInvalidContactEmail
+			}
+		}
+		cleanContacts = append(cleanContacts, c)
+	}
+
+	cleanCertificates := make([]Certificate, 0, len(certificates))
+	for _, c := range certificates {
+		c.Type = strings.TrimSpace(c.Type)
+		c.Number = strings.TrimSpace(c.Number)
+		if c.Type == "" || c.Number == "" {
+			return ErrInvalidSupplierCertificate
+		}
+		cleanCertificates = append(cleanCertificates, c)
+	}
+
+	s.name = name
+	s.supplierType = supplierType
+	s.contacts = cleanContacts
+	s.certificates = cleanCertificates
+	s.paymentTermsDays = paymentTermsDays
+	s.updatedAt = now
+	return nil
+}
+
+// SetActive switches the supplier on or off. Purchase orders cannot be
+// raised against an inactive supplier.
+func (s *Supplier) SetActive(active bool, now time.Time) {
+	s.active = active
+	s.updatedAt = now
+}
@@ -0,0 +1,61 @@
+package procurement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSupplierType(t *testing.T) {
+	kind, err := procurement.NewSupplierType(" Farm ")
+	require.NoError(t, err)
+	assert.Equal(t, procurement.SupplierFarm, kind)
+
+	_, err = procurement.NewSupplierType("broker")
+	assert.ErrorIs(t, err, procurement.ErrInvalidSupplierType)
+}
+
+func TestNewSupplier_TrimsAndValidates(t *testing.T) {
+	now := time.Now()
+	s, err := procurement.NewSupplier(uuid.New(), "  Green Valley Farm ", procurement.SupplierFarm,
+		[]procurement.Contact{{Name: " Amina Khan ", Role: "Sales", Email: "amina@greenvalley.example"}},
+		[]procurement.Certificate{{Type: "halal", Number: " HMC-1042 "}},
+		30, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Green Valley Farm", s.Name())
+	assert.Equal(t, "Amina Khan", s.Contacts()[0].Name)
+	assert.Equal(t, "HMC-1042", s.Certificates()[0].Number)
+	assert.Equal(t, 30, s.PaymentTermsDays())
+	assert.True(t, s.IsActive())
+}
+
+func TestNewSupplier_InvalidFields_ReturnError(t *testing.T) {
+	tests := []struct {
+		name         string
+		supplierName string
+		kind         procurement.SupplierType
+		contacts     []procurement.Contact
+		certificates []procurement.Certificate
+		terms        int
+		want         error
+	}{
+		{"empty name", " ", procurement.SupplierFarm, nil, nil, 30, procurement.ErrEmptySupplierName},
+		{"unknown type", "Farm", "broker", nil, nil, 30, procurement.ErrInvalidSupplierType},
+		{"negative terms", "Farm", procurement.SupplierFarm, nil, nil, -1, procurement.ErrInvalidPaymentTerms},
+		{"terms too long", "Farm", procurement.SupplierFarm, nil, nil, 181, procurement.ErrInvalidPaymentTerms},
+		{"unnamed contact", "Farm", procurement.SupplierFarm, []procurement.Contact{{Phone: "0123"}}, nil, 30, procurement.ErrEmptyContactName},
+		{"bad email", "Farm", procurement.SupplierFarm, []procurement.Contact{{Name: "Amina", Email: "amina"}}, nil, 30, procurement.ErrInvalidContactEmail},
+		{"certificate without number", "Farm", procurement.SupplierFarm, nil, []procurement.Certificate{{Type: "halal"}}, 30, procurement.ErrInvalidSupplierCertificate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := procurement.NewSupplier(uuid.New(), tt.supplierName, tt.kind, tt.contacts, tt.certificates, tt.terms, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
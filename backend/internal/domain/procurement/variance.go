@@ -0,0 +1,112 @@
+package procurement
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricePoint is the price agreed for a product on one purchase order.
+type PricePoint struct {
+	ProductID       uuid.UUID
+	PricePerKgCents int64
+	PurchaseOrderID uuid.UUID
+	SentAt          time.Time
+}
+
+// LineVariance compares what was ordered on one purchase order line with
+// what arrived. Negative variances are shortfalls.
+type LineVariance struct {
+	PurchaseOrderID uuid.UUID
+	SupplierID      uuid.UUID
+	LineID          uuid.UUID
+	ProductID       uuid.UUID
+	OrderedGrams    int64
+	ReceivedGrams   int64
+	OrderedCents    int64
+	ReceivedCents   int64
+}
+
+func (v LineVariance) VarianceGrams() int64 { return v.ReceivedGrams - v.OrderedGrams }
+func (v LineVariance) VarianceCents() int64 { return v.ReceivedCents - v.OrderedCents }
+
+// VarianceBP is the weight variance as a share of what was ordered, in basis points.
+func (v LineVariance) VarianceBP() int64 { return varianceBP(v.OrderedGrams, v.ReceivedGrams) }
+
+// SupplierVariance totals the line variances for one supplier.
+type SupplierVariance struct {
+	SupplierID    uuid.UUID
+	Orders        int
+	OrderedGrams  int64
+	ReceivedGrams int64
+	OrderedCents  int64
+	ReceivedCents int64
+}
+
+func (v SupplierVariance) VarianceGrams() int64 { return v.ReceivedGrams - v.OrderedGrams }
+func (v SupplierVariance) VarianceCents() int64 { return v.ReceivedCents - v.OrderedCents }
+
+// VarianceBP is the weight variance as a share of what was ordered, in basis points.
+func (v SupplierVariance) VarianceBP() int64 { return varianceBP(v.OrderedGrams, v.ReceivedGrams) }
+
+func varianceBP(ordered, received int64) int64 {
+	if ordered == 0 {
+		return 0
+	}
+	return (received - ordered) * 10000 / ordered
+}
+
+// VarianceReport compares ordered and received amounts across purchase orders.
+type VarianceReport struct {
+	Lines     []LineVariance
+	Suppliers []SupplierVariance
+}
+
+// NewVarianceReport builds a report from purchase orders. Only settled orders
+// are included, since what an open order has received so far is not yet a
+// variance. Suppliers are listed with the largest shortfall first.
+func NewVarianceReport(orders []*PurchaseOrder) VarianceReport {
+	report := VarianceReport{Lines: []LineVariance{}, Suppliers: []SupplierVariance{}}
+	bySupplier := make(map[uuid.UUID]*SupplierVariance)
+	for _, po := range orders {
+		if !po.IsSettled() {
+			continue
+		}
+		sv, ok := bySupplier[po.SupplierID()]
+		if !ok {
+			sv = &SupplierVariance{SupplierID: po.SupplierID()}
+			bySupplier[po.SupplierID()] = sv
+		}
+		sv.Orders++
+		for _, l := range po.Lines() {
+			lv := LineVariance{
+				PurchaseOrderID: po.ID(),
+				SupplierID:      po.SupplierID(),
+				LineID:          l.ID,
+				ProductID:       l.ProductID,
+				OrderedGrams:    l.OrderedGrams,
+				ReceivedGrams:   l.ReceivedGrams,
+				OrderedCents:    l.OrderedCents(),
+				ReceivedCents:   l.ReceivedCents(),
+			}
+			report.Lines = append(report.Lines, lv)
+			sv.OrderedGrams += lv.OrderedGrams
+			sv.ReceivedGrams += lv.ReceivedGrams
+			sv.OrderedCents += lv.OrderedCents
+			sv.ReceivedCents += lv.ReceivedCents
+		}
+	}
+
+	for _, sv := range bySupplier {
+		report.Suppliers = append(report.Suppliers, *sv)
+	}
+	sort.Slice(report.Suppliers, func(i, j int) bool {
+		a, b := report.Suppliers[i], report.Suppliers[j]
+		if a.VarianceBP() != b.VarianceBP() {
+			return a.VarianceBP() < b.VarianceBP()
+		}
+		return a.SupplierID.String() < b.SupplierID.String()
+	})
+	return report
+}
@@ -0,0 +1,43 @@
+package procurement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVarianceReport(t *testing.T) {
+	now := time.Now()
+	supplierID := uuid.New()
+	productID := uuid.New()
+	line := func(ordered, received int64) []procurement.Line {
+		return []procurement.Line{{ID: uuid.New(), ProductID: productID, OrderedGrams: ordered, PricePerKgCents: 1000, ReceivedGrams: received}}
+	}
+	order := func(status procurement.Status, lines []procurement.Line) *procurement.PurchaseOrder {
+		return procurement.ReconstructPurchaseOrder(uuid.New(), supplierID, uuid.New(), status, lines,
+			nil, "", nil, 1, now, &now, nil, now)
+	}
+
+	report := procurement.NewVarianceReport([]*procurement.PurchaseOrder{
+		order(procurement.StatusClosed, line(10000, 9000)),
+		order(procurement.StatusReceived, line(10000, 10500)),
+		order(procurement.StatusPartiallyReceived, line(10000, 2000)),
+	})
+
+	require.Len(t, report.Lines, 2)
+	assert.Equal(t, int64(-1000), report.Lines[0].VarianceGrams())
+	assert.Equal(t, int64(-1000), report.Lines[0].VarianceBP())
+	assert.Equal(t, int64(-1000), report.Lines[0].VarianceCents())
+
+	require.Len(t, report.Suppliers, 1)
+	s := report.Suppliers[0]
+	assert.Equal(t, 2, s.Orders)
+	assert.Equal(t, int64(20000), s.OrderedGrams)
+	assert.Equal(t, int64(19500), s.ReceivedGrams)
+	assert.Equal(t, int64(-250), s.VarianceBP())
+	assert.Equal(t, int64(-500), s.VarianceCents())
+}
@@ -0,0 +1,277 @@
+package promotion
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// Line is a weighed product in a cart. Category and Species come from the
+// product's promotion profile and may be empty.
+type Line struct {
+	ProductID       uuid.UUID
+	Category        string
+	Species         string
+	Grams           int64
+	PricePerKgCents int64
+}
+
+// AmountCents is the line's shelf price, rounded to the nearest cent.
+func (l Line) AmountCents() int64 {
+	return priceOf(l.Grams, l.PricePerKgCents)
+}
+
+// Cart is what promotions are applied to.
+type Cart struct {
+	Lines            []Line
+	DeliveryFeeCents int64
+}
+
+// Customer is who the cart is for. ID is nil for anonymous quotes, which
+// never match per-customer limits or first-order offers.
+type Customer struct {
+	ID         *uuid.UUID
+	Segments   []string
+	FirstOrder bool
+}
+
+// Usage is how many times a promotion has been redeemed, in total and by
+// the customer.
+type Usage struct {
+	Total      int
+	ByCustomer int
+}
+
+// Context is everything besides the cart that decides which promotions
+// apply.
+type Context struct {
+	At       time.Time
+	Customer Customer
+	Coupons  []string
+	Usage    map[uuid.UUID]Usage
+}
+
+// Discount is what one promotion took off: LineCents per cart line and
+// DeliveryCents off the delivery fee.
+type Discount struct {
+	PromotionID   uuid.UUID
+	Name          string
+	Code          string
+	LineCents     []int64
+	DeliveryCents int64
+	TotalCents    int64
+}
+
+// Rejection is a coupon that was entered but not applied, and why.
+type Rejection struct {
+	Code string
+	Err  error
+}
+
+// Result is what the engine made of a cart. LineCents and DeliveryCents
+// total every discount on each line and on delivery.
+type Result struct {
+	Discounts     []Discount
+	LineCents     []int64
+	DeliveryCents int64
+	TotalCents    int64
+	Rejected      []Rejection
+}
+
+// Apply works out the discounts on a cart. It is deterministic: promotions
+// are tried in priority order, highest first, then oldest first, then by
+// ID, and each works on what is left of every line after those before it,
+// so no line is ever discounted below zero. Promotions that need a coupon
+// only apply when its code was entered; every entered code that does not
+// apply is reported with the reason.
+func Apply(cart Cart, promotions []*Promotion, ctx Context) Result {
+	remaining := make([]int64, len(cart.Lines))
+	for i, l := range cart.Lines {
+		remaining[i] = l.AmountCents()
+	}
+	res := Result{LineCents: make([]int64, len(cart.Lines))}
+	deliveryLeft := cart.DeliveryFeeCents
+
+	entered := map[string]bool{}
+	var coupons []string
+	for _, raw := range ctx.Coupons {
+		code, err := NormaliseCode(raw)
+		if err != nil {
+			code = strings.ToUpper(strings.TrimSpace(raw))
+		}
+		if code != "" && !entered[code] {
+			entered[code] = true
+			coupons = append(coupons, code)
+		}
+	}
+	reasons := map[string]error{}
+	for _, code := range coupons {
+		reasons[code] = ErrUnknownCoupon
+	}
+
+	ordered := slices.Clone(promotions)
+	slices.SortStableFunc(ordered, func(a, b *Promotion) int {
+		if a.Priority() != b.Priority() {
+			return b.Priority() - a.Priority()
+		}
+		if c := a.createdAt.Compare(b.createdAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id.String(), b.id.String())
+	})
+
+	exclusive := false
+	for _, p := range ordered {
+		if p.Code() != "" && !entered[p.Code()] {
+			continue
+		}
+		reject := func(err error) {
+			if p.Code() != "" {
+				reasons[p.Code()] = err
+			}
+		}
+
+		if !p.IsRunning(ctx.At) {
+			reject(ErrNotRunning)
+			continue
+		}
+		if err := p.checkUsage(ctx); err != nil {
+			reject(err)
+			continue
+		}
+		if !p.Scope().matchesCustomer(ctx.Customer) ||
+			(p.Rule().Kind == KindFirstOrder && (ctx.Customer.ID == nil || !ctx.Customer.FirstOrder)) {
+			reject(ErrNotEligible)
+			continue
+		}
+		if exclusive || (p.Stacking() == StackingExclusive && len(res.Discounts) > 0) {
+			reject(ErrNotStackable)
+			continue
+		}
+
+		matching := make([]bool, len(cart.Lines))
+		var subtotal int64
+		for i, l := range cart.Lines {
+			if p.Scope().matchesLine(l) {
+				matching[i] = true
+				subtotal += remaining[i]
+			}
+		}
+		if subtotal < p.Rule().MinSubtotalCents {
+			reject(ErrNotEligible)
+			continue
+		}
+
+		d := p.discount(cart, matching, remaining, deliveryLeft)
+		if d.TotalCents == 0 {
+			reject(ErrNothingToDiscount)
+			continue
+		}
+		for i, c := range d.LineCents {
+			remaining[i] -= c
+			res.LineCents[i] += c
+		}
+		deliveryLeft -= d.DeliveryCents
+		res.DeliveryCents += d.DeliveryCents
+		res.TotalCents += d.TotalCents
+		res.Discounts = append(res.Discounts, d)
+		if p.Code() != "" {
+			delete(reasons, p.Code())
+		}
+		if p.Stacking() == StackingExclusive {
+			exclusive = true
+		}
+	}
+
+	for _, code := range coupons {
+		if err, ok := reasons[code]; ok {
+			res.Rejected = append(res.Rejected, Rejection{Code: code, Err: err})
+		}
+	}
+	return res
+}
+
+func (p *Promotion) checkUsage(ctx Context) error {
+	u := ctx.Usage[p.id]
+	if p.terms.MaxUses > 0 && u.Total >= p.terms.MaxUses {
+		return ErrUsageLimitReached
+	}
+	if p.terms.MaxUsesPerCustomer > 0 {
+		if ctx.Customer.ID == nil {
+			return ErrNotEligible
+		}
+		if u.ByCustomer >= p.terms.MaxUsesPerCustomer {
+			return ErrUsageLimitReached
+		}
+	}
+	return nil
+}
+
+// discount works out what the promotion takes off the matching lines, given
+// what is left of each line and of the delivery fee.
+func (p *Promotion) discount(cart Cart, matching []bool, remaining []int64, deliveryLeft int64) Discount {
+	d := Discount{PromotionID: p.id, Name: p.Name(), Code: p.Code(), LineCents: make([]int64, len(cart.Lines))}
+	r := p.Rule()
+
+	percentOff := func(bp int) {
+		for i := range cart.Lines {
+			if matching[i] {
+				d.LineCents[i] = (remaining[i]*int64(bp) + 5000) / 10000
+			}
+		}
+	}
+	amountOff := func(amount int64) {
+		weights := make([]int64, len(cart.Lines))
+		var total int64
+		for i := range cart.Lines {
+			if matching[i] {
+				weights[i] = remaining[i]
+				total += remaining[i]
+			}
+		}
+		copy(d.LineCents, tax.Allocate(min(amount, total), weights))
+	}
+
+	switch r.Kind {
+	case KindPercentage:
+		percentOff(r.PercentBP)
+	case KindFixed:
+		amountOff(r.AmountCents)
+	case KindPerKg:
+		for i, l := range cart.Lines {
+			if matching[i] {
+				d.LineCents[i] = min(remaining[i], priceOf(l.Grams, r.AmountCents))
+			}
+		}
+	case KindFreeWeight:
+		for i, l := range cart.Lines {
+			if matching[i] {
+				free := l.Grams / (r.BuyGrams + r.FreeGrams) * r.FreeGrams
+				d.LineCents[i] = min(remaining[i], priceOf(free, l.PricePerKgCents))
+			}
+		}
+	case KindFreeDelivery:
+		d.DeliveryCents = deliveryLeft
+	case KindFirstOrder:
+		if r.AmountCents > 0 {
+			amountOff(r.AmountCents)
+		} else {
+			percentOff(r.PercentBP)
+		}
+	}
+
+	for _, c := range d.LineCents {
+		d.TotalCents += c
+	}
+	d.TotalCents += d.DeliveryCents
+	return d
+}
+
+// priceOf prices a weight at a per-kilogram rate, rounded to the nearest
+// cent.
+func priceOf(grams, perKgCents int64) int64 {
+	return (grams*perKgCents + 500) / 1000
+}
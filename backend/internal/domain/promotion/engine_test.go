@@ -0,0 +1,225 @@
+package promotion_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lambID  = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	beefID  = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	minceID = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+)
+
+// A kilo of lamb at £20, a kilo of beef at £15 and 2.5kg of mince at £10/kg,
+// with £4.99 delivery.
+func weekendCart() promotion.Cart {
+	return promotion.Cart{
+		Lines: []promotion.Line{
+			{ProductID: lambID, Category: "roasting", Species: "lamb", Grams: 1000, PricePerKgCents: 2000},
+			{ProductID: beefID, Category: "steak", Species: "beef", Grams: 1000, PricePerKgCents: 1500},
+			{ProductID: minceID, Category: "mince", Species: "beef", Grams: 2500, PricePerKgCents: 1000},
+		},
+		DeliveryFeeCents: 499,
+	}
+}
+
+func promo(t *testing.T, terms promotion.Terms) *promotion.Promotion {
+	t.Helper()
+	if terms.Name == "" {
+		terms.Name = "Offer"
+	}
+	p, err := promotion.NewPromotion(uuid.New(), terms, now)
+	require.NoError(t, err)
+	return p
+}
+
+func customerCtx() promotion.Context {
+	id := uuid.New()
+	return promotion.Context{At: now, Customer: promotion.Customer{ID: &id, Segments: []string{"returning"}}}
+}
+
+func TestApply_Kinds(t *testing.T) {
+	tests := []struct {
+		name     string
+		terms    promotion.Terms
+		lines    []int64
+		delivery int64
+	}{
+		{
+			name:  "10% off lamb",
+			terms: promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000}, Scope: promotion.Scope{Species: []string{"lamb"}}},
+			lines: []int64{200, 0, 0},
+		},
+		{
+			name:  "£5 off beef shared by price",
+			terms: promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFixed, AmountCents: 500}, Scope: promotion.Scope{Species: []string{"beef"}}},
+			lines: []int64{0, 188, 312},
+		},
+		{
+			name:  "fixed discount is capped at what matching lines cost",
+			terms: promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFixed, AmountCents: 5000}, Scope: promotion.Scope{ProductIDs: []uuid.UUID{lambID}}},
+			lines: []int64{2000, 0, 0},
+		},
+		{
+			name:  "£3 off every kilo of steak",
+			terms: promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindPerKg, AmountCents: 300}, Scope: promotion.Scope{Categories: []string{"steak"}}},
+			lines: []int64{0, 300, 0},
+		},
+		{
+			name:  "buy 2kg mince get 500g free",
+			terms: promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFreeWeight, BuyGrams: 2000, FreeGrams: 500}, Scope: promotion.Scope{Categories: []string{"mince"}}},
+			lines: []int64{0, 0, 500},
+		},
+		{
+			name:     "free delivery over £50",
+			terms:    promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFreeDelivery, MinSubtotalCents: 5000}},
+			lines:    []int64{0, 0, 0},
+			delivery: 499,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := promotion.Apply(weekendCart(), []*promotion.Promotion{promo(t, tt.terms)}, customerCtx())
+
+			require.Len(t, res.Discounts, 1)
+			assert.Equal(t, tt.lines, res.LineCents)
+			assert.Equal(t, tt.delivery, res.DeliveryCents)
+			var want int64
+			for _, c := range tt.lines {
+				want += c
+			}
+			assert.Equal(t, want+tt.delivery, res.TotalCents)
+		})
+	}
+}
+
+func TestApply_SilentlySkipsPromotionsThatDoNotQualify(t *testing.T) {
+	cart := weekendCart()
+	cart.Lines[2].Grams = 2400 // one short of a free block
+
+	res := promotion.Apply(cart, []*promotion.Promotion{
+		promo(t, promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFreeWeight, BuyGrams: 2000, FreeGrams: 500}, Scope: promotion.Scope{Categories: []string{"mince"}}}),
+		promo(t, promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFreeDelivery, MinSubtotalCents: 10000}}),
+		promo(t, promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000}, Scope: promotion.Scope{Segments: []string{"wholesale"}}}),
+	}, customerCtx())
+
+	assert.Empty(t, res.Discounts)
+	assert.Empty(t, res.Rejected)
+	assert.Zero(t, res.TotalCents)
+}
+
+func TestApply_FirstOrder(t *testing.T) {
+	p := promo(t, promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFirstOrder, AmountCents: 1000}})
+
+	returning := promotion.Apply(weekendCart(), []*promotion.Promotion{p}, customerCtx())
+	assert.Empty(t, returning.Discounts)
+
+	anonymous := promotion.Apply(weekendCart(), []*promotion.Promotion{p}, promotion.Context{At: now, Customer: promotion.Customer{FirstOrder: true}})
+	assert.Empty(t, anonymous.Discounts)
+
+	ctx := customerCtx()
+	ctx.Customer.FirstOrder = true
+	first := promotion.Apply(weekendCart(), []*promotion.Promotion{p}, ctx)
+	assert.Equal(t, int64(1000), first.TotalCents)
+	assert.Equal(t, []int64{333, 250, 417}, first.LineCents)
+}
+
+func TestApply_Coupons(t *testing.T) {
+	lamb := promo(t, promotion.Terms{Code: "LAMB10", Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000}, Scope: promotion.Scope{Species: []string{"lamb"}}})
+	expired := promo(t, promotion.Terms{Code: "SPRING", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery}})
+	expired.SetActive(false, now)
+	wholesale := promo(t, promotion.Terms{Code: "TRADE", Rule: promotion.Rule{Kind: promotion.KindFixed, AmountCents: 100}, Scope: promotion.Scope{Segments: []string{"wholesale"}}})
+	promotions := []*promotion.Promotion{lamb, expired, wholesale}
+
+	none := promotion.Apply(weekendCart(), promotions, customerCtx())
+	assert.Empty(t, none.Discounts, "coupon promotions need their code")
+
+	ctx := customerCtx()
+	ctx.Coupons = []string{" lamb10", "LAMB10", "spring", "trade", "nope"}
+	res := promotion.Apply(weekendCart(), promotions, ctx)
+
+	require.Len(t, res.Discounts, 1)
+	assert.Equal(t, "LAMB10", res.Discounts[0].Code)
+	assert.Equal(t, int64(200), res.TotalCents)
+	require.Len(t, res.Rejected, 3)
+	assert.Equal(t, promotion.Rejection{Code: "SPRING", Err: promotion.ErrNotRunning}, res.Rejected[0])
+	assert.Equal(t, promotion.Rejection{Code: "TRADE", Err: promotion.ErrNotEligible}, res.Rejected[1])
+	assert.Equal(t, promotion.Rejection{Code: "NOPE", Err: promotion.ErrUnknownCoupon}, res.Rejected[2])
+}
+
+func TestApply_UsageLimits(t *testing.T) {
+	total := promo(t, promotion.Terms{Code: "FIRST100", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery}, MaxUses: 100})
+	perCustomer := promo(t, promotion.Terms{Code: "ONCE", Rule: promotion.Rule{Kind: promotion.KindFixed, AmountCents: 100}, MaxUsesPerCustomer: 1})
+	promotions := []*promotion.Promotion{total, perCustomer}
+
+	ctx := customerCtx()
+	ctx.Coupons = []string{"FIRST100", "ONCE"}
+	ctx.Usage = map[uuid.UUID]promotion.Usage{
+		total.ID():       {Total: 100},
+		perCustomer.ID(): {Total: 7, ByCustomer: 1},
+	}
+	res := promotion.Apply(weekendCart(), promotions, ctx)
+	assert.Empty(t, res.Discounts)
+	assert.Equal(t, []promotion.Rejection{
+		{Code: "FIRST100", Err: promotion.ErrUsageLimitReached},
+		{Code: "ONCE", Err: promotion.ErrUsageLimitReached},
+	}, res.Rejected)
+
+	anonymous := promotion.Apply(weekendCart(), promotions, promotion.Context{At: now, Coupons: []string{"ONCE"}})
+	assert.Equal(t, []promotion.Rejection{{Code: "ONCE", Err: promotion.ErrNotEligible}}, anonymous.Rejected,
+		"per-customer limits need a known customer")
+}
+
+func TestApply_StackingWorksOnWhatIsLeft(t *testing.T) {
+	first := promo(t, promotion.Terms{Name: "Big", Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 5000}, Scope: promotion.Scope{Species: []string{"lamb"}}, Priority: 10})
+	second := promo(t, promotion.Terms{Name: "Small", Rule: promotion.Rule{Kind: promotion.KindPerKg, AmountCents: 1500}, Scope: promotion.Scope{Species: []string{"lamb"}}})
+
+	res := promotion.Apply(weekendCart(), []*promotion.Promotion{second, first}, customerCtx())
+
+	require.Len(t, res.Discounts, 2)
+	assert.Equal(t, "Big", res.Discounts[0].Name)
+	assert.Equal(t, []int64{1000, 0, 0}, res.Discounts[0].LineCents)
+	assert.Equal(t, []int64{1000, 0, 0}, res.Discounts[1].LineCents, "capped at what the first discount left")
+	assert.Equal(t, int64(2000), res.TotalCents)
+}
+
+func TestApply_Exclusive(t *testing.T) {
+	stackable := promo(t, promotion.Terms{Name: "Stackable", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery}, Priority: 5})
+	exclusive := promo(t, promotion.Terms{Name: "Exclusive", Code: "HALF", Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 5000}, Stacking: promotion.StackingExclusive})
+
+	ctx := customerCtx()
+	ctx.Coupons = []string{"HALF"}
+	res := promotion.Apply(weekendCart(), []*promotion.Promotion{stackable, exclusive}, ctx)
+	require.Len(t, res.Discounts, 1)
+	assert.Equal(t, "Stackable", res.Discounts[0].Name)
+	assert.Equal(t, []promotion.Rejection{{Code: "HALF", Err: promotion.ErrNotStackable}}, res.Rejected)
+
+	// Raised above the stackable one, the exclusive offer wins and shuts
+	// everything else out.
+	exclusive = promo(t, promotion.Terms{Name: "Exclusive", Code: "HALF", Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 5000}, Stacking: promotion.StackingExclusive, Priority: 10})
+	res = promotion.Apply(weekendCart(), []*promotion.Promotion{stackable, exclusive}, ctx)
+	require.Len(t, res.Discounts, 1)
+	assert.Equal(t, "Exclusive", res.Discounts[0].Name)
+	assert.Zero(t, res.DeliveryCents)
+	assert.Equal(t, int64(3000), res.TotalCents)
+}
+
+func TestApply_IsDeterministic(t *testing.T) {
+	var promotions []*promotion.Promotion
+	for range 5 {
+		promotions = append(promotions, promo(t, promotion.Terms{Rule: promotion.Rule{Kind: promotion.KindFixed, AmountCents: 999}}))
+	}
+	want := promotion.Apply(weekendCart(), promotions, customerCtx())
+
+	reversed := slices.Clone(promotions)
+	slices.Reverse(reversed)
+	got := promotion.Apply(weekendCart(), reversed, customerCtx())
+
+	assert.Equal(t, want.Discounts, got.Discounts)
+}
@@ -0,0 +1,29 @@
+package promotion
+
+import "errors"
+
+var (
+	ErrEmptyName         = errors.New("promotion name must not be empty")
+	ErrInvalidKind       = errors.New("promotion kind must be one of percentage, fixed, per_kg, free_weight, free_delivery or first_order")
+	ErrInvalidStacking   = errors.New("stacking must be stackable or exclusive")
+	ErrInvalidPercent    = errors.New("percentage must be between 1 and 10000 basis points")
+	ErrInvalidAmount     = errors.New("discount amount must be greater than zero")
+	ErrInvalidFreeWeight = errors.New("free weight offers need buy and free weights greater than zero")
+	ErrAmbiguousDiscount = errors.New("first order offers take either a percentage or an amount, not both")
+	ErrNegativeMinimum   = errors.New("minimum subtotal must not be negative")
+	ErrInvalidWindow     = errors.New("promotion must end after it starts")
+	ErrInvalidTime       = errors.New("times must be RFC 3339, e.g. 2026-10-24T00:00:00Z")
+	ErrInvalidLimit      = errors.New("usage limits must not be negative")
+	ErrInvalidCode       = errors.New("coupon codes must be 3 to 32 letters, digits, hyphens or underscores")
+	ErrInvalidAttribute  = errors.New("categories, species and segments must be lowercase letters, digits and underscores")
+	ErrUnknownSegment    = errors.New("segment must be new or returning")
+	ErrDuplicateCode     = errors.New("another promotion already uses that coupon code")
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrUnknownCoupon     = errors.New("coupon code is not recognised")
+	ErrNotRunning        = errors.New("promotion is not running")
+	ErrUsageLimitReached = errors.New("promotion has been used as many times as allowed")
+	ErrNotEligible       = errors.New("cart or customer does not qualify for the promotion")
+	ErrNotStackable      = errors.New("promotion cannot be combined with those already applied")
+	ErrNothingToDiscount = errors.New("nothing in the cart is discounted by the promotion")
+	ErrManageNotAllowed  = errors.New("only managers may create, change and target promotions")
+)
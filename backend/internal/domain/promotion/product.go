@@ -0,0 +1,29 @@
+package promotion
+
+// Profile is what promotions know about a product: the category and
+// species it can be targeted by. Either may be empty.
+type Profile struct {
+	Category string
+	Species  string
+}
+
+// NewProfile normalises a product profile. At least one of category and
+// species must be given.
+func NewProfile(category, species string) (Profile, error) {
+	var p Profile
+	var err error
+	if category != "" {
+		if p.Category, err = NormaliseAttribute(category); err != nil {
+			return Profile{}, err
+		}
+	}
+	if species != "" {
+		if p.Species, err = NormaliseAttribute(species); err != nil {
+			return Profile{}, err
+		}
+	}
+	if p.Category == "" && p.Species == "" {
+		return Profile{}, ErrInvalidAttribute
+	}
+	return p, nil
+}
@@ -0,0 +1,300 @@
+package promotion
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is how a promotion works out its discount.
+type Kind string
+
+const (
+	// KindPercentage takes PercentBP off matching lines.
+	KindPercentage Kind = "percentage"
+	// KindFixed takes AmountCents off matching lines, shared in proportion
+	// to what they cost.
+	KindFixed Kind = "fixed"
+	// KindPerKg takes AmountCents off every kilogram of matching lines.
+	KindPerKg Kind = "per_kg"
+	// KindFreeWeight gives FreeGrams free with every BuyGrams bought: of
+	// each BuyGrams+FreeGrams on a line, FreeGrams costs nothing.
+	KindFreeWeight Kind = "free_weight"
+	// KindFreeDelivery waives the delivery fee.
+	KindFreeDelivery Kind = "free_delivery"
+	// KindFirstOrder takes PercentBP or AmountCents off a customer's first
+	// order.
+	KindFirstOrder Kind = "first_order"
+)
+
+// NewKind parses a promotion kind.
+func NewKind(raw string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(raw))); k {
+	case KindPercentage, KindFixed, KindPerKg, KindFreeWeight, KindFreeDelivery, KindFirstOrder:
+		return k, nil
+	default:
+		return "", ErrInvalidKind
+	}
+}
+
+// Stacking says whether a promotion combines with others. Stackable
+// promotions combine with each other; an exclusive one applies only if
+// nothing has been applied before it, and then nothing applies after it.
+type Stacking string
+
+const (
+	StackingStackable Stacking = "stackable"
+	StackingExclusive Stacking = "exclusive"
+)
+
+// NewStacking parses a stacking rule.
+func NewStacking(raw string) (Stacking, error) {
+	switch s := Stacking(strings.ToLower(strings.TrimSpace(raw))); s {
+	case StackingStackable, StackingExclusive:
+		return s, nil
+	default:
+		return "", ErrInvalidStacking
+	}
+}
+
+// Rule is what a promotion gives. Which fields are used depends on Kind.
+// MinSubtotalCents is what matching lines must come to, after any earlier
+// discounts, for the promotion to apply.
+type Rule struct {
+	Kind             Kind
+	PercentBP        int
+	AmountCents      int64
+	BuyGrams         int64
+	FreeGrams        int64
+	MinSubtotalCents int64
+}
+
+func (r Rule) validate() error {
+	if r.MinSubtotalCents < 0 {
+		return ErrNegativeMinimum
+	}
+	switch r.Kind {
+	case KindPercentage:
+		if r.PercentBP < 1 || r.PercentBP > 10000 {
+			return ErrInvalidPercent
+		}
+	case KindFixed, KindPerKg:
+		if r.AmountCents <= 0 {
+			return ErrInvalidAmount
+		}
+	case KindFreeWeight:
+		if r.BuyGrams <= 0 || r.FreeGrams <= 0 {
+			return ErrInvalidFreeWeight
+		}
+	case KindFreeDelivery:
+	case KindFirstOrder:
+		if r.PercentBP != 0 && r.AmountCents != 0 {
+			return ErrAmbiguousDiscount
+		}
+		if r.AmountCents < 0 || (r.AmountCents == 0 && (r.PercentBP < 1 || r.PercentBP > 10000)) {
+			return ErrInvalidAmount
+		}
+	default:
+		return ErrInvalidKind
+	}
+	return nil
+}
+
+// Scope is who and what a promotion is for. A line matches when the
+// product scopes are all empty or it matches any of them; a customer
+// matches when Segments is empty or they are in one of them.
+type Scope struct {
+	ProductIDs []uuid.UUID
+	Categories []string
+	Species    []string
+	Segments   []string
+}
+
+func (s Scope) normalise() (Scope, error) {
+	var err error
+	out := Scope{ProductIDs: slices.Clone(s.ProductIDs)}
+	if out.Categories, err = codes(s.Categories); err != nil {
+		return Scope{}, err
+	}
+	if out.Species, err = codes(s.Species); err != nil {
+		return Scope{}, err
+	}
+	if out.Segments, err = codes(s.Segments); err != nil {
+		return Scope{}, err
+	}
+	return out, nil
+}
+
+func (s Scope) matchesLine(l Line) bool {
+	if len(s.ProductIDs) == 0 && len(s.Categories) == 0 && len(s.Species) == 0 {
+		return true
+	}
+	return slices.Contains(s.ProductIDs, l.ProductID) ||
+		(l.Category != "" && slices.Contains(s.Categories, l.Category)) ||
+		(l.Species != "" && slices.Contains(s.Species, l.Species))
+}
+
+func (s Scope) matchesCustomer(c Customer) bool {
+	if len(s.Segments) == 0 {
+		return true
+	}
+	for _, seg := range c.Segments {
+		if slices.Contains(s.Segments, seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// Terms are everything about a promotion an admin can change. Code is an
+// optional coupon code the customer must enter; StartsAt and EndsAt bound
+// when it runs, either may be nil for open-ended. Zero limits are
+// unlimited. Higher priorities are applied first.
+type Terms struct {
+	Name               string
+	Code               string
+	Rule               Rule
+	Scope              Scope
+	StartsAt           *time.Time
+	EndsAt             *time.Time
+	MaxUses            int
+	MaxUsesPerCustomer int
+	Priority           int
+	Stacking           Stacking
+}
+
+// Promotion is a discount marketing runs, such as 10% off lamb at the
+// weekend or free delivery over £50.
+type Promotion struct {
+	id        uuid.UUID
+	terms     Terms
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewPromotion validates and creates an active promotion.
+func NewPromotion(id uuid.UUID, terms Terms, now time.Time) (*Promotion, error) {
+	p := &Promotion{id: id, active: true, createdAt: now}
+	if err := p.Update(terms, now); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ReconstructPromotion reconstructs a Promotion from persistence without
+// validation.
+func ReconstructPromotion(id uuid.UUID, terms Terms, active bool, createdAt, updatedAt time.Time) *Promotion {
+	return &Promotion{id: id, terms: terms, active: active, createdAt: createdAt, updatedAt: updatedAt}
+}
+
+// Update replaces the promotion's terms. Usage so far still counts towards
+// the new limits.
+func (p *Promotion) Update(terms Terms, now time.Time) error {
+	terms.Name = strings.TrimSpace(terms.Name)
+	if terms.Name == "" {
+		return ErrEmptyName
+	}
+	terms.Code = strings.TrimSpace(terms.Code)
+	if terms.Code != "" {
+		code, err := NormaliseCode(terms.Code)
+		if err != nil {
+			return err
+		}
+		terms.Code = code
+	}
+	if err := terms.Rule.validate(); err != nil {
+		return err
+	}
+	scope, err := terms.Scope.normalise()
+	if err != nil {
+		return err
+	}
+	terms.Scope = scope
+	if terms.StartsAt != nil && terms.EndsAt != nil && !terms.EndsAt.After(*terms.StartsAt) {
+		return ErrInvalidWindow
+	}
+	if terms.MaxUses < 0 || terms.MaxUsesPerCustomer < 0 {
+		return ErrInvalidLimit
+	}
+	if terms.Stacking == "" {
+		terms.Stacking = StackingStackable
+	}
+	if _, err := NewStacking(string(terms.Stacking)); err != nil {
+		return err
+	}
+
+	p.terms = terms
+	p.updatedAt = now
+	return nil
+}
+
+// SetActive switches the promotion on or off.
+func (p *Promotion) SetActive(active bool, now time.Time) {
+	p.active = active
+	p.updatedAt = now
+}
+
+// IsRunning reports whether the promotion is on and within its dates at t.
+func (p *Promotion) IsRunning(t time.Time) bool {
+	if !p.active {
+		return false
+	}
+	if p.terms.StartsAt != nil && t.Before(*p.terms.StartsAt) {
+		return false
+	}
+	return p.terms.EndsAt == nil || t.Before(*p.terms.EndsAt)
+}
+
+func (p *Promotion) ID() uuid.UUID        { return p.id }
+func (p *Promotion) Terms() Terms         { return p.terms }
+func (p *Promotion) Name() string         { return p.terms.Name }
+func (p *Promotion) Code() string         { return p.terms.Code }
+func (p *Promotion) Rule() Rule           { return p.terms.Rule }
+func (p *Promotion) Scope() Scope         { return p.terms.Scope }
+func (p *Promotion) Priority() int        { return p.terms.Priority }
+func (p *Promotion) Stacking() Stacking   { return p.terms.Stacking }
+func (p *Promotion) IsActive() bool       { return p.active }
+func (p *Promotion) CreatedAt() time.Time { return p.createdAt }
+func (p *Promotion) UpdatedAt() time.Time { return p.updatedAt }
+
+// NormaliseCode upper-cases a coupon code and checks its characters, so
+// codes match however customers type them.
+func NormaliseCode(raw string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(raw))
+	if len(code) < 3 || len(code) > 32 || strings.IndexFunc(code, func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_'
+	}) >= 0 {
+		return "", ErrInvalidCode
+	}
+	return code, nil
+}
+
+// NormaliseAttribute lower-cases a category, species or segment and checks
+// its characters.
+func NormaliseAttribute(raw string) (string, error) {
+	a := strings.ToLower(strings.TrimSpace(raw))
+	if a == "" || strings.IndexFunc(a, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_'
+	}) >= 0 {
+		return "", ErrInvalidAttribute
+	}
+	return a, nil
+}
+
+// codes normalises a list of attributes, dropping repeats.
+func codes(raw []string) ([]string, error) {
+	out := make([]string, 0, len(raw))
+	for _, r := range raw {
+		a, err := NormaliseAttribute(r)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
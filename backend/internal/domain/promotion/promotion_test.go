@@ -0,0 +1,92 @@
+package promotion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 5, 16, 10, 0, 0, 0, time.UTC)
+
+func TestNewPromotion(t *testing.T) {
+	p, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name:  "  Lamb weekend ",
+		Code:  " lamb-10 ",
+		Rule:  promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000},
+		Scope: promotion.Scope{Species: []string{"Lamb", "lamb"}, Segments: []string{"Returning"}},
+	}, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Lamb weekend", p.Name())
+	assert.Equal(t, "LAMB-10", p.Code())
+	assert.Equal(t, []string{"lamb"}, p.Scope().Species)
+	assert.Equal(t, []string{"returning"}, p.Scope().Segments)
+	assert.Equal(t, promotion.StackingStackable, p.Stacking())
+	assert.True(t, p.IsActive())
+	assert.Equal(t, now, p.CreatedAt())
+}
+
+func TestNewPromotion_Validation(t *testing.T) {
+	start := now
+	end := now.Add(-time.Hour)
+	valid := promotion.Rule{Kind: promotion.KindFixed, AmountCents: 500}
+
+	tests := []struct {
+		name  string
+		terms promotion.Terms
+		want  error
+	}{
+		{"empty name", promotion.Terms{Name: " ", Rule: valid}, promotion.ErrEmptyName},
+		{"bad code", promotion.Terms{Name: "x", Code: "no spaces", Rule: valid}, promotion.ErrInvalidCode},
+		{"unknown kind", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: "bogof"}}, promotion.ErrInvalidKind},
+		{"percent too high", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 10001}}, promotion.ErrInvalidPercent},
+		{"zero fixed", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindFixed}}, promotion.ErrInvalidAmount},
+		{"zero per kg", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindPerKg}}, promotion.ErrInvalidAmount},
+		{"no free grams", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindFreeWeight, BuyGrams: 2000}}, promotion.ErrInvalidFreeWeight},
+		{"first order both", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindFirstOrder, PercentBP: 1000, AmountCents: 500}}, promotion.ErrAmbiguousDiscount},
+		{"first order neither", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindFirstOrder}}, promotion.ErrInvalidAmount},
+		{"negative minimum", promotion.Terms{Name: "x", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery, MinSubtotalCents: -1}}, promotion.ErrNegativeMinimum},
+		{"bad category", promotion.Terms{Name: "x", Rule: valid, Scope: promotion.Scope{Categories: []string{"mince!"}}}, promotion.ErrInvalidAttribute},
+		{"ends before start", promotion.Terms{Name: "x", Rule: valid, StartsAt: &start, EndsAt: &end}, promotion.ErrInvalidWindow},
+		{"negative limit", promotion.Terms{Name: "x", Rule: valid, MaxUsesPerCustomer: -1}, promotion.ErrInvalidLimit},
+		{"bad stacking", promotion.Terms{Name: "x", Rule: valid, Stacking: "sometimes"}, promotion.ErrInvalidStacking},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := promotion.NewPromotion(uuid.New(), tt.terms, now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestPromotion_IsRunning(t *testing.T) {
+	start := now
+	end := now.Add(48 * time.Hour)
+	p, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name:     "Weekend",
+		Rule:     promotion.Rule{Kind: promotion.KindFreeDelivery},
+		StartsAt: &start,
+		EndsAt:   &end,
+	}, now)
+	require.NoError(t, err)
+
+	assert.False(t, p.IsRunning(start.Add(-time.Second)))
+	assert.True(t, p.IsRunning(start))
+	assert.False(t, p.IsRunning(end), "the end is exclusive")
+
+	p.SetActive(false, now)
+	assert.False(t, p.IsRunning(start))
+}
+
+func TestNewProfile(t *testing.T) {
+	p, err := promotion.NewProfile("Mince", "")
+	require.NoError(t, err)
+	assert.Equal(t, promotion.Profile{Category: "mince"}, p)
+
+	_, err = promotion.NewProfile("", "")
+	assert.ErrorIs(t, err, promotion.ErrInvalidAttribute)
+}
@@ -0,0 +1,52 @@
+package promotion
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to promotions.
+type Repository interface {
+	// Save inserts or updates a promotion. A coupon code already used by
+	// another promotion returns ErrDuplicateCode.
+	Save(ctx context.Context, p *Promotion) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Promotion, error)
+	// FindAll returns every promotion, newest first.
+	FindAll(ctx context.Context) ([]*Promotion, error)
+	// FindRunning returns the active promotions whose dates include at.
+	FindRunning(ctx context.Context, at time.Time) ([]*Promotion, error)
+}
+
+// ProductRepository records the profile each product is targeted by.
+type ProductRepository interface {
+	// Classify sets or replaces a product's profile.
+	Classify(ctx context.Context, productID uuid.UUID, profile Profile) error
+	// FindProfiles returns the profiles of those products that have one.
+	FindProfiles(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]Profile, error)
+}
+
+// Redemption is one use of a promotion on an order.
+type Redemption struct {
+	PromotionID   uuid.UUID
+	OrderID       uuid.UUID
+	CustomerID    uuid.UUID
+	DiscountCents int64
+	RedeemedAt    time.Time
+}
+
+// RedemptionRepository records promotion usage against the limits.
+type RedemptionRepository interface {
+	// Usage returns how often each promotion has been redeemed, in total
+	// and by the customer. Promotions never redeemed are left out.
+	Usage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]Usage, error)
+	// Redeem records redemptions atomically, checking each promotion's
+	// limits under a lock so concurrent orders cannot overshoot them. A
+	// limit already reached returns ErrUsageLimitReached and records
+	// nothing.
+	Redeem(ctx context.Context, redemptions []Redemption) error
+	// Release removes an order's redemptions, e.g. when the order could not
+	// be saved.
+	Release(ctx context.Context, orderID uuid.UUID) error
+}
@@ -0,0 +1,142 @@
+package qurbani
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Animal is one sheep, goat or cow bought in for an offering, numbered in
+// the order it was first given bookings. It is sacrificed for the bookings
+// allocated to it once its slaughter batch is completed.
+type Animal struct {
+	id            uuid.UUID
+	offeringID    uuid.UUID
+	number        int
+	batchID       *uuid.UUID
+	slaughteredAt *time.Time
+	createdAt     time.Time
+}
+
+// ReconstructAnimal reconstructs an Animal from persistence without validation.
+func ReconstructAnimal(id, offeringID uuid.UUID, number int, batchID *uuid.UUID, slaughteredAt *time.Time, createdAt time.Time) *Animal {
+	return &Animal{
+		id:            id,
+		offeringID:    offeringID,
+		number:        number,
+		batchID:       batchID,
+		slaughteredAt: slaughteredAt,
+		createdAt:     createdAt,
+	}
+}
+
+func (a *Animal) ID() uuid.UUID             { return a.id }
+func (a *Animal) OfferingID() uuid.UUID     { return a.offeringID }
+func (a *Animal) Number() int               { return a.number }
+func (a *Animal) BatchID() *uuid.UUID       { return a.batchID }
+func (a *Animal) SlaughteredAt() *time.Time { return a.slaughteredAt }
+func (a *Animal) CreatedAt() time.Time      { return a.createdAt }
+
+// Allocation is the result of grouping shares into animals.
+type Allocation struct {
+	// Animals are the animals opened for the first time.
+	Animals []*Animal
+	// Bookings are the bookings placed on an animal.
+	Bookings []*Booking
+}
+
+// Allocate places an offering's unallocated bookings on its animals, given
+// all the animals it has so far and all its bookings. Bookings are placed in
+// the order they were made. A customer's bookings are kept on one animal
+// where they fit, so a family shares a cow; otherwise gaps left on animals
+// already opened are filled before another is opened.
+func Allocate(offering *Offering, animals []*Animal, bookings []*Booking, now time.Time) (*Allocation, error) {
+	perAnimal := offering.ShareType().SharesPerAnimal()
+
+	taken := make(map[uuid.UUID]int)
+	var pending []*Booking
+	for _, b := range bookings {
+		switch {
+		case b.status == BookingBooked:
+			pending = append(pending, b)
+		case b.animalID != nil:
+			taken[*b.animalID]++
+		}
+	}
+	if len(pending) == 0 {
+		return &Allocation{}, nil
+	}
+
+	type place struct {
+		animal *Animal
+		free   int
+	}
+	var open []*place
+	last, free := 0, 0
+	for _, a := range animals {
+		last = max(last, a.number)
+		if a.slaughteredAt == nil && taken[a.id] < perAnimal {
+			open = append(open, &place{animal: a, free: perAnimal - taken[a.id]})
+			free += perAnimal - taken[a.id]
+		}
+	}
+	free += (offering.Animals() - len(animals)) * perAnimal
+	if len(pending) > free {
+		return nil, ErrNotEnoughAnimals
+	}
+
+	out := &Allocation{}
+	openAnimal := func() *place {
+		last++
+		a := &Animal{id: uuid.New(), offeringID: offering.ID(), number: last, createdAt: now}
+		out.Animals = append(out.Animals, a)
+		p := &place{animal: a, free: perAnimal}
+		open = append(open, p)
+		return p
+	}
+	fullest := func() *place {
+		var best *place
+		for _, p := range open {
+			if p.free > 0 && (best == nil || p.free < best.free) {
+				best = p
+			}
+		}
+		return best
+	}
+
+	slices.SortStableFunc(pending, func(a, b *Booking) int { return a.bookedAt.Compare(b.bookedAt) })
+	var order []uuid.UUID
+	groups := make(map[uuid.UUID][]*Booking)
+	for _, b := range pending {
+		if _, ok := groups[b.customerID]; !ok {
+			order = append(order, b.customerID)
+		}
+		groups[b.customerID] = append(groups[b.customerID], b)
+	}
+
+	for _, customerID := range order {
+		group := groups[customerID]
+		var target *place
+		for _, p := range open {
+			if p.free >= len(group) && (target == nil || p.free < target.free) {
+				target = p
+			}
+		}
+		if target == nil && len(group) <= perAnimal && len(animals)+len(out.Animals) < offering.Animals() {
+			target = openAnimal()
+		}
+		for _, b := range group {
+			if target == nil || target.free == 0 {
+				target = fullest()
+			}
+			if target == nil {
+				target = openAnimal()
+			}
+			b.allocate(target.animal.id, now)
+			target.free--
+			out.Bookings = append(out.Bookings, b)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,71 @@
+package qurbani_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocate(t *testing.T) {
+	t.Run("a family shares a cow and gaps are filled first", func(t *testing.T) {
+		o := newOffering(t, qurbani.ShareCow, 2)
+		single, family := uuid.New(), uuid.New()
+		var bookings []*qurbani.Booking
+		for i := range 5 {
+			bookings = append(bookings, newBooking(t, o, single, now.Add(time.Duration(i)*time.Minute)))
+		}
+		first, err := qurbani.Allocate(o, nil, bookings, now)
+		require.NoError(t, err)
+		require.Len(t, first.Animals, 1)
+		assert.Equal(t, 1, first.Animals[0].Number())
+		assert.Len(t, first.Bookings, 5)
+
+		// Four shares for one family do not fit in the two left on cow 1, so
+		// they open cow 2; a later single share fills a gap on cow 1.
+		for i := range 4 {
+			bookings = append(bookings, newBooking(t, o, family, now.Add(time.Hour+time.Duration(i)*time.Minute)))
+		}
+		bookings = append(bookings, newBooking(t, o, uuid.New(), now.Add(2*time.Hour)))
+
+		second, err := qurbani.Allocate(o, first.Animals, bookings, now)
+		require.NoError(t, err)
+		require.Len(t, second.Animals, 1)
+		cow1, cow2 := first.Animals[0].ID(), second.Animals[0].ID()
+		assert.Equal(t, 2, second.Animals[0].Number())
+		require.Len(t, second.Bookings, 5)
+		for _, b := range second.Bookings[:4] {
+			assert.Equal(t, cow2, *b.AnimalID())
+		}
+		assert.Equal(t, cow1, *second.Bookings[4].AnimalID())
+		assert.Equal(t, qurbani.BookingAllocated, second.Bookings[4].Status())
+	})
+
+	t.Run("cancelled places are reused", func(t *testing.T) {
+		o := newOffering(t, qurbani.ShareWholeSheep, 1)
+		b := newBooking(t, o, uuid.New(), now)
+		first, err := qurbani.Allocate(o, nil, []*qurbani.Booking{b}, now)
+		require.NoError(t, err)
+		require.NoError(t, b.Cancel(now))
+
+		next := newBooking(t, o, uuid.New(), now.Add(time.Hour))
+		second, err := qurbani.Allocate(o, first.Animals, []*qurbani.Booking{b, next}, now)
+
+		require.NoError(t, err)
+		assert.Empty(t, second.Animals)
+		assert.Equal(t, first.Animals[0].ID(), *next.AnimalID())
+	})
+
+	t.Run("more bookings than animals", func(t *testing.T) {
+		o := newOffering(t, qurbani.ShareWholeSheep, 1)
+		bookings := []*qurbani.Booking{newBooking(t, o, uuid.New(), now), newBooking(t, o, uuid.New(), now)}
+
+		_, err := qurbani.Allocate(o, nil, bookings, now)
+
+		assert.ErrorIs(t, err, qurbani.ErrNotEnoughAnimals)
+		assert.Equal(t, qurbani.BookingBooked, bookings[0].Status())
+	})
+}
@@ -0,0 +1,105 @@
+package qurbani
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Batch is a group of animals slaughtered together, numbered in order within
+// a season.
+type Batch struct {
+	id          uuid.UUID
+	season      string
+	number      int
+	scheduledAt time.Time
+	completedAt *time.Time
+	createdAt   time.Time
+}
+
+// ReconstructBatch reconstructs a Batch from persistence without validation.
+func ReconstructBatch(id uuid.UUID, season string, number int, scheduledAt time.Time, completedAt *time.Time, createdAt time.Time) *Batch {
+	return &Batch{
+		id:          id,
+		season:      season,
+		number:      number,
+		scheduledAt: scheduledAt,
+		completedAt: completedAt,
+		createdAt:   createdAt,
+	}
+}
+
+func (b *Batch) ID() uuid.UUID           { return b.id }
+func (b *Batch) Season() string          { return b.season }
+func (b *Batch) Number() int             { return b.number }
+func (b *Batch) ScheduledAt() time.Time  { return b.scheduledAt }
+func (b *Batch) CompletedAt() *time.Time { return b.completedAt }
+func (b *Batch) CreatedAt() time.Time    { return b.createdAt }
+
+// PlanBatches splits animals waiting to be slaughtered into batches of size,
+// the first starting at startsAt and each following interval later. Batches
+// are numbered on from last, the highest number already used in the season.
+func PlanBatches(season string, animals []*Animal, last int, startsAt time.Time, size int, interval time.Duration, now time.Time) ([]*Batch, error) {
+	season = strings.TrimSpace(season)
+	if season == "" {
+		return nil, ErrEmptySeason
+	}
+	if size <= 0 {
+		return nil, ErrInvalidBatchSize
+	}
+	if interval < 0 {
+		return nil, ErrInvalidBatchInterval
+	}
+
+	var batches []*Batch
+	var current *Batch
+	filled := 0
+	for _, a := range animals {
+		if a.batchID != nil || a.slaughteredAt != nil {
+			continue
+		}
+		if current == nil || filled == size {
+			current = &Batch{
+				id:          uuid.New(),
+				season:      season,
+				number:      last + len(batches) + 1,
+				scheduledAt: startsAt.Add(time.Duration(len(batches)) * interval),
+				createdAt:   now,
+			}
+			batches = append(batches, current)
+			filled = 0
+		}
+		a.batchID = &current.id
+		filled++
+	}
+	if len(batches) == 0 {
+		return nil, ErrNothingToBatch
+	}
+	return batches, nil
+}
+
+// Complete records that the batch's animals have been slaughtered, and with
+// them every booking allocated to them. It returns the bookings whose
+// sacrifice is now done.
+func (b *Batch) Complete(animals []*Animal, bookings []*Booking, now time.Time) ([]*Booking, error) {
+	if b.completedAt != nil {
+		return nil, ErrBatchAlreadyCompleted
+	}
+	inBatch := make(map[uuid.UUID]bool)
+	for _, a := range animals {
+		if a.batchID != nil && *a.batchID == b.id {
+			a.slaughteredAt = &now
+			inBatch[a.id] = true
+		}
+	}
+	var done []*Booking
+	for _, bk := range bookings {
+		if bk.status == BookingAllocated && bk.animalID != nil && inBatch[*bk.animalID] {
+			bk.slaughter(now)
+			done = append(done, bk)
+		}
+	}
+	b.completedAt = &now
+	return done, nil
+}
@@ -0,0 +1,68 @@
+package qurbani_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanBatches(t *testing.T) {
+	o := newOffering(t, qurbani.ShareWholeSheep, 5)
+	var bookings []*qurbani.Booking
+	for range 5 {
+		bookings = append(bookings, newBooking(t, o, uuid.New(), now))
+	}
+	alloc, err := qurbani.Allocate(o, nil, bookings, now)
+	require.NoError(t, err)
+	eid := time.Date(2027, 5, 16, 7, 0, 0, 0, time.UTC)
+
+	batches, err := qurbani.PlanBatches("2027", alloc.Animals, 2, eid, 2, 90*time.Minute, now)
+
+	require.NoError(t, err)
+	require.Len(t, batches, 3)
+	assert.Equal(t, 3, batches[0].Number())
+	assert.Equal(t, eid, batches[0].ScheduledAt())
+	assert.Equal(t, eid.Add(3*time.Hour), batches[2].ScheduledAt())
+	assert.Equal(t, batches[0].ID(), *alloc.Animals[1].BatchID())
+	assert.Equal(t, batches[2].ID(), *alloc.Animals[4].BatchID())
+
+	_, err = qurbani.PlanBatches("2027", alloc.Animals, 5, eid, 2, 0, now)
+	assert.ErrorIs(t, err, qurbani.ErrNothingToBatch)
+	_, err = qurbani.PlanBatches("2027", alloc.Animals, 5, eid, 0, 0, now)
+	assert.ErrorIs(t, err, qurbani.ErrInvalidBatchSize)
+}
+
+func TestBatch_Complete(t *testing.T) {
+	o := newOffering(t, qurbani.ShareCow, 2)
+	var bookings []*qurbani.Booking
+	for range 8 {
+		bookings = append(bookings, newBooking(t, o, uuid.New(), now))
+	}
+	alloc, err := qurbani.Allocate(o, nil, bookings, now)
+	require.NoError(t, err)
+	require.Len(t, alloc.Animals, 2)
+	batches, err := qurbani.PlanBatches("2027", alloc.Animals[:1], 0, now, 1, 0, now)
+	require.NoError(t, err)
+	require.NoError(t, bookings[0].Cancel(now))
+
+	at := now.Add(time.Hour)
+	done, err := batches[0].Complete(alloc.Animals, bookings, at)
+
+	require.NoError(t, err)
+	assert.Len(t, done, 6, "the cancelled share and the second cow are not slaughtered")
+	for _, b := range done {
+		assert.Equal(t, qurbani.BookingSlaughtered, b.Status())
+		assert.Equal(t, at, *b.SlaughteredAt())
+	}
+	assert.Equal(t, at, *alloc.Animals[0].SlaughteredAt())
+	assert.Nil(t, alloc.Animals[1].SlaughteredAt())
+	require.NoError(t, done[0].MarkNotified(at))
+	assert.Equal(t, at, *done[0].NotifiedAt())
+
+	_, err = batches[0].Complete(alloc.Animals, bookings, at)
+	assert.ErrorIs(t, err, qurbani.ErrBatchAlreadyCompleted)
+}
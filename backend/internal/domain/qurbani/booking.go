@@ -0,0 +1,159 @@
+package qurbani
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// BookingStatus is where a booking is on the way to the sacrifice.
+type BookingStatus string
+
+const (
+	BookingBooked      BookingStatus = "booked"
+	BookingAllocated   BookingStatus = "allocated"
+	BookingSlaughtered BookingStatus = "slaughtered"
+	BookingCancelled   BookingStatus = "cancelled"
+)
+
+const maxNiyyahName = 100
+
+// Booking is one share of a sacrifice, made on behalf of the person named in
+// the niyyah. It is placed on an animal when shares are allocated.
+type Booking struct {
+	id            uuid.UUID
+	offeringID    uuid.UUID
+	customerID    uuid.UUID
+	niyyahName    string
+	distribution  Distribution
+	addressID     *uuid.UUID
+	priceCents    int64
+	status        BookingStatus
+	animalID      *uuid.UUID
+	bookedAt      time.Time
+	slaughteredAt *time.Time
+	notifiedAt    *time.Time
+	updatedAt     time.Time
+}
+
+// NewBooking validates and creates a booking for one share of an open
+// offering, at the offering's current price. addressID is required for
+// delivery and ignored otherwise. Whether a share is still free is checked
+// when the booking is stored.
+func NewBooking(
+	id uuid.UUID,
+	offering *Offering,
+	customerID uuid.UUID,
+	niyyahName string,
+	distribution Distribution,
+	addressID *uuid.UUID,
+	now time.Time,
+) (*Booking, error) {
+	if !offering.Active() {
+		return nil, ErrOfferingClosed
+	}
+	niyyahName = strings.TrimSpace(niyyahName)
+	if niyyahName == "" {
+		return nil, ErrEmptyNiyyahName
+	}
+	if utf8.RuneCountInString(niyyahName) > maxNiyyahName {
+		return nil, ErrNiyyahNameTooLong
+	}
+	if distribution != DistributionDelivery {
+		addressID = nil
+	} else if addressID == nil {
+		return nil, ErrAddressRequired
+	}
+	return &Booking{
+		id:           id,
+		offeringID:   offering.ID(),
+		customerID:   customerID,
+		niyyahName:   niyyahName,
+		distribution: distribution,
+		addressID:    addressID,
+		priceCents:   offering.PriceCents(),
+		status:       BookingBooked,
+		bookedAt:     now,
+		updatedAt:    now,
+	}, nil
+}
+
+// ReconstructBooking reconstructs a Booking from persistence without validation.
+func ReconstructBooking(
+	id, offeringID, customerID uuid.UUID,
+	niyyahName string,
+	distribution Distribution,
+	addressID *uuid.UUID,
+	priceCents int64,
+	status BookingStatus,
+	animalID *uuid.UUID,
+	bookedAt time.Time,
+	slaughteredAt, notifiedAt *time.Time,
+	updatedAt time.Time,
+) *Booking {
+	return &Booking{
+		id:            id,
+		offeringID:    offeringID,
+		customerID:    customerID,
+		niyyahName:    niyyahName,
+		distribution:  distribution,
+		addressID:     addressID,
+		priceCents:    priceCents,
+		status:        status,
+		animalID:      animalID,
+		bookedAt:      bookedAt,
+		slaughteredAt: slaughteredAt,
+		notifiedAt:    notifiedAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+func (b *Booking) ID() uuid.UUID              { return b.id }
+func (b *Booking) OfferingID() uuid.UUID      { return b.offeringID }
+func (b *Booking) CustomerID() uuid.UUID      { return b.customerID }
+func (b *Booking) NiyyahName() string         { return b.niyyahName }
+func (b *Booking) Distribution() Distribution { return b.distribution }
+func (b *Booking) AddressID() *uuid.UUID      { return b.addressID }
+func (b *Booking) PriceCents() int64          { return b.priceCents }
+func (b *Booking) Status() BookingStatus      { return b.status }
+func (b *Booking) AnimalID() *uuid.UUID       { return b.animalID }
+func (b *Booking) BookedAt() time.Time        { return b.bookedAt }
+func (b *Booking) SlaughteredAt() *time.Time  { return b.slaughteredAt }
+func (b *Booking) NotifiedAt() *time.Time     { return b.notifiedAt }
+func (b *Booking) UpdatedAt() time.Time       { return b.updatedAt }
+
+// Cancel withdraws a booking that has not been slaughtered yet. Its place on
+// an animal is freed for the next allocation.
+func (b *Booking) Cancel(now time.Time) error {
+	if b.status != BookingBooked && b.status != BookingAllocated {
+		return ErrCannotCancel
+	}
+	b.status = BookingCancelled
+	b.animalID = nil
+	b.updatedAt = now
+	return nil
+}
+
+// MarkNotified records that the customer was told the sacrifice is done.
+func (b *Booking) MarkNotified(now time.Time) error {
+	if b.status != BookingSlaughtered {
+		return ErrBatchNotCompleted
+	}
+	b.notifiedAt = &now
+	b.updatedAt = now
+	return nil
+}
+
+func (b *Booking) allocate(animalID uuid.UUID, now time.Time) {
+	b.status = BookingAllocated
+	b.animalID = &animalID
+	b.updatedAt = now
+}
+
+func (b *Booking) slaughter(at time.Time) {
+	b.status = BookingSlaughtered
+	b.slaughteredAt = &at
+	b.updatedAt = at
+}
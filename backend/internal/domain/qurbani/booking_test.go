@@ -0,0 +1,99 @@
+package qurbani_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2027, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func newOffering(t *testing.T, shareType qurbani.ShareType, animals int) *qurbani.Offering {
+	t.Helper()
+	o, err := qurbani.NewOffering(uuid.New(), "2027", shareType, 9500, animals, now)
+	require.NoError(t, err)
+	return o
+}
+
+func newBooking(t *testing.T, o *qurbani.Offering, customerID uuid.UUID, at time.Time) *qurbani.Booking {
+	t.Helper()
+	b, err := qurbani.NewBooking(uuid.New(), o, customerID, "Fatima bint Ahmed", qurbani.DistributionCharity, nil, at)
+	require.NoError(t, err)
+	return b
+}
+
+func TestShareType_SharesPerAnimal(t *testing.T) {
+	cow, err := qurbani.NewShareType("cow_share")
+	require.NoError(t, err)
+	assert.Equal(t, 7, cow.SharesPerAnimal())
+	assert.Equal(t, 1, qurbani.ShareWholeSheep.SharesPerAnimal())
+
+	_, err = qurbani.NewShareType("camel")
+	assert.ErrorIs(t, err, qurbani.ErrInvalidShareType)
+}
+
+func TestOffering_Update(t *testing.T) {
+	o := newOffering(t, qurbani.ShareCow, 3)
+	assert.Equal(t, 21, o.Capacity())
+
+	assert.ErrorIs(t, o.Update(9500, 2, true, 15, now), qurbani.ErrCapacityBelowBooked)
+	require.NoError(t, o.Update(9900, 2, false, 14, now))
+	assert.Equal(t, 14, o.Capacity())
+	assert.False(t, o.Active())
+}
+
+func TestNewBooking(t *testing.T) {
+	o := newOffering(t, qurbani.ShareWholeSheep, 10)
+	addressID := uuid.New()
+
+	t.Run("delivery needs an address", func(t *testing.T) {
+		_, err := qurbani.NewBooking(uuid.New(), o, uuid.New(), "Ali", qurbani.DistributionDelivery, nil, now)
+		assert.ErrorIs(t, err, qurbani.ErrAddressRequired)
+
+		b, err := qurbani.NewBooking(uuid.New(), o, uuid.New(), "Ali", qurbani.DistributionDelivery, &addressID, now)
+		require.NoError(t, err)
+		assert.Equal(t, &addressID, b.AddressID())
+		assert.Equal(t, int64(9500), b.PriceCents())
+		assert.Equal(t, qurbani.BookingBooked, b.Status())
+	})
+
+	t.Run("the address is dropped for charity", func(t *testing.T) {
+		b, err := qurbani.NewBooking(uuid.New(), o, uuid.New(), " Ali ", qurbani.DistributionCharity, &addressID, now)
+		require.NoError(t, err)
+		assert.Nil(t, b.AddressID())
+		assert.Equal(t, "Ali", b.NiyyahName())
+	})
+
+	t.Run("niyyah name is required", func(t *testing.T) {
+		_, err := qurbani.NewBooking(uuid.New(), o, uuid.New(), " ", qurbani.DistributionCollection, nil, now)
+		assert.ErrorIs(t, err, qurbani.ErrEmptyNiyyahName)
+		_, err = qurbani.NewBooking(uuid.New(), o, uuid.New(), strings.Repeat("a", 101), qurbani.DistributionCollection, nil, now)
+		assert.ErrorIs(t, err, qurbani.ErrNiyyahNameTooLong)
+	})
+
+	t.Run("closed offerings take no bookings", func(t *testing.T) {
+		require.NoError(t, o.Update(9500, 10, false, 0, now))
+		_, err := qurbani.NewBooking(uuid.New(), o, uuid.New(), "Ali", qurbani.DistributionCollection, nil, now)
+		assert.ErrorIs(t, err, qurbani.ErrOfferingClosed)
+	})
+}
+
+func TestBooking_Cancel(t *testing.T) {
+	o := newOffering(t, qurbani.ShareWholeGoat, 1)
+	b := newBooking(t, o, uuid.New(), now)
+
+	_, err := qurbani.Allocate(o, nil, []*qurbani.Booking{b}, now)
+	require.NoError(t, err)
+	require.NotNil(t, b.AnimalID())
+
+	require.NoError(t, b.Cancel(now))
+	assert.Equal(t, qurbani.BookingCancelled, b.Status())
+	assert.Nil(t, b.AnimalID())
+	assert.ErrorIs(t, b.Cancel(now), qurbani.ErrCannotCancel)
+	assert.ErrorIs(t, b.MarkNotified(now), qurbani.ErrBatchNotCompleted)
+}
@@ -0,0 +1,29 @@
+package qurbani
+
+import "errors"
+
+var (
+	ErrInvalidShareType      = errors.New("share type must be one of whole_sheep, whole_goat or cow_share")
+	ErrInvalidDistribution   = errors.New("distribution must be one of delivery, collection or charity")
+	ErrEmptySeason           = errors.New("season must not be empty")
+	ErrNegativePrice         = errors.New("price must not be negative")
+	ErrInvalidAnimals        = errors.New("number of animals must be greater than zero")
+	ErrCapacityBelowBooked   = errors.New("offering cannot have fewer shares than are already booked")
+	ErrOfferingClosed        = errors.New("offering is not open for bookings")
+	ErrSoldOut               = errors.New("all shares in this offering are booked")
+	ErrEmptyNiyyahName       = errors.New("niyyah name must not be empty")
+	ErrNiyyahNameTooLong     = errors.New("niyyah name must be at most 100 characters")
+	ErrAddressRequired       = errors.New("a delivery address is required when the meat is delivered")
+	ErrCannotCancel          = errors.New("only bookings not yet slaughtered can be cancelled")
+	ErrNotEnoughAnimals      = errors.New("offering does not have enough animals for its bookings")
+	ErrAllocationConflict    = errors.New("bookings changed while shares were being allocated, try again")
+	ErrInvalidBatchSize      = errors.New("animals per batch must be greater than zero")
+	ErrInvalidBatchInterval  = errors.New("batch interval must not be negative")
+	ErrNothingToBatch        = errors.New("there are no animals waiting for a slaughter batch")
+	ErrBatchAlreadyCompleted = errors.New("slaughter batch has already been completed")
+	ErrBatchNotCompleted     = errors.New("slaughter batch has not been completed yet")
+	ErrDuplicateOffering     = errors.New("an offering for this share type already exists in the season")
+	ErrOfferingNotFound      = errors.New("qurbani offering not found")
+	ErrBookingNotFound       = errors.New("qurbani booking not found")
+	ErrBatchNotFound         = errors.New("slaughter batch not found")
+)
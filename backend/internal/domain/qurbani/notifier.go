@@ -0,0 +1,27 @@
+package qurbani
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notice tells a customer that the sacrifice made for one of their bookings
+// has been done.
+type Notice struct {
+	BookingID     uuid.UUID
+	CustomerID    uuid.UUID
+	Email         string
+	FullName      string
+	NiyyahName    string
+	ShareType     ShareType
+	AnimalNumber  int
+	Distribution  Distribution
+	SlaughteredAt time.Time
+}
+
+// Notifier sends notices to customers.
+type Notifier interface {
+	SacrificeDone(ctx context.Context, n Notice) error
+}
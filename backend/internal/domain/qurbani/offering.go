@@ -0,0 +1,95 @@
+package qurbani
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Offering is a seasonal booking product: one share type for one Eid, with
+// the number of animals the shop has bought in for it. Price is per share,
+// in cents.
+type Offering struct {
+	id         uuid.UUID
+	season     string
+	shareType  ShareType
+	priceCents int64
+	animals    int
+	active     bool
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// NewOffering validates and creates an open offering. season names the Eid
+// it is for, e.g. "2027".
+func NewOffering(id uuid.UUID, season string, shareType ShareType, priceCents int64, animals int, now time.Time) (*Offering, error) {
+	season = strings.TrimSpace(season)
+	if season == "" {
+		return nil, ErrEmptySeason
+	}
+	if priceCents < 0 {
+		return nil, ErrNegativePrice
+	}
+	if animals <= 0 {
+		return nil, ErrInvalidAnimals
+	}
+	return &Offering{
+		id:         id,
+		season:     season,
+		shareType:  shareType,
+		priceCents: priceCents,
+		animals:    animals,
+		active:     true,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// ReconstructOffering reconstructs an Offering from persistence without validation.
+func ReconstructOffering(id uuid.UUID, season string, shareType ShareType, priceCents int64, animals int, active bool, createdAt, updatedAt time.Time) *Offering {
+	return &Offering{
+		id:         id,
+		season:     season,
+		shareType:  shareType,
+		priceCents: priceCents,
+		animals:    animals,
+		active:     active,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (o *Offering) ID() uuid.UUID        { return o.id }
+func (o *Offering) Season() string       { return o.season }
+func (o *Offering) ShareType() ShareType { return o.shareType }
+func (o *Offering) PriceCents() int64    { return o.priceCents }
+func (o *Offering) Animals() int         { return o.animals }
+func (o *Offering) Active() bool         { return o.active }
+func (o *Offering) CreatedAt() time.Time { return o.createdAt }
+func (o *Offering) UpdatedAt() time.Time { return o.updatedAt }
+
+// Capacity is how many shares the offering can be booked for.
+func (o *Offering) Capacity() int {
+	return o.animals * o.shareType.SharesPerAnimal()
+}
+
+// Update changes the price, the number of animals and whether bookings are
+// taken. booked is the number of shares already booked; the offering cannot
+// shrink below it. A new price applies to new bookings only.
+func (o *Offering) Update(priceCents int64, animals int, active bool, booked int, now time.Time) error {
+	if priceCents < 0 {
+		return ErrNegativePrice
+	}
+	if animals <= 0 {
+		return ErrInvalidAnimals
+	}
+	if animals*o.shareType.SharesPerAnimal() < booked {
+		return ErrCapacityBelowBooked
+	}
+	o.priceCents = priceCents
+	o.animals = animals
+	o.active = active
+	o.updatedAt = now
+	return nil
+}
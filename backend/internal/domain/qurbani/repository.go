@@ -0,0 +1,78 @@
+package qurbani
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// BookingFilter narrows BookingRepository.FindAll. Zero fields are not
+// filtered on.
+type BookingFilter struct {
+	Season       string
+	OfferingID   *uuid.UUID
+	CustomerID   *uuid.UUID
+	BatchID      *uuid.UUID
+	Status       *BookingStatus
+	Distribution *Distribution
+}
+
+// AnimalFilter narrows SlaughterRepository.FindAnimals. Zero fields are not
+// filtered on.
+type AnimalFilter struct {
+	Season     string
+	OfferingID *uuid.UUID
+	BatchID    *uuid.UUID
+	// Waiting keeps only animals with bookings allocated to them that are not
+	// in a slaughter batch yet.
+	Waiting bool
+}
+
+// OfferingRepository provides access to qurbani offerings.
+type OfferingRepository interface {
+	Save(ctx context.Context, o *Offering) error
+	Update(ctx context.Context, o *Offering) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Offering, error)
+	// FindAll returns the offerings for a season, or every season when it is
+	// empty, newest season first.
+	FindAll(ctx context.Context, season string) ([]*Offering, error)
+}
+
+// BookingRepository provides access to qurbani bookings.
+type BookingRepository interface {
+	// Book stores the booking only if the offering still has a share free
+	// for it. Implementations must serialise concurrent bookings for the same
+	// offering and return ErrSoldOut when every share is taken.
+	Book(ctx context.Context, offering *Offering, b *Booking) error
+	Update(ctx context.Context, b *Booking) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Booking, error)
+	// FindAll returns bookings in the order they were made.
+	FindAll(ctx context.Context, filter BookingFilter) ([]*Booking, error)
+	// CountActive returns how many shares of the offering are booked and not
+	// cancelled.
+	CountActive(ctx context.Context, offeringID uuid.UUID) (int, error)
+}
+
+// SlaughterRepository provides access to animals and slaughter batches.
+type SlaughterRepository interface {
+	// SaveAllocation stores newly opened animals and the bookings placed on
+	// them in one transaction. It returns ErrAllocationConflict if any of the
+	// bookings was allocated or cancelled in the meantime.
+	SaveAllocation(ctx context.Context, a *Allocation) error
+	// FindAnimals returns animals by offering and number.
+	FindAnimals(ctx context.Context, filter AnimalFilter) ([]*Animal, error)
+	// SaveBatches stores new batches and assigns their animals in one
+	// transaction. It returns ErrAllocationConflict if an animal was put in
+	// another batch in the meantime.
+	SaveBatches(ctx context.Context, batches []*Batch, animals []*Animal) error
+	FindBatchByID(ctx context.Context, id uuid.UUID) (*Batch, error)
+	// FindBatches returns a season's batches in order.
+	FindBatches(ctx context.Context, season string) ([]*Batch, error)
+	// LastBatchNumber returns the highest batch number used in a season, or
+	// zero if there are none.
+	LastBatchNumber(ctx context.Context, season string) (int, error)
+	// CompleteBatch stores a completed batch with its slaughtered animals and
+	// bookings in one transaction. It returns ErrBatchAlreadyCompleted if the
+	// batch was completed concurrently.
+	CompleteBatch(ctx context.Context, b *Batch, animals []*Animal, bookings []*Booking) error
+}
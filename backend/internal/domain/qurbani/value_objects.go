@@ -0,0 +1,52 @@
+package qurbani
+
+// ShareType is what a booking buys: a whole small animal, or one of the
+// seven shares a cow can be sacrificed for.
+type ShareType string
+
+const (
+	ShareWholeSheep ShareType = "whole_sheep"
+	ShareWholeGoat  ShareType = "whole_goat"
+	ShareCow        ShareType = "cow_share"
+)
+
+// NewShareType validates a raw share type.
+func NewShareType(raw string) (ShareType, error) {
+	switch s := ShareType(raw); s {
+	case ShareWholeSheep, ShareWholeGoat, ShareCow:
+		return s, nil
+	default:
+		return "", ErrInvalidShareType
+	}
+}
+
+// SharesPerAnimal is how many bookings one animal is sacrificed for.
+func (s ShareType) SharesPerAnimal() int {
+	if s == ShareCow {
+		return 7
+	}
+	return 1
+}
+
+func (s ShareType) String() string { return string(s) }
+
+// Distribution is what happens to the meat of a booking.
+type Distribution string
+
+const (
+	DistributionDelivery   Distribution = "delivery"
+	DistributionCollection Distribution = "collection"
+	DistributionCharity    Distribution = "charity"
+)
+
+// NewDistribution validates a raw distribution.
+func NewDistribution(raw string) (Distribution, error) {
+	switch d := Distribution(raw); d {
+	case DistributionDelivery, DistributionCollection, DistributionCharity:
+		return d, nil
+	default:
+		return "", ErrInvalidDistribution
+	}
+}
+
+func (d Distribution) String() string { return string(d) }
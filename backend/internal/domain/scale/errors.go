@@ -0,0 +1,22 @@
+package scale
+
+import "errors"
+
+var (
+	ErrEmptyName         = errors.New("scale name must not be empty")
+	ErrUnknownProtocol   = errors.New("scale protocol must be mt-sics or cas")
+	ErrUnknownTransport  = errors.New("scale transport must be tcp or serial")
+	ErrInvalidAddress    = errors.New("tcp scales need a host and port, serial scales a device under /dev")
+	ErrInvalidBaudRate   = errors.New("baud rate must be one of 1200, 2400, 4800, 9600, 19200, 38400, 57600 or 115200")
+	ErrScaleNotFound     = errors.New("scale not found")
+	ErrScaleInactive     = errors.New("scale is disabled")
+	ErrDuplicateScale    = errors.New("another scale already has that name")
+	ErrTareUnsupported   = errors.New("scale cannot be tared remotely")
+	ErrTareRefused       = errors.New("scale refused to tare")
+	ErrNotStable         = errors.New("scale did not settle on a stable weight in time")
+	ErrOverload          = errors.New("scale is overloaded")
+	ErrUnderload         = errors.New("scale is below zero")
+	ErrUnreadable        = errors.New("scale sent a reading that could not be understood")
+	ErrSerialUnsupported = errors.New("serial scales are not supported on this platform")
+	ErrScaleDisconnected = errors.New("scale connection closed")
+)
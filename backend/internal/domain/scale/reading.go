@@ -0,0 +1,47 @@
+package scale
+
+import "time"
+
+// Reading is what a scale showed at one moment. Grams is the net weight on
+// its display, after any tare; TareGrams is the tare taken off, zero if the
+// scale is not tared. Stable is set once the weight has settled.
+type Reading struct {
+	Grams     int64
+	TareGrams int64
+	Stable    bool
+	At        time.Time
+}
+
+// GrossGrams is the weight on the platter, tare included.
+func (r Reading) GrossGrams() int64 { return r.Grams + r.TareGrams }
+
+// Stabiliser decides when a weight has settled. A scale's own stability flag
+// goes up the moment its filter settles, which can be while a butcher's hand
+// is still on the meat, so a reading is only taken as stable once the scale
+// has flagged it and every reading over the window has stayed within the
+// tolerance of it.
+type Stabiliser struct {
+	window    time.Duration
+	tolerance int64
+	since     time.Time
+	low, high int64
+	started   bool
+}
+
+// NewStabiliser creates a Stabiliser that wants a weight held within
+// toleranceGrams for window. A zero window trusts the scale's flag alone.
+func NewStabiliser(window time.Duration, toleranceGrams int64) *Stabiliser {
+	return &Stabiliser{window: window, tolerance: toleranceGrams}
+}
+
+// Observe returns r with Stable set only if the weight has settled.
+func (s *Stabiliser) Observe(r Reading) Reading {
+	low, high := min(s.low, r.Grams), max(s.high, r.Grams)
+	if !s.started || !r.Stable || high-low > s.tolerance {
+		s.since, s.low, s.high, s.started = r.At, r.Grams, r.Grams, true
+	} else {
+		s.low, s.high = low, high
+	}
+	r.Stable = r.Stable && r.At.Sub(s.since) >= s.window
+	return r
+}
@@ -0,0 +1,45 @@
+package scale_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStabiliser_WaitsForWeightToHold(t *testing.T) {
+	s := scale.NewStabiliser(500*time.Millisecond, 2)
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	steps := []struct {
+		grams      int64
+		flagged    bool
+		ms         int
+		wantStable bool
+	}{
+		{grams: 1240, flagged: false, ms: 0},
+		{grams: 1250, flagged: true, ms: 200},
+		{grams: 1251, flagged: true, ms: 400},
+		{grams: 1249, flagged: true, ms: 700, wantStable: true},
+		// A hand pressing on the meat starts the wait again.
+		{grams: 1290, flagged: true, ms: 800},
+		{grams: 1250, flagged: true, ms: 1000},
+		{grams: 1250, flagged: true, ms: 1400},
+		{grams: 1250, flagged: true, ms: 1500, wantStable: true},
+		// The scale's own motion flag always wins.
+		{grams: 1250, flagged: false, ms: 1600},
+	}
+	for i, step := range steps {
+		r := s.Observe(scale.Reading{Grams: step.grams, Stable: step.flagged, At: at(step.ms)})
+		assert.Equal(t, step.wantStable, r.Stable, "step %d", i)
+	}
+}
+
+func TestStabiliser_ZeroWindowTrustsScale(t *testing.T) {
+	s := scale.NewStabiliser(0, 0)
+	r := s.Observe(scale.Reading{Grams: 500, TareGrams: 20, Stable: true, At: time.Now()})
+	assert.True(t, r.Stable)
+	assert.Equal(t, int64(520), r.GrossGrams())
+}
@@ -0,0 +1,36 @@
+package scale
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository provides access to scales.
+type Repository interface {
+	// Save inserts or updates a scale. It returns ErrDuplicateScale if
+	// another scale has its name.
+	Save(ctx context.Context, s *Scale) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Scale, error)
+	// FindAll returns every scale by name.
+	FindAll(ctx context.Context) ([]*Scale, error)
+}
+
+// Connector opens connections to scales.
+type Connector interface {
+	Open(ctx context.Context, s *Scale) (Session, error)
+}
+
+// Session is an open connection to a scale. Next and Tare may be called
+// from different goroutines.
+type Session interface {
+	// Next blocks until the scale's next reading. Stable is as the scale
+	// flagged it. It returns ErrOverload or ErrUnderload while the weight
+	// is out of range, which the caller may ride out, and any other error
+	// once the connection is lost.
+	Next(ctx context.Context) (Reading, error)
+	// Tare takes the weight on the platter off later readings. It returns
+	// ErrTareUnsupported for scales that cannot be tared remotely.
+	Tare(ctx context.Context) error
+	Close() error
+}
@@ -0,0 +1,146 @@
+package scale
+
+import (
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Protocol is how a scale reports its weight.
+type Protocol string
+
+const (
+	// ProtocolMTSICS is Mettler Toledo's Standard Interface Command Set: the
+	// scale answers commands such as SI (send weight now) and T (tare).
+	ProtocolMTSICS Protocol = "mt-sics"
+	// ProtocolCAS is the continuous output of CAS, Dibal and similar scales,
+	// which send a line such as "ST,GS,+001.250kg" several times a second.
+	ProtocolCAS Protocol = "cas"
+)
+
+// Transport is how a scale is connected.
+type Transport string
+
+const (
+	// TransportTCP reaches the scale, or a serial-to-Ethernet adapter in
+	// front of it, at a host and port.
+	TransportTCP Transport = "tcp"
+	// TransportSerial reads the scale from a serial device under /dev on
+	// the server, such as /dev/ttyUSB0.
+	TransportSerial Transport = "serial"
+)
+
+// DefaultBaudRate is the baud rate serial scales use unless set otherwise.
+const DefaultBaudRate = 9600
+
+var baudRates = map[int]bool{1200: true, 2400: true, 4800: true, 9600: true, 19200: true, 38400: true, 57600: true, 115200: true}
+
+// Scale is a weighing scale on a counter that the back office reads weights
+// from.
+type Scale struct {
+	id        uuid.UUID
+	name      string
+	protocol  Protocol
+	transport Transport
+	address   string
+	baudRate  int
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewScale validates and creates an active scale.
+func NewScale(id uuid.UUID, name string, protocol Protocol, transport Transport, address string, baudRate int, now time.Time) (*Scale, error) {
+	s := &Scale{id: id, createdAt: now}
+	if err := s.Update(name, protocol, transport, address, baudRate, true, now); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ReconstructScale reconstructs a Scale from persistence without validation.
+func ReconstructScale(
+	id uuid.UUID,
+	name string,
+	protocol Protocol,
+	transport Transport,
+	address string,
+	baudRate int,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Scale {
+	return &Scale{
+		id:        id,
+		name:      name,
+		protocol:  protocol,
+		transport: transport,
+		address:   address,
+		baudRate:  baudRate,
+		active:    active,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (s *Scale) ID() uuid.UUID        { return s.id }
+func (s *Scale) Name() string         { return s.name }
+func (s *Scale) Protocol() Protocol   { return s.protocol }
+func (s *Scale) Transport() Transport { return s.transport }
+func (s *Scale) Address() string      { return s.address }
+func (s *Scale) BaudRate() int        { return s.baudRate }
+func (s *Scale) Active() bool         { return s.active }
+func (s *Scale) CreatedAt() time.Time { return s.createdAt }
+func (s *Scale) UpdatedAt() time.Time { return s.updatedAt }
+
+// Update changes how the scale is reached, or disables it. The baud rate
+// only applies to serial scales; zero means DefaultBaudRate.
+func (s *Scale) Update(name string, protocol Protocol, transport Transport, address string, baudRate int, active bool, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	if protocol != ProtocolMTSICS && protocol != ProtocolCAS {
+		return ErrUnknownProtocol
+	}
+	address = strings.TrimSpace(address)
+	switch transport {
+	case TransportTCP:
+		if !validHostPort(address) {
+			return ErrInvalidAddress
+		}
+		baudRate = 0
+	case TransportSerial:
+		if !strings.HasPrefix(address, "/dev/") || path.Clean(address) != address {
+			return ErrInvalidAddress
+		}
+		if baudRate == 0 {
+			baudRate = DefaultBaudRate
+		}
+		if !baudRates[baudRate] {
+			return ErrInvalidBaudRate
+		}
+	default:
+		return ErrUnknownTransport
+	}
+	s.name = name
+	s.protocol = protocol
+	s.transport = transport
+	s.address = address
+	s.baudRate = baudRate
+	s.active = active
+	s.updatedAt = now
+	return nil
+}
+
+func validHostPort(address string) bool {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host == "" || strings.ContainsAny(host, "/ ") {
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 1 && n <= 65535
+}
@@ -0,0 +1,47 @@
+package scale_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScale(t *testing.T) {
+	now := time.Now()
+
+	s, err := scale.NewScale(uuid.New(), " Counter ", scale.ProtocolMTSICS, scale.TransportTCP, "10.0.0.7:4001", 9600, now)
+	require.NoError(t, err)
+	assert.Equal(t, "Counter", s.Name())
+	assert.Zero(t, s.BaudRate(), "tcp scales have no baud rate")
+	assert.True(t, s.Active())
+
+	s, err = scale.NewScale(uuid.New(), "Back", scale.ProtocolCAS, scale.TransportSerial, "/dev/ttyUSB0", 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, scale.DefaultBaudRate, s.BaudRate())
+
+	tests := []struct {
+		name      string
+		protocol  scale.Protocol
+		transport scale.Transport
+		address   string
+		baud      int
+		want      error
+	}{
+		{"", scale.ProtocolCAS, scale.TransportTCP, "10.0.0.7:4001", 0, scale.ErrEmptyName},
+		{"A", "sartorius", scale.TransportTCP, "10.0.0.7:4001", 0, scale.ErrUnknownProtocol},
+		{"A", scale.ProtocolCAS, "usb", "10.0.0.7:4001", 0, scale.ErrUnknownTransport},
+		{"A", scale.ProtocolCAS, scale.TransportTCP, "10.0.0.7", 0, scale.ErrInvalidAddress},
+		{"A", scale.ProtocolCAS, scale.TransportSerial, "ttyUSB0", 0, scale.ErrInvalidAddress},
+		{"A", scale.ProtocolCAS, scale.TransportSerial, "/dev/../etc/passwd", 0, scale.ErrInvalidAddress},
+		{"A", scale.ProtocolCAS, scale.TransportSerial, "/etc/passwd", 0, scale.ErrInvalidAddress},
+		{"A", scale.ProtocolCAS, scale.TransportSerial, "/dev/ttyUSB0", 9000, scale.ErrInvalidBaudRate},
+	}
+	for _, tt := range tests {
+		_, err := scale.NewScale(uuid.New(), tt.name, tt.protocol, tt.transport, tt.address, tt.baud, now)
+		assert.ErrorIs(t, err, tt.want, "%s %s %s", tt.protocol, tt.transport, tt.address)
+	}
+}
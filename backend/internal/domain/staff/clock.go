@@ -0,0 +1,136 @@
+package staff
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Break is an unpaid break taken while clocked in. EndedAt is nil while it
+// is being taken.
+type Break struct {
+	StartedAt time.Time
+	EndedAt   *time.Time
+}
+
+// ClockEntry is the time a member of staff was clocked in at a branch, from
+// clocking in to clocking out, with the breaks they took. An entry with no
+// ClockedOutAt is still open; each member of staff has at most one.
+type ClockEntry struct {
+	id           uuid.UUID
+	adminID      uuid.UUID
+	branchID     uuid.UUID
+	clockedInAt  time.Time
+	clockedOutAt *time.Time
+	breaks       []Break
+}
+
+// ClockIn opens an entry for the member of staff p at a branch.
+func ClockIn(id uuid.UUID, p *Profile, branchID uuid.UUID, now time.Time) (*ClockEntry, error) {
+	if !p.active {
+		return nil, ErrProfileInactive
+	}
+	if branchID == uuid.Nil {
+		branchID = p.branchID
+	}
+	return &ClockEntry{id: id, adminID: p.adminID, branchID: branchID, clockedInAt: now}, nil
+}
+
+// ReconstructClockEntry reconstructs a ClockEntry from persistence without
+// validation.
+func ReconstructClockEntry(
+	id, adminID, branchID uuid.UUID,
+	clockedInAt time.Time,
+	clockedOutAt *time.Time,
+	breaks []Break,
+) *ClockEntry {
+	return &ClockEntry{
+		id:           id,
+		adminID:      adminID,
+		branchID:     branchID,
+		clockedInAt:  clockedInAt,
+		clockedOutAt: clockedOutAt,
+		breaks:       breaks,
+	}
+}
+
+func (e *ClockEntry) ID() uuid.UUID            { return e.id }
+func (e *ClockEntry) AdminID() uuid.UUID       { return e.adminID }
+func (e *ClockEntry) BranchID() uuid.UUID      { return e.branchID }
+func (e *ClockEntry) ClockedInAt() time.Time   { return e.clockedInAt }
+func (e *ClockEntry) ClockedOutAt() *time.Time { return e.clockedOutAt }
+func (e *ClockEntry) Breaks() []Break          { return e.breaks }
+
+// Open reports whether the member of staff is still clocked in.
+func (e *ClockEntry) Open() bool { return e.clockedOutAt == nil }
+
+// OnBreak reports whether the member of staff is on a break.
+func (e *ClockEntry) OnBreak() bool {
+	return len(e.breaks) > 0 && e.breaks[len(e.breaks)-1].EndedAt == nil
+}
+
+// StartBreak starts a break.
+func (e *ClockEntry) StartBreak(now time.Time) error {
+	if !e.Open() {
+		return ErrEntryNotFound
+	}
+	if e.OnBreak() {
+		return ErrAlreadyOnBreak
+	}
+	e.breaks = append(e.breaks, Break{StartedAt: now})
+	return nil
+}
+
+// EndBreak ends the break being taken.
+func (e *ClockEntry) EndBreak(now time.Time) error {
+	if !e.Open() {
+		return ErrEntryNotFound
+	}
+	if !e.OnBreak() {
+		return ErrNotOnBreak
+	}
+	e.breaks[len(e.breaks)-1].EndedAt = &now
+	return nil
+}
+
+// ClockOut closes the entry, ending any break being taken.
+func (e *ClockEntry) ClockOut(now time.Time) error {
+	if !e.Open() {
+		return ErrEntryNotFound
+	}
+	if e.OnBreak() {
+		e.breaks[len(e.breaks)-1].EndedAt = &now
+	}
+	e.clockedOutAt = &now
+	return nil
+}
+
+// Span returns how long the member of staff was clocked in, up to now if
+// they still are.
+func (e *ClockEntry) Span(now time.Time) time.Duration {
+	end := now
+	if e.clockedOutAt != nil {
+		end = *e.clockedOutAt
+	}
+	return end.Sub(e.clockedInAt)
+}
+
+// BreakTime returns how long the breaks taken lasted, up to now for one
+// still being taken.
+func (e *ClockEntry) BreakTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, b := range e.breaks {
+		end := now
+		if b.EndedAt != nil {
+			end = *b.EndedAt
+		}
+		total += end.Sub(b.StartedAt)
+	}
+	return total
+}
+
+// WorkedMinutes returns the whole minutes worked, breaks excluded, up to
+// now if the entry is still open.
+func (e *ClockEntry) WorkedMinutes(now time.Time) int {
+	return int((e.Span(now) - e.BreakTime(now)) / time.Minute)
+}
@@ -0,0 +1,23 @@
+package staff
+
+import "errors"
+
+var (
+	ErrProfileNotFound    = errors.New("staff profile not found")
+	ErrBranchRequired     = errors.New("staff must have a home branch")
+	ErrInvalidPosition    = errors.New("position must be one of butcher, apprentice or cashier")
+	ErrNegativeRate       = errors.New("hourly rate must not be negative")
+	ErrInvalidContract    = errors.New("contracted hours must be between 0 and 60 a week")
+	ErrProfileInactive    = errors.New("staff member is no longer active")
+	ErrPositionNotAllowed = errors.New("staff member is not trained for that position")
+	ErrShiftNotFound      = errors.New("shift not found")
+	ErrInvalidShiftTimes  = errors.New("shift must end after it starts and last at most 16 hours")
+	ErrInvalidBreak       = errors.New("break must not be negative or as long as the shift")
+	ErrEntryNotFound      = errors.New("staff member is not clocked in")
+	ErrAlreadyClockedIn   = errors.New("staff member is already clocked in")
+	ErrAlreadyOnBreak     = errors.New("staff member is already on a break")
+	ErrNotOnBreak         = errors.New("staff member is not on a break")
+	ErrInvalidPeriod      = errors.New("period must end on or after its start, and cover at most 62 days")
+	ErrManageNotAllowed   = errors.New("only managers may manage staff profiles, rotas and timesheets")
+	ErrUnsupportedFormat  = errors.New("timesheet format must be csv")
+)
@@ -0,0 +1,135 @@
+package staff
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+)
+
+// Position is the job a member of staff does on a shift.
+type Position string
+
+const (
+	// PositionButcher is a trained butcher, who may cut, prepare and pick
+	// orders unsupervised.
+	PositionButcher    Position = "butcher"
+	PositionApprentice Position = "apprentice"
+	PositionCashier    Position = "cashier"
+)
+
+// ParsePosition validates a raw position.
+func ParsePosition(raw string) (Position, error) {
+	switch p := Position(raw); p {
+	case PositionButcher, PositionApprentice, PositionCashier:
+		return p, nil
+	}
+	return "", ErrInvalidPosition
+}
+
+// maxContractedMinutes caps contracted hours at 60 a week.
+const maxContractedMinutes = 60 * 60
+
+// Profile is the employment record of an admin who works shifts: where they
+// usually work, what they are trained to do, what they are paid and how
+// many hours a week they are contracted for. Hours worked beyond the
+// contract are overtime; staff without contracted hours are paid for what
+// they work and never on overtime.
+type Profile struct {
+	adminID           uuid.UUID
+	fullName          string
+	branchID          uuid.UUID
+	position          Position
+	hourlyRateCents   int64
+	contractedMinutes int
+	active            bool
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+// NewProfile validates and creates an active profile for a.
+func NewProfile(a *admin.Admin, branchID uuid.UUID, position Position, hourlyRateCents int64, contractedMinutes int, now time.Time) (*Profile, error) {
+	p := &Profile{adminID: a.ID(), fullName: a.FullName(), createdAt: now}
+	if err := p.Update(branchID, position, hourlyRateCents, contractedMinutes, true, now); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ReconstructProfile reconstructs a Profile from persistence without
+// validation.
+func ReconstructProfile(
+	adminID uuid.UUID,
+	fullName string,
+	branchID uuid.UUID,
+	position Position,
+	hourlyRateCents int64,
+	contractedMinutes int,
+	active bool,
+	createdAt, updatedAt time.Time,
+) *Profile {
+	return &Profile{
+		adminID:           adminID,
+		fullName:          fullName,
+		branchID:          branchID,
+		position:          position,
+		hourlyRateCents:   hourlyRateCents,
+		contractedMinutes: contractedMinutes,
+		active:            active,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+	}
+}
+
+func (p *Profile) AdminID() uuid.UUID     { return p.adminID }
+func (p *Profile) FullName() string       { return p.fullName }
+func (p *Profile) BranchID() uuid.UUID    { return p.branchID }
+func (p *Profile) Position() Position     { return p.position }
+func (p *Profile) HourlyRateCents() int64 { return p.hourlyRateCents }
+func (p *Profile) ContractedMinutes() int { return p.contractedMinutes }
+func (p *Profile) Active() bool           { return p.active }
+func (p *Profile) CreatedAt() time.Time   { return p.createdAt }
+func (p *Profile) UpdatedAt() time.Time   { return p.updatedAt }
+
+// Update changes the profile. Inactive staff keep their history but can no
+// longer be rostered or clock in.
+func (p *Profile) Update(branchID uuid.UUID, position Position, hourlyRateCents int64, contractedMinutes int, active bool, now time.Time) error {
+	if branchID == uuid.Nil {
+		return ErrBranchRequired
+	}
+	if _, err := ParsePosition(string(position)); err != nil {
+		return err
+	}
+	if hourlyRateCents < 0 {
+		return ErrNegativeRate
+	}
+	if contractedMinutes < 0 || contractedMinutes > maxContractedMinutes {
+		return ErrInvalidContract
+	}
+	p.branchID = branchID
+	p.position = position
+	p.hourlyRateCents = hourlyRateCents
+	p.contractedMinutes = contractedMinutes
+	p.active = active
+	p.updatedAt = now
+	return nil
+}
+
+// CanWork reports whether the member of staff may be rostered as pos.
+// Butchers and apprentices also cover the till; cashiers only work it.
+func (p *Profile) CanWork(pos Position) bool {
+	switch p.position {
+	case PositionButcher:
+		return pos == PositionButcher || pos == PositionCashier
+	case PositionApprentice:
+		return pos == PositionApprentice || pos == PositionCashier
+	default:
+		return pos == p.position
+	}
+}
+
+// PayFor returns what minutes of work cost at the profile's hourly rate,
+// to the nearest cent.
+func (p *Profile) PayFor(minutes int) int64 {
+	return (int64(minutes)*p.hourlyRateCents + 30) / 60
+}
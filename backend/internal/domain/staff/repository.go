@@ -0,0 +1,61 @@
+package staff
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProfileRepository provides access to staff profiles. Profiles of staff
+// whose home branch is outside the scope are not returned.
+type ProfileRepository interface {
+	// Save inserts or updates a profile with a home branch in scope.
+	Save(ctx context.Context, p *Profile) error
+	FindByAdminID(ctx context.Context, adminID uuid.UUID) (*Profile, error)
+	// FindAll returns the profiles of staff based at a branch, or at every
+	// branch in scope, by name.
+	FindAll(ctx context.Context, branchID *uuid.UUID) ([]*Profile, error)
+	// FindByAdminIDs returns the profiles of the given staff, wherever they
+	// are based.
+	FindByAdminIDs(ctx context.Context, adminIDs []uuid.UUID) ([]*Profile, error)
+}
+
+// Filter narrows shifts and clock entries to a branch or a member of
+// staff, and to those starting from From up to but not including To.
+type Filter struct {
+	BranchID *uuid.UUID
+	AdminID  *uuid.UUID
+	From     time.Time
+	To       time.Time
+}
+
+// ShiftRepository provides access to rostered shifts. Shifts at branches
+// outside the scope are not returned.
+type ShiftRepository interface {
+	// Save inserts or updates a shift at a branch in scope.
+	Save(ctx context.Context, s *Shift) error
+	// Delete removes a shift at a branch in scope.
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Shift, error)
+	// FindShifts returns shifts by start.
+	FindShifts(ctx context.Context, f Filter) ([]*Shift, error)
+	// FindStaffShifts returns the shifts of the given staff overlapping
+	// [from, to) at any branch, by start, so that clashes across branches
+	// are seen.
+	FindStaffShifts(ctx context.Context, adminIDs []uuid.UUID, from, to time.Time) ([]*Shift, error)
+}
+
+// ClockRepository provides access to clock entries. Entries at branches
+// outside the scope are not returned.
+type ClockRepository interface {
+	// ClockIn stores a new open entry. It returns ErrAlreadyClockedIn if
+	// the member of staff already has one.
+	ClockIn(ctx context.Context, e *ClockEntry) error
+	// Update stores an entry's breaks and when it was clocked out of.
+	Update(ctx context.Context, e *ClockEntry) error
+	// FindOpen returns a member of staff's open entry, or ErrEntryNotFound.
+	FindOpen(ctx context.Context, adminID uuid.UUID) (*ClockEntry, error)
+	// FindEntries returns entries by when they were clocked into.
+	FindEntries(ctx context.Context, f Filter) ([]*ClockEntry, error)
+}
@@ -0,0 +1,241 @@
+package staff
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Policy is what rotas and timesheets are checked against. Shifts and
+// clocked time longer than BreakAfter must include a break of at least
+// MinBreak; a zero BreakAfter turns the check off. A trained butcher is
+// needed for every OrdersPerButcher orders, or KgPerButcher kilograms,
+// booked into a fulfilment slot; a zero figure turns that check off.
+type Policy struct {
+	BreakAfter       time.Duration
+	MinBreak         time.Duration
+	OrdersPerButcher int
+	KgPerButcher     int
+}
+
+// missingBreak returns how much too short the break taken over span was,
+// or zero if it was long enough or none was needed.
+func (p Policy) missingBreak(span, taken time.Duration) time.Duration {
+	if p.BreakAfter <= 0 || span <= p.BreakAfter || taken >= p.MinBreak {
+		return 0
+	}
+	return p.MinBreak - taken
+}
+
+// ButchersFor returns how many trained butchers the work booked into a slot
+// needs.
+func (p Policy) ButchersFor(d Demand) int {
+	var need int64
+	if p.OrdersPerButcher > 0 && d.Orders > 0 {
+		need = ceilDiv(d.Orders, int64(p.OrdersPerButcher))
+	}
+	if p.KgPerButcher > 0 && d.Grams > 0 {
+		need = max(need, ceilDiv(d.Grams, int64(p.KgPerButcher)*1000))
+	}
+	return int(need)
+}
+
+func ceilDiv(a, b int64) int64 { return (a + b - 1) / b }
+
+// ConflictKind is what is wrong with a rota.
+type ConflictKind string
+
+const (
+	// ConflictDoubleBooked is a member of staff rostered for two shifts at
+	// once, at the same branch or different ones.
+	ConflictDoubleBooked ConflictKind = "double_booked"
+	// ConflictMissingBreak is a shift too long to be worked without the
+	// break it is given.
+	ConflictMissingBreak ConflictKind = "missing_break"
+	// ConflictOvertime is a member of staff rostered beyond their
+	// contracted hours for the week.
+	ConflictOvertime ConflictKind = "overtime"
+	// ConflictUnderstaffed is a fulfilment slot with fewer trained butchers
+	// on shift than its bookings need.
+	ConflictUnderstaffed ConflictKind = "understaffed"
+)
+
+// Conflict is a problem with a rota. AdminID is the member of staff it
+// concerns and ShiftIDs their shifts involved; both are empty for an
+// understaffed slot. StartsAt and EndsAt are when it happens. Minutes is
+// how far over their contract or short of their break staff are; Required
+// and Scheduled are the butchers an understaffed slot needs and has.
+type Conflict struct {
+	Kind      ConflictKind
+	AdminID   *uuid.UUID
+	ShiftIDs  []uuid.UUID
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Minutes   int
+	Required  int
+	Scheduled int
+}
+
+// Demand is the fulfilment work booked into a slot at a branch: orders
+// for slots whose capacity is counted in orders, grams for those counted
+// in weight.
+type Demand struct {
+	BranchID uuid.UUID
+	StartsAt time.Time
+	EndsAt   time.Time
+	Orders   int64
+	Grams    int64
+}
+
+// Coverage is how many trained butchers a slot's bookings need and how many
+// are on shift for the whole of it.
+type Coverage struct {
+	Demand
+	Required  int
+	Scheduled int
+}
+
+// Short reports whether the slot has fewer butchers than it needs.
+func (c Coverage) Short() bool { return c.Scheduled < c.Required }
+
+// WeekStart returns when the week day falls in starts: the Monday before
+// or on it, at midnight in loc.
+func WeekStart(day time.Time, loc *time.Location) time.Time {
+	day = day.In(loc)
+	offset := (int(day.Weekday()) + 6) % 7
+	return time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, loc)
+}
+
+// Rota is a branch's shifts for a week, by start, with the fulfilment
+// slots they cover and the conflicts found in them.
+type Rota struct {
+	BranchID  uuid.UUID
+	WeekStart time.Time
+	Shifts    []*Shift
+	Coverage  []Coverage
+	Conflicts []Conflict
+}
+
+// NewRota builds the rota of branchID for the week starting weekStart.
+// shifts are the week's shifts of everyone rostered at the branch, at any
+// branch, so that double bookings and overtime across branches are found;
+// profiles holds their profiles. demand is the work booked into the
+// branch's fulfilment slots that week.
+func (p Policy) NewRota(branchID uuid.UUID, weekStart time.Time, shifts []*Shift, profiles map[uuid.UUID]*Profile, demand []Demand) *Rota {
+	rota := &Rota{BranchID: branchID, WeekStart: weekStart}
+
+	byAdmin := make(map[uuid.UUID][]*Shift)
+	var admins []uuid.UUID
+	for _, s := range shifts {
+		if s.branchID == branchID {
+			rota.Shifts = append(rota.Shifts, s)
+		}
+		if _, ok := byAdmin[s.adminID]; !ok {
+			admins = append(admins, s.adminID)
+		}
+		byAdmin[s.adminID] = append(byAdmin[s.adminID], s)
+	}
+	sortShifts(rota.Shifts)
+
+	for _, adminID := range admins {
+		own := byAdmin[adminID]
+		sortShifts(own)
+		here := false
+		minutes := 0
+		for i, s := range own {
+			here = here || s.branchID == branchID
+			minutes += s.Minutes()
+			for _, o := range own[i+1:] {
+				if !s.Overlaps(o) {
+					break
+				}
+				if s.branchID != branchID && o.branchID != branchID {
+					continue
+				}
+				rota.Conflicts = append(rota.Conflicts, Conflict{
+					Kind:     ConflictDoubleBooked,
+					AdminID:  &adminID,
+					ShiftIDs: []uuid.UUID{s.id, o.id},
+					StartsAt: o.startsAt,
+					EndsAt:   minTime(s.endsAt, o.endsAt),
+				})
+			}
+			if s.branchID != branchID {
+				continue
+			}
+			if short := p.missingBreak(s.Span(), s.breakTime); short > 0 {
+				rota.Conflicts = append(rota.Conflicts, Conflict{
+					Kind:     ConflictMissingBreak,
+					AdminID:  &adminID,
+					ShiftIDs: []uuid.UUID{s.id},
+					StartsAt: s.startsAt,
+					EndsAt:   s.endsAt,
+					Minutes:  int(short / time.Minute),
+				})
+			}
+		}
+		profile := profiles[adminID]
+		if !here || profile == nil || profile.contractedMinutes == 0 || minutes <= profile.contractedMinutes {
+			continue
+		}
+		ids := make([]uuid.UUID, 0, len(own))
+		for _, s := range own {
+			ids = append(ids, s.id)
+		}
+		rota.Conflicts = append(rota.Conflicts, Conflict{
+			Kind:     ConflictOvertime,
+			AdminID:  &adminID,
+			ShiftIDs: ids,
+			StartsAt: own[0].startsAt,
+			EndsAt:   own[len(own)-1].endsAt,
+			Minutes:  minutes - profile.contractedMinutes,
+		})
+	}
+
+	for _, d := range demand {
+		c := Coverage{Demand: d, Required: p.ButchersFor(d)}
+		for _, s := range rota.Shifts {
+			if s.position == PositionButcher && s.Covers(d.BranchID, d.StartsAt, d.EndsAt) {
+				c.Scheduled++
+			}
+		}
+		rota.Coverage = append(rota.Coverage, c)
+		if c.Short() {
+			rota.Conflicts = append(rota.Conflicts, Conflict{
+				Kind:      ConflictUnderstaffed,
+				StartsAt:  d.StartsAt,
+				EndsAt:    d.EndsAt,
+				Required:  c.Required,
+				Scheduled: c.Scheduled,
+			})
+		}
+	}
+	sort.SliceStable(rota.Coverage, func(i, j int) bool {
+		return rota.Coverage[i].StartsAt.Before(rota.Coverage[j].StartsAt)
+	})
+	sort.SliceStable(rota.Conflicts, func(i, j int) bool {
+		a, b := rota.Conflicts[i], rota.Conflicts[j]
+		if !a.StartsAt.Equal(b.StartsAt) {
+			return a.StartsAt.Before(b.StartsAt)
+		}
+		return a.Kind < b.Kind
+	})
+	return rota
+}
+
+func sortShifts(shifts []*Shift) {
+	sort.SliceStable(shifts, func(i, j int) bool {
+		if !shifts[i].startsAt.Equal(shifts[j].startsAt) {
+			return shifts[i].startsAt.Before(shifts[j].startsAt)
+		}
+		return shifts[i].id.String() < shifts[j].id.String()
+	})
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,140 @@
+package staff
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxShift is the longest a single shift may last.
+const maxShift = 16 * time.Hour
+
+// Shift is a stretch of work a member of staff is rostered for at a branch,
+// as a position, with the unpaid break they are to take during it.
+type Shift struct {
+	id        uuid.UUID
+	adminID   uuid.UUID
+	branchID  uuid.UUID
+	position  Position
+	startsAt  time.Time
+	endsAt    time.Time
+	breakTime time.Duration
+	note      string
+	createdBy uuid.UUID
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewShift validates and rosters a shift for the member of staff p.
+func NewShift(
+	id uuid.UUID,
+	p *Profile,
+	branchID uuid.UUID,
+	position Position,
+	startsAt, endsAt time.Time,
+	breakTime time.Duration,
+	note string,
+	createdBy uuid.UUID,
+	now time.Time,
+) (*Shift, error) {
+	s := &Shift{id: id, adminID: p.adminID, createdBy: createdBy, createdAt: now}
+	if err := s.Reschedule(p, branchID, position, startsAt, endsAt, breakTime, note, now); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ReconstructShift reconstructs a Shift from persistence without
+// validation.
+func ReconstructShift(
+	id, adminID, branchID uuid.UUID,
+	position Position,
+	startsAt, endsAt time.Time,
+	breakTime time.Duration,
+	note string,
+	createdBy uuid.UUID,
+	createdAt, updatedAt time.Time,
+) *Shift {
+	return &Shift{
+		id:        id,
+		adminID:   adminID,
+		branchID:  branchID,
+		position:  position,
+		startsAt:  startsAt,
+		endsAt:    endsAt,
+		breakTime: breakTime,
+		note:      note,
+		createdBy: createdBy,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (s *Shift) ID() uuid.UUID        { return s.id }
+func (s *Shift) AdminID() uuid.UUID   { return s.adminID }
+func (s *Shift) BranchID() uuid.UUID  { return s.branchID }
+func (s *Shift) Position() Position   { return s.position }
+func (s *Shift) StartsAt() time.Time  { return s.startsAt }
+func (s *Shift) EndsAt() time.Time    { return s.endsAt }
+func (s *Shift) Break() time.Duration { return s.breakTime }
+func (s *Shift) Note() string         { return s.note }
+func (s *Shift) CreatedBy() uuid.UUID { return s.createdBy }
+func (s *Shift) CreatedAt() time.Time { return s.createdAt }
+func (s *Shift) UpdatedAt() time.Time { return s.updatedAt }
+
+// Reschedule changes when, where and as what the shift is worked. p is the
+// profile of the member of staff it is rostered for.
+func (s *Shift) Reschedule(
+	p *Profile,
+	branchID uuid.UUID,
+	position Position,
+	startsAt, endsAt time.Time,
+	breakTime time.Duration,
+	note string,
+	now time.Time,
+) error {
+	if !p.active {
+		return ErrProfileInactive
+	}
+	if branchID == uuid.Nil {
+		return ErrBranchRequired
+	}
+	if _, err := ParsePosition(string(position)); err != nil {
+		return err
+	}
+	if !p.CanWork(position) {
+		return ErrPositionNotAllowed
+	}
+	if !endsAt.After(startsAt) || endsAt.Sub(startsAt) > maxShift {
+		return ErrInvalidShiftTimes
+	}
+	if breakTime < 0 || breakTime >= endsAt.Sub(startsAt) {
+		return ErrInvalidBreak
+	}
+	s.branchID = branchID
+	s.position = position
+	s.startsAt = startsAt
+	s.endsAt = endsAt
+	s.breakTime = breakTime
+	s.note = strings.TrimSpace(note)
+	s.updatedAt = now
+	return nil
+}
+
+// Span returns how long the shift lasts from start to end.
+func (s *Shift) Span() time.Duration { return s.endsAt.Sub(s.startsAt) }
+
+// Minutes returns the minutes of paid work in the shift.
+func (s *Shift) Minutes() int { return int((s.Span() - s.breakTime) / time.Minute) }
+
+// Overlaps reports whether the shift and o are worked at the same time.
+func (s *Shift) Overlaps(o *Shift) bool {
+	return s.startsAt.Before(o.endsAt) && o.startsAt.Before(s.endsAt)
+}
+
+// Covers reports whether the shift is worked at branchID for the whole of
+// [from, to).
+func (s *Shift) Covers(branchID uuid.UUID, from, to time.Time) bool {
+	return s.branchID == branchID && !s.startsAt.After(from) && !s.endsAt.Before(to)
+}
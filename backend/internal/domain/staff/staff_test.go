@@ -0,0 +1,236 @@
+package staff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = staff.Policy{BreakAfter: 6 * time.Hour, MinBreak: 20 * time.Minute, OrdersPerButcher: 10}
+
+func newProfile(t *testing.T, branchID uuid.UUID, position staff.Position, contractedHours int) *staff.Profile {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "staff@butchery.com", "$2a$10$hash", "Yusuf Ali", admin.RoleStaff)
+	require.NoError(t, err)
+	p, err := staff.NewProfile(a, branchID, position, 1250, contractedHours*60, time.Now())
+	require.NoError(t, err)
+	return p
+}
+
+// at returns the given hour and minute on day d of November 2026, a
+// Monday to Sunday week starting on the 9th.
+func at(d, hour, minute int) time.Time {
+	return time.Date(2026, 11, d, hour, minute, 0, 0, time.UTC)
+}
+
+func newShift(t *testing.T, p *staff.Profile, branchID uuid.UUID, position staff.Position, from, to time.Time, breakTime time.Duration) *staff.Shift {
+	t.Helper()
+	s, err := staff.NewShift(uuid.New(), p, branchID, position, from, to, breakTime, "", uuid.New(), time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewProfile_Invalid_ReturnsError(t *testing.T) {
+	a, err := admin.NewAdmin(uuid.New(), "staff@butchery.com", "$2a$10$hash", "Yusuf Ali", admin.RoleStaff)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		branchID   uuid.UUID
+		position   staff.Position
+		rate       int64
+		contracted int
+		want       error
+	}{
+		{"no branch", uuid.Nil, staff.PositionButcher, 1250, 0, staff.ErrBranchRequired},
+		{"unknown position", uuid.New(), "driver", 1250, 0, staff.ErrInvalidPosition},
+		{"negative rate", uuid.New(), staff.PositionCashier, -1, 0, staff.ErrNegativeRate},
+		{"contract too long", uuid.New(), staff.PositionCashier, 1250, 61 * 60, staff.ErrInvalidContract},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := staff.NewProfile(a, tt.branchID, tt.position, tt.rate, tt.contracted, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestProfile_CanWork(t *testing.T) {
+	branchID := uuid.New()
+	butcher := newProfile(t, branchID, staff.PositionButcher, 0)
+	apprentice := newProfile(t, branchID, staff.PositionApprentice, 0)
+	cashier := newProfile(t, branchID, staff.PositionCashier, 0)
+
+	assert.True(t, butcher.CanWork(staff.PositionButcher))
+	assert.True(t, butcher.CanWork(staff.PositionCashier))
+	assert.False(t, apprentice.CanWork(staff.PositionButcher))
+	assert.True(t, apprentice.CanWork(staff.PositionCashier))
+	assert.False(t, cashier.CanWork(staff.PositionApprentice))
+	assert.Equal(t, int64(10000), butcher.PayFor(8*60))
+	assert.Equal(t, int64(21), butcher.PayFor(1), "pay rounds to the nearest cent")
+}
+
+func TestNewShift_Invalid_ReturnsError(t *testing.T) {
+	branchID := uuid.New()
+	cashier := newProfile(t, branchID, staff.PositionCashier, 0)
+
+	tests := []struct {
+		name      string
+		position  staff.Position
+		from, to  time.Time
+		breakTime time.Duration
+		want      error
+	}{
+		{"untrained", staff.PositionButcher, at(9, 8, 0), at(9, 16, 0), 0, staff.ErrPositionNotAllowed},
+		{"ends before it starts", staff.PositionCashier, at(9, 16, 0), at(9, 8, 0), 0, staff.ErrInvalidShiftTimes},
+		{"too long", staff.PositionCashier, at(9, 6, 0), at(9, 23, 0), 0, staff.ErrInvalidShiftTimes},
+		{"break as long as the shift", staff.PositionCashier, at(9, 8, 0), at(9, 9, 0), time.Hour, staff.ErrInvalidBreak},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := staff.NewShift(uuid.New(), cashier, branchID, tt.position, tt.from, tt.to, tt.breakTime, "", uuid.New(), time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	require.NoError(t, cashier.Update(branchID, staff.PositionCashier, 1250, 0, false, time.Now()))
+	_, err := staff.NewShift(uuid.New(), cashier, branchID, staff.PositionCashier, at(9, 8, 0), at(9, 16, 0), 0, "", uuid.New(), time.Now())
+	assert.ErrorIs(t, err, staff.ErrProfileInactive)
+}
+
+func TestWeekStart(t *testing.T) {
+	assert.Equal(t, at(9, 0, 0), staff.WeekStart(at(9, 0, 0), time.UTC))
+	assert.Equal(t, at(9, 0, 0), staff.WeekStart(at(15, 23, 59), time.UTC))
+	assert.Equal(t, at(16, 0, 0), staff.WeekStart(at(16, 7, 0), time.UTC))
+}
+
+func TestPolicy_NewRota(t *testing.T) {
+	east, west := uuid.New(), uuid.New()
+	yusuf := newProfile(t, east, staff.PositionButcher, 16)
+	amira := newProfile(t, east, staff.PositionCashier, 0)
+	profiles := map[uuid.UUID]*staff.Profile{yusuf.AdminID(): yusuf, amira.AdminID(): amira}
+
+	monday := newShift(t, yusuf, east, staff.PositionButcher, at(9, 8, 0), at(9, 16, 30), 30*time.Minute)
+	tuesday := newShift(t, yusuf, east, staff.PositionButcher, at(10, 8, 0), at(10, 15, 0), 0)
+	// Yusuf is also down to cover the till at West on Tuesday afternoon.
+	clash := newShift(t, yusuf, west, staff.PositionCashier, at(10, 14, 0), at(10, 18, 0), 0)
+	till := newShift(t, amira, east, staff.PositionCashier, at(9, 9, 0), at(9, 17, 0), 30*time.Minute)
+
+	demand := []staff.Demand{
+		{BranchID: east, StartsAt: at(9, 10, 0), EndsAt: at(9, 12, 0), Orders: 8},
+		{BranchID: east, StartsAt: at(9, 16, 0), EndsAt: at(9, 18, 0), Orders: 14},
+	}
+
+	rota := testPolicy.NewRota(east, at(9, 0, 0), []*staff.Shift{clash, till, tuesday, monday}, profiles, demand)
+
+	assert.Equal(t, []*staff.Shift{monday, till, tuesday}, rota.Shifts, "only East's shifts, by start")
+
+	require.Len(t, rota.Coverage, 2)
+	assert.Equal(t, 1, rota.Coverage[0].Required)
+	assert.Equal(t, 1, rota.Coverage[0].Scheduled)
+	assert.False(t, rota.Coverage[0].Short())
+	assert.Equal(t, 2, rota.Coverage[1].Required)
+	assert.Equal(t, 0, rota.Coverage[1].Scheduled, "Yusuf leaves before the slot ends")
+
+	kinds := make([]staff.ConflictKind, 0, len(rota.Conflicts))
+	for _, c := range rota.Conflicts {
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Equal(t, []staff.ConflictKind{
+		staff.ConflictOvertime,
+		staff.ConflictUnderstaffed,
+		staff.ConflictMissingBreak,
+		staff.ConflictDoubleBooked,
+	}, kinds)
+
+	overtime := rota.Conflicts[0]
+	assert.Equal(t, yusuf.AdminID(), *overtime.AdminID)
+	assert.Equal(t, 8*60+7*60+4*60-16*60, overtime.Minutes)
+	assert.Len(t, overtime.ShiftIDs, 3)
+
+	understaffed := rota.Conflicts[1]
+	assert.Nil(t, understaffed.AdminID)
+	assert.Equal(t, 2, understaffed.Required)
+
+	missingBreak := rota.Conflicts[2]
+	assert.Equal(t, []uuid.UUID{tuesday.ID()}, missingBreak.ShiftIDs)
+	assert.Equal(t, 20, missingBreak.Minutes)
+
+	doubleBooked := rota.Conflicts[3]
+	assert.Equal(t, []uuid.UUID{tuesday.ID(), clash.ID()}, doubleBooked.ShiftIDs)
+	assert.Equal(t, at(10, 14, 0), doubleBooked.StartsAt)
+	assert.Equal(t, at(10, 15, 0), doubleBooked.EndsAt)
+}
+
+func TestClockEntry_Breaks(t *testing.T) {
+	p := newProfile(t, uuid.New(), staff.PositionButcher, 0)
+	e, err := staff.ClockIn(uuid.New(), p, uuid.Nil, at(9, 8, 0))
+	require.NoError(t, err)
+	assert.Equal(t, p.BranchID(), e.BranchID(), "staff clock in at their home branch by default")
+
+	assert.ErrorIs(t, e.EndBreak(at(9, 12, 0)), staff.ErrNotOnBreak)
+	require.NoError(t, e.StartBreak(at(9, 12, 0)))
+	assert.ErrorIs(t, e.StartBreak(at(9, 12, 5)), staff.ErrAlreadyOnBreak)
+	assert.Equal(t, 4*60, e.WorkedMinutes(at(9, 12, 30)), "breaks are not worked")
+	require.NoError(t, e.EndBreak(at(9, 12, 30)))
+
+	require.NoError(t, e.StartBreak(at(9, 16, 50)))
+	require.NoError(t, e.ClockOut(at(9, 17, 0)))
+	assert.False(t, e.Open())
+	assert.False(t, e.OnBreak(), "clocking out ends the break")
+	assert.Equal(t, 40*time.Minute, e.BreakTime(at(9, 20, 0)))
+	assert.Equal(t, 9*60-40, e.WorkedMinutes(at(9, 20, 0)))
+	assert.ErrorIs(t, e.ClockOut(at(9, 18, 0)), staff.ErrEntryNotFound)
+}
+
+func TestPolicy_NewTimesheets(t *testing.T) {
+	branchID := uuid.New()
+	yusuf := newProfile(t, branchID, staff.PositionButcher, 16)
+	amira := newProfile(t, branchID, staff.PositionCashier, 0)
+
+	day := func(d, inHour, outHour int, breakTime time.Duration) *staff.ClockEntry {
+		t.Helper()
+		e, err := staff.ClockIn(uuid.New(), yusuf, branchID, at(d, inHour, 0))
+		require.NoError(t, err)
+		if breakTime > 0 {
+			require.NoError(t, e.StartBreak(at(d, 12, 0)))
+			require.NoError(t, e.EndBreak(at(d, 12, 0).Add(breakTime)))
+		}
+		require.NoError(t, e.ClockOut(at(d, outHour, 0)))
+		return e
+	}
+	// Two long days in the first week, one with too short a break, and one
+	// in the next.
+	entries := []*staff.ClockEntry{
+		day(16, 8, 17, 30*time.Minute),
+		day(9, 8, 17, 30*time.Minute),
+		day(10, 8, 17, 10*time.Minute),
+	}
+	open, err := staff.ClockIn(uuid.New(), yusuf, branchID, at(17, 8, 0))
+	require.NoError(t, err)
+	entries = append(entries, open)
+	shifts := []*staff.Shift{newShift(t, yusuf, branchID, staff.PositionButcher, at(9, 8, 0), at(9, 17, 0), 30*time.Minute)}
+
+	sheets := testPolicy.NewTimesheets([]*staff.Profile{yusuf, amira}, entries, shifts, time.UTC)
+
+	require.Len(t, sheets, 2)
+	sheet := sheets[0]
+	require.Len(t, sheet.Entries, 4)
+	assert.Equal(t, at(9, 8, 0), sheet.Entries[0].Entry.ClockedInAt(), "entries run in the order they were clocked")
+	assert.Equal(t, 10, sheet.Entries[1].MissingBreakMinutes)
+	assert.Equal(t, 0, sheet.Entries[3].WorkedMinutes, "open entries do not count yet")
+	assert.Equal(t, 510, sheet.ScheduledMinutes)
+	assert.Equal(t, 510+530+510, sheet.WorkedMinutes)
+	assert.Equal(t, 70, sheet.BreakMinutes)
+	assert.Equal(t, 1, sheet.MissingBreaks)
+	assert.Equal(t, 510+530-16*60, sheet.OvertimeMinutes, "the second week stays within contract")
+	assert.Equal(t, int64(32292), sheet.PayCents)
+
+	assert.Empty(t, sheets[1].Entries)
+	assert.Zero(t, sheets[1].PayCents)
+}
@@ -0,0 +1,152 @@
+package staff
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format is a file format timesheets are exported in.
+type Format string
+
+const FormatCSV Format = "csv"
+
+// ParseFormat validates a raw timesheet format.
+func ParseFormat(raw string) (Format, error) {
+	if f := Format(raw); f == FormatCSV {
+		return f, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// maxPeriodDays is the longest period a timesheet may cover, about two
+// monthly pay runs.
+const maxPeriodDays = 62
+
+// Period is the days a timesheet covers, From to To inclusive, as dates in
+// the shop's time zone.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewPeriod validates a timesheet period.
+func NewPeriod(from, to time.Time) (Period, error) {
+	if to.Before(from) || to.Sub(from) >= maxPeriodDays*24*time.Hour {
+		return Period{}, ErrInvalidPeriod
+	}
+	return Period{From: from, To: to}, nil
+}
+
+// Start returns when the period's first day starts in loc.
+func (p Period) Start(loc *time.Location) time.Time {
+	return time.Date(p.From.Year(), p.From.Month(), p.From.Day(), 0, 0, 0, 0, loc)
+}
+
+// End returns when the period's last day ends in loc.
+func (p Period) End(loc *time.Location) time.Time {
+	return time.Date(p.To.Year(), p.To.Month(), p.To.Day()+1, 0, 0, 0, 0, loc)
+}
+
+// TimesheetEntry is one clock entry on a timesheet with the minutes worked
+// and spent on breaks in it. MissingBreakMinutes is how much too short its
+// breaks were.
+type TimesheetEntry struct {
+	Entry               *ClockEntry
+	WorkedMinutes       int
+	BreakMinutes        int
+	MissingBreakMinutes int
+}
+
+// Timesheet is the time a member of staff was rostered for and worked over
+// a period. Only entries they have clocked out of count towards the totals.
+// Overtime is counted for each Monday-to-Sunday week by the minutes worked
+// beyond their contracted hours; the first and last weeks of a period that
+// does not start on a Monday or end on a Sunday count only their days in
+// it. Pay is for every minute worked at the profile's hourly rate.
+type Timesheet struct {
+	Profile          *Profile
+	Entries          []TimesheetEntry
+	ScheduledMinutes int
+	WorkedMinutes    int
+	BreakMinutes     int
+	OvertimeMinutes  int
+	MissingBreaks    int
+	PayCents         int64
+}
+
+// NewTimesheets builds a timesheet for each profile from the clock entries
+// and shifts that start in the period. Weeks start in loc.
+func (p Policy) NewTimesheets(profiles []*Profile, entries []*ClockEntry, shifts []*Shift, loc *time.Location) []Timesheet {
+	sheets := make([]Timesheet, len(profiles))
+	index := make(map[uuid.UUID]int, len(profiles))
+	for i, pr := range profiles {
+		sheets[i].Profile = pr
+		index[pr.adminID] = i
+	}
+
+	for _, s := range shifts {
+		if i, ok := index[s.adminID]; ok {
+			sheets[i].ScheduledMinutes += s.Minutes()
+		}
+	}
+
+	sorted := append([]*ClockEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].clockedInAt.Before(sorted[j].clockedInAt) })
+	weekly := make([]map[time.Time]int, len(profiles))
+	for _, e := range sorted {
+		i, ok := index[e.adminID]
+		if !ok {
+			continue
+		}
+		sheet := &sheets[i]
+		if e.Open() {
+			sheet.Entries = append(sheet.Entries, TimesheetEntry{Entry: e})
+			continue
+		}
+		end := *e.clockedOutAt
+		line := TimesheetEntry{
+			Entry:               e,
+			WorkedMinutes:       e.WorkedMinutes(end),
+			BreakMinutes:        int(e.BreakTime(end) / time.Minute),
+			MissingBreakMinutes: int(p.missingBreak(e.Span(end), e.BreakTime(end)) / time.Minute),
+		}
+		sheet.Entries = append(sheet.Entries, line)
+		sheet.WorkedMinutes += line.WorkedMinutes
+		sheet.BreakMinutes += line.BreakMinutes
+		if line.MissingBreakMinutes > 0 {
+			sheet.MissingBreaks++
+		}
+		if weekly[i] == nil {
+			weekly[i] = make(map[time.Time]int)
+		}
+		weekly[i][WeekStart(e.clockedInAt, loc)] += line.WorkedMinutes
+	}
+
+	for i := range sheets {
+		sheet := &sheets[i]
+		if contracted := sheet.Profile.contractedMinutes; contracted > 0 {
+			for _, worked := range weekly[i] {
+				sheet.OvertimeMinutes += max(0, worked-contracted)
+			}
+		}
+		sheet.PayCents = sheet.Profile.PayFor(sheet.WorkedMinutes)
+	}
+	return sheets
+}
+
+// TimesheetReport is the timesheets of a branch's staff, or of everyone,
+// over a period, for payroll. BranchNames maps branches to their names.
+type TimesheetReport struct {
+	Period      Period
+	Location    *time.Location
+	Timesheets  []Timesheet
+	BranchNames map[uuid.UUID]string
+	GeneratedAt time.Time
+}
+
+// TimesheetRenderer exports timesheets as a file.
+type TimesheetRenderer interface {
+	Render(r *TimesheetReport) ([]byte, error)
+}
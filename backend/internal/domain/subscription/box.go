@@ -0,0 +1,162 @@
+package subscription
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cut is a weight of one product at its shelf price per kilogram.
+type Cut struct {
+	ProductID       uuid.UUID
+	Description     string
+	Grams           int64
+	PricePerKgCents int64
+}
+
+// Item is a cut in a box together with the cuts a customer may swap it for.
+type Item struct {
+	Cut
+	Substitutes []Cut
+}
+
+// Swap replaces the cut for ProductID with its substitute SubstituteID.
+type Swap struct {
+	ProductID    uuid.UUID
+	SubstituteID uuid.UUID
+}
+
+// Box is a set of cuts delivered together on every delivery of a
+// subscription. A fixed box is always the same; a customizable box lets
+// customers swap cuts for the substitutes each one offers.
+type Box struct {
+	id           uuid.UUID
+	name         string
+	customizable bool
+	items        []Item
+	active       bool
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewBox validates and creates an active box.
+func NewBox(id uuid.UUID, name string, customizable bool, items []Item, now time.Time) (*Box, error) {
+	b := &Box{id: id, active: true, createdAt: now}
+	if err := b.Update(name, customizable, items, true, now); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ReconstructBox reconstructs a Box from persistence without validation.
+func ReconstructBox(id uuid.UUID, name string, customizable bool, items []Item, active bool, createdAt, updatedAt time.Time) *Box {
+	return &Box{
+		id:           id,
+		name:         name,
+		customizable: customizable,
+		items:        items,
+		active:       active,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (b *Box) ID() uuid.UUID        { return b.id }
+func (b *Box) Name() string         { return b.name }
+func (b *Box) Customizable() bool   { return b.customizable }
+func (b *Box) Items() []Item        { return b.items }
+func (b *Box) Active() bool         { return b.active }
+func (b *Box) CreatedAt() time.Time { return b.createdAt }
+func (b *Box) UpdatedAt() time.Time { return b.updatedAt }
+
+// Update replaces the box's name, contents and availability. Subscribers
+// get the new contents from their next order; swaps that no longer apply
+// are ignored.
+func (b *Box) Update(name string, customizable bool, items []Item, active bool, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyBoxName
+	}
+	if len(items) == 0 {
+		return ErrEmptyBox
+	}
+	seen := make(map[uuid.UUID]bool, len(items))
+	cleaned := make([]Item, 0, len(items))
+	for _, it := range items {
+		cut, err := validCut(it.Cut)
+		if err != nil {
+			return err
+		}
+		if seen[cut.ProductID] {
+			return ErrDuplicateCut
+		}
+		seen[cut.ProductID] = true
+		if len(it.Substitutes) > 0 && !customizable {
+			return ErrFixedBoxSubstitutes
+		}
+		subs := make([]Cut, 0, len(it.Substitutes))
+		offered := make(map[uuid.UUID]bool, len(it.Substitutes))
+		for _, s := range it.Substitutes {
+			sub, err := validCut(s)
+			if err != nil {
+				return err
+			}
+			if sub.ProductID == cut.ProductID || offered[sub.ProductID] {
+				return ErrInvalidSubstitute
+			}
+			offered[sub.ProductID] = true
+			subs = append(subs, sub)
+		}
+		cleaned = append(cleaned, Item{Cut: cut, Substitutes: subs})
+	}
+
+	b.name = name
+	b.customizable = customizable
+	b.items = cleaned
+	b.active = active
+	b.updatedAt = now
+	return nil
+}
+
+// Cuts returns what a delivery of the box contains once swaps are applied.
+func (b *Box) Cuts(swaps []Swap) []Cut {
+	chosen := make(map[uuid.UUID]uuid.UUID, len(swaps))
+	for _, s := range swaps {
+		chosen[s.ProductID] = s.SubstituteID
+	}
+	cuts := make([]Cut, 0, len(b.items))
+	for _, it := range b.items {
+		cut := it.Cut
+		if substituteID, ok := chosen[it.ProductID]; ok && b.customizable {
+			for _, s := range it.Substitutes {
+				if s.ProductID == substituteID {
+					cut = s
+					break
+				}
+			}
+		}
+		cuts = append(cuts, cut)
+	}
+	return cuts
+}
+
+func (b *Box) item(productID uuid.UUID) (Item, bool) {
+	for _, it := range b.items {
+		if it.ProductID == productID {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+func validCut(c Cut) (Cut, error) {
+	if c.Grams <= 0 {
+		return Cut{}, ErrInvalidWeight
+	}
+	if c.PricePerKgCents < 0 {
+		return Cut{}, ErrNegativePrice
+	}
+	c.Description = strings.TrimSpace(c.Description)
+	return c, nil
+}
@@ -0,0 +1,71 @@
+package subscription_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cut(productID uuid.UUID, grams, pricePerKg int64) subscription.Cut {
+	return subscription.Cut{ProductID: productID, Description: "cut", Grams: grams, PricePerKgCents: pricePerKg}
+}
+
+func TestNewBox(t *testing.T) {
+	mince, chops, steak := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		boxName      string
+		customizable bool
+		items        []subscription.Item
+		wantErr      error
+	}{
+		{"valid customizable", "Family box", true, []subscription.Item{
+			{Cut: cut(mince, 1000, 1000), Substitutes: []subscription.Cut{cut(steak, 500, 3000)}},
+			{Cut: cut(chops, 800, 1800)},
+		}, nil},
+		{"empty name", " ", false, []subscription.Item{{Cut: cut(mince, 1000, 1000)}}, subscription.ErrEmptyBoxName},
+		{"no cuts", "Box", false, nil, subscription.ErrEmptyBox},
+		{"zero weight", "Box", false, []subscription.Item{{Cut: cut(mince, 0, 1000)}}, subscription.ErrInvalidWeight},
+		{"negative price", "Box", false, []subscription.Item{{Cut: cut(mince, 100, -1)}}, subscription.ErrNegativePrice},
+		{"product twice", "Box", false, []subscription.Item{{Cut: cut(mince, 100, 1)}, {Cut: cut(mince, 200, 1)}},
+			subscription.ErrDuplicateCut},
+		{"substitutes on a fixed box", "Box", false, []subscription.Item{
+			{Cut: cut(mince, 1000, 1000), Substitutes: []subscription.Cut{cut(steak, 500, 3000)}},
+		}, subscription.ErrFixedBoxSubstitutes},
+		{"substitute for itself", "Box", true, []subscription.Item{
+			{Cut: cut(mince, 1000, 1000), Substitutes: []subscription.Cut{cut(mince, 500, 3000)}},
+		}, subscription.ErrInvalidSubstitute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := subscription.NewBox(uuid.New(), tt.boxName, tt.customizable, tt.items, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, b.Active())
+			assert.Len(t, b.Items(), len(tt.items))
+		})
+	}
+}
+
+func TestBox_Cuts(t *testing.T) {
+	mince, chops, steak := uuid.New(), uuid.New(), uuid.New()
+	b, err := subscription.NewBox(uuid.New(), "Family box", true, []subscription.Item{
+		{Cut: cut(mince, 1000, 1000), Substitutes: []subscription.Cut{cut(steak, 500, 3000)}},
+		{Cut: cut(chops, 800, 1800)},
+	}, now)
+	require.NoError(t, err)
+
+	cuts := b.Cuts([]subscription.Swap{{ProductID: mince, SubstituteID: steak}, {ProductID: chops, SubstituteID: uuid.New()}})
+
+	require.Len(t, cuts, 2)
+	assert.Equal(t, steak, cuts[0].ProductID)
+	assert.Equal(t, int64(500), cuts[0].Grams)
+	assert.Equal(t, chops, cuts[1].ProductID, "a swap that is no longer offered is ignored")
+}
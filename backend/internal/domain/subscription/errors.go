@@ -0,0 +1,34 @@
+package subscription
+
+import "errors"
+
+var (
+	ErrInvalidCadence       = errors.New("cadence must be one of weekly, fortnightly or monthly")
+	ErrInvalidStatus        = errors.New("status must be one of active, paused or cancelled")
+	ErrInvalidRenewalStatus = errors.New("renewal status must be one of awaiting_payment, ordered or failed")
+	ErrEmptyBoxName         = errors.New("box name must not be empty")
+	ErrEmptyBox             = errors.New("a box must contain at least one cut")
+	ErrInvalidWeight        = errors.New("weight must be greater than zero")
+	ErrNegativePrice        = errors.New("price must not be negative")
+	ErrDuplicateCut         = errors.New("a product can appear in a box only once")
+	ErrFixedBoxSubstitutes  = errors.New("only customizable boxes can offer substitutions")
+	ErrInvalidSubstitute    = errors.New("a substitute must be a different product, listed once per cut")
+	ErrBoxUnavailable       = errors.New("box is not available to subscribe to")
+	ErrEmptyPaymentMethod   = errors.New("payment method must not be empty")
+	ErrWrongWeekday         = errors.New("delivery date does not fall on the slot's weekday")
+	ErrPastCutoff           = errors.New("the ordering cutoff for that delivery has passed")
+	ErrNotScheduled         = errors.New("no delivery is scheduled on that date")
+	ErrNotCustomizable      = errors.New("cuts in a fixed box cannot be swapped")
+	ErrUnknownCut           = errors.New("cut is not in the box")
+	ErrSubstituteNotAllowed = errors.New("that substitution is not offered for the cut")
+	ErrNotActive            = errors.New("subscription is not active")
+	ErrNotPaused            = errors.New("subscription is not paused")
+	ErrCancelled            = errors.New("subscription has been cancelled")
+	ErrRenewalNotAwaiting   = errors.New("renewal is not waiting for payment")
+	ErrDuplicateRenewal     = errors.New("an order has already been generated for that delivery")
+	ErrBoxNotFound          = errors.New("box not found")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrRenewalNotFound      = errors.New("renewal not found")
+	ErrConcurrentUpdate     = errors.New("subscription was changed by someone else; reload and try again")
+	ErrSlotTemplateRequired = errors.New("a fulfilment slot must be chosen")
+)
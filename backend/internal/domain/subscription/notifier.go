@@ -0,0 +1,30 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notice tells a customer that the order for a delivery could not be placed.
+// RetryAt is when payment will be tried again; when it is nil the delivery
+// will not go ahead. Paused is set when the subscription has been paused
+// because too many orders in a row failed.
+type Notice struct {
+	SubscriptionID uuid.UUID
+	RenewalID      uuid.UUID
+	CustomerID     uuid.UUID
+	Email          string
+	FullName       string
+	DeliveryDate   time.Time
+	Reason         string
+	Attempts       int
+	RetryAt        *time.Time
+	Paused         bool
+}
+
+// Notifier sends notices to customers.
+type Notifier interface {
+	RenewalFailed(ctx context.Context, n Notice) error
+}
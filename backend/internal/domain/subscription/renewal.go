@@ -0,0 +1,211 @@
+package subscription
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RenewalStatus is where the order for one delivery of a subscription is.
+type RenewalStatus string
+
+const (
+	RenewalAwaitingPayment RenewalStatus = "awaiting_payment"
+	RenewalOrdered         RenewalStatus = "ordered"
+	RenewalFailed          RenewalStatus = "failed"
+)
+
+// NewRenewalStatus validates a raw renewal status.
+func NewRenewalStatus(raw string) (RenewalStatus, error) {
+	switch s := RenewalStatus(raw); s {
+	case RenewalAwaitingPayment, RenewalOrdered, RenewalFailed:
+		return s, nil
+	default:
+		return "", ErrInvalidRenewalStatus
+	}
+}
+
+// Renewal is the order generated for one delivery of a subscription. The
+// slot and stock are held until the cutoff while the customer's card is
+// charged, and the order is placed under OrderID once payment goes through.
+// A declined payment is retried at set intervals until the cutoff, after
+// which the renewal fails and the holds lapse.
+type Renewal struct {
+	id                 uuid.UUID
+	subscriptionID     uuid.UUID
+	customerID         uuid.UUID
+	branchID           uuid.UUID
+	deliveryDate       time.Time
+	slotStartsAt       time.Time
+	cutoffAt           time.Time
+	orderID            uuid.UUID
+	cuts               []Cut
+	totalCents         int64
+	status             RenewalStatus
+	slotReservationID  *uuid.UUID
+	stockReservationID *uuid.UUID
+	paymentID          *uuid.UUID
+	attempts           int
+	nextAttemptAt      *time.Time
+	failureReason      string
+	createdAt          time.Time
+	updatedAt          time.Time
+}
+
+// NewRenewal creates a renewal for the subscription's next delivery, due to
+// be charged straight away. cuts are the box's contents with the customer's
+// swaps, and totalCents what they come to with VAT.
+func NewRenewal(
+	id uuid.UUID,
+	sub *Subscription,
+	branchID uuid.UUID,
+	slotStartsAt, cutoffAt time.Time,
+	orderID uuid.UUID,
+	cuts []Cut,
+	totalCents int64,
+	now time.Time,
+) *Renewal {
+	return &Renewal{
+		id:             id,
+		subscriptionID: sub.ID(),
+		customerID:     sub.CustomerID(),
+		branchID:       branchID,
+		deliveryDate:   sub.NextDelivery(),
+		slotStartsAt:   slotStartsAt,
+		cutoffAt:       cutoffAt,
+		orderID:        orderID,
+		cuts:           cuts,
+		totalCents:     totalCents,
+		status:         RenewalAwaitingPayment,
+		createdAt:      now,
+		updatedAt:      now,
+	}
+}
+
+// ReconstructRenewal reconstructs a Renewal from persistence without validation.
+func ReconstructRenewal(
+	id, subscriptionID, customerID, branchID uuid.UUID,
+	deliveryDate, slotStartsAt, cutoffAt time.Time,
+	orderID uuid.UUID,
+	cuts []Cut,
+	totalCents int64,
+	status RenewalStatus,
+	slotReservationID, stockReservationID, paymentID *uuid.UUID,
+	attempts int,
+	nextAttemptAt *time.Time,
+	failureReason string,
+	createdAt, updatedAt time.Time,
+) *Renewal {
+	return &Renewal{
+		id:                 id,
+		subscriptionID:     subscriptionID,
+		customerID:         customerID,
+		branchID:           branchID,
+		deliveryDate:       deliveryDate,
+		slotStartsAt:       slotStartsAt,
+		cutoffAt:           cutoffAt,
+		orderID:            orderID,
+		cuts:               cuts,
+		totalCents:         totalCents,
+		status:             status,
+		slotReservationID:  slotReservationID,
+		stockReservationID: stockReservationID,
+		paymentID:          paymentID,
+		attempts:           attempts,
+		nextAttemptAt:      nextAttemptAt,
+		failureReason:      failureReason,
+		createdAt:          createdAt,
+		updatedAt:          updatedAt,
+	}
+}
+
+func (r *Renewal) ID() uuid.UUID                  { return r.id }
+func (r *Renewal) SubscriptionID() uuid.UUID      { return r.subscriptionID }
+func (r *Renewal) CustomerID() uuid.UUID          { return r.customerID }
+func (r *Renewal) BranchID() uuid.UUID            { return r.branchID }
+func (r *Renewal) DeliveryDate() time.Time        { return r.deliveryDate }
+func (r *Renewal) SlotStartsAt() time.Time        { return r.slotStartsAt }
+func (r *Renewal) CutoffAt() time.Time            { return r.cutoffAt }
+func (r *Renewal) OrderID() uuid.UUID             { return r.orderID }
+func (r *Renewal) Cuts() []Cut                    { return r.cuts }
+func (r *Renewal) TotalCents() int64              { return r.totalCents }
+func (r *Renewal) Status() RenewalStatus          { return r.status }
+func (r *Renewal) SlotReservationID() *uuid.UUID  { return r.slotReservationID }
+func (r *Renewal) StockReservationID() *uuid.UUID { return r.stockReservationID }
+func (r *Renewal) PaymentID() *uuid.UUID          { return r.paymentID }
+func (r *Renewal) Attempts() int                  { return r.attempts }
+func (r *Renewal) NextAttemptAt() *time.Time      { return r.nextAttemptAt }
+func (r *Renewal) FailureReason() string          { return r.failureReason }
+func (r *Renewal) CreatedAt() time.Time           { return r.createdAt }
+func (r *Renewal) UpdatedAt() time.Time           { return r.updatedAt }
+
+// Due reports whether payment should be attempted as of now.
+func (r *Renewal) Due(now time.Time) bool {
+	if r.status != RenewalAwaitingPayment || !now.Before(r.cutoffAt) {
+		return false
+	}
+	return r.nextAttemptAt == nil || !now.Before(*r.nextAttemptAt)
+}
+
+// Overdue reports whether the cutoff has passed without payment going through.
+func (r *Renewal) Overdue(now time.Time) bool {
+	return r.status == RenewalAwaitingPayment && !now.Before(r.cutoffAt)
+}
+
+// Reserved records the slot and stock held for the delivery.
+func (r *Renewal) Reserved(slotReservationID, stockReservationID uuid.UUID, now time.Time) {
+	r.slotReservationID = &slotReservationID
+	r.stockReservationID = &stockReservationID
+	r.updatedAt = now
+}
+
+// Declined records a payment attempt the card issuer refused. The next
+// attempt is retryIntervals[n] after the nth; once they are used up, or the
+// next attempt would fall after the cutoff, the renewal fails. It returns
+// when payment will be tried again, or nil if it will not.
+func (r *Renewal) Declined(paymentID uuid.UUID, reason string, retryIntervals []time.Duration, now time.Time) (*time.Time, error) {
+	if r.status != RenewalAwaitingPayment {
+		return nil, ErrRenewalNotAwaiting
+	}
+	r.attempts++
+	r.paymentID = &paymentID
+	r.failureReason = reason
+	r.updatedAt = now
+	if r.attempts <= len(retryIntervals) {
+		next := now.Add(retryIntervals[r.attempts-1])
+		if next.Before(r.cutoffAt) {
+			r.nextAttemptAt = &next
+			return &next, nil
+		}
+	}
+	r.status = RenewalFailed
+	r.nextAttemptAt = nil
+	return nil, nil
+}
+
+// Ordered records that payment went through and the order was placed.
+func (r *Renewal) Ordered(paymentID uuid.UUID, now time.Time) error {
+	if r.status != RenewalAwaitingPayment {
+		return ErrRenewalNotAwaiting
+	}
+	r.attempts++
+	r.paymentID = &paymentID
+	r.status = RenewalOrdered
+	r.nextAttemptAt = nil
+	r.failureReason = ""
+	r.updatedAt = now
+	return nil
+}
+
+// Fail gives up on the delivery, e.g. because the slot is full, stock has
+// run out or the cutoff passed before payment went through.
+func (r *Renewal) Fail(reason string, now time.Time) error {
+	if r.status != RenewalAwaitingPayment {
+		return ErrRenewalNotAwaiting
+	}
+	r.status = RenewalFailed
+	r.failureReason = reason
+	r.nextAttemptAt = nil
+	r.updatedAt = now
+	return nil
+}
@@ -0,0 +1,75 @@
+package subscription_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRenewal(t *testing.T, cutoffAt time.Time) *subscription.Renewal {
+	t.Helper()
+	box, _, _ := newBox(t, false)
+	s := newSubscription(t, box, subscription.CadenceWeekly)
+	return subscription.NewRenewal(uuid.New(), s, uuid.New(), cutoffAt.Add(2*time.Hour), cutoffAt,
+		uuid.New(), box.Cuts(nil), 1000, now)
+}
+
+func TestRenewal_Declined(t *testing.T) {
+	retries := []time.Duration{time.Hour, 6 * time.Hour}
+
+	t.Run("retries until the intervals run out", func(t *testing.T) {
+		r := newRenewal(t, now.Add(48*time.Hour))
+		assert.True(t, r.Due(now))
+
+		retryAt, err := r.Declined(uuid.New(), "card declined", retries, now)
+		require.NoError(t, err)
+		require.NotNil(t, retryAt)
+		assert.Equal(t, now.Add(time.Hour), *retryAt)
+		assert.False(t, r.Due(now.Add(time.Minute)))
+		assert.True(t, r.Due(now.Add(time.Hour)))
+
+		retryAt, err = r.Declined(uuid.New(), "card declined", retries, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(7*time.Hour), *retryAt)
+
+		retryAt, err = r.Declined(uuid.New(), "card declined", retries, now.Add(7*time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, retryAt)
+		assert.Equal(t, subscription.RenewalFailed, r.Status())
+		assert.Equal(t, 3, r.Attempts())
+		assert.Equal(t, "card declined", r.FailureReason())
+	})
+
+	t.Run("no retry after the cutoff", func(t *testing.T) {
+		r := newRenewal(t, now.Add(30*time.Minute))
+
+		retryAt, err := r.Declined(uuid.New(), "card declined", retries, now)
+		require.NoError(t, err)
+		assert.Nil(t, retryAt)
+		assert.Equal(t, subscription.RenewalFailed, r.Status())
+	})
+}
+
+func TestRenewal_Ordered(t *testing.T) {
+	r := newRenewal(t, now.Add(48*time.Hour))
+	paymentID := uuid.New()
+
+	require.NoError(t, r.Ordered(paymentID, now))
+
+	assert.Equal(t, subscription.RenewalOrdered, r.Status())
+	assert.Equal(t, paymentID, *r.PaymentID())
+	assert.False(t, r.Due(now))
+	assert.ErrorIs(t, r.Fail("too late", now), subscription.ErrRenewalNotAwaiting)
+}
+
+func TestRenewal_Overdue(t *testing.T) {
+	r := newRenewal(t, now.Add(time.Hour))
+
+	assert.False(t, r.Overdue(now))
+	assert.True(t, r.Overdue(now.Add(time.Hour)))
+	assert.False(t, r.Due(now.Add(time.Hour)))
+}
@@ -0,0 +1,56 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Filter narrows a subscription query. Nil fields are not filtered on.
+type Filter struct {
+	CustomerID *uuid.UUID
+	Status     *Status
+}
+
+// RenewalFilter narrows a renewal query. Nil fields are not filtered on.
+type RenewalFilter struct {
+	SubscriptionID *uuid.UUID
+	CustomerID     *uuid.UUID
+	Status         *RenewalStatus
+	From           *time.Time
+}
+
+// BoxRepository provides access to boxes.
+type BoxRepository interface {
+	// Save inserts or updates a box.
+	Save(ctx context.Context, b *Box) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Box, error)
+	// FindAll returns boxes by name, only those open to subscribe to when
+	// activeOnly is set.
+	FindAll(ctx context.Context, activeOnly bool) ([]*Box, error)
+}
+
+// Repository provides access to subscriptions.
+type Repository interface {
+	Create(ctx context.Context, s *Subscription) error
+	// Update stores a changed subscription. It returns ErrConcurrentUpdate if
+	// the subscription has changed since it was loaded.
+	Update(ctx context.Context, s *Subscription) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	// FindAll returns subscriptions, oldest first.
+	FindAll(ctx context.Context, filter Filter) ([]*Subscription, error)
+}
+
+// RenewalRepository provides access to the orders generated for deliveries.
+type RenewalRepository interface {
+	// Create stores a new renewal together with its subscription, moved on
+	// to the following delivery, in one transaction. It returns
+	// ErrDuplicateRenewal if the delivery already has one and
+	// ErrConcurrentUpdate if the subscription has changed since it was loaded.
+	Create(ctx context.Context, r *Renewal, s *Subscription) error
+	Update(ctx context.Context, r *Renewal) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Renewal, error)
+	// FindAll returns renewals by delivery date.
+	FindAll(ctx context.Context, filter RenewalFilter) ([]*Renewal, error)
+}
@@ -0,0 +1,287 @@
+package subscription
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription is a customer's standing order for a box, delivered in one
+// fulfilment slot at a cadence. NextDelivery is the next date an order will
+// be generated for; dates the customer skips further ahead are kept until
+// they are reached. FailedRenewals counts orders in a row that could not be
+// placed. Version guards against the customer and the scheduler changing it
+// at once.
+type Subscription struct {
+	id             uuid.UUID
+	customerID     uuid.UUID
+	boxID          uuid.UUID
+	cadence        Cadence
+	templateID     uuid.UUID
+	paymentMethod  string
+	status         Status
+	nextDelivery   time.Time
+	skips          []time.Time
+	swaps          []Swap
+	failedRenewals int
+	version        int
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewSubscription validates and creates an active subscription to box,
+// delivered in the slot template templateID, which runs on weekday, from
+// firstDelivery on. Whether the slot runs and its cutoff has not passed is
+// checked by the caller.
+func NewSubscription(
+	id, customerID uuid.UUID,
+	box *Box,
+	cadence Cadence,
+	templateID uuid.UUID,
+	weekday time.Weekday,
+	firstDelivery time.Time,
+	paymentMethod string,
+	now time.Time,
+) (*Subscription, error) {
+	if !box.Active() {
+		return nil, ErrBoxUnavailable
+	}
+	if templateID == uuid.Nil {
+		return nil, ErrSlotTemplateRequired
+	}
+	firstDelivery = Day(firstDelivery)
+	if firstDelivery.Weekday() != weekday {
+		return nil, ErrWrongWeekday
+	}
+	paymentMethod = strings.TrimSpace(paymentMethod)
+	if paymentMethod == "" {
+		return nil, ErrEmptyPaymentMethod
+	}
+	return &Subscription{
+		id:            id,
+		customerID:    customerID,
+		boxID:         box.ID(),
+		cadence:       cadence,
+		templateID:    templateID,
+		paymentMethod: paymentMethod,
+		status:        StatusActive,
+		nextDelivery:  firstDelivery,
+		version:       1,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// ReconstructSubscription reconstructs a Subscription from persistence without validation.
+func ReconstructSubscription(
+	id, customerID, boxID uuid.UUID,
+	cadence Cadence,
+	templateID uuid.UUID,
+	paymentMethod string,
+	status Status,
+	nextDelivery time.Time,
+	skips []time.Time,
+	swaps []Swap,
+	failedRenewals int,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Subscription {
+	return &Subscription{
+		id:             id,
+		customerID:     customerID,
+		boxID:          boxID,
+		cadence:        cadence,
+		templateID:     templateID,
+		paymentMethod:  paymentMethod,
+		status:         status,
+		nextDelivery:   nextDelivery,
+		skips:          skips,
+		swaps:          swaps,
+		failedRenewals: failedRenewals,
+		version:        version,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+func (s *Subscription) ID() uuid.UUID           { return s.id }
+func (s *Subscription) CustomerID() uuid.UUID   { return s.customerID }
+func (s *Subscription) BoxID() uuid.UUID        { return s.boxID }
+func (s *Subscription) Cadence() Cadence        { return s.cadence }
+func (s *Subscription) TemplateID() uuid.UUID   { return s.templateID }
+func (s *Subscription) PaymentMethod() string   { return s.paymentMethod }
+func (s *Subscription) Status() Status          { return s.status }
+func (s *Subscription) NextDelivery() time.Time { return s.nextDelivery }
+func (s *Subscription) Skips() []time.Time      { return s.skips }
+func (s *Subscription) Swaps() []Swap           { return s.swaps }
+func (s *Subscription) FailedRenewals() int     { return s.failedRenewals }
+func (s *Subscription) Version() int            { return s.version }
+func (s *Subscription) CreatedAt() time.Time    { return s.createdAt }
+func (s *Subscription) UpdatedAt() time.Time    { return s.updatedAt }
+
+// Upcoming returns the next n delivery dates, leaving out skipped ones.
+func (s *Subscription) Upcoming(n int) []time.Time {
+	dates := make([]time.Time, 0, n)
+	for d := s.nextDelivery; len(dates) < n; d = s.cadence.After(d) {
+		if !s.skipped(d) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// Advance moves NextDelivery on to the following date that is not skipped,
+// e.g. once an order has been generated for it or its cutoff has passed.
+// Skips that are now behind it are dropped.
+func (s *Subscription) Advance(now time.Time) {
+	d := s.cadence.After(s.nextDelivery)
+	for s.skipped(d) {
+		d = s.cadence.After(d)
+	}
+	s.nextDelivery = d
+	s.skips = slices.DeleteFunc(s.skips, func(skip time.Time) bool { return skip.Before(d) })
+	s.updatedAt = now
+}
+
+// Skip leaves out the delivery on date. It must be a scheduled delivery on
+// or after NextDelivery; the caller checks its cutoff has not passed.
+func (s *Subscription) Skip(date time.Time, now time.Time) error {
+	if s.status == StatusCancelled {
+		return ErrCancelled
+	}
+	date = Day(date)
+	if !s.scheduled(date) {
+		return ErrNotScheduled
+	}
+	if date.Equal(s.nextDelivery) {
+		s.Advance(now)
+		return nil
+	}
+	s.skips = append(s.skips, date)
+	slices.SortFunc(s.skips, func(a, b time.Time) int { return a.Compare(b) })
+	s.updatedAt = now
+	return nil
+}
+
+// Swap chooses substituteID in place of the cut for productID on future
+// deliveries. Choosing the cut itself undoes an earlier swap.
+func (s *Subscription) Swap(box *Box, productID, substituteID uuid.UUID, now time.Time) error {
+	if s.status == StatusCancelled {
+		return ErrCancelled
+	}
+	if !box.Customizable() {
+		return ErrNotCustomizable
+	}
+	it, ok := box.item(productID)
+	if !ok {
+		return ErrUnknownCut
+	}
+	if substituteID != productID && !slices.ContainsFunc(it.Substitutes, func(c Cut) bool { return c.ProductID == substituteID }) {
+		return ErrSubstituteNotAllowed
+	}
+	s.swaps = slices.DeleteFunc(s.swaps, func(sw Swap) bool { return sw.ProductID == productID })
+	if substituteID != productID {
+		s.swaps = append(s.swaps, Swap{ProductID: productID, SubstituteID: substituteID})
+	}
+	s.updatedAt = now
+	return nil
+}
+
+// ChangeSlot moves future deliveries to the slot template templateID, which
+// runs on weekday. The next delivery becomes the first such weekday on or
+// after the current one, and skips, which were for the old weekday, are
+// dropped. The caller moves it on further if that date's cutoff has passed.
+func (s *Subscription) ChangeSlot(templateID uuid.UUID, weekday time.Weekday, now time.Time) error {
+	if s.status == StatusCancelled {
+		return ErrCancelled
+	}
+	if templateID == uuid.Nil {
+		return ErrSlotTemplateRequired
+	}
+	offset := (int(weekday) - int(s.nextDelivery.Weekday()) + 7) % 7
+	s.templateID = templateID
+	s.nextDelivery = s.nextDelivery.AddDate(0, 0, offset)
+	s.skips = nil
+	s.updatedAt = now
+	return nil
+}
+
+// ChangePaymentMethod sets the payment method future orders are charged to,
+// including retries of an order whose payment failed.
+func (s *Subscription) ChangePaymentMethod(paymentMethod string, now time.Time) error {
+	if s.status == StatusCancelled {
+		return ErrCancelled
+	}
+	paymentMethod = strings.TrimSpace(paymentMethod)
+	if paymentMethod == "" {
+		return ErrEmptyPaymentMethod
+	}
+	s.paymentMethod = paymentMethod
+	s.updatedAt = now
+	return nil
+}
+
+// Pause stops orders being generated until the subscription is resumed.
+func (s *Subscription) Pause(now time.Time) error {
+	if s.status != StatusActive {
+		return ErrNotActive
+	}
+	s.status = StatusPaused
+	s.updatedAt = now
+	return nil
+}
+
+// Resume restarts a paused subscription and clears its failed renewals. The
+// caller moves NextDelivery on past dates whose cutoff has gone by.
+func (s *Subscription) Resume(now time.Time) error {
+	if s.status != StatusPaused {
+		return ErrNotPaused
+	}
+	s.status = StatusActive
+	s.failedRenewals = 0
+	s.updatedAt = now
+	return nil
+}
+
+// Cancel ends the subscription. Orders already generated are unaffected.
+func (s *Subscription) Cancel(now time.Time) error {
+	if s.status == StatusCancelled {
+		return ErrCancelled
+	}
+	s.status = StatusCancelled
+	s.updatedAt = now
+	return nil
+}
+
+// RenewalFailed counts an order that could not be placed. Once maxFailures
+// have failed in a row an active subscription is paused, and it reports
+// true, so the customer is not charged again until they resume it.
+func (s *Subscription) RenewalFailed(maxFailures int, now time.Time) bool {
+	s.failedRenewals++
+	s.updatedAt = now
+	if s.status == StatusActive && s.failedRenewals >= maxFailures {
+		s.status = StatusPaused
+		return true
+	}
+	return false
+}
+
+// RenewalPlaced clears the count of failed renewals.
+func (s *Subscription) RenewalPlaced(now time.Time) {
+	s.failedRenewals = 0
+	s.updatedAt = now
+}
+
+func (s *Subscription) skipped(date time.Time) bool {
+	return slices.ContainsFunc(s.skips, date.Equal)
+}
+
+func (s *Subscription) scheduled(date time.Time) bool {
+	d := s.nextDelivery
+	for d.Before(date) {
+		d = s.cadence.After(d)
+	}
+	return d.Equal(date) && !s.skipped(date)
+}
@@ -0,0 +1,148 @@
+package subscription_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBox(t *testing.T, customizable bool) (*subscription.Box, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	mince, steak := uuid.New(), uuid.New()
+	item := subscription.Item{Cut: cut(mince, 1000, 1000)}
+	if customizable {
+		item.Substitutes = []subscription.Cut{cut(steak, 500, 3000)}
+	}
+	b, err := subscription.NewBox(uuid.New(), "Family box", customizable, []subscription.Item{item}, now)
+	require.NoError(t, err)
+	return b, mince, steak
+}
+
+func newSubscription(t *testing.T, box *subscription.Box, cadence subscription.Cadence) *subscription.Subscription {
+	t.Helper()
+	s, err := subscription.NewSubscription(uuid.New(), uuid.New(), box, cadence, uuid.New(), time.Saturday,
+		date(2026, 3, 7), "pm_card_visa", now)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewSubscription(t *testing.T) {
+	box, _, _ := newBox(t, false)
+
+	_, err := subscription.NewSubscription(uuid.New(), uuid.New(), box, subscription.CadenceWeekly, uuid.New(),
+		time.Friday, date(2026, 3, 7), "pm_card_visa", now)
+	assert.ErrorIs(t, err, subscription.ErrWrongWeekday)
+
+	_, err = subscription.NewSubscription(uuid.New(), uuid.New(), box, subscription.CadenceWeekly, uuid.New(),
+		time.Saturday, date(2026, 3, 7), " ", now)
+	assert.ErrorIs(t, err, subscription.ErrEmptyPaymentMethod)
+
+	require.NoError(t, box.Update(box.Name(), false, box.Items(), false, now))
+	_, err = subscription.NewSubscription(uuid.New(), uuid.New(), box, subscription.CadenceWeekly, uuid.New(),
+		time.Saturday, date(2026, 3, 7), "pm_card_visa", now)
+	assert.ErrorIs(t, err, subscription.ErrBoxUnavailable)
+}
+
+func TestSubscription_Skip(t *testing.T) {
+	box, _, _ := newBox(t, false)
+
+	t.Run("skipping the next delivery moves on to the one after", func(t *testing.T) {
+		s := newSubscription(t, box, subscription.CadenceWeekly)
+		require.NoError(t, s.Skip(date(2026, 3, 7), now))
+		assert.Equal(t, date(2026, 3, 14), s.NextDelivery())
+	})
+
+	t.Run("later skips are passed over when their date is reached", func(t *testing.T) {
+		s := newSubscription(t, box, subscription.CadenceFortnightly)
+		require.NoError(t, s.Skip(date(2026, 3, 21), now))
+		assert.Equal(t, []time.Time{date(2026, 3, 7), date(2026, 4, 4)}, s.Upcoming(2))
+
+		s.Advance(now)
+		assert.Equal(t, date(2026, 4, 4), s.NextDelivery())
+		assert.Empty(t, s.Skips())
+	})
+
+	t.Run("only scheduled deliveries can be skipped", func(t *testing.T) {
+		s := newSubscription(t, box, subscription.CadenceFortnightly)
+		assert.ErrorIs(t, s.Skip(date(2026, 3, 14), now), subscription.ErrNotScheduled)
+		assert.ErrorIs(t, s.Skip(date(2026, 2, 21), now), subscription.ErrNotScheduled)
+		require.NoError(t, s.Skip(date(2026, 3, 21), now))
+		assert.ErrorIs(t, s.Skip(date(2026, 3, 21), now), subscription.ErrNotScheduled)
+	})
+}
+
+func TestSubscription_Swap(t *testing.T) {
+	t.Run("swap and undo", func(t *testing.T) {
+		box, mince, steak := newBox(t, true)
+		s := newSubscription(t, box, subscription.CadenceWeekly)
+
+		require.NoError(t, s.Swap(box, mince, steak, now))
+		assert.Equal(t, []subscription.Swap{{ProductID: mince, SubstituteID: steak}}, s.Swaps())
+
+		require.NoError(t, s.Swap(box, mince, mince, now))
+		assert.Empty(t, s.Swaps())
+	})
+
+	t.Run("only offered substitutions", func(t *testing.T) {
+		box, mince, steak := newBox(t, true)
+		s := newSubscription(t, box, subscription.CadenceWeekly)
+		require.NoError(t, s.Swap(box, mince, steak, now))
+
+		assert.ErrorIs(t, s.Swap(box, mince, uuid.New(), now), subscription.ErrSubstituteNotAllowed)
+		assert.ErrorIs(t, s.Swap(box, uuid.New(), steak, now), subscription.ErrUnknownCut)
+		assert.Len(t, s.Swaps(), 1, "a refused swap leaves the earlier one in place")
+	})
+
+	t.Run("fixed boxes cannot be changed", func(t *testing.T) {
+		box, mince, _ := newBox(t, false)
+		s := newSubscription(t, box, subscription.CadenceWeekly)
+		assert.ErrorIs(t, s.Swap(box, mince, uuid.New(), now), subscription.ErrNotCustomizable)
+	})
+}
+
+func TestSubscription_ChangeSlot(t *testing.T) {
+	box, _, _ := newBox(t, false)
+	s := newSubscription(t, box, subscription.CadenceWeekly)
+	require.NoError(t, s.Skip(date(2026, 3, 14), now))
+	templateID := uuid.New()
+
+	require.NoError(t, s.ChangeSlot(templateID, time.Wednesday, now))
+
+	assert.Equal(t, templateID, s.TemplateID())
+	assert.Equal(t, date(2026, 3, 11), s.NextDelivery())
+	assert.Empty(t, s.Skips())
+}
+
+func TestSubscription_PauseResumeCancel(t *testing.T) {
+	box, _, _ := newBox(t, false)
+	s := newSubscription(t, box, subscription.CadenceWeekly)
+
+	assert.ErrorIs(t, s.Resume(now), subscription.ErrNotPaused)
+	require.NoError(t, s.Pause(now))
+	assert.ErrorIs(t, s.Pause(now), subscription.ErrNotActive)
+	require.NoError(t, s.Resume(now))
+	assert.Equal(t, subscription.StatusActive, s.Status())
+
+	require.NoError(t, s.Cancel(now))
+	assert.ErrorIs(t, s.Cancel(now), subscription.ErrCancelled)
+	assert.ErrorIs(t, s.Skip(s.NextDelivery(), now), subscription.ErrCancelled)
+	assert.ErrorIs(t, s.ChangePaymentMethod("pm_card_visa", now), subscription.ErrCancelled)
+}
+
+func TestSubscription_RenewalFailed(t *testing.T) {
+	box, _, _ := newBox(t, false)
+	s := newSubscription(t, box, subscription.CadenceWeekly)
+
+	assert.False(t, s.RenewalFailed(2, now))
+	s.RenewalPlaced(now)
+	assert.False(t, s.RenewalFailed(2, now))
+	assert.True(t, s.RenewalFailed(2, now))
+	assert.Equal(t, subscription.StatusPaused, s.Status())
+
+	require.NoError(t, s.Resume(now))
+	assert.Zero(t, s.FailedRenewals())
+}
@@ -0,0 +1,68 @@
+package subscription
+
+import "time"
+
+// Cadence is how often a subscription is delivered.
+type Cadence string
+
+const (
+	CadenceWeekly      Cadence = "weekly"
+	CadenceFortnightly Cadence = "fortnightly"
+	CadenceMonthly     Cadence = "monthly"
+)
+
+// NewCadence validates a raw cadence.
+func NewCadence(raw string) (Cadence, error) {
+	switch c := Cadence(raw); c {
+	case CadenceWeekly, CadenceFortnightly, CadenceMonthly:
+		return c, nil
+	default:
+		return "", ErrInvalidCadence
+	}
+}
+
+// After returns the delivery date that follows date. Deliveries always fall
+// on the same weekday as their slot, so a monthly delivery is on the same
+// weekday of the same week of the next month, e.g. the second Saturday. A
+// fifth weekday counts as the fourth, so every month has one.
+func (c Cadence) After(date time.Time) time.Time {
+	switch c {
+	case CadenceWeekly:
+		return date.AddDate(0, 0, 7)
+	case CadenceFortnightly:
+		return date.AddDate(0, 0, 14)
+	default:
+		week := min((date.Day()-1)/7, 3)
+		first := time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, date.Location())
+		offset := (int(date.Weekday()) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+7*week)
+	}
+}
+
+func (c Cadence) String() string { return string(c) }
+
+// Status is whether a subscription is being delivered.
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusPaused    Status = "paused"
+	StatusCancelled Status = "cancelled"
+)
+
+// NewStatus validates a raw status.
+func NewStatus(raw string) (Status, error) {
+	switch s := Status(raw); s {
+	case StatusActive, StatusPaused, StatusCancelled:
+		return s, nil
+	default:
+		return "", ErrInvalidStatus
+	}
+}
+
+// Day returns the calendar date of t as midnight UTC, the form in which
+// delivery dates are kept and compared.
+func Day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
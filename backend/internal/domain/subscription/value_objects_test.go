@@ -0,0 +1,46 @@
+package subscription_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCadence_After(t *testing.T) {
+	tests := []struct {
+		name    string
+		cadence subscription.Cadence
+		from    time.Time
+		want    time.Time
+	}{
+		{"weekly", subscription.CadenceWeekly, date(2026, 3, 7), date(2026, 3, 14)},
+		{"fortnightly", subscription.CadenceFortnightly, date(2026, 3, 7), date(2026, 3, 21)},
+		{"monthly keeps the week of the month", subscription.CadenceMonthly, date(2026, 3, 14), date(2026, 4, 11)},
+		{"monthly across the year end", subscription.CadenceMonthly, date(2026, 12, 5), date(2027, 1, 2)},
+		{"a fifth weekday counts as the fourth", subscription.CadenceMonthly, date(2026, 5, 30), date(2026, 6, 27)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cadence.After(tt.from)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.from.Weekday(), got.Weekday())
+		})
+	}
+}
+
+func TestNewCadence(t *testing.T) {
+	_, err := subscription.NewCadence("daily")
+	assert.ErrorIs(t, err, subscription.ErrInvalidCadence)
+
+	c, err := subscription.NewCadence("fortnightly")
+	assert.NoError(t, err)
+	assert.Equal(t, subscription.CadenceFortnightly, c)
+}
@@ -0,0 +1,212 @@
+package tax
+
+import (
+	"slices"
+	"strings"
+)
+
+// Mode says whether the amounts given to the calculator already include tax.
+type Mode string
+
+const (
+	ModeInclusive Mode = "inclusive"
+	ModeExclusive Mode = "exclusive"
+)
+
+// NewMode parses a pricing mode.
+func NewMode(raw string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(raw))); m {
+	case ModeInclusive, ModeExclusive:
+		return m, nil
+	default:
+		return "", ErrInvalidMode
+	}
+}
+
+// Rounding says where tax is rounded to the cent: on every line, or once
+// per rate over the whole invoice with the result shared back over its
+// lines.
+type Rounding string
+
+const (
+	RoundPerLine    Rounding = "line"
+	RoundPerInvoice Rounding = "invoice"
+)
+
+// NewRounding parses a rounding rule.
+func NewRounding(raw string) (Rounding, error) {
+	switch r := Rounding(strings.ToLower(strings.TrimSpace(raw))); r {
+	case RoundPerLine, RoundPerInvoice:
+		return r, nil
+	default:
+		return "", ErrInvalidRounding
+	}
+}
+
+// Item is one amount to tax: a product line or a charge such as delivery.
+// AmountCents includes tax or not according to the calculator's mode.
+type Item struct {
+	Category    Category
+	RateBP      int
+	AmountCents int64
+}
+
+// Line is an item split into its net amount and tax.
+type Line struct {
+	Category   Category
+	RateBP     int
+	NetCents   int64
+	VATCents   int64
+	GrossCents int64
+}
+
+// Portion is the part of the line that grossCents pays for, with the line's
+// own tax shared in proportion. Refunds use it so that crediting a whole
+// line gives back exactly the tax that was charged.
+func (l Line) Portion(grossCents int64) Line {
+	p := Line{Category: l.Category, RateBP: l.RateBP, GrossCents: grossCents}
+	if l.GrossCents > 0 {
+		p.VATCents = (l.VATCents*grossCents + l.GrossCents/2) / l.GrossCents
+	}
+	p.NetCents = grossCents - p.VATCents
+	return p
+}
+
+// Band totals the lines taxed at one rate.
+type Band struct {
+	RateBP     int
+	NetCents   int64
+	VATCents   int64
+	GrossCents int64
+}
+
+// Result is what the calculator makes of a set of items. Lines are in the
+// order the items were given; bands are sorted by rate.
+type Result struct {
+	Lines      []Line
+	Bands      []Band
+	NetCents   int64
+	VATCents   int64
+	GrossCents int64
+}
+
+// Calculator splits amounts into net and tax. Carts, orders and invoices
+// are all priced with the shop's one calculator so they always agree.
+type Calculator struct {
+	Mode     Mode
+	Rounding Rounding
+}
+
+// Calculate taxes items at their rates.
+func (c Calculator) Calculate(items []Item) (Result, error) {
+	for _, it := range items {
+		if it.RateBP < 0 || it.RateBP > 10000 {
+			return Result{}, ErrInvalidRate
+		}
+		if it.AmountCents < 0 {
+			return Result{}, ErrNegativeAmount
+		}
+	}
+
+	vat := make([]int64, len(items))
+	if c.Rounding == RoundPerInvoice {
+		byRate := make(map[int][]int)
+		for i, it := range items {
+			byRate[it.RateBP] = append(byRate[it.RateBP], i)
+		}
+		for rate, idx := range byRate {
+			amounts := make([]int64, len(idx))
+			var sum int64
+			for j, i := range idx {
+				amounts[j] = items[i].AmountCents
+				sum += amounts[j]
+			}
+			for j, share := range Allocate(c.taxOn(sum, rate), amounts) {
+				vat[idx[j]] = share
+			}
+		}
+	} else {
+		for i, it := range items {
+			vat[i] = c.taxOn(it.AmountCents, it.RateBP)
+		}
+	}
+
+	res := Result{Lines: make([]Line, 0, len(items))}
+	bands := make(map[int]*Band)
+	for i, it := range items {
+		l := Line{Category: it.Category, RateBP: it.RateBP, VATCents: vat[i]}
+		if c.Mode == ModeExclusive {
+			l.NetCents, l.GrossCents = it.AmountCents, it.AmountCents+vat[i]
+		} else {
+			l.NetCents, l.GrossCents = it.AmountCents-vat[i], it.AmountCents
+		}
+		res.Lines = append(res.Lines, l)
+
+		b, ok := bands[l.RateBP]
+		if !ok {
+			b = &Band{RateBP: l.RateBP}
+			bands[l.RateBP] = b
+		}
+		b.NetCents += l.NetCents
+		b.VATCents += l.VATCents
+		b.GrossCents += l.GrossCents
+		res.NetCents += l.NetCents
+		res.VATCents += l.VATCents
+		res.GrossCents += l.GrossCents
+	}
+	for _, b := range bands {
+		res.Bands = append(res.Bands, *b)
+	}
+	slices.SortFunc(res.Bands, func(a, b Band) int { return a.RateBP - b.RateBP })
+	return res, nil
+}
+
+// taxOn is the tax on an amount at rateBP, rounded half up: the tax
+// contained in it when prices include tax, or the tax added to it when they
+// do not.
+func (c Calculator) taxOn(amountCents int64, rateBP int) int64 {
+	if c.Mode == ModeExclusive {
+		return (amountCents*int64(rateBP) + 5000) / 10000
+	}
+	divisor := int64(10000 + rateBP)
+	return (amountCents*int64(rateBP) + divisor/2) / divisor
+}
+
+// Allocate splits amount across weights in proportion, giving leftover
+// cents to the largest remainders so the parts always add up to amount.
+func Allocate(amount int64, weights []int64) []int64 {
+	parts := make([]int64, len(weights))
+	var sum int64
+	for _, w := range weights {
+		sum += w
+	}
+	if sum <= 0 {
+		return parts
+	}
+
+	remainders := make([]int64, len(weights))
+	allocated := int64(0)
+	for i, w := range weights {
+		parts[i] = amount * w / sum
+		remainders[i] = amount * w % sum
+		allocated += parts[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case remainders[a] > remainders[b]:
+			return -1
+		case remainders[a] < remainders[b]:
+			return 1
+		}
+		return 0
+	})
+	for i := 0; allocated < amount; i++ {
+		parts[order[i%len(order)]]++
+		allocated++
+	}
+	return parts
+}
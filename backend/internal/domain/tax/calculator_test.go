@@ -0,0 +1,105 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Three 99p lines at 20%: per line each contains 17p of VAT (51p in all),
+// while the 2.97 total contains 50p.
+var threeSmallLines = []tax.Item{
+	{Category: tax.CategoryPreparedFood, RateBP: 2000, AmountCents: 99},
+	{Category: tax.CategoryPreparedFood, RateBP: 2000, AmountCents: 99},
+	{Category: tax.CategoryPreparedFood, RateBP: 2000, AmountCents: 99},
+}
+
+func TestCalculator_InclusivePerLine(t *testing.T) {
+	c := tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}
+
+	res, err := c.Calculate(threeSmallLines)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(297), res.GrossCents)
+	assert.Equal(t, int64(51), res.VATCents)
+	assert.Equal(t, int64(246), res.NetCents)
+	assert.Equal(t, tax.Line{Category: tax.CategoryPreparedFood, RateBP: 2000, NetCents: 82, VATCents: 17, GrossCents: 99}, res.Lines[0])
+}
+
+func TestCalculator_InclusivePerInvoice(t *testing.T) {
+	c := tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerInvoice}
+
+	res, err := c.Calculate(threeSmallLines)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(297), res.GrossCents)
+	assert.Equal(t, int64(50), res.VATCents)
+	var lineVAT int64
+	for _, l := range res.Lines {
+		lineVAT += l.VATCents
+		assert.Equal(t, l.GrossCents, l.NetCents+l.VATCents)
+	}
+	assert.Equal(t, res.VATCents, lineVAT, "lines share the invoice's tax exactly")
+}
+
+func TestCalculator_Exclusive(t *testing.T) {
+	items := []tax.Item{
+		{Category: tax.CategoryFreshMeat, RateBP: 0, AmountCents: 2000},
+		{Category: tax.CategoryPreparedFood, RateBP: 2000, AmountCents: 1250},
+		{Category: tax.CategoryDelivery, RateBP: 2000, AmountCents: 399},
+	}
+
+	perLine, err := tax.Calculator{Mode: tax.ModeExclusive, Rounding: tax.RoundPerLine}.Calculate(items)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3649), perLine.NetCents)
+	assert.Equal(t, int64(250+80), perLine.VATCents)
+	assert.Equal(t, int64(1500), perLine.Lines[1].GrossCents)
+	require.Len(t, perLine.Bands, 2)
+	assert.Equal(t, tax.Band{RateBP: 2000, NetCents: 1649, VATCents: 330, GrossCents: 1979}, perLine.Bands[1])
+
+	perInvoice, err := tax.Calculator{Mode: tax.ModeExclusive, Rounding: tax.RoundPerInvoice}.Calculate(items)
+	require.NoError(t, err)
+	assert.Equal(t, int64(330), perInvoice.VATCents)
+	assert.Equal(t, int64(3979), perInvoice.GrossCents)
+}
+
+func TestCalculator_InvalidItems_ReturnError(t *testing.T) {
+	c := tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}
+
+	_, err := c.Calculate([]tax.Item{{RateBP: 10001, AmountCents: 1}})
+	assert.ErrorIs(t, err, tax.ErrInvalidRate)
+	_, err = c.Calculate([]tax.Item{{RateBP: 2000, AmountCents: -1}})
+	assert.ErrorIs(t, err, tax.ErrNegativeAmount)
+}
+
+func TestLine_Portion(t *testing.T) {
+	l := tax.Line{RateBP: 2000, NetCents: 1250, VATCents: 250, GrossCents: 1500}
+
+	assert.Equal(t, l, l.Portion(1500), "the whole line credits back exactly its tax")
+	half := l.Portion(750)
+	assert.Equal(t, int64(125), half.VATCents)
+	assert.Equal(t, int64(625), half.NetCents)
+}
+
+func TestParseModeAndRounding(t *testing.T) {
+	m, err := tax.NewMode(" Exclusive ")
+	require.NoError(t, err)
+	assert.Equal(t, tax.ModeExclusive, m)
+	_, err = tax.NewMode("gross")
+	assert.ErrorIs(t, err, tax.ErrInvalidMode)
+
+	r, err := tax.NewRounding("invoice")
+	require.NoError(t, err)
+	assert.Equal(t, tax.RoundPerInvoice, r)
+	_, err = tax.NewRounding("bankers")
+	assert.ErrorIs(t, err, tax.ErrInvalidRounding)
+}
+
+func TestAllocate_PartsAddUp(t *testing.T) {
+	assert.Equal(t, []int64{34, 33, 33}, tax.Allocate(100, []int64{1, 1, 1}))
+	assert.Equal(t, []int64{2000, 1500}, tax.Allocate(3500, []int64{2000, 1500}))
+	assert.Equal(t, []int64{667, 333}, tax.Allocate(1000, []int64{2, 1}))
+	assert.Equal(t, []int64{0, 0}, tax.Allocate(100, []int64{0, 0}))
+}
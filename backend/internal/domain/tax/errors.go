@@ -0,0 +1,17 @@
+package tax
+
+import "errors"
+
+var (
+	ErrInvalidCategory      = errors.New("tax category must be lowercase letters, digits and underscores")
+	ErrInvalidRate          = errors.New("tax rate must be between 0 and 10000 basis points")
+	ErrInvalidMode          = errors.New("pricing mode must be inclusive or exclusive")
+	ErrInvalidRounding      = errors.New("rounding must be line or invoice")
+	ErrNegativeAmount       = errors.New("taxable amounts must not be negative")
+	ErrNoRate               = errors.New("no tax rate is in effect for the category")
+	ErrDuplicateRate        = errors.New("a rate for the category already takes effect on that date")
+	ErrUnclassifiedProduct  = errors.New("product has no tax category")
+	ErrMissingEffectiveDate = errors.New("rate must have an effective date")
+	ErrInvalidDate          = errors.New("effective date must be YYYY-MM-DD")
+	ErrManageNotAllowed     = errors.New("only managers may change tax rates and product tax categories")
+)
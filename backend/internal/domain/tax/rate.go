@@ -0,0 +1,115 @@
+package tax
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category groups products and charges that are taxed alike, such as fresh
+// meat or delivery.
+type Category string
+
+// The categories the shop starts with. Others can be added by giving them a
+// rate.
+const (
+	CategoryFreshMeat    Category = "fresh_meat"
+	CategoryPreparedFood Category = "prepared_food"
+	CategoryDelivery     Category = "delivery"
+)
+
+// NewCategory parses a category code.
+func NewCategory(raw string) (Category, error) {
+	c := strings.ToLower(strings.TrimSpace(raw))
+	if c == "" || strings.IndexFunc(c, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_'
+	}) >= 0 {
+		return "", ErrInvalidCategory
+	}
+	return Category(c), nil
+}
+
+// Rate is the VAT rate, in basis points, charged on a category from a date
+// onwards. A rate stays in effect until a later one for the same category
+// takes over, so rate changes are scheduled by adding a rate in advance.
+type Rate struct {
+	id            uuid.UUID
+	category      Category
+	rateBP        int
+	effectiveFrom time.Time
+	createdAt     time.Time
+}
+
+// NewRate validates and creates a rate. Only the date of effectiveFrom is
+// kept.
+func NewRate(id uuid.UUID, category Category, rateBP int, effectiveFrom, now time.Time) (*Rate, error) {
+	if _, err := NewCategory(string(category)); err != nil {
+		return nil, err
+	}
+	if rateBP < 0 || rateBP > 10000 {
+		return nil, ErrInvalidRate
+	}
+	if effectiveFrom.IsZero() {
+		return nil, ErrMissingEffectiveDate
+	}
+	return &Rate{
+		id:            id,
+		category:      category,
+		rateBP:        rateBP,
+		effectiveFrom: dateOf(effectiveFrom),
+		createdAt:     now,
+	}, nil
+}
+
+// ReconstructRate reconstructs a Rate from persistence without validation.
+func ReconstructRate(id uuid.UUID, category Category, rateBP int, effectiveFrom, createdAt time.Time) *Rate {
+	return &Rate{id: id, category: category, rateBP: rateBP, effectiveFrom: effectiveFrom, createdAt: createdAt}
+}
+
+func (r *Rate) ID() uuid.UUID            { return r.id }
+func (r *Rate) Category() Category       { return r.category }
+func (r *Rate) RateBP() int              { return r.rateBP }
+func (r *Rate) EffectiveFrom() time.Time { return r.effectiveFrom }
+func (r *Rate) CreatedAt() time.Time     { return r.createdAt }
+
+// Table is every rate the shop has configured, past and future.
+type Table []*Rate
+
+// RateFor returns the rate in effect for a category on the calendar day of
+// on, in on's location.
+func (t Table) RateFor(category Category, on time.Time) (int, error) {
+	day := dateOf(on)
+	var found *Rate
+	for _, r := range t {
+		if r.category != category || r.effectiveFrom.After(day) {
+			continue
+		}
+		if found == nil || r.effectiveFrom.After(found.effectiveFrom) {
+			found = r
+		}
+	}
+	if found == nil {
+		return 0, ErrNoRate
+	}
+	return found.rateBP, nil
+}
+
+// Categories returns every category with a rate, sorted.
+func (t Table) Categories() []Category {
+	var cs []Category
+	for _, r := range t {
+		if !slices.Contains(cs, r.category) {
+			cs = append(cs, r.category)
+		}
+	}
+	slices.Sort(cs)
+	return cs
+}
+
+// dateOf is midnight UTC on t's calendar day in t's own location.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,89 @@
+package tax_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRate(t *testing.T, category tax.Category, rateBP int, from string) *tax.Rate {
+	t.Helper()
+	day, err := time.Parse(time.DateOnly, from)
+	require.NoError(t, err)
+	r, err := tax.NewRate(uuid.New(), category, rateBP, day, time.Now())
+	require.NoError(t, err)
+	return r
+}
+
+func TestNewCategory(t *testing.T) {
+	c, err := tax.NewCategory(" Fresh_Meat ")
+	require.NoError(t, err)
+	assert.Equal(t, tax.CategoryFreshMeat, c)
+
+	for _, raw := range []string{"", "fresh meat", "fresh-meat", "viande_fraîche"} {
+		_, err := tax.NewCategory(raw)
+		assert.ErrorIs(t, err, tax.ErrInvalidCategory, raw)
+	}
+}
+
+func TestNewRate_Validation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		category tax.Category
+		rateBP   int
+		from     time.Time
+		want     error
+	}{
+		{"bad category", "Fresh Meat", 0, now, tax.ErrInvalidCategory},
+		{"negative rate", tax.CategoryDelivery, -1, now, tax.ErrInvalidRate},
+		{"rate over 100%", tax.CategoryDelivery, 10001, now, tax.ErrInvalidRate},
+		{"no date", tax.CategoryDelivery, 2000, time.Time{}, tax.ErrMissingEffectiveDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tax.NewRate(uuid.New(), tt.category, tt.rateBP, tt.from, now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestTable_RateFor(t *testing.T) {
+	table := tax.Table{
+		mustRate(t, tax.CategoryPreparedFood, 2000, "2020-01-01"),
+		mustRate(t, tax.CategoryPreparedFood, 500, "2020-07-15"),
+		mustRate(t, tax.CategoryPreparedFood, 2000, "2022-04-01"),
+		mustRate(t, tax.CategoryFreshMeat, 0, "2020-01-01"),
+	}
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		category tax.Category
+		on       time.Time
+		want     int
+	}{
+		{"before a change", tax.CategoryPreparedFood, time.Date(2020, 7, 14, 23, 0, 0, 0, time.UTC), 2000},
+		{"local date decides", tax.CategoryPreparedFood, time.Date(2020, 7, 14, 23, 30, 0, 0, time.UTC).In(london), 500},
+		{"on the day of a change", tax.CategoryPreparedFood, time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), 2000},
+		{"other category", tax.CategoryFreshMeat, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.RateFor(tt.category, tt.on)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = table.RateFor(tax.CategoryDelivery, time.Now())
+	assert.ErrorIs(t, err, tax.ErrNoRate)
+	_, err = table.RateFor(tax.CategoryFreshMeat, time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, tax.ErrNoRate)
+	assert.Equal(t, []tax.Category{tax.CategoryFreshMeat, tax.CategoryPreparedFood}, table.Categories())
+}
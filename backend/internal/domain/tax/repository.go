@@ -0,0 +1,24 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RateRepository provides access to the rate table.
+type RateRepository interface {
+	// Save inserts a rate. A second rate for the same category and date
+	// returns ErrDuplicateRate.
+	Save(ctx context.Context, r *Rate) error
+	// FindAll returns every rate, ordered by category and date.
+	FindAll(ctx context.Context) (Table, error)
+}
+
+// ProductRepository records which category each product is taxed under.
+type ProductRepository interface {
+	// AssignCategory sets or replaces a product's category.
+	AssignCategory(ctx context.Context, productID uuid.UUID, category Category) error
+	// FindCategories returns the categories of those products that have one.
+	FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]Category, error)
+}
@@ -0,0 +1,133 @@
+package traceability
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Expiry is where a certificate is in its validity period.
+type Expiry string
+
+const (
+	ExpiryNotYetValid Expiry = "not_yet_valid"
+	ExpiryValid       Expiry = "valid"
+	ExpiryExpiring    Expiry = "expiring"
+	ExpiryExpired     Expiry = "expired"
+)
+
+// Certificate is a halal slaughter certificate issued to a slaughterhouse by
+// a certification body. Validity dates are inclusive calendar dates.
+type Certificate struct {
+	id             uuid.UUID
+	issuer         string
+	number         string
+	slaughterhouse string
+	validFrom      time.Time
+	validUntil     time.Time
+	documentURL    string
+	createdAt      time.Time
+}
+
+// NewCertificate validates a certificate. documentURL links to the scanned
+// certificate and may be empty.
+func NewCertificate(
+	id uuid.UUID,
+	issuer, number, slaughterhouse string,
+	validFrom, validUntil time.Time,
+	documentURL string,
+	now time.Time,
+) (*Certificate, error) {
+	issuer = strings.TrimSpace(issuer)
+	if issuer == "" {
+		return nil, ErrEmptyIssuer
+	}
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return nil, ErrEmptyCertificateNumber
+	}
+	slaughterhouse = strings.TrimSpace(slaughterhouse)
+	if slaughterhouse == "" {
+		return nil, ErrEmptySlaughterhouse
+	}
+	if validUntil.Before(validFrom) {
+		return nil, ErrInvalidValidity
+	}
+	documentURL = strings.TrimSpace(documentURL)
+	if documentURL != "" {
+		u, err := url.Parse(documentURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, ErrInvalidDocumentURL
+		}
+	}
+
+	return &Certificate{
+		id:             id,
+		issuer:         issuer,
+		number:         number,
+		slaughterhouse: slaughterhouse,
+		validFrom:      validFrom,
+		validUntil:     validUntil,
+		documentURL:    documentURL,
+		createdAt:      now,
+	}, nil
+}
+
+// ReconstructCertificate reconstructs a Certificate from persistence without validation.
+func ReconstructCertificate(
+	id uuid.UUID,
+	issuer, number, slaughterhouse string,
+	validFrom, validUntil time.Time,
+	documentURL string,
+	createdAt time.Time,
+) *Certificate {
+	return &Certificate{
+		id:             id,
+		issuer:         issuer,
+		number:         number,
+		slaughterhouse: slaughterhouse,
+		validFrom:      validFrom,
+		validUntil:     validUntil,
+		documentURL:    documentURL,
+		createdAt:      createdAt,
+	}
+}
+
+func (c *Certificate) ID() uuid.UUID          { return c.id }
+func (c *Certificate) Issuer() string         { return c.issuer }
+func (c *Certificate) Number() string         { return c.number }
+func (c *Certificate) Slaughterhouse() string { return c.slaughterhouse }
+func (c *Certificate) ValidFrom() time.Time   { return c.validFrom }
+func (c *Certificate) ValidUntil() time.Time  { return c.validUntil }
+func (c *Certificate) DocumentURL() string    { return c.documentURL }
+func (c *Certificate) CreatedAt() time.Time   { return c.createdAt }
+
+// Covers reports whether the certificate was valid on the given date.
+func (c *Certificate) Covers(date time.Time) bool {
+	d := dateOf(date)
+	return !d.Before(c.validFrom) && !d.After(c.validUntil)
+}
+
+// Expiry reports the certificate's status on today's date. A certificate that
+// lapses within the warning window is reported as expiring.
+func (c *Certificate) Expiry(today time.Time, warning time.Duration) Expiry {
+	d := dateOf(today)
+	switch {
+	case d.Before(c.validFrom):
+		return ExpiryNotYetValid
+	case d.After(c.validUntil):
+		return ExpiryExpired
+	case !d.Add(warning).Before(c.validUntil):
+		return ExpiryExpiring
+	default:
+		return ExpiryValid
+	}
+}
+
+// dateOf truncates t to midnight UTC on its calendar date, matching how
+// dates are read back from the database.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
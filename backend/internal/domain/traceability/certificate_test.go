@@ -0,0 +1,97 @@
+package traceability_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newCertificate(t *testing.T) *traceability.Certificate {
+	t.Helper()
+	c, err := traceability.NewCertificate(uuid.New(), "Halal Monitoring Committee", "HMC-2026-0193",
+		"Hill Farm Abattoir", date(2026, 1, 1), date(2026, 12, 31), "https://files.example.com/hmc-0193.pdf", time.Now())
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewCertificate_InvalidInputs_ReturnError(t *testing.T) {
+	tests := []struct {
+		name           string
+		issuer         string
+		number         string
+		slaughterhouse string
+		until          time.Time
+		document       string
+		want           error
+	}{
+		{"empty issuer", " ", "N", "S", date(2026, 12, 31), "", traceability.ErrEmptyIssuer},
+		{"empty number", "I", "", "S", date(2026, 12, 31), "", traceability.ErrEmptyCertificateNumber},
+		{"empty slaughterhouse", "I", "N", "", date(2026, 12, 31), "", traceability.ErrEmptySlaughterhouse},
+		{"expires before valid", "I", "N", "S", date(2025, 12, 31), "", traceability.ErrInvalidValidity},
+		{"relative document", "I", "N", "S", date(2026, 12, 31), "/scans/1.pdf", traceability.ErrInvalidDocumentURL},
+		{"ftp document", "I", "N", "S", date(2026, 12, 31), "ftp://files/1.pdf", traceability.ErrInvalidDocumentURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := traceability.NewCertificate(uuid.New(), tt.issuer, tt.number, tt.slaughterhouse,
+				date(2026, 1, 1), tt.until, tt.document, time.Now())
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestCertificate_Covers_IsInclusive(t *testing.T) {
+	c := newCertificate(t)
+
+	assert.True(t, c.Covers(date(2026, 1, 1)))
+	assert.True(t, c.Covers(time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, c.Covers(date(2025, 12, 31)))
+	assert.False(t, c.Covers(date(2027, 1, 1)))
+}
+
+func TestCertificate_Expiry(t *testing.T) {
+	c := newCertificate(t)
+	warning := 30 * 24 * time.Hour
+
+	assert.Equal(t, traceability.ExpiryNotYetValid, c.Expiry(date(2025, 12, 1), warning))
+	assert.Equal(t, traceability.ExpiryValid, c.Expiry(date(2026, 11, 30), warning))
+	assert.Equal(t, traceability.ExpiryExpiring, c.Expiry(date(2026, 12, 1), warning))
+	assert.Equal(t, traceability.ExpiryExpiring, c.Expiry(date(2026, 12, 31), warning))
+	assert.Equal(t, traceability.ExpiryExpired, c.Expiry(date(2027, 1, 1), warning))
+}
+
+func TestNewOrigin(t *testing.T) {
+	now := time.Now()
+
+	o, err := traceability.NewOrigin(uuid.New(), nil, " Green Valley Farm ", date(2026, 10, 12), "HMC-2026-0193", now)
+	require.NoError(t, err)
+	assert.Equal(t, "Green Valley Farm", o.Farm())
+	assert.Nil(t, o.CarcassID())
+
+	_, err = traceability.NewOrigin(uuid.New(), nil, "", date(2026, 10, 12), "HMC", now)
+	assert.ErrorIs(t, err, traceability.ErrEmptyFarm)
+	_, err = traceability.NewOrigin(uuid.New(), nil, "Farm", date(2026, 10, 12), " ", now)
+	assert.ErrorIs(t, err, traceability.ErrEmptyCertificateNumber)
+	_, err = traceability.NewOrigin(uuid.New(), nil, "Farm", now.AddDate(0, 0, 1), "HMC", now)
+	assert.ErrorIs(t, err, traceability.ErrSlaughterDateInFuture)
+}
+
+func TestTrace_HalalVerified(t *testing.T) {
+	c := newCertificate(t)
+	inDate, err := traceability.NewOrigin(uuid.New(), nil, "Farm", date(2026, 10, 12), c.Number(), time.Now())
+	require.NoError(t, err)
+	outOfDate, err := traceability.NewOrigin(uuid.New(), nil, "Farm", date(2025, 10, 12), c.Number(), time.Now())
+	require.NoError(t, err)
+
+	assert.True(t, traceability.Trace{Origin: inDate, Certificate: c}.HalalVerified())
+	assert.False(t, traceability.Trace{Origin: outOfDate, Certificate: c}.HalalVerified())
+	assert.False(t, traceability.Trace{Origin: inDate}.HalalVerified())
+}
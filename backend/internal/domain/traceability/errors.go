@@ -0,0 +1,17 @@
+package traceability
+
+import "errors"
+
+var (
+	ErrEmptyIssuer            = errors.New("certificate issuer must not be empty")
+	ErrEmptyCertificateNumber = errors.New("certificate number must not be empty")
+	ErrEmptySlaughterhouse    = errors.New("slaughterhouse must not be empty")
+	ErrInvalidValidity        = errors.New("certificate must not expire before it becomes valid")
+	ErrInvalidDocumentURL     = errors.New("document URL must be an absolute http or https URL")
+	ErrEmptyFarm              = errors.New("farm must not be empty")
+	ErrSlaughterDateInFuture  = errors.New("slaughter date must not be in the future")
+	ErrInvalidDate            = errors.New("date must be in YYYY-MM-DD format")
+	ErrDuplicateCertificate   = errors.New("a certificate with this number already exists")
+	ErrOriginAlreadyRecorded  = errors.New("the origin of this lot has already been recorded")
+	ErrTraceNotFound          = errors.New("no traceable lot with this code")
+)
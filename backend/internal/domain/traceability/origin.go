@@ -0,0 +1,79 @@
+package traceability
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Origin records where the meat in an inventory lot came from: the farm that
+// supplied the animal, when it was slaughtered and the number of the halal
+// certificate it was slaughtered under. The slaughterhouse is the one the
+// certificate was issued to. Lots cut from a carcass also link to it.
+type Origin struct {
+	lotID             uuid.UUID
+	carcassID         *uuid.UUID
+	farm              string
+	slaughteredOn     time.Time
+	certificateNumber string
+	recordedAt        time.Time
+}
+
+// NewOrigin validates the origin of a lot. carcassID is nil for lots that
+// were bought in already cut.
+func NewOrigin(
+	lotID uuid.UUID,
+	carcassID *uuid.UUID,
+	farm string,
+	slaughteredOn time.Time,
+	certificateNumber string,
+	now time.Time,
+) (*Origin, error) {
+	farm = strings.TrimSpace(farm)
+	if farm == "" {
+		return nil, ErrEmptyFarm
+	}
+	certificateNumber = strings.TrimSpace(certificateNumber)
+	if certificateNumber == "" {
+		return nil, ErrEmptyCertificateNumber
+	}
+	if slaughteredOn.After(now) {
+		return nil, ErrSlaughterDateInFuture
+	}
+
+	return &Origin{
+		lotID:             lotID,
+		carcassID:         carcassID,
+		farm:              farm,
+		slaughteredOn:     slaughteredOn,
+		certificateNumber: certificateNumber,
+		recordedAt:        now,
+	}, nil
+}
+
+// ReconstructOrigin reconstructs an Origin from persistence without validation.
+func ReconstructOrigin(
+	lotID uuid.UUID,
+	carcassID *uuid.UUID,
+	farm string,
+	slaughteredOn time.Time,
+	certificateNumber string,
+	recordedAt time.Time,
+) *Origin {
+	return &Origin{
+		lotID:             lotID,
+		carcassID:         carcassID,
+		farm:              farm,
+		slaughteredOn:     slaughteredOn,
+		certificateNumber: certificateNumber,
+		recordedAt:        recordedAt,
+	}
+}
+
+func (o *Origin) LotID() uuid.UUID          { return o.lotID }
+func (o *Origin) CarcassID() *uuid.UUID     { return o.carcassID }
+func (o *Origin) Farm() string              { return o.farm }
+func (o *Origin) SlaughteredOn() time.Time  { return o.slaughteredOn }
+func (o *Origin) CertificateNumber() string { return o.certificateNumber }
+func (o *Origin) RecordedAt() time.Time     { return o.recordedAt }
@@ -0,0 +1,31 @@
+package traceability
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CertificateRepository provides access to halal certificates.
+type CertificateRepository interface {
+	// Save stores a new certificate. It returns ErrDuplicateCertificate if
+	// the number is already registered.
+	Save(ctx context.Context, c *Certificate) error
+	// FindAll returns certificates, soonest to expire first. With
+	// expiringBy set, only certificates that lapse on or before that date
+	// are returned.
+	FindAll(ctx context.Context, expiringBy *time.Time) ([]*Certificate, error)
+}
+
+// OriginRepository provides access to lot origins and the traces built from them.
+type OriginRepository interface {
+	// SaveOrigin stores the origin of a lot. It returns
+	// ErrOriginAlreadyRecorded if the lot already has one.
+	SaveOrigin(ctx context.Context, o *Origin) error
+	// FindTraces returns the traces of every lot with the given code that
+	// has a recorded origin.
+	FindTraces(ctx context.Context, lotCode string) ([]Trace, error)
+	// FindConsumedLots returns the lots each line of an order was sold from.
+	FindConsumedLots(ctx context.Context, orderID uuid.UUID) ([]ConsumedLot, error)
+}
@@ -0,0 +1,38 @@
+package traceability
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trace is the farm-to-counter record of one inventory lot, as shown to
+// customers who look up the lot code printed on a label.
+type Trace struct {
+	LotID      uuid.UUID
+	LotCode    string
+	ProductID  uuid.UUID
+	ReceivedAt time.Time
+	ExpiresOn  *time.Time
+	Origin     *Origin
+	// Species is set for lots cut from a carcass.
+	Species *string
+	// Certificate is nil when the certificate number on the origin has not
+	// been registered.
+	Certificate *Certificate
+}
+
+// HalalVerified reports whether the lot's slaughter date falls within the
+// validity of a registered halal certificate.
+func (t Trace) HalalVerified() bool {
+	return t.Certificate != nil && t.Certificate.Covers(t.Origin.SlaughteredOn())
+}
+
+// ConsumedLot is the weight of one lot that went into a line of an order.
+type ConsumedLot struct {
+	LineID    uuid.UUID
+	ProductID uuid.UUID
+	LotID     uuid.UUID
+	LotCode   string
+	Grams     int64
+}
@@ -0,0 +1,125 @@
+package e2e_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationBarcode_EncodeAndResolveWeighedPacks(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	// Step 1: Lamb mince is given PLU 123 at 12.99 a kilogram. The code
+	// cannot be given to a second product.
+	item := dto.PLUItemRequest{
+		Code:            123,
+		ProductID:       uuid.NewString(),
+		Description:     "Lamb mince",
+		PricePerKgCents: 1299,
+	}
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/plu-items", item, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var i dto.PLUItemResponse
+	parseJSON(t, resp, &i)
+	assert.True(t, i.Active)
+
+	dup := item
+	dup.ProductID = uuid.NewString()
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/plu-items", dup, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: A 1.25kg pack is labelled once with its weight and once with
+	// its price.
+	path := "/api/v1/admin/plu-items/" + i.ID + "/barcodes"
+	resp = ts.postJSONWithAuth(t, path, dto.EncodeBarcodeRequest{Grams: 1250, Measure: "weight"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var byWeight dto.BarcodeResponse
+	parseJSON(t, resp, &byWeight)
+	assert.Equal(t, "2000123012506", byWeight.Code)
+	assert.Equal(t, int64(1624), byWeight.PriceCents)
+
+	resp = ts.postJSONWithAuth(t, path, dto.EncodeBarcodeRequest{Grams: 1250, Measure: "price"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var byPrice dto.BarcodeResponse
+	parseJSON(t, resp, &byPrice)
+	assert.Equal(t, "2100123016242", byPrice.Code)
+	assert.Equal(t, "21IIIIIPPPPPC", byPrice.Layout)
+
+	resp = ts.postJSONWithAuth(t, path, dto.EncodeBarcodeRequest{Grams: 1250, Measure: "volume"}, adminToken)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: Scanning either label gives back the item, weight and price.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/"+byWeight.Code, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var scanned dto.BarcodeResponse
+	parseJSON(t, resp, &scanned)
+	assert.Equal(t, i.ID, scanned.Item.ID)
+	assert.Equal(t, int64(1250), scanned.Grams)
+	assert.Equal(t, int64(1624), scanned.PriceCents)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/"+byPrice.Code, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &scanned)
+	assert.Equal(t, "price", scanned.Measure)
+	assert.Equal(t, int64(1250), scanned.Grams)
+	assert.Equal(t, int64(1624), scanned.PriceCents)
+
+	// Step 4: A mistyped check digit and an unknown PLU are refused.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/2000123012507", nil, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/2000124012505", nil, adminToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: Once withdrawn the item is no longer labelled, but packs
+	// already on the shelf still scan.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/plu-items/"+i.ID, dto.UpdatePLUItemRequest{
+		Description:     "Lamb mince",
+		PricePerKgCents: 1299,
+		Active:          false,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, path, dto.EncodeBarcodeRequest{Grams: 500, Measure: "weight"}, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/"+byWeight.Code, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 6: The label is drawn as SVG and as PNG.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/"+byWeight.Code+"/image", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), "<svg")
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/"+byWeight.Code+"/image?format=png&module=3", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	assert.True(t, bytes.HasPrefix(body, []byte("\x89PNG")))
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/barcodes/"+byWeight.Code+"/image?format=gif", nil, adminToken)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -0,0 +1,151 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationBranch_ScopedAdminsAndPriceOverrides(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	ownerToken := ts.loginAdmin(t)
+	cashierToken := ts.loginAdminWithRole(t, "cashier")
+	managerToken := ts.loginAdminWithRole(t, "manager")
+
+	// Step 1: The owner opens two branches, and both are listed publicly.
+	createBranch := func(name string) dto.BranchResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/branches", dto.BranchRequest{
+			Name: name, Address: []string{"1 High Street", "London"}, Phone: "020 7946 0000",
+		}, ownerToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var b dto.BranchResponse
+		parseJSON(t, resp, &b)
+		return b
+	}
+	east, west := createBranch("East"), createBranch("West")
+
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/branches", dto.BranchRequest{
+		Name: "east", Address: []string{"2 High Street"},
+	}, ownerToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.get(t, "/api/v1/branches")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var public []dto.BranchResponse
+	parseJSON(t, resp, &public)
+	assert.Len(t, public, 2)
+
+	// Step 2: Each branch has a till and a lot of lamb chops, which are
+	// PLU 123 at 18.00 a kilogram but 20.00 at East.
+	productID := uuid.NewString()
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/plu-items", dto.PLUItemRequest{
+		Code: 123, ProductID: productID, Description: "Lamb chops", PricePerKgCents: 1800,
+	}, ownerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	tills := map[string]dto.TillResponse{}
+	for _, b := range []dto.BranchResponse{east, west} {
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+			ProductID: productID, BranchID: b.ID, Code: "LAMB-" + b.Name, Grams: 2000, ExpiresOn: "2026-11-02",
+		}, ownerToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/tills", dto.TillRequest{BranchID: b.ID, Name: "Till 1"}, ownerToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var till dto.TillResponse
+		parseJSON(t, resp, &till)
+		tills[b.ID] = till
+	}
+
+	pricePath := "/api/v1/admin/branches/" + east.ID + "/prices/" + productID
+	resp = ts.doJSONWithAuth(t, http.MethodPut, pricePath, dto.BranchPriceRequest{PricePerKgCents: 2000}, ownerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: The cashier and the manager are moved to East.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/admins", nil, ownerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var admins []dto.AdminResponse
+	parseJSON(t, resp, &admins)
+	ids := map[string]string{}
+	for _, a := range admins {
+		ids[a.Email] = a.ID
+	}
+	for _, email := range []string{"cashier@butchery.com", "manager@butchery.com"} {
+		resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/admins/"+ids[email]+"/branches", dto.AdminBranchesRequest{
+			BranchIDs: []string{east.ID},
+		}, ownerToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var a dto.AdminResponse
+		parseJSON(t, resp, &a)
+		assert.False(t, a.AllBranches)
+		assert.Equal(t, []string{east.ID}, a.BranchIDs)
+	}
+
+	// Step 4: The cashier sees only East's till, branch and stock; West's
+	// till cannot be opened and West cannot receive stock from them.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/pos/tills", nil, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var visible []dto.TillResponse
+	parseJSON(t, resp, &visible)
+	require.Len(t, visible, 1)
+	assert.Equal(t, tills[east.ID].ID, visible[0].ID)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/branches/"+west.ID, nil, cashierToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/stock?branch_id="+west.ID, nil, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var levels []dto.StockLevelResponse
+	parseJSON(t, resp, &levels)
+	assert.Empty(t, levels)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/tills/"+tills[west.ID].ID+"/sessions", dto.OpenSessionRequest{FloatCents: 5000}, cashierToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+		ProductID: productID, BranchID: west.ID, Code: "LAMB-02", Grams: 1000, ExpiresOn: "2026-11-02",
+	}, cashierToken)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: Weighed chops are rung up at East's price.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/tills/"+tills[east.ID].ID+"/sessions", dto.OpenSessionRequest{FloatCents: 5000}, cashierToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var session dto.TillSessionResponse
+	parseJSON(t, resp, &session)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/sessions/"+session.ID+"/sales", nil, cashierToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var sale dto.SaleResponse
+	parseJSON(t, resp, &sale)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/sales/"+sale.ID+"/lines", dto.SaleLineRequest{PLUCode: 123, Grams: 500}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sale)
+	assert.Equal(t, int64(1000), sale.TotalCents)
+
+	// Step 6: The East manager may not hand out every branch, and cannot
+	// see West to change its prices.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/admins/"+ids["cashier@butchery.com"]+"/branches", dto.AdminBranchesRequest{
+		AllBranches: true,
+	}, managerToken)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/branches/"+west.ID+"/prices/"+productID, dto.BranchPriceRequest{PricePerKgCents: 1500}, managerToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -0,0 +1,186 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationBusiness_OrderOnAccountWithApprovalAndStatement(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	branchID := uuid.NewString()
+	mince := uuid.NewString()
+	ts.setTaxCategory(t, adminToken, mince, "fresh_meat")
+
+	// Step 1: Admin opens an account on 30-day terms. Orders over 200.00
+	// need an approver.
+	threshold := int64(20000)
+	account := dto.BusinessAccountRequest{
+		Name:                   "The Grill House Ltd",
+		CompanyNumber:          "01234567",
+		VATNumber:              "GB123456789",
+		BillingEmail:           "accounts@grillhouse.example",
+		BillingAddress:         "1 High Street, London E1 6AN",
+		CreditLimitCents:       100000,
+		TermsDays:              0,
+		ApprovalThresholdCents: &threshold,
+	}
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/business-accounts", account, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	account.TermsDays = 30
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/business-accounts", account, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var a dto.BusinessAccountResponse
+	parseJSON(t, resp, &a)
+	assert.Equal(t, int64(100000), a.AvailableCreditCents)
+	path := "/api/v1/admin/business-accounts/" + a.ID
+
+	// Step 2: Mince is cheaper per kilogram from 10kg.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, path+"/prices", dto.SetBusinessPricesRequest{
+		Prices: []dto.BusinessPriceRequest{{
+			ProductID:   mince,
+			Description: "Beef mince, 20% fat",
+			Tiers:       []dto.BusinessTierRequest{{MinGrams: 0, PricePerKgCents: 900}, {MinGrams: 10000, PricePerKgCents: 800}},
+		}},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &a)
+	require.Len(t, a.Prices, 1)
+
+	// Step 3: A buyer and an approver are added to the account.
+	buyerToken := ts.registerAndLoginCustomer(t, "buyer@grillhouse.example")
+	approverToken := ts.registerAndLoginCustomer(t, "chef@grillhouse.example")
+	for email, role := range map[string]string{"buyer@grillhouse.example": "buyer", "chef@grillhouse.example": "approver"} {
+		var customerID uuid.UUID
+		err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, email).Scan(&customerID)
+		require.NoError(t, err)
+		resp = ts.doJSONWithAuth(t, http.MethodPut, path+"/members/"+customerID.String(),
+			dto.BusinessMemberRequest{Role: role}, adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, path+"/members", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var members []dto.BusinessMemberResponse
+	parseJSON(t, resp, &members)
+	require.Len(t, members, 2)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/business", nil, buyerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var membership dto.BusinessMembershipResponse
+	parseJSON(t, resp, &membership)
+	assert.Equal(t, "buyer", membership.Role)
+	assert.Equal(t, a.ID, membership.Account.ID)
+
+	order := func(token string, grams int64) *http.Response {
+		t.Helper()
+		return ts.postJSONWithAuth(t, "/api/v1/me/business/orders", dto.BusinessOrderRequest{
+			BranchID: branchID,
+			Lines:    []dto.BusinessOrderLineRequest{{ProductID: mince, Grams: grams}},
+		}, token)
+	}
+
+	// Step 4: A 12kg order at the 10kg tier is under the threshold and is
+	// charged straight away, recorded as a sale.
+	resp = order(buyerToken, 12000)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var small dto.BusinessOrderResponse
+	parseJSON(t, resp, &small)
+	assert.Equal(t, "placed", small.Status)
+	assert.Equal(t, int64(9600), small.TotalCents)
+	assert.Equal(t, int64(800), small.Lines[0].PricePerKgCents)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+small.OrderID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: A 30kg order waits for approval with credit held. The buyer
+	// cannot approve it; the approver can.
+	resp = order(buyerToken, 30000)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var big dto.BusinessOrderResponse
+	parseJSON(t, resp, &big)
+	assert.Equal(t, "pending_approval", big.Status)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/business/orders?status=pending_approval", nil, approverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var pending []dto.BusinessOrderResponse
+	parseJSON(t, resp, &pending)
+	require.Len(t, pending, 1)
+	assert.Equal(t, big.ID, pending[0].ID)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/business/orders/"+big.ID+"/approve", nil, buyerToken)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/business/orders/"+big.ID+"/approve", nil, approverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &big)
+	assert.Equal(t, "placed", big.Status)
+	require.NotNil(t, big.DecidedBy)
+
+	// Step 6: An order that would go over the credit limit is refused, and
+	// one the approver turns down releases its hold.
+	resp = order(buyerToken, 90000)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = order(buyerToken, 26000)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var rejected dto.BusinessOrderResponse
+	parseJSON(t, resp, &rejected)
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/business/orders/"+rejected.ID+"/reject",
+		dto.RejectBusinessOrderRequest{Reason: "Over this week's budget"}, approverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &rejected)
+	assert.Equal(t, "rejected", rejected.Status)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, path, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &a)
+	assert.Equal(t, int64(33600), a.BalanceCents)
+	assert.Equal(t, int64(0), a.HeldCents)
+	assert.Equal(t, int64(66400), a.AvailableCreditCents)
+
+	// Step 7: A payment comes in and the month's statement shows what is
+	// still owed, none of it yet due.
+	resp = ts.postJSONWithAuth(t, path+"/payments",
+		dto.BusinessPaymentRequest{AmountCents: 9600, Reference: "BACS GRILL-01"}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var payment dto.BusinessEntryResponse
+	parseJSON(t, resp, &payment)
+	assert.Equal(t, int64(-9600), payment.AmountCents)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/business/statement", nil, buyerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var statement dto.BusinessStatementResponse
+	parseJSON(t, resp, &statement)
+	assert.Equal(t, time.Now().UTC().Format("2006-01"), statement.Month)
+	require.Len(t, statement.Lines, 3)
+	assert.Equal(t, int64(33600), statement.ChargedCents)
+	assert.Equal(t, int64(9600), statement.PaidCents)
+	assert.Equal(t, int64(24000), statement.ClosingCents)
+	assert.Equal(t, int64(0), statement.OverdueCents)
+	require.NotNil(t, statement.Lines[0].DueOn)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, path+"/statement?month=2026-13", nil, adminToken)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 8: Customers who do not buy for a business have no account.
+	outsiderToken := ts.registerAndLoginCustomer(t, "retail@example.com")
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/business", nil, outsiderToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
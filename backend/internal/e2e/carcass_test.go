@@ -0,0 +1,116 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationCarcass_IntakeBreakdownAndYield(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	branchID := uuid.NewString()
+	legID, shoulderID, rackID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	// Step 1: Admin sets the lamb yield template.
+	resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/yield-templates/lamb", dto.SetYieldTemplateRequest{
+		YieldPercent: 68, TrimPercent: 20, WastePercent: 6,
+		Primals: []dto.PrimalYieldTemplate{
+			{Name: "Leg", ExpectedPercent: 30, ValueFactor: 130},
+			{Name: "Shoulder", ExpectedPercent: 25},
+		},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var tmpl dto.YieldTemplateResponse
+	parseJSON(t, resp, &tmpl)
+	require.Len(t, tmpl.Primals, 2)
+	assert.Equal(t, "leg", tmpl.Primals[0].Name)
+	assert.Equal(t, int64(100), tmpl.Primals[1].ValueFactor)
+
+	// Step 2: A lamb carcass is booked in; its tag cannot be reused.
+	intake := dto.RecordCarcassRequest{
+		BranchID: branchID, Tag: "KILL-07", Species: "lamb", HangingWeightGrams: 20000, CostCents: 16000,
+		Supplier: "Green Valley Farm", SlaughteredOn: "2026-10-12", HalalCertificateRef: "HMC-2026-0193",
+	}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/carcasses", intake, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var c dto.CarcassResponse
+	parseJSON(t, resp, &c)
+	assert.Equal(t, "received", c.Status)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/carcasses", intake, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: There is no yield report before the carcass is cut.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/carcasses/"+c.ID+"/yield", nil, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: The carcass is broken down; each cut becomes a lot.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/carcasses/"+c.ID+"/breakdown", dto.RecordBreakdownRequest{
+		Cuts: []dto.BreakdownCut{
+			{Primal: "leg", ProductID: legID, Grams: 6000, ExpiresOn: "2026-10-22"},
+			{Primal: "shoulder", ProductID: shoulderID, Grams: 5000},
+			{Primal: "rack", ProductID: rackID, Grams: 2000},
+		},
+		TrimGrams: 4000, WasteGrams: 1500,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var b dto.BreakdownResponse
+	parseJSON(t, resp, &b)
+	require.Len(t, b.Cuts, 3)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/stock?branch_id="+branchID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var levels []dto.StockLevelResponse
+	parseJSON(t, resp, &levels)
+	assert.Len(t, levels, 3)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/carcasses/"+c.ID+"/breakdown", dto.RecordBreakdownRequest{
+		Cuts: []dto.BreakdownCut{{Primal: "leg", ProductID: legID, Grams: 1000}},
+	}, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: The yield report compares the breakdown with the template and
+	// shares the carcass cost between the cuts.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/carcasses/"+c.ID+"/yield", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report dto.YieldReportResponse
+	parseJSON(t, resp, &report)
+	assert.Equal(t, int64(13000), report.SaleableGrams)
+	assert.Equal(t, int64(1500), report.ShrinkGrams)
+	assert.InDelta(t, 65.0, report.YieldPercent, 0.001)
+	assert.InDelta(t, 20.0, report.TrimPercent, 0.001)
+	require.NotNil(t, report.Expected)
+	assert.InDelta(t, 68.0, report.Expected.YieldPercent, 0.001)
+	assert.Equal(t, int64(1231), report.CostPerSaleableKgCents)
+
+	var total int64
+	for _, cut := range report.Cuts {
+		total += cut.CostCents
+	}
+	assert.Equal(t, int64(16000), total)
+	require.Len(t, report.Cuts, 3)
+	assert.Greater(t, report.Cuts[0].CostPerKgCents, report.Cuts[1].CostPerKgCents)
+
+	require.Len(t, report.Primals, 3)
+	assert.Equal(t, "rack", report.Primals[2].Name)
+	assert.Nil(t, report.Primals[2].ExpectedPercent)
+
+	// Step 6: The carcass is listed as broken down.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/carcasses?status=broken_down&species=lamb", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var carcasses []dto.CarcassResponse
+	parseJSON(t, resp, &carcasses)
+	require.Len(t, carcasses, 1)
+	assert.Equal(t, "KILL-07", carcasses[0].Tag)
+}
@@ -0,0 +1,112 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationDelivery_AddressBookAndZoneCheck(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "delivery@example.com")
+
+	// Step 1: Admin draws a zone around the shop and a cheaper postcode zone.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/delivery-zones", dto.DeliveryZoneRequest{
+		Name: "Around the shop",
+		Area: json.RawMessage(`{"type":"Polygon","coordinates":[
+			[[-0.08,51.51],[-0.06,51.51],[-0.06,51.53],[-0.08,51.53],[-0.08,51.51]]
+		]}`),
+		DeliveryFeeCents:  0,
+		MinimumOrderCents: 1500,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var polygonZone dto.DeliveryZoneResponse
+	parseJSON(t, resp, &polygonZone)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/delivery-zones", dto.DeliveryZoneRequest{
+		Name:              "North",
+		PostcodePrefixes:  []string{"n1"},
+		DeliveryFeeCents:  499,
+		MinimumOrderCents: 3000,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var postcodeZone dto.DeliveryZoneResponse
+	parseJSON(t, resp, &postcodeZone)
+	assert.Equal(t, []string{"N1"}, postcodeZone.PostcodePrefixes)
+
+	// Step 2: Customer saves two addresses; the first becomes the default.
+	lat, lng := 51.52, -0.07
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/addresses", dto.AddressRequest{
+		Label: "Home", Line1: "1 High Street", City: "London", Postcode: "E1 6AN",
+		Latitude: &lat, Longitude: &lng,
+	}, customerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var home dto.AddressResponse
+	parseJSON(t, resp, &home)
+	assert.True(t, home.IsDefault)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/addresses", dto.AddressRequest{
+		Label: "Work", Line1: "2 Upper Street", City: "London", Postcode: "n1 9gu",
+	}, customerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var work dto.AddressResponse
+	parseJSON(t, resp, &work)
+	assert.False(t, work.IsDefault)
+	assert.Equal(t, "N1 9GU", work.Postcode)
+
+	// Step 3: Each address resolves to its zone.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/delivery/quote?address_id="+home.ID, nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quote dto.DeliveryQuoteResponse
+	parseJSON(t, resp, &quote)
+	assert.Equal(t, polygonZone.ID, quote.ZoneID)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/delivery/quote?address_id="+work.ID+"&subtotal_cents=2000", nil, customerToken)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, "order total is below the zone's minimum order", parseError(t, resp))
+
+	// Step 4: Deactivating the zone stops delivery to that address.
+	active := false
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/delivery-zones/"+postcodeZone.ID, dto.DeliveryZoneRequest{
+		Name:             "North",
+		PostcodePrefixes: []string{"N1"},
+		DeliveryFeeCents: 499,
+		Active:           &active,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/delivery/quote?address_id="+work.ID, nil, customerToken)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: Switching the default and deleting it promotes the other address.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/me/addresses/"+work.ID+"/default", nil, customerToken)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodDelete, "/api/v1/me/addresses/"+work.ID, nil, customerToken)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/addresses", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var addresses []dto.AddressResponse
+	parseJSON(t, resp, &addresses)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, home.ID, addresses[0].ID)
+	assert.True(t, addresses[0].IsDefault)
+
+	// Step 6: Another customer cannot see the address.
+	otherToken := ts.registerAndLoginCustomer(t, "other@example.com")
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/delivery/quote?address_id="+home.ID, nil, otherToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
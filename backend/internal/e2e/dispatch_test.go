@@ -0,0 +1,284 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+// testPNG is enough of a PNG for its content type to be sniffed.
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+func TestIntegrationDispatch_RunsRoutesAndProofOfDelivery(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	token := ts.loginAdmin(t)
+	staffToken := ts.loginAdminWithRole(t, "staff")
+	slotDate := time.Now().UTC().AddDate(0, 0, 2)
+
+	// Step 1: A branch opens every day with a delivery slot into a zone
+	// around the shop.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/branches", dto.BranchRequest{
+		Name: "Whitechapel", Address: []string{"1 High Street", "London"},
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var b dto.BranchResponse
+	parseJSON(t, resp, &b)
+
+	days := make([]dto.OpeningHoursDay, 0, 7)
+	for weekday := 0; weekday < 7; weekday++ {
+		days = append(days, dto.OpeningHoursDay{Weekday: weekday, OpensAt: "08:00", ClosesAt: "18:00"})
+	}
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/branches/"+b.ID+"/opening-hours",
+		dto.SetOpeningHoursRequest{Days: days}, token)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/delivery-zones", dto.DeliveryZoneRequest{
+		Name: "Around the shop",
+		Area: json.RawMessage(`{"type":"Polygon","coordinates":[
+			[[-0.09,51.50],[-0.05,51.50],[-0.05,51.54],[-0.09,51.54],[-0.09,51.50]]
+		]}`),
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var zone dto.DeliveryZoneResponse
+	parseJSON(t, resp, &zone)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/fulfilment/slot-templates", dto.CreateSlotTemplateRequest{
+		BranchID: b.ID, ZoneID: &zone.ID, Method: "delivery", Weekday: int(slotDate.Weekday()),
+		StartsAt: "10:00", EndsAt: "12:00", CapacityUnit: "orders", Capacity: 5,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var template dto.IDResponse
+	parseJSON(t, resp, &template)
+
+	// Step 2: Three customers book the slot and their orders are confirmed.
+	product := uuid.NewString()
+	ts.setTaxCategory(t, token, product, "fresh_meat")
+	type booking struct {
+		token   string
+		orderID string
+	}
+	var slotStartsAt time.Time
+	book := func(email string, lat, lng float64) booking {
+		t.Helper()
+		customerToken := ts.registerAndLoginCustomer(t, email)
+		resp := ts.postJSONWithAuth(t, "/api/v1/me/addresses", dto.AddressRequest{
+			Label: "Home", Line1: "1 High Street", City: "London", Postcode: "E1 6AN", Latitude: &lat, Longitude: &lng,
+		}, customerToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = ts.postJSONWithAuth(t, "/api/v1/fulfilment/reservations", dto.ReserveSlotRequest{
+			TemplateID: template.ID, Date: slotDate.Format(time.DateOnly),
+		}, customerToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var reservation dto.ReserveSlotResponse
+		parseJSON(t, resp, &reservation)
+		slotStartsAt = reservation.StartsAt
+
+		var customerID uuid.UUID
+		err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, email).Scan(&customerID)
+		require.NoError(t, err)
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", dto.CreateOrderRequest{
+			CustomerID: customerID.String(),
+			BranchID:   b.ID,
+			Lines:      []dto.OrderLine{{ProductID: product, Description: "Lamb shoulder", Grams: 1000, PricePerKgCents: 2000}},
+		}, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var o dto.OrderResponse
+		parseJSON(t, resp, &o)
+
+		_, err = ts.pool.Exec(context.Background(),
+			`UPDATE slot_reservations SET status = 'confirmed', order_id = $1 WHERE id = $2`, o.ID, reservation.ReservationID)
+		require.NoError(t, err)
+		return booking{token: customerToken, orderID: o.ID}
+	}
+	near := book("near@example.com", 51.517, -0.071)
+	far := book("far@example.com", 51.530, -0.060)
+	middle := book("middle@example.com", 51.523, -0.066)
+
+	// Step 3: The manager takes on a driver, who signs in through the admin
+	// login. Counter staff cannot dispatch.
+	driverReq := dto.DriverRequest{
+		Email: "bilal@butchery.com", Password: "s3cret-pass", FullName: "Bilal Hussain",
+		BranchID: b.ID, Phone: "07700 900123", Vehicle: "White van",
+	}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/drivers", driverReq, staffToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/drivers", driverReq, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var driver dto.DriverResponse
+	parseJSON(t, resp, &driver)
+	assert.True(t, driver.Active)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/drivers", driverReq, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSON(t, "/api/v1/admin/auth/login", dto.LoginRequest{Email: driverReq.Email, Password: driverReq.Password})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var login dto.LoginResponse
+	parseJSON(t, resp, &login)
+	driverToken := login.AccessToken
+
+	// Step 4: The slot's orders are planned into one run from the shop,
+	// routed nearest first.
+	shopLat, shopLng := 51.5154, -0.0722
+	planReq := dto.PlanRunRequest{
+		BranchID: b.ID, ZoneID: zone.ID, SlotStartsAt: slotStartsAt,
+		StartLatitude: &shopLat, StartLongitude: &shopLng, DriverID: driver.AdminID,
+	}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/dispatch/runs", planReq, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var plan dto.PlanRunResponse
+	parseJSON(t, resp, &plan)
+	assert.Empty(t, plan.UnaddressedOrderIDs)
+	run := plan.Run
+	assert.Equal(t, "planned", run.Status)
+	require.Len(t, run.Stops, 3)
+	route := []string{run.Stops[0].OrderID, run.Stops[1].OrderID, run.Stops[2].OrderID}
+	assert.Equal(t, []string{near.orderID, middle.orderID, far.orderID}, route)
+	assert.Positive(t, run.DistanceMetres)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/dispatch/runs", planReq, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "every order is already routed")
+	resp.Body.Close()
+
+	// Step 5: Customers see where their order is; only their own.
+	position := func(b booking) dto.OrderDeliveryResponse {
+		t.Helper()
+		resp := ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/orders/"+b.orderID+"/delivery", nil, b.token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var d dto.OrderDeliveryResponse
+		parseJSON(t, resp, &d)
+		return d
+	}
+	d := position(far)
+	assert.Equal(t, "planned", d.RunStatus)
+	assert.Equal(t, 3, d.Position)
+	assert.Equal(t, 2, d.StopsAhead)
+	assert.Empty(t, d.DriverName)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/orders/"+far.orderID+"/delivery", nil, near.token)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 6: The driver sees the run and sets off.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/driver/runs?date="+slotDate.Format(time.DateOnly), nil, driverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var mine []dto.DeliveryRunResponse
+	parseJSON(t, resp, &mine)
+	require.Len(t, mine, 1)
+	assert.Equal(t, run.ID, mine[0].ID)
+
+	resp = ts.postMultipartWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/stops/"+run.Stops[0].ID+"/delivered",
+		map[string]string{"recipient_name": "Amina"}, map[string][]byte{"photo": testPNG}, driverToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "run has not started")
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/start", nil, driverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	d = position(far)
+	assert.Equal(t, "started", d.RunStatus)
+	assert.Equal(t, "Bilal Hussain", d.DriverName)
+
+	// Step 7: Stops are delivered with proof or failed with a reason.
+	resp = ts.postMultipartWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/stops/"+run.Stops[0].ID+"/delivered",
+		map[string]string{"recipient_name": "Amina"}, nil, driverToken)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "proof is required")
+	resp.Body.Close()
+
+	resp = ts.postMultipartWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/stops/"+run.Stops[0].ID+"/delivered",
+		map[string]string{"recipient_name": "Amina"}, map[string][]byte{"photo": []byte("not an image")}, driverToken)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postMultipartWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/stops/"+run.Stops[0].ID+"/delivered",
+		map[string]string{"recipient_name": "Amina"}, map[string][]byte{"photo": testPNG}, driverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	d = position(far)
+	assert.Equal(t, 1, d.StopsAhead)
+
+	resp = ts.postMultipartWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/stops/"+run.Stops[1].ID+"/failed",
+		map[string]string{"reason": "No one home"}, nil, driverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postMultipartWithAuth(t, "/api/v1/driver/runs/"+run.ID+"/stops/"+run.Stops[2].ID+"/delivered",
+		nil, map[string][]byte{"signature": testPNG}, driverToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var done dto.DeliveryRunResponse
+	parseJSON(t, resp, &done)
+	assert.Equal(t, "completed", done.Status)
+	assert.Equal(t, []string{"delivered", "failed", "delivered"},
+		[]string{done.Stops[0].Status, done.Stops[1].Status, done.Stops[2].Status})
+	assert.Equal(t, "No one home", done.Stops[1].FailureReason)
+
+	d = position(far)
+	assert.Equal(t, "delivered", d.StopStatus)
+	assert.NotNil(t, d.CompletedAt)
+
+	// Step 8: The manager can see the proof; the driver cannot browse it.
+	proofPath := "/api/v1/admin/dispatch/stops/" + run.Stops[0].ID + "/proofs/photo"
+	resp = ts.doJSONWithAuth(t, http.MethodGet, proofPath, nil, driverToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, proofPath, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	img, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, testPNG, img)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/dispatch/stops/"+run.Stops[1].ID+"/proofs/photo", nil, token)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// postMultipartWithAuth sends an authenticated multipart/form-data POST
+// request with the given fields and files.
+func (ts *testServer) postMultipartWithAuth(t *testing.T, path string, fields map[string]string, files map[string][]byte, token string) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, w.WriteField(name, value))
+	}
+	for name, data := range files {
+		part, err := w.CreateFormFile(name, name)
+		require.NoError(t, err)
+		_, err = part.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	req, err := http.NewRequest(http.MethodPost, ts.url(path), &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,86 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationFulfilment_ReserveCollectionSlot(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	branchID := uuid.New().String()
+	slotDate := time.Now().UTC().AddDate(0, 0, 2)
+
+	// Step 1: Open the branch every day.
+	days := make([]dto.OpeningHoursDay, 0, 7)
+	for weekday := 0; weekday < 7; weekday++ {
+		days = append(days, dto.OpeningHoursDay{Weekday: weekday, OpensAt: "08:00", ClosesAt: "18:00"})
+	}
+	resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/branches/"+branchID+"/opening-hours",
+		dto.SetOpeningHoursRequest{Days: days}, adminToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: Create a single-order collection slot on the target weekday.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/fulfilment/slot-templates", dto.CreateSlotTemplateRequest{
+		BranchID:     branchID,
+		Method:       "collection",
+		Weekday:      int(slotDate.Weekday()),
+		StartsAt:     "10:00",
+		EndsAt:       "11:00",
+		CapacityUnit: "orders",
+		Capacity:     1,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created dto.IDResponse
+	parseJSON(t, resp, &created)
+	require.NotEmpty(t, created.ID)
+
+	// Step 3: The slot is listed publicly.
+	resp = ts.get(t, "/api/v1/fulfilment/slots?method=collection&branch_id="+branchID+"&from="+slotDate.Format(time.DateOnly)+"&days=1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var slots []dto.AvailableSlotResponse
+	parseJSON(t, resp, &slots)
+	require.Len(t, slots, 1)
+	assert.Equal(t, created.ID, slots[0].TemplateID)
+	assert.Equal(t, int64(1), slots[0].Remaining)
+
+	// Step 4: A customer holds the slot.
+	customerToken := ts.registerAndLoginCustomer(t, "slots@example.com")
+	reserveBody := dto.ReserveSlotRequest{TemplateID: created.ID, Date: slotDate.Format(time.DateOnly)}
+	resp = ts.postJSONWithAuth(t, "/api/v1/fulfilment/reservations", reserveBody, customerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var reservation dto.ReserveSlotResponse
+	parseJSON(t, resp, &reservation)
+	assert.NotEmpty(t, reservation.ReservationID)
+
+	// Step 5: The slot is now full and no longer listed.
+	resp = ts.postJSONWithAuth(t, "/api/v1/fulfilment/reservations", reserveBody, customerToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.get(t, "/api/v1/fulfilment/slots?method=collection&branch_id="+branchID+"&from="+slotDate.Format(time.DateOnly)+"&days=1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	slots = nil
+	parseJSON(t, resp, &slots)
+	assert.Empty(t, slots)
+
+	// Step 6: Releasing the hold frees the slot again.
+	resp = ts.doJSONWithAuth(t, http.MethodDelete, "/api/v1/fulfilment/reservations/"+reservation.ReservationID, nil, customerToken)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/fulfilment/reservations", reserveBody, customerToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+}
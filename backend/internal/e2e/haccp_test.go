@@ -0,0 +1,182 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationHACCP_ReadingsAlarmsAndReport(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	token := ts.loginAdmin(t)
+
+	// Step 1: A branch has a walk-in chiller read by a sensor and a chest
+	// freezer read by hand every hour.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/branches", dto.BranchRequest{
+		Name: "Whitechapel", Address: []string{"1 High Street", "London"},
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var b dto.BranchResponse
+	parseJSON(t, resp, &b)
+
+	createUnit := func(req dto.HACCPUnitRequest) dto.HACCPUnitResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/haccp/units", req, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var u dto.HACCPUnitResponse
+		parseJSON(t, resp, &u)
+		return u
+	}
+	chiller := createUnit(dto.HACCPUnitRequest{
+		BranchID: b.ID, Name: "Walk-in chiller", Kind: "chiller", MinCelsius: 0, MaxCelsius: 5,
+		SensorID: "chiller-1", CheckEveryMinutes: 30,
+	})
+	freezer := createUnit(dto.HACCPUnitRequest{
+		BranchID: b.ID, Name: "Chest freezer", Kind: "freezer", MinCelsius: -25, MaxCelsius: -18,
+		CheckEveryMinutes: 60,
+	})
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/haccp/units", dto.HACCPUnitRequest{
+		BranchID: b.ID, Name: "Display", Kind: "display", MinCelsius: 0, MaxCelsius: 5,
+		SensorID: "chiller-1", CheckEveryMinutes: 30,
+	}, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "sensors read one unit")
+	resp.Body.Close()
+
+	// Step 2: Sensors must send the key.
+	resp = ts.postSensorReadings(t, dto.SensorReadingsRequest{
+		Readings: []dto.SensorReadingRequest{{SensorID: "chiller-1", Celsius: 3}},
+	}, "wrong-key")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: The chiller warms up past its range: one alarm is raised, not
+	// one per reading. An unknown sensor is rejected without failing the
+	// batch.
+	resp = ts.postSensorReadings(t, dto.SensorReadingsRequest{Readings: []dto.SensorReadingRequest{
+		{SensorID: "chiller-1", Celsius: 3.2},
+		{SensorID: "chiller-1", Celsius: 7.5},
+		{SensorID: "chiller-1", Celsius: 8.1},
+		{SensorID: "chiller-9", Celsius: 2},
+	}}, testSensorKey)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var pushed dto.SensorReadingsResponse
+	parseJSON(t, resp, &pushed)
+	assert.Equal(t, dto.SensorReadingsResponse{Accepted: 3, Rejected: 1, Alerts: 1}, pushed)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/haccp/alerts?open=true", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var alerts []dto.HACCPAlertResponse
+	parseJSON(t, resp, &alerts)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, chiller.ID, alerts[0].UnitID)
+	assert.Equal(t, "out_of_range", alerts[0].Kind)
+	require.NotNil(t, alerts[0].Celsius)
+	assert.Equal(t, 7.5, *alerts[0].Celsius)
+
+	// Step 4: Moving the stock and calling the engineer resolves the alarm,
+	// once.
+	actionPath := "/api/v1/admin/haccp/units/" + chiller.ID + "/corrective-actions"
+	resp = ts.postJSONWithAuth(t, actionPath, dto.HACCPActionRequest{
+		AlertID: &alerts[0].ID, Action: "Moved stock to the back chiller and called the engineer",
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var action dto.HACCPActionResponse
+	parseJSON(t, resp, &action)
+	assert.Equal(t, alerts[0].ID, *action.AlertID)
+
+	resp = ts.postJSONWithAuth(t, actionPath, dto.HACCPActionRequest{AlertID: &alerts[0].ID, Action: "Again"}, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/haccp/alerts?open=true", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &alerts)
+	assert.Empty(t, alerts)
+
+	// Step 5: The freezer was last read by hand two hours ago, so the
+	// checks raise a missing reading alarm for it; the chiller was just
+	// read.
+	takenAt := time.Now().Add(-2 * time.Hour)
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/haccp/units/"+freezer.ID+"/readings", dto.HACCPReadingRequest{
+		Celsius: -19.4, TakenAt: &takenAt, Note: "Lid seal checked",
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var reading dto.HACCPReadingResponse
+	parseJSON(t, resp, &reading)
+	assert.True(t, reading.InRange)
+	assert.Equal(t, "manual", reading.Source)
+	assert.NotNil(t, reading.RecordedBy)
+
+	for _, raised := range []int{1, 0} {
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/haccp/checks/run", nil, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var run dto.HACCPCheckRunResponse
+		parseJSON(t, resp, &run)
+		assert.Equal(t, dto.HACCPCheckRunResponse{Checked: 2, Raised: raised}, run)
+	}
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/haccp/alerts?open=true&unit_id="+freezer.ID, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &alerts)
+	require.Len(t, alerts, 1, "an open alarm is not raised again")
+	assert.Equal(t, "missing", alerts[0].Kind)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/haccp/readings?branch_id="+b.ID, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var readings []dto.HACCPReadingResponse
+	parseJSON(t, resp, &readings)
+	require.Len(t, readings, 4)
+	assert.Equal(t, freezer.ID, readings[0].UnitID, "readings are listed by when they were taken")
+
+	// Step 6: The record since yesterday exports as a PDF and as a CSV with
+	// every reading, alarm and action.
+	from, today := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly), time.Now().UTC().Format(time.DateOnly)
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/haccp/report?from="+from+"&to="+today, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF-")))
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet,
+		"/api/v1/admin/haccp/report?format=csv&branch_id="+b.ID+"&from="+from+"&to="+today, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	csv := string(body)
+	assert.Contains(t, csv, "Walk-in chiller")
+	assert.Contains(t, csv, "Moved stock to the back chiller")
+	assert.Contains(t, csv, "Lid seal checked")
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/haccp/report?format=xlsx&from="+today+"&to="+today, nil, token)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// postSensorReadings pushes readings as a temperature sensor with key.
+func (ts *testServer) postSensorReadings(t *testing.T, body dto.SensorReadingsRequest, key string) *http.Response {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, ts.url("/api/v1/haccp/readings"), bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sensor-Key", key)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,95 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationInventory_ReceiveReserveAndFulfil(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "inventory@example.com")
+
+	productID, branchID := uuid.NewString(), uuid.NewString()
+
+	// Step 1: Admin receives a lot of mince and sets a low-stock threshold.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+		ProductID: productID, BranchID: branchID, Code: "MINCE-01", Grams: 5000, ExpiresOn: "2026-11-02",
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var lot dto.LotResponse
+	parseJSON(t, resp, &lot)
+	assert.Equal(t, int64(5000), lot.OnHandGrams)
+
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/products/"+productID+"/low-stock-threshold",
+		dto.SetLowStockThresholdRequest{Grams: 2000}, adminToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: Trim is written off.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/adjustments", dto.StockAdjustmentRequest{
+		LotID: lot.ID, Type: "wasted", DeltaGrams: -500, Reason: "trim",
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: Customer holds stock at checkout; more than is left is refused.
+	reserve := func(grams int64) *http.Response {
+		return ts.postJSONWithAuth(t, "/api/v1/inventory/reservations", dto.ReserveStockRequest{
+			BranchID: branchID,
+			Lines:    []dto.StockReservationLine{{ProductID: productID, Grams: grams}},
+		}, customerToken)
+	}
+	resp = reserve(3000)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var hold dto.ReserveStockResponse
+	parseJSON(t, resp, &hold)
+
+	resp = reserve(2000)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: Stock report shows the product as low.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/stock?low_only=true&branch_id="+branchID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var levels []dto.StockLevelResponse
+	parseJSON(t, resp, &levels)
+	require.Len(t, levels, 1)
+	assert.Equal(t, int64(4500), levels[0].OnHandGrams)
+	assert.Equal(t, int64(3000), levels[0].ReservedGrams)
+	assert.Equal(t, int64(1500), levels[0].AvailableGrams)
+
+	// Step 5: The order is picked and the stock is sold.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/reservations/"+hold.ReservationID+"/fulfil", nil, adminToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 6: A stock take finds 100g less than the ledger says.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/stock-takes", dto.StockTakeRequest{
+		BranchID: branchID,
+		Counts:   []dto.StockTakeCount{{LotID: lot.ID, CountedGrams: 1400}},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var adjustments []dto.StockMovementResponse
+	parseJSON(t, resp, &adjustments)
+	require.Len(t, adjustments, 1)
+	assert.Equal(t, int64(-100), adjustments[0].DeltaGrams)
+
+	// Step 7: The ledger records every movement, newest first.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/movements?lot_id="+lot.ID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var movements []dto.StockMovementResponse
+	parseJSON(t, resp, &movements)
+	types := make([]string, 0, len(movements))
+	for _, m := range movements {
+		types = append(types, m.Type)
+	}
+	assert.Equal(t, []string{"adjusted", "sold", "wasted", "received"}, types)
+}
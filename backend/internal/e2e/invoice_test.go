@@ -0,0 +1,101 @@
+package e2e_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationInvoices(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "invoice@example.com")
+	o := ts.createPaidOrder(t, adminToken, customerToken, "invoice@example.com")
+
+	// Step 1: Invoice the order. Only the brisket carries VAT.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/orders/"+o.ID+"/invoice", nil, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var inv dto.InvoiceResponse
+	parseJSON(t, resp, &inv)
+	assert.Equal(t, "invoice", inv.Kind)
+	assert.True(t, strings.HasPrefix(inv.Number, "INV-"))
+	assert.True(t, strings.HasSuffix(inv.Number, "-000001"), "first invoice of the branch's year")
+	assert.Equal(t, int64(3500), inv.GrossCents)
+	assert.Equal(t, int64(250), inv.VATCents)
+	require.Len(t, inv.VATBands, 2)
+	assert.Equal(t, "Beef brisket", inv.Lines[1].Description)
+
+	// Step 2: Invoicing again returns the same invoice rather than a new number.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders/"+o.ID+"/invoice", nil, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var again dto.InvoiceResponse
+	parseJSON(t, resp, &again)
+	assert.Equal(t, inv.Number, again.Number)
+
+	// Step 3: The customer downloads exactly the bytes that were issued.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/invoices", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var mine []dto.InvoiceResponse
+	parseJSON(t, resp, &mine)
+	require.Len(t, mine, 1)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/invoices/"+inv.ID+"/pdf", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	sum := sha256.Sum256(body)
+	assert.Equal(t, inv.SHA256, hex.EncodeToString(sum[:]))
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), inv.Number+".pdf")
+	assert.Contains(t, string(body), testShopName)
+	assert.Contains(t, string(body), testShopVATNumber)
+
+	otherToken := ts.registerAndLoginCustomer(t, "someone-else@example.com")
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/invoices/"+inv.ID+"/pdf", nil, otherToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: A short-weight refund is credited against the invoice.
+	lineID := o.Lines[0].ID
+	resp = ts.postJSONWithKey(t, "/api/v1/admin/orders/"+o.ID+"/refunds", dto.RequestOrderRefundRequest{
+		Kind: "weight_difference", LineID: &lineID, DeliveredGrams: 750, Reason: "short_weight",
+	}, adminToken, "short-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var r dto.OrderRefundResponse
+	parseJSON(t, resp, &r)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders/"+o.ID+"/refunds/"+r.ID+"/credit-note", nil, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var cn dto.InvoiceResponse
+	parseJSON(t, resp, &cn)
+	assert.Equal(t, "credit_note", cn.Kind)
+	assert.True(t, strings.HasPrefix(cn.Number, "CN-"))
+	assert.Equal(t, inv.Number, cn.CreditedNumber)
+	assert.Equal(t, int64(500), cn.GrossCents)
+	require.Len(t, cn.Lines, 1)
+	assert.Equal(t, int64(250), cn.Lines[0].Grams)
+
+	// Step 5: Staff see both documents on the order and can download either.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+o.ID+"/invoices", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var docs []dto.InvoiceResponse
+	parseJSON(t, resp, &docs)
+	require.Len(t, docs, 2)
+	assert.Equal(t, inv.ID, docs[0].ID)
+	assert.Equal(t, cn.ID, docs[1].ID)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/invoices/"+cn.ID+"/pdf", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"`+cn.SHA256+`"`, resp.Header.Get("ETag"))
+	resp.Body.Close()
+}
@@ -0,0 +1,224 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationLabels_RenderAndPrintPackLabels(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	address, received := listenAsPrinter(t)
+
+	// Step 1: Lamb mince is on PLU 123 with its allergens and a three-day
+	// shelf life, and a lot of it is received.
+	mince := uuid.NewString()
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/plu-items", dto.PLUItemRequest{
+		Code:            123,
+		ProductID:       mince,
+		Description:     "Lamb mince",
+		PricePerKgCents: 1299,
+		Allergens:       []string{"sulphites", "celery"},
+		ShelfLifeDays:   3,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var item dto.PLUItemResponse
+	parseJSON(t, resp, &item)
+	assert.Equal(t, []string{"celery", "sulphites"}, item.Allergens)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+		ProductID: mince, BranchID: uuid.NewString(), Code: "MINCE-42", Grams: 5000,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var lot dto.LotResponse
+	parseJSON(t, resp, &lot)
+
+	// Step 2: The counter printer is registered at the listener's address.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/printers", dto.LabelPrinterRequest{Name: "Counter", Address: address}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var counter dto.LabelPrinterResponse
+	parseJSON(t, resp, &counter)
+	assert.True(t, counter.Active)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/printers", dto.LabelPrinterRequest{Name: "counter", Address: address}, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/printers", dto.LabelPrinterRequest{Name: "Back room", Address: "http://printer/"}, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: Labels for two packs from the lot are rendered for an office
+	// printer and as ZPL; more than the lot holds cannot be labelled.
+	lotLabels := "/api/v1/admin/inventory/lots/" + lot.ID + "/labels"
+	body, contentType := fetch(t, ts, lotLabels+"?packs=1250,500", adminToken)
+	assert.Equal(t, "application/pdf", contentType)
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF-")))
+
+	body, _ = fetch(t, ts, lotLabels+"?packs=1250,500&format=zpl&template=large", adminToken)
+	zpl := string(body)
+	assert.Equal(t, 2, strings.Count(zpl, "^XA"))
+	assert.Contains(t, zpl, "Contains: celery, sulphites")
+	assert.Contains(t, zpl, "Lot MINCE-42")
+	assert.Contains(t, zpl, "^FD210012301624^FS")
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, lotLabels+"?packs=3000,2500", nil, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, lotLabels+"?packs=500&template=tiny", nil, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: Printing sends the ZPL straight to the printer.
+	resp = ts.postJSONWithAuth(t, lotLabels+"/print", dto.PrintLotLabelsRequest{
+		PrinterID: counter.ID, Packs: []int64{1250, 500},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var job dto.PrintJobResponse
+	parseJSON(t, resp, &job)
+	assert.Equal(t, "printed", job.Status)
+	assert.Equal(t, 2, job.Labels)
+	assert.Equal(t, "lot", job.Source)
+	assert.Equal(t, 2, zplFromPrinter(t, received))
+
+	// Step 5: An order is labelled line by line, priced as it was sold.
+	customerID := ts.createCustomer(t, "labels@example.com")
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", dto.CreateOrderRequest{
+		CustomerID: customerID,
+		BranchID:   uuid.NewString(),
+		Lines:      []dto.OrderLine{{ProductID: mince, Description: "Lamb mince", Grams: 1250, PricePerKgCents: 1299}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var o dto.OrderResponse
+	parseJSON(t, resp, &o)
+
+	body, contentType = fetch(t, ts, "/api/v1/admin/orders/"+o.ID+"/labels?format=zpl", adminToken)
+	assert.True(t, strings.HasPrefix(contentType, "text/plain"))
+	assert.Contains(t, string(body), "Lamb mince")
+	assert.Contains(t, string(body), "^FD210012301624^FS")
+
+	// Step 6: A printer that is switched off leaves the job queued; the
+	// dispatcher gives up on it after its last attempt.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/printers", dto.LabelPrinterRequest{Name: "Back room", Address: closedAddress(t)}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var backRoom dto.LabelPrinterResponse
+	parseJSON(t, resp, &backRoom)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders/"+o.ID+"/labels/print", dto.PrintOrderLabelsRequest{PrinterID: backRoom.ID}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	parseJSON(t, resp, &job)
+	assert.Equal(t, "queued", job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.NotEmpty(t, job.LastError)
+
+	at := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/print-jobs/dispatch?at="+at, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var run dto.PrintDispatchResponse
+	parseJSON(t, resp, &run)
+	assert.Equal(t, dto.PrintDispatchResponse{Failed: 1}, run)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/print-jobs?status=failed", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var failed []dto.PrintJobResponse
+	parseJSON(t, resp, &failed)
+	require.Len(t, failed, 1)
+	assert.Equal(t, job.ID, failed[0].ID)
+
+	// Step 7: Once the printer is back on the network the job is retried.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/printers/"+backRoom.ID, dto.UpdateLabelPrinterRequest{
+		Name: "Back room", Address: address, Active: true,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/print-jobs/"+job.ID+"/retry", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &job)
+	assert.Equal(t, "printed", job.Status)
+	assert.Equal(t, 1, zplFromPrinter(t, received))
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/print-jobs/"+job.ID+"/retry", nil, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// listenAsPrinter stands in for a label printer on a local port and returns
+// its address and everything sent to it, one connection at a time.
+func listenAsPrinter(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 8)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			data, _ := io.ReadAll(conn)
+			conn.Close()
+			received <- string(data)
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+// zplFromPrinter waits for the printer to be sent a job and returns how
+// many labels it held.
+func zplFromPrinter(t *testing.T, received <-chan string) int {
+	t.Helper()
+	select {
+	case data := <-received:
+		return strings.Count(data, "^XA")
+	case <-time.After(5 * time.Second):
+		t.Fatal("printer was sent nothing")
+		return 0
+	}
+}
+
+// closedAddress returns a local address nothing is listening on.
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return address
+}
+
+// fetch GETs a file and returns its body and content type.
+func fetch(t *testing.T, ts *testServer, path, token string) ([]byte, string) {
+	t.Helper()
+	resp := ts.doJSONWithAuth(t, http.MethodGet, path, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return body, resp.Header.Get("Content-Type")
+}
+
+// createCustomer registers a customer and returns their ID.
+func (ts *testServer) createCustomer(t *testing.T, email string) string {
+	t.Helper()
+	ts.registerAndLoginCustomer(t, email)
+	var id uuid.UUID
+	err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, email).Scan(&id)
+	require.NoError(t, err)
+	return id.String()
+}
@@ -0,0 +1,156 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationLoyalty(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	// Step 1: Lamb earns double bonus points on top of a point per pound,
+	// and birthdays are worth 200 points.
+	lamb, mince := uuid.NewString(), uuid.NewString()
+	ts.setTaxCategory(t, adminToken, lamb, "fresh_meat")
+	ts.setTaxCategory(t, adminToken, mince, "fresh_meat")
+	resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/loyalty/products/"+lamb,
+		dto.LoyaltyProductBonusRequest{PointsPerUnit: 2}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/loyalty/rules", dto.LoyaltyRulesRequest{
+		PointsPerUnit: 1, UnitCents: 100, PointValueCents: 1, MinRedeemPoints: 100, BirthdayBonus: 200, ExpiryDays: 365,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var rules dto.LoyaltyRulesResponse
+	parseJSON(t, resp, &rules)
+	require.Len(t, rules.ProductBonuses, 1)
+	assert.Equal(t, lamb, rules.ProductBonuses[0].ProductID)
+
+	// Step 2: A customer whose birthday is today gets the bonus once.
+	customerToken := ts.registerAndLoginCustomer(t, "loyal@example.com")
+	var customerID uuid.UUID
+	err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, "loyal@example.com").Scan(&customerID)
+	require.NoError(t, err)
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/me/loyalty/birthday", dto.BirthdayRequest{Month: 2, Day: 30}, customerToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+	today := time.Now().UTC()
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/me/loyalty/birthday",
+		dto.BirthdayRequest{Month: int(today.Month()), Day: today.Day()}, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	for _, want := range []int{1, 0} {
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/loyalty/birthday-bonuses?date="+today.Format(time.DateOnly), nil, adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var run dto.BirthdayBonusesResponse
+		parseJSON(t, resp, &run)
+		assert.Equal(t, want, run.Awarded)
+	}
+
+	// Step 3: An order earns 30 points for £30 and 40 more for the lamb, once
+	// it is handed over.
+	order := dto.CreateOrderRequest{
+		CustomerID: customerID.String(),
+		BranchID:   uuid.NewString(),
+		Lines: []dto.OrderLine{
+			{ProductID: lamb, Description: "Lamb leg", Grams: 1000, PricePerKgCents: 2000},
+			{ProductID: mince, Description: "Beef mince", Grams: 1000, PricePerKgCents: 1000},
+		},
+	}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", order, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var o dto.OrderResponse
+	parseJSON(t, resp, &o)
+
+	complete := func(orderID string) {
+		t.Helper()
+		resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/orders/"+orderID+"/stage",
+			dto.AdvanceStageRequest{Stage: "completed"}, adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+	account := func() dto.LoyaltyAccountResponse {
+		t.Helper()
+		resp := ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/loyalty", nil, customerToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var a dto.LoyaltyAccountResponse
+		parseJSON(t, resp, &a)
+		return a
+	}
+	assert.Equal(t, int64(200), account().Points)
+	complete(o.ID)
+	assert.Equal(t, int64(270), account().Points)
+
+	// Step 4: Spending 250 points takes £2.50 off across the lines, and the
+	// order earns on what is left to pay.
+	order.RedeemPoints = 250
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", order, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	parseJSON(t, resp, &o)
+	assert.Equal(t, int64(2750), o.TotalCents)
+	assert.Equal(t, int64(167), o.Lines[0].DiscountCents)
+	assert.Equal(t, int64(83), o.Lines[1].DiscountCents)
+
+	assert.Equal(t, int64(20), account().Points)
+	complete(o.ID)
+
+	a := account()
+	assert.Equal(t, int64(83), a.Points)
+	assert.Equal(t, int64(83), a.ValueCents)
+	assert.Equal(t, int64(250), a.RedeemedPoints)
+	require.Len(t, a.History, 4)
+	assert.Equal(t, []string{"birthday", "earn", "redeem", "earn"},
+		[]string{a.History[0].Kind, a.History[1].Kind, a.History[2].Kind, a.History[3].Kind})
+	require.NotNil(t, a.Birthday)
+
+	// Step 5: The customer cannot spend points they do not have.
+	order.RedeemPoints = 100
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", order, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, int64(83), account().Points)
+
+	// Step 6: Staff credit points as a goodwill gesture, with a note.
+	path := "/api/v1/admin/customers/" + customerID.String() + "/loyalty"
+	resp = ts.postJSONWithAuth(t, path+"/adjustments", dto.LoyaltyAdjustmentRequest{Points: 50}, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, path+"/adjustments", dto.LoyaltyAdjustmentRequest{Points: 50, Note: "Late delivery"}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 7: The balance rebuilt from the ledger matches the projection.
+	resp = ts.postJSONWithAuth(t, path+"/rebuild", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var rebuilt dto.LoyaltyBalanceResponse
+	parseJSON(t, resp, &rebuilt)
+	assert.Equal(t, int64(133), rebuilt.Points)
+	assert.Equal(t, 6, rebuilt.Entries)
+
+	// Step 8: A year on, every point has expired.
+	later := time.Now().AddDate(1, 0, 1).UTC().Format(time.RFC3339)
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/loyalty/expiry?at="+later, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var expiry dto.LoyaltyExpiryResponse
+	parseJSON(t, resp, &expiry)
+	assert.Equal(t, dto.LoyaltyExpiryResponse{Customers: 1, Points: 133}, expiry)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, path, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &a)
+	assert.Equal(t, int64(0), a.Points)
+	assert.Equal(t, int64(133), a.ExpiredPoints)
+}
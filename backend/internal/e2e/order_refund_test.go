@@ -0,0 +1,170 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationOrderRefund_WeightDifference(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "refund@example.com")
+	o := ts.createPaidOrder(t, adminToken, customerToken, "refund@example.com")
+
+	// Step 1: The lamb shoulder came in 250g short; the refund is small enough
+	// to be issued straight away.
+	lineID := o.Lines[0].ID
+	req := dto.RequestOrderRefundRequest{Kind: "weight_difference", LineID: &lineID, DeliveredGrams: 750, Reason: "short_weight"}
+	resp := ts.postJSONWithKey(t, "/api/v1/admin/orders/"+o.ID+"/refunds", req, adminToken, "short-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var r dto.OrderRefundResponse
+	parseJSON(t, resp, &r)
+	assert.Equal(t, "issued", r.Status)
+	assert.Equal(t, int64(500), r.AmountCents)
+	require.NotNil(t, r.ReceiptNumber)
+
+	// Step 2: A retry returns the same refund and moves no more money.
+	resp = ts.postJSONWithKey(t, "/api/v1/admin/orders/"+o.ID+"/refunds", req, adminToken, "short-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var again dto.OrderRefundResponse
+	parseJSON(t, resp, &again)
+	assert.Equal(t, r.ID, again.ID)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+o.ID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &o)
+	assert.Equal(t, "partially_refunded", o.Status)
+	assert.Equal(t, int64(500), o.RefundedCents)
+	assert.Equal(t, int64(500), o.Lines[0].RefundedCents)
+
+	// Step 3: The receipt and the audit trail record the refund.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+o.ID+"/refunds/"+r.ID+"/receipt", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var receipt dto.RefundReceiptResponse
+	parseJSON(t, resp, &receipt)
+	assert.Equal(t, *r.ReceiptNumber, receipt.ReceiptNumber)
+	assert.Equal(t, "gbp", receipt.Currency)
+	assert.Equal(t, int64(750), receipt.DeliveredGrams)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+o.ID+"/audit", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var entries []dto.AuditEntryResponse
+	parseJSON(t, resp, &entries)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "refund.requested", entries[0].Action)
+	assert.Equal(t, "refund.issued", entries[1].Action)
+	assert.Equal(t, "short_weight", entries[1].Details["reason"])
+}
+
+func TestIntegrationOrderRefund_ManagerApproval(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	managerToken := ts.loginAdmin(t)
+	staffToken := ts.loginStaffAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "approval@example.com")
+	o := ts.createPaidOrder(t, managerToken, customerToken, "approval@example.com")
+
+	// Step 1: Staff refund the whole order, which is over the threshold.
+	req := dto.RequestOrderRefundRequest{Kind: "order", Reason: "late_delivery"}
+	resp := ts.postJSONWithKey(t, "/api/v1/admin/orders/"+o.ID+"/refunds", req, staffToken, "late-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var r dto.OrderRefundResponse
+	parseJSON(t, resp, &r)
+	assert.Equal(t, "pending_approval", r.Status)
+	assert.Equal(t, int64(3500), r.AmountCents)
+
+	// Step 2: Staff cannot approve it; a manager can.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders/"+o.ID+"/refunds/"+r.ID+"/approve", nil, staffToken)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders/"+o.ID+"/refunds/"+r.ID+"/approve", nil, managerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &r)
+	assert.Equal(t, "issued", r.Status)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+o.ID, nil, managerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &o)
+	assert.Equal(t, "refunded", o.Status)
+	assert.Equal(t, int64(0), o.RefundableCents)
+
+	// Step 3: Nothing is left to refund.
+	resp = ts.postJSONWithKey(t, "/api/v1/admin/orders/"+o.ID+"/refunds", req, managerToken, "late-2")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// createPaidOrder records an order for the customer worth 35.00 and pays for
+// it in full: 1kg of fresh lamb at 20.00/kg and 500g of prepared brisket at
+// 30.00/kg, which carries 2.50 VAT.
+func (ts *testServer) createPaidOrder(t *testing.T, adminToken, customerToken, email string) dto.OrderResponse {
+	t.Helper()
+
+	var customerID uuid.UUID
+	err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, email).Scan(&customerID)
+	require.NoError(t, err)
+
+	lamb, brisket := uuid.NewString(), uuid.NewString()
+	ts.setTaxCategory(t, adminToken, lamb, "fresh_meat")
+	ts.setTaxCategory(t, adminToken, brisket, "prepared_food")
+
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/orders", dto.CreateOrderRequest{
+		CustomerID: customerID.String(),
+		BranchID:   uuid.NewString(),
+		Lines: []dto.OrderLine{
+			{ProductID: lamb, Description: "Lamb shoulder", Grams: 1000, PricePerKgCents: 2000},
+			{ProductID: brisket, Description: "Beef brisket", Grams: 500, PricePerKgCents: 3000},
+		},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var o dto.OrderResponse
+	parseJSON(t, resp, &o)
+	require.Equal(t, int64(3500), o.TotalCents)
+
+	resp = ts.postJSONWithKey(t, "/api/v1/payments", dto.AuthorizePaymentRequest{
+		OrderID: o.ID, PaymentMethod: "pm_card_visa",
+	}, customerToken, "checkout-"+o.ID)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var p dto.PaymentResponse
+	parseJSON(t, resp, &p)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/payments/"+p.ID+"/capture", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	return o
+}
+
+// loginStaffAdmin adds an admin with the staff role, sharing the seeded
+// admin's password, and returns their access token.
+func (ts *testServer) loginStaffAdmin(t *testing.T) string {
+	t.Helper()
+	return ts.loginAdminWithRole(t, "staff")
+}
+
+// loginAdminWithRole adds an admin with the role, sharing the seeded
+// admin's password and branches, and returns their access token.
+func (ts *testServer) loginAdminWithRole(t *testing.T, role string) string {
+	t.Helper()
+
+	email := role + "@butchery.com"
+	_, err := ts.pool.Exec(context.Background(), `
+		INSERT INTO admins (id, email, password_hash, full_name, role, all_branches)
+		SELECT $1, $2, password_hash, 'Counter Staff', $3, all_branches FROM admins WHERE email = $4`,
+		uuid.New(), email, role, testAdminEmail)
+	require.NoError(t, err)
+
+	resp := ts.postJSON(t, "/api/v1/admin/auth/login", dto.LoginRequest{Email: email, Password: testAdminPassword})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var loginResp dto.LoginResponse
+	parseJSON(t, resp, &loginResp)
+	return loginResp.AccessToken
+}
@@ -0,0 +1,180 @@
+package e2e_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationOrderStream_StagesReachCustomersAndBoard(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	token := ts.loginAdmin(t)
+	product := uuid.NewString()
+	ts.setTaxCategory(t, token, product, "fresh_meat")
+	branchID := uuid.NewString()
+
+	placeOrder := func(email string) dto.OrderResponse {
+		t.Helper()
+		var customerID uuid.UUID
+		err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, email).Scan(&customerID)
+		require.NoError(t, err)
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/orders", dto.CreateOrderRequest{
+			CustomerID: customerID.String(),
+			BranchID:   branchID,
+			Lines:      []dto.OrderLine{{ProductID: product, Description: "Lamb chops", Grams: 1000, PricePerKgCents: 1800}},
+		}, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var o dto.OrderResponse
+		parseJSON(t, resp, &o)
+		return o
+	}
+	advance := func(orderID, stage string) *http.Response {
+		t.Helper()
+		return ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/orders/"+orderID+"/stage",
+			dto.AdvanceStageRequest{Stage: stage}, token)
+	}
+
+	// Step 1: Two customers have orders in; one follows theirs and the
+	// cutting-room board follows the branch.
+	customerToken := ts.registerAndLoginCustomer(t, "stream@example.com")
+	ts.registerAndLoginCustomer(t, "other@example.com")
+	mine := placeOrder("stream@example.com")
+	other := placeOrder("other@example.com")
+	assert.Equal(t, "received", mine.Stage)
+
+	stream := ts.streamOrders(t, "/api/v1/me/orders/stream", customerToken, "")
+	board := ts.streamOrders(t, "/api/v1/admin/orders/stream?branch_id="+branchID, token, "")
+
+	// Step 2: The cutting room starts on both orders; the customer only
+	// hears about theirs.
+	resp := advance(other.ID, "cutting")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = advance(mine.ID, "cutting")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	cutting := stream.next(t)
+	assert.Equal(t, strconv.FormatInt(cutting.ID, 10), cutting.sseID)
+	assert.Equal(t, mine.ID, cutting.OrderID)
+	assert.Equal(t, "cutting", cutting.Stage)
+	assert.Equal(t, other.ID, board.next(t).OrderID)
+	assert.Equal(t, mine.ID, board.next(t).OrderID)
+
+	// Step 3: The customer's connection drops; the order is made ready
+	// meanwhile. Reconnecting with Last-Event-ID replays it.
+	stream.close()
+	resp = advance(mine.ID, "ready")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var o dto.OrderResponse
+	parseJSON(t, resp, &o)
+	assert.Equal(t, "ready", o.Stage)
+
+	stream = ts.streamOrders(t, "/api/v1/me/orders/stream", customerToken, cutting.sseID)
+	ready := stream.next(t)
+	assert.Equal(t, "ready", ready.Stage)
+	assert.Greater(t, ready.ID, cutting.ID)
+
+	// Step 4: Orders only move forward.
+	resp = advance(mine.ID, "cutting")
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "order is already at or past that stage", parseError(t, resp))
+	resp = advance(mine.ID, "packed")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: A bad last event ID is refused.
+	req, err := http.NewRequest(http.MethodGet, ts.url("/api/v1/me/orders/stream?last_event_id=abc"), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+customerToken)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// orderEvents reads an order event stream.
+type orderEvents struct {
+	reader *bufio.Reader
+	body   io.Closer
+}
+
+// orderEvent is an event read from an order stream.
+type orderEvent struct {
+	dto.OrderEventResponse
+	sseID string
+}
+
+// streamOrders opens an order stream as a browser's EventSource would,
+// reconnecting from lastEventID if it is set.
+func (ts *testServer) streamOrders(t *testing.T, path, token, lastEventID string) *orderEvents {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, ts.url(path), nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return &orderEvents{reader: bufio.NewReader(resp.Body), body: resp.Body}
+}
+
+func (s *orderEvents) close() { s.body.Close() }
+
+// next returns the next order event, failing if none comes.
+func (s *orderEvents) next(t *testing.T) orderEvent {
+	t.Helper()
+	type result struct {
+		event orderEvent
+		err   error
+	}
+	got := make(chan result, 1)
+	go func() {
+		var e orderEvent
+		var data string
+		for {
+			line, err := s.reader.ReadString('\n')
+			if err != nil {
+				got <- result{err: err}
+				return
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				e.sseID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "" && data != "":
+				err := json.Unmarshal([]byte(data), &e.OrderEventResponse)
+				got <- result{event: e, err: err}
+				return
+			}
+		}
+	}()
+	select {
+	case r := <-got:
+		require.NoError(t, r.err)
+		return r.event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event on stream")
+		return orderEvent{}
+	}
+}
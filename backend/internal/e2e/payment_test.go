@@ -0,0 +1,142 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	infrapayment "github.com/katerji/butchery-app/backend/internal/infrastructure/payment"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationPayment_AuthorizeCaptureRefund(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "payer@example.com")
+	otherToken := ts.registerAndLoginCustomer(t, "other-payer@example.com")
+	orderID := ts.placeOrderFor(t, adminToken, "payer@example.com", 10000)
+
+	// Step 1: Another customer cannot pay for the order.
+	checkout := dto.AuthorizePaymentRequest{OrderID: orderID, PaymentMethod: "pm_card_visa"}
+	resp := ts.postJSONWithKey(t, "/api/v1/payments", checkout, otherToken, "checkout-1")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: The customer checks out; the card is held for the order's
+	// total plus 15%.
+	resp = ts.postJSONWithKey(t, "/api/v1/payments", checkout, customerToken, "checkout-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var p dto.PaymentResponse
+	parseJSON(t, resp, &p)
+	assert.Equal(t, "authorized", p.Status)
+	assert.Equal(t, int64(10000), p.EstimatedCents)
+	assert.Equal(t, int64(11500), p.AuthorizedCents)
+	assert.Equal(t, "gbp", p.Currency)
+
+	// Step 3: A retried checkout returns the same payment.
+	resp = ts.postJSONWithKey(t, "/api/v1/payments", checkout, customerToken, "checkout-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var again dto.PaymentResponse
+	parseJSON(t, resp, &again)
+	assert.Equal(t, p.ID, again.ID)
+
+	// Step 4: The payment is captured at the order's weighed total, however
+	// often it is retried.
+	for range 2 {
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/payments/"+p.ID+"/capture", nil, adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		parseJSON(t, resp, &p)
+		assert.Equal(t, "captured", p.Status)
+		assert.Equal(t, int64(10000), p.CapturedCents)
+	}
+
+	// Step 5: Refunds go through the order, so the payment has no refund
+	// route of its own.
+	resp = ts.postJSONWithKey(t, "/api/v1/admin/payments/"+p.ID+"/refunds", map[string]int64{"amount_cents": 800}, adminToken, "refund-1")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestIntegrationPayment_Declined(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "declined@example.com")
+
+	checkout := dto.AuthorizePaymentRequest{
+		OrderID:       ts.placeOrderFor(t, adminToken, "declined@example.com", 5000),
+		PaymentMethod: infrapayment.FakeDeclinedPaymentMethod,
+	}
+	resp := ts.postJSONWithKey(t, "/api/v1/payments", checkout, customerToken, "checkout-1")
+	require.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithKey(t, "/api/v1/payments", checkout, customerToken, "checkout-1")
+	require.Equal(t, http.StatusPaymentRequired, resp.StatusCode, "a retried decline stays declined")
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/payments", checkout, customerToken)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, parseError(t, resp), "Idempotency-Key")
+}
+
+func TestIntegrationPayment_Webhook(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "webhook@example.com")
+
+	resp := ts.postJSONWithKey(t, "/api/v1/payments", dto.AuthorizePaymentRequest{
+		OrderID: ts.placeOrderFor(t, adminToken, "webhook@example.com", 2000), PaymentMethod: "pm_card_visa",
+	}, customerToken, "checkout-1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var p dto.PaymentResponse
+	parseJSON(t, resp, &p)
+
+	// The hold is cancelled from the provider's dashboard.
+	payload := []byte(fmt.Sprintf(
+		`{"id":"evt_1","type":"payment_intent.canceled","data":{"object":{"id":%q,"metadata":{"payment_id":%q}}}}`,
+		p.ProviderRef, p.ID))
+
+	resp = ts.postWebhook(t, payload, "t=1,v1=00")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	for range 2 {
+		resp = ts.postWebhook(t, payload, ts.payments.SignWebhook(payload))
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/payments/"+p.ID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &p)
+	assert.Equal(t, "voided", p.Status)
+}
+
+// placeOrderFor records an order for the customer worth totalCents, as 1kg
+// of fresh meat, and returns its ID.
+func (ts *testServer) placeOrderFor(t *testing.T, adminToken, email string, totalCents int64) string {
+	t.Helper()
+
+	var customerID uuid.UUID
+	err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, email).Scan(&customerID)
+	require.NoError(t, err)
+	product := uuid.NewString()
+	ts.setTaxCategory(t, adminToken, product, "fresh_meat")
+
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/orders", dto.CreateOrderRequest{
+		CustomerID: customerID.String(),
+		BranchID:   uuid.NewString(),
+		Lines:      []dto.OrderLine{{ProductID: product, Description: "Lamb leg", Grams: 1000, PricePerKgCents: totalCents}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var o dto.OrderResponse
+	parseJSON(t, resp, &o)
+	require.Equal(t, totalCents, o.TotalCents)
+	return o.ID
+}
@@ -0,0 +1,197 @@
+package e2e_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationPOS_TillSessionSalesReturnsAndZReport(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	managerToken := ts.loginAdmin(t)
+	cashierToken := ts.loginAdminWithRole(t, "cashier")
+	staffToken := ts.loginStaffAdmin(t)
+
+	productID, branchID := uuid.NewString(), uuid.NewString()
+	ts.setTaxCategory(t, managerToken, productID, "fresh_meat")
+
+	// Step 1: Lamb chops are PLU 123 at 18.00 a kilogram, with 2kg in stock
+	// at the branch.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/plu-items", dto.PLUItemRequest{
+		Code: 123, ProductID: productID, Description: "Lamb chops", PricePerKgCents: 1800,
+	}, managerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var item dto.PLUItemResponse
+	parseJSON(t, resp, &item)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+		ProductID: productID, BranchID: branchID, Code: "LAMB-01", Grams: 2000, ExpiresOn: "2026-11-02",
+	}, managerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: The manager adds a till; staff may not open it, a cashier
+	// opens it with a 50.00 float.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/tills", dto.TillRequest{BranchID: branchID, Name: "Till 1"}, managerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var till dto.TillResponse
+	parseJSON(t, resp, &till)
+
+	sessionsPath := "/api/v1/admin/pos/tills/" + till.ID + "/sessions"
+	resp = ts.postJSONWithAuth(t, sessionsPath, dto.OpenSessionRequest{FloatCents: 5000}, staffToken)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, sessionsPath, dto.OpenSessionRequest{FloatCents: 5000}, cashierToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var session dto.TillSessionResponse
+	parseJSON(t, resp, &session)
+	assert.Equal(t, "open", session.Status)
+
+	resp = ts.postJSONWithAuth(t, sessionsPath, dto.OpenSessionRequest{FloatCents: 5000}, cashierToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: The cashier weighs 750g of chops, scans a labelled 500g pack
+	// and then voids it.
+	startSale := func() dto.SaleResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/pos/sessions/"+session.ID+"/sales", nil, cashierToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var s dto.SaleResponse
+		parseJSON(t, resp, &s)
+		return s
+	}
+	sale := startSale()
+	salePath := "/api/v1/admin/pos/sales/" + sale.ID
+
+	resp = ts.postJSONWithAuth(t, salePath+"/lines", dto.SaleLineRequest{PLUCode: 123, Grams: 750}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sale)
+	assert.Equal(t, int64(1350), sale.TotalCents)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/plu-items/"+item.ID+"/barcodes", dto.EncodeBarcodeRequest{Grams: 500, Measure: "weight"}, managerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var pack dto.BarcodeResponse
+	parseJSON(t, resp, &pack)
+
+	resp = ts.postJSONWithAuth(t, salePath+"/lines", dto.SaleLineRequest{Barcode: pack.Code}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sale)
+	require.Len(t, sale.Lines, 2)
+	assert.Equal(t, int64(2250), sale.TotalCents)
+
+	resp = ts.doJSONWithAuth(t, http.MethodDelete, salePath+"/lines/"+sale.Lines[1].ID, nil, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sale)
+	assert.True(t, sale.Lines[1].Voided)
+	assert.Equal(t, int64(1350), sale.TotalCents)
+
+	// Step 4: The customer pays 10.00 by card and 5.00 in cash, and gets
+	// 1.50 change. The sale is numbered and its stock is taken.
+	resp = ts.postJSONWithAuth(t, salePath+"/payments", dto.PaymentRequest{Method: "card", AmountCents: 1000, Reference: "A1B2C3"}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sale)
+	assert.Equal(t, "open", sale.Status)
+	assert.Equal(t, int64(350), sale.BalanceCents)
+
+	resp = ts.postJSONWithAuth(t, salePath+"/payments", dto.PaymentRequest{Method: "cash", AmountCents: 500}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sale)
+	assert.Equal(t, "completed", sale.Status)
+	assert.Equal(t, int64(150), sale.ChangeCents)
+	assert.NotEmpty(t, sale.ReceiptNumber)
+	assert.Equal(t, int64(1250), ts.onHand(t, managerToken, branchID))
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, salePath+"/receipt", nil, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	receipt, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Contains(t, string(receipt), "("+sale.ReceiptNumber+")")
+
+	// Step 5: A third of the chops come back and are refunded in cash.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/sessions/"+session.ID+"/returns", dto.ReturnRequest{
+		ReceiptNumber: sale.ReceiptNumber,
+		Items:         []dto.ReturnItemRequest{{LineID: sale.Lines[0].ID, Grams: 250}},
+		Method:        "cash",
+		Reason:        "Too much",
+	}, cashierToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var ret dto.SaleResponse
+	parseJSON(t, resp, &ret)
+	assert.Equal(t, "return", ret.Kind)
+	assert.Equal(t, int64(450), ret.TotalCents)
+
+	// Step 6: A second sale is paid for and then voided; only a manager
+	// may do so, and the stock goes back.
+	second := startSale()
+	secondPath := "/api/v1/admin/pos/sales/" + second.ID
+	resp = ts.postJSONWithAuth(t, secondPath+"/lines", dto.SaleLineRequest{PLUCode: 123, Grams: 500}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, secondPath+"/payments", dto.PaymentRequest{Method: "cash", AmountCents: 900}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, int64(750), ts.onHand(t, managerToken, branchID))
+
+	resp = ts.postJSONWithAuth(t, secondPath+"/void", dto.VoidSaleRequest{Reason: "Rung up twice"}, cashierToken)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, secondPath+"/void", dto.VoidSaleRequest{Reason: "Rung up twice"}, managerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &second)
+	assert.Equal(t, "voided", second.Status)
+	assert.Equal(t, int64(1250), ts.onHand(t, managerToken, branchID))
+
+	// Step 7: The till cannot be closed while a sale is being rung up.
+	third := startSale()
+	closePath := "/api/v1/admin/pos/sessions/" + session.ID + "/close"
+	resp = ts.postJSONWithAuth(t, closePath, dto.CloseSessionRequest{CountedCashCents: 4850}, cashierToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/sales/"+third.ID+"/void", dto.VoidSaleRequest{Reason: "Customer left"}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 8: The drawer should hold the float, plus 3.50 cash taken, less
+	// 4.50 refunded; it is 0.50 short.
+	resp = ts.postJSONWithAuth(t, closePath, dto.CloseSessionRequest{CountedCashCents: 4850}, cashierToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var z dto.TillReportResponse
+	parseJSON(t, resp, &z)
+	assert.Equal(t, 1, z.SalesCount)
+	assert.Equal(t, int64(1350), z.SalesCents)
+	assert.Equal(t, 1, z.ReturnsCount)
+	assert.Equal(t, int64(450), z.ReturnsCents)
+	assert.Equal(t, 2, z.VoidedSalesCount)
+	assert.Equal(t, 1, z.VoidedLinesCount)
+	assert.Equal(t, int64(350), z.CashSalesCents)
+	assert.Equal(t, int64(1000), z.CardSalesCents)
+	assert.Equal(t, int64(4900), z.ExpectedCashCents)
+	require.NotNil(t, z.VarianceCents)
+	assert.Equal(t, int64(-50), *z.VarianceCents)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/pos/sessions/"+session.ID+"/sales", nil, cashierToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// onHand returns the grams of the branch's only product in stock.
+func (ts *testServer) onHand(t *testing.T, adminToken, branchID string) int64 {
+	t.Helper()
+	resp := ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/stock?branch_id="+branchID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var levels []dto.StockLevelResponse
+	parseJSON(t, resp, &levels)
+	require.Len(t, levels, 1)
+	return levels[0].OnHandGrams
+}
@@ -0,0 +1,121 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationProcurement_OrderToShelf(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	branchID, lamb, beef := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	// Step 1: Admin adds the supplier.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/suppliers", dto.SupplierRequest{
+		Name:             "Green Valley Farm",
+		Type:             "farm",
+		Contacts:         []dto.SupplierContact{{Name: "Amina Khan", Email: "amina@greenvalley.example"}},
+		Certificates:     []dto.SupplierCertificate{{Type: "halal", Number: "HMC-1042", ExpiresOn: "2027-03-31"}},
+		PaymentTermsDays: 30,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var supplier dto.SupplierResponse
+	parseJSON(t, resp, &supplier)
+
+	// Step 2: A purchase order is drafted, revised and sent.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/purchase-orders", dto.CreatePurchaseOrderRequest{
+		SupplierID: supplier.ID,
+		BranchID:   branchID,
+		Lines:      []dto.PurchaseOrderLine{{ProductID: lamb, OrderedGrams: 20000, PricePerKgCents: 1200}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var po dto.PurchaseOrderResponse
+	parseJSON(t, resp, &po)
+	assert.Equal(t, "draft", po.Status)
+
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/purchase-orders/"+po.ID, dto.RevisePurchaseOrderRequest{
+		Lines: []dto.PurchaseOrderLine{
+			{ProductID: lamb, OrderedGrams: 20000, PricePerKgCents: 1150},
+			{ProductID: beef, OrderedGrams: 10000, PricePerKgCents: 1500},
+		},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &po)
+	assert.Equal(t, int64(38000), po.TotalCents)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/purchase-orders/"+po.ID+"/send", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &po)
+	assert.Equal(t, "sent", po.Status)
+
+	// Step 3: A sent order can no longer be revised.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/purchase-orders/"+po.ID, dto.RevisePurchaseOrderRequest{
+		Lines: []dto.PurchaseOrderLine{{ProductID: lamb, OrderedGrams: 1000, PricePerKgCents: 1}},
+	}, adminToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: The lamb arrives a little short; a delivery that is too warm to
+	// be plausible is rejected.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/purchase-orders/"+po.ID+"/receipts", dto.ReceiveGoodsRequest{
+		Lines: []dto.GoodsReceivedLine{{LineID: po.Lines[0].ID, LotCode: "GV-0412", Grams: 19400, TemperatureCelsius: 45}},
+	}, adminToken)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/purchase-orders/"+po.ID+"/receipts", dto.ReceiveGoodsRequest{
+		Lines: []dto.GoodsReceivedLine{{LineID: po.Lines[0].ID, LotCode: "GV-0412", Grams: 19400, TemperatureCelsius: 3.2}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var grn dto.GoodsReceivedNoteResponse
+	parseJSON(t, resp, &grn)
+	require.Len(t, grn.Lines, 1)
+	assert.Equal(t, lamb, grn.Lines[0].ProductID)
+	assert.InDelta(t, 3.2, grn.Lines[0].TemperatureCelsius, 0.001)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/purchase-orders/"+po.ID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &po)
+	assert.Equal(t, "partially_received", po.Status)
+	assert.Equal(t, int64(600), po.Lines[0].OutstandingGrams)
+
+	// Step 5: The delivered lamb is on the shelf.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/stock?branch_id="+branchID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var levels []dto.StockLevelResponse
+	parseJSON(t, resp, &levels)
+	require.Len(t, levels, 1)
+	assert.Equal(t, lamb, levels[0].ProductID)
+	assert.Equal(t, int64(19400), levels[0].OnHandGrams)
+
+	// Step 6: The beef never comes, so the order is closed and shows up in
+	// the variance report.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/purchase-orders/"+po.ID+"/close", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/procurement/variances?supplier_id="+supplier.ID, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report dto.VarianceReportResponse
+	parseJSON(t, resp, &report)
+	require.Len(t, report.Suppliers, 1)
+	assert.Equal(t, int64(-10600), report.Suppliers[0].VarianceGrams)
+	assert.Equal(t, int64(-15690), report.Suppliers[0].VarianceCents)
+	require.Len(t, report.Lines, 2)
+
+	// Step 7: The agreed prices are in the supplier's price history.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/suppliers/"+supplier.ID+"/prices?product_id="+lamb, nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var prices []dto.PricePointResponse
+	parseJSON(t, resp, &prices)
+	require.Len(t, prices, 1)
+	assert.Equal(t, int64(1150), prices[0].PricePerKgCents)
+}
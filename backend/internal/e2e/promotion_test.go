@@ -0,0 +1,130 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPromotions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	// Step 1: Products are classified for tax and for promotions.
+	lamb, mince := uuid.NewString(), uuid.NewString()
+	ts.setTaxCategory(t, adminToken, lamb, "fresh_meat")
+	ts.setTaxCategory(t, adminToken, mince, "fresh_meat")
+	for id, profile := range map[string]dto.PromotionProductRequest{
+		lamb:  {Category: "roasting", Species: "lamb"},
+		mince: {Category: "mince", Species: "beef"},
+	} {
+		resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/promotions/products/"+id, profile, adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+	resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/promotions/products/"+lamb,
+		dto.PromotionProductRequest{Species: "pork"}, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: The weekend offers are set up, plus a welcome coupon.
+	create := func(req dto.PromotionRequest) dto.PromotionResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/promotions", req, adminToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var p dto.PromotionResponse
+		parseJSON(t, resp, &p)
+		return p
+	}
+	weekendEnds := time.Now().UTC().Add(48 * time.Hour).Format(time.RFC3339)
+	lambOffer := create(dto.PromotionRequest{
+		Name: "10% off lamb", Kind: "percentage", PercentBP: 1000, Species: []string{"lamb"}, EndsAt: weekendEnds,
+	})
+	create(dto.PromotionRequest{
+		Name: "Buy 2kg mince get 500g free", Kind: "free_weight", BuyGrams: 2000, FreeGrams: 500,
+		Categories: []string{"mince"}, EndsAt: weekendEnds,
+	})
+	welcome := create(dto.PromotionRequest{
+		Name: "Welcome", Code: "welcome5", Kind: "first_order", AmountCents: 500, MaxUsesPerCustomer: 1,
+	})
+	assert.Equal(t, "WELCOME5", welcome.Code)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/promotions", dto.PromotionRequest{
+		Name: "Copy", Code: "WELCOME5", Kind: "free_delivery",
+	}, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: A quote applies the weekend offers; the welcome coupon waits
+	// for checkout, where the customer is known.
+	cart := []dto.CartLine{
+		{ProductID: lamb, Grams: 1000, PricePerKgCents: 2000},
+		{ProductID: mince, Grams: 2500, PricePerKgCents: 1000},
+	}
+	resp = ts.postJSON(t, "/api/v1/cart/quote", dto.CartQuoteRequest{Lines: cart, Coupons: []string{"welcome5"}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quote dto.CartQuoteResponse
+	parseJSON(t, resp, &quote)
+	require.Len(t, quote.Discounts, 2)
+	assert.Equal(t, int64(200), quote.Lines[0].DiscountCents)
+	assert.Equal(t, int64(500), quote.Lines[1].DiscountCents)
+	assert.Equal(t, int64(3800), quote.GrossCents)
+	require.Len(t, quote.RejectedCoupons, 1)
+	assert.Equal(t, "WELCOME5", quote.RejectedCoupons[0].Code)
+
+	// Step 4: A new customer's first order gets the coupon too, shared
+	// across what the weekend offers left.
+	ts.registerAndLoginCustomer(t, "promo@example.com")
+	var customerID uuid.UUID
+	err := ts.pool.QueryRow(context.Background(), `SELECT id FROM customers WHERE email = $1`, "promo@example.com").Scan(&customerID)
+	require.NoError(t, err)
+	order := dto.CreateOrderRequest{
+		CustomerID: customerID.String(),
+		BranchID:   uuid.NewString(),
+		Lines: []dto.OrderLine{
+			{ProductID: lamb, Description: "Lamb leg", Grams: 1000, PricePerKgCents: 2000},
+			{ProductID: mince, Description: "Beef mince", Grams: 2500, PricePerKgCents: 1000},
+		},
+		Coupons: []string{"WELCOME5"},
+	}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", order, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var o dto.OrderResponse
+	parseJSON(t, resp, &o)
+	assert.Equal(t, int64(3300), o.TotalCents)
+	assert.Equal(t, int64(437), o.Lines[0].DiscountCents)
+	assert.Equal(t, int64(763), o.Lines[1].DiscountCents)
+
+	// Step 5: The coupon is refused on their next order.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/orders", order, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 6: Switching the lamb offer off takes it out of quotes.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/promotions/"+lambOffer.ID, dto.PromotionRequest{
+		Name: "10% off lamb", Kind: "percentage", PercentBP: 1000, Species: []string{"lamb"}, Active: new(bool),
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSON(t, "/api/v1/cart/quote", dto.CartQuoteRequest{Lines: cart})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &quote)
+	assert.Equal(t, int64(0), quote.Lines[0].DiscountCents)
+	assert.Equal(t, int64(4000), quote.GrossCents)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/promotions", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var promotions []dto.PromotionResponse
+	parseJSON(t, resp, &promotions)
+	assert.Len(t, promotions, 3)
+}
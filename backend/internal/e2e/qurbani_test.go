@@ -0,0 +1,186 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationQurbani(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	// Step 1: One cow is bought in for the season, shared seven ways.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/qurbani/offerings", dto.QurbaniOfferingRequest{
+		Season: "2027", ShareType: "cow_share", PriceCents: 9500, Animals: 1,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var offering dto.QurbaniOfferingResponse
+	parseJSON(t, resp, &offering)
+	assert.Equal(t, 7, offering.Capacity)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/qurbani/offerings", dto.QurbaniOfferingRequest{
+		Season: "2027", ShareType: "cow_share", PriceCents: 9000, Animals: 2,
+	}, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: Two families book the whole cow between them.
+	familyToken := ts.registerAndLoginCustomer(t, "family@example.com")
+	neighbourToken := ts.registerAndLoginCustomer(t, "neighbour@example.com")
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/addresses", dto.AddressRequest{
+		Label: "Home", Line1: "1 High Street", City: "London", Postcode: "E1 6AN",
+	}, neighbourToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var address dto.AddressResponse
+	parseJSON(t, resp, &address)
+
+	book := func(token, name, distribution, addressID string) *http.Response {
+		t.Helper()
+		return ts.postJSONWithAuth(t, "/api/v1/me/qurbani/bookings", dto.QurbaniBookingRequest{
+			OfferingID: offering.ID, NiyyahName: name, Distribution: distribution, AddressID: addressID,
+		}, token)
+	}
+
+	resp = book(neighbourToken, "Omar", "delivery", "")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	for _, name := range []string{"Ahmed", "Fatima", "Yusuf"} {
+		resp = book(familyToken, name, "charity", "")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+	}
+	resp = book(familyToken, "Maryam", "collection", "")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var cancelled dto.QurbaniBookingResponse
+	parseJSON(t, resp, &cancelled)
+	for _, name := range []string{"Omar", "Aisha", "Bilal"} {
+		resp = book(neighbourToken, name, "delivery", address.ID)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	// Step 3: The cow is full until a share is cancelled.
+	resp = book(neighbourToken, "Zainab", "charity", "")
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/qurbani/bookings/"+cancelled.ID+"/cancel", nil, neighbourToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/qurbani/bookings/"+cancelled.ID+"/cancel", nil, familyToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = book(neighbourToken, "Zainab", "charity", "")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/qurbani/offerings?season=2027", nil, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var offerings []dto.QurbaniOfferingResponse
+	parseJSON(t, resp, &offerings)
+	require.Len(t, offerings, 1)
+	assert.Equal(t, 0, offerings[0].Remaining)
+
+	// Step 4: The shares are grouped onto the cow and it is put in a batch.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/qurbani/offerings/"+offering.ID+"/allocation", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var alloc dto.QurbaniAllocationResponse
+	parseJSON(t, resp, &alloc)
+	assert.Equal(t, 1, alloc.AnimalsOpened)
+	assert.Len(t, alloc.Allocated, 7)
+
+	startsAt := time.Date(2027, 5, 16, 7, 0, 0, 0, time.UTC)
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/qurbani/batches", dto.QurbaniBatchPlanRequest{
+		Season: "2027", StartsAt: startsAt, AnimalsPerBatch: 4, IntervalMinutes: 90,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var batches []dto.QurbaniBatchResponse
+	parseJSON(t, resp, &batches)
+	require.Len(t, batches, 1)
+	assert.Equal(t, 1, batches[0].Number)
+	assert.True(t, startsAt.Equal(batches[0].ScheduledAt))
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/qurbani/animals?season=2027", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sheet []dto.QurbaniAnimalResponse
+	parseJSON(t, resp, &sheet)
+	require.Len(t, sheet, 1)
+	assert.Equal(t, 1, sheet[0].Number)
+	require.NotNil(t, sheet[0].BatchNumber)
+	assert.Equal(t, 1, *sheet[0].BatchNumber)
+	assert.Len(t, sheet[0].NiyyahNames, 7)
+	assert.Equal(t, 0, sheet[0].FreeShares)
+
+	// Step 5: Completing the batch tells every customer, once.
+	path := "/api/v1/admin/qurbani/batches/" + batches[0].ID
+	resp = ts.postJSONWithAuth(t, path+"/notifications", nil, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, path+"/complete", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var completion dto.QurbaniBatchCompletionResponse
+	parseJSON(t, resp, &completion)
+	assert.Equal(t, 7, completion.Slaughtered)
+	assert.Equal(t, 7, completion.Notified)
+	assert.Zero(t, completion.Failed)
+	assert.NotNil(t, completion.Batch.CompletedAt)
+
+	resp = ts.postJSONWithAuth(t, path+"/complete", nil, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, path+"/notifications", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var again dto.QurbaniNotificationResponse
+	parseJSON(t, resp, &again)
+	assert.Zero(t, again.Notified)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/qurbani/bookings?season=2027", nil, neighbourToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var mine []dto.QurbaniBookingResponse
+	parseJSON(t, resp, &mine)
+	require.Len(t, mine, 4)
+	for _, b := range mine {
+		assert.Equal(t, "slaughtered", b.Status)
+		assert.NotNil(t, b.NotifiedAt)
+	}
+
+	// Step 6: The charity list names the four charity shares; deliveries
+	// carry the address.
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/qurbani/distribution?season=2027", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var charity []dto.QurbaniDistributionLineResponse
+	parseJSON(t, resp, &charity)
+	require.Len(t, charity, 4)
+	for _, l := range charity {
+		assert.Equal(t, "charity", l.Distribution)
+		require.NotNil(t, l.BatchNumber)
+		assert.Equal(t, 1, *l.BatchNumber)
+	}
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/qurbani/distribution?season=2027&distribution=delivery", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var deliveries []dto.QurbaniDistributionLineResponse
+	parseJSON(t, resp, &deliveries)
+	require.Len(t, deliveries, 3)
+	require.NotNil(t, deliveries[0].Address)
+	assert.Equal(t, "E1 6AN", deliveries[0].Address.Postcode)
+
+	// Step 7: A share cannot be cancelled once the animal is slaughtered.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/qurbani/bookings/"+mine[0].ID+"/cancel", nil, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+}
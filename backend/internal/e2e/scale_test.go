@@ -0,0 +1,243 @@
+package e2e_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/scaleio"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationScales_WeighTareAndStreamFromSimulator(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	counterSim := startSimulator(t, scale.ProtocolMTSICS)
+	backSim := startSimulator(t, scale.ProtocolCAS)
+
+	// Step 1: The counter scale speaks MT-SICS over TCP; the back room's
+	// sends CAS continuous output.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/scales", dto.ScaleRequest{
+		Name: "Counter", Protocol: "mt-sics", Transport: "tcp", Address: counterSim.address,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var counter dto.ScaleResponse
+	parseJSON(t, resp, &counter)
+	assert.True(t, counter.Active)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/scales", dto.ScaleRequest{
+		Name: "Back room", Protocol: "cas", Transport: "tcp", Address: backSim.address,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var back dto.ScaleResponse
+	parseJSON(t, resp, &back)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/scales", dto.ScaleRequest{
+		Name: "counter", Protocol: "cas", Transport: "tcp", Address: backSim.address,
+	}, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/scales", dto.ScaleRequest{
+		Name: "Window", Protocol: "cas", Transport: "serial", Address: "/etc/passwd",
+	}, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 2: A tray goes on the counter scale and is tared off, then the
+	// meat is weighed once it settles.
+	counterSim.Place(300)
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/scales/"+counter.ID+"/tare", nil, adminToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	counterSim.Place(1550)
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/scales/"+counter.ID+"/reading", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var reading dto.ScaleReadingResponse
+	parseJSON(t, resp, &reading)
+	assert.Equal(t, dto.ScaleReadingResponse{Grams: 1250, TareGrams: 300, GrossGrams: 1550, Stable: true, At: reading.At}, reading)
+
+	// Step 3: The weighing screen streams the scale and sees more meat
+	// added settle at its new weight.
+	events := ts.streamScale(t, counter.ID, adminToken)
+	e := events.untilStable(t)
+	assert.Equal(t, int64(1250), e.Grams)
+
+	counterSim.Place(2000)
+	for e.Grams == 1250 {
+		e = events.untilStable(t)
+	}
+	assert.Equal(t, int64(1700), e.Grams)
+	assert.Equal(t, int64(300), e.TareGrams)
+
+	// Step 4: An overload is reported on the stream without ending it.
+	counterSim.Place(scaleio.SimulatorCapacityGrams + 500)
+	assert.Equal(t, scale.ErrOverload.Error(), events.untilError(t))
+	counterSim.Place(2000)
+	assert.Equal(t, int64(1700), events.untilStable(t).Grams)
+
+	// Step 5: CAS scales are tared on their keypad, which their readings
+	// pick up.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/scales/"+back.ID+"/tare", nil, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	backSim.Place(500)
+	backSim.Tare()
+	backSim.Place(2900)
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/scales/"+back.ID+"/reading", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &reading)
+	assert.Equal(t, int64(2400), reading.Grams)
+	assert.Equal(t, int64(500), reading.TareGrams)
+
+	// Step 6: When the counter scale is unplugged the stream says so and
+	// ends.
+	require.NoError(t, counterSim.Close())
+	assert.Contains(t, events.untilError(t), scale.ErrScaleDisconnected.Error())
+	events.ended(t)
+
+	// Step 7: A disabled scale is not read.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/scales/"+back.ID, dto.UpdateScaleRequest{
+		Name: "Back room", Protocol: "cas", Transport: "tcp", Address: backSim.address, Active: false,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/scales/"+back.ID+"/reading", nil, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/scales", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var scales []dto.ScaleResponse
+	parseJSON(t, resp, &scales)
+	require.Len(t, scales, 2)
+	assert.Equal(t, "Back room", scales[0].Name)
+	assert.False(t, scales[0].Active)
+}
+
+type simulatedScale struct {
+	*scaleio.Simulator
+	address string
+}
+
+// startSimulator runs a simulated scale on a local port.
+func startSimulator(t *testing.T, protocol scale.Protocol) simulatedScale {
+	t.Helper()
+	sim, err := scaleio.NewSimulator(protocol, 5*time.Millisecond)
+	require.NoError(t, err)
+	address, err := sim.Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { sim.Close() })
+	return simulatedScale{Simulator: sim, address: address}
+}
+
+// scaleEvents reads a scale's event stream.
+type scaleEvents struct {
+	reader *bufio.Reader
+}
+
+// streamScale opens a scale's reading stream as a browser's EventSource
+// would.
+func (ts *testServer) streamScale(t *testing.T, scaleID, token string) *scaleEvents {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, ts.url("/api/v1/admin/scales/"+scaleID+"/readings/stream"), nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return &scaleEvents{reader: bufio.NewReader(resp.Body)}
+}
+
+// next returns the next event's name and data, failing if none comes.
+func (s *scaleEvents) next(t *testing.T) (string, string) {
+	t.Helper()
+	type event struct {
+		name, data string
+		err        error
+	}
+	got := make(chan event, 1)
+	go func() {
+		var e event
+		for {
+			line, err := s.reader.ReadString('\n')
+			if err != nil {
+				got <- event{err: err}
+				return
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				e.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				e.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && e.name != "":
+				got <- e
+				return
+			}
+		}
+	}()
+	select {
+	case e := <-got:
+		require.NoError(t, e.err)
+		return e.name, e.data
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event on stream")
+		return "", ""
+	}
+}
+
+// untilStable skips to the next stable reading.
+func (s *scaleEvents) untilStable(t *testing.T) dto.ScaleReadingResponse {
+	t.Helper()
+	for range 200 {
+		name, data := s.next(t)
+		if name != "reading" {
+			continue
+		}
+		var r dto.ScaleReadingResponse
+		require.NoError(t, json.Unmarshal([]byte(data), &r))
+		if r.Stable {
+			return r
+		}
+	}
+	t.Fatal("scale never settled")
+	return dto.ScaleReadingResponse{}
+}
+
+// untilError skips to the next error event and returns its message.
+func (s *scaleEvents) untilError(t *testing.T) string {
+	t.Helper()
+	for range 200 {
+		name, data := s.next(t)
+		if name != "error" {
+			continue
+		}
+		var e dto.ScaleErrorEvent
+		require.NoError(t, json.Unmarshal([]byte(data), &e))
+		return e.Error
+	}
+	t.Fatal("no error on stream")
+	return ""
+}
+
+// ended checks that the server has closed the stream.
+func (s *scaleEvents) ended(t *testing.T) {
+	t.Helper()
+	_, err := s.reader.ReadString('\n')
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+package e2e_test
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationStaff_RotaClockAndTimesheets(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	token := ts.loginAdmin(t)
+	staffToken := ts.loginAdminWithRole(t, "staff")
+
+	// Step 1: The manager and a member of counter staff are put on the rota
+	// of a branch.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/branches", dto.BranchRequest{
+		Name: "Whitechapel", Address: []string{"1 High Street", "London"},
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var b dto.BranchResponse
+	parseJSON(t, resp, &b)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/admins", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var admins []dto.AdminResponse
+	parseJSON(t, resp, &admins)
+	ids := make(map[string]string)
+	for _, a := range admins {
+		ids[a.Email] = a.ID
+	}
+	managerID, staffID := ids[testAdminEmail], ids["staff@butchery.com"]
+	require.NotEmpty(t, managerID)
+	require.NotEmpty(t, staffID)
+
+	setProfile := func(adminID string, req dto.StaffProfileRequest) {
+		t.Helper()
+		resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/staff/"+adminID, req, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+	setProfile(managerID, dto.StaffProfileRequest{
+		BranchID: b.ID, Position: "butcher", HourlyRateCents: 1500, ContractedMinutes: 60, Active: true,
+	})
+	setProfile(staffID, dto.StaffProfileRequest{BranchID: b.ID, Position: "cashier", HourlyRateCents: 1200, Active: true})
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/staff", nil, staffToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "pay is for managers")
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/staff?branch_id="+b.ID, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var profiles []dto.StaffProfileResponse
+	parseJSON(t, resp, &profiles)
+	assert.Len(t, profiles, 2)
+
+	// Step 2: A week's shifts are planned. The cashier cannot be put on the
+	// block, and conflicts do not stop shifts being scheduled.
+	day := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+	schedule := func(req dto.ShiftRequest) *http.Response {
+		t.Helper()
+		req.BranchID = b.ID
+		return ts.postJSONWithAuth(t, "/api/v1/admin/staff/shifts", req, token)
+	}
+	resp = schedule(dto.ShiftRequest{AdminID: staffID, Position: "butcher", StartsAt: day.Add(8 * time.Hour), EndsAt: day.Add(12 * time.Hour)})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	shift := func(req dto.ShiftRequest) dto.ShiftResponse {
+		t.Helper()
+		resp := schedule(req)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var s dto.ShiftResponse
+		parseJSON(t, resp, &s)
+		return s
+	}
+	long := shift(dto.ShiftRequest{AdminID: managerID, StartsAt: day.Add(8 * time.Hour), EndsAt: day.Add(17 * time.Hour)})
+	assert.Equal(t, "butcher", long.Position)
+	shift(dto.ShiftRequest{AdminID: staffID, StartsAt: day.Add(9 * time.Hour), EndsAt: day.Add(13 * time.Hour)})
+	overlap := shift(dto.ShiftRequest{AdminID: staffID, StartsAt: day.Add(12 * time.Hour), EndsAt: day.Add(15 * time.Hour)})
+
+	rota := func() dto.RotaResponse {
+		t.Helper()
+		resp := ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/staff/rota?branch_id="+b.ID+"&week=2026-11-12", nil, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var r dto.RotaResponse
+		parseJSON(t, resp, &r)
+		return r
+	}
+	r := rota()
+	assert.Equal(t, time.Date(2026, 11, 9, 0, 0, 0, 0, time.UTC), r.WeekStart.UTC())
+	assert.Len(t, r.Shifts, 3)
+	kinds := make(map[string]int)
+	for _, c := range r.Conflicts {
+		kinds[c.Kind]++
+	}
+	assert.Equal(t, map[string]int{"overtime": 1, "missing_break": 1, "double_booked": 1}, kinds)
+
+	// Step 3: Giving the long shift a break and moving the overlapping one
+	// leaves only the overtime.
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/staff/shifts/"+long.ID, dto.ShiftRequest{
+		BranchID: b.ID, StartsAt: day.Add(8 * time.Hour), EndsAt: day.Add(17 * time.Hour), BreakMinutes: 30,
+	}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.doJSONWithAuth(t, http.MethodDelete, "/api/v1/admin/staff/shifts/"+overlap.ID, nil, token)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.doJSONWithAuth(t, http.MethodDelete, "/api/v1/admin/staff/shifts/"+overlap.ID, nil, token)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	r = rota()
+	require.Len(t, r.Conflicts, 1)
+	assert.Equal(t, "overtime", r.Conflicts[0].Kind)
+	assert.Equal(t, 510-60, r.Conflicts[0].Minutes)
+
+	// Step 4: The cashier clocks in, takes a break and clocks out.
+	clock := func(path string, want int) dto.ClockEntryResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/staff/clock/"+path, nil, staffToken)
+		require.Equal(t, want, resp.StatusCode)
+		var e dto.ClockEntryResponse
+		if want < http.StatusBadRequest {
+			parseJSON(t, resp, &e)
+		} else {
+			resp.Body.Close()
+		}
+		return e
+	}
+	in := clock("in", http.StatusCreated)
+	assert.Equal(t, b.ID, in.BranchID, "staff clock in at their own branch")
+	clock("in", http.StatusConflict)
+	assert.True(t, clock("break/start", http.StatusOK).OnBreak)
+	clock("break/start", http.StatusConflict)
+	clock("break/end", http.StatusOK)
+	out := clock("out", http.StatusOK)
+	require.NotNil(t, out.ClockedOutAt)
+	assert.Len(t, out.Breaks, 1)
+	clock("out", http.StatusNotFound)
+
+	// Step 5: The timesheets show the entry, and export for payroll.
+	today := time.Now().UTC().Format(time.DateOnly)
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/staff/timesheets?from="+today+"&to="+today+"&admin_id="+staffID, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sheets []dto.TimesheetResponse
+	parseJSON(t, resp, &sheets)
+	require.Len(t, sheets, 1)
+	assert.Equal(t, staffID, sheets[0].Staff.AdminID)
+	require.Len(t, sheets[0].Entries, 1)
+	assert.Equal(t, in.ID, sheets[0].Entries[0].ID)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/staff/timesheets?from="+today+"&to=2020-01-01", nil, token)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/staff/timesheets/export?from="+today+"&to="+today, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "timesheets-"+today)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	rows, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4, "header, the cashier's entry and a total each")
+	assert.Equal(t, "Whitechapel", rows[1][2])
+}
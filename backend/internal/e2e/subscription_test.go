@@ -0,0 +1,177 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	subscriptioncmd "github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationSubscription_RenewSkipSwapAndDunning(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	branchID := uuid.NewString()
+	shoulder, leg, mince := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	first := time.Now().UTC().AddDate(0, 0, 10)
+	firstDate := first.Format(time.DateOnly)
+
+	// Step 1: Open the branch, add a delivery slot and stock the cuts.
+	days := make([]dto.OpeningHoursDay, 0, 7)
+	for weekday := 0; weekday < 7; weekday++ {
+		days = append(days, dto.OpeningHoursDay{Weekday: weekday, OpensAt: "08:00", ClosesAt: "18:00"})
+	}
+	resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/branches/"+branchID+"/opening-hours",
+		dto.SetOpeningHoursRequest{Days: days}, adminToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/fulfilment/slot-templates", dto.CreateSlotTemplateRequest{
+		BranchID:     branchID,
+		Method:       "delivery",
+		Weekday:      int(first.Weekday()),
+		StartsAt:     "10:00",
+		EndsAt:       "12:00",
+		CapacityUnit: "orders",
+		Capacity:     10,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var tmpl dto.IDResponse
+	parseJSON(t, resp, &tmpl)
+
+	for code, productID := range map[string]string{"SHOULDER-01": shoulder, "LEG-01": leg, "MINCE-01": mince} {
+		ts.setTaxCategory(t, adminToken, productID, "fresh_meat")
+		resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+			ProductID: productID, BranchID: branchID, Code: code, Grams: 20000,
+			ExpiresOn: first.AddDate(0, 0, 30).Format(time.DateOnly),
+		}, adminToken)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	// Step 2: Admin adds a customizable box where leg may replace shoulder.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/subscriptions/boxes", dto.SubscriptionBoxRequest{
+		Name:         "Family lamb box",
+		Customizable: true,
+		Items: []dto.SubscriptionItemRequest{
+			{
+				SubscriptionCutRequest: dto.SubscriptionCutRequest{ProductID: shoulder, Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 1800},
+				Substitutes:            []dto.SubscriptionCutRequest{{ProductID: leg, Description: "Lamb leg", Grams: 1500, PricePerKgCents: 2000}},
+			},
+			{SubscriptionCutRequest: dto.SubscriptionCutRequest{ProductID: mince, Description: "Lamb mince", Grams: 1000, PricePerKgCents: 1200}},
+		},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var box dto.SubscriptionBoxResponse
+	parseJSON(t, resp, &box)
+
+	resp = ts.get(t, "/api/v1/subscriptions/boxes")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var boxes []dto.SubscriptionBoxResponse
+	parseJSON(t, resp, &boxes)
+	require.Len(t, boxes, 1)
+
+	// Step 3: A customer subscribes weekly, skips the second week and swaps
+	// shoulder for leg.
+	customerToken := ts.registerAndLoginCustomer(t, "box@example.com")
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/subscriptions", dto.SubscribeRequest{
+		BoxID: box.ID, Cadence: "weekly", TemplateID: tmpl.ID, FirstDelivery: firstDate, PaymentMethod: "pm_card_visa",
+	}, customerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var sub dto.SubscriptionResponse
+	parseJSON(t, resp, &sub)
+	assert.Equal(t, firstDate, sub.NextDelivery)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/subscriptions/"+sub.ID+"/skips",
+		dto.SkipDeliveryRequest{Date: first.AddDate(0, 0, 7).Format(time.DateOnly)}, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sub)
+	require.NotEmpty(t, sub.Upcoming)
+	assert.Equal(t, []string{firstDate, first.AddDate(0, 0, 14).Format(time.DateOnly)}, sub.Upcoming[:2])
+
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/me/subscriptions/"+sub.ID+"/swaps",
+		dto.SwapCutRequest{ProductID: shoulder, SubstituteID: leg}, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sub)
+	require.Len(t, sub.Swaps, 1)
+
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/me/subscriptions/"+sub.ID+"/swaps",
+		dto.SwapCutRequest{ProductID: mince, SubstituteID: leg}, customerToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: A second customer subscribes with a card that will be declined.
+	declinedToken := ts.registerAndLoginCustomer(t, "declined@example.com")
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/subscriptions", dto.SubscribeRequest{
+		BoxID: box.ID, Cadence: "fortnightly", TemplateID: tmpl.ID, FirstDelivery: firstDate, PaymentMethod: "pm_card_chargeDeclined",
+	}, declinedToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var declinedSub dto.SubscriptionResponse
+	parseJSON(t, resp, &declinedSub)
+
+	// Step 5: The scheduler runs a day before the cutoff. One order goes
+	// through and one payment is declined.
+	slotStart := time.Date(first.Year(), first.Month(), first.Day(), 10, 0, 0, 0, time.UTC)
+	runAt := slotStart.Add(-26 * time.Hour)
+	run := func(at time.Time) *subscriptioncmd.RunRenewalsResult {
+		t.Helper()
+		result, err := ts.renewals.Handle(context.Background(), at)
+		require.NoError(t, err)
+		return result
+	}
+	result := run(runAt)
+	assert.Equal(t, 2, result.Generated)
+	assert.Equal(t, 1, result.Ordered)
+	assert.Equal(t, 1, result.Declined)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/subscriptions/"+sub.ID+"/renewals", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var renewals []dto.SubscriptionRenewalResponse
+	parseJSON(t, resp, &renewals)
+	require.Len(t, renewals, 1)
+	assert.Equal(t, "ordered", renewals[0].Status)
+	assert.Equal(t, firstDate, renewals[0].DeliveryDate)
+	require.Len(t, renewals[0].Cuts, 2)
+	assert.Equal(t, leg, renewals[0].Cuts[0].ProductID)
+
+	// Running again does not generate the same delivery twice.
+	result = run(runAt.Add(time.Minute))
+	assert.Equal(t, 0, result.Generated)
+	assert.Equal(t, 0, result.Declined)
+
+	// Step 6: Each retry is declined until they run out and the renewal fails.
+	result = run(runAt.Add(time.Hour))
+	assert.Equal(t, 1, result.Declined)
+	result = run(runAt.Add(7 * time.Hour))
+	assert.Equal(t, 1, result.Failed)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/subscriptions/renewals?status=failed", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &renewals)
+	require.Len(t, renewals, 1)
+	assert.Equal(t, declinedSub.ID, renewals[0].SubscriptionID)
+	assert.Equal(t, 3, renewals[0].Attempts)
+
+	// Step 7: The customer pauses and resumes their subscription.
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/subscriptions/"+sub.ID+"/pause", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sub)
+	assert.Equal(t, "paused", sub.Status)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/me/subscriptions/"+sub.ID+"/resume", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &sub)
+	assert.Equal(t, "active", sub.Status)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/me/subscriptions", nil, customerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var mine []dto.SubscriptionResponse
+	parseJSON(t, resp, &mine)
+	require.Len(t, mine, 1)
+}
@@ -0,0 +1,97 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationTax(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+
+	// Step 1: The rates seeded by the migration are listed.
+	resp := ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/tax/rates", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var rates []dto.TaxRateResponse
+	parseJSON(t, resp, &rates)
+	require.Len(t, rates, 3)
+	assert.Equal(t, "delivery", rates[0].Category)
+
+	// Step 2: A rate change for tomorrow does not affect today's prices, and
+	// cannot be added twice. Only managers may change rates.
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+	change := dto.CreateTaxRateRequest{Category: "prepared_food", RateBP: 500, EffectiveFrom: tomorrow}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/tax/rates", change, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/tax/rates", change, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/tax/rates", dto.CreateTaxRateRequest{
+		Category: "delivery", RateBP: 0, EffectiveFrom: tomorrow,
+	}, ts.loginStaffAdmin(t))
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: Products are classified; unknown categories are refused.
+	lamb, mince := uuid.NewString(), uuid.NewString()
+	ts.setTaxCategory(t, adminToken, lamb, "fresh_meat")
+	ts.setTaxCategory(t, adminToken, mince, "prepared_food")
+	resp = ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/tax/products/"+lamb,
+		dto.ProductTaxCategoryRequest{Category: "fresh_meet"}, adminToken)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 4: A cart with delivery is priced line by line.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/delivery-zones", dto.DeliveryZoneRequest{
+		Name: "East", PostcodePrefixes: []string{"E1"}, DeliveryFeeCents: 499,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var zone dto.DeliveryZoneResponse
+	parseJSON(t, resp, &zone)
+	assert.Equal(t, "delivery", zone.FeeTaxCategory)
+
+	resp = ts.postJSON(t, "/api/v1/cart/quote", dto.CartQuoteRequest{
+		Lines: []dto.CartLine{
+			{ProductID: lamb, Grams: 1500, PricePerKgCents: 2000},
+			{ProductID: mince, Grams: 800, PricePerKgCents: 1125},
+		},
+		DeliveryZoneID: &zone.ID,
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quote dto.CartQuoteResponse
+	parseJSON(t, resp, &quote)
+	require.Len(t, quote.Lines, 2)
+	assert.Equal(t, int64(0), quote.Lines[0].VATCents)
+	assert.Equal(t, int64(150), quote.Lines[1].VATCents)
+	require.NotNil(t, quote.Delivery)
+	assert.Equal(t, int64(83), quote.Delivery.VATCents)
+	assert.Equal(t, int64(4399), quote.GrossCents)
+	assert.Equal(t, int64(233), quote.VATCents)
+
+	// Step 5: Unclassified products cannot be priced.
+	resp = ts.postJSON(t, "/api/v1/cart/quote", dto.CartQuoteRequest{
+		Lines: []dto.CartLine{{ProductID: uuid.NewString(), Grams: 100, PricePerKgCents: 1000}},
+	})
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// setTaxCategory classifies a product for tax.
+func (ts *testServer) setTaxCategory(t *testing.T, adminToken, productID, category string) {
+	t.Helper()
+	resp := ts.doJSONWithAuth(t, http.MethodPut, "/api/v1/admin/tax/products/"+productID,
+		dto.ProductTaxCategoryRequest{Category: category}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
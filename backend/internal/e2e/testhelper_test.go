@@ -21,13 +21,79 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	admincmd "github.com/katerji/butchery-app/backend/internal/application/admin/commands"
+	auditqry "github.com/katerji/butchery-app/backend/internal/application/audit/queries"
 	authcmd "github.com/katerji/butchery-app/backend/internal/application/auth/commands"
+	branchcmd "github.com/katerji/butchery-app/backend/internal/application/branch/commands"
+	branchqry "github.com/katerji/butchery-app/backend/internal/application/branch/queries"
+	businesscmd "github.com/katerji/butchery-app/backend/internal/application/business/commands"
+	businessqry "github.com/katerji/butchery-app/backend/internal/application/business/queries"
+	carcmd "github.com/katerji/butchery-app/backend/internal/application/carcass/commands"
+	carqry "github.com/katerji/butchery-app/backend/internal/application/carcass/queries"
 	custcmd "github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	custqry "github.com/katerji/butchery-app/backend/internal/application/customer/queries"
+	delivcmd "github.com/katerji/butchery-app/backend/internal/application/delivery/commands"
+	delivqry "github.com/katerji/butchery-app/backend/internal/application/delivery/queries"
+	dispatchcmd "github.com/katerji/butchery-app/backend/internal/application/dispatch/commands"
+	dispatchqry "github.com/katerji/butchery-app/backend/internal/application/dispatch/queries"
+	fulfilcmd "github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	fulfilqry "github.com/katerji/butchery-app/backend/internal/application/fulfilment/queries"
+	haccpcmd "github.com/katerji/butchery-app/backend/internal/application/haccp/commands"
+	haccpqry "github.com/katerji/butchery-app/backend/internal/application/haccp/queries"
+	invcmd "github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	invqry "github.com/katerji/butchery-app/backend/internal/application/inventory/queries"
+	invoicecmd "github.com/katerji/butchery-app/backend/internal/application/invoice/commands"
+	invoiceqry "github.com/katerji/butchery-app/backend/internal/application/invoice/queries"
+	labelcmd "github.com/katerji/butchery-app/backend/internal/application/label/commands"
+	labelqry "github.com/katerji/butchery-app/backend/internal/application/label/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/labelling"
+	loyaltycmd "github.com/katerji/butchery-app/backend/internal/application/loyalty/commands"
+	loyaltyqry "github.com/katerji/butchery-app/backend/internal/application/loyalty/queries"
+	ordercmd "github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	orderqry "github.com/katerji/butchery-app/backend/internal/application/order/queries"
+	paycmd "github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	payqry "github.com/katerji/butchery-app/backend/internal/application/payment/queries"
+	plucmd "github.com/katerji/butchery-app/backend/internal/application/plu/commands"
+	pluqry "github.com/katerji/butchery-app/backend/internal/application/plu/queries"
+	poscmd "github.com/katerji/butchery-app/backend/internal/application/pos/commands"
+	posqry "github.com/katerji/butchery-app/backend/internal/application/pos/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/pricing"
+	proccmd "github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	procqry "github.com/katerji/butchery-app/backend/internal/application/procurement/queries"
+	promocmd "github.com/katerji/butchery-app/backend/internal/application/promotion/commands"
+	promoqry "github.com/katerji/butchery-app/backend/internal/application/promotion/queries"
+	qurbanicmd "github.com/katerji/butchery-app/backend/internal/application/qurbani/commands"
+	qurbaniqry "github.com/katerji/butchery-app/backend/internal/application/qurbani/queries"
+	scalecmd "github.com/katerji/butchery-app/backend/internal/application/scale/commands"
+	scaleqry "github.com/katerji/butchery-app/backend/internal/application/scale/queries"
+	staffcmd "github.com/katerji/butchery-app/backend/internal/application/staff/commands"
+	staffqry "github.com/katerji/butchery-app/backend/internal/application/staff/queries"
+	subscriptioncmd "github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	subscriptionqry "github.com/katerji/butchery-app/backend/internal/application/subscription/queries"
+	taxcmd "github.com/katerji/butchery-app/backend/internal/application/tax/commands"
+	taxqry "github.com/katerji/butchery-app/backend/internal/application/tax/queries"
+	tracecmd "github.com/katerji/butchery-app/backend/internal/application/traceability/commands"
+	traceqry "github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/tracking"
+	"github.com/katerji/butchery-app/backend/internal/application/weighing"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
 	infraauth "github.com/katerji/butchery-app/backend/internal/infrastructure/auth"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/csvreport"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/notification"
+	infrapayment "github.com/katerji/butchery-app/backend/internal/infrastructure/payment"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/pdf"
 	pgrepo "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/printer"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/scaleio"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/zpl"
 	apphttp "github.com/katerji/butchery-app/backend/internal/interface/http"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
 	"github.com/katerji/butchery-app/backend/internal/interface/http/handler"
 	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
 	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
 )
 
@@ -35,16 +101,54 @@ const (
 	testJWTSecret     = "e2e-test-secret-that-is-long-enough-for-hmac-sha256"
 	testAdminEmail    = "admin@butchery.com"
 	testAdminPassword = "admin123"
+	testSlotHoldTTL   = 15 * time.Minute
+	testSlotLeadTime  = 2 * time.Hour
+	testStockHoldTTL  = 30 * time.Minute
+
+	testCertificateExpiryWarning = 30 * 24 * time.Hour
+
+	testPaymentCurrency       = "gbp"
+	testAuthorizationMarginBP = 1500
+	testWebhookSecret         = "whsec_e2e"
+
+	testRefundApprovalThreshold = 1000
+
+	testShopName      = "E2E Butchery Ltd"
+	testShopVATNumber = "GB123456789"
+
+	testSubscriptionGenerateAhead = 72 * time.Hour
+	testSubscriptionMaxFailures   = 2
+
+	testPrintTimeout     = 2 * time.Second
+	testPrintRetryAfter  = 30 * time.Second
+	testPrintMaxAttempts = 2
+
+	testScaleReadTimeout     = 2 * time.Second
+	testScalePollInterval    = 5 * time.Millisecond
+	testScaleStableWindow    = 20 * time.Millisecond
+	testScaleStableTolerance = 2
+	testScaleWeighTimeout    = 3 * time.Second
+
+	testSensorKey = "e2e-sensor-key"
 )
 
+// testStaffPolicy wants a 20 minute break in shifts over 6 hours and a
+// butcher for every 2 orders, so small tests can fill a slot.
+var testStaffPolicy = staff.Policy{BreakAfter: 6 * time.Hour, MinBreak: 20 * time.Minute, OrdersPerButcher: 2, KgPerButcher: 80}
+
 func init() {
 	os.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
 }
 
 // testServer wraps an httptest.Server and pgxpool.Pool for e2e tests.
+// payments is the in-process gateway the server charges cards through.
 type testServer struct {
-	server *httptest.Server
-	pool   *pgxpool.Pool
+	server   *httptest.Server
+	pool     *pgxpool.Pool
+	payments *infrapayment.FakeGateway
+	// renewals is the job the API server runs on a ticker; tests call it
+	// directly to run it as of a chosen time.
+	renewals *subscriptioncmd.RunRenewalsHandler
 }
 
 // setupTestServer starts a PostgreSQL testcontainer with all migrations,
@@ -74,6 +178,35 @@ func setupTestServer(t *testing.T, accessTokenTTL time.Duration) *testServer {
 			filepath.Join(migrationsDir, "V2__create_customers_table.sql"),
 			filepath.Join(migrationsDir, "V3__create_refresh_tokens_table.sql"),
 			filepath.Join(migrationsDir, "V4__seed_admin.sql"),
+			filepath.Join(migrationsDir, "V5__create_fulfilment_tables.sql"),
+			filepath.Join(migrationsDir, "V6__create_addresses_and_delivery_zones.sql"),
+			filepath.Join(migrationsDir, "V7__create_inventory_tables.sql"),
+			filepath.Join(migrationsDir, "V8__create_carcass_tables.sql"),
+			filepath.Join(migrationsDir, "V9__create_traceability_tables.sql"),
+			filepath.Join(migrationsDir, "V10__create_procurement_tables.sql"),
+			filepath.Join(migrationsDir, "V11__create_payment_tables.sql"),
+			filepath.Join(migrationsDir, "V12__create_order_refund_and_audit_tables.sql"),
+			filepath.Join(migrationsDir, "V13__create_invoice_tables.sql"),
+			filepath.Join(migrationsDir, "V14__create_tax_tables.sql"),
+			filepath.Join(migrationsDir, "V15__create_promotion_tables.sql"),
+			filepath.Join(migrationsDir, "V16__create_loyalty_tables.sql"),
+			filepath.Join(migrationsDir, "V17__create_qurbani_tables.sql"),
+			filepath.Join(migrationsDir, "V18__create_subscription_tables.sql"),
+			filepath.Join(migrationsDir, "V19__create_business_tables.sql"),
+			filepath.Join(migrationsDir, "V20__create_plu_items.sql"),
+			filepath.Join(migrationsDir, "V21__create_label_printing_tables.sql"),
+			filepath.Join(migrationsDir, "V22__create_scales_table.sql"),
+			filepath.Join(migrationsDir, "V23__create_pos_tables.sql"),
+			filepath.Join(migrationsDir, "V24__create_branch_tables.sql"),
+			filepath.Join(migrationsDir, "V25__create_haccp_tables.sql"),
+			filepath.Join(migrationsDir, "V26__create_waste_tables.sql"),
+			filepath.Join(migrationsDir, "V27__create_staff_tables.sql"),
+			filepath.Join(migrationsDir, "V28__create_dispatch_tables.sql"),
+			filepath.Join(migrationsDir, "V29__create_order_events.sql"),
+			filepath.Join(migrationsDir, "V30__position_order_events.sql"),
+			filepath.Join(migrationsDir, "V31__add_order_delivery_charge.sql"),
+			filepath.Join(migrationsDir, "V32__allow_partial_loyalty_reversals.sql"),
+			filepath.Join(migrationsDir, "V33__create_order_line_lots.sql"),
 		),
 		testcontainers.WithWaitStrategy(
 			wait.ForLog("database system is ready to accept connections").
@@ -101,10 +234,73 @@ func setupTestServer(t *testing.T, accessTokenTTL time.Duration) *testServer {
 	adminRepo := pgrepo.NewAdminRepository(pool)
 	customerRepo := pgrepo.NewCustomerRepository(pool)
 	refreshTokenRepo := pgrepo.NewRefreshTokenRepository(pool)
+	fulfilmentScheduleRepo := pgrepo.NewFulfilmentScheduleRepository(pool)
+	slotReservationRepo := pgrepo.NewSlotReservationRepository(pool)
+	customerAddressRepo := pgrepo.NewCustomerAddressRepository(pool)
+	deliveryZoneRepo := pgrepo.NewDeliveryZoneRepository(pool)
+	inventoryStockRepo := pgrepo.NewInventoryStockRepository(pool)
+	inventoryWasteRepo := pgrepo.NewInventoryWasteRepository(pool)
+	stockReservationRepo := pgrepo.NewStockReservationRepository(pool)
+	carcassRepo := pgrepo.NewCarcassRepository(pool)
+	yieldTemplateRepo := pgrepo.NewYieldTemplateRepository(pool)
+	halalCertificateRepo := pgrepo.NewHalalCertificateRepository(pool)
+	lotOriginRepo := pgrepo.NewLotOriginRepository(pool)
+	supplierRepo := pgrepo.NewSupplierRepository(pool)
+	purchaseOrderRepo := pgrepo.NewPurchaseOrderRepository(pool)
+	paymentRepo := pgrepo.NewPaymentRepository(pool)
+	orderRepo := pgrepo.NewOrderRepository(pool)
+	orderEventRepo := pgrepo.NewOrderEventRepository(pool)
+	auditRepo := pgrepo.NewAuditRepository(pool)
+	invoiceRepo := pgrepo.NewInvoiceRepository(pool)
+	taxRateRepo := pgrepo.NewTaxRateRepository(pool)
+	productTaxCategoryRepo := pgrepo.NewProductTaxCategoryRepository(pool)
+	promotionRepo := pgrepo.NewPromotionRepository(pool)
+	promotionProductRepo := pgrepo.NewPromotionProductRepository(pool)
+	promotionRedemptionRepo := pgrepo.NewPromotionRedemptionRepository(pool)
+	loyaltyLedger := pgrepo.NewLoyaltyLedgerRepository(pool)
+	loyaltyRulesRepo := pgrepo.NewLoyaltyRulesRepository(pool)
+	loyaltyMemberRepo := pgrepo.NewLoyaltyMemberRepository(pool)
+	qurbaniOfferingRepo := pgrepo.NewQurbaniOfferingRepository(pool)
+	qurbaniBookingRepo := pgrepo.NewQurbaniBookingRepository(pool)
+	qurbaniSlaughterRepo := pgrepo.NewQurbaniSlaughterRepository(pool)
+	subscriptionBoxRepo := pgrepo.NewSubscriptionBoxRepository(pool)
+	subscriptionRepo := pgrepo.NewSubscriptionRepository(pool)
+	subscriptionRenewalRepo := pgrepo.NewSubscriptionRenewalRepository(pool)
+	businessAccountRepo := pgrepo.NewBusinessAccountRepository(pool)
+	businessMemberRepo := pgrepo.NewBusinessMemberRepository(pool)
+	businessLedger := pgrepo.NewBusinessLedger(pool)
+	pluItemRepo := pgrepo.NewPLUItemRepository(pool)
+	labelPrinterRepo := pgrepo.NewLabelPrinterRepository(pool)
+	printJobRepo := pgrepo.NewPrintJobRepository(pool)
+	scaleRepo := pgrepo.NewScaleRepository(pool)
+	posTillRepo := pgrepo.NewPosTillRepository(pool)
+	posSessionRepo := pgrepo.NewPosSessionRepository(pool)
+	posSaleRepo := pgrepo.NewPosSaleRepository(pool)
+	branchRepo := pgrepo.NewBranchRepository(pool)
+	haccpUnitRepo := pgrepo.NewHACCPUnitRepository(pool)
+	haccpLogRepo := pgrepo.NewHACCPLogRepository(pool)
+	staffProfileRepo := pgrepo.NewStaffProfileRepository(pool)
+	staffShiftRepo := pgrepo.NewStaffShiftRepository(pool)
+	staffClockRepo := pgrepo.NewStaffClockRepository(pool)
+	driverRepo := pgrepo.NewDriverRepository(pool)
+	deliveryRunRepo := pgrepo.NewDeliveryRunRepository(pool)
+	deliveryProofRepo := pgrepo.NewDeliveryProofRepository(pool)
 
 	// Infrastructure services
 	passwordHasher := infraauth.NewBcryptHasher()
 	tokenService := infraauth.NewTokenService(testJWTSecret, accessTokenTTL)
+	paymentGateway := infrapayment.NewFakeGateway(testWebhookSecret)
+	invoiceRenderer := pdf.NewInvoiceRenderer(time.UTC)
+	zplLabelRenderer := zpl.NewLabelRenderer()
+	pdfLabelRenderer := pdf.NewLabelRenderer()
+	printTransport := printer.NewRawTransport(testPrintTimeout)
+	scaleConnector := scaleio.NewConnector(testScaleReadTimeout, testScalePollInterval)
+	pricer := pricing.NewPricer(taxRateRepo, productTaxCategoryRepo,
+		tax.Calculator{Mode: tax.ModeInclusive, Rounding: tax.RoundPerLine}, time.UTC)
+	discounter := pricing.NewDiscounter(promotionRepo, promotionProductRepo, promotionRedemptionRepo, orderRepo)
+	rewards := pricing.NewRewards(loyaltyRulesRepo, loyaltyLedger)
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	notifier := notification.NewLogNotifier(logger)
 
 	// Use case handlers
 	adminLoginHandler := admincmd.NewAdminLoginHandler(adminRepo, passwordHasher, tokenService, refreshTokenRepo, accessTokenTTL)
@@ -112,29 +308,420 @@ func setupTestServer(t *testing.T, accessTokenTTL time.Duration) *testServer {
 	customerLoginHandler := custcmd.NewCustomerLoginHandler(customerRepo, passwordHasher, tokenService, refreshTokenRepo, accessTokenTTL)
 	refreshTokenHandler := authcmd.NewRefreshTokenHandler(refreshTokenRepo, tokenService, accessTokenTTL)
 	logoutHandler := authcmd.NewLogoutHandler(refreshTokenRepo)
+	setOpeningHoursHandler := fulfilcmd.NewSetOpeningHoursHandler(fulfilmentScheduleRepo)
+	addClosureHandler := fulfilcmd.NewAddClosureHandler(fulfilmentScheduleRepo, time.UTC)
+	createSlotTemplateHandler := fulfilcmd.NewCreateSlotTemplateHandler(fulfilmentScheduleRepo, deliveryZoneRepo)
+	reserveSlotHandler := fulfilcmd.NewReserveSlotHandler(fulfilmentScheduleRepo, slotReservationRepo, testSlotHoldTTL, testSlotLeadTime, time.UTC)
+	releaseReservationHandler := fulfilcmd.NewReleaseReservationHandler(slotReservationRepo)
+	listAvailableSlotsHandler := fulfilqry.NewListAvailableSlotsHandler(fulfilmentScheduleRepo, slotReservationRepo, testSlotLeadTime, time.UTC)
+	listAddressesHandler := custqry.NewListAddressesHandler(customerAddressRepo)
+	addAddressHandler := custcmd.NewAddAddressHandler(customerAddressRepo)
+	updateAddressHandler := custcmd.NewUpdateAddressHandler(customerAddressRepo)
+	deleteAddressHandler := custcmd.NewDeleteAddressHandler(customerAddressRepo)
+	setDefaultAddressHandler := custcmd.NewSetDefaultAddressHandler(customerAddressRepo)
+	listZonesHandler := delivqry.NewListZonesHandler(deliveryZoneRepo)
+	createZoneHandler := delivcmd.NewCreateZoneHandler(deliveryZoneRepo)
+	updateZoneHandler := delivcmd.NewUpdateZoneHandler(deliveryZoneRepo)
+	validateDeliveryAddressHandler := delivqry.NewValidateDeliveryAddressHandler(customerAddressRepo, deliveryZoneRepo)
+	receiveLotHandler := invcmd.NewReceiveLotHandler(inventoryStockRepo)
+	recordMovementHandler := invcmd.NewRecordMovementHandler(inventoryStockRepo)
+	stockTakeHandler := invcmd.NewStockTakeHandler(inventoryStockRepo)
+	setLowStockThresholdHandler := invcmd.NewSetLowStockThresholdHandler(inventoryStockRepo)
+	reserveStockHandler := invcmd.NewReserveStockHandler(stockReservationRepo, testStockHoldTTL)
+	releaseStockHandler := invcmd.NewReleaseStockHandler(stockReservationRepo)
+	fulfilStockHandler := invcmd.NewFulfilStockHandler(stockReservationRepo)
+	listStockLevelsHandler := invqry.NewListStockLevelsHandler(inventoryStockRepo)
+	listMovementsHandler := invqry.NewListMovementsHandler(inventoryStockRepo)
+	expiryPolicy := inventory.ExpiryPolicy{MarkdownDays: 2, MarkdownBP: 3000}
+	recordWasteHandler := invcmd.NewRecordWasteHandler(inventoryStockRepo)
+	setUseByHandler := invcmd.NewSetUseByHandler(inventoryStockRepo, inventoryWasteRepo)
+	scanExpiryHandler := invcmd.NewScanExpiryHandler(inventoryWasteRepo, notifier, expiryPolicy, time.UTC)
+	acceptExpiryProposalHandler := invcmd.NewAcceptExpiryProposalHandler(inventoryStockRepo, inventoryWasteRepo)
+	dismissExpiryProposalHandler := invcmd.NewDismissExpiryProposalHandler(inventoryStockRepo, inventoryWasteRepo)
+	listExpiryProposalsHandler := invqry.NewListExpiryProposalsHandler(inventoryWasteRepo)
+	shrinkageReportHandler := invqry.NewShrinkageReportHandler(inventoryWasteRepo, time.UTC)
+	recordIntakeHandler := carcmd.NewRecordIntakeHandler(carcassRepo)
+	recordBreakdownHandler := carcmd.NewRecordBreakdownHandler(carcassRepo)
+	setYieldTemplateHandler := carcmd.NewSetYieldTemplateHandler(yieldTemplateRepo)
+	listCarcassesHandler := carqry.NewListCarcassesHandler(carcassRepo)
+	yieldReportHandler := carqry.NewYieldReportHandler(carcassRepo, yieldTemplateRepo)
+	listYieldTemplatesHandler := carqry.NewListYieldTemplatesHandler(yieldTemplateRepo)
+	registerCertificateHandler := tracecmd.NewRegisterCertificateHandler(halalCertificateRepo)
+	recordOriginHandler := tracecmd.NewRecordOriginHandler(lotOriginRepo)
+	listCertificatesHandler := traceqry.NewListCertificatesHandler(halalCertificateRepo, testCertificateExpiryWarning)
+	lotTraceHandler := traceqry.NewLotTraceHandler(lotOriginRepo)
+	consumedLotsHandler := traceqry.NewConsumedLotsHandler(lotOriginRepo)
+	createSupplierHandler := proccmd.NewCreateSupplierHandler(supplierRepo)
+	updateSupplierHandler := proccmd.NewUpdateSupplierHandler(supplierRepo)
+	listSuppliersHandler := procqry.NewListSuppliersHandler(supplierRepo)
+	priceHistoryHandler := procqry.NewPriceHistoryHandler(supplierRepo, purchaseOrderRepo)
+	createPurchaseOrderHandler := proccmd.NewCreatePurchaseOrderHandler(supplierRepo, purchaseOrderRepo)
+	revisePurchaseOrderHandler := proccmd.NewRevisePurchaseOrderHandler(purchaseOrderRepo)
+	sendPurchaseOrderHandler := proccmd.NewSendPurchaseOrderHandler(purchaseOrderRepo)
+	closePurchaseOrderHandler := proccmd.NewClosePurchaseOrderHandler(purchaseOrderRepo)
+	receiveGoodsHandler := proccmd.NewReceiveGoodsHandler(purchaseOrderRepo)
+	listPurchaseOrdersHandler := procqry.NewListPurchaseOrdersHandler(purchaseOrderRepo)
+	getPurchaseOrderHandler := procqry.NewGetPurchaseOrderHandler(purchaseOrderRepo)
+	listReceiptsHandler := procqry.NewListReceiptsHandler(purchaseOrderRepo)
+	varianceReportHandler := procqry.NewVarianceReportHandler(purchaseOrderRepo)
+	authorizePaymentHandler := paycmd.NewAuthorizePaymentHandler(orderRepo, paymentRepo, paymentGateway, testPaymentCurrency, testAuthorizationMarginBP)
+	capturePaymentHandler := paycmd.NewCapturePaymentHandler(orderRepo, paymentRepo, paymentGateway)
+	voidPaymentHandler := paycmd.NewVoidPaymentHandler(paymentRepo, paymentGateway)
+	handleWebhookHandler := paycmd.NewHandleWebhookHandler(paymentRepo, paymentGateway)
+	getPaymentHandler := payqry.NewGetPaymentHandler(paymentRepo)
+	createOrderHandler := ordercmd.NewCreateOrderHandler(customerRepo, orderRepo, branchRepo, pricer, discounter, rewards)
+	getOrderHandler := orderqry.NewGetOrderHandler(orderRepo)
+	requestRefundHandler := ordercmd.NewRequestRefundHandler(orderRepo, adminRepo, paymentRepo, paymentGateway, auditRepo, rewards, testRefundApprovalThreshold)
+	approveRefundHandler := ordercmd.NewApproveRefundHandler(orderRepo, adminRepo, paymentRepo, paymentGateway, auditRepo, rewards)
+	rejectRefundHandler := ordercmd.NewRejectRefundHandler(orderRepo, adminRepo, auditRepo)
+	refundReceiptHandler := orderqry.NewGetRefundReceiptHandler(orderRepo, paymentRepo)
+	advanceStageHandler := ordercmd.NewAdvanceStageHandler(orderRepo, stockReservationRepo, rewards)
+	cancelOrderHandler := ordercmd.NewCancelOrderHandler(orderRepo, stockReservationRepo, slotReservationRepo, paymentRepo, paymentGateway, discounter, rewards, auditRepo)
+	placeOrderHandler := ordercmd.NewPlaceOrderHandler(createOrderHandler, cancelOrderHandler, stockReservationRepo, slotReservationRepo,
+		fulfilmentScheduleRepo, pluItemRepo, customerAddressRepo, deliveryZoneRepo)
+	orderTracker := tracking.NewTracker(orderEventRepo, pgrepo.NewOrderEventFeed(pool))
+	watchOrdersHandler := orderqry.NewWatchOrdersHandler(orderTracker)
+	listAuditEntriesHandler := auditqry.NewListEntriesHandler(auditRepo)
+	invoiceSettings := invoicecmd.Settings{
+		Seller:               invoice.Party{Name: testShopName, Address: []string{"1 High Street"}, VATNumber: testShopVATNumber},
+		Currency:             testPaymentCurrency,
+		FiscalYearStartMonth: time.April,
+		Location:             time.UTC,
+	}
+	issueInvoiceHandler := invoicecmd.NewIssueInvoiceHandler(orderRepo, customerRepo, invoiceRepo, invoiceRenderer, auditRepo, invoiceSettings)
+	issueCreditNoteHandler := invoicecmd.NewIssueCreditNoteHandler(orderRepo, invoiceRepo, invoiceRenderer, auditRepo, invoiceSettings)
+	listOrderDocumentsHandler := invoiceqry.NewListOrderDocumentsHandler(invoiceRepo)
+	listCustomerDocumentsHandler := invoiceqry.NewListCustomerDocumentsHandler(invoiceRepo)
+	getDocumentHandler := invoiceqry.NewGetDocumentHandler(invoiceRepo)
+	createTaxRateHandler := taxcmd.NewCreateRateHandler(taxRateRepo, adminRepo)
+	assignProductTaxCategoryHandler := taxcmd.NewAssignProductCategoryHandler(taxRateRepo, productTaxCategoryRepo, adminRepo)
+	listTaxRatesHandler := taxqry.NewListRatesHandler(taxRateRepo)
+	quoteCartHandler := taxqry.NewQuoteCartHandler(pricer, discounter, deliveryZoneRepo)
+	createPromotionHandler := promocmd.NewCreatePromotionHandler(promotionRepo, adminRepo)
+	updatePromotionHandler := promocmd.NewUpdatePromotionHandler(promotionRepo, adminRepo)
+	classifyPromotionProductHandler := promocmd.NewClassifyProductHandler(promotionProductRepo, adminRepo)
+	listPromotionsHandler := promoqry.NewListPromotionsHandler(promotionRepo)
+	getLoyaltyRulesHandler := loyaltyqry.NewGetRulesHandler(loyaltyRulesRepo)
+	updateLoyaltyRulesHandler := loyaltycmd.NewUpdateRulesHandler(loyaltyRulesRepo, adminRepo)
+	setProductBonusHandler := loyaltycmd.NewSetProductBonusHandler(loyaltyRulesRepo, adminRepo)
+	getLoyaltyAccountHandler := loyaltyqry.NewGetAccountHandler(loyaltyLedger, loyaltyRulesRepo, loyaltyMemberRepo)
+	setBirthdayHandler := loyaltycmd.NewSetBirthdayHandler(loyaltyMemberRepo)
+	adjustPointsHandler := loyaltycmd.NewAdjustPointsHandler(customerRepo, loyaltyLedger, rewards, adminRepo)
+	rebuildBalanceHandler := loyaltycmd.NewRebuildBalanceHandler(customerRepo, loyaltyLedger)
+	expirePointsHandler := loyaltycmd.NewExpirePointsHandler(loyaltyLedger, rewards)
+	awardBirthdayBonusesHandler := loyaltycmd.NewAwardBirthdayBonusesHandler(loyaltyMemberRepo, loyaltyLedger, rewards, time.UTC)
+	createQurbaniOfferingHandler := qurbanicmd.NewCreateOfferingHandler(qurbaniOfferingRepo)
+	updateQurbaniOfferingHandler := qurbanicmd.NewUpdateOfferingHandler(qurbaniOfferingRepo, qurbaniBookingRepo)
+	listQurbaniOfferingsHandler := qurbaniqry.NewListOfferingsHandler(qurbaniOfferingRepo, qurbaniBookingRepo)
+	bookQurbaniShareHandler := qurbanicmd.NewBookShareHandler(qurbaniOfferingRepo, qurbaniBookingRepo, customerAddressRepo)
+	listQurbaniBookingsHandler := qurbaniqry.NewListBookingsHandler(qurbaniBookingRepo)
+	cancelQurbaniBookingHandler := qurbanicmd.NewCancelBookingHandler(qurbaniBookingRepo)
+	allocateQurbaniSharesHandler := qurbanicmd.NewAllocateSharesHandler(qurbaniOfferingRepo, qurbaniBookingRepo, qurbaniSlaughterRepo)
+	slaughterSheetHandler := qurbaniqry.NewSlaughterSheetHandler(qurbaniOfferingRepo, qurbaniBookingRepo, qurbaniSlaughterRepo)
+	planQurbaniBatchesHandler := qurbanicmd.NewPlanBatchesHandler(qurbaniSlaughterRepo)
+	listQurbaniBatchesHandler := qurbaniqry.NewListBatchesHandler(qurbaniSlaughterRepo)
+	completeQurbaniBatchHandler := qurbanicmd.NewCompleteBatchHandler(qurbaniSlaughterRepo, qurbaniOfferingRepo,
+		qurbaniBookingRepo, customerRepo, notifier)
+	notifyQurbaniBatchHandler := qurbanicmd.NewNotifyBatchHandler(qurbaniSlaughterRepo, qurbaniOfferingRepo,
+		qurbaniBookingRepo, customerRepo, notifier)
+	qurbaniDistributionHandler := qurbaniqry.NewDistributionListHandler(qurbaniOfferingRepo, qurbaniBookingRepo,
+		qurbaniSlaughterRepo, customerRepo, customerAddressRepo)
+	subscriptionSettings := subscriptioncmd.Settings{
+		Location:              time.UTC,
+		LeadTime:              testSlotLeadTime,
+		GenerateAhead:         testSubscriptionGenerateAhead,
+		RetryIntervals:        []time.Duration{time.Hour, 6 * time.Hour},
+		MaxFailedRenewals:     testSubscriptionMaxFailures,
+		Currency:              testPaymentCurrency,
+		AuthorizationMarginBP: testAuthorizationMarginBP,
+	}
+	createSubscriptionBoxHandler := subscriptioncmd.NewCreateBoxHandler(subscriptionBoxRepo)
+	updateSubscriptionBoxHandler := subscriptioncmd.NewUpdateBoxHandler(subscriptionBoxRepo)
+	listSubscriptionBoxesHandler := subscriptionqry.NewListBoxesHandler(subscriptionBoxRepo)
+	subscribeHandler := subscriptioncmd.NewSubscribeHandler(subscriptionBoxRepo, subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	listSubscriptionsHandler := subscriptionqry.NewListSubscriptionsHandler(subscriptionRepo)
+	skipDeliveryHandler := subscriptioncmd.NewSkipDeliveryHandler(subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	swapCutHandler := subscriptioncmd.NewSwapCutHandler(subscriptionRepo, subscriptionBoxRepo)
+	changeSubscriptionSlotHandler := subscriptioncmd.NewChangeSlotHandler(subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	changePaymentMethodHandler := subscriptioncmd.NewChangePaymentMethodHandler(subscriptionRepo)
+	pauseSubscriptionHandler := subscriptioncmd.NewPauseSubscriptionHandler(subscriptionRepo)
+	resumeSubscriptionHandler := subscriptioncmd.NewResumeSubscriptionHandler(subscriptionRepo, fulfilmentScheduleRepo, subscriptionSettings)
+	cancelSubscriptionHandler := subscriptioncmd.NewCancelSubscriptionHandler(subscriptionRepo)
+	listRenewalsHandler := subscriptionqry.NewListRenewalsHandler(subscriptionRenewalRepo)
+	runRenewalsHandler := subscriptioncmd.NewRunRenewalsHandler(subscriptionRepo, subscriptionRenewalRepo, subscriptionBoxRepo,
+		fulfilmentScheduleRepo, slotReservationRepo, stockReservationRepo, orderRepo, paymentRepo, paymentGateway,
+		customerRepo, pricer, notifier, subscriptionSettings)
+	createBusinessAccountHandler := businesscmd.NewCreateAccountHandler(businessAccountRepo)
+	updateBusinessAccountHandler := businesscmd.NewUpdateAccountHandler(businessAccountRepo)
+	setBusinessPricesHandler := businesscmd.NewSetPricesHandler(businessAccountRepo)
+	listBusinessAccountsHandler := businessqry.NewListAccountsHandler(businessAccountRepo)
+	getBusinessAccountHandler := businessqry.NewGetAccountHandler(businessAccountRepo)
+	listBusinessMembersHandler := businessqry.NewListMembersHandler(businessAccountRepo, businessMemberRepo)
+	saveBusinessMemberHandler := businesscmd.NewSaveMemberHandler(businessAccountRepo, businessMemberRepo, customerRepo)
+	removeBusinessMemberHandler := businesscmd.NewRemoveMemberHandler(businessMemberRepo)
+	recordBusinessPaymentHandler := businesscmd.NewRecordPaymentHandler(businessAccountRepo, businessLedger)
+	getBusinessMembershipHandler := businessqry.NewGetMembershipHandler(businessAccountRepo, businessMemberRepo)
+	placeBusinessOrderHandler := businesscmd.NewPlaceOrderHandler(businessAccountRepo, businessMemberRepo, businessLedger,
+		orderRepo, pricer, time.UTC)
+	decideBusinessOrderHandler := businesscmd.NewDecideOrderHandler(businessAccountRepo, businessMemberRepo, businessLedger,
+		orderRepo, pricer, time.UTC)
+	listBusinessOrdersHandler := businessqry.NewListOrdersHandler(businessLedger)
+	businessStatementHandler := businessqry.NewStatementHandler(businessAccountRepo, businessLedger, time.UTC)
+	barcodeLayouts, err := barcode.ParseLayouts([]string{"20IIIIIWWWWWC", "21IIIIIPPPPPC", "22IIIIIVPPPPC"})
+	require.NoError(t, err)
+	createPLUItemHandler := plucmd.NewCreateItemHandler(pluItemRepo)
+	updatePLUItemHandler := plucmd.NewUpdateItemHandler(pluItemRepo)
+	listPLUItemsHandler := pluqry.NewListItemsHandler(pluItemRepo)
+	encodeBarcodeHandler := pluqry.NewEncodeBarcodeHandler(pluItemRepo, barcodeLayouts)
+	resolveBarcodeHandler := pluqry.NewResolveBarcodeHandler(pluItemRepo, barcodeLayouts)
+	labeller := labelling.NewLabeller(orderRepo, inventoryStockRepo, lotOriginRepo, pluItemRepo, barcodeLayouts,
+		"standard", testPaymentCurrency, time.UTC)
+	printSettings := labelcmd.Settings{
+		Lease:       2 * testPrintTimeout,
+		RetryAfter:  testPrintRetryAfter,
+		MaxAttempts: testPrintMaxAttempts,
+	}
+	createPrinterHandler := labelcmd.NewCreatePrinterHandler(labelPrinterRepo)
+	updatePrinterHandler := labelcmd.NewUpdatePrinterHandler(labelPrinterRepo)
+	printLabelsHandler := labelcmd.NewPrintLabelsHandler(labeller, zplLabelRenderer, labelPrinterRepo, printJobRepo,
+		printTransport, printSettings)
+	dispatchPrintJobsHandler := labelcmd.NewDispatchJobsHandler(labelPrinterRepo, printJobRepo, printTransport, printSettings)
+	retryPrintJobHandler := labelcmd.NewRetryJobHandler(labelPrinterRepo, printJobRepo, printTransport, printSettings)
+	listPrintersHandler := labelqry.NewListPrintersHandler(labelPrinterRepo)
+	listPrintJobsHandler := labelqry.NewListJobsHandler(printJobRepo)
+	renderLabelsHandler := labelqry.NewRenderLabelsHandler(labeller, zplLabelRenderer, pdfLabelRenderer)
+	scaleMonitor := weighing.NewMonitor(scaleRepo, scaleConnector, testScaleStableWindow, testScaleStableTolerance)
+	createScaleHandler := scalecmd.NewCreateScaleHandler(scaleRepo)
+	updateScaleHandler := scalecmd.NewUpdateScaleHandler(scaleRepo)
+	tareScaleHandler := scalecmd.NewTareScaleHandler(scaleMonitor)
+	listScalesHandler := scaleqry.NewListScalesHandler(scaleRepo)
+	watchScaleHandler := scaleqry.NewWatchScaleHandler(scaleMonitor)
+	weighHandler := scaleqry.NewWeighHandler(scaleMonitor, testScaleWeighTimeout)
+	receiptRenderer := pdf.NewReceiptRenderer(invoiceSettings.Seller, testPaymentCurrency, time.UTC)
+	createTillHandler := poscmd.NewCreateTillHandler(posTillRepo)
+	updateTillHandler := poscmd.NewUpdateTillHandler(posTillRepo)
+	openSessionHandler := poscmd.NewOpenSessionHandler(posTillRepo, posSessionRepo, adminRepo)
+	closeSessionHandler := poscmd.NewCloseSessionHandler(posSessionRepo, posSaleRepo, adminRepo)
+	startSaleHandler := poscmd.NewStartSaleHandler(posSessionRepo, posSaleRepo, adminRepo)
+	addSaleLineHandler := poscmd.NewAddSaleLineHandler(posSaleRepo, pluItemRepo, branchRepo, resolveBarcodeHandler, pricer, adminRepo)
+	voidSaleLineHandler := poscmd.NewVoidSaleLineHandler(posSaleRepo, pricer, adminRepo)
+	takePaymentHandler := poscmd.NewTakePaymentHandler(posSaleRepo, adminRepo)
+	voidSaleHandler := poscmd.NewVoidSaleHandler(posSessionRepo, posSaleRepo, adminRepo)
+	returnSaleHandler := poscmd.NewReturnSaleHandler(posSessionRepo, posSaleRepo, adminRepo)
+	listTillsHandler := posqry.NewListTillsHandler(posTillRepo)
+	listTillSessionsHandler := posqry.NewListSessionsHandler(posTillRepo, posSessionRepo)
+	tillReportHandler := posqry.NewSessionReportHandler(posSessionRepo, posSaleRepo)
+	getSaleHandler := posqry.NewGetSaleHandler(posSaleRepo)
+	getReceiptHandler := posqry.NewGetReceiptHandler(posSaleRepo, posTillRepo, adminRepo, receiptRenderer)
+	createBranchHandler := branchcmd.NewCreateBranchHandler(branchRepo, adminRepo)
+	updateBranchHandler := branchcmd.NewUpdateBranchHandler(branchRepo, adminRepo)
+	setBranchPriceHandler := branchcmd.NewSetPriceHandler(branchRepo, branchRepo, adminRepo)
+	deleteBranchPriceHandler := branchcmd.NewDeletePriceHandler(branchRepo, branchRepo, adminRepo)
+	assignAdminBranchesHandler := branchcmd.NewAssignAdminBranchesHandler(adminRepo, branchRepo)
+	listBranchesHandler := branchqry.NewListBranchesHandler(branchRepo)
+	getBranchHandler := branchqry.NewGetBranchHandler(branchRepo, fulfilmentScheduleRepo)
+	listBranchPricesHandler := branchqry.NewListPricesHandler(branchRepo, branchRepo)
+	listAdminsHandler := branchqry.NewListAdminsHandler(adminRepo)
+	haccpRenderers := map[haccp.Format]haccp.ReportRenderer{
+		haccp.FormatPDF: pdf.NewHACCPReportRenderer(),
+		haccp.FormatCSV: csvreport.NewHACCPReportRenderer(),
+	}
+	createHACCPUnitHandler := haccpcmd.NewCreateUnitHandler(haccpUnitRepo, branchRepo)
+	updateHACCPUnitHandler := haccpcmd.NewUpdateUnitHandler(haccpUnitRepo)
+	recordHACCPReadingHandler := haccpcmd.NewRecordReadingHandler(haccpUnitRepo, haccpLogRepo, notifier)
+	ingestHACCPReadingsHandler := haccpcmd.NewIngestReadingsHandler(haccpUnitRepo, haccpLogRepo, notifier, testSensorKey)
+	recordHACCPActionHandler := haccpcmd.NewRecordActionHandler(haccpUnitRepo, haccpLogRepo)
+	runHACCPChecksHandler := haccpcmd.NewRunChecksHandler(haccpUnitRepo, haccpLogRepo, notifier)
+	listHACCPUnitsHandler := haccpqry.NewListUnitsHandler(haccpUnitRepo)
+	listHACCPReadingsHandler := haccpqry.NewListReadingsHandler(haccpLogRepo)
+	listHACCPAlertsHandler := haccpqry.NewListAlertsHandler(haccpLogRepo)
+	exportHACCPReportHandler := haccpqry.NewExportReportHandler(haccpUnitRepo, haccpLogRepo, branchRepo, adminRepo,
+		haccpRenderers, time.UTC)
+
+	staffRenderers := map[staff.Format]staff.TimesheetRenderer{staff.FormatCSV: csvreport.NewTimesheetReportRenderer()}
+	setStaffProfileHandler := staffcmd.NewSetProfileHandler(adminRepo, branchRepo, staffProfileRepo)
+	scheduleShiftHandler := staffcmd.NewScheduleShiftHandler(adminRepo, branchRepo, staffProfileRepo, staffShiftRepo)
+	updateShiftHandler := staffcmd.NewUpdateShiftHandler(adminRepo, branchRepo, staffProfileRepo, staffShiftRepo)
+	deleteShiftHandler := staffcmd.NewDeleteShiftHandler(adminRepo, staffShiftRepo)
+	clockInHandler := staffcmd.NewClockInHandler(staffProfileRepo, staffClockRepo)
+	startBreakHandler := staffcmd.NewStartBreakHandler(staffClockRepo)
+	endBreakHandler := staffcmd.NewEndBreakHandler(staffClockRepo)
+	clockOutHandler := staffcmd.NewClockOutHandler(staffClockRepo)
+	listStaffProfilesHandler := staffqry.NewListProfilesHandler(adminRepo, staffProfileRepo)
+	rotaHandler := staffqry.NewRotaHandler(staffProfileRepo, staffShiftRepo, fulfilmentScheduleRepo, slotReservationRepo,
+		testStaffPolicy, time.UTC)
+	timesheetsHandler := staffqry.NewTimesheetsHandler(adminRepo, staffProfileRepo, staffShiftRepo, staffClockRepo,
+		testStaffPolicy, time.UTC)
+	exportTimesheetsHandler := staffqry.NewExportTimesheetsHandler(timesheetsHandler, branchRepo, staffRenderers, time.UTC)
+
+	createDriverHandler := dispatchcmd.NewCreateDriverHandler(adminRepo, branchRepo, driverRepo, passwordHasher)
+	updateDriverHandler := dispatchcmd.NewUpdateDriverHandler(adminRepo, branchRepo, driverRepo)
+	planRunHandler := dispatchcmd.NewPlanRunHandler(adminRepo, deliveryZoneRepo, fulfilmentScheduleRepo, slotReservationRepo,
+		customerAddressRepo, driverRepo, deliveryRunRepo)
+	addStopHandler := dispatchcmd.NewAddStopHandler(adminRepo, orderRepo, customerAddressRepo, deliveryRunRepo)
+	removeStopHandler := dispatchcmd.NewRemoveStopHandler(adminRepo, deliveryRunRepo)
+	optimiseRunHandler := dispatchcmd.NewOptimiseRunHandler(adminRepo, deliveryRunRepo)
+	assignDriverHandler := dispatchcmd.NewAssignDriverHandler(adminRepo, driverRepo, deliveryRunRepo)
+	startRunHandler := dispatchcmd.NewStartRunHandler(deliveryRunRepo)
+	deliverStopHandler := dispatchcmd.NewDeliverStopHandler(deliveryRunRepo, deliveryProofRepo)
+	failStopHandler := dispatchcmd.NewFailStopHandler(deliveryRunRepo, deliveryProofRepo)
+	listDriversHandler := dispatchqry.NewListDriversHandler(adminRepo, driverRepo)
+	listRunsHandler := dispatchqry.NewListRunsHandler(adminRepo, deliveryRunRepo, time.UTC)
+	getRunHandler := dispatchqry.NewGetRunHandler(adminRepo, deliveryRunRepo)
+	driverRunsHandler := dispatchqry.NewDriverRunsHandler(deliveryRunRepo, time.UTC)
+	getProofHandler := dispatchqry.NewGetProofHandler(adminRepo, deliveryProofRepo)
+	orderDeliveryHandler := dispatchqry.NewOrderDeliveryHandler(deliveryRunRepo, driverRepo)
 
 	// HTTP handlers
 	adminAuthHandler := handler.NewAdminAuthHandler(adminLoginHandler)
 	customerAuthHandler := handler.NewCustomerAuthHandler(registerCustomerHandler, customerLoginHandler)
 	authHandler := handler.NewAuthHandler(refreshTokenHandler, logoutHandler)
+	fulfilmentHandler := handler.NewFulfilmentHandler(listAvailableSlotsHandler, reserveSlotHandler, releaseReservationHandler)
+	adminFulfilmentHandler := handler.NewAdminFulfilmentHandler(setOpeningHoursHandler, addClosureHandler, createSlotTemplateHandler)
+	addressHandler := handler.NewAddressHandler(listAddressesHandler, addAddressHandler, updateAddressHandler, deleteAddressHandler, setDefaultAddressHandler)
+	deliveryHandler := handler.NewDeliveryHandler(validateDeliveryAddressHandler)
+	adminDeliveryHandler := handler.NewAdminDeliveryHandler(listZonesHandler, createZoneHandler, updateZoneHandler)
+	inventoryHandler := handler.NewInventoryHandler(reserveStockHandler, releaseStockHandler)
+	adminInventoryHandler := handler.NewAdminInventoryHandler(receiveLotHandler, recordMovementHandler, stockTakeHandler,
+		setLowStockThresholdHandler, fulfilStockHandler, listStockLevelsHandler, listMovementsHandler)
+	adminWasteHandler := handler.NewAdminWasteHandler(recordWasteHandler, setUseByHandler, scanExpiryHandler,
+		acceptExpiryProposalHandler, dismissExpiryProposalHandler, listExpiryProposalsHandler, shrinkageReportHandler)
+	adminCarcassHandler := handler.NewAdminCarcassHandler(recordIntakeHandler, recordBreakdownHandler, setYieldTemplateHandler,
+		listCarcassesHandler, yieldReportHandler, listYieldTemplatesHandler)
+	traceHandler := handler.NewTraceHandler(lotTraceHandler)
+	adminTraceabilityHandler := handler.NewAdminTraceabilityHandler(registerCertificateHandler, recordOriginHandler,
+		listCertificatesHandler, consumedLotsHandler)
+	adminSupplierHandler := handler.NewAdminSupplierHandler(createSupplierHandler, updateSupplierHandler,
+		listSuppliersHandler, priceHistoryHandler)
+	adminPurchaseOrderHandler := handler.NewAdminPurchaseOrderHandler(createPurchaseOrderHandler, revisePurchaseOrderHandler,
+		sendPurchaseOrderHandler, closePurchaseOrderHandler, receiveGoodsHandler, listPurchaseOrdersHandler,
+		getPurchaseOrderHandler, listReceiptsHandler, varianceReportHandler)
+	paymentHandler := handler.NewPaymentHandler(authorizePaymentHandler, handleWebhookHandler)
+	adminPaymentHandler := handler.NewAdminPaymentHandler(getPaymentHandler, capturePaymentHandler, voidPaymentHandler)
+	adminOrderHandler := handler.NewAdminOrderHandler(createOrderHandler, getOrderHandler, requestRefundHandler,
+		approveRefundHandler, rejectRefundHandler, refundReceiptHandler, listAuditEntriesHandler, advanceStageHandler,
+		watchOrdersHandler, cancelOrderHandler)
+	orderHandler := handler.NewOrderHandler(watchOrdersHandler, placeOrderHandler, cancelOrderHandler)
+	invoiceHandler := handler.NewInvoiceHandler(listCustomerDocumentsHandler, getDocumentHandler)
+	adminInvoiceHandler := handler.NewAdminInvoiceHandler(issueInvoiceHandler, issueCreditNoteHandler,
+		listOrderDocumentsHandler, getDocumentHandler)
+	cartHandler := handler.NewCartHandler(quoteCartHandler)
+	adminTaxHandler := handler.NewAdminTaxHandler(listTaxRatesHandler, createTaxRateHandler, assignProductTaxCategoryHandler)
+	adminPromotionHandler := handler.NewAdminPromotionHandler(listPromotionsHandler, createPromotionHandler,
+		updatePromotionHandler, classifyPromotionProductHandler)
+	loyaltyHandler := handler.NewLoyaltyHandler(getLoyaltyAccountHandler, setBirthdayHandler)
+	adminLoyaltyHandler := handler.NewAdminLoyaltyHandler(getLoyaltyRulesHandler, updateLoyaltyRulesHandler,
+		setProductBonusHandler, getLoyaltyAccountHandler, adjustPointsHandler, rebuildBalanceHandler,
+		expirePointsHandler, awardBirthdayBonusesHandler)
+	qurbaniHandler := handler.NewQurbaniHandler(listQurbaniOfferingsHandler, bookQurbaniShareHandler,
+		listQurbaniBookingsHandler, cancelQurbaniBookingHandler)
+	adminQurbaniHandler := handler.NewAdminQurbaniHandler(createQurbaniOfferingHandler, updateQurbaniOfferingHandler,
+		listQurbaniOfferingsHandler, listQurbaniBookingsHandler, cancelQurbaniBookingHandler, allocateQurbaniSharesHandler,
+		slaughterSheetHandler, planQurbaniBatchesHandler, listQurbaniBatchesHandler, completeQurbaniBatchHandler,
+		notifyQurbaniBatchHandler, qurbaniDistributionHandler)
+	subscriptionHandler := handler.NewSubscriptionHandler(listSubscriptionBoxesHandler, subscribeHandler,
+		listSubscriptionsHandler, skipDeliveryHandler, swapCutHandler, changeSubscriptionSlotHandler,
+		changePaymentMethodHandler, pauseSubscriptionHandler, resumeSubscriptionHandler, cancelSubscriptionHandler,
+		listRenewalsHandler)
+	adminSubscriptionHandler := handler.NewAdminSubscriptionHandler(createSubscriptionBoxHandler,
+		updateSubscriptionBoxHandler, listSubscriptionBoxesHandler, listSubscriptionsHandler, listRenewalsHandler,
+		runRenewalsHandler)
+	businessHandler := handler.NewBusinessHandler(getBusinessMembershipHandler, placeBusinessOrderHandler,
+		decideBusinessOrderHandler, listBusinessOrdersHandler, businessStatementHandler)
+	adminBusinessHandler := handler.NewAdminBusinessHandler(createBusinessAccountHandler, updateBusinessAccountHandler,
+		setBusinessPricesHandler, listBusinessAccountsHandler, getBusinessAccountHandler, listBusinessMembersHandler,
+		saveBusinessMemberHandler, removeBusinessMemberHandler, recordBusinessPaymentHandler, listBusinessOrdersHandler,
+		businessStatementHandler)
+	adminBarcodeHandler := handler.NewAdminBarcodeHandler(createPLUItemHandler, updatePLUItemHandler,
+		listPLUItemsHandler, encodeBarcodeHandler, resolveBarcodeHandler)
+	adminLabelHandler := handler.NewAdminLabelHandler(createPrinterHandler, updatePrinterHandler, printLabelsHandler,
+		dispatchPrintJobsHandler, retryPrintJobHandler, listPrintersHandler, listPrintJobsHandler, renderLabelsHandler)
+	adminScaleHandler := handler.NewAdminScaleHandler(createScaleHandler, updateScaleHandler, tareScaleHandler,
+		listScalesHandler, watchScaleHandler, weighHandler)
+	adminPOSHandler := handler.NewAdminPOSHandler(createTillHandler, updateTillHandler, openSessionHandler,
+		closeSessionHandler, startSaleHandler, addSaleLineHandler, voidSaleLineHandler, takePaymentHandler,
+		voidSaleHandler, returnSaleHandler, listTillsHandler, listTillSessionsHandler, tillReportHandler,
+		getSaleHandler, getReceiptHandler)
+	branchHandler := handler.NewBranchHandler(listBranchesHandler, getBranchHandler)
+	adminBranchHandler := handler.NewAdminBranchHandler(createBranchHandler, updateBranchHandler, setBranchPriceHandler,
+		deleteBranchPriceHandler, assignAdminBranchesHandler, listBranchesHandler, getBranchHandler,
+		listBranchPricesHandler, listAdminsHandler)
+	haccpHandler := handler.NewHACCPHandler(ingestHACCPReadingsHandler)
+	adminHACCPHandler := handler.NewAdminHACCPHandler(createHACCPUnitHandler, updateHACCPUnitHandler,
+		recordHACCPReadingHandler, recordHACCPActionHandler, runHACCPChecksHandler, listHACCPUnitsHandler,
+		listHACCPReadingsHandler, listHACCPAlertsHandler, exportHACCPReportHandler)
+	adminStaffHandler := handler.NewAdminStaffHandler(setStaffProfileHandler, scheduleShiftHandler, updateShiftHandler,
+		deleteShiftHandler, clockInHandler, startBreakHandler, endBreakHandler, clockOutHandler,
+		listStaffProfilesHandler, rotaHandler, timesheetsHandler, exportTimesheetsHandler)
+	dispatchHandler := handler.NewDispatchHandler(orderDeliveryHandler)
+	adminDispatchHandler := handler.NewAdminDispatchHandler(createDriverHandler, updateDriverHandler, planRunHandler,
+		addStopHandler, removeStopHandler, optimiseRunHandler, assignDriverHandler, listDriversHandler, listRunsHandler,
+		getRunHandler, getProofHandler)
+	driverHandler := handler.NewDriverHandler(startRunHandler, deliverStopHandler, failStopHandler, driverRunsHandler,
+		getRunHandler)
 
 	// Middleware
 	authMiddleware := middleware.NewAuthMiddleware(tokenService)
+	branchScopeMiddleware := middleware.NewBranchScopeMiddleware(adminRepo)
+	roleMiddleware := middleware.NewRoleMiddleware(adminRepo)
 
 	// Router
-	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
 	router := apphttp.NewRouter(apphttp.RouterDeps{
 		Logger:              logger,
 		AuthMiddleware:      authMiddleware,
+		BranchScope:         branchScopeMiddleware,
+		Roles:               roleMiddleware,
 		AdminAuthHandler:    adminAuthHandler,
 		CustomerAuthHandler: customerAuthHandler,
 		AuthHandler:         authHandler,
+		FulfilmentHandler:   fulfilmentHandler,
+		AdminFulfilment:     adminFulfilmentHandler,
+		AddressHandler:      addressHandler,
+		DeliveryHandler:     deliveryHandler,
+		AdminDelivery:       adminDeliveryHandler,
+		InventoryHandler:    inventoryHandler,
+		AdminInventory:      adminInventoryHandler,
+		AdminWaste:          adminWasteHandler,
+		AdminCarcass:        adminCarcassHandler,
+		TraceHandler:        traceHandler,
+		AdminTraceability:   adminTraceabilityHandler,
+		AdminSupplier:       adminSupplierHandler,
+		AdminPurchaseOrder:  adminPurchaseOrderHandler,
+		PaymentHandler:      paymentHandler,
+		AdminPayment:        adminPaymentHandler,
+		OrderHandler:        orderHandler,
+		AdminOrder:          adminOrderHandler,
+		InvoiceHandler:      invoiceHandler,
+		AdminInvoice:        adminInvoiceHandler,
+		CartHandler:         cartHandler,
+		AdminTax:            adminTaxHandler,
+		AdminPromotion:      adminPromotionHandler,
+		LoyaltyHandler:      loyaltyHandler,
+		AdminLoyalty:        adminLoyaltyHandler,
+		QurbaniHandler:      qurbaniHandler,
+		AdminQurbani:        adminQurbaniHandler,
+		SubscriptionHandler: subscriptionHandler,
+		AdminSubscription:   adminSubscriptionHandler,
+		BusinessHandler:     businessHandler,
+		AdminBusiness:       adminBusinessHandler,
+		AdminBarcode:        adminBarcodeHandler,
+		AdminLabel:          adminLabelHandler,
+		AdminScale:          adminScaleHandler,
+		AdminPOS:            adminPOSHandler,
+		BranchHandler:       branchHandler,
+		AdminBranch:         adminBranchHandler,
+		HACCPHandler:        haccpHandler,
+		AdminHACCP:          adminHACCPHandler,
+		AdminStaff:          adminStaffHandler,
+		DispatchHandler:     dispatchHandler,
+		AdminDispatch:       adminDispatchHandler,
+		Driver:              driverHandler,
 	})
 
 	server := httptest.NewServer(router)
 	t.Cleanup(func() { server.Close() })
 
-	return &testServer{server: server, pool: pool}
+	return &testServer{server: server, pool: pool, payments: paymentGateway, renewals: runRenewalsHandler}
 }
 
 // url returns the full URL for a given API path.
@@ -173,6 +760,113 @@ func (ts *testServer) postJSONWithAuth(t *testing.T, path string, body any, toke
 	return resp
 }
 
+// doJSONWithAuth sends an authenticated request with the given method and
+// optional JSON body.
+func (ts *testServer) doJSONWithAuth(t *testing.T, method, path string, body any, token string) *http.Response {
+	t.Helper()
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequest(method, ts.url(path), reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+// postJSONWithKey sends an authenticated POST request with a JSON body and
+// an Idempotency-Key header.
+func (ts *testServer) postJSONWithKey(t *testing.T, path string, body any, token, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, ts.url(path), bytes.NewReader(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+// postWebhook delivers a payment provider event, signed with signature.
+func (ts *testServer) postWebhook(t *testing.T, payload []byte, signature string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, ts.url("/api/v1/payments/webhook"), bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(infrapayment.SignatureHeader, signature)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+// get sends an unauthenticated GET request.
+func (ts *testServer) get(t *testing.T, path string) *http.Response {
+	t.Helper()
+
+	resp, err := http.Get(ts.url(path))
+	require.NoError(t, err)
+
+	return resp
+}
+
+// loginAdmin logs in as the seeded admin and returns the access token.
+func (ts *testServer) loginAdmin(t *testing.T) string {
+	t.Helper()
+
+	resp := ts.postJSON(t, "/api/v1/admin/auth/login", dto.LoginRequest{
+		Email:    testAdminEmail,
+		Password: testAdminPassword,
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var loginResp dto.LoginResponse
+	parseJSON(t, resp, &loginResp)
+	return loginResp.AccessToken
+}
+
+// registerAndLoginCustomer registers a customer and returns the access token.
+func (ts *testServer) registerAndLoginCustomer(t *testing.T, email string) string {
+	t.Helper()
+
+	resp := ts.postJSON(t, "/api/v1/auth/register", dto.RegisterCustomerRequest{
+		Email:    email,
+		Password: "securepassword123",
+		FullName: "Test Customer",
+		Phone:    "+1234567890",
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSON(t, "/api/v1/auth/login", dto.LoginRequest{
+		Email:    email,
+		Password: "securepassword123",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var loginResp dto.LoginResponse
+	parseJSON(t, resp, &loginResp)
+	return loginResp.AccessToken
+}
+
 // responseEnvelope matches the httpresponse.Response structure.
 type responseEnvelope struct {
 	Data  json.RawMessage `json:"data,omitempty"`
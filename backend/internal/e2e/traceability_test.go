@@ -0,0 +1,126 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationTraceability_FarmToCounter(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	adminToken := ts.loginAdmin(t)
+	customerToken := ts.registerAndLoginCustomer(t, "trace@example.com")
+
+	branchID, legID, mince := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	today := time.Now().UTC()
+
+	// Step 1: Admin registers the slaughterhouse's halal certificate, which
+	// lapses within the warning window.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/halal-certificates", dto.RegisterCertificateRequest{
+		Issuer: "Halal Monitoring Committee", Number: "HMC-2026-0193", Slaughterhouse: "Hill Farm Abattoir",
+		ValidFrom:   today.AddDate(0, -11, 0).Format(time.DateOnly),
+		ValidUntil:  today.AddDate(0, 0, 10).Format(time.DateOnly),
+		DocumentURL: "https://files.example.com/hmc-2026-0193.pdf",
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/halal-certificates?expiring=true", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var certificates []dto.CertificateResponse
+	parseJSON(t, resp, &certificates)
+	require.Len(t, certificates, 1)
+	assert.Equal(t, "expiring", certificates[0].Expiry)
+
+	// Step 2: A carcass is booked in and cut; its lots inherit its origin.
+	slaughtered := today.AddDate(0, 0, -3).Format(time.DateOnly)
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/carcasses", dto.RecordCarcassRequest{
+		BranchID: branchID, Tag: "KILL-11", Species: "lamb", HangingWeightGrams: 20000, CostCents: 16000,
+		Supplier: "Green Valley Farm", SlaughteredOn: slaughtered, HalalCertificateRef: "HMC-2026-0193",
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var c dto.CarcassResponse
+	parseJSON(t, resp, &c)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/carcasses/"+c.ID+"/breakdown", dto.RecordBreakdownRequest{
+		Cuts: []dto.BreakdownCut{{Primal: "leg", ProductID: legID, Grams: 6000}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: Anyone can trace the carcass tag printed on the label.
+	resp = ts.get(t, "/api/v1/trace/KILL-11")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var traces []dto.LotTraceResponse
+	parseJSON(t, resp, &traces)
+	require.Len(t, traces, 1)
+	assert.Equal(t, "Green Valley Farm", traces[0].Farm)
+	require.NotNil(t, traces[0].Species)
+	assert.Equal(t, "lamb", *traces[0].Species)
+	assert.True(t, traces[0].HalalVerified)
+	require.NotNil(t, traces[0].Certificate)
+	assert.Equal(t, "Hill Farm Abattoir", traces[0].Certificate.Slaughterhouse)
+
+	// Step 4: Boxed mince is bought in and its origin recorded by hand, under
+	// a certificate that has not been registered.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+		ProductID: mince, BranchID: branchID, Code: "MINCE-11", Grams: 5000,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var lot dto.LotResponse
+	parseJSON(t, resp, &lot)
+
+	resp = ts.get(t, "/api/v1/trace/MINCE-11")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	origin := dto.RecordOriginRequest{Farm: "Moor End Farm", SlaughteredOn: slaughtered, CertificateNumber: "HFA-551"}
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots/"+lot.ID+"/origin", origin, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots/"+lot.ID+"/origin", origin, adminToken)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.get(t, "/api/v1/trace/MINCE-11")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseJSON(t, resp, &traces)
+	require.Len(t, traces, 1)
+	assert.False(t, traces[0].HalalVerified)
+	assert.Nil(t, traces[0].Certificate)
+
+	// Step 5: A customer's order is picked; the lots it consumed are listed.
+	resp = ts.postJSONWithAuth(t, "/api/v1/inventory/reservations", dto.ReserveStockRequest{
+		BranchID: branchID,
+		Lines: []dto.StockReservationLine{
+			{ProductID: legID, Grams: 1500},
+			{ProductID: mince, Grams: 500},
+		},
+	}, customerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var hold dto.ReserveStockResponse
+	parseJSON(t, resp, &hold)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/reservations/"+hold.ReservationID+"/fulfil", nil, adminToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/orders/"+hold.ReservationID+"/lots", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var consumed []dto.ConsumedLotResponse
+	parseJSON(t, resp, &consumed)
+	require.Len(t, consumed, 2)
+	grams := map[string]int64{}
+	for _, l := range consumed {
+		grams[l.LotCode] = l.Grams
+	}
+	assert.Equal(t, int64(1500), grams["KILL-11"])
+	assert.Equal(t, int64(500), grams["MINCE-11"])
+}
@@ -0,0 +1,116 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+)
+
+func TestIntegrationWaste_ExpiryProposalsAndShrinkage(t *testing.T) {
+	ts := setupTestServer(t, 15*time.Minute)
+	token := ts.loginAdmin(t)
+
+	productID, branchID := uuid.NewString(), uuid.NewString()
+
+	// Step 1: Three lots of lamb are received at 12.00/kg with different
+	// use-by dates.
+	receive := func(code, useBy string) dto.LotResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/inventory/lots", dto.ReceiveLotRequest{
+			ProductID: productID, BranchID: branchID, Code: code, Grams: 5000, ExpiresOn: useBy, CostPerKgCents: 1200,
+		}, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var lot dto.LotResponse
+		parseJSON(t, resp, &lot)
+		assert.Equal(t, int64(1200), lot.CostPerKgCents)
+		return lot
+	}
+	expired := receive("LAMB-01", "2026-11-09")
+	expiring := receive("LAMB-02", "2026-11-11")
+	fresh := receive("LAMB-03", "2026-11-30")
+
+	// Step 2: Trim is written off with a reason code; free-text reasons are
+	// refused.
+	resp := ts.postJSONWithAuth(t, "/api/v1/admin/inventory/waste", dto.WasteRequest{
+		LotID: fresh.ID, Grams: 500, Reason: "trim",
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/waste", dto.WasteRequest{
+		LotID: fresh.ID, Grams: 500, Reason: "dropped",
+	}, token)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 3: The scan on the 10th proposes writing off the expired lot and
+	// marking down the one due the next day. Running it again changes
+	// nothing.
+	scan := func() dto.ExpiryScanResponse {
+		t.Helper()
+		resp := ts.postJSONWithAuth(t, "/api/v1/admin/inventory/expiry/scan?date=2026-11-10", nil, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result dto.ExpiryScanResponse
+		parseJSON(t, resp, &result)
+		return result
+	}
+	assert.Equal(t, dto.ExpiryScanResponse{Scanned: 2, Markdowns: 1, Waste: 1}, scan())
+	assert.Equal(t, dto.ExpiryScanResponse{Scanned: 2}, scan())
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/expiry/proposals?status=proposed&branch_id="+branchID, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var proposals []dto.ExpiryProposalResponse
+	parseJSON(t, resp, &proposals)
+	require.Len(t, proposals, 2)
+	byLot := map[string]dto.ExpiryProposalResponse{}
+	for _, p := range proposals {
+		byLot[p.LotID] = p
+	}
+	writeOff, markdown := byLot[expired.ID], byLot[expiring.ID]
+	assert.Equal(t, "waste", writeOff.Kind)
+	assert.Equal(t, "markdown", markdown.Kind)
+	assert.Equal(t, 3000, markdown.MarkdownBP)
+
+	// Step 4: Staff accept the write-off and dismiss the markdown; a
+	// decided proposal cannot be decided again.
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/expiry/proposals/"+writeOff.ID+"/accept", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var accepted dto.ExpiryProposalResponse
+	parseJSON(t, resp, &accepted)
+	assert.Equal(t, "accepted", accepted.Status)
+	assert.Equal(t, int64(5000), accepted.WastedGrams)
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/expiry/proposals/"+markdown.ID+"/dismiss", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = ts.postJSONWithAuth(t, "/api/v1/admin/inventory/expiry/proposals/"+markdown.ID+"/accept", nil, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// Step 5: The shrinkage report values the losses at cost and reconciles
+	// with the ledger.
+	today := time.Now().Format(time.DateOnly)
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/shrinkage?from="+today+"&to="+today+"&branch_id="+branchID, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report dto.ShrinkageResponse
+	parseJSON(t, resp, &report)
+	require.Len(t, report.Lines, 1)
+	line := report.Lines[0]
+	assert.Equal(t, productID, line.Key)
+	assert.Equal(t, int64(15000), line.ReceivedGrams)
+	assert.Equal(t, int64(5500), line.WastedGrams)
+	assert.Equal(t, map[string]int64{"trim": 500, "expired": 5000}, line.WastedByReason)
+	assert.Equal(t, int64(6600), line.ShrinkCents)
+	assert.Equal(t, int64(9500), line.NetGrams)
+
+	resp = ts.doJSONWithAuth(t, http.MethodGet, "/api/v1/admin/inventory/shrinkage?from="+today+"&to="+today+"&group_by=supplier", nil, token)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp.Body.Close()
+}
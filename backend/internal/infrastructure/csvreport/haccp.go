@@ -0,0 +1,105 @@
+// Package csvreport exports reports as CSV files for spreadsheets and
+// inspectors' own tools.
+package csvreport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// haccpHeader names the columns of a HACCP report. Each row is a reading,
+// an alarm or a corrective action; columns that do not apply are empty.
+var haccpHeader = []string{
+	"branch", "unit", "kind", "min_celsius", "max_celsius", "record", "at",
+	"celsius", "in_range", "source", "by", "alarm", "resolved_at", "resolved_by", "details",
+}
+
+// HACCPReportRenderer renders HACCP temperature records as CSV, one row per
+// reading, alarm and corrective action, in the order they happened for
+// each unit.
+type HACCPReportRenderer struct{}
+
+// NewHACCPReportRenderer creates a new HACCPReportRenderer.
+func NewHACCPReportRenderer() *HACCPReportRenderer {
+	return &HACCPReportRenderer{}
+}
+
+// Render writes the report. Times are given in the report's time zone.
+func (r *HACCPReportRenderer) Render(rep *haccp.Report) ([]byte, error) {
+	if rep.Location == nil {
+		return nil, fmt.Errorf("rendering HACCP report: no time zone")
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(haccpHeader); err != nil {
+		return nil, err
+	}
+
+	when := func(t time.Time) string { return t.In(rep.Location).Format("2006-01-02 15:04") }
+	staff := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		if name, ok := rep.Staff[*id]; ok {
+			return name
+		}
+		return id.String()
+	}
+
+	for _, l := range rep.Units {
+		u := l.Unit
+		unit := []string{l.BranchName, u.Name(), string(u.Kind()), celsius(u.MinCelsius()), celsius(u.MaxCelsius())}
+		var rows [][]string
+		for _, rd := range l.Readings {
+			rows = append(rows, append(unit[:len(unit):len(unit)],
+				"reading", when(rd.TakenAt()), celsius(rd.Celsius()), strconv.FormatBool(rd.InRange()),
+				string(rd.Source()), staff(rd.RecordedBy()), "", "", "", rd.Note()))
+		}
+		for _, a := range l.Alerts {
+			var temp, resolvedAt string
+			if a.Celsius() != nil {
+				temp = celsius(*a.Celsius())
+			}
+			if a.ResolvedAt() != nil {
+				resolvedAt = when(*a.ResolvedAt())
+			}
+			rows = append(rows, append(unit[:len(unit):len(unit)],
+				"alarm", when(a.RaisedAt()), temp, "", "", "", string(a.Kind()), resolvedAt, staff(a.ResolvedBy()), ""))
+		}
+		for _, c := range l.Actions {
+			by := c.TakenBy()
+			var alarm string
+			if c.AlertID() != nil {
+				alarm = c.AlertID().String()
+			}
+			rows = append(rows, append(unit[:len(unit):len(unit)],
+				"corrective_action", when(c.TakenAt()), "", "", "", staff(&by), alarm, "", "", c.Action()))
+		}
+		// Readings stay before the alarms they raised, and alarms before
+		// the actions taken about them.
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i][atColumn] < rows[j][atColumn] })
+		if err := w.WriteAll(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// atColumn is the index of the "at" column in haccpHeader.
+const atColumn = 6
+
+func celsius(c float64) string {
+	return strconv.FormatFloat(c, 'f', 1, 64)
+}
@@ -0,0 +1,56 @@
+package csvreport_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/csvreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHACCPReportRenderer_WritesRowPerRecordInOrder(t *testing.T) {
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	u, err := haccp.NewUnit(uuid.New(), uuid.New(), "Display counter", haccp.KindDisplay, 0, 5, "probe-1", 15*time.Minute, at)
+	require.NoError(t, err)
+	staffID := uuid.New()
+
+	warm, err := u.Record(uuid.New(), 6.04, haccp.SourceSensor, nil, "", at, at)
+	require.NoError(t, err)
+	cool, err := u.Record(uuid.New(), 4, haccp.SourceManual, &staffID, "Checked by hand, after \"defrost\"", at.Add(time.Hour), at.Add(time.Hour))
+	require.NoError(t, err)
+	alert := haccp.NewOutOfRangeAlert(uuid.New(), warm, at)
+	require.NoError(t, alert.Resolve(staffID, at.Add(30*time.Minute)))
+	alertID := alert.ID()
+	action, err := haccp.NewCorrectiveAction(uuid.New(), u.ID(), &alertID, "Closed the blind, probe rechecked", staffID, at.Add(30*time.Minute))
+	require.NoError(t, err)
+
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	period, err := haccp.NewPeriod(at, at)
+	require.NoError(t, err)
+	out, err := csvreport.NewHACCPReportRenderer().Render(&haccp.Report{
+		Period:   period,
+		Location: london,
+		Units: []haccp.UnitLog{{
+			Unit: u, BranchName: "Whitechapel",
+			Readings: []*haccp.Reading{warm, cool}, Alerts: []*haccp.Alert{alert}, Actions: []*haccp.CorrectiveAction{action},
+		}},
+		Staff: map[uuid.UUID]string{staffID: "Sam Ahmed"},
+	})
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	assert.Equal(t, "record", rows[0][5])
+	assert.Equal(t, []string{"Whitechapel", "Display counter", "display", "0.0", "5.0", "reading", "2026-10-01 09:00",
+		"6.0", "false", "sensor", "", "", "", "", ""}, rows[1])
+	assert.Equal(t, []string{"alarm", "out_of_range", "2026-10-01 09:30", "Sam Ahmed"}, []string{rows[2][5], rows[2][11], rows[2][12], rows[2][13]})
+	assert.Equal(t, []string{"corrective_action", "Sam Ahmed", alertID.String()}, []string{rows[3][5], rows[3][10], rows[3][11]})
+	assert.Equal(t, "Checked by hand, after \"defrost\"", rows[4][14])
+}
@@ -0,0 +1,81 @@
+package csvreport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// timesheetHeader names the columns of a timesheet export. Each row is a
+// clock entry or a member of staff's totals; columns that do not apply are
+// empty.
+var timesheetHeader = []string{
+	"staff", "position", "home_branch", "record", "branch", "clocked_in", "clocked_out",
+	"worked_minutes", "break_minutes", "missing_break_minutes", "scheduled_minutes", "overtime_minutes", "pay_cents",
+}
+
+// TimesheetReportRenderer renders timesheets as CSV for payroll, one row
+// per clock entry followed by a total row for each member of staff.
+type TimesheetReportRenderer struct{}
+
+// NewTimesheetReportRenderer creates a new TimesheetReportRenderer.
+func NewTimesheetReportRenderer() *TimesheetReportRenderer {
+	return &TimesheetReportRenderer{}
+}
+
+// Render writes the timesheets. Times are given in the report's time zone.
+func (r *TimesheetReportRenderer) Render(rep *staff.TimesheetReport) ([]byte, error) {
+	if rep.Location == nil {
+		return nil, fmt.Errorf("rendering timesheets: no time zone")
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(timesheetHeader); err != nil {
+		return nil, err
+	}
+
+	when := func(t time.Time) string { return t.In(rep.Location).Format("2006-01-02 15:04") }
+	branch := func(id uuid.UUID) string {
+		if name, ok := rep.BranchNames[id]; ok {
+			return name
+		}
+		return id.String()
+	}
+	itoa := strconv.Itoa
+
+	for _, s := range rep.Timesheets {
+		p := s.Profile
+		who := []string{p.FullName(), string(p.Position()), branch(p.BranchID())}
+		for _, l := range s.Entries {
+			e := l.Entry
+			if e.Open() {
+				if err := w.Write(append(who[:len(who):len(who)],
+					"entry", branch(e.BranchID()), when(e.ClockedInAt()), "", "", "", "", "", "", "")); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := w.Write(append(who[:len(who):len(who)],
+				"entry", branch(e.BranchID()), when(e.ClockedInAt()), when(*e.ClockedOutAt()),
+				itoa(l.WorkedMinutes), itoa(l.BreakMinutes), itoa(l.MissingBreakMinutes), "", "", "")); err != nil {
+				return nil, err
+			}
+		}
+		if err := w.Write(append(who[:len(who):len(who)],
+			"total", "", "", "", itoa(s.WorkedMinutes), itoa(s.BreakMinutes), "",
+			itoa(s.ScheduledMinutes), itoa(s.OvertimeMinutes), strconv.FormatInt(s.PayCents, 10))); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+package csvreport_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/csvreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimesheetReportRenderer_WritesEntriesThenTotals(t *testing.T) {
+	branchID := uuid.New()
+	a, err := admin.NewAdmin(uuid.New(), "yusuf@butchery.com", "$2a$10$hash", "Yusuf Ali", admin.RoleStaff)
+	require.NoError(t, err)
+	p, err := staff.NewProfile(a, branchID, staff.PositionButcher, 1200, 0, time.Now())
+	require.NoError(t, err)
+
+	in := time.Date(2026, 10, 1, 7, 0, 0, 0, time.UTC)
+	e, err := staff.ClockIn(uuid.New(), p, branchID, in)
+	require.NoError(t, err)
+	require.NoError(t, e.StartBreak(in.Add(4*time.Hour)))
+	require.NoError(t, e.EndBreak(in.Add(4*time.Hour+30*time.Minute)))
+	require.NoError(t, e.ClockOut(in.Add(8*time.Hour)))
+	open, err := staff.ClockIn(uuid.New(), p, branchID, in.AddDate(0, 0, 1))
+	require.NoError(t, err)
+
+	policy := staff.Policy{BreakAfter: 6 * time.Hour, MinBreak: 20 * time.Minute}
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	period, err := staff.NewPeriod(in, in.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	out, err := csvreport.NewTimesheetReportRenderer().Render(&staff.TimesheetReport{
+		Period:      period,
+		Location:    london,
+		Timesheets:  policy.NewTimesheets([]*staff.Profile{p}, []*staff.ClockEntry{e, open}, nil, london),
+		BranchNames: map[uuid.UUID]string{branchID: "Whitechapel"},
+	})
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, "record", rows[0][3])
+	assert.Equal(t, []string{"Yusuf Ali", "butcher", "Whitechapel", "entry", "Whitechapel", "2026-10-01 08:00",
+		"2026-10-01 16:00", "450", "30", "0", "", "", ""}, rows[1])
+	assert.Equal(t, []string{"entry", "2026-10-02 08:00", ""}, []string{rows[2][3], rows[2][5], rows[2][6]})
+	assert.Equal(t, []string{"total", "450", "30", "0", "0", "9000"},
+		[]string{rows[3][3], rows[3][7], rows[3][8], rows[3][10], rows[3][11], rows[3][12]})
+}
@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// LogNotifier is an implementation of qurbani.Notifier,
+// subscription.Notifier, haccp.Notifier and inventory.Notifier that writes
+// each notice to the log instead of sending it, for local development and
+// until an email or SMS provider is wired in.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier.
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// SacrificeDone logs that the sacrifice for a booking has been done.
+func (n *LogNotifier) SacrificeDone(ctx context.Context, notice qurbani.Notice) error {
+	n.logger.InfoContext(ctx, "qurbani sacrifice done",
+		slog.String("booking_id", notice.BookingID.String()),
+		slog.String("email", notice.Email),
+		slog.String("niyyah_name", notice.NiyyahName),
+		slog.String("share_type", notice.ShareType.String()),
+		slog.Int("animal", notice.AnimalNumber),
+		slog.String("distribution", notice.Distribution.String()),
+		slog.String("slaughtered_at", notice.SlaughteredAt.Format(time.RFC3339)),
+	)
+	return nil
+}
+
+// RenewalFailed logs that payment for a subscription order was declined, or
+// that the order will not go ahead.
+func (n *LogNotifier) RenewalFailed(ctx context.Context, notice subscription.Notice) error {
+	attrs := []any{
+		slog.String("subscription_id", notice.SubscriptionID.String()),
+		slog.String("renewal_id", notice.RenewalID.String()),
+		slog.String("email", notice.Email),
+		slog.String("delivery_date", notice.DeliveryDate.Format(time.DateOnly)),
+		slog.String("reason", notice.Reason),
+		slog.Int("attempts", notice.Attempts),
+		slog.Bool("paused", notice.Paused),
+	}
+	if notice.RetryAt != nil {
+		attrs = append(attrs, slog.String("retry_at", notice.RetryAt.Format(time.RFC3339)))
+	}
+	n.logger.InfoContext(ctx, "subscription renewal failed", attrs...)
+	return nil
+}
+
+// AlertRaised logs that a unit has raised a temperature alarm.
+func (n *LogNotifier) AlertRaised(ctx context.Context, notice haccp.Notice) error {
+	attrs := []any{
+		slog.String("alert_id", notice.AlertID.String()),
+		slog.String("kind", string(notice.Kind)),
+		slog.String("unit_id", notice.UnitID.String()),
+		slog.String("unit", notice.UnitName),
+		slog.String("branch_id", notice.BranchID.String()),
+		slog.Float64("min_celsius", notice.MinCelsius),
+		slog.Float64("max_celsius", notice.MaxCelsius),
+		slog.String("raised_at", notice.RaisedAt.Format(time.RFC3339)),
+	}
+	if notice.Celsius != nil {
+		attrs = append(attrs, slog.Float64("celsius", *notice.Celsius))
+	}
+	if notice.LastReadingAt != nil {
+		attrs = append(attrs, slog.String("last_reading_at", notice.LastReadingAt.Format(time.RFC3339)))
+	}
+	n.logger.WarnContext(ctx, "haccp alarm raised", attrs...)
+	return nil
+}
+
+// UseByReached logs that a lot is near or past its use-by date and what the
+// expiry scan proposes doing with it.
+func (n *LogNotifier) UseByReached(ctx context.Context, notice inventory.ExpiryNotice) error {
+	attrs := []any{
+		slog.String("proposal_id", notice.ProposalID.String()),
+		slog.String("kind", string(notice.Kind)),
+		slog.String("lot_id", notice.LotID.String()),
+		slog.String("lot", notice.LotCode),
+		slog.String("product_id", notice.ProductID.String()),
+		slog.String("branch_id", notice.BranchID.String()),
+		slog.String("use_by", notice.UseBy.Format(time.DateOnly)),
+		slog.Int64("on_hand_grams", notice.OnHandGrams),
+	}
+	if notice.Kind == inventory.ProposalMarkdown {
+		attrs = append(attrs, slog.Int("markdown_bp", notice.MarkdownBP))
+	}
+	n.logger.WarnContext(ctx, "lot use-by date reached", attrs...)
+	return nil
+}
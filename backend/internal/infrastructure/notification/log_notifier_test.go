@@ -0,0 +1,105 @@
+package notification_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogNotifier_SacrificeDone(t *testing.T) {
+	var buf bytes.Buffer
+	n := notification.NewLogNotifier(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := n.SacrificeDone(context.Background(), qurbani.Notice{
+		BookingID:     uuid.New(),
+		Email:         "amina@example.com",
+		NiyyahName:    "Amina",
+		ShareType:     qurbani.ShareCow,
+		AnimalNumber:  3,
+		Distribution:  qurbani.DistributionCharity,
+		SlaughteredAt: time.Date(2027, 5, 16, 8, 0, 0, 0, time.UTC),
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "qurbani sacrifice done")
+	assert.Contains(t, buf.String(), "email=amina@example.com")
+	assert.Contains(t, buf.String(), "animal=3")
+}
+
+func TestLogNotifier_RenewalFailed(t *testing.T) {
+	var buf bytes.Buffer
+	n := notification.NewLogNotifier(slog.New(slog.NewTextHandler(&buf, nil)))
+	retryAt := time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	err := n.RenewalFailed(context.Background(), subscription.Notice{
+		SubscriptionID: uuid.New(),
+		RenewalID:      uuid.New(),
+		Email:          "amina@example.com",
+		DeliveryDate:   time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+		Reason:         "card declined",
+		Attempts:       1,
+		RetryAt:        &retryAt,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "subscription renewal failed")
+	assert.Contains(t, buf.String(), "delivery_date=2026-03-07")
+	assert.Contains(t, buf.String(), "retry_at=2026-03-05T10:00:00Z")
+}
+
+func TestLogNotifier_AlertRaised(t *testing.T) {
+	var buf bytes.Buffer
+	n := notification.NewLogNotifier(slog.New(slog.NewTextHandler(&buf, nil)))
+	celsius := 8.5
+
+	err := n.AlertRaised(context.Background(), haccp.Notice{
+		AlertID:    uuid.New(),
+		Kind:       haccp.AlertOutOfRange,
+		UnitID:     uuid.New(),
+		UnitName:   "Walk-in chiller",
+		BranchID:   uuid.New(),
+		Celsius:    &celsius,
+		MinCelsius: 0,
+		MaxCelsius: 5,
+		RaisedAt:   time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC),
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "kind=out_of_range")
+	assert.Contains(t, buf.String(), "celsius=8.5")
+	assert.NotContains(t, buf.String(), "last_reading_at")
+}
+
+func TestLogNotifier_UseByReached(t *testing.T) {
+	var buf bytes.Buffer
+	n := notification.NewLogNotifier(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := n.UseByReached(context.Background(), inventory.ExpiryNotice{
+		ProposalID:  uuid.New(),
+		Kind:        inventory.ProposalMarkdown,
+		LotID:       uuid.New(),
+		LotCode:     "LAMB-0412",
+		ProductID:   uuid.New(),
+		BranchID:    uuid.New(),
+		UseBy:       time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		OnHandGrams: 2400,
+		MarkdownBP:  3000,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "use_by=2026-10-20")
+	assert.Contains(t, buf.String(), "markdown_bp=3000")
+}
@@ -0,0 +1,146 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	domainpayment "github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// FakeDeclinedPaymentMethod is the payment method the fake gateway always
+// declines, named after the equivalent Stripe test card.
+const FakeDeclinedPaymentMethod = "pm_card_chargeDeclined"
+
+// FakeGateway is an in-process implementation of payment.PaymentGateway for
+// tests and local development. It keeps payment intents in memory, honours
+// idempotency keys the way a real provider does and signs and verifies
+// webhooks in the same format as the Stripe adapter.
+type FakeGateway struct {
+	webhookSecret string
+
+	mu      sync.Mutex
+	seq     int
+	intents map[string]*fakeIntent
+	replies map[string]fakeReply
+}
+
+type fakeIntent struct {
+	status          string
+	authorizedCents int64
+	capturedCents   int64
+	refundedCents   int64
+}
+
+// fakeReply is the answer given to an idempotency key, replayed when the
+// key is used again.
+type fakeReply struct {
+	ref string
+	err error
+}
+
+// NewFakeGateway creates a new FakeGateway.
+func NewFakeGateway(webhookSecret string) *FakeGateway {
+	return &FakeGateway{
+		webhookSecret: webhookSecret,
+		intents:       make(map[string]*fakeIntent),
+		replies:       make(map[string]fakeReply),
+	}
+}
+
+// Name identifies the provider.
+func (g *FakeGateway) Name() string { return "fake" }
+
+// Authorize holds the amount unless the payment method is
+// FakeDeclinedPaymentMethod.
+func (g *FakeGateway) Authorize(_ context.Context, req domainpayment.AuthorizeRequest) (*domainpayment.Authorization, error) {
+	reply := g.once(req.IdempotencyKey, func() fakeReply {
+		if req.PaymentMethod == FakeDeclinedPaymentMethod {
+			return fakeReply{err: fmt.Errorf("%w: your card was declined", domainpayment.ErrDeclined)}
+		}
+		ref := g.nextRef("pi")
+		g.intents[ref] = &fakeIntent{status: "requires_capture", authorizedCents: req.AmountCents}
+		return fakeReply{ref: ref}
+	})
+	if reply.err != nil {
+		return nil, reply.err
+	}
+	return &domainpayment.Authorization{ProviderRef: reply.ref}, nil
+}
+
+// Capture captures up to the held amount.
+func (g *FakeGateway) Capture(_ context.Context, providerRef string, amountCents int64, idempotencyKey string) error {
+	return g.once(idempotencyKey, func() fakeReply {
+		pi, ok := g.intents[providerRef]
+		if !ok || pi.status != "requires_capture" {
+			return fakeReply{err: fmt.Errorf("fake gateway: payment intent %s cannot be captured", providerRef)}
+		}
+		if amountCents > pi.authorizedCents {
+			return fakeReply{err: fmt.Errorf("fake gateway: capture of %d exceeds %d held", amountCents, pi.authorizedCents)}
+		}
+		pi.status = "succeeded"
+		pi.capturedCents = amountCents
+		return fakeReply{}
+	}).err
+}
+
+// Void releases a hold.
+func (g *FakeGateway) Void(_ context.Context, providerRef string, idempotencyKey string) error {
+	return g.once(idempotencyKey, func() fakeReply {
+		pi, ok := g.intents[providerRef]
+		if !ok || pi.status != "requires_capture" {
+			return fakeReply{err: fmt.Errorf("fake gateway: payment intent %s cannot be canceled", providerRef)}
+		}
+		pi.status = "canceled"
+		return fakeReply{}
+	}).err
+}
+
+// Refund refunds part or all of a captured amount.
+func (g *FakeGateway) Refund(_ context.Context, providerRef string, amountCents int64, idempotencyKey string) (string, error) {
+	reply := g.once(idempotencyKey, func() fakeReply {
+		pi, ok := g.intents[providerRef]
+		if !ok || pi.status != "succeeded" {
+			return fakeReply{err: fmt.Errorf("fake gateway: payment intent %s has not been captured", providerRef)}
+		}
+		if pi.refundedCents+amountCents > pi.capturedCents {
+			return fakeReply{err: fmt.Errorf("fake gateway: refund of %d exceeds %d refundable", amountCents, pi.capturedCents-pi.refundedCents)}
+		}
+		pi.refundedCents += amountCents
+		return fakeReply{ref: g.nextRef("re")}
+	})
+	return reply.ref, reply.err
+}
+
+// ParseWebhook verifies and decodes a webhook signed by SignWebhook.
+func (g *FakeGateway) ParseWebhook(payload []byte, signature string) (*domainpayment.Event, error) {
+	if err := verifyWebhook(g.webhookSecret, payload, signature, time.Now()); err != nil {
+		return nil, err
+	}
+	return decodeEvent(payload)
+}
+
+// SignWebhook signs a Stripe-format event payload so that tests can deliver
+// webhooks as the provider would.
+func (g *FakeGateway) SignWebhook(payload []byte) string {
+	return signWebhook(g.webhookSecret, payload, time.Now())
+}
+
+// once runs fn for the first request with an idempotency key and replays
+// its reply for every later one.
+func (g *FakeGateway) once(idempotencyKey string, fn func() fakeReply) fakeReply {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if reply, ok := g.replies[idempotencyKey]; ok {
+		return reply
+	}
+	reply := fn()
+	g.replies[idempotencyKey] = reply
+	return reply
+}
+
+func (g *FakeGateway) nextRef(prefix string) string {
+	g.seq++
+	return fmt.Sprintf("%s_fake_%d", prefix, g.seq)
+}
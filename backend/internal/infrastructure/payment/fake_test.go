@@ -0,0 +1,68 @@
+package payment_test
+
+import (
+	"context"
+	"testing"
+
+	domainpayment "github.com/katerji/butchery-app/backend/internal/domain/payment"
+	infrapayment "github.com/katerji/butchery-app/backend/internal/infrastructure/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeGateway_Authorize_IsIdempotent(t *testing.T) {
+	gw := infrapayment.NewFakeGateway("whsec")
+	req := domainpayment.AuthorizeRequest{AmountCents: 1000, Currency: "gbp", PaymentMethod: "pm_card_visa", IdempotencyKey: "k1"}
+
+	first, err := gw.Authorize(context.Background(), req)
+	require.NoError(t, err)
+	again, err := gw.Authorize(context.Background(), req)
+	require.NoError(t, err)
+	other, err := gw.Authorize(context.Background(), domainpayment.AuthorizeRequest{AmountCents: 1000, IdempotencyKey: "k2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ProviderRef, again.ProviderRef)
+	assert.NotEqual(t, first.ProviderRef, other.ProviderRef)
+}
+
+func TestFakeGateway_Authorize_DeclinedPaymentMethod(t *testing.T) {
+	gw := infrapayment.NewFakeGateway("whsec")
+
+	_, err := gw.Authorize(context.Background(), domainpayment.AuthorizeRequest{
+		AmountCents: 1000, PaymentMethod: infrapayment.FakeDeclinedPaymentMethod, IdempotencyKey: "k1",
+	})
+
+	assert.ErrorIs(t, err, domainpayment.ErrDeclined)
+}
+
+func TestFakeGateway_CaptureRefundVoid(t *testing.T) {
+	ctx := context.Background()
+	gw := infrapayment.NewFakeGateway("whsec")
+	auth, err := gw.Authorize(ctx, domainpayment.AuthorizeRequest{AmountCents: 1000, IdempotencyKey: "a1"})
+	require.NoError(t, err)
+
+	assert.Error(t, gw.Capture(ctx, auth.ProviderRef, 1001, "c0"))
+	require.NoError(t, gw.Capture(ctx, auth.ProviderRef, 900, "c1"))
+	require.NoError(t, gw.Capture(ctx, auth.ProviderRef, 900, "c1"), "retried capture is replayed")
+	assert.Error(t, gw.Void(ctx, auth.ProviderRef, "v1"))
+
+	ref, err := gw.Refund(ctx, auth.ProviderRef, 900, "r1")
+	require.NoError(t, err)
+	again, err := gw.Refund(ctx, auth.ProviderRef, 900, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, ref, again)
+	_, err = gw.Refund(ctx, auth.ProviderRef, 1, "r2")
+	assert.Error(t, err)
+}
+
+func TestFakeGateway_SignedWebhook_RoundTrips(t *testing.T) {
+	gw := infrapayment.NewFakeGateway("whsec")
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"id":"pi_fake_1","amount_received":900}}}`)
+
+	e, err := gw.ParseWebhook(payload, gw.SignWebhook(payload))
+	require.NoError(t, err)
+	assert.Equal(t, domainpayment.EventCaptured, e.Type)
+
+	_, err = infrapayment.NewFakeGateway("other").ParseWebhook(payload, gw.SignWebhook(payload))
+	assert.ErrorIs(t, err, domainpayment.ErrInvalidSignature)
+}
@@ -0,0 +1,153 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	domainpayment "github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// StripeGateway implements payment.PaymentGateway against the Stripe
+// PaymentIntents API, or any service that speaks it. Payments are created
+// with manual capture so that the hold can be captured at the weighed
+// total later.
+type StripeGateway struct {
+	apiKey        string
+	baseURL       string
+	webhookSecret string
+	client        *http.Client
+}
+
+// NewStripeGateway creates a new StripeGateway.
+func NewStripeGateway(apiKey, baseURL, webhookSecret string) *StripeGateway {
+	return &StripeGateway{
+		apiKey:        apiKey,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Name identifies the provider.
+func (g *StripeGateway) Name() string { return "stripe" }
+
+// Authorize creates and confirms a manually captured PaymentIntent.
+func (g *StripeGateway) Authorize(ctx context.Context, req domainpayment.AuthorizeRequest) (*domainpayment.Authorization, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.AmountCents, 10))
+	form.Set("currency", req.Currency)
+	form.Set("payment_method", req.PaymentMethod)
+	form.Set("payment_method_types[]", "card")
+	form.Set("capture_method", "manual")
+	form.Set("confirm", "true")
+	form.Set("metadata[payment_id]", req.PaymentID)
+
+	var intent struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	if err := g.post(ctx, "/v1/payment_intents", form, req.IdempotencyKey, &intent); err != nil {
+		return nil, err
+	}
+	if intent.Status != "requires_capture" {
+		// Cards that need the customer to authenticate cannot be completed
+		// server-side, so they are treated as declined.
+		return nil, fmt.Errorf("%w: payment intent is %s", domainpayment.ErrDeclined, intent.Status)
+	}
+	return &domainpayment.Authorization{ProviderRef: intent.ID}, nil
+}
+
+// Capture captures amountCents of a PaymentIntent.
+func (g *StripeGateway) Capture(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) error {
+	form := url.Values{}
+	form.Set("amount_to_capture", strconv.FormatInt(amountCents, 10))
+	return g.post(ctx, "/v1/payment_intents/"+url.PathEscape(providerRef)+"/capture", form, idempotencyKey, nil)
+}
+
+// Void cancels a PaymentIntent, releasing the hold.
+func (g *StripeGateway) Void(ctx context.Context, providerRef string, idempotencyKey string) error {
+	return g.post(ctx, "/v1/payment_intents/"+url.PathEscape(providerRef)+"/cancel", url.Values{}, idempotencyKey, nil)
+}
+
+// Refund refunds amountCents of a captured PaymentIntent.
+func (g *StripeGateway) Refund(ctx context.Context, providerRef string, amountCents int64, idempotencyKey string) (string, error) {
+	form := url.Values{}
+	form.Set("payment_intent", providerRef)
+	form.Set("amount", strconv.FormatInt(amountCents, 10))
+
+	var refund struct {
+		ID string `json:"id"`
+	}
+	if err := g.post(ctx, "/v1/refunds", form, idempotencyKey, &refund); err != nil {
+		return "", err
+	}
+	return refund.ID, nil
+}
+
+// ParseWebhook verifies a Stripe-Signature header and decodes the event.
+func (g *StripeGateway) ParseWebhook(payload []byte, signature string) (*domainpayment.Event, error) {
+	if err := verifyWebhook(g.webhookSecret, payload, signature, time.Now()); err != nil {
+		return nil, err
+	}
+	return decodeEvent(payload)
+}
+
+type stripeErrorBody struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// post sends a form-encoded request with an Idempotency-Key header, so that
+// the provider replays its original answer if the request is retried, and
+// decodes a successful response into out.
+func (g *StripeGateway) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("building stripe request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+g.apiKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling stripe: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading stripe response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return stripeError(resp.StatusCode, body)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decoding stripe response: %w", err)
+	}
+	return nil
+}
+
+func stripeError(status int, body []byte) error {
+	var envelope struct {
+		Error stripeErrorBody `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error.Message == "" {
+		return fmt.Errorf("stripe returned status %d", status)
+	}
+	e := envelope.Error
+	if e.Type == "card_error" {
+		return fmt.Errorf("%w: %s", domainpayment.ErrDeclined, e.Message)
+	}
+	return fmt.Errorf("stripe returned status %d: %s", status, e.Message)
+}
@@ -0,0 +1,170 @@
+package payment_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	domainpayment "github.com/katerji/butchery-app/backend/internal/domain/payment"
+	infrapayment "github.com/katerji/butchery-app/backend/internal/infrastructure/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret string, payload []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "." + string(payload)))
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestStripeGateway_Authorize_CreatesManualCaptureIntent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/payment_intents", r.URL.Path)
+		assert.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		assert.Equal(t, "authorize:p1", r.Header.Get("Idempotency-Key"))
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "11500", r.PostForm.Get("amount"))
+		assert.Equal(t, "gbp", r.PostForm.Get("currency"))
+		assert.Equal(t, "manual", r.PostForm.Get("capture_method"))
+		assert.Equal(t, "pm_card_visa", r.PostForm.Get("payment_method"))
+		_, _ = w.Write([]byte(`{"id":"pi_123","status":"requires_capture"}`))
+	}))
+	defer srv.Close()
+	gw := infrapayment.NewStripeGateway("sk_test", srv.URL, "whsec")
+
+	auth, err := gw.Authorize(context.Background(), domainpayment.AuthorizeRequest{
+		PaymentID: "p1", AmountCents: 11500, Currency: "gbp", PaymentMethod: "pm_card_visa", IdempotencyKey: "authorize:p1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pi_123", auth.ProviderRef)
+}
+
+func TestStripeGateway_Authorize_CardError_ReturnsDeclined(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		_, _ = w.Write([]byte(`{"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}}`))
+	}))
+	defer srv.Close()
+	gw := infrapayment.NewStripeGateway("sk_test", srv.URL, "whsec")
+
+	_, err := gw.Authorize(context.Background(), domainpayment.AuthorizeRequest{AmountCents: 100, Currency: "gbp", IdempotencyKey: "k"})
+
+	assert.ErrorIs(t, err, domainpayment.ErrDeclined)
+}
+
+func TestStripeGateway_Authorize_RequiresAction_ReturnsDeclined(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"pi_123","status":"requires_action"}`))
+	}))
+	defer srv.Close()
+	gw := infrapayment.NewStripeGateway("sk_test", srv.URL, "whsec")
+
+	_, err := gw.Authorize(context.Background(), domainpayment.AuthorizeRequest{AmountCents: 100, Currency: "gbp", IdempotencyKey: "k"})
+
+	assert.ErrorIs(t, err, domainpayment.ErrDeclined)
+}
+
+func TestStripeGateway_CaptureAndRefund(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.URL.Path {
+		case "/v1/payment_intents/pi_123/capture":
+			assert.Equal(t, "10400", r.PostForm.Get("amount_to_capture"))
+			_, _ = w.Write([]byte(`{"id":"pi_123","status":"succeeded"}`))
+		case "/v1/refunds":
+			assert.Equal(t, "pi_123", r.PostForm.Get("payment_intent"))
+			assert.Equal(t, "400", r.PostForm.Get("amount"))
+			_, _ = w.Write([]byte(`{"id":"re_1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"No such route"}}`))
+		}
+	}))
+	defer srv.Close()
+	gw := infrapayment.NewStripeGateway("sk_test", srv.URL, "whsec")
+
+	require.NoError(t, gw.Capture(context.Background(), "pi_123", 10400, "capture:p1"))
+	ref, err := gw.Refund(context.Background(), "pi_123", 400, "refund:p1:r1")
+	require.NoError(t, err)
+	assert.Equal(t, "re_1", ref)
+
+	err = gw.Void(context.Background(), "pi_missing", "void:p2")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domainpayment.ErrDeclined)
+}
+
+func TestStripeGateway_ParseWebhook(t *testing.T) {
+	gw := infrapayment.NewStripeGateway("sk_test", "http://unused", "whsec")
+	payload := []byte(`{"id":"evt_1","type":"charge.refunded","data":{"object":{"id":"ch_1","payment_intent":"pi_123","amount_refunded":400}}}`)
+
+	e, err := gw.ParseWebhook(payload, sign("whsec", payload, time.Now()))
+
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", e.ID)
+	assert.Equal(t, domainpayment.EventRefunded, e.Type)
+	assert.Equal(t, "pi_123", e.ProviderRef)
+	assert.Equal(t, int64(400), e.AmountCents)
+}
+
+func TestStripeGateway_ParseWebhook_MapsEventTypes(t *testing.T) {
+	gw := infrapayment.NewStripeGateway("sk_test", "http://unused", "whsec")
+	tests := []struct {
+		payload string
+		want    domainpayment.EventType
+		amount  int64
+	}{
+		{`{"id":"e","type":"payment_intent.amount_capturable_updated","data":{"object":{"id":"pi","amount_capturable":900,"metadata":{"payment_id":"p1"}}}}`, domainpayment.EventAuthorized, 900},
+		{`{"id":"e","type":"payment_intent.succeeded","data":{"object":{"id":"pi","amount_received":800}}}`, domainpayment.EventCaptured, 800},
+		{`{"id":"e","type":"payment_intent.canceled","data":{"object":{"id":"pi"}}}`, domainpayment.EventVoided, 0},
+		{`{"id":"e","type":"payment_intent.payment_failed","data":{"object":{"id":"pi","last_payment_error":{"message":"declined"}}}}`, domainpayment.EventFailed, 0},
+		{`{"id":"e","type":"customer.created","data":{"object":{"id":"cus"}}}`, domainpayment.EventIgnored, 0},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.want), func(t *testing.T) {
+			payload := []byte(tt.payload)
+			e, err := gw.ParseWebhook(payload, sign("whsec", payload, time.Now()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, e.Type)
+			assert.Equal(t, tt.amount, e.AmountCents)
+		})
+	}
+}
+
+func TestStripeGateway_ParseWebhook_BadSignature_ReturnsError(t *testing.T) {
+	gw := infrapayment.NewStripeGateway("sk_test", "http://unused", "whsec")
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.canceled","data":{"object":{"id":"pi_1"}}}`)
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{"missing", ""},
+		{"wrong secret", sign("other", payload, time.Now())},
+		{"tampered", sign("whsec", []byte(`{"id":"evt_2"}`), time.Now())},
+		{"stale", sign("whsec", payload, time.Now().Add(-10*time.Minute))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gw.ParseWebhook(payload, tt.signature)
+			assert.ErrorIs(t, err, domainpayment.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestStripeGateway_ParseWebhook_AnyRolledSignatureMatches(t *testing.T) {
+	gw := infrapayment.NewStripeGateway("sk_test", "http://unused", "whsec")
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.canceled","data":{"object":{"id":"pi_1"}}}`)
+	old := sign("old", payload, time.Now())
+	current := sign("whsec", payload, time.Now())
+
+	_, err := gw.ParseWebhook(payload, old+","+current[len("t=1234567890,"):])
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,133 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domainpayment "github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// SignatureHeader is the request header carrying a webhook's signature.
+const SignatureHeader = "Stripe-Signature"
+
+// webhookTolerance is how old a signed webhook may be before it is rejected
+// as a possible replay.
+const webhookTolerance = 5 * time.Minute
+
+// signWebhook returns a signature header value for payload in the Stripe
+// format: "t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<payload>">".
+func signWebhook(secret string, payload []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(webhookMAC(secret, ts, payload))
+}
+
+// verifyWebhook checks a Stripe-format signature header against payload.
+// Any of several v1 signatures may match, which is how the provider rolls
+// webhook secrets.
+func verifyWebhook(secret string, payload []byte, header string, now time.Time) error {
+	var ts string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			if sig, err := hex.DecodeString(v); err == nil {
+				signatures = append(signatures, sig)
+			}
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return domainpayment.ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return domainpayment.ErrInvalidSignature
+	}
+
+	expected := webhookMAC(secret, ts, payload)
+	for _, sig := range signatures {
+		if hmac.Equal(sig, expected) {
+			return nil
+		}
+	}
+	return domainpayment.ErrInvalidSignature
+}
+
+func webhookMAC(secret, ts string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// stripeEvent is the part of a Stripe event the payment lifecycle needs.
+// The object is a PaymentIntent for payment_intent.* events and a Charge
+// for charge.refunded.
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID               string           `json:"id"`
+			PaymentIntent    string           `json:"payment_intent"`
+			AmountCapturable int64            `json:"amount_capturable"`
+			AmountReceived   int64            `json:"amount_received"`
+			AmountRefunded   int64            `json:"amount_refunded"`
+			LastPaymentError *stripeErrorBody `json:"last_payment_error"`
+			Metadata         struct {
+				PaymentID string `json:"payment_id"`
+			} `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// decodeEvent maps a Stripe event onto the payment lifecycle.
+func decodeEvent(payload []byte) (*domainpayment.Event, error) {
+	var se stripeEvent
+	if err := json.Unmarshal(payload, &se); err != nil {
+		return nil, fmt.Errorf("decoding webhook event: %w", err)
+	}
+	if se.ID == "" {
+		return nil, fmt.Errorf("decoding webhook event: missing id")
+	}
+
+	obj := se.Data.Object
+	e := &domainpayment.Event{
+		ID:          se.ID,
+		Type:        domainpayment.EventIgnored,
+		PaymentID:   obj.Metadata.PaymentID,
+		ProviderRef: obj.ID,
+	}
+	switch se.Type {
+	case "payment_intent.amount_capturable_updated":
+		e.Type = domainpayment.EventAuthorized
+		e.AmountCents = obj.AmountCapturable
+	case "payment_intent.succeeded":
+		e.Type = domainpayment.EventCaptured
+		e.AmountCents = obj.AmountReceived
+	case "payment_intent.canceled":
+		e.Type = domainpayment.EventVoided
+	case "payment_intent.payment_failed":
+		e.Type = domainpayment.EventFailed
+		if obj.LastPaymentError != nil {
+			e.Reason = obj.LastPaymentError.Message
+		}
+	case "charge.refunded":
+		e.Type = domainpayment.EventRefunded
+		e.ProviderRef = obj.PaymentIntent
+		e.AmountCents = obj.AmountRefunded
+	}
+	return e, nil
+}
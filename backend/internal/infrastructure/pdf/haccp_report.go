@@ -0,0 +1,222 @@
+package pdf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// Left edges of the HACCP report's columns, except colTemp which is the
+// right edge of the temperatures.
+const (
+	colTaken   = margin
+	colTemp    = 200
+	colStatus  = 215
+	colAction  = 140
+	colSource  = 290
+	colBy      = 340
+	colNote    = 440
+	colRight   = pageWidth - margin
+	haccpRow   = 12
+	haccpFloor = margin + 20
+)
+
+// maxActionLines is how many lines a corrective action may wrap onto.
+const maxActionLines = 6
+
+// HACCPReportRenderer renders HACCP temperature records for inspectors.
+type HACCPReportRenderer struct{}
+
+// NewHACCPReportRenderer creates a new HACCPReportRenderer.
+func NewHACCPReportRenderer() *HACCPReportRenderer {
+	return &HACCPReportRenderer{}
+}
+
+// haccpPage lays a report out over as many A4 pages as it needs.
+type haccpPage struct {
+	w   *writer
+	rep *haccp.Report
+	y   float64
+}
+
+// need starts a new page unless there is room for rows more rows.
+func (p *haccpPage) need(rows int) {
+	if p.y-float64(rows)*haccpRow >= haccpFloor {
+		return
+	}
+	p.w.addPage()
+	p.w.text(margin, pageHeight-margin, bold, 11, "HACCP temperature record (continued)")
+	p.y = pageHeight - margin - 25
+}
+
+// Render lays the report out unit by unit: the unit's readings, then its
+// alarms, then the corrective actions taken.
+func (r *HACCPReportRenderer) Render(rep *haccp.Report) ([]byte, error) {
+	if rep.Location == nil {
+		return nil, fmt.Errorf("rendering HACCP report: no time zone")
+	}
+	p := &haccpPage{w: newWriter("HACCP temperature record", rep.GeneratedAt), rep: rep}
+	p.w.addPage()
+	p.header()
+
+	if len(rep.Units) == 0 {
+		p.w.text(margin, p.y, regular, 10, "No units were in use over this period.")
+	}
+	for _, l := range rep.Units {
+		p.unit(l)
+	}
+
+	for i := range p.w.pages {
+		p.w.setPage(i)
+		p.w.textRight(colRight, margin-20, regular, 8, fmt.Sprintf("Page %d of %d", i+1, len(p.w.pages)))
+	}
+	return p.w.bytes(), nil
+}
+
+func (p *haccpPage) header() {
+	rep := p.rep
+	top := float64(pageHeight - margin)
+	p.w.text(margin, top-12, bold, 16, "HACCP TEMPERATURE RECORD")
+	branchName := rep.BranchName
+	if branchName == "" {
+		branchName = "All branches"
+	}
+	p.w.textRight(colRight, top, bold, 11, branchName)
+	p.w.textRight(colRight, top-14, regular, 9, rep.Period.From.Format("2 January 2006")+" to "+rep.Period.To.Format("2 January 2006"))
+	p.w.textRight(colRight, top-26, regular, 9, "Generated "+rep.GeneratedAt.In(rep.Location).Format("2 Jan 2006 15:04"))
+	p.y = top - 55
+}
+
+func (p *haccpPage) unit(l haccp.UnitLog) {
+	u := l.Unit
+	p.need(6)
+	p.w.rule(margin, colRight, p.y+haccpRow)
+	p.w.text(margin, p.y, bold, 11, u.Name()+" ("+string(u.Kind())+")")
+	if l.BranchName != "" {
+		p.w.textRight(colRight, p.y, regular, 9, l.BranchName)
+	}
+	p.y -= haccpRow + 2
+	readBy := "read by hand"
+	if u.SensorID() != "" {
+		readBy = "read by sensor " + u.SensorID()
+	}
+	p.w.text(margin, p.y, regular, 9, fmt.Sprintf("Safe range %s to %s, %s at least every %s",
+		formatCelsius(u.MinCelsius()), formatCelsius(u.MaxCelsius()), readBy, formatInterval(u.CheckEvery())))
+	p.y -= 2 * haccpRow
+
+	p.readings(l.Readings)
+	if len(l.Alerts) > 0 {
+		p.alerts(l.Alerts)
+	}
+	if len(l.Actions) > 0 {
+		p.actions(l.Actions)
+	}
+	p.y -= haccpRow
+}
+
+func (p *haccpPage) readings(readings []*haccp.Reading) {
+	p.need(3)
+	p.w.text(colTaken, p.y, bold, 9, "Taken")
+	p.w.textRight(colTemp, p.y, bold, 9, "Temp.")
+	p.w.text(colStatus, p.y, bold, 9, "Status")
+	p.w.text(colSource, p.y, bold, 9, "Source")
+	p.w.text(colBy, p.y, bold, 9, "By")
+	p.w.text(colNote, p.y, bold, 9, "Note")
+	p.y -= haccpRow
+	if len(readings) == 0 {
+		p.w.text(colTaken, p.y, regular, 9, "No readings were taken.")
+		p.y -= haccpRow
+	}
+	for _, rd := range readings {
+		p.need(1)
+		p.w.text(colTaken, p.y, regular, 9, p.when(rd.TakenAt()))
+		p.w.textRight(colTemp, p.y, regular, 9, formatCelsius(rd.Celsius()))
+		if rd.InRange() {
+			p.w.text(colStatus, p.y, regular, 9, "OK")
+		} else {
+			p.w.text(colStatus, p.y, bold, 9, "OUT OF RANGE")
+		}
+		p.w.text(colSource, p.y, regular, 9, string(rd.Source()))
+		p.w.text(colBy, p.y, regular, 9, truncate(p.staff(rd.RecordedBy()), regular, 9, colNote-colBy-5))
+		p.w.text(colNote, p.y, regular, 9, truncate(rd.Note(), regular, 9, colRight-colNote))
+		p.y -= haccpRow
+	}
+	p.y -= haccpRow / 2
+}
+
+func (p *haccpPage) alerts(alerts []*haccp.Alert) {
+	p.need(3)
+	p.w.text(colTaken, p.y, bold, 9, "Alarm raised")
+	p.w.textRight(colTemp, p.y, bold, 9, "Temp.")
+	p.w.text(colStatus, p.y, bold, 9, "Alarm")
+	p.w.text(colBy, p.y, bold, 9, "Resolved")
+	p.y -= haccpRow
+	for _, a := range alerts {
+		p.need(1)
+		p.w.text(colTaken, p.y, regular, 9, p.when(a.RaisedAt()))
+		if a.Celsius() != nil {
+			p.w.textRight(colTemp, p.y, regular, 9, formatCelsius(*a.Celsius()))
+		}
+		kind := "Out of range"
+		if a.Kind() == haccp.AlertMissing {
+			kind = "Reading missed"
+		}
+		p.w.text(colStatus, p.y, regular, 9, kind)
+		resolved := "Open"
+		if a.ResolvedAt() != nil {
+			resolved = p.when(*a.ResolvedAt()) + " by " + p.staff(a.ResolvedBy())
+		}
+		p.w.text(colBy, p.y, regular, 9, truncate(resolved, regular, 9, colRight-colBy))
+		p.y -= haccpRow
+	}
+	p.y -= haccpRow / 2
+}
+
+func (p *haccpPage) actions(actions []*haccp.CorrectiveAction) {
+	p.need(3)
+	p.w.text(colTaken, p.y, bold, 9, "Action taken")
+	p.w.text(colAction, p.y, bold, 9, "Corrective action")
+	p.w.text(colBy, p.y, bold, 9, "By")
+	p.y -= haccpRow
+	for _, c := range actions {
+		lines := wrap(c.Action(), regular, 9, colBy-colAction-10, maxActionLines)
+		p.need(len(lines))
+		p.w.text(colTaken, p.y, regular, 9, p.when(c.TakenAt()))
+		by := c.TakenBy()
+		p.w.text(colBy, p.y, regular, 9, truncate(p.staff(&by), regular, 9, colRight-colBy))
+		for _, line := range lines {
+			p.w.text(colAction, p.y, regular, 9, line)
+			p.y -= haccpRow
+		}
+	}
+	p.y -= haccpRow / 2
+}
+
+func (p *haccpPage) when(t time.Time) string {
+	return t.In(p.rep.Location).Format("02/01/2006 15:04")
+}
+
+func (p *haccpPage) staff(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	if name, ok := p.rep.Staff[*id]; ok {
+		return name
+	}
+	return id.String()
+}
+
+// formatCelsius formats a temperature to a tenth of a degree, e.g. -18.5 °C.
+func formatCelsius(c float64) string {
+	return fmt.Sprintf("%.1f °C", c)
+}
+
+// formatInterval formats how often a unit is read, e.g. 12h or 15m.
+func formatInterval(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
@@ -0,0 +1,76 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHACCPReport(t *testing.T, readings int) *haccp.Report {
+	t.Helper()
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	u, err := haccp.NewUnit(uuid.New(), uuid.New(), "Walk-in chiller", haccp.KindChiller, 0, 5, "", 12*time.Hour, start)
+	require.NoError(t, err)
+	staffID := uuid.New()
+
+	l := haccp.UnitLog{Unit: u, BranchName: "Whitechapel"}
+	for i := range readings {
+		celsius := 3.0
+		if i == 1 {
+			celsius = 7.5
+		}
+		at := start.Add(time.Duration(i) * 12 * time.Hour)
+		r, err := u.Record(uuid.New(), celsius, haccp.SourceManual, &staffID, "", at, at)
+		require.NoError(t, err)
+		l.Readings = append(l.Readings, r)
+	}
+	alert := haccp.NewOutOfRangeAlert(uuid.New(), l.Readings[1], l.Readings[1].TakenAt())
+	require.NoError(t, alert.Resolve(staffID, l.Readings[1].TakenAt().Add(time.Hour)))
+	l.Alerts = []*haccp.Alert{alert}
+	alertID := alert.ID()
+	action, err := haccp.NewCorrectiveAction(uuid.New(), u.ID(), &alertID, "Door seal replaced and stock moved to the reserve chiller", staffID, alert.RaisedAt())
+	require.NoError(t, err)
+	l.Actions = []*haccp.CorrectiveAction{action}
+
+	period, err := haccp.NewPeriod(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return &haccp.Report{
+		Period:      period,
+		BranchName:  "Whitechapel",
+		Location:    time.UTC,
+		Units:       []haccp.UnitLog{l},
+		Staff:       map[uuid.UUID]string{staffID: "Sam Ahmed"},
+		GeneratedAt: time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestHACCPReportRenderer_RendersDeterministicPDF(t *testing.T) {
+	rep := testHACCPReport(t, 4)
+
+	first, err := NewHACCPReportRenderer().Render(rep)
+	require.NoError(t, err)
+	second, err := NewHACCPReportRenderer().Render(rep)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.True(t, bytes.HasPrefix(first, []byte("%PDF-1.4\n")))
+	assert.Contains(t, string(first), "(Whitechapel)")
+	assert.Contains(t, string(first), "(1 October 2026 to 31 October 2026)")
+	assert.Contains(t, string(first), "(7.5 \xb0C)")
+	assert.Contains(t, string(first), "(OUT OF RANGE)")
+	assert.Contains(t, string(first), "(Sam Ahmed)")
+	assert.Contains(t, string(first), "(01/10/2026 21:00 by Sam Ahmed)")
+}
+
+func TestHACCPReportRenderer_BreaksLongReportsOntoPages(t *testing.T) {
+	out, err := NewHACCPReportRenderer().Render(testHACCPReport(t, 120))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "/Count 3")
+	assert.Contains(t, string(out), "(Page 3 of 3)")
+}
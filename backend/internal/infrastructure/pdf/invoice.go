@@ -0,0 +1,229 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+)
+
+// Right edges of the line table's columns, after the description.
+const (
+	colWeight   = 290
+	colPrice    = 355
+	colRate     = 395
+	colNet      = 450
+	colVAT      = 500
+	colGross    = pageWidth - margin
+	descWidth   = colWeight - margin - 60
+	rowHeight   = 14
+	tableBottom = 110
+)
+
+// InvoiceRenderer renders invoices and credit notes. Dates are printed in
+// the shop's time zone.
+type InvoiceRenderer struct {
+	location *time.Location
+}
+
+// NewInvoiceRenderer creates a new InvoiceRenderer.
+func NewInvoiceRenderer(location *time.Location) *InvoiceRenderer {
+	return &InvoiceRenderer{location: location}
+}
+
+// Render lays out the document on as many A4 pages as its lines need.
+func (r *InvoiceRenderer) Render(d *invoice.Document) ([]byte, error) {
+	if d.Number() == "" {
+		return nil, fmt.Errorf("rendering document %s: document has not been numbered", d.ID())
+	}
+
+	w := newWriter(d.Number(), d.IssuedAt())
+	w.addPage()
+	y := r.header(w, d)
+	y = tableHeader(w, y)
+
+	for _, l := range d.Lines() {
+		if y < tableBottom {
+			w.addPage()
+			w.text(margin, pageHeight-margin, bold, 11, fmt.Sprintf("%s %s (continued)", title(d.Kind()), d.Number()))
+			y = tableHeader(w, pageHeight-margin-30)
+		}
+		w.text(margin, y, regular, 9, truncate(l.Description, regular, 9, descWidth))
+		if l.Grams > 0 {
+			w.textRight(colWeight, y, regular, 9, formatWeight(l.Grams))
+			w.textRight(colPrice, y, regular, 9, formatMoney(l.PricePerKgCents, d.Currency()))
+		}
+		w.textRight(colRate, y, regular, 9, formatRate(l.VATRateBP))
+		w.textRight(colNet, y, regular, 9, formatMoney(l.NetCents, d.Currency()))
+		w.textRight(colVAT, y, regular, 9, formatMoney(l.VATCents, d.Currency()))
+		w.textRight(colGross, y, regular, 9, formatMoney(l.GrossCents, d.Currency()))
+		y -= rowHeight
+	}
+
+	bands := d.VATBands()
+	if y-float64(len(bands)+6)*rowHeight < margin {
+		w.addPage()
+		y = pageHeight - margin
+	}
+	w.rule(margin, colGross, y+rowHeight-4)
+	y -= 10
+	r.summary(w, d, bands, y)
+
+	for i := range w.pages {
+		w.setPage(i)
+		w.textRight(colGross, margin-20, regular, 8, fmt.Sprintf("Page %d of %d", i+1, len(w.pages)))
+	}
+	return w.bytes(), nil
+}
+
+// header prints the title, seller, buyer and document details, and
+// returns where the line table starts.
+func (r *InvoiceRenderer) header(w *writer, d *invoice.Document) float64 {
+	top := float64(pageHeight - margin)
+	w.text(margin, top-12, bold, 18, strings.ToUpper(title(d.Kind())))
+
+	seller := d.Seller()
+	y := top
+	w.textRight(colGross, y, bold, 11, seller.Name)
+	for _, line := range seller.Address {
+		y -= 12
+		w.textRight(colGross, y, regular, 9, line)
+	}
+	if seller.VATNumber != "" {
+		y -= 12
+		w.textRight(colGross, y, regular, 9, "VAT reg. no. "+seller.VATNumber)
+	}
+	if seller.CompanyNumber != "" {
+		y -= 12
+		w.textRight(colGross, y, regular, 9, "Company no. "+seller.CompanyNumber)
+	}
+	if seller.Email != "" {
+		y -= 12
+		w.textRight(colGross, y, regular, 9, seller.Email)
+	}
+
+	y = min(y, top-40) - 30
+	details := [][2]string{
+		{"Number", d.Number()},
+		{"Date", d.IssuedAt().In(r.location).Format("2 January 2006")},
+		{"Order", d.OrderID().String()},
+	}
+	if d.Kind() == invoice.KindCreditNote {
+		details = append(details, [2]string{"Credits invoice", d.CreditedNumber()})
+	}
+	detailY := y
+	for _, kv := range details {
+		w.text(margin, detailY, bold, 9, kv[0])
+		w.text(margin+80, detailY, regular, 9, kv[1])
+		detailY -= 12
+	}
+
+	buyer := d.Buyer()
+	buyerY := y
+	w.text(330, buyerY, bold, 9, "Bill to")
+	buyerLines := append([]string{buyer.Name}, buyer.Address...)
+	buyerLines = append(buyerLines, buyer.Email)
+	if buyer.VATNumber != "" {
+		buyerLines = append(buyerLines, "VAT reg. no. "+buyer.VATNumber)
+	}
+	for _, line := range buyerLines {
+		if line == "" {
+			continue
+		}
+		buyerY -= 12
+		w.text(330, buyerY, regular, 9, line)
+	}
+
+	return min(detailY, buyerY) - 30
+}
+
+// tableHeader prints the column headings of the line table at y and
+// returns where the first row goes.
+func tableHeader(w *writer, y float64) float64 {
+	w.text(margin, y, bold, 9, "Description")
+	w.textRight(colWeight, y, bold, 9, "Weight")
+	w.textRight(colPrice, y, bold, 9, "Price/kg")
+	w.textRight(colRate, y, bold, 9, "VAT %")
+	w.textRight(colNet, y, bold, 9, "Net")
+	w.textRight(colVAT, y, bold, 9, "VAT")
+	w.textRight(colGross, y, bold, 9, "Total")
+	w.rule(margin, colGross, y-5)
+	return y - 20
+}
+
+// summary prints the VAT breakdown by rate and the document totals.
+func (r *InvoiceRenderer) summary(w *writer, d *invoice.Document, bands []invoice.VATBand, y float64) {
+	w.text(margin, y, bold, 9, "VAT summary")
+	w.textRight(colNet, y, bold, 9, "Net")
+	w.textRight(colVAT, y, bold, 9, "VAT")
+	w.textRight(colGross, y, bold, 9, "Total")
+	for _, b := range bands {
+		y -= rowHeight
+		w.text(margin, y, regular, 9, "VAT at "+formatRate(b.RateBP))
+		w.textRight(colNet, y, regular, 9, formatMoney(b.NetCents, d.Currency()))
+		w.textRight(colVAT, y, regular, 9, formatMoney(b.VATCents, d.Currency()))
+		w.textRight(colGross, y, regular, 9, formatMoney(b.GrossCents, d.Currency()))
+	}
+
+	y -= 2 * rowHeight
+	totalLabel := "Total due"
+	if d.Kind() == invoice.KindCreditNote {
+		totalLabel = "Total credited"
+	}
+	for _, t := range []struct {
+		label string
+		cents int64
+		f     font
+	}{
+		{"Total net", d.NetCents(), regular},
+		{"Total VAT", d.VATCents(), regular},
+		{totalLabel, d.GrossCents(), bold},
+	} {
+		w.textRight(colVAT, y, t.f, 10, t.label)
+		w.textRight(colGross, y, t.f, 10, formatMoney(t.cents, d.Currency()))
+		y -= rowHeight
+	}
+}
+
+func title(k invoice.Kind) string {
+	if k == invoice.KindCreditNote {
+		return "Credit note"
+	}
+	return "VAT invoice"
+}
+
+// formatMoney formats cents with the currency's symbol and thousands
+// separators, e.g. £1,234.56.
+func formatMoney(cents int64, currency string) string {
+	symbol := strings.ToUpper(currency) + " "
+	switch strings.ToLower(currency) {
+	case "gbp":
+		symbol = "£"
+	case "eur":
+		symbol = "€"
+	case "usd":
+		symbol = "$"
+	}
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	units := fmt.Sprintf("%d", cents/100)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "," + units[i:]
+	}
+	return fmt.Sprintf("%s%s%s.%02d", sign, symbol, units, cents%100)
+}
+
+// formatWeight formats grams as kilograms, e.g. 1.250 kg.
+func formatWeight(grams int64) string {
+	return fmt.Sprintf("%d.%03d kg", grams/1000, grams%1000)
+}
+
+// formatRate formats basis points as a percentage, e.g. 5.5%.
+func formatRate(bp int) string {
+	s := fmt.Sprintf("%d.%02d", bp/100, bp%100)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".") + "%"
+}
@@ -0,0 +1,90 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument(t *testing.T, lines int) *invoice.Document {
+	t.Helper()
+	in := make([]invoice.LineInput, 0, lines)
+	for i := range lines {
+		in = append(in, invoice.LineInput{
+			Description: fmt.Sprintf("Lamb shoulder (bone-in) #%d", i+1), Grams: 1250, PricePerKgCents: 2000,
+			VATRateBP: 2000 * (i % 2), VATCents: 417 * int64(i%2), GrossCents: 2500,
+		})
+	}
+	d, err := invoice.NewInvoice(uuid.New(), uuid.New(), uuid.New(), uuid.New(),
+		invoice.Party{Name: "Halal Butchery Ltd", Address: []string{"1 High Street", "London E1 6AN"}, VATNumber: "GB123456789"},
+		invoice.Party{Name: "Le Bistro", Email: "accounts@bistro.example"},
+		in, "gbp", 2026, time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	d.AssignNumber(1)
+	return d
+}
+
+func TestInvoiceRenderer_RendersDeterministicPDF(t *testing.T) {
+	r := NewInvoiceRenderer(time.UTC)
+	d := testDocument(t, 3)
+
+	first, err := r.Render(d)
+	require.NoError(t, err)
+	second, err := r.Render(d)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.True(t, bytes.HasPrefix(first, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(first, []byte("%%EOF\n")))
+	assert.Contains(t, string(first), "("+d.Number()+")")
+	assert.Contains(t, string(first), "(\xa325.00)", "amounts are shown in pounds")
+	assert.Contains(t, string(first), "(1.250 kg)")
+	assert.Contains(t, string(first), "(VAT at 20%)")
+	assert.Contains(t, string(first), "(VAT reg. no. GB123456789)")
+
+	// The cross-reference table must point at every object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(first)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(first[xref:], []byte("xref\n")))
+	for i, off := range regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(first, -1) {
+		at, _ := strconv.Atoi(string(off[1]))
+		assert.True(t, bytes.HasPrefix(first[at:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+}
+
+func TestInvoiceRenderer_BreaksLongDocumentsOntoPages(t *testing.T) {
+	out, err := NewInvoiceRenderer(time.UTC).Render(testDocument(t, 80))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "/Count 3")
+	assert.Contains(t, string(out), "(Page 3 of 3)")
+}
+
+func TestInvoiceRenderer_UnnumberedDocument_ReturnsError(t *testing.T) {
+	d, err := invoice.NewInvoice(uuid.New(), uuid.New(), uuid.New(), uuid.New(), invoice.Party{}, invoice.Party{},
+		[]invoice.LineInput{{GrossCents: 100}}, "gbp", 2026, time.Now())
+	require.NoError(t, err)
+
+	_, err = NewInvoiceRenderer(time.UTC).Render(d)
+	assert.Error(t, err)
+}
+
+func TestFormatting(t *testing.T) {
+	assert.Equal(t, "£1,234,567.08", formatMoney(123456708, "gbp"))
+	assert.Equal(t, "SEK 5.00", formatMoney(500, "sek"))
+	assert.Equal(t, "0.050 kg", formatWeight(50))
+	assert.Equal(t, "5.5%", formatRate(550))
+	assert.Equal(t, "0%", formatRate(0))
+	assert.Equal(t, `a\(b\)\\`, escape(encode(`a(b)\`)))
+	assert.Equal(t, []byte("caf\xe9 ?"), encode("café ✓"))
+}
@@ -0,0 +1,111 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+)
+
+// pointsPerMM converts template millimetres to PDF points.
+const pointsPerMM = 72 / 25.4
+
+// LabelRenderer renders labels as a PDF with one label-sized page per
+// label, for printing on sheets of labels from an office printer.
+type LabelRenderer struct{}
+
+// NewLabelRenderer creates a new LabelRenderer.
+func NewLabelRenderer() *LabelRenderer {
+	return &LabelRenderer{}
+}
+
+// Render lays labels out on the template. The file is dated by the first
+// label's packing date so that rendering the same labels again gives the
+// same bytes.
+func (r *LabelRenderer) Render(t label.Template, labels []label.Label) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, label.ErrNoLabels
+	}
+
+	height := t.HeightMM * pointsPerMM
+	w := newSizedWriter("Labels", labels[0].PackedOn, t.WidthMM*pointsPerMM, height)
+	for _, l := range labels {
+		w.addPage()
+		for _, e := range t.Elements {
+			text := l.Text(e.Field)
+			if text == "" {
+				continue
+			}
+			x, top := e.X*pointsPerMM, height-e.Y*pointsPerMM
+			width, size := e.Width*pointsPerMM, e.Height*pointsPerMM
+			switch e.Field {
+			case label.FieldBarcode:
+				if err := bars(w, x, top, width, size, text); err != nil {
+					return nil, fmt.Errorf("rendering label for %q: %w", l.Description, err)
+				}
+			case label.FieldHalal:
+				d := min(width, size)
+				w.ring(x, top-d, d, d/16)
+				w.text(x+(d-textWidth(text, bold, d/5))/2, top-d/2-d/14, bold, d/5, text)
+			default:
+				f := regular
+				if e.Bold {
+					f = bold
+				}
+				y := top - size*0.8
+				for _, line := range wrap(text, f, size, width, max(e.Lines, 1)) {
+					w.text(x, y, f, size, line)
+					y -= size * 1.1
+				}
+			}
+		}
+	}
+	return w.bytes(), nil
+}
+
+// bars draws code's bars size points tall from top, scaled to width, with
+// its digits beneath.
+func bars(w *writer, x, top, width, size float64, code string) error {
+	modules, err := barcode.Modules(code)
+	if err != nil {
+		return err
+	}
+	module := width / float64(len(modules))
+	for start := 0; start < len(modules); {
+		if !modules[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < len(modules) && modules[end] {
+			end++
+		}
+		w.fill(x+float64(start)*module, top-size, float64(end-start)*module, size)
+		start = end
+	}
+	digitSize := min(size/3, 8)
+	w.text(x+(width-textWidth(code, regular, digitSize))/2, top-size-digitSize, regular, digitSize, code)
+	return nil
+}
+
+// wrap breaks s into at most lines lines that fit in width points, the last
+// shortened with an ellipsis if s does not fit.
+func wrap(s string, f font, size, width float64, lines int) []string {
+	var out []string
+	current := ""
+	words := strings.Fields(s)
+	for i, word := range words {
+		next := strings.TrimSpace(current + " " + word)
+		if current == "" || textWidth(next, f, size) <= width {
+			current = next
+			continue
+		}
+		if len(out) == lines-1 {
+			return append(out, truncate(strings.Join(append([]string{current}, words[i:]...), " "), f, size, width))
+		}
+		out = append(out, current)
+		current = word
+	}
+	return append(out, truncate(current, f, size, width))
+}
@@ -0,0 +1,63 @@
+package pdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelRenderer_RendersOnePagePerLabel(t *testing.T) {
+	tmpl, err := label.FindTemplate("standard")
+	require.NoError(t, err)
+	useBy := time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)
+	l := label.Label{
+		Description:     "Lamb mince (20% fat)",
+		Grams:           1250,
+		PricePerKgCents: 1299,
+		TotalCents:      1624,
+		Currency:        "gbp",
+		PackedOn:        time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		UseBy:           &useBy,
+		LotCode:         "L-1019",
+		Allergens:       []string{"gluten", "sulphites"},
+		Halal:           true,
+		Barcode:         "2100123016242",
+	}
+
+	r := NewLabelRenderer()
+	first, err := r.Render(tmpl, []label.Label{l, l})
+	require.NoError(t, err)
+	second, err := r.Render(tmpl, []label.Label{l, l})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	out := string(first)
+	assert.True(t, bytes.HasPrefix(first, []byte("%PDF-1.4\n")))
+	assert.Contains(t, out, "/Count 2")
+	assert.Contains(t, out, "/MediaBox [0 0 170.08 170.08]", "60 mm square")
+	assert.Contains(t, out, "(Lamb mince \\(20% fat\\))")
+	assert.Contains(t, out, "(\xa316.24)")
+	assert.Contains(t, out, "(Use by 22 Oct 2026)")
+	assert.Contains(t, out, "(Contains: gluten, sulphites)")
+	assert.Contains(t, out, "(HALAL)")
+	assert.Contains(t, out, "(2100123016242)")
+	assert.Equal(t, 2*30, strings.Count(out, " re f\n"), "an EAN-13 has 30 bars")
+
+	_, err = r.Render(tmpl, nil)
+	assert.ErrorIs(t, err, label.ErrNoLabels)
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"Lamb mince"}, wrap("Lamb mince", regular, 10, 100, 2))
+	assert.Equal(t, []string{"Boneless lamb", "shoulder rolled"}, wrap("Boneless lamb shoulder rolled", regular, 10, 80, 2))
+
+	lines := wrap("Boneless lamb shoulder rolled and tied with rosemary", regular, 10, 80, 2)
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[1], "..."))
+	assert.LessOrEqual(t, textWidth(lines[1], regular, 10), 80.0)
+}
@@ -0,0 +1,169 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// Receipt roll width and layout, in points. The page is as tall as the
+// receipt needs.
+const (
+	receiptWidth  = 80 * pointsPerMM
+	receiptMargin = 10
+	receiptRight  = receiptWidth - receiptMargin
+	receiptLine   = 11
+)
+
+// ReceiptRenderer renders till receipts for an 80mm receipt roll. Times are
+// printed in the shop's time zone.
+type ReceiptRenderer struct {
+	shop     invoice.Party
+	currency string
+	location *time.Location
+}
+
+// NewReceiptRenderer creates a new ReceiptRenderer.
+func NewReceiptRenderer(shop invoice.Party, currency string, location *time.Location) *ReceiptRenderer {
+	return &ReceiptRenderer{shop: shop, currency: currency, location: location}
+}
+
+// row is one line of a receipt: text on the left and, optionally, an
+// amount on the right.
+type row struct {
+	left, right string
+	f           font
+	size        float64
+	centred     bool
+}
+
+// Render lays the receipt out on a single page.
+func (r *ReceiptRenderer) Render(rc pos.Receipt) ([]byte, error) {
+	s := rc.Sale
+	if s.ReceiptNumber() == "" {
+		return nil, fmt.Errorf("rendering receipt for sale %s: sale has not been completed", s.ID())
+	}
+
+	rows := r.rows(rc)
+	height := float64(len(rows))*receiptLine + 2*receiptMargin + 10
+	w := newSizedWriter(s.ReceiptNumber(), *s.CompletedAt(), receiptWidth, height)
+	w.addPage()
+	y := height - receiptMargin - 10
+	for _, row := range rows {
+		switch {
+		case row.left == "" && row.right == "":
+			w.rule(receiptMargin, receiptRight, y+3)
+		case row.centred:
+			w.text((receiptWidth-textWidth(row.left, row.f, row.size))/2, y, row.f, row.size, row.left)
+		default:
+			width := receiptRight - receiptMargin
+			if row.right != "" {
+				width -= textWidth(row.right, row.f, row.size) + 6
+			}
+			w.text(receiptMargin, y, row.f, row.size, truncate(row.left, row.f, row.size, width))
+			w.textRight(receiptRight, y, row.f, row.size, row.right)
+		}
+		y -= receiptLine
+	}
+	return w.bytes(), nil
+}
+
+func (r *ReceiptRenderer) rows(rc pos.Receipt) []row {
+	s := rc.Sale
+	money := func(cents int64) string { return formatMoney(cents, r.currency) }
+	rows := []row{{left: r.shop.Name, f: bold, size: 11, centred: true}}
+	for _, line := range r.shop.Address {
+		rows = append(rows, row{left: line, f: regular, size: 8, centred: true})
+	}
+	if r.shop.VATNumber != "" {
+		rows = append(rows, row{left: "VAT reg. no. " + r.shop.VATNumber, f: regular, size: 8, centred: true})
+	}
+	rows = append(rows, row{})
+
+	if s.Kind() == pos.KindReturn {
+		rows = append(rows, row{left: "REFUND", f: bold, size: 10, centred: true})
+	}
+	rows = append(rows,
+		row{left: "Receipt", right: s.ReceiptNumber(), f: regular, size: 8},
+		row{left: "Date", right: s.CompletedAt().In(r.location).Format("02/01/2006 15:04"), f: regular, size: 8},
+		row{left: "Till", right: rc.TillName, f: regular, size: 8},
+		row{left: "Served by", right: rc.CashierName, f: regular, size: 8},
+	)
+	if rc.OriginalReceiptNumber != "" {
+		rows = append(rows, row{left: "Against receipt", right: rc.OriginalReceiptNumber, f: regular, size: 8})
+	}
+	if s.Reason() != "" && s.Kind() == pos.KindReturn {
+		rows = append(rows, row{left: "Reason: " + s.Reason(), f: regular, size: 8})
+	}
+	rows = append(rows, row{})
+
+	for _, l := range s.LiveLines() {
+		rows = append(rows,
+			row{left: l.Description, right: money(l.GrossCents), f: regular, size: 9},
+			row{left: fmt.Sprintf("  %s @ %s/kg", formatWeight(l.Grams), money(l.PricePerKgCents)), f: regular, size: 8},
+		)
+		if l.ReturnedGrams > 0 {
+			rows = append(rows, row{left: "  Returned " + formatWeight(l.ReturnedGrams), right: money(-l.ReturnedCents), f: regular, size: 8})
+		}
+	}
+	rows = append(rows, row{})
+
+	total := "Total"
+	if s.Kind() == pos.KindReturn {
+		total = "Total refunded"
+	}
+	rows = append(rows, row{left: total, right: money(s.TotalCents()), f: bold, size: 10})
+	for _, p := range s.Payments() {
+		label := "Cash"
+		if p.Method == pos.MethodCard {
+			label = "Card"
+			if p.Reference != "" {
+				label += " " + p.Reference
+			}
+		}
+		rows = append(rows, row{left: label, right: money(p.TenderedCents), f: regular, size: 9})
+	}
+	if change := s.ChangeCents(); change > 0 {
+		rows = append(rows, row{left: "Change", right: money(change), f: regular, size: 9})
+	}
+	rows = append(rows, row{})
+
+	rows = append(rows, row{left: "VAT summary", right: "VAT", f: bold, size: 8})
+	for _, b := range vatBands(s.LiveLines()) {
+		rows = append(rows, row{
+			left:  fmt.Sprintf("%s on %s", formatRate(b.RateBP), money(b.GrossCents)),
+			right: money(b.VATCents), f: regular, size: 8,
+		})
+	}
+
+	if s.Status() == pos.SaleVoided {
+		rows = append(rows, row{}, row{left: "VOIDED", f: bold, size: 10, centred: true})
+	} else {
+		rows = append(rows, row{}, row{left: "Thank you", f: regular, size: 9, centred: true})
+	}
+	return rows
+}
+
+// vatBands totals lines by VAT rate, lowest rate first.
+func vatBands(lines []pos.Line) []pos.Band {
+	byRate := map[int]*pos.Band{}
+	for _, l := range lines {
+		b, ok := byRate[l.VATRateBP]
+		if !ok {
+			b = &pos.Band{RateBP: l.VATRateBP}
+			byRate[l.VATRateBP] = b
+		}
+		b.NetCents += l.NetCents
+		b.VATCents += l.VATCents
+		b.GrossCents += l.GrossCents
+	}
+	bands := make([]pos.Band, 0, len(byRate))
+	for _, b := range byRate {
+		bands = append(bands, *b)
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].RateBP < bands[j].RateBP })
+	return bands
+}
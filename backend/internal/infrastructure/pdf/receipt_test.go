@@ -0,0 +1,63 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptRenderer_RendersSaleWithSplitPayment(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	till, err := pos.NewTill(uuid.New(), uuid.New(), "Till 1", now)
+	require.NoError(t, err)
+	session, err := pos.OpenSession(uuid.New(), till, 5000, uuid.New(), now)
+	require.NoError(t, err)
+	s, err := pos.NewSale(uuid.New(), session, uuid.New(), now)
+	require.NoError(t, err)
+	_, err = s.AddLine(pos.Line{ProductID: uuid.New(), PLUCode: 101, Description: "Lamb chops", Grams: 750, PricePerKgCents: 1800, AmountCents: 1350}, now)
+	require.NoError(t, err)
+	require.NoError(t, s.Reprice([]pos.LinePrice{{VATRateBP: 2000, NetCents: 1125, VATCents: 225, GrossCents: 1350}}))
+	_, err = s.Pay(pos.MethodCard, 1000, "AUTH1", now)
+	require.NoError(t, err)
+	_, err = s.Pay(pos.MethodCash, 500, "", now)
+	require.NoError(t, err)
+	require.NoError(t, s.Complete("POS-000042", now))
+
+	r := NewReceiptRenderer(invoice.Party{Name: "Halal Butchery Ltd", Address: []string{"1 High Street"}, VATNumber: "GB123456789"}, "gbp", time.UTC)
+	receipt := pos.Receipt{Sale: s, TillName: "Till 1", CashierName: "Sam"}
+	first, err := r.Render(receipt)
+	require.NoError(t, err)
+	second, err := r.Render(receipt)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.True(t, bytes.HasPrefix(first, []byte("%PDF-1.4\n")))
+	out := string(first)
+	assert.Contains(t, out, "(POS-000042)")
+	assert.Contains(t, out, "(19/10/2026 09:30)")
+	assert.Contains(t, out, "(\xa313.50)")
+	assert.Contains(t, out, "(  0.750 kg @ \xa318.00/kg)")
+	assert.Contains(t, out, "(Card AUTH1)")
+	assert.Contains(t, out, "(Change)")
+	assert.Contains(t, out, "(\xa31.50)", "change from the cash tendered")
+	assert.Contains(t, out, "(20% on \xa313.50)")
+	assert.Contains(t, out, "(VAT reg. no. GB123456789)")
+}
+
+func TestReceiptRenderer_UncompletedSale_ReturnsError(t *testing.T) {
+	till, err := pos.NewTill(uuid.New(), uuid.New(), "Till 1", time.Now())
+	require.NoError(t, err)
+	session, err := pos.OpenSession(uuid.New(), till, 0, uuid.New(), time.Now())
+	require.NoError(t, err)
+	s, err := pos.NewSale(uuid.New(), session, uuid.New(), time.Now())
+	require.NoError(t, err)
+
+	_, err = NewReceiptRenderer(invoice.Party{}, "gbp", time.UTC).Render(pos.Receipt{Sale: s})
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+// Package pdf renders documents to PDF without any dependencies. Output is
+// deterministic: the same input always produces the same bytes.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A4 page size and margin, in points.
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 50
+)
+
+type font int
+
+const (
+	regular font = iota
+	bold
+)
+
+// writer builds a PDF 1.4 file of pages holding text, rules and filled
+// shapes. Text is set in the standard Helvetica fonts, which every reader
+// provides, so nothing is embedded.
+type writer struct {
+	title   string
+	created time.Time
+	width   float64
+	height  float64
+	pages   []*bytes.Buffer
+	current int
+}
+
+// newWriter starts a file of A4 pages.
+func newWriter(title string, created time.Time) *writer {
+	return newSizedWriter(title, created, pageWidth, pageHeight)
+}
+
+// newSizedWriter starts a file of pages width by height points.
+func newSizedWriter(title string, created time.Time, width, height float64) *writer {
+	return &writer{title: title, created: created, width: width, height: height}
+}
+
+// addPage starts a new page and draws on it from now on.
+func (w *writer) addPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	w.current = len(w.pages) - 1
+}
+
+// setPage draws on an earlier page, e.g. to number pages once all are laid
+// out.
+func (w *writer) setPage(i int) { w.current = i }
+
+func (w *writer) page() *bytes.Buffer { return w.pages[w.current] }
+
+// text draws s with its baseline starting at x, y.
+func (w *writer) text(x, y float64, f font, size float64, s string) {
+	fmt.Fprintf(w.page(), "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", f+1, num(size), num(x), num(y), escape(encode(s)))
+}
+
+// textRight draws s so that it ends at x.
+func (w *writer) textRight(x, y float64, f font, size float64, s string) {
+	w.text(x-textWidth(s, f, size), y, f, size, s)
+}
+
+// rule draws a horizontal line from x1 to x2.
+func (w *writer) rule(x1, x2, y float64) {
+	fmt.Fprintf(w.page(), "0.5 w %s %s m %s %s l S\n", num(x1), num(y), num(x2), num(y))
+}
+
+// fill draws a filled rectangle with its bottom left corner at x, y.
+func (w *writer) fill(x, y, width, height float64) {
+	fmt.Fprintf(w.page(), "%s %s %s %s re f\n", num(x), num(y), num(width), num(height))
+}
+
+// ring draws a circle of diameter d with its bottom left corner at x, y,
+// as four Bezier curves.
+func (w *writer) ring(x, y, d, lineWidth float64) {
+	const k = 0.5523
+	r := d / 2
+	cx, cy := x+r, y+r
+	fmt.Fprintf(w.page(), "%s w %s %s m ", num(lineWidth), num(cx+r), num(cy))
+	fmt.Fprintf(w.page(), "%s %s %s %s %s %s c ", num(cx+r), num(cy+k*r), num(cx+k*r), num(cy+r), num(cx), num(cy+r))
+	fmt.Fprintf(w.page(), "%s %s %s %s %s %s c ", num(cx-k*r), num(cy+r), num(cx-r), num(cy+k*r), num(cx-r), num(cy))
+	fmt.Fprintf(w.page(), "%s %s %s %s %s %s c ", num(cx-r), num(cy-k*r), num(cx-k*r), num(cy-r), num(cx), num(cy-r))
+	fmt.Fprintf(w.page(), "%s %s %s %s %s %s c S\n", num(cx+k*r), num(cy-r), num(cx+r), num(cy-k*r), num(cx+r), num(cy))
+}
+
+// bytes lays out the file: catalog, page tree, fonts and info first, then
+// each page with its content stream, then the cross-reference table.
+func (w *writer) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	const firstPage = 6
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (butchery-app) /CreationDate (D:%s) >>",
+		escape(encode(w.title)), w.created.UTC().Format("20060102150405Z")))
+
+	for i, content := range w.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(w.width), num(w.height), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// num formats a coordinate without trailing zeros.
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// encode maps s onto WinAnsiEncoding, replacing anything it cannot show.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '€':
+			out = append(out, 0x80)
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// textWidth measures s in points using the Helvetica metrics.
+func textWidth(s string, f font, size float64) float64 {
+	widths := helveticaWidths
+	if f == bold {
+		widths = helveticaBoldWidths
+	}
+	var units int
+	for _, c := range encode(s) {
+		if c >= 0x20 && c < 0x7f {
+			units += widths[c-0x20]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// truncate shortens s with an ellipsis so that it fits in width points.
+func truncate(s string, f font, size, width float64) string {
+	if textWidth(s, f, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", f, size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "..."
+}
+
+// Advance widths of the printable ASCII characters, from the Adobe font
+// metrics of the standard fonts.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
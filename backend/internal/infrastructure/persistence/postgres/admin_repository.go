@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
 )
 
 // AdminRepository implements admin.Repository using PostgreSQL.
@@ -21,39 +22,114 @@ func NewAdminRepository(pool *pgxpool.Pool) *AdminRepository {
 	return &AdminRepository{pool: pool}
 }
 
+// adminColumns selects an admin with the branches they are assigned to.
+const adminColumns = `id, email, password_hash, full_name, role, all_branches,
+	ARRAY(SELECT branch_id FROM admin_branches ab WHERE ab.admin_id = admins.id ORDER BY branch_id)`
+
 // FindByEmail finds an admin by email address.
 func (r *AdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
-	var id uuid.UUID
-	var dbEmail, passwordHash, fullName string
-
-	err := r.pool.QueryRow(ctx,
-		"SELECT id, email, password_hash, full_name FROM admins WHERE email = $1",
-		email,
-	).Scan(&id, &dbEmail, &passwordHash, &fullName)
+	a, err := scanAdmin(r.pool.QueryRow(ctx, "SELECT "+adminColumns+" FROM admins WHERE email = $1", email))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, admin.ErrAdminNotFound
 		}
 		return nil, fmt.Errorf("querying admin by email: %w", err)
 	}
-
-	return admin.NewAdmin(id, dbEmail, passwordHash, fullName)
+	return a, nil
 }
 
 // FindByID finds an admin by ID.
 func (r *AdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
-	var dbEmail, passwordHash, fullName string
-
-	err := r.pool.QueryRow(ctx,
-		"SELECT email, password_hash, full_name FROM admins WHERE id = $1",
-		id,
-	).Scan(&dbEmail, &passwordHash, &fullName)
+	a, err := scanAdmin(r.pool.QueryRow(ctx, "SELECT "+adminColumns+" FROM admins WHERE id = $1", id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, admin.ErrAdminNotFound
 		}
 		return nil, fmt.Errorf("querying admin by id: %w", err)
 	}
+	return a, nil
+}
+
+// FindAll returns admins by name. In a scoped context only admins assigned
+// to a branch in scope are returned.
+func (r *AdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	cond, args := branchScope(ctx, "ab.branch_id", nil)
+	query := "SELECT " + adminColumns + " FROM admins"
+	if !branch.ScopeFrom(ctx).All() {
+		query += " WHERE EXISTS (SELECT 1 FROM admin_branches ab WHERE ab.admin_id = admins.id AND " + cond + ")"
+	}
+	rows, err := r.pool.Query(ctx, query+" ORDER BY LOWER(full_name), id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying admins: %w", err)
+	}
+	defer rows.Close()
 
-	return admin.NewAdmin(id, dbEmail, passwordHash, fullName)
+	var admins []*admin.Admin
+	for rows.Next() {
+		a, err := scanAdmin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning admin: %w", err)
+		}
+		admins = append(admins, a)
+	}
+	return admins, rows.Err()
+}
+
+// SaveBranches replaces the branches the admin is assigned to.
+func (r *AdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, "UPDATE admins SET all_branches = $2, updated_at = NOW() WHERE id = $1", a.ID(), a.AllBranches())
+	if err != nil {
+		return fmt.Errorf("updating admin: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return admin.ErrAdminNotFound
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM admin_branches WHERE admin_id = $1", a.ID()); err != nil {
+		return fmt.Errorf("clearing admin branches: %w", err)
+	}
+	for _, branchID := range a.BranchIDs() {
+		if _, err := tx.Exec(ctx, "INSERT INTO admin_branches (admin_id, branch_id) VALUES ($1, $2)", a.ID(), branchID); err != nil {
+			return fmt.Errorf("inserting admin branch: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing admin branches: %w", err)
+	}
+	return nil
+}
+
+// SaveRole stores the admin's role.
+func (r *AdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	result, err := r.pool.Exec(ctx, "UPDATE admins SET role = $2, updated_at = NOW() WHERE id = $1", a.ID(), string(a.Role()))
+	if err != nil {
+		return fmt.Errorf("updating admin role: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return admin.ErrAdminNotFound
+	}
+	return nil
+}
+
+func scanAdmin(row pgx.Row) (*admin.Admin, error) {
+	var id uuid.UUID
+	var email, passwordHash, fullName, role string
+	var allBranches bool
+	var branchIDs []uuid.UUID
+
+	if err := row.Scan(&id, &email, &passwordHash, &fullName, &role, &allBranches, &branchIDs); err != nil {
+		return nil, err
+	}
+	a, err := admin.NewAdmin(id, email, passwordHash, fullName, admin.Role(role))
+	if err != nil {
+		return nil, err
+	}
+	a.AssignBranches(allBranches, branchIDs)
+	return a, nil
 }
@@ -36,6 +36,7 @@ func TestIntegrationAdminRepository_FindByEmail(t *testing.T) {
 		assert.Equal(t, "admin@butchery.com", a.Email())
 		assert.Equal(t, "$2a$10$hashvalue", a.PasswordHash())
 		assert.Equal(t, "Butchery Admin", a.FullName())
+		assert.Equal(t, admin.RoleStaff, a.Role(), "admins are staff unless made managers")
 	})
 
 	t.Run("non-existing email returns ErrAdminNotFound", func(t *testing.T) {
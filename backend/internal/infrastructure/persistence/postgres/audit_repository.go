@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+)
+
+// AuditRepository implements audit.Repository using PostgreSQL.
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditRepository creates a new AuditRepository.
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{pool: pool}
+}
+
+const auditColumns = "id, actor_id, action, entity_type, entity_id, details, created_at"
+
+// Append writes an entry to the audit log.
+func (r *AuditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	details, err := json.Marshal(e.Details)
+	if err != nil {
+		return fmt.Errorf("encoding audit details: %w", err)
+	}
+	if e.Details == nil {
+		details = []byte("{}")
+	}
+
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO audit_log (`+auditColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		e.ID, e.ActorID, e.Action, e.EntityType, e.EntityID, details, e.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting audit entry: %w", err)
+	}
+	return nil
+}
+
+// FindByEntity returns the entries recorded against one record, oldest first.
+func (r *AuditRepository) FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*audit.Entry, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+auditColumns+" FROM audit_log WHERE entity_type = $1 AND entity_id = $2 ORDER BY created_at, id",
+		entityType, entityID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*audit.Entry
+	for rows.Next() {
+		var e audit.Entry
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &e.EntityType, &e.EntityID, &details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning audit entry: %w", err)
+		}
+		if err := json.Unmarshal(details, &e.Details); err != nil {
+			return nil, fmt.Errorf("decoding audit details: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// BranchRepository implements branch.Repository and branch.PriceRepository
+// using PostgreSQL.
+type BranchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBranchRepository creates a new BranchRepository.
+func NewBranchRepository(pool *pgxpool.Pool) *BranchRepository {
+	return &BranchRepository{pool: pool}
+}
+
+const (
+	branchColumns      = "id, name, address, phone, email, active, created_at, updated_at"
+	branchPriceColumns = "branch_id, product_id, price_per_kg_cents, updated_at"
+)
+
+// Save inserts or updates a branch. Only admins of every branch may add one.
+func (r *BranchRepository) Save(ctx context.Context, b *branch.Branch) error {
+	if err := branch.ScopeFrom(ctx).Check(b.ID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO branches (`+branchColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, address = EXCLUDED.address, phone = EXCLUDED.phone, email = EXCLUDED.email,
+		     active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		b.ID(), b.Name(), b.Address(), b.Phone(), b.Email(), b.Active(), b.CreatedAt(), b.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_branches_name" {
+			return branch.ErrDuplicateName
+		}
+		return fmt.Errorf("saving branch: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a branch in scope by ID.
+func (r *BranchRepository) FindByID(ctx context.Context, id uuid.UUID) (*branch.Branch, error) {
+	cond, args := branchScope(ctx, "id", []any{id})
+	b, err := scanBranch(r.pool.QueryRow(ctx, "SELECT "+branchColumns+" FROM branches WHERE id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, branch.ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("querying branch: %w", err)
+	}
+	return b, nil
+}
+
+// FindAll returns the branches in scope by name, closed ones only if asked
+// for.
+func (r *BranchRepository) FindAll(ctx context.Context, includeInactive bool) ([]*branch.Branch, error) {
+	cond, args := branchScope(ctx, "id", []any{includeInactive})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+branchColumns+" FROM branches WHERE ($1 OR active) AND "+cond+" ORDER BY LOWER(name), id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying branches: %w", err)
+	}
+	defer rows.Close()
+
+	var branches []*branch.Branch
+	for rows.Next() {
+		b, err := scanBranch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning branch: %w", err)
+		}
+		branches = append(branches, b)
+	}
+	return branches, rows.Err()
+}
+
+// SetPrice inserts or replaces a branch's price for a product.
+func (r *BranchRepository) SetPrice(ctx context.Context, p *branch.PriceOverride) error {
+	if err := branch.ScopeFrom(ctx).Check(p.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO branch_prices (`+branchPriceColumns+`)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (branch_id, product_id) DO UPDATE SET
+		     price_per_kg_cents = EXCLUDED.price_per_kg_cents, updated_at = EXCLUDED.updated_at`,
+		p.BranchID(), p.ProductID(), p.PricePerKgCents(), p.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving branch price: %w", err)
+	}
+	return nil
+}
+
+// DeletePrice removes a branch's price for a product.
+func (r *BranchRepository) DeletePrice(ctx context.Context, branchID, productID uuid.UUID) error {
+	if err := branch.ScopeFrom(ctx).Check(branchID); err != nil {
+		return err
+	}
+	result, err := r.pool.Exec(ctx, "DELETE FROM branch_prices WHERE branch_id = $1 AND product_id = $2", branchID, productID)
+	if err != nil {
+		return fmt.Errorf("deleting branch price: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return branch.ErrPriceNotFound
+	}
+	return nil
+}
+
+// FindPrices returns a branch's price overrides by product.
+func (r *BranchRepository) FindPrices(ctx context.Context, branchID uuid.UUID) ([]*branch.PriceOverride, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{branchID})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+branchPriceColumns+" FROM branch_prices WHERE branch_id = $1 AND "+cond+" ORDER BY product_id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying branch prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*branch.PriceOverride
+	for rows.Next() {
+		var bID, productID uuid.UUID
+		var cents int64
+		var updatedAt time.Time
+		if err := rows.Scan(&bID, &productID, &cents, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scanning branch price: %w", err)
+		}
+		prices = append(prices, branch.ReconstructPriceOverride(bID, productID, cents, updatedAt))
+	}
+	return prices, rows.Err()
+}
+
+// PricesFor returns the branch's price a kilogram for each of the products
+// it overrides.
+func (r *BranchRepository) PricesFor(ctx context.Context, branchID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	prices := make(map[uuid.UUID]int64)
+	if len(productIDs) == 0 {
+		return prices, nil
+	}
+	rows, err := r.pool.Query(ctx,
+		"SELECT product_id, price_per_kg_cents FROM branch_prices WHERE branch_id = $1 AND product_id = ANY($2)",
+		branchID, productIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying branch prices: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID uuid.UUID
+		var cents int64
+		if err := rows.Scan(&productID, &cents); err != nil {
+			return nil, fmt.Errorf("scanning branch price: %w", err)
+		}
+		prices[productID] = cents
+	}
+	return prices, rows.Err()
+}
+
+func scanBranch(row pgx.Row) (*branch.Branch, error) {
+	var id uuid.UUID
+	var name, phone, email string
+	var address []string
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &address, &phone, &email, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return branch.ReconstructBranch(id, name, address, phone, email, active, createdAt, updatedAt), nil
+}
@@ -0,0 +1,100 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationBranchRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewBranchRepository(pool)
+	admins := pgstore.NewAdminRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	east, err := branch.NewBranch(uuid.New(), "East", []string{"1 High Street", "London"}, "020 7946 0000", "east@butchery.com", now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, east))
+	west, err := branch.NewBranch(uuid.New(), "West", []string{"2 Park Lane"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, west))
+
+	clash, err := branch.NewBranch(uuid.New(), "east", []string{"3 Mill Road"}, "", "", now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Save(ctx, clash), branch.ErrDuplicateName)
+
+	require.NoError(t, west.Update("West", []string{"2 Park Lane"}, "", "", false, now))
+	require.NoError(t, repo.Save(ctx, west))
+
+	got, err := repo.FindByID(ctx, east.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1 High Street", "London"}, got.Address())
+	assert.Equal(t, "east@butchery.com", got.Email())
+
+	open, err := repo.FindAll(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, east.ID(), open[0].ID())
+
+	// Prices are replaced, not added to.
+	productID := uuid.New()
+	for _, cents := range []int64{1900, 2000} {
+		p, err := branch.NewPriceOverride(east.ID(), productID, cents, now)
+		require.NoError(t, err)
+		require.NoError(t, repo.SetPrice(ctx, p))
+	}
+	prices, err := repo.PricesFor(ctx, east.ID(), []uuid.UUID{productID, uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int64{productID: 2000}, prices)
+
+	// An admin scoped to East cannot see or price West.
+	eastOnly := branch.WithScope(ctx, branch.Only(east.ID()))
+	_, err = repo.FindByID(eastOnly, west.ID())
+	assert.ErrorIs(t, err, branch.ErrBranchNotFound)
+	all, err := repo.FindAll(eastOnly, true)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	p, err := branch.NewPriceOverride(west.ID(), productID, 1500, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.SetPrice(eastOnly, p), branch.ErrOutOfScope)
+
+	require.NoError(t, repo.DeletePrice(ctx, east.ID(), productID))
+	assert.ErrorIs(t, repo.DeletePrice(ctx, east.ID(), productID), branch.ErrPriceNotFound)
+
+	// Admins' branches are stored and listed within scope.
+	adminID := uuid.New()
+	_, err = pool.Exec(ctx,
+		"INSERT INTO admins (id, email, password_hash, full_name, role) VALUES ($1, $2, $3, $4, 'cashier')",
+		adminID, "cashier@butchery.com", "$2a$10$hashvalue", "Counter Staff",
+	)
+	require.NoError(t, err)
+	a, err := admins.FindByID(ctx, adminID)
+	require.NoError(t, err)
+	assert.False(t, a.AllBranches())
+	assert.Empty(t, a.BranchIDs())
+
+	a.AssignBranches(false, []uuid.UUID{west.ID()})
+	require.NoError(t, admins.SaveBranches(ctx, a))
+	a, err = admins.FindByID(ctx, adminID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{west.ID()}, a.BranchIDs())
+
+	listed, err := admins.FindAll(eastOnly)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	listed, err = admins.FindAll(branch.WithScope(ctx, branch.Only(west.ID())))
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+)
+
+// branchScope returns a condition limiting column to the branches in the
+// context's scope, with the scope's branches appended to args. Rows outside
+// the scope are treated as if they did not exist.
+func branchScope(ctx context.Context, column string, args []any) (string, []any) {
+	s := branch.ScopeFrom(ctx)
+	if s.All() {
+		return "TRUE", args
+	}
+	args = append(args, s.IDs())
+	return column + " = ANY($" + strconv.Itoa(len(args)) + "::uuid[])", args
+}
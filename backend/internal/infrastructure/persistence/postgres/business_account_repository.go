@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// BusinessAccountRepository implements business.AccountRepository using PostgreSQL.
+type BusinessAccountRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBusinessAccountRepository creates a new BusinessAccountRepository.
+func NewBusinessAccountRepository(pool *pgxpool.Pool) *BusinessAccountRepository {
+	return &BusinessAccountRepository{pool: pool}
+}
+
+const businessAccountColumns = "id, name, company_number, vat_number, billing_email, billing_address, " +
+	"credit_limit_cents, terms_days, approval_threshold_cents, prices, balance_cents, held_cents, active, " +
+	"version, created_at, updated_at"
+
+// updateBusinessAccountSQL stores a changed account if it has not changed
+// since it was loaded.
+const updateBusinessAccountSQL = `UPDATE business_accounts
+	 SET name = $3, company_number = $4, vat_number = $5, billing_email = $6, billing_address = $7,
+	     credit_limit_cents = $8, terms_days = $9, approval_threshold_cents = $10, prices = $11,
+	     balance_cents = $12, held_cents = $13, active = $14, updated_at = $15, version = version + 1
+	 WHERE id = $1 AND version = $2`
+
+// businessPriceRow is the JSON shape of a price in business_accounts.prices.
+type businessPriceRow struct {
+	ProductID   uuid.UUID         `json:"product_id"`
+	Description string            `json:"description"`
+	Tiers       []businessTierRow `json:"tiers"`
+}
+
+type businessTierRow struct {
+	MinGrams        int64 `json:"min_grams"`
+	PricePerKgCents int64 `json:"price_per_kg_cents"`
+}
+
+func encodeBusinessPrices(prices []business.Price) ([]byte, error) {
+	rows := make([]businessPriceRow, 0, len(prices))
+	for _, p := range prices {
+		row := businessPriceRow{ProductID: p.ProductID, Description: p.Description}
+		for _, t := range p.Tiers {
+			row.Tiers = append(row.Tiers, businessTierRow{MinGrams: t.MinGrams, PricePerKgCents: t.PricePerKgCents})
+		}
+		rows = append(rows, row)
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("encoding business prices: %w", err)
+	}
+	return data, nil
+}
+
+func updateBusinessAccountArgs(a *business.Account) ([]any, error) {
+	prices, err := encodeBusinessPrices(a.Prices())
+	if err != nil {
+		return nil, err
+	}
+	d, t := a.Details(), a.Terms()
+	return []any{
+		a.ID(), a.Version(), d.Name, d.CompanyNumber, d.VATNumber, d.BillingEmail, d.BillingAddress,
+		t.CreditLimitCents, t.TermsDays, t.ApprovalThresholdCents, prices, a.BalanceCents(), a.HeldCents(),
+		a.Active(), a.UpdatedAt(),
+	}, nil
+}
+
+// Create inserts a new account.
+func (r *BusinessAccountRepository) Create(ctx context.Context, a *business.Account) error {
+	prices, err := encodeBusinessPrices(a.Prices())
+	if err != nil {
+		return err
+	}
+	d, t := a.Details(), a.Terms()
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO business_accounts (`+businessAccountColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		a.ID(), d.Name, d.CompanyNumber, d.VATNumber, d.BillingEmail, d.BillingAddress,
+		t.CreditLimitCents, t.TermsDays, t.ApprovalThresholdCents, prices, a.BalanceCents(), a.HeldCents(),
+		a.Active(), a.Version(), a.CreatedAt(), a.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting business account: %w", err)
+	}
+	return nil
+}
+
+// Update stores a changed account, guarded by its version.
+func (r *BusinessAccountRepository) Update(ctx context.Context, a *business.Account) error {
+	args, err := updateBusinessAccountArgs(a)
+	if err != nil {
+		return err
+	}
+	result, err := r.pool.Exec(ctx, updateBusinessAccountSQL, args...)
+	if err != nil {
+		return fmt.Errorf("updating business account: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return business.ErrConcurrentUpdate
+	}
+	return nil
+}
+
+// FindByID finds an account by ID.
+func (r *BusinessAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*business.Account, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+businessAccountColumns+" FROM business_accounts WHERE id = $1", id)
+
+	a, err := scanBusinessAccount(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, business.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("querying business account by id: %w", err)
+	}
+	return a, nil
+}
+
+// FindAll returns accounts by name.
+func (r *BusinessAccountRepository) FindAll(ctx context.Context) ([]*business.Account, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+businessAccountColumns+" FROM business_accounts ORDER BY name, id")
+	if err != nil {
+		return nil, fmt.Errorf("querying business accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*business.Account
+	for rows.Next() {
+		a, err := scanBusinessAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning business account: %w", err)
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
+
+func scanBusinessAccount(row pgx.Row) (*business.Account, error) {
+	var id uuid.UUID
+	var d business.Details
+	var t business.Terms
+	var pricesJSON []byte
+	var balanceCents, heldCents int64
+	var active bool
+	var version int
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &d.Name, &d.CompanyNumber, &d.VATNumber, &d.BillingEmail, &d.BillingAddress,
+		&t.CreditLimitCents, &t.TermsDays, &t.ApprovalThresholdCents, &pricesJSON, &balanceCents, &heldCents,
+		&active, &version, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	var rows []businessPriceRow
+	if err := json.Unmarshal(pricesJSON, &rows); err != nil {
+		return nil, fmt.Errorf("decoding business prices: %w", err)
+	}
+	prices := make([]business.Price, 0, len(rows))
+	for _, row := range rows {
+		p := business.Price{ProductID: row.ProductID, Description: row.Description}
+		for _, t := range row.Tiers {
+			p.Tiers = append(p.Tiers, business.Tier{MinGrams: t.MinGrams, PricePerKgCents: t.PricePerKgCents})
+		}
+		prices = append(prices, p)
+	}
+	return business.ReconstructAccount(id, d, t, prices, balanceCents, heldCents, active, version, createdAt, updatedAt), nil
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// BusinessLedger implements business.Ledger using PostgreSQL.
+type BusinessLedger struct {
+	pool *pgxpool.Pool
+}
+
+// NewBusinessLedger creates a new BusinessLedger.
+func NewBusinessLedger(pool *pgxpool.Pool) *BusinessLedger {
+	return &BusinessLedger{pool: pool}
+}
+
+const businessOrderColumns = "id, account_id, placed_by, branch_id, order_id, lines, total_cents, status, " +
+	"decided_by, decided_at, reject_reason, created_at, updated_at"
+
+const businessEntryColumns = "id, account_id, type, amount_cents, order_id, reference, due_on, posted_at"
+
+// businessLineRow is the JSON shape of a line in business_orders.lines.
+type businessLineRow struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	Description     string    `json:"description"`
+	Grams           int64     `json:"grams"`
+	PricePerKgCents int64     `json:"price_per_kg_cents"`
+}
+
+// Record updates the account, upserts the order and inserts the entry in
+// one transaction.
+func (l *BusinessLedger) Record(ctx context.Context, a *business.Account, o *business.Order, e *business.Entry) error {
+	args, err := updateBusinessAccountArgs(a)
+	if err != nil {
+		return err
+	}
+
+	tx, err := l.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, updateBusinessAccountSQL, args...)
+	if err != nil {
+		return fmt.Errorf("updating business account: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return business.ErrConcurrentUpdate
+	}
+
+	if o != nil {
+		rows := make([]businessLineRow, 0, len(o.Lines()))
+		for _, ln := range o.Lines() {
+			rows = append(rows, businessLineRow{ProductID: ln.ProductID, Description: ln.Description, Grams: ln.Grams, PricePerKgCents: ln.PricePerKgCents})
+		}
+		lines, err := json.Marshal(rows)
+		if err != nil {
+			return fmt.Errorf("encoding business order lines: %w", err)
+		}
+		_, err = tx.Exec(ctx,
+			`INSERT INTO business_orders (`+businessOrderColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			 ON CONFLICT (id) DO UPDATE SET
+			     total_cents = EXCLUDED.total_cents, status = EXCLUDED.status, decided_by = EXCLUDED.decided_by,
+			     decided_at = EXCLUDED.decided_at, reject_reason = EXCLUDED.reject_reason,
+			     updated_at = EXCLUDED.updated_at`,
+			o.ID(), o.AccountID(), o.PlacedBy(), o.BranchID(), o.OrderID(), lines, o.TotalCents(),
+			string(o.Status()), o.DecidedBy(), o.DecidedAt(), o.RejectReason(), o.CreatedAt(), o.UpdatedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("saving business order: %w", err)
+		}
+	}
+
+	if e != nil {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO business_ledger_entries (`+businessEntryColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			e.ID, e.AccountID, string(e.Type), e.AmountCents, e.OrderID, e.Reference, e.DueOn, e.PostedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting business ledger entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing business ledger entry: %w", err)
+	}
+	return nil
+}
+
+// Entries returns an account's entries posted before the given time, oldest
+// first. Accounts are not held at a branch, so their entries are not scoped.
+func (l *BusinessLedger) Entries(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*business.Entry, error) {
+	rows, err := l.pool.Query(ctx,
+		"SELECT "+businessEntryColumns+` FROM business_ledger_entries
+		 WHERE account_id = $1 AND posted_at < $2 ORDER BY posted_at, id`,
+		accountID, before)
+	if err != nil {
+		return nil, fmt.Errorf("querying business ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*business.Entry
+	for rows.Next() {
+		var e business.Entry
+		var entryType string
+		if err := rows.Scan(&e.ID, &e.AccountID, &entryType, &e.AmountCents, &e.OrderID, &e.Reference,
+			&e.DueOn, &e.PostedAt); err != nil {
+			return nil, fmt.Errorf("scanning business ledger entry: %w", err)
+		}
+		e.Type = business.EntryType(entryType)
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// FindOrder finds an order by ID.
+func (l *BusinessLedger) FindOrder(ctx context.Context, id uuid.UUID) (*business.Order, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	row := l.pool.QueryRow(ctx, "SELECT "+businessOrderColumns+" FROM business_orders WHERE id = $1 AND "+cond, args...)
+
+	o, err := scanBusinessOrder(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, business.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("querying business order by id: %w", err)
+	}
+	return o, nil
+}
+
+// FindOrders returns orders in scope matching filter, newest first.
+func (l *BusinessLedger) FindOrders(ctx context.Context, filter business.OrderFilter) ([]*business.Order, error) {
+	cond, args := branchScope(ctx, "branch_id", nil)
+	query := "SELECT " + businessOrderColumns + " FROM business_orders WHERE " + cond
+	if filter.AccountID != nil {
+		args = append(args, *filter.AccountID)
+		query += " AND account_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY created_at DESC, id"
+
+	rows, err := l.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying business orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*business.Order
+	for rows.Next() {
+		o, err := scanBusinessOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning business order: %w", err)
+		}
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+func scanBusinessOrder(row pgx.Row) (*business.Order, error) {
+	var id, accountID, placedBy, branchID, orderID uuid.UUID
+	var linesJSON []byte
+	var totalCents int64
+	var status, rejectReason string
+	var decidedBy *uuid.UUID
+	var decidedAt *time.Time
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &accountID, &placedBy, &branchID, &orderID, &linesJSON, &totalCents, &status,
+		&decidedBy, &decidedAt, &rejectReason, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	var rows []businessLineRow
+	if err := json.Unmarshal(linesJSON, &rows); err != nil {
+		return nil, fmt.Errorf("decoding business order lines: %w", err)
+	}
+	lines := make([]business.Line, 0, len(rows))
+	for _, r := range rows {
+		lines = append(lines, business.Line{ProductID: r.ProductID, Description: r.Description, Grams: r.Grams, PricePerKgCents: r.PricePerKgCents})
+	}
+	return business.ReconstructOrder(id, accountID, placedBy, branchID, orderID, lines, totalCents,
+		business.OrderStatus(status), decidedBy, decidedAt, rejectReason, createdAt, updatedAt), nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+)
+
+// BusinessMemberRepository implements business.MemberRepository using PostgreSQL.
+type BusinessMemberRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBusinessMemberRepository creates a new BusinessMemberRepository.
+func NewBusinessMemberRepository(pool *pgxpool.Pool) *BusinessMemberRepository {
+	return &BusinessMemberRepository{pool: pool}
+}
+
+const businessMemberColumns = "account_id, customer_id, role, created_at"
+
+// Save adds a member or changes their role. The unique customer stops a
+// customer buying for two businesses.
+func (r *BusinessMemberRepository) Save(ctx context.Context, m business.Member) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO business_members (`+businessMemberColumns+`)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (account_id, customer_id) DO UPDATE SET role = EXCLUDED.role`,
+		m.AccountID, m.CustomerID, string(m.Role), m.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return business.ErrMemberOfAnotherAccount
+		}
+		return fmt.Errorf("saving business member: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes a member from an account.
+func (r *BusinessMemberRepository) Remove(ctx context.Context, accountID, customerID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx,
+		"DELETE FROM business_members WHERE account_id = $1 AND customer_id = $2", accountID, customerID)
+	if err != nil {
+		return fmt.Errorf("deleting business member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return business.ErrMemberNotFound
+	}
+	return nil
+}
+
+// FindByCustomer finds the business a customer buys for.
+func (r *BusinessMemberRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) (business.Member, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+businessMemberColumns+" FROM business_members WHERE customer_id = $1", customerID)
+
+	m, err := scanBusinessMember(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return business.Member{}, business.ErrMemberNotFound
+		}
+		return business.Member{}, fmt.Errorf("querying business member by customer: %w", err)
+	}
+	return m, nil
+}
+
+// FindByAccount returns an account's members, earliest added first.
+func (r *BusinessMemberRepository) FindByAccount(ctx context.Context, accountID uuid.UUID) ([]business.Member, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+businessMemberColumns+" FROM business_members WHERE account_id = $1 ORDER BY created_at, customer_id",
+		accountID)
+	if err != nil {
+		return nil, fmt.Errorf("querying business members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []business.Member
+	for rows.Next() {
+		m, err := scanBusinessMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning business member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func scanBusinessMember(row pgx.Row) (business.Member, error) {
+	var m business.Member
+	var role string
+	if err := row.Scan(&m.AccountID, &m.CustomerID, &role, &m.CreatedAt); err != nil {
+		return business.Member{}, err
+	}
+	m.Role = business.Role(role)
+	return m, nil
+}
@@ -0,0 +1,139 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationBusinessRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	accounts := pgstore.NewBusinessAccountRepository(pool)
+	members := pgstore.NewBusinessMemberRepository(pool)
+	ledger := pgstore.NewBusinessLedger(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customers := pgstore.NewCustomerRepository(pool)
+	buyerID := seedCustomer(t, customers, "buyer@grill.example")
+	approverID := seedCustomer(t, customers, "approver@grill.example")
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	threshold := int64(10000)
+	a, err := business.NewAccount(uuid.New(), business.Details{
+		Name: "The Grill House", VATNumber: "GB123456789", BillingEmail: "accounts@grill.example",
+	}, business.Terms{CreditLimitCents: 100000, TermsDays: 30, ApprovalThresholdCents: &threshold}, now)
+	require.NoError(t, err)
+	mince := uuid.New()
+	require.NoError(t, a.SetPrices([]business.Price{{ProductID: mince, Description: "Beef mince", Tiers: []business.Tier{
+		{MinGrams: 0, PricePerKgCents: 900}, {MinGrams: 10000, PricePerKgCents: 800},
+	}}}, now))
+	require.NoError(t, accounts.Create(ctx, a))
+
+	t.Run("accounts round-trip their terms and prices", func(t *testing.T) {
+		found, err := accounts.FindByID(ctx, a.ID())
+		require.NoError(t, err)
+		assert.Equal(t, a.Details(), found.Details())
+		assert.Equal(t, a.Terms(), found.Terms())
+		assert.Equal(t, a.Prices(), found.Prices())
+
+		_, err = accounts.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, business.ErrAccountNotFound)
+	})
+
+	t.Run("a customer buys for one business", func(t *testing.T) {
+		require.NoError(t, members.Save(ctx, business.Member{AccountID: a.ID(), CustomerID: buyerID, Role: business.RoleBuyer, CreatedAt: now}))
+		require.NoError(t, members.Save(ctx, business.Member{AccountID: a.ID(), CustomerID: approverID, Role: business.RoleBuyer, CreatedAt: now}))
+		require.NoError(t, members.Save(ctx, business.Member{AccountID: a.ID(), CustomerID: approverID, Role: business.RoleApprover, CreatedAt: now}))
+
+		m, err := members.FindByCustomer(ctx, approverID)
+		require.NoError(t, err)
+		assert.Equal(t, business.RoleApprover, m.Role)
+		all, err := members.FindByAccount(ctx, a.ID())
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+
+		other, err := business.NewAccount(uuid.New(), business.Details{Name: "Caterers", BillingEmail: "c@c.example"},
+			business.Terms{TermsDays: 30}, now)
+		require.NoError(t, err)
+		require.NoError(t, accounts.Create(ctx, other))
+		err = members.Save(ctx, business.Member{AccountID: other.ID(), CustomerID: buyerID, Role: business.RoleBuyer, CreatedAt: now})
+		assert.ErrorIs(t, err, business.ErrMemberOfAnotherAccount)
+	})
+
+	t.Run("orders and entries move the balance together", func(t *testing.T) {
+		a, err := accounts.FindByID(ctx, a.ID())
+		require.NoError(t, err)
+		buyer, err := members.FindByCustomer(ctx, buyerID)
+		require.NoError(t, err)
+
+		o, err := business.NewOrder(uuid.New(), uuid.New(), a, buyer, uuid.New(),
+			[]business.Line{{ProductID: mince, Description: "Beef mince", Grams: 20000, PricePerKgCents: 800}}, 16000, now)
+		require.NoError(t, err)
+		require.Equal(t, business.OrderPendingApproval, o.Status())
+		require.NoError(t, a.Hold(16000, now))
+		require.NoError(t, ledger.Record(ctx, a, o, nil))
+
+		// The same stale account cannot be recorded twice.
+		assert.ErrorIs(t, ledger.Record(ctx, a, nil, nil), business.ErrConcurrentUpdate)
+
+		a, err = accounts.FindByID(ctx, a.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(16000), a.HeldCents())
+		approver, err := members.FindByCustomer(ctx, approverID)
+		require.NoError(t, err)
+		require.NoError(t, o.Approve(approver, 16000, now))
+		a.Release(16000, now)
+		require.NoError(t, a.Charge(16000, now))
+		require.NoError(t, ledger.Record(ctx, a, o, business.NewCharge(uuid.New(), a, o, now, now)))
+
+		found, err := ledger.FindOrder(ctx, o.ID())
+		require.NoError(t, err)
+		assert.Equal(t, business.OrderPlaced, found.Status())
+		assert.Equal(t, approverID, *found.DecidedBy())
+		assert.Equal(t, o.Lines(), found.Lines())
+
+		status := business.OrderPlaced
+		accountID := a.ID()
+		orders, err := ledger.FindOrders(ctx, business.OrderFilter{AccountID: &accountID, Status: &status})
+		require.NoError(t, err)
+		assert.Len(t, orders, 1)
+
+		elsewhere := branch.WithScope(ctx, branch.Only(uuid.New()))
+		_, err = ledger.FindOrder(elsewhere, o.ID())
+		assert.ErrorIs(t, err, business.ErrOrderNotFound)
+		orders, err = ledger.FindOrders(elsewhere, business.OrderFilter{AccountID: &accountID})
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+		orders, err = ledger.FindOrders(branch.WithScope(ctx, branch.Only(o.BranchID())), business.OrderFilter{AccountID: &accountID})
+		require.NoError(t, err)
+		assert.Len(t, orders, 1)
+
+		a, err = accounts.FindByID(ctx, a.ID())
+		require.NoError(t, err)
+		require.NoError(t, a.Pay(6000, now))
+		require.NoError(t, ledger.Record(ctx, a, nil, business.NewPayment(uuid.New(), a, 6000, "BACS", now.Add(time.Second))))
+
+		entries, err := ledger.Entries(ctx, a.ID(), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, business.EntryCharge, entries[0].Type)
+		assert.Equal(t, a.DueOn(now), *entries[0].DueOn)
+		assert.Equal(t, int64(-6000), entries[1].AmountCents)
+
+		a, err = accounts.FindByID(ctx, a.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), a.BalanceCents())
+		assert.Equal(t, int64(0), a.HeldCents())
+	})
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// CarcassRepository implements carcass.CarcassRepository using PostgreSQL.
+type CarcassRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCarcassRepository creates a new CarcassRepository.
+func NewCarcassRepository(pool *pgxpool.Pool) *CarcassRepository {
+	return &CarcassRepository{pool: pool}
+}
+
+const carcassColumns = "id, branch_id, tag, species, hanging_weight_grams, cost_cents, supplier, slaughtered_on, halal_certificate_ref, status, received_at, updated_at"
+
+// Save inserts a new carcass intake.
+func (r *CarcassRepository) Save(ctx context.Context, c *carcass.Carcass) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO carcasses (`+carcassColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		c.ID(), c.BranchID(), c.Tag(), string(c.Species()), c.HangingWeightGrams(), c.CostCents(), c.Supplier(),
+		c.SlaughteredOn(), c.HalalCertificateRef(), string(c.Status()), c.ReceivedAt(), c.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return carcass.ErrDuplicateTag
+		}
+		return fmt.Errorf("inserting carcass: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a carcass by ID.
+func (r *CarcassRepository) FindByID(ctx context.Context, id uuid.UUID) (*carcass.Carcass, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	row := r.pool.QueryRow(ctx, "SELECT "+carcassColumns+" FROM carcasses WHERE id = $1 AND "+cond, args...)
+
+	c, err := scanCarcass(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, carcass.ErrCarcassNotFound
+		}
+		return nil, fmt.Errorf("querying carcass by id: %w", err)
+	}
+	return c, nil
+}
+
+// FindAll returns carcasses in scope, most recently received first.
+func (r *CarcassRepository) FindAll(ctx context.Context, filter carcass.Filter) ([]*carcass.Carcass, error) {
+	cond, args := branchScope(ctx, "branch_id", nil)
+	query := "SELECT " + carcassColumns + " FROM carcasses WHERE " + cond
+	if filter.Species != nil {
+		args = append(args, string(*filter.Species))
+		query += " AND species = $" + strconv.Itoa(len(args))
+	}
+	if filter.BranchID != nil {
+		args = append(args, *filter.BranchID)
+		query += " AND branch_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY received_at DESC, id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying carcasses: %w", err)
+	}
+	defer rows.Close()
+
+	var carcasses []*carcass.Carcass
+	for rows.Next() {
+		c, err := scanCarcass(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning carcass: %w", err)
+		}
+		carcasses = append(carcasses, c)
+	}
+	return carcasses, rows.Err()
+}
+
+// SaveBreakdown marks the carcass broken down and stores the breakdown, its
+// cuts, the inventory lots they were received into and the lots' origins, in
+// one transaction.
+// The status change only applies to a carcass that has not been broken down
+// yet, so two concurrent breakdowns cannot both succeed.
+func (r *CarcassRepository) SaveBreakdown(ctx context.Context, c *carcass.Carcass, b *carcass.Breakdown, lots []*inventory.Lot, received []*inventory.Movement, origins []*traceability.Origin) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		"UPDATE carcasses SET status = $2, updated_at = $3 WHERE id = $1 AND status = $4",
+		c.ID(), string(c.Status()), c.UpdatedAt(), string(carcass.StatusReceived),
+	)
+	if err != nil {
+		return fmt.Errorf("updating carcass: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return carcass.ErrAlreadyBrokenDown
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO carcass_breakdowns (carcass_id, trim_grams, waste_grams, recorded_by, recorded_at)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		b.CarcassID(), b.TrimGrams(), b.WasteGrams(), b.RecordedBy(), b.RecordedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting breakdown: %w", err)
+	}
+
+	for i, lot := range lots {
+		if err := insertInventoryLot(ctx, tx, lot); err != nil {
+			return err
+		}
+		if err := insertStockMovement(ctx, tx, received[i]); err != nil {
+			return err
+		}
+	}
+
+	for i, cut := range b.Cuts() {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO carcass_cuts (lot_id, carcass_id, position, primal, product_id, grams, expires_on)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			cut.LotID, b.CarcassID(), i, cut.Primal, cut.ProductID, cut.Grams, cut.ExpiresOn,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting cut: %w", err)
+		}
+	}
+
+	for _, o := range origins {
+		if err := insertLotOrigin(ctx, tx, o); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing breakdown: %w", err)
+	}
+	return nil
+}
+
+// FindBreakdown finds the breakdown of a carcass. It returns
+// carcass.ErrNotBrokenDown if the carcass has no breakdown.
+func (r *CarcassRepository) FindBreakdown(ctx context.Context, carcassID uuid.UUID) (*carcass.Breakdown, error) {
+	var trimGrams, wasteGrams int64
+	var recordedBy *uuid.UUID
+	var recordedAt time.Time
+
+	cond, args := branchScope(ctx, "c.branch_id", []any{carcassID})
+	err := r.pool.QueryRow(ctx,
+		`SELECT b.trim_grams, b.waste_grams, b.recorded_by, b.recorded_at
+		 FROM carcass_breakdowns b JOIN carcasses c ON c.id = b.carcass_id
+		 WHERE b.carcass_id = $1 AND `+cond,
+		args...,
+	).Scan(&trimGrams, &wasteGrams, &recordedBy, &recordedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, carcass.ErrNotBrokenDown
+		}
+		return nil, fmt.Errorf("querying breakdown: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx,
+		"SELECT lot_id, primal, product_id, grams, expires_on FROM carcass_cuts WHERE carcass_id = $1 ORDER BY position",
+		carcassID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying cuts: %w", err)
+	}
+	defer rows.Close()
+
+	var cuts []carcass.Cut
+	for rows.Next() {
+		var cut carcass.Cut
+		if err := rows.Scan(&cut.LotID, &cut.Primal, &cut.ProductID, &cut.Grams, &cut.ExpiresOn); err != nil {
+			return nil, fmt.Errorf("scanning cut: %w", err)
+		}
+		cuts = append(cuts, cut)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return carcass.ReconstructBreakdown(carcassID, cuts, trimGrams, wasteGrams, recordedBy, recordedAt), nil
+}
+
+func scanCarcass(row pgx.Row) (*carcass.Carcass, error) {
+	var id, branchID uuid.UUID
+	var tag, species, supplier, halalCertificateRef, status string
+	var hangingWeightGrams, costCents int64
+	var slaughteredOn, receivedAt, updatedAt time.Time
+
+	err := row.Scan(&id, &branchID, &tag, &species, &hangingWeightGrams, &costCents, &supplier,
+		&slaughteredOn, &halalCertificateRef, &status, &receivedAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return carcass.ReconstructCarcass(id, branchID, tag, carcass.Species(species), hangingWeightGrams, costCents,
+		supplier, slaughteredOn, halalCertificateRef, carcass.Status(status), receivedAt, updatedAt), nil
+}
@@ -0,0 +1,202 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCarcass(t *testing.T, tag string) *carcass.Carcass {
+	t.Helper()
+	c, err := carcass.NewCarcass(uuid.New(), uuid.New(), tag, carcass.SpeciesLamb, 20000, 16000,
+		"Green Valley Farm", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), "HMC-2026-0193", time.Now())
+	require.NoError(t, err)
+	return c
+}
+
+// breakDown cuts c into a leg and a shoulder and receives both into inventory.
+func breakDown(t *testing.T, c *carcass.Carcass) (*carcass.Breakdown, []*inventory.Lot, []*inventory.Movement, []*traceability.Origin) {
+	t.Helper()
+	b, err := c.BreakDown([]carcass.Cut{
+		{Primal: "leg", ProductID: uuid.New(), Grams: 6000},
+		{Primal: "shoulder", ProductID: uuid.New(), Grams: 5000},
+	}, 2500, 1000, nil, time.Now())
+	require.NoError(t, err)
+
+	var lots []*inventory.Lot
+	var received []*inventory.Movement
+	var origins []*traceability.Origin
+	carcassID := c.ID()
+	for _, cut := range b.Cuts() {
+		lot, m, err := inventory.ReceiveLotFrom(cut.LotID, cut.ProductID, c.BranchID(), c.Tag(), cut.Grams,
+			nil, "carcass breakdown", c.ID(), nil, time.Now())
+		require.NoError(t, err)
+		o, err := traceability.NewOrigin(cut.LotID, &carcassID, c.Supplier(), c.SlaughteredOn(), c.HalalCertificateRef(), time.Now())
+		require.NoError(t, err)
+		lots = append(lots, lot)
+		received = append(received, m)
+		origins = append(origins, o)
+	}
+	return b, lots, received, origins
+}
+
+func TestIntegrationCarcassRepository_Intake(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewCarcassRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	c := newTestCarcass(t, "KILL-1")
+	require.NoError(t, repo.Save(ctx, c))
+
+	t.Run("saves and retrieves carcass", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, c.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "KILL-1", found.Tag())
+		assert.Equal(t, carcass.SpeciesLamb, found.Species())
+		assert.Equal(t, "2026-10-12", found.SlaughteredOn().Format(time.DateOnly))
+		assert.Equal(t, carcass.StatusReceived, found.Status())
+	})
+
+	t.Run("duplicate tag returns ErrDuplicateTag", func(t *testing.T) {
+		err := repo.Save(ctx, newTestCarcass(t, "KILL-1"))
+		assert.ErrorIs(t, err, carcass.ErrDuplicateTag)
+	})
+
+	t.Run("filters by species", func(t *testing.T) {
+		beef := carcass.SpeciesBeef
+		found, err := repo.FindAll(ctx, carcass.Filter{Species: &beef})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		lamb := carcass.SpeciesLamb
+		found, err = repo.FindAll(ctx, carcass.Filter{Species: &lamb})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	t.Run("unknown carcass returns ErrCarcassNotFound", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, carcass.ErrCarcassNotFound)
+	})
+
+	t.Run("carcasses at other branches are out of scope", func(t *testing.T) {
+		elsewhere := branch.WithScope(ctx, branch.Only(uuid.New()))
+
+		_, err := repo.FindByID(elsewhere, c.ID())
+		assert.ErrorIs(t, err, carcass.ErrCarcassNotFound)
+		found, err := repo.FindAll(elsewhere, carcass.Filter{})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		found, err = repo.FindAll(branch.WithScope(ctx, branch.Only(c.BranchID())), carcass.Filter{})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+}
+
+func TestIntegrationCarcassRepository_Breakdown(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewCarcassRepository(pool)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	c := newTestCarcass(t, "KILL-2")
+	require.NoError(t, repo.Save(ctx, c))
+
+	_, err := repo.FindBreakdown(ctx, c.ID())
+	assert.ErrorIs(t, err, carcass.ErrNotBrokenDown)
+
+	b, lots, received, origins := breakDown(t, c)
+	require.NoError(t, repo.SaveBreakdown(ctx, c, b, lots, received, origins))
+
+	t.Run("breakdown is stored with cuts in order", func(t *testing.T) {
+		found, err := repo.FindBreakdown(ctx, c.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2500), found.TrimGrams())
+		require.Len(t, found.Cuts(), 2)
+		assert.Equal(t, "leg", found.Cuts()[0].Primal)
+		assert.Equal(t, b.Cuts()[1].LotID, found.Cuts()[1].LotID)
+
+		reloaded, err := repo.FindByID(ctx, c.ID())
+		require.NoError(t, err)
+		assert.Equal(t, carcass.StatusBrokenDown, reloaded.Status())
+	})
+
+	t.Run("breakdowns at other branches are out of scope", func(t *testing.T) {
+		_, err := repo.FindBreakdown(branch.WithScope(ctx, branch.Only(uuid.New())), c.ID())
+		assert.ErrorIs(t, err, carcass.ErrNotBrokenDown)
+	})
+
+	t.Run("cuts are received into inventory", func(t *testing.T) {
+		lot, err := stockRepo.FindLotByID(ctx, b.Cuts()[0].LotID)
+		require.NoError(t, err)
+		assert.Equal(t, "KILL-2", lot.Code())
+		assert.Equal(t, int64(6000), lot.OnHandGrams())
+	})
+
+	t.Run("a second breakdown is rejected", func(t *testing.T) {
+		stale := newTestCarcass(t, "KILL-2")
+		stale = carcass.ReconstructCarcass(c.ID(), c.BranchID(), c.Tag(), c.Species(), c.HangingWeightGrams(),
+			c.CostCents(), c.Supplier(), c.SlaughteredOn(), c.HalalCertificateRef(), carcass.StatusReceived,
+			c.ReceivedAt(), c.UpdatedAt())
+		b2, lots2, received2, origins2 := breakDown(t, stale)
+
+		err := repo.SaveBreakdown(ctx, stale, b2, lots2, received2, origins2)
+
+		assert.ErrorIs(t, err, carcass.ErrAlreadyBrokenDown)
+		_, err = stockRepo.FindLotByID(ctx, b2.Cuts()[0].LotID)
+		assert.ErrorIs(t, err, inventory.ErrLotNotFound)
+	})
+}
+
+func TestIntegrationYieldTemplateRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewYieldTemplateRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	_, err := repo.FindYieldTemplate(ctx, carcass.SpeciesBeef)
+	assert.ErrorIs(t, err, carcass.ErrYieldTemplateNotFound)
+
+	tmpl, err := carcass.NewYieldTemplate(carcass.SpeciesBeef, 6500, 2000, 800,
+		[]carcass.PrimalYield{{Name: "rump", ExpectedBP: 1200, ValueFactor: 180}}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveYieldTemplate(ctx, tmpl))
+
+	replaced, err := carcass.NewYieldTemplate(carcass.SpeciesBeef, 6400, 2000, 800,
+		[]carcass.PrimalYield{{Name: "rump", ExpectedBP: 1100, ValueFactor: 180}}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveYieldTemplate(ctx, replaced))
+
+	found, err := repo.FindYieldTemplate(ctx, carcass.SpeciesBeef)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6400), found.ExpectedYieldBP())
+	assert.Equal(t, replaced.Primals(), found.Primals())
+
+	all, err := repo.FindYieldTemplates(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+)
+
+// CustomerAddressRepository implements customer.AddressRepository using PostgreSQL.
+type CustomerAddressRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCustomerAddressRepository creates a new CustomerAddressRepository.
+func NewCustomerAddressRepository(pool *pgxpool.Pool) *CustomerAddressRepository {
+	return &CustomerAddressRepository{pool: pool}
+}
+
+const customerAddressColumns = "id, customer_id, label, line1, line2, city, postcode, latitude, longitude, is_default, created_at, updated_at"
+
+// Save inserts or updates an address.
+func (r *CustomerAddressRepository) Save(ctx context.Context, a *customer.Address) error {
+	var latitude, longitude *float64
+	if c := a.Coordinates(); c != nil {
+		lat, lng := c.Latitude(), c.Longitude()
+		latitude, longitude = &lat, &lng
+	}
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO customer_addresses (`+customerAddressColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 ON CONFLICT (id) DO UPDATE SET
+		     label = EXCLUDED.label, line1 = EXCLUDED.line1, line2 = EXCLUDED.line2,
+		     city = EXCLUDED.city, postcode = EXCLUDED.postcode,
+		     latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude,
+		     updated_at = EXCLUDED.updated_at`,
+		a.ID(), a.CustomerID(), a.Label(), a.Line1(), a.Line2(), a.City(), a.Postcode().String(),
+		latitude, longitude, a.IsDefault(), a.CreatedAt(), a.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving address: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds an address by ID.
+func (r *CustomerAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Address, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+customerAddressColumns+" FROM customer_addresses WHERE id = $1", id)
+
+	a, err := scanCustomerAddress(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, customer.ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("querying address by id: %w", err)
+	}
+	return a, nil
+}
+
+// FindByCustomer returns a customer's addresses, default first and then oldest first.
+func (r *CustomerAddressRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*customer.Address, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+customerAddressColumns+` FROM customer_addresses
+		 WHERE customer_id = $1 ORDER BY is_default DESC, created_at, id`,
+		customerID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying addresses: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []*customer.Address
+	for rows.Next() {
+		a, err := scanCustomerAddress(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning address: %w", err)
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, rows.Err()
+}
+
+// Delete removes an address.
+func (r *CustomerAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM customer_addresses WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting address: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return customer.ErrAddressNotFound
+	}
+	return nil
+}
+
+// SetDefault clears the customer's current default and marks addressID as the
+// default in one transaction.
+func (r *CustomerAddressRepository) SetDefault(ctx context.Context, customerID, addressID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		"UPDATE customer_addresses SET is_default = FALSE WHERE customer_id = $1 AND is_default",
+		customerID,
+	)
+	if err != nil {
+		return fmt.Errorf("clearing default address: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"UPDATE customer_addresses SET is_default = TRUE, updated_at = NOW() WHERE id = $1 AND customer_id = $2",
+		addressID, customerID,
+	)
+	if err != nil {
+		return fmt.Errorf("setting default address: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return customer.ErrAddressNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing default address: %w", err)
+	}
+	return nil
+}
+
+func scanCustomerAddress(row pgx.Row) (*customer.Address, error) {
+	var id, customerID uuid.UUID
+	var label, line1, line2, city, postcodeStr string
+	var latitude, longitude *float64
+	var isDefault bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &customerID, &label, &line1, &line2, &city, &postcodeStr,
+		&latitude, &longitude, &isDefault, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	postcode, err := customer.NewPostcode(postcodeStr)
+	if err != nil {
+		return nil, fmt.Errorf("reconstructing postcode: %w", err)
+	}
+	var coordinates *customer.Coordinates
+	if latitude != nil && longitude != nil {
+		c, err := customer.NewCoordinates(*latitude, *longitude)
+		if err != nil {
+			return nil, fmt.Errorf("reconstructing coordinates: %w", err)
+		}
+		coordinates = &c
+	}
+
+	return customer.ReconstructAddress(id, customerID, label, line1, line2, city, postcode,
+		coordinates, isDefault, createdAt, updatedAt), nil
+}
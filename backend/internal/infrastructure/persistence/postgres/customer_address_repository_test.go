@@ -0,0 +1,98 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAddress(t *testing.T, customerID uuid.UUID, label string, coords *customer.Coordinates) *customer.Address {
+	t.Helper()
+	postcode, err := customer.NewPostcode("E1 6AN")
+	require.NoError(t, err)
+	a, err := customer.NewAddress(uuid.New(), customerID, label, "1 High Street", "", "London", postcode, coords)
+	require.NoError(t, err)
+	return a
+}
+
+func TestIntegrationCustomerAddressRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewCustomerAddressRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "addresses@example.com")
+	coords, err := customer.NewCoordinates(51.52, -0.07)
+	require.NoError(t, err)
+
+	home := newTestAddress(t, customerID, "Home", &coords)
+	home.MarkDefault()
+	work := newTestAddress(t, customerID, "Work", nil)
+	require.NoError(t, repo.Save(ctx, home))
+	require.NoError(t, repo.Save(ctx, work))
+
+	t.Run("finds address by id", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, home.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Home", found.Label())
+		assert.Equal(t, "E1 6AN", found.Postcode().String())
+		require.NotNil(t, found.Coordinates())
+		assert.InDelta(t, 51.52, found.Coordinates().Latitude(), 1e-9)
+		assert.True(t, found.IsDefault())
+	})
+
+	t.Run("address without coordinates", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, work.ID())
+
+		require.NoError(t, err)
+		assert.Nil(t, found.Coordinates())
+	})
+
+	t.Run("set default moves the flag", func(t *testing.T) {
+		require.NoError(t, repo.SetDefault(ctx, customerID, work.ID()))
+
+		addresses, err := repo.FindByCustomer(ctx, customerID)
+		require.NoError(t, err)
+		require.Len(t, addresses, 2)
+		assert.Equal(t, work.ID(), addresses[0].ID())
+		assert.True(t, addresses[0].IsDefault())
+		assert.False(t, addresses[1].IsDefault())
+	})
+
+	t.Run("set default on another customer's address returns ErrAddressNotFound", func(t *testing.T) {
+		err := repo.SetDefault(ctx, uuid.New(), home.ID())
+
+		assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+	})
+
+	t.Run("save updates details but not the default flag", func(t *testing.T) {
+		postcode, err := customer.NewPostcode("N1 9GU")
+		require.NoError(t, err)
+		require.NoError(t, home.Update("Home", "9 New Road", "", "London", postcode, nil))
+		require.NoError(t, repo.Save(ctx, home))
+
+		found, err := repo.FindByID(ctx, home.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "9 New Road", found.Line1())
+		assert.Nil(t, found.Coordinates())
+		assert.False(t, found.IsDefault())
+	})
+
+	t.Run("delete removes the address", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, home.ID()))
+
+		_, err := repo.FindByID(ctx, home.ID())
+		assert.ErrorIs(t, err, customer.ErrAddressNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, home.ID()), customer.ErrAddressNotFound)
+	})
+}
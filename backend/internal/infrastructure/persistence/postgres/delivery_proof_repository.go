@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// DeliveryProofRepository implements dispatch.ProofRepository using
+// PostgreSQL.
+type DeliveryProofRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDeliveryProofRepository creates a new DeliveryProofRepository.
+func NewDeliveryProofRepository(pool *pgxpool.Pool) *DeliveryProofRepository {
+	return &DeliveryProofRepository{pool: pool}
+}
+
+// Save stores a proof, replacing the stop's proof of the same kind.
+func (r *DeliveryProofRepository) Save(ctx context.Context, p *dispatch.Proof) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO delivery_proofs (stop_id, kind, content_type, data, created_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (stop_id, kind) DO UPDATE SET
+		     content_type = EXCLUDED.content_type, data = EXCLUDED.data, created_at = EXCLUDED.created_at`,
+		p.StopID(), string(p.Kind()), p.ContentType(), p.Data(), p.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving delivery proof: %w", err)
+	}
+	return nil
+}
+
+// Find returns a stop's proof of the given kind if its run is at a branch
+// in scope.
+func (r *DeliveryProofRepository) Find(ctx context.Context, stopID uuid.UUID, kind dispatch.ProofKind) (*dispatch.Proof, error) {
+	cond, args := branchScope(ctx, "dr.branch_id", []any{stopID, string(kind)})
+	var contentType string
+	var data []byte
+	var createdAt time.Time
+	err := r.pool.QueryRow(ctx,
+		`SELECT p.content_type, p.data, p.created_at FROM delivery_proofs p
+		 JOIN delivery_stops ds ON ds.id = p.stop_id
+		 JOIN delivery_runs dr ON dr.id = ds.run_id
+		 WHERE p.stop_id = $1 AND p.kind = $2 AND `+cond,
+		args...,
+	).Scan(&contentType, &data, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dispatch.ErrProofNotFound
+		}
+		return nil, fmt.Errorf("querying delivery proof: %w", err)
+	}
+	return dispatch.ReconstructProof(stopID, kind, contentType, data, createdAt), nil
+}
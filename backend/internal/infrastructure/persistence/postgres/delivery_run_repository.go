@@ -0,0 +1,301 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// DeliveryRunRepository implements dispatch.RunRepository using PostgreSQL.
+type DeliveryRunRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDeliveryRunRepository creates a new DeliveryRunRepository.
+func NewDeliveryRunRepository(pool *pgxpool.Pool) *DeliveryRunRepository {
+	return &DeliveryRunRepository{pool: pool}
+}
+
+const deliveryRunColumns = "id, branch_id, zone_id, slot_starts_at, slot_ends_at, start_latitude, start_longitude, driver_id, " +
+	"status, distance_metres, created_by, version, created_at, updated_at, started_at, completed_at"
+
+const deliveryStopColumns = "id, run_id, order_id, customer_id, address_id, address_lines, postcode, latitude, longitude, " +
+	"sequence, status, recipient_name, failure_reason, proofs, completed_at"
+
+// Create inserts a run at a branch in scope with its stops.
+func (r *DeliveryRunRepository) Create(ctx context.Context, run *dispatch.Run) error {
+	if err := branch.ScopeFrom(ctx).Check(run.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var startLat, startLon *float64
+	if p := run.Start(); p != nil {
+		startLat, startLon = &p.Latitude, &p.Longitude
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO delivery_runs (`+deliveryRunColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		run.ID(), run.BranchID(), run.ZoneID(), run.SlotStartsAt(), run.SlotEndsAt(), startLat, startLon, run.DriverID(),
+		string(run.Status()), run.DistanceMetres(), run.CreatedBy(), run.Version(), run.CreatedAt(), run.UpdatedAt(),
+		run.StartedAt(), run.CompletedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting delivery run: %w", err)
+	}
+	if err := writeDeliveryStops(ctx, tx, run); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing delivery run: %w", err)
+	}
+	return nil
+}
+
+// Update stores a changed run at a branch in scope and replaces its stops.
+func (r *DeliveryRunRepository) Update(ctx context.Context, run *dispatch.Run) error {
+	if err := branch.ScopeFrom(ctx).Check(run.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		`UPDATE delivery_runs SET driver_id = $3, status = $4, distance_metres = $5, updated_at = $6, started_at = $7,
+		     completed_at = $8, version = version + 1
+		 WHERE id = $1 AND version = $2`,
+		run.ID(), run.Version(), run.DriverID(), string(run.Status()), run.DistanceMetres(), run.UpdatedAt(),
+		run.StartedAt(), run.CompletedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating delivery run: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return dispatch.ErrConcurrentUpdate
+	}
+
+	stopIDs := make([]uuid.UUID, 0, len(run.Stops()))
+	for _, s := range run.Stops() {
+		stopIDs = append(stopIDs, s.ID())
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM delivery_stops WHERE run_id = $1 AND NOT id = ANY($2::uuid[])", run.ID(), stopIDs); err != nil {
+		return fmt.Errorf("deleting removed stops: %w", err)
+	}
+	if err := writeDeliveryStops(ctx, tx, run); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing delivery run: %w", err)
+	}
+	return nil
+}
+
+// writeDeliveryStops upserts a run's stops. Sequences are only unique once
+// every stop is written, so they are not constrained to be.
+func writeDeliveryStops(ctx context.Context, tx pgx.Tx, run *dispatch.Run) error {
+	for _, s := range run.Stops() {
+		var lat, lon *float64
+		if p := s.Point(); p != nil {
+			lat, lon = &p.Latitude, &p.Longitude
+		}
+		proofs := make([]string, 0, len(s.Proofs()))
+		for _, k := range s.Proofs() {
+			proofs = append(proofs, string(k))
+		}
+		addressLines := s.AddressLines()
+		if addressLines == nil {
+			addressLines = []string{}
+		}
+		_, err := tx.Exec(ctx,
+			`INSERT INTO delivery_stops (`+deliveryStopColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			 ON CONFLICT (id) DO UPDATE SET
+			     sequence = EXCLUDED.sequence, status = EXCLUDED.status, recipient_name = EXCLUDED.recipient_name,
+			     failure_reason = EXCLUDED.failure_reason, proofs = EXCLUDED.proofs, completed_at = EXCLUDED.completed_at`,
+			s.ID(), run.ID(), s.OrderID(), s.CustomerID(), s.AddressID(), addressLines, s.Postcode(), lat, lon,
+			s.Sequence(), string(s.Status()), s.RecipientName(), s.FailureReason(), proofs, s.CompletedAt(),
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "delivery_stops_order_id_key" {
+				return dispatch.ErrOrderAlreadyRouted
+			}
+			return fmt.Errorf("writing delivery stop: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindByID finds a run at a branch in scope.
+func (r *DeliveryRunRepository) FindByID(ctx context.Context, id uuid.UUID) (*dispatch.Run, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	runs, err := r.query(ctx, "SELECT "+deliveryRunColumns+" FROM delivery_runs WHERE id = $1 AND "+cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, dispatch.ErrRunNotFound
+	}
+	return runs[0], nil
+}
+
+// FindByOrderID returns the run an order is on, at any branch.
+func (r *DeliveryRunRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) (*dispatch.Run, error) {
+	runs, err := r.query(ctx,
+		"SELECT "+deliveryRunColumns+" FROM delivery_runs WHERE id = (SELECT run_id FROM delivery_stops WHERE order_id = $1)",
+		orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, dispatch.ErrOrderNotOnRun
+	}
+	return runs[0], nil
+}
+
+// FindRuns returns runs at branches in scope by slot start.
+func (r *DeliveryRunRepository) FindRuns(ctx context.Context, f dispatch.RunFilter) ([]*dispatch.Run, error) {
+	var status *string
+	if f.Status != nil {
+		s := string(*f.Status)
+		status = &s
+	}
+	cond, args := branchScope(ctx, "branch_id", []any{f.BranchID, f.DriverID, status, f.From, f.To})
+	return r.query(ctx,
+		"SELECT "+deliveryRunColumns+` FROM delivery_runs
+		 WHERE ($1::UUID IS NULL OR branch_id = $1) AND ($2::UUID IS NULL OR driver_id = $2)
+		   AND ($3::TEXT IS NULL OR status = $3) AND slot_starts_at >= $4 AND slot_starts_at < $5 AND `+cond+`
+		 ORDER BY slot_starts_at, created_at, id`,
+		args...)
+}
+
+// RoutedOrders returns which of the given orders are on a run.
+func (r *DeliveryRunRepository) RoutedOrders(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	rows, err := r.pool.Query(ctx, "SELECT order_id FROM delivery_stops WHERE order_id = ANY($1::uuid[])", orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querying routed orders: %w", err)
+	}
+	defer rows.Close()
+
+	routed := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning routed order: %w", err)
+		}
+		routed[id] = true
+	}
+	return routed, rows.Err()
+}
+
+// query loads runs and then their stops in sequence.
+func (r *DeliveryRunRepository) query(ctx context.Context, query string, args ...any) ([]*dispatch.Run, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying delivery runs: %w", err)
+	}
+	defer rows.Close()
+
+	type runRow struct {
+		id, branchID, zoneID, createdBy uuid.UUID
+		slotStartsAt, slotEndsAt        time.Time
+		startLat, startLon              *float64
+		driverID                        *uuid.UUID
+		status                          string
+		distanceMetres, version         int
+		createdAt, updatedAt            time.Time
+		startedAt, completedAt          *time.Time
+	}
+	var runRows []runRow
+	for rows.Next() {
+		var rr runRow
+		err := rows.Scan(&rr.id, &rr.branchID, &rr.zoneID, &rr.slotStartsAt, &rr.slotEndsAt, &rr.startLat, &rr.startLon,
+			&rr.driverID, &rr.status, &rr.distanceMetres, &rr.createdBy, &rr.version, &rr.createdAt, &rr.updatedAt,
+			&rr.startedAt, &rr.completedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning delivery run: %w", err)
+		}
+		runRows = append(runRows, rr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(runRows) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(runRows))
+	for i, rr := range runRows {
+		ids[i] = rr.id
+	}
+	stops, err := r.findStops(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*dispatch.Run, len(runRows))
+	for i, rr := range runRows {
+		var start *delivery.Point
+		if rr.startLat != nil && rr.startLon != nil {
+			start = &delivery.Point{Longitude: *rr.startLon, Latitude: *rr.startLat}
+		}
+		runs[i] = dispatch.ReconstructRun(rr.id, rr.branchID, rr.zoneID, rr.slotStartsAt, rr.slotEndsAt, start, rr.driverID,
+			dispatch.RunStatus(rr.status), stops[rr.id], rr.distanceMetres, rr.createdBy, rr.version, rr.createdAt,
+			rr.updatedAt, rr.startedAt, rr.completedAt)
+	}
+	return runs, nil
+}
+
+func (r *DeliveryRunRepository) findStops(ctx context.Context, runIDs []uuid.UUID) (map[uuid.UUID][]*dispatch.Stop, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+deliveryStopColumns+" FROM delivery_stops WHERE run_id = ANY($1::uuid[]) ORDER BY run_id, sequence", runIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querying delivery stops: %w", err)
+	}
+	defer rows.Close()
+
+	stops := make(map[uuid.UUID][]*dispatch.Stop)
+	for rows.Next() {
+		var id, runID, orderID, customerID uuid.UUID
+		var addressID *uuid.UUID
+		var addressLines, proofs []string
+		var postcode, status, recipientName, failureReason string
+		var lat, lon *float64
+		var sequence int
+		var completedAt *time.Time
+		err := rows.Scan(&id, &runID, &orderID, &customerID, &addressID, &addressLines, &postcode, &lat, &lon,
+			&sequence, &status, &recipientName, &failureReason, &proofs, &completedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning delivery stop: %w", err)
+		}
+		var point *delivery.Point
+		if lat != nil && lon != nil {
+			point = &delivery.Point{Longitude: *lon, Latitude: *lat}
+		}
+		kinds := make([]dispatch.ProofKind, len(proofs))
+		for i, k := range proofs {
+			kinds[i] = dispatch.ProofKind(k)
+		}
+		stops[runID] = append(stops[runID], dispatch.ReconstructStop(id, orderID, customerID, addressID, addressLines,
+			postcode, point, sequence, dispatch.StopStatus(status), recipientName, failureReason, kinds, completedAt))
+	}
+	return stops, rows.Err()
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+)
+
+// DeliveryZoneRepository implements delivery.ZoneRepository using PostgreSQL.
+type DeliveryZoneRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDeliveryZoneRepository creates a new DeliveryZoneRepository.
+func NewDeliveryZoneRepository(pool *pgxpool.Pool) *DeliveryZoneRepository {
+	return &DeliveryZoneRepository{pool: pool}
+}
+
+const deliveryZoneColumns = "id, name, area, postcode_prefixes, delivery_fee_cents, minimum_order_cents, " +
+	"fee_tax_category, active, created_at, updated_at"
+
+// Save inserts or updates a delivery zone.
+func (r *DeliveryZoneRepository) Save(ctx context.Context, z *delivery.Zone) error {
+	var area []byte
+	if z.Area() != nil {
+		area = z.Area().GeoJSON()
+	}
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO delivery_zones (`+deliveryZoneColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, area = EXCLUDED.area, postcode_prefixes = EXCLUDED.postcode_prefixes,
+		     delivery_fee_cents = EXCLUDED.delivery_fee_cents, minimum_order_cents = EXCLUDED.minimum_order_cents,
+		     fee_tax_category = EXCLUDED.fee_tax_category, active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		z.ID(), z.Name(), area, z.PostcodePrefixes(), z.DeliveryFeeCents(), z.MinimumOrderCents(),
+		z.FeeTaxCategory(), z.IsActive(), z.CreatedAt(), z.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving delivery zone: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a delivery zone by ID.
+func (r *DeliveryZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*delivery.Zone, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+deliveryZoneColumns+" FROM delivery_zones WHERE id = $1", id)
+
+	z, err := scanDeliveryZone(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrZoneNotFound
+		}
+		return nil, fmt.Errorf("querying delivery zone by id: %w", err)
+	}
+	return z, nil
+}
+
+// FindAll returns delivery zones ordered by name.
+func (r *DeliveryZoneRepository) FindAll(ctx context.Context, activeOnly bool) ([]*delivery.Zone, error) {
+	query := "SELECT " + deliveryZoneColumns + " FROM delivery_zones"
+	if activeOnly {
+		query += " WHERE active"
+	}
+	query += " ORDER BY name, id"
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying delivery zones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []*delivery.Zone
+	for rows.Next() {
+		z, err := scanDeliveryZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning delivery zone: %w", err)
+		}
+		zones = append(zones, z)
+	}
+	return zones, rows.Err()
+}
+
+func scanDeliveryZone(row pgx.Row) (*delivery.Zone, error) {
+	var id uuid.UUID
+	var name, feeTaxCategory string
+	var rawArea []byte
+	var prefixes []string
+	var fee, minimum int64
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &rawArea, &prefixes, &fee, &minimum, &feeTaxCategory, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var area *delivery.Area
+	if rawArea != nil {
+		a, err := delivery.ParseArea(rawArea)
+		if err != nil {
+			return nil, fmt.Errorf("reconstructing zone area: %w", err)
+		}
+		area = a
+	}
+
+	return delivery.ReconstructZone(id, name, area, prefixes, fee, minimum, feeTaxCategory, active, createdAt, updatedAt), nil
+}
@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedDeliveryZone(t *testing.T, repo *pgstore.DeliveryZoneRepository, name string) uuid.UUID {
+	t.Helper()
+	z, err := delivery.NewZone(uuid.New(), name, nil, []string{"E1"}, 499, 2500)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), z))
+	return z.ID()
+}
+
+func TestIntegrationDeliveryZoneRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewDeliveryZoneRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	area, err := delivery.ParseArea([]byte(`{"type":"Polygon","coordinates":[
+		[[-0.08,51.51],[-0.06,51.51],[-0.06,51.53],[-0.08,51.53],[-0.08,51.51]]
+	]}`))
+	require.NoError(t, err)
+	polygonZone, err := delivery.NewZone(uuid.New(), "Around the shop", area, nil, 0, 1500)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, polygonZone))
+
+	postcodeZone, err := delivery.NewZone(uuid.New(), "Zone B", nil, []string{"E1", "e2"}, 699, 3000)
+	require.NoError(t, err)
+	require.NoError(t, postcodeZone.SetFeeTaxCategory("delivery_zero_rated"))
+	require.NoError(t, repo.Save(ctx, postcodeZone))
+
+	t.Run("round-trips polygon area", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, polygonZone.ID())
+
+		require.NoError(t, err)
+		require.NotNil(t, found.Area())
+		assert.True(t, found.Area().Contains(delivery.Point{Longitude: -0.07, Latitude: 51.52}))
+		assert.Empty(t, found.PostcodePrefixes())
+		assert.Equal(t, int64(1500), found.MinimumOrderCents())
+		assert.Equal(t, delivery.DefaultFeeTaxCategory, found.FeeTaxCategory())
+	})
+
+	t.Run("round-trips postcode prefixes", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, postcodeZone.ID())
+
+		require.NoError(t, err)
+		assert.Nil(t, found.Area())
+		assert.Equal(t, []string{"E1", "E2"}, found.PostcodePrefixes())
+		assert.Equal(t, int64(699), found.DeliveryFeeCents())
+		assert.Equal(t, "delivery_zero_rated", found.FeeTaxCategory())
+	})
+
+	t.Run("unknown id returns ErrZoneNotFound", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, delivery.ErrZoneNotFound)
+	})
+
+	t.Run("find all filters inactive zones", func(t *testing.T) {
+		postcodeZone.SetActive(false)
+		require.NoError(t, repo.Save(ctx, postcodeZone))
+
+		all, err := repo.FindAll(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, "Around the shop", all[0].Name())
+
+		active, err := repo.FindAll(ctx, true)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, polygonZone.ID(), active[0].ID())
+	})
+}
@@ -0,0 +1,153 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationDispatchRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	branches := pgstore.NewBranchRepository(pool)
+	drivers := pgstore.NewDriverRepository(pool)
+	runs := pgstore.NewDeliveryRunRepository(pool)
+	proofs := pgstore.NewDeliveryProofRepository(pool)
+	addresses := pgstore.NewCustomerAddressRepository(pool)
+	orders := pgstore.NewOrderRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	east, err := branch.NewBranch(uuid.New(), "East", []string{"1 High Street"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, branches.Save(ctx, east))
+	west, err := branch.NewBranch(uuid.New(), "West", []string{"2 Park Lane"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, branches.Save(ctx, west))
+	zoneID := seedDeliveryZone(t, pgstore.NewDeliveryZoneRepository(pool), "Whitechapel")
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "dispatch@example.com")
+	createdBy := seedStaffAdmin(t, pool, "Dispatcher").ID()
+
+	account, err := admin.NewAdmin(uuid.New(), "bilal@butchery.com", "$2a$10$hash", "Bilal Khan", admin.RoleDriver)
+	require.NoError(t, err)
+	account.AssignBranches(false, []uuid.UUID{east.ID()})
+	bilal, err := dispatch.NewDriver(account, east.ID(), "07700 900123", "Van", now)
+	require.NoError(t, err)
+	require.NoError(t, drivers.Create(ctx, account, bilal))
+
+	t.Run("drivers", func(t *testing.T) {
+		login, err := pgstore.NewAdminRepository(pool).FindByEmail(ctx, "bilal@butchery.com")
+		require.NoError(t, err)
+		assert.Equal(t, admin.RoleDriver, login.Role())
+		assert.Equal(t, []uuid.UUID{east.ID()}, login.BranchIDs())
+
+		again, err := admin.NewAdmin(uuid.New(), "bilal@butchery.com", "$2a$10$hash", "Bilal Two", admin.RoleDriver)
+		require.NoError(t, err)
+		other, err := dispatch.NewDriver(again, east.ID(), "", "Bike", now)
+		require.NoError(t, err)
+		assert.ErrorIs(t, drivers.Create(ctx, again, other), dispatch.ErrEmailTaken)
+
+		got, err := drivers.FindByAdminID(ctx, bilal.AdminID())
+		require.NoError(t, err)
+		assert.Equal(t, "Bilal Khan", got.FullName())
+		assert.Equal(t, "Van", got.Vehicle())
+
+		westOnly := branch.WithScope(ctx, branch.Only(west.ID()))
+		_, err = drivers.FindByAdminID(westOnly, bilal.AdminID())
+		assert.ErrorIs(t, err, dispatch.ErrDriverNotFound)
+		all, err := drivers.FindAll(westOnly, nil)
+		require.NoError(t, err)
+		assert.Empty(t, all)
+	})
+
+	newOrderStop := func(lat, lon float64) *dispatch.Stop {
+		o, err := order.NewOrder(uuid.New(), customerID, east.ID(), []order.Line{
+			{ProductID: uuid.New(), Grams: 800, PricePerKgCents: 1125, TaxCategory: "fresh_meat", NetCents: 900, GrossCents: 900},
+		}, nil, now)
+		require.NoError(t, err)
+		require.NoError(t, orders.Create(ctx, o))
+		coords, err := customer.NewCoordinates(lat, lon)
+		require.NoError(t, err)
+		addr := newTestAddress(t, customerID, "Home", &coords)
+		require.NoError(t, addresses.Save(ctx, addr))
+		return dispatch.NewStop(uuid.New(), o.ID(), addr)
+	}
+
+	t.Run("runs", func(t *testing.T) {
+		slot := time.Date(2026, 11, 10, 16, 0, 0, 0, time.UTC)
+		run, err := dispatch.NewRun(uuid.New(), east.ID(), zoneID, slot, slot.Add(2*time.Hour),
+			&delivery.Point{Longitude: -0.07, Latitude: 51.515}, createdBy, now)
+		require.NoError(t, err)
+		far, near := newOrderStop(51.53, -0.05), newOrderStop(51.516, -0.069)
+		require.NoError(t, run.AddStop(far, now))
+		require.NoError(t, run.AddStop(near, now))
+		require.NoError(t, run.Optimise(now))
+		require.NoError(t, runs.Create(ctx, run))
+
+		got, err := runs.FindByID(ctx, run.ID())
+		require.NoError(t, err)
+		require.Len(t, got.Stops(), 2)
+		assert.Equal(t, near.ID(), got.Stops()[0].ID(), "stops load in route order")
+		assert.Equal(t, []string{"1 High Street", "London"}, got.Stops()[0].AddressLines())
+		assert.InDelta(t, 51.516, got.Stops()[0].Point().Latitude, 1e-9)
+		assert.Equal(t, run.DistanceMetres(), got.DistanceMetres())
+
+		_, err = runs.FindByID(branch.WithScope(ctx, branch.Only(west.ID())), run.ID())
+		assert.ErrorIs(t, err, dispatch.ErrRunNotFound)
+
+		routed, err := runs.RoutedOrders(ctx, []uuid.UUID{far.OrderID(), uuid.New()})
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]bool{far.OrderID(): true}, routed)
+
+		// A second run cannot take an order that is already routed.
+		other, err := dispatch.NewRun(uuid.New(), east.ID(), zoneID, slot, slot.Add(2*time.Hour), nil, createdBy, now)
+		require.NoError(t, err)
+		dup := dispatch.ReconstructStop(uuid.New(), far.OrderID(), customerID, nil, nil, "", nil, 0, dispatch.StopPending, "", "", nil, nil)
+		require.NoError(t, other.AddStop(dup, now))
+		assert.ErrorIs(t, runs.Create(ctx, other), dispatch.ErrOrderAlreadyRouted)
+
+		require.NoError(t, got.AssignDriver(bilal, now))
+		require.NoError(t, got.StartRun(bilal.AdminID(), now))
+		p, err := dispatch.NewProof(near.ID(), dispatch.ProofPhoto, "image/jpeg", []byte{0xff, 0xd8, 0xff}, now)
+		require.NoError(t, err)
+		require.NoError(t, got.Deliver(near.ID(), bilal.AdminID(), "Amina", []*dispatch.Proof{p}, now))
+		require.NoError(t, proofs.Save(ctx, p))
+		require.NoError(t, runs.Update(ctx, got))
+		assert.ErrorIs(t, runs.Update(ctx, got), dispatch.ErrConcurrentUpdate, "the loaded version is stale")
+
+		byOrder, err := runs.FindByOrderID(ctx, near.OrderID())
+		require.NoError(t, err)
+		assert.Equal(t, dispatch.RunStarted, byOrder.Status())
+		assert.Equal(t, bilal.AdminID(), *byOrder.DriverID())
+		assert.Equal(t, dispatch.StopDelivered, byOrder.Stops()[0].Status())
+		assert.Equal(t, []dispatch.ProofKind{dispatch.ProofPhoto}, byOrder.Stops()[0].Proofs())
+		_, err = runs.FindByOrderID(ctx, uuid.New())
+		assert.ErrorIs(t, err, dispatch.ErrOrderNotOnRun)
+
+		bilalID := bilal.AdminID()
+		listed, err := runs.FindRuns(ctx, dispatch.RunFilter{DriverID: &bilalID, From: slot, To: slot.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+
+		stored, err := proofs.Find(ctx, near.ID(), dispatch.ProofPhoto)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xd8, 0xff}, stored.Data())
+		_, err = proofs.Find(ctx, near.ID(), dispatch.ProofSignature)
+		assert.ErrorIs(t, err, dispatch.ErrProofNotFound)
+	})
+}
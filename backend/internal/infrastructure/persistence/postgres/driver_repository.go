@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+)
+
+// DriverRepository implements dispatch.DriverRepository using PostgreSQL.
+type DriverRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDriverRepository creates a new DriverRepository.
+func NewDriverRepository(pool *pgxpool.Pool) *DriverRepository {
+	return &DriverRepository{pool: pool}
+}
+
+const driverColumns = "d.admin_id, a.full_name, d.branch_id, d.phone, d.vehicle, d.active, d.created_at, d.updated_at"
+
+const driverFrom = " FROM drivers d JOIN admins a ON a.id = d.admin_id"
+
+// Create inserts the driver's admin account, assigned to their home
+// branch, and their profile in one transaction.
+func (r *DriverRepository) Create(ctx context.Context, account *admin.Admin, d *dispatch.Driver) error {
+	if err := branch.ScopeFrom(ctx).Check(d.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO admins (id, email, password_hash, full_name, role, all_branches, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		account.ID(), account.Email(), account.PasswordHash(), account.FullName(), string(account.Role()),
+		account.AllBranches(), d.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return dispatch.ErrEmailTaken
+		}
+		return fmt.Errorf("inserting driver account: %w", err)
+	}
+	for _, branchID := range account.BranchIDs() {
+		if _, err := tx.Exec(ctx, "INSERT INTO admin_branches (admin_id, branch_id) VALUES ($1, $2)", account.ID(), branchID); err != nil {
+			return fmt.Errorf("inserting admin branch: %w", err)
+		}
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO drivers (admin_id, branch_id, phone, vehicle, active, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		d.AdminID(), d.BranchID(), d.Phone(), d.Vehicle(), d.Active(), d.CreatedAt(), d.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting driver: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing driver: %w", err)
+	}
+	return nil
+}
+
+// Save updates a driver based at a branch in scope. A driver based at a
+// branch outside the scope is not moved into it.
+func (r *DriverRepository) Save(ctx context.Context, d *dispatch.Driver) error {
+	if err := branch.ScopeFrom(ctx).Check(d.BranchID()); err != nil {
+		return err
+	}
+	cond, args := branchScope(ctx, "branch_id", []any{d.AdminID(), d.BranchID(), d.Phone(), d.Vehicle(), d.Active(), d.UpdatedAt()})
+	result, err := r.pool.Exec(ctx,
+		`UPDATE drivers SET branch_id = $2, phone = $3, vehicle = $4, active = $5, updated_at = $6
+		 WHERE admin_id = $1 AND `+cond,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("updating driver: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return dispatch.ErrDriverNotFound
+	}
+	return nil
+}
+
+// FindByAdminID finds the driver profile of an admin based at a branch in
+// scope.
+func (r *DriverRepository) FindByAdminID(ctx context.Context, adminID uuid.UUID) (*dispatch.Driver, error) {
+	cond, args := branchScope(ctx, "d.branch_id", []any{adminID})
+	d, err := scanDriver(r.pool.QueryRow(ctx, "SELECT "+driverColumns+driverFrom+" WHERE d.admin_id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dispatch.ErrDriverNotFound
+		}
+		return nil, fmt.Errorf("querying driver: %w", err)
+	}
+	return d, nil
+}
+
+// FindAll returns the drivers based at a branch, or at every branch in
+// scope, by name.
+func (r *DriverRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*dispatch.Driver, error) {
+	cond, args := branchScope(ctx, "d.branch_id", []any{branchID})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+driverColumns+driverFrom+" WHERE ($1::UUID IS NULL OR d.branch_id = $1) AND "+cond+
+			" ORDER BY a.full_name, d.admin_id",
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying drivers: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []*dispatch.Driver
+	for rows.Next() {
+		d, err := scanDriver(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning driver: %w", err)
+		}
+		drivers = append(drivers, d)
+	}
+	return drivers, rows.Err()
+}
+
+func scanDriver(row pgx.Row) (*dispatch.Driver, error) {
+	var adminID, branchID uuid.UUID
+	var fullName, phone, vehicle string
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&adminID, &fullName, &branchID, &phone, &vehicle, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return dispatch.ReconstructDriver(adminID, fullName, branchID, phone, vehicle, active, createdAt, updatedAt), nil
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// FulfilmentScheduleRepository implements fulfilment.ScheduleRepository using PostgreSQL.
+type FulfilmentScheduleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFulfilmentScheduleRepository creates a new FulfilmentScheduleRepository.
+func NewFulfilmentScheduleRepository(pool *pgxpool.Pool) *FulfilmentScheduleRepository {
+	return &FulfilmentScheduleRepository{pool: pool}
+}
+
+// ReplaceOpeningHours replaces all opening hours of a branch in one transaction.
+func (r *FulfilmentScheduleRepository) ReplaceOpeningHours(ctx context.Context, branchID uuid.UUID, hours []*fulfilment.OpeningHours) error {
+	if err := branch.ScopeFrom(ctx).Check(branchID); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "DELETE FROM branch_opening_hours WHERE branch_id = $1", branchID); err != nil {
+		return fmt.Errorf("deleting opening hours: %w", err)
+	}
+
+	for _, h := range hours {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO branch_opening_hours (branch_id, weekday, opens_at, closes_at)
+			 VALUES ($1, $2, $3, $4)`,
+			branchID, int16(h.Weekday()), int16(h.OpensAt().Minutes()), int16(h.ClosesAt().Minutes()),
+		)
+		if err != nil {
+			return fmt.Errorf("inserting opening hours: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing opening hours: %w", err)
+	}
+	return nil
+}
+
+// FindOpeningHours returns a branch's opening hours ordered by weekday.
+func (r *FulfilmentScheduleRepository) FindOpeningHours(ctx context.Context, branchID uuid.UUID) ([]*fulfilment.OpeningHours, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT weekday, opens_at, closes_at FROM branch_opening_hours WHERE branch_id = $1 ORDER BY weekday",
+		branchID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying opening hours: %w", err)
+	}
+	defer rows.Close()
+
+	var hours []*fulfilment.OpeningHours
+	for rows.Next() {
+		var weekday, opensAt, closesAt int16
+		if err := rows.Scan(&weekday, &opensAt, &closesAt); err != nil {
+			return nil, fmt.Errorf("scanning opening hours: %w", err)
+		}
+		opens, err := fulfilment.NewTimeOfDay(int(opensAt))
+		if err != nil {
+			return nil, fmt.Errorf("reconstructing opens_at: %w", err)
+		}
+		closes, err := fulfilment.NewTimeOfDay(int(closesAt))
+		if err != nil {
+			return nil, fmt.Errorf("reconstructing closes_at: %w", err)
+		}
+		h, err := fulfilment.NewOpeningHours(branchID, time.Weekday(weekday), opens, closes)
+		if err != nil {
+			return nil, fmt.Errorf("reconstructing opening hours: %w", err)
+		}
+		hours = append(hours, h)
+	}
+	return hours, rows.Err()
+}
+
+// SaveClosure persists a branch closure. Adding the same date twice is a no-op.
+func (r *FulfilmentScheduleRepository) SaveClosure(ctx context.Context, c *fulfilment.Closure) error {
+	if err := branch.ScopeFrom(ctx).Check(c.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO branch_closures (id, branch_id, closed_on, reason)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (branch_id, closed_on) DO UPDATE SET reason = EXCLUDED.reason`,
+		c.ID(), c.BranchID(), c.Date(), c.Reason(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting closure: %w", err)
+	}
+	return nil
+}
+
+// FindClosures returns a branch's closures dated within [from, to).
+func (r *FulfilmentScheduleRepository) FindClosures(ctx context.Context, branchID uuid.UUID, from, to time.Time) ([]*fulfilment.Closure, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, closed_on, reason FROM branch_closures
+		 WHERE branch_id = $1 AND closed_on >= $2::date AND closed_on < $3::date
+		 ORDER BY closed_on`,
+		branchID, from.Format(time.DateOnly), to.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying closures: %w", err)
+	}
+	defer rows.Close()
+
+	var closures []*fulfilment.Closure
+	for rows.Next() {
+		var id uuid.UUID
+		var closedOn time.Time
+		var reason string
+		if err := rows.Scan(&id, &closedOn, &reason); err != nil {
+			return nil, fmt.Errorf("scanning closure: %w", err)
+		}
+		closures = append(closures, fulfilment.NewClosure(id, branchID, closedOn, reason))
+	}
+	return closures, rows.Err()
+}
+
+// SaveSlotTemplate inserts or updates a slot template at a branch in scope.
+func (r *FulfilmentScheduleRepository) SaveSlotTemplate(ctx context.Context, t *fulfilment.SlotTemplate) error {
+	if err := branch.ScopeFrom(ctx).Check(t.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO slot_templates (id, branch_id, zone_id, method, weekday, starts_at, ends_at, capacity_unit, capacity, active)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (id) DO UPDATE SET
+		     zone_id = EXCLUDED.zone_id, method = EXCLUDED.method, weekday = EXCLUDED.weekday,
+		     starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at,
+		     capacity_unit = EXCLUDED.capacity_unit, capacity = EXCLUDED.capacity,
+		     active = EXCLUDED.active, updated_at = NOW()`,
+		t.ID(), t.BranchID(), t.ZoneID(), t.Method().String(), int16(t.Weekday()),
+		int16(t.StartsAt().Minutes()), int16(t.EndsAt().Minutes()),
+		string(t.Capacity().Unit()), t.Capacity().Amount(), t.IsActive(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving slot template: %w", err)
+	}
+	return nil
+}
+
+const slotTemplateColumns = "id, branch_id, zone_id, method, weekday, starts_at, ends_at, capacity_unit, capacity, active"
+
+// FindSlotTemplateByID finds a slot template in scope by ID.
+func (r *FulfilmentScheduleRepository) FindSlotTemplateByID(ctx context.Context, id uuid.UUID) (*fulfilment.SlotTemplate, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	row := r.pool.QueryRow(ctx, "SELECT "+slotTemplateColumns+" FROM slot_templates WHERE id = $1 AND "+cond, args...)
+
+	t, err := scanSlotTemplate(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fulfilment.ErrSlotTemplateNotFound
+		}
+		return nil, fmt.Errorf("querying slot template by id: %w", err)
+	}
+	return t, nil
+}
+
+// FindSlotTemplates returns the slot templates in scope matching filter.
+func (r *FulfilmentScheduleRepository) FindSlotTemplates(ctx context.Context, filter fulfilment.SlotTemplateFilter) ([]*fulfilment.SlotTemplate, error) {
+	scope, args := branchScope(ctx, "branch_id", nil)
+	conds := []string{scope}
+	if filter.Method != nil {
+		args = append(args, filter.Method.String())
+		conds = append(conds, fmt.Sprintf("method = $%d", len(args)))
+	}
+	if filter.BranchID != nil {
+		args = append(args, *filter.BranchID)
+		conds = append(conds, fmt.Sprintf("branch_id = $%d", len(args)))
+	}
+	if filter.ZoneID != nil {
+		args = append(args, *filter.ZoneID)
+		conds = append(conds, fmt.Sprintf("zone_id = $%d", len(args)))
+	}
+	if filter.ActiveOnly {
+		conds = append(conds, "active")
+	}
+
+	query := "SELECT " + slotTemplateColumns + " FROM slot_templates WHERE " + strings.Join(conds, " AND ")
+	query += " ORDER BY weekday, starts_at"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying slot templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*fulfilment.SlotTemplate
+	for rows.Next() {
+		t, err := scanSlotTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning slot template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func scanSlotTemplate(row pgx.Row) (*fulfilment.SlotTemplate, error) {
+	var id, branchID uuid.UUID
+	var zoneID *uuid.UUID
+	var method, unit string
+	var weekday, startsAt, endsAt int16
+	var capacity int64
+	var active bool
+
+	if err := row.Scan(&id, &branchID, &zoneID, &method, &weekday, &startsAt, &endsAt, &unit, &capacity, &active); err != nil {
+		return nil, err
+	}
+
+	start, err := fulfilment.NewTimeOfDay(int(startsAt))
+	if err != nil {
+		return nil, fmt.Errorf("reconstructing starts_at: %w", err)
+	}
+	end, err := fulfilment.NewTimeOfDay(int(endsAt))
+	if err != nil {
+		return nil, fmt.Errorf("reconstructing ends_at: %w", err)
+	}
+	slotCapacity, err := fulfilment.NewCapacity(fulfilment.CapacityUnit(unit), capacity)
+	if err != nil {
+		return nil, fmt.Errorf("reconstructing capacity: %w", err)
+	}
+
+	return fulfilment.ReconstructSlotTemplate(id, branchID, zoneID, fulfilment.Method(method),
+		time.Weekday(weekday), start, end, slotCapacity, active), nil
+}
@@ -0,0 +1,143 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTimeOfDay(t *testing.T, raw string) fulfilment.TimeOfDay {
+	t.Helper()
+	tod, err := fulfilment.ParseTimeOfDay(raw)
+	require.NoError(t, err)
+	return tod
+}
+
+func newTestSlotTemplate(t *testing.T, branchID uuid.UUID, zoneID *uuid.UUID, method fulfilment.Method, capacity int64) *fulfilment.SlotTemplate {
+	t.Helper()
+	c, err := fulfilment.NewCapacity(fulfilment.CapacityUnitOrders, capacity)
+	require.NoError(t, err)
+	tmpl, err := fulfilment.NewSlotTemplate(uuid.New(), branchID, zoneID, method,
+		time.Monday, mustTimeOfDay(t, "10:00"), mustTimeOfDay(t, "12:00"), c)
+	require.NoError(t, err)
+	return tmpl
+}
+
+func TestIntegrationFulfilmentScheduleRepository_OpeningHours(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewFulfilmentScheduleRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	branchID := uuid.New()
+	monday, err := fulfilment.NewOpeningHours(branchID, time.Monday, mustTimeOfDay(t, "08:00"), mustTimeOfDay(t, "18:00"))
+	require.NoError(t, err)
+	saturday, err := fulfilment.NewOpeningHours(branchID, time.Saturday, mustTimeOfDay(t, "09:00"), mustTimeOfDay(t, "13:30"))
+	require.NoError(t, err)
+
+	t.Run("saves and retrieves hours", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceOpeningHours(ctx, branchID, []*fulfilment.OpeningHours{saturday, monday}))
+
+		hours, err := repo.FindOpeningHours(ctx, branchID)
+		require.NoError(t, err)
+		require.Len(t, hours, 2)
+		assert.Equal(t, time.Monday, hours[0].Weekday())
+		assert.Equal(t, "13:30", hours[1].ClosesAt().String())
+	})
+
+	t.Run("replace removes days not given", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceOpeningHours(ctx, branchID, []*fulfilment.OpeningHours{monday}))
+
+		hours, err := repo.FindOpeningHours(ctx, branchID)
+		require.NoError(t, err)
+		assert.Len(t, hours, 1)
+	})
+}
+
+func TestIntegrationFulfilmentScheduleRepository_Closures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewFulfilmentScheduleRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	branchID := uuid.New()
+	christmas := fulfilment.NewClosure(uuid.New(), branchID, time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), "Christmas")
+	newYear := fulfilment.NewClosure(uuid.New(), branchID, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), "New Year")
+	require.NoError(t, repo.SaveClosure(ctx, christmas))
+	require.NoError(t, repo.SaveClosure(ctx, newYear))
+
+	closures, err := repo.FindClosures(ctx, branchID,
+		time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	require.Len(t, closures, 1)
+	assert.Equal(t, "Christmas", closures[0].Reason())
+	assert.True(t, closures[0].IsOn(time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestIntegrationFulfilmentScheduleRepository_SlotTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewFulfilmentScheduleRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	branchID := uuid.New()
+	zoneID := seedDeliveryZone(t, pgstore.NewDeliveryZoneRepository(pool), "Central")
+	delivery := newTestSlotTemplate(t, branchID, &zoneID, fulfilment.MethodDelivery, 6)
+	collection := newTestSlotTemplate(t, branchID, nil, fulfilment.MethodCollection, 10)
+	require.NoError(t, repo.SaveSlotTemplate(ctx, delivery))
+	require.NoError(t, repo.SaveSlotTemplate(ctx, collection))
+
+	t.Run("finds template by id", func(t *testing.T) {
+		found, err := repo.FindSlotTemplateByID(ctx, delivery.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, zoneID, *found.ZoneID())
+		assert.Equal(t, fulfilment.MethodDelivery, found.Method())
+		assert.Equal(t, "10:00", found.StartsAt().String())
+		assert.Equal(t, int64(6), found.Capacity().Amount())
+	})
+
+	t.Run("unknown id returns ErrSlotTemplateNotFound", func(t *testing.T) {
+		_, err := repo.FindSlotTemplateByID(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, fulfilment.ErrSlotTemplateNotFound)
+	})
+
+	t.Run("filters by zone", func(t *testing.T) {
+		found, err := repo.FindSlotTemplates(ctx, fulfilment.SlotTemplateFilter{ZoneID: &zoneID})
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, delivery.ID(), found[0].ID())
+	})
+
+	t.Run("active only excludes deactivated templates", func(t *testing.T) {
+		collection.Deactivate()
+		require.NoError(t, repo.SaveSlotTemplate(ctx, collection))
+
+		found, err := repo.FindSlotTemplates(ctx, fulfilment.SlotTemplateFilter{BranchID: &branchID, ActiveOnly: true})
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, delivery.ID(), found[0].ID())
+	})
+}
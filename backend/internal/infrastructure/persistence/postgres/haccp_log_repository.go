@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// HACCPLogRepository implements haccp.LogRepository using PostgreSQL.
+type HACCPLogRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHACCPLogRepository creates a new HACCPLogRepository.
+func NewHACCPLogRepository(pool *pgxpool.Pool) *HACCPLogRepository {
+	return &HACCPLogRepository{pool: pool}
+}
+
+const (
+	haccpReadingColumns = "r.id, r.unit_id, r.celsius, r.source, r.recorded_by, r.note, r.in_range, r.taken_at, r.created_at"
+	haccpAlertColumns   = "a.id, a.unit_id, a.kind, a.reading_id, a.celsius, a.raised_at, a.resolved_at, a.resolved_by"
+	haccpActionColumns  = "c.id, c.unit_id, c.alert_id, c.action, c.taken_by, c.taken_at"
+)
+
+// SaveReading stores a reading and raises alert with it, unless the unit
+// already has an open alert of the same kind.
+func (r *HACCPLogRepository) SaveReading(ctx context.Context, rd *haccp.Reading, alert *haccp.Alert) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO haccp_readings (id, unit_id, celsius, source, recorded_by, note, in_range, taken_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		rd.ID(), rd.UnitID(), rd.Celsius(), string(rd.Source()), rd.RecordedBy(), rd.Note(), rd.InRange(),
+		rd.TakenAt(), rd.CreatedAt(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("inserting reading: %w", err)
+	}
+	raised := false
+	if alert != nil {
+		result, err := tx.Exec(ctx, haccpInsertAlert, haccpAlertArgs(alert)...)
+		if err != nil {
+			return false, fmt.Errorf("inserting alert: %w", err)
+		}
+		raised = result.RowsAffected() == 1
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return raised, nil
+}
+
+// RaiseAlert stores an alert unless the unit already has an open alert of
+// the same kind.
+func (r *HACCPLogRepository) RaiseAlert(ctx context.Context, a *haccp.Alert) (bool, error) {
+	result, err := r.pool.Exec(ctx, haccpInsertAlert, haccpAlertArgs(a)...)
+	if err != nil {
+		return false, fmt.Errorf("inserting alert: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// haccpInsertAlert inserts an alert unless its unit has an open alert of
+// the same kind.
+const haccpInsertAlert = `INSERT INTO haccp_alerts (id, unit_id, kind, reading_id, celsius, raised_at, resolved_at, resolved_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (unit_id, kind) WHERE resolved_at IS NULL DO NOTHING`
+
+func haccpAlertArgs(a *haccp.Alert) []any {
+	return []any{a.ID(), a.UnitID(), string(a.Kind()), a.ReadingID(), a.Celsius(), a.RaisedAt(), a.ResolvedAt(), a.ResolvedBy()}
+}
+
+// FindAlertByID finds an alert on a unit in scope by ID.
+func (r *HACCPLogRepository) FindAlertByID(ctx context.Context, id uuid.UUID) (*haccp.Alert, error) {
+	cond, args := branchScope(ctx, "u.branch_id", []any{id})
+	a, err := scanHACCPAlert(r.pool.QueryRow(ctx,
+		"SELECT "+haccpAlertColumns+" FROM haccp_alerts a JOIN haccp_units u ON u.id = a.unit_id WHERE a.id = $1 AND "+cond,
+		args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, haccp.ErrAlertNotFound
+		}
+		return nil, fmt.Errorf("querying alert: %w", err)
+	}
+	return a, nil
+}
+
+// SaveAction stores a corrective action and resolves the alert it dealt
+// with, if any.
+func (r *HACCPLogRepository) SaveAction(ctx context.Context, c *haccp.CorrectiveAction, resolved *haccp.Alert) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO haccp_corrective_actions (id, unit_id, alert_id, action, taken_by, taken_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		c.ID(), c.UnitID(), c.AlertID(), c.Action(), c.TakenBy(), c.TakenAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting corrective action: %w", err)
+	}
+	if resolved != nil {
+		result, err := tx.Exec(ctx,
+			"UPDATE haccp_alerts SET resolved_at = $2, resolved_by = $3 WHERE id = $1 AND resolved_at IS NULL",
+			resolved.ID(), resolved.ResolvedAt(), resolved.ResolvedBy(),
+		)
+		if err != nil {
+			return fmt.Errorf("resolving alert: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return haccp.ErrAlertResolved
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// LastReadings returns when each unit that has been read was last read.
+func (r *HACCPLogRepository) LastReadings(ctx context.Context) (map[uuid.UUID]time.Time, error) {
+	rows, err := r.pool.Query(ctx, "SELECT unit_id, MAX(taken_at) FROM haccp_readings GROUP BY unit_id")
+	if err != nil {
+		return nil, fmt.Errorf("querying last readings: %w", err)
+	}
+	defer rows.Close()
+
+	last := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var unitID uuid.UUID
+		var takenAt time.Time
+		if err := rows.Scan(&unitID, &takenAt); err != nil {
+			return nil, fmt.Errorf("scanning last reading: %w", err)
+		}
+		last[unitID] = takenAt
+	}
+	return last, rows.Err()
+}
+
+// FindReadings returns readings of units in scope by when they were taken.
+func (r *HACCPLogRepository) FindReadings(ctx context.Context, f haccp.Filter) ([]*haccp.Reading, error) {
+	where, args := haccpFilter(ctx, f, "r.taken_at")
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+haccpReadingColumns+" FROM haccp_readings r JOIN haccp_units u ON u.id = r.unit_id WHERE "+where+
+			" ORDER BY r.taken_at, r.id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []*haccp.Reading
+	for rows.Next() {
+		var id, unitID uuid.UUID
+		var celsius float64
+		var source, note string
+		var recordedBy *uuid.UUID
+		var inRange bool
+		var takenAt, createdAt time.Time
+		if err := rows.Scan(&id, &unitID, &celsius, &source, &recordedBy, &note, &inRange, &takenAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning reading: %w", err)
+		}
+		readings = append(readings, haccp.ReconstructReading(id, unitID, celsius, haccp.Source(source), recordedBy, note,
+			inRange, takenAt, createdAt))
+	}
+	return readings, rows.Err()
+}
+
+// FindAlerts returns alerts on units in scope, newest first.
+func (r *HACCPLogRepository) FindAlerts(ctx context.Context, f haccp.Filter) ([]*haccp.Alert, error) {
+	where, args := haccpFilter(ctx, f, "a.raised_at")
+	if f.OpenOnly {
+		where += " AND a.resolved_at IS NULL"
+	}
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+haccpAlertColumns+" FROM haccp_alerts a JOIN haccp_units u ON u.id = a.unit_id WHERE "+where+
+			" ORDER BY a.raised_at DESC, a.id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*haccp.Alert
+	for rows.Next() {
+		a, err := scanHACCPAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+// FindActions returns corrective actions on units in scope by when they
+// were taken.
+func (r *HACCPLogRepository) FindActions(ctx context.Context, f haccp.Filter) ([]*haccp.CorrectiveAction, error) {
+	where, args := haccpFilter(ctx, f, "c.taken_at")
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+haccpActionColumns+" FROM haccp_corrective_actions c JOIN haccp_units u ON u.id = c.unit_id WHERE "+where+
+			" ORDER BY c.taken_at, c.id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying corrective actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []*haccp.CorrectiveAction
+	for rows.Next() {
+		var id, unitID, takenBy uuid.UUID
+		var alertID *uuid.UUID
+		var action string
+		var takenAt time.Time
+		if err := rows.Scan(&id, &unitID, &alertID, &action, &takenBy, &takenAt); err != nil {
+			return nil, fmt.Errorf("scanning corrective action: %w", err)
+		}
+		actions = append(actions, haccp.ReconstructCorrectiveAction(id, unitID, alertID, action, takenBy, takenAt))
+	}
+	return actions, rows.Err()
+}
+
+// haccpFilter returns the conditions selecting records matching f from a
+// query joined to haccp_units as u, with at the column holding when each
+// record happened.
+func haccpFilter(ctx context.Context, f haccp.Filter, at string) (string, []any) {
+	args := []any{f.BranchID, f.UnitID, f.From, f.To}
+	where := "($1::uuid IS NULL OR u.branch_id = $1) AND ($2::uuid IS NULL OR u.id = $2)" +
+		" AND ($3::timestamptz IS NULL OR " + at + " >= $3) AND ($4::timestamptz IS NULL OR " + at + " < $4)"
+	cond, args := branchScope(ctx, "u.branch_id", args)
+	return where + " AND " + cond, args
+}
+
+func scanHACCPAlert(row pgx.Row) (*haccp.Alert, error) {
+	var id, unitID uuid.UUID
+	var kind string
+	var readingID, resolvedBy *uuid.UUID
+	var celsius *float64
+	var raisedAt time.Time
+	var resolvedAt *time.Time
+
+	if err := row.Scan(&id, &unitID, &kind, &readingID, &celsius, &raisedAt, &resolvedAt, &resolvedBy); err != nil {
+		return nil, err
+	}
+	return haccp.ReconstructAlert(id, unitID, haccp.AlertKind(kind), readingID, celsius, raisedAt, resolvedAt, resolvedBy), nil
+}
@@ -0,0 +1,117 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationHACCPRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	branches := pgstore.NewBranchRepository(pool)
+	units := pgstore.NewHACCPUnitRepository(pool)
+	logs := pgstore.NewHACCPLogRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	east, err := branch.NewBranch(uuid.New(), "East", []string{"1 High Street"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, branches.Save(ctx, east))
+	west, err := branch.NewBranch(uuid.New(), "West", []string{"2 Park Lane"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, branches.Save(ctx, west))
+
+	chiller, err := haccp.NewUnit(uuid.New(), east.ID(), "Walk-in chiller", haccp.KindChiller, 0, 5, "chiller-1", 30*time.Minute, now)
+	require.NoError(t, err)
+	require.NoError(t, units.Save(ctx, chiller))
+	freezer, err := haccp.NewUnit(uuid.New(), west.ID(), "Chest freezer", haccp.KindFreezer, -25, -18, "", time.Hour, now)
+	require.NoError(t, err)
+	require.NoError(t, units.Save(ctx, freezer))
+
+	clash, err := haccp.NewUnit(uuid.New(), east.ID(), "walk-in CHILLER", haccp.KindChiller, 0, 5, "", time.Hour, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, units.Save(ctx, clash), haccp.ErrDuplicateUnit)
+	clash, err = haccp.NewUnit(uuid.New(), west.ID(), "Display", haccp.KindDisplay, 0, 5, "chiller-1", time.Hour, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, units.Save(ctx, clash), haccp.ErrDuplicateSensor)
+
+	got, err := units.FindBySensor(ctx, "chiller-1")
+	require.NoError(t, err)
+	assert.Equal(t, chiller.ID(), got.ID())
+	assert.Equal(t, 30*time.Minute, got.CheckEvery())
+	_, err = units.FindBySensor(ctx, "")
+	assert.ErrorIs(t, err, haccp.ErrUnitNotFound, "units read by hand have no sensor")
+
+	// Only one out of range alert is open on a unit at a time.
+	for i, celsius := range []float64{7.5, 8.1} {
+		r, err := chiller.Record(uuid.New(), celsius, haccp.SourceSensor, nil, "", now.Add(time.Duration(i)*time.Minute), now.Add(time.Hour))
+		require.NoError(t, err)
+		raised, err := logs.SaveReading(ctx, r, haccp.NewOutOfRangeAlert(uuid.New(), r, now))
+		require.NoError(t, err)
+		assert.Equal(t, i == 0, raised)
+	}
+	alerts, err := logs.FindAlerts(ctx, haccp.Filter{OpenOnly: true})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.NotNil(t, alerts[0].Celsius())
+	assert.Equal(t, 7.5, *alerts[0].Celsius())
+
+	raised, err := logs.RaiseAlert(ctx, haccp.NewMissingAlert(uuid.New(), freezer.ID(), now))
+	require.NoError(t, err)
+	assert.True(t, raised, "an alert of another kind may be open alongside")
+
+	// Resolving an alert with a corrective action closes it, once.
+	alert, err := logs.FindAlertByID(ctx, alerts[0].ID())
+	require.NoError(t, err)
+	require.NoError(t, alert.Resolve(uuid.New(), now))
+	alertID := alert.ID()
+	action, err := haccp.NewCorrectiveAction(uuid.New(), chiller.ID(), &alertID, "Called the engineer", uuid.New(), now)
+	require.NoError(t, err)
+	require.NoError(t, logs.SaveAction(ctx, action, alert))
+	again, err := haccp.NewCorrectiveAction(uuid.New(), chiller.ID(), &alertID, "Called again", uuid.New(), now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, logs.SaveAction(ctx, again, alert), haccp.ErrAlertResolved)
+
+	actions, err := logs.FindActions(ctx, haccp.Filter{UnitID: ptr(chiller.ID())})
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Called the engineer", actions[0].Action())
+
+	last, err := logs.LastReadings(ctx)
+	require.NoError(t, err)
+	require.Len(t, last, 1)
+	assert.True(t, last[chiller.ID()].Equal(now.Add(time.Minute)))
+
+	// An admin scoped to East sees only East's units and records.
+	eastOnly := branch.WithScope(ctx, branch.Only(east.ID()))
+	scoped, err := units.FindAll(eastOnly, nil)
+	require.NoError(t, err)
+	require.Len(t, scoped, 1)
+	assert.Equal(t, chiller.ID(), scoped[0].ID())
+	_, err = units.FindByID(eastOnly, freezer.ID())
+	assert.ErrorIs(t, err, haccp.ErrUnitNotFound)
+	assert.ErrorIs(t, units.Save(eastOnly, freezer), branch.ErrOutOfScope)
+
+	alerts, err = logs.FindAlerts(eastOnly, haccp.Filter{})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, chiller.ID(), alerts[0].UnitID())
+
+	from := now.Add(30 * time.Second)
+	readings, err := logs.FindReadings(ctx, haccp.Filter{BranchID: ptr(east.ID()), From: &from})
+	require.NoError(t, err)
+	require.Len(t, readings, 1)
+	assert.Equal(t, 8.1, readings[0].Celsius())
+}
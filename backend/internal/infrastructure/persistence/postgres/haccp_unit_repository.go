@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+)
+
+// HACCPUnitRepository implements haccp.UnitRepository using PostgreSQL.
+type HACCPUnitRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHACCPUnitRepository creates a new HACCPUnitRepository.
+func NewHACCPUnitRepository(pool *pgxpool.Pool) *HACCPUnitRepository {
+	return &HACCPUnitRepository{pool: pool}
+}
+
+const haccpUnitColumns = "id, branch_id, name, kind, min_celsius, max_celsius, sensor_id, check_every_minutes, " +
+	"active, created_at, updated_at"
+
+// Save inserts or updates a unit at a branch in scope.
+func (r *HACCPUnitRepository) Save(ctx context.Context, u *haccp.Unit) error {
+	if err := branch.ScopeFrom(ctx).Check(u.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO haccp_units (`+haccpUnitColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, kind = EXCLUDED.kind,
+		     min_celsius = EXCLUDED.min_celsius, max_celsius = EXCLUDED.max_celsius,
+		     sensor_id = EXCLUDED.sensor_id, check_every_minutes = EXCLUDED.check_every_minutes,
+		     active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		u.ID(), u.BranchID(), u.Name(), string(u.Kind()), u.MinCelsius(), u.MaxCelsius(), u.SensorID(),
+		int(u.CheckEvery()/time.Minute), u.Active(), u.CreatedAt(), u.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			switch pgErr.ConstraintName {
+			case "uq_haccp_units_branch_name":
+				return haccp.ErrDuplicateUnit
+			case "uq_haccp_units_sensor":
+				return haccp.ErrDuplicateSensor
+			}
+		}
+		return fmt.Errorf("saving unit: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a unit in scope by ID.
+func (r *HACCPUnitRepository) FindByID(ctx context.Context, id uuid.UUID) (*haccp.Unit, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	u, err := scanHACCPUnit(r.pool.QueryRow(ctx, "SELECT "+haccpUnitColumns+" FROM haccp_units WHERE id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, haccp.ErrUnitNotFound
+		}
+		return nil, fmt.Errorf("querying unit: %w", err)
+	}
+	return u, nil
+}
+
+// FindBySensor finds the unit a sensor reads.
+func (r *HACCPUnitRepository) FindBySensor(ctx context.Context, sensorID string) (*haccp.Unit, error) {
+	u, err := scanHACCPUnit(r.pool.QueryRow(ctx,
+		"SELECT "+haccpUnitColumns+" FROM haccp_units WHERE sensor_id = $1 AND sensor_id <> ''", sensorID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, haccp.ErrUnitNotFound
+		}
+		return nil, fmt.Errorf("querying unit: %w", err)
+	}
+	return u, nil
+}
+
+// FindAll returns the units at a branch, or at every branch in scope, by
+// name.
+func (r *HACCPUnitRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*haccp.Unit, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{branchID})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+haccpUnitColumns+" FROM haccp_units WHERE ($1::uuid IS NULL OR branch_id = $1) AND "+cond+
+			" ORDER BY LOWER(name), branch_id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying units: %w", err)
+	}
+	defer rows.Close()
+
+	var units []*haccp.Unit
+	for rows.Next() {
+		u, err := scanHACCPUnit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning unit: %w", err)
+		}
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+func scanHACCPUnit(row pgx.Row) (*haccp.Unit, error) {
+	var id, branchID uuid.UUID
+	var name, kind, sensorID string
+	var minCelsius, maxCelsius float64
+	var checkEveryMinutes int
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &branchID, &name, &kind, &minCelsius, &maxCelsius, &sensorID, &checkEveryMinutes,
+		&active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return haccp.ReconstructUnit(id, branchID, name, haccp.Kind(kind), minCelsius, maxCelsius, sensorID,
+		time.Duration(checkEveryMinutes)*time.Minute, active, createdAt, updatedAt), nil
+}
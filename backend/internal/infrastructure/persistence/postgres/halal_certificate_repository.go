@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// HalalCertificateRepository implements traceability.CertificateRepository using PostgreSQL.
+type HalalCertificateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHalalCertificateRepository creates a new HalalCertificateRepository.
+func NewHalalCertificateRepository(pool *pgxpool.Pool) *HalalCertificateRepository {
+	return &HalalCertificateRepository{pool: pool}
+}
+
+const halalCertificateColumns = "id, issuer, number, slaughterhouse, valid_from, valid_until, document_url, created_at"
+
+// Save inserts a new certificate.
+func (r *HalalCertificateRepository) Save(ctx context.Context, c *traceability.Certificate) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO halal_certificates (`+halalCertificateColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		c.ID(), c.Issuer(), c.Number(), c.Slaughterhouse(), c.ValidFrom(), c.ValidUntil(), c.DocumentURL(), c.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return traceability.ErrDuplicateCertificate
+		}
+		return fmt.Errorf("inserting halal certificate: %w", err)
+	}
+	return nil
+}
+
+// FindAll returns certificates, soonest to expire first, optionally only
+// those lapsing on or before expiringBy.
+func (r *HalalCertificateRepository) FindAll(ctx context.Context, expiringBy *time.Time) ([]*traceability.Certificate, error) {
+	query := "SELECT " + halalCertificateColumns + " FROM halal_certificates"
+	var args []any
+	if expiringBy != nil {
+		args = append(args, *expiringBy)
+		query += " WHERE valid_until <= $1"
+	}
+	query += " ORDER BY valid_until, number"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying halal certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certificates []*traceability.Certificate
+	for rows.Next() {
+		c, err := scanHalalCertificate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning halal certificate: %w", err)
+		}
+		certificates = append(certificates, c)
+	}
+	return certificates, rows.Err()
+}
+
+func scanHalalCertificate(row pgx.Row) (*traceability.Certificate, error) {
+	var id uuid.UUID
+	var issuer, number, slaughterhouse, documentURL string
+	var validFrom, validUntil, createdAt time.Time
+
+	err := row.Scan(&id, &issuer, &number, &slaughterhouse, &validFrom, &validUntil, &documentURL, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return traceability.ReconstructCertificate(id, issuer, number, slaughterhouse, validFrom, validUntil, documentURL, createdAt), nil
+}
@@ -0,0 +1,360 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// InventoryStockRepository implements inventory.StockRepository using PostgreSQL.
+type InventoryStockRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewInventoryStockRepository creates a new InventoryStockRepository.
+func NewInventoryStockRepository(pool *pgxpool.Pool) *InventoryStockRepository {
+	return &InventoryStockRepository{pool: pool}
+}
+
+const (
+	inventoryLotColumns  = "id, product_id, branch_id, code, received_grams, on_hand_grams, expires_on, cost_per_kg_cents, markdown_bp, received_at"
+	stockMovementColumns = "id, lot_id, product_id, branch_id, type, delta_grams, reason, reference_id, actor_id, occurred_at"
+)
+
+// activeStockReservationCondition matches reservations that still hold back stock.
+const activeStockReservationCondition = "(sr.status = 'confirmed' OR (sr.status = 'held' AND sr.expires_at > $1))"
+
+// ReceiveLot stores a new lot together with its received movement.
+func (r *InventoryStockRepository) ReceiveLot(ctx context.Context, lot *inventory.Lot, received *inventory.Movement) error {
+	if err := branch.ScopeFrom(ctx).Check(lot.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := insertInventoryLot(ctx, tx, lot); err != nil {
+		return err
+	}
+	if err := insertStockMovement(ctx, tx, received); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing lot: %w", err)
+	}
+	return nil
+}
+
+// FindLotByID finds a lot in scope by ID.
+func (r *InventoryStockRepository) FindLotByID(ctx context.Context, id uuid.UUID) (*inventory.Lot, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	row := r.pool.QueryRow(ctx, "SELECT "+inventoryLotColumns+" FROM inventory_lots WHERE id = $1 AND "+cond, args...)
+
+	lot, err := scanInventoryLot(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, inventory.ErrLotNotFound
+		}
+		return nil, fmt.Errorf("querying lot by id: %w", err)
+	}
+	return lot, nil
+}
+
+// FindLots returns a product's lots at a branch, oldest first.
+func (r *InventoryStockRepository) FindLots(ctx context.Context, productID, branchID uuid.UUID) ([]*inventory.Lot, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{productID, branchID})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+inventoryLotColumns+" FROM inventory_lots WHERE product_id = $1 AND branch_id = $2 AND "+cond+
+			" ORDER BY received_at, id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []*inventory.Lot
+	for rows.Next() {
+		lot, err := scanInventoryLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	return lots, rows.Err()
+}
+
+// RecordMovements appends movements to the ledger and applies them to their
+// lots in one transaction. Every movement must be at a branch in scope.
+func (r *InventoryStockRepository) RecordMovements(ctx context.Context, movements []*inventory.Movement) error {
+	scope := branch.ScopeFrom(ctx)
+	for _, m := range movements {
+		if err := scope.Check(m.BranchID()); err != nil {
+			return err
+		}
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, m := range movements {
+		if err := applyStockMovement(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing movements: %w", err)
+	}
+	return nil
+}
+
+// TakeStock locks the counted lots, in ID order so concurrent stock takes
+// cannot deadlock, and records the adjustment each count calls for.
+func (r *InventoryStockRepository) TakeStock(
+	ctx context.Context,
+	branchID uuid.UUID,
+	counts []inventory.Count,
+	actorID *uuid.UUID,
+	now time.Time,
+) ([]*inventory.Movement, error) {
+	if err := branch.ScopeFrom(ctx).Check(branchID); err != nil {
+		return nil, err
+	}
+	sorted := append([]inventory.Count{}, counts...)
+	slices.SortFunc(sorted, func(a, b inventory.Count) int { return strings.Compare(a.LotID.String(), b.LotID.String()) })
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var adjustments []*inventory.Movement
+	for _, c := range sorted {
+		lot, err := scanInventoryLot(tx.QueryRow(ctx,
+			"SELECT "+inventoryLotColumns+" FROM inventory_lots WHERE id = $1 FOR UPDATE", c.LotID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, inventory.ErrLotNotFound
+			}
+			return nil, fmt.Errorf("locking lot: %w", err)
+		}
+		if lot.BranchID() != branchID {
+			return nil, inventory.ErrLotBranchMismatch
+		}
+
+		m, err := lot.Count(c.CountedGrams, actorID, now)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			continue
+		}
+		if err := applyStockMovement(ctx, tx, m); err != nil {
+			return nil, err
+		}
+		adjustments = append(adjustments, m)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing stock take: %w", err)
+	}
+	return adjustments, nil
+}
+
+// FindMovements returns ledger entries in scope, newest first.
+func (r *InventoryStockRepository) FindMovements(ctx context.Context, filter inventory.MovementFilter) ([]*inventory.Movement, error) {
+	cond, args := branchScope(ctx, "branch_id", nil)
+	query := "SELECT " + stockMovementColumns + " FROM stock_movements WHERE " + cond
+	if filter.LotID != nil {
+		args = append(args, *filter.LotID)
+		query += " AND lot_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.ProductID != nil {
+		args = append(args, *filter.ProductID)
+		query += " AND product_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.BranchID != nil {
+		args = append(args, *filter.BranchID)
+		query += " AND branch_id = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY occurred_at DESC, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []*inventory.Movement
+	for rows.Next() {
+		m, err := scanStockMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning stock movement: %w", err)
+		}
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
+
+// StockLevels returns per-product stock for a branch, or for all branches
+// in scope when branchID is nil.
+func (r *InventoryStockRepository) StockLevels(ctx context.Context, branchID *uuid.UUID, now time.Time) ([]inventory.StockLevel, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{now, branchID})
+	rows, err := r.pool.Query(ctx,
+		`WITH on_hand AS (
+		     SELECT product_id, branch_id, SUM(on_hand_grams)::BIGINT AS grams
+		     FROM inventory_lots
+		     WHERE ($2::UUID IS NULL OR branch_id = $2) AND `+cond+`
+		     GROUP BY product_id, branch_id
+		 ), reserved AS (
+		     SELECT srl.product_id, sr.branch_id, SUM(srl.grams)::BIGINT AS grams
+		     FROM stock_reservation_lines srl
+		     JOIN stock_reservations sr ON sr.id = srl.reservation_id
+		     WHERE `+activeStockReservationCondition+` AND ($2::UUID IS NULL OR sr.branch_id = $2)
+		     GROUP BY srl.product_id, sr.branch_id
+		 )
+		 SELECT o.product_id, o.branch_id, o.grams, COALESCE(rs.grams, 0), COALESCE(s.low_stock_threshold_grams, 0)
+		 FROM on_hand o
+		 LEFT JOIN reserved rs ON rs.product_id = o.product_id AND rs.branch_id = o.branch_id
+		 LEFT JOIN inventory_settings s ON s.product_id = o.product_id
+		 ORDER BY o.branch_id, o.product_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock levels: %w", err)
+	}
+	defer rows.Close()
+
+	var levels []inventory.StockLevel
+	for rows.Next() {
+		var l inventory.StockLevel
+		if err := rows.Scan(&l.ProductID, &l.BranchID, &l.OnHandGrams, &l.ReservedGrams, &l.LowStockThresholdGrams); err != nil {
+			return nil, fmt.Errorf("scanning stock level: %w", err)
+		}
+		levels = append(levels, l)
+	}
+	return levels, rows.Err()
+}
+
+// SetLowStockThreshold inserts or updates a product's low-stock threshold.
+func (r *InventoryStockRepository) SetLowStockThreshold(ctx context.Context, productID uuid.UUID, grams int64) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO inventory_settings (product_id, low_stock_threshold_grams, updated_at)
+		 VALUES ($1, $2, NOW())
+		 ON CONFLICT (product_id) DO UPDATE SET
+		     low_stock_threshold_grams = EXCLUDED.low_stock_threshold_grams, updated_at = EXCLUDED.updated_at`,
+		productID, grams,
+	)
+	if err != nil {
+		return fmt.Errorf("saving low-stock threshold: %w", err)
+	}
+	return nil
+}
+
+// applyStockMovement adjusts a lot's on-hand weight and appends the movement.
+// The guarded UPDATE makes the check and the write atomic, so concurrent
+// movements cannot take a lot below zero.
+func applyStockMovement(ctx context.Context, tx pgx.Tx, m *inventory.Movement) error {
+	result, err := tx.Exec(ctx,
+		`UPDATE inventory_lots SET on_hand_grams = on_hand_grams + $2
+		 WHERE id = $1 AND on_hand_grams + $2 >= 0`,
+		m.LotID(), m.DeltaGrams(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating lot stock: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM inventory_lots WHERE id = $1)", m.LotID()).Scan(&exists); err != nil {
+			return fmt.Errorf("checking lot: %w", err)
+		}
+		if !exists {
+			return inventory.ErrLotNotFound
+		}
+		return inventory.ErrInsufficientStock
+	}
+
+	return insertStockMovement(ctx, tx, m)
+}
+
+func insertInventoryLot(ctx context.Context, tx pgx.Tx, lot *inventory.Lot) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO inventory_lots (`+inventoryLotColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		lot.ID(), lot.ProductID(), lot.BranchID(), lot.Code(), lot.ReceivedGrams(), lot.OnHandGrams(),
+		lot.ExpiresOn(), lot.CostPerKgCents(), lot.MarkdownBP(), lot.ReceivedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting lot: %w", err)
+	}
+	return nil
+}
+
+func insertStockMovement(ctx context.Context, tx pgx.Tx, m *inventory.Movement) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO stock_movements (`+stockMovementColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		m.ID(), m.LotID(), m.ProductID(), m.BranchID(), string(m.Type()), m.DeltaGrams(), m.Reason(),
+		m.ReferenceID(), m.ActorID(), m.OccurredAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting stock movement: %w", err)
+	}
+	return nil
+}
+
+func scanInventoryLot(row pgx.Row) (*inventory.Lot, error) {
+	var id, productID, branchID uuid.UUID
+	var code string
+	var receivedGrams, onHandGrams, costPerKgCents int64
+	var markdownBP int
+	var expiresOn *time.Time
+	var receivedAt time.Time
+
+	err := row.Scan(&id, &productID, &branchID, &code, &receivedGrams, &onHandGrams, &expiresOn,
+		&costPerKgCents, &markdownBP, &receivedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.ReconstructLot(id, productID, branchID, code, receivedGrams, onHandGrams, expiresOn,
+		costPerKgCents, markdownBP, receivedAt), nil
+}
+
+func scanStockMovement(row pgx.Row) (*inventory.Movement, error) {
+	var id, lotID, productID, branchID uuid.UUID
+	var kind, reason string
+	var deltaGrams int64
+	var referenceID, actorID *uuid.UUID
+	var occurredAt time.Time
+
+	err := row.Scan(&id, &lotID, &productID, &branchID, &kind, &deltaGrams, &reason, &referenceID, &actorID, &occurredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.ReconstructMovement(id, lotID, productID, branchID, inventory.MovementType(kind),
+		deltaGrams, reason, referenceID, actorID, occurredAt), nil
+}
@@ -0,0 +1,158 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedLot receives a lot of grams for a product at a branch.
+func seedLot(t *testing.T, repo *pgstore.InventoryStockRepository, productID, branchID uuid.UUID, grams int64, expiresOn *time.Time) *inventory.Lot {
+	t.Helper()
+	lot, received, err := inventory.ReceiveLot(uuid.New(), productID, branchID, "LOT-"+uuid.NewString()[:8],
+		grams, expiresOn, nil, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.ReceiveLot(context.Background(), lot, received))
+	return lot
+}
+
+func TestIntegrationInventoryStockRepository_Ledger(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	productID, branchID := uuid.New(), uuid.New()
+	expiresOn := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	lot := seedLot(t, repo, productID, branchID, 10000, &expiresOn)
+
+	t.Run("receiving a lot records a received movement", func(t *testing.T) {
+		found, err := repo.FindLotByID(ctx, lot.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), found.OnHandGrams())
+		require.NotNil(t, found.ExpiresOn())
+		assert.Equal(t, "2026-11-02", found.ExpiresOn().Format(time.DateOnly))
+
+		lotID := lot.ID()
+		movements, err := repo.FindMovements(ctx, inventory.MovementFilter{LotID: &lotID})
+		require.NoError(t, err)
+		require.Len(t, movements, 1)
+		assert.Equal(t, inventory.MovementReceived, movements[0].Type())
+	})
+
+	t.Run("movements update on-hand weight", func(t *testing.T) {
+		wasted, err := inventory.NewMovement(uuid.New(), lot, inventory.MovementWasted, -1500, "trim", nil, nil, time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RecordMovements(ctx, []*inventory.Movement{wasted}))
+
+		found, err := repo.FindLotByID(ctx, lot.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(8500), found.OnHandGrams())
+	})
+
+	t.Run("movements that would go negative are rejected atomically", func(t *testing.T) {
+		cut, _ := inventory.NewMovement(uuid.New(), lot, inventory.MovementCut, -500, "", nil, nil, time.Now())
+		tooMuch, _ := inventory.NewMovement(uuid.New(), lot, inventory.MovementWasted, -9000, "", nil, nil, time.Now())
+
+		err := repo.RecordMovements(ctx, []*inventory.Movement{cut, tooMuch})
+		assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+
+		found, err := repo.FindLotByID(ctx, lot.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(8500), found.OnHandGrams())
+	})
+
+	t.Run("the ledger cannot be edited", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "DELETE FROM stock_movements WHERE lot_id = $1", lot.ID())
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown lot returns ErrLotNotFound", func(t *testing.T) {
+		_, err := repo.FindLotByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, inventory.ErrLotNotFound)
+	})
+}
+
+func TestIntegrationInventoryStockRepository_TakeStock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	branchID := uuid.New()
+	lot := seedLot(t, repo, uuid.New(), branchID, 6000, nil)
+	matching := seedLot(t, repo, uuid.New(), branchID, 4000, nil)
+
+	t.Run("adjusts against stock as it stands when the count is recorded", func(t *testing.T) {
+		// A sale goes through after the count was made but before it is saved.
+		sold, err := inventory.NewMovement(uuid.New(), lot, inventory.MovementSold, -1000, "", nil, nil, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.RecordMovements(ctx, []*inventory.Movement{sold}))
+
+		adjustments, err := repo.TakeStock(ctx, branchID, []inventory.Count{
+			{LotID: lot.ID(), CountedGrams: 5200},
+			{LotID: matching.ID(), CountedGrams: 4000},
+		}, nil, time.Now())
+
+		require.NoError(t, err)
+		require.Len(t, adjustments, 1)
+		assert.Equal(t, int64(200), adjustments[0].DeltaGrams())
+		found, err := repo.FindLotByID(ctx, lot.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(5200), found.OnHandGrams())
+	})
+
+	t.Run("a lot at another branch is rejected", func(t *testing.T) {
+		_, err := repo.TakeStock(ctx, uuid.New(), []inventory.Count{{LotID: lot.ID(), CountedGrams: 0}}, nil, time.Now())
+		assert.ErrorIs(t, err, inventory.ErrLotBranchMismatch)
+	})
+
+	t.Run("an unknown lot returns ErrLotNotFound", func(t *testing.T) {
+		_, err := repo.TakeStock(ctx, branchID, []inventory.Count{{LotID: uuid.New(), CountedGrams: 0}}, nil, time.Now())
+		assert.ErrorIs(t, err, inventory.ErrLotNotFound)
+	})
+}
+
+func TestIntegrationInventoryStockRepository_StockLevels(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	productID, branchID, otherBranch := uuid.New(), uuid.New(), uuid.New()
+	seedLot(t, repo, productID, branchID, 3000, nil)
+	seedLot(t, repo, productID, branchID, 2000, nil)
+	seedLot(t, repo, productID, otherBranch, 9000, nil)
+	require.NoError(t, repo.SetLowStockThreshold(ctx, productID, 6000))
+
+	levels, err := repo.StockLevels(ctx, &branchID, time.Now())
+
+	require.NoError(t, err)
+	require.Len(t, levels, 1)
+	assert.Equal(t, int64(5000), levels[0].OnHandGrams)
+	assert.Equal(t, int64(6000), levels[0].LowStockThresholdGrams)
+	assert.True(t, levels[0].IsLow())
+
+	all, err := repo.StockLevels(ctx, nil, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// InventoryWasteRepository implements inventory.WasteRepository using
+// PostgreSQL.
+type InventoryWasteRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewInventoryWasteRepository creates a new InventoryWasteRepository.
+func NewInventoryWasteRepository(pool *pgxpool.Pool) *InventoryWasteRepository {
+	return &InventoryWasteRepository{pool: pool}
+}
+
+const expiryProposalColumns = "p.id, p.lot_id, l.product_id, l.branch_id, p.kind, p.use_by, p.on_hand_grams, p.markdown_bp, " +
+	"p.status, p.wasted_grams, p.created_at, p.decided_at, p.decided_by"
+
+// ExpiringLots returns lots in scope with stock on hand whose use-by date
+// is before the given day, soonest first.
+func (r *InventoryWasteRepository) ExpiringLots(ctx context.Context, before time.Time) ([]*inventory.Lot, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{before})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+inventoryLotColumns+" FROM inventory_lots WHERE on_hand_grams > 0 AND expires_on < $1::DATE AND "+cond+
+			" ORDER BY expires_on, received_at, id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying expiring lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []*inventory.Lot
+	for rows.Next() {
+		lot, err := scanInventoryLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	return lots, rows.Err()
+}
+
+// UpdateLot stores a lot's use-by date, cost and markdown.
+func (r *InventoryWasteRepository) UpdateLot(ctx context.Context, lot *inventory.Lot) error {
+	if err := branch.ScopeFrom(ctx).Check(lot.BranchID()); err != nil {
+		return err
+	}
+	result, err := r.pool.Exec(ctx,
+		"UPDATE inventory_lots SET expires_on = $2, cost_per_kg_cents = $3, markdown_bp = $4 WHERE id = $1",
+		lot.ID(), lot.ExpiresOn(), lot.CostPerKgCents(), lot.MarkdownBP(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating lot: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return inventory.ErrLotNotFound
+	}
+	return nil
+}
+
+// SaveProposal stores a new proposal unless its lot already has one of the
+// same kind.
+func (r *InventoryWasteRepository) SaveProposal(ctx context.Context, p *inventory.ExpiryProposal) (bool, error) {
+	if err := branch.ScopeFrom(ctx).Check(p.BranchID()); err != nil {
+		return false, err
+	}
+	result, err := r.pool.Exec(ctx,
+		`INSERT INTO expiry_proposals (id, lot_id, kind, use_by, on_hand_grams, markdown_bp, status, wasted_grams, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (lot_id, kind) DO NOTHING`,
+		p.ID(), p.LotID(), string(p.Kind()), p.UseBy(), p.OnHandGrams(), p.MarkdownBP(), string(p.Status()),
+		p.WastedGrams(), p.CreatedAt(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("inserting expiry proposal: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// FindProposalByID finds a proposal for a lot in scope by ID.
+func (r *InventoryWasteRepository) FindProposalByID(ctx context.Context, id uuid.UUID) (*inventory.ExpiryProposal, error) {
+	cond, args := branchScope(ctx, "l.branch_id", []any{id})
+	p, err := scanExpiryProposal(r.pool.QueryRow(ctx,
+		"SELECT "+expiryProposalColumns+" FROM expiry_proposals p JOIN inventory_lots l ON l.id = p.lot_id WHERE p.id = $1 AND "+cond,
+		args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, inventory.ErrProposalNotFound
+		}
+		return nil, fmt.Errorf("querying expiry proposal: %w", err)
+	}
+	return p, nil
+}
+
+// FindProposals returns proposals for lots in scope, newest first.
+func (r *InventoryWasteRepository) FindProposals(ctx context.Context, filter inventory.ProposalFilter) ([]*inventory.ExpiryProposal, error) {
+	cond, args := branchScope(ctx, "l.branch_id", nil)
+	query := "SELECT " + expiryProposalColumns + " FROM expiry_proposals p JOIN inventory_lots l ON l.id = p.lot_id WHERE " + cond
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND p.status = $" + strconv.Itoa(len(args))
+	}
+	if filter.BranchID != nil {
+		args = append(args, *filter.BranchID)
+		query += " AND l.branch_id = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY p.created_at DESC, p.use_by, p.id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying expiry proposals: %w", err)
+	}
+	defer rows.Close()
+
+	var proposals []*inventory.ExpiryProposal
+	for rows.Next() {
+		p, err := scanExpiryProposal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning expiry proposal: %w", err)
+		}
+		proposals = append(proposals, p)
+	}
+	return proposals, rows.Err()
+}
+
+// DecideProposal stores an accepted or dismissed proposal with the lot's
+// markdown and the movement that wasted it, if any, in one transaction.
+func (r *InventoryWasteRepository) DecideProposal(ctx context.Context, p *inventory.ExpiryProposal, lot *inventory.Lot, wasted *inventory.Movement) error {
+	if err := branch.ScopeFrom(ctx).Check(lot.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		`UPDATE expiry_proposals SET status = $2, wasted_grams = $3, decided_at = $4, decided_by = $5
+		 WHERE id = $1 AND status = $6`,
+		p.ID(), string(p.Status()), p.WastedGrams(), p.DecidedAt(), p.DecidedBy(), string(inventory.ProposalProposed),
+	)
+	if err != nil {
+		return fmt.Errorf("updating expiry proposal: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return inventory.ErrProposalDecided
+	}
+	if _, err := tx.Exec(ctx, "UPDATE inventory_lots SET markdown_bp = $2 WHERE id = $1", lot.ID(), lot.MarkdownBP()); err != nil {
+		return fmt.Errorf("updating lot markdown: %w", err)
+	}
+	if wasted != nil {
+		if err := applyStockMovement(ctx, tx, wasted); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// LedgerTotals sums ledger movements at branches in scope by product,
+// branch, species, type and reason. A lot's species is that of the carcass
+// it was cut from, falling back to the species its product is classified
+// under.
+func (r *InventoryWasteRepository) LedgerTotals(ctx context.Context, filter inventory.LedgerFilter) ([]inventory.LedgerTotal, error) {
+	cond, args := branchScope(ctx, "m.branch_id", []any{filter.From, filter.To, filter.BranchID})
+	rows, err := r.pool.Query(ctx,
+		`SELECT m.product_id, m.branch_id, COALESCE(c.species, pp.species, '') AS species, m.type, m.reason,
+		     SUM(m.delta_grams)::BIGINT, ROUND(SUM(m.delta_grams * l.cost_per_kg_cents) / 1000.0)::BIGINT
+		 FROM stock_movements m
+		 JOIN inventory_lots l ON l.id = m.lot_id
+		 LEFT JOIN lot_origins lo ON lo.lot_id = m.lot_id
+		 LEFT JOIN carcasses c ON c.id = lo.carcass_id
+		 LEFT JOIN promotion_products pp ON pp.product_id = m.product_id AND pp.species <> ''
+		 WHERE m.occurred_at >= $1 AND m.occurred_at < $2 AND ($3::UUID IS NULL OR m.branch_id = $3) AND `+cond+`
+		 GROUP BY 1, 2, 3, 4, 5
+		 ORDER BY 1, 2, 3, 4, 5`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying ledger totals: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []inventory.LedgerTotal
+	for rows.Next() {
+		var t inventory.LedgerTotal
+		var kind string
+		if err := rows.Scan(&t.ProductID, &t.BranchID, &t.Species, &kind, &t.Reason, &t.Grams, &t.CostCents); err != nil {
+			return nil, fmt.Errorf("scanning ledger total: %w", err)
+		}
+		t.Type = inventory.MovementType(kind)
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+func scanExpiryProposal(row pgx.Row) (*inventory.ExpiryProposal, error) {
+	var id, lotID, productID, branchID uuid.UUID
+	var kind, status string
+	var useBy, createdAt time.Time
+	var onHandGrams, wastedGrams int64
+	var markdownBP int
+	var decidedAt *time.Time
+	var decidedBy *uuid.UUID
+
+	err := row.Scan(&id, &lotID, &productID, &branchID, &kind, &useBy, &onHandGrams, &markdownBP,
+		&status, &wastedGrams, &createdAt, &decidedAt, &decidedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.ReconstructExpiryProposal(id, lotID, productID, branchID, inventory.ProposalKind(kind), useBy,
+		onHandGrams, markdownBP, inventory.ProposalStatus(status), wastedGrams, createdAt, decidedAt, decidedBy), nil
+}
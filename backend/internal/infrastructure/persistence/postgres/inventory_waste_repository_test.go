@@ -0,0 +1,117 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationInventoryWasteRepository_ExpiryAndShrinkage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	stock := pgstore.NewInventoryStockRepository(pool)
+	repo := pgstore.NewInventoryWasteRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	adminID := uuid.New()
+	_, err := pool.Exec(ctx,
+		"INSERT INTO admins (id, email, password_hash, full_name) VALUES ($1, 'waste@butchery.com', 'hash', 'Manager')",
+		adminID)
+	require.NoError(t, err)
+
+	productID, branchID := uuid.New(), uuid.New()
+	yesterday := time.Date(2026, 11, 9, 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(2026, 11, 17, 0, 0, 0, 0, time.UTC)
+	expired := seedLot(t, stock, productID, branchID, 4000, &yesterday)
+	fresh := seedLot(t, stock, productID, branchID, 6000, &nextWeek)
+
+	t.Run("a lot's cost and markdown are stored", func(t *testing.T) {
+		require.NoError(t, expired.SetCost(1200))
+		require.NoError(t, repo.UpdateLot(ctx, expired))
+
+		found, err := stock.FindLotByID(ctx, expired.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1200), found.CostPerKgCents())
+		assert.Equal(t, 0, found.MarkdownBP())
+	})
+
+	t.Run("only lots with stock before the horizon are expiring", func(t *testing.T) {
+		lots, err := repo.ExpiringLots(ctx, time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, lots, 1)
+		assert.Equal(t, expired.ID(), lots[0].ID())
+		assert.NotEqual(t, fresh.ID(), lots[0].ID())
+	})
+
+	policy := inventory.ExpiryPolicy{MarkdownDays: 1, MarkdownBP: 3000}
+	today := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+	proposal := policy.Propose(uuid.New(), expired, today, time.Now())
+	require.NotNil(t, proposal)
+
+	t.Run("a lot gets at most one proposal of each kind", func(t *testing.T) {
+		saved, err := repo.SaveProposal(ctx, proposal)
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		saved, err = repo.SaveProposal(ctx, policy.Propose(uuid.New(), expired, today, time.Now()))
+		require.NoError(t, err)
+		assert.False(t, saved)
+
+		status := inventory.ProposalProposed
+		proposals, err := repo.FindProposals(ctx, inventory.ProposalFilter{Status: &status})
+		require.NoError(t, err)
+		require.Len(t, proposals, 1)
+		assert.Equal(t, inventory.ProposalWaste, proposals[0].Kind())
+		assert.Equal(t, branchID, proposals[0].BranchID())
+	})
+
+	t.Run("accepting a write-off wastes the lot once", func(t *testing.T) {
+		wasted, err := proposal.Accept(expired, adminID, time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DecideProposal(ctx, proposal, expired, wasted))
+		assert.ErrorIs(t, repo.DecideProposal(ctx, proposal, expired, wasted), inventory.ErrProposalDecided)
+
+		found, err := stock.FindLotByID(ctx, expired.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), found.OnHandGrams())
+
+		decided, err := repo.FindProposalByID(ctx, proposal.ID())
+		require.NoError(t, err)
+		assert.Equal(t, inventory.ProposalAccepted, decided.Status())
+		assert.Equal(t, int64(4000), decided.WastedGrams())
+		assert.Equal(t, &adminID, decided.DecidedBy())
+	})
+
+	t.Run("ledger totals are valued at each lot's cost", func(t *testing.T) {
+		totals, err := repo.LedgerTotals(ctx, inventory.LedgerFilter{
+			From: time.Now().Add(-time.Hour),
+			To:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		report := inventory.NewShrinkageReport(inventory.ShrinkageByProduct, totals)
+		require.Len(t, report.Lines, 1)
+		line := report.Lines[0]
+		assert.Equal(t, int64(10000), line.ReceivedGrams)
+		assert.Equal(t, int64(4000), line.WastedGrams)
+		assert.Equal(t, map[string]int64{"expired": 4000}, line.WastedByReason)
+		assert.Equal(t, int64(4800), line.WastedCents)
+		assert.Equal(t, int64(6000), line.NetGrams())
+	})
+
+	t.Run("an unknown proposal is not found", func(t *testing.T) {
+		_, err := repo.FindProposalByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, inventory.ErrProposalNotFound)
+	})
+}
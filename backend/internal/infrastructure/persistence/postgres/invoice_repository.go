@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+)
+
+// InvoiceRepository implements invoice.Repository using PostgreSQL.
+type InvoiceRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewInvoiceRepository creates a new InvoiceRepository.
+func NewInvoiceRepository(pool *pgxpool.Pool) *InvoiceRepository {
+	return &InvoiceRepository{pool: pool}
+}
+
+const invoiceColumns = "id, kind, number, branch_id, fiscal_year, sequence, order_id, customer_id, refund_id, " +
+	"credited_invoice_id, credited_number, seller, buyer, lines, currency, issued_at, sha256"
+
+// partyJSON and lineJSON are how parties and lines are stored in the
+// seller, buyer and lines columns.
+type partyJSON struct {
+	Name          string   `json:"name"`
+	Address       []string `json:"address,omitempty"`
+	VATNumber     string   `json:"vat_number,omitempty"`
+	CompanyNumber string   `json:"company_number,omitempty"`
+	Email         string   `json:"email,omitempty"`
+}
+
+type lineJSON struct {
+	Description     string `json:"description"`
+	Grams           int64  `json:"grams"`
+	PricePerKgCents int64  `json:"price_per_kg_cents"`
+	VATRateBP       int    `json:"vat_rate_bp"`
+	NetCents        int64  `json:"net_cents"`
+	VATCents        int64  `json:"vat_cents"`
+	GrossCents      int64  `json:"gross_cents"`
+}
+
+// Issue numbers, renders and inserts a document in one transaction. The
+// series row stays locked until the document is committed, so concurrent
+// issues in one series queue up, and a failed issue gives its number back.
+func (r *InvoiceRepository) Issue(ctx context.Context, d *invoice.Document, renderer invoice.Renderer) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var seq int64
+	err = tx.QueryRow(ctx,
+		`INSERT INTO invoice_sequences (branch_id, fiscal_year, kind, last_number) VALUES ($1, $2, $3, 1)
+		 ON CONFLICT (branch_id, fiscal_year, kind) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		 RETURNING last_number`,
+		d.BranchID(), d.FiscalYear(), string(d.Kind()),
+	).Scan(&seq)
+	if err != nil {
+		return fmt.Errorf("taking invoice number: %w", err)
+	}
+	d.AssignNumber(seq)
+
+	pdf, err := renderer.Render(d)
+	if err != nil {
+		return fmt.Errorf("rendering %s: %w", d.Number(), err)
+	}
+	d.AttachPDF(pdf)
+
+	seller, err := json.Marshal(partyJSON(d.Seller()))
+	if err != nil {
+		return fmt.Errorf("encoding seller: %w", err)
+	}
+	buyer, err := json.Marshal(partyJSON(d.Buyer()))
+	if err != nil {
+		return fmt.Errorf("encoding buyer: %w", err)
+	}
+	lines := make([]lineJSON, 0, len(d.Lines()))
+	for _, l := range d.Lines() {
+		lines = append(lines, lineJSON(l))
+	}
+	linesJSON, err := json.Marshal(lines)
+	if err != nil {
+		return fmt.Errorf("encoding invoice lines: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO invoices (`+invoiceColumns+`, net_cents, vat_cents, gross_cents, pdf)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`,
+		d.ID(), string(d.Kind()), d.Number(), d.BranchID(), d.FiscalYear(), d.Sequence(), d.OrderID(), d.CustomerID(),
+		d.RefundID(), d.CreditedInvoiceID(), d.CreditedNumber(), seller, buyer, linesJSON, d.Currency(), d.IssuedAt(),
+		d.Checksum(), d.NetCents(), d.VATCents(), d.GrossCents(), d.PDF(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+			(pgErr.ConstraintName == "idx_invoices_order_invoice" || pgErr.ConstraintName == "idx_invoices_refund") {
+			return invoice.ErrAlreadyIssued
+		}
+		return fmt.Errorf("inserting invoice: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing invoice: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a document by ID together with its PDF.
+func (r *InvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*invoice.Document, error) {
+	var pdf []byte
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	d, err := scanInvoice(r.pool.QueryRow(ctx, "SELECT "+invoiceColumns+", pdf FROM invoices WHERE id = $1 AND "+cond, args...), &pdf)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, invoice.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("querying invoice by id: %w", err)
+	}
+	return d, nil
+}
+
+// FindInvoiceByOrderID finds the invoice issued for an order.
+func (r *InvoiceRepository) FindInvoiceByOrderID(ctx context.Context, orderID uuid.UUID) (*invoice.Document, error) {
+	return r.findOne(ctx, "SELECT "+invoiceColumns+" FROM invoices WHERE order_id = $1 AND kind = 'invoice' AND %s", orderID)
+}
+
+// FindByRefundID finds the credit note issued for a refund.
+func (r *InvoiceRepository) FindByRefundID(ctx context.Context, refundID uuid.UUID) (*invoice.Document, error) {
+	return r.findOne(ctx, "SELECT "+invoiceColumns+" FROM invoices WHERE refund_id = $1 AND %s", refundID)
+}
+
+// ListByOrderID returns an order's documents, oldest first.
+func (r *InvoiceRepository) ListByOrderID(ctx context.Context, orderID uuid.UUID) ([]*invoice.Document, error) {
+	return r.list(ctx, "SELECT "+invoiceColumns+" FROM invoices WHERE order_id = $1 AND %s ORDER BY issued_at, number", orderID)
+}
+
+// ListByCustomerID returns a customer's documents, newest first.
+func (r *InvoiceRepository) ListByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*invoice.Document, error) {
+	return r.list(ctx,
+		"SELECT "+invoiceColumns+" FROM invoices WHERE customer_id = $1 AND %s ORDER BY issued_at DESC, number DESC",
+		customerID)
+}
+
+// findOne runs a query for one document in scope. The query takes arg as $1
+// and has a %s verb where the scope condition goes.
+func (r *InvoiceRepository) findOne(ctx context.Context, query string, arg uuid.UUID) (*invoice.Document, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{arg})
+	d, err := scanInvoice(r.pool.QueryRow(ctx, fmt.Sprintf(query, cond), args...), nil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, invoice.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("querying invoice: %w", err)
+	}
+	return d, nil
+}
+
+// list runs a query for the documents in scope, written as for findOne.
+func (r *InvoiceRepository) list(ctx context.Context, query string, arg uuid.UUID) ([]*invoice.Document, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{arg})
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(query, cond), args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*invoice.Document
+	for rows.Next() {
+		d, err := scanInvoice(rows, nil)
+		if err != nil {
+			return nil, fmt.Errorf("scanning invoice: %w", err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
+
+// scanInvoice scans a row of invoiceColumns, followed by the PDF if pdf is
+// not nil.
+func scanInvoice(row pgx.Row, pdf *[]byte) (*invoice.Document, error) {
+	var id, branchID, orderID, customerID uuid.UUID
+	var kind, number, creditedNumber, currency, checksum string
+	var fiscalYear int
+	var sequence int64
+	var refundID, creditedInvoiceID *uuid.UUID
+	var seller, buyer, lines []byte
+	var issuedAt time.Time
+	dest := []any{&id, &kind, &number, &branchID, &fiscalYear, &sequence, &orderID, &customerID, &refundID,
+		&creditedInvoiceID, &creditedNumber, &seller, &buyer, &lines, &currency, &issuedAt, &checksum}
+	if pdf != nil {
+		dest = append(dest, pdf)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var s, b partyJSON
+	if err := json.Unmarshal(seller, &s); err != nil {
+		return nil, fmt.Errorf("decoding seller: %w", err)
+	}
+	if err := json.Unmarshal(buyer, &b); err != nil {
+		return nil, fmt.Errorf("decoding buyer: %w", err)
+	}
+	var ls []lineJSON
+	if err := json.Unmarshal(lines, &ls); err != nil {
+		return nil, fmt.Errorf("decoding invoice lines: %w", err)
+	}
+	docLines := make([]invoice.Line, 0, len(ls))
+	for _, l := range ls {
+		docLines = append(docLines, invoice.Line(l))
+	}
+
+	var body []byte
+	if pdf != nil {
+		body = *pdf
+	}
+	return invoice.ReconstructDocument(id, invoice.Kind(kind), number, branchID, fiscalYear, sequence, orderID,
+		customerID, refundID, creditedInvoiceID, creditedNumber, invoice.Party(s), invoice.Party(b), docLines,
+		currency, issuedAt, body, checksum), nil
+}
@@ -0,0 +1,142 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/infrastructure/pdf"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationInvoiceRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewInvoiceRepository(pool)
+	orders := pgstore.NewOrderRepository(pool)
+	renderer := pdf.NewInvoiceRenderer(time.UTC)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "invoices@example.com")
+	branchID := uuid.New()
+
+	newInvoice := func() *invoice.Document {
+		o, err := order.NewOrder(uuid.New(), customerID, branchID, []order.Line{
+			{ProductID: uuid.New(), Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 2000,
+				TaxCategory: "fresh_meat", NetCents: 3000, GrossCents: 3000},
+		}, nil, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, orders.Create(ctx, o))
+		d, err := invoice.NewInvoice(uuid.New(), branchID, o.ID(), customerID,
+			invoice.Party{Name: "Butchery", Address: []string{"1 High Street"}}, invoice.Party{Name: "Le Bistro"},
+			[]invoice.LineInput{{Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 2000, GrossCents: 3000}},
+			"gbp", 2026, time.Now().UTC().Truncate(time.Microsecond))
+		require.NoError(t, err)
+		return d
+	}
+
+	first := newInvoice()
+	require.NoError(t, repo.Issue(ctx, first, renderer))
+	second := newInvoice()
+	require.NoError(t, repo.Issue(ctx, second, renderer))
+
+	t.Run("numbers follow on within a series", func(t *testing.T) {
+		assert.Equal(t, int64(1), first.Sequence())
+		assert.Equal(t, int64(2), second.Sequence())
+		assert.Equal(t, invoice.FormatNumber(invoice.KindInvoice, branchID, 2026, 2), second.Number())
+	})
+
+	t.Run("stored PDF is byte-identical", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, first.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, first.PDF(), found.PDF())
+		assert.Equal(t, first.Checksum(), found.Checksum())
+		assert.Equal(t, first.Lines(), found.Lines())
+		assert.Equal(t, first.Seller(), found.Seller())
+
+		_, err = repo.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, invoice.ErrDocumentNotFound)
+	})
+
+	t.Run("second invoice for an order gives its number back", func(t *testing.T) {
+		dup, err := invoice.NewInvoice(uuid.New(), branchID, first.OrderID(), customerID, invoice.Party{}, invoice.Party{},
+			[]invoice.LineInput{{GrossCents: 100}}, "gbp", 2026, time.Now())
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, repo.Issue(ctx, dup, renderer), invoice.ErrAlreadyIssued)
+
+		third := newInvoice()
+		require.NoError(t, repo.Issue(ctx, third, renderer))
+		assert.Equal(t, int64(3), third.Sequence())
+	})
+
+	t.Run("credit notes have their own series", func(t *testing.T) {
+		o, err := orders.FindByID(ctx, first.OrderID())
+		require.NoError(t, err)
+		lineID := o.Lines()[0].ID
+		rf, err := o.RequestRefund(uuid.New(), order.RefundRequest{
+			Kind: order.RefundKindLine, LineID: &lineID, Reason: order.ReasonQuality, IdempotencyKey: "cn-1",
+			RequestedBy: uuid.New(),
+		}, order.ApprovalPolicy{ThresholdCents: 10000}, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, orders.Update(ctx, o))
+		refundID := rf.ID()
+
+		cn, err := invoice.NewCreditNote(uuid.New(), first, refundID, []invoice.LineInput{
+			{Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 2000, GrossCents: 3000},
+		}, 2026, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.Issue(ctx, cn, renderer))
+		assert.Equal(t, int64(1), cn.Sequence())
+
+		found, err := repo.FindByRefundID(ctx, refundID)
+		require.NoError(t, err)
+		assert.Equal(t, first.Number(), found.CreditedNumber())
+		assert.Nil(t, found.PDF(), "lookups leave the PDF out")
+
+		docs, err := repo.ListByOrderID(ctx, first.OrderID())
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, invoice.KindInvoice, docs[0].Kind())
+
+		docs, err = repo.ListByCustomerID(ctx, customerID)
+		require.NoError(t, err)
+		assert.Len(t, docs, 4)
+	})
+
+	t.Run("documents at other branches are out of scope", func(t *testing.T) {
+		elsewhere := branch.WithScope(ctx, branch.Only(uuid.New()))
+
+		_, err := repo.FindByID(elsewhere, first.ID())
+		assert.ErrorIs(t, err, invoice.ErrDocumentNotFound)
+		_, err = repo.FindInvoiceByOrderID(elsewhere, first.OrderID())
+		assert.ErrorIs(t, err, invoice.ErrDocumentNotFound)
+		docs, err := repo.ListByOrderID(elsewhere, first.OrderID())
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+		docs, err = repo.ListByCustomerID(elsewhere, customerID)
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+
+		docs, err = repo.ListByCustomerID(branch.WithScope(ctx, branch.Only(branchID)), customerID)
+		require.NoError(t, err)
+		assert.Len(t, docs, 4)
+	})
+
+	t.Run("issued documents cannot be changed", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "UPDATE invoices SET pdf = 'x' WHERE id = $1", first.ID())
+		assert.Error(t, err)
+		_, err = pool.Exec(ctx, "DELETE FROM invoices WHERE id = $1", second.ID())
+		assert.Error(t, err)
+	})
+}
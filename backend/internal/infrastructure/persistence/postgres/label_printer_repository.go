@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// LabelPrinterRepository implements label.PrinterRepository using PostgreSQL.
+type LabelPrinterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLabelPrinterRepository creates a new LabelPrinterRepository.
+func NewLabelPrinterRepository(pool *pgxpool.Pool) *LabelPrinterRepository {
+	return &LabelPrinterRepository{pool: pool}
+}
+
+const labelPrinterColumns = "id, name, address, active, created_at, updated_at"
+
+// Save inserts or updates a printer.
+func (r *LabelPrinterRepository) Save(ctx context.Context, p *label.Printer) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO label_printers (`+labelPrinterColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, address = EXCLUDED.address, active = EXCLUDED.active,
+		     updated_at = EXCLUDED.updated_at`,
+		p.ID(), p.Name(), p.Address(), p.Active(), p.CreatedAt(), p.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_label_printers_name" {
+			return label.ErrDuplicatePrinter
+		}
+		return fmt.Errorf("saving label printer: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a printer by ID.
+func (r *LabelPrinterRepository) FindByID(ctx context.Context, id uuid.UUID) (*label.Printer, error) {
+	p, err := scanLabelPrinter(r.pool.QueryRow(ctx, "SELECT "+labelPrinterColumns+" FROM label_printers WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, label.ErrPrinterNotFound
+		}
+		return nil, fmt.Errorf("querying label printer: %w", err)
+	}
+	return p, nil
+}
+
+// FindAll returns every printer by name.
+func (r *LabelPrinterRepository) FindAll(ctx context.Context) ([]*label.Printer, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+labelPrinterColumns+" FROM label_printers ORDER BY LOWER(name)")
+	if err != nil {
+		return nil, fmt.Errorf("querying label printers: %w", err)
+	}
+	defer rows.Close()
+
+	var printers []*label.Printer
+	for rows.Next() {
+		p, err := scanLabelPrinter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning label printer: %w", err)
+		}
+		printers = append(printers, p)
+	}
+	return printers, rows.Err()
+}
+
+func scanLabelPrinter(row pgx.Row) (*label.Printer, error) {
+	var id uuid.UUID
+	var name, address string
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &address, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return label.ReconstructPrinter(id, name, address, active, createdAt, updatedAt), nil
+}
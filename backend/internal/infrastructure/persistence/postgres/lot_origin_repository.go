@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+)
+
+// LotOriginRepository implements traceability.OriginRepository using PostgreSQL.
+type LotOriginRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLotOriginRepository creates a new LotOriginRepository.
+func NewLotOriginRepository(pool *pgxpool.Pool) *LotOriginRepository {
+	return &LotOriginRepository{pool: pool}
+}
+
+const lotOriginColumns = "lot_id, carcass_id, farm, slaughtered_on, certificate_number, recorded_at"
+
+// SaveOrigin inserts the origin of a lot.
+func (r *LotOriginRepository) SaveOrigin(ctx context.Context, o *traceability.Origin) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO lot_origins (`+lotOriginColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		o.LotID(), o.CarcassID(), o.Farm(), o.SlaughteredOn(), o.CertificateNumber(), o.RecordedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return traceability.ErrOriginAlreadyRecorded
+			case "23503":
+				return inventory.ErrLotNotFound
+			}
+		}
+		return fmt.Errorf("inserting lot origin: %w", err)
+	}
+	return nil
+}
+
+// FindTraces returns the traces of the lots with the given code, most
+// recently received first. Lots without a recorded origin are left out.
+func (r *LotOriginRepository) FindTraces(ctx context.Context, lotCode string) ([]traceability.Trace, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT l.id, l.code, l.product_id, l.received_at, l.expires_on,
+		        o.carcass_id, o.farm, o.slaughtered_on, o.certificate_number, o.recorded_at,
+		        c.species,
+		        hc.id, hc.issuer, hc.number, hc.slaughterhouse, hc.valid_from, hc.valid_until, hc.document_url, hc.created_at
+		 FROM inventory_lots l
+		 JOIN lot_origins o ON o.lot_id = l.id
+		 LEFT JOIN carcasses c ON c.id = o.carcass_id
+		 LEFT JOIN halal_certificates hc ON hc.number = o.certificate_number
+		 WHERE l.code = $1
+		 ORDER BY l.received_at DESC, l.id`,
+		lotCode,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying lot traces: %w", err)
+	}
+	defer rows.Close()
+
+	var traces []traceability.Trace
+	for rows.Next() {
+		var t traceability.Trace
+		var carcassID *uuid.UUID
+		var farm, certificateNumber string
+		var slaughteredOn, recordedAt time.Time
+		var certID *uuid.UUID
+		var issuer, number, slaughterhouse, documentURL *string
+		var validFrom, validUntil, createdAt *time.Time
+
+		err := rows.Scan(&t.LotID, &t.LotCode, &t.ProductID, &t.ReceivedAt, &t.ExpiresOn,
+			&carcassID, &farm, &slaughteredOn, &certificateNumber, &recordedAt,
+			&t.Species,
+			&certID, &issuer, &number, &slaughterhouse, &validFrom, &validUntil, &documentURL, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning lot trace: %w", err)
+		}
+
+		t.Origin = traceability.ReconstructOrigin(t.LotID, carcassID, farm, slaughteredOn, certificateNumber, recordedAt)
+		if certID != nil {
+			t.Certificate = traceability.ReconstructCertificate(*certID, *issuer, *number, *slaughterhouse,
+				*validFrom, *validUntil, *documentURL, *createdAt)
+		}
+		traces = append(traces, t)
+	}
+	return traces, rows.Err()
+}
+
+// FindConsumedLots returns the weight each line of an order took from each
+// lot, in line order. Orders outside the caller's branches have none.
+func (r *LotOriginRepository) FindConsumedLots(ctx context.Context, orderID uuid.UUID) ([]traceability.ConsumedLot, error) {
+	cond, args := branchScope(ctx, "o.branch_id", []any{orderID})
+	rows, err := r.pool.Query(ctx,
+		`SELECT ol.id, ol.product_id, l.id, l.code, oll.grams
+		 FROM order_line_lots oll
+		 JOIN order_lines ol ON ol.id = oll.order_line_id
+		 JOIN orders o ON o.id = ol.order_id
+		 JOIN inventory_lots l ON l.id = oll.lot_id
+		 WHERE ol.order_id = $1 AND `+cond+`
+		 ORDER BY ol.position, l.code, l.id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying consumed lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []traceability.ConsumedLot
+	for rows.Next() {
+		var l traceability.ConsumedLot
+		if err := rows.Scan(&l.LineID, &l.ProductID, &l.LotID, &l.LotCode, &l.Grams); err != nil {
+			return nil, fmt.Errorf("scanning consumed lot: %w", err)
+		}
+		lots = append(lots, l)
+	}
+	return lots, rows.Err()
+}
+
+func insertLotOrigin(ctx context.Context, tx pgx.Tx, o *traceability.Origin) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO lot_origins (`+lotOriginColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		o.LotID(), o.CarcassID(), o.Farm(), o.SlaughteredOn(), o.CertificateNumber(), o.RecordedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting lot origin: %w", err)
+	}
+	return nil
+}
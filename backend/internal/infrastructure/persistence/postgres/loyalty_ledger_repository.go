@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// LoyaltyLedgerRepository implements loyalty.Ledger using PostgreSQL.
+type LoyaltyLedgerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoyaltyLedgerRepository creates a new LoyaltyLedgerRepository.
+func NewLoyaltyLedgerRepository(pool *pgxpool.Pool) *LoyaltyLedgerRepository {
+	return &LoyaltyLedgerRepository{pool: pool}
+}
+
+const loyaltyEntryColumns = "id, customer_id, kind, points, reference, source_id, expires_at, note, created_by, created_at"
+
+const loyaltyEntriesQuery = "SELECT " + loyaltyEntryColumns + " FROM loyalty_entries WHERE customer_id = $1 ORDER BY seq"
+
+const loyaltyBalanceColumns = "customer_id, points, earned_points, redeemed_points, expired_points, entries, updated_at"
+
+// Append writes entries and moves their customers' balances in one
+// transaction. Balances are locked in customer ID order so concurrent
+// appends cannot deadlock.
+func (r *LoyaltyLedgerRepository) Append(ctx context.Context, entries ...*loyalty.Entry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var customerIDs []uuid.UUID
+	for _, e := range entries {
+		if !slices.Contains(customerIDs, e.CustomerID) {
+			customerIDs = append(customerIDs, e.CustomerID)
+		}
+	}
+	slices.SortFunc(customerIDs, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	balances := make(map[uuid.UUID]loyalty.Balance, len(customerIDs))
+	for _, id := range customerIDs {
+		if balances[id], err = lockLoyaltyBalance(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range entries {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO loyalty_entries (`+loyaltyEntryColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			e.ID, e.CustomerID, string(e.Kind), e.Points, e.Reference, e.SourceID, e.ExpiresAt, e.Note, e.CreatedBy, e.CreatedAt,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+				(pgErr.ConstraintName == "uq_loyalty_entries_reference" || pgErr.ConstraintName == "uq_loyalty_entries_source") {
+				return loyalty.ErrDuplicateEntry
+			}
+			return fmt.Errorf("inserting loyalty entry: %w", err)
+		}
+		balances[e.CustomerID] = balances[e.CustomerID].Apply(e)
+	}
+
+	for _, id := range customerIDs {
+		if balances[id].Points < 0 {
+			return loyalty.ErrInsufficientPoints
+		}
+		if err := saveLoyaltyBalance(ctx, tx, balances[id]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing loyalty entries: %w", err)
+	}
+	return nil
+}
+
+// Entries returns a customer's entries in the order they were written.
+func (r *LoyaltyLedgerRepository) Entries(ctx context.Context, customerID uuid.UUID) ([]*loyalty.Entry, error) {
+	return scanLoyaltyEntries(r.pool.Query(ctx, loyaltyEntriesQuery, customerID))
+}
+
+// Balance returns a customer's projected balance.
+func (r *LoyaltyLedgerRepository) Balance(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+loyaltyBalanceColumns+" FROM loyalty_balances WHERE customer_id = $1", customerID)
+	b, err := scanLoyaltyBalance(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return loyalty.Balance{CustomerID: customerID}, nil
+	}
+	if err != nil {
+		return loyalty.Balance{}, fmt.Errorf("querying loyalty balance: %w", err)
+	}
+	return b, nil
+}
+
+// Rebuild recomputes a customer's balance from their entries while the
+// balance is locked, so no entry can be appended halfway through.
+func (r *LoyaltyLedgerRepository) Rebuild(ctx context.Context, customerID uuid.UUID) (loyalty.Balance, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return loyalty.Balance{}, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := lockLoyaltyBalance(ctx, tx, customerID); err != nil {
+		return loyalty.Balance{}, err
+	}
+	entries, err := scanLoyaltyEntries(tx.Query(ctx, loyaltyEntriesQuery, customerID))
+	if err != nil {
+		return loyalty.Balance{}, err
+	}
+	b := loyalty.Project(customerID, entries)
+	if err := saveLoyaltyBalance(ctx, tx, b); err != nil {
+		return loyalty.Balance{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return loyalty.Balance{}, fmt.Errorf("committing loyalty balance: %w", err)
+	}
+	return b, nil
+}
+
+// CustomersWithExpiring returns the customers with points to spend who hold
+// credits that expire by at and have not been expired yet.
+func (r *LoyaltyLedgerRepository) CustomersWithExpiring(ctx context.Context, at time.Time) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT e.customer_id
+		 FROM loyalty_entries e
+		 JOIN loyalty_balances b ON b.customer_id = e.customer_id AND b.points > 0
+		 WHERE e.expires_at <= $1
+		   AND NOT EXISTS (SELECT 1 FROM loyalty_entries x WHERE x.source_id = e.id)
+		 ORDER BY e.customer_id`,
+		at,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying customers with expiring points: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning customer id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// lockLoyaltyBalance locks a customer's balance row, creating an empty one
+// first if they have none.
+func lockLoyaltyBalance(ctx context.Context, tx pgx.Tx, customerID uuid.UUID) (loyalty.Balance, error) {
+	_, err := tx.Exec(ctx,
+		"INSERT INTO loyalty_balances (customer_id, points) VALUES ($1, 0) ON CONFLICT (customer_id) DO NOTHING", customerID)
+	if err != nil {
+		return loyalty.Balance{}, fmt.Errorf("creating loyalty balance: %w", err)
+	}
+	row := tx.QueryRow(ctx, "SELECT "+loyaltyBalanceColumns+" FROM loyalty_balances WHERE customer_id = $1 FOR UPDATE", customerID)
+	b, err := scanLoyaltyBalance(row)
+	if err != nil {
+		return loyalty.Balance{}, fmt.Errorf("locking loyalty balance: %w", err)
+	}
+	if b.Entries == 0 {
+		// A new balance moves to its first entry's time, as a rebuild would.
+		b.UpdatedAt = time.Time{}
+	}
+	return b, nil
+}
+
+func saveLoyaltyBalance(ctx context.Context, tx pgx.Tx, b loyalty.Balance) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE loyalty_balances SET points = $2, earned_points = $3, redeemed_points = $4, expired_points = $5,
+		     entries = $6, updated_at = $7
+		 WHERE customer_id = $1`,
+		b.CustomerID, b.Points, b.EarnedPoints, b.RedeemedPoints, b.ExpiredPoints, b.Entries, b.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating loyalty balance: %w", err)
+	}
+	return nil
+}
+
+func scanLoyaltyBalance(row pgx.Row) (loyalty.Balance, error) {
+	var b loyalty.Balance
+	err := row.Scan(&b.CustomerID, &b.Points, &b.EarnedPoints, &b.RedeemedPoints, &b.ExpiredPoints, &b.Entries, &b.UpdatedAt)
+	return b, err
+}
+
+func scanLoyaltyEntries(rows pgx.Rows, err error) ([]*loyalty.Entry, error) {
+	if err != nil {
+		return nil, fmt.Errorf("querying loyalty entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*loyalty.Entry
+	for rows.Next() {
+		var e loyalty.Entry
+		var kind string
+		if err := rows.Scan(&e.ID, &e.CustomerID, &kind, &e.Points, &e.Reference, &e.SourceID, &e.ExpiresAt,
+			&e.Note, &e.CreatedBy, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning loyalty entry: %w", err)
+		}
+		e.Kind = loyalty.Kind(kind)
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
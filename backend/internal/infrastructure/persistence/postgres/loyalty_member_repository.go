@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// LoyaltyMemberRepository implements loyalty.MemberRepository using
+// PostgreSQL.
+type LoyaltyMemberRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoyaltyMemberRepository creates a new LoyaltyMemberRepository.
+func NewLoyaltyMemberRepository(pool *pgxpool.Pool) *LoyaltyMemberRepository {
+	return &LoyaltyMemberRepository{pool: pool}
+}
+
+// SetBirthday sets or replaces a customer's birthday.
+func (r *LoyaltyMemberRepository) SetBirthday(ctx context.Context, customerID uuid.UUID, b loyalty.Birthday) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO loyalty_members (customer_id, birth_month, birth_day) VALUES ($1, $2, $3)
+		 ON CONFLICT (customer_id) DO UPDATE SET
+		     birth_month = EXCLUDED.birth_month, birth_day = EXCLUDED.birth_day, updated_at = NOW()`,
+		customerID, int(b.Month), b.Day,
+	)
+	if err != nil {
+		return fmt.Errorf("saving birthday: %w", err)
+	}
+	return nil
+}
+
+// FindBirthday returns a customer's birthday, or nil if they have not given
+// one.
+func (r *LoyaltyMemberRepository) FindBirthday(ctx context.Context, customerID uuid.UUID) (*loyalty.Birthday, error) {
+	var month, day int
+	err := r.pool.QueryRow(ctx,
+		"SELECT birth_month, birth_day FROM loyalty_members WHERE customer_id = $1", customerID,
+	).Scan(&month, &day)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying birthday: %w", err)
+	}
+	return &loyalty.Birthday{Month: time.Month(month), Day: day}, nil
+}
+
+// FindByBirthday returns the customers whose birthday falls on date,
+// including those born on 29 February when date is 28 February of a common
+// year.
+func (r *LoyaltyMemberRepository) FindByBirthday(ctx context.Context, date time.Time) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT customer_id, birth_month, birth_day FROM loyalty_members WHERE birth_month = $1 AND birth_day >= $2 ORDER BY customer_id",
+		int(date.Month()), date.Day(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying birthdays: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var month, day int
+		if err := rows.Scan(&id, &month, &day); err != nil {
+			return nil, fmt.Errorf("scanning birthday: %w", err)
+		}
+		if (loyalty.Birthday{Month: time.Month(month), Day: day}).On(date) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, rows.Err()
+}
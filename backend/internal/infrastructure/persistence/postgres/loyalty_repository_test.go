@@ -0,0 +1,141 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationLoyaltyLedgerRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	ledger := pgstore.NewLoyaltyLedgerRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "loyalty@example.com")
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	expires := now.Add(time.Hour)
+
+	newEntry := func(kind loyalty.Kind, points int64, reference string, source *loyalty.Entry, expiresAt *time.Time) *loyalty.Entry {
+		var sourceID *uuid.UUID
+		if source != nil {
+			sourceID = &source.ID
+		}
+		e, err := loyalty.NewEntry(uuid.New(), customerID, kind, points, reference, sourceID, expiresAt, "", nil, now)
+		require.NoError(t, err)
+		return e
+	}
+
+	earn := newEntry(loyalty.KindEarn, 300, "order-1", nil, &expires)
+	require.NoError(t, ledger.Append(ctx, earn))
+	require.NoError(t, ledger.Append(ctx, newEntry(loyalty.KindRedeem, -100, "order-2", nil, nil)))
+
+	t.Run("balance follows the entries", func(t *testing.T) {
+		b, err := ledger.Balance(ctx, customerID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(200), b.Points)
+		assert.Equal(t, int64(300), b.EarnedPoints)
+		assert.Equal(t, int64(100), b.RedeemedPoints)
+		assert.Equal(t, 2, b.Entries)
+	})
+
+	t.Run("overdraft and duplicates are refused", func(t *testing.T) {
+		assert.ErrorIs(t, ledger.Append(ctx, newEntry(loyalty.KindRedeem, -201, "order-3", nil, nil)), loyalty.ErrInsufficientPoints)
+		assert.ErrorIs(t, ledger.Append(ctx, newEntry(loyalty.KindEarn, 10, "order-1", nil, nil)), loyalty.ErrDuplicateEntry)
+
+		entries, err := ledger.Entries(ctx, customerID)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("entries cannot be changed", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "UPDATE loyalty_entries SET points = 1 WHERE id = $1", earn.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("expiring credits are found until expired", func(t *testing.T) {
+		ids, err := ledger.CustomersWithExpiring(ctx, expires)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{customerID}, ids)
+
+		require.NoError(t, ledger.Append(ctx, newEntry(loyalty.KindExpire, -200, "", earn, nil)))
+
+		ids, err = ledger.CustomersWithExpiring(ctx, expires)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("rebuild matches the projection", func(t *testing.T) {
+		before, err := ledger.Balance(ctx, customerID)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE loyalty_balances SET points = 999 WHERE customer_id = $1", customerID)
+		require.NoError(t, err)
+
+		rebuilt, err := ledger.Rebuild(ctx, customerID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), rebuilt.Points)
+		assert.Equal(t, before.Points, rebuilt.Points)
+		assert.Equal(t, before.ExpiredPoints, rebuilt.ExpiredPoints)
+		assert.True(t, before.UpdatedAt.Equal(rebuilt.UpdatedAt))
+	})
+}
+
+func TestIntegrationLoyaltyRulesAndMembers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	rulesRepo := pgstore.NewLoyaltyRulesRepository(pool)
+	members := pgstore.NewLoyaltyMemberRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "birthday@example.com")
+
+	defaults, err := rulesRepo.Find(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rulesRepo.Save(ctx, defaults) })
+
+	productID := uuid.New()
+	require.NoError(t, rulesRepo.Save(ctx, loyalty.Rules{PointsPerUnit: 2, UnitCents: 100, PointValueCents: 1, BirthdayBonus: 500, ExpiryDays: 90}))
+	require.NoError(t, rulesRepo.SetProductBonus(ctx, productID, 3))
+
+	rules, err := rulesRepo.Find(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rules.PointsPerUnit)
+	assert.Equal(t, int64(500), rules.BirthdayBonus)
+	assert.Equal(t, map[uuid.UUID]int64{productID: 3}, rules.ProductBonuses)
+
+	require.NoError(t, rulesRepo.SetProductBonus(ctx, productID, 0))
+	rules, err = rulesRepo.Find(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules.ProductBonuses)
+
+	none, err := members.FindBirthday(ctx, customerID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	require.NoError(t, members.SetBirthday(ctx, customerID, loyalty.Birthday{Month: time.February, Day: 29}))
+	b, err := members.FindBirthday(ctx, customerID)
+	require.NoError(t, err)
+	assert.Equal(t, loyalty.Birthday{Month: time.February, Day: 29}, *b)
+
+	ids, err := members.FindByBirthday(ctx, time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{customerID}, ids)
+
+	ids, err = members.FindByBirthday(ctx, time.Date(2028, 2, 28, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
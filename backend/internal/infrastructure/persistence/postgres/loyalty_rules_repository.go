@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+)
+
+// LoyaltyRulesRepository implements loyalty.RulesRepository using
+// PostgreSQL.
+type LoyaltyRulesRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoyaltyRulesRepository creates a new LoyaltyRulesRepository.
+func NewLoyaltyRulesRepository(pool *pgxpool.Pool) *LoyaltyRulesRepository {
+	return &LoyaltyRulesRepository{pool: pool}
+}
+
+// Find returns the rules with every product bonus.
+func (r *LoyaltyRulesRepository) Find(ctx context.Context) (loyalty.Rules, error) {
+	var rules loyalty.Rules
+	err := r.pool.QueryRow(ctx,
+		`SELECT points_per_unit, unit_cents, point_value_cents, min_redeem_points, birthday_bonus, expiry_days
+		 FROM loyalty_rules`,
+	).Scan(&rules.PointsPerUnit, &rules.UnitCents, &rules.PointValueCents, &rules.MinRedeemPoints,
+		&rules.BirthdayBonus, &rules.ExpiryDays)
+	if err != nil {
+		return loyalty.Rules{}, fmt.Errorf("querying loyalty rules: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, "SELECT product_id, points_per_unit FROM loyalty_product_bonuses")
+	if err != nil {
+		return loyalty.Rules{}, fmt.Errorf("querying loyalty product bonuses: %w", err)
+	}
+	defer rows.Close()
+
+	rules.ProductBonuses = map[uuid.UUID]int64{}
+	for rows.Next() {
+		var productID uuid.UUID
+		var points int64
+		if err := rows.Scan(&productID, &points); err != nil {
+			return loyalty.Rules{}, fmt.Errorf("scanning loyalty product bonus: %w", err)
+		}
+		rules.ProductBonuses[productID] = points
+	}
+	return rules, rows.Err()
+}
+
+// Save replaces the rules.
+func (r *LoyaltyRulesRepository) Save(ctx context.Context, rules loyalty.Rules) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE loyalty_rules SET points_per_unit = $1, unit_cents = $2, point_value_cents = $3,
+		     min_redeem_points = $4, birthday_bonus = $5, expiry_days = $6, updated_at = NOW()`,
+		rules.PointsPerUnit, rules.UnitCents, rules.PointValueCents, rules.MinRedeemPoints,
+		rules.BirthdayBonus, rules.ExpiryDays,
+	)
+	if err != nil {
+		return fmt.Errorf("saving loyalty rules: %w", err)
+	}
+	return nil
+}
+
+// SetProductBonus sets or removes a product's bonus.
+func (r *LoyaltyRulesRepository) SetProductBonus(ctx context.Context, productID uuid.UUID, points int64) error {
+	var err error
+	if points == 0 {
+		_, err = r.pool.Exec(ctx, "DELETE FROM loyalty_product_bonuses WHERE product_id = $1", productID)
+	} else {
+		_, err = r.pool.Exec(ctx,
+			`INSERT INTO loyalty_product_bonuses (product_id, points_per_unit) VALUES ($1, $2)
+			 ON CONFLICT (product_id) DO UPDATE SET points_per_unit = EXCLUDED.points_per_unit, updated_at = NOW()`,
+			productID, points,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("setting loyalty product bonus: %w", err)
+	}
+	return nil
+}
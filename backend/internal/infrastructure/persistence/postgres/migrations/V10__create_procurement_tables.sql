@@ -0,0 +1,70 @@
+CREATE TABLE suppliers (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    contacts JSONB NOT NULL DEFAULT '[]',
+    certificates JSONB NOT NULL DEFAULT '[]',
+    payment_terms_days SMALLINT NOT NULL CHECK (payment_terms_days BETWEEN 0 AND 180),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE purchase_orders (
+    id UUID PRIMARY KEY,
+    supplier_id UUID NOT NULL REFERENCES suppliers(id),
+    branch_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expected_on DATE,
+    notes TEXT NOT NULL DEFAULT '',
+    created_by UUID,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_orders_supplier ON purchase_orders(supplier_id, sent_at);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders(status, created_at);
+
+-- Lines of a draft are replaced wholesale when it is revised; once the order
+-- is sent only received_grams changes.
+CREATE TABLE purchase_order_lines (
+    id UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    product_id UUID NOT NULL,
+    ordered_grams BIGINT NOT NULL CHECK (ordered_grams > 0),
+    price_per_kg_cents BIGINT NOT NULL CHECK (price_per_kg_cents >= 0),
+    received_grams BIGINT NOT NULL DEFAULT 0 CHECK (received_grams >= 0)
+);
+
+CREATE INDEX idx_purchase_order_lines_order ON purchase_order_lines(purchase_order_id, position);
+CREATE INDEX idx_purchase_order_lines_product ON purchase_order_lines(product_id);
+
+CREATE TABLE goods_received_notes (
+    id UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id),
+    branch_id UUID NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    received_by UUID,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_goods_received_notes_order ON goods_received_notes(purchase_order_id, received_at);
+
+-- Every delivered batch is received into inventory as its own lot.
+CREATE TABLE goods_received_lines (
+    lot_id UUID PRIMARY KEY REFERENCES inventory_lots(id),
+    goods_received_note_id UUID NOT NULL REFERENCES goods_received_notes(id),
+    position SMALLINT NOT NULL,
+    purchase_order_line_id UUID NOT NULL REFERENCES purchase_order_lines(id),
+    product_id UUID NOT NULL,
+    lot_code VARCHAR(100) NOT NULL,
+    grams BIGINT NOT NULL CHECK (grams > 0),
+    temperature_tenths_c SMALLINT NOT NULL,
+    expires_on DATE
+);
+
+CREATE INDEX idx_goods_received_lines_note ON goods_received_lines(goods_received_note_id, position);
@@ -0,0 +1,41 @@
+CREATE TABLE payments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    currency CHAR(3) NOT NULL,
+    estimated_cents BIGINT NOT NULL CHECK (estimated_cents > 0),
+    authorized_cents BIGINT NOT NULL CHECK (authorized_cents >= estimated_cents),
+    captured_cents BIGINT NOT NULL DEFAULT 0 CHECK (captured_cents >= 0),
+    refunded_cents BIGINT NOT NULL DEFAULT 0 CHECK (refunded_cents >= 0 AND refunded_cents <= captured_cents),
+    status VARCHAR(20) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    provider_ref VARCHAR(255) NOT NULL DEFAULT '',
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_payments_order ON payments(order_id);
+CREATE INDEX idx_payments_provider_ref ON payments(provider, provider_ref) WHERE provider_ref <> '';
+
+CREATE TABLE payment_refunds (
+    id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    idempotency_key VARCHAR(255) NOT NULL,
+    provider_ref VARCHAR(255) NOT NULL DEFAULT '',
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (payment_id, idempotency_key)
+);
+
+-- Provider events already applied, so that redelivered webhooks are ignored.
+CREATE TABLE payment_webhook_events (
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, event_id)
+);
@@ -0,0 +1,70 @@
+-- Managers may approve refunds above the approval threshold. The seeded
+-- administrator is the shop's first manager.
+ALTER TABLE admins ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'staff';
+UPDATE admins SET role = 'manager' WHERE email = 'admin@butchery.com';
+
+CREATE TABLE orders (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    status VARCHAR(20) NOT NULL,
+    refunded_cents BIGINT NOT NULL DEFAULT 0 CHECK (refunded_cents >= 0),
+    created_by UUID,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_orders_customer ON orders(customer_id, created_at);
+
+CREATE TABLE order_lines (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    product_id UUID NOT NULL,
+    grams BIGINT NOT NULL CHECK (grams > 0),
+    price_per_kg_cents BIGINT NOT NULL CHECK (price_per_kg_cents >= 0),
+    refunded_cents BIGINT NOT NULL DEFAULT 0 CHECK (refunded_cents >= 0)
+);
+
+CREATE INDEX idx_order_lines_order ON order_lines(order_id, position);
+
+-- Receipt numbers come from a sequence; a number is only kept by a refund
+-- once it has been issued.
+CREATE SEQUENCE order_refund_receipt_seq;
+
+CREATE TABLE order_refunds (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id),
+    kind VARCHAR(20) NOT NULL,
+    line_id UUID REFERENCES order_lines(id),
+    delivered_grams BIGINT NOT NULL DEFAULT 0 CHECK (delivered_grams >= 0),
+    reason VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    status VARCHAR(20) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    requested_by UUID NOT NULL,
+    decided_by UUID,
+    payment_id UUID REFERENCES payments(id),
+    payment_refund_id UUID REFERENCES payment_refunds(id),
+    receipt_number VARCHAR(20) UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMPTZ,
+    issued_at TIMESTAMPTZ,
+    UNIQUE (order_id, idempotency_key)
+);
+
+CREATE INDEX idx_order_refunds_status ON order_refunds(status, created_at);
+
+-- Append-only record of what admins did to which record.
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    actor_id UUID NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at);
@@ -0,0 +1,56 @@
+-- Invoices are numbered per branch, so orders record the branch they were
+-- placed at. Lines carry the description and VAT rate printed on invoices;
+-- prices include VAT.
+ALTER TABLE orders ADD COLUMN branch_id UUID;
+ALTER TABLE order_lines ADD COLUMN description VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE order_lines ADD COLUMN vat_rate_bp INTEGER NOT NULL DEFAULT 0 CHECK (vat_rate_bp BETWEEN 0 AND 10000);
+
+-- The last number used in each series. Issuing a document increments its
+-- row in the same transaction that stores the document, so numbers have no
+-- gaps.
+CREATE TABLE invoice_sequences (
+    branch_id UUID NOT NULL,
+    fiscal_year INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    last_number BIGINT NOT NULL,
+    PRIMARY KEY (branch_id, fiscal_year, kind)
+);
+
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    number VARCHAR(40) NOT NULL UNIQUE,
+    branch_id UUID NOT NULL,
+    fiscal_year INTEGER NOT NULL,
+    sequence BIGINT NOT NULL CHECK (sequence > 0),
+    order_id UUID NOT NULL REFERENCES orders(id),
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    refund_id UUID REFERENCES order_refunds(id),
+    credited_invoice_id UUID REFERENCES invoices(id),
+    credited_number VARCHAR(40) NOT NULL DEFAULT '',
+    seller JSONB NOT NULL,
+    buyer JSONB NOT NULL,
+    lines JSONB NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    net_cents BIGINT NOT NULL,
+    vat_cents BIGINT NOT NULL,
+    gross_cents BIGINT NOT NULL,
+    pdf BYTEA NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (branch_id, fiscal_year, kind, sequence)
+);
+
+CREATE UNIQUE INDEX idx_invoices_order_invoice ON invoices(order_id) WHERE kind = 'invoice';
+CREATE UNIQUE INDEX idx_invoices_refund ON invoices(refund_id) WHERE refund_id IS NOT NULL;
+CREATE INDEX idx_invoices_customer ON invoices(customer_id, issued_at);
+
+-- Issued documents are kept exactly as the customer received them.
+CREATE FUNCTION reject_invoice_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'issued invoices cannot be changed or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER invoices_immutable BEFORE UPDATE OR DELETE ON invoices
+    FOR EACH ROW EXECUTE FUNCTION reject_invoice_change();
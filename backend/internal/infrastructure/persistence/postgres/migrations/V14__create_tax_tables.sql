@@ -0,0 +1,41 @@
+-- VAT rates by tax category. A rate applies from its effective date until
+-- the next rate for the same category takes over.
+CREATE TABLE tax_rates (
+    id UUID PRIMARY KEY,
+    category VARCHAR(50) NOT NULL,
+    rate_bp INTEGER NOT NULL CHECK (rate_bp BETWEEN 0 AND 10000),
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_tax_rates_category_date UNIQUE (category, effective_from)
+);
+
+-- Rates in force when the tax engine was introduced.
+INSERT INTO tax_rates (id, category, rate_bp, effective_from) VALUES
+    ('6f1c2a4e-0d55-4b5e-9a43-0b7a4cf2e001', 'fresh_meat', 0, '2000-01-01'),
+    ('6f1c2a4e-0d55-4b5e-9a43-0b7a4cf2e002', 'prepared_food', 2000, '2000-01-01'),
+    ('6f1c2a4e-0d55-4b5e-9a43-0b7a4cf2e003', 'delivery', 2000, '2000-01-01');
+
+-- The tax category of each catalog product.
+CREATE TABLE product_tax_categories (
+    product_id UUID PRIMARY KEY,
+    category VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE delivery_zones ADD COLUMN fee_tax_category VARCHAR(50) NOT NULL DEFAULT 'delivery';
+
+-- Lines keep the calculator's result, so invoices and refunds reuse the
+-- same figures. Existing lines were priced VAT-inclusive and rounded per
+-- line.
+ALTER TABLE order_lines ADD COLUMN tax_category VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE order_lines ADD COLUMN gross_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_lines ADD COLUMN vat_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_lines ADD COLUMN net_cents BIGINT NOT NULL DEFAULT 0;
+
+UPDATE order_lines SET gross_cents = (grams * price_per_kg_cents + 500) / 1000;
+UPDATE order_lines
+   SET vat_cents = (gross_cents * vat_rate_bp + (10000 + vat_rate_bp) / 2) / (10000 + vat_rate_bp);
+UPDATE order_lines SET net_cents = gross_cents - vat_cents;
+
+ALTER TABLE order_lines ADD CONSTRAINT chk_order_lines_balanced
+    CHECK (net_cents >= 0 AND vat_cents >= 0 AND net_cents + vat_cents = gross_cents);
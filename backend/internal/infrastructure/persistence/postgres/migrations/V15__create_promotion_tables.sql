@@ -0,0 +1,51 @@
+-- Promotions marketing runs. Empty scope arrays match everything; a code
+-- makes the promotion a coupon that must be entered.
+CREATE TABLE promotions (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(32),
+    kind VARCHAR(20) NOT NULL,
+    percent_bp INTEGER NOT NULL DEFAULT 0,
+    amount_cents BIGINT NOT NULL DEFAULT 0,
+    buy_grams BIGINT NOT NULL DEFAULT 0,
+    free_grams BIGINT NOT NULL DEFAULT 0,
+    min_subtotal_cents BIGINT NOT NULL DEFAULT 0,
+    product_ids UUID[] NOT NULL DEFAULT '{}',
+    categories TEXT[] NOT NULL DEFAULT '{}',
+    species TEXT[] NOT NULL DEFAULT '{}',
+    segments TEXT[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    max_uses INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    max_uses_per_customer INTEGER NOT NULL DEFAULT 0 CHECK (max_uses_per_customer >= 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    stacking VARCHAR(20) NOT NULL DEFAULT 'stackable',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_promotions_code ON promotions (code) WHERE code IS NOT NULL;
+
+-- The category and species promotions can target each product by.
+CREATE TABLE promotion_products (
+    product_id UUID PRIMARY KEY,
+    category VARCHAR(50) NOT NULL DEFAULT '',
+    species VARCHAR(50) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Each use of a promotion, counted against its limits.
+CREATE TABLE promotion_redemptions (
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    order_id UUID NOT NULL,
+    customer_id UUID NOT NULL,
+    discount_cents BIGINT NOT NULL CHECK (discount_cents >= 0),
+    redeemed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX idx_promotion_redemptions_customer ON promotion_redemptions (promotion_id, customer_id);
+CREATE INDEX idx_promotion_redemptions_order ON promotion_redemptions (order_id);
+
+ALTER TABLE order_lines ADD COLUMN discount_cents BIGINT NOT NULL DEFAULT 0 CHECK (discount_cents >= 0);
@@ -0,0 +1,73 @@
+-- The earn and redeem rules. There is only ever one row; by default a
+-- customer earns a point per pound that is worth a penny at checkout.
+CREATE TABLE loyalty_rules (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    points_per_unit BIGINT NOT NULL CHECK (points_per_unit >= 0),
+    unit_cents BIGINT NOT NULL CHECK (unit_cents > 0),
+    point_value_cents BIGINT NOT NULL CHECK (point_value_cents >= 0),
+    min_redeem_points BIGINT NOT NULL CHECK (min_redeem_points >= 0),
+    birthday_bonus BIGINT NOT NULL CHECK (birthday_bonus >= 0),
+    expiry_days INTEGER NOT NULL CHECK (expiry_days >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO loyalty_rules (points_per_unit, unit_cents, point_value_cents, min_redeem_points, birthday_bonus, expiry_days)
+VALUES (1, 100, 1, 100, 0, 365);
+
+-- Extra points per unit spent on particular products.
+CREATE TABLE loyalty_product_bonuses (
+    product_id UUID PRIMARY KEY,
+    points_per_unit BIGINT NOT NULL CHECK (points_per_unit > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE loyalty_members (
+    customer_id UUID PRIMARY KEY REFERENCES customers(id),
+    birth_month SMALLINT NOT NULL CHECK (birth_month BETWEEN 1 AND 12),
+    birth_day SMALLINT NOT NULL CHECK (birth_day BETWEEN 1 AND 31),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_loyalty_members_birthday ON loyalty_members (birth_month, birth_day);
+
+-- Append-only points ledger. seq orders entries as they were written.
+CREATE TABLE loyalty_entries (
+    seq BIGSERIAL UNIQUE,
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    kind VARCHAR(20) NOT NULL,
+    points BIGINT NOT NULL CHECK (points <> 0),
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    source_id UUID REFERENCES loyalty_entries(id),
+    expires_at TIMESTAMPTZ,
+    note TEXT NOT NULL DEFAULT '',
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_loyalty_entries_customer ON loyalty_entries (customer_id, seq);
+CREATE INDEX idx_loyalty_entries_expiry ON loyalty_entries (expires_at) WHERE expires_at IS NOT NULL;
+CREATE UNIQUE INDEX uq_loyalty_entries_reference ON loyalty_entries (customer_id, kind, reference) WHERE reference <> '';
+CREATE UNIQUE INDEX uq_loyalty_entries_source ON loyalty_entries (source_id) WHERE source_id IS NOT NULL;
+
+CREATE FUNCTION loyalty_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'loyalty_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_loyalty_entries_append_only
+    BEFORE UPDATE OR DELETE ON loyalty_entries
+    FOR EACH ROW EXECUTE FUNCTION loyalty_entries_append_only();
+
+-- Balance projected from the ledger, moved in the same transaction as each
+-- entry and rebuildable from it.
+CREATE TABLE loyalty_balances (
+    customer_id UUID PRIMARY KEY REFERENCES customers(id),
+    points BIGINT NOT NULL CHECK (points >= 0),
+    earned_points BIGINT NOT NULL DEFAULT 0,
+    redeemed_points BIGINT NOT NULL DEFAULT 0,
+    expired_points BIGINT NOT NULL DEFAULT 0,
+    entries INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
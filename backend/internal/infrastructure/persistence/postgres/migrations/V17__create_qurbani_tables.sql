@@ -0,0 +1,59 @@
+-- Eid al-Adha sacrifice bookings. An offering is one share type for one
+-- season; its capacity is animals times the shares each animal is
+-- sacrificed for (one for a sheep or goat, seven for a cow).
+CREATE TABLE qurbani_offerings (
+    id UUID PRIMARY KEY,
+    season VARCHAR(20) NOT NULL,
+    share_type VARCHAR(20) NOT NULL,
+    price_cents BIGINT NOT NULL CHECK (price_cents >= 0),
+    animals INTEGER NOT NULL CHECK (animals > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (season, share_type)
+);
+
+-- Animals slaughtered together, numbered in order within a season.
+CREATE TABLE qurbani_batches (
+    id UUID PRIMARY KEY,
+    season VARCHAR(20) NOT NULL,
+    number INTEGER NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (season, number)
+);
+
+-- Animals are opened as shares are allocated to them.
+CREATE TABLE qurbani_animals (
+    id UUID PRIMARY KEY,
+    offering_id UUID NOT NULL REFERENCES qurbani_offerings(id),
+    number INTEGER NOT NULL,
+    batch_id UUID REFERENCES qurbani_batches(id),
+    slaughtered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (offering_id, number)
+);
+
+CREATE INDEX idx_qurbani_animals_batch ON qurbani_animals (batch_id);
+
+-- One share each, made in the name of the person in the niyyah.
+CREATE TABLE qurbani_bookings (
+    id UUID PRIMARY KEY,
+    offering_id UUID NOT NULL REFERENCES qurbani_offerings(id),
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    niyyah_name VARCHAR(100) NOT NULL,
+    distribution VARCHAR(20) NOT NULL,
+    address_id UUID,
+    price_cents BIGINT NOT NULL CHECK (price_cents >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'booked',
+    animal_id UUID REFERENCES qurbani_animals(id),
+    booked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    slaughtered_at TIMESTAMPTZ,
+    notified_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_qurbani_bookings_offering ON qurbani_bookings (offering_id, booked_at);
+CREATE INDEX idx_qurbani_bookings_customer ON qurbani_bookings (customer_id, booked_at);
+CREATE INDEX idx_qurbani_bookings_animal ON qurbani_bookings (animal_id);
@@ -0,0 +1,64 @@
+-- Boxes customers subscribe to. Items hold each cut with its weight, shelf
+-- price and, for customizable boxes, the cuts it may be swapped for.
+CREATE TABLE subscription_boxes (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    customizable BOOLEAN NOT NULL DEFAULT FALSE,
+    items JSONB NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Standing orders for a box, delivered in one fulfilment slot at a cadence.
+-- next_delivery is the next date an order will be generated for; skips are
+-- later dates the customer has left out. version guards against the
+-- customer and the scheduler changing a subscription at once.
+CREATE TABLE subscriptions (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    box_id UUID NOT NULL REFERENCES subscription_boxes(id),
+    cadence VARCHAR(20) NOT NULL,
+    template_id UUID NOT NULL REFERENCES slot_templates(id),
+    payment_method VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    next_delivery DATE NOT NULL,
+    skips DATE[] NOT NULL DEFAULT '{}',
+    swaps JSONB NOT NULL DEFAULT '[]',
+    failed_renewals INTEGER NOT NULL DEFAULT 0,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscriptions_customer ON subscriptions (customer_id, created_at);
+CREATE INDEX idx_subscriptions_status ON subscriptions (status, next_delivery);
+
+-- The order generated for each delivery. order_id is assigned when the
+-- renewal is generated and the order is only created once payment has been
+-- taken, so it has no foreign key.
+CREATE TABLE subscription_renewals (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id),
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    branch_id UUID NOT NULL,
+    delivery_date DATE NOT NULL,
+    slot_starts_at TIMESTAMPTZ NOT NULL,
+    cutoff_at TIMESTAMPTZ NOT NULL,
+    order_id UUID NOT NULL UNIQUE,
+    cuts JSONB NOT NULL,
+    total_cents BIGINT NOT NULL CHECK (total_cents >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'awaiting_payment',
+    slot_reservation_id UUID REFERENCES slot_reservations(id),
+    stock_reservation_id UUID REFERENCES stock_reservations(id),
+    payment_id UUID REFERENCES payments(id),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (subscription_id, delivery_date)
+);
+
+CREATE INDEX idx_subscription_renewals_status ON subscription_renewals (status, cutoff_at);
+CREATE INDEX idx_subscription_renewals_customer ON subscription_renewals (customer_id, delivery_date);
@@ -0,0 +1,67 @@
+-- Wholesale customers such as restaurants and caterers. prices is the
+-- account's own price list, each product with volume tiers. balance_cents
+-- is what the account owes and held_cents what orders waiting for approval
+-- would add; version guards against two orders moving them at once.
+CREATE TABLE business_accounts (
+    id UUID PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    company_number VARCHAR(50) NOT NULL DEFAULT '',
+    vat_number VARCHAR(50) NOT NULL DEFAULT '',
+    billing_email VARCHAR(255) NOT NULL,
+    billing_address TEXT NOT NULL DEFAULT '',
+    credit_limit_cents BIGINT NOT NULL CHECK (credit_limit_cents >= 0),
+    terms_days INTEGER NOT NULL CHECK (terms_days > 0),
+    approval_threshold_cents BIGINT CHECK (approval_threshold_cents >= 0),
+    prices JSONB NOT NULL DEFAULT '[]',
+    balance_cents BIGINT NOT NULL DEFAULT 0,
+    held_cents BIGINT NOT NULL DEFAULT 0 CHECK (held_cents >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Customers who buy for a business. A customer buys for one business at most.
+CREATE TABLE business_members (
+    account_id UUID NOT NULL REFERENCES business_accounts(id),
+    customer_id UUID NOT NULL UNIQUE REFERENCES customers(id),
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, customer_id)
+);
+
+-- Orders placed on account. order_id is assigned when the order is placed
+-- and the order itself is only created once it has been approved, so it
+-- has no foreign key.
+CREATE TABLE business_orders (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES business_accounts(id),
+    placed_by UUID NOT NULL REFERENCES customers(id),
+    branch_id UUID NOT NULL,
+    order_id UUID NOT NULL UNIQUE,
+    lines JSONB NOT NULL,
+    total_cents BIGINT NOT NULL CHECK (total_cents >= 0),
+    status VARCHAR(20) NOT NULL,
+    decided_by UUID REFERENCES customers(id),
+    decided_at TIMESTAMPTZ,
+    reject_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_business_orders_account ON business_orders (account_id, created_at);
+
+-- Charges and payments on business accounts. Charges are positive and fall
+-- due on due_on; payments are negative.
+CREATE TABLE business_ledger_entries (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES business_accounts(id),
+    type VARCHAR(20) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    order_id UUID UNIQUE,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    due_on DATE,
+    posted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_business_ledger_entries_account ON business_ledger_entries (account_id, posted_at);
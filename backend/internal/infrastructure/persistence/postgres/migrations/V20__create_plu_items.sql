@@ -0,0 +1,16 @@
+-- Price look-up codes for products sold by weight. code is the item number
+-- scales and variable-measure barcodes carry; price_per_kg_cents is the
+-- shelf price packs of it are priced at.
+CREATE TABLE plu_items (
+    id UUID PRIMARY KEY,
+    code INTEGER NOT NULL CHECK (code BETWEEN 1 AND 99999),
+    product_id UUID NOT NULL,
+    description VARCHAR(200) NOT NULL,
+    price_per_kg_cents BIGINT NOT NULL CHECK (price_per_kg_cents >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_plu_items_code ON plu_items (code);
+CREATE INDEX idx_plu_items_product ON plu_items (product_id);
@@ -0,0 +1,42 @@
+-- What pack labels say about a PLU item: the declarable allergens it
+-- contains and how many days a pack keeps (0 when packs are only dated by
+-- their lot).
+ALTER TABLE plu_items
+    ADD COLUMN allergens TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN shelf_life_days INTEGER NOT NULL DEFAULT 0 CHECK (shelf_life_days >= 0);
+
+-- Label printers on the shop's network. address is host:port of the
+-- printer's raw print port, usually 9100.
+CREATE TABLE label_printers (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_label_printers_name ON label_printers (LOWER(name));
+
+-- The print queue. payload is the ZPL sent to the printer; a queued job is
+-- sent once next_attempt_at has passed, which also leases it to whoever is
+-- sending it.
+CREATE TABLE print_jobs (
+    id UUID PRIMARY KEY,
+    printer_id UUID NOT NULL REFERENCES label_printers(id),
+    source VARCHAR(20) NOT NULL,
+    source_id UUID NOT NULL,
+    template VARCHAR(50) NOT NULL,
+    labels INTEGER NOT NULL CHECK (labels > 0),
+    payload BYTEA NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    printed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_print_jobs_due ON print_jobs (next_attempt_at) WHERE status = 'queued';
+CREATE INDEX idx_print_jobs_printer ON print_jobs (printer_id, created_at);
@@ -0,0 +1,16 @@
+-- Weighing scales the back office reads weights from. address is host:port
+-- for tcp scales and a device such as /dev/ttyUSB0 for serial ones, which
+-- alone use baud_rate.
+CREATE TABLE scales (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    protocol VARCHAR(20) NOT NULL,
+    transport VARCHAR(20) NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    baud_rate INTEGER NOT NULL DEFAULT 0 CHECK (baud_rate >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_scales_name ON scales (LOWER(name));
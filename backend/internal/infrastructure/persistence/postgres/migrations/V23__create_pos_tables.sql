@@ -0,0 +1,90 @@
+-- Tills at a branch's counter.
+CREATE TABLE pos_tills (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_pos_tills_branch_name ON pos_tills (branch_id, LOWER(name));
+
+-- A cashier's session on a till, from the float going in to the drawer being
+-- counted. A till has at most one open session.
+CREATE TABLE pos_sessions (
+    id UUID PRIMARY KEY,
+    till_id UUID NOT NULL REFERENCES pos_tills(id),
+    branch_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    float_cents BIGINT NOT NULL CHECK (float_cents >= 0),
+    counted_cash_cents BIGINT CHECK (counted_cash_cents >= 0),
+    opened_by UUID NOT NULL REFERENCES admins(id),
+    closed_by UUID REFERENCES admins(id),
+    opened_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX uq_pos_sessions_open_till ON pos_sessions (till_id) WHERE status = 'open';
+CREATE INDEX idx_pos_sessions_till ON pos_sessions (till_id, opened_at);
+
+-- Walk-in sales and the returns taken against them. Receipt numbers are
+-- given when a sale is paid for, and to returns when they are made.
+CREATE SEQUENCE pos_receipt_seq;
+
+CREATE TABLE pos_sales (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    session_id UUID NOT NULL REFERENCES pos_sessions(id),
+    till_id UUID NOT NULL REFERENCES pos_tills(id),
+    branch_id UUID NOT NULL,
+    cashier_id UUID NOT NULL REFERENCES admins(id),
+    status VARCHAR(20) NOT NULL,
+    receipt_number VARCHAR(20) UNIQUE,
+    original_sale_id UUID REFERENCES pos_sales(id),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    voided_by UUID REFERENCES admins(id),
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    voided_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pos_sales_session ON pos_sales (session_id, created_at);
+
+CREATE TABLE pos_sale_lines (
+    id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES pos_sales(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    product_id UUID NOT NULL,
+    plu_code INTEGER NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    barcode VARCHAR(13) NOT NULL DEFAULT '',
+    grams BIGINT NOT NULL CHECK (grams > 0),
+    price_per_kg_cents BIGINT NOT NULL CHECK (price_per_kg_cents >= 0),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    tax_category VARCHAR(50) NOT NULL DEFAULT '',
+    vat_rate_bp INTEGER NOT NULL DEFAULT 0,
+    net_cents BIGINT NOT NULL DEFAULT 0,
+    vat_cents BIGINT NOT NULL DEFAULT 0,
+    gross_cents BIGINT NOT NULL DEFAULT 0,
+    voided BOOLEAN NOT NULL DEFAULT FALSE,
+    returned_grams BIGINT NOT NULL DEFAULT 0 CHECK (returned_grams >= 0 AND returned_grams <= grams),
+    returned_cents BIGINT NOT NULL DEFAULT 0 CHECK (returned_cents >= 0),
+    original_line_id UUID REFERENCES pos_sale_lines(id),
+    UNIQUE (sale_id, position)
+);
+
+CREATE TABLE pos_payments (
+    id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES pos_sales(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    tendered_cents BIGINT NOT NULL CHECK (tendered_cents >= amount_cents),
+    change_cents BIGINT NOT NULL DEFAULT 0 CHECK (change_cents >= 0),
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    taken_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (sale_id, position)
+);
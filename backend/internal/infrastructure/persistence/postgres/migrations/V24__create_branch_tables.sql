@@ -0,0 +1,38 @@
+-- The shops. Stock, fulfilment slots, tills and orders already carry a
+-- branch_id; this gives it a name, address and contact details. Trading
+-- hours are the branch's opening hours.
+CREATE TABLE branches (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    address TEXT[] NOT NULL,
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_branches_name ON branches (LOWER(name));
+
+-- What a branch charges a kilogram for a product instead of its usual price.
+CREATE TABLE branch_prices (
+    branch_id UUID NOT NULL REFERENCES branches(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    price_per_kg_cents BIGINT NOT NULL CHECK (price_per_kg_cents >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (branch_id, product_id)
+);
+
+-- The branches an admin works at. Admins of every branch have none listed.
+ALTER TABLE admins ADD COLUMN all_branches BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Admins from before branches existed keep seeing everything.
+UPDATE admins SET all_branches = TRUE;
+
+CREATE TABLE admin_branches (
+    admin_id UUID NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    branch_id UUID NOT NULL REFERENCES branches(id) ON DELETE CASCADE,
+    PRIMARY KEY (admin_id, branch_id)
+);
+
+CREATE INDEX idx_admin_branches_branch ON admin_branches (branch_id);
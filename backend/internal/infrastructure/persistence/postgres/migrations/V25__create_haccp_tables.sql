@@ -0,0 +1,61 @@
+-- Fridges, freezers and display counters whose temperatures are recorded
+-- for food safety inspections. Units with a sensor_id push their readings;
+-- the rest are read by hand at least every check_every_minutes.
+CREATE TABLE haccp_units (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL REFERENCES branches(id),
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    min_celsius NUMERIC(4,1) NOT NULL,
+    max_celsius NUMERIC(4,1) NOT NULL,
+    sensor_id VARCHAR(100) NOT NULL DEFAULT '',
+    check_every_minutes INT NOT NULL CHECK (check_every_minutes > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (min_celsius < max_celsius)
+);
+
+CREATE UNIQUE INDEX uq_haccp_units_branch_name ON haccp_units (branch_id, LOWER(name));
+CREATE UNIQUE INDEX uq_haccp_units_sensor ON haccp_units (sensor_id) WHERE sensor_id <> '';
+
+CREATE TABLE haccp_readings (
+    id UUID PRIMARY KEY,
+    unit_id UUID NOT NULL REFERENCES haccp_units(id),
+    celsius NUMERIC(4,1) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    recorded_by UUID REFERENCES admins(id),
+    note TEXT NOT NULL DEFAULT '',
+    in_range BOOLEAN NOT NULL,
+    taken_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_haccp_readings_unit ON haccp_readings (unit_id, taken_at);
+
+-- Alarms on units, open until a corrective action resolves them. A unit has
+-- at most one open alarm of each kind.
+CREATE TABLE haccp_alerts (
+    id UUID PRIMARY KEY,
+    unit_id UUID NOT NULL REFERENCES haccp_units(id),
+    kind VARCHAR(20) NOT NULL,
+    reading_id UUID REFERENCES haccp_readings(id),
+    celsius NUMERIC(4,1),
+    raised_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    resolved_by UUID REFERENCES admins(id)
+);
+
+CREATE UNIQUE INDEX uq_haccp_alerts_open ON haccp_alerts (unit_id, kind) WHERE resolved_at IS NULL;
+CREATE INDEX idx_haccp_alerts_raised ON haccp_alerts (raised_at);
+
+CREATE TABLE haccp_corrective_actions (
+    id UUID PRIMARY KEY,
+    unit_id UUID NOT NULL REFERENCES haccp_units(id),
+    alert_id UUID REFERENCES haccp_alerts(id),
+    action TEXT NOT NULL,
+    taken_by UUID NOT NULL REFERENCES admins(id),
+    taken_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_haccp_corrective_actions_unit ON haccp_corrective_actions (unit_id, taken_at);
@@ -0,0 +1,29 @@
+-- What a lot's stock cost, so losses can be valued, and how far its price
+-- has been marked down as it nears its use-by date (expires_on).
+ALTER TABLE inventory_lots
+    ADD COLUMN cost_per_kg_cents BIGINT NOT NULL DEFAULT 0 CHECK (cost_per_kg_cents >= 0),
+    ADD COLUMN markdown_bp INT NOT NULL DEFAULT 0 CHECK (markdown_bp >= 0 AND markdown_bp < 10000);
+
+CREATE INDEX idx_inventory_lots_expires_on ON inventory_lots (expires_on) WHERE on_hand_grams > 0;
+CREATE INDEX idx_stock_movements_occurred_at ON stock_movements (occurred_at);
+
+-- Markdowns and write-offs the daily expiry scan proposes for lots near or
+-- past their use-by date, until staff accept or dismiss them. A lot gets at
+-- most one proposal of each kind, so a dismissed proposal is not raised
+-- again by the next scan.
+CREATE TABLE expiry_proposals (
+    id UUID PRIMARY KEY,
+    lot_id UUID NOT NULL REFERENCES inventory_lots(id),
+    kind VARCHAR(20) NOT NULL,
+    use_by DATE NOT NULL,
+    on_hand_grams BIGINT NOT NULL,
+    markdown_bp INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    wasted_grams BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    decided_at TIMESTAMPTZ,
+    decided_by UUID REFERENCES admins(id),
+    UNIQUE (lot_id, kind)
+);
+
+CREATE INDEX idx_expiry_proposals_status ON expiry_proposals (status, created_at);
@@ -0,0 +1,56 @@
+-- Employment records of admins who work shifts: their home branch, what
+-- they are trained to do, their pay and their contracted weekly minutes.
+CREATE TABLE staff_profiles (
+    admin_id UUID PRIMARY KEY REFERENCES admins(id),
+    branch_id UUID NOT NULL REFERENCES branches(id),
+    position VARCHAR(20) NOT NULL,
+    hourly_rate_cents BIGINT NOT NULL CHECK (hourly_rate_cents >= 0),
+    contracted_minutes INT NOT NULL DEFAULT 0 CHECK (contracted_minutes >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_staff_profiles_branch ON staff_profiles (branch_id);
+
+-- The rota: shifts staff are rostered for at a branch, with the unpaid
+-- break they are to take.
+CREATE TABLE staff_shifts (
+    id UUID PRIMARY KEY,
+    admin_id UUID NOT NULL REFERENCES staff_profiles(admin_id),
+    branch_id UUID NOT NULL REFERENCES branches(id),
+    position VARCHAR(20) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    break_minutes INT NOT NULL DEFAULT 0 CHECK (break_minutes >= 0),
+    note TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES admins(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX idx_staff_shifts_branch ON staff_shifts (branch_id, starts_at);
+CREATE INDEX idx_staff_shifts_admin ON staff_shifts (admin_id, starts_at);
+
+-- The time clock. An entry stays open until its member of staff clocks
+-- out, and each has at most one open entry.
+CREATE TABLE staff_clock_entries (
+    id UUID PRIMARY KEY,
+    admin_id UUID NOT NULL REFERENCES staff_profiles(admin_id),
+    branch_id UUID NOT NULL REFERENCES branches(id),
+    clocked_in_at TIMESTAMPTZ NOT NULL,
+    clocked_out_at TIMESTAMPTZ,
+    CHECK (clocked_out_at IS NULL OR clocked_out_at >= clocked_in_at)
+);
+
+CREATE UNIQUE INDEX uq_staff_clock_entries_open ON staff_clock_entries (admin_id) WHERE clocked_out_at IS NULL;
+CREATE INDEX idx_staff_clock_entries_branch ON staff_clock_entries (branch_id, clocked_in_at);
+
+CREATE TABLE staff_breaks (
+    entry_id UUID NOT NULL REFERENCES staff_clock_entries(id),
+    position INT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    PRIMARY KEY (entry_id, position)
+);
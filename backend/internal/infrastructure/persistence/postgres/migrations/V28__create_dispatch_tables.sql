@@ -0,0 +1,72 @@
+-- Profiles of admins with the driver role: the branch they deliver from,
+-- their vehicle and a number to reach them on.
+CREATE TABLE drivers (
+    admin_id UUID PRIMARY KEY REFERENCES admins(id),
+    branch_id UUID NOT NULL REFERENCES branches(id),
+    phone VARCHAR(30) NOT NULL DEFAULT '',
+    vehicle VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_drivers_branch ON drivers (branch_id);
+
+-- Batches of delivery orders for one zone and slot that a driver takes out
+-- together, routed from an optional start point.
+CREATE TABLE delivery_runs (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL REFERENCES branches(id),
+    zone_id UUID NOT NULL REFERENCES delivery_zones(id),
+    slot_starts_at TIMESTAMPTZ NOT NULL,
+    slot_ends_at TIMESTAMPTZ NOT NULL,
+    start_latitude DOUBLE PRECISION,
+    start_longitude DOUBLE PRECISION,
+    driver_id UUID REFERENCES drivers(admin_id),
+    status VARCHAR(20) NOT NULL,
+    distance_metres INT NOT NULL DEFAULT 0,
+    created_by UUID NOT NULL REFERENCES admins(id),
+    version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    CHECK (slot_starts_at < slot_ends_at)
+);
+
+CREATE INDEX idx_delivery_runs_branch_slot ON delivery_runs (branch_id, slot_starts_at);
+CREATE INDEX idx_delivery_runs_driver ON delivery_runs (driver_id, slot_starts_at);
+
+-- The orders on a run in the order they are to be delivered, with the
+-- address they go to copied from the customer's address book. An order is
+-- on at most one run.
+CREATE TABLE delivery_stops (
+    id UUID PRIMARY KEY,
+    run_id UUID NOT NULL REFERENCES delivery_runs(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id),
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    address_id UUID REFERENCES customer_addresses(id) ON DELETE SET NULL,
+    address_lines TEXT[] NOT NULL DEFAULT '{}',
+    postcode VARCHAR(20) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    sequence INT NOT NULL CHECK (sequence > 0),
+    status VARCHAR(20) NOT NULL,
+    recipient_name VARCHAR(255) NOT NULL DEFAULT '',
+    failure_reason TEXT NOT NULL DEFAULT '',
+    proofs TEXT[] NOT NULL DEFAULT '{}',
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_delivery_stops_run ON delivery_stops (run_id, sequence);
+
+-- Photos and signatures drivers upload as proof of delivery, one of each
+-- kind per stop.
+CREATE TABLE delivery_proofs (
+    stop_id UUID NOT NULL REFERENCES delivery_stops(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (stop_id, kind)
+);
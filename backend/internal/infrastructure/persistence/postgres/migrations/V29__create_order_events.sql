@@ -0,0 +1,80 @@
+-- How far the cutting room has got with each order.
+ALTER TABLE orders ADD COLUMN stage VARCHAR(20) NOT NULL DEFAULT 'received';
+
+-- Every change to an order its customer or the cutting room follows,
+-- recorded by the triggers below whichever instance made the change.
+-- Clients pick up where they left off by asking for the events after the
+-- last ID they saw.
+CREATE TABLE order_events (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id),
+    customer_id UUID NOT NULL,
+    branch_id UUID,
+    status VARCHAR(20) NOT NULL,
+    stage VARCHAR(20) NOT NULL,
+    delivery VARCHAR(20) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_events_customer ON order_events (customer_id, id);
+CREATE INDEX idx_order_events_branch ON order_events (branch_id, id);
+
+CREATE FUNCTION record_order_event() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.status IS DISTINCT FROM OLD.status OR NEW.stage IS DISTINCT FROM OLD.stage THEN
+        INSERT INTO order_events (order_id, customer_id, branch_id, status, stage)
+        VALUES (NEW.id, NEW.customer_id, NEW.branch_id, NEW.status, NEW.stage);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_record_event
+    AFTER INSERT OR UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION record_order_event();
+
+-- A run setting off takes every order on it out for delivery.
+CREATE FUNCTION record_delivery_run_event() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status = 'started' AND OLD.status IS DISTINCT FROM 'started' THEN
+        INSERT INTO order_events (order_id, customer_id, branch_id, status, stage, delivery)
+        SELECT o.id, o.customer_id, o.branch_id, o.status, o.stage, 'out_for_delivery'
+        FROM delivery_stops s JOIN orders o ON o.id = s.order_id
+        WHERE s.run_id = NEW.id AND s.status = 'pending'
+        ORDER BY s.sequence;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER delivery_runs_record_event
+    AFTER UPDATE ON delivery_runs
+    FOR EACH ROW EXECUTE FUNCTION record_delivery_run_event();
+
+CREATE FUNCTION record_delivery_stop_event() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status IN ('delivered', 'failed') AND NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO order_events (order_id, customer_id, branch_id, status, stage, delivery)
+        SELECT o.id, o.customer_id, o.branch_id, o.status, o.stage, NEW.status
+        FROM orders o WHERE o.id = NEW.order_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER delivery_stops_record_event
+    AFTER UPDATE ON delivery_stops
+    FOR EACH ROW EXECUTE FUNCTION record_delivery_stop_event();
+
+-- Tells every listening instance about a new event once its transaction
+-- commits. The payload is just the ID; listeners read the event itself.
+CREATE FUNCTION notify_order_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('order_events', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER order_events_notify
+    AFTER INSERT ON order_events
+    FOR EACH ROW EXECUTE FUNCTION notify_order_event();
@@ -0,0 +1,31 @@
+-- Event IDs are taken as events are inserted, not as their transactions
+-- commit, so an event could become visible after one with a higher ID
+-- and be skipped by a client resuming from that. Each event is also given
+-- a position as its transaction commits, under a lock held until the
+-- commit is visible, so positions become visible strictly in order.
+-- Clients resume from positions instead of IDs.
+CREATE SEQUENCE order_event_positions;
+
+ALTER TABLE order_events ADD COLUMN position BIGINT;
+UPDATE order_events SET position = id;
+SELECT setval('order_event_positions', COALESCE(MAX(position), 0) + 1, false) FROM order_events;
+ALTER TABLE order_events ADD CONSTRAINT order_events_position_key UNIQUE (position);
+
+DROP INDEX idx_order_events_customer;
+DROP INDEX idx_order_events_branch;
+CREATE INDEX idx_order_events_customer ON order_events (customer_id, position);
+CREATE INDEX idx_order_events_branch ON order_events (branch_id, position);
+
+CREATE FUNCTION position_order_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('order_event_positions'));
+    UPDATE order_events SET position = nextval('order_event_positions') WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Deferred to commit, and run in the order the events were inserted.
+CREATE CONSTRAINT TRIGGER order_events_position
+    AFTER INSERT ON order_events
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION position_order_event();
@@ -0,0 +1,12 @@
+-- Delivered orders carry the zone's fee, priced and taxed as a line is.
+-- Collected orders, and orders placed before delivery was charged, have none.
+ALTER TABLE orders
+    ADD COLUMN delivery_zone_id UUID REFERENCES delivery_zones(id),
+    ADD COLUMN delivery_tax_category VARCHAR(50),
+    ADD COLUMN delivery_fee_cents BIGINT CHECK (delivery_fee_cents >= 0),
+    ADD COLUMN delivery_vat_rate_bp INTEGER,
+    ADD COLUMN delivery_discount_cents BIGINT,
+    ADD COLUMN delivery_net_cents BIGINT,
+    ADD COLUMN delivery_vat_cents BIGINT,
+    ADD COLUMN delivery_gross_cents BIGINT,
+    ADD CHECK ((delivery_zone_id IS NULL) = (delivery_gross_cents IS NULL));
@@ -0,0 +1,4 @@
+-- Refunds take back part of the points an order earned, once per refund,
+-- so only reversals without a reference of their own must be one per entry.
+DROP INDEX uq_loyalty_entries_source;
+CREATE UNIQUE INDEX uq_loyalty_entries_source ON loyalty_entries (source_id) WHERE source_id IS NOT NULL AND reference = '';
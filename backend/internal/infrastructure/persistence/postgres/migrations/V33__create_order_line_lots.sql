@@ -0,0 +1,11 @@
+-- The lots each order line was sold from, recorded when the stock held for
+-- the order is fulfilled, so a recall can be traced to the customers it
+-- reached.
+CREATE TABLE order_line_lots (
+    order_line_id UUID NOT NULL REFERENCES order_lines(id) ON DELETE CASCADE,
+    lot_id UUID NOT NULL REFERENCES inventory_lots(id),
+    grams BIGINT NOT NULL CHECK (grams > 0),
+    PRIMARY KEY (order_line_id, lot_id)
+);
+
+CREATE INDEX idx_order_line_lots_lot ON order_line_lots(lot_id);
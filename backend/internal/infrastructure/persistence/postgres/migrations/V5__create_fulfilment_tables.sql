@@ -0,0 +1,60 @@
+CREATE TABLE branch_opening_hours (
+    branch_id UUID NOT NULL,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens_at SMALLINT NOT NULL,
+    closes_at SMALLINT NOT NULL,
+    PRIMARY KEY (branch_id, weekday)
+);
+
+CREATE TABLE branch_closures (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL,
+    closed_on DATE NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_branch_closures_branch_date ON branch_closures(branch_id, closed_on);
+
+CREATE TABLE slot_templates (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL,
+    zone_id UUID,
+    method VARCHAR(20) NOT NULL,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    starts_at SMALLINT NOT NULL,
+    ends_at SMALLINT NOT NULL,
+    capacity_unit VARCHAR(10) NOT NULL,
+    capacity BIGINT NOT NULL CHECK (capacity > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_slot_templates_branch ON slot_templates(branch_id);
+CREATE INDEX idx_slot_templates_zone ON slot_templates(zone_id);
+
+-- One row per slot occurrence that has been reserved at least once. Reservations
+-- lock this row so that concurrent checkouts for the same slot are serialised.
+CREATE TABLE fulfilment_slots (
+    template_id UUID NOT NULL REFERENCES slot_templates(id),
+    starts_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (template_id, starts_at)
+);
+
+CREATE TABLE slot_reservations (
+    id UUID PRIMARY KEY,
+    template_id UUID NOT NULL,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    slot_starts_at TIMESTAMPTZ NOT NULL,
+    slot_ends_at TIMESTAMPTZ NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL,
+    order_id UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (template_id, slot_starts_at) REFERENCES fulfilment_slots(template_id, starts_at)
+);
+
+CREATE INDEX idx_slot_reservations_slot ON slot_reservations(template_id, slot_starts_at);
+CREATE INDEX idx_slot_reservations_customer ON slot_reservations(customer_id);
@@ -0,0 +1,34 @@
+CREATE TABLE customer_addresses (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    line1 VARCHAR(255) NOT NULL,
+    line2 VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    postcode VARCHAR(20) NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE INDEX idx_customer_addresses_customer ON customer_addresses(customer_id);
+CREATE UNIQUE INDEX idx_customer_addresses_default ON customer_addresses(customer_id) WHERE is_default;
+
+-- A zone is drawn as a GeoJSON MultiPolygon, listed as postcode prefixes, or both.
+CREATE TABLE delivery_zones (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    area JSONB,
+    postcode_prefixes TEXT[] NOT NULL DEFAULT '{}',
+    delivery_fee_cents BIGINT NOT NULL CHECK (delivery_fee_cents >= 0),
+    minimum_order_cents BIGINT NOT NULL CHECK (minimum_order_cents >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE slot_templates
+    ADD CONSTRAINT fk_slot_templates_zone FOREIGN KEY (zone_id) REFERENCES delivery_zones(id);
@@ -0,0 +1,67 @@
+CREATE TABLE inventory_lots (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    branch_id UUID NOT NULL,
+    code VARCHAR(100) NOT NULL,
+    received_grams BIGINT NOT NULL CHECK (received_grams > 0),
+    on_hand_grams BIGINT NOT NULL CHECK (on_hand_grams >= 0),
+    expires_on DATE,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_lots_product_branch ON inventory_lots(product_id, branch_id);
+
+-- The stock ledger. on_hand_grams on a lot is always the sum of its movements;
+-- corrections are made by appending an adjustment, never by editing history.
+CREATE TABLE stock_movements (
+    id UUID PRIMARY KEY,
+    lot_id UUID NOT NULL REFERENCES inventory_lots(id),
+    product_id UUID NOT NULL,
+    branch_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    delta_grams BIGINT NOT NULL CHECK (delta_grams <> 0),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    reference_id UUID,
+    actor_id UUID,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_movements_lot ON stock_movements(lot_id);
+CREATE INDEX idx_stock_movements_product_branch ON stock_movements(product_id, branch_id, occurred_at);
+
+CREATE FUNCTION reject_stock_movement_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_append_only
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION reject_stock_movement_change();
+
+CREATE TABLE inventory_settings (
+    product_id UUID PRIMARY KEY,
+    low_stock_threshold_grams BIGINT NOT NULL CHECK (low_stock_threshold_grams >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    status VARCHAR(20) NOT NULL,
+    order_id UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_reservations_customer ON stock_reservations(customer_id);
+
+CREATE TABLE stock_reservation_lines (
+    reservation_id UUID NOT NULL REFERENCES stock_reservations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    grams BIGINT NOT NULL CHECK (grams > 0),
+    PRIMARY KEY (reservation_id, product_id)
+);
+
+CREATE INDEX idx_stock_reservation_lines_product ON stock_reservation_lines(product_id);
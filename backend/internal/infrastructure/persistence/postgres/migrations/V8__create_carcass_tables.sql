@@ -0,0 +1,47 @@
+CREATE TABLE carcasses (
+    id UUID PRIMARY KEY,
+    branch_id UUID NOT NULL,
+    tag VARCHAR(100) NOT NULL UNIQUE,
+    species VARCHAR(20) NOT NULL,
+    hanging_weight_grams BIGINT NOT NULL CHECK (hanging_weight_grams > 0),
+    cost_cents BIGINT NOT NULL CHECK (cost_cents >= 0),
+    supplier VARCHAR(255) NOT NULL,
+    slaughtered_on DATE NOT NULL,
+    halal_certificate_ref VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_carcasses_branch ON carcasses(branch_id, received_at);
+
+CREATE TABLE carcass_breakdowns (
+    carcass_id UUID PRIMARY KEY REFERENCES carcasses(id),
+    trim_grams BIGINT NOT NULL CHECK (trim_grams >= 0),
+    waste_grams BIGINT NOT NULL CHECK (waste_grams >= 0),
+    recorded_by UUID,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Every cut is received into inventory as its own lot, so lot_id links a
+-- piece of stock back to the carcass it was cut from.
+CREATE TABLE carcass_cuts (
+    lot_id UUID PRIMARY KEY REFERENCES inventory_lots(id),
+    carcass_id UUID NOT NULL REFERENCES carcass_breakdowns(carcass_id),
+    position SMALLINT NOT NULL,
+    primal VARCHAR(100) NOT NULL,
+    product_id UUID NOT NULL,
+    grams BIGINT NOT NULL CHECK (grams > 0),
+    expires_on DATE
+);
+
+CREATE INDEX idx_carcass_cuts_carcass ON carcass_cuts(carcass_id, position);
+
+CREATE TABLE yield_templates (
+    species VARCHAR(20) PRIMARY KEY,
+    expected_yield_bp INTEGER NOT NULL,
+    expected_trim_bp INTEGER NOT NULL,
+    expected_waste_bp INTEGER NOT NULL,
+    primals JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
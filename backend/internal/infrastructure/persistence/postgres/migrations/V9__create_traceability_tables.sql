@@ -0,0 +1,33 @@
+CREATE TABLE halal_certificates (
+    id UUID PRIMARY KEY,
+    issuer VARCHAR(255) NOT NULL,
+    number VARCHAR(100) NOT NULL UNIQUE,
+    slaughterhouse VARCHAR(255) NOT NULL,
+    valid_from DATE NOT NULL,
+    valid_until DATE NOT NULL,
+    document_url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (valid_until >= valid_from)
+);
+
+CREATE INDEX idx_halal_certificates_valid_until ON halal_certificates(valid_until);
+
+-- certificate_number is deliberately not a foreign key: a lot can be booked
+-- in against a certificate before the certificate itself is registered.
+CREATE TABLE lot_origins (
+    lot_id UUID PRIMARY KEY REFERENCES inventory_lots(id),
+    carcass_id UUID REFERENCES carcasses(id),
+    farm VARCHAR(255) NOT NULL,
+    slaughtered_on DATE NOT NULL,
+    certificate_number VARCHAR(100) NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_lots_code ON inventory_lots(code);
+
+-- Lots already cut from carcasses take their origin from the carcass.
+INSERT INTO lot_origins (lot_id, carcass_id, farm, slaughtered_on, certificate_number, recorded_at)
+SELECT cc.lot_id, c.id, c.supplier, c.slaughtered_on, c.halal_certificate_ref, b.recorded_at
+FROM carcass_cuts cc
+JOIN carcass_breakdowns b ON b.carcass_id = cc.carcass_id
+JOIN carcasses c ON c.id = cc.carcass_id;
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// orderEventChannel is the channel V29's trigger notifies of each new
+// order event, with the event's row ID, not its position, as the payload.
+const orderEventChannel = "order_events"
+
+// orderEventColumns reads an event's position, given as its transaction
+// commits, as its ID; see V30.
+const orderEventColumns = "position, order_id, customer_id, branch_id, status, stage, delivery, created_at"
+
+// OrderEventRepository implements order.EventRepository using PostgreSQL.
+type OrderEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderEventRepository creates a new OrderEventRepository.
+func NewOrderEventRepository(pool *pgxpool.Pool) *OrderEventRepository {
+	return &OrderEventRepository{pool: pool}
+}
+
+// FindEvents returns up to limit events at branches in scope matching f,
+// oldest first.
+func (r *OrderEventRepository) FindEvents(ctx context.Context, f order.EventFilter, limit int) ([]order.Event, error) {
+	scope, args := branchScope(ctx, "branch_id", []any{f.AfterID})
+	conds := []string{"position > $1", scope}
+	if f.CustomerID != nil {
+		args = append(args, *f.CustomerID)
+		conds = append(conds, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+	if f.BranchID != nil {
+		args = append(args, *f.BranchID)
+		conds = append(conds, fmt.Sprintf("branch_id = $%d", len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+orderEventColumns+" FROM order_events WHERE "+strings.Join(conds, " AND ")+
+			fmt.Sprintf(" ORDER BY position LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying order events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []order.Event
+	for rows.Next() {
+		e, err := scanOrderEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning order event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func scanOrderEvent(row pgx.Row) (order.Event, error) {
+	var e order.Event
+	var branchID *uuid.UUID
+	var status, stage, delivery string
+	if err := row.Scan(&e.ID, &e.OrderID, &e.CustomerID, &branchID, &status, &stage, &delivery, &e.At); err != nil {
+		return order.Event{}, err
+	}
+	// Orders recorded before branches were tracked have none.
+	if branchID != nil {
+		e.BranchID = *branchID
+	}
+	e.Status = order.Status(status)
+	e.Stage = order.Stage(stage)
+	e.Delivery = order.Delivery(delivery)
+	return e, nil
+}
+
+// OrderEventFeed implements order.EventFeed with PostgreSQL LISTEN, so
+// every instance hears of the events any of them records.
+type OrderEventFeed struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderEventFeed creates a new OrderEventFeed.
+func NewOrderEventFeed(pool *pgxpool.Pool) *OrderEventFeed {
+	return &OrderEventFeed{pool: pool}
+}
+
+// Listen takes a connection from the pool for the subscription's sole use
+// and listens on it.
+func (f *OrderEventFeed) Listen(ctx context.Context) (order.EventSubscription, error) {
+	conn, err := f.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection: %w", err)
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+orderEventChannel); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("listening for order events: %w", err)
+	}
+	done, stop := context.WithCancel(context.Background())
+	return &orderEventSubscription{pool: f.pool, conn: conn, done: done, stop: stop}, nil
+}
+
+// orderEventSubscription waits for notifications on conn and reads each
+// event they name. mu is held while waiting, so Close can wait for Next to
+// let go of the connection.
+type orderEventSubscription struct {
+	pool *pgxpool.Pool
+	conn *pgxpool.Conn
+	done context.Context
+	stop context.CancelFunc
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Next waits for the next notification and reads its event.
+func (s *orderEventSubscription) Next(ctx context.Context) (order.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return order.Event{}, errors.New("order event subscription is closed")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(s.done, cancel)()
+
+	n, err := s.conn.Conn().WaitForNotification(ctx)
+	if err != nil {
+		return order.Event{}, fmt.Errorf("waiting for order event: %w", err)
+	}
+	id, err := strconv.ParseInt(n.Payload, 10, 64)
+	if err != nil {
+		return order.Event{}, fmt.Errorf("parsing order event ID %q: %w", n.Payload, err)
+	}
+
+	queryCtx, cancelQuery := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelQuery()
+	e, err := scanOrderEvent(s.pool.QueryRow(queryCtx, "SELECT "+orderEventColumns+" FROM order_events WHERE id = $1", id))
+	if err != nil {
+		return order.Event{}, fmt.Errorf("querying order event %d: %w", id, err)
+	}
+	return e, nil
+}
+
+// Close stops listening. The connection is closed rather than returned to
+// the pool, as a wait cut short can leave it unusable.
+func (s *orderEventSubscription) Close() error {
+	s.stop()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.conn.Hijack().Close(ctx)
+}
@@ -0,0 +1,101 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationOrderEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	orders := pgstore.NewOrderRepository(pool)
+	events := pgstore.NewOrderEventRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "events@example.com")
+	branchID := uuid.New()
+
+	sub, err := pgstore.NewOrderEventFeed(pool).Listen(ctx)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	o, err := order.NewOrder(uuid.New(), customerID, branchID, []order.Line{
+		{ProductID: uuid.New(), Grams: 1000, PricePerKgCents: 1500, NetCents: 1500, GrossCents: 1500},
+	}, nil, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, orders.Create(ctx, o))
+	require.NoError(t, o.Advance(order.StageReady, time.Now()))
+	require.NoError(t, orders.Update(ctx, o))
+
+	t.Run("records placing and stage changes", func(t *testing.T) {
+		found, err := events.FindEvents(ctx, order.EventFilter{CustomerID: &customerID}, 10)
+
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, order.StageReceived, found[0].Stage)
+		assert.Equal(t, order.StageReady, found[1].Stage)
+		assert.Equal(t, order.StatusPlaced, found[1].Status)
+		assert.Equal(t, branchID, found[1].BranchID)
+		assert.Greater(t, found[1].ID, found[0].ID)
+
+		after, err := events.FindEvents(ctx, order.EventFilter{CustomerID: &customerID, AfterID: found[0].ID}, 10)
+		require.NoError(t, err)
+		require.Len(t, after, 1)
+		assert.Equal(t, found[1].ID, after[0].ID)
+	})
+
+	t.Run("scopes by branch", func(t *testing.T) {
+		found, err := events.FindEvents(branch.WithScope(ctx, branch.Only(uuid.New())), order.EventFilter{}, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("resumes in commit order", func(t *testing.T) {
+		insert := "INSERT INTO order_events (order_id, customer_id, branch_id, status, stage) VALUES ($1, $2, $3, 'placed', 'received')"
+		late, err := pool.Begin(ctx)
+		require.NoError(t, err)
+		defer late.Rollback(ctx)
+		_, err = late.Exec(ctx, insert, o.ID(), customerID, branchID)
+		require.NoError(t, err)
+		early, err := pool.Begin(ctx)
+		require.NoError(t, err)
+		defer early.Rollback(ctx)
+		_, err = early.Exec(ctx, insert, o.ID(), customerID, branchID)
+		require.NoError(t, err)
+
+		require.NoError(t, early.Commit(ctx))
+		seen, err := events.FindEvents(ctx, order.EventFilter{CustomerID: &customerID}, 10)
+		require.NoError(t, err)
+		require.Len(t, seen, 3)
+		require.NoError(t, late.Commit(ctx))
+
+		after, err := events.FindEvents(ctx, order.EventFilter{CustomerID: &customerID, AfterID: seen[2].ID}, 10)
+		require.NoError(t, err)
+		assert.Len(t, after, 1, "the event inserted first but committed last is not skipped")
+	})
+
+	t.Run("notifies listeners", func(t *testing.T) {
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		first, err := sub.Next(waitCtx)
+		require.NoError(t, err)
+		second, err := sub.Next(waitCtx)
+		require.NoError(t, err)
+
+		assert.Equal(t, o.ID(), first.OrderID)
+		assert.Equal(t, order.StageReady, second.Stage)
+	})
+}
@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+)
+
+// OrderRepository implements order.Repository using PostgreSQL.
+type OrderRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderRepository creates a new OrderRepository.
+func NewOrderRepository(pool *pgxpool.Pool) *OrderRepository {
+	return &OrderRepository{pool: pool}
+}
+
+const orderColumns = "id, customer_id, branch_id, status, stage, refunded_cents, created_by, version, created_at, updated_at, " +
+	"delivery_zone_id, delivery_tax_category, delivery_fee_cents, delivery_vat_rate_bp, delivery_discount_cents, " +
+	"delivery_net_cents, delivery_vat_cents, delivery_gross_cents"
+
+const orderLineColumns = "id, product_id, description, grams, price_per_kg_cents, tax_category, vat_rate_bp, " +
+	"discount_cents, net_cents, vat_cents, gross_cents, refunded_cents"
+
+const orderRefundColumns = "id, order_id, kind, line_id, delivered_grams, reason, note, amount_cents, status, " +
+	"idempotency_key, requested_by, decided_by, payment_id, payment_refund_id, receipt_number, created_at, " +
+	"decided_at, issued_at"
+
+// Create inserts a new order and its lines. Admins may only take orders
+// for branches in scope.
+func (r *OrderRepository) Create(ctx context.Context, o *order.Order) error {
+	if err := branch.ScopeFrom(ctx).Check(o.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	d := deliveryRow{}
+	if c := o.Delivery(); c != nil {
+		d = deliveryRow{&c.ZoneID, &c.TaxCategory, &c.FeeCents, &c.VATRateBP, &c.DiscountCents, &c.NetCents, &c.VATCents, &c.GrossCents}
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO orders (`+orderColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+		o.ID(), o.CustomerID(), o.BranchID(), string(o.Status()), string(o.Stage()), o.RefundedCents(), o.CreatedBy(), o.Version(),
+		o.CreatedAt(), o.UpdatedAt(),
+		d.zoneID, d.taxCategory, d.feeCents, d.vatRateBP, d.discountCents, d.netCents, d.vatCents, d.grossCents,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting order: %w", err)
+	}
+
+	if err := writeOrderLines(ctx, tx, o); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing order: %w", err)
+	}
+	return nil
+}
+
+// Update stores a changed order with its lines and refunds.
+func (r *OrderRepository) Update(ctx context.Context, o *order.Order) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		`UPDATE orders SET status = $3, stage = $4, refunded_cents = $5, updated_at = $6, version = version + 1
+		 WHERE id = $1 AND version = $2`,
+		o.ID(), o.Version(), string(o.Status()), string(o.Stage()), o.RefundedCents(), o.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return order.ErrConcurrentUpdate
+	}
+
+	if err := writeOrderLines(ctx, tx, o); err != nil {
+		return err
+	}
+	for _, rf := range o.Refunds() {
+		if err := writeOrderRefund(ctx, tx, rf); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing order: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds an order in scope by ID together with its lines and
+// refunds.
+func (r *OrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	var customerID uuid.UUID
+	var branchID *uuid.UUID
+	var status, stage string
+	var refundedCents int64
+	var createdBy *uuid.UUID
+	var version int
+	var createdAt, updatedAt time.Time
+	var d deliveryRow
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	err := r.pool.QueryRow(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = $1 AND "+cond, args...).
+		Scan(&id, &customerID, &branchID, &status, &stage, &refundedCents, &createdBy, &version, &createdAt, &updatedAt,
+			&d.zoneID, &d.taxCategory, &d.feeCents, &d.vatRateBP, &d.discountCents, &d.netCents, &d.vatCents, &d.grossCents)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, order.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("querying order by id: %w", err)
+	}
+
+	lines, err := r.findLines(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := r.findRefunds(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders recorded before branches were tracked have none.
+	var orderBranch uuid.UUID
+	if branchID != nil {
+		orderBranch = *branchID
+	}
+	return order.ReconstructOrder(id, customerID, orderBranch, order.Status(status), order.Stage(stage), lines, d.charge(),
+		refunds, refundedCents, createdBy, version, createdAt, updatedAt), nil
+}
+
+// deliveryRow is an order's delivery charge columns, all NULL when the
+// order is not delivered.
+type deliveryRow struct {
+	zoneID        *uuid.UUID
+	taxCategory   *string
+	feeCents      *int64
+	vatRateBP     *int
+	discountCents *int64
+	netCents      *int64
+	vatCents      *int64
+	grossCents    *int64
+}
+
+func (d deliveryRow) charge() *order.DeliveryCharge {
+	if d.zoneID == nil {
+		return nil
+	}
+	return &order.DeliveryCharge{
+		ZoneID:        *d.zoneID,
+		TaxCategory:   *d.taxCategory,
+		FeeCents:      *d.feeCents,
+		VATRateBP:     *d.vatRateBP,
+		DiscountCents: *d.discountCents,
+		NetCents:      *d.netCents,
+		VATCents:      *d.vatCents,
+		GrossCents:    *d.grossCents,
+	}
+}
+
+// CountByCustomer returns how many orders a customer has placed.
+func (r *OrderRepository) CountByCustomer(ctx context.Context, customerID uuid.UUID) (int, error) {
+	var n int
+	if err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM orders WHERE customer_id = $1", customerID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting customer orders: %w", err)
+	}
+	return n, nil
+}
+
+// NextReceiptNumber reserves the next refund receipt number.
+func (r *OrderRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	var seq int64
+	if err := r.pool.QueryRow(ctx, "SELECT nextval('order_refund_receipt_seq')").Scan(&seq); err != nil {
+		return "", fmt.Errorf("reserving receipt number: %w", err)
+	}
+	return order.ReceiptNumber(seq), nil
+}
+
+func (r *OrderRepository) findLines(ctx context.Context, orderID uuid.UUID) ([]order.Line, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+orderLineColumns+" FROM order_lines WHERE order_id = $1 ORDER BY position",
+		orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying order lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []order.Line
+	for rows.Next() {
+		var l order.Line
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.Description, &l.Grams, &l.PricePerKgCents, &l.TaxCategory,
+			&l.VATRateBP, &l.DiscountCents, &l.NetCents, &l.VATCents, &l.GrossCents, &l.RefundedCents); err != nil {
+			return nil, fmt.Errorf("scanning order line: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, rows.Err()
+}
+
+func (r *OrderRepository) findRefunds(ctx context.Context, orderID uuid.UUID) ([]*order.Refund, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+orderRefundColumns+" FROM order_refunds WHERE order_id = $1 ORDER BY created_at, id",
+		orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying order refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []*order.Refund
+	for rows.Next() {
+		rf, err := scanOrderRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning order refund: %w", err)
+		}
+		refunds = append(refunds, rf)
+	}
+	return refunds, rows.Err()
+}
+
+// writeOrderLines upserts the order's lines. Only refunded amounts change
+// once an order has been placed.
+func writeOrderLines(ctx context.Context, tx pgx.Tx, o *order.Order) error {
+	for i, l := range o.Lines() {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO order_lines (order_id, position, `+orderLineColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			 ON CONFLICT (id) DO UPDATE SET refunded_cents = EXCLUDED.refunded_cents`,
+			o.ID(), i, l.ID, l.ProductID, l.Description, l.Grams, l.PricePerKgCents, l.TaxCategory, l.VATRateBP,
+			l.DiscountCents, l.NetCents, l.VATCents, l.GrossCents, l.RefundedCents,
+		)
+		if err != nil {
+			return fmt.Errorf("saving order line: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeOrderRefund upserts a refund. A new refund that reuses another's
+// idempotency key is reported as ErrIdempotencyKeyReused.
+func writeOrderRefund(ctx context.Context, tx pgx.Tx, rf *order.Refund) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO order_refunds (`+orderRefundColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, ''), $16, $17, $18)
+		 ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, decided_by = EXCLUDED.decided_by,
+		     payment_id = EXCLUDED.payment_id, payment_refund_id = EXCLUDED.payment_refund_id,
+		     receipt_number = EXCLUDED.receipt_number, decided_at = EXCLUDED.decided_at,
+		     issued_at = EXCLUDED.issued_at`,
+		rf.ID(), rf.OrderID(), string(rf.Kind()), rf.LineID(), rf.DeliveredGrams(), string(rf.Reason()), rf.Note(),
+		rf.AmountCents(), string(rf.Status()), rf.IdempotencyKey(), rf.RequestedBy(), rf.DecidedBy(), rf.PaymentID(),
+		rf.PaymentRefundID(), rf.ReceiptNumber(), rf.CreatedAt(), rf.DecidedAt(), rf.IssuedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "order_refunds_order_id_idempotency_key_key" {
+			return order.ErrIdempotencyKeyReused
+		}
+		return fmt.Errorf("saving order refund: %w", err)
+	}
+	return nil
+}
+
+func scanOrderRefund(row pgx.Row) (*order.Refund, error) {
+	var id, orderID, requestedBy uuid.UUID
+	var kind, reason, note, status, idempotencyKey string
+	var lineID, decidedBy, paymentID, paymentRefundID *uuid.UUID
+	var deliveredGrams, amountCents int64
+	var receiptNumber *string
+	var createdAt time.Time
+	var decidedAt, issuedAt *time.Time
+	err := row.Scan(&id, &orderID, &kind, &lineID, &deliveredGrams, &reason, &note, &amountCents, &status,
+		&idempotencyKey, &requestedBy, &decidedBy, &paymentID, &paymentRefundID, &receiptNumber, &createdAt,
+		&decidedAt, &issuedAt)
+	if err != nil {
+		return nil, err
+	}
+	var number string
+	if receiptNumber != nil {
+		number = *receiptNumber
+	}
+	return order.ReconstructRefund(id, orderID, order.RefundKind(kind), lineID, deliveredGrams, order.Reason(reason),
+		note, amountCents, order.RefundStatus(status), idempotencyKey, requestedBy, decidedBy, paymentID,
+		paymentRefundID, number, createdAt, decidedAt, issuedAt), nil
+}
@@ -0,0 +1,182 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationOrderRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewOrderRepository(pool)
+	payments := pgstore.NewPaymentRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "orders@example.com")
+	branchID := uuid.New()
+
+	newOrder := func() *order.Order {
+		o, err := order.NewOrder(uuid.New(), customerID, branchID, []order.Line{
+			{ProductID: uuid.New(), Description: "Lamb shoulder", Grams: 1500, PricePerKgCents: 2000,
+				TaxCategory: "prepared_food", VATRateBP: 2000, NetCents: 2500, VATCents: 500, GrossCents: 3000},
+			{ProductID: uuid.New(), Grams: 800, PricePerKgCents: 1125, TaxCategory: "fresh_meat", NetCents: 900, GrossCents: 900},
+		}, nil, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, o))
+		return o
+	}
+	request := func(o *order.Order, key string) *order.Refund {
+		lineID := o.Lines()[1].ID
+		r, err := o.RequestRefund(uuid.New(), order.RefundRequest{
+			Kind: order.RefundKindLine, LineID: &lineID, Reason: order.ReasonUnavailable,
+			IdempotencyKey: key, RequestedBy: uuid.New(),
+		}, order.ApprovalPolicy{ThresholdCents: 10000}, time.Now())
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("create and find", func(t *testing.T) {
+		o := newOrder()
+
+		found, err := repo.FindByID(ctx, o.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPlaced, found.Status())
+		require.Len(t, found.Lines(), 2)
+		assert.Equal(t, o.Lines()[1].ID, found.Lines()[1].ID)
+		assert.Equal(t, branchID, found.BranchID())
+		assert.Equal(t, "Lamb shoulder", found.Lines()[0].Description)
+		assert.Equal(t, o.Lines(), found.Lines(), "lines keep the tax they were priced with")
+		assert.Equal(t, int64(3900), found.TotalCents())
+
+		count, err := repo.CountByCustomer(ctx, customerID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		_, err = repo.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	})
+
+	t.Run("delivery charge is stored", func(t *testing.T) {
+		zoneID := seedDeliveryZone(t, pgstore.NewDeliveryZoneRepository(pool), "Whitechapel")
+		o, err := order.NewOrder(uuid.New(), customerID, branchID, []order.Line{
+			{ProductID: uuid.New(), Grams: 800, PricePerKgCents: 1125, TaxCategory: "fresh_meat", NetCents: 900, GrossCents: 900},
+		}, nil, time.Now())
+		require.NoError(t, err)
+		charge := order.DeliveryCharge{ZoneID: zoneID, TaxCategory: "delivery", FeeCents: 499, VATRateBP: 2000,
+			NetCents: 416, VATCents: 83, GrossCents: 499}
+		require.NoError(t, o.ChargeDelivery(charge))
+		require.NoError(t, repo.Create(ctx, o))
+
+		found, err := repo.FindByID(ctx, o.ID())
+
+		require.NoError(t, err)
+		require.NotNil(t, found.Delivery())
+		assert.Equal(t, charge, *found.Delivery())
+		assert.Equal(t, int64(1399), found.TotalCents())
+
+		collected, err := repo.FindByID(ctx, newOrder().ID())
+		require.NoError(t, err)
+		assert.Nil(t, collected.Delivery())
+	})
+
+	t.Run("refund is stored and issued", func(t *testing.T) {
+		o := newOrder()
+		r := request(o, "refund-1")
+		require.NoError(t, repo.Update(ctx, o))
+
+		loaded, err := repo.FindByID(ctx, o.ID())
+		require.NoError(t, err)
+		require.Len(t, loaded.Refunds(), 1)
+		assert.Equal(t, order.RefundStatusApproved, loaded.Refunds()[0].Status())
+		assert.Empty(t, loaded.Refunds()[0].ReceiptNumber())
+
+		p, err := payment.NewPayment(uuid.New(), o.ID(), customerID, "gbp", 3900, 1500, "fake", "checkout-"+o.ID().String(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, payments.Create(ctx, p))
+		require.NoError(t, p.Authorize("pi_1", time.Now()))
+		require.NoError(t, p.Capture(3900, time.Now()))
+		require.NoError(t, payments.Update(ctx, p))
+		p, err = payments.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+		pr, err := p.Refund(uuid.New(), r.AmountCents(), "unavailable", "order-refund:"+r.ID().String(), nil, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, payments.SaveRefund(ctx, p, pr))
+
+		number, err := repo.NextReceiptNumber(ctx)
+		require.NoError(t, err)
+		_, err = loaded.IssueRefund(r.ID(), p.ID(), pr.ID, number, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(ctx, loaded))
+
+		issued, err := repo.FindByID(ctx, o.ID())
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPartiallyRefunded, issued.Status())
+		assert.Equal(t, int64(900), issued.RefundedCents())
+		assert.Equal(t, int64(900), issued.Lines()[1].RefundedCents)
+		assert.Equal(t, number, issued.Refunds()[0].ReceiptNumber())
+		assert.Equal(t, pr.ID, *issued.Refunds()[0].PaymentRefundID())
+		assert.Equal(t, 3, issued.Version())
+	})
+
+	t.Run("stale update is rejected", func(t *testing.T) {
+		o := newOrder()
+		stale, err := repo.FindByID(ctx, o.ID())
+		require.NoError(t, err)
+		request(o, "refund-1")
+		require.NoError(t, repo.Update(ctx, o))
+
+		request(stale, "refund-2")
+		assert.ErrorIs(t, repo.Update(ctx, stale), order.ErrConcurrentUpdate)
+	})
+
+	t.Run("receipt numbers increase", func(t *testing.T) {
+		first, err := repo.NextReceiptNumber(ctx)
+		require.NoError(t, err)
+		second, err := repo.NextReceiptNumber(ctx)
+		require.NoError(t, err)
+		assert.Less(t, first, second)
+	})
+}
+
+func TestIntegrationAuditRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewAuditRepository(pool)
+	ctx := context.Background()
+	entityID := uuid.New()
+
+	for i, action := range []string{"refund.requested", "refund.issued"} {
+		e, err := audit.NewEntry(uuid.New(), uuid.New(), action, "order", entityID,
+			map[string]string{"amount_cents": "900"}, time.Now().Add(time.Duration(i)*time.Second))
+		require.NoError(t, err)
+		require.NoError(t, repo.Append(ctx, e))
+	}
+
+	entries, err := repo.FindByEntity(ctx, "order", entityID)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "refund.requested", entries[0].Action)
+	assert.Equal(t, "refund.issued", entries[1].Action)
+	assert.Equal(t, "900", entries[1].Details["amount_cents"])
+
+	none, err := repo.FindByEntity(ctx, "order", uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
@@ -0,0 +1,286 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+)
+
+// PaymentRepository implements payment.Repository using PostgreSQL.
+type PaymentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPaymentRepository creates a new PaymentRepository.
+func NewPaymentRepository(pool *pgxpool.Pool) *PaymentRepository {
+	return &PaymentRepository{pool: pool}
+}
+
+const paymentColumns = "id, order_id, customer_id, currency, estimated_cents, authorized_cents, captured_cents, refunded_cents, " +
+	"status, provider, provider_ref, idempotency_key, failure_reason, version, created_at, updated_at"
+
+const paymentRefundColumns = "id, payment_id, amount_cents, reason, idempotency_key, provider_ref, created_by, created_at"
+
+// paymentSelect reads payments joined to their orders so that finds can be
+// limited to the branches in scope. A payment whose order is not on record
+// is only seen when every branch is in scope.
+const paymentSelect = "SELECT p.id, p.order_id, p.customer_id, p.currency, p.estimated_cents, p.authorized_cents, " +
+	"p.captured_cents, p.refunded_cents, p.status, p.provider, p.provider_ref, p.idempotency_key, p.failure_reason, " +
+	"p.version, p.created_at, p.updated_at FROM payments p LEFT JOIN orders o ON o.id = p.order_id"
+
+// paymentRefundSelect reads refunds joined through their payments to the
+// orders, limited the same way as paymentSelect.
+const paymentRefundSelect = "SELECT r.id, r.payment_id, r.amount_cents, r.reason, r.idempotency_key, r.provider_ref, " +
+	"r.created_by, r.created_at FROM payment_refunds r JOIN payments p ON p.id = r.payment_id " +
+	"LEFT JOIN orders o ON o.id = p.order_id"
+
+// Create inserts a new payment.
+func (r *PaymentRepository) Create(ctx context.Context, p *payment.Payment) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO payments (`+paymentColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		p.ID(), p.OrderID(), p.CustomerID(), p.Currency(), p.EstimatedCents(), p.AuthorizedCents(), p.CapturedCents(),
+		p.RefundedCents(), string(p.Status()), p.Provider(), p.ProviderRef(), p.IdempotencyKey(), p.FailureReason(),
+		p.Version(), p.CreatedAt(), p.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return payment.ErrIdempotencyKeyReused
+		}
+		return fmt.Errorf("inserting payment: %w", err)
+	}
+	return nil
+}
+
+// Update stores a changed payment.
+func (r *PaymentRepository) Update(ctx context.Context, p *payment.Payment) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := updatePayment(ctx, tx, p); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing payment: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a payment by ID.
+func (r *PaymentRepository) FindByID(ctx context.Context, id uuid.UUID) (*payment.Payment, error) {
+	return r.findOne(ctx, "p.id = $1", id)
+}
+
+// FindByIdempotencyKey finds the payment created with a client's idempotency key.
+func (r *PaymentRepository) FindByIdempotencyKey(ctx context.Context, key string) (*payment.Payment, error) {
+	return r.findOne(ctx, "p.idempotency_key = $1", key)
+}
+
+// FindByProviderRef finds a payment by the provider's reference for it.
+func (r *PaymentRepository) FindByProviderRef(ctx context.Context, provider, providerRef string) (*payment.Payment, error) {
+	return r.findOne(ctx, "p.provider = $1 AND p.provider_ref = $2", provider, providerRef)
+}
+
+// FindByOrderID returns every payment attempted for an order, newest first.
+func (r *PaymentRepository) FindByOrderID(ctx context.Context, orderID uuid.UUID) ([]*payment.Payment, error) {
+	cond, args := branchScope(ctx, "o.branch_id", []any{orderID})
+	rows, err := r.pool.Query(ctx,
+		paymentSelect+" WHERE p.order_id = $1 AND "+cond+" ORDER BY p.created_at DESC, p.id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying payments by order: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []*payment.Payment
+	for rows.Next() {
+		p, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning payment: %w", err)
+		}
+		payments = append(payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating payments: %w", err)
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepository) findOne(ctx context.Context, where string, args ...any) (*payment.Payment, error) {
+	cond, args := branchScope(ctx, "o.branch_id", args)
+	row := r.pool.QueryRow(ctx, paymentSelect+" WHERE "+where+" AND "+cond, args...)
+	p, err := scanPayment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, payment.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("querying payment: %w", err)
+	}
+	return p, nil
+}
+
+// SaveRefund stores a refund together with the updated payment.
+func (r *PaymentRepository) SaveRefund(ctx context.Context, p *payment.Payment, refund *payment.Refund) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := updatePayment(ctx, tx, p); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO payment_refunds (`+paymentRefundColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		refund.ID, refund.PaymentID, refund.AmountCents, refund.Reason, refund.IdempotencyKey, refund.ProviderRef,
+		refund.CreatedBy, refund.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return payment.ErrIdempotencyKeyReused
+		}
+		return fmt.Errorf("inserting refund: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing refund: %w", err)
+	}
+	return nil
+}
+
+// FindRefundByIdempotencyKey finds a payment's refund by the client's
+// idempotency key, returning nil if there is none.
+func (r *PaymentRepository) FindRefundByIdempotencyKey(ctx context.Context, paymentID uuid.UUID, key string) (*payment.Refund, error) {
+	cond, args := branchScope(ctx, "o.branch_id", []any{paymentID, key})
+	row := r.pool.QueryRow(ctx,
+		paymentRefundSelect+" WHERE r.payment_id = $1 AND r.idempotency_key = $2 AND "+cond,
+		args...,
+	)
+	refund, err := scanPaymentRefund(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying refund by idempotency key: %w", err)
+	}
+	return refund, nil
+}
+
+// FindRefunds returns a payment's refunds, oldest first.
+func (r *PaymentRepository) FindRefunds(ctx context.Context, paymentID uuid.UUID) ([]*payment.Refund, error) {
+	cond, args := branchScope(ctx, "o.branch_id", []any{paymentID})
+	rows, err := r.pool.Query(ctx,
+		paymentRefundSelect+" WHERE r.payment_id = $1 AND "+cond+" ORDER BY r.created_at, r.id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []*payment.Refund
+	for rows.Next() {
+		refund, err := scanPaymentRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating refunds: %w", err)
+	}
+	return refunds, nil
+}
+
+// SaveWebhookEvent records a provider event and stores the payment it
+// changed, if any. A redelivered event is left alone.
+func (r *PaymentRepository) SaveWebhookEvent(ctx context.Context, provider, eventID string, p *payment.Payment) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		`INSERT INTO payment_webhook_events (provider, event_id) VALUES ($1, $2)
+		 ON CONFLICT (provider, event_id) DO NOTHING`,
+		provider, eventID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("inserting webhook event: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if p != nil {
+		if err := updatePayment(ctx, tx, p); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("committing webhook event: %w", err)
+	}
+	return true, nil
+}
+
+func updatePayment(ctx context.Context, tx pgx.Tx, p *payment.Payment) error {
+	result, err := tx.Exec(ctx,
+		`UPDATE payments SET authorized_cents = $3, captured_cents = $4, refunded_cents = $5, status = $6,
+		     provider_ref = $7, failure_reason = $8, updated_at = $9, version = version + 1
+		 WHERE id = $1 AND version = $2`,
+		p.ID(), p.Version(), p.AuthorizedCents(), p.CapturedCents(), p.RefundedCents(), string(p.Status()),
+		p.ProviderRef(), p.FailureReason(), p.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating payment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return payment.ErrConcurrentUpdate
+	}
+	return nil
+}
+
+func scanPayment(row pgx.Row) (*payment.Payment, error) {
+	var id, orderID, customerID uuid.UUID
+	var currency, status, provider, providerRef, idempotencyKey, failureReason string
+	var estimatedCents, authorizedCents, capturedCents, refundedCents int64
+	var version int
+	var createdAt, updatedAt time.Time
+	err := row.Scan(&id, &orderID, &customerID, &currency, &estimatedCents, &authorizedCents, &capturedCents,
+		&refundedCents, &status, &provider, &providerRef, &idempotencyKey, &failureReason, &version,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return payment.ReconstructPayment(id, orderID, customerID, currency, estimatedCents, authorizedCents,
+		capturedCents, refundedCents, payment.Status(status), provider, providerRef, idempotencyKey, failureReason,
+		version, createdAt, updatedAt), nil
+}
+
+func scanPaymentRefund(row pgx.Row) (*payment.Refund, error) {
+	var refund payment.Refund
+	err := row.Scan(&refund.ID, &refund.PaymentID, &refund.AmountCents, &refund.Reason, &refund.IdempotencyKey,
+		&refund.ProviderRef, &refund.CreatedBy, &refund.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
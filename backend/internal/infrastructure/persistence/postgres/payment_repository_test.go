@@ -0,0 +1,159 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPaymentRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewPaymentRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "payer@example.com")
+
+	newPayment := func(key string) *payment.Payment {
+		p, err := payment.NewPayment(uuid.New(), uuid.New(), customerID, "gbp", 10000, 1500, "fake", key, time.Now())
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("create, authorize and find by key and provider reference", func(t *testing.T) {
+		p := newPayment("checkout-1")
+		require.NoError(t, repo.Create(ctx, p))
+		assert.ErrorIs(t, repo.Create(ctx, newPayment("checkout-1")), payment.ErrIdempotencyKeyReused)
+
+		require.NoError(t, p.Authorize("pi_1", time.Now()))
+		require.NoError(t, repo.Update(ctx, p))
+
+		byKey, err := repo.FindByIdempotencyKey(ctx, "checkout-1")
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusAuthorized, byKey.Status())
+		assert.Equal(t, int64(11500), byKey.AuthorizedCents())
+		assert.Equal(t, 2, byKey.Version())
+
+		byRef, err := repo.FindByProviderRef(ctx, "fake", "pi_1")
+		require.NoError(t, err)
+		assert.Equal(t, p.ID(), byRef.ID())
+
+		byOrder, err := repo.FindByOrderID(ctx, p.OrderID())
+		require.NoError(t, err)
+		require.Len(t, byOrder, 1)
+		assert.Equal(t, p.ID(), byOrder[0].ID())
+
+		_, err = repo.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, payment.ErrPaymentNotFound)
+	})
+
+	t.Run("stale update is rejected", func(t *testing.T) {
+		p := newPayment("checkout-2")
+		require.NoError(t, repo.Create(ctx, p))
+		first, err := repo.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+
+		require.NoError(t, first.Authorize("pi_2", time.Now()))
+		require.NoError(t, repo.Update(ctx, first))
+		require.NoError(t, second.Fail("declined", time.Now()))
+		assert.ErrorIs(t, repo.Update(ctx, second), payment.ErrConcurrentUpdate)
+	})
+
+	t.Run("refunds", func(t *testing.T) {
+		p := newPayment("checkout-3")
+		require.NoError(t, repo.Create(ctx, p))
+		require.NoError(t, p.Authorize("pi_3", time.Now()))
+		require.NoError(t, p.Capture(10400, time.Now()))
+		require.NoError(t, repo.Update(ctx, p))
+		p, err := repo.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+
+		none, err := repo.FindRefundByIdempotencyKey(ctx, p.ID(), "refund-1")
+		require.NoError(t, err)
+		assert.Nil(t, none)
+
+		r, err := p.Refund(uuid.New(), 400, "short weight", "refund-1", nil, time.Now())
+		require.NoError(t, err)
+		r.ProviderRef = "re_1"
+		require.NoError(t, repo.SaveRefund(ctx, p, r))
+
+		found, err := repo.FindRefundByIdempotencyKey(ctx, p.ID(), "refund-1")
+		require.NoError(t, err)
+		assert.Equal(t, "re_1", found.ProviderRef)
+
+		refunds, err := repo.FindRefunds(ctx, p.ID())
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		assert.Equal(t, int64(400), refunds[0].AmountCents)
+
+		reloaded, err := repo.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(400), reloaded.RefundedCents())
+	})
+
+	t.Run("webhook events are recorded once", func(t *testing.T) {
+		p := newPayment("checkout-4")
+		require.NoError(t, repo.Create(ctx, p))
+		require.True(t, p.ApplyEvent(payment.Event{Type: payment.EventVoided}, time.Now()))
+
+		recorded, err := repo.SaveWebhookEvent(ctx, "fake", "evt_1", p)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+
+		recorded, err = repo.SaveWebhookEvent(ctx, "fake", "evt_1", nil)
+		require.NoError(t, err)
+		assert.False(t, recorded)
+
+		reloaded, err := repo.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusVoided, reloaded.Status())
+	})
+
+	t.Run("finds are limited to the branches in scope", func(t *testing.T) {
+		branchID := uuid.New()
+		o, err := order.NewOrder(uuid.New(), customerID, branchID, []order.Line{
+			{ProductID: uuid.New(), Grams: 1000, PricePerKgCents: 1000, TaxCategory: "fresh_meat", NetCents: 1000, GrossCents: 1000},
+		}, nil, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, pgstore.NewOrderRepository(pool).Create(ctx, o))
+		p, err := payment.NewPayment(uuid.New(), o.ID(), customerID, "gbp", 1000, 150, "fake", "checkout-5", time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, p))
+		require.NoError(t, p.Authorize("pi_5", time.Now()))
+		require.NoError(t, p.Capture(1000, time.Now()))
+		r, err := p.Refund(uuid.New(), 100, "short weight", "refund-5", nil, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveRefund(ctx, p, r))
+
+		elsewhere := branch.WithScope(ctx, branch.Only(uuid.New()))
+		_, err = repo.FindByID(elsewhere, p.ID())
+		assert.ErrorIs(t, err, payment.ErrPaymentNotFound)
+		byOrder, err := repo.FindByOrderID(elsewhere, o.ID())
+		require.NoError(t, err)
+		assert.Empty(t, byOrder)
+		refunds, err := repo.FindRefunds(elsewhere, p.ID())
+		require.NoError(t, err)
+		assert.Empty(t, refunds)
+
+		here := branch.WithScope(ctx, branch.Only(branchID))
+		found, err := repo.FindByID(here, p.ID())
+		require.NoError(t, err)
+		assert.Equal(t, p.ID(), found.ID())
+		refunds, err = repo.FindRefunds(here, p.ID())
+		require.NoError(t, err)
+		assert.Len(t, refunds, 1)
+	})
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+)
+
+// PLUItemRepository implements plu.Repository using PostgreSQL.
+type PLUItemRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPLUItemRepository creates a new PLUItemRepository.
+func NewPLUItemRepository(pool *pgxpool.Pool) *PLUItemRepository {
+	return &PLUItemRepository{pool: pool}
+}
+
+const pluItemColumns = "id, code, product_id, description, price_per_kg_cents, allergens, shelf_life_days, active, created_at, updated_at"
+
+// Save inserts or updates a PLU item. The code never changes once set.
+func (r *PLUItemRepository) Save(ctx context.Context, i *plu.Item) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO plu_items (`+pluItemColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (id) DO UPDATE SET
+		     description = EXCLUDED.description, price_per_kg_cents = EXCLUDED.price_per_kg_cents,
+		     allergens = EXCLUDED.allergens, shelf_life_days = EXCLUDED.shelf_life_days,
+		     active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		i.ID(), i.Code(), i.ProductID(), i.Description(), i.PricePerKgCents(), allergenNames(i.Allergens()),
+		i.ShelfLifeDays(), i.Active(), i.CreatedAt(), i.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_plu_items_code" {
+			return plu.ErrDuplicateCode
+		}
+		return fmt.Errorf("saving PLU item: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a PLU item by ID.
+func (r *PLUItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*plu.Item, error) {
+	return r.findOne(ctx, "SELECT "+pluItemColumns+" FROM plu_items WHERE id = $1", id)
+}
+
+// FindByCode finds a PLU item by its code.
+func (r *PLUItemRepository) FindByCode(ctx context.Context, code int) (*plu.Item, error) {
+	return r.findOne(ctx, "SELECT "+pluItemColumns+" FROM plu_items WHERE code = $1", code)
+}
+
+// FindByProduct finds the PLU item a product is labelled under, preferring
+// an active item and then the lowest code.
+func (r *PLUItemRepository) FindByProduct(ctx context.Context, productID uuid.UUID) (*plu.Item, error) {
+	return r.findOne(ctx,
+		"SELECT "+pluItemColumns+" FROM plu_items WHERE product_id = $1 ORDER BY active DESC, code LIMIT 1", productID)
+}
+
+// FindAll returns every PLU item in code order.
+func (r *PLUItemRepository) FindAll(ctx context.Context) ([]*plu.Item, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+pluItemColumns+" FROM plu_items ORDER BY code")
+	if err != nil {
+		return nil, fmt.Errorf("querying PLU items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*plu.Item
+	for rows.Next() {
+		i, err := scanPLUItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning PLU item: %w", err)
+		}
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
+
+func (r *PLUItemRepository) findOne(ctx context.Context, query string, arg any) (*plu.Item, error) {
+	i, err := scanPLUItem(r.pool.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, plu.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("querying PLU item: %w", err)
+	}
+	return i, nil
+}
+
+func scanPLUItem(row pgx.Row) (*plu.Item, error) {
+	var id, productID uuid.UUID
+	var code int
+	var description string
+	var pricePerKgCents int64
+	var allergens []string
+	var shelfLifeDays int
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &code, &productID, &description, &pricePerKgCents, &allergens, &shelfLifeDays,
+		&active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	items := make([]plu.Allergen, 0, len(allergens))
+	for _, a := range allergens {
+		items = append(items, plu.Allergen(a))
+	}
+	return plu.ReconstructItem(id, code, productID, description, pricePerKgCents, items, shelfLifeDays,
+		active, createdAt, updatedAt), nil
+}
+
+func allergenNames(allergens []plu.Allergen) []string {
+	names := make([]string, 0, len(allergens))
+	for _, a := range allergens {
+		names = append(names, string(a))
+	}
+	return names
+}
@@ -0,0 +1,69 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPLUItemRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewPLUItemRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	mince, err := plu.NewItem(uuid.New(), 123, uuid.New(), "Lamb mince", 1299, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, mince))
+	chops, err := plu.NewItem(uuid.New(), 45, uuid.New(), "Lamb chops", 2400, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, chops))
+
+	clash, err := plu.NewItem(uuid.New(), 123, uuid.New(), "Beef mince", 999, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Save(ctx, clash), plu.ErrDuplicateCode)
+
+	require.NoError(t, mince.Update("Lamb mince, 15% fat", 1350, false, now))
+	require.NoError(t, mince.SetLabelling([]plu.Allergen{plu.AllergenSulphites}, 3, now))
+	require.NoError(t, repo.Save(ctx, mince))
+
+	got, err := repo.FindByCode(ctx, 123)
+	require.NoError(t, err)
+	assert.Equal(t, mince.ID(), got.ID())
+	assert.Equal(t, "Lamb mince, 15% fat", got.Description())
+	assert.Equal(t, int64(1350), got.PricePerKgCents())
+	assert.False(t, got.Active())
+	assert.Equal(t, []plu.Allergen{plu.AllergenSulphites}, got.Allergens())
+	assert.Equal(t, 3, got.ShelfLifeDays())
+
+	// A product's active item is preferred over a withdrawn one.
+	replacement, err := plu.NewItem(uuid.New(), 124, mince.ProductID(), "Lamb mince", 1350, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, replacement))
+	got, err = repo.FindByProduct(ctx, mince.ProductID())
+	require.NoError(t, err)
+	assert.Equal(t, replacement.ID(), got.ID())
+	_, err = repo.FindByProduct(ctx, uuid.New())
+	assert.ErrorIs(t, err, plu.ErrItemNotFound)
+
+	_, err = repo.FindByCode(ctx, 999)
+	assert.ErrorIs(t, err, plu.ErrItemNotFound)
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, plu.ErrItemNotFound)
+
+	all, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, 45, all[0].Code())
+}
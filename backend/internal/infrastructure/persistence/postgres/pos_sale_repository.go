@@ -0,0 +1,422 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// PosSaleRepository implements pos.SaleRepository using PostgreSQL.
+type PosSaleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPosSaleRepository creates a new PosSaleRepository.
+func NewPosSaleRepository(pool *pgxpool.Pool) *PosSaleRepository {
+	return &PosSaleRepository{pool: pool}
+}
+
+const posSaleColumns = "id, kind, session_id, till_id, branch_id, cashier_id, status, receipt_number, " +
+	"original_sale_id, reason, voided_by, version, created_at, completed_at, voided_at, updated_at"
+
+const posSaleLineColumns = "id, product_id, plu_code, description, barcode, grams, price_per_kg_cents, amount_cents, " +
+	"tax_category, vat_rate_bp, net_cents, vat_cents, gross_cents, voided, returned_grams, returned_cents, " +
+	"original_line_id"
+
+const posPaymentColumns = "id, method, amount_cents, tendered_cents, change_cents, reference, taken_at"
+
+// voidedSaleReason is recorded on the movements that put a voided sale's
+// stock back.
+const voidedSaleReason = "till sale voided"
+
+// Save stores a sale that is being rung up, or one voided before it was
+// paid for.
+func (r *PosSaleRepository) Save(ctx context.Context, s *pos.Sale) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := lockOpenPosSession(ctx, tx, s.SessionID()); err != nil {
+			return err
+		}
+		return writePosSale(ctx, tx, s)
+	})
+}
+
+// NextReceiptNumber reserves the next till receipt number.
+func (r *PosSaleRepository) NextReceiptNumber(ctx context.Context) (string, error) {
+	var seq int64
+	if err := r.pool.QueryRow(ctx, "SELECT nextval('pos_receipt_seq')").Scan(&seq); err != nil {
+		return "", fmt.Errorf("reserving receipt number: %w", err)
+	}
+	return pos.ReceiptNumber(seq), nil
+}
+
+// Complete stores a completed sale and takes its stock from the branch's
+// lots first-expired-first-out, each product's lots locked in turn. Stock
+// held for online orders is not for the till: a sale that would cut into
+// it returns ErrInsufficientStock.
+func (r *PosSaleRepository) Complete(ctx context.Context, s *pos.Sale) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := writePosSale(ctx, tx, s); err != nil {
+			return err
+		}
+
+		grams := map[uuid.UUID]int64{}
+		for _, l := range s.LiveLines() {
+			grams[l.ProductID] += l.Grams
+		}
+		products := make([]uuid.UUID, 0, len(grams))
+		for p := range grams {
+			products = append(products, p)
+		}
+		sort.Slice(products, func(i, j int) bool { return products[i].String() < products[j].String() })
+
+		reference, actorID := s.ID(), s.CashierID()
+		for _, productID := range products {
+			lots, err := lockLots(ctx, tx, productID, s.BranchID())
+			if err != nil {
+				return err
+			}
+			var onHand int64
+			for _, lot := range lots {
+				onHand += lot.OnHandGrams()
+			}
+			reserved, err := reservedGrams(ctx, tx, productID, s.BranchID(), *s.CompletedAt())
+			if err != nil {
+				return err
+			}
+			if onHand-reserved < grams[productID] {
+				return inventory.ErrInsufficientStock
+			}
+			allocations, err := inventory.AllocateFEFO(lots, grams[productID])
+			if err != nil {
+				return err
+			}
+			for _, a := range allocations {
+				m, err := inventory.NewMovement(uuid.New(), a.Lot, inventory.MovementSold, -a.Grams, "", &reference, &actorID, *s.CompletedAt())
+				if err != nil {
+					return err
+				}
+				if err := applyStockMovement(ctx, tx, m); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Void stores a voided sale. A completed sale's stock is put back on the
+// lots its sold movements took it from.
+func (r *PosSaleRepository) Void(ctx context.Context, s *pos.Sale) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := writePosSale(ctx, tx, s); err != nil {
+			return err
+		}
+		if s.CompletedAt() == nil {
+			return nil
+		}
+
+		rows, err := tx.Query(ctx,
+			`SELECT lot_id, -SUM(delta_grams) FROM stock_movements
+			 WHERE reference_id = $1 AND type = $2
+			 GROUP BY lot_id ORDER BY lot_id`,
+			s.ID(), string(inventory.MovementSold),
+		)
+		if err != nil {
+			return fmt.Errorf("querying sale movements: %w", err)
+		}
+		sold := map[uuid.UUID]int64{}
+		var lotIDs []uuid.UUID
+		for rows.Next() {
+			var lotID uuid.UUID
+			var grams int64
+			if err := rows.Scan(&lotID, &grams); err != nil {
+				rows.Close()
+				return fmt.Errorf("scanning sale movement: %w", err)
+			}
+			sold[lotID] = grams
+			lotIDs = append(lotIDs, lotID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("querying sale movements: %w", err)
+		}
+
+		reference := s.ID()
+		for _, lotID := range lotIDs {
+			lot, err := scanInventoryLot(tx.QueryRow(ctx,
+				"SELECT "+inventoryLotColumns+" FROM inventory_lots WHERE id = $1 FOR UPDATE", lotID))
+			if err != nil {
+				return fmt.Errorf("locking lot: %w", err)
+			}
+			m, err := inventory.NewMovement(uuid.New(), lot, inventory.MovementAdjusted, sold[lotID], voidedSaleReason, &reference, s.VoidedBy(), *s.VoidedAt())
+			if err != nil {
+				return err
+			}
+			if err := applyStockMovement(ctx, tx, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveReturn stores a return and the sale it was taken against together, so
+// that what has been returned on the sale always matches its returns.
+func (r *PosSaleRepository) SaveReturn(ctx context.Context, original, ret *pos.Sale) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := lockOpenPosSession(ctx, tx, ret.SessionID()); err != nil {
+			return err
+		}
+		if err := writePosSale(ctx, tx, original); err != nil {
+			return err
+		}
+		return writePosSale(ctx, tx, ret)
+	})
+}
+
+// FindByID finds a sale by ID together with its lines and payments.
+func (r *PosSaleRepository) FindByID(ctx context.Context, id uuid.UUID) (*pos.Sale, error) {
+	return r.findOne(ctx, "id = $1", id)
+}
+
+// FindByReceiptNumber finds a sale or return by its receipt number.
+func (r *PosSaleRepository) FindByReceiptNumber(ctx context.Context, number string) (*pos.Sale, error) {
+	return r.findOne(ctx, "receipt_number = $1", number)
+}
+
+// FindBySession returns the sales and returns taken in a session, oldest
+// first.
+func (r *PosSaleRepository) FindBySession(ctx context.Context, sessionID uuid.UUID) ([]*pos.Sale, error) {
+	return r.find(ctx, "session_id = $1 ORDER BY created_at, id", sessionID)
+}
+
+func (r *PosSaleRepository) findOne(ctx context.Context, where string, arg any) (*pos.Sale, error) {
+	sales, err := r.find(ctx, where, arg)
+	if err != nil {
+		return nil, err
+	}
+	if len(sales) == 0 {
+		return nil, pos.ErrSaleNotFound
+	}
+	return sales[0], nil
+}
+
+// find loads the sales in scope matching where, then all of their lines
+// and payments in one query each.
+func (r *PosSaleRepository) find(ctx context.Context, where string, arg any) ([]*pos.Sale, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{arg})
+	rows, err := r.pool.Query(ctx, "SELECT "+posSaleColumns+" FROM pos_sales WHERE "+cond+" AND "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying sales: %w", err)
+	}
+	var heads []posSaleRow
+	for rows.Next() {
+		var h posSaleRow
+		err := rows.Scan(&h.id, &h.kind, &h.sessionID, &h.tillID, &h.branchID, &h.cashierID, &h.status,
+			&h.receiptNumber, &h.originalSaleID, &h.reason, &h.voidedBy, &h.version, &h.createdAt, &h.completedAt,
+			&h.voidedAt, &h.updatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning sale: %w", err)
+		}
+		heads = append(heads, h)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("querying sales: %w", err)
+	}
+	if len(heads) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(heads))
+	for i, h := range heads {
+		ids[i] = h.id
+	}
+	lines, err := r.findLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := r.findPayments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	sales := make([]*pos.Sale, 0, len(heads))
+	for _, h := range heads {
+		saleLines := lines[h.id]
+		if saleLines == nil {
+			saleLines = []pos.Line{}
+		}
+		salePayments := payments[h.id]
+		if salePayments == nil {
+			salePayments = []pos.Payment{}
+		}
+		var receiptNumber string
+		if h.receiptNumber != nil {
+			receiptNumber = *h.receiptNumber
+		}
+		sales = append(sales, pos.ReconstructSale(h.id, pos.Kind(h.kind), h.sessionID, h.tillID, h.branchID,
+			h.cashierID, pos.SaleStatus(h.status), receiptNumber, h.originalSaleID, saleLines, salePayments, h.reason,
+			h.voidedBy, h.version, h.createdAt, h.completedAt, h.voidedAt, h.updatedAt))
+	}
+	return sales, nil
+}
+
+type posSaleRow struct {
+	id, sessionID, tillID, branchID, cashierID uuid.UUID
+	kind, status, reason                       string
+	receiptNumber                              *string
+	originalSaleID, voidedBy                   *uuid.UUID
+	version                                    int
+	createdAt, updatedAt                       time.Time
+	completedAt, voidedAt                      *time.Time
+}
+
+func (r *PosSaleRepository) findLines(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID][]pos.Line, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT sale_id, "+posSaleLineColumns+" FROM pos_sale_lines WHERE sale_id = ANY($1) ORDER BY sale_id, position",
+		saleIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying sale lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := map[uuid.UUID][]pos.Line{}
+	for rows.Next() {
+		var saleID uuid.UUID
+		var l pos.Line
+		err := rows.Scan(&saleID, &l.ID, &l.ProductID, &l.PLUCode, &l.Description, &l.Barcode, &l.Grams,
+			&l.PricePerKgCents, &l.AmountCents, &l.TaxCategory, &l.VATRateBP, &l.NetCents, &l.VATCents, &l.GrossCents,
+			&l.Voided, &l.ReturnedGrams, &l.ReturnedCents, &l.OriginalLineID)
+		if err != nil {
+			return nil, fmt.Errorf("scanning sale line: %w", err)
+		}
+		lines[saleID] = append(lines[saleID], l)
+	}
+	return lines, rows.Err()
+}
+
+func (r *PosSaleRepository) findPayments(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID][]pos.Payment, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT sale_id, "+posPaymentColumns+" FROM pos_payments WHERE sale_id = ANY($1) ORDER BY sale_id, position",
+		saleIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying sale payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := map[uuid.UUID][]pos.Payment{}
+	for rows.Next() {
+		var saleID uuid.UUID
+		var p pos.Payment
+		var method string
+		err := rows.Scan(&saleID, &p.ID, &method, &p.AmountCents, &p.TenderedCents, &p.ChangeCents, &p.Reference, &p.TakenAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning sale payment: %w", err)
+		}
+		p.Method = pos.Method(method)
+		payments[saleID] = append(payments[saleID], p)
+	}
+	return payments, rows.Err()
+}
+
+func (r *PosSaleRepository) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing sale: %w", err)
+	}
+	return nil
+}
+
+// lockOpenPosSession holds a session open until the transaction ends, as
+// closing it takes the same row.
+func lockOpenPosSession(ctx context.Context, tx pgx.Tx, sessionID uuid.UUID) error {
+	var status string
+	err := tx.QueryRow(ctx, "SELECT status FROM pos_sessions WHERE id = $1 FOR SHARE", sessionID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pos.ErrSessionNotFound
+		}
+		return fmt.Errorf("locking till session: %w", err)
+	}
+	if pos.SessionStatus(status) != pos.SessionOpen {
+		return pos.ErrSessionClosed
+	}
+	return nil
+}
+
+// writePosSale upserts a sale with its lines and payments. An existing sale
+// is only updated at the version it was read at.
+func writePosSale(ctx context.Context, tx pgx.Tx, s *pos.Sale) error {
+	if err := branch.ScopeFrom(ctx).Check(s.BranchID()); err != nil {
+		return err
+	}
+	result, err := tx.Exec(ctx,
+		`INSERT INTO pos_sales (`+posSaleColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12, $13, $14, $15, $16)
+		 ON CONFLICT (id) DO UPDATE SET
+		     status = EXCLUDED.status, receipt_number = EXCLUDED.receipt_number, reason = EXCLUDED.reason,
+		     voided_by = EXCLUDED.voided_by, version = pos_sales.version + 1,
+		     completed_at = EXCLUDED.completed_at, voided_at = EXCLUDED.voided_at, updated_at = EXCLUDED.updated_at
+		 WHERE pos_sales.version = EXCLUDED.version`,
+		s.ID(), string(s.Kind()), s.SessionID(), s.TillID(), s.BranchID(), s.CashierID(), string(s.Status()),
+		s.ReceiptNumber(), s.OriginalSaleID(), s.Reason(), s.VoidedBy(), s.Version(), s.CreatedAt(), s.CompletedAt(),
+		s.VoidedAt(), s.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving sale: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return pos.ErrConcurrentUpdate
+	}
+
+	for i, l := range s.Lines() {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO pos_sale_lines (sale_id, position, `+posSaleLineColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+			 ON CONFLICT (id) DO UPDATE SET
+			     tax_category = EXCLUDED.tax_category, vat_rate_bp = EXCLUDED.vat_rate_bp,
+			     net_cents = EXCLUDED.net_cents, vat_cents = EXCLUDED.vat_cents, gross_cents = EXCLUDED.gross_cents,
+			     voided = EXCLUDED.voided, returned_grams = EXCLUDED.returned_grams,
+			     returned_cents = EXCLUDED.returned_cents`,
+			s.ID(), i, l.ID, l.ProductID, l.PLUCode, l.Description, l.Barcode, l.Grams, l.PricePerKgCents,
+			l.AmountCents, l.TaxCategory, l.VATRateBP, l.NetCents, l.VATCents, l.GrossCents, l.Voided,
+			l.ReturnedGrams, l.ReturnedCents, l.OriginalLineID,
+		)
+		if err != nil {
+			return fmt.Errorf("saving sale line: %w", err)
+		}
+	}
+	for i, p := range s.Payments() {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO pos_payments (sale_id, position, `+posPaymentColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			 ON CONFLICT (id) DO NOTHING`,
+			s.ID(), i, p.ID, string(p.Method), p.AmountCents, p.TenderedCents, p.ChangeCents, p.Reference, p.TakenAt,
+		)
+		if err != nil {
+			return fmt.Errorf("saving sale payment: %w", err)
+		}
+	}
+	return nil
+}
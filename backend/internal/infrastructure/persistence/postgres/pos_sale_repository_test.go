@@ -0,0 +1,225 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPosRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	tills := pgstore.NewPosTillRepository(pool)
+	sessions := pgstore.NewPosSessionRepository(pool)
+	sales := pgstore.NewPosSaleRepository(pool)
+	stock := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	cashierID := uuid.New()
+	_, err := pool.Exec(ctx,
+		"INSERT INTO admins (id, email, password_hash, full_name, role) VALUES ($1, 'till@butchery.com', 'hash', 'Cashier', 'cashier')",
+		cashierID)
+	require.NoError(t, err)
+
+	// Lamb is in two lots; the one expiring first is sold from first.
+	branchID, lamb := uuid.New(), uuid.New()
+	soon, later := now.AddDate(0, 0, 2), now.AddDate(0, 0, 5)
+	for _, l := range []struct {
+		code    string
+		expires time.Time
+	}{{"LATE", later}, {"SOON", soon}} {
+		lot, received, err := inventory.ReceiveLot(uuid.New(), lamb, branchID, l.code, 1000, &l.expires, nil, now)
+		require.NoError(t, err)
+		require.NoError(t, stock.ReceiveLot(ctx, lot, received))
+	}
+	onHand := func() map[string]int64 {
+		lots, err := stock.FindLots(ctx, lamb, branchID)
+		require.NoError(t, err)
+		got := map[string]int64{}
+		for _, l := range lots {
+			got[l.Code()] = l.OnHandGrams()
+		}
+		return got
+	}
+
+	// Tills are named uniquely per branch.
+	till, err := pos.NewTill(uuid.New(), branchID, "Till 1", now)
+	require.NoError(t, err)
+	require.NoError(t, tills.Save(ctx, till))
+	clash, err := pos.NewTill(uuid.New(), branchID, "till 1", now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, tills.Save(ctx, clash), pos.ErrDuplicateTillName)
+	elsewhere, err := pos.NewTill(uuid.New(), uuid.New(), "Till 1", now)
+	require.NoError(t, err)
+	require.NoError(t, tills.Save(ctx, elsewhere))
+	atBranch, err := tills.FindAll(ctx, &branchID)
+	require.NoError(t, err)
+	require.Len(t, atBranch, 1)
+	all, err := tills.FindAll(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// A till has one open session at a time.
+	session, err := pos.OpenSession(uuid.New(), till, 5000, cashierID, now)
+	require.NoError(t, err)
+	require.NoError(t, sessions.Open(ctx, session))
+	second, err := pos.OpenSession(uuid.New(), till, 5000, cashierID, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, sessions.Open(ctx, second), pos.ErrTillInUse)
+
+	// A sale is rung up and saved as it goes; a stale copy is refused.
+	sale, err := pos.NewSale(uuid.New(), session, cashierID, now)
+	require.NoError(t, err)
+	require.NoError(t, sales.Save(ctx, sale))
+	sale, err = sales.FindByID(ctx, sale.ID())
+	require.NoError(t, err)
+	for _, grams := range []int64{1200, 300} {
+		_, err = sale.AddLine(pos.Line{ProductID: lamb, PLUCode: 101, Description: "Lamb chops", Grams: grams, PricePerKgCents: 1000, AmountCents: grams}, now)
+		require.NoError(t, err)
+	}
+	require.NoError(t, sale.Reprice([]pos.LinePrice{
+		{TaxCategory: "fresh_meat", NetCents: 1200, GrossCents: 1200},
+		{TaxCategory: "fresh_meat", NetCents: 300, GrossCents: 300},
+	}))
+	require.NoError(t, sales.Save(ctx, sale))
+	assert.ErrorIs(t, sales.Save(ctx, sale), pos.ErrConcurrentUpdate)
+
+	// Closing waits for the sale being rung up.
+	require.NoError(t, session.Close(5000, nil, cashierID, now))
+	assert.ErrorIs(t, sessions.Close(ctx, session), pos.ErrSalesInProgress)
+	session, err = sessions.FindByID(ctx, session.ID())
+	require.NoError(t, err)
+	assert.True(t, session.IsOpen())
+
+	// Paying in full completes the sale and takes its stock FEFO.
+	sale, err = sales.FindByID(ctx, sale.ID())
+	require.NoError(t, err)
+	_, err = sale.Pay(pos.MethodCard, 1000, "A1", now)
+	require.NoError(t, err)
+	_, err = sale.Pay(pos.MethodCash, 1000, "", now)
+	require.NoError(t, err)
+	number, err := sales.NextReceiptNumber(ctx)
+	require.NoError(t, err)
+	require.NoError(t, sale.Complete(number, now))
+	require.NoError(t, sales.Complete(ctx, sale))
+	assert.Equal(t, map[string]int64{"SOON": 0, "LATE": 500}, onHand())
+
+	got, err := sales.FindByReceiptNumber(ctx, number)
+	require.NoError(t, err)
+	assert.Equal(t, pos.SaleCompleted, got.Status())
+	require.Len(t, got.Payments(), 2)
+	assert.Equal(t, int64(500), got.Payments()[1].ChangeCents)
+	assert.Equal(t, "A1", got.Payments()[0].Reference)
+	require.Len(t, got.Lines(), 2)
+	assert.Equal(t, int64(1200), got.Lines()[0].Grams)
+
+	// A sale the branch has no stock for is not completed.
+	short, err := pos.NewSale(uuid.New(), session, cashierID, now)
+	require.NoError(t, err)
+	_, err = short.AddLine(pos.Line{ProductID: lamb, PLUCode: 101, Description: "Lamb chops", Grams: 600, AmountCents: 600}, now)
+	require.NoError(t, err)
+	require.NoError(t, short.Reprice([]pos.LinePrice{{NetCents: 600, GrossCents: 600}}))
+	require.NoError(t, sales.Save(ctx, short))
+	short, err = sales.FindByID(ctx, short.ID())
+	require.NoError(t, err)
+	_, err = short.Pay(pos.MethodCash, 600, "", now)
+	require.NoError(t, err)
+	require.NoError(t, short.Complete("POS-999999", now))
+	assert.ErrorIs(t, sales.Complete(ctx, short), inventory.ErrInsufficientStock)
+	assert.Equal(t, map[string]int64{"SOON": 0, "LATE": 500}, onHand())
+	short, err = sales.FindByID(ctx, short.ID())
+	require.NoError(t, err)
+	assert.Equal(t, pos.SaleOpen, short.Status())
+	require.NoError(t, short.Void("out of stock", cashierID, now))
+	require.NoError(t, sales.Void(ctx, short))
+
+	// A return is stored with what it marks returned on the sale.
+	original, err := sales.FindByID(ctx, sale.ID())
+	require.NoError(t, err)
+	ret, err := pos.NewReturn(uuid.New(), original, session, cashierID, []pos.ReturnItem{{LineID: original.Lines()[1].ID}}, pos.MethodCash, "", "off", "POS-000900", now)
+	require.NoError(t, err)
+	require.NoError(t, sales.SaveReturn(ctx, original, ret))
+	original, err = sales.FindByID(ctx, sale.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), original.Lines()[1].ReturnedGrams)
+	gotReturn, err := sales.FindByID(ctx, ret.ID())
+	require.NoError(t, err)
+	assert.Equal(t, pos.KindReturn, gotReturn.Kind())
+	assert.Equal(t, sale.ID(), *gotReturn.OriginalSaleID())
+	assert.Equal(t, original.Lines()[1].ID, *gotReturn.Lines()[0].OriginalLineID)
+
+	// Voiding a paid sale puts its stock back where it came from.
+	another, err := pos.NewSale(uuid.New(), session, cashierID, now)
+	require.NoError(t, err)
+	_, err = another.AddLine(pos.Line{ProductID: lamb, PLUCode: 101, Description: "Lamb chops", Grams: 400, AmountCents: 400}, now)
+	require.NoError(t, err)
+	require.NoError(t, another.Reprice([]pos.LinePrice{{NetCents: 400, GrossCents: 400}}))
+	require.NoError(t, sales.Save(ctx, another))
+	another, err = sales.FindByID(ctx, another.ID())
+	require.NoError(t, err)
+	_, err = another.Pay(pos.MethodCash, 400, "", now)
+	require.NoError(t, err)
+	require.NoError(t, another.Complete("POS-000901", now))
+	require.NoError(t, sales.Complete(ctx, another))
+	assert.Equal(t, map[string]int64{"SOON": 0, "LATE": 100}, onHand())
+	another, err = sales.FindByID(ctx, another.ID())
+	require.NoError(t, err)
+	require.NoError(t, another.Void("rung up twice", cashierID, now))
+	require.NoError(t, sales.Void(ctx, another))
+	assert.Equal(t, map[string]int64{"SOON": 0, "LATE": 500}, onHand())
+
+	// Stock held for an online order is not sold at the till.
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "held@example.com")
+	hold, err := inventory.NewReservation(uuid.New(), branchID, customerID,
+		[]inventory.ReservationLine{{ProductID: lamb, Grams: 200}}, 15*time.Minute, now)
+	require.NoError(t, err)
+	require.NoError(t, pgstore.NewStockReservationRepository(pool).Reserve(ctx, hold, now))
+	held, err := pos.NewSale(uuid.New(), session, cashierID, now)
+	require.NoError(t, err)
+	_, err = held.AddLine(pos.Line{ProductID: lamb, PLUCode: 101, Description: "Lamb chops", Grams: 400, AmountCents: 400}, now)
+	require.NoError(t, err)
+	require.NoError(t, held.Reprice([]pos.LinePrice{{NetCents: 400, GrossCents: 400}}))
+	require.NoError(t, sales.Save(ctx, held))
+	held, err = sales.FindByID(ctx, held.ID())
+	require.NoError(t, err)
+	_, err = held.Pay(pos.MethodCash, 400, "", now)
+	require.NoError(t, err)
+	require.NoError(t, held.Complete("POS-000902", now))
+	assert.ErrorIs(t, sales.Complete(ctx, held), inventory.ErrInsufficientStock)
+	assert.Equal(t, map[string]int64{"SOON": 0, "LATE": 500}, onHand())
+	held, err = sales.FindByID(ctx, held.ID())
+	require.NoError(t, err)
+	require.NoError(t, held.Void("held for an order", cashierID, now))
+	require.NoError(t, sales.Void(ctx, held))
+
+	// The session closes once nothing is being rung up, and only once.
+	inSession, err := sales.FindBySession(ctx, session.ID())
+	require.NoError(t, err)
+	assert.Len(t, inSession, 5)
+	require.NoError(t, session.Close(6400, inSession, cashierID, now))
+	require.NoError(t, sessions.Close(ctx, session))
+	assert.ErrorIs(t, sessions.Close(ctx, session), pos.ErrSessionClosed)
+	closed, err := sessions.FindByID(ctx, session.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(6400), *closed.CountedCashCents())
+	assert.ErrorIs(t, sales.Save(ctx, short), pos.ErrSessionClosed)
+
+	history, err := sessions.FindByTill(ctx, till.ID())
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	_, err = sales.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, pos.ErrSaleNotFound)
+}
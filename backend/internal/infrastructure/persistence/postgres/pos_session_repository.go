@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// PosSessionRepository implements pos.SessionRepository using PostgreSQL.
+type PosSessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPosSessionRepository creates a new PosSessionRepository.
+func NewPosSessionRepository(pool *pgxpool.Pool) *PosSessionRepository {
+	return &PosSessionRepository{pool: pool}
+}
+
+const posSessionColumns = "id, till_id, branch_id, status, float_cents, counted_cash_cents, opened_by, closed_by, " +
+	"opened_at, closed_at"
+
+// Open inserts a new session at a branch in scope.
+func (r *PosSessionRepository) Open(ctx context.Context, s *pos.Session) error {
+	if err := branch.ScopeFrom(ctx).Check(s.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO pos_sessions (`+posSessionColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		s.ID(), s.TillID(), s.BranchID(), string(s.Status()), s.FloatCents(), s.CountedCashCents(), s.OpenedBy(),
+		s.ClosedBy(), s.OpenedAt(), s.ClosedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_pos_sessions_open_till" {
+			return pos.ErrTillInUse
+		}
+		return fmt.Errorf("inserting till session: %w", err)
+	}
+	return nil
+}
+
+// Close stores a closed session. The session row is what sales started in
+// it lock, so a sale cannot slip in between the check and the close.
+func (r *PosSessionRepository) Close(ctx context.Context, s *pos.Session) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var status string
+	if err := tx.QueryRow(ctx, "SELECT status FROM pos_sessions WHERE id = $1 FOR UPDATE", s.ID()).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pos.ErrSessionNotFound
+		}
+		return fmt.Errorf("locking till session: %w", err)
+	}
+	if pos.SessionStatus(status) != pos.SessionOpen {
+		return pos.ErrSessionClosed
+	}
+	var inProgress bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM pos_sales WHERE session_id = $1 AND status = $2)",
+		s.ID(), string(pos.SaleOpen),
+	).Scan(&inProgress)
+	if err != nil {
+		return fmt.Errorf("checking sales in progress: %w", err)
+	}
+	if inProgress {
+		return pos.ErrSalesInProgress
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE pos_sessions SET status = $2, counted_cash_cents = $3, closed_by = $4, closed_at = $5
+		 WHERE id = $1`,
+		s.ID(), string(s.Status()), s.CountedCashCents(), s.ClosedBy(), s.ClosedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("closing till session: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing till session: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a session in scope by ID.
+func (r *PosSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*pos.Session, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	s, err := scanPosSession(r.pool.QueryRow(ctx,
+		"SELECT "+posSessionColumns+" FROM pos_sessions WHERE id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pos.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("querying till session: %w", err)
+	}
+	return s, nil
+}
+
+// FindByTill returns a till's sessions, newest first.
+func (r *PosSessionRepository) FindByTill(ctx context.Context, tillID uuid.UUID) ([]*pos.Session, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{tillID})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+posSessionColumns+" FROM pos_sessions WHERE till_id = $1 AND "+cond+" ORDER BY opened_at DESC, id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying till sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*pos.Session
+	for rows.Next() {
+		s, err := scanPosSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning till session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func scanPosSession(row pgx.Row) (*pos.Session, error) {
+	var id, tillID, branchID, openedBy uuid.UUID
+	var status string
+	var floatCents int64
+	var countedCashCents *int64
+	var closedBy *uuid.UUID
+	var openedAt time.Time
+	var closedAt *time.Time
+
+	err := row.Scan(&id, &tillID, &branchID, &status, &floatCents, &countedCashCents, &openedBy, &closedBy,
+		&openedAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+	return pos.ReconstructSession(id, tillID, branchID, pos.SessionStatus(status), floatCents, countedCashCents,
+		openedBy, closedBy, openedAt, closedAt), nil
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+)
+
+// PosTillRepository implements pos.TillRepository using PostgreSQL.
+type PosTillRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPosTillRepository creates a new PosTillRepository.
+func NewPosTillRepository(pool *pgxpool.Pool) *PosTillRepository {
+	return &PosTillRepository{pool: pool}
+}
+
+const posTillColumns = "id, branch_id, name, active, created_at, updated_at"
+
+// Save inserts or updates a till at a branch in scope.
+func (r *PosTillRepository) Save(ctx context.Context, t *pos.Till) error {
+	if err := branch.ScopeFrom(ctx).Check(t.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO pos_tills (`+posTillColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		t.ID(), t.BranchID(), t.Name(), t.Active(), t.CreatedAt(), t.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_pos_tills_branch_name" {
+			return pos.ErrDuplicateTillName
+		}
+		return fmt.Errorf("saving till: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a till in scope by ID.
+func (r *PosTillRepository) FindByID(ctx context.Context, id uuid.UUID) (*pos.Till, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	t, err := scanPosTill(r.pool.QueryRow(ctx, "SELECT "+posTillColumns+" FROM pos_tills WHERE id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pos.ErrTillNotFound
+		}
+		return nil, fmt.Errorf("querying till: %w", err)
+	}
+	return t, nil
+}
+
+// FindAll returns the tills at a branch, or at every branch in scope, by
+// name.
+func (r *PosTillRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*pos.Till, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{branchID})
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+posTillColumns+" FROM pos_tills WHERE ($1::uuid IS NULL OR branch_id = $1) AND "+cond+
+			" ORDER BY LOWER(name), branch_id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying tills: %w", err)
+	}
+	defer rows.Close()
+
+	var tills []*pos.Till
+	for rows.Next() {
+		t, err := scanPosTill(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning till: %w", err)
+		}
+		tills = append(tills, t)
+	}
+	return tills, rows.Err()
+}
+
+func scanPosTill(row pgx.Row) (*pos.Till, error) {
+	var id, branchID uuid.UUID
+	var name string
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &branchID, &name, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return pos.ReconstructTill(id, branchID, name, active, createdAt, updatedAt), nil
+}
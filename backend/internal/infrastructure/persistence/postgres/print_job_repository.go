@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+)
+
+// PrintJobRepository implements label.JobRepository using PostgreSQL.
+type PrintJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPrintJobRepository creates a new PrintJobRepository.
+func NewPrintJobRepository(pool *pgxpool.Pool) *PrintJobRepository {
+	return &PrintJobRepository{pool: pool}
+}
+
+const printJobColumns = "id, printer_id, source, source_id, template, labels, payload, status, attempts, last_error, " +
+	"next_attempt_at, printed_at, created_at, updated_at"
+
+// Save inserts or updates a print job. What a job prints never changes.
+func (r *PrintJobRepository) Save(ctx context.Context, j *label.Job) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO print_jobs (`+printJobColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		 ON CONFLICT (id) DO UPDATE SET
+		     status = EXCLUDED.status, attempts = EXCLUDED.attempts, last_error = EXCLUDED.last_error,
+		     next_attempt_at = EXCLUDED.next_attempt_at, printed_at = EXCLUDED.printed_at,
+		     updated_at = EXCLUDED.updated_at`,
+		j.ID(), j.PrinterID(), string(j.Source()), j.SourceID(), j.Template(), j.Labels(), j.Payload(),
+		string(j.Status()), j.Attempts(), j.LastError(), j.NextAttemptAt(), j.PrintedAt(), j.CreatedAt(), j.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving print job: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a print job by ID.
+func (r *PrintJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*label.Job, error) {
+	j, err := scanPrintJob(r.pool.QueryRow(ctx, "SELECT "+printJobColumns+" FROM print_jobs WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, label.ErrJobNotFound
+		}
+		return nil, fmt.Errorf("querying print job: %w", err)
+	}
+	return j, nil
+}
+
+// FindAll returns print jobs, newest first.
+func (r *PrintJobRepository) FindAll(ctx context.Context, filter label.JobFilter) ([]*label.Job, error) {
+	query := "SELECT " + printJobColumns + " FROM print_jobs WHERE TRUE"
+	var args []any
+	if filter.PrinterID != nil {
+		args = append(args, *filter.PrinterID)
+		query += " AND printer_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY created_at DESC, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying print jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*label.Job
+	for rows.Next() {
+		j, err := scanPrintJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning print job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// ClaimNext leases the oldest due job to the caller. SKIP LOCKED lets
+// concurrent senders each claim a different job instead of waiting.
+func (r *PrintJobRepository) ClaimNext(ctx context.Context, lease time.Duration, now time.Time) (*label.Job, error) {
+	j, err := scanPrintJob(r.pool.QueryRow(ctx,
+		`UPDATE print_jobs SET attempts = attempts + 1, next_attempt_at = $2, updated_at = $1
+		 WHERE id = (
+		     SELECT id FROM print_jobs
+		     WHERE status = 'queued' AND next_attempt_at <= $1
+		     ORDER BY next_attempt_at, created_at
+		     LIMIT 1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+printJobColumns,
+		now, now.Add(lease),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, label.ErrNoJobDue
+		}
+		return nil, fmt.Errorf("claiming print job: %w", err)
+	}
+	return j, nil
+}
+
+func scanPrintJob(row pgx.Row) (*label.Job, error) {
+	var id, printerID, sourceID uuid.UUID
+	var source, template, status, lastError string
+	var labels, attempts int
+	var payload []byte
+	var nextAttemptAt, createdAt, updatedAt time.Time
+	var printedAt *time.Time
+
+	if err := row.Scan(&id, &printerID, &source, &sourceID, &template, &labels, &payload, &status, &attempts,
+		&lastError, &nextAttemptAt, &printedAt, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return label.ReconstructJob(id, printerID, label.Source(source), sourceID, template, labels, payload,
+		label.JobStatus(status), attempts, lastError, nextAttemptAt, printedAt, createdAt, updatedAt), nil
+}
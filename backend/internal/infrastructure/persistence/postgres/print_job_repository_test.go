@@ -0,0 +1,79 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPrintJobRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	printers := pgstore.NewLabelPrinterRepository(pool)
+	jobs := pgstore.NewPrintJobRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	counter, err := label.NewPrinter(uuid.New(), "Counter", "192.168.1.40", now)
+	require.NoError(t, err)
+	require.NoError(t, printers.Save(ctx, counter))
+	clash, err := label.NewPrinter(uuid.New(), "counter", "192.168.1.41", now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, printers.Save(ctx, clash), label.ErrDuplicatePrinter)
+
+	got, err := printers.FindByID(ctx, counter.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.1.40:9100", got.Address())
+	_, err = printers.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, label.ErrPrinterNotFound)
+
+	first, err := label.NewJob(uuid.New(), counter.ID(), label.SourceLot, uuid.New(), "standard", 2, []byte("^XA^XZ^XA^XZ"), now)
+	require.NoError(t, err)
+	require.NoError(t, jobs.Save(ctx, first))
+	second, err := label.NewJob(uuid.New(), counter.ID(), label.SourceOrder, uuid.New(), "standard", 1, []byte("^XA^XZ"), now.Add(time.Second))
+	require.NoError(t, err)
+	require.NoError(t, jobs.Save(ctx, second))
+
+	// The oldest due job is claimed first and leased, so the next claim
+	// gets the other one and a third finds nothing due.
+	claimed, err := jobs.ClaimNext(ctx, time.Minute, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), claimed.ID())
+	assert.Equal(t, 1, claimed.Attempts())
+	assert.Equal(t, []byte("^XA^XZ^XA^XZ"), claimed.Payload())
+	claimedSecond, err := jobs.ClaimNext(ctx, time.Minute, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, second.ID(), claimedSecond.ID())
+	_, err = jobs.ClaimNext(ctx, time.Minute, now.Add(time.Second))
+	assert.ErrorIs(t, err, label.ErrNoJobDue)
+
+	claimed.Printed(now.Add(2 * time.Second))
+	require.NoError(t, jobs.Save(ctx, claimed))
+	claimedSecond.Failed("connection refused", time.Minute, 1, now.Add(2*time.Second))
+	require.NoError(t, jobs.Save(ctx, claimedSecond))
+
+	failed := label.JobFailed
+	found, err := jobs.FindAll(ctx, label.JobFilter{PrinterID: ptr(counter.ID()), Status: &failed})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "connection refused", found[0].LastError())
+
+	all, err := jobs.FindAll(ctx, label.JobFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, second.ID(), all[0].ID(), "newest first")
+	require.NotNil(t, all[1].PrintedAt())
+
+	_, err = jobs.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, label.ErrJobNotFound)
+}
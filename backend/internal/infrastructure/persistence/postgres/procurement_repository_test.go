@@ -0,0 +1,206 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSupplier(t *testing.T, name string) *procurement.Supplier {
+	t.Helper()
+	expires := time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC)
+	s, err := procurement.NewSupplier(uuid.New(), name, procurement.SupplierFarm,
+		[]procurement.Contact{{Name: "Amina Khan", Role: "Sales", Email: "amina@greenvalley.example"}},
+		[]procurement.Certificate{{Type: "halal", Number: "HMC-1042", ExpiresOn: &expires}},
+		30, time.Now())
+	require.NoError(t, err)
+	return s
+}
+
+// receiveAll books a delivery covering every outstanding line of po and
+// returns the note with the lots it created.
+func receiveAll(t *testing.T, po *procurement.PurchaseOrder, gramsFor func(procurement.Line) int64) (*procurement.GoodsReceivedNote, []*inventory.Lot, []*inventory.Movement) {
+	t.Helper()
+	now := time.Now()
+	var lines []procurement.ReceiptLine
+	for _, l := range po.Lines() {
+		lines = append(lines, procurement.ReceiptLine{LineID: l.ID, LotCode: "GV-0412", Grams: gramsFor(l), TemperatureTenthsC: 32})
+	}
+	n, err := po.Receive(uuid.New(), lines, "", nil, now)
+	require.NoError(t, err)
+
+	var lots []*inventory.Lot
+	var received []*inventory.Movement
+	for _, rl := range n.Lines() {
+		lot, m, err := inventory.ReceiveLotFrom(rl.LotID, rl.ProductID, po.BranchID(), rl.LotCode, rl.Grams,
+			rl.ExpiresOn, "goods received", n.ID(), nil, now)
+		require.NoError(t, err)
+		lots = append(lots, lot)
+		received = append(received, m)
+	}
+	return n, lots, received
+}
+
+func TestIntegrationSupplierRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewSupplierRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	farm := newTestSupplier(t, "Green Valley Farm")
+	wholesaler := newTestSupplier(t, "Atlas Meats")
+	require.NoError(t, repo.Save(ctx, farm))
+	require.NoError(t, repo.Save(ctx, wholesaler))
+
+	t.Run("round-trips contacts and certificates", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, farm.ID())
+		require.NoError(t, err)
+		assert.Equal(t, farm.Contacts(), found.Contacts())
+		require.Len(t, found.Certificates(), 1)
+		assert.Equal(t, "2027-03-31", found.Certificates()[0].ExpiresOn.Format(time.DateOnly))
+		assert.Equal(t, 30, found.PaymentTermsDays())
+	})
+
+	t.Run("save updates and active filter applies", func(t *testing.T) {
+		wholesaler.SetActive(false, time.Now())
+		require.NoError(t, repo.Save(ctx, wholesaler))
+
+		all, err := repo.FindAll(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, "Atlas Meats", all[0].Name())
+
+		active, err := repo.FindAll(ctx, true)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, farm.ID(), active[0].ID())
+	})
+
+	t.Run("unknown supplier returns ErrSupplierNotFound", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, procurement.ErrSupplierNotFound)
+	})
+}
+
+func TestIntegrationPurchaseOrderRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	supplierRepo := pgstore.NewSupplierRepository(pool)
+	repo := pgstore.NewPurchaseOrderRepository(pool)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	s := newTestSupplier(t, "Green Valley Farm")
+	require.NoError(t, supplierRepo.Save(ctx, s))
+
+	lamb, beef := uuid.New(), uuid.New()
+	po, err := procurement.NewPurchaseOrder(uuid.New(), s.ID(), uuid.New(),
+		[]procurement.Line{{ProductID: lamb, OrderedGrams: 20000, PricePerKgCents: 1200}}, nil, "", nil, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, po))
+
+	t.Run("revising a draft replaces its lines", func(t *testing.T) {
+		loaded, err := repo.FindByID(ctx, po.ID())
+		require.NoError(t, err)
+		require.NoError(t, loaded.Revise([]procurement.Line{
+			{ProductID: lamb, OrderedGrams: 20000, PricePerKgCents: 1150},
+			{ProductID: beef, OrderedGrams: 10000, PricePerKgCents: 1500},
+		}, nil, "add beef", time.Now()))
+		require.NoError(t, repo.Update(ctx, loaded))
+
+		found, err := repo.FindByID(ctx, po.ID())
+		require.NoError(t, err)
+		require.Len(t, found.Lines(), 2)
+		assert.Equal(t, int64(1150), found.Lines()[0].PricePerKgCents)
+		assert.Equal(t, 2, found.Version())
+	})
+
+	t.Run("stale version returns ErrConcurrentUpdate", func(t *testing.T) {
+		require.NoError(t, po.Send(time.Now()))
+		assert.ErrorIs(t, repo.Update(ctx, po), procurement.ErrConcurrentUpdate)
+	})
+
+	t.Run("goods received updates order and creates lots", func(t *testing.T) {
+		loaded, err := repo.FindByID(ctx, po.ID())
+		require.NoError(t, err)
+		require.NoError(t, loaded.Send(time.Now()))
+		require.NoError(t, repo.Update(ctx, loaded))
+
+		loaded, err = repo.FindByID(ctx, po.ID())
+		require.NoError(t, err)
+		n, lots, received := receiveAll(t, loaded, func(l procurement.Line) int64 { return l.OrderedGrams - 500 })
+		require.NoError(t, repo.SaveReceipt(ctx, loaded, n, lots, received))
+
+		found, err := repo.FindByID(ctx, po.ID())
+		require.NoError(t, err)
+		assert.Equal(t, procurement.StatusPartiallyReceived, found.Status())
+		assert.Equal(t, int64(19500), found.Lines()[0].ReceivedGrams)
+
+		lot, err := stockRepo.FindLotByID(ctx, n.Lines()[0].LotID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(19500), lot.OnHandGrams())
+		assert.Equal(t, "GV-0412", lot.Code())
+
+		receipts, err := repo.FindReceipts(ctx, po.ID())
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Len(t, receipts[0].Lines(), 2)
+		assert.Equal(t, 32, receipts[0].Lines()[0].TemperatureTenthsC)
+		assert.Equal(t, found.Lines()[0].ID, receipts[0].Lines()[0].LineID)
+	})
+
+	t.Run("filters by status and sent date", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, procurement.Filter{Statuses: []procurement.Status{procurement.StatusPartiallyReceived}})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+
+		supplierID := s.ID()
+		future := time.Now().Add(time.Hour)
+		found, err = repo.FindAll(ctx, procurement.Filter{SupplierID: &supplierID, SentFrom: &future})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("price history lists sent prices", func(t *testing.T) {
+		prices, err := repo.FindPriceHistory(ctx, s.ID(), &lamb)
+		require.NoError(t, err)
+		require.Len(t, prices, 1)
+		assert.Equal(t, int64(1150), prices[0].PricePerKgCents)
+		assert.Equal(t, po.ID(), prices[0].PurchaseOrderID)
+	})
+	t.Run("orders at other branches are out of scope", func(t *testing.T) {
+		elsewhere := branch.WithScope(ctx, branch.Only(uuid.New()))
+
+		_, err := repo.FindByID(elsewhere, po.ID())
+		assert.ErrorIs(t, err, procurement.ErrPurchaseOrderNotFound)
+		found, err := repo.FindAll(elsewhere, procurement.Filter{})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+		receipts, err := repo.FindReceipts(elsewhere, po.ID())
+		require.NoError(t, err)
+		assert.Empty(t, receipts)
+		prices, err := repo.FindPriceHistory(elsewhere, s.ID(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, prices)
+
+		found, err = repo.FindAll(branch.WithScope(ctx, branch.Only(po.BranchID())), procurement.Filter{})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// ProductTaxCategoryRepository implements tax.ProductRepository using
+// PostgreSQL.
+type ProductTaxCategoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewProductTaxCategoryRepository creates a new ProductTaxCategoryRepository.
+func NewProductTaxCategoryRepository(pool *pgxpool.Pool) *ProductTaxCategoryRepository {
+	return &ProductTaxCategoryRepository{pool: pool}
+}
+
+// AssignCategory sets or replaces a product's category.
+func (r *ProductTaxCategoryRepository) AssignCategory(ctx context.Context, productID uuid.UUID, category tax.Category) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO product_tax_categories (product_id, category, updated_at) VALUES ($1, $2, NOW())
+		 ON CONFLICT (product_id) DO UPDATE SET category = EXCLUDED.category, updated_at = EXCLUDED.updated_at`,
+		productID, string(category),
+	)
+	if err != nil {
+		return fmt.Errorf("saving product tax category: %w", err)
+	}
+	return nil
+}
+
+// FindCategories returns the categories of those products that have one.
+func (r *ProductTaxCategoryRepository) FindCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]tax.Category, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT product_id, category FROM product_tax_categories WHERE product_id = ANY($1)", productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querying product tax categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make(map[uuid.UUID]tax.Category, len(productIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		var category string
+		if err := rows.Scan(&id, &category); err != nil {
+			return nil, fmt.Errorf("scanning product tax category: %w", err)
+		}
+		categories[id] = tax.Category(category)
+	}
+	return categories, rows.Err()
+}
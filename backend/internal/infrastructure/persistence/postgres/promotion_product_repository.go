@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// PromotionProductRepository implements promotion.ProductRepository using
+// PostgreSQL.
+type PromotionProductRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPromotionProductRepository creates a new PromotionProductRepository.
+func NewPromotionProductRepository(pool *pgxpool.Pool) *PromotionProductRepository {
+	return &PromotionProductRepository{pool: pool}
+}
+
+// Classify sets or replaces a product's profile.
+func (r *PromotionProductRepository) Classify(ctx context.Context, productID uuid.UUID, profile promotion.Profile) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO promotion_products (product_id, category, species, updated_at) VALUES ($1, $2, $3, NOW())
+		 ON CONFLICT (product_id) DO UPDATE SET
+		     category = EXCLUDED.category, species = EXCLUDED.species, updated_at = EXCLUDED.updated_at`,
+		productID, profile.Category, profile.Species,
+	)
+	if err != nil {
+		return fmt.Errorf("saving promotion product: %w", err)
+	}
+	return nil
+}
+
+// FindProfiles returns the profiles of those products that have one.
+func (r *PromotionProductRepository) FindProfiles(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]promotion.Profile, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT product_id, category, species FROM promotion_products WHERE product_id = ANY($1)", productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querying promotion products: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := make(map[uuid.UUID]promotion.Profile, len(productIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		var p promotion.Profile
+		if err := rows.Scan(&id, &p.Category, &p.Species); err != nil {
+			return nil, fmt.Errorf("scanning promotion product: %w", err)
+		}
+		profiles[id] = p
+	}
+	return profiles, rows.Err()
+}
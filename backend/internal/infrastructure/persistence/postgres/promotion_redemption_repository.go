@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// PromotionRedemptionRepository implements promotion.RedemptionRepository
+// using PostgreSQL.
+type PromotionRedemptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPromotionRedemptionRepository creates a new PromotionRedemptionRepository.
+func NewPromotionRedemptionRepository(pool *pgxpool.Pool) *PromotionRedemptionRepository {
+	return &PromotionRedemptionRepository{pool: pool}
+}
+
+// Usage returns how often each promotion has been redeemed, in total and by
+// the customer.
+func (r *PromotionRedemptionRepository) Usage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]promotion.Usage, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT promotion_id, COUNT(*), COUNT(*) FILTER (WHERE customer_id = $2)
+		 FROM promotion_redemptions WHERE promotion_id = ANY($1) GROUP BY promotion_id`,
+		promotionIDs, customerID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying promotion usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[uuid.UUID]promotion.Usage, len(promotionIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		var u promotion.Usage
+		if err := rows.Scan(&id, &u.Total, &u.ByCustomer); err != nil {
+			return nil, fmt.Errorf("scanning promotion usage: %w", err)
+		}
+		usage[id] = u
+	}
+	return usage, rows.Err()
+}
+
+// Redeem records redemptions in one transaction. Each promotion's row is
+// locked while its limits are checked, in ID order so concurrent orders
+// cannot deadlock.
+func (r *PromotionRedemptionRepository) Redeem(ctx context.Context, redemptions []promotion.Redemption) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sorted := slices.Clone(redemptions)
+	slices.SortFunc(sorted, func(a, b promotion.Redemption) int {
+		return strings.Compare(a.PromotionID.String(), b.PromotionID.String())
+	})
+	for _, rd := range sorted {
+		var maxUses, maxPerCustomer, total, byCustomer int
+		err := tx.QueryRow(ctx,
+			"SELECT max_uses, max_uses_per_customer FROM promotions WHERE id = $1 FOR UPDATE", rd.PromotionID).
+			Scan(&maxUses, &maxPerCustomer)
+		if err != nil {
+			return fmt.Errorf("locking promotion: %w", err)
+		}
+		err = tx.QueryRow(ctx,
+			`SELECT COUNT(*), COUNT(*) FILTER (WHERE customer_id = $2)
+			 FROM promotion_redemptions WHERE promotion_id = $1`,
+			rd.PromotionID, rd.CustomerID,
+		).Scan(&total, &byCustomer)
+		if err != nil {
+			return fmt.Errorf("counting promotion redemptions: %w", err)
+		}
+		if (maxUses > 0 && total >= maxUses) || (maxPerCustomer > 0 && byCustomer >= maxPerCustomer) {
+			return promotion.ErrUsageLimitReached
+		}
+
+		_, err = tx.Exec(ctx,
+			`INSERT INTO promotion_redemptions (promotion_id, order_id, customer_id, discount_cents, redeemed_at)
+			 VALUES ($1, $2, $3, $4, $5)`,
+			rd.PromotionID, rd.OrderID, rd.CustomerID, rd.DiscountCents, rd.RedeemedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting promotion redemption: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing promotion redemptions: %w", err)
+	}
+	return nil
+}
+
+// Release removes an order's redemptions.
+func (r *PromotionRedemptionRepository) Release(ctx context.Context, orderID uuid.UUID) error {
+	if _, err := r.pool.Exec(ctx, "DELETE FROM promotion_redemptions WHERE order_id = $1", orderID); err != nil {
+		return fmt.Errorf("releasing promotion redemptions: %w", err)
+	}
+	return nil
+}
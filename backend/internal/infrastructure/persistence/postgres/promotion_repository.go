@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+)
+
+// PromotionRepository implements promotion.Repository using PostgreSQL.
+type PromotionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPromotionRepository creates a new PromotionRepository.
+func NewPromotionRepository(pool *pgxpool.Pool) *PromotionRepository {
+	return &PromotionRepository{pool: pool}
+}
+
+const promotionColumns = "id, name, code, kind, percent_bp, amount_cents, buy_grams, free_grams, min_subtotal_cents, " +
+	"product_ids, categories, species, segments, starts_at, ends_at, max_uses, max_uses_per_customer, priority, " +
+	"stacking, active, created_at, updated_at"
+
+// Save inserts or updates a promotion.
+func (r *PromotionRepository) Save(ctx context.Context, p *promotion.Promotion) error {
+	t := p.Terms()
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO promotions (`+promotionColumns+`)
+		 VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, code = EXCLUDED.code, kind = EXCLUDED.kind, percent_bp = EXCLUDED.percent_bp,
+		     amount_cents = EXCLUDED.amount_cents, buy_grams = EXCLUDED.buy_grams, free_grams = EXCLUDED.free_grams,
+		     min_subtotal_cents = EXCLUDED.min_subtotal_cents, product_ids = EXCLUDED.product_ids,
+		     categories = EXCLUDED.categories, species = EXCLUDED.species, segments = EXCLUDED.segments,
+		     starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at, max_uses = EXCLUDED.max_uses,
+		     max_uses_per_customer = EXCLUDED.max_uses_per_customer, priority = EXCLUDED.priority,
+		     stacking = EXCLUDED.stacking, active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		p.ID(), t.Name, t.Code, string(t.Rule.Kind), t.Rule.PercentBP, t.Rule.AmountCents, t.Rule.BuyGrams,
+		t.Rule.FreeGrams, t.Rule.MinSubtotalCents, nonNil(t.Scope.ProductIDs), nonNil(t.Scope.Categories),
+		nonNil(t.Scope.Species), nonNil(t.Scope.Segments), t.StartsAt, t.EndsAt, t.MaxUses, t.MaxUsesPerCustomer,
+		t.Priority, string(t.Stacking), p.IsActive(), p.CreatedAt(), p.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_promotions_code" {
+			return promotion.ErrDuplicateCode
+		}
+		return fmt.Errorf("saving promotion: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a promotion by ID.
+func (r *PromotionRepository) FindByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+promotionColumns+" FROM promotions WHERE id = $1", id)
+
+	p, err := scanPromotion(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, promotion.ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("querying promotion by id: %w", err)
+	}
+	return p, nil
+}
+
+// FindAll returns every promotion, newest first.
+func (r *PromotionRepository) FindAll(ctx context.Context) ([]*promotion.Promotion, error) {
+	return r.query(ctx, "SELECT "+promotionColumns+" FROM promotions ORDER BY created_at DESC, id")
+}
+
+// FindRunning returns the active promotions whose dates include at.
+func (r *PromotionRepository) FindRunning(ctx context.Context, at time.Time) ([]*promotion.Promotion, error) {
+	return r.query(ctx,
+		`SELECT `+promotionColumns+` FROM promotions
+		 WHERE active AND (starts_at IS NULL OR starts_at <= $1) AND (ends_at IS NULL OR ends_at > $1)
+		 ORDER BY priority DESC, created_at, id`,
+		at,
+	)
+}
+
+func (r *PromotionRepository) query(ctx context.Context, query string, args ...any) ([]*promotion.Promotion, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying promotions: %w", err)
+	}
+	defer rows.Close()
+
+	var promotions []*promotion.Promotion
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning promotion: %w", err)
+		}
+		promotions = append(promotions, p)
+	}
+	return promotions, rows.Err()
+}
+
+func scanPromotion(row pgx.Row) (*promotion.Promotion, error) {
+	var id uuid.UUID
+	var t promotion.Terms
+	var code *string
+	var kind, stacking string
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &t.Name, &code, &kind, &t.Rule.PercentBP, &t.Rule.AmountCents, &t.Rule.BuyGrams,
+		&t.Rule.FreeGrams, &t.Rule.MinSubtotalCents, &t.Scope.ProductIDs, &t.Scope.Categories, &t.Scope.Species,
+		&t.Scope.Segments, &t.StartsAt, &t.EndsAt, &t.MaxUses, &t.MaxUsesPerCustomer, &t.Priority, &stacking,
+		&active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if code != nil {
+		t.Code = *code
+	}
+	t.Rule.Kind = promotion.Kind(kind)
+	t.Stacking = promotion.Stacking(stacking)
+
+	return promotion.ReconstructPromotion(id, t, active, createdAt, updatedAt), nil
+}
+
+// nonNil turns a nil slice into an empty one, so it is stored as an empty
+// array rather than NULL.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
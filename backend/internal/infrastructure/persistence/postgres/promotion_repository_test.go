@@ -0,0 +1,114 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPromotionRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	promotions := pgstore.NewPromotionRepository(pool)
+	products := pgstore.NewPromotionProductRepository(pool)
+	redemptions := pgstore.NewPromotionRedemptionRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ends := now.Add(48 * time.Hour)
+	lambID := uuid.New()
+	lamb, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+		Name:               "Lamb weekend",
+		Code:               "LAMB10",
+		Rule:               promotion.Rule{Kind: promotion.KindPercentage, PercentBP: 1000},
+		Scope:              promotion.Scope{ProductIDs: []uuid.UUID{lambID}, Species: []string{"lamb"}},
+		EndsAt:             &ends,
+		MaxUses:            2,
+		MaxUsesPerCustomer: 1,
+		Priority:           5,
+	}, now)
+	require.NoError(t, err)
+
+	t.Run("promotions round-trip", func(t *testing.T) {
+		require.NoError(t, promotions.Save(ctx, lamb))
+
+		found, err := promotions.FindByID(ctx, lamb.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, lamb.Terms().Scope, found.Terms().Scope)
+		assert.Equal(t, "LAMB10", found.Code())
+		assert.Equal(t, ends, found.Terms().EndsAt.UTC())
+		assert.Nil(t, found.Terms().StartsAt)
+		assert.Equal(t, promotion.StackingStackable, found.Stacking())
+	})
+
+	t.Run("codes are unique", func(t *testing.T) {
+		dup, err := promotion.NewPromotion(uuid.New(), promotion.Terms{
+			Name: "Copy", Code: "lamb10", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery},
+		}, now)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, promotions.Save(ctx, dup), promotion.ErrDuplicateCode)
+	})
+
+	t.Run("only running promotions are found", func(t *testing.T) {
+		off, err := promotion.NewPromotion(uuid.New(), promotion.Terms{Name: "Off", Rule: promotion.Rule{Kind: promotion.KindFreeDelivery}}, now)
+		require.NoError(t, err)
+		off.SetActive(false, now)
+		require.NoError(t, promotions.Save(ctx, off))
+
+		running, err := promotions.FindRunning(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, running, 1)
+		assert.Equal(t, lamb.ID(), running[0].ID())
+
+		later, err := promotions.FindRunning(ctx, ends)
+		require.NoError(t, err)
+		assert.Empty(t, later)
+
+		all, err := promotions.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("product profiles are replaced", func(t *testing.T) {
+		require.NoError(t, products.Classify(ctx, lambID, promotion.Profile{Category: "roasting", Species: "lamb"}))
+		require.NoError(t, products.Classify(ctx, lambID, promotion.Profile{Species: "lamb"}))
+
+		found, err := products.FindProfiles(ctx, []uuid.UUID{lambID, uuid.New()})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]promotion.Profile{lambID: {Species: "lamb"}}, found)
+	})
+
+	t.Run("redemptions are limited and released", func(t *testing.T) {
+		alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+		redeem := func(customerID, orderID uuid.UUID) error {
+			return redemptions.Redeem(ctx, []promotion.Redemption{
+				{PromotionID: lamb.ID(), OrderID: orderID, CustomerID: customerID, DiscountCents: 200, RedeemedAt: now},
+			})
+		}
+		aliceOrder := uuid.New()
+		require.NoError(t, redeem(alice, aliceOrder))
+		assert.ErrorIs(t, redeem(alice, uuid.New()), promotion.ErrUsageLimitReached)
+		require.NoError(t, redeem(bob, uuid.New()))
+		assert.ErrorIs(t, redeem(carol, uuid.New()), promotion.ErrUsageLimitReached)
+
+		usage, err := redemptions.Usage(ctx, []uuid.UUID{lamb.ID()}, alice)
+		require.NoError(t, err)
+		assert.Equal(t, promotion.Usage{Total: 2, ByCustomer: 1}, usage[lamb.ID()])
+
+		require.NoError(t, redemptions.Release(ctx, aliceOrder))
+		require.NoError(t, redeem(carol, uuid.New()))
+	})
+}
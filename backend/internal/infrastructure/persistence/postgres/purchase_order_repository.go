@@ -0,0 +1,404 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// PurchaseOrderRepository implements procurement.PurchaseOrderRepository using PostgreSQL.
+type PurchaseOrderRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPurchaseOrderRepository creates a new PurchaseOrderRepository.
+func NewPurchaseOrderRepository(pool *pgxpool.Pool) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{pool: pool}
+}
+
+const purchaseOrderColumns = "id, supplier_id, branch_id, status, expected_on, notes, created_by, version, created_at, sent_at, closed_at, updated_at"
+
+const purchaseOrderLineColumns = "id, product_id, ordered_grams, price_per_kg_cents, received_grams"
+
+// Create inserts a new purchase order and its lines.
+func (r *PurchaseOrderRepository) Create(ctx context.Context, po *procurement.PurchaseOrder) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO purchase_orders (`+purchaseOrderColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		po.ID(), po.SupplierID(), po.BranchID(), string(po.Status()), po.ExpectedOn(), po.Notes(), po.CreatedBy(),
+		po.Version(), po.CreatedAt(), po.SentAt(), po.ClosedAt(), po.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting purchase order: %w", err)
+	}
+
+	if err := writePurchaseOrderLines(ctx, tx, po); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing purchase order: %w", err)
+	}
+	return nil
+}
+
+// Update stores a changed purchase order and its lines.
+func (r *PurchaseOrderRepository) Update(ctx context.Context, po *procurement.PurchaseOrder) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := updatePurchaseOrder(ctx, tx, po); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing purchase order: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a purchase order by ID.
+func (r *PurchaseOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*procurement.PurchaseOrder, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	row := r.pool.QueryRow(ctx, "SELECT "+purchaseOrderColumns+" FROM purchase_orders WHERE id = $1 AND "+cond, args...)
+
+	header, err := scanPurchaseOrderRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, procurement.ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("querying purchase order by id: %w", err)
+	}
+
+	orders, err := r.withLines(ctx, []purchaseOrderRow{header})
+	if err != nil {
+		return nil, err
+	}
+	return orders[0], nil
+}
+
+// FindAll returns purchase orders in scope, most recently created first.
+func (r *PurchaseOrderRepository) FindAll(ctx context.Context, filter procurement.Filter) ([]*procurement.PurchaseOrder, error) {
+	cond, args := branchScope(ctx, "branch_id", nil)
+	query := "SELECT " + purchaseOrderColumns + " FROM purchase_orders WHERE " + cond
+	if filter.SupplierID != nil {
+		args = append(args, *filter.SupplierID)
+		query += " AND supplier_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.BranchID != nil {
+		args = append(args, *filter.BranchID)
+		query += " AND branch_id = $" + strconv.Itoa(len(args))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, s := range filter.Statuses {
+			statuses = append(statuses, string(s))
+		}
+		args = append(args, statuses)
+		query += " AND status = ANY($" + strconv.Itoa(len(args)) + ")"
+	}
+	if filter.SentFrom != nil {
+		args = append(args, *filter.SentFrom)
+		query += " AND sent_at >= $" + strconv.Itoa(len(args))
+	}
+	if filter.SentTo != nil {
+		args = append(args, *filter.SentTo)
+		query += " AND sent_at < $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY created_at DESC, id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying purchase orders: %w", err)
+	}
+	defer rows.Close()
+
+	var headers []purchaseOrderRow
+	for rows.Next() {
+		header, err := scanPurchaseOrderRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning purchase order: %w", err)
+		}
+		headers = append(headers, header)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return r.withLines(ctx, headers)
+}
+
+// SaveReceipt stores a goods-received note, the received amounts on the
+// purchase order and the inventory lots the delivery was received into, in
+// one transaction.
+func (r *PurchaseOrderRepository) SaveReceipt(ctx context.Context, po *procurement.PurchaseOrder, n *procurement.GoodsReceivedNote, lots []*inventory.Lot, received []*inventory.Movement) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := updatePurchaseOrder(ctx, tx, po); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO goods_received_notes (id, purchase_order_id, branch_id, notes, received_by, received_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		n.ID(), n.PurchaseOrderID(), n.BranchID(), n.Notes(), n.ReceivedBy(), n.ReceivedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting goods received note: %w", err)
+	}
+
+	for i, lot := range lots {
+		if err := insertInventoryLot(ctx, tx, lot); err != nil {
+			return err
+		}
+		if err := insertStockMovement(ctx, tx, received[i]); err != nil {
+			return err
+		}
+	}
+
+	for i, l := range n.Lines() {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO goods_received_lines (lot_id, goods_received_note_id, position, purchase_order_line_id,
+			     product_id, lot_code, grams, temperature_tenths_c, expires_on)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			l.LotID, n.ID(), i, l.LineID, l.ProductID, l.LotCode, l.Grams, l.TemperatureTenthsC, l.ExpiresOn,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting goods received line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing goods received note: %w", err)
+	}
+	return nil
+}
+
+// FindReceipts returns the goods-received notes in scope for an order,
+// oldest first.
+func (r *PurchaseOrderRepository) FindReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]*procurement.GoodsReceivedNote, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{purchaseOrderID})
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, branch_id, notes, received_by, received_at FROM goods_received_notes
+		 WHERE purchase_order_id = $1 AND `+cond+` ORDER BY received_at, id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying goods received notes: %w", err)
+	}
+	defer rows.Close()
+
+	type noteRow struct {
+		id, branchID uuid.UUID
+		notes        string
+		receivedBy   *uuid.UUID
+		receivedAt   time.Time
+	}
+	var notes []noteRow
+	var ids []uuid.UUID
+	for rows.Next() {
+		var n noteRow
+		if err := rows.Scan(&n.id, &n.branchID, &n.notes, &n.receivedBy, &n.receivedAt); err != nil {
+			return nil, fmt.Errorf("scanning goods received note: %w", err)
+		}
+		notes = append(notes, n)
+		ids = append(ids, n.id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, nil
+	}
+
+	lineRows, err := r.pool.Query(ctx,
+		`SELECT goods_received_note_id, purchase_order_line_id, product_id, lot_id, lot_code, grams,
+		     temperature_tenths_c, expires_on
+		 FROM goods_received_lines WHERE goods_received_note_id = ANY($1) ORDER BY position`,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying goods received lines: %w", err)
+	}
+	defer lineRows.Close()
+
+	lines := make(map[uuid.UUID][]procurement.ReceiptLine, len(notes))
+	for lineRows.Next() {
+		var noteID uuid.UUID
+		var l procurement.ReceiptLine
+		err := lineRows.Scan(&noteID, &l.LineID, &l.ProductID, &l.LotID, &l.LotCode, &l.Grams,
+			&l.TemperatureTenthsC, &l.ExpiresOn)
+		if err != nil {
+			return nil, fmt.Errorf("scanning goods received line: %w", err)
+		}
+		lines[noteID] = append(lines[noteID], l)
+	}
+	if err := lineRows.Err(); err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*procurement.GoodsReceivedNote, 0, len(notes))
+	for _, n := range notes {
+		receipts = append(receipts, procurement.ReconstructGoodsReceivedNote(n.id, purchaseOrderID, n.branchID,
+			lines[n.id], n.notes, n.receivedBy, n.receivedAt))
+	}
+	return receipts, nil
+}
+
+// FindPriceHistory returns the prices a supplier has been paid on sent
+// orders in scope, newest first.
+func (r *PurchaseOrderRepository) FindPriceHistory(ctx context.Context, supplierID uuid.UUID, productID *uuid.UUID) ([]procurement.PricePoint, error) {
+	cond, args := branchScope(ctx, "po.branch_id", []any{supplierID})
+	query := `SELECT l.product_id, l.price_per_kg_cents, po.id, po.sent_at
+		 FROM purchase_order_lines l
+		 JOIN purchase_orders po ON po.id = l.purchase_order_id
+		 WHERE po.supplier_id = $1 AND po.sent_at IS NOT NULL AND ` + cond
+	if productID != nil {
+		args = append(args, *productID)
+		query += " AND l.product_id = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY po.sent_at DESC, l.position"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying price history: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []procurement.PricePoint
+	for rows.Next() {
+		var p procurement.PricePoint
+		if err := rows.Scan(&p.ProductID, &p.PricePerKgCents, &p.PurchaseOrderID, &p.SentAt); err != nil {
+			return nil, fmt.Errorf("scanning price point: %w", err)
+		}
+		prices = append(prices, p)
+	}
+	return prices, rows.Err()
+}
+
+// updatePurchaseOrder writes the order and its lines, bumping the version.
+// The write only applies if the stored version is still the one the order
+// was loaded with.
+func updatePurchaseOrder(ctx context.Context, tx pgx.Tx, po *procurement.PurchaseOrder) error {
+	result, err := tx.Exec(ctx,
+		`UPDATE purchase_orders SET status = $3, expected_on = $4, notes = $5, sent_at = $6, closed_at = $7,
+		     updated_at = $8, version = version + 1
+		 WHERE id = $1 AND version = $2`,
+		po.ID(), po.Version(), string(po.Status()), po.ExpectedOn(), po.Notes(), po.SentAt(), po.ClosedAt(),
+		po.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating purchase order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return procurement.ErrConcurrentUpdate
+	}
+	return writePurchaseOrderLines(ctx, tx, po)
+}
+
+// writePurchaseOrderLines upserts the order's lines and deletes any it no
+// longer has. Revising a draft replaces every line; later changes only touch
+// received weights.
+func writePurchaseOrderLines(ctx context.Context, tx pgx.Tx, po *procurement.PurchaseOrder) error {
+	ids := make([]uuid.UUID, 0, len(po.Lines()))
+	for i, l := range po.Lines() {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO purchase_order_lines (purchase_order_id, position, `+purchaseOrderLineColumns+`)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 ON CONFLICT (id) DO UPDATE SET position = EXCLUDED.position, received_grams = EXCLUDED.received_grams`,
+			po.ID(), i, l.ID, l.ProductID, l.OrderedGrams, l.PricePerKgCents, l.ReceivedGrams,
+		)
+		if err != nil {
+			return fmt.Errorf("saving purchase order line: %w", err)
+		}
+		ids = append(ids, l.ID)
+	}
+
+	_, err := tx.Exec(ctx,
+		"DELETE FROM purchase_order_lines WHERE purchase_order_id = $1 AND id <> ALL($2)",
+		po.ID(), ids,
+	)
+	if err != nil {
+		return fmt.Errorf("deleting purchase order lines: %w", err)
+	}
+	return nil
+}
+
+// purchaseOrderRow is a purchase_orders row waiting for its lines.
+type purchaseOrderRow struct {
+	id, supplierID, branchID uuid.UUID
+	status, notes            string
+	expectedOn               *time.Time
+	createdBy                *uuid.UUID
+	version                  int
+	createdAt, updatedAt     time.Time
+	sentAt, closedAt         *time.Time
+}
+
+func scanPurchaseOrderRow(row pgx.Row) (purchaseOrderRow, error) {
+	var h purchaseOrderRow
+	err := row.Scan(&h.id, &h.supplierID, &h.branchID, &h.status, &h.expectedOn, &h.notes, &h.createdBy,
+		&h.version, &h.createdAt, &h.sentAt, &h.closedAt, &h.updatedAt)
+	return h, err
+}
+
+// withLines loads the lines of every order in one query and reconstructs the orders.
+func (r *PurchaseOrderRepository) withLines(ctx context.Context, headers []purchaseOrderRow) ([]*procurement.PurchaseOrder, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, 0, len(headers))
+	for _, h := range headers {
+		ids = append(ids, h.id)
+	}
+
+	rows, err := r.pool.Query(ctx,
+		"SELECT purchase_order_id, "+purchaseOrderLineColumns+" FROM purchase_order_lines WHERE purchase_order_id = ANY($1) ORDER BY position",
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying purchase order lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[uuid.UUID][]procurement.Line, len(headers))
+	for rows.Next() {
+		var orderID uuid.UUID
+		var l procurement.Line
+		if err := rows.Scan(&orderID, &l.ID, &l.ProductID, &l.OrderedGrams, &l.PricePerKgCents, &l.ReceivedGrams); err != nil {
+			return nil, fmt.Errorf("scanning purchase order line: %w", err)
+		}
+		lines[orderID] = append(lines[orderID], l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*procurement.PurchaseOrder, 0, len(headers))
+	for _, h := range headers {
+		orders = append(orders, procurement.ReconstructPurchaseOrder(h.id, h.supplierID, h.branchID,
+			procurement.Status(h.status), lines[h.id], h.expectedOn, h.notes, h.createdBy, h.version,
+			h.createdAt, h.sentAt, h.closedAt, h.updatedAt))
+	}
+	return orders, nil
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// QurbaniBookingRepository implements qurbani.BookingRepository using PostgreSQL.
+type QurbaniBookingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQurbaniBookingRepository creates a new QurbaniBookingRepository.
+func NewQurbaniBookingRepository(pool *pgxpool.Pool) *QurbaniBookingRepository {
+	return &QurbaniBookingRepository{pool: pool}
+}
+
+const qurbaniBookingColumns = "b.id, b.offering_id, b.customer_id, b.niyyah_name, b.distribution, b.address_id, " +
+	"b.price_cents, b.status, b.animal_id, b.booked_at, b.slaughtered_at, b.notified_at, b.updated_at"
+
+// Book stores the booking if the offering has a share free for it. The
+// offering row is locked FOR UPDATE so concurrent bookings queue behind each
+// other and always see each other when counting shares.
+func (r *QurbaniBookingRepository) Book(ctx context.Context, offering *qurbani.Offering, b *qurbani.Booking) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var animals int
+	var active bool
+	err = tx.QueryRow(ctx,
+		"SELECT animals, active FROM qurbani_offerings WHERE id = $1 FOR UPDATE",
+		offering.ID(),
+	).Scan(&animals, &active)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return qurbani.ErrOfferingNotFound
+		}
+		return fmt.Errorf("locking qurbani offering: %w", err)
+	}
+	if !active {
+		return qurbani.ErrOfferingClosed
+	}
+
+	var booked int
+	err = tx.QueryRow(ctx,
+		"SELECT COUNT(*) FROM qurbani_bookings WHERE offering_id = $1 AND status <> 'cancelled'",
+		offering.ID(),
+	).Scan(&booked)
+	if err != nil {
+		return fmt.Errorf("counting qurbani bookings: %w", err)
+	}
+	if booked >= animals*offering.ShareType().SharesPerAnimal() {
+		return qurbani.ErrSoldOut
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO qurbani_bookings
+		     (id, offering_id, customer_id, niyyah_name, distribution, address_id, price_cents, status, booked_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		b.ID(), b.OfferingID(), b.CustomerID(), b.NiyyahName(), string(b.Distribution()), b.AddressID(),
+		b.PriceCents(), string(b.Status()), b.BookedAt(), b.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting qurbani booking: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing qurbani booking: %w", err)
+	}
+	return nil
+}
+
+// Update persists a booking's status, animal and notification.
+func (r *QurbaniBookingRepository) Update(ctx context.Context, b *qurbani.Booking) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE qurbani_bookings
+		 SET status = $2, animal_id = $3, slaughtered_at = $4, notified_at = $5, updated_at = $6
+		 WHERE id = $1`,
+		b.ID(), string(b.Status()), b.AnimalID(), b.SlaughteredAt(), b.NotifiedAt(), b.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating qurbani booking: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return qurbani.ErrBookingNotFound
+	}
+	return nil
+}
+
+// FindByID finds a booking by ID.
+func (r *QurbaniBookingRepository) FindByID(ctx context.Context, id uuid.UUID) (*qurbani.Booking, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+qurbaniBookingColumns+" FROM qurbani_bookings b WHERE b.id = $1", id)
+
+	b, err := scanQurbaniBooking(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, qurbani.ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("querying qurbani booking by id: %w", err)
+	}
+	return b, nil
+}
+
+// FindAll returns bookings in the order they were made.
+func (r *QurbaniBookingRepository) FindAll(ctx context.Context, filter qurbani.BookingFilter) ([]*qurbani.Booking, error) {
+	query := `SELECT ` + qurbaniBookingColumns + ` FROM qurbani_bookings b
+		 JOIN qurbani_offerings o ON o.id = b.offering_id
+		 LEFT JOIN qurbani_animals a ON a.id = b.animal_id
+		 WHERE TRUE`
+	var args []any
+	if filter.Season != "" {
+		args = append(args, filter.Season)
+		query += " AND o.season = $" + strconv.Itoa(len(args))
+	}
+	if filter.OfferingID != nil {
+		args = append(args, *filter.OfferingID)
+		query += " AND b.offering_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		query += " AND b.customer_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.BatchID != nil {
+		args = append(args, *filter.BatchID)
+		query += " AND a.batch_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND b.status = $" + strconv.Itoa(len(args))
+	}
+	if filter.Distribution != nil {
+		args = append(args, string(*filter.Distribution))
+		query += " AND b.distribution = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY b.booked_at, b.id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*qurbani.Booking
+	for rows.Next() {
+		b, err := scanQurbaniBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning qurbani booking: %w", err)
+		}
+		bookings = append(bookings, b)
+	}
+	return bookings, rows.Err()
+}
+
+// CountActive counts the offering's bookings that are not cancelled.
+func (r *QurbaniBookingRepository) CountActive(ctx context.Context, offeringID uuid.UUID) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM qurbani_bookings WHERE offering_id = $1 AND status <> 'cancelled'",
+		offeringID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("counting qurbani bookings: %w", err)
+	}
+	return n, nil
+}
+
+func scanQurbaniBooking(row pgx.Row) (*qurbani.Booking, error) {
+	var id, offeringID, customerID uuid.UUID
+	var niyyahName, distribution, status string
+	var addressID, animalID *uuid.UUID
+	var priceCents int64
+	var bookedAt, updatedAt time.Time
+	var slaughteredAt, notifiedAt *time.Time
+
+	if err := row.Scan(&id, &offeringID, &customerID, &niyyahName, &distribution, &addressID, &priceCents,
+		&status, &animalID, &bookedAt, &slaughteredAt, &notifiedAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return qurbani.ReconstructBooking(id, offeringID, customerID, niyyahName, qurbani.Distribution(distribution),
+		addressID, priceCents, qurbani.BookingStatus(status), animalID, bookedAt, slaughteredAt, notifiedAt, updatedAt), nil
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// QurbaniOfferingRepository implements qurbani.OfferingRepository using PostgreSQL.
+type QurbaniOfferingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQurbaniOfferingRepository creates a new QurbaniOfferingRepository.
+func NewQurbaniOfferingRepository(pool *pgxpool.Pool) *QurbaniOfferingRepository {
+	return &QurbaniOfferingRepository{pool: pool}
+}
+
+const qurbaniOfferingColumns = "id, season, share_type, price_cents, animals, active, created_at, updated_at"
+
+// Save inserts a new offering. It returns qurbani.ErrDuplicateOffering if the
+// season already has one for the share type.
+func (r *QurbaniOfferingRepository) Save(ctx context.Context, o *qurbani.Offering) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO qurbani_offerings (`+qurbaniOfferingColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		o.ID(), o.Season(), string(o.ShareType()), o.PriceCents(), o.Animals(), o.Active(), o.CreatedAt(), o.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return qurbani.ErrDuplicateOffering
+		}
+		return fmt.Errorf("inserting qurbani offering: %w", err)
+	}
+	return nil
+}
+
+// Update persists an offering's price, animals and whether it is open.
+func (r *QurbaniOfferingRepository) Update(ctx context.Context, o *qurbani.Offering) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE qurbani_offerings SET price_cents = $2, animals = $3, active = $4, updated_at = $5 WHERE id = $1",
+		o.ID(), o.PriceCents(), o.Animals(), o.Active(), o.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating qurbani offering: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return qurbani.ErrOfferingNotFound
+	}
+	return nil
+}
+
+// FindByID finds an offering by ID.
+func (r *QurbaniOfferingRepository) FindByID(ctx context.Context, id uuid.UUID) (*qurbani.Offering, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+qurbaniOfferingColumns+" FROM qurbani_offerings WHERE id = $1", id)
+
+	o, err := scanQurbaniOffering(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, qurbani.ErrOfferingNotFound
+		}
+		return nil, fmt.Errorf("querying qurbani offering by id: %w", err)
+	}
+	return o, nil
+}
+
+// FindAll returns a season's offerings, or every season's when it is empty.
+func (r *QurbaniOfferingRepository) FindAll(ctx context.Context, season string) ([]*qurbani.Offering, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+qurbaniOfferingColumns+` FROM qurbani_offerings
+		 WHERE $1 = '' OR season = $1
+		 ORDER BY season DESC, share_type, id`,
+		season,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani offerings: %w", err)
+	}
+	defer rows.Close()
+
+	var offerings []*qurbani.Offering
+	for rows.Next() {
+		o, err := scanQurbaniOffering(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning qurbani offering: %w", err)
+		}
+		offerings = append(offerings, o)
+	}
+	return offerings, rows.Err()
+}
+
+func scanQurbaniOffering(row pgx.Row) (*qurbani.Offering, error) {
+	var id uuid.UUID
+	var season, shareType string
+	var priceCents int64
+	var animals int
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &season, &shareType, &priceCents, &animals, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return qurbani.ReconstructOffering(id, season, qurbani.ShareType(shareType), priceCents, animals, active, createdAt, updatedAt), nil
+}
@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationQurbaniRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	offerings := pgstore.NewQurbaniOfferingRepository(pool)
+	bookings := pgstore.NewQurbaniBookingRepository(pool)
+	slaughter := pgstore.NewQurbaniSlaughterRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "qurbani@example.com")
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	offering, err := qurbani.NewOffering(uuid.New(), "2027", qurbani.ShareWholeSheep, 22000, 2, now)
+	require.NoError(t, err)
+	require.NoError(t, offerings.Save(ctx, offering))
+	dup, err := qurbani.NewOffering(uuid.New(), "2027", qurbani.ShareWholeSheep, 20000, 1, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, offerings.Save(ctx, dup), qurbani.ErrDuplicateOffering)
+
+	book := func(name string) (*qurbani.Booking, error) {
+		b, err := qurbani.NewBooking(uuid.New(), offering, customerID, name, qurbani.DistributionCharity, nil, now)
+		require.NoError(t, err)
+		return b, bookings.Book(ctx, offering, b)
+	}
+
+	t.Run("bookings stop when every share is taken", func(t *testing.T) {
+		_, err := book("Ahmed")
+		require.NoError(t, err)
+		_, err = book("Maryam")
+		require.NoError(t, err)
+		_, err = book("Yusuf")
+		assert.ErrorIs(t, err, qurbani.ErrSoldOut)
+
+		n, err := bookings.CountActive(ctx, offering.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+
+	var batch *qurbani.Batch
+	t.Run("shares are allocated and batched", func(t *testing.T) {
+		all, err := bookings.FindAll(ctx, qurbani.BookingFilter{OfferingID: ptr(offering.ID())})
+		require.NoError(t, err)
+		alloc, err := qurbani.Allocate(offering, nil, all, now)
+		require.NoError(t, err)
+		require.NoError(t, slaughter.SaveAllocation(ctx, alloc))
+		assert.ErrorIs(t, slaughter.SaveAllocation(ctx, alloc), qurbani.ErrAllocationConflict)
+
+		waiting, err := slaughter.FindAnimals(ctx, qurbani.AnimalFilter{Season: "2027", Waiting: true})
+		require.NoError(t, err)
+		require.Len(t, waiting, 2)
+		batches, err := qurbani.PlanBatches("2027", waiting, 0, now.Add(time.Hour), 5, 0, now)
+		require.NoError(t, err)
+		require.NoError(t, slaughter.SaveBatches(ctx, batches, waiting))
+		batch = batches[0]
+
+		last, err := slaughter.LastBatchNumber(ctx, "2027")
+		require.NoError(t, err)
+		assert.Equal(t, 1, last)
+		waiting, err = slaughter.FindAnimals(ctx, qurbani.AnimalFilter{Season: "2027", Waiting: true})
+		require.NoError(t, err)
+		assert.Empty(t, waiting)
+	})
+
+	t.Run("completing a batch slaughters its bookings once", func(t *testing.T) {
+		animals, err := slaughter.FindAnimals(ctx, qurbani.AnimalFilter{BatchID: ptr(batch.ID())})
+		require.NoError(t, err)
+		inBatch, err := bookings.FindAll(ctx, qurbani.BookingFilter{BatchID: ptr(batch.ID())})
+		require.NoError(t, err)
+		require.Len(t, inBatch, 2)
+
+		done, err := batch.Complete(animals, inBatch, now.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, slaughter.CompleteBatch(ctx, batch, animals, done))
+		assert.ErrorIs(t, slaughter.CompleteBatch(ctx, batch, animals, done), qurbani.ErrBatchAlreadyCompleted)
+
+		status := qurbani.BookingSlaughtered
+		slaughtered, err := bookings.FindAll(ctx, qurbani.BookingFilter{Season: "2027", Status: &status})
+		require.NoError(t, err)
+		assert.Len(t, slaughtered, 2)
+
+		stored, err := slaughter.FindBatchByID(ctx, batch.ID())
+		require.NoError(t, err)
+		assert.NotNil(t, stored.CompletedAt())
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+)
+
+// QurbaniSlaughterRepository implements qurbani.SlaughterRepository using PostgreSQL.
+type QurbaniSlaughterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQurbaniSlaughterRepository creates a new QurbaniSlaughterRepository.
+func NewQurbaniSlaughterRepository(pool *pgxpool.Pool) *QurbaniSlaughterRepository {
+	return &QurbaniSlaughterRepository{pool: pool}
+}
+
+const qurbaniBatchColumns = "id, season, number, scheduled_at, completed_at, created_at"
+
+// SaveAllocation inserts the new animals and places the bookings on them.
+// Bookings are only moved while still unallocated, so a booking cancelled or
+// allocated by a concurrent run fails the whole allocation.
+func (r *QurbaniSlaughterRepository) SaveAllocation(ctx context.Context, a *qurbani.Allocation) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, animal := range a.Animals {
+		_, err := tx.Exec(ctx,
+			"INSERT INTO qurbani_animals (id, offering_id, number, created_at) VALUES ($1, $2, $3, $4)",
+			animal.ID(), animal.OfferingID(), animal.Number(), animal.CreatedAt(),
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return qurbani.ErrAllocationConflict
+			}
+			return fmt.Errorf("inserting qurbani animal: %w", err)
+		}
+	}
+
+	for _, b := range a.Bookings {
+		result, err := tx.Exec(ctx,
+			`UPDATE qurbani_bookings SET status = $2, animal_id = $3, updated_at = $4
+			 WHERE id = $1 AND status = 'booked'`,
+			b.ID(), string(b.Status()), b.AnimalID(), b.UpdatedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("allocating qurbani booking: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return qurbani.ErrAllocationConflict
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing qurbani allocation: %w", err)
+	}
+	return nil
+}
+
+// FindAnimals returns animals by season, offering and number.
+func (r *QurbaniSlaughterRepository) FindAnimals(ctx context.Context, filter qurbani.AnimalFilter) ([]*qurbani.Animal, error) {
+	query := `SELECT a.id, a.offering_id, a.number, a.batch_id, a.slaughtered_at, a.created_at
+		 FROM qurbani_animals a JOIN qurbani_offerings o ON o.id = a.offering_id
+		 WHERE TRUE`
+	var args []any
+	if filter.Season != "" {
+		args = append(args, filter.Season)
+		query += " AND o.season = $" + strconv.Itoa(len(args))
+	}
+	if filter.OfferingID != nil {
+		args = append(args, *filter.OfferingID)
+		query += " AND a.offering_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.BatchID != nil {
+		args = append(args, *filter.BatchID)
+		query += " AND a.batch_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Waiting {
+		query += ` AND a.batch_id IS NULL
+		 AND EXISTS (SELECT 1 FROM qurbani_bookings b WHERE b.animal_id = a.id AND b.status = 'allocated')`
+	}
+	query += " ORDER BY o.season, o.share_type, a.number, a.id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying qurbani animals: %w", err)
+	}
+	defer rows.Close()
+
+	var animals []*qurbani.Animal
+	for rows.Next() {
+		var id, offeringID uuid.UUID
+		var number int
+		var batchID *uuid.UUID
+		var slaughteredAt *time.Time
+		var createdAt time.Time
+		if err := rows.Scan(&id, &offeringID, &number, &batchID, &slaughteredAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning qurbani animal: %w", err)
+		}
+		animals = append(animals, qurbani.ReconstructAnimal(id, offeringID, number, batchID, slaughteredAt, createdAt))
+	}
+	return animals, rows.Err()
+}
+
+// SaveBatches inserts the batches and puts the animals in them. Animals are
+// only moved while not in a batch, so a concurrent plan fails the whole run.
+func (r *QurbaniSlaughterRepository) SaveBatches(ctx context.Context, batches []*qurbani.Batch, animals []*qurbani.Animal) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, b := range batches {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO qurbani_batches (`+qurbaniBatchColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+			b.ID(), b.Season(), b.Number(), b.ScheduledAt(), b.CompletedAt(), b.CreatedAt(),
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return qurbani.ErrAllocationConflict
+			}
+			return fmt.Errorf("inserting slaughter batch: %w", err)
+		}
+	}
+
+	for _, a := range animals {
+		if a.BatchID() == nil {
+			continue
+		}
+		result, err := tx.Exec(ctx,
+			"UPDATE qurbani_animals SET batch_id = $2 WHERE id = $1 AND batch_id IS NULL",
+			a.ID(), a.BatchID(),
+		)
+		if err != nil {
+			return fmt.Errorf("assigning qurbani animal to batch: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return qurbani.ErrAllocationConflict
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing slaughter batches: %w", err)
+	}
+	return nil
+}
+
+// FindBatchByID finds a slaughter batch by ID.
+func (r *QurbaniSlaughterRepository) FindBatchByID(ctx context.Context, id uuid.UUID) (*qurbani.Batch, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+qurbaniBatchColumns+" FROM qurbani_batches WHERE id = $1", id)
+
+	b, err := scanQurbaniBatch(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, qurbani.ErrBatchNotFound
+		}
+		return nil, fmt.Errorf("querying slaughter batch by id: %w", err)
+	}
+	return b, nil
+}
+
+// FindBatches returns a season's batches in order.
+func (r *QurbaniSlaughterRepository) FindBatches(ctx context.Context, season string) ([]*qurbani.Batch, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT "+qurbaniBatchColumns+" FROM qurbani_batches WHERE season = $1 ORDER BY number",
+		season,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying slaughter batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*qurbani.Batch
+	for rows.Next() {
+		b, err := scanQurbaniBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning slaughter batch: %w", err)
+		}
+		batches = append(batches, b)
+	}
+	return batches, rows.Err()
+}
+
+// LastBatchNumber returns the highest batch number used in a season.
+func (r *QurbaniSlaughterRepository) LastBatchNumber(ctx context.Context, season string) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx,
+		"SELECT COALESCE(MAX(number), 0) FROM qurbani_batches WHERE season = $1",
+		season,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("querying last batch number: %w", err)
+	}
+	return n, nil
+}
+
+// CompleteBatch marks the batch, its animals and their bookings slaughtered.
+// The batch is only completed once, so two concurrent completions cannot
+// both succeed.
+func (r *QurbaniSlaughterRepository) CompleteBatch(ctx context.Context, b *qurbani.Batch, animals []*qurbani.Animal, bookings []*qurbani.Booking) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		"UPDATE qurbani_batches SET completed_at = $2 WHERE id = $1 AND completed_at IS NULL",
+		b.ID(), b.CompletedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("completing slaughter batch: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return qurbani.ErrBatchAlreadyCompleted
+	}
+
+	for _, a := range animals {
+		if _, err := tx.Exec(ctx,
+			"UPDATE qurbani_animals SET slaughtered_at = $2 WHERE id = $1",
+			a.ID(), a.SlaughteredAt(),
+		); err != nil {
+			return fmt.Errorf("marking qurbani animal slaughtered: %w", err)
+		}
+	}
+
+	for _, bk := range bookings {
+		result, err := tx.Exec(ctx,
+			`UPDATE qurbani_bookings SET status = $2, slaughtered_at = $3, updated_at = $4
+			 WHERE id = $1 AND status = 'allocated'`,
+			bk.ID(), string(bk.Status()), bk.SlaughteredAt(), bk.UpdatedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("marking qurbani booking slaughtered: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return qurbani.ErrAllocationConflict
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing slaughter batch: %w", err)
+	}
+	return nil
+}
+
+func scanQurbaniBatch(row pgx.Row) (*qurbani.Batch, error) {
+	var id uuid.UUID
+	var season string
+	var number int
+	var scheduledAt, createdAt time.Time
+	var completedAt *time.Time
+
+	if err := row.Scan(&id, &season, &number, &scheduledAt, &completedAt, &createdAt); err != nil {
+		return nil, err
+	}
+	return qurbani.ReconstructBatch(id, season, number, scheduledAt, completedAt, createdAt), nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// ScaleRepository implements scale.Repository using PostgreSQL.
+type ScaleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewScaleRepository creates a new ScaleRepository.
+func NewScaleRepository(pool *pgxpool.Pool) *ScaleRepository {
+	return &ScaleRepository{pool: pool}
+}
+
+const scaleColumns = "id, name, protocol, transport, address, baud_rate, active, created_at, updated_at"
+
+// Save inserts or updates a scale.
+func (r *ScaleRepository) Save(ctx context.Context, s *scale.Scale) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO scales (`+scaleColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, protocol = EXCLUDED.protocol, transport = EXCLUDED.transport,
+		     address = EXCLUDED.address, baud_rate = EXCLUDED.baud_rate, active = EXCLUDED.active,
+		     updated_at = EXCLUDED.updated_at`,
+		s.ID(), s.Name(), string(s.Protocol()), string(s.Transport()), s.Address(), s.BaudRate(), s.Active(),
+		s.CreatedAt(), s.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_scales_name" {
+			return scale.ErrDuplicateScale
+		}
+		return fmt.Errorf("saving scale: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a scale by ID.
+func (r *ScaleRepository) FindByID(ctx context.Context, id uuid.UUID) (*scale.Scale, error) {
+	s, err := scanScale(r.pool.QueryRow(ctx, "SELECT "+scaleColumns+" FROM scales WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, scale.ErrScaleNotFound
+		}
+		return nil, fmt.Errorf("querying scale: %w", err)
+	}
+	return s, nil
+}
+
+// FindAll returns every scale by name.
+func (r *ScaleRepository) FindAll(ctx context.Context) ([]*scale.Scale, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+scaleColumns+" FROM scales ORDER BY LOWER(name)")
+	if err != nil {
+		return nil, fmt.Errorf("querying scales: %w", err)
+	}
+	defer rows.Close()
+
+	var scales []*scale.Scale
+	for rows.Next() {
+		s, err := scanScale(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning scale: %w", err)
+		}
+		scales = append(scales, s)
+	}
+	return scales, rows.Err()
+}
+
+func scanScale(row pgx.Row) (*scale.Scale, error) {
+	var id uuid.UUID
+	var name, protocol, transport, address string
+	var baudRate int
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &protocol, &transport, &address, &baudRate, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	return scale.ReconstructScale(id, name, scale.Protocol(protocol), scale.Transport(transport), address, baudRate, active, createdAt, updatedAt), nil
+}
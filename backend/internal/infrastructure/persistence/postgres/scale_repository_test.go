@@ -0,0 +1,60 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationScaleRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewScaleRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	counter, err := scale.NewScale(uuid.New(), "Counter", scale.ProtocolMTSICS, scale.TransportTCP, "192.168.1.50:4001", 0, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, counter))
+	back, err := scale.NewScale(uuid.New(), "Back room", scale.ProtocolCAS, scale.TransportSerial, "/dev/ttyUSB0", 0, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, back))
+
+	clash, err := scale.NewScale(uuid.New(), "counter", scale.ProtocolCAS, scale.TransportTCP, "192.168.1.51:4001", 0, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Save(ctx, clash), scale.ErrDuplicateScale)
+
+	got, err := repo.FindByID(ctx, back.ID())
+	require.NoError(t, err)
+	assert.Equal(t, scale.ProtocolCAS, got.Protocol())
+	assert.Equal(t, scale.TransportSerial, got.Transport())
+	assert.Equal(t, scale.DefaultBaudRate, got.BaudRate())
+	assert.True(t, got.Active())
+
+	require.NoError(t, got.Update("Back room", scale.ProtocolCAS, scale.TransportSerial, "/dev/ttyUSB1", 19200, false, now.Add(time.Minute)))
+	require.NoError(t, repo.Save(ctx, got))
+	got, err = repo.FindByID(ctx, back.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/ttyUSB1", got.Address())
+	assert.Equal(t, 19200, got.BaudRate())
+	assert.False(t, got.Active())
+
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, scale.ErrScaleNotFound)
+
+	all, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "Back room", all[0].Name())
+	assert.Equal(t, "Counter", all[1].Name())
+}
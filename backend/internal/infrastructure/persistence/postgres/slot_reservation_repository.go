@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+)
+
+// activeReservationCondition matches reservations that still consume slot capacity.
+const activeReservationCondition = "(status = 'confirmed' OR (status = 'held' AND expires_at > $3))"
+
+// SlotReservationRepository implements fulfilment.ReservationRepository using PostgreSQL.
+type SlotReservationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSlotReservationRepository creates a new SlotReservationRepository.
+func NewSlotReservationRepository(pool *pgxpool.Pool) *SlotReservationRepository {
+	return &SlotReservationRepository{pool: pool}
+}
+
+// Reserve stores the reservation if the slot has room for it. The slot row is
+// locked FOR UPDATE so concurrent checkouts for the same slot queue behind
+// each other and always see each other's holds when summing usage.
+func (r *SlotReservationRepository) Reserve(ctx context.Context, slot fulfilment.Slot, res *fulfilment.Reservation) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO fulfilment_slots (template_id, starts_at) VALUES ($1, $2)
+		 ON CONFLICT DO NOTHING`,
+		slot.TemplateID(), slot.StartsAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting slot: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		"SELECT 1 FROM fulfilment_slots WHERE template_id = $1 AND starts_at = $2 FOR UPDATE",
+		slot.TemplateID(), slot.StartsAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("locking slot: %w", err)
+	}
+
+	var used int64
+	err = tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(quantity), 0)::BIGINT FROM slot_reservations
+		 WHERE template_id = $1 AND slot_starts_at = $2 AND `+activeReservationCondition,
+		slot.TemplateID(), slot.StartsAt(), res.CreatedAt(),
+	).Scan(&used)
+	if err != nil {
+		return fmt.Errorf("summing slot usage: %w", err)
+	}
+
+	if used+res.Quantity() > slot.Capacity().Amount() {
+		return fulfilment.ErrSlotFull
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO slot_reservations
+		     (id, template_id, customer_id, slot_starts_at, slot_ends_at, quantity, status, order_id, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		res.ID(), res.TemplateID(), res.CustomerID(), res.SlotStartsAt(), res.SlotEndsAt(),
+		res.Quantity(), string(res.Status()), res.OrderID(), res.ExpiresAt(), res.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing reservation: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a reservation by ID.
+func (r *SlotReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*fulfilment.Reservation, error) {
+	var templateID, customerID uuid.UUID
+	var orderID *uuid.UUID
+	var startsAt, endsAt, expiresAt, createdAt time.Time
+	var quantity int64
+	var status string
+
+	err := r.pool.QueryRow(ctx,
+		`SELECT template_id, customer_id, slot_starts_at, slot_ends_at, quantity, status, order_id, expires_at, created_at
+		 FROM slot_reservations WHERE id = $1`,
+		id,
+	).Scan(&templateID, &customerID, &startsAt, &endsAt, &quantity, &status, &orderID, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fulfilment.ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("querying reservation by id: %w", err)
+	}
+
+	return fulfilment.ReconstructReservation(id, templateID, customerID, startsAt, endsAt, quantity,
+		fulfilment.ReservationStatus(status), orderID, expiresAt, createdAt), nil
+}
+
+// FindByOrder returns the reservations confirmed for an order.
+func (r *SlotReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*fulfilment.Reservation, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, template_id, customer_id, slot_starts_at, slot_ends_at, quantity, status, order_id, expires_at, created_at
+		 FROM slot_reservations WHERE order_id = $1 ORDER BY created_at, id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying reservations by order: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []*fulfilment.Reservation
+	for rows.Next() {
+		var id, templateID, customerID uuid.UUID
+		var resOrderID *uuid.UUID
+		var startsAt, endsAt, expiresAt, createdAt time.Time
+		var quantity int64
+		var status string
+		if err := rows.Scan(&id, &templateID, &customerID, &startsAt, &endsAt, &quantity, &status, &resOrderID, &expiresAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning reservation: %w", err)
+		}
+		reservations = append(reservations, fulfilment.ReconstructReservation(id, templateID, customerID, startsAt, endsAt,
+			quantity, fulfilment.ReservationStatus(status), resOrderID, expiresAt, createdAt))
+	}
+	return reservations, rows.Err()
+}
+
+// Update persists a reservation's status and order link.
+func (r *SlotReservationRepository) Update(ctx context.Context, res *fulfilment.Reservation) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE slot_reservations SET status = $2, order_id = $3 WHERE id = $1",
+		res.ID(), string(res.Status()), res.OrderID(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating reservation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fulfilment.ErrReservationNotFound
+	}
+	return nil
+}
+
+// UsageBySlot sums active reservation quantities per slot.
+func (r *SlotReservationRepository) UsageBySlot(ctx context.Context, templateIDs []uuid.UUID, from, to, now time.Time) (map[fulfilment.SlotKey]int64, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT template_id, slot_starts_at, SUM(quantity)::BIGINT FROM slot_reservations
+		 WHERE template_id = ANY($1) AND slot_starts_at >= $2 AND slot_starts_at < $4 AND `+activeReservationCondition+`
+		 GROUP BY template_id, slot_starts_at`,
+		templateIDs, from, now, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying slot usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[fulfilment.SlotKey]int64)
+	for rows.Next() {
+		var templateID uuid.UUID
+		var startsAt time.Time
+		var used int64
+		if err := rows.Scan(&templateID, &startsAt, &used); err != nil {
+			return nil, fmt.Errorf("scanning slot usage: %w", err)
+		}
+		usage[fulfilment.SlotKey{TemplateID: templateID, StartsAt: startsAt.Unix()}] = used
+	}
+	return usage, rows.Err()
+}
+
+// FindConfirmed returns the confirmed reservations for the templates' slots
+// starting within [from, to), by slot start.
+func (r *SlotReservationRepository) FindConfirmed(ctx context.Context, templateIDs []uuid.UUID, from, to time.Time) ([]*fulfilment.Reservation, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, template_id, customer_id, slot_starts_at, slot_ends_at, quantity, status, order_id, expires_at, created_at
+		 FROM slot_reservations
+		 WHERE template_id = ANY($1) AND slot_starts_at >= $2 AND slot_starts_at < $3 AND status = 'confirmed'
+		 ORDER BY slot_starts_at, created_at, id`,
+		templateIDs, from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying confirmed reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []*fulfilment.Reservation
+	for rows.Next() {
+		var id, templateID, customerID uuid.UUID
+		var orderID *uuid.UUID
+		var startsAt, endsAt, expiresAt, createdAt time.Time
+		var quantity int64
+		var status string
+		if err := rows.Scan(&id, &templateID, &customerID, &startsAt, &endsAt, &quantity, &status, &orderID, &expiresAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning reservation: %w", err)
+		}
+		reservations = append(reservations, fulfilment.ReconstructReservation(id, templateID, customerID, startsAt, endsAt,
+			quantity, fulfilment.ReservationStatus(status), orderID, expiresAt, createdAt))
+	}
+	return reservations, rows.Err()
+}
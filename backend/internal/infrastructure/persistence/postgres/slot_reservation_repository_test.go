@@ -0,0 +1,132 @@
+package postgres_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedCustomer inserts a customer row so reservations satisfy their foreign key.
+func seedCustomer(t *testing.T, repo *pgstore.CustomerRepository, email string) uuid.UUID {
+	t.Helper()
+	e, err := customer.NewEmail(email)
+	require.NoError(t, err)
+	phone, err := customer.NewPhoneNumber("+1234567890")
+	require.NoError(t, err)
+	c, err := customer.NewCustomer(uuid.New(), e, "$2a$10$hash", "Slot Customer", phone)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), c))
+	return c.ID()
+}
+
+func TestIntegrationSlotReservationRepository_Reserve(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	scheduleRepo := pgstore.NewFulfilmentScheduleRepository(pool)
+	repo := pgstore.NewSlotReservationRepository(pool)
+	customerRepo := pgstore.NewCustomerRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	tmpl := newTestSlotTemplate(t, uuid.New(), nil, fulfilment.MethodCollection, 2)
+	require.NoError(t, scheduleRepo.SaveSlotTemplate(ctx, tmpl))
+	slot := tmpl.SlotOn(time.Now().AddDate(0, 0, 7), time.UTC)
+	customerID := seedCustomer(t, customerRepo, "slots@example.com")
+
+	t.Run("saves and retrieves reservation", func(t *testing.T) {
+		res, err := fulfilment.NewReservation(uuid.New(), customerID, slot, 0, 15*time.Minute, time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Reserve(ctx, slot, res))
+
+		found, err := repo.FindByID(ctx, res.ID())
+		require.NoError(t, err)
+		assert.Equal(t, customerID, found.CustomerID())
+		assert.Equal(t, fulfilment.ReservationHeld, found.Status())
+		assert.True(t, found.SlotStartsAt().Equal(slot.StartsAt()))
+	})
+
+	t.Run("expired holds do not count against capacity", func(t *testing.T) {
+		expired, err := fulfilment.NewReservation(uuid.New(), customerID, slot, 0, time.Minute, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, repo.Reserve(ctx, slot, expired))
+
+		usage, err := repo.UsageBySlot(ctx, []uuid.UUID{tmpl.ID()}, slot.StartsAt().Add(-time.Hour), slot.EndsAt(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage[slot.Key()])
+	})
+
+	second, _ := fulfilment.NewReservation(uuid.New(), customerID, slot, 0, 15*time.Minute, time.Now())
+
+	t.Run("full slot returns ErrSlotFull", func(t *testing.T) {
+		require.NoError(t, repo.Reserve(ctx, slot, second))
+
+		third, _ := fulfilment.NewReservation(uuid.New(), customerID, slot, 0, 15*time.Minute, time.Now())
+		err := repo.Reserve(ctx, slot, third)
+
+		assert.ErrorIs(t, err, fulfilment.ErrSlotFull)
+	})
+
+	t.Run("released reservations free capacity", func(t *testing.T) {
+		require.NoError(t, second.Release())
+		require.NoError(t, repo.Update(ctx, second))
+
+		usage, err := repo.UsageBySlot(ctx, []uuid.UUID{tmpl.ID()}, slot.StartsAt(), slot.EndsAt(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage[slot.Key()])
+	})
+}
+
+func TestIntegrationSlotReservationRepository_ConcurrentReserve(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	scheduleRepo := pgstore.NewFulfilmentScheduleRepository(pool)
+	repo := pgstore.NewSlotReservationRepository(pool)
+	customerRepo := pgstore.NewCustomerRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	const capacity = 3
+	tmpl := newTestSlotTemplate(t, uuid.New(), nil, fulfilment.MethodCollection, capacity)
+	require.NoError(t, scheduleRepo.SaveSlotTemplate(ctx, tmpl))
+	slot := tmpl.SlotOn(time.Now().AddDate(0, 0, 7), time.UTC)
+	customerID := seedCustomer(t, customerRepo, "race@example.com")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded, full := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, _ := fulfilment.NewReservation(uuid.New(), customerID, slot, 0, 15*time.Minute, time.Now())
+			err := repo.Reserve(ctx, slot, res)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case assert.ErrorIs(t, err, fulfilment.ErrSlotFull):
+				full++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, capacity, succeeded)
+	assert.Equal(t, 10-capacity, full)
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// StaffClockRepository implements staff.ClockRepository using PostgreSQL.
+type StaffClockRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStaffClockRepository creates a new StaffClockRepository.
+func NewStaffClockRepository(pool *pgxpool.Pool) *StaffClockRepository {
+	return &StaffClockRepository{pool: pool}
+}
+
+const staffClockEntryColumns = "id, admin_id, branch_id, clocked_in_at, clocked_out_at"
+
+// ClockIn inserts a new open entry at a branch in scope.
+func (r *StaffClockRepository) ClockIn(ctx context.Context, e *staff.ClockEntry) error {
+	if err := branch.ScopeFrom(ctx).Check(e.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO staff_clock_entries (`+staffClockEntryColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		e.ID(), e.AdminID(), e.BranchID(), e.ClockedInAt(), e.ClockedOutAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_staff_clock_entries_open" {
+			return staff.ErrAlreadyClockedIn
+		}
+		return fmt.Errorf("inserting clock entry: %w", err)
+	}
+	return nil
+}
+
+// Update stores an entry's breaks and when it was clocked out of. Entries
+// that have been clocked out of are not changed again.
+func (r *StaffClockRepository) Update(ctx context.Context, e *staff.ClockEntry) error {
+	if err := branch.ScopeFrom(ctx).Check(e.BranchID()); err != nil {
+		return err
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		"UPDATE staff_clock_entries SET clocked_out_at = $2 WHERE id = $1 AND clocked_out_at IS NULL",
+		e.ID(), e.ClockedOutAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating clock entry: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return staff.ErrEntryNotFound
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM staff_breaks WHERE entry_id = $1", e.ID()); err != nil {
+		return fmt.Errorf("clearing breaks: %w", err)
+	}
+	for i, b := range e.Breaks() {
+		_, err := tx.Exec(ctx,
+			"INSERT INTO staff_breaks (entry_id, position, started_at, ended_at) VALUES ($1, $2, $3, $4)",
+			e.ID(), i+1, b.StartedAt, b.EndedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting break: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing clock entry: %w", err)
+	}
+	return nil
+}
+
+// FindOpen returns a member of staff's open entry at a branch in scope.
+func (r *StaffClockRepository) FindOpen(ctx context.Context, adminID uuid.UUID) (*staff.ClockEntry, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{adminID})
+	entries, err := r.query(ctx,
+		"SELECT "+staffClockEntryColumns+" FROM staff_clock_entries WHERE admin_id = $1 AND clocked_out_at IS NULL AND "+cond,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, staff.ErrEntryNotFound
+	}
+	return entries[0], nil
+}
+
+// FindEntries returns entries in scope clocked into in the filter's
+// period, by when they were clocked into.
+func (r *StaffClockRepository) FindEntries(ctx context.Context, f staff.Filter) ([]*staff.ClockEntry, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{f.From, f.To, f.BranchID, f.AdminID})
+	return r.query(ctx,
+		"SELECT "+staffClockEntryColumns+" FROM staff_clock_entries WHERE clocked_in_at >= $1 AND clocked_in_at < $2"+
+			" AND ($3::UUID IS NULL OR branch_id = $3) AND ($4::UUID IS NULL OR admin_id = $4) AND "+cond+
+			" ORDER BY clocked_in_at, id",
+		args...)
+}
+
+// query loads entries with their breaks.
+func (r *StaffClockRepository) query(ctx context.Context, query string, args ...any) ([]*staff.ClockEntry, error) {
+	type row struct {
+		id, adminID, branchID uuid.UUID
+		clockedInAt           time.Time
+		clockedOutAt          *time.Time
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying clock entries: %w", err)
+	}
+	var found []row
+	var ids []uuid.UUID
+	for rows.Next() {
+		var e row
+		if err := rows.Scan(&e.id, &e.adminID, &e.branchID, &e.clockedInAt, &e.clockedOutAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning clock entry: %w", err)
+		}
+		found = append(found, e)
+		ids = append(ids, e.id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("querying clock entries: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	breaks := make(map[uuid.UUID][]staff.Break)
+	rows, err = r.pool.Query(ctx,
+		"SELECT entry_id, started_at, ended_at FROM staff_breaks WHERE entry_id = ANY($1::uuid[]) ORDER BY entry_id, position",
+		ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying breaks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entryID uuid.UUID
+		var b staff.Break
+		if err := rows.Scan(&entryID, &b.StartedAt, &b.EndedAt); err != nil {
+			return nil, fmt.Errorf("scanning break: %w", err)
+		}
+		breaks[entryID] = append(breaks[entryID], b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("querying breaks: %w", err)
+	}
+
+	entries := make([]*staff.ClockEntry, 0, len(found))
+	for _, e := range found {
+		entries = append(entries, staff.ReconstructClockEntry(e.id, e.adminID, e.branchID, e.clockedInAt, e.clockedOutAt, breaks[e.id]))
+	}
+	return entries, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// StaffProfileRepository implements staff.ProfileRepository using
+// PostgreSQL.
+type StaffProfileRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStaffProfileRepository creates a new StaffProfileRepository.
+func NewStaffProfileRepository(pool *pgxpool.Pool) *StaffProfileRepository {
+	return &StaffProfileRepository{pool: pool}
+}
+
+const staffProfileColumns = "p.admin_id, a.full_name, p.branch_id, p.position, p.hourly_rate_cents, p.contracted_minutes, " +
+	"p.active, p.created_at, p.updated_at"
+
+const staffProfileFrom = " FROM staff_profiles p JOIN admins a ON a.id = p.admin_id"
+
+// Save inserts or updates a profile with a home branch in scope. A profile
+// based at a branch outside the scope is not moved into it.
+func (r *StaffProfileRepository) Save(ctx context.Context, p *staff.Profile) error {
+	if err := branch.ScopeFrom(ctx).Check(p.BranchID()); err != nil {
+		return err
+	}
+	cond, args := branchScope(ctx, "staff_profiles.branch_id", []any{
+		p.AdminID(), p.BranchID(), string(p.Position()), p.HourlyRateCents(), p.ContractedMinutes(), p.Active(),
+		p.CreatedAt(), p.UpdatedAt(),
+	})
+	result, err := r.pool.Exec(ctx,
+		`INSERT INTO staff_profiles (admin_id, branch_id, position, hourly_rate_cents, contracted_minutes, active, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (admin_id) DO UPDATE SET
+		     branch_id = EXCLUDED.branch_id, position = EXCLUDED.position,
+		     hourly_rate_cents = EXCLUDED.hourly_rate_cents, contracted_minutes = EXCLUDED.contracted_minutes,
+		     active = EXCLUDED.active, updated_at = EXCLUDED.updated_at
+		 WHERE `+cond,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("saving staff profile: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return branch.ErrOutOfScope
+	}
+	return nil
+}
+
+// FindByAdminID finds the profile of an admin based at a branch in scope.
+func (r *StaffProfileRepository) FindByAdminID(ctx context.Context, adminID uuid.UUID) (*staff.Profile, error) {
+	cond, args := branchScope(ctx, "p.branch_id", []any{adminID})
+	p, err := scanStaffProfile(r.pool.QueryRow(ctx,
+		"SELECT "+staffProfileColumns+staffProfileFrom+" WHERE p.admin_id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, staff.ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("querying staff profile: %w", err)
+	}
+	return p, nil
+}
+
+// FindAll returns the profiles of staff based at a branch, or at every
+// branch in scope, by name.
+func (r *StaffProfileRepository) FindAll(ctx context.Context, branchID *uuid.UUID) ([]*staff.Profile, error) {
+	cond, args := branchScope(ctx, "p.branch_id", []any{branchID})
+	return r.query(ctx,
+		"SELECT "+staffProfileColumns+staffProfileFrom+" WHERE ($1::UUID IS NULL OR p.branch_id = $1) AND "+cond+
+			" ORDER BY a.full_name, p.admin_id",
+		args...)
+}
+
+// FindByAdminIDs returns the profiles of the given staff, wherever they are
+// based.
+func (r *StaffProfileRepository) FindByAdminIDs(ctx context.Context, adminIDs []uuid.UUID) ([]*staff.Profile, error) {
+	return r.query(ctx,
+		"SELECT "+staffProfileColumns+staffProfileFrom+" WHERE p.admin_id = ANY($1::uuid[]) ORDER BY a.full_name, p.admin_id",
+		adminIDs)
+}
+
+func (r *StaffProfileRepository) query(ctx context.Context, query string, args ...any) ([]*staff.Profile, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying staff profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*staff.Profile
+	for rows.Next() {
+		p, err := scanStaffProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning staff profile: %w", err)
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, rows.Err()
+}
+
+func scanStaffProfile(row pgx.Row) (*staff.Profile, error) {
+	var adminID, branchID uuid.UUID
+	var fullName, position string
+	var hourlyRateCents int64
+	var contractedMinutes int
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&adminID, &fullName, &branchID, &position, &hourlyRateCents, &contractedMinutes,
+		&active, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return staff.ReconstructProfile(adminID, fullName, branchID, staff.Position(position), hourlyRateCents,
+		contractedMinutes, active, createdAt, updatedAt), nil
+}
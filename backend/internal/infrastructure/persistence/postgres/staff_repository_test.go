@@ -0,0 +1,135 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedStaffAdmin(t *testing.T, pool *pgxpool.Pool, name string) *admin.Admin {
+	t.Helper()
+	id := uuid.New()
+	_, err := pool.Exec(context.Background(),
+		"INSERT INTO admins (id, email, password_hash, full_name) VALUES ($1, $2, $3, $4)",
+		id, id.String()+"@butchery.com", "$2a$10$hash", name,
+	)
+	require.NoError(t, err)
+	a, err := pgstore.NewAdminRepository(pool).FindByID(context.Background(), id)
+	require.NoError(t, err)
+	return a
+}
+
+func TestIntegrationStaffRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	branches := pgstore.NewBranchRepository(pool)
+	profiles := pgstore.NewStaffProfileRepository(pool)
+	shifts := pgstore.NewStaffShiftRepository(pool)
+	clock := pgstore.NewStaffClockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	east, err := branch.NewBranch(uuid.New(), "East", []string{"1 High Street"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, branches.Save(ctx, east))
+	west, err := branch.NewBranch(uuid.New(), "West", []string{"2 Park Lane"}, "", "", now)
+	require.NoError(t, err)
+	require.NoError(t, branches.Save(ctx, west))
+
+	yusuf, err := staff.NewProfile(seedStaffAdmin(t, pool, "Yusuf Ali"), east.ID(), staff.PositionButcher, 1250, 2400, now)
+	require.NoError(t, err)
+	require.NoError(t, profiles.Save(ctx, yusuf))
+	amira, err := staff.NewProfile(seedStaffAdmin(t, pool, "Amira Khan"), west.ID(), staff.PositionCashier, 1100, 0, now)
+	require.NoError(t, err)
+	require.NoError(t, profiles.Save(ctx, amira))
+
+	got, err := profiles.FindByAdminID(ctx, yusuf.AdminID())
+	require.NoError(t, err)
+	assert.Equal(t, "Yusuf Ali", got.FullName())
+	assert.Equal(t, 2400, got.ContractedMinutes())
+
+	all, err := profiles.FindAll(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, amira.AdminID(), all[0].AdminID(), "profiles are listed by name")
+
+	// Profiles of staff based elsewhere are hidden, but can still be loaded
+	// to roster them across branches.
+	eastOnly := branch.WithScope(ctx, branch.Only(east.ID()))
+	_, err = profiles.FindByAdminID(eastOnly, amira.AdminID())
+	assert.ErrorIs(t, err, staff.ErrProfileNotFound)
+	both, err := profiles.FindByAdminIDs(eastOnly, []uuid.UUID{yusuf.AdminID(), amira.AdminID()})
+	require.NoError(t, err)
+	assert.Len(t, both, 2)
+	require.NoError(t, amira.Update(east.ID(), staff.PositionCashier, 1100, 0, true, now))
+	assert.ErrorIs(t, profiles.Save(eastOnly, amira), branch.ErrOutOfScope, "staff based elsewhere cannot be moved in")
+
+	day := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+	morning, err := staff.NewShift(uuid.New(), yusuf, east.ID(), staff.PositionButcher, day.Add(8*time.Hour), day.Add(16*time.Hour),
+		30*time.Minute, "Counter", amira.AdminID(), now)
+	require.NoError(t, err)
+	require.NoError(t, shifts.Save(ctx, morning))
+	evening, err := staff.NewShift(uuid.New(), yusuf, west.ID(), staff.PositionCashier, day.Add(15*time.Hour), day.Add(19*time.Hour),
+		0, "", amira.AdminID(), now)
+	require.NoError(t, err)
+	require.NoError(t, shifts.Save(ctx, evening))
+
+	s, err := shifts.FindByID(ctx, morning.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, s.Break())
+	assert.Equal(t, "Counter", s.Note())
+
+	atEast, err := shifts.FindShifts(eastOnly, staff.Filter{From: day, To: day.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	require.Len(t, atEast, 1)
+	anywhere, err := shifts.FindStaffShifts(eastOnly, []uuid.UUID{yusuf.AdminID()}, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Len(t, anywhere, 2, "clashes are found across branches")
+
+	assert.ErrorIs(t, shifts.Delete(eastOnly, evening.ID()), staff.ErrShiftNotFound)
+	require.NoError(t, shifts.Delete(ctx, evening.ID()))
+
+	// One open entry at a time, with its breaks kept in order.
+	in := now.Add(-5 * time.Hour)
+	e, err := staff.ClockIn(uuid.New(), yusuf, uuid.Nil, in)
+	require.NoError(t, err)
+	require.NoError(t, clock.ClockIn(ctx, e))
+	again, err := staff.ClockIn(uuid.New(), yusuf, uuid.Nil, now)
+	require.NoError(t, err)
+	assert.ErrorIs(t, clock.ClockIn(ctx, again), staff.ErrAlreadyClockedIn)
+
+	require.NoError(t, e.StartBreak(in.Add(2*time.Hour)))
+	require.NoError(t, e.EndBreak(in.Add(2*time.Hour+20*time.Minute)))
+	require.NoError(t, e.StartBreak(in.Add(4*time.Hour)))
+	require.NoError(t, clock.Update(ctx, e))
+	open, err := clock.FindOpen(ctx, yusuf.AdminID())
+	require.NoError(t, err)
+	require.Len(t, open.Breaks(), 2)
+	assert.True(t, open.OnBreak())
+
+	require.NoError(t, open.ClockOut(now))
+	require.NoError(t, clock.Update(ctx, open))
+	assert.ErrorIs(t, clock.Update(ctx, open), staff.ErrEntryNotFound, "closed entries are not changed")
+	_, err = clock.FindOpen(ctx, yusuf.AdminID())
+	assert.ErrorIs(t, err, staff.ErrEntryNotFound)
+
+	yusufID := yusuf.AdminID()
+	entries, err := clock.FindEntries(ctx, staff.Filter{AdminID: &yusufID, From: in.Add(-time.Hour), To: now})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.False(t, entries[0].Open())
+	assert.Equal(t, 20+60, int(entries[0].BreakTime(now)/time.Minute))
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+)
+
+// StaffShiftRepository implements staff.ShiftRepository using PostgreSQL.
+type StaffShiftRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStaffShiftRepository creates a new StaffShiftRepository.
+func NewStaffShiftRepository(pool *pgxpool.Pool) *StaffShiftRepository {
+	return &StaffShiftRepository{pool: pool}
+}
+
+const staffShiftColumns = "id, admin_id, branch_id, position, starts_at, ends_at, break_minutes, note, created_by, " +
+	"created_at, updated_at"
+
+// Save inserts or updates a shift at a branch in scope.
+func (r *StaffShiftRepository) Save(ctx context.Context, s *staff.Shift) error {
+	if err := branch.ScopeFrom(ctx).Check(s.BranchID()); err != nil {
+		return err
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO staff_shifts (`+staffShiftColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		 ON CONFLICT (id) DO UPDATE SET
+		     branch_id = EXCLUDED.branch_id, position = EXCLUDED.position,
+		     starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at,
+		     break_minutes = EXCLUDED.break_minutes, note = EXCLUDED.note, updated_at = EXCLUDED.updated_at`,
+		s.ID(), s.AdminID(), s.BranchID(), string(s.Position()), s.StartsAt(), s.EndsAt(),
+		int(s.Break()/time.Minute), s.Note(), s.CreatedBy(), s.CreatedAt(), s.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving shift: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a shift at a branch in scope.
+func (r *StaffShiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	result, err := r.pool.Exec(ctx, "DELETE FROM staff_shifts WHERE id = $1 AND "+cond, args...)
+	if err != nil {
+		return fmt.Errorf("deleting shift: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return staff.ErrShiftNotFound
+	}
+	return nil
+}
+
+// FindByID finds a shift in scope by ID.
+func (r *StaffShiftRepository) FindByID(ctx context.Context, id uuid.UUID) (*staff.Shift, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	s, err := scanStaffShift(r.pool.QueryRow(ctx,
+		"SELECT "+staffShiftColumns+" FROM staff_shifts WHERE id = $1 AND "+cond, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, staff.ErrShiftNotFound
+		}
+		return nil, fmt.Errorf("querying shift: %w", err)
+	}
+	return s, nil
+}
+
+// FindShifts returns shifts in scope starting in the filter's period, by
+// start.
+func (r *StaffShiftRepository) FindShifts(ctx context.Context, f staff.Filter) ([]*staff.Shift, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{f.From, f.To, f.BranchID, f.AdminID})
+	return r.query(ctx,
+		"SELECT "+staffShiftColumns+" FROM staff_shifts WHERE starts_at >= $1 AND starts_at < $2"+
+			" AND ($3::UUID IS NULL OR branch_id = $3) AND ($4::UUID IS NULL OR admin_id = $4) AND "+cond+
+			" ORDER BY starts_at, id",
+		args...)
+}
+
+// FindStaffShifts returns the shifts of the given staff overlapping
+// [from, to) at any branch, by start.
+func (r *StaffShiftRepository) FindStaffShifts(ctx context.Context, adminIDs []uuid.UUID, from, to time.Time) ([]*staff.Shift, error) {
+	return r.query(ctx,
+		"SELECT "+staffShiftColumns+" FROM staff_shifts WHERE admin_id = ANY($1::uuid[]) AND starts_at < $3 AND ends_at > $2"+
+			" ORDER BY starts_at, id",
+		adminIDs, from, to)
+}
+
+func (r *StaffShiftRepository) query(ctx context.Context, query string, args ...any) ([]*staff.Shift, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying shifts: %w", err)
+	}
+	defer rows.Close()
+
+	var shifts []*staff.Shift
+	for rows.Next() {
+		s, err := scanStaffShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning shift: %w", err)
+		}
+		shifts = append(shifts, s)
+	}
+	return shifts, rows.Err()
+}
+
+func scanStaffShift(row pgx.Row) (*staff.Shift, error) {
+	var id, adminID, branchID, createdBy uuid.UUID
+	var position, note string
+	var startsAt, endsAt, createdAt, updatedAt time.Time
+	var breakMinutes int
+
+	err := row.Scan(&id, &adminID, &branchID, &position, &startsAt, &endsAt, &breakMinutes, &note, &createdBy,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return staff.ReconstructShift(id, adminID, branchID, staff.Position(position), startsAt, endsAt,
+		time.Duration(breakMinutes)*time.Minute, note, createdBy, createdAt, updatedAt), nil
+}
@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// StockReservationRepository implements inventory.ReservationRepository using PostgreSQL.
+type StockReservationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStockReservationRepository creates a new StockReservationRepository.
+func NewStockReservationRepository(pool *pgxpool.Pool) *StockReservationRepository {
+	return &StockReservationRepository{pool: pool}
+}
+
+// Reserve stores the reservation if every line fits in the available stock.
+// Each product's lots at the branch are locked FOR UPDATE, in product order,
+// so concurrent checkouts for the same cut queue behind each other and always
+// see each other's holds when summing reserved stock.
+func (r *StockReservationRepository) Reserve(ctx context.Context, res *inventory.Reservation, now time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, line := range res.Lines() {
+		lots, err := lockLots(ctx, tx, line.ProductID, res.BranchID())
+		if err != nil {
+			return err
+		}
+		var onHand int64
+		for _, lot := range lots {
+			onHand += lot.OnHandGrams()
+		}
+
+		reserved, err := reservedGrams(ctx, tx, line.ProductID, res.BranchID(), now)
+		if err != nil {
+			return err
+		}
+		if onHand-reserved < line.Grams {
+			return inventory.ErrInsufficientStock
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO stock_reservations (id, branch_id, customer_id, status, order_id, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		res.ID(), res.BranchID(), res.CustomerID(), string(res.Status()), res.OrderID(), res.ExpiresAt(), res.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting stock reservation: %w", err)
+	}
+
+	for _, line := range res.Lines() {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO stock_reservation_lines (reservation_id, product_id, grams) VALUES ($1, $2, $3)",
+			res.ID(), line.ProductID, line.Grams,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting stock reservation line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing stock reservation: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a stock reservation by ID.
+func (r *StockReservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.Reservation, error) {
+	var branchID, customerID uuid.UUID
+	var orderID *uuid.UUID
+	var status string
+	var expiresAt, createdAt time.Time
+
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	err := r.pool.QueryRow(ctx,
+		`SELECT branch_id, customer_id, status, order_id, expires_at, created_at
+		 FROM stock_reservations WHERE id = $1 AND `+cond,
+		args...,
+	).Scan(&branchID, &customerID, &status, &orderID, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, inventory.ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("querying stock reservation by id: %w", err)
+	}
+
+	lines, err := r.findLines(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.ReconstructReservation(id, branchID, customerID, lines,
+		inventory.ReservationStatus(status), orderID, expiresAt, createdAt), nil
+}
+
+// FindByOrder returns the stock reservations confirmed for an order.
+func (r *StockReservationRepository) FindByOrder(ctx context.Context, orderID uuid.UUID) ([]*inventory.Reservation, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, branch_id, customer_id, status, expires_at, created_at
+		 FROM stock_reservations WHERE order_id = $1 ORDER BY created_at`,
+		orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock reservations by order: %w", err)
+	}
+	defer rows.Close()
+
+	type header struct {
+		id, branchID, customerID uuid.UUID
+		status                   string
+		expiresAt, createdAt     time.Time
+	}
+	var headers []header
+	for rows.Next() {
+		var h header
+		if err := rows.Scan(&h.id, &h.branchID, &h.customerID, &h.status, &h.expiresAt, &h.createdAt); err != nil {
+			return nil, fmt.Errorf("scanning stock reservation: %w", err)
+		}
+		headers = append(headers, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	reservations := make([]*inventory.Reservation, 0, len(headers))
+	for _, h := range headers {
+		lines, err := r.findLines(ctx, h.id)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, inventory.ReconstructReservation(h.id, h.branchID, h.customerID, lines,
+			inventory.ReservationStatus(h.status), &orderID, h.expiresAt, h.createdAt))
+	}
+	return reservations, nil
+}
+
+// findLines loads a stock reservation's lines in product order.
+func (r *StockReservationRepository) findLines(ctx context.Context, reservationID uuid.UUID) ([]inventory.ReservationLine, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT product_id, grams FROM stock_reservation_lines WHERE reservation_id = $1 ORDER BY product_id",
+		reservationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock reservation lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []inventory.ReservationLine
+	for rows.Next() {
+		var line inventory.ReservationLine
+		if err := rows.Scan(&line.ProductID, &line.Grams); err != nil {
+			return nil, fmt.Errorf("scanning stock reservation line: %w", err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
+
+// Update persists a stock reservation's status and order link.
+func (r *StockReservationRepository) Update(ctx context.Context, res *inventory.Reservation) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE stock_reservations SET status = $2, order_id = $3 WHERE id = $1",
+		res.ID(), string(res.Status()), res.OrderID(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating stock reservation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return inventory.ErrReservationNotFound
+	}
+	return nil
+}
+
+// Fulfil marks the reservation fulfilled and records a sold movement for each
+// lot the reserved stock is taken from. When the reservation is for an order,
+// each of its lines is linked to the lots it was sold from. The status change
+// only applies to a reservation that is still active, so a reservation cannot
+// be sold twice.
+func (r *StockReservationRepository) Fulfil(ctx context.Context, res *inventory.Reservation, actorID *uuid.UUID, now time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx,
+		`UPDATE stock_reservations sr SET status = $3
+		 WHERE sr.id = $2 AND `+activeStockReservationCondition,
+		now, res.ID(), string(res.Status()),
+	)
+	if err != nil {
+		return fmt.Errorf("updating stock reservation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return inventory.ErrReservationNotActive
+	}
+
+	reference := res.ID()
+	if res.OrderID() != nil {
+		reference = *res.OrderID()
+	}
+
+	for _, line := range res.Lines() {
+		lots, err := lockLots(ctx, tx, line.ProductID, res.BranchID())
+		if err != nil {
+			return err
+		}
+		allocations, err := inventory.AllocateFEFO(lots, line.Grams)
+		if err != nil {
+			return err
+		}
+		for _, a := range allocations {
+			m, err := inventory.NewMovement(uuid.New(), a.Lot, inventory.MovementSold, -a.Grams, "", &reference, actorID, now)
+			if err != nil {
+				return err
+			}
+			if err := applyStockMovement(ctx, tx, m); err != nil {
+				return err
+			}
+		}
+		if res.OrderID() != nil {
+			if err := insertOrderLineLots(ctx, tx, *res.OrderID(), line.ProductID, allocations); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing stock reservation: %w", err)
+	}
+	return nil
+}
+
+// insertOrderLineLots links an order's lines for a product to the lots
+// their stock was sold from, filling the lines in order. The last line takes
+// whatever is left over.
+func insertOrderLineLots(ctx context.Context, tx pgx.Tx, orderID, productID uuid.UUID, allocations []inventory.Allocation) error {
+	rows, err := tx.Query(ctx,
+		"SELECT id, grams FROM order_lines WHERE order_id = $1 AND product_id = $2 ORDER BY position",
+		orderID, productID,
+	)
+	if err != nil {
+		return fmt.Errorf("querying order lines: %w", err)
+	}
+	type line struct {
+		id    uuid.UUID
+		grams int64
+	}
+	var lines []line
+	for rows.Next() {
+		var l line
+		if err := rows.Scan(&l.id, &l.grams); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning order line: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	i := 0
+	for _, a := range allocations {
+		for left := a.Grams; left > 0 && len(lines) > 0; {
+			if lines[i].grams <= 0 && i < len(lines)-1 {
+				i++
+				continue
+			}
+			grams := left
+			if i < len(lines)-1 {
+				grams = min(left, lines[i].grams)
+			}
+			_, err := tx.Exec(ctx,
+				`INSERT INTO order_line_lots (order_line_id, lot_id, grams) VALUES ($1, $2, $3)
+				 ON CONFLICT (order_line_id, lot_id) DO UPDATE SET grams = order_line_lots.grams + EXCLUDED.grams`,
+				lines[i].id, a.Lot.ID(), grams,
+			)
+			if err != nil {
+				return fmt.Errorf("inserting order line lot: %w", err)
+			}
+			lines[i].grams -= grams
+			left -= grams
+		}
+	}
+	return nil
+}
+
+// reservedGrams sums the stock of a product that reservations at a branch
+// still hold back. Callers lock the product's lots first so the sum cannot
+// change under them.
+func reservedGrams(ctx context.Context, tx pgx.Tx, productID, branchID uuid.UUID, now time.Time) (int64, error) {
+	var reserved int64
+	err := tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(srl.grams), 0)::BIGINT
+		 FROM stock_reservation_lines srl
+		 JOIN stock_reservations sr ON sr.id = srl.reservation_id
+		 WHERE `+activeStockReservationCondition+` AND srl.product_id = $2 AND sr.branch_id = $3`,
+		now, productID, branchID,
+	).Scan(&reserved)
+	if err != nil {
+		return 0, fmt.Errorf("summing reserved stock: %w", err)
+	}
+	return reserved, nil
+}
+
+// lockLots loads and locks a product's lots at a branch. Lots are locked in
+// ID order so that concurrent transactions cannot deadlock on them.
+func lockLots(ctx context.Context, tx pgx.Tx, productID, branchID uuid.UUID) ([]*inventory.Lot, error) {
+	rows, err := tx.Query(ctx,
+		"SELECT "+inventoryLotColumns+" FROM inventory_lots WHERE product_id = $1 AND branch_id = $2 ORDER BY id FOR UPDATE",
+		productID, branchID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("locking lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []*inventory.Lot
+	for rows.Next() {
+		lot, err := scanInventoryLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	return lots, rows.Err()
+}
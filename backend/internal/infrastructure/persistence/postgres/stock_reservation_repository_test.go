@@ -0,0 +1,176 @@
+package postgres_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStockReservation(t *testing.T, branchID, customerID, productID uuid.UUID, grams int64, now time.Time) *inventory.Reservation {
+	t.Helper()
+	r, err := inventory.NewReservation(uuid.New(), branchID, customerID,
+		[]inventory.ReservationLine{{ProductID: productID, Grams: grams}}, 30*time.Minute, now)
+	require.NoError(t, err)
+	return r
+}
+
+func TestIntegrationStockReservationRepository_Reserve(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	repo := pgstore.NewStockReservationRepository(pool)
+	customerRepo := pgstore.NewCustomerRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	productID, branchID := uuid.New(), uuid.New()
+	seedLot(t, stockRepo, productID, branchID, 5000, nil)
+	customerID := seedCustomer(t, customerRepo, "stock@example.com")
+
+	first := newStockReservation(t, branchID, customerID, productID, 3000, time.Now())
+
+	t.Run("saves and retrieves reservation", func(t *testing.T) {
+		require.NoError(t, repo.Reserve(ctx, first, time.Now()))
+
+		found, err := repo.FindByID(ctx, first.ID())
+		require.NoError(t, err)
+		assert.Equal(t, inventory.ReservationHeld, found.Status())
+		assert.Equal(t, first.Lines(), found.Lines())
+	})
+
+	t.Run("reservations at other branches are out of scope", func(t *testing.T) {
+		_, err := repo.FindByID(branch.WithScope(ctx, branch.Only(uuid.New())), first.ID())
+		assert.ErrorIs(t, err, inventory.ErrReservationNotFound)
+
+		_, err = repo.FindByID(branch.WithScope(ctx, branch.Only(branchID)), first.ID())
+		assert.NoError(t, err)
+	})
+
+	t.Run("reserved stock is not available to others", func(t *testing.T) {
+		second := newStockReservation(t, branchID, customerID, productID, 2500, time.Now())
+
+		err := repo.Reserve(ctx, second, time.Now())
+
+		assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+	})
+
+	t.Run("expired holds do not count", func(t *testing.T) {
+		levels, err := stockRepo.StockLevels(ctx, &branchID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, levels, 1)
+		assert.Equal(t, int64(0), levels[0].ReservedGrams)
+	})
+
+	t.Run("released reservations free stock", func(t *testing.T) {
+		require.NoError(t, first.Release())
+		require.NoError(t, repo.Update(ctx, first))
+
+		second := newStockReservation(t, branchID, customerID, productID, 5000, time.Now())
+		assert.NoError(t, repo.Reserve(ctx, second, time.Now()))
+	})
+}
+
+func TestIntegrationStockReservationRepository_Fulfil(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	repo := pgstore.NewStockReservationRepository(pool)
+	customerRepo := pgstore.NewCustomerRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	productID, branchID := uuid.New(), uuid.New()
+	soon := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 0, 5)
+	laterLot := seedLot(t, stockRepo, productID, branchID, 4000, &later)
+	soonLot := seedLot(t, stockRepo, productID, branchID, 1000, &soon)
+	customerID := seedCustomer(t, customerRepo, "fulfil@example.com")
+
+	now := time.Now()
+	res := newStockReservation(t, branchID, customerID, productID, 2500, now)
+	require.NoError(t, repo.Reserve(ctx, res, now))
+	require.NoError(t, res.Fulfil(now))
+
+	require.NoError(t, repo.Fulfil(ctx, res, nil, now))
+
+	found, err := stockRepo.FindLotByID(ctx, soonLot.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), found.OnHandGrams())
+	found, err = stockRepo.FindLotByID(ctx, laterLot.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), found.OnHandGrams())
+
+	movements, err := stockRepo.FindMovements(ctx, inventory.MovementFilter{ProductID: &productID})
+	require.NoError(t, err)
+	var sold int64
+	for _, m := range movements {
+		if m.Type() == inventory.MovementSold {
+			sold += m.DeltaGrams()
+			require.NotNil(t, m.ReferenceID())
+			assert.Equal(t, res.ID(), *m.ReferenceID())
+		}
+	}
+	assert.Equal(t, int64(-2500), sold)
+
+	t.Run("fulfilling twice is rejected", func(t *testing.T) {
+		err := repo.Fulfil(ctx, res, nil, now)
+		assert.ErrorIs(t, err, inventory.ErrReservationNotActive)
+	})
+}
+
+func TestIntegrationStockReservationRepository_ConcurrentReserve(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	repo := pgstore.NewStockReservationRepository(pool)
+	customerRepo := pgstore.NewCustomerRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	productID, branchID := uuid.New(), uuid.New()
+	seedLot(t, stockRepo, productID, branchID, 2000, nil)
+	seedLot(t, stockRepo, productID, branchID, 1000, nil)
+	customerID := seedCustomer(t, customerRepo, "stockrace@example.com")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded, short := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, _ := inventory.NewReservation(uuid.New(), branchID, customerID,
+				[]inventory.ReservationLine{{ProductID: productID, Grams: 1000}}, 30*time.Minute, time.Now())
+			err := repo.Reserve(ctx, res, time.Now())
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case assert.ErrorIs(t, err, inventory.ErrInsufficientStock):
+				short++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, succeeded)
+	assert.Equal(t, 7, short)
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// SubscriptionBoxRepository implements subscription.BoxRepository using PostgreSQL.
+type SubscriptionBoxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSubscriptionBoxRepository creates a new SubscriptionBoxRepository.
+func NewSubscriptionBoxRepository(pool *pgxpool.Pool) *SubscriptionBoxRepository {
+	return &SubscriptionBoxRepository{pool: pool}
+}
+
+const subscriptionBoxColumns = "id, name, customizable, items, active, created_at, updated_at"
+
+// subscriptionCutRow is the JSON shape of a cut in subscription_boxes.items
+// and subscription_renewals.cuts.
+type subscriptionCutRow struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	Description     string    `json:"description"`
+	Grams           int64     `json:"grams"`
+	PricePerKgCents int64     `json:"price_per_kg_cents"`
+}
+
+// subscriptionItemRow is the JSON shape of an item in subscription_boxes.items.
+type subscriptionItemRow struct {
+	subscriptionCutRow
+	Substitutes []subscriptionCutRow `json:"substitutes,omitempty"`
+}
+
+func toSubscriptionCutRow(c subscription.Cut) subscriptionCutRow {
+	return subscriptionCutRow{ProductID: c.ProductID, Description: c.Description, Grams: c.Grams, PricePerKgCents: c.PricePerKgCents}
+}
+
+func (row subscriptionCutRow) cut() subscription.Cut {
+	return subscription.Cut{ProductID: row.ProductID, Description: row.Description, Grams: row.Grams, PricePerKgCents: row.PricePerKgCents}
+}
+
+// Save inserts or updates a box.
+func (r *SubscriptionBoxRepository) Save(ctx context.Context, b *subscription.Box) error {
+	rows := make([]subscriptionItemRow, 0, len(b.Items()))
+	for _, it := range b.Items() {
+		row := subscriptionItemRow{subscriptionCutRow: toSubscriptionCutRow(it.Cut)}
+		for _, s := range it.Substitutes {
+			row.Substitutes = append(row.Substitutes, toSubscriptionCutRow(s))
+		}
+		rows = append(rows, row)
+	}
+	items, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("encoding box items: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO subscription_boxes (`+subscriptionBoxColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, customizable = EXCLUDED.customizable, items = EXCLUDED.items,
+		     active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		b.ID(), b.Name(), b.Customizable(), items, b.Active(), b.CreatedAt(), b.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving subscription box: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a box by ID.
+func (r *SubscriptionBoxRepository) FindByID(ctx context.Context, id uuid.UUID) (*subscription.Box, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+subscriptionBoxColumns+" FROM subscription_boxes WHERE id = $1", id)
+
+	b, err := scanSubscriptionBox(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, subscription.ErrBoxNotFound
+		}
+		return nil, fmt.Errorf("querying subscription box by id: %w", err)
+	}
+	return b, nil
+}
+
+// FindAll returns boxes by name.
+func (r *SubscriptionBoxRepository) FindAll(ctx context.Context, activeOnly bool) ([]*subscription.Box, error) {
+	query := "SELECT " + subscriptionBoxColumns + " FROM subscription_boxes"
+	if activeOnly {
+		query += " WHERE active"
+	}
+	query += " ORDER BY name, id"
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying subscription boxes: %w", err)
+	}
+	defer rows.Close()
+
+	var boxes []*subscription.Box
+	for rows.Next() {
+		b, err := scanSubscriptionBox(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning subscription box: %w", err)
+		}
+		boxes = append(boxes, b)
+	}
+	return boxes, rows.Err()
+}
+
+func scanSubscriptionBox(row pgx.Row) (*subscription.Box, error) {
+	var id uuid.UUID
+	var name string
+	var customizable, active bool
+	var itemsJSON []byte
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &customizable, &itemsJSON, &active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	var rows []subscriptionItemRow
+	if err := json.Unmarshal(itemsJSON, &rows); err != nil {
+		return nil, fmt.Errorf("decoding box items: %w", err)
+	}
+	items := make([]subscription.Item, 0, len(rows))
+	for _, row := range rows {
+		it := subscription.Item{Cut: row.cut()}
+		for _, s := range row.Substitutes {
+			it.Substitutes = append(it.Substitutes, s.cut())
+		}
+		items = append(items, it)
+	}
+	return subscription.ReconstructBox(id, name, customizable, items, active, createdAt, updatedAt), nil
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// SubscriptionRenewalRepository implements subscription.RenewalRepository using PostgreSQL.
+type SubscriptionRenewalRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSubscriptionRenewalRepository creates a new SubscriptionRenewalRepository.
+func NewSubscriptionRenewalRepository(pool *pgxpool.Pool) *SubscriptionRenewalRepository {
+	return &SubscriptionRenewalRepository{pool: pool}
+}
+
+const subscriptionRenewalColumns = "id, subscription_id, customer_id, branch_id, delivery_date, slot_starts_at, " +
+	"cutoff_at, order_id, cuts, total_cents, status, slot_reservation_id, stock_reservation_id, payment_id, " +
+	"attempts, next_attempt_at, failure_reason, created_at, updated_at"
+
+// Create inserts a renewal and updates its subscription in one transaction.
+// The unique delivery date per subscription stops two runs generating the
+// same order.
+func (r *SubscriptionRenewalRepository) Create(ctx context.Context, rn *subscription.Renewal, s *subscription.Subscription) error {
+	rows := make([]subscriptionCutRow, 0, len(rn.Cuts()))
+	for _, c := range rn.Cuts() {
+		rows = append(rows, toSubscriptionCutRow(c))
+	}
+	cuts, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("encoding renewal cuts: %w", err)
+	}
+	args, err := updateSubscriptionArgs(s)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO subscription_renewals (`+subscriptionRenewalColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+		rn.ID(), rn.SubscriptionID(), rn.CustomerID(), rn.BranchID(), rn.DeliveryDate(), rn.SlotStartsAt(),
+		rn.CutoffAt(), rn.OrderID(), cuts, rn.TotalCents(), string(rn.Status()), rn.SlotReservationID(),
+		rn.StockReservationID(), rn.PaymentID(), rn.Attempts(), rn.NextAttemptAt(), rn.FailureReason(),
+		rn.CreatedAt(), rn.UpdatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return subscription.ErrDuplicateRenewal
+		}
+		return fmt.Errorf("inserting subscription renewal: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, updateSubscriptionSQL, args...)
+	if err != nil {
+		return fmt.Errorf("updating subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return subscription.ErrConcurrentUpdate
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing subscription renewal: %w", err)
+	}
+	return nil
+}
+
+// Update persists a renewal's holds, payment attempts and status.
+func (r *SubscriptionRenewalRepository) Update(ctx context.Context, rn *subscription.Renewal) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE subscription_renewals
+		 SET status = $2, slot_reservation_id = $3, stock_reservation_id = $4, payment_id = $5, attempts = $6,
+		     next_attempt_at = $7, failure_reason = $8, updated_at = $9
+		 WHERE id = $1`,
+		rn.ID(), string(rn.Status()), rn.SlotReservationID(), rn.StockReservationID(), rn.PaymentID(),
+		rn.Attempts(), rn.NextAttemptAt(), rn.FailureReason(), rn.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("updating subscription renewal: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return subscription.ErrRenewalNotFound
+	}
+	return nil
+}
+
+// FindByID finds a renewal by ID.
+func (r *SubscriptionRenewalRepository) FindByID(ctx context.Context, id uuid.UUID) (*subscription.Renewal, error) {
+	cond, args := branchScope(ctx, "branch_id", []any{id})
+	row := r.pool.QueryRow(ctx, "SELECT "+subscriptionRenewalColumns+" FROM subscription_renewals WHERE id = $1 AND "+cond, args...)
+
+	rn, err := scanSubscriptionRenewal(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, subscription.ErrRenewalNotFound
+		}
+		return nil, fmt.Errorf("querying subscription renewal by id: %w", err)
+	}
+	return rn, nil
+}
+
+// FindAll returns renewals in scope by delivery date.
+func (r *SubscriptionRenewalRepository) FindAll(ctx context.Context, filter subscription.RenewalFilter) ([]*subscription.Renewal, error) {
+	cond, args := branchScope(ctx, "branch_id", nil)
+	query := "SELECT " + subscriptionRenewalColumns + " FROM subscription_renewals WHERE " + cond
+	if filter.SubscriptionID != nil {
+		args = append(args, *filter.SubscriptionID)
+		query += " AND subscription_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		query += " AND customer_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		query += " AND delivery_date >= $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY delivery_date, created_at, id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying subscription renewals: %w", err)
+	}
+	defer rows.Close()
+
+	var renewals []*subscription.Renewal
+	for rows.Next() {
+		rn, err := scanSubscriptionRenewal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning subscription renewal: %w", err)
+		}
+		renewals = append(renewals, rn)
+	}
+	return renewals, rows.Err()
+}
+
+func scanSubscriptionRenewal(row pgx.Row) (*subscription.Renewal, error) {
+	var id, subscriptionID, customerID, branchID, orderID uuid.UUID
+	var deliveryDate, slotStartsAt, cutoffAt, createdAt, updatedAt time.Time
+	var cutsJSON []byte
+	var totalCents int64
+	var status, failureReason string
+	var slotReservationID, stockReservationID, paymentID *uuid.UUID
+	var attempts int
+	var nextAttemptAt *time.Time
+
+	if err := row.Scan(&id, &subscriptionID, &customerID, &branchID, &deliveryDate, &slotStartsAt, &cutoffAt,
+		&orderID, &cutsJSON, &totalCents, &status, &slotReservationID, &stockReservationID, &paymentID,
+		&attempts, &nextAttemptAt, &failureReason, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	var rows []subscriptionCutRow
+	if err := json.Unmarshal(cutsJSON, &rows); err != nil {
+		return nil, fmt.Errorf("decoding renewal cuts: %w", err)
+	}
+	cuts := make([]subscription.Cut, 0, len(rows))
+	for _, row := range rows {
+		cuts = append(cuts, row.cut())
+	}
+	return subscription.ReconstructRenewal(id, subscriptionID, customerID, branchID, deliveryDate, slotStartsAt,
+		cutoffAt, orderID, cuts, totalCents, subscription.RenewalStatus(status), slotReservationID,
+		stockReservationID, paymentID, attempts, nextAttemptAt, failureReason, createdAt, updatedAt), nil
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+)
+
+// SubscriptionRepository implements subscription.Repository using PostgreSQL.
+type SubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepository.
+func NewSubscriptionRepository(pool *pgxpool.Pool) *SubscriptionRepository {
+	return &SubscriptionRepository{pool: pool}
+}
+
+const subscriptionColumns = "id, customer_id, box_id, cadence, template_id, payment_method, status, next_delivery, " +
+	"skips, swaps, failed_renewals, version, created_at, updated_at"
+
+// updateSubscriptionSQL stores a changed subscription if its version has not
+// moved on since it was loaded.
+const updateSubscriptionSQL = `UPDATE subscriptions
+	 SET template_id = $3, payment_method = $4, status = $5, next_delivery = $6, skips = $7, swaps = $8,
+	     failed_renewals = $9, updated_at = $10, version = version + 1
+	 WHERE id = $1 AND version = $2`
+
+// subscriptionSwapRow is the JSON shape of a swap in subscriptions.swaps.
+type subscriptionSwapRow struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	SubstituteID uuid.UUID `json:"substitute_id"`
+}
+
+func encodeSwaps(swaps []subscription.Swap) ([]byte, error) {
+	rows := make([]subscriptionSwapRow, 0, len(swaps))
+	for _, s := range swaps {
+		rows = append(rows, subscriptionSwapRow{ProductID: s.ProductID, SubstituteID: s.SubstituteID})
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("encoding swaps: %w", err)
+	}
+	return b, nil
+}
+
+// updateSubscriptionArgs returns the arguments for updateSubscriptionSQL.
+func updateSubscriptionArgs(s *subscription.Subscription) ([]any, error) {
+	swaps, err := encodeSwaps(s.Swaps())
+	if err != nil {
+		return nil, err
+	}
+	return []any{
+		s.ID(), s.Version(), s.TemplateID(), s.PaymentMethod(), string(s.Status()), s.NextDelivery(),
+		skipDates(s), swaps, s.FailedRenewals(), s.UpdatedAt(),
+	}, nil
+}
+
+// skipDates never returns nil, so skips is stored as an empty array.
+func skipDates(s *subscription.Subscription) []time.Time {
+	if s.Skips() == nil {
+		return []time.Time{}
+	}
+	return s.Skips()
+}
+
+// Create inserts a new subscription.
+func (r *SubscriptionRepository) Create(ctx context.Context, s *subscription.Subscription) error {
+	swaps, err := encodeSwaps(s.Swaps())
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO subscriptions (`+subscriptionColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		s.ID(), s.CustomerID(), s.BoxID(), string(s.Cadence()), s.TemplateID(), s.PaymentMethod(),
+		string(s.Status()), s.NextDelivery(), skipDates(s), swaps, s.FailedRenewals(), s.Version(),
+		s.CreatedAt(), s.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting subscription: %w", err)
+	}
+	return nil
+}
+
+// Update stores a changed subscription, bumping its version.
+func (r *SubscriptionRepository) Update(ctx context.Context, s *subscription.Subscription) error {
+	args, err := updateSubscriptionArgs(s)
+	if err != nil {
+		return err
+	}
+	result, err := r.pool.Exec(ctx, updateSubscriptionSQL, args...)
+	if err != nil {
+		return fmt.Errorf("updating subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return subscription.ErrConcurrentUpdate
+	}
+	return nil
+}
+
+// FindByID finds a subscription by ID.
+func (r *SubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*subscription.Subscription, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+subscriptionColumns+" FROM subscriptions WHERE id = $1", id)
+
+	s, err := scanSubscription(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, subscription.ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("querying subscription by id: %w", err)
+	}
+	return s, nil
+}
+
+// FindAll returns subscriptions, oldest first.
+func (r *SubscriptionRepository) FindAll(ctx context.Context, filter subscription.Filter) ([]*subscription.Subscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM subscriptions WHERE TRUE"
+	var args []any
+	if filter.CustomerID != nil {
+		args = append(args, *filter.CustomerID)
+		query += " AND customer_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY created_at, id"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*subscription.Subscription
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+func scanSubscription(row pgx.Row) (*subscription.Subscription, error) {
+	var id, customerID, boxID, templateID uuid.UUID
+	var cadence, paymentMethod, status string
+	var nextDelivery, createdAt, updatedAt time.Time
+	var skips []time.Time
+	var swapsJSON []byte
+	var failedRenewals, version int
+
+	if err := row.Scan(&id, &customerID, &boxID, &cadence, &templateID, &paymentMethod, &status, &nextDelivery,
+		&skips, &swapsJSON, &failedRenewals, &version, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	var rows []subscriptionSwapRow
+	if err := json.Unmarshal(swapsJSON, &rows); err != nil {
+		return nil, fmt.Errorf("decoding swaps: %w", err)
+	}
+	var swaps []subscription.Swap
+	for _, row := range rows {
+		swaps = append(swaps, subscription.Swap{ProductID: row.ProductID, SubstituteID: row.SubstituteID})
+	}
+	if len(skips) == 0 {
+		skips = nil
+	}
+	return subscription.ReconstructSubscription(id, customerID, boxID, subscription.Cadence(cadence), templateID,
+		paymentMethod, subscription.Status(status), nextDelivery, skips, swaps, failedRenewals, version,
+		createdAt, updatedAt), nil
+}
@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationSubscriptionRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	boxes := pgstore.NewSubscriptionBoxRepository(pool)
+	subs := pgstore.NewSubscriptionRepository(pool)
+	renewals := pgstore.NewSubscriptionRenewalRepository(pool)
+	scheduleRepo := pgstore.NewFulfilmentScheduleRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+	customerID := seedCustomer(t, pgstore.NewCustomerRepository(pool), "subscriptions@example.com")
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	tmpl := newTestSlotTemplate(t, uuid.New(), nil, fulfilment.MethodCollection, 10)
+	require.NoError(t, scheduleRepo.SaveSlotTemplate(ctx, tmpl))
+	first := now.AddDate(0, 0, 7)
+	for first.Weekday() != tmpl.Weekday() {
+		first = first.AddDate(0, 0, 1)
+	}
+
+	shoulder := subscription.Cut{ProductID: uuid.New(), Description: "Lamb shoulder", Grams: 1000, PricePerKgCents: 1800}
+	leg := subscription.Cut{ProductID: uuid.New(), Description: "Lamb leg", Grams: 1000, PricePerKgCents: 2000}
+	box, err := subscription.NewBox(uuid.New(), "Family box", true,
+		[]subscription.Item{{Cut: shoulder, Substitutes: []subscription.Cut{leg}}}, now)
+	require.NoError(t, err)
+	require.NoError(t, boxes.Save(ctx, box))
+
+	t.Run("boxes round-trip their items", func(t *testing.T) {
+		found, err := boxes.FindByID(ctx, box.ID())
+		require.NoError(t, err)
+		assert.Equal(t, box.Items(), found.Items())
+		assert.True(t, found.Customizable())
+
+		_, err = boxes.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, subscription.ErrBoxNotFound)
+	})
+
+	s, err := subscription.NewSubscription(uuid.New(), customerID, box, subscription.CadenceWeekly, tmpl.ID(),
+		tmpl.Weekday(), first, "pm_card_visa", now)
+	require.NoError(t, err)
+	require.NoError(t, subs.Create(ctx, s))
+
+	t.Run("updates are versioned", func(t *testing.T) {
+		require.NoError(t, s.Skip(first.AddDate(0, 0, 14), now))
+		require.NoError(t, s.Swap(box, shoulder.ProductID, leg.ProductID, now))
+		require.NoError(t, subs.Update(ctx, s))
+		assert.ErrorIs(t, subs.Update(ctx, s), subscription.ErrConcurrentUpdate)
+
+		found, err := subs.FindByID(ctx, s.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.Version())
+		require.Len(t, found.Skips(), 1)
+		assert.Equal(t, subscription.Day(first.AddDate(0, 0, 14)), found.Skips()[0].UTC())
+		assert.Equal(t, []subscription.Swap{{ProductID: shoulder.ProductID, SubstituteID: leg.ProductID}}, found.Swaps())
+		s = found
+	})
+
+	t.Run("a delivery has one renewal", func(t *testing.T) {
+		slot := tmpl.SlotOn(s.NextDelivery(), time.UTC)
+		r := subscription.NewRenewal(uuid.New(), s, tmpl.BranchID(), slot.StartsAt(), slot.StartsAt().Add(-2*time.Hour),
+			uuid.New(), box.Cuts(s.Swaps()), 2000, now)
+		s.Advance(now)
+		require.NoError(t, renewals.Create(ctx, r, s))
+
+		stale, err := subs.FindByID(ctx, s.ID())
+		require.NoError(t, err)
+		assert.Equal(t, subscription.Day(first.AddDate(0, 0, 7)), stale.NextDelivery().UTC())
+
+		again := subscription.NewRenewal(uuid.New(), s, tmpl.BranchID(), slot.StartsAt(), slot.StartsAt().Add(-2*time.Hour),
+			uuid.New(), r.Cuts(), 2000, now)
+		assert.ErrorIs(t, renewals.Create(ctx, again, stale), subscription.ErrDuplicateRenewal)
+
+		p, err := payment.NewPayment(uuid.New(), r.OrderID(), customerID, "gbp", r.TotalCents(), 1500, "fake",
+			"subscription:"+r.ID().String()+":1", now)
+		require.NoError(t, err)
+		require.NoError(t, pgstore.NewPaymentRepository(pool).Create(ctx, p))
+		retryAt, err := r.Declined(p.ID(), "card declined", []time.Duration{time.Hour}, now)
+		require.NoError(t, err)
+		require.NotNil(t, retryAt)
+		require.NoError(t, renewals.Update(ctx, r))
+
+		awaiting := subscription.RenewalAwaitingPayment
+		found, err := renewals.FindAll(ctx, subscription.RenewalFilter{CustomerID: &customerID, Status: &awaiting})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, []subscription.Cut{leg}, found[0].Cuts())
+		assert.Equal(t, 1, found[0].Attempts())
+		assert.Equal(t, "card declined", found[0].FailureReason())
+
+		elsewhere := branch.WithScope(ctx, branch.Only(uuid.New()))
+		_, err = renewals.FindByID(elsewhere, r.ID())
+		assert.ErrorIs(t, err, subscription.ErrRenewalNotFound)
+		found, err = renewals.FindAll(elsewhere, subscription.RenewalFilter{CustomerID: &customerID})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+		found, err = renewals.FindAll(branch.WithScope(ctx, branch.Only(tmpl.BranchID())), subscription.RenewalFilter{CustomerID: &customerID})
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+)
+
+// SupplierRepository implements procurement.SupplierRepository using PostgreSQL.
+type SupplierRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSupplierRepository creates a new SupplierRepository.
+func NewSupplierRepository(pool *pgxpool.Pool) *SupplierRepository {
+	return &SupplierRepository{pool: pool}
+}
+
+const supplierColumns = "id, name, type, contacts, certificates, payment_terms_days, active, created_at, updated_at"
+
+// supplierContactRow is the JSON shape of a contact in suppliers.contacts.
+type supplierContactRow struct {
+	Name  string `json:"name"`
+	Role  string `json:"role,omitempty"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// supplierCertificateRow is the JSON shape of a certificate in
+// suppliers.certificates. ExpiresOn is a YYYY-MM-DD date.
+type supplierCertificateRow struct {
+	Type      string `json:"type"`
+	Number    string `json:"number"`
+	ExpiresOn string `json:"expires_on,omitempty"`
+}
+
+// Save inserts or updates a supplier.
+func (r *SupplierRepository) Save(ctx context.Context, s *procurement.Supplier) error {
+	contactRows := make([]supplierContactRow, 0, len(s.Contacts()))
+	for _, c := range s.Contacts() {
+		contactRows = append(contactRows, supplierContactRow{Name: c.Name, Role: c.Role, Email: c.Email, Phone: c.Phone})
+	}
+	contacts, err := json.Marshal(contactRows)
+	if err != nil {
+		return fmt.Errorf("encoding contacts: %w", err)
+	}
+
+	certificateRows := make([]supplierCertificateRow, 0, len(s.Certificates()))
+	for _, c := range s.Certificates() {
+		row := supplierCertificateRow{Type: c.Type, Number: c.Number}
+		if c.ExpiresOn != nil {
+			row.ExpiresOn = c.ExpiresOn.Format(time.DateOnly)
+		}
+		certificateRows = append(certificateRows, row)
+	}
+	certificates, err := json.Marshal(certificateRows)
+	if err != nil {
+		return fmt.Errorf("encoding certificates: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO suppliers (`+supplierColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (id) DO UPDATE SET
+		     name = EXCLUDED.name, type = EXCLUDED.type, contacts = EXCLUDED.contacts,
+		     certificates = EXCLUDED.certificates, payment_terms_days = EXCLUDED.payment_terms_days,
+		     active = EXCLUDED.active, updated_at = EXCLUDED.updated_at`,
+		s.ID(), s.Name(), string(s.Type()), contacts, certificates, s.PaymentTermsDays(),
+		s.IsActive(), s.CreatedAt(), s.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving supplier: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a supplier by ID.
+func (r *SupplierRepository) FindByID(ctx context.Context, id uuid.UUID) (*procurement.Supplier, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+supplierColumns+" FROM suppliers WHERE id = $1", id)
+
+	s, err := scanSupplier(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, procurement.ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("querying supplier by id: %w", err)
+	}
+	return s, nil
+}
+
+// FindAll returns suppliers ordered by name.
+func (r *SupplierRepository) FindAll(ctx context.Context, activeOnly bool) ([]*procurement.Supplier, error) {
+	query := "SELECT " + supplierColumns + " FROM suppliers"
+	if activeOnly {
+		query += " WHERE active"
+	}
+	query += " ORDER BY name, id"
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []*procurement.Supplier
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning supplier: %w", err)
+		}
+		suppliers = append(suppliers, s)
+	}
+	return suppliers, rows.Err()
+}
+
+func scanSupplier(row pgx.Row) (*procurement.Supplier, error) {
+	var id uuid.UUID
+	var name, supplierType string
+	var rawContacts, rawCertificates []byte
+	var paymentTermsDays int
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&id, &name, &supplierType, &rawContacts, &rawCertificates, &paymentTermsDays,
+		&active, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var contactRows []supplierContactRow
+	if err := json.Unmarshal(rawContacts, &contactRows); err != nil {
+		return nil, fmt.Errorf("decoding contacts: %w", err)
+	}
+	contacts := make([]procurement.Contact, 0, len(contactRows))
+	for _, c := range contactRows {
+		contacts = append(contacts, procurement.Contact{Name: c.Name, Role: c.Role, Email: c.Email, Phone: c.Phone})
+	}
+
+	var certificateRows []supplierCertificateRow
+	if err := json.Unmarshal(rawCertificates, &certificateRows); err != nil {
+		return nil, fmt.Errorf("decoding certificates: %w", err)
+	}
+	certificates := make([]procurement.Certificate, 0, len(certificateRows))
+	for _, c := range certificateRows {
+		cert := procurement.Certificate{Type: c.Type, Number: c.Number}
+		if c.ExpiresOn != "" {
+			d, err := time.Parse(time.DateOnly, c.ExpiresOn)
+			if err != nil {
+				return nil, fmt.Errorf("decoding certificate expiry: %w", err)
+			}
+			cert.ExpiresOn = &d
+		}
+		certificates = append(certificates, cert)
+	}
+
+	return procurement.ReconstructSupplier(id, name, procurement.SupplierType(supplierType), contacts, certificates,
+		paymentTermsDays, active, createdAt, updatedAt), nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+)
+
+// TaxRateRepository implements tax.RateRepository using PostgreSQL.
+type TaxRateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTaxRateRepository creates a new TaxRateRepository.
+func NewTaxRateRepository(pool *pgxpool.Pool) *TaxRateRepository {
+	return &TaxRateRepository{pool: pool}
+}
+
+// Save inserts a rate.
+func (r *TaxRateRepository) Save(ctx context.Context, rate *tax.Rate) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO tax_rates (id, category, rate_bp, effective_from, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		rate.ID(), string(rate.Category()), rate.RateBP(), rate.EffectiveFrom(), rate.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_tax_rates_category_date" {
+			return tax.ErrDuplicateRate
+		}
+		return fmt.Errorf("inserting tax rate: %w", err)
+	}
+	return nil
+}
+
+// FindAll returns every rate, ordered by category and date.
+func (r *TaxRateRepository) FindAll(ctx context.Context) (tax.Table, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT id, category, rate_bp, effective_from, created_at FROM tax_rates ORDER BY category, effective_from")
+	if err != nil {
+		return nil, fmt.Errorf("querying tax rates: %w", err)
+	}
+	defer rows.Close()
+
+	var table tax.Table
+	for rows.Next() {
+		var id uuid.UUID
+		var category string
+		var rateBP int
+		var effectiveFrom, createdAt time.Time
+		if err := rows.Scan(&id, &category, &rateBP, &effectiveFrom, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning tax rate: %w", err)
+		}
+		table = append(table, tax.ReconstructRate(id, tax.Category(category), rateBP, effectiveFrom, createdAt))
+	}
+	return table, rows.Err()
+}
@@ -0,0 +1,66 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationTaxRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	rates := pgstore.NewTaxRateRepository(pool)
+	products := pgstore.NewProductTaxCategoryRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	newRate := func(category tax.Category, rateBP int, from string) *tax.Rate {
+		on, err := time.Parse(time.DateOnly, from)
+		require.NoError(t, err)
+		r, err := tax.NewRate(uuid.New(), category, rateBP, on, time.Now().UTC().Truncate(time.Microsecond))
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("rates round-trip in category and date order", func(t *testing.T) {
+		require.NoError(t, rates.Save(ctx, newRate(tax.CategoryPreparedFood, 500, "2027-01-01")))
+		require.NoError(t, rates.Save(ctx, newRate(tax.CategoryPreparedFood, 2000, "2011-01-04")))
+		require.NoError(t, rates.Save(ctx, newRate(tax.CategoryDelivery, 2000, "2011-01-04")))
+
+		table, err := rates.FindAll(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, table, 3)
+		assert.Equal(t, tax.CategoryDelivery, table[0].Category())
+		assert.Equal(t, "2027-01-01", table[2].EffectiveFrom().Format(time.DateOnly))
+		rate, err := table.RateFor(tax.CategoryPreparedFood, time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 2000, rate)
+	})
+
+	t.Run("second rate on the same day is refused", func(t *testing.T) {
+		err := rates.Save(ctx, newRate(tax.CategoryDelivery, 0, "2011-01-04"))
+
+		assert.ErrorIs(t, err, tax.ErrDuplicateRate)
+	})
+
+	t.Run("product categories are replaced", func(t *testing.T) {
+		lamb, mince := uuid.New(), uuid.New()
+		require.NoError(t, products.AssignCategory(ctx, lamb, tax.CategoryPreparedFood))
+		require.NoError(t, products.AssignCategory(ctx, lamb, tax.CategoryFreshMeat))
+
+		found, err := products.FindCategories(ctx, []uuid.UUID{lamb, mince})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]tax.Category{lamb: tax.CategoryFreshMeat}, found)
+	})
+}
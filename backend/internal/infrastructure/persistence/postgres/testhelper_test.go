@@ -38,6 +38,35 @@ func setupTestDB(t *testing.T) *pgxpool.Pool {
 			filepath.Join(migrationsDir, "V1__create_admins_table.sql"),
 			filepath.Join(migrationsDir, "V2__create_customers_table.sql"),
 			filepath.Join(migrationsDir, "V3__create_refresh_tokens_table.sql"),
+			filepath.Join(migrationsDir, "V5__create_fulfilment_tables.sql"),
+			filepath.Join(migrationsDir, "V6__create_addresses_and_delivery_zones.sql"),
+			filepath.Join(migrationsDir, "V7__create_inventory_tables.sql"),
+			filepath.Join(migrationsDir, "V8__create_carcass_tables.sql"),
+			filepath.Join(migrationsDir, "V9__create_traceability_tables.sql"),
+			filepath.Join(migrationsDir, "V10__create_procurement_tables.sql"),
+			filepath.Join(migrationsDir, "V11__create_payment_tables.sql"),
+			filepath.Join(migrationsDir, "V12__create_order_refund_and_audit_tables.sql"),
+			filepath.Join(migrationsDir, "V13__create_invoice_tables.sql"),
+			filepath.Join(migrationsDir, "V14__create_tax_tables.sql"),
+			filepath.Join(migrationsDir, "V15__create_promotion_tables.sql"),
+			filepath.Join(migrationsDir, "V16__create_loyalty_tables.sql"),
+			filepath.Join(migrationsDir, "V17__create_qurbani_tables.sql"),
+			filepath.Join(migrationsDir, "V18__create_subscription_tables.sql"),
+			filepath.Join(migrationsDir, "V19__create_business_tables.sql"),
+			filepath.Join(migrationsDir, "V20__create_plu_items.sql"),
+			filepath.Join(migrationsDir, "V21__create_label_printing_tables.sql"),
+			filepath.Join(migrationsDir, "V22__create_scales_table.sql"),
+			filepath.Join(migrationsDir, "V23__create_pos_tables.sql"),
+			filepath.Join(migrationsDir, "V24__create_branch_tables.sql"),
+			filepath.Join(migrationsDir, "V25__create_haccp_tables.sql"),
+			filepath.Join(migrationsDir, "V26__create_waste_tables.sql"),
+			filepath.Join(migrationsDir, "V27__create_staff_tables.sql"),
+			filepath.Join(migrationsDir, "V28__create_dispatch_tables.sql"),
+			filepath.Join(migrationsDir, "V29__create_order_events.sql"),
+			filepath.Join(migrationsDir, "V30__position_order_events.sql"),
+			filepath.Join(migrationsDir, "V31__add_order_delivery_charge.sql"),
+			filepath.Join(migrationsDir, "V32__allow_partial_loyalty_reversals.sql"),
+			filepath.Join(migrationsDir, "V33__create_order_line_lots.sql"),
 		),
 		testcontainers.WithWaitStrategy(
 			wait.ForLog("database system is ready to accept connections").
@@ -76,7 +105,7 @@ func setupTestDB(t *testing.T) *pgxpool.Pool {
 func truncateAll(t *testing.T, pool *pgxpool.Pool) {
 	t.Helper()
 	ctx := context.Background()
-	_, err := pool.Exec(ctx, "TRUNCATE TABLE refresh_tokens, customers, admins CASCADE")
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE order_events, delivery_proofs, delivery_stops, delivery_runs, drivers, staff_breaks, staff_clock_entries, staff_shifts, staff_profiles, expiry_proposals, haccp_corrective_actions, haccp_alerts, haccp_readings, haccp_units, admin_branches, branch_prices, branches, pos_payments, pos_sale_lines, pos_sales, pos_sessions, pos_tills, scales, print_jobs, label_printers, plu_items, business_ledger_entries, business_orders, business_members, business_accounts, subscription_renewals, subscriptions, subscription_boxes, qurbani_bookings, qurbani_animals, qurbani_batches, qurbani_offerings, loyalty_balances, loyalty_entries, loyalty_members, loyalty_product_bonuses, promotion_redemptions, promotion_products, promotions, tax_rates, product_tax_categories, invoices, invoice_sequences, audit_log, order_refunds, order_line_lots, order_lines, orders, payment_webhook_events, payment_refunds, payments, goods_received_lines, goods_received_notes, purchase_order_lines, purchase_orders, suppliers, lot_origins, halal_certificates, carcass_cuts, carcass_breakdowns, carcasses, yield_templates, stock_reservation_lines, stock_reservations, inventory_settings, stock_movements, inventory_lots, slot_reservations, fulfilment_slots, slot_templates, delivery_zones, customer_addresses, branch_closures, branch_opening_hours, refresh_tokens, customers, admins CASCADE")
 	if err != nil {
 		t.Fatalf("failed to truncate tables: %v", err)
 	}
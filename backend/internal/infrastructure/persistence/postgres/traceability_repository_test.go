@@ -0,0 +1,161 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	pgstore "github.com/katerji/butchery-app/backend/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, number string, validUntil time.Time) *traceability.Certificate {
+	t.Helper()
+	c, err := traceability.NewCertificate(uuid.New(), "Halal Monitoring Committee", number, "Hill Farm Abattoir",
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), validUntil, "https://files.example.com/"+number+".pdf", time.Now())
+	require.NoError(t, err)
+	return c
+}
+
+func TestIntegrationHalalCertificateRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewHalalCertificateRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	later := newTestCertificate(t, "HMC-2", time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC))
+	sooner := newTestCertificate(t, "HMC-1", time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, repo.Save(ctx, later))
+	require.NoError(t, repo.Save(ctx, sooner))
+
+	t.Run("duplicate number returns ErrDuplicateCertificate", func(t *testing.T) {
+		err := repo.Save(ctx, newTestCertificate(t, "HMC-1", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.ErrorIs(t, err, traceability.ErrDuplicateCertificate)
+	})
+
+	t.Run("lists soonest to expire first", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "HMC-1", found[0].Number())
+		assert.Equal(t, "2026-12-31", found[0].ValidUntil().Format(time.DateOnly))
+	})
+
+	t.Run("filters by expiry date", func(t *testing.T) {
+		by := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+		found, err := repo.FindAll(ctx, &by)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, sooner.ID(), found[0].ID())
+	})
+}
+
+func TestIntegrationLotOriginRepository_Traces(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewLotOriginRepository(pool)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	certRepo := pgstore.NewHalalCertificateRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	cert := newTestCertificate(t, "HMC-7", time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, certRepo.Save(ctx, cert))
+
+	registered := seedLot(t, stockRepo, uuid.New(), uuid.New(), 5000, nil)
+	unregistered := seedLot(t, stockRepo, uuid.New(), uuid.New(), 5000, nil)
+	slaughtered := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+
+	o, err := traceability.NewOrigin(registered.ID(), nil, "Green Valley Farm", slaughtered, "HMC-7", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveOrigin(ctx, o))
+	o, err = traceability.NewOrigin(unregistered.ID(), nil, "Green Valley Farm", slaughtered, "HMC-unknown", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveOrigin(ctx, o))
+
+	t.Run("trace joins the registered certificate", func(t *testing.T) {
+		traces, err := repo.FindTraces(ctx, registered.Code())
+		require.NoError(t, err)
+		require.Len(t, traces, 1)
+		assert.Equal(t, "Green Valley Farm", traces[0].Origin.Farm())
+		require.NotNil(t, traces[0].Certificate)
+		assert.Equal(t, "Hill Farm Abattoir", traces[0].Certificate.Slaughterhouse())
+		assert.True(t, traces[0].HalalVerified())
+	})
+
+	t.Run("unregistered certificate leaves certificate nil", func(t *testing.T) {
+		traces, err := repo.FindTraces(ctx, unregistered.Code())
+		require.NoError(t, err)
+		require.Len(t, traces, 1)
+		assert.Nil(t, traces[0].Certificate)
+		assert.False(t, traces[0].HalalVerified())
+	})
+
+	t.Run("second origin for a lot returns ErrOriginAlreadyRecorded", func(t *testing.T) {
+		again, err := traceability.NewOrigin(registered.ID(), nil, "Other Farm", slaughtered, "HMC-7", time.Now())
+		require.NoError(t, err)
+		assert.ErrorIs(t, repo.SaveOrigin(ctx, again), traceability.ErrOriginAlreadyRecorded)
+	})
+
+	t.Run("unknown lot returns ErrLotNotFound", func(t *testing.T) {
+		missing, err := traceability.NewOrigin(uuid.New(), nil, "Farm", slaughtered, "HMC-7", time.Now())
+		require.NoError(t, err)
+		assert.ErrorIs(t, repo.SaveOrigin(ctx, missing), inventory.ErrLotNotFound)
+	})
+
+	t.Run("unknown code has no traces", func(t *testing.T) {
+		traces, err := repo.FindTraces(ctx, "NO-SUCH-LOT")
+		require.NoError(t, err)
+		assert.Empty(t, traces)
+	})
+}
+
+func TestIntegrationLotOriginRepository_ConsumedLots(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	repo := pgstore.NewLotOriginRepository(pool)
+	stockRepo := pgstore.NewInventoryStockRepository(pool)
+	ctx := context.Background()
+	truncateAll(t, pool)
+
+	productID, branchID, orderID := uuid.New(), uuid.New(), uuid.New()
+	first := seedLot(t, stockRepo, productID, branchID, 1000, nil)
+	second := seedLot(t, stockRepo, productID, branchID, 5000, nil)
+
+	var movements []*inventory.Movement
+	for _, sale := range []struct {
+		lot   *inventory.Lot
+		grams int64
+	}{{first, 1000}, {second, 500}, {second, 250}} {
+		m, err := inventory.NewMovement(uuid.New(), sale.lot, inventory.MovementSold, -sale.grams, "order picked",
+			&orderID, nil, time.Now())
+		require.NoError(t, err)
+		movements = append(movements, m)
+	}
+	require.NoError(t, stockRepo.RecordMovements(ctx, movements))
+
+	lots, err := repo.FindConsumedLots(ctx, orderID)
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	byLot := map[uuid.UUID]int64{lots[0].LotID: lots[0].Grams, lots[1].LotID: lots[1].Grams}
+	assert.Equal(t, int64(1000), byLot[first.ID()])
+	assert.Equal(t, int64(750), byLot[second.ID()])
+
+	none, err := repo.FindConsumedLots(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
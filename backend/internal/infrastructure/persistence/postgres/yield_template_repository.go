@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+)
+
+// YieldTemplateRepository implements carcass.YieldTemplateRepository using PostgreSQL.
+type YieldTemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewYieldTemplateRepository creates a new YieldTemplateRepository.
+func NewYieldTemplateRepository(pool *pgxpool.Pool) *YieldTemplateRepository {
+	return &YieldTemplateRepository{pool: pool}
+}
+
+const yieldTemplateColumns = "species, expected_yield_bp, expected_trim_bp, expected_waste_bp, primals, updated_at"
+
+// primalYieldRow is the JSON shape of a primal in yield_templates.primals.
+type primalYieldRow struct {
+	Name        string `json:"name"`
+	ExpectedBP  int64  `json:"expected_bp"`
+	ValueFactor int64  `json:"value_factor"`
+}
+
+// SaveYieldTemplate inserts or replaces a species' yield template.
+func (r *YieldTemplateRepository) SaveYieldTemplate(ctx context.Context, t *carcass.YieldTemplate) error {
+	rows := make([]primalYieldRow, 0, len(t.Primals()))
+	for _, p := range t.Primals() {
+		rows = append(rows, primalYieldRow{Name: p.Name, ExpectedBP: p.ExpectedBP, ValueFactor: p.ValueFactor})
+	}
+	primals, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("encoding primals: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO yield_templates (`+yieldTemplateColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (species) DO UPDATE SET
+		     expected_yield_bp = EXCLUDED.expected_yield_bp, expected_trim_bp = EXCLUDED.expected_trim_bp,
+		     expected_waste_bp = EXCLUDED.expected_waste_bp, primals = EXCLUDED.primals, updated_at = EXCLUDED.updated_at`,
+		string(t.Species()), t.ExpectedYieldBP(), t.ExpectedTrimBP(), t.ExpectedWasteBP(), primals, t.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("saving yield template: %w", err)
+	}
+	return nil
+}
+
+// FindYieldTemplate finds the yield template for a species.
+func (r *YieldTemplateRepository) FindYieldTemplate(ctx context.Context, species carcass.Species) (*carcass.YieldTemplate, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+yieldTemplateColumns+" FROM yield_templates WHERE species = $1", string(species))
+
+	t, err := scanYieldTemplate(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, carcass.ErrYieldTemplateNotFound
+		}
+		return nil, fmt.Errorf("querying yield template: %w", err)
+	}
+	return t, nil
+}
+
+// FindYieldTemplates returns every yield template ordered by species.
+func (r *YieldTemplateRepository) FindYieldTemplates(ctx context.Context) ([]*carcass.YieldTemplate, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+yieldTemplateColumns+" FROM yield_templates ORDER BY species")
+	if err != nil {
+		return nil, fmt.Errorf("querying yield templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*carcass.YieldTemplate
+	for rows.Next() {
+		t, err := scanYieldTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning yield template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func scanYieldTemplate(row pgx.Row) (*carcass.YieldTemplate, error) {
+	var species string
+	var yieldBP, trimBP, wasteBP int64
+	var raw []byte
+	var updatedAt time.Time
+
+	if err := row.Scan(&species, &yieldBP, &trimBP, &wasteBP, &raw, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var rows []primalYieldRow
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, fmt.Errorf("decoding primals: %w", err)
+	}
+	primals := make([]carcass.PrimalYield, 0, len(rows))
+	for _, p := range rows {
+		primals = append(primals, carcass.PrimalYield{Name: p.Name, ExpectedBP: p.ExpectedBP, ValueFactor: p.ValueFactor})
+	}
+
+	return carcass.ReconstructYieldTemplate(carcass.Species(species), yieldBP, trimBP, wasteBP, primals, updatedAt), nil
+}
@@ -0,0 +1,49 @@
+// Package printer sends print data to network printers.
+package printer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// RawTransport sends print data over a plain TCP connection, the raw or
+// JetDirect protocol label printers listen for on port 9100. The printer
+// prints whatever arrives before the connection closes.
+type RawTransport struct {
+	timeout time.Duration
+}
+
+// NewRawTransport creates a new RawTransport that gives up on a printer
+// that has not taken the data within timeout.
+func NewRawTransport(timeout time.Duration) *RawTransport {
+	return &RawTransport{timeout: timeout}
+}
+
+// Send connects to the printer at address, writes payload and closes the
+// connection.
+func (t *RawTransport) Send(ctx context.Context, address string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("connecting to printer: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return fmt.Errorf("sending to printer: %w", err)
+		}
+	}
+	if _, err := conn.Write(payload); err != nil {
+		return fmt.Errorf("sending to printer: %w", err)
+	}
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("sending to printer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package printer
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawTransport_SendsPayloadToListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+
+	payload := []byte("^XA^FO20,20^A0N,30,30^FDLamb mince^FS^XZ\n")
+	require.NoError(t, NewRawTransport(time.Second).Send(context.Background(), ln.Addr().String(), payload))
+
+	select {
+	case got := <-received:
+		assert.Equal(t, payload, got)
+	case <-time.After(time.Second):
+		t.Fatal("listener received nothing")
+	}
+}
+
+func TestRawTransport_FailsWhenNobodyListens(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	err = NewRawTransport(time.Second).Send(context.Background(), address, []byte("^XA^XZ"))
+	assert.ErrorContains(t, err, "connecting to printer")
+}
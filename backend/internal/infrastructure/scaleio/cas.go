@@ -0,0 +1,85 @@
+package scaleio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// casSession reads the continuous output of CAS, Dibal and similar scales:
+// a line such as "ST,GS,+001.250kg" several times a second, where the first
+// field is ST (stable), US (unstable) or OL (overload) and the second is GS
+// (gross), NT (net) or TR (the tare behind a net weight). These scales are
+// tared on their own keypad.
+type casSession struct {
+	conn *lineConn
+	tare int64
+}
+
+func newCASSession(conn *lineConn) *casSession {
+	return &casSession{conn: conn}
+}
+
+// Next returns the next weight line the scale sends.
+func (s *casSession) Next(ctx context.Context) (scale.Reading, error) {
+	for {
+		line, err := s.conn.read(ctx)
+		if err != nil {
+			return scale.Reading{}, err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		at := time.Now()
+		status, mode, grams, err := parseCASLine(line)
+		if err != nil {
+			return scale.Reading{}, err
+		}
+		switch mode {
+		case "TR":
+			s.tare = grams
+			continue
+		case "GS":
+			s.tare = 0
+		}
+		return scale.Reading{Grams: grams, TareGrams: s.tare, Stable: status == "ST", At: at}, nil
+	}
+}
+
+func (s *casSession) Tare(context.Context) error { return scale.ErrTareUnsupported }
+
+func (s *casSession) Close() error { return s.conn.close() }
+
+// parseCASLine splits a line into its status, mode and weight. Some scales
+// put a device number between the mode and the weight, which is ignored.
+func parseCASLine(line string) (string, string, int64, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) < 3 {
+		return "", "", 0, fmt.Errorf("%w: %q", scale.ErrUnreadable, line)
+	}
+	status := strings.TrimSpace(fields[0])
+	mode := strings.TrimSpace(fields[1])
+	switch status {
+	case "OL":
+		return "", "", 0, scale.ErrOverload
+	case "ST", "US":
+	default:
+		return "", "", 0, fmt.Errorf("%w: %q", scale.ErrUnreadable, line)
+	}
+	switch mode {
+	case "GS", "NT", "TR":
+	default:
+		return "", "", 0, fmt.Errorf("%w: %q", scale.ErrUnreadable, line)
+	}
+
+	weight := strings.TrimSpace(fields[len(fields)-1])
+	value := strings.TrimRight(weight, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	grams, err := parseGrams(value, strings.TrimSpace(weight[len(value):]))
+	if err != nil {
+		return "", "", 0, err
+	}
+	return status, mode, grams, nil
+}
@@ -0,0 +1,132 @@
+// Package scaleio reads weighing scales over TCP or a serial line.
+package scaleio
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// Connector opens sessions to scales, speaking whichever protocol each is
+// set up for.
+type Connector struct {
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// NewConnector creates a new Connector. A scale that does not connect or
+// answer within timeout is taken as disconnected; scales that have to be
+// asked for their weight are asked every pollInterval.
+func NewConnector(timeout, pollInterval time.Duration) *Connector {
+	return &Connector{timeout: timeout, pollInterval: pollInterval}
+}
+
+// Open connects to s and starts a session with it.
+func (c *Connector) Open(ctx context.Context, s *scale.Scale) (scale.Session, error) {
+	p, err := c.dial(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", scale.ErrScaleDisconnected, err)
+	}
+	lines := newLineConn(p, c.timeout)
+	switch s.Protocol() {
+	case scale.ProtocolMTSICS:
+		session, err := newMTSICSSession(ctx, lines, c.pollInterval)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		return session, nil
+	case scale.ProtocolCAS:
+		return newCASSession(lines), nil
+	}
+	p.Close()
+	return nil, scale.ErrUnknownProtocol
+}
+
+func (c *Connector) dial(ctx context.Context, s *scale.Scale) (port, error) {
+	switch s.Transport() {
+	case scale.TransportTCP:
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", s.Address())
+	case scale.TransportSerial:
+		return openSerial(s.Address(), s.BaudRate())
+	}
+	return nil, scale.ErrUnknownTransport
+}
+
+// port is a connection to a scale: a TCP connection or an open serial
+// device.
+type port interface {
+	io.ReadWriteCloser
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// lineConn exchanges CR LF terminated lines with a scale.
+type lineConn struct {
+	port    port
+	reader  *bufio.Reader
+	timeout time.Duration
+}
+
+func newLineConn(p port, timeout time.Duration) *lineConn {
+	return &lineConn{port: p, reader: bufio.NewReader(p), timeout: timeout}
+}
+
+// write sends a command line.
+func (c *lineConn) write(line string) error {
+	// Devices that cannot time out, such as some serial adapters, return
+	// an error here and are simply written without a deadline.
+	_ = c.port.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := io.WriteString(c.port, line+"\r\n"); err != nil {
+		return fmt.Errorf("%w: %w", scale.ErrScaleDisconnected, err)
+	}
+	return nil
+}
+
+// read returns the next line without its terminator. It gives up when ctx
+// is done or nothing arrives within the timeout.
+func (c *lineConn) read(ctx context.Context) (string, error) {
+	_ = c.port.SetReadDeadline(time.Now().Add(c.timeout))
+	stop := context.AfterFunc(ctx, func() { _ = c.port.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("%w: %w", scale.ErrScaleDisconnected, err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *lineConn) close() error { return c.port.Close() }
+
+// parseGrams converts a weight as a scale prints it, such as "+001.250" in
+// "kg", to grams.
+func parseGrams(value, unit string) (int64, error) {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(value, " ", ""), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: weight %q", scale.ErrUnreadable, value)
+	}
+	switch strings.ToLower(unit) {
+	case "g":
+		return int64(math.Round(v)), nil
+	case "kg":
+		return int64(math.Round(v * 1000)), nil
+	case "lb":
+		return int64(math.Round(v * 453.59237)), nil
+	}
+	return 0, fmt.Errorf("%w: unit %q", scale.ErrUnreadable, unit)
+}
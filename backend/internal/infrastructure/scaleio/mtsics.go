@@ -0,0 +1,141 @@
+package scaleio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// mtsicsSession polls a Mettler Toledo scale with MT-SICS commands. Every
+// command is a line such as "SI" and is answered by a line starting with
+// the command's reply code and a status, such as "S S      1.250 kg".
+type mtsicsSession struct {
+	mu   sync.Mutex
+	conn *lineConn
+	poll time.Duration
+	last time.Time
+	tare int64
+}
+
+// newMTSICSSession starts a session by asking the scale for the tare it
+// already holds, which older scales that lack TA simply leave at zero.
+func newMTSICSSession(ctx context.Context, conn *lineConn, poll time.Duration) (*mtsicsSession, error) {
+	s := &mtsicsSession{conn: conn, poll: poll}
+	reply, err := s.command(ctx, "TA", "TA")
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) >= 4 && reply[1] == "A" {
+		if s.tare, err = parseGrams(reply[2], reply[3]); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Next waits out the poll interval and asks for the current weight with
+// SI. A busy scale is asked again.
+func (s *mtsicsSession) Next(ctx context.Context) (scale.Reading, error) {
+	for {
+		s.mu.Lock()
+		wait := time.Until(s.last.Add(s.poll))
+		s.mu.Unlock()
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return scale.Reading{}, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		r, busy, err := s.weigh(ctx)
+		if !busy {
+			return r, err
+		}
+	}
+}
+
+func (s *mtsicsSession) weigh(ctx context.Context) (scale.Reading, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = time.Now()
+	reply, err := s.command(ctx, "SI", "S")
+	if err != nil {
+		return scale.Reading{}, false, err
+	}
+	if len(reply) < 2 {
+		return scale.Reading{}, false, fmt.Errorf("%w: %q", scale.ErrUnreadable, strings.Join(reply, " "))
+	}
+	switch reply[1] {
+	case "I":
+		return scale.Reading{}, true, nil
+	case "+":
+		return scale.Reading{}, false, scale.ErrOverload
+	case "-":
+		return scale.Reading{}, false, scale.ErrUnderload
+	case "S", "D":
+		if len(reply) < 4 {
+			break
+		}
+		grams, err := parseGrams(reply[2], reply[3])
+		if err != nil {
+			return scale.Reading{}, false, err
+		}
+		return scale.Reading{Grams: grams, TareGrams: s.tare, Stable: reply[1] == "S", At: s.last}, false, nil
+	}
+	return scale.Reading{}, false, fmt.Errorf("%w: %q", scale.ErrUnreadable, strings.Join(reply, " "))
+}
+
+// Tare sends T, which the scale carries out once the weight is stable and
+// answers with the tare it took.
+func (s *mtsicsSession) Tare(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reply, err := s.command(ctx, "T", "T")
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(reply) >= 4 && reply[1] == "S":
+		tare, err := parseGrams(reply[2], reply[3])
+		if err != nil {
+			return err
+		}
+		s.tare = tare
+		return nil
+	case reply[0] == "ES":
+		return scale.ErrTareUnsupported
+	}
+	return scale.ErrTareRefused
+}
+
+func (s *mtsicsSession) Close() error { return s.conn.close() }
+
+// command sends cmd and returns the fields of its reply, which starts with
+// code, or of the error reply the scale sent instead. Replies to earlier
+// commands that were given up on are skipped.
+func (s *mtsicsSession) command(ctx context.Context, cmd, code string) ([]string, error) {
+	if err := s.conn.write(cmd); err != nil {
+		return nil, err
+	}
+	for {
+		line, err := s.conn.read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case code, "ES", "ET", "EL":
+			return fields, nil
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package scaleio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+func startSimulator(t *testing.T, protocol scale.Protocol) (*Simulator, scale.Session) {
+	t.Helper()
+	sim, err := NewSimulator(protocol, 5*time.Millisecond)
+	require.NoError(t, err)
+	address, err := sim.Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { sim.Close() })
+
+	s, err := scale.NewScale(uuid.New(), "Counter", protocol, scale.TransportTCP, address, 0, time.Now())
+	require.NoError(t, err)
+	session, err := NewConnector(time.Second, time.Millisecond).Open(context.Background(), s)
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close() })
+	return sim, session
+}
+
+// nextStable reads until the scale flags a stable weight.
+func nextStable(t *testing.T, session scale.Session) scale.Reading {
+	t.Helper()
+	for range 20 {
+		r, err := session.Next(context.Background())
+		require.NoError(t, err)
+		if r.Stable {
+			return r
+		}
+	}
+	t.Fatal("scale never settled")
+	return scale.Reading{}
+}
+
+func TestMTSICS_ReadsSettlingWeightAndTares(t *testing.T) {
+	sim, session := startSimulator(t, scale.ProtocolMTSICS)
+	sim.Place(1250)
+
+	r, err := session.Next(context.Background())
+	require.NoError(t, err)
+	assert.False(t, r.Stable)
+	assert.NotEqual(t, int64(1250), r.Grams)
+
+	r = nextStable(t, session)
+	assert.Equal(t, int64(1250), r.Grams)
+	assert.Zero(t, r.TareGrams)
+
+	require.NoError(t, session.Tare(context.Background()))
+	sim.Place(1750)
+	r = nextStable(t, session)
+	assert.Equal(t, int64(500), r.Grams)
+	assert.Equal(t, int64(1250), r.TareGrams)
+	assert.Equal(t, int64(1750), r.GrossGrams())
+}
+
+func TestMTSICS_ReportsOverload(t *testing.T) {
+	sim, session := startSimulator(t, scale.ProtocolMTSICS)
+	sim.Place(SimulatorCapacityGrams + 1)
+
+	_, err := session.Next(context.Background())
+	assert.ErrorIs(t, err, scale.ErrOverload)
+	assert.ErrorIs(t, session.Tare(context.Background()), scale.ErrTareRefused)
+}
+
+func TestMTSICS_PicksUpTareAlreadyHeld(t *testing.T) {
+	sim, err := NewSimulator(scale.ProtocolMTSICS, time.Millisecond)
+	require.NoError(t, err)
+	address, err := sim.Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	defer sim.Close()
+	sim.Place(300)
+	sim.Tare()
+	sim.Place(1300)
+
+	s, err := scale.NewScale(uuid.New(), "Counter", scale.ProtocolMTSICS, scale.TransportTCP, address, 0, time.Now())
+	require.NoError(t, err)
+	session, err := NewConnector(time.Second, time.Millisecond).Open(context.Background(), s)
+	require.NoError(t, err)
+	defer session.Close()
+
+	r := nextStable(t, session)
+	assert.Equal(t, int64(1000), r.Grams)
+	assert.Equal(t, int64(300), r.TareGrams)
+}
+
+func TestCAS_ReadsContinuousOutput(t *testing.T) {
+	sim, session := startSimulator(t, scale.ProtocolCAS)
+	sim.Place(2400)
+
+	r := nextStable(t, session)
+	assert.Equal(t, int64(2400), r.Grams)
+	assert.Zero(t, r.TareGrams)
+
+	assert.ErrorIs(t, session.Tare(context.Background()), scale.ErrTareUnsupported)
+
+	sim.Tare()
+	sim.Place(2650)
+	r = nextStable(t, session)
+	assert.Equal(t, int64(250), r.Grams)
+	assert.Equal(t, int64(2400), r.TareGrams)
+}
+
+func TestSession_ReportsLostConnection(t *testing.T) {
+	sim, session := startSimulator(t, scale.ProtocolCAS)
+	require.NoError(t, sim.Close())
+
+	var err error
+	for err == nil {
+		_, err = session.Next(context.Background())
+	}
+	assert.ErrorIs(t, err, scale.ErrScaleDisconnected)
+}
+
+func TestSession_StopsWhenContextIsDone(t *testing.T) {
+	_, session := startSimulator(t, scale.ProtocolCAS)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := session.Next(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseCASLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		status string
+		mode   string
+		grams  int64
+		err    error
+	}{
+		{line: "ST,GS,+001.250kg", status: "ST", mode: "GS", grams: 1250},
+		{line: "US,NT,-  0.075 kg", status: "US", mode: "NT", grams: -75},
+		{line: "ST,GS,01,+  850 g", status: "ST", mode: "GS", grams: 850},
+		{line: "ST,TR,+2.000lb", status: "ST", mode: "TR", grams: 907},
+		{line: "OL,GS,+99.999kg", err: scale.ErrOverload},
+		{line: "ST,GS,+1.250oz", err: scale.ErrUnreadable},
+		{line: "XX,GS,+1.250kg", err: scale.ErrUnreadable},
+		{line: "garbage", err: scale.ErrUnreadable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			status, mode, grams, err := parseCASLine(tt.line)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.mode, mode)
+			assert.Equal(t, tt.grams, grams)
+		})
+	}
+}
+
+func TestConnector_FailsWhenNobodyListens(t *testing.T) {
+	sim, err := NewSimulator(scale.ProtocolCAS, time.Millisecond)
+	require.NoError(t, err)
+	address, err := sim.Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, sim.Close())
+
+	s, err := scale.NewScale(uuid.New(), "Counter", scale.ProtocolCAS, scale.TransportTCP, address, 0, time.Now())
+	require.NoError(t, err)
+	_, err = NewConnector(time.Second, time.Millisecond).Open(context.Background(), s)
+	assert.ErrorIs(t, err, scale.ErrScaleDisconnected)
+}
@@ -0,0 +1,74 @@
+//go:build linux
+
+package scaleio
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// cbaud masks the baud rate bits of the control flags; the syscall package
+// does not export it.
+const cbaud = 0o10017
+
+var baudFlags = map[int]uint32{
+	1200:   syscall.B1200,
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+}
+
+// openSerial opens a serial device in raw mode at 8N1 and baudRate. The
+// device is opened non-blocking so that reads honour deadlines.
+func openSerial(device string, baudRate int) (port, error) {
+	speed, ok := baudFlags[baudRate]
+	if !ok {
+		return nil, scale.ErrInvalidBaudRate
+	}
+	f, err := os.OpenFile(device, os.O_RDWR|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := configure(f, speed); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("configuring %s: %w", device, err)
+	}
+	return f, nil
+}
+
+func configure(f *os.File, speed uint32) error {
+	raw, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var errno syscall.Errno
+	err = raw.Control(func(fd uintptr) {
+		var t syscall.Termios
+		if _, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+			return
+		}
+		t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+		t.Oflag &^= syscall.OPOST
+		t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+		t.Cflag &^= syscall.CSIZE | syscall.PARENB | syscall.CSTOPB | cbaud
+		t.Cflag |= syscall.CS8 | syscall.CREAD | syscall.CLOCAL | speed
+		t.Ispeed, t.Ospeed = speed, speed
+		t.Cc[syscall.VMIN], t.Cc[syscall.VTIME] = 1, 0
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&t)))
+	})
+	if err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
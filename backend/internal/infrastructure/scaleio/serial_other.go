@@ -0,0 +1,9 @@
+//go:build !linux
+
+package scaleio
+
+import "github.com/katerji/butchery-app/backend/internal/domain/scale"
+
+func openSerial(string, int) (port, error) {
+	return nil, scale.ErrSerialUnsupported
+}
@@ -0,0 +1,264 @@
+package scaleio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+)
+
+// SimulatorCapacityGrams is the most the simulated scale weighs before it
+// reports an overload.
+const SimulatorCapacityGrams = 30000
+
+// simulatorSettleSteps is how many readings a new load takes to settle.
+const simulatorSettleSteps = 3
+
+// Simulator is a scale on a TCP port, for trying out the weighing flow
+// without hardware. It speaks MT-SICS, answering SI, S, T, TA and TAC, or
+// sends CAS continuous output every interval. Something placed on it wobbles
+// for a few readings before it settles.
+type Simulator struct {
+	protocol scale.Protocol
+	interval time.Duration
+
+	mu       sync.Mutex
+	load     int64
+	tare     int64
+	settle   int
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+}
+
+// NewSimulator creates a Simulator speaking protocol. CAS output is sent
+// every interval.
+func NewSimulator(protocol scale.Protocol, interval time.Duration) (*Simulator, error) {
+	if protocol != scale.ProtocolMTSICS && protocol != scale.ProtocolCAS {
+		return nil, scale.ErrUnknownProtocol
+	}
+	return &Simulator{protocol: protocol, interval: interval, conns: make(map[net.Conn]struct{})}, nil
+}
+
+// Listen starts serving on address and returns the address it listens on,
+// which tells the port chosen for "127.0.0.1:0".
+func (s *Simulator) Listen(address string) (string, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.listener = ln
+	s.mu.Unlock()
+	go s.accept(ln)
+	return ln.Addr().String(), nil
+}
+
+// Close stops listening and drops every connection.
+func (s *Simulator) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+// Place puts grams on the platter, replacing whatever was there.
+func (s *Simulator) Place(grams int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if grams != s.load {
+		s.settle = simulatorSettleSteps
+	}
+	s.load = grams
+}
+
+// Tare takes the current load off later readings, as the tare key does.
+func (s *Simulator) Tare() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tare = s.load
+	s.settle = 0
+}
+
+func (s *Simulator) accept(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go func() {
+			defer s.drop(conn)
+			if s.protocol == scale.ProtocolCAS {
+				s.stream(conn)
+			} else {
+				s.answer(conn)
+			}
+		}()
+	}
+}
+
+func (s *Simulator) drop(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, conn)
+	conn.Close()
+}
+
+// display is what the scale shows next: the net weight, wobbling while a
+// new load settles, and whether it is stable.
+type display struct {
+	net, tare int64
+	stable    bool
+	overload  bool
+	underload bool
+}
+
+// step advances the scale by one reading.
+func (s *Simulator) step() display {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	gross := s.load
+	if s.settle > 0 {
+		wobble := int64(s.settle) * 7
+		if s.settle%2 == 0 {
+			wobble = -wobble
+		}
+		gross += wobble
+		s.settle--
+	}
+	return display{
+		net:       gross - s.tare,
+		tare:      s.tare,
+		stable:    s.settle == 0 && gross == s.load,
+		overload:  s.load > SimulatorCapacityGrams,
+		underload: s.load < 0,
+	}
+}
+
+// settled steps the scale until its weight is stable, as S and T wait for.
+func (s *Simulator) settled() display {
+	for {
+		if d := s.step(); d.stable || d.overload || d.underload {
+			return d
+		}
+	}
+}
+
+func (s *Simulator) answer(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		var reply string
+		switch strings.TrimSpace(line) {
+		case "SI":
+			reply = mtsicsWeight("S", s.step())
+		case "S":
+			reply = mtsicsWeight("S", s.settled())
+		case "T":
+			d := s.settled()
+			if d.overload || d.underload {
+				reply = mtsicsWeight("T", d)
+				break
+			}
+			s.mu.Lock()
+			s.tare = s.load
+			s.mu.Unlock()
+			reply = fmt.Sprintf("T S %10s kg", kilograms(s.load))
+		case "TA":
+			s.mu.Lock()
+			reply = fmt.Sprintf("TA A %10s kg", kilograms(s.tare))
+			s.mu.Unlock()
+		case "TAC":
+			s.mu.Lock()
+			s.tare = 0
+			s.mu.Unlock()
+			reply = "TAC A"
+		default:
+			reply = "ES"
+		}
+		if _, err := io.WriteString(conn, reply+"\r\n"); err != nil {
+			return
+		}
+	}
+}
+
+func mtsicsWeight(code string, d display) string {
+	switch {
+	case d.overload:
+		return code + " +"
+	case d.underload:
+		return code + " -"
+	case d.stable:
+		return fmt.Sprintf("%s S %10s kg", code, kilograms(d.net))
+	}
+	return fmt.Sprintf("%s D %10s kg", code, kilograms(d.net))
+}
+
+func (s *Simulator) stream(conn net.Conn) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := s.send(conn, s.step()); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Simulator) send(conn net.Conn, d display) error {
+	status := "US"
+	if d.stable {
+		status = "ST"
+	}
+	var out string
+	switch {
+	case d.overload:
+		out = "OL,GS,+99.999kg\r\n"
+	case d.tare != 0:
+		out = fmt.Sprintf("%s,TR,%skg\r\n%s,NT,%skg\r\n", status, signed(d.tare), status, signed(d.net))
+	default:
+		out = fmt.Sprintf("%s,GS,%skg\r\n", status, signed(d.net))
+	}
+	_, err := io.WriteString(conn, out)
+	return err
+}
+
+// kilograms formats grams as kilograms to three places.
+func kilograms(grams int64) string {
+	sign := ""
+	if grams < 0 {
+		sign, grams = "-", -grams
+	}
+	return fmt.Sprintf("%s%d.%03d", sign, grams/1000, grams%1000)
+}
+
+// signed formats grams the CAS way, such as "+001.250".
+func signed(grams int64) string {
+	sign := "+"
+	if grams < 0 {
+		sign, grams = "-", -grams
+	}
+	return fmt.Sprintf("%s%03d.%03d", sign, grams/1000, grams%1000)
+}
@@ -0,0 +1,91 @@
+// Package zpl renders labels in ZPL II, the language of Zebra thermal
+// label printers and the many printers that emulate them.
+package zpl
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+)
+
+// dotsPerMM is the resolution of a 203 dpi print head.
+const dotsPerMM = 8
+
+// LabelRenderer renders labels as one ZPL format per label, so a printer
+// prints and cuts or tears each in turn.
+type LabelRenderer struct{}
+
+// NewLabelRenderer creates a new LabelRenderer.
+func NewLabelRenderer() *LabelRenderer {
+	return &LabelRenderer{}
+}
+
+// Render lays labels out on the template. Text is sent as UTF-8 in the
+// printer's scalable font; the barcode is drawn by the printer from its
+// first twelve digits, which also has it print the digits beneath.
+func (r *LabelRenderer) Render(t label.Template, labels []label.Label) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, label.ErrNoLabels
+	}
+
+	var b bytes.Buffer
+	for _, l := range labels {
+		fmt.Fprintf(&b, "^XA\n^CI28\n^PW%d\n^LL%d\n^LH0,0\n", dots(t.WidthMM), dots(t.HeightMM))
+		for _, e := range t.Elements {
+			text := l.Text(e.Field)
+			if text == "" {
+				continue
+			}
+			switch e.Field {
+			case label.FieldBarcode:
+				if err := barcode.Validate(text); err != nil {
+					return nil, fmt.Errorf("rendering label for %q: %w", l.Description, err)
+				}
+				module := max(1, dots(e.Width)/95)
+				fmt.Fprintf(&b, "^FO%d,%d^BY%d^BEN,%d,Y,N^FD%s^FS\n", dots(e.X), dots(e.Y), module, dots(e.Height), text[:12])
+			case label.FieldHalal:
+				badge(&b, e, text)
+			default:
+				field(&b, e, text)
+			}
+		}
+		b.WriteString("^XZ\n")
+	}
+	return b.Bytes(), nil
+}
+
+// field prints text in a block that wraps onto the element's lines. Bold
+// text is struck twice a dot apart, as the scalable font has no bold.
+func field(b *bytes.Buffer, e label.Element, text string) {
+	lines := max(e.Lines, 1)
+	strikes := 1
+	if e.Bold {
+		strikes = 2
+	}
+	for i := range strikes {
+		fmt.Fprintf(b, "^FO%d,%d^A0N,%d,%d^FB%d,%d,0,L,0^FH^FD%s^FS\n",
+			dots(e.X)+i, dots(e.Y), dots(e.Height), dots(e.Height), dots(e.Width), lines, escape(text))
+	}
+}
+
+// badge draws the halal logo: the word in a ring.
+func badge(b *bytes.Buffer, e label.Element, text string) {
+	d := dots(min(e.Width, e.Height))
+	size := d / 5
+	fmt.Fprintf(b, "^FO%d,%d^GC%d,%d,B^FS\n", dots(e.X), dots(e.Y), d, max(2, d/16))
+	fmt.Fprintf(b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,C,0^FH^FD%s^FS\n", dots(e.X), dots(e.Y)+(d-size)/2, size, size, d, escape(text))
+}
+
+func dots(mm float64) int {
+	return int(math.Round(mm * dotsPerMM))
+}
+
+// escape hex-encodes the characters that would end a field or start a
+// command; ^FH has the printer decode them.
+func escape(s string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(s)
+}
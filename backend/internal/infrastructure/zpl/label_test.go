@@ -0,0 +1,68 @@
+package zpl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLabel() label.Label {
+	useBy := time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)
+	return label.Label{
+		Description:     "Lamb mince ^20% fat~",
+		Grams:           1250,
+		PricePerKgCents: 1299,
+		TotalCents:      1624,
+		Currency:        "gbp",
+		PackedOn:        time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		UseBy:           &useBy,
+		LotCode:         "L_1019",
+		Halal:           true,
+		Barcode:         "2100123016242",
+	}
+}
+
+func TestLabelRenderer_RendersOneFormatPerLabel(t *testing.T) {
+	tmpl, err := label.FindTemplate("standard")
+	require.NoError(t, err)
+	plain := testLabel()
+	plain.Halal = false
+	plain.Barcode = ""
+	plain.UseBy = nil
+
+	out, err := NewLabelRenderer().Render(tmpl, []label.Label{testLabel(), plain})
+	require.NoError(t, err)
+	zpl := string(out)
+
+	assert.Equal(t, 2, strings.Count(zpl, "^XA"))
+	assert.Equal(t, 2, strings.Count(zpl, "^XZ"))
+	assert.Contains(t, zpl, "^PW480\n^LL480\n", "60 mm at 8 dots per mm")
+	assert.Contains(t, zpl, "^FD£16.24^FS")
+	assert.Contains(t, zpl, "^FDUse by 22 Oct 2026^FS")
+	assert.Contains(t, zpl, "^FDLamb mince _5E20% fat_7E^FS", "control characters are escaped")
+	assert.Contains(t, zpl, "^FDLot L_5F1019^FS")
+	first, second, _ := strings.Cut(zpl, "^XZ")
+	assert.Contains(t, first, "^BEN,96,Y,N^FD210012301624^FS")
+	assert.Contains(t, first, "^GC")
+	assert.Equal(t, 2, strings.Count(first, "^FDUse by"), "bold text is struck twice")
+	assert.NotContains(t, second, "^BE")
+	assert.NotContains(t, second, "^GC")
+	assert.NotContains(t, second, "Use by")
+}
+
+func TestLabelRenderer_RejectsBadInput(t *testing.T) {
+	tmpl, err := label.FindTemplate("standard")
+	require.NoError(t, err)
+
+	_, err = NewLabelRenderer().Render(tmpl, nil)
+	assert.ErrorIs(t, err, label.ErrNoLabels)
+
+	l := testLabel()
+	l.Barcode = "2100123016243"
+	_, err = NewLabelRenderer().Render(tmpl, []label.Label{l})
+	assert.Error(t, err)
+}
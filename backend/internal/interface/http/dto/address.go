@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// AddressRequest is the request body for adding or editing an address. Latitude
+// and longitude come from the map pin and are needed for polygon delivery zones.
+type AddressRequest struct {
+	Label       string   `json:"label" example:"Home"`
+	Line1       string   `json:"line1" example:"1 High Street"`
+	Line2       string   `json:"line2"`
+	City        string   `json:"city" example:"London"`
+	Postcode    string   `json:"postcode" example:"E1 6AN"`
+	Latitude    *float64 `json:"latitude,omitempty" example:"51.5194"`
+	Longitude   *float64 `json:"longitude,omitempty" example:"-0.0707"`
+	MakeDefault bool     `json:"make_default"`
+}
+
+// AddressResponse is an entry in the customer's address book.
+type AddressResponse struct {
+	ID        string    `json:"id"`
+	Label     string    `json:"label"`
+	Line1     string    `json:"line1"`
+	Line2     string    `json:"line2"`
+	City      string    `json:"city"`
+	Postcode  string    `json:"postcode"`
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
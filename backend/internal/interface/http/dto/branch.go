@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// BranchRequest is the request body for opening a branch.
+type BranchRequest struct {
+	Name    string   `json:"name" example:"Whitechapel"`
+	Address []string `json:"address" example:"1 High Street,London E1 6AN"`
+	Phone   string   `json:"phone" example:"020 7946 0000"`
+	Email   string   `json:"email" example:"whitechapel@butchery.com"`
+}
+
+// UpdateBranchRequest is the request body for changing a branch. Setting
+// active to false closes it to customers; its history is kept.
+type UpdateBranchRequest struct {
+	Name    string   `json:"name" example:"Whitechapel"`
+	Address []string `json:"address" example:"1 High Street,London E1 6AN"`
+	Phone   string   `json:"phone" example:"020 7946 0000"`
+	Email   string   `json:"email" example:"whitechapel@butchery.com"`
+	Active  bool     `json:"active" example:"true"`
+}
+
+// BranchResponse is one of the shops. hours are its opening hours, only
+// given when a single branch is fetched.
+type BranchResponse struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name" example:"Whitechapel"`
+	Address   []string          `json:"address" example:"1 High Street,London E1 6AN"`
+	Phone     string            `json:"phone" example:"020 7946 0000"`
+	Email     string            `json:"email" example:"whitechapel@butchery.com"`
+	Active    bool              `json:"active" example:"true"`
+	Hours     []OpeningHoursDay `json:"hours,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// BranchPriceRequest is the request body for setting what a branch charges
+// a kilogram for a product.
+type BranchPriceRequest struct {
+	PricePerKgCents int64 `json:"price_per_kg_cents" example:"2000"`
+}
+
+// BranchPriceResponse is what a branch charges a kilogram for a product in
+// place of its usual price.
+type BranchPriceResponse struct {
+	BranchID        string    `json:"branch_id"`
+	ProductID       string    `json:"product_id"`
+	PricePerKgCents int64     `json:"price_per_kg_cents" example:"2000"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// AdminBranchesRequest is the request body for setting the branches an
+// admin works at. all_branches gives them every branch, and branch_ids is
+// then ignored.
+type AdminBranchesRequest struct {
+	AllBranches bool     `json:"all_branches" example:"false"`
+	BranchIDs   []string `json:"branch_ids"`
+}
+
+// AdminResponse is a back-office user and the branches they work at.
+type AdminResponse struct {
+	ID          string   `json:"id"`
+	Email       string   `json:"email" example:"cashier@butchery.com"`
+	FullName    string   `json:"full_name" example:"Sam Ahmed"`
+	Role        string   `json:"role" example:"cashier"`
+	AllBranches bool     `json:"all_branches" example:"false"`
+	BranchIDs   []string `json:"branch_ids"`
+}
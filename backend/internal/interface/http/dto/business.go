@@ -0,0 +1,188 @@
+package dto
+
+import "time"
+
+// BusinessAccountRequest is the request body for opening a business
+// account. Orders are due terms_days after they are placed; orders over
+// approval_threshold_cents need an approver's sign-off, and leaving it out
+// means none do.
+type BusinessAccountRequest struct {
+	Name                   string `json:"name" example:"The Grill House Ltd"`
+	CompanyNumber          string `json:"company_number" example:"01234567"`
+	VATNumber              string `json:"vat_number" example:"GB123456789"`
+	BillingEmail           string `json:"billing_email" example:"accounts@grillhouse.example"`
+	BillingAddress         string `json:"billing_address" example:"1 High Street, London E1 6AN"`
+	CreditLimitCents       int64  `json:"credit_limit_cents" example:"500000"`
+	TermsDays              int    `json:"terms_days" example:"30"`
+	ApprovalThresholdCents *int64 `json:"approval_threshold_cents,omitempty" example:"50000"`
+}
+
+// UpdateBusinessAccountRequest is the request body for changing a business
+// account. Setting active to false puts the account on hold.
+type UpdateBusinessAccountRequest struct {
+	BusinessAccountRequest
+	Active bool `json:"active" example:"true"`
+}
+
+// BusinessTierRequest is the price per kilogram from a weight upwards.
+type BusinessTierRequest struct {
+	MinGrams        int64 `json:"min_grams" example:"10000"`
+	PricePerKgCents int64 `json:"price_per_kg_cents" example:"800"`
+}
+
+// BusinessPriceRequest is a product on a business's price list. The first
+// tier starts at zero grams.
+type BusinessPriceRequest struct {
+	ProductID   string                `json:"product_id"`
+	Description string                `json:"description" example:"Beef mince, 20% fat"`
+	Tiers       []BusinessTierRequest `json:"tiers"`
+}
+
+// SetBusinessPricesRequest is the request body for replacing a business's
+// price list.
+type SetBusinessPricesRequest struct {
+	Prices []BusinessPriceRequest `json:"prices"`
+}
+
+// BusinessMemberRequest is the request body for letting a customer buy for
+// a business. role is buyer or approver.
+type BusinessMemberRequest struct {
+	Role string `json:"role" example:"buyer"`
+}
+
+// BusinessPaymentRequest is the request body for recording a payment
+// received from a business.
+type BusinessPaymentRequest struct {
+	AmountCents int64  `json:"amount_cents" example:"120000"`
+	Reference   string `json:"reference" example:"BACS GRILL-0326"`
+}
+
+// BusinessOrderLineRequest is a weight of a product on the price list.
+type BusinessOrderLineRequest struct {
+	ProductID string `json:"product_id"`
+	Grams     int64  `json:"grams" example:"12000"`
+}
+
+// BusinessOrderRequest is the request body for ordering on account.
+type BusinessOrderRequest struct {
+	BranchID string                     `json:"branch_id"`
+	Lines    []BusinessOrderLineRequest `json:"lines"`
+}
+
+// RejectBusinessOrderRequest is the request body for turning down an order.
+type RejectBusinessOrderRequest struct {
+	Reason string `json:"reason" example:"Over this week's budget"`
+}
+
+// BusinessTierResponse is the price per kilogram from a weight upwards.
+type BusinessTierResponse struct {
+	MinGrams        int64 `json:"min_grams" example:"10000"`
+	PricePerKgCents int64 `json:"price_per_kg_cents" example:"800"`
+}
+
+// BusinessPriceResponse is a product on a business's price list.
+type BusinessPriceResponse struct {
+	ProductID   string                 `json:"product_id"`
+	Description string                 `json:"description" example:"Beef mince, 20% fat"`
+	Tiers       []BusinessTierResponse `json:"tiers"`
+}
+
+// BusinessAccountResponse is a business account. balance_cents is what it
+// owes, held_cents what orders waiting for approval would add, and
+// available_credit_cents what is left of its credit limit.
+type BusinessAccountResponse struct {
+	ID                     string                  `json:"id"`
+	Name                   string                  `json:"name" example:"The Grill House Ltd"`
+	CompanyNumber          string                  `json:"company_number" example:"01234567"`
+	VATNumber              string                  `json:"vat_number" example:"GB123456789"`
+	BillingEmail           string                  `json:"billing_email" example:"accounts@grillhouse.example"`
+	BillingAddress         string                  `json:"billing_address" example:"1 High Street, London E1 6AN"`
+	CreditLimitCents       int64                   `json:"credit_limit_cents" example:"500000"`
+	TermsDays              int                     `json:"terms_days" example:"30"`
+	ApprovalThresholdCents *int64                  `json:"approval_threshold_cents,omitempty" example:"50000"`
+	Prices                 []BusinessPriceResponse `json:"prices"`
+	BalanceCents           int64                   `json:"balance_cents" example:"120000"`
+	HeldCents              int64                   `json:"held_cents" example:"0"`
+	AvailableCreditCents   int64                   `json:"available_credit_cents" example:"380000"`
+	Active                 bool                    `json:"active" example:"true"`
+	CreatedAt              time.Time               `json:"created_at"`
+	UpdatedAt              time.Time               `json:"updated_at"`
+}
+
+// BusinessMemberResponse is a customer who buys for a business.
+type BusinessMemberResponse struct {
+	AccountID  string    `json:"account_id"`
+	CustomerID string    `json:"customer_id"`
+	Role       string    `json:"role" example:"buyer"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BusinessMembershipResponse is the business the customer buys for and
+// their role on its account.
+type BusinessMembershipResponse struct {
+	Role    string                  `json:"role" example:"approver"`
+	Account BusinessAccountResponse `json:"account"`
+}
+
+// BusinessOrderLineResponse is a line of an order on account.
+type BusinessOrderLineResponse struct {
+	ProductID       string `json:"product_id"`
+	Description     string `json:"description" example:"Beef mince, 20% fat"`
+	Grams           int64  `json:"grams" example:"12000"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"800"`
+}
+
+// BusinessOrderResponse is an order on account. status is
+// pending_approval, placed or rejected; order_id is the order the sale is
+// recorded under once placed.
+type BusinessOrderResponse struct {
+	ID           string                      `json:"id"`
+	AccountID    string                      `json:"account_id"`
+	PlacedBy     string                      `json:"placed_by"`
+	BranchID     string                      `json:"branch_id"`
+	OrderID      string                      `json:"order_id"`
+	Lines        []BusinessOrderLineResponse `json:"lines"`
+	TotalCents   int64                       `json:"total_cents" example:"9600"`
+	Status       string                      `json:"status" example:"placed"`
+	DecidedBy    *string                     `json:"decided_by,omitempty"`
+	DecidedAt    *time.Time                  `json:"decided_at,omitempty"`
+	RejectReason string                      `json:"reject_reason,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+// BusinessEntryResponse is a charge or payment on a business account.
+// Charges are positive and payments negative.
+type BusinessEntryResponse struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type" example:"charge"`
+	AmountCents int64     `json:"amount_cents" example:"9600"`
+	OrderID     *string   `json:"order_id,omitempty"`
+	Reference   string    `json:"reference,omitempty" example:"BACS GRILL-0326"`
+	DueOn       *string   `json:"due_on,omitempty" example:"2026-04-09"`
+	PostedAt    time.Time `json:"posted_at"`
+}
+
+// BusinessStatementLineResponse is an entry on a statement with the
+// balance after it.
+type BusinessStatementLineResponse struct {
+	BusinessEntryResponse
+	BalanceCents int64 `json:"balance_cents" example:"129600"`
+}
+
+// BusinessStatementResponse is a business's statement for a month.
+// overdue_cents is the part of the closing balance past due at the end of
+// the month.
+type BusinessStatementResponse struct {
+	AccountID      string                          `json:"account_id"`
+	Name           string                          `json:"name" example:"The Grill House Ltd"`
+	BillingAddress string                          `json:"billing_address" example:"1 High Street, London E1 6AN"`
+	VATNumber      string                          `json:"vat_number" example:"GB123456789"`
+	Month          string                          `json:"month" example:"2026-03"`
+	OpeningCents   int64                           `json:"opening_cents" example:"120000"`
+	Lines          []BusinessStatementLineResponse `json:"lines"`
+	ChargedCents   int64                           `json:"charged_cents" example:"9600"`
+	PaidCents      int64                           `json:"paid_cents" example:"120000"`
+	ClosingCents   int64                           `json:"closing_cents" example:"9600"`
+	OverdueCents   int64                           `json:"overdue_cents" example:"0"`
+}
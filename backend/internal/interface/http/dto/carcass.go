@@ -0,0 +1,144 @@
+package dto
+
+import "time"
+
+// RecordCarcassRequest is the request body for booking a carcass into the
+// cutting room. supplier is the farm the animal came from. Weights are in
+// grams and cost in cents.
+type RecordCarcassRequest struct {
+	BranchID            string `json:"branch_id"`
+	Tag                 string `json:"tag" example:"KILL-20261012-07"`
+	Species             string `json:"species" example:"lamb"`
+	HangingWeightGrams  int64  `json:"hanging_weight_grams" example:"21500"`
+	CostCents           int64  `json:"cost_cents" example:"17200"`
+	Supplier            string `json:"supplier" example:"Green Valley Farm"`
+	SlaughteredOn       string `json:"slaughtered_on" example:"2026-10-12"`
+	HalalCertificateRef string `json:"halal_certificate_ref" example:"HMC-2026-0193"`
+}
+
+// CarcassResponse is a carcass booked into the cutting room.
+type CarcassResponse struct {
+	ID                  string    `json:"id"`
+	BranchID            string    `json:"branch_id"`
+	Tag                 string    `json:"tag"`
+	Species             string    `json:"species"`
+	HangingWeightGrams  int64     `json:"hanging_weight_grams"`
+	CostCents           int64     `json:"cost_cents"`
+	Supplier            string    `json:"supplier"`
+	SlaughteredOn       string    `json:"slaughtered_on"`
+	HalalCertificateRef string    `json:"halal_certificate_ref"`
+	Status              string    `json:"status"`
+	ReceivedAt          time.Time `json:"received_at"`
+}
+
+// BreakdownCut is one cut weighed off a carcass. It is received into
+// inventory as a lot of the given product.
+type BreakdownCut struct {
+	Primal    string `json:"primal" example:"leg"`
+	ProductID string `json:"product_id"`
+	Grams     int64  `json:"grams" example:"6200"`
+	ExpiresOn string `json:"expires_on,omitempty" example:"2026-10-22"`
+}
+
+// RecordBreakdownRequest is the request body for recording how a carcass was
+// cut up. Trim is fat and bone that may be sold on; waste is discarded.
+type RecordBreakdownRequest struct {
+	Cuts       []BreakdownCut `json:"cuts"`
+	TrimGrams  int64          `json:"trim_grams" example:"3100"`
+	WasteGrams int64          `json:"waste_grams" example:"900"`
+}
+
+// BreakdownCutResponse is a cut and the inventory lot it was received into.
+type BreakdownCutResponse struct {
+	LotID     string  `json:"lot_id"`
+	Primal    string  `json:"primal"`
+	ProductID string  `json:"product_id"`
+	Grams     int64   `json:"grams"`
+	ExpiresOn *string `json:"expires_on,omitempty"`
+}
+
+// BreakdownResponse is the response body for a recorded breakdown.
+type BreakdownResponse struct {
+	CarcassID  string                 `json:"carcass_id"`
+	Cuts       []BreakdownCutResponse `json:"cuts"`
+	TrimGrams  int64                  `json:"trim_grams"`
+	WasteGrams int64                  `json:"waste_grams"`
+	RecordedAt time.Time              `json:"recorded_at"`
+}
+
+// CutYieldResponse is a cut's share of the carcass weight and cost.
+type CutYieldResponse struct {
+	LotID          string  `json:"lot_id"`
+	ProductID      string  `json:"product_id"`
+	Primal         string  `json:"primal"`
+	Grams          int64   `json:"grams"`
+	YieldPercent   float64 `json:"yield_percent"`
+	CostCents      int64   `json:"cost_cents"`
+	CostPerKgCents int64   `json:"cost_per_kg_cents"`
+}
+
+// PrimalYieldReportResponse compares a primal's actual yield with the
+// template. expected_percent is omitted when the template does not list it.
+type PrimalYieldReportResponse struct {
+	Name            string   `json:"name"`
+	Grams           int64    `json:"grams"`
+	YieldPercent    float64  `json:"yield_percent"`
+	ExpectedPercent *float64 `json:"expected_percent,omitempty"`
+}
+
+// ExpectedYieldResponse is the template's expectation for a whole carcass.
+type ExpectedYieldResponse struct {
+	YieldPercent float64 `json:"yield_percent"`
+	TrimPercent  float64 `json:"trim_percent"`
+	WastePercent float64 `json:"waste_percent"`
+}
+
+// YieldReportResponse describes how well a carcass broke down and what each
+// cut cost. Shrink is weight lost to drying and handling. expected is omitted
+// when there is no yield template for the species.
+type YieldReportResponse struct {
+	CarcassID              string                      `json:"carcass_id"`
+	Species                string                      `json:"species"`
+	HangingGrams           int64                       `json:"hanging_grams"`
+	SaleableGrams          int64                       `json:"saleable_grams"`
+	TrimGrams              int64                       `json:"trim_grams"`
+	WasteGrams             int64                       `json:"waste_grams"`
+	ShrinkGrams            int64                       `json:"shrink_grams"`
+	YieldPercent           float64                     `json:"yield_percent"`
+	TrimPercent            float64                     `json:"trim_percent"`
+	WastePercent           float64                     `json:"waste_percent"`
+	ShrinkPercent          float64                     `json:"shrink_percent"`
+	Expected               *ExpectedYieldResponse      `json:"expected,omitempty"`
+	CostCents              int64                       `json:"cost_cents"`
+	CostPerSaleableKgCents int64                       `json:"cost_per_saleable_kg_cents"`
+	Cuts                   []CutYieldResponse          `json:"cuts"`
+	Primals                []PrimalYieldReportResponse `json:"primals"`
+}
+
+// PrimalYieldTemplate is the share of the carcass a primal is expected to
+// yield. value_factor weights the primal's cost per kg against the carcass
+// average of 100; zero means 100.
+type PrimalYieldTemplate struct {
+	Name            string  `json:"name" example:"leg"`
+	ExpectedPercent float64 `json:"expected_percent" example:"32.5"`
+	ValueFactor     int64   `json:"value_factor" example:"130"`
+}
+
+// SetYieldTemplateRequest is the request body for setting what a species is
+// expected to break down into.
+type SetYieldTemplateRequest struct {
+	YieldPercent float64               `json:"yield_percent" example:"68"`
+	TrimPercent  float64               `json:"trim_percent" example:"20"`
+	WastePercent float64               `json:"waste_percent" example:"6"`
+	Primals      []PrimalYieldTemplate `json:"primals"`
+}
+
+// YieldTemplateResponse is what a species is expected to break down into.
+type YieldTemplateResponse struct {
+	Species      string                `json:"species"`
+	YieldPercent float64               `json:"yield_percent"`
+	TrimPercent  float64               `json:"trim_percent"`
+	WastePercent float64               `json:"waste_percent"`
+	Primals      []PrimalYieldTemplate `json:"primals"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
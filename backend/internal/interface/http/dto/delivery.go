@@ -0,0 +1,36 @@
+package dto
+
+import "encoding/json"
+
+// DeliveryZoneRequest is the request body for creating or updating a delivery
+// zone. Area is a GeoJSON Polygon or MultiPolygon (or a Feature wrapping one).
+type DeliveryZoneRequest struct {
+	Name              string          `json:"name" example:"East London"`
+	Area              json.RawMessage `json:"area,omitempty" swaggertype:"object"`
+	PostcodePrefixes  []string        `json:"postcode_prefixes" example:"E1,E2"`
+	DeliveryFeeCents  int64           `json:"delivery_fee_cents" example:"499"`
+	MinimumOrderCents int64           `json:"minimum_order_cents" example:"2500"`
+	FeeTaxCategory    string          `json:"fee_tax_category,omitempty" example:"delivery"`
+	Active            *bool           `json:"active,omitempty"`
+}
+
+// DeliveryZoneResponse is a delivery zone as seen by the back office. Area is
+// returned as a GeoJSON MultiPolygon.
+type DeliveryZoneResponse struct {
+	ID                string          `json:"id"`
+	Name              string          `json:"name"`
+	Area              json.RawMessage `json:"area,omitempty" swaggertype:"object"`
+	PostcodePrefixes  []string        `json:"postcode_prefixes"`
+	DeliveryFeeCents  int64           `json:"delivery_fee_cents"`
+	MinimumOrderCents int64           `json:"minimum_order_cents"`
+	FeeTaxCategory    string          `json:"fee_tax_category" example:"delivery"`
+	Active            bool            `json:"active"`
+}
+
+// DeliveryQuoteResponse is the delivery zone serving an address and its charges.
+type DeliveryQuoteResponse struct {
+	ZoneID            string `json:"zone_id"`
+	ZoneName          string `json:"zone_name"`
+	DeliveryFeeCents  int64  `json:"delivery_fee_cents"`
+	MinimumOrderCents int64  `json:"minimum_order_cents"`
+}
@@ -0,0 +1,125 @@
+package dto
+
+import "time"
+
+// DriverRequest is the request body for taking on a delivery driver. It
+// creates their admin account, with the driver role, at their home branch.
+type DriverRequest struct {
+	Email    string `json:"email" example:"bilal@butchery.com"`
+	Password string `json:"password" example:"s3cret-pass"`
+	FullName string `json:"full_name" example:"Bilal Hussain"`
+	BranchID string `json:"branch_id"`
+	Phone    string `json:"phone" example:"07700 900123"`
+	Vehicle  string `json:"vehicle" example:"White van LK21 ABC"`
+}
+
+// UpdateDriverRequest is the request body for changing a driver's details;
+// setting active to false stops them being given runs.
+type UpdateDriverRequest struct {
+	BranchID string `json:"branch_id"`
+	Phone    string `json:"phone" example:"07700 900123"`
+	Vehicle  string `json:"vehicle" example:"White van LK21 ABC"`
+	Active   bool   `json:"active" example:"true"`
+}
+
+// DriverResponse is a delivery driver.
+type DriverResponse struct {
+	AdminID   string    `json:"admin_id"`
+	FullName  string    `json:"full_name" example:"Bilal Hussain"`
+	BranchID  string    `json:"branch_id"`
+	Phone     string    `json:"phone" example:"07700 900123"`
+	Vehicle   string    `json:"vehicle" example:"White van LK21 ABC"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PlanRunRequest is the request body for planning a delivery run for a
+// zone's slot. start_latitude and start_longitude are where the driver
+// sets off from; without them the route starts at whichever stop makes it
+// shortest. driver_id optionally assigns the run straight away.
+type PlanRunRequest struct {
+	BranchID       string    `json:"branch_id"`
+	ZoneID         string    `json:"zone_id"`
+	SlotStartsAt   time.Time `json:"slot_starts_at"`
+	StartLatitude  *float64  `json:"start_latitude,omitempty" example:"51.5154"`
+	StartLongitude *float64  `json:"start_longitude,omitempty" example:"-0.0722"`
+	DriverID       string    `json:"driver_id"`
+}
+
+// AddStopRequest is the request body for adding an order to a run.
+// address_id defaults to the customer's default address.
+type AddStopRequest struct {
+	OrderID   string `json:"order_id"`
+	AddressID string `json:"address_id"`
+}
+
+// AssignDriverRequest is the request body for giving a run to a driver.
+type AssignDriverRequest struct {
+	DriverID string `json:"driver_id"`
+}
+
+// DeliveryStopResponse is a stop on a delivery run, with the address it
+// was planned for. proofs lists the kinds of proof of delivery uploaded.
+type DeliveryStopResponse struct {
+	ID            string     `json:"id"`
+	OrderID       string     `json:"order_id"`
+	CustomerID    string     `json:"customer_id"`
+	AddressID     *string    `json:"address_id"`
+	AddressLines  []string   `json:"address_lines" example:"1 High Street,London"`
+	Postcode      string     `json:"postcode" example:"E1 6AN"`
+	Latitude      *float64   `json:"latitude,omitempty" example:"51.5194"`
+	Longitude     *float64   `json:"longitude,omitempty" example:"-0.0707"`
+	Sequence      int        `json:"sequence" example:"1"`
+	Status        string     `json:"status" example:"pending" enums:"pending,delivered,failed"`
+	RecipientName string     `json:"recipient_name" example:"Amina Khan"`
+	FailureReason string     `json:"failure_reason" example:"No one home"`
+	Proofs        []string   `json:"proofs" example:"photo"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
+
+// DeliveryRunResponse is a delivery run: the stops one driver makes for a
+// zone's slot, in the order they make them.
+type DeliveryRunResponse struct {
+	ID             string                 `json:"id"`
+	BranchID       string                 `json:"branch_id"`
+	ZoneID         string                 `json:"zone_id"`
+	SlotStartsAt   time.Time              `json:"slot_starts_at"`
+	SlotEndsAt     time.Time              `json:"slot_ends_at"`
+	StartLatitude  *float64               `json:"start_latitude,omitempty" example:"51.5154"`
+	StartLongitude *float64               `json:"start_longitude,omitempty" example:"-0.0722"`
+	DriverID       *string                `json:"driver_id"`
+	Status         string                 `json:"status" example:"planned" enums:"planned,started,completed"`
+	DistanceMetres int                    `json:"distance_metres" example:"8400"`
+	Stops          []DeliveryStopResponse `json:"stops"`
+	CreatedBy      string                 `json:"created_by"`
+	Version        int                    `json:"version" example:"1"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	StartedAt      *time.Time             `json:"started_at"`
+	CompletedAt    *time.Time             `json:"completed_at"`
+}
+
+// PlanRunResponse is a newly planned run. unaddressed_order_ids are the
+// orders booked into the slot that were left off because their customer
+// has no address to deliver to.
+type PlanRunResponse struct {
+	Run                 DeliveryRunResponse `json:"run"`
+	UnaddressedOrderIDs []string            `json:"unaddressed_order_ids"`
+}
+
+// OrderDeliveryResponse is where a customer's order is on its delivery
+// run. stops_ahead counts the stops before it still to be made;
+// driver_name is shown once the run has started.
+type OrderDeliveryResponse struct {
+	OrderID      string     `json:"order_id"`
+	RunStatus    string     `json:"run_status" example:"started" enums:"planned,started,completed"`
+	SlotStartsAt time.Time  `json:"slot_starts_at"`
+	SlotEndsAt   time.Time  `json:"slot_ends_at"`
+	StopStatus   string     `json:"stop_status" example:"pending" enums:"pending,delivered,failed"`
+	Position     int        `json:"position" example:"4"`
+	TotalStops   int        `json:"total_stops" example:"9"`
+	StopsAhead   int        `json:"stops_ahead" example:"2"`
+	DriverName   string     `json:"driver_name" example:"Bilal Hussain"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
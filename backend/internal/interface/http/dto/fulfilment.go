@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// IDResponse is the response body for endpoints that create a resource.
+type IDResponse struct {
+	ID string `json:"id"`
+}
+
+// OpeningHoursDay is the trading window for one weekday (0 = Sunday).
+type OpeningHoursDay struct {
+	Weekday  int    `json:"weekday"`
+	OpensAt  string `json:"opens_at" example:"08:00"`
+	ClosesAt string `json:"closes_at" example:"18:00"`
+}
+
+// SetOpeningHoursRequest is the request body for replacing a branch's opening hours.
+type SetOpeningHoursRequest struct {
+	Days []OpeningHoursDay `json:"days"`
+}
+
+// AddClosureRequest is the request body for adding a branch closure.
+type AddClosureRequest struct {
+	Date   string `json:"date" example:"2026-12-25"`
+	Reason string `json:"reason"`
+}
+
+// CreateSlotTemplateRequest is the request body for creating a slot template.
+// Capacity is a number of orders, or grams when capacity_unit is "kg".
+type CreateSlotTemplateRequest struct {
+	BranchID     string  `json:"branch_id"`
+	ZoneID       *string `json:"zone_id,omitempty"`
+	Method       string  `json:"method" example:"delivery"`
+	Weekday      int     `json:"weekday"`
+	StartsAt     string  `json:"starts_at" example:"10:00"`
+	EndsAt       string  `json:"ends_at" example:"12:00"`
+	CapacityUnit string  `json:"capacity_unit" example:"orders"`
+	Capacity     int64   `json:"capacity"`
+}
+
+// AvailableSlotResponse is a bookable fulfilment slot.
+type AvailableSlotResponse struct {
+	TemplateID   string    `json:"template_id"`
+	BranchID     string    `json:"branch_id"`
+	ZoneID       *string   `json:"zone_id,omitempty"`
+	Method       string    `json:"method"`
+	Date         string    `json:"date"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	CapacityUnit string    `json:"capacity_unit"`
+	Capacity     int64     `json:"capacity"`
+	Remaining    int64     `json:"remaining"`
+}
+
+// ReserveSlotRequest is the request body for holding a slot during checkout.
+type ReserveSlotRequest struct {
+	TemplateID  string `json:"template_id"`
+	Date        string `json:"date" example:"2026-11-06"`
+	WeightGrams int64  `json:"weight_grams"`
+}
+
+// ReserveSlotResponse is the response body for a slot hold.
+type ReserveSlotResponse struct {
+	ReservationID string    `json:"reservation_id"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
@@ -0,0 +1,124 @@
+package dto
+
+import "time"
+
+// HACCPUnitRequest is the request body for adding a chiller, freezer or
+// display counter to a branch's temperature records. sensor_id is left
+// empty for units read by hand.
+type HACCPUnitRequest struct {
+	BranchID          string  `json:"branch_id"`
+	Name              string  `json:"name" example:"Walk-in chiller"`
+	Kind              string  `json:"kind" example:"chiller" enums:"chiller,freezer,display"`
+	MinCelsius        float64 `json:"min_celsius" example:"0"`
+	MaxCelsius        float64 `json:"max_celsius" example:"5"`
+	SensorID          string  `json:"sensor_id" example:"chiller-1"`
+	CheckEveryMinutes int     `json:"check_every_minutes" example:"240"`
+}
+
+// UpdateHACCPUnitRequest is the request body for changing a unit. Setting
+// active to false takes it out of service: it is no longer read or checked.
+type UpdateHACCPUnitRequest struct {
+	Name              string  `json:"name" example:"Walk-in chiller"`
+	Kind              string  `json:"kind" example:"chiller" enums:"chiller,freezer,display"`
+	MinCelsius        float64 `json:"min_celsius" example:"0"`
+	MaxCelsius        float64 `json:"max_celsius" example:"5"`
+	SensorID          string  `json:"sensor_id" example:"chiller-1"`
+	CheckEveryMinutes int     `json:"check_every_minutes" example:"240"`
+	Active            bool    `json:"active" example:"true"`
+}
+
+// HACCPUnitResponse is a unit whose temperature is kept for HACCP.
+type HACCPUnitResponse struct {
+	ID                string    `json:"id"`
+	BranchID          string    `json:"branch_id"`
+	Name              string    `json:"name" example:"Walk-in chiller"`
+	Kind              string    `json:"kind" example:"chiller"`
+	MinCelsius        float64   `json:"min_celsius" example:"0"`
+	MaxCelsius        float64   `json:"max_celsius" example:"5"`
+	SensorID          string    `json:"sensor_id" example:"chiller-1"`
+	CheckEveryMinutes int       `json:"check_every_minutes" example:"240"`
+	Active            bool      `json:"active" example:"true"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// HACCPReadingRequest is the request body for recording a temperature taken
+// by hand. taken_at defaults to now.
+type HACCPReadingRequest struct {
+	Celsius float64    `json:"celsius" example:"3.5"`
+	TakenAt *time.Time `json:"taken_at"`
+	Note    string     `json:"note" example:"Probe in the middle shelf"`
+}
+
+// HACCPReadingResponse is a temperature recorded for a unit. recorded_by is
+// the admin who took it by hand.
+type HACCPReadingResponse struct {
+	ID         string    `json:"id"`
+	UnitID     string    `json:"unit_id"`
+	Celsius    float64   `json:"celsius" example:"3.5"`
+	InRange    bool      `json:"in_range" example:"true"`
+	Source     string    `json:"source" example:"manual"`
+	RecordedBy *string   `json:"recorded_by"`
+	Note       string    `json:"note" example:"Probe in the middle shelf"`
+	TakenAt    time.Time `json:"taken_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SensorReadingsRequest is the request body sensors, or a gateway for
+// several, push temperatures in.
+type SensorReadingsRequest struct {
+	Readings []SensorReadingRequest `json:"readings"`
+}
+
+// SensorReadingRequest is a temperature a sensor took. taken_at defaults to
+// when it is received.
+type SensorReadingRequest struct {
+	SensorID string     `json:"sensor_id" example:"chiller-1"`
+	Celsius  float64    `json:"celsius" example:"3.5"`
+	TakenAt  *time.Time `json:"taken_at"`
+}
+
+// SensorReadingsResponse counts what happened to pushed readings. Rejected
+// readings came from unknown sensors or could not be taken.
+type SensorReadingsResponse struct {
+	Accepted int `json:"accepted" example:"4"`
+	Rejected int `json:"rejected" example:"0"`
+	Alerts   int `json:"alerts" example:"1"`
+}
+
+// HACCPAlertResponse is an alarm raised on a unit, for a reading out of its
+// range or a reading missed. It stays open until a corrective action
+// resolves it.
+type HACCPAlertResponse struct {
+	ID         string     `json:"id"`
+	UnitID     string     `json:"unit_id"`
+	Kind       string     `json:"kind" example:"out_of_range" enums:"out_of_range,missing"`
+	ReadingID  *string    `json:"reading_id"`
+	Celsius    *float64   `json:"celsius" example:"8.5"`
+	RaisedAt   time.Time  `json:"raised_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	ResolvedBy *string    `json:"resolved_by"`
+}
+
+// HACCPActionRequest is the request body for recording a corrective action.
+// alert_id is the alarm it dealt with, which it resolves.
+type HACCPActionRequest struct {
+	AlertID *string `json:"alert_id"`
+	Action  string  `json:"action" example:"Moved stock to the back chiller and called the engineer"`
+}
+
+// HACCPActionResponse is what staff did about a unit.
+type HACCPActionResponse struct {
+	ID      string    `json:"id"`
+	UnitID  string    `json:"unit_id"`
+	AlertID *string   `json:"alert_id"`
+	Action  string    `json:"action" example:"Moved stock to the back chiller and called the engineer"`
+	TakenBy string    `json:"taken_by"`
+	TakenAt time.Time `json:"taken_at"`
+}
+
+// HACCPCheckRunResponse summarises a run of the missing reading checks.
+type HACCPCheckRunResponse struct {
+	Checked int `json:"checked" example:"6"`
+	Raised  int `json:"raised" example:"1"`
+}
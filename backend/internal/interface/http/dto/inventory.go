@@ -0,0 +1,101 @@
+package dto
+
+import "time"
+
+// ReceiveLotRequest is the request body for booking a delivery of stock into
+// a branch. expires_on is its use-by date; cost_per_kg_cents is what it
+// cost, used to value waste.
+type ReceiveLotRequest struct {
+	ProductID      string `json:"product_id"`
+	BranchID       string `json:"branch_id"`
+	Code           string `json:"code" example:"BEEF-0412"`
+	Grams          int64  `json:"grams" example:"25000"`
+	ExpiresOn      string `json:"expires_on,omitempty" example:"2026-11-02"`
+	CostPerKgCents int64  `json:"cost_per_kg_cents" example:"1250"`
+}
+
+// LotResponse is a batch of stock at a branch. markdown_bp is how far its
+// price has been reduced as it nears its use-by date.
+type LotResponse struct {
+	ID             string    `json:"id"`
+	ProductID      string    `json:"product_id"`
+	BranchID       string    `json:"branch_id"`
+	Code           string    `json:"code"`
+	ReceivedGrams  int64     `json:"received_grams"`
+	OnHandGrams    int64     `json:"on_hand_grams"`
+	ExpiresOn      *string   `json:"expires_on,omitempty"`
+	CostPerKgCents int64     `json:"cost_per_kg_cents" example:"1250"`
+	MarkdownBP     int       `json:"markdown_bp" example:"0"`
+	ReceivedAt     time.Time `json:"received_at"`
+}
+
+// StockAdjustmentRequest is the request body for recording a cut, wastage or
+// adjustment against a lot. delta_grams is negative for stock leaving the lot.
+// Wastage must give one of the waste reason codes.
+type StockAdjustmentRequest struct {
+	LotID      string `json:"lot_id"`
+	Type       string `json:"type" example:"wasted"`
+	DeltaGrams int64  `json:"delta_grams" example:"-800"`
+	Reason     string `json:"reason" example:"trim"`
+}
+
+// StockTakeCount is the weight counted for one lot.
+type StockTakeCount struct {
+	LotID        string `json:"lot_id"`
+	CountedGrams int64  `json:"counted_grams"`
+}
+
+// StockTakeRequest is the request body for a stock take at a branch.
+type StockTakeRequest struct {
+	BranchID string           `json:"branch_id"`
+	Counts   []StockTakeCount `json:"counts"`
+}
+
+// StockMovementResponse is an entry in the stock ledger.
+type StockMovementResponse struct {
+	ID          string    `json:"id"`
+	LotID       string    `json:"lot_id"`
+	ProductID   string    `json:"product_id"`
+	BranchID    string    `json:"branch_id"`
+	Type        string    `json:"type"`
+	DeltaGrams  int64     `json:"delta_grams"`
+	Reason      string    `json:"reason"`
+	ReferenceID *string   `json:"reference_id,omitempty"`
+	ActorID     *string   `json:"actor_id,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// StockLevelResponse is the stock of one product at one branch.
+type StockLevelResponse struct {
+	ProductID              string `json:"product_id"`
+	BranchID               string `json:"branch_id"`
+	OnHandGrams            int64  `json:"on_hand_grams"`
+	ReservedGrams          int64  `json:"reserved_grams"`
+	AvailableGrams         int64  `json:"available_grams"`
+	LowStockThresholdGrams int64  `json:"low_stock_threshold_grams"`
+	Low                    bool   `json:"low"`
+}
+
+// SetLowStockThresholdRequest is the request body for setting a product's
+// low-stock threshold. Zero turns low-stock reporting off.
+type SetLowStockThresholdRequest struct {
+	Grams int64 `json:"grams" example:"5000"`
+}
+
+// StockReservationLine is one product in a stock reservation.
+type StockReservationLine struct {
+	ProductID string `json:"product_id"`
+	Grams     int64  `json:"grams" example:"1500"`
+}
+
+// ReserveStockRequest is the request body for holding stock during checkout.
+type ReserveStockRequest struct {
+	BranchID string                 `json:"branch_id"`
+	Lines    []StockReservationLine `json:"lines"`
+}
+
+// ReserveStockResponse is the response body for a stock hold.
+type ReserveStockResponse struct {
+	ReservationID string    `json:"reservation_id"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
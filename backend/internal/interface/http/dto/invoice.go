@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// InvoiceLineResponse is one line of an invoice or credit note. Amounts are
+// in cents; gross includes VAT at vat_rate_bp basis points.
+type InvoiceLineResponse struct {
+	Description     string `json:"description" example:"Lamb shoulder"`
+	Grams           int64  `json:"grams" example:"1500"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"2000"`
+	VATRateBP       int    `json:"vat_rate_bp" example:"0"`
+	NetCents        int64  `json:"net_cents" example:"3000"`
+	VATCents        int64  `json:"vat_cents" example:"0"`
+	GrossCents      int64  `json:"gross_cents" example:"3000"`
+}
+
+// VATBandResponse totals a document's lines charged at one VAT rate.
+type VATBandResponse struct {
+	RateBP     int   `json:"rate_bp" example:"2000"`
+	NetCents   int64 `json:"net_cents" example:"750"`
+	VATCents   int64 `json:"vat_cents" example:"150"`
+	GrossCents int64 `json:"gross_cents" example:"900"`
+}
+
+// InvoiceResponse is an issued invoice or credit note. The PDF is
+// downloaded separately; sha256 is the checksum of its stored bytes.
+type InvoiceResponse struct {
+	ID             string                `json:"id"`
+	Kind           string                `json:"kind" example:"invoice"`
+	Number         string                `json:"number" example:"INV-3F2A9C01-2026-000042"`
+	BranchID       string                `json:"branch_id"`
+	FiscalYear     int                   `json:"fiscal_year" example:"2026"`
+	OrderID        string                `json:"order_id"`
+	CustomerID     string                `json:"customer_id"`
+	RefundID       *string               `json:"refund_id,omitempty"`
+	CreditedNumber string                `json:"credited_number,omitempty"`
+	Currency       string                `json:"currency" example:"gbp"`
+	Lines          []InvoiceLineResponse `json:"lines"`
+	VATBands       []VATBandResponse     `json:"vat_bands"`
+	NetCents       int64                 `json:"net_cents" example:"3750"`
+	VATCents       int64                 `json:"vat_cents" example:"150"`
+	GrossCents     int64                 `json:"gross_cents" example:"3900"`
+	SHA256         string                `json:"sha256"`
+	IssuedAt       time.Time             `json:"issued_at"`
+}
@@ -0,0 +1,71 @@
+package dto
+
+import "time"
+
+// LabelPrinterRequest is the request body for registering a label printer.
+// address is the printer's host name or IP address, optionally with a port;
+// printers take raw ZPL on port 9100 unless told otherwise.
+type LabelPrinterRequest struct {
+	Name    string `json:"name" example:"Counter"`
+	Address string `json:"address" example:"192.168.1.40"`
+}
+
+// UpdateLabelPrinterRequest is the request body for changing a label
+// printer. Setting active to false stops jobs being sent to it.
+type UpdateLabelPrinterRequest struct {
+	Name    string `json:"name" example:"Counter"`
+	Address string `json:"address" example:"192.168.1.40"`
+	Active  bool   `json:"active" example:"true"`
+}
+
+// LabelPrinterResponse is a label printer on the shop's network.
+type LabelPrinterResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name" example:"Counter"`
+	Address   string    `json:"address" example:"192.168.1.40:9100"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PrintOrderLabelsRequest is the request body for printing a label for
+// every line of an order. An empty template uses the default one.
+type PrintOrderLabelsRequest struct {
+	PrinterID string `json:"printer_id"`
+	Template  string `json:"template" example:"standard"`
+}
+
+// PrintLotLabelsRequest is the request body for printing labels for packs
+// weighed out of a lot, one per weight in packs.
+type PrintLotLabelsRequest struct {
+	PrinterID string  `json:"printer_id"`
+	Template  string  `json:"template" example:"standard"`
+	Packs     []int64 `json:"packs" example:"500,750"`
+}
+
+// PrintJobResponse is a batch of labels sent or waiting to be sent to a
+// printer. source is order or lot and source_id the order or lot it was
+// made for. A queued job is sent again at next_attempt_at.
+type PrintJobResponse struct {
+	ID            string     `json:"id"`
+	PrinterID     string     `json:"printer_id"`
+	Source        string     `json:"source" example:"lot"`
+	SourceID      string     `json:"source_id"`
+	Template      string     `json:"template" example:"standard"`
+	Labels        int        `json:"labels" example:"2"`
+	Status        string     `json:"status" example:"printed"`
+	Attempts      int        `json:"attempts" example:"1"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	PrintedAt     *time.Time `json:"printed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// PrintDispatchResponse counts what became of the jobs a dispatch run
+// sent: printed, queued to be tried again, or given up on.
+type PrintDispatchResponse struct {
+	Printed  int `json:"printed" example:"3"`
+	Retrying int `json:"retrying" example:"1"`
+	Failed   int `json:"failed" example:"0"`
+}
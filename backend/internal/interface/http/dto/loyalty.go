@@ -0,0 +1,108 @@
+package dto
+
+import "time"
+
+// LoyaltyRulesRequest is the request body for changing how customers earn
+// and spend points. Customers earn points_per_unit for every unit_cents
+// they pay, and each point is worth point_value_cents at checkout. Points
+// expire expiry_days after they are earned; zero means never.
+type LoyaltyRulesRequest struct {
+	PointsPerUnit   int64 `json:"points_per_unit" example:"1"`
+	UnitCents       int64 `json:"unit_cents" example:"100"`
+	PointValueCents int64 `json:"point_value_cents" example:"1"`
+	MinRedeemPoints int64 `json:"min_redeem_points" example:"100"`
+	BirthdayBonus   int64 `json:"birthday_bonus" example:"200"`
+	ExpiryDays      int   `json:"expiry_days" example:"365"`
+}
+
+// LoyaltyRulesResponse is the loyalty rules in force, product bonuses
+// included.
+type LoyaltyRulesResponse struct {
+	PointsPerUnit   int64                         `json:"points_per_unit" example:"1"`
+	UnitCents       int64                         `json:"unit_cents" example:"100"`
+	PointValueCents int64                         `json:"point_value_cents" example:"1"`
+	MinRedeemPoints int64                         `json:"min_redeem_points" example:"100"`
+	BirthdayBonus   int64                         `json:"birthday_bonus" example:"200"`
+	ExpiryDays      int                           `json:"expiry_days" example:"365"`
+	ProductBonuses  []LoyaltyProductBonusResponse `json:"product_bonuses"`
+}
+
+// LoyaltyProductBonusRequest is the request body for setting the extra
+// points per unit a product earns on top of the base rate. Zero removes
+// the bonus.
+type LoyaltyProductBonusRequest struct {
+	PointsPerUnit int64 `json:"points_per_unit" example:"2"`
+}
+
+// LoyaltyProductBonusResponse is the extra points per unit a product earns.
+type LoyaltyProductBonusResponse struct {
+	ProductID     string `json:"product_id"`
+	PointsPerUnit int64  `json:"points_per_unit" example:"2"`
+}
+
+// BirthdayRequest is the request body for setting a customer's birthday.
+// Only the day and month are kept.
+type BirthdayRequest struct {
+	Month int `json:"month" example:"10"`
+	Day   int `json:"day" example:"19"`
+}
+
+// BirthdayResponse is a customer's birthday.
+type BirthdayResponse struct {
+	Month int `json:"month" example:"10"`
+	Day   int `json:"day" example:"19"`
+}
+
+// LoyaltyAdjustmentRequest is the request body for crediting or, with
+// negative points, debiting a customer's points by hand.
+type LoyaltyAdjustmentRequest struct {
+	Points int64  `json:"points" example:"250"`
+	Note   string `json:"note" example:"Sorry for the late delivery"`
+}
+
+// LoyaltyEntryResponse is one movement on a customer's points ledger.
+// source_id names the entry a reversal undoes or the credit an expiry took
+// points from.
+type LoyaltyEntryResponse struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind" example:"earn"`
+	Points    int64      `json:"points" example:"42"`
+	Reference string     `json:"reference,omitempty"`
+	SourceID  *string    `json:"source_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LoyaltyBalanceResponse is a customer's balance as projected from their
+// ledger. Earned, redeemed and expired points are gross totals.
+type LoyaltyBalanceResponse struct {
+	CustomerID     string    `json:"customer_id"`
+	Points         int64     `json:"points" example:"1250"`
+	EarnedPoints   int64     `json:"earned_points" example:"2000"`
+	RedeemedPoints int64     `json:"redeemed_points" example:"500"`
+	ExpiredPoints  int64     `json:"expired_points" example:"250"`
+	Entries        int       `json:"entries" example:"14"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// LoyaltyAccountResponse is a customer's points: the balance, what it is
+// worth at checkout and the entries behind it, oldest first.
+type LoyaltyAccountResponse struct {
+	LoyaltyBalanceResponse
+	ValueCents int64                  `json:"value_cents" example:"1250"`
+	Birthday   *BirthdayResponse      `json:"birthday,omitempty"`
+	History    []LoyaltyEntryResponse `json:"history"`
+}
+
+// LoyaltyExpiryResponse is what an expiry run took off.
+type LoyaltyExpiryResponse struct {
+	Customers int   `json:"customers" example:"3"`
+	Points    int64 `json:"points" example:"740"`
+}
+
+// BirthdayBonusesResponse is how many customers a run awarded the bonus.
+type BirthdayBonusesResponse struct {
+	Awarded int `json:"awarded" example:"2"`
+}
@@ -0,0 +1,165 @@
+package dto
+
+import "time"
+
+// OrderLine is one weighed product on a new order. Weight is in grams and
+// price is the shelf price in cents per kg; VAT follows the product's tax
+// category.
+type OrderLine struct {
+	ProductID       string `json:"product_id"`
+	Description     string `json:"description,omitempty" example:"Lamb shoulder"`
+	Grams           int64  `json:"grams" example:"1500"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"2000"`
+}
+
+// CreateOrderRequest is the request body for recording a customer's order.
+// Coupons are codes the customer has given. RedeemPoints is how many
+// loyalty points to spend; only as many as the order can absorb are taken.
+type CreateOrderRequest struct {
+	CustomerID   string      `json:"customer_id"`
+	BranchID     string      `json:"branch_id"`
+	Lines        []OrderLine `json:"lines"`
+	Coupons      []string    `json:"coupons,omitempty" example:"LAMB10"`
+	RedeemPoints int64       `json:"redeem_points,omitempty" example:"500"`
+}
+
+// PlaceOrderRequest is the request body for checking out. The order is for
+// the stock on hold, delivered or collected in the slot on hold. AddressID
+// is the saved address to deliver to, required for a delivery slot.
+type PlaceOrderRequest struct {
+	StockReservationID string   `json:"stock_reservation_id"`
+	SlotReservationID  string   `json:"slot_reservation_id"`
+	AddressID          string   `json:"address_id,omitempty"`
+	Coupons            []string `json:"coupons,omitempty" example:"LAMB10"`
+	RedeemPoints       int64    `json:"redeem_points,omitempty" example:"500"`
+}
+
+// RequestOrderRefundRequest is the request body for refunding an order.
+// LineID is required for line and weight_difference refunds, and
+// DeliveredGrams is the weight the customer actually received for a
+// weight_difference refund.
+type RequestOrderRefundRequest struct {
+	Kind           string  `json:"kind" example:"weight_difference"`
+	LineID         *string `json:"line_id,omitempty"`
+	DeliveredGrams int64   `json:"delivered_grams,omitempty" example:"1320"`
+	Reason         string  `json:"reason" example:"short_weight"`
+	Note           string  `json:"note,omitempty"`
+}
+
+// OrderLineResponse is one weighed product on an order.
+type OrderLineResponse struct {
+	ID              string `json:"id"`
+	ProductID       string `json:"product_id"`
+	Description     string `json:"description"`
+	Grams           int64  `json:"grams"`
+	PricePerKgCents int64  `json:"price_per_kg_cents"`
+	TaxCategory     string `json:"tax_category" example:"fresh_meat"`
+	VATRateBP       int    `json:"vat_rate_bp"`
+	DiscountCents   int64  `json:"discount_cents"`
+	NetCents        int64  `json:"net_cents"`
+	VATCents        int64  `json:"vat_cents"`
+	TotalCents      int64  `json:"total_cents"`
+	RefundedCents   int64  `json:"refunded_cents"`
+}
+
+// OrderRefundResponse is a refund against an order and where it is in
+// approval.
+type OrderRefundResponse struct {
+	ID             string     `json:"id"`
+	OrderID        string     `json:"order_id"`
+	Kind           string     `json:"kind" example:"weight_difference"`
+	LineID         *string    `json:"line_id,omitempty"`
+	DeliveredGrams int64      `json:"delivered_grams,omitempty"`
+	Reason         string     `json:"reason" example:"short_weight"`
+	Note           string     `json:"note,omitempty"`
+	AmountCents    int64      `json:"amount_cents"`
+	Status         string     `json:"status" example:"issued"`
+	RequestedBy    string     `json:"requested_by"`
+	DecidedBy      *string    `json:"decided_by,omitempty"`
+	PaymentID      *string    `json:"payment_id,omitempty"`
+	ReceiptNumber  *string    `json:"receipt_number,omitempty" example:"RF-000001"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	IssuedAt       *time.Time `json:"issued_at,omitempty"`
+}
+
+// OrderDeliveryChargeResponse is the delivery fee charged on an order,
+// priced as a line is.
+type OrderDeliveryChargeResponse struct {
+	ZoneID        string `json:"zone_id"`
+	FeeCents      int64  `json:"fee_cents" example:"499"`
+	TaxCategory   string `json:"tax_category" example:"delivery"`
+	VATRateBP     int    `json:"vat_rate_bp"`
+	DiscountCents int64  `json:"discount_cents"`
+	NetCents      int64  `json:"net_cents"`
+	VATCents      int64  `json:"vat_cents"`
+	TotalCents    int64  `json:"total_cents"`
+}
+
+// OrderResponse is a customer's order with its refunds. Delivery is set
+// for delivered orders.
+type OrderResponse struct {
+	ID              string                       `json:"id"`
+	CustomerID      string                       `json:"customer_id"`
+	BranchID        string                       `json:"branch_id"`
+	Status          string                       `json:"status" example:"partially_refunded"`
+	Stage           string                       `json:"stage" example:"cutting" enums:"received,cutting,ready,completed"`
+	Lines           []OrderLineResponse          `json:"lines"`
+	Delivery        *OrderDeliveryChargeResponse `json:"delivery,omitempty"`
+	TotalCents      int64                        `json:"total_cents"`
+	RefundedCents   int64                        `json:"refunded_cents"`
+	RefundableCents int64                        `json:"refundable_cents"`
+	Refunds         []OrderRefundResponse        `json:"refunds"`
+	CreatedAt       time.Time                    `json:"created_at"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+}
+
+// RefundReceiptResponse is the receipt given to the customer for an issued
+// refund. Line is omitted for whole-order refunds.
+type RefundReceiptResponse struct {
+	ReceiptNumber      string             `json:"receipt_number" example:"RF-000001"`
+	IssuedAt           time.Time          `json:"issued_at"`
+	OrderID            string             `json:"order_id"`
+	CustomerID         string             `json:"customer_id"`
+	RefundID           string             `json:"refund_id"`
+	Kind               string             `json:"kind"`
+	Reason             string             `json:"reason"`
+	Note               string             `json:"note,omitempty"`
+	Line               *OrderLineResponse `json:"line,omitempty"`
+	DeliveredGrams     int64              `json:"delivered_grams,omitempty"`
+	AmountCents        int64              `json:"amount_cents"`
+	Currency           string             `json:"currency" example:"gbp"`
+	OrderTotalCents    int64              `json:"order_total_cents"`
+	OrderRefundedCents int64              `json:"order_refunded_cents"`
+}
+
+// AuditEntryResponse is one entry in a record's audit trail.
+type AuditEntryResponse struct {
+	ID         string            `json:"id"`
+	ActorID    string            `json:"actor_id"`
+	Action     string            `json:"action" example:"refund.issued"`
+	EntityType string            `json:"entity_type" example:"order"`
+	EntityID   string            `json:"entity_id"`
+	Details    map[string]string `json:"details"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// AdvanceStageRequest is the request body for moving an order on through
+// the cutting room. Orders only move forward, though they may skip stages.
+type AdvanceStageRequest struct {
+	Stage string `json:"stage" example:"ready" enums:"cutting,ready,completed"`
+}
+
+// OrderEventResponse is a change to an order, sent on the order streams as
+// an "order" event whose SSE ID is id. status and stage are the order's as
+// of the change; delivery is only set when the change is to its delivery.
+type OrderEventResponse struct {
+	ID         int64     `json:"id" example:"1042"`
+	OrderID    string    `json:"order_id"`
+	CustomerID string    `json:"customer_id"`
+	BranchID   string    `json:"branch_id"`
+	Status     string    `json:"status" example:"placed"`
+	Stage      string    `json:"stage" example:"ready" enums:"received,cutting,ready,completed"`
+	Delivery   string    `json:"delivery,omitempty" example:"out_for_delivery" enums:"out_for_delivery,delivered,failed"`
+	At         time.Time `json:"at"`
+}
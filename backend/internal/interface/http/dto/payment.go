@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// AuthorizePaymentRequest is the request body for authorizing a card at
+// checkout. The card is held for the order's estimated total plus a margin,
+// since the final price depends on the weighed goods.
+type AuthorizePaymentRequest struct {
+	OrderID       string `json:"order_id"`
+	PaymentMethod string `json:"payment_method" example:"pm_card_visa"`
+}
+
+// RefundResponse is money returned against a payment.
+type RefundResponse struct {
+	ID          string    `json:"id"`
+	PaymentID   string    `json:"payment_id"`
+	AmountCents int64     `json:"amount_cents"`
+	Reason      string    `json:"reason"`
+	ProviderRef string    `json:"provider_ref"`
+	CreatedBy   *string   `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PaymentResponse is a card payment for an order. Refunds are only listed
+// when a single payment is fetched.
+type PaymentResponse struct {
+	ID              string           `json:"id"`
+	OrderID         string           `json:"order_id"`
+	CustomerID      string           `json:"customer_id"`
+	Currency        string           `json:"currency" example:"gbp"`
+	Status          string           `json:"status" example:"authorized"`
+	EstimatedCents  int64            `json:"estimated_cents"`
+	AuthorizedCents int64            `json:"authorized_cents"`
+	CapturedCents   int64            `json:"captured_cents"`
+	RefundedCents   int64            `json:"refunded_cents"`
+	Provider        string           `json:"provider"`
+	ProviderRef     string           `json:"provider_ref"`
+	FailureReason   *string          `json:"failure_reason,omitempty"`
+	Refunds         []RefundResponse `json:"refunds,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// PLUItemRequest is the request body for giving a product a PLU code.
+// code is the item number scales and barcodes carry, up to five digits.
+// allergens are any of the 14 declarable allergens, e.g. gluten or
+// sulphites; shelf_life_days dates packs that are not cut from a dated lot.
+type PLUItemRequest struct {
+	Code            int      `json:"code" example:"123"`
+	ProductID       string   `json:"product_id"`
+	Description     string   `json:"description" example:"Lamb mince"`
+	PricePerKgCents int64    `json:"price_per_kg_cents" example:"1299"`
+	Allergens       []string `json:"allergens"`
+	ShelfLifeDays   int      `json:"shelf_life_days" example:"3"`
+}
+
+// UpdatePLUItemRequest is the request body for changing a PLU item. Setting
+// active to false withdraws it; packs already labelled still scan.
+type UpdatePLUItemRequest struct {
+	Description     string   `json:"description" example:"Lamb mince"`
+	PricePerKgCents int64    `json:"price_per_kg_cents" example:"1299"`
+	Allergens       []string `json:"allergens"`
+	ShelfLifeDays   int      `json:"shelf_life_days" example:"3"`
+	Active          bool     `json:"active" example:"true"`
+}
+
+// PLUItemResponse is a product's price look-up entry.
+type PLUItemResponse struct {
+	ID              string    `json:"id"`
+	Code            int       `json:"code" example:"123"`
+	ProductID       string    `json:"product_id"`
+	Description     string    `json:"description" example:"Lamb mince"`
+	PricePerKgCents int64     `json:"price_per_kg_cents" example:"1299"`
+	Allergens       []string  `json:"allergens"`
+	ShelfLifeDays   int       `json:"shelf_life_days" example:"3"`
+	Active          bool      `json:"active" example:"true"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// EncodeBarcodeRequest is the request body for the barcode of a weighed
+// pack. measure is weight to embed the weight or price to embed the price.
+type EncodeBarcodeRequest struct {
+	Grams   int64  `json:"grams" example:"1250"`
+	Measure string `json:"measure" example:"weight"`
+}
+
+// BarcodeResponse is a variable-measure barcode with the pack it stands for.
+type BarcodeResponse struct {
+	Code       string          `json:"code" example:"2000123012506"`
+	Layout     string          `json:"layout" example:"20IIIIIWWWWWC"`
+	Measure    string          `json:"measure" example:"weight"`
+	Item       PLUItemResponse `json:"item"`
+	Grams      int64           `json:"grams" example:"1250"`
+	PriceCents int64           `json:"price_cents" example:"1624"`
+}
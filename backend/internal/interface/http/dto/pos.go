@@ -0,0 +1,185 @@
+package dto
+
+import "time"
+
+// TillRequest is the request body for adding a till at a branch.
+type TillRequest struct {
+	BranchID string `json:"branch_id"`
+	Name     string `json:"name" example:"Till 1"`
+}
+
+// UpdateTillRequest is the request body for changing a till. Setting
+// active to false stops sessions being opened on it.
+type UpdateTillRequest struct {
+	Name   string `json:"name" example:"Till 1"`
+	Active bool   `json:"active" example:"true"`
+}
+
+// TillResponse is a till at a branch's counter.
+type TillResponse struct {
+	ID        string    `json:"id"`
+	BranchID  string    `json:"branch_id"`
+	Name      string    `json:"name" example:"Till 1"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OpenSessionRequest is the request body for opening a till session with
+// the cash float put in the drawer.
+type OpenSessionRequest struct {
+	FloatCents int64 `json:"float_cents" example:"5000"`
+}
+
+// CloseSessionRequest is the request body for closing a till session with
+// the cash counted in the drawer, float included.
+type CloseSessionRequest struct {
+	CountedCashCents int64 `json:"counted_cash_cents" example:"18450"`
+}
+
+// TillSessionResponse is a cashier's session on a till.
+type TillSessionResponse struct {
+	ID               string     `json:"id"`
+	TillID           string     `json:"till_id"`
+	BranchID         string     `json:"branch_id"`
+	Status           string     `json:"status" example:"open"`
+	FloatCents       int64      `json:"float_cents" example:"5000"`
+	CountedCashCents *int64     `json:"counted_cash_cents,omitempty" example:"18450"`
+	OpenedBy         string     `json:"opened_by"`
+	ClosedBy         *string    `json:"closed_by,omitempty"`
+	OpenedAt         time.Time  `json:"opened_at"`
+	ClosedAt         *time.Time `json:"closed_at,omitempty"`
+}
+
+// SaleLineRequest is the request body for ringing up an item, either by
+// scanning a pack's barcode or by keying in a PLU code with the weight
+// from the counter scale.
+type SaleLineRequest struct {
+	Barcode string `json:"barcode" example:"2000123012506"`
+	PLUCode int    `json:"plu_code" example:"123"`
+	Grams   int64  `json:"grams" example:"750"`
+}
+
+// PaymentRequest is the request body for taking a payment. method is cash
+// or card; for cash, amount_cents is what was tendered and change is given
+// back, and reference is the card terminal's authorisation code.
+type PaymentRequest struct {
+	Method      string `json:"method" example:"cash"`
+	AmountCents int64  `json:"amount_cents" example:"2000"`
+	Reference   string `json:"reference" example:"A1B2C3"`
+}
+
+// VoidSaleRequest is the request body for voiding a sale.
+type VoidSaleRequest struct {
+	Reason string `json:"reason" example:"Rung up twice"`
+}
+
+// ReturnItemRequest is part of a sale line brought back. Zero grams returns
+// all that is left of the line.
+type ReturnItemRequest struct {
+	LineID string `json:"line_id"`
+	Grams  int64  `json:"grams" example:"0"`
+}
+
+// ReturnRequest is the request body for handing money back against a
+// receipt. method is cash or card.
+type ReturnRequest struct {
+	ReceiptNumber string              `json:"receipt_number" example:"POS-000042"`
+	Items         []ReturnItemRequest `json:"items"`
+	Method        string              `json:"method" example:"cash"`
+	Reference     string              `json:"reference"`
+	Reason        string              `json:"reason" example:"Wrong cut"`
+}
+
+// SaleLineResponse is an item rung up on a sale.
+type SaleLineResponse struct {
+	ID              string  `json:"id"`
+	ProductID       string  `json:"product_id"`
+	PLUCode         int     `json:"plu_code" example:"123"`
+	Description     string  `json:"description" example:"Lamb chops"`
+	Barcode         string  `json:"barcode" example:"2000123012506"`
+	Grams           int64   `json:"grams" example:"750"`
+	PricePerKgCents int64   `json:"price_per_kg_cents" example:"1800"`
+	TaxCategory     string  `json:"tax_category" example:"fresh_meat"`
+	VATRateBP       int     `json:"vat_rate_bp" example:"0"`
+	NetCents        int64   `json:"net_cents" example:"1350"`
+	VATCents        int64   `json:"vat_cents" example:"0"`
+	GrossCents      int64   `json:"gross_cents" example:"1350"`
+	Voided          bool    `json:"voided" example:"false"`
+	ReturnedGrams   int64   `json:"returned_grams" example:"0"`
+	ReturnedCents   int64   `json:"returned_cents" example:"0"`
+	OriginalLineID  *string `json:"original_line_id,omitempty"`
+}
+
+// SalePaymentResponse is money taken for a sale or handed back for a
+// return.
+type SalePaymentResponse struct {
+	ID            string    `json:"id"`
+	Method        string    `json:"method" example:"cash"`
+	AmountCents   int64     `json:"amount_cents" example:"350"`
+	TenderedCents int64     `json:"tendered_cents" example:"500"`
+	ChangeCents   int64     `json:"change_cents" example:"150"`
+	Reference     string    `json:"reference"`
+	TakenAt       time.Time `json:"taken_at"`
+}
+
+// SaleResponse is a till sale or return.
+type SaleResponse struct {
+	ID             string                `json:"id"`
+	Kind           string                `json:"kind" example:"sale"`
+	SessionID      string                `json:"session_id"`
+	TillID         string                `json:"till_id"`
+	BranchID       string                `json:"branch_id"`
+	CashierID      string                `json:"cashier_id"`
+	Status         string                `json:"status" example:"completed"`
+	ReceiptNumber  string                `json:"receipt_number,omitempty" example:"POS-000042"`
+	OriginalSaleID *string               `json:"original_sale_id,omitempty"`
+	Lines          []SaleLineResponse    `json:"lines"`
+	Payments       []SalePaymentResponse `json:"payments"`
+	TotalCents     int64                 `json:"total_cents" example:"1350"`
+	PaidCents      int64                 `json:"paid_cents" example:"1350"`
+	BalanceCents   int64                 `json:"balance_cents" example:"0"`
+	ChangeCents    int64                 `json:"change_cents" example:"150"`
+	Reason         string                `json:"reason,omitempty"`
+	VoidedBy       *string               `json:"voided_by,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	CompletedAt    *time.Time            `json:"completed_at,omitempty"`
+	VoidedAt       *time.Time            `json:"voided_at,omitempty"`
+}
+
+// TillReportBandResponse totals a session's takings at one VAT rate.
+type TillReportBandResponse struct {
+	RateBP     int   `json:"rate_bp" example:"2000"`
+	NetCents   int64 `json:"net_cents" example:"10000"`
+	VATCents   int64 `json:"vat_cents" example:"2000"`
+	GrossCents int64 `json:"gross_cents" example:"12000"`
+}
+
+// TillReportResponse is a till session's takings. Once the session is
+// closed it is the Z report: expected_cash_cents is the float plus cash
+// taken less cash refunded, and variance_cents is what was counted less
+// what was expected.
+type TillReportResponse struct {
+	SessionID         string                   `json:"session_id"`
+	TillID            string                   `json:"till_id"`
+	BranchID          string                   `json:"branch_id"`
+	OpenedAt          time.Time                `json:"opened_at"`
+	ClosedAt          *time.Time               `json:"closed_at,omitempty"`
+	FloatCents        int64                    `json:"float_cents" example:"5000"`
+	SalesCount        int                      `json:"sales_count" example:"12"`
+	SalesCents        int64                    `json:"sales_cents" example:"15400"`
+	ReturnsCount      int                      `json:"returns_count" example:"1"`
+	ReturnsCents      int64                    `json:"returns_cents" example:"950"`
+	VoidedSalesCount  int                      `json:"voided_sales_count" example:"1"`
+	VoidedSalesCents  int64                    `json:"voided_sales_cents" example:"1200"`
+	VoidedLinesCount  int                      `json:"voided_lines_count" example:"2"`
+	VoidedLinesCents  int64                    `json:"voided_lines_cents" example:"640"`
+	CashSalesCents    int64                    `json:"cash_sales_cents" example:"9400"`
+	CardSalesCents    int64                    `json:"card_sales_cents" example:"6000"`
+	CashRefundsCents  int64                    `json:"cash_refunds_cents" example:"950"`
+	CardRefundsCents  int64                    `json:"card_refunds_cents" example:"0"`
+	Bands             []TillReportBandResponse `json:"bands"`
+	ExpectedCashCents int64                    `json:"expected_cash_cents" example:"13450"`
+	CountedCashCents  *int64                   `json:"counted_cash_cents,omitempty" example:"13400"`
+	VarianceCents     *int64                   `json:"variance_cents,omitempty" example:"-50"`
+}
@@ -0,0 +1,175 @@
+package dto
+
+import "time"
+
+// SupplierContact is a person to talk to at a supplier.
+type SupplierContact struct {
+	Name  string `json:"name" example:"Amina Khan"`
+	Role  string `json:"role,omitempty" example:"Sales"`
+	Email string `json:"email,omitempty" example:"amina@greenvalley.example"`
+	Phone string `json:"phone,omitempty" example:"+44 1632 960123"`
+}
+
+// SupplierCertificate is an accreditation held by a supplier.
+type SupplierCertificate struct {
+	Type      string `json:"type" example:"halal"`
+	Number    string `json:"number" example:"HMC-1042"`
+	ExpiresOn string `json:"expires_on,omitempty" example:"2027-03-31"`
+}
+
+// SupplierRequest is the request body for creating or updating a supplier.
+// Payment terms are the number of days after delivery that invoices fall due.
+type SupplierRequest struct {
+	Name             string                `json:"name" example:"Green Valley Farm"`
+	Type             string                `json:"type" example:"farm"`
+	Contacts         []SupplierContact     `json:"contacts"`
+	Certificates     []SupplierCertificate `json:"certificates"`
+	PaymentTermsDays int                   `json:"payment_terms_days" example:"30"`
+	Active           *bool                 `json:"active,omitempty"`
+}
+
+// SupplierResponse is a supplier as seen by the back office.
+type SupplierResponse struct {
+	ID               string                `json:"id"`
+	Name             string                `json:"name"`
+	Type             string                `json:"type"`
+	Contacts         []SupplierContact     `json:"contacts"`
+	Certificates     []SupplierCertificate `json:"certificates"`
+	PaymentTermsDays int                   `json:"payment_terms_days"`
+	Active           bool                  `json:"active"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+// PricePointResponse is the price agreed for a product on one purchase order.
+type PricePointResponse struct {
+	ProductID       string    `json:"product_id"`
+	PricePerKgCents int64     `json:"price_per_kg_cents"`
+	PurchaseOrderID string    `json:"purchase_order_id"`
+	SentAt          time.Time `json:"sent_at"`
+}
+
+// PurchaseOrderLine is one product to order, by weight in grams, at a price
+// per kilogram in cents.
+type PurchaseOrderLine struct {
+	ProductID       string `json:"product_id"`
+	OrderedGrams    int64  `json:"ordered_grams" example:"20000"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"1150"`
+}
+
+// CreatePurchaseOrderRequest is the request body for drafting a purchase order.
+type CreatePurchaseOrderRequest struct {
+	SupplierID string              `json:"supplier_id"`
+	BranchID   string              `json:"branch_id"`
+	Lines      []PurchaseOrderLine `json:"lines"`
+	ExpectedOn string              `json:"expected_on,omitempty" example:"2026-11-02"`
+	Notes      string              `json:"notes,omitempty"`
+}
+
+// RevisePurchaseOrderRequest is the request body for changing a draft
+// purchase order. The lines replace the existing ones.
+type RevisePurchaseOrderRequest struct {
+	Lines      []PurchaseOrderLine `json:"lines"`
+	ExpectedOn string              `json:"expected_on,omitempty" example:"2026-11-02"`
+	Notes      string              `json:"notes,omitempty"`
+}
+
+// PurchaseOrderLineResponse is an order line and how much of it has arrived.
+type PurchaseOrderLineResponse struct {
+	ID               string `json:"id"`
+	ProductID        string `json:"product_id"`
+	OrderedGrams     int64  `json:"ordered_grams"`
+	PricePerKgCents  int64  `json:"price_per_kg_cents"`
+	ReceivedGrams    int64  `json:"received_grams"`
+	OutstandingGrams int64  `json:"outstanding_grams"`
+}
+
+// PurchaseOrderResponse is a purchase order. Version changes on every update.
+type PurchaseOrderResponse struct {
+	ID         string                      `json:"id"`
+	SupplierID string                      `json:"supplier_id"`
+	BranchID   string                      `json:"branch_id"`
+	Status     string                      `json:"status"`
+	Lines      []PurchaseOrderLineResponse `json:"lines"`
+	TotalCents int64                       `json:"total_cents"`
+	ExpectedOn *string                     `json:"expected_on,omitempty"`
+	Notes      string                      `json:"notes"`
+	Version    int                         `json:"version"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	SentAt     *time.Time                  `json:"sent_at,omitempty"`
+	ClosedAt   *time.Time                  `json:"closed_at,omitempty"`
+}
+
+// GoodsReceivedLine is one batch weighed in on arrival against a purchase
+// order line. It is received into inventory as a lot under the supplier's
+// batch code.
+type GoodsReceivedLine struct {
+	LineID             string  `json:"line_id"`
+	LotCode            string  `json:"lot_code" example:"GV-0412"`
+	Grams              int64   `json:"grams" example:"19600"`
+	TemperatureCelsius float64 `json:"temperature_celsius" example:"3.2"`
+	ExpiresOn          string  `json:"expires_on,omitempty" example:"2026-10-30"`
+}
+
+// ReceiveGoodsRequest is the request body for booking a delivery.
+type ReceiveGoodsRequest struct {
+	Lines []GoodsReceivedLine `json:"lines"`
+	Notes string              `json:"notes,omitempty"`
+}
+
+// GoodsReceivedLineResponse is a delivered batch and the lot it was received into.
+type GoodsReceivedLineResponse struct {
+	LineID             string  `json:"line_id"`
+	ProductID          string  `json:"product_id"`
+	LotID              string  `json:"lot_id"`
+	LotCode            string  `json:"lot_code"`
+	Grams              int64   `json:"grams"`
+	TemperatureCelsius float64 `json:"temperature_celsius"`
+	ExpiresOn          *string `json:"expires_on,omitempty"`
+}
+
+// GoodsReceivedNoteResponse is a delivery booked against a purchase order.
+type GoodsReceivedNoteResponse struct {
+	ID              string                      `json:"id"`
+	PurchaseOrderID string                      `json:"purchase_order_id"`
+	BranchID        string                      `json:"branch_id"`
+	Lines           []GoodsReceivedLineResponse `json:"lines"`
+	Notes           string                      `json:"notes"`
+	ReceivedAt      time.Time                   `json:"received_at"`
+}
+
+// LineVarianceResponse compares what was ordered on a purchase order line
+// with what arrived. Negative variances are shortfalls.
+type LineVarianceResponse struct {
+	PurchaseOrderID string  `json:"purchase_order_id"`
+	SupplierID      string  `json:"supplier_id"`
+	LineID          string  `json:"line_id"`
+	ProductID       string  `json:"product_id"`
+	OrderedGrams    int64   `json:"ordered_grams"`
+	ReceivedGrams   int64   `json:"received_grams"`
+	VarianceGrams   int64   `json:"variance_grams"`
+	VariancePercent float64 `json:"variance_percent"`
+	OrderedCents    int64   `json:"ordered_cents"`
+	ReceivedCents   int64   `json:"received_cents"`
+	VarianceCents   int64   `json:"variance_cents"`
+}
+
+// SupplierVarianceResponse totals the line variances for one supplier.
+type SupplierVarianceResponse struct {
+	SupplierID      string  `json:"supplier_id"`
+	Orders          int     `json:"orders"`
+	OrderedGrams    int64   `json:"ordered_grams"`
+	ReceivedGrams   int64   `json:"received_grams"`
+	VarianceGrams   int64   `json:"variance_grams"`
+	VariancePercent float64 `json:"variance_percent"`
+	OrderedCents    int64   `json:"ordered_cents"`
+	ReceivedCents   int64   `json:"received_cents"`
+	VarianceCents   int64   `json:"variance_cents"`
+}
+
+// VarianceReportResponse compares ordered and received amounts on received
+// and closed purchase orders. Suppliers are listed largest shortfall first.
+type VarianceReportResponse struct {
+	Suppliers []SupplierVarianceResponse `json:"suppliers"`
+	Lines     []LineVarianceResponse     `json:"lines"`
+}
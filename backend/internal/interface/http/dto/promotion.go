@@ -0,0 +1,86 @@
+package dto
+
+import "time"
+
+// PromotionRequest is the request body for creating or updating a
+// promotion. Which amounts apply depends on kind: percent_bp for
+// percentage, amount_cents for fixed and per_kg (off each kg), buy_grams
+// and free_grams for free_weight, and one of percent_bp or amount_cents for
+// first_order. Empty scope lists match everything. starts_at and ends_at
+// are RFC 3339 times; ends_at is exclusive. Zero limits are unlimited.
+type PromotionRequest struct {
+	Name               string   `json:"name" example:"Buy 2kg mince get 500g free"`
+	Code               string   `json:"code,omitempty" example:"MINCE500"`
+	Kind               string   `json:"kind" example:"free_weight"`
+	PercentBP          int      `json:"percent_bp,omitempty" example:"1000"`
+	AmountCents        int64    `json:"amount_cents,omitempty" example:"500"`
+	BuyGrams           int64    `json:"buy_grams,omitempty" example:"2000"`
+	FreeGrams          int64    `json:"free_grams,omitempty" example:"500"`
+	MinSubtotalCents   int64    `json:"min_subtotal_cents,omitempty" example:"5000"`
+	ProductIDs         []string `json:"product_ids,omitempty"`
+	Categories         []string `json:"categories,omitempty" example:"mince"`
+	Species            []string `json:"species,omitempty" example:"beef"`
+	Segments           []string `json:"segments,omitempty" example:"returning"`
+	StartsAt           string   `json:"starts_at,omitempty" example:"2026-10-24T00:00:00+01:00"`
+	EndsAt             string   `json:"ends_at,omitempty" example:"2026-10-26T00:00:00Z"`
+	MaxUses            int      `json:"max_uses,omitempty" example:"500"`
+	MaxUsesPerCustomer int      `json:"max_uses_per_customer,omitempty" example:"1"`
+	Priority           int      `json:"priority,omitempty" example:"10"`
+	Stacking           string   `json:"stacking,omitempty" example:"stackable"`
+	Active             *bool    `json:"active,omitempty"`
+}
+
+// PromotionResponse is a promotion as seen by the back office.
+type PromotionResponse struct {
+	ID                 string     `json:"id"`
+	Name               string     `json:"name"`
+	Code               string     `json:"code,omitempty"`
+	Kind               string     `json:"kind" example:"free_weight"`
+	PercentBP          int        `json:"percent_bp"`
+	AmountCents        int64      `json:"amount_cents"`
+	BuyGrams           int64      `json:"buy_grams"`
+	FreeGrams          int64      `json:"free_grams"`
+	MinSubtotalCents   int64      `json:"min_subtotal_cents"`
+	ProductIDs         []string   `json:"product_ids"`
+	Categories         []string   `json:"categories"`
+	Species            []string   `json:"species"`
+	Segments           []string   `json:"segments"`
+	StartsAt           *time.Time `json:"starts_at,omitempty"`
+	EndsAt             *time.Time `json:"ends_at,omitempty"`
+	MaxUses            int        `json:"max_uses"`
+	MaxUsesPerCustomer int        `json:"max_uses_per_customer"`
+	Priority           int        `json:"priority"`
+	Stacking           string     `json:"stacking" example:"stackable"`
+	Active             bool       `json:"active"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// PromotionProductRequest is the request body for setting the category and
+// species promotions can target a product by. At least one is required.
+type PromotionProductRequest struct {
+	Category string `json:"category,omitempty" example:"mince"`
+	Species  string `json:"species,omitempty" example:"beef"`
+}
+
+// PromotionProductResponse is what promotions can target a product by.
+type PromotionProductResponse struct {
+	ProductID string `json:"product_id"`
+	Category  string `json:"category,omitempty" example:"mince"`
+	Species   string `json:"species,omitempty" example:"beef"`
+}
+
+// DiscountResponse is what one promotion took off a cart.
+type DiscountResponse struct {
+	PromotionID   string `json:"promotion_id"`
+	Name          string `json:"name" example:"Lamb weekend"`
+	Code          string `json:"code,omitempty" example:"LAMB10"`
+	DeliveryCents int64  `json:"delivery_cents,omitempty"`
+	AmountCents   int64  `json:"amount_cents" example:"300"`
+}
+
+// RejectedCouponResponse is a coupon code that did not apply, and why.
+type RejectedCouponResponse struct {
+	Code   string `json:"code" example:"SPRING"`
+	Reason string `json:"reason" example:"promotion is not running"`
+}
@@ -0,0 +1,134 @@
+package dto
+
+import "time"
+
+// QurbaniOfferingRequest is the request body for opening a share type for
+// booking in a season. share_type is whole_sheep, whole_goat or cow_share;
+// a cow is sacrificed for seven shares, a sheep or goat for one.
+type QurbaniOfferingRequest struct {
+	Season     string `json:"season" example:"2027"`
+	ShareType  string `json:"share_type" example:"cow_share"`
+	PriceCents int64  `json:"price_cents" example:"9500"`
+	Animals    int    `json:"animals" example:"12"`
+}
+
+// UpdateQurbaniOfferingRequest is the request body for changing an
+// offering's price and animals, or closing it for booking.
+type UpdateQurbaniOfferingRequest struct {
+	PriceCents int64 `json:"price_cents" example:"9500"`
+	Animals    int   `json:"animals" example:"12"`
+	Active     bool  `json:"active" example:"true"`
+}
+
+// QurbaniOfferingResponse is an offering with how many shares are left.
+type QurbaniOfferingResponse struct {
+	ID              string    `json:"id"`
+	Season          string    `json:"season" example:"2027"`
+	ShareType       string    `json:"share_type" example:"cow_share"`
+	PriceCents      int64     `json:"price_cents" example:"9500"`
+	Animals         int       `json:"animals" example:"12"`
+	SharesPerAnimal int       `json:"shares_per_animal" example:"7"`
+	Capacity        int       `json:"capacity" example:"84"`
+	Booked          int       `json:"booked" example:"30"`
+	Remaining       int       `json:"remaining" example:"54"`
+	Active          bool      `json:"active" example:"true"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// QurbaniBookingRequest is the request body for booking one share.
+// niyyah_name is the person the sacrifice is made on behalf of.
+// distribution is delivery, collection or charity; address_id is one of the
+// customer's addresses and is required for delivery.
+type QurbaniBookingRequest struct {
+	OfferingID   string `json:"offering_id"`
+	NiyyahName   string `json:"niyyah_name" example:"Fatima bint Ahmed"`
+	Distribution string `json:"distribution" example:"charity"`
+	AddressID    string `json:"address_id,omitempty"`
+}
+
+// QurbaniBookingResponse is one booked share.
+type QurbaniBookingResponse struct {
+	ID            string     `json:"id"`
+	OfferingID    string     `json:"offering_id"`
+	CustomerID    string     `json:"customer_id"`
+	NiyyahName    string     `json:"niyyah_name" example:"Fatima bint Ahmed"`
+	Distribution  string     `json:"distribution" example:"charity"`
+	AddressID     *string    `json:"address_id,omitempty"`
+	PriceCents    int64      `json:"price_cents" example:"9500"`
+	Status        string     `json:"status" example:"allocated"`
+	AnimalID      *string    `json:"animal_id,omitempty"`
+	BookedAt      time.Time  `json:"booked_at"`
+	SlaughteredAt *time.Time `json:"slaughtered_at,omitempty"`
+	NotifiedAt    *time.Time `json:"notified_at,omitempty"`
+}
+
+// QurbaniAllocationResponse is the result of grouping shares into animals.
+type QurbaniAllocationResponse struct {
+	AnimalsOpened int                      `json:"animals_opened" example:"2"`
+	Allocated     []QurbaniBookingResponse `json:"allocated"`
+}
+
+// QurbaniBatchPlanRequest is the request body for putting the animals with
+// shares on them into slaughter batches of animals_per_batch, the first at
+// starts_at and each following interval_minutes later.
+type QurbaniBatchPlanRequest struct {
+	Season          string    `json:"season" example:"2027"`
+	StartsAt        time.Time `json:"starts_at" example:"2027-05-16T07:00:00Z"`
+	AnimalsPerBatch int       `json:"animals_per_batch" example:"4"`
+	IntervalMinutes int       `json:"interval_minutes" example:"90"`
+}
+
+// QurbaniBatchResponse is a slaughter batch.
+type QurbaniBatchResponse struct {
+	ID          string     `json:"id"`
+	Season      string     `json:"season" example:"2027"`
+	Number      int        `json:"number" example:"1"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// QurbaniBatchCompletionResponse is a completed batch, how many shares were
+// sacrificed and how many customers were told. Customers who could not be
+// reached are counted in failed and can be notified again.
+type QurbaniBatchCompletionResponse struct {
+	Batch       QurbaniBatchResponse `json:"batch"`
+	Slaughtered int                  `json:"slaughtered" example:"28"`
+	Notified    int                  `json:"notified" example:"27"`
+	Failed      int                  `json:"failed" example:"1"`
+}
+
+// QurbaniNotificationResponse is the result of notifying a batch's customers
+// again.
+type QurbaniNotificationResponse struct {
+	Notified int `json:"notified" example:"1"`
+	Failed   int `json:"failed" example:"0"`
+}
+
+// QurbaniAnimalResponse is one animal on the slaughter sheet and the niyyah
+// names it is sacrificed for.
+type QurbaniAnimalResponse struct {
+	ID            string     `json:"id"`
+	OfferingID    string     `json:"offering_id"`
+	ShareType     string     `json:"share_type" example:"cow_share"`
+	Number        int        `json:"number" example:"3"`
+	BatchNumber   *int       `json:"batch_number,omitempty" example:"1"`
+	SlaughteredAt *time.Time `json:"slaughtered_at,omitempty"`
+	NiyyahNames   []string   `json:"niyyah_names"`
+	FreeShares    int        `json:"free_shares" example:"0"`
+}
+
+// QurbaniDistributionLineResponse is one share's meat to hand out. animal
+// and batch are left out until the share is allocated and batched.
+type QurbaniDistributionLineResponse struct {
+	BookingID     string           `json:"booking_id"`
+	NiyyahName    string           `json:"niyyah_name" example:"Fatima bint Ahmed"`
+	ShareType     string           `json:"share_type" example:"cow_share"`
+	Distribution  string           `json:"distribution" example:"charity"`
+	Status        string           `json:"status" example:"slaughtered"`
+	AnimalNumber  *int             `json:"animal_number,omitempty" example:"3"`
+	BatchNumber   *int             `json:"batch_number,omitempty" example:"1"`
+	CustomerName  string           `json:"customer_name" example:"Ahmed Khan"`
+	CustomerPhone string           `json:"customer_phone" example:"+447700900123"`
+	Address       *AddressResponse `json:"address,omitempty"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// ScaleRequest is the request body for registering a weighing scale.
+// protocol is mt-sics or cas and transport is tcp or serial. A tcp scale's
+// address is host:port; a serial scale's is a device such as /dev/ttyUSB0,
+// read at baud_rate (9600 if zero).
+type ScaleRequest struct {
+	Name      string `json:"name" example:"Counter"`
+	Protocol  string `json:"protocol" example:"mt-sics"`
+	Transport string `json:"transport" example:"tcp"`
+	Address   string `json:"address" example:"192.168.1.50:4001"`
+	BaudRate  int    `json:"baud_rate" example:"0"`
+}
+
+// UpdateScaleRequest is the request body for changing a scale. Setting
+// active to false stops it being read.
+type UpdateScaleRequest struct {
+	Name      string `json:"name" example:"Counter"`
+	Protocol  string `json:"protocol" example:"mt-sics"`
+	Transport string `json:"transport" example:"tcp"`
+	Address   string `json:"address" example:"192.168.1.50:4001"`
+	BaudRate  int    `json:"baud_rate" example:"0"`
+	Active    bool   `json:"active" example:"true"`
+}
+
+// ScaleResponse is a weighing scale.
+type ScaleResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name" example:"Counter"`
+	Protocol  string    `json:"protocol" example:"mt-sics"`
+	Transport string    `json:"transport" example:"tcp"`
+	Address   string    `json:"address" example:"192.168.1.50:4001"`
+	BaudRate  int       `json:"baud_rate" example:"0"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ScaleReadingResponse is what a scale showed: the net weight, the tare
+// taken off it and whether the weight has settled. It is also the data of
+// each reading event on a scale's stream.
+type ScaleReadingResponse struct {
+	Grams      int64     `json:"grams" example:"1250"`
+	TareGrams  int64     `json:"tare_grams" example:"300"`
+	GrossGrams int64     `json:"gross_grams" example:"1550"`
+	Stable     bool      `json:"stable" example:"true"`
+	At         time.Time `json:"at"`
+}
+
+// ScaleErrorEvent is the data of an error event on a scale's stream, sent
+// while the scale is overloaded or once its connection is lost.
+type ScaleErrorEvent struct {
+	Error string `json:"error" example:"scale is overloaded"`
+}
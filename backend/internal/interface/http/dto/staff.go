@@ -0,0 +1,142 @@
+package dto
+
+import "time"
+
+// StaffProfileRequest is the request body for putting an admin on the
+// staff rota. contracted_minutes is the time a week they are contracted
+// for, zero for staff paid only for the hours they work; setting active
+// to false takes them off the rota. role is left as it is when omitted.
+type StaffProfileRequest struct {
+	BranchID          string `json:"branch_id"`
+	Position          string `json:"position" example:"butcher" enums:"butcher,apprentice,cashier"`
+	HourlyRateCents   int64  `json:"hourly_rate_cents" example:"1250"`
+	ContractedMinutes int    `json:"contracted_minutes" example:"2400"`
+	Active            bool   `json:"active" example:"true"`
+	Role              string `json:"role,omitempty" example:"cashier" enums:"staff,cashier,manager"`
+}
+
+// StaffProfileResponse is an admin's staff profile: where they are based,
+// what they are trained for and what they are paid.
+type StaffProfileResponse struct {
+	AdminID           string    `json:"admin_id"`
+	FullName          string    `json:"full_name" example:"Yusuf Ali"`
+	BranchID          string    `json:"branch_id"`
+	Position          string    `json:"position" example:"butcher"`
+	HourlyRateCents   int64     `json:"hourly_rate_cents" example:"1250"`
+	ContractedMinutes int       `json:"contracted_minutes" example:"2400"`
+	Active            bool      `json:"active" example:"true"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ShiftRequest is the request body for scheduling or changing a shift.
+// position defaults to the member of staff's own; butchers may also be
+// put on the till.
+type ShiftRequest struct {
+	AdminID      string    `json:"admin_id"`
+	BranchID     string    `json:"branch_id"`
+	Position     string    `json:"position" example:"butcher" enums:"butcher,apprentice,cashier"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	BreakMinutes int       `json:"break_minutes" example:"30"`
+	Note         string    `json:"note" example:"Cutting for the Saturday collections"`
+}
+
+// ShiftResponse is a shift on a rota.
+type ShiftResponse struct {
+	ID           string    `json:"id"`
+	AdminID      string    `json:"admin_id"`
+	BranchID     string    `json:"branch_id"`
+	Position     string    `json:"position" example:"butcher"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	BreakMinutes int       `json:"break_minutes" example:"30"`
+	Minutes      int       `json:"minutes" example:"480"`
+	Note         string    `json:"note" example:"Cutting for the Saturday collections"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SlotCoverageResponse is how many trained butchers a fulfilment slot
+// needs for the orders booked into it and how many are on shift for all of
+// it.
+type SlotCoverageResponse struct {
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Orders    int64     `json:"orders" example:"14"`
+	Grams     int64     `json:"grams" example:"0"`
+	Required  int       `json:"required" example:"2"`
+	Scheduled int       `json:"scheduled" example:"1"`
+}
+
+// RotaConflictResponse is a problem with a rota. admin_id is the member of
+// staff it is about and shift_ids the shifts involved; minutes is the
+// overtime or the break still needed, and required and scheduled count
+// butchers for an understaffed slot.
+type RotaConflictResponse struct {
+	Kind      string    `json:"kind" example:"double_booked" enums:"double_booked,missing_break,overtime,understaffed"`
+	AdminID   *string   `json:"admin_id"`
+	ShiftIDs  []string  `json:"shift_ids"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Minutes   int       `json:"minutes" example:"0"`
+	Required  int       `json:"required" example:"0"`
+	Scheduled int       `json:"scheduled" example:"0"`
+}
+
+// RotaResponse is a branch's rota for a week with what is wrong with it.
+type RotaResponse struct {
+	BranchID  string                 `json:"branch_id"`
+	WeekStart time.Time              `json:"week_start"`
+	Shifts    []ShiftResponse        `json:"shifts"`
+	Coverage  []SlotCoverageResponse `json:"coverage"`
+	Conflicts []RotaConflictResponse `json:"conflicts"`
+}
+
+// ClockInRequest is the request body for clocking in. branch_id defaults
+// to the member of staff's own branch.
+type ClockInRequest struct {
+	BranchID *string `json:"branch_id"`
+}
+
+// BreakResponse is a break taken during a clock entry. ended_at is null
+// while it lasts.
+type BreakResponse struct {
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// ClockEntryResponse is time a member of staff was clocked in, as of now
+// while they still are.
+type ClockEntryResponse struct {
+	ID            string          `json:"id"`
+	AdminID       string          `json:"admin_id"`
+	BranchID      string          `json:"branch_id"`
+	ClockedInAt   time.Time       `json:"clocked_in_at"`
+	ClockedOutAt  *time.Time      `json:"clocked_out_at"`
+	OnBreak       bool            `json:"on_break" example:"false"`
+	Breaks        []BreakResponse `json:"breaks"`
+	WorkedMinutes int             `json:"worked_minutes" example:"450"`
+}
+
+// TimesheetEntryResponse is a clock entry on a timesheet.
+// missing_break_minutes is how much too short its breaks were.
+type TimesheetEntryResponse struct {
+	ClockEntryResponse
+	BreakMinutes        int `json:"break_minutes" example:"30"`
+	MissingBreakMinutes int `json:"missing_break_minutes" example:"0"`
+}
+
+// TimesheetResponse is the time a member of staff was rostered for and
+// worked over a period. Entries still open are listed but not counted.
+type TimesheetResponse struct {
+	Staff            StaffProfileResponse     `json:"staff"`
+	Entries          []TimesheetEntryResponse `json:"entries"`
+	ScheduledMinutes int                      `json:"scheduled_minutes" example:"2400"`
+	WorkedMinutes    int                      `json:"worked_minutes" example:"2460"`
+	BreakMinutes     int                      `json:"break_minutes" example:"150"`
+	OvertimeMinutes  int                      `json:"overtime_minutes" example:"60"`
+	MissingBreaks    int                      `json:"missing_breaks" example:"0"`
+	PayCents         int64                    `json:"pay_cents" example:"51250"`
+}
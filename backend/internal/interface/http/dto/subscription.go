@@ -0,0 +1,160 @@
+package dto
+
+import "time"
+
+// SubscriptionCutRequest is a weight of one product at its shelf price.
+type SubscriptionCutRequest struct {
+	ProductID       string `json:"product_id"`
+	Description     string `json:"description" example:"Lamb shoulder, bone in"`
+	Grams           int64  `json:"grams" example:"1500"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"1800"`
+}
+
+// SubscriptionItemRequest is a cut in a box. substitutes are the cuts
+// customers may swap it for, and only customizable boxes may list them.
+type SubscriptionItemRequest struct {
+	SubscriptionCutRequest
+	Substitutes []SubscriptionCutRequest `json:"substitutes,omitempty"`
+}
+
+// SubscriptionBoxRequest is the request body for adding a box customers can
+// subscribe to.
+type SubscriptionBoxRequest struct {
+	Name         string                    `json:"name" example:"Family lamb box"`
+	Customizable bool                      `json:"customizable" example:"true"`
+	Items        []SubscriptionItemRequest `json:"items"`
+}
+
+// UpdateSubscriptionBoxRequest is the request body for changing a box's
+// contents or withdrawing it from new subscribers.
+type UpdateSubscriptionBoxRequest struct {
+	Name         string                    `json:"name" example:"Family lamb box"`
+	Customizable bool                      `json:"customizable" example:"true"`
+	Items        []SubscriptionItemRequest `json:"items"`
+	Active       bool                      `json:"active" example:"true"`
+}
+
+// SubscriptionCutResponse is a weight of one product at its shelf price.
+type SubscriptionCutResponse struct {
+	ProductID       string `json:"product_id"`
+	Description     string `json:"description" example:"Lamb shoulder, bone in"`
+	Grams           int64  `json:"grams" example:"1500"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"1800"`
+}
+
+// SubscriptionItemResponse is a cut in a box and what it may be swapped for.
+type SubscriptionItemResponse struct {
+	SubscriptionCutResponse
+	Substitutes []SubscriptionCutResponse `json:"substitutes"`
+}
+
+// SubscriptionBoxResponse is a box customers can subscribe to.
+type SubscriptionBoxResponse struct {
+	ID           string                     `json:"id"`
+	Name         string                     `json:"name" example:"Family lamb box"`
+	Customizable bool                       `json:"customizable" example:"true"`
+	Items        []SubscriptionItemResponse `json:"items"`
+	Active       bool                       `json:"active" example:"true"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+}
+
+// SubscribeRequest is the request body for subscribing to a box. cadence is
+// weekly, fortnightly or monthly. template_id is the fulfilment slot every
+// delivery is made in, and first_delivery (YYYY-MM-DD) must fall on its
+// weekday. payment_method is the provider's token for the card every order
+// is charged to.
+type SubscribeRequest struct {
+	BoxID         string `json:"box_id"`
+	Cadence       string `json:"cadence" example:"weekly"`
+	TemplateID    string `json:"template_id"`
+	FirstDelivery string `json:"first_delivery" example:"2026-03-07"`
+	PaymentMethod string `json:"payment_method" example:"pm_card_visa"`
+}
+
+// SkipDeliveryRequest is the request body for skipping one delivery.
+type SkipDeliveryRequest struct {
+	Date string `json:"date" example:"2026-03-14"`
+}
+
+// SwapCutRequest is the request body for swapping a cut in a customizable
+// box. Setting substitute_id to product_id undoes an earlier swap.
+type SwapCutRequest struct {
+	ProductID    string `json:"product_id"`
+	SubstituteID string `json:"substitute_id"`
+}
+
+// ChangeSubscriptionSlotRequest is the request body for moving deliveries
+// to another fulfilment slot.
+type ChangeSubscriptionSlotRequest struct {
+	TemplateID string `json:"template_id"`
+}
+
+// ChangePaymentMethodRequest is the request body for changing the card
+// orders are charged to.
+type ChangePaymentMethodRequest struct {
+	PaymentMethod string `json:"payment_method" example:"pm_card_visa"`
+}
+
+// SubscriptionSwapResponse is a cut the customer has swapped.
+type SubscriptionSwapResponse struct {
+	ProductID    string `json:"product_id"`
+	SubstituteID string `json:"substitute_id"`
+}
+
+// SubscriptionResponse is a customer's subscription. upcoming lists the next
+// delivery dates, leaving out skipped ones.
+type SubscriptionResponse struct {
+	ID             string                     `json:"id"`
+	CustomerID     string                     `json:"customer_id"`
+	BoxID          string                     `json:"box_id"`
+	Cadence        string                     `json:"cadence" example:"weekly"`
+	TemplateID     string                     `json:"template_id"`
+	PaymentMethod  string                     `json:"payment_method" example:"pm_card_visa"`
+	Status         string                     `json:"status" example:"active"`
+	NextDelivery   string                     `json:"next_delivery" example:"2026-03-07"`
+	Upcoming       []string                   `json:"upcoming" example:"2026-03-07,2026-03-21"`
+	Skips          []string                   `json:"skips" example:"2026-03-14"`
+	Swaps          []SubscriptionSwapResponse `json:"swaps"`
+	FailedRenewals int                        `json:"failed_renewals" example:"0"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+// SubscriptionRenewalResponse is the order generated for one delivery.
+// status is awaiting_payment, ordered or failed; next_attempt_at is when a
+// declined payment will be retried.
+type SubscriptionRenewalResponse struct {
+	ID             string                    `json:"id"`
+	SubscriptionID string                    `json:"subscription_id"`
+	CustomerID     string                    `json:"customer_id"`
+	BranchID       string                    `json:"branch_id"`
+	DeliveryDate   string                    `json:"delivery_date" example:"2026-03-07"`
+	SlotStartsAt   time.Time                 `json:"slot_starts_at"`
+	CutoffAt       time.Time                 `json:"cutoff_at"`
+	OrderID        string                    `json:"order_id"`
+	Cuts           []SubscriptionCutResponse `json:"cuts"`
+	TotalCents     int64                     `json:"total_cents" example:"4500"`
+	Status         string                    `json:"status" example:"ordered"`
+	PaymentID      *string                   `json:"payment_id,omitempty"`
+	Attempts       int                       `json:"attempts" example:"1"`
+	NextAttemptAt  *time.Time                `json:"next_attempt_at,omitempty"`
+	FailureReason  string                    `json:"failure_reason,omitempty" example:"card declined"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// SubscriptionRunResponse counts what a run of the subscription scheduler
+// did. missed deliveries had their cutoff pass before an order could be
+// generated; errored subscriptions and renewals ran into an unexpected error
+// and were failed so the run could carry on.
+type SubscriptionRunResponse struct {
+	Generated int `json:"generated" example:"12"`
+	Missed    int `json:"missed" example:"0"`
+	Ordered   int `json:"ordered" example:"10"`
+	Declined  int `json:"declined" example:"1"`
+	Failed    int `json:"failed" example:"1"`
+	Paused    int `json:"paused" example:"0"`
+	Notified  int `json:"notified" example:"2"`
+	Errored   int `json:"errored" example:"0"`
+}
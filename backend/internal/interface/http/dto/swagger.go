@@ -26,3 +26,759 @@ type ErrorBody struct {
 	Data  *string `json:"data"`
 	Error string  `json:"error"`
 }
+
+// IDSuccessResponse wraps IDResponse in the standard API envelope.
+type IDSuccessResponse struct {
+	Data  IDResponse `json:"data"`
+	Error *string    `json:"error"`
+}
+
+// AvailableSlotsSuccessResponse wraps a list of AvailableSlotResponse in the standard API envelope.
+type AvailableSlotsSuccessResponse struct {
+	Data  []AvailableSlotResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// ReserveSlotSuccessResponse wraps ReserveSlotResponse in the standard API envelope.
+type ReserveSlotSuccessResponse struct {
+	Data  ReserveSlotResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// AddressSuccessResponse wraps AddressResponse in the standard API envelope.
+type AddressSuccessResponse struct {
+	Data  AddressResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// AddressesSuccessResponse wraps a list of AddressResponse in the standard API envelope.
+type AddressesSuccessResponse struct {
+	Data  []AddressResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// DeliveryZoneSuccessResponse wraps DeliveryZoneResponse in the standard API envelope.
+type DeliveryZoneSuccessResponse struct {
+	Data  DeliveryZoneResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// DeliveryZonesSuccessResponse wraps a list of DeliveryZoneResponse in the standard API envelope.
+type DeliveryZonesSuccessResponse struct {
+	Data  []DeliveryZoneResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// DeliveryQuoteSuccessResponse wraps DeliveryQuoteResponse in the standard API envelope.
+type DeliveryQuoteSuccessResponse struct {
+	Data  DeliveryQuoteResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// LotSuccessResponse wraps LotResponse in the standard API envelope.
+type LotSuccessResponse struct {
+	Data  LotResponse `json:"data"`
+	Error *string     `json:"error"`
+}
+
+// StockMovementSuccessResponse wraps StockMovementResponse in the standard API envelope.
+type StockMovementSuccessResponse struct {
+	Data  StockMovementResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// StockMovementsSuccessResponse wraps a list of StockMovementResponse in the standard API envelope.
+type StockMovementsSuccessResponse struct {
+	Data  []StockMovementResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// StockLevelsSuccessResponse wraps a list of StockLevelResponse in the standard API envelope.
+type StockLevelsSuccessResponse struct {
+	Data  []StockLevelResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// ReserveStockSuccessResponse wraps ReserveStockResponse in the standard API envelope.
+type ReserveStockSuccessResponse struct {
+	Data  ReserveStockResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// CarcassSuccessResponse wraps CarcassResponse in the standard API envelope.
+type CarcassSuccessResponse struct {
+	Data  CarcassResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// CarcassesSuccessResponse wraps a list of CarcassResponse in the standard API envelope.
+type CarcassesSuccessResponse struct {
+	Data  []CarcassResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// BreakdownSuccessResponse wraps BreakdownResponse in the standard API envelope.
+type BreakdownSuccessResponse struct {
+	Data  BreakdownResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// YieldReportSuccessResponse wraps YieldReportResponse in the standard API envelope.
+type YieldReportSuccessResponse struct {
+	Data  YieldReportResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// YieldTemplateSuccessResponse wraps YieldTemplateResponse in the standard API envelope.
+type YieldTemplateSuccessResponse struct {
+	Data  YieldTemplateResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// YieldTemplatesSuccessResponse wraps a list of YieldTemplateResponse in the standard API envelope.
+type YieldTemplatesSuccessResponse struct {
+	Data  []YieldTemplateResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// CertificateSuccessResponse wraps CertificateResponse in the standard API envelope.
+type CertificateSuccessResponse struct {
+	Data  CertificateResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// CertificatesSuccessResponse wraps a list of CertificateResponse in the standard API envelope.
+type CertificatesSuccessResponse struct {
+	Data  []CertificateResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// LotOriginSuccessResponse wraps LotOriginResponse in the standard API envelope.
+type LotOriginSuccessResponse struct {
+	Data  LotOriginResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// LotTracesSuccessResponse wraps a list of LotTraceResponse in the standard API envelope.
+type LotTracesSuccessResponse struct {
+	Data  []LotTraceResponse `json:"data"`
+	Error *string            `json:"error"`
+}
+
+// ConsumedLotsSuccessResponse wraps a list of ConsumedLotResponse in the standard API envelope.
+type ConsumedLotsSuccessResponse struct {
+	Data  []ConsumedLotResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// SupplierSuccessResponse wraps SupplierResponse in the standard API envelope.
+type SupplierSuccessResponse struct {
+	Data  SupplierResponse `json:"data"`
+	Error *string          `json:"error"`
+}
+
+// SuppliersSuccessResponse wraps a list of SupplierResponse in the standard API envelope.
+type SuppliersSuccessResponse struct {
+	Data  []SupplierResponse `json:"data"`
+	Error *string            `json:"error"`
+}
+
+// PricePointsSuccessResponse wraps a list of PricePointResponse in the standard API envelope.
+type PricePointsSuccessResponse struct {
+	Data  []PricePointResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// PurchaseOrderSuccessResponse wraps PurchaseOrderResponse in the standard API envelope.
+type PurchaseOrderSuccessResponse struct {
+	Data  PurchaseOrderResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// PurchaseOrdersSuccessResponse wraps a list of PurchaseOrderResponse in the standard API envelope.
+type PurchaseOrdersSuccessResponse struct {
+	Data  []PurchaseOrderResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// GoodsReceivedNoteSuccessResponse wraps GoodsReceivedNoteResponse in the standard API envelope.
+type GoodsReceivedNoteSuccessResponse struct {
+	Data  GoodsReceivedNoteResponse `json:"data"`
+	Error *string                   `json:"error"`
+}
+
+// GoodsReceivedNotesSuccessResponse wraps a list of GoodsReceivedNoteResponse in the standard API envelope.
+type GoodsReceivedNotesSuccessResponse struct {
+	Data  []GoodsReceivedNoteResponse `json:"data"`
+	Error *string                     `json:"error"`
+}
+
+// VarianceReportSuccessResponse wraps VarianceReportResponse in the standard API envelope.
+type VarianceReportSuccessResponse struct {
+	Data  VarianceReportResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// PaymentSuccessResponse wraps PaymentResponse in the standard API envelope.
+type PaymentSuccessResponse struct {
+	Data  PaymentResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// OrderSuccessResponse wraps OrderResponse in the standard API envelope.
+type OrderSuccessResponse struct {
+	Data  OrderResponse `json:"data"`
+	Error *string       `json:"error"`
+}
+
+// OrderRefundSuccessResponse wraps OrderRefundResponse in the standard API envelope.
+type OrderRefundSuccessResponse struct {
+	Data  OrderRefundResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// RefundReceiptSuccessResponse wraps RefundReceiptResponse in the standard API envelope.
+type RefundReceiptSuccessResponse struct {
+	Data  RefundReceiptResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// AuditEntriesSuccessResponse wraps a list of AuditEntryResponse in the standard API envelope.
+type AuditEntriesSuccessResponse struct {
+	Data  []AuditEntryResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// InvoiceSuccessResponse wraps InvoiceResponse in the standard API envelope.
+type InvoiceSuccessResponse struct {
+	Data  InvoiceResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// InvoicesSuccessResponse wraps a list of InvoiceResponse in the standard API envelope.
+type InvoicesSuccessResponse struct {
+	Data  []InvoiceResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// TaxRateSuccessResponse wraps TaxRateResponse in the standard API envelope.
+type TaxRateSuccessResponse struct {
+	Data  TaxRateResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// TaxRatesSuccessResponse wraps a list of TaxRateResponse in the standard API envelope.
+type TaxRatesSuccessResponse struct {
+	Data  []TaxRateResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// ProductTaxCategorySuccessResponse wraps ProductTaxCategoryResponse in the standard API envelope.
+type ProductTaxCategorySuccessResponse struct {
+	Data  ProductTaxCategoryResponse `json:"data"`
+	Error *string                    `json:"error"`
+}
+
+// CartQuoteSuccessResponse wraps CartQuoteResponse in the standard API envelope.
+type CartQuoteSuccessResponse struct {
+	Data  CartQuoteResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// PromotionSuccessResponse wraps PromotionResponse in the standard API envelope.
+type PromotionSuccessResponse struct {
+	Data  PromotionResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// PromotionsSuccessResponse wraps a list of PromotionResponse in the standard API envelope.
+type PromotionsSuccessResponse struct {
+	Data  []PromotionResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// PromotionProductSuccessResponse wraps PromotionProductResponse in the standard API envelope.
+type PromotionProductSuccessResponse struct {
+	Data  PromotionProductResponse `json:"data"`
+	Error *string                  `json:"error"`
+}
+
+// LoyaltyRulesSuccessResponse wraps LoyaltyRulesResponse in the standard API envelope.
+type LoyaltyRulesSuccessResponse struct {
+	Data  LoyaltyRulesResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// LoyaltyProductBonusSuccessResponse wraps LoyaltyProductBonusResponse in the standard API envelope.
+type LoyaltyProductBonusSuccessResponse struct {
+	Data  LoyaltyProductBonusResponse `json:"data"`
+	Error *string                     `json:"error"`
+}
+
+// LoyaltyAccountSuccessResponse wraps LoyaltyAccountResponse in the standard API envelope.
+type LoyaltyAccountSuccessResponse struct {
+	Data  LoyaltyAccountResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// LoyaltyEntrySuccessResponse wraps LoyaltyEntryResponse in the standard API envelope.
+type LoyaltyEntrySuccessResponse struct {
+	Data  LoyaltyEntryResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// LoyaltyBalanceSuccessResponse wraps LoyaltyBalanceResponse in the standard API envelope.
+type LoyaltyBalanceSuccessResponse struct {
+	Data  LoyaltyBalanceResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// BirthdaySuccessResponse wraps BirthdayResponse in the standard API envelope.
+type BirthdaySuccessResponse struct {
+	Data  BirthdayResponse `json:"data"`
+	Error *string          `json:"error"`
+}
+
+// LoyaltyExpirySuccessResponse wraps LoyaltyExpiryResponse in the standard API envelope.
+type LoyaltyExpirySuccessResponse struct {
+	Data  LoyaltyExpiryResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// BirthdayBonusesSuccessResponse wraps BirthdayBonusesResponse in the standard API envelope.
+type BirthdayBonusesSuccessResponse struct {
+	Data  BirthdayBonusesResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// QurbaniOfferingSuccessResponse wraps QurbaniOfferingResponse in the standard API envelope.
+type QurbaniOfferingSuccessResponse struct {
+	Data  QurbaniOfferingResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// QurbaniOfferingListSuccessResponse wraps a list of QurbaniOfferingResponse in the standard API envelope.
+type QurbaniOfferingListSuccessResponse struct {
+	Data  []QurbaniOfferingResponse `json:"data"`
+	Error *string                   `json:"error"`
+}
+
+// QurbaniBookingSuccessResponse wraps QurbaniBookingResponse in the standard API envelope.
+type QurbaniBookingSuccessResponse struct {
+	Data  QurbaniBookingResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// QurbaniBookingListSuccessResponse wraps a list of QurbaniBookingResponse in the standard API envelope.
+type QurbaniBookingListSuccessResponse struct {
+	Data  []QurbaniBookingResponse `json:"data"`
+	Error *string                  `json:"error"`
+}
+
+// QurbaniAllocationSuccessResponse wraps QurbaniAllocationResponse in the standard API envelope.
+type QurbaniAllocationSuccessResponse struct {
+	Data  QurbaniAllocationResponse `json:"data"`
+	Error *string                   `json:"error"`
+}
+
+// QurbaniBatchListSuccessResponse wraps a list of QurbaniBatchResponse in the standard API envelope.
+type QurbaniBatchListSuccessResponse struct {
+	Data  []QurbaniBatchResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// QurbaniBatchCompletionSuccessResponse wraps QurbaniBatchCompletionResponse in the standard API envelope.
+type QurbaniBatchCompletionSuccessResponse struct {
+	Data  QurbaniBatchCompletionResponse `json:"data"`
+	Error *string                        `json:"error"`
+}
+
+// QurbaniNotificationSuccessResponse wraps QurbaniNotificationResponse in the standard API envelope.
+type QurbaniNotificationSuccessResponse struct {
+	Data  QurbaniNotificationResponse `json:"data"`
+	Error *string                     `json:"error"`
+}
+
+// QurbaniAnimalListSuccessResponse wraps a list of QurbaniAnimalResponse in the standard API envelope.
+type QurbaniAnimalListSuccessResponse struct {
+	Data  []QurbaniAnimalResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// QurbaniDistributionListSuccessResponse wraps a list of QurbaniDistributionLineResponse in the standard API envelope.
+type QurbaniDistributionListSuccessResponse struct {
+	Data  []QurbaniDistributionLineResponse `json:"data"`
+	Error *string                           `json:"error"`
+}
+
+// SubscriptionBoxSuccessResponse wraps SubscriptionBoxResponse in the standard API envelope.
+type SubscriptionBoxSuccessResponse struct {
+	Data  SubscriptionBoxResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// SubscriptionBoxListSuccessResponse wraps a list of SubscriptionBoxResponse in the standard API envelope.
+type SubscriptionBoxListSuccessResponse struct {
+	Data  []SubscriptionBoxResponse `json:"data"`
+	Error *string                   `json:"error"`
+}
+
+// SubscriptionSuccessResponse wraps SubscriptionResponse in the standard API envelope.
+type SubscriptionSuccessResponse struct {
+	Data  SubscriptionResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// SubscriptionListSuccessResponse wraps a list of SubscriptionResponse in the standard API envelope.
+type SubscriptionListSuccessResponse struct {
+	Data  []SubscriptionResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// SubscriptionRenewalListSuccessResponse wraps a list of SubscriptionRenewalResponse in the standard API envelope.
+type SubscriptionRenewalListSuccessResponse struct {
+	Data  []SubscriptionRenewalResponse `json:"data"`
+	Error *string                       `json:"error"`
+}
+
+// SubscriptionRunSuccessResponse wraps SubscriptionRunResponse in the standard API envelope.
+type SubscriptionRunSuccessResponse struct {
+	Data  SubscriptionRunResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// BusinessAccountSuccessResponse wraps BusinessAccountResponse in the standard API envelope.
+type BusinessAccountSuccessResponse struct {
+	Data  BusinessAccountResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// BusinessAccountListSuccessResponse wraps a list of BusinessAccountResponse in the standard API envelope.
+type BusinessAccountListSuccessResponse struct {
+	Data  []BusinessAccountResponse `json:"data"`
+	Error *string                   `json:"error"`
+}
+
+// BusinessMembershipSuccessResponse wraps BusinessMembershipResponse in the standard API envelope.
+type BusinessMembershipSuccessResponse struct {
+	Data  BusinessMembershipResponse `json:"data"`
+	Error *string                    `json:"error"`
+}
+
+// BusinessMemberSuccessResponse wraps BusinessMemberResponse in the standard API envelope.
+type BusinessMemberSuccessResponse struct {
+	Data  BusinessMemberResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// BusinessMemberListSuccessResponse wraps a list of BusinessMemberResponse in the standard API envelope.
+type BusinessMemberListSuccessResponse struct {
+	Data  []BusinessMemberResponse `json:"data"`
+	Error *string                  `json:"error"`
+}
+
+// BusinessOrderSuccessResponse wraps BusinessOrderResponse in the standard API envelope.
+type BusinessOrderSuccessResponse struct {
+	Data  BusinessOrderResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// BusinessOrderListSuccessResponse wraps a list of BusinessOrderResponse in the standard API envelope.
+type BusinessOrderListSuccessResponse struct {
+	Data  []BusinessOrderResponse `json:"data"`
+	Error *string                 `json:"error"`
+}
+
+// BusinessEntrySuccessResponse wraps BusinessEntryResponse in the standard API envelope.
+type BusinessEntrySuccessResponse struct {
+	Data  BusinessEntryResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// BusinessStatementSuccessResponse wraps BusinessStatementResponse in the standard API envelope.
+type BusinessStatementSuccessResponse struct {
+	Data  BusinessStatementResponse `json:"data"`
+	Error *string                   `json:"error"`
+}
+
+// PLUItemSuccessResponse wraps PLUItemResponse in the standard API envelope.
+type PLUItemSuccessResponse struct {
+	Data  PLUItemResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// PLUItemListSuccessResponse wraps a list of PLUItemResponse in the standard API envelope.
+type PLUItemListSuccessResponse struct {
+	Data  []PLUItemResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// BarcodeSuccessResponse wraps BarcodeResponse in the standard API envelope.
+type BarcodeSuccessResponse struct {
+	Data  BarcodeResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// LabelPrinterSuccessResponse wraps LabelPrinterResponse in the standard API envelope.
+type LabelPrinterSuccessResponse struct {
+	Data  LabelPrinterResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// LabelPrinterListSuccessResponse wraps a list of LabelPrinterResponse in the standard API envelope.
+type LabelPrinterListSuccessResponse struct {
+	Data  []LabelPrinterResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// PrintJobSuccessResponse wraps PrintJobResponse in the standard API envelope.
+type PrintJobSuccessResponse struct {
+	Data  PrintJobResponse `json:"data"`
+	Error *string          `json:"error"`
+}
+
+// PrintJobListSuccessResponse wraps a list of PrintJobResponse in the standard API envelope.
+type PrintJobListSuccessResponse struct {
+	Data  []PrintJobResponse `json:"data"`
+	Error *string            `json:"error"`
+}
+
+// PrintDispatchSuccessResponse wraps PrintDispatchResponse in the standard API envelope.
+type PrintDispatchSuccessResponse struct {
+	Data  PrintDispatchResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// ScaleSuccessResponse wraps ScaleResponse in the standard API envelope.
+type ScaleSuccessResponse struct {
+	Data  ScaleResponse `json:"data"`
+	Error *string       `json:"error"`
+}
+
+// ScaleListSuccessResponse wraps a list of ScaleResponse in the standard API envelope.
+type ScaleListSuccessResponse struct {
+	Data  []ScaleResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// ScaleReadingSuccessResponse wraps ScaleReadingResponse in the standard API envelope.
+type ScaleReadingSuccessResponse struct {
+	Data  ScaleReadingResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// TillSuccessResponse wraps TillResponse in the standard API envelope.
+type TillSuccessResponse struct {
+	Data  TillResponse `json:"data"`
+	Error *string      `json:"error"`
+}
+
+// TillListSuccessResponse wraps a list of TillResponse in the standard API envelope.
+type TillListSuccessResponse struct {
+	Data  []TillResponse `json:"data"`
+	Error *string        `json:"error"`
+}
+
+// TillSessionSuccessResponse wraps TillSessionResponse in the standard API envelope.
+type TillSessionSuccessResponse struct {
+	Data  TillSessionResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// TillSessionListSuccessResponse wraps a list of TillSessionResponse in the standard API envelope.
+type TillSessionListSuccessResponse struct {
+	Data  []TillSessionResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// SaleSuccessResponse wraps SaleResponse in the standard API envelope.
+type SaleSuccessResponse struct {
+	Data  SaleResponse `json:"data"`
+	Error *string      `json:"error"`
+}
+
+// TillReportSuccessResponse wraps TillReportResponse in the standard API envelope.
+type TillReportSuccessResponse struct {
+	Data  TillReportResponse `json:"data"`
+	Error *string            `json:"error"`
+}
+
+// BranchSuccessResponse wraps BranchResponse in the standard API envelope.
+type BranchSuccessResponse struct {
+	Data  BranchResponse `json:"data"`
+	Error *string        `json:"error"`
+}
+
+// BranchListSuccessResponse wraps a list of BranchResponse in the standard API envelope.
+type BranchListSuccessResponse struct {
+	Data  []BranchResponse `json:"data"`
+	Error *string          `json:"error"`
+}
+
+// BranchPriceSuccessResponse wraps BranchPriceResponse in the standard API envelope.
+type BranchPriceSuccessResponse struct {
+	Data  BranchPriceResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// BranchPriceListSuccessResponse wraps a list of BranchPriceResponse in the standard API envelope.
+type BranchPriceListSuccessResponse struct {
+	Data  []BranchPriceResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// AdminSuccessResponse wraps AdminResponse in the standard API envelope.
+type AdminSuccessResponse struct {
+	Data  AdminResponse `json:"data"`
+	Error *string       `json:"error"`
+}
+
+// AdminListSuccessResponse wraps a list of AdminResponse in the standard API envelope.
+type AdminListSuccessResponse struct {
+	Data  []AdminResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// HACCPUnitSuccessResponse wraps HACCPUnitResponse in the standard API envelope.
+type HACCPUnitSuccessResponse struct {
+	Data  HACCPUnitResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// HACCPUnitListSuccessResponse wraps a list of HACCPUnitResponse in the standard API envelope.
+type HACCPUnitListSuccessResponse struct {
+	Data  []HACCPUnitResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// HACCPReadingSuccessResponse wraps HACCPReadingResponse in the standard API envelope.
+type HACCPReadingSuccessResponse struct {
+	Data  HACCPReadingResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// HACCPReadingListSuccessResponse wraps a list of HACCPReadingResponse in the standard API envelope.
+type HACCPReadingListSuccessResponse struct {
+	Data  []HACCPReadingResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// SensorReadingsSuccessResponse wraps SensorReadingsResponse in the standard API envelope.
+type SensorReadingsSuccessResponse struct {
+	Data  SensorReadingsResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// HACCPAlertListSuccessResponse wraps a list of HACCPAlertResponse in the standard API envelope.
+type HACCPAlertListSuccessResponse struct {
+	Data  []HACCPAlertResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// HACCPActionSuccessResponse wraps HACCPActionResponse in the standard API envelope.
+type HACCPActionSuccessResponse struct {
+	Data  HACCPActionResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// HACCPCheckRunSuccessResponse wraps HACCPCheckRunResponse in the standard API envelope.
+type HACCPCheckRunSuccessResponse struct {
+	Data  HACCPCheckRunResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// ExpiryScanSuccessResponse wraps ExpiryScanResponse in the standard API envelope.
+type ExpiryScanSuccessResponse struct {
+	Data  ExpiryScanResponse `json:"data"`
+	Error *string            `json:"error"`
+}
+
+// ExpiryProposalSuccessResponse wraps ExpiryProposalResponse in the standard API envelope.
+type ExpiryProposalSuccessResponse struct {
+	Data  ExpiryProposalResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// ExpiryProposalsSuccessResponse wraps a list of ExpiryProposalResponse in the standard API envelope.
+type ExpiryProposalsSuccessResponse struct {
+	Data  []ExpiryProposalResponse `json:"data"`
+	Error *string                  `json:"error"`
+}
+
+// ShrinkageSuccessResponse wraps ShrinkageResponse in the standard API envelope.
+type ShrinkageSuccessResponse struct {
+	Data  ShrinkageResponse `json:"data"`
+	Error *string           `json:"error"`
+}
+
+// StaffProfileSuccessResponse wraps StaffProfileResponse in the standard API envelope.
+type StaffProfileSuccessResponse struct {
+	Data  StaffProfileResponse `json:"data"`
+	Error *string              `json:"error"`
+}
+
+// StaffProfileListSuccessResponse wraps a list of StaffProfileResponse in the standard API envelope.
+type StaffProfileListSuccessResponse struct {
+	Data  []StaffProfileResponse `json:"data"`
+	Error *string                `json:"error"`
+}
+
+// ShiftSuccessResponse wraps ShiftResponse in the standard API envelope.
+type ShiftSuccessResponse struct {
+	Data  ShiftResponse `json:"data"`
+	Error *string       `json:"error"`
+}
+
+// RotaSuccessResponse wraps RotaResponse in the standard API envelope.
+type RotaSuccessResponse struct {
+	Data  RotaResponse `json:"data"`
+	Error *string      `json:"error"`
+}
+
+// ClockEntrySuccessResponse wraps ClockEntryResponse in the standard API envelope.
+type ClockEntrySuccessResponse struct {
+	Data  ClockEntryResponse `json:"data"`
+	Error *string            `json:"error"`
+}
+
+// TimesheetListSuccessResponse wraps a list of TimesheetResponse in the standard API envelope.
+type TimesheetListSuccessResponse struct {
+	Data  []TimesheetResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// DriverSuccessResponse wraps DriverResponse in the standard API envelope.
+type DriverSuccessResponse struct {
+	Data  DriverResponse `json:"data"`
+	Error *string        `json:"error"`
+}
+
+// DriverListSuccessResponse wraps a list of DriverResponse in the standard API envelope.
+type DriverListSuccessResponse struct {
+	Data  []DriverResponse `json:"data"`
+	Error *string          `json:"error"`
+}
+
+// DeliveryRunSuccessResponse wraps DeliveryRunResponse in the standard API envelope.
+type DeliveryRunSuccessResponse struct {
+	Data  DeliveryRunResponse `json:"data"`
+	Error *string             `json:"error"`
+}
+
+// DeliveryRunListSuccessResponse wraps a list of DeliveryRunResponse in the standard API envelope.
+type DeliveryRunListSuccessResponse struct {
+	Data  []DeliveryRunResponse `json:"data"`
+	Error *string               `json:"error"`
+}
+
+// PlanRunSuccessResponse wraps PlanRunResponse in the standard API envelope.
+type PlanRunSuccessResponse struct {
+	Data  PlanRunResponse `json:"data"`
+	Error *string         `json:"error"`
+}
+
+// OrderDeliverySuccessResponse wraps OrderDeliveryResponse in the standard API envelope.
+type OrderDeliverySuccessResponse struct {
+	Data  OrderDeliveryResponse `json:"data"`
+	Error *string               `json:"error"`
+}
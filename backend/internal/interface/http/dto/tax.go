@@ -0,0 +1,75 @@
+package dto
+
+import "time"
+
+// CreateTaxRateRequest is the request body for adding a VAT rate. The rate
+// applies to its category from effective_from until a later rate takes over.
+type CreateTaxRateRequest struct {
+	Category      string `json:"category" example:"prepared_food"`
+	RateBP        int    `json:"rate_bp" example:"2000"`
+	EffectiveFrom string `json:"effective_from" example:"2027-01-01"`
+}
+
+// TaxRateResponse is a VAT rate in the rate table.
+type TaxRateResponse struct {
+	ID            string    `json:"id"`
+	Category      string    `json:"category" example:"prepared_food"`
+	RateBP        int       `json:"rate_bp" example:"2000"`
+	EffectiveFrom string    `json:"effective_from" example:"2027-01-01"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ProductTaxCategoryRequest is the request body for setting the tax category
+// a product is sold under.
+type ProductTaxCategoryRequest struct {
+	Category string `json:"category" example:"fresh_meat"`
+}
+
+// ProductTaxCategoryResponse is the tax category a product is sold under.
+type ProductTaxCategoryResponse struct {
+	ProductID string `json:"product_id"`
+	Category  string `json:"category" example:"fresh_meat"`
+}
+
+// CartLine is one weighed product in a cart at its shelf price in cents per
+// kg.
+type CartLine struct {
+	ProductID       string `json:"product_id"`
+	Grams           int64  `json:"grams" example:"1500"`
+	PricePerKgCents int64  `json:"price_per_kg_cents" example:"2000"`
+}
+
+// CartQuoteRequest is the request body for pricing a cart. Give
+// delivery_zone_id to include the zone's delivery fee and coupons for any
+// codes the shopper has entered.
+type CartQuoteRequest struct {
+	Lines          []CartLine `json:"lines"`
+	DeliveryZoneID *string    `json:"delivery_zone_id,omitempty"`
+	Coupons        []string   `json:"coupons,omitempty" example:"LAMB10"`
+}
+
+// TaxedLineResponse is an amount split into its net value and VAT, after
+// discount_cents of promotions.
+type TaxedLineResponse struct {
+	ProductID     string `json:"product_id,omitempty"`
+	TaxCategory   string `json:"tax_category" example:"fresh_meat"`
+	DiscountCents int64  `json:"discount_cents"`
+	VATRateBP     int    `json:"vat_rate_bp"`
+	NetCents      int64  `json:"net_cents"`
+	VATCents      int64  `json:"vat_cents"`
+	GrossCents    int64  `json:"gross_cents"`
+}
+
+// CartQuoteResponse is a priced cart after promotions. Delivery is omitted
+// for collection.
+type CartQuoteResponse struct {
+	Lines           []TaxedLineResponse      `json:"lines"`
+	Delivery        *TaxedLineResponse       `json:"delivery,omitempty"`
+	VATBands        []VATBandResponse        `json:"vat_bands"`
+	Discounts       []DiscountResponse       `json:"discounts"`
+	RejectedCoupons []RejectedCouponResponse `json:"rejected_coupons"`
+	DiscountCents   int64                    `json:"discount_cents"`
+	NetCents        int64                    `json:"net_cents"`
+	VATCents        int64                    `json:"vat_cents"`
+	GrossCents      int64                    `json:"gross_cents"`
+}
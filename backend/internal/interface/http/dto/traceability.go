@@ -0,0 +1,82 @@
+package dto
+
+import "time"
+
+// RegisterCertificateRequest is the request body for registering a halal
+// slaughter certificate. document_url links to the scanned certificate.
+type RegisterCertificateRequest struct {
+	Issuer         string `json:"issuer" example:"Halal Monitoring Committee"`
+	Number         string `json:"number" example:"HMC-2026-0193"`
+	Slaughterhouse string `json:"slaughterhouse" example:"Hill Farm Abattoir"`
+	ValidFrom      string `json:"valid_from" example:"2026-01-01"`
+	ValidUntil     string `json:"valid_until" example:"2026-12-31"`
+	DocumentURL    string `json:"document_url,omitempty" example:"https://files.example.com/hmc-2026-0193.pdf"`
+}
+
+// CertificateResponse is a halal slaughter certificate. expiry is one of
+// not_yet_valid, valid, expiring or expired, and is set in listings.
+type CertificateResponse struct {
+	ID             string    `json:"id"`
+	Issuer         string    `json:"issuer"`
+	Number         string    `json:"number"`
+	Slaughterhouse string    `json:"slaughterhouse"`
+	ValidFrom      string    `json:"valid_from"`
+	ValidUntil     string    `json:"valid_until"`
+	DocumentURL    *string   `json:"document_url,omitempty"`
+	Expiry         string    `json:"expiry,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// RecordOriginRequest is the request body for recording where a lot that
+// was bought in already cut came from.
+type RecordOriginRequest struct {
+	Farm              string `json:"farm" example:"Green Valley Farm"`
+	SlaughteredOn     string `json:"slaughtered_on" example:"2026-10-12"`
+	CertificateNumber string `json:"certificate_number" example:"HMC-2026-0193"`
+}
+
+// LotOriginResponse is where the meat in a lot came from.
+type LotOriginResponse struct {
+	LotID             string    `json:"lot_id"`
+	CarcassID         *string   `json:"carcass_id,omitempty"`
+	Farm              string    `json:"farm"`
+	SlaughteredOn     string    `json:"slaughtered_on"`
+	CertificateNumber string    `json:"certificate_number"`
+	RecordedAt        time.Time `json:"recorded_at"`
+}
+
+// TraceCertificateResponse is the halal certificate a lot was slaughtered under.
+type TraceCertificateResponse struct {
+	Issuer         string  `json:"issuer"`
+	Number         string  `json:"number"`
+	Slaughterhouse string  `json:"slaughterhouse"`
+	ValidFrom      string  `json:"valid_from"`
+	ValidUntil     string  `json:"valid_until"`
+	DocumentURL    *string `json:"document_url,omitempty"`
+}
+
+// LotTraceResponse is the public farm-to-counter record of a lot.
+// halal_verified is true when the slaughter date falls within the validity
+// of a registered certificate; certificate is omitted when the certificate
+// number has not been registered.
+type LotTraceResponse struct {
+	LotCode           string                    `json:"lot_code"`
+	ProductID         string                    `json:"product_id"`
+	ReceivedAt        time.Time                 `json:"received_at"`
+	ExpiresOn         *string                   `json:"expires_on,omitempty"`
+	Species           *string                   `json:"species,omitempty"`
+	Farm              string                    `json:"farm"`
+	SlaughteredOn     string                    `json:"slaughtered_on"`
+	CertificateNumber string                    `json:"certificate_number"`
+	HalalVerified     bool                      `json:"halal_verified"`
+	Certificate       *TraceCertificateResponse `json:"certificate,omitempty"`
+}
+
+// ConsumedLotResponse is the weight of one lot picked for a line of an order.
+type ConsumedLotResponse struct {
+	LineID    string `json:"line_id"`
+	ProductID string `json:"product_id"`
+	LotID     string `json:"lot_id"`
+	LotCode   string `json:"lot_code"`
+	Grams     int64  `json:"grams"`
+}
@@ -0,0 +1,78 @@
+package dto
+
+import "time"
+
+// WasteRequest is the request body for writing stock off a lot.
+type WasteRequest struct {
+	LotID  string `json:"lot_id"`
+	Grams  int64  `json:"grams" example:"800"`
+	Reason string `json:"reason" example:"trim" enums:"trim,expired,damaged,returned"`
+}
+
+// SetUseByRequest is the request body for correcting a lot's use-by date.
+// An empty use_by clears it.
+type SetUseByRequest struct {
+	UseBy string `json:"use_by" example:"2026-11-02"`
+}
+
+// ExpiryScanResponse summarises an expiry scan: how many lots near or past
+// their use-by date it looked at and how many proposals of each kind it
+// raised.
+type ExpiryScanResponse struct {
+	Scanned   int `json:"scanned" example:"12"`
+	Markdowns int `json:"markdowns" example:"3"`
+	Waste     int `json:"waste" example:"1"`
+}
+
+// ExpiryProposalResponse is a markdown or write-off proposed for a lot near
+// or past its use-by date. on_hand_grams is what the lot held when it was
+// proposed and wasted_grams what accepting a write-off wasted.
+type ExpiryProposalResponse struct {
+	ID          string     `json:"id"`
+	LotID       string     `json:"lot_id"`
+	ProductID   string     `json:"product_id"`
+	BranchID    string     `json:"branch_id"`
+	Kind        string     `json:"kind" example:"markdown" enums:"markdown,waste"`
+	UseBy       string     `json:"use_by" example:"2026-10-20"`
+	OnHandGrams int64      `json:"on_hand_grams" example:"2400"`
+	MarkdownBP  int        `json:"markdown_bp" example:"3000"`
+	Status      string     `json:"status" example:"proposed" enums:"proposed,accepted,dismissed"`
+	WastedGrams int64      `json:"wasted_grams" example:"0"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DecidedAt   *time.Time `json:"decided_at"`
+	DecidedBy   *string    `json:"decided_by"`
+}
+
+// ShrinkageResponse breaks down stock lost over a period by product,
+// species or branch.
+type ShrinkageResponse struct {
+	From    string                  `json:"from" example:"2026-10-01"`
+	To      string                  `json:"to" example:"2026-10-31"`
+	GroupBy string                  `json:"group_by" example:"product" enums:"product,species,branch"`
+	Lines   []ShrinkageLineResponse `json:"lines"`
+	Total   ShrinkageLineResponse   `json:"total"`
+}
+
+// ShrinkageLineResponse is the stock that came in and went out for one
+// product, species or branch, named by key. Sold and wasted weights are
+// positive; cut and adjusted weights are net, negative when stock was lost.
+// net_grams is the change in stock on hand and reconciles with the ledger.
+// Shrink is what was wasted plus what stock takes found missing; shrink_bp
+// is that as a share of what was received.
+type ShrinkageLineResponse struct {
+	Key            string           `json:"key" example:"lamb"`
+	ReceivedGrams  int64            `json:"received_grams" example:"100000"`
+	CutGrams       int64            `json:"cut_grams" example:"0"`
+	SoldGrams      int64            `json:"sold_grams" example:"82000"`
+	WastedGrams    int64            `json:"wasted_grams" example:"3500"`
+	AdjustedGrams  int64            `json:"adjusted_grams" example:"-500"`
+	NetGrams       int64            `json:"net_grams" example:"14000"`
+	WastedByReason map[string]int64 `json:"wasted_by_reason"`
+	ShrinkGrams    int64            `json:"shrink_grams" example:"4000"`
+	ShrinkBP       int64            `json:"shrink_bp" example:"400"`
+	ReceivedCents  int64            `json:"received_cents" example:"125000"`
+	SoldCents      int64            `json:"sold_cents" example:"102500"`
+	WastedCents    int64            `json:"wasted_cents" example:"4375"`
+	AdjustedCents  int64            `json:"adjusted_cents" example:"-625"`
+	ShrinkCents    int64            `json:"shrink_cents" example:"5000"`
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	custcmd "github.com/katerji/butchery-app/backend/internal/application/customer/commands"
+	custqry "github.com/katerji/butchery-app/backend/internal/application/customer/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AddressHandler handles the signed-in customer's address book.
+type AddressHandler struct {
+	listHandler       *custqry.ListAddressesHandler
+	addHandler        *custcmd.AddAddressHandler
+	updateHandler     *custcmd.UpdateAddressHandler
+	deleteHandler     *custcmd.DeleteAddressHandler
+	setDefaultHandler *custcmd.SetDefaultAddressHandler
+}
+
+// NewAddressHandler creates a new AddressHandler.
+func NewAddressHandler(
+	listHandler *custqry.ListAddressesHandler,
+	addHandler *custcmd.AddAddressHandler,
+	updateHandler *custcmd.UpdateAddressHandler,
+	deleteHandler *custcmd.DeleteAddressHandler,
+	setDefaultHandler *custcmd.SetDefaultAddressHandler,
+) *AddressHandler {
+	return &AddressHandler{
+		listHandler:       listHandler,
+		addHandler:        addHandler,
+		updateHandler:     updateHandler,
+		deleteHandler:     deleteHandler,
+		setDefaultHandler: setDefaultHandler,
+	}
+}
+
+// List handles GET /api/v1/me/addresses.
+//
+//	@Summary		List my addresses
+//	@Description	List the signed-in customer's saved addresses, default first.
+//	@Tags			Addresses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.AddressesSuccessResponse	"Addresses"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/addresses [get]
+func (h *AddressHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	addresses, err := h.listHandler.Handle(r.Context(), claims.SubjectID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.AddressResponse, 0, len(addresses))
+	for _, a := range addresses {
+		resp = append(resp, toAddressResponse(a))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// Create handles POST /api/v1/me/addresses.
+//
+//	@Summary		Add an address
+//	@Description	Save a new address. The first address saved becomes the default.
+//	@Tags			Addresses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.AddressRequest				true	"Address"
+//	@Success		201		{object}	dto.AddressSuccessResponse		"Address added"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/addresses [post]
+func (h *AddressHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.AddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	a, err := h.addHandler.Handle(r.Context(), custcmd.AddAddressCommand{
+		CustomerID:  claims.SubjectID,
+		Fields:      addressFields(req),
+		MakeDefault: req.MakeDefault,
+	})
+	if err != nil {
+		writeAddressError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toAddressResponse(a))
+}
+
+// Update handles PUT /api/v1/me/addresses/{addressID}.
+//
+//	@Summary		Edit an address
+//	@Description	Replace the details of a saved address. Use the default endpoint to change the default.
+//	@Tags			Addresses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			addressID	path		string						true	"Address ID"
+//	@Param			body		body		dto.AddressRequest			true	"Address"
+//	@Success		200			{object}	dto.AddressSuccessResponse	"Address updated"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		404			{object}	dto.ErrorBody				"Address not found"
+//	@Failure		422			{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/me/addresses/{addressID} [put]
+func (h *AddressHandler) Update(w http.ResponseWriter, r *http.Request) {
+	addressID, ok := uuidParam(r, "addressID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid address id")
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	a, err := h.updateHandler.Handle(r.Context(), custcmd.UpdateAddressCommand{
+		CustomerID: claims.SubjectID,
+		AddressID:  addressID,
+		Fields:     addressFields(req),
+	})
+	if err != nil {
+		writeAddressError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toAddressResponse(a))
+}
+
+// Delete handles DELETE /api/v1/me/addresses/{addressID}.
+//
+//	@Summary		Delete an address
+//	@Description	Remove a saved address. If it was the default, the oldest remaining address becomes the default.
+//	@Tags			Addresses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			addressID	path	string	true	"Address ID"
+//	@Success		204			"Address deleted"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid address ID"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		404			{object}	dto.ErrorBody	"Address not found"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/me/addresses/{addressID} [delete]
+func (h *AddressHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	addressID, ok := uuidParam(r, "addressID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid address id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	err := h.deleteHandler.Handle(r.Context(), custcmd.DeleteAddressCommand{
+		CustomerID: claims.SubjectID,
+		AddressID:  addressID,
+	})
+	if err != nil {
+		writeAddressError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// SetDefault handles PUT /api/v1/me/addresses/{addressID}/default.
+//
+//	@Summary		Set default address
+//	@Description	Make a saved address the default for checkout.
+//	@Tags			Addresses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			addressID	path	string	true	"Address ID"
+//	@Success		204			"Default address changed"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid address ID"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		404			{object}	dto.ErrorBody	"Address not found"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/me/addresses/{addressID}/default [put]
+func (h *AddressHandler) SetDefault(w http.ResponseWriter, r *http.Request) {
+	addressID, ok := uuidParam(r, "addressID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid address id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	err := h.setDefaultHandler.Handle(r.Context(), custcmd.SetDefaultAddressCommand{
+		CustomerID: claims.SubjectID,
+		AddressID:  addressID,
+	})
+	if err != nil {
+		writeAddressError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+func addressFields(req dto.AddressRequest) custcmd.AddressFields {
+	return custcmd.AddressFields{
+		Label:     req.Label,
+		Line1:     req.Line1,
+		Line2:     req.Line2,
+		City:      req.City,
+		Postcode:  req.Postcode,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	}
+}
+
+func toAddressResponse(a *customer.Address) dto.AddressResponse {
+	resp := dto.AddressResponse{
+		ID:        a.ID().String(),
+		Label:     a.Label(),
+		Line1:     a.Line1(),
+		Line2:     a.Line2(),
+		City:      a.City(),
+		Postcode:  a.Postcode().String(),
+		IsDefault: a.IsDefault(),
+		CreatedAt: a.CreatedAt(),
+		UpdatedAt: a.UpdatedAt(),
+	}
+	if c := a.Coordinates(); c != nil {
+		lat, lng := c.Latitude(), c.Longitude()
+		resp.Latitude, resp.Longitude = &lat, &lng
+	}
+	return resp
+}
+
+func writeAddressError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customer.ErrAddressNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "address not found")
+	case errors.Is(err, customer.ErrEmptyAddressLine),
+		errors.Is(err, customer.ErrEmptyCity),
+		errors.Is(err, customer.ErrInvalidPostcode),
+		errors.Is(err, customer.ErrInvalidCoordinates):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	plucmd "github.com/katerji/butchery-app/backend/internal/application/plu/commands"
+	pluqry "github.com/katerji/butchery-app/backend/internal/application/plu/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// maxModule is the widest bar module, in pixels, a barcode image is drawn at.
+const maxModule = 10
+
+// AdminBarcodeHandler manages the PLU codes products are weighed and
+// labelled under, and reads and draws the variable-measure barcodes on
+// weighed packs.
+type AdminBarcodeHandler struct {
+	createHandler  *plucmd.CreateItemHandler
+	updateHandler  *plucmd.UpdateItemHandler
+	listHandler    *pluqry.ListItemsHandler
+	encodeHandler  *pluqry.EncodeBarcodeHandler
+	resolveHandler *pluqry.ResolveBarcodeHandler
+}
+
+// NewAdminBarcodeHandler creates a new AdminBarcodeHandler.
+func NewAdminBarcodeHandler(
+	createHandler *plucmd.CreateItemHandler,
+	updateHandler *plucmd.UpdateItemHandler,
+	listHandler *pluqry.ListItemsHandler,
+	encodeHandler *pluqry.EncodeBarcodeHandler,
+	resolveHandler *pluqry.ResolveBarcodeHandler,
+) *AdminBarcodeHandler {
+	return &AdminBarcodeHandler{
+		createHandler:  createHandler,
+		updateHandler:  updateHandler,
+		listHandler:    listHandler,
+		encodeHandler:  encodeHandler,
+		resolveHandler: resolveHandler,
+	}
+}
+
+// ListItems handles GET /api/v1/admin/plu-items.
+//
+//	@Summary		List PLU items
+//	@Description	List every PLU item in code order, withdrawn ones included.
+//	@Tags			Admin Barcodes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.PLUItemListSuccessResponse	"PLU items"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/plu-items [get]
+func (h *AdminBarcodeHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.listHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toPLUItemResponses(items))
+}
+
+// CreateItem handles POST /api/v1/admin/plu-items.
+//
+//	@Summary		Create a PLU item
+//	@Description	Give a product the PLU code scales weigh it under and barcodes carry, with its description, shelf price per kilogram, and the allergens and shelf life printed on its labels.
+//	@Tags			Admin Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.PLUItemRequest			true	"PLU item"
+//	@Success		201		{object}	dto.PLUItemSuccessResponse	"PLU item created"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody				"Code already in use"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/plu-items [post]
+func (h *AdminBarcodeHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
+	var req dto.PLUItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	i, err := h.createHandler.Handle(r.Context(), plucmd.CreateItemCommand{
+		Code:            req.Code,
+		ProductID:       productID,
+		Description:     req.Description,
+		PricePerKgCents: req.PricePerKgCents,
+		Allergens:       toAllergens(req.Allergens),
+		ShelfLifeDays:   req.ShelfLifeDays,
+	})
+	if err != nil {
+		writePLUError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toPLUItemResponse(i))
+}
+
+// UpdateItem handles PUT /api/v1/admin/plu-items/{itemID}.
+//
+//	@Summary		Update a PLU item
+//	@Description	Change a PLU item's description, price per kilogram and labelling, or withdraw it. Packs already labelled keep the price on their barcode.
+//	@Tags			Admin Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			itemID	path		string						true	"PLU item ID"
+//	@Param			body	body		dto.UpdatePLUItemRequest	true	"PLU item"
+//	@Success		200		{object}	dto.PLUItemSuccessResponse	"PLU item updated"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"PLU item not found"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/plu-items/{itemID} [put]
+func (h *AdminBarcodeHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	itemID, ok := uuidParam(r, "itemID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid item ID")
+		return
+	}
+	var req dto.UpdatePLUItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	i, err := h.updateHandler.Handle(r.Context(), plucmd.UpdateItemCommand{
+		ItemID:          itemID,
+		Description:     req.Description,
+		PricePerKgCents: req.PricePerKgCents,
+		Allergens:       toAllergens(req.Allergens),
+		ShelfLifeDays:   req.ShelfLifeDays,
+		Active:          req.Active,
+	})
+	if err != nil {
+		writePLUError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPLUItemResponse(i))
+}
+
+// Encode handles POST /api/v1/admin/plu-items/{itemID}/barcodes.
+//
+//	@Summary		Encode a pack's barcode
+//	@Description	Work out the barcode for a weighed pack of an item, embedding its weight in grams or its price at the item's current price per kilogram. The first configured layout for the measure is used.
+//	@Tags			Admin Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			itemID	path		string						true	"PLU item ID"
+//	@Param			body	body		dto.EncodeBarcodeRequest	true	"Pack"
+//	@Success		200		{object}	dto.BarcodeSuccessResponse	"Barcode"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"PLU item not found"
+//	@Failure		409		{object}	dto.ErrorBody				"PLU item withdrawn"
+//	@Failure		422		{object}	dto.ErrorBody				"Pack cannot be encoded"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/plu-items/{itemID}/barcodes [post]
+func (h *AdminBarcodeHandler) Encode(w http.ResponseWriter, r *http.Request) {
+	itemID, ok := uuidParam(r, "itemID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid item ID")
+		return
+	}
+	var req dto.EncodeBarcodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	measure := barcode.Measure(req.Measure)
+	if measure != barcode.Weight && measure != barcode.Price {
+		httpresponse.Error(w, http.StatusBadRequest, "measure must be weight or price")
+		return
+	}
+
+	scan, err := h.encodeHandler.Handle(r.Context(), itemID, req.Grams, measure)
+	if err != nil {
+		writePLUError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBarcodeResponse(scan))
+}
+
+// Resolve handles GET /api/v1/admin/barcodes/{code}.
+//
+//	@Summary		Resolve a scanned barcode
+//	@Description	Read a scanned variable-measure barcode as the item, weight and price of the pack. The weight of a price-embedded pack is worked back from the item's current price per kilogram.
+//	@Tags			Admin Barcodes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			code	path		string						true	"EAN-13 code"
+//	@Success		200		{object}	dto.BarcodeSuccessResponse	"Pack"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"No item has the code's PLU"
+//	@Failure		422		{object}	dto.ErrorBody				"Not a valid variable-measure code"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/barcodes/{code} [get]
+func (h *AdminBarcodeHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	scan, err := h.resolveHandler.Handle(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		writePLUError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBarcodeResponse(scan))
+}
+
+// Image handles GET /api/v1/admin/barcodes/{code}/image.
+//
+//	@Summary		Draw a barcode
+//	@Description	Draw any valid EAN-13 code as an SVG with its digits, or as a PNG of the bars alone for label printers.
+//	@Tags			Admin Barcodes
+//	@Produce		image/svg+xml
+//	@Produce		image/png
+//	@Security		BearerAuth
+//	@Param			code	path		string			true	"EAN-13 code"
+//	@Param			format	query		string			false	"svg or png (default svg)"
+//	@Param			module	query		int				false	"Bar module width in pixels, 1-10 (default 2)"
+//	@Success		200		{file}		file			"Barcode image"
+//	@Failure		400		{object}	dto.ErrorBody	"Invalid format or module"
+//	@Failure		401		{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody	"Not a valid EAN-13 code"
+//	@Router			/admin/barcodes/{code}/image [get]
+func (h *AdminBarcodeHandler) Image(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	module := 2
+	if raw := q.Get("module"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxModule {
+			httpresponse.Error(w, http.StatusBadRequest, "module must be between 1 and 10")
+			return
+		}
+		module = n
+	}
+
+	code := chi.URLParam(r, "code")
+	var (
+		img         []byte
+		contentType string
+		err         error
+	)
+	switch q.Get("format") {
+	case "", "svg":
+		img, err = barcode.SVG(code, module)
+		contentType = "image/svg+xml"
+	case "png":
+		img, err = barcode.PNG(code, module)
+		contentType = "image/png"
+	default:
+		httpresponse.Error(w, http.StatusBadRequest, "format must be svg or png")
+		return
+	}
+	if err != nil {
+		writePLUError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(img)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(img)
+}
+
+func toPLUItemResponse(i *plu.Item) dto.PLUItemResponse {
+	return dto.PLUItemResponse{
+		ID:              i.ID().String(),
+		Code:            i.Code(),
+		ProductID:       i.ProductID().String(),
+		Description:     i.Description(),
+		PricePerKgCents: i.PricePerKgCents(),
+		Allergens:       allergenNames(i.Allergens()),
+		ShelfLifeDays:   i.ShelfLifeDays(),
+		Active:          i.Active(),
+		CreatedAt:       i.CreatedAt(),
+		UpdatedAt:       i.UpdatedAt(),
+	}
+}
+
+func toAllergens(names []string) []plu.Allergen {
+	allergens := make([]plu.Allergen, 0, len(names))
+	for _, n := range names {
+		allergens = append(allergens, plu.Allergen(n))
+	}
+	return allergens
+}
+
+func allergenNames(allergens []plu.Allergen) []string {
+	names := make([]string, 0, len(allergens))
+	for _, a := range allergens {
+		names = append(names, string(a))
+	}
+	return names
+}
+
+func toPLUItemResponses(items []*plu.Item) []dto.PLUItemResponse {
+	resp := make([]dto.PLUItemResponse, 0, len(items))
+	for _, i := range items {
+		resp = append(resp, toPLUItemResponse(i))
+	}
+	return resp
+}
+
+func toBarcodeResponse(s *pluqry.Scan) dto.BarcodeResponse {
+	return dto.BarcodeResponse{
+		Code:       s.Code.Code,
+		Layout:     s.Code.Layout.String(),
+		Measure:    string(s.Code.Measure),
+		Item:       toPLUItemResponse(s.Item),
+		Grams:      s.Pack.Grams,
+		PriceCents: s.Pack.PriceCents,
+	}
+}
+
+func writePLUError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, plu.ErrItemNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, plu.ErrDuplicateCode),
+		errors.Is(err, plu.ErrItemInactive):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, plu.ErrInvalidCode),
+		errors.Is(err, plu.ErrProductRequired),
+		errors.Is(err, plu.ErrEmptyDescription),
+		errors.Is(err, plu.ErrNegativePrice),
+		errors.Is(err, plu.ErrInvalidWeight),
+		errors.Is(err, plu.ErrUnknownAllergen),
+		errors.Is(err, plu.ErrNegativeShelfLife),
+		errors.Is(err, barcode.ErrInvalidCode),
+		errors.Is(err, barcode.ErrCheckDigit),
+		errors.Is(err, barcode.ErrPriceCheckDigit),
+		errors.Is(err, barcode.ErrNoLayout),
+		errors.Is(err, barcode.ErrNoLayoutFor),
+		errors.Is(err, barcode.ErrItemTooLarge),
+		errors.Is(err, barcode.ErrValueTooLarge):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,486 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	branchcmd "github.com/katerji/butchery-app/backend/internal/application/branch/commands"
+	branchqry "github.com/katerji/butchery-app/backend/internal/application/branch/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminBranchHandler manages the branches, their own prices and which
+// branches each admin works at.
+type AdminBranchHandler struct {
+	createHandler      *branchcmd.CreateBranchHandler
+	updateHandler      *branchcmd.UpdateBranchHandler
+	setPriceHandler    *branchcmd.SetPriceHandler
+	deletePriceHandler *branchcmd.DeletePriceHandler
+	assignHandler      *branchcmd.AssignAdminBranchesHandler
+	listHandler        *branchqry.ListBranchesHandler
+	getHandler         *branchqry.GetBranchHandler
+	listPricesHandler  *branchqry.ListPricesHandler
+	listAdminsHandler  *branchqry.ListAdminsHandler
+}
+
+// NewAdminBranchHandler creates a new AdminBranchHandler.
+func NewAdminBranchHandler(
+	createHandler *branchcmd.CreateBranchHandler,
+	updateHandler *branchcmd.UpdateBranchHandler,
+	setPriceHandler *branchcmd.SetPriceHandler,
+	deletePriceHandler *branchcmd.DeletePriceHandler,
+	assignHandler *branchcmd.AssignAdminBranchesHandler,
+	listHandler *branchqry.ListBranchesHandler,
+	getHandler *branchqry.GetBranchHandler,
+	listPricesHandler *branchqry.ListPricesHandler,
+	listAdminsHandler *branchqry.ListAdminsHandler,
+) *AdminBranchHandler {
+	return &AdminBranchHandler{
+		createHandler:      createHandler,
+		updateHandler:      updateHandler,
+		setPriceHandler:    setPriceHandler,
+		deletePriceHandler: deletePriceHandler,
+		assignHandler:      assignHandler,
+		listHandler:        listHandler,
+		getHandler:         getHandler,
+		listPricesHandler:  listPricesHandler,
+		listAdminsHandler:  listAdminsHandler,
+	}
+}
+
+// ListBranches handles GET /api/v1/admin/branches.
+//
+//	@Summary		List branches
+//	@Description	List the branches the admin works at by name, closed ones included if asked for.
+//	@Tags			Admin Branches
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			include_inactive	query		bool							false	"Include closed branches"
+//	@Success		200					{object}	dto.BranchListSuccessResponse	"Branches"
+//	@Failure		400					{object}	dto.ErrorBody					"Invalid include_inactive"
+//	@Failure		401					{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403					{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500					{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/branches [get]
+func (h *AdminBranchHandler) ListBranches(w http.ResponseWriter, r *http.Request) {
+	includeInactive := false
+	if raw := r.URL.Query().Get("include_inactive"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid include_inactive")
+			return
+		}
+		includeInactive = b
+	}
+
+	branches, err := h.listHandler.Handle(r.Context(), includeInactive)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.BranchResponse, 0, len(branches))
+	for _, b := range branches {
+		resp = append(resp, toBranchResponse(b, nil))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateBranch handles POST /api/v1/admin/branches.
+//
+//	@Summary		Open a branch
+//	@Description	Open a branch. Only managers of every branch may. Its trading hours are set as its opening hours.
+//	@Tags			Admin Branches
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.BranchRequest			true	"Branch"
+//	@Success		201		{object}	dto.BranchSuccessResponse	"Branch opened"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager of every branch"
+//	@Failure		409		{object}	dto.ErrorBody				"Name already in use"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/branches [post]
+func (h *AdminBranchHandler) CreateBranch(w http.ResponseWriter, r *http.Request) {
+	var req dto.BranchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	b, err := h.createHandler.Handle(r.Context(), branchcmd.CreateBranchCommand{
+		Name:    req.Name,
+		Address: req.Address,
+		Phone:   req.Phone,
+		Email:   req.Email,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toBranchResponse(b, nil))
+}
+
+// GetBranch handles GET /api/v1/admin/branches/{branchID}.
+//
+//	@Summary		Get a branch
+//	@Description	Get a branch with its opening hours.
+//	@Tags			Admin Branches
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branchID	path		string						true	"Branch ID"
+//	@Success		200			{object}	dto.BranchSuccessResponse	"Branch"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid branch ID"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody				"Branch not found"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/branches/{branchID} [get]
+func (h *AdminBranchHandler) GetBranch(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	details, err := h.getHandler.Handle(r.Context(), branchID)
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBranchResponse(details.Branch, details.Hours))
+}
+
+// UpdateBranch handles PUT /api/v1/admin/branches/{branchID}.
+//
+//	@Summary		Update a branch
+//	@Description	Change a branch's details, or close it to customers.
+//	@Tags			Admin Branches
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branchID	path		string						true	"Branch ID"
+//	@Param			body		body		dto.UpdateBranchRequest		true	"Branch"
+//	@Success		200			{object}	dto.BranchSuccessResponse	"Branch updated"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Not a manager"
+//	@Failure		404			{object}	dto.ErrorBody				"Branch not found"
+//	@Failure		409			{object}	dto.ErrorBody				"Name already in use"
+//	@Failure		422			{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/branches/{branchID} [put]
+func (h *AdminBranchHandler) UpdateBranch(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+	var req dto.UpdateBranchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	b, err := h.updateHandler.Handle(r.Context(), branchcmd.UpdateBranchCommand{
+		BranchID: branchID,
+		Name:     req.Name,
+		Address:  req.Address,
+		Phone:    req.Phone,
+		Email:    req.Email,
+		Active:   req.Active,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBranchResponse(b, nil))
+}
+
+// ListPrices handles GET /api/v1/admin/branches/{branchID}/prices.
+//
+//	@Summary		List a branch's prices
+//	@Description	List the products a branch charges its own price for.
+//	@Tags			Admin Branches
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branchID	path		string								true	"Branch ID"
+//	@Success		200			{object}	dto.BranchPriceListSuccessResponse	"Prices"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid branch ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Branch not found"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/branches/{branchID}/prices [get]
+func (h *AdminBranchHandler) ListPrices(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	prices, err := h.listPricesHandler.Handle(r.Context(), branchID)
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	resp := make([]dto.BranchPriceResponse, 0, len(prices))
+	for _, p := range prices {
+		resp = append(resp, toBranchPriceResponse(p))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// SetPrice handles PUT /api/v1/admin/branches/{branchID}/prices/{productID}.
+//
+//	@Summary		Set a branch's price
+//	@Description	Set what a branch charges a kilogram for a product in place of its usual price. Weighed items at the till and orders taken for the branch are charged it; labelled packs keep the price on their barcode.
+//	@Tags			Admin Branches
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branchID	path		string							true	"Branch ID"
+//	@Param			productID	path		string							true	"Product ID"
+//	@Param			body		body		dto.BranchPriceRequest			true	"Price"
+//	@Success		200			{object}	dto.BranchPriceSuccessResponse	"Price set"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		404			{object}	dto.ErrorBody					"Branch not found"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/branches/{branchID}/prices/{productID} [put]
+func (h *AdminBranchHandler) SetPrice(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+	productID, ok := uuidParam(r, "productID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	var req dto.BranchPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	p, err := h.setPriceHandler.Handle(r.Context(), branchcmd.SetPriceCommand{
+		BranchID:        branchID,
+		ProductID:       productID,
+		PricePerKgCents: req.PricePerKgCents,
+		ActorID:         middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBranchPriceResponse(p))
+}
+
+// DeletePrice handles DELETE /api/v1/admin/branches/{branchID}/prices/{productID}.
+//
+//	@Summary		Remove a branch's price
+//	@Description	Put a product back on its usual price at a branch.
+//	@Tags			Admin Branches
+//	@Security		BearerAuth
+//	@Param			branchID	path	string	true	"Branch ID"
+//	@Param			productID	path	string	true	"Product ID"
+//	@Success		204			"Price removed"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Not a manager"
+//	@Failure		404			{object}	dto.ErrorBody	"Branch or price not found"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/branches/{branchID}/prices/{productID} [delete]
+func (h *AdminBranchHandler) DeletePrice(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+	productID, ok := uuidParam(r, "productID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	err := h.deletePriceHandler.Handle(r.Context(), branchcmd.DeletePriceCommand{
+		BranchID:  branchID,
+		ProductID: productID,
+		ActorID:   middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// ListAdmins handles GET /api/v1/admin/admins.
+//
+//	@Summary		List admins
+//	@Description	List the admins working at the branches the admin works at, by name.
+//	@Tags			Admin Branches
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.AdminListSuccessResponse	"Admins"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/admins [get]
+func (h *AdminBranchHandler) ListAdmins(w http.ResponseWriter, r *http.Request) {
+	admins, err := h.listAdminsHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.AdminResponse, 0, len(admins))
+	for _, a := range admins {
+		resp = append(resp, toAdminResponse(a))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// AssignBranches handles PUT /api/v1/admin/admins/{adminID}/branches.
+//
+//	@Summary		Assign an admin's branches
+//	@Description	Set the branches an admin works at; they see and change only those branches' stock, slots, tills and orders. Managers may only hand out branches they work at themselves.
+//	@Tags			Admin Branches
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			adminID	path		string						true	"Admin ID"
+//	@Param			body	body		dto.AdminBranchesRequest	true	"Branches"
+//	@Success		200		{object}	dto.AdminSuccessResponse	"Branches assigned"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager, or branches beyond the manager's own"
+//	@Failure		404		{object}	dto.ErrorBody				"Admin or branch not found"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/admins/{adminID}/branches [put]
+func (h *AdminBranchHandler) AssignBranches(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := uuidParam(r, "adminID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid admin ID")
+		return
+	}
+	var req dto.AdminBranchesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchIDs := make([]uuid.UUID, 0, len(req.BranchIDs))
+	for _, raw := range req.BranchIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+			return
+		}
+		branchIDs = append(branchIDs, id)
+	}
+
+	a, err := h.assignHandler.Handle(r.Context(), branchcmd.AssignAdminBranchesCommand{
+		AdminID:     adminID,
+		AllBranches: req.AllBranches,
+		BranchIDs:   branchIDs,
+		ActorID:     middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toAdminResponse(a))
+}
+
+func toBranchResponse(b *branch.Branch, hours []*fulfilment.OpeningHours) dto.BranchResponse {
+	resp := dto.BranchResponse{
+		ID:        b.ID().String(),
+		Name:      b.Name(),
+		Address:   b.Address(),
+		Phone:     b.Phone(),
+		Email:     b.Email(),
+		Active:    b.Active(),
+		CreatedAt: b.CreatedAt(),
+		UpdatedAt: b.UpdatedAt(),
+	}
+	for _, oh := range hours {
+		resp.Hours = append(resp.Hours, dto.OpeningHoursDay{
+			Weekday:  int(oh.Weekday()),
+			OpensAt:  oh.OpensAt().String(),
+			ClosesAt: oh.ClosesAt().String(),
+		})
+	}
+	return resp
+}
+
+func toBranchPriceResponse(p *branch.PriceOverride) dto.BranchPriceResponse {
+	return dto.BranchPriceResponse{
+		BranchID:        p.BranchID().String(),
+		ProductID:       p.ProductID().String(),
+		PricePerKgCents: p.PricePerKgCents(),
+		UpdatedAt:       p.UpdatedAt(),
+	}
+}
+
+func toAdminResponse(a *admin.Admin) dto.AdminResponse {
+	branchIDs := make([]string, 0, len(a.BranchIDs()))
+	for _, id := range a.BranchIDs() {
+		branchIDs = append(branchIDs, id.String())
+	}
+	return dto.AdminResponse{
+		ID:          a.ID().String(),
+		Email:       a.Email(),
+		FullName:    a.FullName(),
+		Role:        string(a.Role()),
+		AllBranches: a.AllBranches(),
+		BranchIDs:   branchIDs,
+	}
+}
+
+// writeBranchError maps branch domain errors to HTTP responses.
+func writeBranchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrBranchNotFound),
+		errors.Is(err, branch.ErrPriceNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, admin.ErrAdminNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "admin not found")
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, branch.ErrManageNotAllowed):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, branch.ErrDuplicateName):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, branch.ErrEmptyName),
+		errors.Is(err, branch.ErrEmptyAddress),
+		errors.Is(err, branch.ErrInvalidEmail),
+		errors.Is(err, branch.ErrProductRequired),
+		errors.Is(err, branch.ErrNegativePrice):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
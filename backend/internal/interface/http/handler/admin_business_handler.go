@@ -0,0 +1,499 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	businesscmd "github.com/katerji/butchery-app/backend/internal/application/business/commands"
+	businessqry "github.com/katerji/butchery-app/backend/internal/application/business/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminBusinessHandler manages wholesale business accounts: their details
+// and credit terms, price lists, who may order for them, and the payments
+// they send in.
+type AdminBusinessHandler struct {
+	createHandler     *businesscmd.CreateAccountHandler
+	updateHandler     *businesscmd.UpdateAccountHandler
+	pricesHandler     *businesscmd.SetPricesHandler
+	listHandler       *businessqry.ListAccountsHandler
+	getHandler        *businessqry.GetAccountHandler
+	membersHandler    *businessqry.ListMembersHandler
+	saveMemberHandler *businesscmd.SaveMemberHandler
+	removeHandler     *businesscmd.RemoveMemberHandler
+	paymentHandler    *businesscmd.RecordPaymentHandler
+	ordersHandler     *businessqry.ListOrdersHandler
+	statementHandler  *businessqry.StatementHandler
+}
+
+// NewAdminBusinessHandler creates a new AdminBusinessHandler.
+func NewAdminBusinessHandler(
+	createHandler *businesscmd.CreateAccountHandler,
+	updateHandler *businesscmd.UpdateAccountHandler,
+	pricesHandler *businesscmd.SetPricesHandler,
+	listHandler *businessqry.ListAccountsHandler,
+	getHandler *businessqry.GetAccountHandler,
+	membersHandler *businessqry.ListMembersHandler,
+	saveMemberHandler *businesscmd.SaveMemberHandler,
+	removeHandler *businesscmd.RemoveMemberHandler,
+	paymentHandler *businesscmd.RecordPaymentHandler,
+	ordersHandler *businessqry.ListOrdersHandler,
+	statementHandler *businessqry.StatementHandler,
+) *AdminBusinessHandler {
+	return &AdminBusinessHandler{
+		createHandler:     createHandler,
+		updateHandler:     updateHandler,
+		pricesHandler:     pricesHandler,
+		listHandler:       listHandler,
+		getHandler:        getHandler,
+		membersHandler:    membersHandler,
+		saveMemberHandler: saveMemberHandler,
+		removeHandler:     removeHandler,
+		paymentHandler:    paymentHandler,
+		ordersHandler:     ordersHandler,
+		statementHandler:  statementHandler,
+	}
+}
+
+// List handles GET /api/v1/admin/business-accounts.
+//
+//	@Summary		List business accounts
+//	@Description	List every business account by name with what it owes and how much credit it has left.
+//	@Tags			Admin Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.BusinessAccountListSuccessResponse	"Business accounts"
+//	@Failure		401	{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/business-accounts [get]
+func (h *AdminBusinessHandler) List(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.listHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toBusinessAccountResponses(accounts))
+}
+
+// Create handles POST /api/v1/admin/business-accounts.
+//
+//	@Summary		Open a business account
+//	@Description	Open a wholesale account for a business with its company and billing details, credit limit, payment terms and the order total above which orders need an approver.
+//	@Tags			Admin Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.BusinessAccountRequest			true	"Business account"
+//	@Success		201		{object}	dto.BusinessAccountSuccessResponse	"Account opened"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/business-accounts [post]
+func (h *AdminBusinessHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.BusinessAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	details, terms := toBusinessDetailsAndTerms(req)
+	a, err := h.createHandler.Handle(r.Context(), businesscmd.CreateAccountCommand{Details: details, Terms: terms})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toBusinessAccountResponse(a))
+}
+
+// Get handles GET /api/v1/admin/business-accounts/{accountID}.
+//
+//	@Summary		Get a business account
+//	@Description	Get a business account with its price list and balance.
+//	@Tags			Admin Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string								true	"Business account ID"
+//	@Success		200			{object}	dto.BusinessAccountSuccessResponse	"Business account"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid account ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Account not found"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/business-accounts/{accountID} [get]
+func (h *AdminBusinessHandler) Get(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	a, err := h.getHandler.Handle(r.Context(), accountID)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessAccountResponse(a))
+}
+
+// Update handles PUT /api/v1/admin/business-accounts/{accountID}.
+//
+//	@Summary		Update a business account
+//	@Description	Change a business account's details and credit terms, or put it on hold. Lowering the credit limit below what is owed stops new orders until payments come in.
+//	@Tags			Admin Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string								true	"Business account ID"
+//	@Param			body		body		dto.UpdateBusinessAccountRequest	true	"Business account"
+//	@Success		200			{object}	dto.BusinessAccountSuccessResponse	"Account updated"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Account not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Changed concurrently"
+//	@Failure		422			{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/business-accounts/{accountID} [put]
+func (h *AdminBusinessHandler) Update(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	var req dto.UpdateBusinessAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	details, terms := toBusinessDetailsAndTerms(req.BusinessAccountRequest)
+	a, err := h.updateHandler.Handle(r.Context(), businesscmd.UpdateAccountCommand{
+		AccountID: accountID,
+		Details:   details,
+		Terms:     terms,
+		Active:    req.Active,
+	})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessAccountResponse(a))
+}
+
+// SetPrices handles PUT /api/v1/admin/business-accounts/{accountID}/prices.
+//
+//	@Summary		Set a business's price list
+//	@Description	Replace the products a business can order and what it pays for them. Each product has volume tiers: the price per kilogram for a line of at least each weight, the first from zero grams. Orders already placed keep their prices.
+//	@Tags			Admin Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string								true	"Business account ID"
+//	@Param			body		body		dto.SetBusinessPricesRequest		true	"Price list"
+//	@Success		200			{object}	dto.BusinessAccountSuccessResponse	"Price list set"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Account not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Changed concurrently"
+//	@Failure		422			{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/prices [put]
+func (h *AdminBusinessHandler) SetPrices(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	var req dto.SetBusinessPricesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	prices := make([]business.Price, 0, len(req.Prices))
+	for _, p := range req.Prices {
+		productID, err := uuid.Parse(p.ProductID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+			return
+		}
+		tiers := make([]business.Tier, 0, len(p.Tiers))
+		for _, t := range p.Tiers {
+			tiers = append(tiers, business.Tier{MinGrams: t.MinGrams, PricePerKgCents: t.PricePerKgCents})
+		}
+		prices = append(prices, business.Price{ProductID: productID, Description: p.Description, Tiers: tiers})
+	}
+
+	a, err := h.pricesHandler.Handle(r.Context(), businesscmd.SetPricesCommand{AccountID: accountID, Prices: prices})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessAccountResponse(a))
+}
+
+// ListMembers handles GET /api/v1/admin/business-accounts/{accountID}/members.
+//
+//	@Summary		List a business's members
+//	@Description	List the customers who order for a business and whether they are buyers or approvers.
+//	@Tags			Admin Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string									true	"Business account ID"
+//	@Success		200			{object}	dto.BusinessMemberListSuccessResponse	"Members"
+//	@Failure		400			{object}	dto.ErrorBody							"Invalid account ID"
+//	@Failure		401			{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody							"Account not found"
+//	@Failure		500			{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/members [get]
+func (h *AdminBusinessHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	members, err := h.membersHandler.Handle(r.Context(), accountID)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessMemberResponses(members))
+}
+
+// SaveMember handles PUT /api/v1/admin/business-accounts/{accountID}/members/{customerID}.
+//
+//	@Summary		Add or change a business member
+//	@Description	Let a customer order for a business as a buyer, or as an approver who can also sign off orders over the approval threshold. A customer can belong to one business only.
+//	@Tags			Admin Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string								true	"Business account ID"
+//	@Param			customerID	path		string								true	"Customer ID"
+//	@Param			body		body		dto.BusinessMemberRequest			true	"Role"
+//	@Success		200			{object}	dto.BusinessMemberSuccessResponse	"Member saved"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Account or customer not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Member of another business"
+//	@Failure		422			{object}	dto.ErrorBody						"Invalid role"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/members/{customerID} [put]
+func (h *AdminBusinessHandler) SaveMember(w http.ResponseWriter, r *http.Request) {
+	accountID, customerID, ok := businessMemberParams(w, r)
+	if !ok {
+		return
+	}
+	var req dto.BusinessMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	role, err := business.NewRole(req.Role)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	m, err := h.saveMemberHandler.Handle(r.Context(), businesscmd.SaveMemberCommand{
+		AccountID:  accountID,
+		CustomerID: customerID,
+		Role:       role,
+	})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessMemberResponse(m))
+}
+
+// RemoveMember handles DELETE /api/v1/admin/business-accounts/{accountID}/members/{customerID}.
+//
+//	@Summary		Remove a business member
+//	@Description	Stop a customer ordering for a business. Orders they already placed are unaffected.
+//	@Tags			Admin Business
+//	@Security		BearerAuth
+//	@Param			accountID	path	string	true	"Business account ID"
+//	@Param			customerID	path	string	true	"Customer ID"
+//	@Success		204			"Member removed"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid ID"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody	"Not a member of the business"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/members/{customerID} [delete]
+func (h *AdminBusinessHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	accountID, customerID, ok := businessMemberParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.removeHandler.Handle(r.Context(), accountID, customerID); err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// RecordPayment handles POST /api/v1/admin/business-accounts/{accountID}/payments.
+//
+//	@Summary		Record a payment
+//	@Description	Take a payment received from a business, e.g. a bank transfer, off what it owes. Payments settle the oldest charges first.
+//	@Tags			Admin Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string								true	"Business account ID"
+//	@Param			body		body		dto.BusinessPaymentRequest			true	"Payment"
+//	@Success		201			{object}	dto.BusinessEntrySuccessResponse	"Payment recorded"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Account not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Changed concurrently"
+//	@Failure		422			{object}	dto.ErrorBody						"Invalid amount"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/payments [post]
+func (h *AdminBusinessHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	var req dto.BusinessPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	e, err := h.paymentHandler.Handle(r.Context(), businesscmd.RecordPaymentCommand{
+		AccountID:   accountID,
+		AmountCents: req.AmountCents,
+		Reference:   req.Reference,
+	})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toBusinessEntryResponse(e))
+}
+
+// ListOrders handles GET /api/v1/admin/business-accounts/{accountID}/orders.
+//
+//	@Summary		List a business's orders
+//	@Description	List the orders placed on a business's account, newest first.
+//	@Tags			Admin Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string									true	"Business account ID"
+//	@Param			status		query		string									false	"pending_approval, placed or rejected"
+//	@Success		200			{object}	dto.BusinessOrderListSuccessResponse	"Orders"
+//	@Failure		400			{object}	dto.ErrorBody							"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/orders [get]
+func (h *AdminBusinessHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	filter, ok := businessOrderFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.AccountID = &accountID
+
+	orders, err := h.ordersHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toBusinessOrderResponses(orders))
+}
+
+// Statement handles GET /api/v1/admin/business-accounts/{accountID}/statement.
+//
+//	@Summary		Get a business's statement
+//	@Description	Get a business's monthly statement to send to its billing email: the balance brought forward, each charge and payment, what is owed at the end of the month and how much of it is overdue.
+//	@Tags			Admin Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			accountID	path		string									true	"Business account ID"
+//	@Param			month		query		string									false	"Month (YYYY-MM, default this month)"
+//	@Success		200			{object}	dto.BusinessStatementSuccessResponse	"Statement"
+//	@Failure		400			{object}	dto.ErrorBody							"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody							"Account not found"
+//	@Failure		500			{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/business-accounts/{accountID}/statement [get]
+func (h *AdminBusinessHandler) Statement(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	month, ok := statementMonthParam(w, r)
+	if !ok {
+		return
+	}
+
+	s, err := h.statementHandler.Handle(r.Context(), accountID, month)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessStatementResponse(s))
+}
+
+// businessMemberParams reads the account and customer IDs of a member
+// route, writing a 400 if either is invalid.
+func businessMemberParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	accountID, ok := uuidParam(r, "accountID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid account ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	customerID, ok := uuidParam(r, "customerID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid customer ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return accountID, customerID, true
+}
+
+func toBusinessDetailsAndTerms(req dto.BusinessAccountRequest) (business.Details, business.Terms) {
+	return business.Details{
+		Name:           req.Name,
+		CompanyNumber:  req.CompanyNumber,
+		VATNumber:      req.VATNumber,
+		BillingEmail:   req.BillingEmail,
+		BillingAddress: req.BillingAddress,
+	}, business.Terms{
+		CreditLimitCents:       req.CreditLimitCents,
+		TermsDays:              req.TermsDays,
+		ApprovalThresholdCents: req.ApprovalThresholdCents,
+	}
+}
@@ -0,0 +1,465 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	carcmd "github.com/katerji/butchery-app/backend/internal/application/carcass/commands"
+	carqry "github.com/katerji/butchery-app/backend/internal/application/carcass/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminCarcassHandler handles the cutting room: carcass intake, breakdowns
+// into retail cuts and yield reporting.
+type AdminCarcassHandler struct {
+	intakeHandler      *carcmd.RecordIntakeHandler
+	breakdownHandler   *carcmd.RecordBreakdownHandler
+	setTemplateHandler *carcmd.SetYieldTemplateHandler
+	listHandler        *carqry.ListCarcassesHandler
+	yieldReportHandler *carqry.YieldReportHandler
+	templatesHandler   *carqry.ListYieldTemplatesHandler
+}
+
+// NewAdminCarcassHandler creates a new AdminCarcassHandler.
+func NewAdminCarcassHandler(
+	intakeHandler *carcmd.RecordIntakeHandler,
+	breakdownHandler *carcmd.RecordBreakdownHandler,
+	setTemplateHandler *carcmd.SetYieldTemplateHandler,
+	listHandler *carqry.ListCarcassesHandler,
+	yieldReportHandler *carqry.YieldReportHandler,
+	templatesHandler *carqry.ListYieldTemplatesHandler,
+) *AdminCarcassHandler {
+	return &AdminCarcassHandler{
+		intakeHandler:      intakeHandler,
+		breakdownHandler:   breakdownHandler,
+		setTemplateHandler: setTemplateHandler,
+		listHandler:        listHandler,
+		yieldReportHandler: yieldReportHandler,
+		templatesHandler:   templatesHandler,
+	}
+}
+
+// RecordIntake handles POST /api/v1/admin/carcasses.
+//
+//	@Summary		Record a carcass intake
+//	@Description	Book a carcass into the cutting room with its hanging weight in grams, cost in cents, supplier, slaughter date and halal certificate reference.
+//	@Tags			Admin Carcasses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.RecordCarcassRequest	true	"Carcass"
+//	@Success		201		{object}	dto.CarcassSuccessResponse	"Carcass recorded"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody				"Tag already in use"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/carcasses [post]
+func (h *AdminCarcassHandler) RecordIntake(w http.ResponseWriter, r *http.Request) {
+	var req dto.RecordCarcassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+
+	c, err := h.intakeHandler.Handle(r.Context(), carcmd.RecordIntakeCommand{
+		BranchID:            branchID,
+		Tag:                 req.Tag,
+		Species:             req.Species,
+		HangingWeightGrams:  req.HangingWeightGrams,
+		CostCents:           req.CostCents,
+		Supplier:            req.Supplier,
+		SlaughteredOn:       req.SlaughteredOn,
+		HalalCertificateRef: req.HalalCertificateRef,
+	})
+	if err != nil {
+		writeCarcassError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toCarcassResponse(c))
+}
+
+// ListCarcasses handles GET /api/v1/admin/carcasses.
+//
+//	@Summary		List carcasses
+//	@Description	List carcasses, newest first.
+//	@Tags			Admin Carcasses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			species		query		string	false	"Species"
+//	@Param			branch_id	query		string	false	"Branch ID"
+//	@Param			status		query		string	false	"Status (received or broken_down)"
+//	@Success		200			{object}	dto.CarcassesSuccessResponse	"Carcasses"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/carcasses [get]
+func (h *AdminCarcassHandler) ListCarcasses(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var filter carcass.Filter
+	if raw := q.Get("species"); raw != "" {
+		species, err := carcass.NewSpecies(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid species")
+			return
+		}
+		filter.Species = &species
+	}
+	var ok bool
+	if filter.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	if raw := q.Get("status"); raw != "" {
+		status := carcass.Status(raw)
+		if status != carcass.StatusReceived && status != carcass.StatusBrokenDown {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		filter.Status = &status
+	}
+
+	carcasses, err := h.listHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.CarcassResponse, 0, len(carcasses))
+	for _, c := range carcasses {
+		resp = append(resp, toCarcassResponse(c))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// RecordBreakdown handles POST /api/v1/admin/carcasses/{carcassID}/breakdown.
+//
+//	@Summary		Record a carcass breakdown
+//	@Description	Record the cuts weighed off a carcass along with its trim and waste. Each cut is received into inventory as a lot coded with the carcass tag. A carcass can only be broken down once.
+//	@Tags			Admin Carcasses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			carcassID	path		string						true	"Carcass ID"
+//	@Param			body		body		dto.RecordBreakdownRequest	true	"Breakdown"
+//	@Success		201			{object}	dto.BreakdownSuccessResponse	"Breakdown recorded"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Carcass not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Carcass already broken down"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/carcasses/{carcassID}/breakdown [post]
+func (h *AdminCarcassHandler) RecordBreakdown(w http.ResponseWriter, r *http.Request) {
+	carcassID, ok := uuidParam(r, "carcassID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid carcass id")
+		return
+	}
+
+	var req dto.RecordBreakdownRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cuts := make([]carcmd.CutInput, 0, len(req.Cuts))
+	for _, c := range req.Cuts {
+		productID, err := uuid.Parse(c.ProductID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+			return
+		}
+		cuts = append(cuts, carcmd.CutInput{
+			Primal:    c.Primal,
+			ProductID: productID,
+			Grams:     c.Grams,
+			ExpiresOn: c.ExpiresOn,
+		})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	b, err := h.breakdownHandler.Handle(r.Context(), carcmd.RecordBreakdownCommand{
+		CarcassID:  carcassID,
+		Cuts:       cuts,
+		TrimGrams:  req.TrimGrams,
+		WasteGrams: req.WasteGrams,
+		ActorID:    claims.SubjectID,
+	})
+	if err != nil {
+		writeCarcassError(w, err)
+		return
+	}
+
+	resp := dto.BreakdownResponse{
+		CarcassID:  b.CarcassID().String(),
+		Cuts:       make([]dto.BreakdownCutResponse, 0, len(b.Cuts())),
+		TrimGrams:  b.TrimGrams(),
+		WasteGrams: b.WasteGrams(),
+		RecordedAt: b.RecordedAt(),
+	}
+	for _, c := range b.Cuts() {
+		var expiresOn *string
+		if c.ExpiresOn != nil {
+			s := c.ExpiresOn.Format(time.DateOnly)
+			expiresOn = &s
+		}
+		resp.Cuts = append(resp.Cuts, dto.BreakdownCutResponse{
+			LotID:     c.LotID.String(),
+			Primal:    c.Primal,
+			ProductID: c.ProductID.String(),
+			Grams:     c.Grams,
+			ExpiresOn: expiresOn,
+		})
+	}
+	httpresponse.Created(w, resp)
+}
+
+// YieldReport handles GET /api/v1/admin/carcasses/{carcassID}/yield.
+//
+//	@Summary		Get a carcass yield report
+//	@Description	Report the yield, trim, waste and shrink of a broken-down carcass as percentages of its hanging weight, compared with the species' yield template, and the cost and cost per kg of each cut.
+//	@Tags			Admin Carcasses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			carcassID	path		string							true	"Carcass ID"
+//	@Success		200			{object}	dto.YieldReportSuccessResponse	"Yield report"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid carcass ID"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Carcass not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Carcass not broken down yet"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/carcasses/{carcassID}/yield [get]
+func (h *AdminCarcassHandler) YieldReport(w http.ResponseWriter, r *http.Request) {
+	carcassID, ok := uuidParam(r, "carcassID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid carcass id")
+		return
+	}
+
+	report, err := h.yieldReportHandler.Handle(r.Context(), carcassID)
+	if err != nil {
+		writeCarcassError(w, err)
+		return
+	}
+
+	resp := dto.YieldReportResponse{
+		CarcassID:              report.CarcassID.String(),
+		Species:                report.Species.String(),
+		HangingGrams:           report.HangingGrams,
+		SaleableGrams:          report.SaleableGrams,
+		TrimGrams:              report.TrimGrams,
+		WasteGrams:             report.WasteGrams,
+		ShrinkGrams:            report.ShrinkGrams,
+		YieldPercent:           bpToPercent(report.YieldBP),
+		TrimPercent:            bpToPercent(report.TrimBP),
+		WastePercent:           bpToPercent(report.WasteBP),
+		ShrinkPercent:          bpToPercent(report.ShrinkBP),
+		CostCents:              report.CostCents,
+		CostPerSaleableKgCents: report.CostPerSaleableKgCents,
+		Cuts:                   make([]dto.CutYieldResponse, 0, len(report.Cuts)),
+		Primals:                make([]dto.PrimalYieldReportResponse, 0, len(report.Primals)),
+	}
+	if report.Expected != nil {
+		resp.Expected = &dto.ExpectedYieldResponse{
+			YieldPercent: bpToPercent(report.Expected.YieldBP),
+			TrimPercent:  bpToPercent(report.Expected.TrimBP),
+			WastePercent: bpToPercent(report.Expected.WasteBP),
+		}
+	}
+	for _, c := range report.Cuts {
+		resp.Cuts = append(resp.Cuts, dto.CutYieldResponse{
+			LotID:          c.LotID.String(),
+			ProductID:      c.ProductID.String(),
+			Primal:         c.Primal,
+			Grams:          c.Grams,
+			YieldPercent:   bpToPercent(c.YieldBP),
+			CostCents:      c.CostCents,
+			CostPerKgCents: c.CostPerKgCents,
+		})
+	}
+	for _, p := range report.Primals {
+		var expected *float64
+		if p.ExpectedBP != nil {
+			e := bpToPercent(*p.ExpectedBP)
+			expected = &e
+		}
+		resp.Primals = append(resp.Primals, dto.PrimalYieldReportResponse{
+			Name:            p.Name,
+			Grams:           p.Grams,
+			YieldPercent:    bpToPercent(p.YieldBP),
+			ExpectedPercent: expected,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+// ListYieldTemplates handles GET /api/v1/admin/yield-templates.
+//
+//	@Summary		List yield templates
+//	@Description	List what each species is expected to break down into.
+//	@Tags			Admin Carcasses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.YieldTemplatesSuccessResponse	"Yield templates"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/yield-templates [get]
+func (h *AdminCarcassHandler) ListYieldTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templatesHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.YieldTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, toYieldTemplateResponse(t))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// SetYieldTemplate handles PUT /api/v1/admin/yield-templates/{species}.
+//
+//	@Summary		Set a yield template
+//	@Description	Replace what a species is expected to break down into. Percentages are of the hanging weight and are held to two decimal places.
+//	@Tags			Admin Carcasses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			species	path		string							true	"Species"
+//	@Param			body	body		dto.SetYieldTemplateRequest		true	"Template"
+//	@Success		200		{object}	dto.YieldTemplateSuccessResponse	"Template set"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/yield-templates/{species} [put]
+func (h *AdminCarcassHandler) SetYieldTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.SetYieldTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	primals := make([]carcass.PrimalYield, 0, len(req.Primals))
+	for _, p := range req.Primals {
+		primals = append(primals, carcass.PrimalYield{
+			Name:        p.Name,
+			ExpectedBP:  percentToBP(p.ExpectedPercent),
+			ValueFactor: p.ValueFactor,
+		})
+	}
+
+	tmpl, err := h.setTemplateHandler.Handle(r.Context(), carcmd.SetYieldTemplateCommand{
+		Species:         chi.URLParam(r, "species"),
+		ExpectedYieldBP: percentToBP(req.YieldPercent),
+		ExpectedTrimBP:  percentToBP(req.TrimPercent),
+		ExpectedWasteBP: percentToBP(req.WastePercent),
+		Primals:         primals,
+	})
+	if err != nil {
+		writeCarcassError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toYieldTemplateResponse(tmpl))
+}
+
+func writeCarcassError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, carcass.ErrCarcassNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "carcass not found")
+	case errors.Is(err, carcass.ErrDuplicateTag),
+		errors.Is(err, carcass.ErrAlreadyBrokenDown),
+		errors.Is(err, carcass.ErrNotBrokenDown):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, carcass.ErrInvalidSpecies),
+		errors.Is(err, carcass.ErrEmptyTag),
+		errors.Is(err, carcass.ErrEmptySupplier),
+		errors.Is(err, carcass.ErrEmptyHalalCertificate),
+		errors.Is(err, carcass.ErrInvalidWeight),
+		errors.Is(err, carcass.ErrNegativeWeight),
+		errors.Is(err, carcass.ErrNegativeCost),
+		errors.Is(err, carcass.ErrSlaughterDateInFuture),
+		errors.Is(err, carcass.ErrInvalidDate),
+		errors.Is(err, carcass.ErrEmptyPrimal),
+		errors.Is(err, carcass.ErrNoCuts),
+		errors.Is(err, carcass.ErrBreakdownExceedsCarcass),
+		errors.Is(err, carcass.ErrInvalidPercentage),
+		errors.Is(err, carcass.ErrInvalidYieldTemplate),
+		errors.Is(err, carcass.ErrInvalidPrimalYields),
+		errors.Is(err, carcass.ErrDuplicatePrimal),
+		errors.Is(err, carcass.ErrInvalidValueFactor):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func toCarcassResponse(c *carcass.Carcass) dto.CarcassResponse {
+	return dto.CarcassResponse{
+		ID:                  c.ID().String(),
+		BranchID:            c.BranchID().String(),
+		Tag:                 c.Tag(),
+		Species:             c.Species().String(),
+		HangingWeightGrams:  c.HangingWeightGrams(),
+		CostCents:           c.CostCents(),
+		Supplier:            c.Supplier(),
+		SlaughteredOn:       c.SlaughteredOn().Format(time.DateOnly),
+		HalalCertificateRef: c.HalalCertificateRef(),
+		Status:              string(c.Status()),
+		ReceivedAt:          c.ReceivedAt(),
+	}
+}
+
+func toYieldTemplateResponse(t *carcass.YieldTemplate) dto.YieldTemplateResponse {
+	primals := make([]dto.PrimalYieldTemplate, 0, len(t.Primals()))
+	for _, p := range t.Primals() {
+		primals = append(primals, dto.PrimalYieldTemplate{
+			Name:            p.Name,
+			ExpectedPercent: bpToPercent(p.ExpectedBP),
+			ValueFactor:     p.ValueFactor,
+		})
+	}
+	return dto.YieldTemplateResponse{
+		Species:      t.Species().String(),
+		YieldPercent: bpToPercent(t.ExpectedYieldBP()),
+		TrimPercent:  bpToPercent(t.ExpectedTrimBP()),
+		WastePercent: bpToPercent(t.ExpectedWasteBP()),
+		Primals:      primals,
+		UpdatedAt:    t.UpdatedAt(),
+	}
+}
+
+// percentToBP converts a percentage from the API into basis points.
+func percentToBP(p float64) int64 {
+	return int64(math.Round(p * 100))
+}
+
+// bpToPercent converts basis points into a percentage for the API.
+func bpToPercent(bp int64) float64 {
+	return float64(bp) / 100
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	delivcmd "github.com/katerji/butchery-app/backend/internal/application/delivery/commands"
+	delivqry "github.com/katerji/butchery-app/backend/internal/application/delivery/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminDeliveryHandler handles back-office management of delivery zones.
+type AdminDeliveryHandler struct {
+	listHandler   *delivqry.ListZonesHandler
+	createHandler *delivcmd.CreateZoneHandler
+	updateHandler *delivcmd.UpdateZoneHandler
+}
+
+// NewAdminDeliveryHandler creates a new AdminDeliveryHandler.
+func NewAdminDeliveryHandler(
+	listHandler *delivqry.ListZonesHandler,
+	createHandler *delivcmd.CreateZoneHandler,
+	updateHandler *delivcmd.UpdateZoneHandler,
+) *AdminDeliveryHandler {
+	return &AdminDeliveryHandler{
+		listHandler:   listHandler,
+		createHandler: createHandler,
+		updateHandler: updateHandler,
+	}
+}
+
+// ListZones handles GET /api/v1/admin/delivery-zones.
+//
+//	@Summary		List delivery zones
+//	@Description	List all delivery zones, including inactive ones, ordered by name.
+//	@Tags			Admin Delivery
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.DeliveryZonesSuccessResponse	"Delivery zones"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/delivery-zones [get]
+func (h *AdminDeliveryHandler) ListZones(w http.ResponseWriter, r *http.Request) {
+	zones, err := h.listHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.DeliveryZoneResponse, 0, len(zones))
+	for _, z := range zones {
+		resp = append(resp, toDeliveryZoneResponse(z))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateZone handles POST /api/v1/admin/delivery-zones.
+//
+//	@Summary		Create delivery zone
+//	@Description	Create a delivery zone from a GeoJSON polygon, a list of postcode prefixes, or both. Where zones overlap, a polygon match beats a postcode match and a longer prefix beats a shorter one.
+//	@Tags			Admin Delivery
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.DeliveryZoneRequest				true	"Delivery zone"
+//	@Success		201		{object}	dto.DeliveryZoneSuccessResponse		"Zone created"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/delivery-zones [post]
+func (h *AdminDeliveryHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	var req dto.DeliveryZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	z, err := h.createHandler.Handle(r.Context(), zoneFields(req))
+	if err != nil {
+		writeDeliveryZoneError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toDeliveryZoneResponse(z))
+}
+
+// UpdateZone handles PUT /api/v1/admin/delivery-zones/{zoneID}.
+//
+//	@Summary		Update delivery zone
+//	@Description	Replace a delivery zone's area, postcodes and pricing. Omitting active leaves the zone active.
+//	@Tags			Admin Delivery
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			zoneID	path		string								true	"Zone ID"
+//	@Param			body	body		dto.DeliveryZoneRequest				true	"Delivery zone"
+//	@Success		200		{object}	dto.DeliveryZoneSuccessResponse		"Zone updated"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Zone not found"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/delivery-zones/{zoneID} [put]
+func (h *AdminDeliveryHandler) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	zoneID, ok := uuidParam(r, "zoneID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid zone id")
+		return
+	}
+
+	var req dto.DeliveryZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	active := req.Active == nil || *req.Active
+	z, err := h.updateHandler.Handle(r.Context(), delivcmd.UpdateZoneCommand{
+		ZoneID: zoneID,
+		Fields: zoneFields(req),
+		Active: active,
+	})
+	if err != nil {
+		writeDeliveryZoneError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryZoneResponse(z))
+}
+
+func zoneFields(req dto.DeliveryZoneRequest) delivcmd.ZoneFields {
+	return delivcmd.ZoneFields{
+		Name:              req.Name,
+		Area:              req.Area,
+		PostcodePrefixes:  req.PostcodePrefixes,
+		DeliveryFeeCents:  req.DeliveryFeeCents,
+		MinimumOrderCents: req.MinimumOrderCents,
+		FeeTaxCategory:    req.FeeTaxCategory,
+	}
+}
+
+func toDeliveryZoneResponse(z *delivery.Zone) dto.DeliveryZoneResponse {
+	resp := dto.DeliveryZoneResponse{
+		ID:                z.ID().String(),
+		Name:              z.Name(),
+		PostcodePrefixes:  z.PostcodePrefixes(),
+		DeliveryFeeCents:  z.DeliveryFeeCents(),
+		MinimumOrderCents: z.MinimumOrderCents(),
+		FeeTaxCategory:    z.FeeTaxCategory(),
+		Active:            z.IsActive(),
+	}
+	if z.Area() != nil {
+		resp.Area = z.Area().GeoJSON()
+	}
+	return resp
+}
+
+func writeDeliveryZoneError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, delivery.ErrZoneNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "delivery zone not found")
+	case errors.Is(err, delivery.ErrEmptyZoneName),
+		errors.Is(err, delivery.ErrMissingZoneArea),
+		errors.Is(err, delivery.ErrInvalidGeoJSON),
+		errors.Is(err, delivery.ErrInvalidPolygon),
+		errors.Is(err, delivery.ErrInvalidPostcodePrefix),
+		errors.Is(err, delivery.ErrNegativeDeliveryFee),
+		errors.Is(err, delivery.ErrNegativeMinimumOrder),
+		errors.Is(err, delivery.ErrEmptyTaxCategory),
+		errors.Is(err, tax.ErrInvalidCategory):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,722 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	dispatchcmd "github.com/katerji/butchery-app/backend/internal/application/dispatch/commands"
+	dispatchqry "github.com/katerji/butchery-app/backend/internal/application/dispatch/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminDispatchHandler takes on delivery drivers and batches the day's
+// delivery orders into routed runs for them.
+type AdminDispatchHandler struct {
+	createDriverHandler *dispatchcmd.CreateDriverHandler
+	updateDriverHandler *dispatchcmd.UpdateDriverHandler
+	planRunHandler      *dispatchcmd.PlanRunHandler
+	addStopHandler      *dispatchcmd.AddStopHandler
+	removeStopHandler   *dispatchcmd.RemoveStopHandler
+	optimiseRunHandler  *dispatchcmd.OptimiseRunHandler
+	assignDriverHandler *dispatchcmd.AssignDriverHandler
+	listDriversHandler  *dispatchqry.ListDriversHandler
+	listRunsHandler     *dispatchqry.ListRunsHandler
+	getRunHandler       *dispatchqry.GetRunHandler
+	getProofHandler     *dispatchqry.GetProofHandler
+}
+
+// NewAdminDispatchHandler creates a new AdminDispatchHandler.
+func NewAdminDispatchHandler(
+	createDriverHandler *dispatchcmd.CreateDriverHandler,
+	updateDriverHandler *dispatchcmd.UpdateDriverHandler,
+	planRunHandler *dispatchcmd.PlanRunHandler,
+	addStopHandler *dispatchcmd.AddStopHandler,
+	removeStopHandler *dispatchcmd.RemoveStopHandler,
+	optimiseRunHandler *dispatchcmd.OptimiseRunHandler,
+	assignDriverHandler *dispatchcmd.AssignDriverHandler,
+	listDriversHandler *dispatchqry.ListDriversHandler,
+	listRunsHandler *dispatchqry.ListRunsHandler,
+	getRunHandler *dispatchqry.GetRunHandler,
+	getProofHandler *dispatchqry.GetProofHandler,
+) *AdminDispatchHandler {
+	return &AdminDispatchHandler{
+		createDriverHandler: createDriverHandler,
+		updateDriverHandler: updateDriverHandler,
+		planRunHandler:      planRunHandler,
+		addStopHandler:      addStopHandler,
+		removeStopHandler:   removeStopHandler,
+		optimiseRunHandler:  optimiseRunHandler,
+		assignDriverHandler: assignDriverHandler,
+		listDriversHandler:  listDriversHandler,
+		listRunsHandler:     listRunsHandler,
+		getRunHandler:       getRunHandler,
+		getProofHandler:     getProofHandler,
+	}
+}
+
+// ListDrivers handles GET /api/v1/admin/drivers.
+//
+//	@Summary		List drivers
+//	@Description	List the delivery drivers by name, those no longer active included. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string							false	"Only drivers based at this branch"
+//	@Success		200			{object}	dto.DriverListSuccessResponse	"Drivers"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid branch ID"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/drivers [get]
+func (h *AdminDispatchHandler) ListDrivers(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := optionalUUID(r.URL.Query().Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+
+	drivers, err := h.listDriversHandler.Handle(r.Context(), dispatchqry.ListDriversQuery{
+		BranchID: branchID,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	resp := make([]dto.DriverResponse, 0, len(drivers))
+	for _, d := range drivers {
+		resp = append(resp, toDriverResponse(d))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateDriver handles POST /api/v1/admin/drivers.
+//
+//	@Summary		Take on a driver
+//	@Description	Create an admin account with the driver role for a delivery driver, based at a branch. Drivers sign in through the admin login and see only the runs they are given. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.DriverRequest			true	"Driver"
+//	@Success		201		{object}	dto.DriverSuccessResponse	"Driver created"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager, or branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody				"Branch not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Email already taken"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/drivers [post]
+func (h *AdminDispatchHandler) CreateDriver(w http.ResponseWriter, r *http.Request) {
+	var req dto.DriverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	d, err := h.createDriverHandler.Handle(r.Context(), dispatchcmd.CreateDriverCommand{
+		Email:    req.Email,
+		Password: req.Password,
+		FullName: req.FullName,
+		BranchID: branchID,
+		Phone:    req.Phone,
+		Vehicle:  req.Vehicle,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toDriverResponse(d))
+}
+
+// UpdateDriver handles PUT /api/v1/admin/drivers/{adminID}.
+//
+//	@Summary		Update a driver
+//	@Description	Change where a driver is based, their phone and vehicle, or stop them being given runs. Runs already assigned are not changed. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			adminID	path		string						true	"Driver's admin ID"
+//	@Param			body	body		dto.UpdateDriverRequest		true	"Driver"
+//	@Success		200		{object}	dto.DriverSuccessResponse	"Driver updated"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager, or branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody				"Driver or branch not found"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/drivers/{adminID} [put]
+func (h *AdminDispatchHandler) UpdateDriver(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := uuidParam(r, "adminID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid admin ID")
+		return
+	}
+	var req dto.UpdateDriverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	d, err := h.updateDriverHandler.Handle(r.Context(), dispatchcmd.UpdateDriverCommand{
+		AdminID:  adminID,
+		BranchID: branchID,
+		Phone:    req.Phone,
+		Vehicle:  req.Vehicle,
+		Active:   req.Active,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDriverResponse(d))
+}
+
+// ListRuns handles GET /api/v1/admin/dispatch/runs.
+//
+//	@Summary		List delivery runs
+//	@Description	List the delivery runs for slots on a day by slot start, with their stops. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			date		query		string								false	"Day, YYYY-MM-DD (default today)"
+//	@Param			branch_id	query		string								false	"Only runs from this branch"
+//	@Param			driver_id	query		string								false	"Only runs given to this driver"
+//	@Param			status		query		string								false	"planned, started or completed"
+//	@Success		200			{object}	dto.DeliveryRunListSuccessResponse	"Runs"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Not a manager"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/dispatch/runs [get]
+func (h *AdminDispatchHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	date, ok := runDate(w, r)
+	if !ok {
+		return
+	}
+	query := dispatchqry.ListRunsQuery{Date: date, ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID}
+	if query.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	if query.DriverID, ok = optionalUUID(q.Get("driver_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid driver_id")
+		return
+	}
+	if raw := q.Get("status"); raw != "" {
+		status := dispatch.RunStatus(raw)
+		switch status {
+		case dispatch.RunPlanned, dispatch.RunStarted, dispatch.RunCompleted:
+			query.Status = &status
+		default:
+			httpresponse.Error(w, http.StatusBadRequest, "status must be planned, started or completed")
+			return
+		}
+	}
+
+	runs, err := h.listRunsHandler.Handle(r.Context(), query)
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponses(runs))
+}
+
+// PlanRun handles POST /api/v1/admin/dispatch/runs.
+//
+//	@Summary		Plan a delivery run
+//	@Description	Batch the confirmed delivery orders booked into a zone's slot at a branch, and not already on a run, into one run, routed nearest-neighbour then improved with 2-opt over the customers' stored coordinates. Orders go to the customer's default address; those whose customer has no address are returned rather than routed. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.PlanRunRequest			true	"Zone and slot"
+//	@Success		201		{object}	dto.PlanRunSuccessResponse	"Run planned"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager, or branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody				"Zone or driver not found"
+//	@Failure		409		{object}	dto.ErrorBody				"No orders waiting, or driver inactive"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/dispatch/runs [post]
+func (h *AdminDispatchHandler) PlanRun(w http.ResponseWriter, r *http.Request) {
+	var req dto.PlanRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+	zoneID, err := uuid.Parse(req.ZoneID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid zone ID")
+		return
+	}
+	driverID, ok := optionalUUID(req.DriverID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid driver ID")
+		return
+	}
+	if (req.StartLatitude == nil) != (req.StartLongitude == nil) {
+		httpresponse.Error(w, http.StatusBadRequest, "start_latitude and start_longitude must be given together")
+		return
+	}
+
+	cmd := dispatchcmd.PlanRunCommand{
+		BranchID:     branchID,
+		ZoneID:       zoneID,
+		SlotStartsAt: req.SlotStartsAt,
+		DriverID:     driverID,
+		ActorID:      middleware.ClaimsFromContext(r.Context()).SubjectID,
+	}
+	if req.StartLatitude != nil {
+		cmd.Start = &delivery.Point{Latitude: *req.StartLatitude, Longitude: *req.StartLongitude}
+	}
+	result, err := h.planRunHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	unaddressed := make([]string, 0, len(result.Unaddressed))
+	for _, id := range result.Unaddressed {
+		unaddressed = append(unaddressed, id.String())
+	}
+	httpresponse.Created(w, dto.PlanRunResponse{
+		Run:                 toDeliveryRunResponse(result.Run),
+		UnaddressedOrderIDs: unaddressed,
+	})
+}
+
+// GetRun handles GET /api/v1/admin/dispatch/runs/{runID}.
+//
+//	@Summary		Get a delivery run
+//	@Description	Get a delivery run with its stops in route order. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Run"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid run ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody					"Run not found"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/dispatch/runs/{runID} [get]
+func (h *AdminDispatchHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+
+	run, err := h.getRunHandler.Handle(r.Context(), dispatchqry.GetRunQuery{
+		RunID:   runID,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// AddStop handles POST /api/v1/admin/dispatch/runs/{runID}/stops.
+//
+//	@Summary		Add an order to a run
+//	@Description	Add a delivery order for the run's branch to the end of a planned run, at the customer's default address or another of theirs. Optimise the run again to route it. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Param			body	body		dto.AddStopRequest				true	"Order"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Order added"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody					"Run, order or address not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Run already started, order already routed or run changed"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/dispatch/runs/{runID}/stops [post]
+func (h *AdminDispatchHandler) AddStop(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+	var req dto.AddStopRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	orderID, err := uuid.Parse(req.OrderID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	addressID, ok := optionalUUID(req.AddressID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid address ID")
+		return
+	}
+
+	run, err := h.addStopHandler.Handle(r.Context(), dispatchcmd.AddStopCommand{
+		RunID:     runID,
+		OrderID:   orderID,
+		AddressID: addressID,
+		ActorID:   middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// RemoveStop handles DELETE /api/v1/admin/dispatch/runs/{runID}/stops/{stopID}.
+//
+//	@Summary		Take an order off a run
+//	@Description	Take a stop off a planned run; the order can then be planned into another. The stops after it move up. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Param			stopID	path		string							true	"Stop ID"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Stop removed"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody					"Run or stop not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Run already started or changed"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/dispatch/runs/{runID}/stops/{stopID} [delete]
+func (h *AdminDispatchHandler) RemoveStop(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+	stopID, ok := uuidParam(r, "stopID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid stop ID")
+		return
+	}
+
+	run, err := h.removeStopHandler.Handle(r.Context(), dispatchcmd.RemoveStopCommand{
+		RunID:   runID,
+		StopID:  stopID,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// OptimiseRun handles POST /api/v1/admin/dispatch/runs/{runID}/optimise.
+//
+//	@Summary		Re-route a run
+//	@Description	Reorder a planned run's stops nearest-neighbour from its start, then improve the route with 2-opt. Stops whose address has no coordinates go last. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Run re-routed"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid run ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody					"Run not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Run already started or changed"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/dispatch/runs/{runID}/optimise [post]
+func (h *AdminDispatchHandler) OptimiseRun(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+
+	run, err := h.optimiseRunHandler.Handle(r.Context(), dispatchcmd.OptimiseRunCommand{
+		RunID:   runID,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// AssignDriver handles PUT /api/v1/admin/dispatch/runs/{runID}/driver.
+//
+//	@Summary		Give a run to a driver
+//	@Description	Give a planned run to an active driver based at the run's branch, in place of any driver it had. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Param			body	body		dto.AssignDriverRequest			true	"Driver"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Driver assigned"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody					"Run or driver not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Run already started, driver inactive or run changed"
+//	@Failure		422		{object}	dto.ErrorBody					"Driver based at another branch"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/dispatch/runs/{runID}/driver [put]
+func (h *AdminDispatchHandler) AssignDriver(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+	var req dto.AssignDriverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	driverID, err := uuid.Parse(req.DriverID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid driver ID")
+		return
+	}
+
+	run, err := h.assignDriverHandler.Handle(r.Context(), dispatchcmd.AssignDriverCommand{
+		RunID:    runID,
+		DriverID: driverID,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// Proof handles GET /api/v1/admin/dispatch/stops/{stopID}/proofs/{kind}.
+//
+//	@Summary		Show a proof of delivery
+//	@Description	Show the photo or signature a driver uploaded for a stop. Needs the dispatch_deliveries permission.
+//	@Tags			Admin Dispatch
+//	@Produce		image/jpeg
+//	@Produce		image/png
+//	@Produce		image/webp
+//	@Security		BearerAuth
+//	@Param			stopID	path		string			true	"Stop ID"
+//	@Param			kind	path		string			true	"photo or signature"
+//	@Success		200		{file}		file			"Proof image"
+//	@Failure		400		{object}	dto.ErrorBody	"Invalid stop ID"
+//	@Failure		401		{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody	"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody	"Proof not found"
+//	@Failure		422		{object}	dto.ErrorBody	"Invalid proof kind"
+//	@Failure		500		{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/dispatch/stops/{stopID}/proofs/{kind} [get]
+func (h *AdminDispatchHandler) Proof(w http.ResponseWriter, r *http.Request) {
+	stopID, ok := uuidParam(r, "stopID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid stop ID")
+		return
+	}
+	kind, err := dispatch.ParseProofKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	p, err := h.getProofHandler.Handle(r.Context(), dispatchqry.GetProofQuery{
+		StopID:  stopID,
+		Kind:    kind,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", p.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", string(kind)+"-"+stopID.String()))
+	w.Header().Set("Content-Length", strconv.Itoa(len(p.Data())))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(p.Data())
+}
+
+// runDate reads the day wanted from the date query parameter, today if it
+// is not given, writing a 400 if it is invalid.
+func runDate(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	raw := r.URL.Query().Get("date")
+	if raw == "" {
+		return time.Now(), true
+	}
+	date, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "date must be a YYYY-MM-DD date")
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func toDriverResponse(d *dispatch.Driver) dto.DriverResponse {
+	return dto.DriverResponse{
+		AdminID:   d.AdminID().String(),
+		FullName:  d.FullName(),
+		BranchID:  d.BranchID().String(),
+		Phone:     d.Phone(),
+		Vehicle:   d.Vehicle(),
+		Active:    d.Active(),
+		CreatedAt: d.CreatedAt(),
+		UpdatedAt: d.UpdatedAt(),
+	}
+}
+
+func toDeliveryRunResponse(run *dispatch.Run) dto.DeliveryRunResponse {
+	stops := make([]dto.DeliveryStopResponse, 0, len(run.Stops()))
+	for _, s := range run.Stops() {
+		stops = append(stops, toDeliveryStopResponse(s))
+	}
+	resp := dto.DeliveryRunResponse{
+		ID:             run.ID().String(),
+		BranchID:       run.BranchID().String(),
+		ZoneID:         run.ZoneID().String(),
+		SlotStartsAt:   run.SlotStartsAt(),
+		SlotEndsAt:     run.SlotEndsAt(),
+		DriverID:       uuidString(run.DriverID()),
+		Status:         string(run.Status()),
+		DistanceMetres: run.DistanceMetres(),
+		Stops:          stops,
+		CreatedBy:      run.CreatedBy().String(),
+		Version:        run.Version(),
+		CreatedAt:      run.CreatedAt(),
+		UpdatedAt:      run.UpdatedAt(),
+		StartedAt:      run.StartedAt(),
+		CompletedAt:    run.CompletedAt(),
+	}
+	if p := run.Start(); p != nil {
+		resp.StartLatitude, resp.StartLongitude = &p.Latitude, &p.Longitude
+	}
+	return resp
+}
+
+func toDeliveryStopResponse(s *dispatch.Stop) dto.DeliveryStopResponse {
+	proofs := make([]string, 0, len(s.Proofs()))
+	for _, k := range s.Proofs() {
+		proofs = append(proofs, string(k))
+	}
+	resp := dto.DeliveryStopResponse{
+		ID:            s.ID().String(),
+		OrderID:       s.OrderID().String(),
+		CustomerID:    s.CustomerID().String(),
+		AddressID:     uuidString(s.AddressID()),
+		AddressLines:  s.AddressLines(),
+		Postcode:      s.Postcode(),
+		Sequence:      s.Sequence(),
+		Status:        string(s.Status()),
+		RecipientName: s.RecipientName(),
+		FailureReason: s.FailureReason(),
+		Proofs:        proofs,
+		CompletedAt:   s.CompletedAt(),
+	}
+	if p := s.Point(); p != nil {
+		resp.Latitude, resp.Longitude = &p.Latitude, &p.Longitude
+	}
+	return resp
+}
+
+func writeDispatchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, dispatch.ErrDispatchNotAllowed),
+		errors.Is(err, dispatch.ErrNotRunDriver):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, dispatch.ErrDriverNotFound),
+		errors.Is(err, dispatch.ErrRunNotFound),
+		errors.Is(err, dispatch.ErrStopNotFound),
+		errors.Is(err, dispatch.ErrOrderNotOnRun),
+		errors.Is(err, dispatch.ErrProofNotFound),
+		errors.Is(err, delivery.ErrZoneNotFound),
+		errors.Is(err, order.ErrOrderNotFound),
+		errors.Is(err, customer.ErrAddressNotFound),
+		errors.Is(err, admin.ErrAdminNotFound),
+		errors.Is(err, branch.ErrBranchNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, dispatch.ErrEmailTaken),
+		errors.Is(err, dispatch.ErrConcurrentUpdate),
+		errors.Is(err, dispatch.ErrOrderAlreadyRouted),
+		errors.Is(err, dispatch.ErrRunNotPlanned),
+		errors.Is(err, dispatch.ErrRunNotStarted),
+		errors.Is(err, dispatch.ErrStopCompleted),
+		errors.Is(err, dispatch.ErrDriverInactive),
+		errors.Is(err, dispatch.ErrNoOrders),
+		errors.Is(err, dispatch.ErrNoAddress),
+		errors.Is(err, dispatch.ErrNoDriver),
+		errors.Is(err, dispatch.ErrEmptyRun):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, dispatch.ErrProofTooLarge):
+		httpresponse.Error(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, dispatch.ErrNotADriver),
+		errors.Is(err, dispatch.ErrDriverWrongBranch),
+		errors.Is(err, dispatch.ErrBranchRequired),
+		errors.Is(err, dispatch.ErrEmptyVehicle),
+		errors.Is(err, dispatch.ErrInvalidPassword),
+		errors.Is(err, dispatch.ErrInvalidSlot),
+		errors.Is(err, dispatch.ErrOrderWrongBranch),
+		errors.Is(err, dispatch.ErrAddressNotCustomers),
+		errors.Is(err, dispatch.ErrProofRequired),
+		errors.Is(err, dispatch.ErrFailureReason),
+		errors.Is(err, dispatch.ErrInvalidProofKind),
+		errors.Is(err, dispatch.ErrUnsupportedProof),
+		errors.Is(err, admin.ErrInvalidEmail),
+		errors.Is(err, admin.ErrEmptyFullName):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	fulfilcmd "github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminFulfilmentHandler handles back-office management of opening hours,
+// closures and slot templates.
+type AdminFulfilmentHandler struct {
+	setHoursHandler       *fulfilcmd.SetOpeningHoursHandler
+	addClosureHandler     *fulfilcmd.AddClosureHandler
+	createTemplateHandler *fulfilcmd.CreateSlotTemplateHandler
+}
+
+// NewAdminFulfilmentHandler creates a new AdminFulfilmentHandler.
+func NewAdminFulfilmentHandler(
+	setHoursHandler *fulfilcmd.SetOpeningHoursHandler,
+	addClosureHandler *fulfilcmd.AddClosureHandler,
+	createTemplateHandler *fulfilcmd.CreateSlotTemplateHandler,
+) *AdminFulfilmentHandler {
+	return &AdminFulfilmentHandler{
+		setHoursHandler:       setHoursHandler,
+		addClosureHandler:     addClosureHandler,
+		createTemplateHandler: createTemplateHandler,
+	}
+}
+
+// SetOpeningHours handles PUT /api/v1/admin/branches/{branchID}/opening-hours.
+//
+//	@Summary		Set branch opening hours
+//	@Description	Replace a branch's weekly opening hours. Weekdays that are not listed are closed.
+//	@Tags			Admin Fulfilment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branchID	path	string						true	"Branch ID"
+//	@Param			body		body	dto.SetOpeningHoursRequest	true	"Opening hours"
+//	@Success		204			"Opening hours replaced"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request body"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody	"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/branches/{branchID}/opening-hours [put]
+func (h *AdminFulfilmentHandler) SetOpeningHours(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch id")
+		return
+	}
+
+	var req dto.SetOpeningHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	days := make([]fulfilcmd.OpeningHoursInput, 0, len(req.Days))
+	for _, d := range req.Days {
+		days = append(days, fulfilcmd.OpeningHoursInput{Weekday: d.Weekday, OpensAt: d.OpensAt, ClosesAt: d.ClosesAt})
+	}
+
+	if err := h.setHoursHandler.Handle(r.Context(), fulfilcmd.SetOpeningHoursCommand{
+		BranchID: branchID,
+		Days:     days,
+	}); err != nil {
+		writeFulfilmentValidationError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// AddClosure handles POST /api/v1/admin/branches/{branchID}/closures.
+//
+//	@Summary		Add branch closure
+//	@Description	Close a branch for a whole date, e.g. a public holiday. No slots are offered on closed dates.
+//	@Tags			Admin Fulfilment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branchID	path		string					true	"Branch ID"
+//	@Param			body		body		dto.AddClosureRequest	true	"Closure"
+//	@Success		201			{object}	dto.IDSuccessResponse	"Closure added"
+//	@Failure		400			{object}	dto.ErrorBody			"Invalid request body"
+//	@Failure		401			{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody			"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/branches/{branchID}/closures [post]
+func (h *AdminFulfilmentHandler) AddClosure(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch id")
+		return
+	}
+
+	var req dto.AddClosureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	id, err := h.addClosureHandler.Handle(r.Context(), fulfilcmd.AddClosureCommand{
+		BranchID: branchID,
+		Date:     req.Date,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		writeFulfilmentValidationError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, dto.IDResponse{ID: id.String()})
+}
+
+// CreateSlotTemplate handles POST /api/v1/admin/fulfilment/slot-templates.
+//
+//	@Summary		Create slot template
+//	@Description	Create a recurring weekly delivery or collection slot. Delivery templates need a zone_id. For capacity_unit "kg", capacity is in grams.
+//	@Tags			Admin Fulfilment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.CreateSlotTemplateRequest	true	"Slot template"
+//	@Success		201		{object}	dto.IDSuccessResponse			"Slot template created"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Delivery zone not found"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/fulfilment/slot-templates [post]
+func (h *AdminFulfilmentHandler) CreateSlotTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSlotTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+	var zoneID *uuid.UUID
+	if req.ZoneID != nil {
+		var ok bool
+		if zoneID, ok = optionalUUID(*req.ZoneID); !ok {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid zone_id")
+			return
+		}
+	}
+
+	id, err := h.createTemplateHandler.Handle(r.Context(), fulfilcmd.CreateSlotTemplateCommand{
+		BranchID:     branchID,
+		ZoneID:       zoneID,
+		Method:       req.Method,
+		Weekday:      req.Weekday,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		CapacityUnit: req.CapacityUnit,
+		Capacity:     req.Capacity,
+	})
+	if err != nil {
+		if errors.Is(err, delivery.ErrZoneNotFound) {
+			httpresponse.Error(w, http.StatusNotFound, "delivery zone not found")
+			return
+		}
+		writeFulfilmentValidationError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, dto.IDResponse{ID: id.String()})
+}
+
+// writeFulfilmentValidationError maps fulfilment domain validation errors to
+// 422, and branches the admin is not assigned to to 403.
+func writeFulfilmentValidationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, fulfilment.ErrInvalidMethod),
+		errors.Is(err, fulfilment.ErrInvalidCapacityUnit),
+		errors.Is(err, fulfilment.ErrInvalidCapacity),
+		errors.Is(err, fulfilment.ErrInvalidTimeOfDay),
+		errors.Is(err, fulfilment.ErrInvalidWeekday),
+		errors.Is(err, fulfilment.ErrInvalidTimeRange),
+		errors.Is(err, fulfilment.ErrDuplicateWeekday),
+		errors.Is(err, fulfilment.ErrMissingZone),
+		errors.Is(err, fulfilment.ErrInvalidDate):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
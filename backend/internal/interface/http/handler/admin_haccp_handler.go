@@ -0,0 +1,565 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	haccpcmd "github.com/katerji/butchery-app/backend/internal/application/haccp/commands"
+	haccpqry "github.com/katerji/butchery-app/backend/internal/application/haccp/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/haccp"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminHACCPHandler keeps the temperature records of branches' chillers,
+// freezers and display counters for HACCP.
+type AdminHACCPHandler struct {
+	createUnitHandler    *haccpcmd.CreateUnitHandler
+	updateUnitHandler    *haccpcmd.UpdateUnitHandler
+	recordReadingHandler *haccpcmd.RecordReadingHandler
+	recordActionHandler  *haccpcmd.RecordActionHandler
+	runChecksHandler     *haccpcmd.RunChecksHandler
+	listUnitsHandler     *haccpqry.ListUnitsHandler
+	listReadingsHandler  *haccpqry.ListReadingsHandler
+	listAlertsHandler    *haccpqry.ListAlertsHandler
+	reportHandler        *haccpqry.ExportReportHandler
+}
+
+// NewAdminHACCPHandler creates a new AdminHACCPHandler.
+func NewAdminHACCPHandler(
+	createUnitHandler *haccpcmd.CreateUnitHandler,
+	updateUnitHandler *haccpcmd.UpdateUnitHandler,
+	recordReadingHandler *haccpcmd.RecordReadingHandler,
+	recordActionHandler *haccpcmd.RecordActionHandler,
+	runChecksHandler *haccpcmd.RunChecksHandler,
+	listUnitsHandler *haccpqry.ListUnitsHandler,
+	listReadingsHandler *haccpqry.ListReadingsHandler,
+	listAlertsHandler *haccpqry.ListAlertsHandler,
+	reportHandler *haccpqry.ExportReportHandler,
+) *AdminHACCPHandler {
+	return &AdminHACCPHandler{
+		createUnitHandler:    createUnitHandler,
+		updateUnitHandler:    updateUnitHandler,
+		recordReadingHandler: recordReadingHandler,
+		recordActionHandler:  recordActionHandler,
+		runChecksHandler:     runChecksHandler,
+		listUnitsHandler:     listUnitsHandler,
+		listReadingsHandler:  listReadingsHandler,
+		listAlertsHandler:    listAlertsHandler,
+		reportHandler:        reportHandler,
+	}
+}
+
+// ListUnits handles GET /api/v1/admin/haccp/units.
+//
+//	@Summary		List temperature units
+//	@Description	List the chillers, freezers and display counters whose temperatures are kept, by name, out of service ones included.
+//	@Tags			Admin HACCP
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string							false	"Only units at this branch"
+//	@Success		200			{object}	dto.HACCPUnitListSuccessResponse	"Units"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid branch ID"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/haccp/units [get]
+func (h *AdminHACCPHandler) ListUnits(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := optionalUUID(r.URL.Query().Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+
+	units, err := h.listUnitsHandler.Handle(r.Context(), branchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.HACCPUnitResponse, 0, len(units))
+	for _, u := range units {
+		resp = append(resp, toHACCPUnitResponse(u))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateUnit handles POST /api/v1/admin/haccp/units.
+//
+//	@Summary		Add a temperature unit
+//	@Description	Add a chiller, freezer or display counter to a branch's temperature records, with the range it must be kept in and how often it must be read. Units with a sensor ID are read by the sensor pushing to /haccp/readings; the others are read by hand.
+//	@Tags			Admin HACCP
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.HACCPUnitRequest			true	"Unit"
+//	@Success		201		{object}	dto.HACCPUnitSuccessResponse	"Unit added"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Branch not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Name or sensor already in use"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/haccp/units [post]
+func (h *AdminHACCPHandler) CreateUnit(w http.ResponseWriter, r *http.Request) {
+	var req dto.HACCPUnitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, ok := optionalUUID(req.BranchID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	cmd := haccpcmd.CreateUnitCommand{
+		Name:       req.Name,
+		Kind:       haccp.Kind(req.Kind),
+		MinCelsius: req.MinCelsius,
+		MaxCelsius: req.MaxCelsius,
+		SensorID:   req.SensorID,
+		CheckEvery: time.Duration(req.CheckEveryMinutes) * time.Minute,
+	}
+	if branchID != nil {
+		cmd.BranchID = *branchID
+	}
+	u, err := h.createUnitHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeHACCPError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toHACCPUnitResponse(u))
+}
+
+// UpdateUnit handles PUT /api/v1/admin/haccp/units/{unitID}.
+//
+//	@Summary		Update a temperature unit
+//	@Description	Change a unit's range, sensor or how often it must be read, or take it out of service. Records already kept are not changed.
+//	@Tags			Admin HACCP
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			unitID	path		string							true	"Unit ID"
+//	@Param			body	body		dto.UpdateHACCPUnitRequest		true	"Unit"
+//	@Success		200		{object}	dto.HACCPUnitSuccessResponse	"Unit updated"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Unit not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Name or sensor already in use"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/haccp/units/{unitID} [put]
+func (h *AdminHACCPHandler) UpdateUnit(w http.ResponseWriter, r *http.Request) {
+	unitID, ok := uuidParam(r, "unitID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid unit ID")
+		return
+	}
+	var req dto.UpdateHACCPUnitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	u, err := h.updateUnitHandler.Handle(r.Context(), haccpcmd.UpdateUnitCommand{
+		UnitID:     unitID,
+		Name:       req.Name,
+		Kind:       haccp.Kind(req.Kind),
+		MinCelsius: req.MinCelsius,
+		MaxCelsius: req.MaxCelsius,
+		SensorID:   req.SensorID,
+		CheckEvery: time.Duration(req.CheckEveryMinutes) * time.Minute,
+		Active:     req.Active,
+	})
+	if err != nil {
+		writeHACCPError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toHACCPUnitResponse(u))
+}
+
+// RecordReading handles POST /api/v1/admin/haccp/units/{unitID}/readings.
+//
+//	@Summary		Record a temperature
+//	@Description	Record a temperature read by hand, as of now or of when it was written down. A reading out of the unit's range raises an alarm unless one is already open on the unit.
+//	@Tags			Admin HACCP
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			unitID	path		string							true	"Unit ID"
+//	@Param			body	body		dto.HACCPReadingRequest			true	"Reading"
+//	@Success		201		{object}	dto.HACCPReadingSuccessResponse	"Reading recorded"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Unit not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Unit out of service"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/haccp/units/{unitID}/readings [post]
+func (h *AdminHACCPHandler) RecordReading(w http.ResponseWriter, r *http.Request) {
+	unitID, ok := uuidParam(r, "unitID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid unit ID")
+		return
+	}
+	var req dto.HACCPReadingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rd, err := h.recordReadingHandler.Handle(r.Context(), haccpcmd.RecordReadingCommand{
+		UnitID:  unitID,
+		Celsius: req.Celsius,
+		TakenAt: req.TakenAt,
+		Note:    req.Note,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeHACCPError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toHACCPReadingResponse(rd))
+}
+
+// RecordAction handles POST /api/v1/admin/haccp/units/{unitID}/corrective-actions.
+//
+//	@Summary		Record a corrective action
+//	@Description	Record what was done about a unit, such as moving stock or calling an engineer. Naming the alarm it dealt with resolves the alarm.
+//	@Tags			Admin HACCP
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			unitID	path		string							true	"Unit ID"
+//	@Param			body	body		dto.HACCPActionRequest			true	"Corrective action"
+//	@Success		201		{object}	dto.HACCPActionSuccessResponse	"Action recorded"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Unit or alarm not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Alarm already resolved"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/haccp/units/{unitID}/corrective-actions [post]
+func (h *AdminHACCPHandler) RecordAction(w http.ResponseWriter, r *http.Request) {
+	unitID, ok := uuidParam(r, "unitID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid unit ID")
+		return
+	}
+	var req dto.HACCPActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd := haccpcmd.RecordActionCommand{
+		UnitID:  unitID,
+		Action:  req.Action,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	}
+	if req.AlertID != nil {
+		if cmd.AlertID, ok = optionalUUID(*req.AlertID); !ok {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid alert ID")
+			return
+		}
+	}
+
+	c, err := h.recordActionHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeHACCPError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, dto.HACCPActionResponse{
+		ID:      c.ID().String(),
+		UnitID:  c.UnitID().String(),
+		AlertID: uuidString(c.AlertID()),
+		Action:  c.Action(),
+		TakenBy: c.TakenBy().String(),
+		TakenAt: c.TakenAt(),
+	})
+}
+
+// ListReadings handles GET /api/v1/admin/haccp/readings.
+//
+//	@Summary		List temperature readings
+//	@Description	List the temperatures recorded by hand and by sensors, by when they were taken.
+//	@Tags			Admin HACCP
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string								false	"Only units at this branch"
+//	@Param			unit_id		query		string								false	"Only this unit"
+//	@Param			from		query		string								false	"RFC 3339 time to list from"
+//	@Param			to			query		string								false	"RFC 3339 time to list until"
+//	@Success		200			{object}	dto.HACCPReadingListSuccessResponse	"Readings"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid filter"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/haccp/readings [get]
+func (h *AdminHACCPHandler) ListReadings(w http.ResponseWriter, r *http.Request) {
+	filter, ok := haccpFilter(w, r)
+	if !ok {
+		return
+	}
+
+	readings, err := h.listReadingsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.HACCPReadingResponse, 0, len(readings))
+	for _, rd := range readings {
+		resp = append(resp, toHACCPReadingResponse(rd))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// ListAlerts handles GET /api/v1/admin/haccp/alerts.
+//
+//	@Summary		List temperature alarms
+//	@Description	List the alarms raised for readings out of range and readings missed, newest first.
+//	@Tags			Admin HACCP
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string								false	"Only units at this branch"
+//	@Param			unit_id		query		string								false	"Only this unit"
+//	@Param			from		query		string								false	"RFC 3339 time to list from"
+//	@Param			to			query		string								false	"RFC 3339 time to list until"
+//	@Param			open		query		bool								false	"Only alarms not yet resolved"
+//	@Success		200			{object}	dto.HACCPAlertListSuccessResponse	"Alarms"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid filter"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/haccp/alerts [get]
+func (h *AdminHACCPHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	filter, ok := haccpFilter(w, r)
+	if !ok {
+		return
+	}
+	if raw := r.URL.Query().Get("open"); raw != "" {
+		open, err := strconv.ParseBool(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "open must be true or false")
+			return
+		}
+		filter.OpenOnly = open
+	}
+
+	alerts, err := h.listAlertsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.HACCPAlertResponse, 0, len(alerts))
+	for _, a := range alerts {
+		resp = append(resp, dto.HACCPAlertResponse{
+			ID:         a.ID().String(),
+			UnitID:     a.UnitID().String(),
+			Kind:       string(a.Kind()),
+			ReadingID:  uuidString(a.ReadingID()),
+			Celsius:    a.Celsius(),
+			RaisedAt:   a.RaisedAt(),
+			ResolvedAt: a.ResolvedAt(),
+			ResolvedBy: uuidString(a.ResolvedBy()),
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+// RunChecks handles POST /api/v1/admin/haccp/checks/run.
+//
+//	@Summary		Check for missed readings
+//	@Description	Raise an alarm for every unit in service that has gone longer than it should without a reading. The server runs these checks every few minutes on its own; a unit whose alarm is still open does not raise another.
+//	@Tags			Admin HACCP
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.HACCPCheckRunSuccessResponse	"Checks run"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/haccp/checks/run [post]
+func (h *AdminHACCPHandler) RunChecks(w http.ResponseWriter, r *http.Request) {
+	run, err := h.runChecksHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, dto.HACCPCheckRunResponse{Checked: run.Checked, Raised: run.Raised})
+}
+
+// Report handles GET /api/v1/admin/haccp/report.
+//
+//	@Summary		Export the HACCP temperature record
+//	@Description	Export every reading, alarm and corrective action of each unit from the first day to the last, for an inspector. Days run in the shop's time zone.
+//	@Tags			Admin HACCP
+//	@Produce		application/pdf
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			from		query		string			true	"First day, YYYY-MM-DD"
+//	@Param			to			query		string			true	"Last day, YYYY-MM-DD"
+//	@Param			branch_id	query		string			false	"Only units at this branch"
+//	@Param			format		query		string			false	"pdf or csv (default pdf)"
+//	@Success		200			{file}		file			"Report"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody	"Branch not found"
+//	@Failure		422			{object}	dto.ErrorBody	"Invalid period or format"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/haccp/report [get]
+func (h *AdminHACCPHandler) Report(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err := time.Parse(time.DateOnly, q.Get("from"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "from must be a YYYY-MM-DD date")
+		return
+	}
+	to, err := time.Parse(time.DateOnly, q.Get("to"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "to must be a YYYY-MM-DD date")
+		return
+	}
+	branchID, ok := optionalUUID(q.Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	format := haccp.FormatPDF
+	if raw := q.Get("format"); raw != "" {
+		format = haccp.Format(raw)
+	}
+
+	out, err := h.reportHandler.Handle(r.Context(), haccpqry.ExportReportQuery{
+		BranchID: branchID,
+		From:     from,
+		To:       to,
+		Format:   format,
+	})
+	if err != nil {
+		writeHACCPError(w, err)
+		return
+	}
+
+	contentType := "application/pdf"
+	if format == haccp.FormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	name := fmt.Sprintf("haccp-%s-%s.%s", from.Format(time.DateOnly), to.Format(time.DateOnly), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.Header().Set("Content-Length", strconv.Itoa(len(out)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+// haccpFilter reads the filter for readings and alarms from the query,
+// writing a 400 if it is invalid.
+func haccpFilter(w http.ResponseWriter, r *http.Request) (haccp.Filter, bool) {
+	q := r.URL.Query()
+	var filter haccp.Filter
+	var ok bool
+	if filter.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return filter, false
+	}
+	if filter.UnitID, ok = optionalUUID(q.Get("unit_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid unit_id")
+		return filter, false
+	}
+	if raw := q.Get("from"); raw != "" {
+		from, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "from must be an RFC 3339 time")
+			return filter, false
+		}
+		filter.From = &from
+	}
+	if raw := q.Get("to"); raw != "" {
+		to, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "to must be an RFC 3339 time")
+			return filter, false
+		}
+		filter.To = &to
+	}
+	return filter, true
+}
+
+func toHACCPUnitResponse(u *haccp.Unit) dto.HACCPUnitResponse {
+	return dto.HACCPUnitResponse{
+		ID:                u.ID().String(),
+		BranchID:          u.BranchID().String(),
+		Name:              u.Name(),
+		Kind:              string(u.Kind()),
+		MinCelsius:        u.MinCelsius(),
+		MaxCelsius:        u.MaxCelsius(),
+		SensorID:          u.SensorID(),
+		CheckEveryMinutes: int(u.CheckEvery() / time.Minute),
+		Active:            u.Active(),
+		CreatedAt:         u.CreatedAt(),
+		UpdatedAt:         u.UpdatedAt(),
+	}
+}
+
+func toHACCPReadingResponse(rd *haccp.Reading) dto.HACCPReadingResponse {
+	return dto.HACCPReadingResponse{
+		ID:         rd.ID().String(),
+		UnitID:     rd.UnitID().String(),
+		Celsius:    rd.Celsius(),
+		InRange:    rd.InRange(),
+		Source:     string(rd.Source()),
+		RecordedBy: uuidString(rd.RecordedBy()),
+		Note:       rd.Note(),
+		TakenAt:    rd.TakenAt(),
+		CreatedAt:  rd.CreatedAt(),
+	}
+}
+
+func writeHACCPError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, haccp.ErrInvalidSensorKey):
+		httpresponse.Error(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, haccp.ErrUnitNotFound),
+		errors.Is(err, haccp.ErrAlertNotFound),
+		errors.Is(err, branch.ErrBranchNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, haccp.ErrDuplicateUnit),
+		errors.Is(err, haccp.ErrDuplicateSensor),
+		errors.Is(err, haccp.ErrUnitInactive),
+		errors.Is(err, haccp.ErrAlertResolved):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, haccp.ErrBranchRequired),
+		errors.Is(err, haccp.ErrEmptyUnitName),
+		errors.Is(err, haccp.ErrInvalidKind),
+		errors.Is(err, haccp.ErrInvalidRange),
+		errors.Is(err, haccp.ErrInvalidCheckInterval),
+		errors.Is(err, haccp.ErrImplausibleTemperature),
+		errors.Is(err, haccp.ErrReadingInFuture),
+		errors.Is(err, haccp.ErrEmptyAction),
+		errors.Is(err, haccp.ErrInvalidReportPeriod),
+		errors.Is(err, haccp.ErrUnsupportedReportFormat):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
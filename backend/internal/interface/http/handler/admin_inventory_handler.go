@@ -0,0 +1,426 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	invcmd "github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	invqry "github.com/katerji/butchery-app/backend/internal/application/inventory/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminInventoryHandler handles back-office stock management: goods in,
+// adjustments, stock takes and stock reports.
+type AdminInventoryHandler struct {
+	receiveLotHandler  *invcmd.ReceiveLotHandler
+	recordHandler      *invcmd.RecordMovementHandler
+	stockTakeHandler   *invcmd.StockTakeHandler
+	thresholdHandler   *invcmd.SetLowStockThresholdHandler
+	fulfilHandler      *invcmd.FulfilStockHandler
+	stockLevelsHandler *invqry.ListStockLevelsHandler
+	movementsHandler   *invqry.ListMovementsHandler
+}
+
+// NewAdminInventoryHandler creates a new AdminInventoryHandler.
+func NewAdminInventoryHandler(
+	receiveLotHandler *invcmd.ReceiveLotHandler,
+	recordHandler *invcmd.RecordMovementHandler,
+	stockTakeHandler *invcmd.StockTakeHandler,
+	thresholdHandler *invcmd.SetLowStockThresholdHandler,
+	fulfilHandler *invcmd.FulfilStockHandler,
+	stockLevelsHandler *invqry.ListStockLevelsHandler,
+	movementsHandler *invqry.ListMovementsHandler,
+) *AdminInventoryHandler {
+	return &AdminInventoryHandler{
+		receiveLotHandler:  receiveLotHandler,
+		recordHandler:      recordHandler,
+		stockTakeHandler:   stockTakeHandler,
+		thresholdHandler:   thresholdHandler,
+		fulfilHandler:      fulfilHandler,
+		stockLevelsHandler: stockLevelsHandler,
+		movementsHandler:   movementsHandler,
+	}
+}
+
+// ReceiveLot handles POST /api/v1/admin/inventory/lots.
+//
+//	@Summary		Receive a lot
+//	@Description	Book a delivered batch of stock into a branch. Weights are in grams. expires_on is the use-by date the expiry scan works from; cost_per_kg_cents values any of it later wasted.
+//	@Tags			Admin Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ReceiveLotRequest	true	"Lot"
+//	@Success		201		{object}	dto.LotSuccessResponse	"Lot received"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/inventory/lots [post]
+func (h *AdminInventoryHandler) ReceiveLot(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReceiveLotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "product_id is required")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	lot, err := h.receiveLotHandler.Handle(r.Context(), invcmd.ReceiveLotCommand{
+		ProductID:      productID,
+		BranchID:       branchID,
+		Code:           req.Code,
+		Grams:          req.Grams,
+		ExpiresOn:      req.ExpiresOn,
+		CostPerKgCents: req.CostPerKgCents,
+		ActorID:        claims.SubjectID,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toLotResponse(lot))
+}
+
+// RecordAdjustment handles POST /api/v1/admin/inventory/adjustments.
+//
+//	@Summary		Record a stock adjustment
+//	@Description	Record a cut, wastage or ad-hoc adjustment against a lot. delta_grams is negative for stock leaving the lot. Wastage must give a reason of trim, expired, damaged or returned. Sales and goods in are recorded by their own flows.
+//	@Tags			Admin Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.StockAdjustmentRequest			true	"Adjustment"
+//	@Success		201		{object}	dto.StockMovementSuccessResponse	"Adjustment recorded"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Lot not found"
+//	@Failure		409		{object}	dto.ErrorBody						"Insufficient stock"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/adjustments [post]
+func (h *AdminInventoryHandler) RecordAdjustment(w http.ResponseWriter, r *http.Request) {
+	var req dto.StockAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	lotID, err := uuid.Parse(req.LotID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "lot_id is required")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	m, err := h.recordHandler.Handle(r.Context(), invcmd.RecordMovementCommand{
+		LotID:      lotID,
+		Type:       req.Type,
+		DeltaGrams: req.DeltaGrams,
+		Reason:     req.Reason,
+		ActorID:    claims.SubjectID,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toStockMovementResponse(m))
+}
+
+// StockTake handles POST /api/v1/admin/inventory/stock-takes.
+//
+//	@Summary		Record a stock take
+//	@Description	Reconcile counted weights with the ledger. An adjustment is recorded for every lot whose count differs; the adjustments are returned.
+//	@Tags			Admin Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.StockTakeRequest				true	"Counts"
+//	@Success		200		{object}	dto.StockMovementsSuccessResponse	"Adjustments recorded"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Lot not found"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/stock-takes [post]
+func (h *AdminInventoryHandler) StockTake(w http.ResponseWriter, r *http.Request) {
+	var req dto.StockTakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+	counts := make([]invcmd.LotCount, 0, len(req.Counts))
+	for _, c := range req.Counts {
+		lotID, err := uuid.Parse(c.LotID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid lot_id")
+			return
+		}
+		counts = append(counts, invcmd.LotCount{LotID: lotID, CountedGrams: c.CountedGrams})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	adjustments, err := h.stockTakeHandler.Handle(r.Context(), invcmd.StockTakeCommand{
+		BranchID: branchID,
+		Counts:   counts,
+		ActorID:  claims.SubjectID,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toStockMovementResponses(adjustments))
+}
+
+// ListStock handles GET /api/v1/admin/inventory/stock.
+//
+//	@Summary		List stock levels
+//	@Description	List on-hand, reserved and available grams per product and branch. With low_only, only products at or below their low-stock threshold are listed.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string	false	"Branch ID"
+//	@Param			low_only	query		bool	false	"Only list low stock"
+//	@Success		200			{object}	dto.StockLevelsSuccessResponse	"Stock levels"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/inventory/stock [get]
+func (h *AdminInventoryHandler) ListStock(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	branchID, ok := optionalUUID(q.Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	lowOnly := false
+	if raw := q.Get("low_only"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid low_only")
+			return
+		}
+		lowOnly = b
+	}
+
+	levels, err := h.stockLevelsHandler.Handle(r.Context(), invqry.ListStockLevelsQuery{BranchID: branchID, LowOnly: lowOnly})
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.StockLevelResponse, 0, len(levels))
+	for _, l := range levels {
+		resp = append(resp, dto.StockLevelResponse{
+			ProductID:              l.ProductID.String(),
+			BranchID:               l.BranchID.String(),
+			OnHandGrams:            l.OnHandGrams,
+			ReservedGrams:          l.ReservedGrams,
+			AvailableGrams:         l.AvailableGrams(),
+			LowStockThresholdGrams: l.LowStockThresholdGrams,
+			Low:                    l.IsLow(),
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+// ListMovements handles GET /api/v1/admin/inventory/movements.
+//
+//	@Summary		List stock movements
+//	@Description	Read the stock ledger, newest first.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			lot_id		query		string	false	"Lot ID"
+//	@Param			product_id	query		string	false	"Product ID"
+//	@Param			branch_id	query		string	false	"Branch ID"
+//	@Param			limit		query		int		false	"Maximum entries (default 100, max 500)"
+//	@Success		200			{object}	dto.StockMovementsSuccessResponse	"Stock movements"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/movements [get]
+func (h *AdminInventoryHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var filter inventory.MovementFilter
+	var ok bool
+	if filter.LotID, ok = optionalUUID(q.Get("lot_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid lot_id")
+		return
+	}
+	if filter.ProductID, ok = optionalUUID(q.Get("product_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+	if filter.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+
+	movements, err := h.movementsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toStockMovementResponses(movements))
+}
+
+// SetLowStockThreshold handles PUT /api/v1/admin/products/{productID}/low-stock-threshold.
+//
+//	@Summary		Set low-stock threshold
+//	@Description	Set the available weight in grams at or below which a product is reported as low stock. Zero turns reporting off.
+//	@Tags			Admin Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			productID	path	string							true	"Product ID"
+//	@Param			body		body	dto.SetLowStockThresholdRequest	true	"Threshold"
+//	@Success		204			"Threshold set"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request body"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody	"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/products/{productID}/low-stock-threshold [put]
+func (h *AdminInventoryHandler) SetLowStockThreshold(w http.ResponseWriter, r *http.Request) {
+	productID, ok := uuidParam(r, "productID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req dto.SetLowStockThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.thresholdHandler.Handle(r.Context(), invcmd.SetLowStockThresholdCommand{
+		ProductID: productID,
+		Grams:     req.Grams,
+	}); err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// FulfilReservation handles POST /api/v1/admin/inventory/reservations/{reservationID}/fulfil.
+//
+//	@Summary		Fulfil a stock reservation
+//	@Description	Record the reserved stock as sold once the order has been picked, taking it from the lots that expire first.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			reservationID	path	string	true	"Reservation ID"
+//	@Success		204				"Reservation fulfilled"
+//	@Failure		400				{object}	dto.ErrorBody	"Invalid reservation ID"
+//	@Failure		401				{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody	"Reservation not found"
+//	@Failure		409				{object}	dto.ErrorBody	"Reservation is not active or stock is short"
+//	@Failure		500				{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/inventory/reservations/{reservationID}/fulfil [post]
+func (h *AdminInventoryHandler) FulfilReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, ok := uuidParam(r, "reservationID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid reservation id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	if err := h.fulfilHandler.Handle(r.Context(), invcmd.FulfilStockCommand{
+		ReservationID: reservationID,
+		ActorID:       claims.SubjectID,
+	}); err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+func toLotResponse(lot *inventory.Lot) dto.LotResponse {
+	var expiresOn *string
+	if lot.ExpiresOn() != nil {
+		s := lot.ExpiresOn().Format(time.DateOnly)
+		expiresOn = &s
+	}
+	return dto.LotResponse{
+		ID:             lot.ID().String(),
+		ProductID:      lot.ProductID().String(),
+		BranchID:       lot.BranchID().String(),
+		Code:           lot.Code(),
+		ReceivedGrams:  lot.ReceivedGrams(),
+		OnHandGrams:    lot.OnHandGrams(),
+		ExpiresOn:      expiresOn,
+		CostPerKgCents: lot.CostPerKgCents(),
+		MarkdownBP:     lot.MarkdownBP(),
+		ReceivedAt:     lot.ReceivedAt(),
+	}
+}
+
+func toStockMovementResponse(m *inventory.Movement) dto.StockMovementResponse {
+	return dto.StockMovementResponse{
+		ID:          m.ID().String(),
+		LotID:       m.LotID().String(),
+		ProductID:   m.ProductID().String(),
+		BranchID:    m.BranchID().String(),
+		Type:        string(m.Type()),
+		DeltaGrams:  m.DeltaGrams(),
+		Reason:      m.Reason(),
+		ReferenceID: uuidString(m.ReferenceID()),
+		ActorID:     uuidString(m.ActorID()),
+		OccurredAt:  m.OccurredAt(),
+	}
+}
+
+func toStockMovementResponses(movements []*inventory.Movement) []dto.StockMovementResponse {
+	resp := make([]dto.StockMovementResponse, 0, len(movements))
+	for _, m := range movements {
+		resp = append(resp, toStockMovementResponse(m))
+	}
+	return resp
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"net/http"
+
+	invoicecmd "github.com/katerji/butchery-app/backend/internal/application/invoice/commands"
+	invoiceqry "github.com/katerji/butchery-app/backend/internal/application/invoice/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminInvoiceHandler handles issuing invoices and credit notes for orders
+// and downloading them.
+type AdminInvoiceHandler struct {
+	invoiceHandler    *invoicecmd.IssueInvoiceHandler
+	creditNoteHandler *invoicecmd.IssueCreditNoteHandler
+	listHandler       *invoiceqry.ListOrderDocumentsHandler
+	getHandler        *invoiceqry.GetDocumentHandler
+}
+
+// NewAdminInvoiceHandler creates a new AdminInvoiceHandler.
+func NewAdminInvoiceHandler(
+	invoiceHandler *invoicecmd.IssueInvoiceHandler,
+	creditNoteHandler *invoicecmd.IssueCreditNoteHandler,
+	listHandler *invoiceqry.ListOrderDocumentsHandler,
+	getHandler *invoiceqry.GetDocumentHandler,
+) *AdminInvoiceHandler {
+	return &AdminInvoiceHandler{
+		invoiceHandler:    invoiceHandler,
+		creditNoteHandler: creditNoteHandler,
+		listHandler:       listHandler,
+		getHandler:        getHandler,
+	}
+}
+
+// IssueInvoice handles POST /api/v1/admin/orders/{orderID}/invoice.
+//
+//	@Summary		Invoice an order
+//	@Description	Issue the VAT invoice for an order, numbered next in its branch's series for the fiscal year. An order is invoiced once; asking again returns the same invoice.
+//	@Tags			Admin Invoices
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Success		201		{object}	dto.InvoiceSuccessResponse	"Invoice issued"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Order not found"
+//	@Failure		422		{object}	dto.ErrorBody				"Order cannot be invoiced"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID}/invoice [post]
+func (h *AdminInvoiceHandler) IssueInvoice(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	d, err := h.invoiceHandler.Handle(r.Context(), invoicecmd.IssueInvoiceCommand{
+		OrderID: orderID,
+		ActorID: claims.SubjectID,
+	})
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toInvoiceResponse(d))
+}
+
+// IssueCreditNote handles POST /api/v1/admin/orders/{orderID}/refunds/{refundID}/credit-note.
+//
+//	@Summary		Issue a credit note for a refund
+//	@Description	Issue a credit note against the order's invoice for an issued refund, in the branch's credit note series. Each refund gets one credit note; asking again returns the same one.
+//	@Tags			Admin Invoices
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID		path		string						true	"Order ID"
+//	@Param			refundID	path		string						true	"Refund ID"
+//	@Success		201			{object}	dto.InvoiceSuccessResponse	"Credit note issued"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid ID"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody				"Order or refund not found"
+//	@Failure		409			{object}	dto.ErrorBody				"Refund not issued or order not invoiced"
+//	@Failure		422			{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID}/refunds/{refundID}/credit-note [post]
+func (h *AdminInvoiceHandler) IssueCreditNote(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	refundID, ok := uuidParam(r, "refundID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid refund ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	d, err := h.creditNoteHandler.Handle(r.Context(), invoicecmd.IssueCreditNoteCommand{
+		OrderID:  orderID,
+		RefundID: refundID,
+		ActorID:  claims.SubjectID,
+	})
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toInvoiceResponse(d))
+}
+
+// ListOrderInvoices handles GET /api/v1/admin/orders/{orderID}/invoices.
+//
+//	@Summary		List an order's invoices
+//	@Description	List the invoice and credit notes issued for an order, oldest first.
+//	@Tags			Admin Invoices
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Success		200		{object}	dto.InvoicesSuccessResponse	"Invoices and credit notes"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID}/invoices [get]
+func (h *AdminInvoiceHandler) ListOrderInvoices(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	docs, err := h.listHandler.Handle(r.Context(), orderID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toInvoiceResponses(docs))
+}
+
+// DownloadInvoice handles GET /api/v1/admin/invoices/{invoiceID}/pdf.
+//
+//	@Summary		Download an invoice
+//	@Description	Download an invoice or credit note as the PDF stored when it was issued. The ETag is the SHA-256 of the bytes.
+//	@Tags			Admin Invoices
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			invoiceID	path		string			true	"Invoice or credit note ID"
+//	@Success		200			{file}		file			"PDF"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid invoice ID"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody	"Invoice not found"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/invoices/{invoiceID}/pdf [get]
+func (h *AdminInvoiceHandler) DownloadInvoice(w http.ResponseWriter, r *http.Request) {
+	invoiceID, ok := uuidParam(r, "invoiceID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid invoice ID")
+		return
+	}
+
+	d, err := h.getHandler.Handle(r.Context(), invoiceID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writePDF(w, d)
+}
+
+func toInvoiceResponse(d *invoice.Document) dto.InvoiceResponse {
+	resp := dto.InvoiceResponse{
+		ID:             d.ID().String(),
+		Kind:           string(d.Kind()),
+		Number:         d.Number(),
+		BranchID:       d.BranchID().String(),
+		FiscalYear:     d.FiscalYear(),
+		OrderID:        d.OrderID().String(),
+		CustomerID:     d.CustomerID().String(),
+		RefundID:       uuidString(d.RefundID()),
+		CreditedNumber: d.CreditedNumber(),
+		Currency:       d.Currency(),
+		Lines:          make([]dto.InvoiceLineResponse, 0, len(d.Lines())),
+		VATBands:       make([]dto.VATBandResponse, 0),
+		NetCents:       d.NetCents(),
+		VATCents:       d.VATCents(),
+		GrossCents:     d.GrossCents(),
+		SHA256:         d.Checksum(),
+		IssuedAt:       d.IssuedAt(),
+	}
+	for _, l := range d.Lines() {
+		resp.Lines = append(resp.Lines, dto.InvoiceLineResponse(l))
+	}
+	for _, b := range d.VATBands() {
+		resp.VATBands = append(resp.VATBands, dto.VATBandResponse(b))
+	}
+	return resp
+}
@@ -0,0 +1,553 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	labelcmd "github.com/katerji/butchery-app/backend/internal/application/label/commands"
+	labelqry "github.com/katerji/butchery-app/backend/internal/application/label/queries"
+	"github.com/katerji/butchery-app/backend/internal/application/labelling"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/label"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminLabelHandler manages label printers, renders pack labels for orders
+// and inventory lots, and runs the queue that sends them to printers.
+type AdminLabelHandler struct {
+	createPrinterHandler *labelcmd.CreatePrinterHandler
+	updatePrinterHandler *labelcmd.UpdatePrinterHandler
+	printHandler         *labelcmd.PrintLabelsHandler
+	dispatchHandler      *labelcmd.DispatchJobsHandler
+	retryHandler         *labelcmd.RetryJobHandler
+	printersHandler      *labelqry.ListPrintersHandler
+	jobsHandler          *labelqry.ListJobsHandler
+	renderHandler        *labelqry.RenderLabelsHandler
+}
+
+// NewAdminLabelHandler creates a new AdminLabelHandler.
+func NewAdminLabelHandler(
+	createPrinterHandler *labelcmd.CreatePrinterHandler,
+	updatePrinterHandler *labelcmd.UpdatePrinterHandler,
+	printHandler *labelcmd.PrintLabelsHandler,
+	dispatchHandler *labelcmd.DispatchJobsHandler,
+	retryHandler *labelcmd.RetryJobHandler,
+	printersHandler *labelqry.ListPrintersHandler,
+	jobsHandler *labelqry.ListJobsHandler,
+	renderHandler *labelqry.RenderLabelsHandler,
+) *AdminLabelHandler {
+	return &AdminLabelHandler{
+		createPrinterHandler: createPrinterHandler,
+		updatePrinterHandler: updatePrinterHandler,
+		printHandler:         printHandler,
+		dispatchHandler:      dispatchHandler,
+		retryHandler:         retryHandler,
+		printersHandler:      printersHandler,
+		jobsHandler:          jobsHandler,
+		renderHandler:        renderHandler,
+	}
+}
+
+// ListPrinters handles GET /api/v1/admin/printers.
+//
+//	@Summary		List label printers
+//	@Description	List every label printer by name, disabled ones included.
+//	@Tags			Admin Labels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.LabelPrinterListSuccessResponse	"Printers"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/printers [get]
+func (h *AdminLabelHandler) ListPrinters(w http.ResponseWriter, r *http.Request) {
+	printers, err := h.printersHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toLabelPrinterResponses(printers))
+}
+
+// CreatePrinter handles POST /api/v1/admin/printers.
+//
+//	@Summary		Register a label printer
+//	@Description	Register a Zebra or other ZPL label printer by its network address. Jobs are sent as raw ZPL over TCP, to port 9100 unless the address gives another.
+//	@Tags			Admin Labels
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.LabelPrinterRequest			true	"Printer"
+//	@Success		201		{object}	dto.LabelPrinterSuccessResponse	"Printer registered"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody					"Name already in use"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/printers [post]
+func (h *AdminLabelHandler) CreatePrinter(w http.ResponseWriter, r *http.Request) {
+	var req dto.LabelPrinterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	p, err := h.createPrinterHandler.Handle(r.Context(), labelcmd.CreatePrinterCommand{
+		Name:    req.Name,
+		Address: req.Address,
+	})
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toLabelPrinterResponse(p))
+}
+
+// UpdatePrinter handles PUT /api/v1/admin/printers/{printerID}.
+//
+//	@Summary		Update a label printer
+//	@Description	Rename, move or disable a label printer. Queued jobs for a disabled printer wait until it is enabled again or they run out of attempts.
+//	@Tags			Admin Labels
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			printerID	path		string							true	"Printer ID"
+//	@Param			body		body		dto.UpdateLabelPrinterRequest	true	"Printer"
+//	@Success		200			{object}	dto.LabelPrinterSuccessResponse	"Printer updated"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Printer not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Name already in use"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/printers/{printerID} [put]
+func (h *AdminLabelHandler) UpdatePrinter(w http.ResponseWriter, r *http.Request) {
+	printerID, ok := uuidParam(r, "printerID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid printer ID")
+		return
+	}
+	var req dto.UpdateLabelPrinterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	p, err := h.updatePrinterHandler.Handle(r.Context(), labelcmd.UpdatePrinterCommand{
+		PrinterID: printerID,
+		Name:      req.Name,
+		Address:   req.Address,
+		Active:    req.Active,
+	})
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLabelPrinterResponse(p))
+}
+
+// OrderLabels handles GET /api/v1/admin/orders/{orderID}/labels.
+//
+//	@Summary		Render an order's labels
+//	@Description	Render a label for every line of an order, with its weight, price, the lots it was picked from, their earliest use-by date, allergens and a halal logo if every lot is covered by a halal certificate. PDF prints on office printers; ZPL can be sent to a label printer by hand.
+//	@Tags			Admin Labels
+//	@Produce		application/pdf
+//	@Produce		text/plain
+//	@Security		BearerAuth
+//	@Param			orderID		path		string			true	"Order ID"
+//	@Param			format		query		string			false	"pdf or zpl (default pdf)"
+//	@Param			template	query		string			false	"standard or large (default from LABEL_TEMPLATE)"
+//	@Success		200			{file}		file			"Labels"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody	"Order not found"
+//	@Failure		422			{object}	dto.ErrorBody	"Unknown template"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/orders/{orderID}/labels [get]
+func (h *AdminLabelHandler) OrderLabels(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	h.render(w, r, labelling.Source{OrderID: &orderID}, "order-"+orderID.String())
+}
+
+// LotLabels handles GET /api/v1/admin/inventory/lots/{lotID}/labels.
+//
+//	@Summary		Render a lot's pack labels
+//	@Description	Render a label for each pack weighed out of a lot, priced at the product's PLU item. Use-by is the lot's expiry, or the item's shelf life from today if the lot has none.
+//	@Tags			Admin Labels
+//	@Produce		application/pdf
+//	@Produce		text/plain
+//	@Security		BearerAuth
+//	@Param			lotID		path		string			true	"Lot ID"
+//	@Param			packs		query		string			true	"Pack weights in grams, separated by commas"
+//	@Param			format		query		string			false	"pdf or zpl (default pdf)"
+//	@Param			template	query		string			false	"standard or large (default from LABEL_TEMPLATE)"
+//	@Success		200			{file}		file			"Labels"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody	"Lot not found"
+//	@Failure		409			{object}	dto.ErrorBody	"PLU item withdrawn"
+//	@Failure		422			{object}	dto.ErrorBody	"Packs cannot be labelled"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/inventory/lots/{lotID}/labels [get]
+func (h *AdminLabelHandler) LotLabels(w http.ResponseWriter, r *http.Request) {
+	lotID, ok := uuidParam(r, "lotID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid lot ID")
+		return
+	}
+	packs, ok := packWeights(r.URL.Query().Get("packs"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "packs must be weights in grams separated by commas")
+		return
+	}
+
+	h.render(w, r, labelling.Source{LotID: &lotID, Packs: packs}, "lot-"+lotID.String())
+}
+
+// render writes the labels for src in the format and template the query
+// asks for.
+func (h *AdminLabelHandler) render(w http.ResponseWriter, r *http.Request, src labelling.Source, name string) {
+	q := r.URL.Query()
+	format := label.FormatPDF
+	if raw := q.Get("format"); raw != "" {
+		format = label.Format(raw)
+	}
+	if format != label.FormatPDF && format != label.FormatZPL {
+		httpresponse.Error(w, http.StatusBadRequest, "format must be pdf or zpl")
+		return
+	}
+
+	out, err := h.renderHandler.Handle(r.Context(), src, format, q.Get("template"))
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	contentType := "application/pdf"
+	if format == label.FormatZPL {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", "labels-"+name+"."+string(format)))
+	w.Header().Set("Content-Length", strconv.Itoa(len(out)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+// PrintOrderLabels handles POST /api/v1/admin/orders/{orderID}/labels/print.
+//
+//	@Summary		Print an order's labels
+//	@Description	Render a label for every line of an order to ZPL and send it to a label printer. If the printer does not take the job it stays queued and the dispatcher retries it.
+//	@Tags			Admin Labels
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Param			body	body		dto.PrintOrderLabelsRequest	true	"Printer and template"
+//	@Success		201		{object}	dto.PrintJobSuccessResponse	"Print job"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Order or printer not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Printer disabled"
+//	@Failure		422		{object}	dto.ErrorBody				"Unknown template"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID}/labels/print [post]
+func (h *AdminLabelHandler) PrintOrderLabels(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	var req dto.PrintOrderLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	h.print(w, r, req.PrinterID, req.Template, labelling.Source{OrderID: &orderID})
+}
+
+// PrintLotLabels handles POST /api/v1/admin/inventory/lots/{lotID}/labels/print.
+//
+//	@Summary		Print a lot's pack labels
+//	@Description	Render a label for each pack weighed out of a lot to ZPL and send it to a label printer. If the printer does not take the job it stays queued and the dispatcher retries it.
+//	@Tags			Admin Labels
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			lotID	path		string						true	"Lot ID"
+//	@Param			body	body		dto.PrintLotLabelsRequest	true	"Printer, template and packs"
+//	@Success		201		{object}	dto.PrintJobSuccessResponse	"Print job"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Lot or printer not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Printer disabled or PLU item withdrawn"
+//	@Failure		422		{object}	dto.ErrorBody				"Packs cannot be labelled"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/inventory/lots/{lotID}/labels/print [post]
+func (h *AdminLabelHandler) PrintLotLabels(w http.ResponseWriter, r *http.Request) {
+	lotID, ok := uuidParam(r, "lotID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid lot ID")
+		return
+	}
+	var req dto.PrintLotLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	h.print(w, r, req.PrinterID, req.Template, labelling.Source{LotID: &lotID, Packs: req.Packs})
+}
+
+func (h *AdminLabelHandler) print(w http.ResponseWriter, r *http.Request, printer, template string, src labelling.Source) {
+	printerID, err := uuid.Parse(printer)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid printer_id")
+		return
+	}
+
+	j, err := h.printHandler.Handle(r.Context(), labelcmd.PrintLabelsCommand{
+		PrinterID: printerID,
+		Source:    src,
+		Template:  template,
+	})
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toPrintJobResponse(j))
+}
+
+// ListJobs handles GET /api/v1/admin/print-jobs.
+//
+//	@Summary		List print jobs
+//	@Description	List print jobs, newest first, optionally only those of one printer or in one status.
+//	@Tags			Admin Labels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			printer_id	query		string							false	"Printer ID"
+//	@Param			status		query		string							false	"queued, printed or failed"
+//	@Param			limit		query		int								false	"Maximum number of jobs"
+//	@Success		200			{object}	dto.PrintJobListSuccessResponse	"Print jobs"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid filter"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/print-jobs [get]
+func (h *AdminLabelHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var filter label.JobFilter
+	var ok bool
+	if filter.PrinterID, ok = optionalUUID(q.Get("printer_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid printer_id")
+		return
+	}
+	if raw := q.Get("status"); raw != "" {
+		s, err := label.ParseJobStatus(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Status = &s
+	}
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+
+	jobs, err := h.jobsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toPrintJobResponses(jobs))
+}
+
+// DispatchJobs handles POST /api/v1/admin/print-jobs/dispatch.
+//
+//	@Summary		Run the print queue
+//	@Description	Send every queued print job that is due to its printer. A printer that cannot be reached is tried again later; a job is given up on after PRINT_MAX_ATTEMPTS attempts. Meant to run every minute or so; overlapping runs never send the same job twice.
+//	@Tags			Admin Labels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			at	query		string								false	"RFC 3339 time to run as of (default now)"
+//	@Success		200	{object}	dto.PrintDispatchSuccessResponse	"Dispatch run"
+//	@Failure		400	{object}	dto.ErrorBody						"Invalid time"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/print-jobs/dispatch [post]
+func (h *AdminLabelHandler) DispatchJobs(w http.ResponseWriter, r *http.Request) {
+	at := time.Now()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "at must be an RFC 3339 time")
+			return
+		}
+		at = t
+	}
+
+	result, err := h.dispatchHandler.Handle(r.Context(), at)
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.PrintDispatchResponse{
+		Printed:  result.Printed,
+		Retrying: result.Retrying,
+		Failed:   result.Failed,
+	})
+}
+
+// RetryJob handles POST /api/v1/admin/print-jobs/{jobID}/retry.
+//
+//	@Summary		Retry a print job
+//	@Description	Send a print job that was given up on again, with a fresh set of attempts. If the printer still does not take it, it stays queued for the dispatcher.
+//	@Tags			Admin Labels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			jobID	path		string						true	"Print job ID"
+//	@Success		200		{object}	dto.PrintJobSuccessResponse	"Print job"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid job ID"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Print job not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Print job has not failed"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/print-jobs/{jobID}/retry [post]
+func (h *AdminLabelHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := uuidParam(r, "jobID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid job ID")
+		return
+	}
+
+	j, err := h.retryHandler.Handle(r.Context(), jobID)
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPrintJobResponse(j))
+}
+
+// packWeights parses a comma-separated list of pack weights in grams.
+func packWeights(raw string) ([]int64, bool) {
+	if raw == "" {
+		return nil, false
+	}
+	parts := strings.Split(raw, ",")
+	packs := make([]int64, 0, len(parts))
+	for _, p := range parts {
+		g, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		packs = append(packs, g)
+	}
+	return packs, true
+}
+
+func toLabelPrinterResponse(p *label.Printer) dto.LabelPrinterResponse {
+	return dto.LabelPrinterResponse{
+		ID:        p.ID().String(),
+		Name:      p.Name(),
+		Address:   p.Address(),
+		Active:    p.Active(),
+		CreatedAt: p.CreatedAt(),
+		UpdatedAt: p.UpdatedAt(),
+	}
+}
+
+func toLabelPrinterResponses(printers []*label.Printer) []dto.LabelPrinterResponse {
+	resp := make([]dto.LabelPrinterResponse, 0, len(printers))
+	for _, p := range printers {
+		resp = append(resp, toLabelPrinterResponse(p))
+	}
+	return resp
+}
+
+func toPrintJobResponse(j *label.Job) dto.PrintJobResponse {
+	return dto.PrintJobResponse{
+		ID:            j.ID().String(),
+		PrinterID:     j.PrinterID().String(),
+		Source:        string(j.Source()),
+		SourceID:      j.SourceID().String(),
+		Template:      j.Template(),
+		Labels:        j.Labels(),
+		Status:        string(j.Status()),
+		Attempts:      j.Attempts(),
+		LastError:     j.LastError(),
+		NextAttemptAt: j.NextAttemptAt(),
+		PrintedAt:     j.PrintedAt(),
+		CreatedAt:     j.CreatedAt(),
+		UpdatedAt:     j.UpdatedAt(),
+	}
+}
+
+func toPrintJobResponses(jobs []*label.Job) []dto.PrintJobResponse {
+	resp := make([]dto.PrintJobResponse, 0, len(jobs))
+	for _, j := range jobs {
+		resp = append(resp, toPrintJobResponse(j))
+	}
+	return resp
+}
+
+func writeLabelError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, label.ErrPrinterNotFound),
+		errors.Is(err, label.ErrJobNotFound),
+		errors.Is(err, order.ErrOrderNotFound),
+		errors.Is(err, inventory.ErrLotNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, label.ErrDuplicatePrinter),
+		errors.Is(err, label.ErrPrinterInactive),
+		errors.Is(err, label.ErrJobNotFailed),
+		errors.Is(err, plu.ErrItemInactive):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, label.ErrNoLabels),
+		errors.Is(err, label.ErrTemplateNotFound),
+		errors.Is(err, label.ErrInvalidFormat),
+		errors.Is(err, label.ErrEmptyPrinterName),
+		errors.Is(err, label.ErrInvalidAddress),
+		errors.Is(err, label.ErrEmptyJob),
+		errors.Is(err, label.ErrInvalidPackWeight),
+		errors.Is(err, label.ErrPacksExceedLot),
+		errors.Is(err, label.ErrProductNotOnPLU):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
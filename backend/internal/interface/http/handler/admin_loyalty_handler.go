@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	loyaltycmd "github.com/katerji/butchery-app/backend/internal/application/loyalty/commands"
+	loyaltyqry "github.com/katerji/butchery-app/backend/internal/application/loyalty/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminLoyaltyHandler handles back-office management of the loyalty
+// scheme: the earn and redeem rules, customers' points, and the daily
+// expiry and birthday runs.
+type AdminLoyaltyHandler struct {
+	rulesHandler       *loyaltyqry.GetRulesHandler
+	updateRulesHandler *loyaltycmd.UpdateRulesHandler
+	bonusHandler       *loyaltycmd.SetProductBonusHandler
+	accountHandler     *loyaltyqry.GetAccountHandler
+	adjustHandler      *loyaltycmd.AdjustPointsHandler
+	rebuildHandler     *loyaltycmd.RebuildBalanceHandler
+	expireHandler      *loyaltycmd.ExpirePointsHandler
+	birthdayHandler    *loyaltycmd.AwardBirthdayBonusesHandler
+}
+
+// NewAdminLoyaltyHandler creates a new AdminLoyaltyHandler.
+func NewAdminLoyaltyHandler(
+	rulesHandler *loyaltyqry.GetRulesHandler,
+	updateRulesHandler *loyaltycmd.UpdateRulesHandler,
+	bonusHandler *loyaltycmd.SetProductBonusHandler,
+	accountHandler *loyaltyqry.GetAccountHandler,
+	adjustHandler *loyaltycmd.AdjustPointsHandler,
+	rebuildHandler *loyaltycmd.RebuildBalanceHandler,
+	expireHandler *loyaltycmd.ExpirePointsHandler,
+	birthdayHandler *loyaltycmd.AwardBirthdayBonusesHandler,
+) *AdminLoyaltyHandler {
+	return &AdminLoyaltyHandler{
+		rulesHandler:       rulesHandler,
+		updateRulesHandler: updateRulesHandler,
+		bonusHandler:       bonusHandler,
+		accountHandler:     accountHandler,
+		adjustHandler:      adjustHandler,
+		rebuildHandler:     rebuildHandler,
+		expireHandler:      expireHandler,
+		birthdayHandler:    birthdayHandler,
+	}
+}
+
+// GetRules handles GET /api/v1/admin/loyalty/rules.
+//
+//	@Summary		Get loyalty rules
+//	@Description	Get how customers earn and spend points, with every product bonus.
+//	@Tags			Admin Loyalty
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.LoyaltyRulesSuccessResponse	"Loyalty rules"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/loyalty/rules [get]
+func (h *AdminLoyaltyHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.rulesHandler.Handle(r.Context())
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLoyaltyRulesResponse(rules))
+}
+
+// UpdateRules handles PUT /api/v1/admin/loyalty/rules.
+//
+//	@Summary		Update loyalty rules
+//	@Description	Change the base earn rate, what a point is worth at checkout, the minimum redemption, the birthday bonus and how long points last. Points already earned keep the expiry they were given. Needs the manage_pricing permission.
+//	@Tags			Admin Loyalty
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.LoyaltyRulesRequest			true	"Rules"
+//	@Success		200		{object}	dto.LoyaltyRulesSuccessResponse	"Rules updated"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/loyalty/rules [put]
+func (h *AdminLoyaltyHandler) UpdateRules(w http.ResponseWriter, r *http.Request) {
+	var req dto.LoyaltyRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rules, err := h.updateRulesHandler.Handle(r.Context(), loyaltycmd.UpdateRulesCommand{
+		PointsPerUnit:   req.PointsPerUnit,
+		UnitCents:       req.UnitCents,
+		PointValueCents: req.PointValueCents,
+		MinRedeemPoints: req.MinRedeemPoints,
+		BirthdayBonus:   req.BirthdayBonus,
+		ExpiryDays:      req.ExpiryDays,
+		ActorID:         middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLoyaltyRulesResponse(rules))
+}
+
+// SetProductBonus handles PUT /api/v1/admin/loyalty/products/{productID}.
+//
+//	@Summary		Set product points bonus
+//	@Description	Set the extra points per unit spent a product earns on top of the base rate. Zero removes the bonus. Needs the manage_pricing permission.
+//	@Tags			Admin Loyalty
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			productID	path		string									true	"Product ID"
+//	@Param			body		body		dto.LoyaltyProductBonusRequest			true	"Bonus"
+//	@Success		200			{object}	dto.LoyaltyProductBonusSuccessResponse	"Bonus set"
+//	@Failure		400			{object}	dto.ErrorBody							"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody							"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/loyalty/products/{productID} [put]
+func (h *AdminLoyaltyHandler) SetProductBonus(w http.ResponseWriter, r *http.Request) {
+	productID, ok := uuidParam(r, "productID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	var req dto.LoyaltyProductBonusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err := h.bonusHandler.Handle(r.Context(), loyaltycmd.SetProductBonusCommand{
+		ProductID: productID,
+		Points:    req.PointsPerUnit,
+		ActorID:   middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.LoyaltyProductBonusResponse{ProductID: productID.String(), PointsPerUnit: req.PointsPerUnit})
+}
+
+// GetAccount handles GET /api/v1/admin/customers/{customerID}/loyalty.
+//
+//	@Summary		Get a customer's points
+//	@Description	Get a customer's points balance, what it is worth at checkout, and every entry behind it, oldest first.
+//	@Tags			Admin Loyalty
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			customerID	path		string								true	"Customer ID"
+//	@Success		200			{object}	dto.LoyaltyAccountSuccessResponse	"Loyalty account"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid customer ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/customers/{customerID}/loyalty [get]
+func (h *AdminLoyaltyHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := uuidParam(r, "customerID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid customer ID")
+		return
+	}
+
+	account, err := h.accountHandler.Handle(r.Context(), customerID)
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLoyaltyAccountResponse(account))
+}
+
+// AdjustPoints handles POST /api/v1/admin/customers/{customerID}/loyalty/adjustments.
+//
+//	@Summary		Adjust a customer's points
+//	@Description	Credit points by hand, e.g. as a goodwill gesture, or take them off with negative points. A note saying why is required; credits expire like earned points, and a debit cannot take the balance below zero. Needs the manage_pricing permission.
+//	@Tags			Admin Loyalty
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			customerID	path		string							true	"Customer ID"
+//	@Param			body		body		dto.LoyaltyAdjustmentRequest	true	"Adjustment"
+//	@Success		201			{object}	dto.LoyaltyEntrySuccessResponse	"Adjustment recorded"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Customer not found"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/customers/{customerID}/loyalty/adjustments [post]
+func (h *AdminLoyaltyHandler) AdjustPoints(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := uuidParam(r, "customerID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid customer ID")
+		return
+	}
+	var req dto.LoyaltyAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	e, err := h.adjustHandler.Handle(r.Context(), loyaltycmd.AdjustPointsCommand{
+		CustomerID: customerID,
+		Points:     req.Points,
+		Note:       req.Note,
+		ActorID:    claims.SubjectID,
+	})
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toLoyaltyEntryResponse(e))
+}
+
+// RebuildBalance handles POST /api/v1/admin/customers/{customerID}/loyalty/rebuild.
+//
+//	@Summary		Rebuild a customer's balance
+//	@Description	Recompute a customer's balance from their ledger entries and replace the stored balance with it.
+//	@Tags			Admin Loyalty
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			customerID	path		string								true	"Customer ID"
+//	@Success		200			{object}	dto.LoyaltyBalanceSuccessResponse	"Balance rebuilt"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid customer ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Customer not found"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/customers/{customerID}/loyalty/rebuild [post]
+func (h *AdminLoyaltyHandler) RebuildBalance(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := uuidParam(r, "customerID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid customer ID")
+		return
+	}
+
+	b, err := h.rebuildHandler.Handle(r.Context(), customerID)
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLoyaltyBalanceResponse(b))
+}
+
+// ExpirePoints handles POST /api/v1/admin/loyalty/expiry.
+//
+//	@Summary		Expire points
+//	@Description	Take expired points off every customer's balance. Meant to run daily; running it again does no harm.
+//	@Tags			Admin Loyalty
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			at	query		string								false	"RFC 3339 time to expire points as of (default now)"
+//	@Success		200	{object}	dto.LoyaltyExpirySuccessResponse	"Points expired"
+//	@Failure		400	{object}	dto.ErrorBody						"Invalid time"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/loyalty/expiry [post]
+func (h *AdminLoyaltyHandler) ExpirePoints(w http.ResponseWriter, r *http.Request) {
+	at := time.Now()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "at must be an RFC 3339 time")
+			return
+		}
+		at = t
+	}
+
+	run, err := h.expireHandler.Handle(r.Context(), loyaltycmd.ExpirePointsCommand{At: at})
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.LoyaltyExpiryResponse{Customers: run.Customers, Points: run.Points})
+}
+
+// AwardBirthdayBonuses handles POST /api/v1/admin/loyalty/birthday-bonuses.
+//
+//	@Summary		Award birthday bonuses
+//	@Description	Credit the birthday bonus to every customer whose birthday is on the given day, today by default. Meant to run daily; each customer gets the bonus at most once a year.
+//	@Tags			Admin Loyalty
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			date	query		string								false	"Day to award for, YYYY-MM-DD in the shop's time zone (default today)"
+//	@Success		200		{object}	dto.BirthdayBonusesSuccessResponse	"Bonuses awarded"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/loyalty/birthday-bonuses [post]
+func (h *AdminLoyaltyHandler) AwardBirthdayBonuses(w http.ResponseWriter, r *http.Request) {
+	awarded, err := h.birthdayHandler.Handle(r.Context(), loyaltycmd.AwardBirthdayBonusesCommand{Date: r.URL.Query().Get("date")})
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.BirthdayBonusesResponse{Awarded: awarded})
+}
+
+func toLoyaltyRulesResponse(rules loyalty.Rules) dto.LoyaltyRulesResponse {
+	resp := dto.LoyaltyRulesResponse{
+		PointsPerUnit:   rules.PointsPerUnit,
+		UnitCents:       rules.UnitCents,
+		PointValueCents: rules.PointValueCents,
+		MinRedeemPoints: rules.MinRedeemPoints,
+		BirthdayBonus:   rules.BirthdayBonus,
+		ExpiryDays:      rules.ExpiryDays,
+		ProductBonuses:  make([]dto.LoyaltyProductBonusResponse, 0, len(rules.ProductBonuses)),
+	}
+	for id, points := range rules.ProductBonuses {
+		resp.ProductBonuses = append(resp.ProductBonuses, dto.LoyaltyProductBonusResponse{ProductID: id.String(), PointsPerUnit: points})
+	}
+	slices.SortFunc(resp.ProductBonuses, func(a, b dto.LoyaltyProductBonusResponse) int {
+		return strings.Compare(a.ProductID, b.ProductID)
+	})
+	return resp
+}
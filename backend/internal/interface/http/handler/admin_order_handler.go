@@ -0,0 +1,684 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	auditqry "github.com/katerji/butchery-app/backend/internal/application/audit/queries"
+	ordercmd "github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	orderqry "github.com/katerji/butchery-app/backend/internal/application/order/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/audit"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminOrderHandler handles customer orders and the refunds made against them.
+type AdminOrderHandler struct {
+	createHandler  *ordercmd.CreateOrderHandler
+	getHandler     *orderqry.GetOrderHandler
+	refundHandler  *ordercmd.RequestRefundHandler
+	approveHandler *ordercmd.ApproveRefundHandler
+	rejectHandler  *ordercmd.RejectRefundHandler
+	receiptHandler *orderqry.GetRefundReceiptHandler
+	auditHandler   *auditqry.ListEntriesHandler
+	stageHandler   *ordercmd.AdvanceStageHandler
+	watchHandler   *orderqry.WatchOrdersHandler
+	cancelHandler  *ordercmd.CancelOrderHandler
+}
+
+// NewAdminOrderHandler creates a new AdminOrderHandler.
+func NewAdminOrderHandler(
+	createHandler *ordercmd.CreateOrderHandler,
+	getHandler *orderqry.GetOrderHandler,
+	refundHandler *ordercmd.RequestRefundHandler,
+	approveHandler *ordercmd.ApproveRefundHandler,
+	rejectHandler *ordercmd.RejectRefundHandler,
+	receiptHandler *orderqry.GetRefundReceiptHandler,
+	auditHandler *auditqry.ListEntriesHandler,
+	stageHandler *ordercmd.AdvanceStageHandler,
+	watchHandler *orderqry.WatchOrdersHandler,
+	cancelHandler *ordercmd.CancelOrderHandler,
+) *AdminOrderHandler {
+	return &AdminOrderHandler{
+		createHandler:  createHandler,
+		getHandler:     getHandler,
+		refundHandler:  refundHandler,
+		approveHandler: approveHandler,
+		rejectHandler:  rejectHandler,
+		receiptHandler: receiptHandler,
+		auditHandler:   auditHandler,
+		stageHandler:   stageHandler,
+		watchHandler:   watchHandler,
+		cancelHandler:  cancelHandler,
+	}
+}
+
+// CreateOrder handles POST /api/v1/admin/orders.
+//
+//	@Summary		Record an order
+//	@Description	Record a customer's order of weighed products, e.g. one taken over the phone. Weights are in grams and prices in cents per kg. Running promotions and then any loyalty points redeemed come off each line before VAT, and the order earns points on what is left to pay; a coupon that does not apply is refused with the reason.
+//	@Tags			Admin Orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.CreateOrderRequest		true	"Order"
+//	@Success		201		{object}	dto.OrderSuccessResponse	"Order recorded"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Customer not found"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders [post]
+func (h *AdminOrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	customerID, err := uuid.Parse(req.CustomerID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "customer_id is required")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+	lines := make([]ordercmd.LineInput, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		productID, err := uuid.Parse(l.ProductID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+			return
+		}
+		lines = append(lines, ordercmd.LineInput{
+			ProductID:       productID,
+			Description:     l.Description,
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+		})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	o, err := h.createHandler.Handle(r.Context(), ordercmd.CreateOrderCommand{
+		CustomerID:   customerID,
+		BranchID:     branchID,
+		Lines:        lines,
+		Coupons:      req.Coupons,
+		RedeemPoints: req.RedeemPoints,
+		ActorID:      &claims.SubjectID,
+	})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toOrderResponse(o))
+}
+
+// GetOrder handles GET /api/v1/admin/orders/{orderID}.
+//
+//	@Summary		Get an order
+//	@Description	Get an order with its lines and refunds.
+//	@Tags			Admin Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Success		200		{object}	dto.OrderSuccessResponse	"Order"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Order not found"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID} [get]
+func (h *AdminOrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	o, err := h.getHandler.Handle(r.Context(), orderID)
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toOrderResponse(o))
+}
+
+// AdvanceStage handles PUT /api/v1/admin/orders/{orderID}/stage.
+//
+//	@Summary		Move an order on
+//	@Description	Move an order on through the cutting room: received, cutting, ready (for collection or dispatch) and completed. Orders only move forward, though they may skip stages. Once an order is ready, the stock confirmed for it is sold from its lots, soonest to expire first. The customer and the cutting-room board see the change straight away on their order streams.
+//	@Tags			Admin Orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Param			body	body		dto.AdvanceStageRequest		true	"Stage"
+//	@Success		200		{object}	dto.OrderSuccessResponse	"Order moved on"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Order not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Order already at or past that stage, changed concurrently, or not enough stock in its lots"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID}/stage [put]
+func (h *AdminOrderHandler) AdvanceStage(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	var req dto.AdvanceStageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	o, err := h.stageHandler.Handle(r.Context(), ordercmd.AdvanceStageCommand{
+		OrderID: orderID,
+		Stage:   req.Stage,
+		ActorID: &claims.SubjectID,
+	})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toOrderResponse(o))
+}
+
+// CancelOrder handles POST /api/v1/admin/orders/{orderID}/cancel.
+//
+//	@Summary		Cancel an order
+//	@Description	Cancel an order the cutting room has not started on. Its reserved stock goes back on sale, the hold on the customer's card is voided, and any coupons and loyalty points it used are given back. An order already paid for is refunded instead. Cancelling an order again finishes anything left undone the first time.
+//	@Tags			Admin Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Success		200		{object}	dto.OrderSuccessResponse	"Order cancelled"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Order not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Order already cut, refunded or paid for"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/orders/{orderID}/cancel [post]
+func (h *AdminOrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	o, err := h.cancelHandler.Handle(r.Context(), ordercmd.CancelOrderCommand{OrderID: orderID, ActorID: &claims.SubjectID})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toOrderResponse(o))
+}
+
+// StreamOrders handles GET /api/v1/admin/orders/stream.
+//
+//	@Summary		Stream the cutting-room board
+//	@Description	Stream changes to orders at the admin's branches as server-sent events, for the cutting-room board. Events are the same as on /me/orders/stream, including replay from Last-Event-ID after a reconnect. Changes recorded by any API instance are sent.
+//	@Tags			Admin Orders
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			branch_id		query		string			false	"Only orders at this branch"
+//	@Param			Last-Event-ID	header		string			false	"ID of the last event received"
+//	@Param			last_event_id	query		string			false	"ID of the last event received, if the header cannot be set"
+//	@Success		200				{string}	string			"Event stream"
+//	@Failure		400				{object}	dto.ErrorBody	"Invalid branch or last event ID"
+//	@Failure		401				{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		500				{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/orders/stream [get]
+func (h *AdminOrderHandler) StreamOrders(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := optionalUUID(r.URL.Query().Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	if branchID != nil {
+		if err := branch.ScopeFrom(r.Context()).Check(*branchID); err != nil {
+			writeOrderError(w, err)
+			return
+		}
+	}
+	lastID, ok := lastEventID(r)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid last event ID")
+		return
+	}
+
+	events, err := h.watchHandler.Handle(r.Context(), orderqry.WatchOrdersQuery{BranchID: branchID, LastEventID: lastID})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+	streamOrderEvents(w, events)
+}
+
+// RequestRefund handles POST /api/v1/admin/orders/{orderID}/refunds.
+//
+//	@Summary		Refund an order
+//	@Description	Refund one line, the price of the weight a line was short, or whatever is left of the whole order, through the payment the order was captured with. Refunds above the approval threshold wait for a manager unless a manager asks for them. Retrying with the same Idempotency-Key returns the original refund.
+//	@Tags			Admin Orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID			path		string							true	"Order ID"
+//	@Param			Idempotency-Key	header		string							true	"Client-chosen key that makes retries safe"
+//	@Param			body			body		dto.RequestOrderRefundRequest	true	"Refund"
+//	@Success		201				{object}	dto.OrderRefundSuccessResponse	"Refund issued or waiting for approval"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody					"Order not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Order not paid or changed concurrently"
+//	@Failure		422				{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/orders/{orderID}/refunds [post]
+func (h *AdminOrderHandler) RequestRefund(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	key := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if key == "" {
+		httpresponse.Error(w, http.StatusBadRequest, "Idempotency-Key header is required")
+		return
+	}
+	var req dto.RequestOrderRefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	var lineID *uuid.UUID
+	if req.LineID != nil {
+		if lineID, ok = optionalUUID(*req.LineID); !ok {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid line_id")
+			return
+		}
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	refund, err := h.refundHandler.Handle(r.Context(), ordercmd.RequestRefundCommand{
+		OrderID:        orderID,
+		Kind:           req.Kind,
+		LineID:         lineID,
+		DeliveredGrams: req.DeliveredGrams,
+		Reason:         req.Reason,
+		Note:           req.Note,
+		IdempotencyKey: key,
+		ActorID:        claims.SubjectID,
+	})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toOrderRefundResponse(refund))
+}
+
+// ApproveRefund handles POST /api/v1/admin/orders/{orderID}/refunds/{refundID}/approve.
+//
+//	@Summary		Approve a refund
+//	@Description	Approve a refund waiting for a manager and issue it. Needs the manager role. Approving a refund whose issue failed tries again.
+//	@Tags			Admin Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID		path		string							true	"Order ID"
+//	@Param			refundID	path		string							true	"Refund ID"
+//	@Success		200			{object}	dto.OrderRefundSuccessResponse	"Refund issued"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid ID"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Manager permission required"
+//	@Failure		404			{object}	dto.ErrorBody					"Order or refund not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Refund is not waiting for approval"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/orders/{orderID}/refunds/{refundID}/approve [post]
+func (h *AdminOrderHandler) ApproveRefund(w http.ResponseWriter, r *http.Request) {
+	cmd, ok := decideRefundCommand(w, r)
+	if !ok {
+		return
+	}
+
+	refund, err := h.approveHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toOrderRefundResponse(refund))
+}
+
+// RejectRefund handles POST /api/v1/admin/orders/{orderID}/refunds/{refundID}/reject.
+//
+//	@Summary		Reject a refund
+//	@Description	Turn down a refund waiting for a manager. Needs the manager role.
+//	@Tags			Admin Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID		path		string							true	"Order ID"
+//	@Param			refundID	path		string							true	"Refund ID"
+//	@Success		200			{object}	dto.OrderRefundSuccessResponse	"Refund rejected"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid ID"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Manager permission required"
+//	@Failure		404			{object}	dto.ErrorBody					"Order or refund not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Refund is not waiting for approval"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/orders/{orderID}/refunds/{refundID}/reject [post]
+func (h *AdminOrderHandler) RejectRefund(w http.ResponseWriter, r *http.Request) {
+	cmd, ok := decideRefundCommand(w, r)
+	if !ok {
+		return
+	}
+
+	refund, err := h.rejectHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toOrderRefundResponse(refund))
+}
+
+// RefundReceipt handles GET /api/v1/admin/orders/{orderID}/refunds/{refundID}/receipt.
+//
+//	@Summary		Get a refund receipt
+//	@Description	Get the receipt for an issued refund.
+//	@Tags			Admin Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID		path		string								true	"Order ID"
+//	@Param			refundID	path		string								true	"Refund ID"
+//	@Success		200			{object}	dto.RefundReceiptSuccessResponse	"Refund receipt"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Order or refund not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Refund has not been issued"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/orders/{orderID}/refunds/{refundID}/receipt [get]
+func (h *AdminOrderHandler) RefundReceipt(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	refundID, ok := uuidParam(r, "refundID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid refund ID")
+		return
+	}
+
+	receipt, err := h.receiptHandler.Handle(r.Context(), orderID, refundID)
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	resp := dto.RefundReceiptResponse{
+		ReceiptNumber:      receipt.Refund.ReceiptNumber(),
+		IssuedAt:           *receipt.Refund.IssuedAt(),
+		OrderID:            receipt.Order.ID().String(),
+		CustomerID:         receipt.Order.CustomerID().String(),
+		RefundID:           receipt.Refund.ID().String(),
+		Kind:               string(receipt.Refund.Kind()),
+		Reason:             string(receipt.Refund.Reason()),
+		Note:               receipt.Refund.Note(),
+		DeliveredGrams:     receipt.Refund.DeliveredGrams(),
+		AmountCents:        receipt.Refund.AmountCents(),
+		Currency:           receipt.Currency,
+		OrderTotalCents:    receipt.Order.TotalCents(),
+		OrderRefundedCents: receipt.Order.RefundedCents(),
+	}
+	if receipt.Line != nil {
+		line := toOrderLineResponse(*receipt.Line)
+		resp.Line = &line
+	}
+	httpresponse.Success(w, resp)
+}
+
+// Audit handles GET /api/v1/admin/orders/{orderID}/audit.
+//
+//	@Summary		Get an order's audit trail
+//	@Description	List what admins have done to an order, oldest first, including every refund requested, approved, rejected and issued.
+//	@Tags			Admin Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string							true	"Order ID"
+//	@Success		200		{object}	dto.AuditEntriesSuccessResponse	"Audit entries"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/orders/{orderID}/audit [get]
+func (h *AdminOrderHandler) Audit(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	entries, err := h.auditHandler.Handle(r.Context(), "order", orderID)
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	resp := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, toAuditEntryResponse(e))
+	}
+	httpresponse.Success(w, resp)
+}
+
+func decideRefundCommand(w http.ResponseWriter, r *http.Request) (ordercmd.DecideRefundCommand, bool) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return ordercmd.DecideRefundCommand{}, false
+	}
+	refundID, ok := uuidParam(r, "refundID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid refund ID")
+		return ordercmd.DecideRefundCommand{}, false
+	}
+	claims := middleware.ClaimsFromContext(r.Context())
+	return ordercmd.DecideRefundCommand{OrderID: orderID, RefundID: refundID, ActorID: claims.SubjectID}, true
+}
+
+func toOrderResponse(o *order.Order) dto.OrderResponse {
+	resp := dto.OrderResponse{
+		ID:              o.ID().String(),
+		CustomerID:      o.CustomerID().String(),
+		BranchID:        o.BranchID().String(),
+		Status:          string(o.Status()),
+		Stage:           string(o.Stage()),
+		Lines:           make([]dto.OrderLineResponse, 0, len(o.Lines())),
+		TotalCents:      o.TotalCents(),
+		RefundedCents:   o.RefundedCents(),
+		RefundableCents: o.RefundableCents(),
+		Refunds:         make([]dto.OrderRefundResponse, 0, len(o.Refunds())),
+		CreatedAt:       o.CreatedAt(),
+		UpdatedAt:       o.UpdatedAt(),
+	}
+	for _, l := range o.Lines() {
+		resp.Lines = append(resp.Lines, toOrderLineResponse(l))
+	}
+	if d := o.Delivery(); d != nil {
+		resp.Delivery = &dto.OrderDeliveryChargeResponse{
+			ZoneID:        d.ZoneID.String(),
+			FeeCents:      d.FeeCents,
+			TaxCategory:   d.TaxCategory,
+			VATRateBP:     d.VATRateBP,
+			DiscountCents: d.DiscountCents,
+			NetCents:      d.NetCents,
+			VATCents:      d.VATCents,
+			TotalCents:    d.GrossCents,
+		}
+	}
+	for _, r := range o.Refunds() {
+		resp.Refunds = append(resp.Refunds, toOrderRefundResponse(r))
+	}
+	return resp
+}
+
+func toOrderLineResponse(l order.Line) dto.OrderLineResponse {
+	return dto.OrderLineResponse{
+		ID:              l.ID.String(),
+		ProductID:       l.ProductID.String(),
+		Description:     l.Description,
+		Grams:           l.Grams,
+		PricePerKgCents: l.PricePerKgCents,
+		TaxCategory:     l.TaxCategory,
+		VATRateBP:       l.VATRateBP,
+		DiscountCents:   l.DiscountCents,
+		NetCents:        l.NetCents,
+		VATCents:        l.VATCents,
+		TotalCents:      l.TotalCents(),
+		RefundedCents:   l.RefundedCents,
+	}
+}
+
+func toOrderRefundResponse(r *order.Refund) dto.OrderRefundResponse {
+	return dto.OrderRefundResponse{
+		ID:             r.ID().String(),
+		OrderID:        r.OrderID().String(),
+		Kind:           string(r.Kind()),
+		LineID:         uuidString(r.LineID()),
+		DeliveredGrams: r.DeliveredGrams(),
+		Reason:         string(r.Reason()),
+		Note:           r.Note(),
+		AmountCents:    r.AmountCents(),
+		Status:         string(r.Status()),
+		RequestedBy:    r.RequestedBy().String(),
+		DecidedBy:      uuidString(r.DecidedBy()),
+		PaymentID:      uuidString(r.PaymentID()),
+		ReceiptNumber:  optionalString(r.ReceiptNumber()),
+		CreatedAt:      r.CreatedAt(),
+		DecidedAt:      r.DecidedAt(),
+		IssuedAt:       r.IssuedAt(),
+	}
+}
+
+func toAuditEntryResponse(e *audit.Entry) dto.AuditEntryResponse {
+	return dto.AuditEntryResponse{
+		ID:         e.ID.String(),
+		ActorID:    e.ActorID.String(),
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID.String(),
+		Details:    e.Details,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func writeOrderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, order.ErrOrderNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "order not found")
+	case reservationMissing(err):
+		httpresponse.Error(w, http.StatusNotFound, "reservation not found")
+	case errors.Is(err, order.ErrRefundNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "refund not found")
+	case errors.Is(err, customer.ErrCustomerNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "customer not found")
+	case errors.Is(err, customer.ErrAddressNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "address not found")
+	case errors.Is(err, order.ErrApprovalNotPermitted),
+		errors.Is(err, admin.ErrAdminNotFound):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, order.ErrRefundNotPending),
+		errors.Is(err, order.ErrRefundNotApproved),
+		errors.Is(err, order.ErrRefundNotIssued),
+		errors.Is(err, order.ErrIdempotencyKeyReused),
+		errors.Is(err, order.ErrConcurrentUpdate),
+		errors.Is(err, order.ErrStageBackwards),
+		errors.Is(err, order.ErrCannotCancel),
+		errors.Is(err, order.ErrOrderCancelled),
+		errors.Is(err, order.ErrOrderPaid),
+		errors.Is(err, inventory.ErrReservationNotHeld),
+		errors.Is(err, inventory.ErrReservationExpired),
+		errors.Is(err, inventory.ErrReservationNotActive),
+		errors.Is(err, inventory.ErrInsufficientStock),
+		errors.Is(err, fulfilment.ErrReservationNotHeld),
+		errors.Is(err, fulfilment.ErrReservationExpired),
+		errors.Is(err, payment.ErrNotCaptured),
+		errors.Is(err, payment.ErrConcurrentUpdate):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, order.ErrBranchRequired),
+		errors.Is(err, order.ErrNoLines),
+		errors.Is(err, order.ErrInvalidWeight),
+		errors.Is(err, order.ErrNegativePrice),
+		errors.Is(err, order.ErrInvalidVATRate),
+		errors.Is(err, order.ErrInvalidRefundKind),
+		errors.Is(err, order.ErrInvalidReason),
+		errors.Is(err, order.ErrNoteRequired),
+		errors.Is(err, order.ErrEmptyIdempotencyKey),
+		errors.Is(err, order.ErrLineRequired),
+		errors.Is(err, order.ErrUnknownLine),
+		errors.Is(err, order.ErrInvalidDeliveredWeight),
+		errors.Is(err, order.ErrNothingToRefund),
+		errors.Is(err, order.ErrUnbalancedLine),
+		errors.Is(err, order.ErrInvalidDiscount),
+		errors.Is(err, order.ErrInvalidStage),
+		errors.Is(err, order.ErrHoldsAtDifferentBranches),
+		errors.Is(err, order.ErrAddressRequired),
+		errors.Is(err, order.ErrSlotNotForAddress),
+		errors.Is(err, delivery.ErrAddressNotServed),
+		errors.Is(err, delivery.ErrBelowMinimumOrder),
+		errors.Is(err, plu.ErrItemNotFound),
+		errors.Is(err, plu.ErrItemInactive),
+		errors.Is(err, promotion.ErrUnknownCoupon),
+		errors.Is(err, promotion.ErrNotRunning),
+		errors.Is(err, promotion.ErrUsageLimitReached),
+		errors.Is(err, promotion.ErrNotEligible),
+		errors.Is(err, promotion.ErrNotStackable),
+		errors.Is(err, promotion.ErrNothingToDiscount),
+		errors.Is(err, loyalty.ErrRedemptionDisabled),
+		errors.Is(err, loyalty.ErrBelowMinimum),
+		errors.Is(err, loyalty.ErrNothingToRedeem),
+		errors.Is(err, loyalty.ErrInsufficientPoints),
+		errors.Is(err, tax.ErrUnclassifiedProduct),
+		errors.Is(err, tax.ErrNoRate),
+		errors.Is(err, tax.ErrNegativeAmount),
+		errors.Is(err, payment.ErrRefundExceedsCaptured):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+
+	paycmd "github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	payqry "github.com/katerji/butchery-app/backend/internal/application/payment/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminPaymentHandler handles capturing and voiding card payments. Refunds go
+// through the order, so they follow the refund policy.
+type AdminPaymentHandler struct {
+	getHandler     *payqry.GetPaymentHandler
+	captureHandler *paycmd.CapturePaymentHandler
+	voidHandler    *paycmd.VoidPaymentHandler
+}
+
+// NewAdminPaymentHandler creates a new AdminPaymentHandler.
+func NewAdminPaymentHandler(
+	getHandler *payqry.GetPaymentHandler,
+	captureHandler *paycmd.CapturePaymentHandler,
+	voidHandler *paycmd.VoidPaymentHandler,
+) *AdminPaymentHandler {
+	return &AdminPaymentHandler{
+		getHandler:     getHandler,
+		captureHandler: captureHandler,
+		voidHandler:    voidHandler,
+	}
+}
+
+// GetPayment handles GET /api/v1/admin/payments/{paymentID}.
+//
+//	@Summary		Get a payment
+//	@Description	Get a payment with its refunds.
+//	@Tags			Admin Payments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			paymentID	path		string						true	"Payment ID"
+//	@Success		200			{object}	dto.PaymentSuccessResponse	"Payment"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid payment ID"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody				"Payment not found"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/payments/{paymentID} [get]
+func (h *AdminPaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
+	paymentID, ok := uuidParam(r, "paymentID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid payment ID")
+		return
+	}
+
+	detail, err := h.getHandler.Handle(r.Context(), paymentID)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPaymentResponse(detail.Payment, detail.Refunds))
+}
+
+// CapturePayment handles POST /api/v1/admin/payments/{paymentID}/capture.
+//
+//	@Summary		Capture a payment
+//	@Description	Take the total of the order's weighed lines, which may not exceed the authorized amount, and release the rest of the hold. Capturing a captured payment again is a no-op.
+//	@Tags			Admin Payments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			paymentID	path		string						true	"Payment ID"
+//	@Success		200			{object}	dto.PaymentSuccessResponse	"Payment captured"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody				"Payment not found"
+//	@Failure		409			{object}	dto.ErrorBody				"Payment is not authorized"
+//	@Failure		422			{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/payments/{paymentID}/capture [post]
+func (h *AdminPaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request) {
+	paymentID, ok := uuidParam(r, "paymentID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid payment ID")
+		return
+	}
+	p, err := h.captureHandler.Handle(r.Context(), paycmd.CapturePaymentCommand{PaymentID: paymentID})
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPaymentResponse(p, nil))
+}
+
+// VoidPayment handles POST /api/v1/admin/payments/{paymentID}/void.
+//
+//	@Summary		Void a payment
+//	@Description	Release the hold on the customer's card without taking anything.
+//	@Tags			Admin Payments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			paymentID	path		string						true	"Payment ID"
+//	@Success		200			{object}	dto.PaymentSuccessResponse	"Payment voided"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid payment ID"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody				"Payment not found"
+//	@Failure		409			{object}	dto.ErrorBody				"Payment is not authorized"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/payments/{paymentID}/void [post]
+func (h *AdminPaymentHandler) VoidPayment(w http.ResponseWriter, r *http.Request) {
+	paymentID, ok := uuidParam(r, "paymentID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid payment ID")
+		return
+	}
+
+	p, err := h.voidHandler.Handle(r.Context(), paymentID)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPaymentResponse(p, nil))
+}
+
+func toRefundResponse(r *payment.Refund) dto.RefundResponse {
+	return dto.RefundResponse{
+		ID:          r.ID.String(),
+		PaymentID:   r.PaymentID.String(),
+		AmountCents: r.AmountCents,
+		Reason:      r.Reason,
+		ProviderRef: r.ProviderRef,
+		CreatedBy:   uuidString(r.CreatedBy),
+		CreatedAt:   r.CreatedAt,
+	}
+}
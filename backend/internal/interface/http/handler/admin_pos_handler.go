@@ -0,0 +1,858 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	poscmd "github.com/katerji/butchery-app/backend/internal/application/pos/commands"
+	posqry "github.com/katerji/butchery-app/backend/internal/application/pos/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/plu"
+	"github.com/katerji/butchery-app/backend/internal/domain/pos"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminPOSHandler runs the tills at the counter: till sessions, walk-in
+// sales and returns, receipts and Z reports.
+type AdminPOSHandler struct {
+	createTillHandler   *poscmd.CreateTillHandler
+	updateTillHandler   *poscmd.UpdateTillHandler
+	openSessionHandler  *poscmd.OpenSessionHandler
+	closeSessionHandler *poscmd.CloseSessionHandler
+	startSaleHandler    *poscmd.StartSaleHandler
+	addLineHandler      *poscmd.AddSaleLineHandler
+	voidLineHandler     *poscmd.VoidSaleLineHandler
+	paymentHandler      *poscmd.TakePaymentHandler
+	voidSaleHandler     *poscmd.VoidSaleHandler
+	returnHandler       *poscmd.ReturnSaleHandler
+	listTillsHandler    *posqry.ListTillsHandler
+	listSessionsHandler *posqry.ListSessionsHandler
+	reportHandler       *posqry.SessionReportHandler
+	getSaleHandler      *posqry.GetSaleHandler
+	receiptHandler      *posqry.GetReceiptHandler
+}
+
+// NewAdminPOSHandler creates a new AdminPOSHandler.
+func NewAdminPOSHandler(
+	createTillHandler *poscmd.CreateTillHandler,
+	updateTillHandler *poscmd.UpdateTillHandler,
+	openSessionHandler *poscmd.OpenSessionHandler,
+	closeSessionHandler *poscmd.CloseSessionHandler,
+	startSaleHandler *poscmd.StartSaleHandler,
+	addLineHandler *poscmd.AddSaleLineHandler,
+	voidLineHandler *poscmd.VoidSaleLineHandler,
+	paymentHandler *poscmd.TakePaymentHandler,
+	voidSaleHandler *poscmd.VoidSaleHandler,
+	returnHandler *poscmd.ReturnSaleHandler,
+	listTillsHandler *posqry.ListTillsHandler,
+	listSessionsHandler *posqry.ListSessionsHandler,
+	reportHandler *posqry.SessionReportHandler,
+	getSaleHandler *posqry.GetSaleHandler,
+	receiptHandler *posqry.GetReceiptHandler,
+) *AdminPOSHandler {
+	return &AdminPOSHandler{
+		createTillHandler:   createTillHandler,
+		updateTillHandler:   updateTillHandler,
+		openSessionHandler:  openSessionHandler,
+		closeSessionHandler: closeSessionHandler,
+		startSaleHandler:    startSaleHandler,
+		addLineHandler:      addLineHandler,
+		voidLineHandler:     voidLineHandler,
+		paymentHandler:      paymentHandler,
+		voidSaleHandler:     voidSaleHandler,
+		returnHandler:       returnHandler,
+		listTillsHandler:    listTillsHandler,
+		listSessionsHandler: listSessionsHandler,
+		reportHandler:       reportHandler,
+		getSaleHandler:      getSaleHandler,
+		receiptHandler:      receiptHandler,
+	}
+}
+
+// ListTills handles GET /api/v1/admin/pos/tills.
+//
+//	@Summary		List tills
+//	@Description	List the tills at a branch, or at every branch, by name.
+//	@Tags			Admin POS
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string						false	"Branch ID"
+//	@Success		200			{object}	dto.TillListSuccessResponse	"Tills"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid branch ID"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/pos/tills [get]
+func (h *AdminPOSHandler) ListTills(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := optionalUUID(r.URL.Query().Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	tills, err := h.listTillsHandler.Handle(r.Context(), branchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.TillResponse, 0, len(tills))
+	for _, t := range tills {
+		resp = append(resp, toTillResponse(t))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateTill handles POST /api/v1/admin/pos/tills.
+//
+//	@Summary		Add a till
+//	@Description	Add a till at a branch's counter. Till names are unique within a branch.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.TillRequest			true	"Till"
+//	@Success		201		{object}	dto.TillSuccessResponse	"Till added"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody			"Name already in use"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/tills [post]
+func (h *AdminPOSHandler) CreateTill(w http.ResponseWriter, r *http.Request) {
+	var req dto.TillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, ok := optionalUUID(req.BranchID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	cmd := poscmd.CreateTillCommand{Name: req.Name}
+	if branchID != nil {
+		cmd.BranchID = *branchID
+	}
+	t, err := h.createTillHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toTillResponse(t))
+}
+
+// UpdateTill handles PUT /api/v1/admin/pos/tills/{tillID}.
+//
+//	@Summary		Update a till
+//	@Description	Rename or disable a till. A session already open on a disabled till can still be closed.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tillID	path		string					true	"Till ID"
+//	@Param			body	body		dto.UpdateTillRequest	true	"Till"
+//	@Success		200		{object}	dto.TillSuccessResponse	"Till updated"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody			"Till not found"
+//	@Failure		409		{object}	dto.ErrorBody			"Name already in use"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/tills/{tillID} [put]
+func (h *AdminPOSHandler) UpdateTill(w http.ResponseWriter, r *http.Request) {
+	tillID, ok := uuidParam(r, "tillID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid till ID")
+		return
+	}
+	var req dto.UpdateTillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	t, err := h.updateTillHandler.Handle(r.Context(), poscmd.UpdateTillCommand{TillID: tillID, Name: req.Name, Active: req.Active})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toTillResponse(t))
+}
+
+// ListSessions handles GET /api/v1/admin/pos/tills/{tillID}/sessions.
+//
+//	@Summary		List a till's sessions
+//	@Description	List the sessions opened on a till, newest first.
+//	@Tags			Admin POS
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tillID	path		string								true	"Till ID"
+//	@Success		200		{object}	dto.TillSessionListSuccessResponse	"Sessions"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid till ID"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Till not found"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/pos/tills/{tillID}/sessions [get]
+func (h *AdminPOSHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	tillID, ok := uuidParam(r, "tillID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid till ID")
+		return
+	}
+
+	sessions, err := h.listSessionsHandler.Handle(r.Context(), tillID)
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	resp := make([]dto.TillSessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, toTillSessionResponse(s))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// OpenSession handles POST /api/v1/admin/pos/tills/{tillID}/sessions.
+//
+//	@Summary		Open a till session
+//	@Description	Open a session on a till with the cash float put in the drawer. A till has one open session at a time. Cashiers and managers only.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tillID	path		string							true	"Till ID"
+//	@Param			body	body		dto.OpenSessionRequest			true	"Float"
+//	@Success		201		{object}	dto.TillSessionSuccessResponse	"Session opened"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not permitted to operate tills"
+//	@Failure		404		{object}	dto.ErrorBody					"Till not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Till disabled or already open"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/pos/tills/{tillID}/sessions [post]
+func (h *AdminPOSHandler) OpenSession(w http.ResponseWriter, r *http.Request) {
+	tillID, ok := uuidParam(r, "tillID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid till ID")
+		return
+	}
+	var req dto.OpenSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.openSessionHandler.Handle(r.Context(), poscmd.OpenSessionCommand{
+		TillID:     tillID,
+		FloatCents: req.FloatCents,
+		ActorID:    claims.SubjectID,
+	})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toTillSessionResponse(s))
+}
+
+// CloseSession handles POST /api/v1/admin/pos/sessions/{sessionID}/close.
+//
+//	@Summary		Close a till session
+//	@Description	Close a session with the cash counted in the drawer, float included, and return its Z report: the cash expected, the cash counted and the difference. Every sale must be paid for or voided first.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			sessionID	path		string							true	"Session ID"
+//	@Param			body		body		dto.CloseSessionRequest			true	"Counted cash"
+//	@Success		200			{object}	dto.TillReportSuccessResponse	"Z report"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Not permitted to operate tills"
+//	@Failure		404			{object}	dto.ErrorBody					"Session not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Session closed or sale in progress"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/pos/sessions/{sessionID}/close [post]
+func (h *AdminPOSHandler) CloseSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := uuidParam(r, "sessionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+	var req dto.CloseSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	report, err := h.closeSessionHandler.Handle(r.Context(), poscmd.CloseSessionCommand{
+		SessionID:        sessionID,
+		CountedCashCents: req.CountedCashCents,
+		ActorID:          claims.SubjectID,
+	})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toTillReportResponse(report))
+}
+
+// SessionReport handles GET /api/v1/admin/pos/sessions/{sessionID}/report.
+//
+//	@Summary		Get a till session's report
+//	@Description	Report a session's takings by payment method and VAT rate, with voids and returns. For an open session this is an X report; once closed it is the Z report with the counted cash and variance.
+//	@Tags			Admin POS
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			sessionID	path		string							true	"Session ID"
+//	@Success		200			{object}	dto.TillReportSuccessResponse	"Report"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid session ID"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Session not found"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/pos/sessions/{sessionID}/report [get]
+func (h *AdminPOSHandler) SessionReport(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := uuidParam(r, "sessionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+
+	report, err := h.reportHandler.Handle(r.Context(), sessionID)
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toTillReportResponse(report))
+}
+
+// StartSale handles POST /api/v1/admin/pos/sessions/{sessionID}/sales.
+//
+//	@Summary		Start a sale
+//	@Description	Start ringing up a walk-in customer's sale in an open session.
+//	@Tags			Admin POS
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			sessionID	path		string					true	"Session ID"
+//	@Success		201			{object}	dto.SaleSuccessResponse	"Sale started"
+//	@Failure		400			{object}	dto.ErrorBody			"Invalid session ID"
+//	@Failure		401			{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody			"Not permitted to operate tills"
+//	@Failure		404			{object}	dto.ErrorBody			"Session not found"
+//	@Failure		409			{object}	dto.ErrorBody			"Session closed"
+//	@Failure		500			{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sessions/{sessionID}/sales [post]
+func (h *AdminPOSHandler) StartSale(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := uuidParam(r, "sessionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.startSaleHandler.Handle(r.Context(), poscmd.StartSaleCommand{SessionID: sessionID, ActorID: claims.SubjectID})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toSaleResponse(s))
+}
+
+// ReturnSale handles POST /api/v1/admin/pos/sessions/{sessionID}/returns.
+//
+//	@Summary		Take a return
+//	@Description	Hand money back for items of an earlier sale, found by its receipt number, in cash or to a card. Returned meat is not put back into stock.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			sessionID	path		string					true	"Session ID"
+//	@Param			body		body		dto.ReturnRequest		true	"Return"
+//	@Success		201			{object}	dto.SaleSuccessResponse	"Return taken"
+//	@Failure		400			{object}	dto.ErrorBody			"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody			"Not permitted to operate tills"
+//	@Failure		404			{object}	dto.ErrorBody			"Session, sale or line not found"
+//	@Failure		409			{object}	dto.ErrorBody			"Session closed or sale not returnable"
+//	@Failure		422			{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sessions/{sessionID}/returns [post]
+func (h *AdminPOSHandler) ReturnSale(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := uuidParam(r, "sessionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+	var req dto.ReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	items := make([]pos.ReturnItem, 0, len(req.Items))
+	for _, it := range req.Items {
+		lineID, err := uuid.Parse(it.LineID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid line ID")
+			return
+		}
+		items = append(items, pos.ReturnItem{LineID: lineID, Grams: it.Grams})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.returnHandler.Handle(r.Context(), poscmd.ReturnSaleCommand{
+		SessionID:     sessionID,
+		ReceiptNumber: req.ReceiptNumber,
+		Items:         items,
+		Method:        pos.Method(req.Method),
+		Reference:     req.Reference,
+		Reason:        req.Reason,
+		ActorID:       claims.SubjectID,
+	})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toSaleResponse(s))
+}
+
+// GetSale handles GET /api/v1/admin/pos/sales/{saleID}.
+//
+//	@Summary		Get a sale
+//	@Description	Get a till sale or return with its lines and payments.
+//	@Tags			Admin POS
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			saleID	path		string					true	"Sale ID"
+//	@Success		200		{object}	dto.SaleSuccessResponse	"Sale"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid sale ID"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody			"Sale not found"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sales/{saleID} [get]
+func (h *AdminPOSHandler) GetSale(w http.ResponseWriter, r *http.Request) {
+	saleID, ok := uuidParam(r, "saleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid sale ID")
+		return
+	}
+
+	s, err := h.getSaleHandler.Handle(r.Context(), saleID)
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSaleResponse(s))
+}
+
+// AddSaleLine handles POST /api/v1/admin/pos/sales/{saleID}/lines.
+//
+//	@Summary		Ring up an item
+//	@Description	Add an item to a sale by scanning a pack's variable-measure barcode, or by keying in a PLU code with the weight from the counter scale. The sale is repriced with VAT on every item.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			saleID	path		string					true	"Sale ID"
+//	@Param			body	body		dto.SaleLineRequest		true	"Item"
+//	@Success		200		{object}	dto.SaleSuccessResponse	"Sale"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Not permitted to operate tills"
+//	@Failure		404		{object}	dto.ErrorBody			"Sale or PLU item not found"
+//	@Failure		409		{object}	dto.ErrorBody			"Sale not open or item withdrawn"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sales/{saleID}/lines [post]
+func (h *AdminPOSHandler) AddSaleLine(w http.ResponseWriter, r *http.Request) {
+	saleID, ok := uuidParam(r, "saleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid sale ID")
+		return
+	}
+	var req dto.SaleLineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.addLineHandler.Handle(r.Context(), poscmd.AddSaleLineCommand{
+		SaleID:  saleID,
+		Barcode: req.Barcode,
+		PLUCode: req.PLUCode,
+		Grams:   req.Grams,
+		ActorID: claims.SubjectID,
+	})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSaleResponse(s))
+}
+
+// VoidSaleLine handles DELETE /api/v1/admin/pos/sales/{saleID}/lines/{lineID}.
+//
+//	@Summary		Void an item
+//	@Description	Void an item rung up by mistake, before any payment is taken. The line stays on the sale, marked voided, for the session report.
+//	@Tags			Admin POS
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			saleID	path		string					true	"Sale ID"
+//	@Param			lineID	path		string					true	"Line ID"
+//	@Success		200		{object}	dto.SaleSuccessResponse	"Sale"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid sale or line ID"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Not permitted to operate tills"
+//	@Failure		404		{object}	dto.ErrorBody			"Sale or line not found"
+//	@Failure		409		{object}	dto.ErrorBody			"Sale not open or payment taken"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sales/{saleID}/lines/{lineID} [delete]
+func (h *AdminPOSHandler) VoidSaleLine(w http.ResponseWriter, r *http.Request) {
+	saleID, ok := uuidParam(r, "saleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid sale ID")
+		return
+	}
+	lineID, ok := uuidParam(r, "lineID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid line ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.voidLineHandler.Handle(r.Context(), poscmd.VoidSaleLineCommand{SaleID: saleID, LineID: lineID, ActorID: claims.SubjectID})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSaleResponse(s))
+}
+
+// TakePayment handles POST /api/v1/admin/pos/sales/{saleID}/payments.
+//
+//	@Summary		Take a payment
+//	@Description	Take cash or card towards a sale; a sale can be split across several payments. Cash tendered over the balance is given back as change. Once the balance is paid the sale is completed, given a receipt number and its stock is taken from the branch's lots, first expiring first.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			saleID	path		string					true	"Sale ID"
+//	@Param			body	body		dto.PaymentRequest		true	"Payment"
+//	@Success		200		{object}	dto.SaleSuccessResponse	"Sale"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Not permitted to operate tills"
+//	@Failure		404		{object}	dto.ErrorBody			"Sale not found"
+//	@Failure		409		{object}	dto.ErrorBody			"Sale not open or not enough stock"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sales/{saleID}/payments [post]
+func (h *AdminPOSHandler) TakePayment(w http.ResponseWriter, r *http.Request) {
+	saleID, ok := uuidParam(r, "saleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid sale ID")
+		return
+	}
+	var req dto.PaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.paymentHandler.Handle(r.Context(), poscmd.TakePaymentCommand{
+		SaleID:      saleID,
+		Method:      pos.Method(req.Method),
+		AmountCents: req.AmountCents,
+		Reference:   req.Reference,
+		ActorID:     claims.SubjectID,
+	})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSaleResponse(s))
+}
+
+// VoidSale handles POST /api/v1/admin/pos/sales/{saleID}/void.
+//
+//	@Summary		Void a sale
+//	@Description	Void a sale. Any cashier may void a sale still being rung up; a paid sale can only be voided by a manager, in the session it was taken in, and its stock goes back to the lots it came from.
+//	@Tags			Admin POS
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			saleID	path		string					true	"Sale ID"
+//	@Param			body	body		dto.VoidSaleRequest		true	"Reason"
+//	@Success		200		{object}	dto.SaleSuccessResponse	"Sale voided"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Not permitted"
+//	@Failure		404		{object}	dto.ErrorBody			"Sale not found"
+//	@Failure		409		{object}	dto.ErrorBody			"Sale cannot be voided"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/pos/sales/{saleID}/void [post]
+func (h *AdminPOSHandler) VoidSale(w http.ResponseWriter, r *http.Request) {
+	saleID, ok := uuidParam(r, "saleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid sale ID")
+		return
+	}
+	var req dto.VoidSaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.voidSaleHandler.Handle(r.Context(), poscmd.VoidSaleCommand{SaleID: saleID, Reason: req.Reason, ActorID: claims.SubjectID})
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSaleResponse(s))
+}
+
+// GetReceipt handles GET /api/v1/admin/pos/sales/{saleID}/receipt.
+//
+//	@Summary		Print a receipt
+//	@Description	Render the receipt for a paid sale or a return as a PDF for an 80mm receipt roll, when it is completed or as a copy later.
+//	@Tags			Admin POS
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			saleID	path		string			true	"Sale ID"
+//	@Success		200		{file}		file			"PDF"
+//	@Failure		400		{object}	dto.ErrorBody	"Invalid sale ID"
+//	@Failure		401		{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody	"Sale not found"
+//	@Failure		409		{object}	dto.ErrorBody	"Sale not paid for"
+//	@Failure		500		{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/pos/sales/{saleID}/receipt [get]
+func (h *AdminPOSHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	saleID, ok := uuidParam(r, "saleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid sale ID")
+		return
+	}
+
+	out, err := h.receiptHandler.Handle(r.Context(), saleID)
+	if err != nil {
+		writePOSError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", "receipt-"+saleID.String()+".pdf"))
+	w.Header().Set("Content-Length", strconv.Itoa(len(out)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+func toTillResponse(t *pos.Till) dto.TillResponse {
+	return dto.TillResponse{
+		ID:        t.ID().String(),
+		BranchID:  t.BranchID().String(),
+		Name:      t.Name(),
+		Active:    t.Active(),
+		CreatedAt: t.CreatedAt(),
+		UpdatedAt: t.UpdatedAt(),
+	}
+}
+
+func toTillSessionResponse(s *pos.Session) dto.TillSessionResponse {
+	return dto.TillSessionResponse{
+		ID:               s.ID().String(),
+		TillID:           s.TillID().String(),
+		BranchID:         s.BranchID().String(),
+		Status:           string(s.Status()),
+		FloatCents:       s.FloatCents(),
+		CountedCashCents: s.CountedCashCents(),
+		OpenedBy:         s.OpenedBy().String(),
+		ClosedBy:         uuidString(s.ClosedBy()),
+		OpenedAt:         s.OpenedAt(),
+		ClosedAt:         s.ClosedAt(),
+	}
+}
+
+func toSaleResponse(s *pos.Sale) dto.SaleResponse {
+	resp := dto.SaleResponse{
+		ID:             s.ID().String(),
+		Kind:           string(s.Kind()),
+		SessionID:      s.SessionID().String(),
+		TillID:         s.TillID().String(),
+		BranchID:       s.BranchID().String(),
+		CashierID:      s.CashierID().String(),
+		Status:         string(s.Status()),
+		ReceiptNumber:  s.ReceiptNumber(),
+		OriginalSaleID: uuidString(s.OriginalSaleID()),
+		Lines:          make([]dto.SaleLineResponse, 0, len(s.Lines())),
+		Payments:       make([]dto.SalePaymentResponse, 0, len(s.Payments())),
+		TotalCents:     s.TotalCents(),
+		PaidCents:      s.PaidCents(),
+		BalanceCents:   s.BalanceCents(),
+		ChangeCents:    s.ChangeCents(),
+		Reason:         s.Reason(),
+		VoidedBy:       uuidString(s.VoidedBy()),
+		CreatedAt:      s.CreatedAt(),
+		CompletedAt:    s.CompletedAt(),
+		VoidedAt:       s.VoidedAt(),
+	}
+	for _, l := range s.Lines() {
+		resp.Lines = append(resp.Lines, dto.SaleLineResponse{
+			ID:              l.ID.String(),
+			ProductID:       l.ProductID.String(),
+			PLUCode:         l.PLUCode,
+			Description:     l.Description,
+			Barcode:         l.Barcode,
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+			TaxCategory:     l.TaxCategory,
+			VATRateBP:       l.VATRateBP,
+			NetCents:        l.NetCents,
+			VATCents:        l.VATCents,
+			GrossCents:      l.GrossCents,
+			Voided:          l.Voided,
+			ReturnedGrams:   l.ReturnedGrams,
+			ReturnedCents:   l.ReturnedCents,
+			OriginalLineID:  uuidString(l.OriginalLineID),
+		})
+	}
+	for _, p := range s.Payments() {
+		resp.Payments = append(resp.Payments, dto.SalePaymentResponse{
+			ID:            p.ID.String(),
+			Method:        string(p.Method),
+			AmountCents:   p.AmountCents,
+			TenderedCents: p.TenderedCents,
+			ChangeCents:   p.ChangeCents,
+			Reference:     p.Reference,
+			TakenAt:       p.TakenAt,
+		})
+	}
+	return resp
+}
+
+func toTillReportResponse(r *pos.Report) dto.TillReportResponse {
+	resp := dto.TillReportResponse{
+		SessionID:         r.SessionID.String(),
+		TillID:            r.TillID.String(),
+		BranchID:          r.BranchID.String(),
+		OpenedAt:          r.OpenedAt,
+		ClosedAt:          r.ClosedAt,
+		FloatCents:        r.FloatCents,
+		SalesCount:        r.SalesCount,
+		SalesCents:        r.SalesCents,
+		ReturnsCount:      r.ReturnsCount,
+		ReturnsCents:      r.ReturnsCents,
+		VoidedSalesCount:  r.VoidedSalesCount,
+		VoidedSalesCents:  r.VoidedSalesCents,
+		VoidedLinesCount:  r.VoidedLinesCount,
+		VoidedLinesCents:  r.VoidedLinesCents,
+		CashSalesCents:    r.CashSalesCents,
+		CardSalesCents:    r.CardSalesCents,
+		CashRefundsCents:  r.CashRefundsCents,
+		CardRefundsCents:  r.CardRefundsCents,
+		Bands:             make([]dto.TillReportBandResponse, 0, len(r.Bands)),
+		ExpectedCashCents: r.ExpectedCashCents,
+		CountedCashCents:  r.CountedCashCents,
+		VarianceCents:     r.VarianceCents,
+	}
+	for _, b := range r.Bands {
+		resp.Bands = append(resp.Bands, dto.TillReportBandResponse(b))
+	}
+	return resp
+}
+
+func writePOSError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, pos.ErrTillNotFound),
+		errors.Is(err, pos.ErrSessionNotFound),
+		errors.Is(err, pos.ErrSaleNotFound),
+		errors.Is(err, pos.ErrLineNotFound),
+		errors.Is(err, plu.ErrItemNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, pos.ErrTillNotPermitted),
+		errors.Is(err, pos.ErrVoidNotPermitted),
+		errors.Is(err, admin.ErrAdminNotFound):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, pos.ErrDuplicateTillName),
+		errors.Is(err, pos.ErrTillInactive),
+		errors.Is(err, pos.ErrTillInUse),
+		errors.Is(err, pos.ErrSessionClosed),
+		errors.Is(err, pos.ErrSalesInProgress),
+		errors.Is(err, pos.ErrSaleNotOpen),
+		errors.Is(err, pos.ErrSaleNotCompleted),
+		errors.Is(err, pos.ErrSaleVoided),
+		errors.Is(err, pos.ErrSaleReturned),
+		errors.Is(err, pos.ErrVoidFromOtherSession),
+		errors.Is(err, pos.ErrLineVoided),
+		errors.Is(err, pos.ErrPaymentsTaken),
+		errors.Is(err, pos.ErrNothingDue),
+		errors.Is(err, pos.ErrReturnNotVoidable),
+		errors.Is(err, pos.ErrNotAReturn),
+		errors.Is(err, pos.ErrConcurrentUpdate),
+		errors.Is(err, plu.ErrItemInactive),
+		errors.Is(err, inventory.ErrInsufficientStock):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pos.ErrEmptyTillName),
+		errors.Is(err, pos.ErrBranchRequired),
+		errors.Is(err, pos.ErrInvalidFloat),
+		errors.Is(err, pos.ErrInvalidCount),
+		errors.Is(err, pos.ErrInvalidWeight),
+		errors.Is(err, pos.ErrNegativePrice),
+		errors.Is(err, pos.ErrUnbalancedLine),
+		errors.Is(err, pos.ErrNoLines),
+		errors.Is(err, pos.ErrInvalidMethod),
+		errors.Is(err, pos.ErrInvalidAmount),
+		errors.Is(err, pos.ErrBalanceDue),
+		errors.Is(err, pos.ErrOverpayment),
+		errors.Is(err, pos.ErrReturnTooLarge),
+		errors.Is(err, pos.ErrReasonRequired),
+		errors.Is(err, plu.ErrInvalidWeight),
+		errors.Is(err, tax.ErrUnclassifiedProduct),
+		errors.Is(err, tax.ErrNoRate),
+		errors.Is(err, barcode.ErrInvalidCode),
+		errors.Is(err, barcode.ErrCheckDigit),
+		errors.Is(err, barcode.ErrPriceCheckDigit),
+		errors.Is(err, barcode.ErrNoLayout):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
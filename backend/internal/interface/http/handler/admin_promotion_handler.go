@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	promocmd "github.com/katerji/butchery-app/backend/internal/application/promotion/commands"
+	promoqry "github.com/katerji/butchery-app/backend/internal/application/promotion/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/carcass"
+	"github.com/katerji/butchery-app/backend/internal/domain/promotion"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminPromotionHandler handles back-office management of promotions,
+// coupon codes and the product profiles promotions target.
+type AdminPromotionHandler struct {
+	listHandler     *promoqry.ListPromotionsHandler
+	createHandler   *promocmd.CreatePromotionHandler
+	updateHandler   *promocmd.UpdatePromotionHandler
+	classifyHandler *promocmd.ClassifyProductHandler
+}
+
+// NewAdminPromotionHandler creates a new AdminPromotionHandler.
+func NewAdminPromotionHandler(
+	listHandler *promoqry.ListPromotionsHandler,
+	createHandler *promocmd.CreatePromotionHandler,
+	updateHandler *promocmd.UpdatePromotionHandler,
+	classifyHandler *promocmd.ClassifyProductHandler,
+) *AdminPromotionHandler {
+	return &AdminPromotionHandler{
+		listHandler:     listHandler,
+		createHandler:   createHandler,
+		updateHandler:   updateHandler,
+		classifyHandler: classifyHandler,
+	}
+}
+
+// ListPromotions handles GET /api/v1/admin/promotions.
+//
+//	@Summary		List promotions
+//	@Description	List every promotion, including inactive and finished ones, newest first.
+//	@Tags			Admin Promotions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.PromotionsSuccessResponse	"Promotions"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/promotions [get]
+func (h *AdminPromotionHandler) ListPromotions(w http.ResponseWriter, r *http.Request) {
+	promotions, err := h.listHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.PromotionResponse, 0, len(promotions))
+	for _, p := range promotions {
+		resp = append(resp, toPromotionResponse(p))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreatePromotion handles POST /api/v1/admin/promotions.
+//
+//	@Summary		Create promotion
+//	@Description	Create a promotion: a percentage, fixed amount or amount per kg off, free weight with weight bought, free delivery, or a first-order discount. Scope it by product, category, species and customer segment (new or returning), limit it by dates and uses, and give it a code to make it a coupon. Promotions apply highest priority first, each to what earlier ones left; an exclusive promotion applies only on its own. Needs the manage_pricing permission.
+//	@Tags			Admin Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.PromotionRequest			true	"Promotion"
+//	@Success		201		{object}	dto.PromotionSuccessResponse	"Promotion created"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody					"Coupon code already in use"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/promotions [post]
+func (h *AdminPromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var req dto.PromotionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	fields, ok := promotionFields(req)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_ids")
+		return
+	}
+
+	p, err := h.createHandler.Handle(r.Context(), promocmd.CreatePromotionCommand{
+		Fields:  fields,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writePromotionError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toPromotionResponse(p))
+}
+
+// UpdatePromotion handles PUT /api/v1/admin/promotions/{promotionID}.
+//
+//	@Summary		Update promotion
+//	@Description	Replace a promotion's terms or switch it off. Omitting active leaves the promotion active. Uses so far still count towards its limits. Needs the manage_pricing permission.
+//	@Tags			Admin Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			promotionID	path		string							true	"Promotion ID"
+//	@Param			body		body		dto.PromotionRequest			true	"Promotion"
+//	@Success		200			{object}	dto.PromotionSuccessResponse	"Promotion updated"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Promotion not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Coupon code already in use"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/promotions/{promotionID} [put]
+func (h *AdminPromotionHandler) UpdatePromotion(w http.ResponseWriter, r *http.Request) {
+	promotionID, ok := uuidParam(r, "promotionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid promotion ID")
+		return
+	}
+	var req dto.PromotionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	fields, ok := promotionFields(req)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_ids")
+		return
+	}
+
+	p, err := h.updateHandler.Handle(r.Context(), promocmd.UpdatePromotionCommand{
+		PromotionID: promotionID,
+		Fields:      fields,
+		Active:      req.Active == nil || *req.Active,
+		ActorID:     middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writePromotionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPromotionResponse(p))
+}
+
+// ClassifyProduct handles PUT /api/v1/admin/promotions/products/{productID}.
+//
+//	@Summary		Set product promotion profile
+//	@Description	Set the category and species promotions can target a product by, e.g. so "10% off lamb" covers every lamb cut. Species must be one the shop cuts. Needs the manage_pricing permission.
+//	@Tags			Admin Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			productID	path		string								true	"Product ID"
+//	@Param			body		body		dto.PromotionProductRequest			true	"Profile"
+//	@Success		200			{object}	dto.PromotionProductSuccessResponse	"Profile set"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/promotions/products/{productID} [put]
+func (h *AdminPromotionHandler) ClassifyProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := uuidParam(r, "productID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	var req dto.PromotionProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	profile, err := h.classifyHandler.Handle(r.Context(), promocmd.ClassifyProductCommand{
+		ProductID: productID,
+		Category:  req.Category,
+		Species:   req.Species,
+		ActorID:   middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writePromotionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.PromotionProductResponse{
+		ProductID: productID.String(),
+		Category:  profile.Category,
+		Species:   profile.Species,
+	})
+}
+
+func promotionFields(req dto.PromotionRequest) (promocmd.PromotionFields, bool) {
+	productIDs := make([]uuid.UUID, 0, len(req.ProductIDs))
+	for _, raw := range req.ProductIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return promocmd.PromotionFields{}, false
+		}
+		productIDs = append(productIDs, id)
+	}
+	return promocmd.PromotionFields{
+		Name:               req.Name,
+		Code:               req.Code,
+		Kind:               req.Kind,
+		PercentBP:          req.PercentBP,
+		AmountCents:        req.AmountCents,
+		BuyGrams:           req.BuyGrams,
+		FreeGrams:          req.FreeGrams,
+		MinSubtotalCents:   req.MinSubtotalCents,
+		ProductIDs:         productIDs,
+		Categories:         req.Categories,
+		Species:            req.Species,
+		Segments:           req.Segments,
+		StartsAt:           req.StartsAt,
+		EndsAt:             req.EndsAt,
+		MaxUses:            req.MaxUses,
+		MaxUsesPerCustomer: req.MaxUsesPerCustomer,
+		Priority:           req.Priority,
+		Stacking:           req.Stacking,
+	}, true
+}
+
+func toPromotionResponse(p *promotion.Promotion) dto.PromotionResponse {
+	t := p.Terms()
+	productIDs := make([]string, 0, len(t.Scope.ProductIDs))
+	for _, id := range t.Scope.ProductIDs {
+		productIDs = append(productIDs, id.String())
+	}
+	return dto.PromotionResponse{
+		ID:                 p.ID().String(),
+		Name:               t.Name,
+		Code:               t.Code,
+		Kind:               string(t.Rule.Kind),
+		PercentBP:          t.Rule.PercentBP,
+		AmountCents:        t.Rule.AmountCents,
+		BuyGrams:           t.Rule.BuyGrams,
+		FreeGrams:          t.Rule.FreeGrams,
+		MinSubtotalCents:   t.Rule.MinSubtotalCents,
+		ProductIDs:         productIDs,
+		Categories:         t.Scope.Categories,
+		Species:            t.Scope.Species,
+		Segments:           t.Scope.Segments,
+		StartsAt:           t.StartsAt,
+		EndsAt:             t.EndsAt,
+		MaxUses:            t.MaxUses,
+		MaxUsesPerCustomer: t.MaxUsesPerCustomer,
+		Priority:           t.Priority,
+		Stacking:           string(t.Stacking),
+		Active:             p.IsActive(),
+		CreatedAt:          p.CreatedAt(),
+		UpdatedAt:          p.UpdatedAt(),
+	}
+}
+
+func writePromotionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, promotion.ErrManageNotAllowed):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, promotion.ErrPromotionNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "promotion not found")
+	case errors.Is(err, promotion.ErrDuplicateCode):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, promotion.ErrEmptyName),
+		errors.Is(err, promotion.ErrInvalidKind),
+		errors.Is(err, promotion.ErrInvalidStacking),
+		errors.Is(err, promotion.ErrInvalidPercent),
+		errors.Is(err, promotion.ErrInvalidAmount),
+		errors.Is(err, promotion.ErrInvalidFreeWeight),
+		errors.Is(err, promotion.ErrAmbiguousDiscount),
+		errors.Is(err, promotion.ErrNegativeMinimum),
+		errors.Is(err, promotion.ErrInvalidWindow),
+		errors.Is(err, promotion.ErrInvalidTime),
+		errors.Is(err, promotion.ErrInvalidLimit),
+		errors.Is(err, promotion.ErrInvalidCode),
+		errors.Is(err, promotion.ErrInvalidAttribute),
+		errors.Is(err, promotion.ErrUnknownSegment),
+		errors.Is(err, carcass.ErrInvalidSpecies):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,585 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	proccmd "github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	procqry "github.com/katerji/butchery-app/backend/internal/application/procurement/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminPurchaseOrderHandler handles purchase orders, the deliveries received
+// against them and the report of what arrived versus what was ordered.
+type AdminPurchaseOrderHandler struct {
+	createHandler   *proccmd.CreatePurchaseOrderHandler
+	reviseHandler   *proccmd.RevisePurchaseOrderHandler
+	sendHandler     *proccmd.SendPurchaseOrderHandler
+	closeHandler    *proccmd.ClosePurchaseOrderHandler
+	receiveHandler  *proccmd.ReceiveGoodsHandler
+	listHandler     *procqry.ListPurchaseOrdersHandler
+	getHandler      *procqry.GetPurchaseOrderHandler
+	receiptsHandler *procqry.ListReceiptsHandler
+	varianceHandler *procqry.VarianceReportHandler
+}
+
+// NewAdminPurchaseOrderHandler creates a new AdminPurchaseOrderHandler.
+func NewAdminPurchaseOrderHandler(
+	createHandler *proccmd.CreatePurchaseOrderHandler,
+	reviseHandler *proccmd.RevisePurchaseOrderHandler,
+	sendHandler *proccmd.SendPurchaseOrderHandler,
+	closeHandler *proccmd.ClosePurchaseOrderHandler,
+	receiveHandler *proccmd.ReceiveGoodsHandler,
+	listHandler *procqry.ListPurchaseOrdersHandler,
+	getHandler *procqry.GetPurchaseOrderHandler,
+	receiptsHandler *procqry.ListReceiptsHandler,
+	varianceHandler *procqry.VarianceReportHandler,
+) *AdminPurchaseOrderHandler {
+	return &AdminPurchaseOrderHandler{
+		createHandler:   createHandler,
+		reviseHandler:   reviseHandler,
+		sendHandler:     sendHandler,
+		closeHandler:    closeHandler,
+		receiveHandler:  receiveHandler,
+		listHandler:     listHandler,
+		getHandler:      getHandler,
+		receiptsHandler: receiptsHandler,
+		varianceHandler: varianceHandler,
+	}
+}
+
+// CreatePurchaseOrder handles POST /api/v1/admin/purchase-orders.
+//
+//	@Summary		Draft a purchase order
+//	@Description	Draft an order with an active supplier for delivery to a branch. Weights are in grams and prices in cents per kg.
+//	@Tags			Admin Procurement
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.CreatePurchaseOrderRequest		true	"Purchase order"
+//	@Success		201		{object}	dto.PurchaseOrderSuccessResponse	"Purchase order drafted"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Supplier not found"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/purchase-orders [post]
+func (h *AdminPurchaseOrderHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreatePurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	supplierID, err := uuid.Parse(req.SupplierID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "supplier_id is required")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+	lines, ok := orderLines(req.Lines)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	po, err := h.createHandler.Handle(r.Context(), proccmd.CreatePurchaseOrderCommand{
+		SupplierID: supplierID,
+		BranchID:   branchID,
+		Fields:     proccmd.OrderFields{Lines: lines, ExpectedOn: req.ExpectedOn, Notes: req.Notes},
+		ActorID:    claims.SubjectID,
+	})
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toPurchaseOrderResponse(po))
+}
+
+// ListPurchaseOrders handles GET /api/v1/admin/purchase-orders.
+//
+//	@Summary		List purchase orders
+//	@Description	List purchase orders, newest first.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			supplier_id	query		string	false	"Supplier ID"
+//	@Param			branch_id	query		string	false	"Branch ID"
+//	@Param			status		query		string	false	"Status (draft, sent, partially_received, received or closed)"
+//	@Success		200			{object}	dto.PurchaseOrdersSuccessResponse	"Purchase orders"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/purchase-orders [get]
+func (h *AdminPurchaseOrderHandler) ListPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var filter procurement.Filter
+	var ok bool
+	if filter.SupplierID, ok = optionalUUID(q.Get("supplier_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid supplier_id")
+		return
+	}
+	if filter.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	if raw := q.Get("status"); raw != "" {
+		status, err := procurement.NewStatus(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		filter.Statuses = []procurement.Status{status}
+	}
+
+	orders, err := h.listHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.PurchaseOrderResponse, 0, len(orders))
+	for _, po := range orders {
+		resp = append(resp, toPurchaseOrderResponse(po))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// GetPurchaseOrder handles GET /api/v1/admin/purchase-orders/{purchaseOrderID}.
+//
+//	@Summary		Get a purchase order
+//	@Description	Get a purchase order with how much of each line has arrived.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrderID	path		string								true	"Purchase order ID"
+//	@Success		200				{object}	dto.PurchaseOrderSuccessResponse	"Purchase order"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid purchase order ID"
+//	@Failure		401				{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody						"Purchase order not found"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/purchase-orders/{purchaseOrderID} [get]
+func (h *AdminPurchaseOrderHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, ok := uuidParam(r, "purchaseOrderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	po, err := h.getHandler.Handle(r.Context(), purchaseOrderID)
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPurchaseOrderResponse(po))
+}
+
+// RevisePurchaseOrder handles PUT /api/v1/admin/purchase-orders/{purchaseOrderID}.
+//
+//	@Summary		Revise a draft purchase order
+//	@Description	Replace the lines, expected delivery date and notes of a draft purchase order.
+//	@Tags			Admin Procurement
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrderID	path		string								true	"Purchase order ID"
+//	@Param			body			body		dto.RevisePurchaseOrderRequest		true	"Purchase order"
+//	@Success		200				{object}	dto.PurchaseOrderSuccessResponse	"Purchase order revised"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401				{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody						"Purchase order not found"
+//	@Failure		409				{object}	dto.ErrorBody						"Purchase order is not a draft or was changed concurrently"
+//	@Failure		422				{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/purchase-orders/{purchaseOrderID} [put]
+func (h *AdminPurchaseOrderHandler) RevisePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, ok := uuidParam(r, "purchaseOrderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	var req dto.RevisePurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	lines, ok := orderLines(req.Lines)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	po, err := h.reviseHandler.Handle(r.Context(), proccmd.RevisePurchaseOrderCommand{
+		PurchaseOrderID: purchaseOrderID,
+		Fields:          proccmd.OrderFields{Lines: lines, ExpectedOn: req.ExpectedOn, Notes: req.Notes},
+	})
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPurchaseOrderResponse(po))
+}
+
+// SendPurchaseOrder handles POST /api/v1/admin/purchase-orders/{purchaseOrderID}/send.
+//
+//	@Summary		Send a purchase order
+//	@Description	Mark a draft purchase order as placed with the supplier. Its lines and prices can no longer be changed.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrderID	path		string								true	"Purchase order ID"
+//	@Success		200				{object}	dto.PurchaseOrderSuccessResponse	"Purchase order sent"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid purchase order ID"
+//	@Failure		401				{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody						"Purchase order not found"
+//	@Failure		409				{object}	dto.ErrorBody						"Purchase order already sent or changed concurrently"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/purchase-orders/{purchaseOrderID}/send [post]
+func (h *AdminPurchaseOrderHandler) SendPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.sendHandler.Handle)
+}
+
+// ClosePurchaseOrder handles POST /api/v1/admin/purchase-orders/{purchaseOrderID}/close.
+//
+//	@Summary		Close a purchase order
+//	@Description	Close a sent purchase order. Anything still outstanding is no longer expected and counts as a shortfall in the variance report.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrderID	path		string								true	"Purchase order ID"
+//	@Success		200				{object}	dto.PurchaseOrderSuccessResponse	"Purchase order closed"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid purchase order ID"
+//	@Failure		401				{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody						"Purchase order not found"
+//	@Failure		409				{object}	dto.ErrorBody						"Purchase order is a draft, already closed or changed concurrently"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/purchase-orders/{purchaseOrderID}/close [post]
+func (h *AdminPurchaseOrderHandler) ClosePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.closeHandler.Handle)
+}
+
+func (h *AdminPurchaseOrderHandler) transition(
+	w http.ResponseWriter,
+	r *http.Request,
+	handle func(ctx context.Context, purchaseOrderID uuid.UUID) (*procurement.PurchaseOrder, error),
+) {
+	purchaseOrderID, ok := uuidParam(r, "purchaseOrderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	po, err := handle(r.Context(), purchaseOrderID)
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toPurchaseOrderResponse(po))
+}
+
+// ReceiveGoods handles POST /api/v1/admin/purchase-orders/{purchaseOrderID}/receipts.
+//
+//	@Summary		Receive goods against a purchase order
+//	@Description	Book a delivery with the weight and temperature of each batch on arrival. Each batch is received into inventory as a lot under its lot code. Deliveries may be split across several receipts and may exceed what was ordered.
+//	@Tags			Admin Procurement
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrderID	path		string									true	"Purchase order ID"
+//	@Param			body			body		dto.ReceiveGoodsRequest					true	"Delivery"
+//	@Success		201				{object}	dto.GoodsReceivedNoteSuccessResponse	"Goods received"
+//	@Failure		400				{object}	dto.ErrorBody							"Invalid request body"
+//	@Failure		401				{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody							"Purchase order not found"
+//	@Failure		409				{object}	dto.ErrorBody							"Purchase order not open for receiving or changed concurrently"
+//	@Failure		422				{object}	dto.ErrorBody							"Validation error"
+//	@Failure		500				{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/purchase-orders/{purchaseOrderID}/receipts [post]
+func (h *AdminPurchaseOrderHandler) ReceiveGoods(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, ok := uuidParam(r, "purchaseOrderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	var req dto.ReceiveGoodsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	lines := make([]proccmd.ReceiptLineInput, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		lineID, err := uuid.Parse(l.LineID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid line_id")
+			return
+		}
+		lines = append(lines, proccmd.ReceiptLineInput{
+			LineID:             lineID,
+			LotCode:            l.LotCode,
+			Grams:              l.Grams,
+			TemperatureTenthsC: celsiusToTenths(l.TemperatureCelsius),
+			ExpiresOn:          l.ExpiresOn,
+		})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	n, err := h.receiveHandler.Handle(r.Context(), proccmd.ReceiveGoodsCommand{
+		PurchaseOrderID: purchaseOrderID,
+		Lines:           lines,
+		Notes:           req.Notes,
+		ActorID:         claims.SubjectID,
+	})
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toGoodsReceivedNoteResponse(n))
+}
+
+// ListReceipts handles GET /api/v1/admin/purchase-orders/{purchaseOrderID}/receipts.
+//
+//	@Summary		List deliveries for a purchase order
+//	@Description	List the goods-received notes booked against a purchase order, oldest first.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrderID	path		string									true	"Purchase order ID"
+//	@Success		200				{object}	dto.GoodsReceivedNotesSuccessResponse	"Goods received notes"
+//	@Failure		400				{object}	dto.ErrorBody							"Invalid purchase order ID"
+//	@Failure		401				{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		404				{object}	dto.ErrorBody							"Purchase order not found"
+//	@Failure		500				{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/purchase-orders/{purchaseOrderID}/receipts [get]
+func (h *AdminPurchaseOrderHandler) ListReceipts(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, ok := uuidParam(r, "purchaseOrderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	receipts, err := h.receiptsHandler.Handle(r.Context(), purchaseOrderID)
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	resp := make([]dto.GoodsReceivedNoteResponse, 0, len(receipts))
+	for _, n := range receipts {
+		resp = append(resp, toGoodsReceivedNoteResponse(n))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// VarianceReport handles GET /api/v1/admin/procurement/variances.
+//
+//	@Summary		Get the ordered versus received variance report
+//	@Description	Compare ordered and received weights and costs on received and closed purchase orders, per line and per supplier. Dates bound when the orders were sent and are inclusive.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			supplier_id	query		string	false	"Supplier ID"
+//	@Param			from		query		string	false	"First send date (YYYY-MM-DD)"
+//	@Param			to			query		string	false	"Last send date (YYYY-MM-DD)"
+//	@Success		200			{object}	dto.VarianceReportSuccessResponse	"Variance report"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/procurement/variances [get]
+func (h *AdminPurchaseOrderHandler) VarianceReport(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	supplierID, ok := optionalUUID(q.Get("supplier_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid supplier_id")
+		return
+	}
+
+	report, err := h.varianceHandler.Handle(r.Context(), procqry.VarianceReportQuery{
+		SupplierID: supplierID,
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+	})
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	resp := dto.VarianceReportResponse{
+		Suppliers: make([]dto.SupplierVarianceResponse, 0, len(report.Suppliers)),
+		Lines:     make([]dto.LineVarianceResponse, 0, len(report.Lines)),
+	}
+	for _, s := range report.Suppliers {
+		resp.Suppliers = append(resp.Suppliers, dto.SupplierVarianceResponse{
+			SupplierID:      s.SupplierID.String(),
+			Orders:          s.Orders,
+			OrderedGrams:    s.OrderedGrams,
+			ReceivedGrams:   s.ReceivedGrams,
+			VarianceGrams:   s.VarianceGrams(),
+			VariancePercent: bpToPercent(s.VarianceBP()),
+			OrderedCents:    s.OrderedCents,
+			ReceivedCents:   s.ReceivedCents,
+			VarianceCents:   s.VarianceCents(),
+		})
+	}
+	for _, l := range report.Lines {
+		resp.Lines = append(resp.Lines, dto.LineVarianceResponse{
+			PurchaseOrderID: l.PurchaseOrderID.String(),
+			SupplierID:      l.SupplierID.String(),
+			LineID:          l.LineID.String(),
+			ProductID:       l.ProductID.String(),
+			OrderedGrams:    l.OrderedGrams,
+			ReceivedGrams:   l.ReceivedGrams,
+			VarianceGrams:   l.VarianceGrams(),
+			VariancePercent: bpToPercent(l.VarianceBP()),
+			OrderedCents:    l.OrderedCents,
+			ReceivedCents:   l.ReceivedCents,
+			VarianceCents:   l.VarianceCents(),
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+func writeProcurementError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, procurement.ErrSupplierNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "supplier not found")
+	case errors.Is(err, procurement.ErrPurchaseOrderNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "purchase order not found")
+	case errors.Is(err, procurement.ErrNotDraft),
+		errors.Is(err, procurement.ErrInvalidTransition),
+		errors.Is(err, procurement.ErrNotReceivable),
+		errors.Is(err, procurement.ErrConcurrentUpdate):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, procurement.ErrEmptySupplierName),
+		errors.Is(err, procurement.ErrInvalidSupplierType),
+		errors.Is(err, procurement.ErrEmptyContactName),
+		errors.Is(err, procurement.ErrInvalidContactEmail),
+		errors.Is(err, procurement.ErrInvalidSupplierCertificate),
+		errors.Is(err, procurement.ErrInvalidPaymentTerms),
+		errors.Is(err, procurement.ErrSupplierInactive),
+		errors.Is(err, procurement.ErrNoLines),
+		errors.Is(err, procurement.ErrInvalidWeight),
+		errors.Is(err, procurement.ErrNegativePrice),
+		errors.Is(err, procurement.ErrDuplicateProduct),
+		errors.Is(err, procurement.ErrInvalidDate),
+		errors.Is(err, procurement.ErrUnknownLine),
+		errors.Is(err, procurement.ErrEmptyLotCode),
+		errors.Is(err, procurement.ErrInvalidTemperature):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+// orderLines parses purchase order lines from a request body.
+func orderLines(in []dto.PurchaseOrderLine) ([]proccmd.LineInput, bool) {
+	lines := make([]proccmd.LineInput, 0, len(in))
+	for _, l := range in {
+		productID, err := uuid.Parse(l.ProductID)
+		if err != nil {
+			return nil, false
+		}
+		lines = append(lines, proccmd.LineInput{
+			ProductID:       productID,
+			OrderedGrams:    l.OrderedGrams,
+			PricePerKgCents: l.PricePerKgCents,
+		})
+	}
+	return lines, true
+}
+
+func toPurchaseOrderResponse(po *procurement.PurchaseOrder) dto.PurchaseOrderResponse {
+	resp := dto.PurchaseOrderResponse{
+		ID:         po.ID().String(),
+		SupplierID: po.SupplierID().String(),
+		BranchID:   po.BranchID().String(),
+		Status:     string(po.Status()),
+		Lines:      make([]dto.PurchaseOrderLineResponse, 0, len(po.Lines())),
+		TotalCents: po.TotalCents(),
+		ExpectedOn: dateString(po.ExpectedOn()),
+		Notes:      po.Notes(),
+		Version:    po.Version(),
+		CreatedAt:  po.CreatedAt(),
+		SentAt:     po.SentAt(),
+		ClosedAt:   po.ClosedAt(),
+	}
+	for _, l := range po.Lines() {
+		resp.Lines = append(resp.Lines, dto.PurchaseOrderLineResponse{
+			ID:               l.ID.String(),
+			ProductID:        l.ProductID.String(),
+			OrderedGrams:     l.OrderedGrams,
+			PricePerKgCents:  l.PricePerKgCents,
+			ReceivedGrams:    l.ReceivedGrams,
+			OutstandingGrams: l.OutstandingGrams(),
+		})
+	}
+	return resp
+}
+
+func toGoodsReceivedNoteResponse(n *procurement.GoodsReceivedNote) dto.GoodsReceivedNoteResponse {
+	resp := dto.GoodsReceivedNoteResponse{
+		ID:              n.ID().String(),
+		PurchaseOrderID: n.PurchaseOrderID().String(),
+		BranchID:        n.BranchID().String(),
+		Lines:           make([]dto.GoodsReceivedLineResponse, 0, len(n.Lines())),
+		Notes:           n.Notes(),
+		ReceivedAt:      n.ReceivedAt(),
+	}
+	for _, l := range n.Lines() {
+		resp.Lines = append(resp.Lines, dto.GoodsReceivedLineResponse{
+			LineID:             l.LineID.String(),
+			ProductID:          l.ProductID.String(),
+			LotID:              l.LotID.String(),
+			LotCode:            l.LotCode,
+			Grams:              l.Grams,
+			TemperatureCelsius: float64(l.TemperatureTenthsC) / 10,
+			ExpiresOn:          dateString(l.ExpiresOn),
+		})
+	}
+	return resp
+}
+
+// celsiusToTenths converts a temperature from the API into tenths of a degree.
+func celsiusToTenths(c float64) int {
+	return int(math.Round(c * 10))
+}
+
+// dateString formats an optional date for the API.
+func dateString(d *time.Time) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.Format(time.DateOnly)
+	return &s
+}
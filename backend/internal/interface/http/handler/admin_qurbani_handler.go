@@ -0,0 +1,571 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	qurbanicmd "github.com/katerji/butchery-app/backend/internal/application/qurbani/commands"
+	qurbaniqry "github.com/katerji/butchery-app/backend/internal/application/qurbani/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminQurbaniHandler runs the Eid al-Adha sacrifice: offerings, grouping
+// shares into animals, slaughter batches and the distribution lists.
+type AdminQurbaniHandler struct {
+	createOfferingHandler *qurbanicmd.CreateOfferingHandler
+	updateOfferingHandler *qurbanicmd.UpdateOfferingHandler
+	offeringsHandler      *qurbaniqry.ListOfferingsHandler
+	bookingsHandler       *qurbaniqry.ListBookingsHandler
+	cancelHandler         *qurbanicmd.CancelBookingHandler
+	allocateHandler       *qurbanicmd.AllocateSharesHandler
+	sheetHandler          *qurbaniqry.SlaughterSheetHandler
+	planHandler           *qurbanicmd.PlanBatchesHandler
+	batchesHandler        *qurbaniqry.ListBatchesHandler
+	completeHandler       *qurbanicmd.CompleteBatchHandler
+	notifyHandler         *qurbanicmd.NotifyBatchHandler
+	distributionHandler   *qurbaniqry.DistributionListHandler
+}
+
+// NewAdminQurbaniHandler creates a new AdminQurbaniHandler.
+func NewAdminQurbaniHandler(
+	createOfferingHandler *qurbanicmd.CreateOfferingHandler,
+	updateOfferingHandler *qurbanicmd.UpdateOfferingHandler,
+	offeringsHandler *qurbaniqry.ListOfferingsHandler,
+	bookingsHandler *qurbaniqry.ListBookingsHandler,
+	cancelHandler *qurbanicmd.CancelBookingHandler,
+	allocateHandler *qurbanicmd.AllocateSharesHandler,
+	sheetHandler *qurbaniqry.SlaughterSheetHandler,
+	planHandler *qurbanicmd.PlanBatchesHandler,
+	batchesHandler *qurbaniqry.ListBatchesHandler,
+	completeHandler *qurbanicmd.CompleteBatchHandler,
+	notifyHandler *qurbanicmd.NotifyBatchHandler,
+	distributionHandler *qurbaniqry.DistributionListHandler,
+) *AdminQurbaniHandler {
+	return &AdminQurbaniHandler{
+		createOfferingHandler: createOfferingHandler,
+		updateOfferingHandler: updateOfferingHandler,
+		offeringsHandler:      offeringsHandler,
+		bookingsHandler:       bookingsHandler,
+		cancelHandler:         cancelHandler,
+		allocateHandler:       allocateHandler,
+		sheetHandler:          sheetHandler,
+		planHandler:           planHandler,
+		batchesHandler:        batchesHandler,
+		completeHandler:       completeHandler,
+		notifyHandler:         notifyHandler,
+		distributionHandler:   distributionHandler,
+	}
+}
+
+// ListOfferings handles GET /api/v1/admin/qurbani/offerings.
+//
+//	@Summary		List qurbani offerings
+//	@Description	List every offering, open or closed, with how many shares are booked.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			season	query		string								false	"Season, e.g. 2027; every season when omitted"
+//	@Success		200		{object}	dto.QurbaniOfferingListSuccessResponse	"Offerings"
+//	@Failure		401		{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		500		{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/qurbani/offerings [get]
+func (h *AdminQurbaniHandler) ListOfferings(w http.ResponseWriter, r *http.Request) {
+	offerings, err := h.offeringsHandler.Handle(r.Context(), r.URL.Query().Get("season"), false)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniOfferingResponses(offerings))
+}
+
+// CreateOffering handles POST /api/v1/admin/qurbani/offerings.
+//
+//	@Summary		Create a qurbani offering
+//	@Description	Open a share type for booking in a season, with the price per share and the number of animals bought in. A season has at most one offering per share type.
+//	@Tags			Admin Qurbani
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.QurbaniOfferingRequest			true	"Offering"
+//	@Success		201		{object}	dto.QurbaniOfferingSuccessResponse	"Offering created"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody						"Share type already offered this season"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/qurbani/offerings [post]
+func (h *AdminQurbaniHandler) CreateOffering(w http.ResponseWriter, r *http.Request) {
+	var req dto.QurbaniOfferingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	o, err := h.createOfferingHandler.Handle(r.Context(), qurbanicmd.CreateOfferingCommand{
+		Season:     req.Season,
+		ShareType:  req.ShareType,
+		PriceCents: req.PriceCents,
+		Animals:    req.Animals,
+	})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toQurbaniOfferingResponse(qurbaniqry.OfferingAvailability{Offering: o}))
+}
+
+// UpdateOffering handles PUT /api/v1/admin/qurbani/offerings/{offeringID}.
+//
+//	@Summary		Update a qurbani offering
+//	@Description	Change an offering's price and number of animals, or close it for booking. A new price applies to new bookings only, and an offering cannot have fewer shares than are already booked.
+//	@Tags			Admin Qurbani
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			offeringID	path		string								true	"Offering ID"
+//	@Param			body		body		dto.UpdateQurbaniOfferingRequest	true	"Offering"
+//	@Success		200			{object}	dto.QurbaniOfferingSuccessResponse	"Offering updated"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Offering not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Fewer shares than are booked"
+//	@Failure		422			{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/qurbani/offerings/{offeringID} [put]
+func (h *AdminQurbaniHandler) UpdateOffering(w http.ResponseWriter, r *http.Request) {
+	offeringID, ok := uuidParam(r, "offeringID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid offering ID")
+		return
+	}
+	var req dto.UpdateQurbaniOfferingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	o, err := h.updateOfferingHandler.Handle(r.Context(), qurbanicmd.UpdateOfferingCommand{
+		OfferingID: offeringID,
+		PriceCents: req.PriceCents,
+		Animals:    req.Animals,
+		Active:     req.Active,
+	})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniOfferingResponse(qurbaniqry.OfferingAvailability{Offering: o}))
+}
+
+// ListBookings handles GET /api/v1/admin/qurbani/bookings.
+//
+//	@Summary		List qurbani bookings
+//	@Description	List bookings in the order they were made.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			season			query		string								false	"Season, e.g. 2027"
+//	@Param			offering_id		query		string								false	"Offering ID"
+//	@Param			status			query		string								false	"booked, allocated, slaughtered or cancelled"
+//	@Param			distribution	query		string								false	"delivery, collection or charity"
+//	@Success		200				{object}	dto.QurbaniBookingListSuccessResponse	"Bookings"
+//	@Failure		400				{object}	dto.ErrorBody							"Invalid filter"
+//	@Failure		401				{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		500				{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/qurbani/bookings [get]
+func (h *AdminQurbaniHandler) ListBookings(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := qurbani.BookingFilter{Season: q.Get("season")}
+	var ok bool
+	if filter.OfferingID, ok = optionalUUID(q.Get("offering_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid offering_id")
+		return
+	}
+	if raw := q.Get("status"); raw != "" {
+		status := qurbani.BookingStatus(raw)
+		switch status {
+		case qurbani.BookingBooked, qurbani.BookingAllocated, qurbani.BookingSlaughtered, qurbani.BookingCancelled:
+		default:
+			httpresponse.Error(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		filter.Status = &status
+	}
+	if raw := q.Get("distribution"); raw != "" {
+		distribution, err := qurbani.NewDistribution(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid distribution")
+			return
+		}
+		filter.Distribution = &distribution
+	}
+
+	bookings, err := h.bookingsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniBookingResponses(bookings))
+}
+
+// CancelBooking handles POST /api/v1/admin/qurbani/bookings/{bookingID}/cancel.
+//
+//	@Summary		Cancel a qurbani booking
+//	@Description	Cancel a share on the customer's behalf before the sacrifice.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			bookingID	path		string								true	"Booking ID"
+//	@Success		200			{object}	dto.QurbaniBookingSuccessResponse	"Booking cancelled"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid booking ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Booking not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Already slaughtered or cancelled"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/qurbani/bookings/{bookingID}/cancel [post]
+func (h *AdminQurbaniHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	bookingID, ok := uuidParam(r, "bookingID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid booking ID")
+		return
+	}
+
+	b, err := h.cancelHandler.Handle(r.Context(), qurbanicmd.CancelBookingCommand{BookingID: bookingID})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniBookingResponse(b))
+}
+
+// AllocateShares handles POST /api/v1/admin/qurbani/offerings/{offeringID}/allocation.
+//
+//	@Summary		Group shares into animals
+//	@Description	Place every booking of the offering not yet on an animal, in the order they were made. A customer's shares are kept on one animal where they fit; otherwise gaps on animals already opened are filled first. Running it again only places bookings made since.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			offeringID	path		string									true	"Offering ID"
+//	@Success		200			{object}	dto.QurbaniAllocationSuccessResponse	"Shares allocated"
+//	@Failure		400			{object}	dto.ErrorBody							"Invalid offering ID"
+//	@Failure		401			{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody							"Offering not found"
+//	@Failure		409			{object}	dto.ErrorBody							"Not enough animals, or bookings changed meanwhile"
+//	@Failure		500			{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/qurbani/offerings/{offeringID}/allocation [post]
+func (h *AdminQurbaniHandler) AllocateShares(w http.ResponseWriter, r *http.Request) {
+	offeringID, ok := uuidParam(r, "offeringID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid offering ID")
+		return
+	}
+
+	alloc, err := h.allocateHandler.Handle(r.Context(), offeringID)
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.QurbaniAllocationResponse{
+		AnimalsOpened: len(alloc.Animals),
+		Allocated:     toQurbaniBookingResponses(alloc.Bookings),
+	})
+}
+
+// SlaughterSheet handles GET /api/v1/admin/qurbani/animals.
+//
+//	@Summary		Get the slaughter sheet
+//	@Description	List a season's animals by share type and number, with their batch and the niyyah names each is sacrificed for.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			season		query		string								true	"Season, e.g. 2027"
+//	@Param			batch_id	query		string								false	"Only the animals in this batch"
+//	@Success		200			{object}	dto.QurbaniAnimalListSuccessResponse	"Slaughter sheet"
+//	@Failure		400			{object}	dto.ErrorBody							"Invalid filter"
+//	@Failure		401			{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/qurbani/animals [get]
+func (h *AdminQurbaniHandler) SlaughterSheet(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	season := q.Get("season")
+	if season == "" {
+		httpresponse.Error(w, http.StatusBadRequest, "season is required")
+		return
+	}
+	batchID, ok := optionalUUID(q.Get("batch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid batch_id")
+		return
+	}
+
+	sheet, err := h.sheetHandler.Handle(r.Context(), season, batchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.QurbaniAnimalResponse, 0, len(sheet))
+	for _, s := range sheet {
+		animal := dto.QurbaniAnimalResponse{
+			ID:            s.Animal.ID().String(),
+			OfferingID:    s.Animal.OfferingID().String(),
+			ShareType:     s.ShareType.String(),
+			Number:        s.Animal.Number(),
+			SlaughteredAt: s.Animal.SlaughteredAt(),
+			NiyyahNames:   make([]string, 0, len(s.Bookings)),
+			FreeShares:    max(s.ShareType.SharesPerAnimal()-len(s.Bookings), 0),
+		}
+		if s.Batch != nil {
+			n := s.Batch.Number()
+			animal.BatchNumber = &n
+		}
+		for _, b := range s.Bookings {
+			animal.NiyyahNames = append(animal.NiyyahNames, b.NiyyahName())
+		}
+		resp = append(resp, animal)
+	}
+	httpresponse.Success(w, resp)
+}
+
+// PlanBatches handles POST /api/v1/admin/qurbani/batches.
+//
+//	@Summary		Plan slaughter batches
+//	@Description	Put every animal of the season with shares on it and no batch yet into batches, numbered on from the season's last batch and scheduled interval_minutes apart.
+//	@Tags			Admin Qurbani
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.QurbaniBatchPlanRequest			true	"Plan"
+//	@Success		201		{object}	dto.QurbaniBatchListSuccessResponse	"Batches planned"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody						"No animals waiting, or planned meanwhile"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/qurbani/batches [post]
+func (h *AdminQurbaniHandler) PlanBatches(w http.ResponseWriter, r *http.Request) {
+	var req dto.QurbaniBatchPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.StartsAt.IsZero() {
+		httpresponse.Error(w, http.StatusBadRequest, "starts_at is required")
+		return
+	}
+
+	batches, err := h.planHandler.Handle(r.Context(), qurbanicmd.PlanBatchesCommand{
+		Season:          req.Season,
+		StartsAt:        req.StartsAt,
+		AnimalsPerBatch: req.AnimalsPerBatch,
+		IntervalMinutes: req.IntervalMinutes,
+	})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toQurbaniBatchResponses(batches))
+}
+
+// ListBatches handles GET /api/v1/admin/qurbani/batches.
+//
+//	@Summary		List slaughter batches
+//	@Description	List a season's slaughter batches in order.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			season	query		string								true	"Season, e.g. 2027"
+//	@Success		200		{object}	dto.QurbaniBatchListSuccessResponse	"Batches"
+//	@Failure		400		{object}	dto.ErrorBody						"Season missing"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/qurbani/batches [get]
+func (h *AdminQurbaniHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		httpresponse.Error(w, http.StatusBadRequest, "season is required")
+		return
+	}
+
+	batches, err := h.batchesHandler.Handle(r.Context(), season)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniBatchResponses(batches))
+}
+
+// CompleteBatch handles POST /api/v1/admin/qurbani/batches/{batchID}/complete.
+//
+//	@Summary		Complete a slaughter batch
+//	@Description	Record that a batch's animals have been slaughtered, and tell each customer their sacrifice is done. Customers who cannot be reached are counted as failed and can be notified again.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			batchID	path		string										true	"Batch ID"
+//	@Success		200		{object}	dto.QurbaniBatchCompletionSuccessResponse	"Batch completed"
+//	@Failure		400		{object}	dto.ErrorBody								"Invalid batch ID"
+//	@Failure		401		{object}	dto.ErrorBody								"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody								"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody								"Batch not found"
+//	@Failure		409		{object}	dto.ErrorBody								"Batch already completed"
+//	@Failure		500		{object}	dto.ErrorBody								"Internal server error"
+//	@Router			/admin/qurbani/batches/{batchID}/complete [post]
+func (h *AdminQurbaniHandler) CompleteBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := uuidParam(r, "batchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid batch ID")
+		return
+	}
+
+	result, err := h.completeHandler.Handle(r.Context(), batchID)
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.QurbaniBatchCompletionResponse{
+		Batch:       toQurbaniBatchResponse(result.Batch),
+		Slaughtered: result.Slaughtered,
+		Notified:    result.Notified,
+		Failed:      result.Failed,
+	})
+}
+
+// NotifyBatch handles POST /api/v1/admin/qurbani/batches/{batchID}/notifications.
+//
+//	@Summary		Notify a batch's customers again
+//	@Description	Tell the customers of a completed batch who have not been reached yet that their sacrifice is done.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			batchID	path		string									true	"Batch ID"
+//	@Success		200		{object}	dto.QurbaniNotificationSuccessResponse	"Customers notified"
+//	@Failure		400		{object}	dto.ErrorBody							"Invalid batch ID"
+//	@Failure		401		{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody							"Batch not found"
+//	@Failure		409		{object}	dto.ErrorBody							"Batch not completed"
+//	@Failure		500		{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/qurbani/batches/{batchID}/notifications [post]
+func (h *AdminQurbaniHandler) NotifyBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := uuidParam(r, "batchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid batch ID")
+		return
+	}
+
+	result, err := h.notifyHandler.Handle(r.Context(), batchID)
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.QurbaniNotificationResponse{Notified: result.Notified, Failed: result.Failed})
+}
+
+// DistributionList handles GET /api/v1/admin/qurbani/distribution.
+//
+//	@Summary		Get a distribution list
+//	@Description	List where the meat of a season's shares goes, by batch and animal, with the customer to contact and the address for deliveries. Defaults to the charity portions. Cancelled bookings are left out.
+//	@Tags			Admin Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			season			query		string										true	"Season, e.g. 2027"
+//	@Param			distribution	query		string										false	"delivery, collection or charity (default)"
+//	@Param			batch_id		query		string										false	"Only shares in this batch"
+//	@Success		200				{object}	dto.QurbaniDistributionListSuccessResponse	"Distribution list"
+//	@Failure		400				{object}	dto.ErrorBody								"Invalid filter"
+//	@Failure		401				{object}	dto.ErrorBody								"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody								"Forbidden"
+//	@Failure		422				{object}	dto.ErrorBody								"Validation error"
+//	@Failure		500				{object}	dto.ErrorBody								"Internal server error"
+//	@Router			/admin/qurbani/distribution [get]
+func (h *AdminQurbaniHandler) DistributionList(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	season := q.Get("season")
+	if season == "" {
+		httpresponse.Error(w, http.StatusBadRequest, "season is required")
+		return
+	}
+	batchID, ok := optionalUUID(q.Get("batch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid batch_id")
+		return
+	}
+
+	lines, err := h.distributionHandler.Handle(r.Context(), qurbaniqry.DistributionListQuery{
+		Season:       season,
+		Distribution: q.Get("distribution"),
+		BatchID:      batchID,
+	})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	resp := make([]dto.QurbaniDistributionLineResponse, 0, len(lines))
+	for _, l := range lines {
+		line := dto.QurbaniDistributionLineResponse{
+			BookingID:     l.Booking.ID().String(),
+			NiyyahName:    l.Booking.NiyyahName(),
+			ShareType:     l.ShareType.String(),
+			Distribution:  l.Booking.Distribution().String(),
+			Status:        string(l.Booking.Status()),
+			CustomerName:  l.Customer.FullName(),
+			CustomerPhone: l.Customer.Phone().String(),
+		}
+		if l.AnimalNumber != 0 {
+			n := l.AnimalNumber
+			line.AnimalNumber = &n
+		}
+		if l.BatchNumber != 0 {
+			n := l.BatchNumber
+			line.BatchNumber = &n
+		}
+		if l.Address != nil {
+			address := toAddressResponse(l.Address)
+			line.Address = &address
+		}
+		resp = append(resp, line)
+	}
+	httpresponse.Success(w, resp)
+}
+
+func toQurbaniBatchResponses(batches []*qurbani.Batch) []dto.QurbaniBatchResponse {
+	resp := make([]dto.QurbaniBatchResponse, 0, len(batches))
+	for _, b := range batches {
+		resp = append(resp, toQurbaniBatchResponse(b))
+	}
+	return resp
+}
+
+func toQurbaniBatchResponse(b *qurbani.Batch) dto.QurbaniBatchResponse {
+	return dto.QurbaniBatchResponse{
+		ID:          b.ID().String(),
+		Season:      b.Season(),
+		Number:      b.Number(),
+		ScheduledAt: b.ScheduledAt(),
+		CompletedAt: b.CompletedAt(),
+	}
+}
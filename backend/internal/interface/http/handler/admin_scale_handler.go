@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	scalecmd "github.com/katerji/butchery-app/backend/internal/application/scale/commands"
+	scaleqry "github.com/katerji/butchery-app/backend/internal/application/scale/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/scale"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+	"github.com/katerji/butchery-app/backend/pkg/sse"
+)
+
+// streamPingInterval is how often an idle event stream is pinged.
+const streamPingInterval = 15 * time.Second
+
+// AdminScaleHandler manages weighing scales and reads weights from them
+// for the back office's weighing screen.
+type AdminScaleHandler struct {
+	createHandler *scalecmd.CreateScaleHandler
+	updateHandler *scalecmd.UpdateScaleHandler
+	tareHandler   *scalecmd.TareScaleHandler
+	listHandler   *scaleqry.ListScalesHandler
+	watchHandler  *scaleqry.WatchScaleHandler
+	weighHandler  *scaleqry.WeighHandler
+}
+
+// NewAdminScaleHandler creates a new AdminScaleHandler.
+func NewAdminScaleHandler(
+	createHandler *scalecmd.CreateScaleHandler,
+	updateHandler *scalecmd.UpdateScaleHandler,
+	tareHandler *scalecmd.TareScaleHandler,
+	listHandler *scaleqry.ListScalesHandler,
+	watchHandler *scaleqry.WatchScaleHandler,
+	weighHandler *scaleqry.WeighHandler,
+) *AdminScaleHandler {
+	return &AdminScaleHandler{
+		createHandler: createHandler,
+		updateHandler: updateHandler,
+		tareHandler:   tareHandler,
+		listHandler:   listHandler,
+		watchHandler:  watchHandler,
+		weighHandler:  weighHandler,
+	}
+}
+
+// ListScales handles GET /api/v1/admin/scales.
+//
+//	@Summary		List scales
+//	@Description	List every weighing scale by name, disabled ones included.
+//	@Tags			Admin Scales
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.ScaleListSuccessResponse	"Scales"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/scales [get]
+func (h *AdminScaleHandler) ListScales(w http.ResponseWriter, r *http.Request) {
+	scales, err := h.listHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.ScaleResponse, 0, len(scales))
+	for _, s := range scales {
+		resp = append(resp, toScaleResponse(s))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateScale handles POST /api/v1/admin/scales.
+//
+//	@Summary		Register a scale
+//	@Description	Register a weighing scale that speaks MT-SICS (Mettler Toledo) or CAS/Dibal-style continuous output, reached over TCP, e.g. through a serial-to-Ethernet adapter, or on a serial device of the server.
+//	@Tags			Admin Scales
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ScaleRequest			true	"Scale"
+//	@Success		201		{object}	dto.ScaleSuccessResponse	"Scale registered"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody				"Name already in use"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/scales [post]
+func (h *AdminScaleHandler) CreateScale(w http.ResponseWriter, r *http.Request) {
+	var req dto.ScaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	s, err := h.createHandler.Handle(r.Context(), scalecmd.CreateScaleCommand{
+		Name:      req.Name,
+		Protocol:  scale.Protocol(req.Protocol),
+		Transport: scale.Transport(req.Transport),
+		Address:   req.Address,
+		BaudRate:  req.BaudRate,
+	})
+	if err != nil {
+		writeScaleError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toScaleResponse(s))
+}
+
+// UpdateScale handles PUT /api/v1/admin/scales/{scaleID}.
+//
+//	@Summary		Update a scale
+//	@Description	Rename, reconnect or disable a scale. Screens already reading it keep their connection until they reconnect.
+//	@Tags			Admin Scales
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			scaleID	path		string						true	"Scale ID"
+//	@Param			body	body		dto.UpdateScaleRequest		true	"Scale"
+//	@Success		200		{object}	dto.ScaleSuccessResponse	"Scale updated"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody				"Scale not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Name already in use"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/scales/{scaleID} [put]
+func (h *AdminScaleHandler) UpdateScale(w http.ResponseWriter, r *http.Request) {
+	scaleID, ok := uuidParam(r, "scaleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid scale ID")
+		return
+	}
+	var req dto.UpdateScaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	s, err := h.updateHandler.Handle(r.Context(), scalecmd.UpdateScaleCommand{
+		ScaleID:   scaleID,
+		Name:      req.Name,
+		Protocol:  scale.Protocol(req.Protocol),
+		Transport: scale.Transport(req.Transport),
+		Address:   req.Address,
+		BaudRate:  req.BaudRate,
+		Active:    req.Active,
+	})
+	if err != nil {
+		writeScaleError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toScaleResponse(s))
+}
+
+// StreamReadings handles GET /api/v1/admin/scales/{scaleID}/readings/stream.
+//
+//	@Summary		Stream a scale's readings
+//	@Description	Stream a scale's readings as server-sent events for the weighing screen. Each "reading" event carries a dto.ScaleReadingResponse; stable is only set once the weight has held still for SCALE_STABLE_WINDOW. An "error" event carrying a dto.ScaleErrorEvent is sent while the scale is overloaded, and once more if its connection is lost, after which the stream ends. Screens watching the same scale share one connection to it.
+//	@Tags			Admin Scales
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			scaleID	path		string			true	"Scale ID"
+//	@Success		200		{string}	string			"Event stream"
+//	@Failure		400		{object}	dto.ErrorBody	"Invalid scale ID"
+//	@Failure		401		{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody	"Scale not found"
+//	@Failure		409		{object}	dto.ErrorBody	"Scale is disabled"
+//	@Failure		502		{object}	dto.ErrorBody	"Scale could not be reached"
+//	@Failure		500		{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/scales/{scaleID}/readings/stream [get]
+func (h *AdminScaleHandler) StreamReadings(w http.ResponseWriter, r *http.Request) {
+	scaleID, ok := uuidParam(r, "scaleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid scale ID")
+		return
+	}
+
+	events, err := h.watchHandler.Handle(r.Context(), scaleID)
+	if err != nil {
+		writeScaleError(w, err)
+		return
+	}
+	stream, err := sse.Open(w)
+	if err != nil {
+		return
+	}
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.Err != nil {
+				err = stream.Send("error", dto.ScaleErrorEvent{Error: e.Err.Error()})
+			} else {
+				err = stream.Send("reading", toScaleReadingResponse(e.Reading))
+			}
+		case <-ping.C:
+			err = stream.Ping()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Weigh handles GET /api/v1/admin/scales/{scaleID}/reading.
+//
+//	@Summary		Take a stable weight
+//	@Description	Wait for the scale to settle and return its weight, for filling in an order line without a stream. Gives up after SCALE_WEIGH_TIMEOUT.
+//	@Tags			Admin Scales
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			scaleID	path		string							true	"Scale ID"
+//	@Success		200		{object}	dto.ScaleReadingSuccessResponse	"Stable reading"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid scale ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Scale not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Scale is disabled, overloaded or did not settle"
+//	@Failure		502		{object}	dto.ErrorBody					"Scale could not be reached"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/scales/{scaleID}/reading [get]
+func (h *AdminScaleHandler) Weigh(w http.ResponseWriter, r *http.Request) {
+	scaleID, ok := uuidParam(r, "scaleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid scale ID")
+		return
+	}
+
+	reading, err := h.weighHandler.Handle(r.Context(), scaleID)
+	if err != nil {
+		writeScaleError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toScaleReadingResponse(reading))
+}
+
+// Tare handles POST /api/v1/admin/scales/{scaleID}/tare.
+//
+//	@Summary		Tare a scale
+//	@Description	Take the weight on the scale, such as a tray, off its later readings. Only MT-SICS scales can be tared remotely; CAS-style scales are tared on their keypad and their streams pick the tare up.
+//	@Tags			Admin Scales
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			scaleID	path	string	true	"Scale ID"
+//	@Success		204		"Scale tared"
+//	@Failure		400		{object}	dto.ErrorBody	"Invalid scale ID"
+//	@Failure		401		{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody	"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody	"Scale not found"
+//	@Failure		409		{object}	dto.ErrorBody	"Scale is disabled or refused to tare"
+//	@Failure		422		{object}	dto.ErrorBody	"Scale cannot be tared remotely"
+//	@Failure		502		{object}	dto.ErrorBody	"Scale could not be reached"
+//	@Failure		500		{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/scales/{scaleID}/tare [post]
+func (h *AdminScaleHandler) Tare(w http.ResponseWriter, r *http.Request) {
+	scaleID, ok := uuidParam(r, "scaleID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid scale ID")
+		return
+	}
+
+	if err := h.tareHandler.Handle(r.Context(), scaleID); err != nil {
+		writeScaleError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+func toScaleResponse(s *scale.Scale) dto.ScaleResponse {
+	return dto.ScaleResponse{
+		ID:        s.ID().String(),
+		Name:      s.Name(),
+		Protocol:  string(s.Protocol()),
+		Transport: string(s.Transport()),
+		Address:   s.Address(),
+		BaudRate:  s.BaudRate(),
+		Active:    s.Active(),
+		CreatedAt: s.CreatedAt(),
+		UpdatedAt: s.UpdatedAt(),
+	}
+}
+
+func toScaleReadingResponse(r scale.Reading) dto.ScaleReadingResponse {
+	return dto.ScaleReadingResponse{
+		Grams:      r.Grams,
+		TareGrams:  r.TareGrams,
+		GrossGrams: r.GrossGrams(),
+		Stable:     r.Stable,
+		At:         r.At,
+	}
+}
+
+// writeScaleError maps scale errors to responses. A scale that cannot be
+// reached or understood is a bad gateway.
+func writeScaleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, scale.ErrScaleNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, scale.ErrDuplicateScale),
+		errors.Is(err, scale.ErrScaleInactive),
+		errors.Is(err, scale.ErrTareRefused),
+		errors.Is(err, scale.ErrNotStable),
+		errors.Is(err, scale.ErrOverload),
+		errors.Is(err, scale.ErrUnderload):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, scale.ErrEmptyName),
+		errors.Is(err, scale.ErrUnknownProtocol),
+		errors.Is(err, scale.ErrUnknownTransport),
+		errors.Is(err, scale.ErrInvalidAddress),
+		errors.Is(err, scale.ErrInvalidBaudRate),
+		errors.Is(err, scale.ErrTareUnsupported):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, scale.ErrScaleDisconnected),
+		errors.Is(err, scale.ErrUnreadable),
+		errors.Is(err, scale.ErrSerialUnsupported):
+		httpresponse.Error(w, http.StatusBadGateway, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
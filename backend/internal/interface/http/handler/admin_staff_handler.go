@@ -0,0 +1,700 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	staffcmd "github.com/katerji/butchery-app/backend/internal/application/staff/commands"
+	staffqry "github.com/katerji/butchery-app/backend/internal/application/staff/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/staff"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminStaffHandler plans the rotas of butchers and cashiers at each branch
+// and keeps the time they clock.
+type AdminStaffHandler struct {
+	setProfileHandler       *staffcmd.SetProfileHandler
+	scheduleShiftHandler    *staffcmd.ScheduleShiftHandler
+	updateShiftHandler      *staffcmd.UpdateShiftHandler
+	deleteShiftHandler      *staffcmd.DeleteShiftHandler
+	clockInHandler          *staffcmd.ClockInHandler
+	startBreakHandler       *staffcmd.StartBreakHandler
+	endBreakHandler         *staffcmd.EndBreakHandler
+	clockOutHandler         *staffcmd.ClockOutHandler
+	listProfilesHandler     *staffqry.ListProfilesHandler
+	rotaHandler             *staffqry.RotaHandler
+	timesheetsHandler       *staffqry.TimesheetsHandler
+	exportTimesheetsHandler *staffqry.ExportTimesheetsHandler
+}
+
+// NewAdminStaffHandler creates a new AdminStaffHandler.
+func NewAdminStaffHandler(
+	setProfileHandler *staffcmd.SetProfileHandler,
+	scheduleShiftHandler *staffcmd.ScheduleShiftHandler,
+	updateShiftHandler *staffcmd.UpdateShiftHandler,
+	deleteShiftHandler *staffcmd.DeleteShiftHandler,
+	clockInHandler *staffcmd.ClockInHandler,
+	startBreakHandler *staffcmd.StartBreakHandler,
+	endBreakHandler *staffcmd.EndBreakHandler,
+	clockOutHandler *staffcmd.ClockOutHandler,
+	listProfilesHandler *staffqry.ListProfilesHandler,
+	rotaHandler *staffqry.RotaHandler,
+	timesheetsHandler *staffqry.TimesheetsHandler,
+	exportTimesheetsHandler *staffqry.ExportTimesheetsHandler,
+) *AdminStaffHandler {
+	return &AdminStaffHandler{
+		setProfileHandler:       setProfileHandler,
+		scheduleShiftHandler:    scheduleShiftHandler,
+		updateShiftHandler:      updateShiftHandler,
+		deleteShiftHandler:      deleteShiftHandler,
+		clockInHandler:          clockInHandler,
+		startBreakHandler:       startBreakHandler,
+		endBreakHandler:         endBreakHandler,
+		clockOutHandler:         clockOutHandler,
+		listProfilesHandler:     listProfilesHandler,
+		rotaHandler:             rotaHandler,
+		timesheetsHandler:       timesheetsHandler,
+		exportTimesheetsHandler: exportTimesheetsHandler,
+	}
+}
+
+// ListProfiles handles GET /api/v1/admin/staff.
+//
+//	@Summary		List staff
+//	@Description	List the staff profiles, with pay, by name, those off the rota included. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string								false	"Only staff based at this branch"
+//	@Success		200			{object}	dto.StaffProfileListSuccessResponse	"Staff"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid branch ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Not a manager"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/staff [get]
+func (h *AdminStaffHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := optionalUUID(r.URL.Query().Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+
+	profiles, err := h.listProfilesHandler.Handle(r.Context(), staffqry.ListProfilesQuery{
+		BranchID: branchID,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	resp := make([]dto.StaffProfileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		resp = append(resp, toStaffProfileResponse(p))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// SetProfile handles PUT /api/v1/admin/staff/{adminID}.
+//
+//	@Summary		Set a staff profile
+//	@Description	Put an admin on the rota of a branch, or change where they are based, what they are trained for, what they are paid and their role: staff, cashier (till only) or manager. Drivers are taken on through dispatch instead. Shifts already scheduled are not changed. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			adminID	path		string							true	"Admin ID"
+//	@Param			body	body		dto.StaffProfileRequest			true	"Profile"
+//	@Success		200		{object}	dto.StaffProfileSuccessResponse	"Profile set"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Not a manager, or branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody					"Admin or branch not found"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/staff/{adminID} [put]
+func (h *AdminStaffHandler) SetProfile(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := uuidParam(r, "adminID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid admin ID")
+		return
+	}
+	var req dto.StaffProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, ok := optionalUUID(req.BranchID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	cmd := staffcmd.SetProfileCommand{
+		AdminID:           adminID,
+		Position:          staff.Position(req.Position),
+		HourlyRateCents:   req.HourlyRateCents,
+		ContractedMinutes: req.ContractedMinutes,
+		Active:            req.Active,
+		Role:              admin.Role(req.Role),
+		ActorID:           middleware.ClaimsFromContext(r.Context()).SubjectID,
+	}
+	if branchID != nil {
+		cmd.BranchID = *branchID
+	}
+	p, err := h.setProfileHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toStaffProfileResponse(p))
+}
+
+// Rota handles GET /api/v1/admin/staff/rota.
+//
+//	@Summary		Show a branch's rota
+//	@Description	Show the shifts at a branch for a Monday-to-Sunday week, how many trained butchers each fulfilment slot needs for the orders booked into it, and the conflicts: staff double booked at any branch, shifts too long for their breaks, staff rostered beyond their contracted hours, and slots short of butchers. Conflicts are warnings; shifts are scheduled regardless.
+//	@Tags			Admin Staff
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			branch_id	query		string					true	"Branch ID"
+//	@Param			week		query		string					false	"Any day in the week, YYYY-MM-DD (default this week)"
+//	@Success		200			{object}	dto.RotaSuccessResponse	"Rota"
+//	@Failure		400			{object}	dto.ErrorBody			"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody			"Branch out of scope"
+//	@Failure		500			{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/staff/rota [get]
+func (h *AdminStaffHandler) Rota(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	branchID, err := uuid.Parse(q.Get("branch_id"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	week := time.Now()
+	if raw := q.Get("week"); raw != "" {
+		if week, err = time.Parse(time.DateOnly, raw); err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "week must be a YYYY-MM-DD date")
+			return
+		}
+	}
+
+	rota, err := h.rotaHandler.Handle(r.Context(), staffqry.RotaQuery{BranchID: branchID, Week: week})
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	resp := dto.RotaResponse{
+		BranchID:  rota.BranchID.String(),
+		WeekStart: rota.WeekStart,
+		Shifts:    make([]dto.ShiftResponse, 0, len(rota.Shifts)),
+		Coverage:  make([]dto.SlotCoverageResponse, 0, len(rota.Coverage)),
+		Conflicts: make([]dto.RotaConflictResponse, 0, len(rota.Conflicts)),
+	}
+	for _, s := range rota.Shifts {
+		resp.Shifts = append(resp.Shifts, toShiftResponse(s))
+	}
+	for _, c := range rota.Coverage {
+		resp.Coverage = append(resp.Coverage, dto.SlotCoverageResponse{
+			StartsAt:  c.StartsAt,
+			EndsAt:    c.EndsAt,
+			Orders:    c.Orders,
+			Grams:     c.Grams,
+			Required:  c.Required,
+			Scheduled: c.Scheduled,
+		})
+	}
+	for _, c := range rota.Conflicts {
+		shiftIDs := make([]string, 0, len(c.ShiftIDs))
+		for _, id := range c.ShiftIDs {
+			shiftIDs = append(shiftIDs, id.String())
+		}
+		resp.Conflicts = append(resp.Conflicts, dto.RotaConflictResponse{
+			Kind:      string(c.Kind),
+			AdminID:   uuidString(c.AdminID),
+			ShiftIDs:  shiftIDs,
+			StartsAt:  c.StartsAt,
+			EndsAt:    c.EndsAt,
+			Minutes:   c.Minutes,
+			Required:  c.Required,
+			Scheduled: c.Scheduled,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+// ScheduleShift handles POST /api/v1/admin/staff/shifts.
+//
+//	@Summary		Schedule a shift
+//	@Description	Put a member of staff on the rota at a branch, in a position they are trained for. Check the rota afterwards for conflicts. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ShiftRequest			true	"Shift"
+//	@Success		201		{object}	dto.ShiftSuccessResponse	"Shift scheduled"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager, or branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody				"Staff profile or branch not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Staff member off the rota"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/staff/shifts [post]
+func (h *AdminStaffHandler) ScheduleShift(w http.ResponseWriter, r *http.Request) {
+	var req dto.ShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	adminID, err := uuid.Parse(req.AdminID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid admin ID")
+		return
+	}
+	branchID, ok := optionalUUID(req.BranchID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	cmd := staffcmd.ScheduleShiftCommand{
+		AdminID:  adminID,
+		Position: staff.Position(req.Position),
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Break:    time.Duration(req.BreakMinutes) * time.Minute,
+		Note:     req.Note,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	}
+	if branchID != nil {
+		cmd.BranchID = *branchID
+	}
+	s, err := h.scheduleShiftHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toShiftResponse(s))
+}
+
+// UpdateShift handles PUT /api/v1/admin/staff/shifts/{shiftID}.
+//
+//	@Summary		Change a shift
+//	@Description	Move a shift to another time, branch or position. admin_id in the body is ignored; delete the shift and schedule another to give it to someone else. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			shiftID	path		string						true	"Shift ID"
+//	@Param			body	body		dto.ShiftRequest			true	"Shift"
+//	@Success		200		{object}	dto.ShiftSuccessResponse	"Shift changed"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Not a manager, or branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody				"Shift or branch not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Staff member off the rota"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/staff/shifts/{shiftID} [put]
+func (h *AdminStaffHandler) UpdateShift(w http.ResponseWriter, r *http.Request) {
+	shiftID, ok := uuidParam(r, "shiftID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid shift ID")
+		return
+	}
+	var req dto.ShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, ok := optionalUUID(req.BranchID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	cmd := staffcmd.UpdateShiftCommand{
+		ShiftID:  shiftID,
+		Position: staff.Position(req.Position),
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Break:    time.Duration(req.BreakMinutes) * time.Minute,
+		Note:     req.Note,
+		ActorID:  middleware.ClaimsFromContext(r.Context()).SubjectID,
+	}
+	if branchID != nil {
+		cmd.BranchID = *branchID
+	}
+	s, err := h.updateShiftHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toShiftResponse(s))
+}
+
+// DeleteShift handles DELETE /api/v1/admin/staff/shifts/{shiftID}.
+//
+//	@Summary		Delete a shift
+//	@Description	Take a shift off the rota. Time already clocked is kept. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Security		BearerAuth
+//	@Param			shiftID	path	string	true	"Shift ID"
+//	@Success		204		"Shift deleted"
+//	@Failure		400		{object}	dto.ErrorBody	"Invalid shift ID"
+//	@Failure		401		{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody	"Not a manager"
+//	@Failure		404		{object}	dto.ErrorBody	"Shift not found"
+//	@Failure		500		{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/staff/shifts/{shiftID} [delete]
+func (h *AdminStaffHandler) DeleteShift(w http.ResponseWriter, r *http.Request) {
+	shiftID, ok := uuidParam(r, "shiftID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid shift ID")
+		return
+	}
+
+	err := h.deleteShiftHandler.Handle(r.Context(), staffcmd.DeleteShiftCommand{
+		ShiftID: shiftID,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// ClockIn handles POST /api/v1/admin/staff/clock/in.
+//
+//	@Summary		Clock in
+//	@Description	Start the signed-in member of staff's working time at a branch, their own by default.
+//	@Tags			Admin Staff
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ClockInRequest				false	"Branch"
+//	@Success		201		{object}	dto.ClockEntrySuccessResponse	"Clocked in"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Branch out of scope"
+//	@Failure		404		{object}	dto.ErrorBody					"No staff profile"
+//	@Failure		409		{object}	dto.ErrorBody					"Already clocked in, or off the rota"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/staff/clock/in [post]
+func (h *AdminStaffHandler) ClockIn(w http.ResponseWriter, r *http.Request) {
+	var req dto.ClockInRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	cmd := staffcmd.ClockInCommand{AdminID: middleware.ClaimsFromContext(r.Context()).SubjectID}
+	if req.BranchID != nil {
+		var ok bool
+		if cmd.BranchID, ok = optionalUUID(*req.BranchID); !ok {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+			return
+		}
+	}
+
+	e, err := h.clockInHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toClockEntryResponse(e, time.Now()))
+}
+
+// StartBreak handles POST /api/v1/admin/staff/clock/break/start.
+//
+//	@Summary		Start a break
+//	@Description	Start a break in the signed-in member of staff's working time.
+//	@Tags			Admin Staff
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.ClockEntrySuccessResponse	"On break"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404	{object}	dto.ErrorBody					"Not clocked in"
+//	@Failure		409	{object}	dto.ErrorBody					"Already on a break"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/staff/clock/break/start [post]
+func (h *AdminStaffHandler) StartBreak(w http.ResponseWriter, r *http.Request) {
+	h.clock(w, r, h.startBreakHandler.Handle)
+}
+
+// EndBreak handles POST /api/v1/admin/staff/clock/break/end.
+//
+//	@Summary		End a break
+//	@Description	End the signed-in member of staff's break.
+//	@Tags			Admin Staff
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.ClockEntrySuccessResponse	"Back from break"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404	{object}	dto.ErrorBody					"Not clocked in"
+//	@Failure		409	{object}	dto.ErrorBody					"Not on a break"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/staff/clock/break/end [post]
+func (h *AdminStaffHandler) EndBreak(w http.ResponseWriter, r *http.Request) {
+	h.clock(w, r, h.endBreakHandler.Handle)
+}
+
+// ClockOut handles POST /api/v1/admin/staff/clock/out.
+//
+//	@Summary		Clock out
+//	@Description	End the signed-in member of staff's working time, and any break they are on.
+//	@Tags			Admin Staff
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.ClockEntrySuccessResponse	"Clocked out"
+//	@Failure		401	{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404	{object}	dto.ErrorBody					"Not clocked in"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/staff/clock/out [post]
+func (h *AdminStaffHandler) ClockOut(w http.ResponseWriter, r *http.Request) {
+	h.clock(w, r, h.clockOutHandler.Handle)
+}
+
+// clock runs a change to the signed-in admin's open clock entry.
+func (h *AdminStaffHandler) clock(w http.ResponseWriter, r *http.Request,
+	handle func(context.Context, staffcmd.ClockCommand) (*staff.ClockEntry, error),
+) {
+	e, err := handle(r.Context(), staffcmd.ClockCommand{AdminID: middleware.ClaimsFromContext(r.Context()).SubjectID})
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toClockEntryResponse(e, time.Now()))
+}
+
+// Timesheets handles GET /api/v1/admin/staff/timesheets.
+//
+//	@Summary		List timesheets
+//	@Description	Total the time each member of staff was rostered for and clocked from the first day to the last, with overtime beyond their contracted hours each week, breaks too short, and pay. Days run in the shop's time zone. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			from		query		string							true	"First day, YYYY-MM-DD"
+//	@Param			to			query		string							true	"Last day, YYYY-MM-DD"
+//	@Param			branch_id	query		string							false	"Only staff based at this branch"
+//	@Param			admin_id	query		string							false	"Only this member of staff"
+//	@Success		200			{object}	dto.TimesheetListSuccessResponse	"Timesheets"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Not a manager"
+//	@Failure		422			{object}	dto.ErrorBody					"Invalid period"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/staff/timesheets [get]
+func (h *AdminStaffHandler) Timesheets(w http.ResponseWriter, r *http.Request) {
+	q, ok := timesheetsQuery(w, r)
+	if !ok {
+		return
+	}
+
+	sheets, _, err := h.timesheetsHandler.Handle(r.Context(), q)
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	now := time.Now()
+	resp := make([]dto.TimesheetResponse, 0, len(sheets))
+	for _, s := range sheets {
+		entries := make([]dto.TimesheetEntryResponse, 0, len(s.Entries))
+		for _, l := range s.Entries {
+			entries = append(entries, dto.TimesheetEntryResponse{
+				ClockEntryResponse:  toClockEntryResponse(l.Entry, now),
+				BreakMinutes:        l.BreakMinutes,
+				MissingBreakMinutes: l.MissingBreakMinutes,
+			})
+		}
+		resp = append(resp, dto.TimesheetResponse{
+			Staff:            toStaffProfileResponse(s.Profile),
+			Entries:          entries,
+			ScheduledMinutes: s.ScheduledMinutes,
+			WorkedMinutes:    s.WorkedMinutes,
+			BreakMinutes:     s.BreakMinutes,
+			OvertimeMinutes:  s.OvertimeMinutes,
+			MissingBreaks:    s.MissingBreaks,
+			PayCents:         s.PayCents,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+// ExportTimesheets handles GET /api/v1/admin/staff/timesheets/export.
+//
+//	@Summary		Export timesheets
+//	@Description	Export the timesheets from the first day to the last for payroll, one row per clock entry and a total row for each member of staff. Needs the manage_staff permission.
+//	@Tags			Admin Staff
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			from		query		string			true	"First day, YYYY-MM-DD"
+//	@Param			to			query		string			true	"Last day, YYYY-MM-DD"
+//	@Param			branch_id	query		string			false	"Only staff based at this branch"
+//	@Param			admin_id	query		string			false	"Only this member of staff"
+//	@Param			format		query		string			false	"csv (default csv)"
+//	@Success		200			{file}		file			"Timesheets"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody	"Not a manager"
+//	@Failure		422			{object}	dto.ErrorBody	"Invalid period or format"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/admin/staff/timesheets/export [get]
+func (h *AdminStaffHandler) ExportTimesheets(w http.ResponseWriter, r *http.Request) {
+	q, ok := timesheetsQuery(w, r)
+	if !ok {
+		return
+	}
+	format := staff.FormatCSV
+	if raw := r.URL.Query().Get("format"); raw != "" {
+		format = staff.Format(raw)
+	}
+
+	out, err := h.exportTimesheetsHandler.Handle(r.Context(), q, format)
+	if err != nil {
+		writeStaffError(w, err)
+		return
+	}
+
+	name := fmt.Sprintf("timesheets-%s-%s.%s", q.From.Format(time.DateOnly), q.To.Format(time.DateOnly), format)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.Header().Set("Content-Length", strconv.Itoa(len(out)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+// timesheetsQuery reads the timesheets wanted from the query, writing a
+// 400 if it is invalid.
+func timesheetsQuery(w http.ResponseWriter, r *http.Request) (staffqry.TimesheetsQuery, bool) {
+	q := r.URL.Query()
+	query := staffqry.TimesheetsQuery{ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID}
+	var err error
+	if query.From, err = time.Parse(time.DateOnly, q.Get("from")); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "from must be a YYYY-MM-DD date")
+		return query, false
+	}
+	if query.To, err = time.Parse(time.DateOnly, q.Get("to")); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "to must be a YYYY-MM-DD date")
+		return query, false
+	}
+	var ok bool
+	if query.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return query, false
+	}
+	if query.AdminID, ok = optionalUUID(q.Get("admin_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid admin_id")
+		return query, false
+	}
+	return query, true
+}
+
+func toStaffProfileResponse(p *staff.Profile) dto.StaffProfileResponse {
+	return dto.StaffProfileResponse{
+		AdminID:           p.AdminID().String(),
+		FullName:          p.FullName(),
+		BranchID:          p.BranchID().String(),
+		Position:          string(p.Position()),
+		HourlyRateCents:   p.HourlyRateCents(),
+		ContractedMinutes: p.ContractedMinutes(),
+		Active:            p.Active(),
+		CreatedAt:         p.CreatedAt(),
+		UpdatedAt:         p.UpdatedAt(),
+	}
+}
+
+func toShiftResponse(s *staff.Shift) dto.ShiftResponse {
+	return dto.ShiftResponse{
+		ID:           s.ID().String(),
+		AdminID:      s.AdminID().String(),
+		BranchID:     s.BranchID().String(),
+		Position:     string(s.Position()),
+		StartsAt:     s.StartsAt(),
+		EndsAt:       s.EndsAt(),
+		BreakMinutes: int(s.Break() / time.Minute),
+		Minutes:      s.Minutes(),
+		Note:         s.Note(),
+		CreatedBy:    s.CreatedBy().String(),
+		CreatedAt:    s.CreatedAt(),
+		UpdatedAt:    s.UpdatedAt(),
+	}
+}
+
+// toClockEntryResponse shows an entry, with the time worked so far as of
+// now if it is still open.
+func toClockEntryResponse(e *staff.ClockEntry, now time.Time) dto.ClockEntryResponse {
+	breaks := make([]dto.BreakResponse, 0, len(e.Breaks()))
+	for _, b := range e.Breaks() {
+		breaks = append(breaks, dto.BreakResponse{StartedAt: b.StartedAt, EndedAt: b.EndedAt})
+	}
+	if out := e.ClockedOutAt(); out != nil {
+		now = *out
+	}
+	return dto.ClockEntryResponse{
+		ID:            e.ID().String(),
+		AdminID:       e.AdminID().String(),
+		BranchID:      e.BranchID().String(),
+		ClockedInAt:   e.ClockedInAt(),
+		ClockedOutAt:  e.ClockedOutAt(),
+		OnBreak:       e.OnBreak(),
+		Breaks:        breaks,
+		WorkedMinutes: e.WorkedMinutes(now),
+	}
+}
+
+func writeStaffError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, staff.ErrManageNotAllowed):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, staff.ErrProfileNotFound),
+		errors.Is(err, staff.ErrShiftNotFound),
+		errors.Is(err, staff.ErrEntryNotFound),
+		errors.Is(err, admin.ErrAdminNotFound),
+		errors.Is(err, branch.ErrBranchNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, staff.ErrAlreadyClockedIn),
+		errors.Is(err, staff.ErrAlreadyOnBreak),
+		errors.Is(err, staff.ErrNotOnBreak),
+		errors.Is(err, staff.ErrProfileInactive):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, staff.ErrBranchRequired),
+		errors.Is(err, staff.ErrInvalidPosition),
+		errors.Is(err, staff.ErrNegativeRate),
+		errors.Is(err, staff.ErrInvalidContract),
+		errors.Is(err, staff.ErrPositionNotAllowed),
+		errors.Is(err, staff.ErrInvalidShiftTimes),
+		errors.Is(err, staff.ErrInvalidBreak),
+		errors.Is(err, staff.ErrInvalidPeriod),
+		errors.Is(err, staff.ErrUnsupportedFormat),
+		errors.Is(err, admin.ErrInvalidRole),
+		errors.Is(err, admin.ErrRoleNotAssignable):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
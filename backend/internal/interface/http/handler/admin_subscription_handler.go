@@ -0,0 +1,317 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	subscriptioncmd "github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	subscriptionqry "github.com/katerji/butchery-app/backend/internal/application/subscription/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminSubscriptionHandler manages subscription boxes, oversees customers'
+// subscriptions and runs the scheduler that generates their orders.
+type AdminSubscriptionHandler struct {
+	createBoxHandler     *subscriptioncmd.CreateBoxHandler
+	updateBoxHandler     *subscriptioncmd.UpdateBoxHandler
+	boxesHandler         *subscriptionqry.ListBoxesHandler
+	subscriptionsHandler *subscriptionqry.ListSubscriptionsHandler
+	renewalsHandler      *subscriptionqry.ListRenewalsHandler
+	runHandler           *subscriptioncmd.RunRenewalsHandler
+}
+
+// NewAdminSubscriptionHandler creates a new AdminSubscriptionHandler.
+func NewAdminSubscriptionHandler(
+	createBoxHandler *subscriptioncmd.CreateBoxHandler,
+	updateBoxHandler *subscriptioncmd.UpdateBoxHandler,
+	boxesHandler *subscriptionqry.ListBoxesHandler,
+	subscriptionsHandler *subscriptionqry.ListSubscriptionsHandler,
+	renewalsHandler *subscriptionqry.ListRenewalsHandler,
+	runHandler *subscriptioncmd.RunRenewalsHandler,
+) *AdminSubscriptionHandler {
+	return &AdminSubscriptionHandler{
+		createBoxHandler:     createBoxHandler,
+		updateBoxHandler:     updateBoxHandler,
+		boxesHandler:         boxesHandler,
+		subscriptionsHandler: subscriptionsHandler,
+		renewalsHandler:      renewalsHandler,
+		runHandler:           runHandler,
+	}
+}
+
+// ListBoxes handles GET /api/v1/admin/subscriptions/boxes.
+//
+//	@Summary		List subscription boxes
+//	@Description	List every box, including those withdrawn from new subscribers.
+//	@Tags			Admin Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.SubscriptionBoxListSuccessResponse	"Boxes"
+//	@Failure		401	{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody							"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/admin/subscriptions/boxes [get]
+func (h *AdminSubscriptionHandler) ListBoxes(w http.ResponseWriter, r *http.Request) {
+	boxes, err := h.boxesHandler.Handle(r.Context(), false)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionBoxResponses(boxes))
+}
+
+// CreateBox handles POST /api/v1/admin/subscriptions/boxes.
+//
+//	@Summary		Create a subscription box
+//	@Description	Add a box customers can subscribe to. A customizable box lists, for each cut, the cuts customers may swap it for.
+//	@Tags			Admin Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.SubscriptionBoxRequest			true	"Box"
+//	@Success		201		{object}	dto.SubscriptionBoxSuccessResponse	"Box created"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/subscriptions/boxes [post]
+func (h *AdminSubscriptionHandler) CreateBox(w http.ResponseWriter, r *http.Request) {
+	var req dto.SubscriptionBoxRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	items, ok := boxItems(req.Items)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	b, err := h.createBoxHandler.Handle(r.Context(), subscriptioncmd.CreateBoxCommand{
+		Name:         req.Name,
+		Customizable: req.Customizable,
+		Items:        items,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toSubscriptionBoxResponse(b))
+}
+
+// UpdateBox handles PUT /api/v1/admin/subscriptions/boxes/{boxID}.
+//
+//	@Summary		Update a subscription box
+//	@Description	Change a box's contents or withdraw it from new subscribers. Existing subscribers get the new contents from their next order; swaps no longer offered are dropped.
+//	@Tags			Admin Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boxID	path		string								true	"Box ID"
+//	@Param			body	body		dto.UpdateSubscriptionBoxRequest	true	"Box"
+//	@Success		200		{object}	dto.SubscriptionBoxSuccessResponse	"Box updated"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Box not found"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/subscriptions/boxes/{boxID} [put]
+func (h *AdminSubscriptionHandler) UpdateBox(w http.ResponseWriter, r *http.Request) {
+	boxID, ok := uuidParam(r, "boxID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid box ID")
+		return
+	}
+	var req dto.UpdateSubscriptionBoxRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	items, ok := boxItems(req.Items)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	b, err := h.updateBoxHandler.Handle(r.Context(), subscriptioncmd.UpdateBoxCommand{
+		BoxID:        boxID,
+		Name:         req.Name,
+		Customizable: req.Customizable,
+		Items:        items,
+		Active:       req.Active,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionBoxResponse(b))
+}
+
+// ListSubscriptions handles GET /api/v1/admin/subscriptions.
+//
+//	@Summary		List subscriptions
+//	@Description	List customers' subscriptions, oldest first.
+//	@Tags			Admin Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string								false	"active, paused or cancelled"
+//	@Param			customer_id	query		string								false	"Customer ID"
+//	@Success		200			{object}	dto.SubscriptionListSuccessResponse	"Subscriptions"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid filter"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/subscriptions [get]
+func (h *AdminSubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	var filter subscription.Filter
+	q := r.URL.Query()
+	if raw := q.Get("status"); raw != "" {
+		status, err := subscription.NewStatus(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Status = &status
+	}
+	customerID, ok := optionalUUID(q.Get("customer_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+	filter.CustomerID = customerID
+
+	subs, err := h.subscriptionsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponses(subs))
+}
+
+// ListRenewals handles GET /api/v1/admin/subscriptions/renewals.
+//
+//	@Summary		List subscription orders
+//	@Description	List the orders generated for subscription deliveries by delivery date, e.g. those whose payment failed.
+//	@Tags			Admin Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status			query		string										false	"awaiting_payment, ordered or failed"
+//	@Param			subscription_id	query		string										false	"Subscription ID"
+//	@Param			from			query		string										false	"Earliest delivery date (YYYY-MM-DD)"
+//	@Success		200				{object}	dto.SubscriptionRenewalListSuccessResponse	"Renewals"
+//	@Failure		400				{object}	dto.ErrorBody								"Invalid filter"
+//	@Failure		401				{object}	dto.ErrorBody								"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody								"Forbidden"
+//	@Failure		500				{object}	dto.ErrorBody								"Internal server error"
+//	@Router			/admin/subscriptions/renewals [get]
+func (h *AdminSubscriptionHandler) ListRenewals(w http.ResponseWriter, r *http.Request) {
+	var filter subscription.RenewalFilter
+	q := r.URL.Query()
+	if raw := q.Get("status"); raw != "" {
+		status, err := subscription.NewRenewalStatus(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Status = &status
+	}
+	subscriptionID, ok := optionalUUID(q.Get("subscription_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription_id")
+		return
+	}
+	filter.SubscriptionID = subscriptionID
+	if raw := q.Get("from"); raw != "" {
+		from, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "from must be a YYYY-MM-DD date")
+			return
+		}
+		filter.From = &from
+	}
+
+	renewals, err := h.renewalsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionRenewalResponses(renewals))
+}
+
+// RunRenewals handles POST /api/v1/admin/subscriptions/renewals/run.
+//
+//	@Summary		Run the subscription scheduler
+//	@Description	Generate orders for deliveries coming up to their cutoff, holding the slot and stock, and charge customers' cards. Declined payments are retried at set intervals until the cutoff and customers are told each time; a subscription is paused after repeated failures. The server runs it every few minutes on its own; running it by hand as well does no harm.
+//	@Tags			Admin Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.SubscriptionRunSuccessResponse	"Scheduler run"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/subscriptions/renewals/run [post]
+func (h *AdminSubscriptionHandler) RunRenewals(w http.ResponseWriter, r *http.Request) {
+	// Errors with particular subscriptions are counted in the result; only
+	// a run that could not get going has none.
+	run, err := h.runHandler.Handle(r.Context(), time.Now())
+	if run == nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.SubscriptionRunResponse{
+		Generated: run.Generated,
+		Missed:    run.Missed,
+		Ordered:   run.Ordered,
+		Declined:  run.Declined,
+		Failed:    run.Failed,
+		Paused:    run.Paused,
+		Notified:  run.Notified,
+		Errored:   run.Errored,
+	})
+}
+
+// boxItems parses a box's items from a request body.
+func boxItems(in []dto.SubscriptionItemRequest) ([]subscription.Item, bool) {
+	items := make([]subscription.Item, 0, len(in))
+	for _, it := range in {
+		c, ok := boxCut(it.SubscriptionCutRequest)
+		if !ok {
+			return nil, false
+		}
+		item := subscription.Item{Cut: c}
+		for _, sub := range it.Substitutes {
+			c, ok := boxCut(sub)
+			if !ok {
+				return nil, false
+			}
+			item.Substitutes = append(item.Substitutes, c)
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+func boxCut(in dto.SubscriptionCutRequest) (subscription.Cut, bool) {
+	productID, err := uuid.Parse(in.ProductID)
+	if err != nil {
+		return subscription.Cut{}, false
+	}
+	return subscription.Cut{
+		ProductID:       productID,
+		Description:     in.Description,
+		Grams:           in.Grams,
+		PricePerKgCents: in.PricePerKgCents,
+	}, true
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	proccmd "github.com/katerji/butchery-app/backend/internal/application/procurement/commands"
+	procqry "github.com/katerji/butchery-app/backend/internal/application/procurement/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/procurement"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminSupplierHandler handles the supplier list and the prices paid to each supplier.
+type AdminSupplierHandler struct {
+	createHandler       *proccmd.CreateSupplierHandler
+	updateHandler       *proccmd.UpdateSupplierHandler
+	listHandler         *procqry.ListSuppliersHandler
+	priceHistoryHandler *procqry.PriceHistoryHandler
+}
+
+// NewAdminSupplierHandler creates a new AdminSupplierHandler.
+func NewAdminSupplierHandler(
+	createHandler *proccmd.CreateSupplierHandler,
+	updateHandler *proccmd.UpdateSupplierHandler,
+	listHandler *procqry.ListSuppliersHandler,
+	priceHistoryHandler *procqry.PriceHistoryHandler,
+) *AdminSupplierHandler {
+	return &AdminSupplierHandler{
+		createHandler:       createHandler,
+		updateHandler:       updateHandler,
+		listHandler:         listHandler,
+		priceHistoryHandler: priceHistoryHandler,
+	}
+}
+
+// CreateSupplier handles POST /api/v1/admin/suppliers.
+//
+//	@Summary		Create a supplier
+//	@Description	Add a farm, abattoir or wholesaler with its contacts, certificates and payment terms in days.
+//	@Tags			Admin Procurement
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.SupplierRequest			true	"Supplier"
+//	@Success		201		{object}	dto.SupplierSuccessResponse	"Supplier created"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/suppliers [post]
+func (h *AdminSupplierHandler) CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	var req dto.SupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	s, err := h.createHandler.Handle(r.Context(), supplierFields(req))
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toSupplierResponse(s))
+}
+
+// ListSuppliers handles GET /api/v1/admin/suppliers.
+//
+//	@Summary		List suppliers
+//	@Description	List suppliers by name.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			active	query		bool	false	"Only list active suppliers"
+//	@Success		200		{object}	dto.SuppliersSuccessResponse	"Suppliers"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid query parameters"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/suppliers [get]
+func (h *AdminSupplierHandler) ListSuppliers(w http.ResponseWriter, r *http.Request) {
+	var activeOnly bool
+	if raw := r.URL.Query().Get("active"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid active")
+			return
+		}
+		activeOnly = b
+	}
+
+	suppliers, err := h.listHandler.Handle(r.Context(), activeOnly)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.SupplierResponse, 0, len(suppliers))
+	for _, s := range suppliers {
+		resp = append(resp, toSupplierResponse(s))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// UpdateSupplier handles PUT /api/v1/admin/suppliers/{supplierID}.
+//
+//	@Summary		Update a supplier
+//	@Description	Replace a supplier's details. Set active to false to stop new purchase orders being raised against it.
+//	@Tags			Admin Procurement
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			supplierID	path		string						true	"Supplier ID"
+//	@Param			body		body		dto.SupplierRequest			true	"Supplier"
+//	@Success		200			{object}	dto.SupplierSuccessResponse	"Supplier updated"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401			{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody				"Supplier not found"
+//	@Failure		422			{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/suppliers/{supplierID} [put]
+func (h *AdminSupplierHandler) UpdateSupplier(w http.ResponseWriter, r *http.Request) {
+	supplierID, ok := uuidParam(r, "supplierID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid supplier id")
+		return
+	}
+
+	var req dto.SupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	active := req.Active == nil || *req.Active
+	s, err := h.updateHandler.Handle(r.Context(), proccmd.UpdateSupplierCommand{
+		SupplierID: supplierID,
+		Fields:     supplierFields(req),
+		Active:     active,
+	})
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSupplierResponse(s))
+}
+
+// PriceHistory handles GET /api/v1/admin/suppliers/{supplierID}/prices.
+//
+//	@Summary		Get a supplier's price history
+//	@Description	List the prices per kg agreed with a supplier on sent purchase orders, newest first.
+//	@Tags			Admin Procurement
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			supplierID	path		string	true	"Supplier ID"
+//	@Param			product_id	query		string	false	"Product ID"
+//	@Success		200			{object}	dto.PricePointsSuccessResponse	"Price history"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody					"Supplier not found"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/suppliers/{supplierID}/prices [get]
+func (h *AdminSupplierHandler) PriceHistory(w http.ResponseWriter, r *http.Request) {
+	supplierID, ok := uuidParam(r, "supplierID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid supplier id")
+		return
+	}
+	productID, ok := optionalUUID(r.URL.Query().Get("product_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	prices, err := h.priceHistoryHandler.Handle(r.Context(), procqry.PriceHistoryQuery{SupplierID: supplierID, ProductID: productID})
+	if err != nil {
+		writeProcurementError(w, err)
+		return
+	}
+
+	resp := make([]dto.PricePointResponse, 0, len(prices))
+	for _, p := range prices {
+		resp = append(resp, dto.PricePointResponse{
+			ProductID:       p.ProductID.String(),
+			PricePerKgCents: p.PricePerKgCents,
+			PurchaseOrderID: p.PurchaseOrderID.String(),
+			SentAt:          p.SentAt,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+func supplierFields(req dto.SupplierRequest) proccmd.SupplierFields {
+	contacts := make([]procurement.Contact, 0, len(req.Contacts))
+	for _, c := range req.Contacts {
+		contacts = append(contacts, procurement.Contact{Name: c.Name, Role: c.Role, Email: c.Email, Phone: c.Phone})
+	}
+	certificates := make([]proccmd.CertificateInput, 0, len(req.Certificates))
+	for _, c := range req.Certificates {
+		certificates = append(certificates, proccmd.CertificateInput{Type: c.Type, Number: c.Number, ExpiresOn: c.ExpiresOn})
+	}
+	return proccmd.SupplierFields{
+		Name:             req.Name,
+		Type:             req.Type,
+		Contacts:         contacts,
+		Certificates:     certificates,
+		PaymentTermsDays: req.PaymentTermsDays,
+	}
+}
+
+func toSupplierResponse(s *procurement.Supplier) dto.SupplierResponse {
+	resp := dto.SupplierResponse{
+		ID:               s.ID().String(),
+		Name:             s.Name(),
+		Type:             string(s.Type()),
+		Contacts:         make([]dto.SupplierContact, 0, len(s.Contacts())),
+		Certificates:     make([]dto.SupplierCertificate, 0, len(s.Certificates())),
+		PaymentTermsDays: s.PaymentTermsDays(),
+		Active:           s.IsActive(),
+		CreatedAt:        s.CreatedAt(),
+		UpdatedAt:        s.UpdatedAt(),
+	}
+	for _, c := range s.Contacts() {
+		resp.Contacts = append(resp.Contacts, dto.SupplierContact{Name: c.Name, Role: c.Role, Email: c.Email, Phone: c.Phone})
+	}
+	for _, c := range s.Certificates() {
+		cert := dto.SupplierCertificate{Type: c.Type, Number: c.Number}
+		if c.ExpiresOn != nil {
+			cert.ExpiresOn = c.ExpiresOn.Format(time.DateOnly)
+		}
+		resp.Certificates = append(resp.Certificates, cert)
+	}
+	return resp
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	taxcmd "github.com/katerji/butchery-app/backend/internal/application/tax/commands"
+	taxqry "github.com/katerji/butchery-app/backend/internal/application/tax/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminTaxHandler handles back-office management of VAT rates and product
+// tax categories.
+type AdminTaxHandler struct {
+	listHandler   *taxqry.ListRatesHandler
+	createHandler *taxcmd.CreateRateHandler
+	assignHandler *taxcmd.AssignProductCategoryHandler
+}
+
+// NewAdminTaxHandler creates a new AdminTaxHandler.
+func NewAdminTaxHandler(
+	listHandler *taxqry.ListRatesHandler,
+	createHandler *taxcmd.CreateRateHandler,
+	assignHandler *taxcmd.AssignProductCategoryHandler,
+) *AdminTaxHandler {
+	return &AdminTaxHandler{
+		listHandler:   listHandler,
+		createHandler: createHandler,
+		assignHandler: assignHandler,
+	}
+}
+
+// ListRates handles GET /api/v1/admin/tax/rates.
+//
+//	@Summary		List VAT rates
+//	@Description	List every VAT rate, past and scheduled, ordered by category and effective date.
+//	@Tags			Admin Tax
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.TaxRatesSuccessResponse	"VAT rates"
+//	@Failure		401	{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403	{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		500	{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/tax/rates [get]
+func (h *AdminTaxHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	table, err := h.listHandler.Handle(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.TaxRateResponse, 0, len(table))
+	for _, rate := range table {
+		resp = append(resp, toTaxRateResponse(rate))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// CreateRate handles POST /api/v1/admin/tax/rates.
+//
+//	@Summary		Add VAT rate
+//	@Description	Add a VAT rate for a tax category from a date. Schedule a rate change by adding the new rate with a future date; sales are taxed at the rate in force on the day, in the shop's time zone. Needs the manage_pricing permission.
+//	@Tags			Admin Tax
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.CreateTaxRateRequest	true	"VAT rate"
+//	@Success		201		{object}	dto.TaxRateSuccessResponse	"Rate added"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody				"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody				"A rate already takes effect on that date"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/admin/tax/rates [post]
+func (h *AdminTaxHandler) CreateRate(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateTaxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rate, err := h.createHandler.Handle(r.Context(), taxcmd.CreateRateCommand{
+		Category:      req.Category,
+		RateBP:        req.RateBP,
+		EffectiveFrom: req.EffectiveFrom,
+		ActorID:       middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toTaxRateResponse(rate))
+}
+
+// SetProductCategory handles PUT /api/v1/admin/tax/products/{productID}.
+//
+//	@Summary		Set product tax category
+//	@Description	Set the tax category a product is sold under. The category must have a rate. Orders already placed keep the VAT they were charged. Needs the manage_pricing permission.
+//	@Tags			Admin Tax
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			productID	path		string								true	"Product ID"
+//	@Param			body		body		dto.ProductTaxCategoryRequest		true	"Tax category"
+//	@Success		200			{object}	dto.ProductTaxCategorySuccessResponse	"Category set"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody						"Unknown category"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/tax/products/{productID} [put]
+func (h *AdminTaxHandler) SetProductCategory(w http.ResponseWriter, r *http.Request) {
+	productID, ok := uuidParam(r, "productID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	var req dto.ProductTaxCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	category, err := h.assignHandler.Handle(r.Context(), taxcmd.AssignProductCategoryCommand{
+		ProductID: productID,
+		Category:  req.Category,
+		ActorID:   middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.ProductTaxCategoryResponse{ProductID: productID.String(), Category: string(category)})
+}
+
+func toTaxRateResponse(r *tax.Rate) dto.TaxRateResponse {
+	return dto.TaxRateResponse{
+		ID:            r.ID().String(),
+		Category:      string(r.Category()),
+		RateBP:        r.RateBP(),
+		EffectiveFrom: r.EffectiveFrom().Format(time.DateOnly),
+		CreatedAt:     r.CreatedAt(),
+	}
+}
+
+func writeTaxError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, tax.ErrManageNotAllowed):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, tax.ErrDuplicateRate):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, tax.ErrInvalidCategory),
+		errors.Is(err, tax.ErrInvalidRate),
+		errors.Is(err, tax.ErrInvalidDate),
+		errors.Is(err, tax.ErrMissingEffectiveDate),
+		errors.Is(err, tax.ErrNoRate):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	tracecmd "github.com/katerji/butchery-app/backend/internal/application/traceability/commands"
+	traceqry "github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminTraceabilityHandler handles halal certificates, lot origins and the
+// lots that went into an order.
+type AdminTraceabilityHandler struct {
+	registerHandler     *tracecmd.RegisterCertificateHandler
+	recordOriginHandler *tracecmd.RecordOriginHandler
+	certificatesHandler *traceqry.ListCertificatesHandler
+	consumedLotsHandler *traceqry.ConsumedLotsHandler
+}
+
+// NewAdminTraceabilityHandler creates a new AdminTraceabilityHandler.
+func NewAdminTraceabilityHandler(
+	registerHandler *tracecmd.RegisterCertificateHandler,
+	recordOriginHandler *tracecmd.RecordOriginHandler,
+	certificatesHandler *traceqry.ListCertificatesHandler,
+	consumedLotsHandler *traceqry.ConsumedLotsHandler,
+) *AdminTraceabilityHandler {
+	return &AdminTraceabilityHandler{
+		registerHandler:     registerHandler,
+		recordOriginHandler: recordOriginHandler,
+		certificatesHandler: certificatesHandler,
+		consumedLotsHandler: consumedLotsHandler,
+	}
+}
+
+// RegisterCertificate handles POST /api/v1/admin/halal-certificates.
+//
+//	@Summary		Register a halal certificate
+//	@Description	Register a halal slaughter certificate issued to a slaughterhouse, with its validity dates and a link to the scanned document. Lots are matched to certificates by number.
+//	@Tags			Admin Traceability
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.RegisterCertificateRequest	true	"Certificate"
+//	@Success		201		{object}	dto.CertificateSuccessResponse	"Certificate registered"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		409		{object}	dto.ErrorBody					"Certificate number already registered"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/halal-certificates [post]
+func (h *AdminTraceabilityHandler) RegisterCertificate(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterCertificateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	c, err := h.registerHandler.Handle(r.Context(), tracecmd.RegisterCertificateCommand{
+		Issuer:         req.Issuer,
+		Number:         req.Number,
+		Slaughterhouse: req.Slaughterhouse,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		DocumentURL:    req.DocumentURL,
+	})
+	if err != nil {
+		writeTraceabilityError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toCertificateResponse(c, ""))
+}
+
+// ListCertificates handles GET /api/v1/admin/halal-certificates.
+//
+//	@Summary		List halal certificates
+//	@Description	List halal certificates, soonest to expire first. Each is flagged as not_yet_valid, valid, expiring (lapses within the warning window) or expired. With expiring, only expiring and expired certificates are listed.
+//	@Tags			Admin Traceability
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			expiring	query		bool	false	"Only list certificates nearing or past expiry"
+//	@Success		200			{object}	dto.CertificatesSuccessResponse	"Certificates"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/halal-certificates [get]
+func (h *AdminTraceabilityHandler) ListCertificates(w http.ResponseWriter, r *http.Request) {
+	var q traceqry.ListCertificatesQuery
+	if raw := r.URL.Query().Get("expiring"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid expiring")
+			return
+		}
+		q.ExpiringOnly = b
+	}
+
+	listings, err := h.certificatesHandler.Handle(r.Context(), q)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.CertificateResponse, 0, len(listings))
+	for _, l := range listings {
+		resp = append(resp, toCertificateResponse(l.Certificate, l.Expiry))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// RecordOrigin handles POST /api/v1/admin/inventory/lots/{lotID}/origin.
+//
+//	@Summary		Record a lot's origin
+//	@Description	Record the farm, slaughter date and halal certificate number of a lot that was bought in already cut. Lots cut from a carcass take their origin from the carcass.
+//	@Tags			Admin Traceability
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			lotID	path		string						true	"Lot ID"
+//	@Param			body	body		dto.RecordOriginRequest		true	"Origin"
+//	@Success		201		{object}	dto.LotOriginSuccessResponse	"Origin recorded"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody					"Lot not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Origin already recorded"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/inventory/lots/{lotID}/origin [post]
+func (h *AdminTraceabilityHandler) RecordOrigin(w http.ResponseWriter, r *http.Request) {
+	lotID, ok := uuidParam(r, "lotID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid lot id")
+		return
+	}
+
+	var req dto.RecordOriginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	o, err := h.recordOriginHandler.Handle(r.Context(), tracecmd.RecordOriginCommand{
+		LotID:             lotID,
+		Farm:              req.Farm,
+		SlaughteredOn:     req.SlaughteredOn,
+		CertificateNumber: req.CertificateNumber,
+	})
+	if err != nil {
+		writeTraceabilityError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, dto.LotOriginResponse{
+		LotID:             o.LotID().String(),
+		CarcassID:         uuidString(o.CarcassID()),
+		Farm:              o.Farm(),
+		SlaughteredOn:     o.SlaughteredOn().Format(time.DateOnly),
+		CertificateNumber: o.CertificateNumber(),
+		RecordedAt:        o.RecordedAt(),
+	})
+}
+
+// ListOrderLots handles GET /api/v1/admin/orders/{orderID}/lots.
+//
+//	@Summary		List the lots in an order
+//	@Description	List the lots each line of an order was picked from and the weight taken from each, for recalls and audits. Lots are linked to the lines when the stock held for the order is sold; orders outside the admin's branches have none.
+//	@Tags			Admin Traceability
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string							true	"Order ID"
+//	@Success		200		{object}	dto.ConsumedLotsSuccessResponse	"Consumed lots"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/orders/{orderID}/lots [get]
+func (h *AdminTraceabilityHandler) ListOrderLots(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+
+	lots, err := h.consumedLotsHandler.Handle(r.Context(), orderID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.ConsumedLotResponse, 0, len(lots))
+	for _, l := range lots {
+		resp = append(resp, dto.ConsumedLotResponse{
+			LineID:    l.LineID.String(),
+			ProductID: l.ProductID.String(),
+			LotID:     l.LotID.String(),
+			LotCode:   l.LotCode,
+			Grams:     l.Grams,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+func toCertificateResponse(c *traceability.Certificate, expiry traceability.Expiry) dto.CertificateResponse {
+	return dto.CertificateResponse{
+		ID:             c.ID().String(),
+		Issuer:         c.Issuer(),
+		Number:         c.Number(),
+		Slaughterhouse: c.Slaughterhouse(),
+		ValidFrom:      c.ValidFrom().Format(time.DateOnly),
+		ValidUntil:     c.ValidUntil().Format(time.DateOnly),
+		DocumentURL:    optionalString(c.DocumentURL()),
+		Expiry:         string(expiry),
+		CreatedAt:      c.CreatedAt(),
+	}
+}
@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	invcmd "github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	invqry "github.com/katerji/butchery-app/backend/internal/application/inventory/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// AdminWasteHandler handles waste, use-by dates, expiry proposals and
+// shrinkage reporting.
+type AdminWasteHandler struct {
+	wasteHandler     *invcmd.RecordWasteHandler
+	useByHandler     *invcmd.SetUseByHandler
+	scanHandler      *invcmd.ScanExpiryHandler
+	acceptHandler    *invcmd.AcceptExpiryProposalHandler
+	dismissHandler   *invcmd.DismissExpiryProposalHandler
+	proposalsHandler *invqry.ListExpiryProposalsHandler
+	shrinkageHandler *invqry.ShrinkageReportHandler
+}
+
+// NewAdminWasteHandler creates a new AdminWasteHandler.
+func NewAdminWasteHandler(
+	wasteHandler *invcmd.RecordWasteHandler,
+	useByHandler *invcmd.SetUseByHandler,
+	scanHandler *invcmd.ScanExpiryHandler,
+	acceptHandler *invcmd.AcceptExpiryProposalHandler,
+	dismissHandler *invcmd.DismissExpiryProposalHandler,
+	proposalsHandler *invqry.ListExpiryProposalsHandler,
+	shrinkageHandler *invqry.ShrinkageReportHandler,
+) *AdminWasteHandler {
+	return &AdminWasteHandler{
+		wasteHandler:     wasteHandler,
+		useByHandler:     useByHandler,
+		scanHandler:      scanHandler,
+		acceptHandler:    acceptHandler,
+		dismissHandler:   dismissHandler,
+		proposalsHandler: proposalsHandler,
+		shrinkageHandler: shrinkageHandler,
+	}
+}
+
+// RecordWaste handles POST /api/v1/admin/inventory/waste.
+//
+//	@Summary		Record waste
+//	@Description	Write stock off a lot. The reason is one of trim, expired, damaged or returned, and is kept on the ledger entry so waste can be reported by reason.
+//	@Tags			Admin Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.WasteRequest					true	"Waste"
+//	@Success		201		{object}	dto.StockMovementSuccessResponse	"Waste recorded"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody						"Lot not found"
+//	@Failure		409		{object}	dto.ErrorBody						"Insufficient stock"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/waste [post]
+func (h *AdminWasteHandler) RecordWaste(w http.ResponseWriter, r *http.Request) {
+	var req dto.WasteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	lotID, err := uuid.Parse(req.LotID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "lot_id is required")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	m, err := h.wasteHandler.Handle(r.Context(), invcmd.RecordWasteCommand{
+		LotID:   lotID,
+		Grams:   req.Grams,
+		Reason:  req.Reason,
+		ActorID: claims.SubjectID,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toStockMovementResponse(m))
+}
+
+// SetUseBy handles PUT /api/v1/admin/inventory/lots/{lotID}/use-by.
+//
+//	@Summary		Set a lot's use-by date
+//	@Description	Correct a lot's use-by date, or clear it for stock that does not go off. The next expiry scan works from the new date.
+//	@Tags			Admin Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			lotID	path		string					true	"Lot ID"
+//	@Param			body	body		dto.SetUseByRequest		true	"Use-by date"
+//	@Success		200		{object}	dto.LotSuccessResponse	"Lot updated"
+//	@Failure		400		{object}	dto.ErrorBody			"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody			"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody			"Forbidden"
+//	@Failure		404		{object}	dto.ErrorBody			"Lot not found"
+//	@Failure		422		{object}	dto.ErrorBody			"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody			"Internal server error"
+//	@Router			/admin/inventory/lots/{lotID}/use-by [put]
+func (h *AdminWasteHandler) SetUseBy(w http.ResponseWriter, r *http.Request) {
+	lotID, ok := uuidParam(r, "lotID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid lot id")
+		return
+	}
+
+	var req dto.SetUseByRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	lot, err := h.useByHandler.Handle(r.Context(), invcmd.SetUseByCommand{LotID: lotID, UseBy: req.UseBy})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLotResponse(lot))
+}
+
+// ScanExpiry handles POST /api/v1/admin/inventory/expiry/scan.
+//
+//	@Summary		Run the expiry scan
+//	@Description	Propose markdowns for lots nearing their use-by date and write-offs for lots past it, as of the given day, today by default, and alert staff to each. The server runs the scan for today on its own every hour or so; a lot is proposed each kind at most once.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			date	query		string							false	"Day to scan as of, YYYY-MM-DD in the shop's time zone (default today)"
+//	@Success		200		{object}	dto.ExpiryScanSuccessResponse	"Scan run"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/inventory/expiry/scan [post]
+func (h *AdminWasteHandler) ScanExpiry(w http.ResponseWriter, r *http.Request) {
+	result, err := h.scanHandler.Handle(r.Context(), invcmd.ScanExpiryCommand{Date: r.URL.Query().Get("date")})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.ExpiryScanResponse{
+		Scanned:   result.Scanned,
+		Markdowns: result.Markdowns,
+		Waste:     result.Waste,
+	})
+}
+
+// ListProposals handles GET /api/v1/admin/inventory/expiry/proposals.
+//
+//	@Summary		List expiry proposals
+//	@Description	List the markdowns and write-offs expiry scans have proposed, newest first.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string	false	"Status"	Enums(proposed, accepted, dismissed)
+//	@Param			branch_id	query		string	false	"Branch ID"
+//	@Success		200			{object}	dto.ExpiryProposalsSuccessResponse	"Expiry proposals"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/expiry/proposals [get]
+func (h *AdminWasteHandler) ListProposals(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var filter inventory.ProposalFilter
+	var ok bool
+	if filter.BranchID, ok = optionalUUID(q.Get("branch_id")); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	if raw := q.Get("status"); raw != "" {
+		status, err := inventory.NewProposalStatus(raw)
+		if err != nil {
+			writeInventoryError(w, err)
+			return
+		}
+		filter.Status = &status
+	}
+
+	proposals, err := h.proposalsHandler.Handle(r.Context(), filter)
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	resp := make([]dto.ExpiryProposalResponse, 0, len(proposals))
+	for _, p := range proposals {
+		resp = append(resp, toExpiryProposalResponse(p))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// AcceptProposal handles POST /api/v1/admin/inventory/expiry/proposals/{proposalID}/accept.
+//
+//	@Summary		Accept an expiry proposal
+//	@Description	Carry out a proposal: a markdown reduces the lot's price on its labels, a write-off wastes whatever is left of the lot as expired.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			proposalID	path		string								true	"Proposal ID"
+//	@Success		200			{object}	dto.ExpiryProposalSuccessResponse	"Proposal accepted"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid proposal ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Proposal not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Proposal already decided"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/expiry/proposals/{proposalID}/accept [post]
+func (h *AdminWasteHandler) AcceptProposal(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.acceptHandler.Handle)
+}
+
+// DismissProposal handles POST /api/v1/admin/inventory/expiry/proposals/{proposalID}/dismiss.
+//
+//	@Summary		Dismiss an expiry proposal
+//	@Description	Leave the lot as it is, for stock checked and judged fit to sell at full price. The scan does not propose the same again.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			proposalID	path		string								true	"Proposal ID"
+//	@Success		200			{object}	dto.ExpiryProposalSuccessResponse	"Proposal dismissed"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid proposal ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody						"Forbidden"
+//	@Failure		404			{object}	dto.ErrorBody						"Proposal not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Proposal already decided"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/admin/inventory/expiry/proposals/{proposalID}/dismiss [post]
+func (h *AdminWasteHandler) DismissProposal(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.dismissHandler.Handle)
+}
+
+func (h *AdminWasteHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	handle func(context.Context, invcmd.DecideExpiryProposalCommand) (*inventory.ExpiryProposal, error),
+) {
+	proposalID, ok := uuidParam(r, "proposalID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid proposal id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	p, err := handle(r.Context(), invcmd.DecideExpiryProposalCommand{ProposalID: proposalID, ActorID: claims.SubjectID})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toExpiryProposalResponse(p))
+}
+
+// Shrinkage handles GET /api/v1/admin/inventory/shrinkage.
+//
+//	@Summary		Report shrinkage
+//	@Description	Report stock lost to waste and stock-take differences between two days, in grams and at cost, by product, species or branch. Figures are summed from the stock ledger, so each line's net_grams reconciles with its movements.
+//	@Tags			Admin Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			from		query		string	true	"First day, YYYY-MM-DD"
+//	@Param			to			query		string	true	"Last day, YYYY-MM-DD"
+//	@Param			branch_id	query		string	false	"Branch ID"
+//	@Param			group_by	query		string	false	"Grouping (default product)"	Enums(product, species, branch)
+//	@Success		200			{object}	dto.ShrinkageSuccessResponse	"Shrinkage report"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid query parameters"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Forbidden"
+//	@Failure		422			{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/admin/inventory/shrinkage [get]
+func (h *AdminWasteHandler) Shrinkage(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err := time.Parse(time.DateOnly, q.Get("from"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "from must be a YYYY-MM-DD date")
+		return
+	}
+	to, err := time.Parse(time.DateOnly, q.Get("to"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "to must be a YYYY-MM-DD date")
+		return
+	}
+	branchID, ok := optionalUUID(q.Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	groupBy := inventory.ShrinkageByProduct
+	if raw := q.Get("group_by"); raw != "" {
+		groupBy = inventory.ShrinkageGroup(raw)
+	}
+
+	report, err := h.shrinkageHandler.Handle(r.Context(), invqry.ShrinkageQuery{
+		BranchID: branchID,
+		From:     from,
+		To:       to,
+		GroupBy:  groupBy,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	resp := dto.ShrinkageResponse{
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		GroupBy: string(report.GroupBy),
+		Lines:   make([]dto.ShrinkageLineResponse, 0, len(report.Lines)),
+		Total:   toShrinkageLineResponse(report.Total),
+	}
+	for _, l := range report.Lines {
+		resp.Lines = append(resp.Lines, toShrinkageLineResponse(l))
+	}
+	httpresponse.Success(w, resp)
+}
+
+func toExpiryProposalResponse(p *inventory.ExpiryProposal) dto.ExpiryProposalResponse {
+	return dto.ExpiryProposalResponse{
+		ID:          p.ID().String(),
+		LotID:       p.LotID().String(),
+		ProductID:   p.ProductID().String(),
+		BranchID:    p.BranchID().String(),
+		Kind:        string(p.Kind()),
+		UseBy:       p.UseBy().Format(time.DateOnly),
+		OnHandGrams: p.OnHandGrams(),
+		MarkdownBP:  p.MarkdownBP(),
+		Status:      string(p.Status()),
+		WastedGrams: p.WastedGrams(),
+		CreatedAt:   p.CreatedAt(),
+		DecidedAt:   p.DecidedAt(),
+		DecidedBy:   uuidString(p.DecidedBy()),
+	}
+}
+
+func toShrinkageLineResponse(l inventory.ShrinkageLine) dto.ShrinkageLineResponse {
+	wastedByReason := l.WastedByReason
+	if wastedByReason == nil {
+		wastedByReason = map[string]int64{}
+	}
+	return dto.ShrinkageLineResponse{
+		Key:            l.Key,
+		ReceivedGrams:  l.ReceivedGrams,
+		CutGrams:       l.CutGrams,
+		SoldGrams:      l.SoldGrams,
+		WastedGrams:    l.WastedGrams,
+		AdjustedGrams:  l.AdjustedGrams,
+		NetGrams:       l.NetGrams(),
+		WastedByReason: wastedByReason,
+		ShrinkGrams:    l.ShrinkGrams(),
+		ShrinkBP:       l.ShrinkBP(),
+		ReceivedCents:  l.ReceivedCents,
+		SoldCents:      l.SoldCents,
+		WastedCents:    l.WastedCents,
+		AdjustedCents:  l.AdjustedCents,
+		ShrinkCents:    l.ShrinkCents(),
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	branchqry "github.com/katerji/butchery-app/backend/internal/application/branch/queries"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// BranchHandler shows customers the shops.
+type BranchHandler struct {
+	listHandler *branchqry.ListBranchesHandler
+	getHandler  *branchqry.GetBranchHandler
+}
+
+// NewBranchHandler creates a new BranchHandler.
+func NewBranchHandler(listHandler *branchqry.ListBranchesHandler, getHandler *branchqry.GetBranchHandler) *BranchHandler {
+	return &BranchHandler{listHandler: listHandler, getHandler: getHandler}
+}
+
+// List handles GET /api/v1/branches.
+//
+//	@Summary		List branches
+//	@Description	List the open branches by name.
+//	@Tags			Branches
+//	@Produce		json
+//	@Success		200	{object}	dto.BranchListSuccessResponse	"Branches"
+//	@Failure		500	{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/branches [get]
+func (h *BranchHandler) List(w http.ResponseWriter, r *http.Request) {
+	branches, err := h.listHandler.Handle(r.Context(), false)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]dto.BranchResponse, 0, len(branches))
+	for _, b := range branches {
+		resp = append(resp, toBranchResponse(b, nil))
+	}
+	httpresponse.Success(w, resp)
+}
+
+// Get handles GET /api/v1/branches/{branchID}.
+//
+//	@Summary		Get a branch
+//	@Description	Get an open branch with its address, contact details and opening hours.
+//	@Tags			Branches
+//	@Produce		json
+//	@Param			branchID	path		string						true	"Branch ID"
+//	@Success		200			{object}	dto.BranchSuccessResponse	"Branch"
+//	@Failure		400			{object}	dto.ErrorBody				"Invalid branch ID"
+//	@Failure		404			{object}	dto.ErrorBody				"Branch not found"
+//	@Failure		500			{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/branches/{branchID} [get]
+func (h *BranchHandler) Get(w http.ResponseWriter, r *http.Request) {
+	branchID, ok := uuidParam(r, "branchID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch ID")
+		return
+	}
+
+	details, err := h.getHandler.Handle(r.Context(), branchID)
+	if err != nil {
+		writeBranchError(w, err)
+		return
+	}
+	if !details.Branch.Active() {
+		httpresponse.Error(w, http.StatusNotFound, "branch not found")
+		return
+	}
+
+	httpresponse.Success(w, toBranchResponse(details.Branch, details.Hours))
+}
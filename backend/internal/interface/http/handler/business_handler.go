@@ -0,0 +1,490 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	businesscmd "github.com/katerji/butchery-app/backend/internal/application/business/commands"
+	businessqry "github.com/katerji/butchery-app/backend/internal/application/business/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/business"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// statementMonth is the layout of a statement's month.
+const statementMonth = "2006-01"
+
+// BusinessHandler lets members of a business account order on credit,
+// approve each other's orders and see the account's statement.
+type BusinessHandler struct {
+	membershipHandler *businessqry.GetMembershipHandler
+	placeHandler      *businesscmd.PlaceOrderHandler
+	decideHandler     *businesscmd.DecideOrderHandler
+	ordersHandler     *businessqry.ListOrdersHandler
+	statementHandler  *businessqry.StatementHandler
+}
+
+// NewBusinessHandler creates a new BusinessHandler.
+func NewBusinessHandler(
+	membershipHandler *businessqry.GetMembershipHandler,
+	placeHandler *businesscmd.PlaceOrderHandler,
+	decideHandler *businesscmd.DecideOrderHandler,
+	ordersHandler *businessqry.ListOrdersHandler,
+	statementHandler *businessqry.StatementHandler,
+) *BusinessHandler {
+	return &BusinessHandler{
+		membershipHandler: membershipHandler,
+		placeHandler:      placeHandler,
+		decideHandler:     decideHandler,
+		ordersHandler:     ordersHandler,
+		statementHandler:  statementHandler,
+	}
+}
+
+// GetMembership handles GET /api/v1/me/business.
+//
+//	@Summary		Get my business account
+//	@Description	Get the business account the authenticated customer buys for, their role on it, its price list and how much credit is left.
+//	@Tags			Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.BusinessMembershipSuccessResponse	"Membership"
+//	@Failure		401	{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		404	{object}	dto.ErrorBody							"Not a member of a business account"
+//	@Failure		500	{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/me/business [get]
+func (h *BusinessHandler) GetMembership(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	m, err := h.membershipHandler.Handle(r.Context(), claims.SubjectID)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.BusinessMembershipResponse{
+		Role:    string(m.Member.Role),
+		Account: toBusinessAccountResponse(m.Account),
+	})
+}
+
+// PlaceOrder handles POST /api/v1/me/business/orders.
+//
+//	@Summary		Order on account
+//	@Description	Order products on the business's price list, charged to its account and due on its payment terms. The price per kilogram drops as a line reaches each volume tier. Orders over the account's approval threshold wait for an approver, with credit held for them, unless placed by an approver.
+//	@Tags			Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.BusinessOrderRequest			true	"Order"
+//	@Success		201		{object}	dto.BusinessOrderSuccessResponse	"Order placed or waiting for approval"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody						"Not a member of a business account"
+//	@Failure		409		{object}	dto.ErrorBody						"Account on hold, over its credit limit or changed concurrently"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/business/orders [post]
+func (h *BusinessHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.BusinessOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	lines := make([]businesscmd.LineInput, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		productID, err := uuid.Parse(l.ProductID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+			return
+		}
+		lines = append(lines, businesscmd.LineInput{ProductID: productID, Grams: l.Grams})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	o, err := h.placeHandler.Handle(r.Context(), businesscmd.PlaceOrderCommand{
+		CustomerID: claims.SubjectID,
+		BranchID:   branchID,
+		Lines:      lines,
+	})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toBusinessOrderResponse(o))
+}
+
+// ListOrders handles GET /api/v1/me/business/orders.
+//
+//	@Summary		List my business's orders
+//	@Description	List the orders placed on the business's account, newest first, e.g. those waiting for approval.
+//	@Tags			Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status	query		string								false	"pending_approval, placed or rejected"
+//	@Success		200		{object}	dto.BusinessOrderListSuccessResponse	"Orders"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid status"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody						"Not a member of a business account"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/business/orders [get]
+func (h *BusinessHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	filter, ok := businessOrderFilter(w, r)
+	if !ok {
+		return
+	}
+	claims := middleware.ClaimsFromContext(r.Context())
+	m, err := h.membershipHandler.Handle(r.Context(), claims.SubjectID)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+	accountID := m.Account.ID()
+	filter.AccountID = &accountID
+
+	orders, err := h.ordersHandler.Handle(r.Context(), filter)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toBusinessOrderResponses(orders))
+}
+
+// ApproveOrder handles POST /api/v1/me/business/orders/{orderID}/approve.
+//
+//	@Summary		Approve an order
+//	@Description	Place an order waiting for approval. It is charged to the account at today's VAT rates in place of the credit held for it.
+//	@Tags			Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string								true	"Business order ID"
+//	@Success		200		{object}	dto.BusinessOrderSuccessResponse	"Order approved"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Not an approver"
+//	@Failure		404		{object}	dto.ErrorBody						"Order not found"
+//	@Failure		409		{object}	dto.ErrorBody						"Not waiting for approval, account on hold, over its credit limit or changed concurrently"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/business/orders/{orderID}/approve [post]
+func (h *BusinessHandler) ApproveOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	o, err := h.decideHandler.Handle(r.Context(), businesscmd.DecideOrderCommand{
+		OrderID:    orderID,
+		CustomerID: claims.SubjectID,
+		Approve:    true,
+	})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessOrderResponse(o))
+}
+
+// RejectOrder handles POST /api/v1/me/business/orders/{orderID}/reject.
+//
+//	@Summary		Reject an order
+//	@Description	Turn down an order waiting for approval and release the credit held for it.
+//	@Tags			Business
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string								true	"Business order ID"
+//	@Param			body	body		dto.RejectBusinessOrderRequest		true	"Reason"
+//	@Success		200		{object}	dto.BusinessOrderSuccessResponse	"Order rejected"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody						"Not an approver"
+//	@Failure		404		{object}	dto.ErrorBody						"Order not found"
+//	@Failure		409		{object}	dto.ErrorBody						"Not waiting for approval or changed concurrently"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/business/orders/{orderID}/reject [post]
+func (h *BusinessHandler) RejectOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	var req dto.RejectBusinessOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	o, err := h.decideHandler.Handle(r.Context(), businesscmd.DecideOrderCommand{
+		OrderID:    orderID,
+		CustomerID: claims.SubjectID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessOrderResponse(o))
+}
+
+// Statement handles GET /api/v1/me/business/statement.
+//
+//	@Summary		Get my business's statement
+//	@Description	Get the account's statement for a month: the balance brought forward, each charge and payment, what is owed at the end of the month and how much of it is overdue.
+//	@Tags			Business
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			month	query		string									false	"Month (YYYY-MM, default this month)"
+//	@Success		200		{object}	dto.BusinessStatementSuccessResponse	"Statement"
+//	@Failure		400		{object}	dto.ErrorBody							"Invalid month"
+//	@Failure		401		{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody							"Not a member of a business account"
+//	@Failure		500		{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/me/business/statement [get]
+func (h *BusinessHandler) Statement(w http.ResponseWriter, r *http.Request) {
+	month, ok := statementMonthParam(w, r)
+	if !ok {
+		return
+	}
+	claims := middleware.ClaimsFromContext(r.Context())
+	m, err := h.membershipHandler.Handle(r.Context(), claims.SubjectID)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	s, err := h.statementHandler.Handle(r.Context(), m.Account.ID(), month)
+	if err != nil {
+		writeBusinessError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toBusinessStatementResponse(s))
+}
+
+// businessOrderFilter reads the status filter of a list of business orders,
+// writing a 400 if it is invalid.
+func businessOrderFilter(w http.ResponseWriter, r *http.Request) (business.OrderFilter, bool) {
+	var filter business.OrderFilter
+	if raw := r.URL.Query().Get("status"); raw != "" {
+		status, err := business.NewOrderStatus(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, err.Error())
+			return filter, false
+		}
+		filter.Status = &status
+	}
+	return filter, true
+}
+
+// statementMonthParam reads the month a statement is for, defaulting to
+// this month, writing a 400 if it is invalid.
+func statementMonthParam(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	raw := r.URL.Query().Get("month")
+	if raw == "" {
+		return time.Now(), true
+	}
+	month, err := time.Parse(statementMonth, raw)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "month must be YYYY-MM")
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+func toBusinessAccountResponses(accounts []*business.Account) []dto.BusinessAccountResponse {
+	resp := make([]dto.BusinessAccountResponse, 0, len(accounts))
+	for _, a := range accounts {
+		resp = append(resp, toBusinessAccountResponse(a))
+	}
+	return resp
+}
+
+func toBusinessAccountResponse(a *business.Account) dto.BusinessAccountResponse {
+	details, terms := a.Details(), a.Terms()
+	prices := make([]dto.BusinessPriceResponse, 0, len(a.Prices()))
+	for _, p := range a.Prices() {
+		tiers := make([]dto.BusinessTierResponse, 0, len(p.Tiers))
+		for _, t := range p.Tiers {
+			tiers = append(tiers, dto.BusinessTierResponse{MinGrams: t.MinGrams, PricePerKgCents: t.PricePerKgCents})
+		}
+		prices = append(prices, dto.BusinessPriceResponse{
+			ProductID:   p.ProductID.String(),
+			Description: p.Description,
+			Tiers:       tiers,
+		})
+	}
+	return dto.BusinessAccountResponse{
+		ID:                     a.ID().String(),
+		Name:                   details.Name,
+		CompanyNumber:          details.CompanyNumber,
+		VATNumber:              details.VATNumber,
+		BillingEmail:           details.BillingEmail,
+		BillingAddress:         details.BillingAddress,
+		CreditLimitCents:       terms.CreditLimitCents,
+		TermsDays:              terms.TermsDays,
+		ApprovalThresholdCents: terms.ApprovalThresholdCents,
+		Prices:                 prices,
+		BalanceCents:           a.BalanceCents(),
+		HeldCents:              a.HeldCents(),
+		AvailableCreditCents:   a.AvailableCreditCents(),
+		Active:                 a.Active(),
+		CreatedAt:              a.CreatedAt(),
+		UpdatedAt:              a.UpdatedAt(),
+	}
+}
+
+func toBusinessMemberResponses(members []business.Member) []dto.BusinessMemberResponse {
+	resp := make([]dto.BusinessMemberResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, toBusinessMemberResponse(m))
+	}
+	return resp
+}
+
+func toBusinessMemberResponse(m business.Member) dto.BusinessMemberResponse {
+	return dto.BusinessMemberResponse{
+		AccountID:  m.AccountID.String(),
+		CustomerID: m.CustomerID.String(),
+		Role:       string(m.Role),
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func toBusinessOrderResponses(orders []*business.Order) []dto.BusinessOrderResponse {
+	resp := make([]dto.BusinessOrderResponse, 0, len(orders))
+	for _, o := range orders {
+		resp = append(resp, toBusinessOrderResponse(o))
+	}
+	return resp
+}
+
+func toBusinessOrderResponse(o *business.Order) dto.BusinessOrderResponse {
+	lines := make([]dto.BusinessOrderLineResponse, 0, len(o.Lines()))
+	for _, l := range o.Lines() {
+		lines = append(lines, dto.BusinessOrderLineResponse{
+			ProductID:       l.ProductID.String(),
+			Description:     l.Description,
+			Grams:           l.Grams,
+			PricePerKgCents: l.PricePerKgCents,
+		})
+	}
+	resp := dto.BusinessOrderResponse{
+		ID:           o.ID().String(),
+		AccountID:    o.AccountID().String(),
+		PlacedBy:     o.PlacedBy().String(),
+		BranchID:     o.BranchID().String(),
+		OrderID:      o.OrderID().String(),
+		Lines:        lines,
+		TotalCents:   o.TotalCents(),
+		Status:       string(o.Status()),
+		DecidedAt:    o.DecidedAt(),
+		RejectReason: o.RejectReason(),
+		CreatedAt:    o.CreatedAt(),
+		UpdatedAt:    o.UpdatedAt(),
+	}
+	if o.DecidedBy() != nil {
+		s := o.DecidedBy().String()
+		resp.DecidedBy = &s
+	}
+	return resp
+}
+
+func toBusinessEntryResponse(e *business.Entry) dto.BusinessEntryResponse {
+	resp := dto.BusinessEntryResponse{
+		ID:          e.ID.String(),
+		Type:        string(e.Type),
+		AmountCents: e.AmountCents,
+		Reference:   e.Reference,
+		PostedAt:    e.PostedAt,
+	}
+	if e.OrderID != nil {
+		s := e.OrderID.String()
+		resp.OrderID = &s
+	}
+	if e.DueOn != nil {
+		s := e.DueOn.Format(time.DateOnly)
+		resp.DueOn = &s
+	}
+	return resp
+}
+
+func toBusinessStatementResponse(s business.Statement) dto.BusinessStatementResponse {
+	details := s.Account.Details()
+	lines := make([]dto.BusinessStatementLineResponse, 0, len(s.Lines))
+	for _, l := range s.Lines {
+		lines = append(lines, dto.BusinessStatementLineResponse{
+			BusinessEntryResponse: toBusinessEntryResponse(l.Entry),
+			BalanceCents:          l.BalanceCents,
+		})
+	}
+	return dto.BusinessStatementResponse{
+		AccountID:      s.Account.ID().String(),
+		Name:           details.Name,
+		BillingAddress: details.BillingAddress,
+		VATNumber:      details.VATNumber,
+		Month:          s.From.Format(statementMonth),
+		OpeningCents:   s.OpeningCents,
+		Lines:          lines,
+		ChargedCents:   s.ChargedCents,
+		PaidCents:      s.PaidCents,
+		ClosingCents:   s.ClosingCents,
+		OverdueCents:   s.OverdueCents,
+	}
+}
+
+func writeBusinessError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, business.ErrAccountNotFound),
+		errors.Is(err, business.ErrMemberNotFound),
+		errors.Is(err, business.ErrOrderNotFound),
+		errors.Is(err, customer.ErrCustomerNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, business.ErrNotApprover):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, business.ErrAccountInactive),
+		errors.Is(err, business.ErrCreditLimitExceeded),
+		errors.Is(err, business.ErrOrderNotPending),
+		errors.Is(err, business.ErrMemberOfAnotherAccount),
+		errors.Is(err, business.ErrConcurrentUpdate):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, business.ErrInvalidRole),
+		errors.Is(err, business.ErrEmptyName),
+		errors.Is(err, business.ErrEmptyBillingEmail),
+		errors.Is(err, business.ErrNegativeCreditLimit),
+		errors.Is(err, business.ErrInvalidTerms),
+		errors.Is(err, business.ErrNegativeThreshold),
+		errors.Is(err, business.ErrInvalidTiers),
+		errors.Is(err, business.ErrNegativePrice),
+		errors.Is(err, business.ErrDuplicatePrice),
+		errors.Is(err, business.ErrNotOnPriceList),
+		errors.Is(err, business.ErrBranchRequired),
+		errors.Is(err, business.ErrNoLines),
+		errors.Is(err, business.ErrInvalidWeight),
+		errors.Is(err, business.ErrInvalidAmount),
+		errors.Is(err, order.ErrUnbalancedLine),
+		errors.Is(err, order.ErrInvalidVATRate),
+		errors.Is(err, tax.ErrUnclassifiedProduct),
+		errors.Is(err, tax.ErrNoRate):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	taxqry "github.com/katerji/butchery-app/backend/internal/application/tax/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/tax"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// CartHandler prices carts before checkout.
+type CartHandler struct {
+	quoteHandler *taxqry.QuoteCartHandler
+}
+
+// NewCartHandler creates a new CartHandler.
+func NewCartHandler(quoteHandler *taxqry.QuoteCartHandler) *CartHandler {
+	return &CartHandler{quoteHandler: quoteHandler}
+}
+
+// Quote handles POST /api/v1/cart/quote.
+//
+//	@Summary		Price a cart
+//	@Description	Price a cart at today's VAT rates and promotions, with each line split into net and VAT and a breakdown by rate. Orders are priced the same way, so the totals match what is charged. Give delivery_zone_id to include the zone's delivery fee and coupons for any codes entered; codes that do not apply are listed with the reason. Offers for particular customers, such as first-order discounts, are applied at checkout.
+//	@Tags			Cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.CartQuoteRequest			true	"Cart"
+//	@Success		200		{object}	dto.CartQuoteSuccessResponse	"Priced cart"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		404		{object}	dto.ErrorBody					"Delivery zone not found"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/cart/quote [post]
+func (h *CartHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	var req dto.CartQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	lines := make([]taxqry.CartLine, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		productID, err := uuid.Parse(l.ProductID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+			return
+		}
+		lines = append(lines, taxqry.CartLine{ProductID: productID, Grams: l.Grams, PricePerKgCents: l.PricePerKgCents})
+	}
+	var zoneID *uuid.UUID
+	if req.DeliveryZoneID != nil {
+		id, ok := optionalUUID(*req.DeliveryZoneID)
+		if !ok {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid delivery_zone_id")
+			return
+		}
+		zoneID = id
+	}
+
+	quote, err := h.quoteHandler.Handle(r.Context(), taxqry.QuoteCartQuery{
+		Lines:          lines,
+		DeliveryZoneID: zoneID,
+		Coupons:        req.Coupons,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrZoneNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "delivery zone not found")
+		case errors.Is(err, order.ErrNoLines),
+			errors.Is(err, order.ErrInvalidWeight),
+			errors.Is(err, order.ErrNegativePrice),
+			errors.Is(err, tax.ErrUnclassifiedProduct),
+			errors.Is(err, tax.ErrNoRate),
+			errors.Is(err, tax.ErrNegativeAmount):
+			httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := dto.CartQuoteResponse{
+		Lines:           make([]dto.TaxedLineResponse, 0, len(quote.Lines)),
+		VATBands:        make([]dto.VATBandResponse, 0, len(quote.Bands)),
+		Discounts:       make([]dto.DiscountResponse, 0, len(quote.Discounts)),
+		RejectedCoupons: make([]dto.RejectedCouponResponse, 0, len(quote.Rejected)),
+		DiscountCents:   quote.DiscountCents,
+		NetCents:        quote.NetCents,
+		VATCents:        quote.VATCents,
+		GrossCents:      quote.GrossCents,
+	}
+	for i, l := range quote.Lines {
+		line := toTaxedLineResponse(l)
+		line.ProductID = lines[i].ProductID.String()
+		line.DiscountCents = quote.LineDiscountCents[i]
+		resp.Lines = append(resp.Lines, line)
+	}
+	if quote.Delivery != nil {
+		d := toTaxedLineResponse(*quote.Delivery)
+		d.DiscountCents = quote.DeliveryDiscountCents
+		resp.Delivery = &d
+	}
+	for _, d := range quote.Discounts {
+		resp.Discounts = append(resp.Discounts, dto.DiscountResponse{
+			PromotionID:   d.PromotionID.String(),
+			Name:          d.Name,
+			Code:          d.Code,
+			DeliveryCents: d.DeliveryCents,
+			AmountCents:   d.TotalCents,
+		})
+	}
+	for _, rj := range quote.Rejected {
+		resp.RejectedCoupons = append(resp.RejectedCoupons, dto.RejectedCouponResponse{Code: rj.Code, Reason: rj.Err.Error()})
+	}
+	for _, b := range quote.Bands {
+		resp.VATBands = append(resp.VATBands, dto.VATBandResponse{
+			RateBP: b.RateBP, NetCents: b.NetCents, VATCents: b.VATCents, GrossCents: b.GrossCents,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+func toTaxedLineResponse(l tax.Line) dto.TaxedLineResponse {
+	return dto.TaxedLineResponse{
+		TaxCategory: string(l.Category),
+		VATRateBP:   l.RateBP,
+		NetCents:    l.NetCents,
+		VATCents:    l.VATCents,
+		GrossCents:  l.GrossCents,
+	}
+}
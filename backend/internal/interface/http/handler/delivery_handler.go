@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	delivqry "github.com/katerji/butchery-app/backend/internal/application/delivery/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/delivery"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// DeliveryHandler answers whether the signed-in customer can get a delivery.
+type DeliveryHandler struct {
+	validateHandler *delivqry.ValidateDeliveryAddressHandler
+}
+
+// NewDeliveryHandler creates a new DeliveryHandler.
+func NewDeliveryHandler(validateHandler *delivqry.ValidateDeliveryAddressHandler) *DeliveryHandler {
+	return &DeliveryHandler{validateHandler: validateHandler}
+}
+
+// Quote handles GET /api/v1/delivery/quote.
+//
+//	@Summary		Check delivery to an address
+//	@Description	Find the active delivery zone covering a saved address and its fee. When subtotal_cents is given, the zone's minimum order is checked as well. Use the returned zone_id to list delivery slots.
+//	@Tags			Delivery
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			address_id		query		string	true	"Saved address ID"
+//	@Param			subtotal_cents	query		int		false	"Basket subtotal in cents"
+//	@Success		200				{object}	dto.DeliveryQuoteSuccessResponse	"Delivery quote"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid query parameters"
+//	@Failure		401				{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody						"Address not found"
+//	@Failure		422				{object}	dto.ErrorBody						"Address not served or below minimum order"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/delivery/quote [get]
+func (h *DeliveryHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	addressID, err := uuid.Parse(q.Get("address_id"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "address_id is required")
+		return
+	}
+	var subtotal *int64
+	if raw := q.Get("subtotal_cents"); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid subtotal_cents")
+			return
+		}
+		subtotal = &n
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	quote, err := h.validateHandler.Handle(r.Context(), delivqry.ValidateDeliveryAddressQuery{
+		CustomerID:    claims.SubjectID,
+		AddressID:     addressID,
+		SubtotalCents: subtotal,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, customer.ErrAddressNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "address not found")
+		case errors.Is(err, delivery.ErrAddressNotServed), errors.Is(err, delivery.ErrBelowMinimumOrder):
+			httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.Success(w, dto.DeliveryQuoteResponse{
+		ZoneID:            quote.ZoneID.String(),
+		ZoneName:          quote.ZoneName,
+		DeliveryFeeCents:  quote.DeliveryFeeCents,
+		MinimumOrderCents: quote.MinimumOrderCents,
+	})
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+
+	dispatchqry "github.com/katerji/butchery-app/backend/internal/application/dispatch/queries"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// DispatchHandler shows the signed-in customer how their delivery is
+// getting on.
+type DispatchHandler struct {
+	orderDeliveryHandler *dispatchqry.OrderDeliveryHandler
+}
+
+// NewDispatchHandler creates a new DispatchHandler.
+func NewDispatchHandler(orderDeliveryHandler *dispatchqry.OrderDeliveryHandler) *DispatchHandler {
+	return &DispatchHandler{orderDeliveryHandler: orderDeliveryHandler}
+}
+
+// OrderDelivery handles GET /api/v1/me/orders/{orderID}/delivery.
+//
+//	@Summary		Track my delivery
+//	@Description	Show where one of the customer's orders is on its delivery run: its place in the route, how many stops are still to be made before it and, once the run has set off, the driver's name.
+//	@Tags			Delivery
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string								true	"Order ID"
+//	@Success		200		{object}	dto.OrderDeliverySuccessResponse	"Delivery position"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody						"Order not on a delivery run yet"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/orders/{orderID}/delivery [get]
+func (h *DispatchHandler) OrderDelivery(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	d, err := h.orderDeliveryHandler.Handle(r.Context(), dispatchqry.OrderDeliveryQuery{
+		OrderID:    orderID,
+		CustomerID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	pos := d.Position
+	httpresponse.Success(w, dto.OrderDeliveryResponse{
+		OrderID:      orderID.String(),
+		RunStatus:    string(pos.Run.Status()),
+		SlotStartsAt: pos.Run.SlotStartsAt(),
+		SlotEndsAt:   pos.Run.SlotEndsAt(),
+		StopStatus:   string(pos.Stop.Status()),
+		Position:     pos.Stop.Sequence(),
+		TotalStops:   pos.Total,
+		StopsAhead:   pos.Ahead,
+		DriverName:   d.DriverName,
+		CompletedAt:  pos.Stop.CompletedAt(),
+	})
+}
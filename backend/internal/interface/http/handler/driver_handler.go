@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	dispatchcmd "github.com/katerji/butchery-app/backend/internal/application/dispatch/commands"
+	dispatchqry "github.com/katerji/butchery-app/backend/internal/application/dispatch/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/dispatch"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// maxStopUpload bounds a stop completion form: a photo and a signature at
+// their largest, with room for the text fields and multipart framing.
+const maxStopUpload = 2*dispatch.MaxProofBytes + 1<<20
+
+// DriverHandler lets delivery drivers work through the runs they are given
+// and record proof of each delivery.
+type DriverHandler struct {
+	startRunHandler    *dispatchcmd.StartRunHandler
+	deliverStopHandler *dispatchcmd.DeliverStopHandler
+	failStopHandler    *dispatchcmd.FailStopHandler
+	driverRunsHandler  *dispatchqry.DriverRunsHandler
+	getRunHandler      *dispatchqry.GetRunHandler
+}
+
+// NewDriverHandler creates a new DriverHandler.
+func NewDriverHandler(
+	startRunHandler *dispatchcmd.StartRunHandler,
+	deliverStopHandler *dispatchcmd.DeliverStopHandler,
+	failStopHandler *dispatchcmd.FailStopHandler,
+	driverRunsHandler *dispatchqry.DriverRunsHandler,
+	getRunHandler *dispatchqry.GetRunHandler,
+) *DriverHandler {
+	return &DriverHandler{
+		startRunHandler:    startRunHandler,
+		deliverStopHandler: deliverStopHandler,
+		failStopHandler:    failStopHandler,
+		driverRunsHandler:  driverRunsHandler,
+		getRunHandler:      getRunHandler,
+	}
+}
+
+// ListRuns handles GET /api/v1/driver/runs.
+//
+//	@Summary		List my runs
+//	@Description	List the delivery runs the signed-in driver has for slots on a day by slot start, with their stops in route order.
+//	@Tags			Driver
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			date	query		string								false	"Day, YYYY-MM-DD (default today)"
+//	@Success		200		{object}	dto.DeliveryRunListSuccessResponse	"Runs"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid date"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/driver/runs [get]
+func (h *DriverHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	date, ok := runDate(w, r)
+	if !ok {
+		return
+	}
+
+	runs, err := h.driverRunsHandler.Handle(r.Context(), dispatchqry.DriverRunsQuery{
+		Date:     date,
+		DriverID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponses(runs))
+}
+
+// GetRun handles GET /api/v1/driver/runs/{runID}.
+//
+//	@Summary		Get my run
+//	@Description	Get one of the signed-in driver's runs with its stops in route order.
+//	@Tags			Driver
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Run"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid run ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Run given to another driver"
+//	@Failure		404		{object}	dto.ErrorBody					"Run not found"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/driver/runs/{runID} [get]
+func (h *DriverHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+
+	run, err := h.getRunHandler.Handle(r.Context(), dispatchqry.GetRunQuery{
+		RunID:   runID,
+		ActorID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// StartRun handles POST /api/v1/driver/runs/{runID}/start.
+//
+//	@Summary		Start my run
+//	@Description	Set off on a planned run the signed-in driver has been given. Its stops can no longer be changed, and customers see the driver's name.
+//	@Tags			Driver
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID	path		string							true	"Run ID"
+//	@Success		200		{object}	dto.DeliveryRunSuccessResponse	"Run started"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid run ID"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403		{object}	dto.ErrorBody					"Run given to another driver"
+//	@Failure		404		{object}	dto.ErrorBody					"Run not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Run already started, has no stops or was changed"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/driver/runs/{runID}/start [post]
+func (h *DriverHandler) StartRun(w http.ResponseWriter, r *http.Request) {
+	runID, ok := uuidParam(r, "runID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return
+	}
+
+	run, err := h.startRunHandler.Handle(r.Context(), dispatchcmd.StartRunCommand{
+		RunID:    runID,
+		DriverID: middleware.ClaimsFromContext(r.Context()).SubjectID,
+	})
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// DeliverStop handles POST /api/v1/driver/runs/{runID}/stops/{stopID}/delivered.
+//
+//	@Summary		Mark a stop delivered
+//	@Description	Record that the order was handed over or left safely, with a photo, the recipient's signature or both as proof. Images are JPEG, PNG or WebP of at most 5 MB each. The run completes with its last stop.
+//	@Tags			Driver
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID			path		string							true	"Run ID"
+//	@Param			stopID			path		string							true	"Stop ID"
+//	@Param			recipient_name	formData	string							false	"Who took the order"
+//	@Param			photo			formData	file							false	"Photo of the order where it was left"
+//	@Param			signature		formData	file							false	"Recipient's signature"
+//	@Success		200				{object}	dto.DeliveryRunSuccessResponse	"Stop delivered"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403				{object}	dto.ErrorBody					"Run given to another driver"
+//	@Failure		404				{object}	dto.ErrorBody					"Run or stop not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Run not started, stop already completed or run changed"
+//	@Failure		413				{object}	dto.ErrorBody					"Proof too large"
+//	@Failure		422				{object}	dto.ErrorBody					"No proof, or not an image"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/driver/runs/{runID}/stops/{stopID}/delivered [post]
+func (h *DriverHandler) DeliverStop(w http.ResponseWriter, r *http.Request) {
+	cmd, ok := completeStopCommand(w, r)
+	if !ok {
+		return
+	}
+
+	run, err := h.deliverStopHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// FailStop handles POST /api/v1/driver/runs/{runID}/stops/{stopID}/failed.
+//
+//	@Summary		Mark a stop failed
+//	@Description	Record that the order could not be delivered and why, optionally with a photo of where it was tried. The run completes with its last stop.
+//	@Tags			Driver
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			runID		path		string							true	"Run ID"
+//	@Param			stopID		path		string							true	"Stop ID"
+//	@Param			reason		formData	string							true	"Why it could not be delivered"
+//	@Param			photo		formData	file							false	"Photo of the door or address"
+//	@Success		200			{object}	dto.DeliveryRunSuccessResponse	"Stop failed"
+//	@Failure		400			{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401			{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		403			{object}	dto.ErrorBody					"Run given to another driver"
+//	@Failure		404			{object}	dto.ErrorBody					"Run or stop not found"
+//	@Failure		409			{object}	dto.ErrorBody					"Run not started, stop already completed or run changed"
+//	@Failure		413			{object}	dto.ErrorBody					"Proof too large"
+//	@Failure		422			{object}	dto.ErrorBody					"No reason, or not an image"
+//	@Failure		500			{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/driver/runs/{runID}/stops/{stopID}/failed [post]
+func (h *DriverHandler) FailStop(w http.ResponseWriter, r *http.Request) {
+	cmd, ok := completeStopCommand(w, r)
+	if !ok {
+		return
+	}
+
+	run, err := h.failStopHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeDispatchError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toDeliveryRunResponse(run))
+}
+
+// completeStopCommand reads a stop completion form, writing a 400 or 413
+// if it cannot be read.
+func completeStopCommand(w http.ResponseWriter, r *http.Request) (dispatchcmd.CompleteStopCommand, bool) {
+	cmd := dispatchcmd.CompleteStopCommand{DriverID: middleware.ClaimsFromContext(r.Context()).SubjectID}
+	var ok bool
+	if cmd.RunID, ok = uuidParam(r, "runID"); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid run ID")
+		return cmd, false
+	}
+	if cmd.StopID, ok = uuidParam(r, "stopID"); !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid stop ID")
+		return cmd, false
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxStopUpload)
+	if err := r.ParseMultipartForm(maxStopUpload); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpresponse.Error(w, http.StatusRequestEntityTooLarge, dispatch.ErrProofTooLarge.Error())
+			return cmd, false
+		}
+		httpresponse.Error(w, http.StatusBadRequest, "request must be multipart/form-data")
+		return cmd, false
+	}
+	cmd.RecipientName = r.FormValue("recipient_name")
+	cmd.Reason = r.FormValue("reason")
+
+	for _, kind := range []dispatch.ProofKind{dispatch.ProofPhoto, dispatch.ProofSignature} {
+		headers := r.MultipartForm.File[string(kind)]
+		if len(headers) == 0 {
+			continue
+		}
+		upload, err := readProof(kind, headers[0])
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "could not read "+string(kind))
+			return cmd, false
+		}
+		cmd.Proofs = append(cmd.Proofs, upload)
+	}
+	return cmd, true
+}
+
+// readProof reads an uploaded image, sniffing its content type rather than
+// trusting the one the phone sent. At most one byte more than a proof may
+// be is read, so that oversized images are still refused.
+func readProof(kind dispatch.ProofKind, header *multipart.FileHeader) (dispatchcmd.ProofUpload, error) {
+	f, err := header.Open()
+	if err != nil {
+		return dispatchcmd.ProofUpload{}, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, dispatch.MaxProofBytes+1))
+	if err != nil {
+		return dispatchcmd.ProofUpload{}, err
+	}
+	return dispatchcmd.ProofUpload{Kind: kind, ContentType: http.DetectContentType(data), Data: data}, nil
+}
+
+func toDeliveryRunResponses(runs []*dispatch.Run) []dto.DeliveryRunResponse {
+	resp := make([]dto.DeliveryRunResponse, 0, len(runs))
+	for _, run := range runs {
+		resp = append(resp, toDeliveryRunResponse(run))
+	}
+	return resp
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	fulfilcmd "github.com/katerji/butchery-app/backend/internal/application/fulfilment/commands"
+	fulfilqry "github.com/katerji/butchery-app/backend/internal/application/fulfilment/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// FulfilmentHandler handles public slot availability and customer slot reservations.
+type FulfilmentHandler struct {
+	listSlotsHandler *fulfilqry.ListAvailableSlotsHandler
+	reserveHandler   *fulfilcmd.ReserveSlotHandler
+	releaseHandler   *fulfilcmd.ReleaseReservationHandler
+}
+
+// NewFulfilmentHandler creates a new FulfilmentHandler.
+func NewFulfilmentHandler(
+	listSlotsHandler *fulfilqry.ListAvailableSlotsHandler,
+	reserveHandler *fulfilcmd.ReserveSlotHandler,
+	releaseHandler *fulfilcmd.ReleaseReservationHandler,
+) *FulfilmentHandler {
+	return &FulfilmentHandler{
+		listSlotsHandler: listSlotsHandler,
+		reserveHandler:   reserveHandler,
+		releaseHandler:   releaseHandler,
+	}
+}
+
+// ListSlots handles GET /api/v1/fulfilment/slots.
+//
+//	@Summary		List available fulfilment slots
+//	@Description	List upcoming delivery slots for a zone or collection slots for a branch that still have capacity. For kg-capacity slots, capacity and remaining are in grams.
+//	@Tags			Fulfilment
+//	@Produce		json
+//	@Param			method		query		string	true	"Fulfilment method"	Enums(delivery, collection)
+//	@Param			zone_id		query		string	false	"Delivery zone ID"
+//	@Param			branch_id	query		string	false	"Pickup branch ID"
+//	@Param			from		query		string	false	"First date (YYYY-MM-DD), defaults to today"
+//	@Param			days		query		int		false	"Number of days to list (default 7, max 28)"
+//	@Success		200			{object}	dto.AvailableSlotsSuccessResponse	"Available slots"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid query parameters"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/fulfilment/slots [get]
+func (h *FulfilmentHandler) ListSlots(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	zoneID, ok := optionalUUID(q.Get("zone_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid zone_id")
+		return
+	}
+	branchID, ok := optionalUUID(q.Get("branch_id"))
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid branch_id")
+		return
+	}
+	days := 0
+	if raw := q.Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid days")
+			return
+		}
+		days = n
+	}
+
+	slots, err := h.listSlotsHandler.Handle(r.Context(), fulfilqry.ListAvailableSlotsQuery{
+		Method:   q.Get("method"),
+		ZoneID:   zoneID,
+		BranchID: branchID,
+		From:     q.Get("from"),
+		Days:     days,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, fulfilment.ErrInvalidMethod),
+			errors.Is(err, fulfilment.ErrInvalidDate),
+			errors.Is(err, fulfilqry.ErrMissingSlotFilter):
+			httpresponse.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := make([]dto.AvailableSlotResponse, 0, len(slots))
+	for _, s := range slots {
+		resp = append(resp, dto.AvailableSlotResponse{
+			TemplateID:   s.TemplateID.String(),
+			BranchID:     s.BranchID.String(),
+			ZoneID:       uuidString(s.ZoneID),
+			Method:       s.Method,
+			Date:         s.StartsAt.Format(time.DateOnly),
+			StartsAt:     s.StartsAt,
+			EndsAt:       s.EndsAt,
+			CapacityUnit: s.CapacityUnit,
+			Capacity:     s.Capacity,
+			Remaining:    s.Remaining,
+		})
+	}
+	httpresponse.Success(w, resp)
+}
+
+// Reserve handles POST /api/v1/fulfilment/reservations.
+//
+//	@Summary		Hold a fulfilment slot
+//	@Description	Place a time-limited hold on a slot during checkout. The hold lapses at expires_at unless the order is placed. weight_grams is required for kg-capacity slots.
+//	@Tags			Fulfilment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ReserveSlotRequest				true	"Slot to hold"
+//	@Success		201		{object}	dto.ReserveSlotSuccessResponse		"Slot held"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody						"Slot template not found"
+//	@Failure		409		{object}	dto.ErrorBody						"Slot is full or unavailable"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/fulfilment/reservations [post]
+func (h *FulfilmentHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReserveSlotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil || req.Date == "" {
+		httpresponse.Error(w, http.StatusBadRequest, "template_id and date are required")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	result, err := h.reserveHandler.Handle(r.Context(), fulfilcmd.ReserveSlotCommand{
+		CustomerID:  claims.SubjectID,
+		TemplateID:  templateID,
+		Date:        req.Date,
+		WeightGrams: req.WeightGrams,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, fulfilment.ErrSlotTemplateNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "slot template not found")
+		case errors.Is(err, fulfilment.ErrSlotFull), errors.Is(err, fulfilment.ErrSlotUnavailable):
+			httpresponse.Error(w, http.StatusConflict, err.Error())
+		case errors.Is(err, fulfilment.ErrInvalidDate), errors.Is(err, fulfilment.ErrInvalidWeight):
+			httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.Created(w, dto.ReserveSlotResponse{
+		ReservationID: result.ReservationID.String(),
+		StartsAt:      result.StartsAt,
+		EndsAt:        result.EndsAt,
+		ExpiresAt:     result.ExpiresAt,
+	})
+}
+
+// Release handles DELETE /api/v1/fulfilment/reservations/{reservationID}.
+//
+//	@Summary		Release a slot hold
+//	@Description	Give a held slot back, e.g. when checkout is abandoned. A slot already confirmed for an order cannot be released by the customer.
+//	@Tags			Fulfilment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			reservationID	path	string	true	"Reservation ID"
+//	@Success		204				"Reservation released"
+//	@Failure		400				{object}	dto.ErrorBody	"Invalid reservation ID"
+//	@Failure		401				{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody	"Reservation not found"
+//	@Failure		409				{object}	dto.ErrorBody	"Reservation already confirmed for an order"
+//	@Failure		500				{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/fulfilment/reservations/{reservationID} [delete]
+func (h *FulfilmentHandler) Release(w http.ResponseWriter, r *http.Request) {
+	reservationID, ok := uuidParam(r, "reservationID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid reservation id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	err := h.releaseHandler.Handle(r.Context(), fulfilcmd.ReleaseReservationCommand{
+		ReservationID: reservationID,
+		CustomerID:    claims.SubjectID,
+	})
+	if err != nil {
+		switch {
+		case reservationMissing(err):
+			httpresponse.Error(w, http.StatusNotFound, "reservation not found")
+		case errors.Is(err, fulfilment.ErrReservationNotHeld):
+			httpresponse.Error(w, http.StatusConflict, err.Error())
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
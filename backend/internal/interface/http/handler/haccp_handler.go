@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	haccpcmd "github.com/katerji/butchery-app/backend/internal/application/haccp/commands"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// sensorKeyHeader carries the key temperature sensors authenticate with.
+const sensorKeyHeader = "X-Sensor-Key"
+
+// maxSensorBytes caps the size of a batch of sensor readings.
+const maxSensorBytes = 256 << 10
+
+// HACCPHandler takes the temperatures sensors push.
+type HACCPHandler struct {
+	ingestHandler *haccpcmd.IngestReadingsHandler
+}
+
+// NewHACCPHandler creates a new HACCPHandler.
+func NewHACCPHandler(ingestHandler *haccpcmd.IngestReadingsHandler) *HACCPHandler {
+	return &HACCPHandler{ingestHandler: ingestHandler}
+}
+
+// PushReadings handles POST /api/v1/haccp/readings.
+//
+//	@Summary		Push sensor temperatures
+//	@Description	Record temperatures taken by sensors, or by a gateway for several, authenticated with the shared key set in HACCP_SENSOR_KEY. Readings from sensors no unit in service has are counted as rejected rather than failing the batch. Readings out of range raise alarms.
+//	@Tags			HACCP
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Sensor-Key	header		string								true	"Sensor key"
+//	@Param			body			body		dto.SensorReadingsRequest			true	"Readings"
+//	@Success		200				{object}	dto.SensorReadingsSuccessResponse	"Readings taken"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401				{object}	dto.ErrorBody						"Invalid sensor key"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/haccp/readings [post]
+func (h *HACCPHandler) PushReadings(w http.ResponseWriter, r *http.Request) {
+	var req dto.SensorReadingsRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxSensorBytes)).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := haccpcmd.IngestReadingsCommand{Key: r.Header.Get(sensorKeyHeader)}
+	for _, sr := range req.Readings {
+		cmd.Readings = append(cmd.Readings, haccpcmd.SensorReading{
+			SensorID: sr.SensorID,
+			Celsius:  sr.Celsius,
+			TakenAt:  sr.TakenAt,
+		})
+	}
+	result, err := h.ingestHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		writeHACCPError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.SensorReadingsResponse{
+		Accepted: result.Accepted,
+		Rejected: result.Rejected,
+		Alerts:   result.Alerts,
+	})
+}
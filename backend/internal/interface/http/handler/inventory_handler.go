@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	invcmd "github.com/katerji/butchery-app/backend/internal/application/inventory/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// InventoryHandler handles customer stock reservations made during checkout.
+type InventoryHandler struct {
+	reserveHandler *invcmd.ReserveStockHandler
+	releaseHandler *invcmd.ReleaseStockHandler
+}
+
+// NewInventoryHandler creates a new InventoryHandler.
+func NewInventoryHandler(reserveHandler *invcmd.ReserveStockHandler, releaseHandler *invcmd.ReleaseStockHandler) *InventoryHandler {
+	return &InventoryHandler{reserveHandler: reserveHandler, releaseHandler: releaseHandler}
+}
+
+// Reserve handles POST /api/v1/inventory/reservations.
+//
+//	@Summary		Hold stock
+//	@Description	Place a time-limited hold on stock for the basket during checkout. The hold lapses at expires_at unless the order is placed.
+//	@Tags			Inventory
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ReserveStockRequest				true	"Stock to hold"
+//	@Success		201		{object}	dto.ReserveStockSuccessResponse		"Stock held"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		409		{object}	dto.ErrorBody						"Insufficient stock"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/inventory/reservations [post]
+func (h *InventoryHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReserveStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	branchID, err := uuid.Parse(req.BranchID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "branch_id is required")
+		return
+	}
+	lines := make([]inventory.ReservationLine, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		productID, err := uuid.Parse(l.ProductID)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+			return
+		}
+		lines = append(lines, inventory.ReservationLine{ProductID: productID, Grams: l.Grams})
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	result, err := h.reserveHandler.Handle(r.Context(), invcmd.ReserveStockCommand{
+		CustomerID: claims.SubjectID,
+		BranchID:   branchID,
+		Lines:      lines,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, dto.ReserveStockResponse{
+		ReservationID: result.ReservationID.String(),
+		ExpiresAt:     result.ExpiresAt,
+	})
+}
+
+// Release handles DELETE /api/v1/inventory/reservations/{reservationID}.
+//
+//	@Summary		Release a stock hold
+//	@Description	Give held stock back, e.g. when checkout is abandoned. Stock already confirmed for an order cannot be released by the customer.
+//	@Tags			Inventory
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			reservationID	path	string	true	"Reservation ID"
+//	@Success		204				"Reservation released"
+//	@Failure		400				{object}	dto.ErrorBody	"Invalid reservation ID"
+//	@Failure		401				{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody	"Reservation not found"
+//	@Failure		409				{object}	dto.ErrorBody	"Reservation already confirmed for an order or fulfilled"
+//	@Failure		500				{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/inventory/reservations/{reservationID} [delete]
+func (h *InventoryHandler) Release(w http.ResponseWriter, r *http.Request) {
+	reservationID, ok := uuidParam(r, "reservationID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid reservation id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	err := h.releaseHandler.Handle(r.Context(), invcmd.ReleaseStockCommand{
+		ReservationID: reservationID,
+		CustomerID:    claims.SubjectID,
+	})
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+// writeInventoryError maps inventory domain errors to HTTP responses.
+func writeInventoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, branch.ErrOutOfScope):
+		httpresponse.Error(w, http.StatusForbidden, branch.ErrOutOfScope.Error())
+	case errors.Is(err, inventory.ErrLotNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "lot not found")
+	case errors.Is(err, inventory.ErrProposalNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "expiry proposal not found")
+	case reservationMissing(err):
+		httpresponse.Error(w, http.StatusNotFound, "reservation not found")
+	case errors.Is(err, inventory.ErrInsufficientStock),
+		errors.Is(err, inventory.ErrReservationNotActive),
+		errors.Is(err, inventory.ErrReservationNotHeld),
+		errors.Is(err, inventory.ErrReservationExpired),
+		errors.Is(err, inventory.ErrProposalDecided):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, inventory.ErrInvalidWeight),
+		errors.Is(err, inventory.ErrInvalidCount),
+		errors.Is(err, inventory.ErrInvalidThreshold),
+		errors.Is(err, inventory.ErrEmptyLotCode),
+		errors.Is(err, inventory.ErrInvalidDate),
+		errors.Is(err, inventory.ErrInvalidMovementType),
+		errors.Is(err, inventory.ErrInvalidMovementDelta),
+		errors.Is(err, inventory.ErrMovementTypeNotAllowed),
+		errors.Is(err, inventory.ErrLotBranchMismatch),
+		errors.Is(err, inventory.ErrEmptyReservation),
+		errors.Is(err, inventory.ErrInvalidCost),
+		errors.Is(err, inventory.ErrInvalidMarkdown),
+		errors.Is(err, inventory.ErrInvalidWasteReason),
+		errors.Is(err, inventory.ErrInvalidProposalStatus),
+		errors.Is(err, inventory.ErrInvalidShrinkageGroup),
+		errors.Is(err, inventory.ErrInvalidPeriod):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	invoiceqry "github.com/katerji/butchery-app/backend/internal/application/invoice/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/invoice"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// InvoiceHandler lets customers list and download their invoices and credit
+// notes.
+type InvoiceHandler struct {
+	listHandler *invoiceqry.ListCustomerDocumentsHandler
+	getHandler  *invoiceqry.GetDocumentHandler
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler.
+func NewInvoiceHandler(listHandler *invoiceqry.ListCustomerDocumentsHandler, getHandler *invoiceqry.GetDocumentHandler) *InvoiceHandler {
+	return &InvoiceHandler{listHandler: listHandler, getHandler: getHandler}
+}
+
+// List handles GET /api/v1/me/invoices.
+//
+//	@Summary		List my invoices
+//	@Description	List the authenticated customer's invoices and credit notes, newest first.
+//	@Tags			Invoices
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.InvoicesSuccessResponse	"Invoices and credit notes"
+//	@Failure		401	{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		500	{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/me/invoices [get]
+func (h *InvoiceHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	docs, err := h.listHandler.Handle(r.Context(), claims.SubjectID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toInvoiceResponses(docs))
+}
+
+// Download handles GET /api/v1/me/invoices/{invoiceID}/pdf.
+//
+//	@Summary		Download an invoice
+//	@Description	Download one of the authenticated customer's invoices or credit notes as the PDF stored when it was issued.
+//	@Tags			Invoices
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			invoiceID	path		string			true	"Invoice or credit note ID"
+//	@Success		200			{file}		file			"PDF"
+//	@Failure		400			{object}	dto.ErrorBody	"Invalid invoice ID"
+//	@Failure		401			{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		404			{object}	dto.ErrorBody	"Invoice not found"
+//	@Failure		500			{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/me/invoices/{invoiceID}/pdf [get]
+func (h *InvoiceHandler) Download(w http.ResponseWriter, r *http.Request) {
+	invoiceID, ok := uuidParam(r, "invoiceID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid invoice ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	d, err := h.getHandler.HandleForCustomer(r.Context(), invoiceID, claims.SubjectID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writePDF(w, d)
+}
+
+// writePDF sends a document's stored PDF unchanged. Its checksum doubles as
+// the ETag, since the bytes never change once issued.
+func writePDF(w http.ResponseWriter, d *invoice.Document) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", d.Number()+".pdf"))
+	w.Header().Set("Content-Length", strconv.Itoa(len(d.PDF())))
+	w.Header().Set("ETag", strconv.Quote(d.Checksum()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(d.PDF())
+}
+
+func toInvoiceResponses(docs []*invoice.Document) []dto.InvoiceResponse {
+	resp := make([]dto.InvoiceResponse, 0, len(docs))
+	for _, d := range docs {
+		resp = append(resp, toInvoiceResponse(d))
+	}
+	return resp
+}
+
+func writeInvoiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, invoice.ErrDocumentNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "invoice not found")
+	case errors.Is(err, order.ErrOrderNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "order not found")
+	case errors.Is(err, order.ErrRefundNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "refund not found")
+	case errors.Is(err, order.ErrRefundNotIssued),
+		errors.Is(err, invoice.ErrInvoiceRequired),
+		errors.Is(err, invoice.ErrAlreadyIssued):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, invoice.ErrBranchRequired),
+		errors.Is(err, invoice.ErrNoLines),
+		errors.Is(err, invoice.ErrInvalidVATRate),
+		errors.Is(err, invoice.ErrNegativeAmount),
+		errors.Is(err, invoice.ErrCreditExceedsInvoice):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
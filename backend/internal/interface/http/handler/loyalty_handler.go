@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	loyaltycmd "github.com/katerji/butchery-app/backend/internal/application/loyalty/commands"
+	loyaltyqry "github.com/katerji/butchery-app/backend/internal/application/loyalty/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/loyalty"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// LoyaltyHandler lets customers see their points and set their birthday.
+type LoyaltyHandler struct {
+	accountHandler  *loyaltyqry.GetAccountHandler
+	birthdayHandler *loyaltycmd.SetBirthdayHandler
+}
+
+// NewLoyaltyHandler creates a new LoyaltyHandler.
+func NewLoyaltyHandler(accountHandler *loyaltyqry.GetAccountHandler, birthdayHandler *loyaltycmd.SetBirthdayHandler) *LoyaltyHandler {
+	return &LoyaltyHandler{accountHandler: accountHandler, birthdayHandler: birthdayHandler}
+}
+
+// GetAccount handles GET /api/v1/me/loyalty.
+//
+//	@Summary		Get my points
+//	@Description	Get the authenticated customer's points balance, what it is worth at checkout, and every entry behind it, oldest first.
+//	@Tags			Loyalty
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.LoyaltyAccountSuccessResponse	"Loyalty account"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/loyalty [get]
+func (h *LoyaltyHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	account, err := h.accountHandler.Handle(r.Context(), claims.SubjectID)
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toLoyaltyAccountResponse(account))
+}
+
+// SetBirthday handles PUT /api/v1/me/loyalty/birthday.
+//
+//	@Summary		Set my birthday
+//	@Description	Set the day and month the authenticated customer gets their birthday bonus. Customers born on 29 February get it on 28 February in other years.
+//	@Tags			Loyalty
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.BirthdayRequest			true	"Birthday"
+//	@Success		200		{object}	dto.BirthdaySuccessResponse	"Birthday set"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/me/loyalty/birthday [put]
+func (h *LoyaltyHandler) SetBirthday(w http.ResponseWriter, r *http.Request) {
+	var req dto.BirthdayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	b, err := h.birthdayHandler.Handle(r.Context(), loyaltycmd.SetBirthdayCommand{
+		CustomerID: claims.SubjectID,
+		Month:      req.Month,
+		Day:        req.Day,
+	})
+	if err != nil {
+		writeLoyaltyError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, dto.BirthdayResponse{Month: int(b.Month), Day: b.Day})
+}
+
+func toLoyaltyAccountResponse(a *loyaltyqry.Account) dto.LoyaltyAccountResponse {
+	resp := dto.LoyaltyAccountResponse{
+		LoyaltyBalanceResponse: toLoyaltyBalanceResponse(a.Balance),
+		ValueCents:             a.ValueCents,
+		History:                make([]dto.LoyaltyEntryResponse, 0, len(a.Entries)),
+	}
+	if a.Birthday != nil {
+		resp.Birthday = &dto.BirthdayResponse{Month: int(a.Birthday.Month), Day: a.Birthday.Day}
+	}
+	for _, e := range a.Entries {
+		resp.History = append(resp.History, toLoyaltyEntryResponse(e))
+	}
+	return resp
+}
+
+func toLoyaltyBalanceResponse(b loyalty.Balance) dto.LoyaltyBalanceResponse {
+	return dto.LoyaltyBalanceResponse{
+		CustomerID:     b.CustomerID.String(),
+		Points:         b.Points,
+		EarnedPoints:   b.EarnedPoints,
+		RedeemedPoints: b.RedeemedPoints,
+		ExpiredPoints:  b.ExpiredPoints,
+		Entries:        b.Entries,
+		UpdatedAt:      b.UpdatedAt,
+	}
+}
+
+func toLoyaltyEntryResponse(e *loyalty.Entry) dto.LoyaltyEntryResponse {
+	resp := dto.LoyaltyEntryResponse{
+		ID:        e.ID.String(),
+		Kind:      string(e.Kind),
+		Points:    e.Points,
+		Reference: e.Reference,
+		ExpiresAt: e.ExpiresAt,
+		Note:      e.Note,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.SourceID != nil {
+		s := e.SourceID.String()
+		resp.SourceID = &s
+	}
+	if e.CreatedBy != nil {
+		s := e.CreatedBy.String()
+		resp.CreatedBy = &s
+	}
+	return resp
+}
+
+func writeLoyaltyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, loyalty.ErrManageNotAllowed):
+		httpresponse.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, customer.ErrCustomerNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "customer not found")
+	case errors.Is(err, loyalty.ErrInvalidPoints),
+		errors.Is(err, loyalty.ErrNoteRequired),
+		errors.Is(err, loyalty.ErrInvalidUnit),
+		errors.Is(err, loyalty.ErrNegativeRule),
+		errors.Is(err, loyalty.ErrInvalidExpiryDays),
+		errors.Is(err, loyalty.ErrInvalidBirthday),
+		errors.Is(err, loyalty.ErrInvalidDate),
+		errors.Is(err, loyalty.ErrInsufficientPoints):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	ordercmd "github.com/katerji/butchery-app/backend/internal/application/order/commands"
+	orderqry "github.com/katerji/butchery-app/backend/internal/application/order/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+	"github.com/katerji/butchery-app/backend/pkg/sse"
+)
+
+// OrderHandler lets the signed-in customer place, follow and cancel their
+// orders.
+type OrderHandler struct {
+	watchHandler  *orderqry.WatchOrdersHandler
+	placeHandler  *ordercmd.PlaceOrderHandler
+	cancelHandler *ordercmd.CancelOrderHandler
+}
+
+// NewOrderHandler creates a new OrderHandler.
+func NewOrderHandler(
+	watchHandler *orderqry.WatchOrdersHandler,
+	placeHandler *ordercmd.PlaceOrderHandler,
+	cancelHandler *ordercmd.CancelOrderHandler,
+) *OrderHandler {
+	return &OrderHandler{watchHandler: watchHandler, placeHandler: placeHandler, cancelHandler: cancelHandler}
+}
+
+// PlaceOrder handles POST /api/v1/me/orders.
+//
+//	@Summary		Check out
+//	@Description	Place an order for the stock the customer has on hold, in the slot they have on hold. The stock is set aside for the order and the slot booked for it, so neither lapses. Lines are priced at the shelf or branch price, with running promotions, the coupons given and then any loyalty points redeemed taken off before VAT. A delivery slot needs a saved address inside an active delivery zone, the one the slot delivers to; the order must reach the zone's minimum and is charged its delivery fee, taxed as delivery. Both holds must still be on hold; one that has expired or been used for another order is refused. Authorize payment for the order next.
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.PlaceOrderRequest		true	"Checkout"
+//	@Success		201		{object}	dto.OrderSuccessResponse	"Order placed"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody				"Reservation or address not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Hold expired or no longer on hold"
+//	@Failure		422		{object}	dto.ErrorBody				"Validation error, address outside every delivery zone or order below the zone's minimum"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/me/orders [post]
+func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.PlaceOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	stockID, err := uuid.Parse(req.StockReservationID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "stock_reservation_id is required")
+		return
+	}
+	slotID, err := uuid.Parse(req.SlotReservationID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "slot_reservation_id is required")
+		return
+	}
+	addressID, ok := optionalUUID(req.AddressID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid address_id")
+		return
+	}
+
+	o, err := h.placeHandler.Handle(r.Context(), ordercmd.PlaceOrderCommand{
+		CustomerID:         middleware.ClaimsFromContext(r.Context()).SubjectID,
+		StockReservationID: stockID,
+		SlotReservationID:  slotID,
+		AddressID:          addressID,
+		Coupons:            req.Coupons,
+		RedeemPoints:       req.RedeemPoints,
+	})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toOrderResponse(o))
+}
+
+// CancelOrder handles POST /api/v1/me/orders/{orderID}/cancel.
+//
+//	@Summary		Cancel my order
+//	@Description	Cancel one of the customer's orders before the cutting room starts on it. The hold on their card is voided and any coupons and loyalty points used are given back. An order already paid for has to be refunded by the shop.
+//	@Tags			Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			orderID	path		string						true	"Order ID"
+//	@Success		200		{object}	dto.OrderSuccessResponse	"Order cancelled"
+//	@Failure		400		{object}	dto.ErrorBody				"Invalid order ID"
+//	@Failure		401		{object}	dto.ErrorBody				"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody				"Order not found"
+//	@Failure		409		{object}	dto.ErrorBody				"Order already cut, refunded or paid for"
+//	@Failure		500		{object}	dto.ErrorBody				"Internal server error"
+//	@Router			/me/orders/{orderID}/cancel [post]
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, ok := uuidParam(r, "orderID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	customerID := middleware.ClaimsFromContext(r.Context()).SubjectID
+	o, err := h.cancelHandler.Handle(r.Context(), ordercmd.CancelOrderCommand{OrderID: orderID, CustomerID: &customerID})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toOrderResponse(o))
+}
+
+// StreamOrders handles GET /api/v1/me/orders/stream.
+//
+//	@Summary		Follow my orders
+//	@Description	Stream changes to the customer's orders as server-sent events, so the dashboard shows an order is ready without being refreshed. Each "order" event carries a dto.OrderEventResponse and has its ID as the SSE ID: an EventSource that reconnects sends it back in Last-Event-ID and is sent every change it missed before new ones. Clients that cannot set the header may pass last_event_id instead. Without either, only changes from now on are sent.
+//	@Tags			Orders
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			Last-Event-ID	header		string			false	"ID of the last event received"
+//	@Param			last_event_id	query		string			false	"ID of the last event received, if the header cannot be set"
+//	@Success		200				{string}	string			"Event stream"
+//	@Failure		400				{object}	dto.ErrorBody	"Invalid last event ID"
+//	@Failure		401				{object}	dto.ErrorBody	"Unauthorized"
+//	@Failure		500				{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/me/orders/stream [get]
+func (h *OrderHandler) StreamOrders(w http.ResponseWriter, r *http.Request) {
+	lastID, ok := lastEventID(r)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid last event ID")
+		return
+	}
+
+	customerID := middleware.ClaimsFromContext(r.Context()).SubjectID
+	events, err := h.watchHandler.Handle(r.Context(), orderqry.WatchOrdersQuery{
+		CustomerID:  &customerID,
+		LastEventID: lastID,
+	})
+	if err != nil {
+		writeOrderError(w, err)
+		return
+	}
+	streamOrderEvents(w, events)
+}
+
+// lastEventID reads the last event a reconnecting client got from the
+// Last-Event-ID header or, failing that, the last_event_id query parameter.
+// It is zero for a client connecting for the first time.
+func lastEventID(r *http.Request) (int64, bool) {
+	raw := sse.LastEventID(r)
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// streamOrderEvents sends events as "order" events until the channel is
+// closed or the client goes.
+func streamOrderEvents(w http.ResponseWriter, events <-chan order.Event) {
+	stream, err := sse.Open(w)
+	if err != nil {
+		return
+	}
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			err = stream.SendWithID(strconv.FormatInt(e.ID, 10), "order", toOrderEventResponse(e))
+		case <-ping.C:
+			err = stream.Ping()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func toOrderEventResponse(e order.Event) dto.OrderEventResponse {
+	return dto.OrderEventResponse{
+		ID:         e.ID,
+		OrderID:    e.OrderID.String(),
+		CustomerID: e.CustomerID.String(),
+		BranchID:   e.BranchID.String(),
+		Status:     string(e.Status),
+		Stage:      string(e.Stage),
+		Delivery:   string(e.Delivery),
+		At:         e.At,
+	}
+}
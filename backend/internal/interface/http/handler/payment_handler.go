@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	paycmd "github.com/katerji/butchery-app/backend/internal/application/payment/commands"
+	"github.com/katerji/butchery-app/backend/internal/domain/order"
+	"github.com/katerji/butchery-app/backend/internal/domain/payment"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// idempotencyKeyHeader carries the client's key for requests that must not
+// be applied twice, such as authorizing a card.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// paymentSignatureHeader carries the provider's signature on webhooks.
+const paymentSignatureHeader = "Stripe-Signature"
+
+// maxWebhookBytes caps the size of a webhook body.
+const maxWebhookBytes = 64 << 10
+
+// PaymentHandler handles card authorization at checkout and notifications
+// from the payment provider.
+type PaymentHandler struct {
+	authorizeHandler *paycmd.AuthorizePaymentHandler
+	webhookHandler   *paycmd.HandleWebhookHandler
+}
+
+// NewPaymentHandler creates a new PaymentHandler.
+func NewPaymentHandler(authorizeHandler *paycmd.AuthorizePaymentHandler, webhookHandler *paycmd.HandleWebhookHandler) *PaymentHandler {
+	return &PaymentHandler{authorizeHandler: authorizeHandler, webhookHandler: webhookHandler}
+}
+
+// Authorize handles POST /api/v1/payments.
+//
+//	@Summary		Authorize a card
+//	@Description	Hold the order's estimated total plus a margin on the customer's card. The weighed total is captured later. Retrying with the same Idempotency-Key returns the original payment.
+//	@Tags			Payments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Idempotency-Key	header		string							true	"Client-chosen key that makes retries safe"
+//	@Param			body			body		dto.AuthorizePaymentRequest		true	"Payment"
+//	@Success		201				{object}	dto.PaymentSuccessResponse		"Card authorized"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		402				{object}	dto.ErrorBody					"Card declined"
+//	@Failure		404				{object}	dto.ErrorBody					"Order not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Idempotency key conflict"
+//	@Failure		422				{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/payments [post]
+func (h *PaymentHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if key == "" {
+		httpresponse.Error(w, http.StatusBadRequest, "Idempotency-Key header is required")
+		return
+	}
+	var req dto.AuthorizePaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	orderID, err := uuid.Parse(req.OrderID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "order_id is required")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	p, err := h.authorizeHandler.Handle(r.Context(), paycmd.AuthorizePaymentCommand{
+		OrderID:        orderID,
+		CustomerID:     claims.SubjectID,
+		PaymentMethod:  req.PaymentMethod,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toPaymentResponse(p, nil))
+}
+
+// Webhook handles POST /api/v1/payments/webhook.
+//
+//	@Summary		Receive a payment provider webhook
+//	@Description	Apply a signed notification from the payment provider. Redelivered events are acknowledged without being applied again.
+//	@Tags			Payments
+//	@Accept			json
+//	@Produce		json
+//	@Param			Stripe-Signature	header	string	true	"Provider signature"
+//	@Success		204					"Event accepted"
+//	@Failure		400					{object}	dto.ErrorBody	"Invalid signature"
+//	@Failure		409					{object}	dto.ErrorBody	"Payment changed concurrently; retry"
+//	@Failure		500					{object}	dto.ErrorBody	"Internal server error"
+//	@Router			/payments/webhook [post]
+func (h *PaymentHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBytes))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err = h.webhookHandler.Handle(r.Context(), paycmd.HandleWebhookCommand{
+		Payload:   payload,
+		Signature: r.Header.Get(paymentSignatureHeader),
+	})
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	httpresponse.NoContent(w)
+}
+
+func toPaymentResponse(p *payment.Payment, refunds []*payment.Refund) dto.PaymentResponse {
+	resp := dto.PaymentResponse{
+		ID:              p.ID().String(),
+		OrderID:         p.OrderID().String(),
+		CustomerID:      p.CustomerID().String(),
+		Currency:        p.Currency(),
+		Status:          string(p.Status()),
+		EstimatedCents:  p.EstimatedCents(),
+		AuthorizedCents: p.AuthorizedCents(),
+		CapturedCents:   p.CapturedCents(),
+		RefundedCents:   p.RefundedCents(),
+		Provider:        p.Provider(),
+		ProviderRef:     p.ProviderRef(),
+		FailureReason:   optionalString(p.FailureReason()),
+		CreatedAt:       p.CreatedAt(),
+		UpdatedAt:       p.UpdatedAt(),
+	}
+	for _, r := range refunds {
+		resp.Refunds = append(resp.Refunds, toRefundResponse(r))
+	}
+	return resp
+}
+
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, payment.ErrPaymentNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "payment not found")
+	case errors.Is(err, order.ErrOrderNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "order not found")
+	case errors.Is(err, payment.ErrDeclined):
+		httpresponse.Error(w, http.StatusPaymentRequired, err.Error())
+	case errors.Is(err, payment.ErrInvalidSignature):
+		httpresponse.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, payment.ErrNotAuthorized),
+		errors.Is(err, payment.ErrNotCaptured),
+		errors.Is(err, payment.ErrAlreadyCaptured),
+		errors.Is(err, payment.ErrAuthorizationInProgress),
+		errors.Is(err, payment.ErrIdempotencyKeyReused),
+		errors.Is(err, payment.ErrConcurrentUpdate):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, payment.ErrInvalidAmount),
+		errors.Is(err, payment.ErrInvalidCurrency),
+		errors.Is(err, payment.ErrEmptyIdempotencyKey),
+		errors.Is(err, payment.ErrEmptyPaymentMethod),
+		errors.Is(err, payment.ErrCaptureExceedsAuthorization),
+		errors.Is(err, payment.ErrRefundExceedsCaptured):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	qurbanicmd "github.com/katerji/butchery-app/backend/internal/application/qurbani/commands"
+	qurbaniqry "github.com/katerji/butchery-app/backend/internal/application/qurbani/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/customer"
+	"github.com/katerji/butchery-app/backend/internal/domain/qurbani"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// QurbaniHandler lets customers book shares of an Eid al-Adha sacrifice and
+// follow them until the sacrifice is done.
+type QurbaniHandler struct {
+	offeringsHandler *qurbaniqry.ListOfferingsHandler
+	bookHandler      *qurbanicmd.BookShareHandler
+	bookingsHandler  *qurbaniqry.ListBookingsHandler
+	cancelHandler    *qurbanicmd.CancelBookingHandler
+}
+
+// NewQurbaniHandler creates a new QurbaniHandler.
+func NewQurbaniHandler(
+	offeringsHandler *qurbaniqry.ListOfferingsHandler,
+	bookHandler *qurbanicmd.BookShareHandler,
+	bookingsHandler *qurbaniqry.ListBookingsHandler,
+	cancelHandler *qurbanicmd.CancelBookingHandler,
+) *QurbaniHandler {
+	return &QurbaniHandler{
+		offeringsHandler: offeringsHandler,
+		bookHandler:      bookHandler,
+		bookingsHandler:  bookingsHandler,
+		cancelHandler:    cancelHandler,
+	}
+}
+
+// ListOfferings handles GET /api/v1/qurbani/offerings.
+//
+//	@Summary		List qurbani offerings
+//	@Description	List the share types open for booking, with the price per share and how many shares are left.
+//	@Tags			Qurbani
+//	@Produce		json
+//	@Param			season	query		string								false	"Season, e.g. 2027; every season when omitted"
+//	@Success		200		{object}	dto.QurbaniOfferingListSuccessResponse	"Open offerings"
+//	@Failure		500		{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/qurbani/offerings [get]
+func (h *QurbaniHandler) ListOfferings(w http.ResponseWriter, r *http.Request) {
+	offerings, err := h.offeringsHandler.Handle(r.Context(), r.URL.Query().Get("season"), true)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniOfferingResponses(offerings))
+}
+
+// Book handles POST /api/v1/me/qurbani/bookings.
+//
+//	@Summary		Book a qurbani share
+//	@Description	Book one share of a sacrifice on behalf of the person named in the niyyah, and choose whether the meat is delivered, collected or given to charity. Each share is a separate booking.
+//	@Tags			Qurbani
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.QurbaniBookingRequest			true	"Booking"
+//	@Success		201		{object}	dto.QurbaniBookingSuccessResponse	"Share booked"
+//	@Failure		400		{object}	dto.ErrorBody						"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody						"Offering or address not found"
+//	@Failure		409		{object}	dto.ErrorBody						"Sold out or closed for booking"
+//	@Failure		422		{object}	dto.ErrorBody						"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/qurbani/bookings [post]
+func (h *QurbaniHandler) Book(w http.ResponseWriter, r *http.Request) {
+	var req dto.QurbaniBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	offeringID, err := uuid.Parse(req.OfferingID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid offering_id")
+		return
+	}
+	addressID, ok := optionalUUID(req.AddressID)
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid address_id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	b, err := h.bookHandler.Handle(r.Context(), qurbanicmd.BookShareCommand{
+		CustomerID:   claims.SubjectID,
+		OfferingID:   offeringID,
+		NiyyahName:   req.NiyyahName,
+		Distribution: req.Distribution,
+		AddressID:    addressID,
+	})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toQurbaniBookingResponse(b))
+}
+
+// ListBookings handles GET /api/v1/me/qurbani/bookings.
+//
+//	@Summary		List my qurbani bookings
+//	@Description	List the authenticated customer's shares in the order they were booked, with when each sacrifice was done.
+//	@Tags			Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			season	query		string								false	"Season, e.g. 2027"
+//	@Success		200		{object}	dto.QurbaniBookingListSuccessResponse	"Bookings"
+//	@Failure		401		{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		500		{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/me/qurbani/bookings [get]
+func (h *QurbaniHandler) ListBookings(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	bookings, err := h.bookingsHandler.Handle(r.Context(), qurbani.BookingFilter{
+		Season:     r.URL.Query().Get("season"),
+		CustomerID: &claims.SubjectID,
+	})
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniBookingResponses(bookings))
+}
+
+// Cancel handles POST /api/v1/me/qurbani/bookings/{bookingID}/cancel.
+//
+//	@Summary		Cancel a qurbani booking
+//	@Description	Cancel one of the authenticated customer's shares before the sacrifice. Its place on the animal goes to the next booking.
+//	@Tags			Qurbani
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			bookingID	path		string								true	"Booking ID"
+//	@Success		200			{object}	dto.QurbaniBookingSuccessResponse	"Booking cancelled"
+//	@Failure		400			{object}	dto.ErrorBody						"Invalid booking ID"
+//	@Failure		401			{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404			{object}	dto.ErrorBody						"Booking not found"
+//	@Failure		409			{object}	dto.ErrorBody						"Already slaughtered or cancelled"
+//	@Failure		500			{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/qurbani/bookings/{bookingID}/cancel [post]
+func (h *QurbaniHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	bookingID, ok := uuidParam(r, "bookingID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid booking ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	b, err := h.cancelHandler.Handle(r.Context(), qurbanicmd.CancelBookingCommand{
+		BookingID:  bookingID,
+		CustomerID: &claims.SubjectID,
+	})
+	if err != nil {
+		writeQurbaniError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toQurbaniBookingResponse(b))
+}
+
+func toQurbaniOfferingResponses(offerings []qurbaniqry.OfferingAvailability) []dto.QurbaniOfferingResponse {
+	resp := make([]dto.QurbaniOfferingResponse, 0, len(offerings))
+	for _, a := range offerings {
+		resp = append(resp, toQurbaniOfferingResponse(a))
+	}
+	return resp
+}
+
+func toQurbaniOfferingResponse(a qurbaniqry.OfferingAvailability) dto.QurbaniOfferingResponse {
+	o := a.Offering
+	return dto.QurbaniOfferingResponse{
+		ID:              o.ID().String(),
+		Season:          o.Season(),
+		ShareType:       o.ShareType().String(),
+		PriceCents:      o.PriceCents(),
+		Animals:         o.Animals(),
+		SharesPerAnimal: o.ShareType().SharesPerAnimal(),
+		Capacity:        o.Capacity(),
+		Booked:          a.Booked,
+		Remaining:       a.Remaining(),
+		Active:          o.Active(),
+		CreatedAt:       o.CreatedAt(),
+		UpdatedAt:       o.UpdatedAt(),
+	}
+}
+
+func toQurbaniBookingResponses(bookings []*qurbani.Booking) []dto.QurbaniBookingResponse {
+	resp := make([]dto.QurbaniBookingResponse, 0, len(bookings))
+	for _, b := range bookings {
+		resp = append(resp, toQurbaniBookingResponse(b))
+	}
+	return resp
+}
+
+func toQurbaniBookingResponse(b *qurbani.Booking) dto.QurbaniBookingResponse {
+	resp := dto.QurbaniBookingResponse{
+		ID:            b.ID().String(),
+		OfferingID:    b.OfferingID().String(),
+		CustomerID:    b.CustomerID().String(),
+		NiyyahName:    b.NiyyahName(),
+		Distribution:  b.Distribution().String(),
+		PriceCents:    b.PriceCents(),
+		Status:        string(b.Status()),
+		BookedAt:      b.BookedAt(),
+		SlaughteredAt: b.SlaughteredAt(),
+		NotifiedAt:    b.NotifiedAt(),
+	}
+	if b.AddressID() != nil {
+		s := b.AddressID().String()
+		resp.AddressID = &s
+	}
+	if b.AnimalID() != nil {
+		s := b.AnimalID().String()
+		resp.AnimalID = &s
+	}
+	return resp
+}
+
+func writeQurbaniError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, qurbani.ErrOfferingNotFound),
+		errors.Is(err, qurbani.ErrBookingNotFound),
+		errors.Is(err, qurbani.ErrBatchNotFound),
+		errors.Is(err, customer.ErrAddressNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, qurbani.ErrSoldOut),
+		errors.Is(err, qurbani.ErrOfferingClosed),
+		errors.Is(err, qurbani.ErrDuplicateOffering),
+		errors.Is(err, qurbani.ErrCapacityBelowBooked),
+		errors.Is(err, qurbani.ErrCannotCancel),
+		errors.Is(err, qurbani.ErrNotEnoughAnimals),
+		errors.Is(err, qurbani.ErrAllocationConflict),
+		errors.Is(err, qurbani.ErrNothingToBatch),
+		errors.Is(err, qurbani.ErrBatchAlreadyCompleted),
+		errors.Is(err, qurbani.ErrBatchNotCompleted):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, qurbani.ErrInvalidShareType),
+		errors.Is(err, qurbani.ErrInvalidDistribution),
+		errors.Is(err, qurbani.ErrEmptySeason),
+		errors.Is(err, qurbani.ErrNegativePrice),
+		errors.Is(err, qurbani.ErrInvalidAnimals),
+		errors.Is(err, qurbani.ErrEmptyNiyyahName),
+		errors.Is(err, qurbani.ErrNiyyahNameTooLong),
+		errors.Is(err, qurbani.ErrAddressRequired),
+		errors.Is(err, qurbani.ErrInvalidBatchSize),
+		errors.Is(err, qurbani.ErrInvalidBatchInterval):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+)
+
+// reservationMissing reports whether err means the caller has no such slot
+// or stock reservation. A reservation owned by someone else is reported as
+// missing so IDs cannot be probed.
+func reservationMissing(err error) bool {
+	return errors.Is(err, fulfilment.ErrReservationNotFound) ||
+		errors.Is(err, fulfilment.ErrReservationNotOwned) ||
+		errors.Is(err, inventory.ErrReservationNotFound) ||
+		errors.Is(err, inventory.ErrReservationNotOwned)
+}
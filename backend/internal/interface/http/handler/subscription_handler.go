@@ -0,0 +1,617 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	subscriptioncmd "github.com/katerji/butchery-app/backend/internal/application/subscription/commands"
+	subscriptionqry "github.com/katerji/butchery-app/backend/internal/application/subscription/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/fulfilment"
+	"github.com/katerji/butchery-app/backend/internal/domain/subscription"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// upcomingDeliveries is how many delivery dates a subscription lists ahead.
+const upcomingDeliveries = 4
+
+// SubscriptionHandler lets customers subscribe to a box delivered at a
+// regular cadence and manage their deliveries.
+type SubscriptionHandler struct {
+	boxesHandler         *subscriptionqry.ListBoxesHandler
+	subscribeHandler     *subscriptioncmd.SubscribeHandler
+	listHandler          *subscriptionqry.ListSubscriptionsHandler
+	skipHandler          *subscriptioncmd.SkipDeliveryHandler
+	swapHandler          *subscriptioncmd.SwapCutHandler
+	slotHandler          *subscriptioncmd.ChangeSlotHandler
+	paymentMethodHandler *subscriptioncmd.ChangePaymentMethodHandler
+	pauseHandler         *subscriptioncmd.PauseSubscriptionHandler
+	resumeHandler        *subscriptioncmd.ResumeSubscriptionHandler
+	cancelHandler        *subscriptioncmd.CancelSubscriptionHandler
+	renewalsHandler      *subscriptionqry.ListRenewalsHandler
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler.
+func NewSubscriptionHandler(
+	boxesHandler *subscriptionqry.ListBoxesHandler,
+	subscribeHandler *subscriptioncmd.SubscribeHandler,
+	listHandler *subscriptionqry.ListSubscriptionsHandler,
+	skipHandler *subscriptioncmd.SkipDeliveryHandler,
+	swapHandler *subscriptioncmd.SwapCutHandler,
+	slotHandler *subscriptioncmd.ChangeSlotHandler,
+	paymentMethodHandler *subscriptioncmd.ChangePaymentMethodHandler,
+	pauseHandler *subscriptioncmd.PauseSubscriptionHandler,
+	resumeHandler *subscriptioncmd.ResumeSubscriptionHandler,
+	cancelHandler *subscriptioncmd.CancelSubscriptionHandler,
+	renewalsHandler *subscriptionqry.ListRenewalsHandler,
+) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		boxesHandler:         boxesHandler,
+		subscribeHandler:     subscribeHandler,
+		listHandler:          listHandler,
+		skipHandler:          skipHandler,
+		swapHandler:          swapHandler,
+		slotHandler:          slotHandler,
+		paymentMethodHandler: paymentMethodHandler,
+		pauseHandler:         pauseHandler,
+		resumeHandler:        resumeHandler,
+		cancelHandler:        cancelHandler,
+		renewalsHandler:      renewalsHandler,
+	}
+}
+
+// ListBoxes handles GET /api/v1/subscriptions/boxes.
+//
+//	@Summary		List subscription boxes
+//	@Description	List the boxes open to subscribe to, with their cuts and, for customizable boxes, what each cut can be swapped for.
+//	@Tags			Subscriptions
+//	@Produce		json
+//	@Success		200	{object}	dto.SubscriptionBoxListSuccessResponse	"Boxes"
+//	@Failure		500	{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/subscriptions/boxes [get]
+func (h *SubscriptionHandler) ListBoxes(w http.ResponseWriter, r *http.Request) {
+	boxes, err := h.boxesHandler.Handle(r.Context(), true)
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionBoxResponses(boxes))
+}
+
+// Subscribe handles POST /api/v1/me/subscriptions.
+//
+//	@Summary		Subscribe to a box
+//	@Description	Subscribe to regular deliveries of a box in a fulfilment slot. An order is generated ahead of each delivery's cutoff and charged to the payment method given.
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.SubscribeRequest				true	"Subscription"
+//	@Success		201		{object}	dto.SubscriptionSuccessResponse	"Subscribed"
+//	@Failure		400		{object}	dto.ErrorBody					"Invalid request body"
+//	@Failure		401		{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404		{object}	dto.ErrorBody					"Box or slot not found"
+//	@Failure		409		{object}	dto.ErrorBody					"Box or slot unavailable, or the first delivery's cutoff has passed"
+//	@Failure		422		{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions [post]
+func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req dto.SubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	boxID, err := uuid.Parse(req.BoxID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid box_id")
+		return
+	}
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid template_id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.subscribeHandler.Handle(r.Context(), subscriptioncmd.SubscribeCommand{
+		CustomerID:    claims.SubjectID,
+		BoxID:         boxID,
+		Cadence:       req.Cadence,
+		TemplateID:    templateID,
+		FirstDelivery: req.FirstDelivery,
+		PaymentMethod: req.PaymentMethod,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Created(w, toSubscriptionResponse(s))
+}
+
+// List handles GET /api/v1/me/subscriptions.
+//
+//	@Summary		List my subscriptions
+//	@Description	List the authenticated customer's subscriptions with their upcoming deliveries.
+//	@Tags			Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.SubscriptionListSuccessResponse	"Subscriptions"
+//	@Failure		401	{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		500	{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/subscriptions [get]
+func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	subs, err := h.listHandler.Handle(r.Context(), subscription.Filter{CustomerID: &claims.SubjectID})
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponses(subs))
+}
+
+// Skip handles POST /api/v1/me/subscriptions/{subscriptionID}/skips.
+//
+//	@Summary		Skip a delivery
+//	@Description	Leave out one upcoming delivery. A delivery can be skipped until its cutoff, unless its order has already been generated.
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string							true	"Subscription ID"
+//	@Param			body			body		dto.SkipDeliveryRequest			true	"Delivery to skip"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse	"Delivery skipped"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody					"Subscription not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Cutoff passed, cancelled or changed concurrently"
+//	@Failure		422				{object}	dto.ErrorBody					"No delivery on that date"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/skips [post]
+func (h *SubscriptionHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := uuidParam(r, "subscriptionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+	var req dto.SkipDeliveryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.skipHandler.Handle(r.Context(), subscriptioncmd.SkipDeliveryCommand{
+		SubscriptionID: subscriptionID,
+		CustomerID:     claims.SubjectID,
+		Date:           req.Date,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponse(s))
+}
+
+// Swap handles PUT /api/v1/me/subscriptions/{subscriptionID}/swaps.
+//
+//	@Summary		Swap a cut
+//	@Description	Swap a cut in a customizable box for one of the substitutes it offers, from the next order generated on. Swapping a cut for itself undoes an earlier swap.
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string							true	"Subscription ID"
+//	@Param			body			body		dto.SwapCutRequest				true	"Swap"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse	"Cut swapped"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody					"Subscription not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Cancelled or changed concurrently"
+//	@Failure		422				{object}	dto.ErrorBody					"Swap not allowed"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/swaps [put]
+func (h *SubscriptionHandler) Swap(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := uuidParam(r, "subscriptionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+	var req dto.SwapCutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product_id")
+		return
+	}
+	substituteID, err := uuid.Parse(req.SubstituteID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid substitute_id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.swapHandler.Handle(r.Context(), subscriptioncmd.SwapCutCommand{
+		SubscriptionID: subscriptionID,
+		CustomerID:     claims.SubjectID,
+		ProductID:      productID,
+		SubstituteID:   substituteID,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponse(s))
+}
+
+// ChangeSlot handles PUT /api/v1/me/subscriptions/{subscriptionID}/slot.
+//
+//	@Summary		Change fulfilment slot
+//	@Description	Move deliveries to another fulfilment slot. The next delivery moves to the slot's weekday on or after it, and skipped dates are cleared.
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string								true	"Subscription ID"
+//	@Param			body			body		dto.ChangeSubscriptionSlotRequest	true	"Slot"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse		"Slot changed"
+//	@Failure		400				{object}	dto.ErrorBody						"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody						"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody						"Subscription or slot not found"
+//	@Failure		409				{object}	dto.ErrorBody						"Slot unavailable, cancelled or changed concurrently"
+//	@Failure		500				{object}	dto.ErrorBody						"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/slot [put]
+func (h *SubscriptionHandler) ChangeSlot(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := uuidParam(r, "subscriptionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+	var req dto.ChangeSubscriptionSlotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid template_id")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.slotHandler.Handle(r.Context(), subscriptioncmd.ChangeSlotCommand{
+		SubscriptionID: subscriptionID,
+		CustomerID:     claims.SubjectID,
+		TemplateID:     templateID,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponse(s))
+}
+
+// ChangePaymentMethod handles PUT /api/v1/me/subscriptions/{subscriptionID}/payment-method.
+//
+//	@Summary		Change payment method
+//	@Description	Change the card orders are charged to, including retries of an order whose payment was declined.
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string							true	"Subscription ID"
+//	@Param			body			body		dto.ChangePaymentMethodRequest	true	"Payment method"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse	"Payment method changed"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid request"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody					"Subscription not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Cancelled or changed concurrently"
+//	@Failure		422				{object}	dto.ErrorBody					"Validation error"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/payment-method [put]
+func (h *SubscriptionHandler) ChangePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := uuidParam(r, "subscriptionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+	var req dto.ChangePaymentMethodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := h.paymentMethodHandler.Handle(r.Context(), subscriptioncmd.ChangePaymentMethodCommand{
+		SubscriptionID: subscriptionID,
+		CustomerID:     claims.SubjectID,
+		PaymentMethod:  req.PaymentMethod,
+	})
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponse(s))
+}
+
+// Pause handles POST /api/v1/me/subscriptions/{subscriptionID}/pause.
+//
+//	@Summary		Pause a subscription
+//	@Description	Stop orders being generated until the subscription is resumed. Orders already generated still go ahead.
+//	@Tags			Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string							true	"Subscription ID"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse	"Subscription paused"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid subscription ID"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody					"Subscription not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Not active or changed concurrently"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/pause [post]
+func (h *SubscriptionHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.pauseHandler.Handle)
+}
+
+// Resume handles POST /api/v1/me/subscriptions/{subscriptionID}/resume.
+//
+//	@Summary		Resume a subscription
+//	@Description	Restart a paused subscription, including one paused after its payments kept failing. Deliveries whose cutoff passed while it was paused are not made up.
+//	@Tags			Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string							true	"Subscription ID"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse	"Subscription resumed"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid subscription ID"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody					"Subscription not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Not paused or changed concurrently"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/resume [post]
+func (h *SubscriptionHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.resumeHandler.Handle)
+}
+
+// Cancel handles POST /api/v1/me/subscriptions/{subscriptionID}/cancel.
+//
+//	@Summary		Cancel a subscription
+//	@Description	End the subscription. Orders already generated still go ahead.
+//	@Tags			Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string							true	"Subscription ID"
+//	@Success		200				{object}	dto.SubscriptionSuccessResponse	"Subscription cancelled"
+//	@Failure		400				{object}	dto.ErrorBody					"Invalid subscription ID"
+//	@Failure		401				{object}	dto.ErrorBody					"Unauthorized"
+//	@Failure		404				{object}	dto.ErrorBody					"Subscription not found"
+//	@Failure		409				{object}	dto.ErrorBody					"Already cancelled or changed concurrently"
+//	@Failure		500				{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/cancel [post]
+func (h *SubscriptionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.cancelHandler.Handle)
+}
+
+// ListRenewals handles GET /api/v1/me/subscriptions/{subscriptionID}/renewals.
+//
+//	@Summary		List a subscription's orders
+//	@Description	List the orders generated for a subscription's deliveries, with whether payment went through and when a declined payment will be retried.
+//	@Tags			Subscriptions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscriptionID	path		string									true	"Subscription ID"
+//	@Success		200				{object}	dto.SubscriptionRenewalListSuccessResponse	"Renewals"
+//	@Failure		400				{object}	dto.ErrorBody							"Invalid subscription ID"
+//	@Failure		401				{object}	dto.ErrorBody							"Unauthorized"
+//	@Failure		500				{object}	dto.ErrorBody							"Internal server error"
+//	@Router			/me/subscriptions/{subscriptionID}/renewals [get]
+func (h *SubscriptionHandler) ListRenewals(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := uuidParam(r, "subscriptionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	renewals, err := h.renewalsHandler.Handle(r.Context(), subscription.RenewalFilter{
+		SubscriptionID: &subscriptionID,
+		CustomerID:     &claims.SubjectID,
+	})
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionRenewalResponses(renewals))
+}
+
+func (h *SubscriptionHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	handle func(ctx context.Context, subscriptionID, customerID uuid.UUID) (*subscription.Subscription, error),
+) {
+	subscriptionID, ok := uuidParam(r, "subscriptionID")
+	if !ok {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	claims := middleware.ClaimsFromContext(r.Context())
+	s, err := handle(r.Context(), subscriptionID, claims.SubjectID)
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	httpresponse.Success(w, toSubscriptionResponse(s))
+}
+
+func toSubscriptionCutResponses(cuts []subscription.Cut) []dto.SubscriptionCutResponse {
+	resp := make([]dto.SubscriptionCutResponse, 0, len(cuts))
+	for _, c := range cuts {
+		resp = append(resp, toSubscriptionCutResponse(c))
+	}
+	return resp
+}
+
+func toSubscriptionCutResponse(c subscription.Cut) dto.SubscriptionCutResponse {
+	return dto.SubscriptionCutResponse{
+		ProductID:       c.ProductID.String(),
+		Description:     c.Description,
+		Grams:           c.Grams,
+		PricePerKgCents: c.PricePerKgCents,
+	}
+}
+
+func toSubscriptionBoxResponses(boxes []*subscription.Box) []dto.SubscriptionBoxResponse {
+	resp := make([]dto.SubscriptionBoxResponse, 0, len(boxes))
+	for _, b := range boxes {
+		resp = append(resp, toSubscriptionBoxResponse(b))
+	}
+	return resp
+}
+
+func toSubscriptionBoxResponse(b *subscription.Box) dto.SubscriptionBoxResponse {
+	items := make([]dto.SubscriptionItemResponse, 0, len(b.Items()))
+	for _, it := range b.Items() {
+		items = append(items, dto.SubscriptionItemResponse{
+			SubscriptionCutResponse: toSubscriptionCutResponse(it.Cut),
+			Substitutes:             toSubscriptionCutResponses(it.Substitutes),
+		})
+	}
+	return dto.SubscriptionBoxResponse{
+		ID:           b.ID().String(),
+		Name:         b.Name(),
+		Customizable: b.Customizable(),
+		Items:        items,
+		Active:       b.Active(),
+		CreatedAt:    b.CreatedAt(),
+		UpdatedAt:    b.UpdatedAt(),
+	}
+}
+
+func toSubscriptionResponses(subs []*subscription.Subscription) []dto.SubscriptionResponse {
+	resp := make([]dto.SubscriptionResponse, 0, len(subs))
+	for _, s := range subs {
+		resp = append(resp, toSubscriptionResponse(s))
+	}
+	return resp
+}
+
+func toSubscriptionResponse(s *subscription.Subscription) dto.SubscriptionResponse {
+	resp := dto.SubscriptionResponse{
+		ID:             s.ID().String(),
+		CustomerID:     s.CustomerID().String(),
+		BoxID:          s.BoxID().String(),
+		Cadence:        string(s.Cadence()),
+		TemplateID:     s.TemplateID().String(),
+		PaymentMethod:  s.PaymentMethod(),
+		Status:         string(s.Status()),
+		NextDelivery:   s.NextDelivery().Format(time.DateOnly),
+		Upcoming:       []string{},
+		Skips:          make([]string, 0, len(s.Skips())),
+		Swaps:          make([]dto.SubscriptionSwapResponse, 0, len(s.Swaps())),
+		FailedRenewals: s.FailedRenewals(),
+		CreatedAt:      s.CreatedAt(),
+		UpdatedAt:      s.UpdatedAt(),
+	}
+	if s.Status() == subscription.StatusActive {
+		for _, d := range s.Upcoming(upcomingDeliveries) {
+			resp.Upcoming = append(resp.Upcoming, d.Format(time.DateOnly))
+		}
+	}
+	for _, d := range s.Skips() {
+		resp.Skips = append(resp.Skips, d.Format(time.DateOnly))
+	}
+	for _, sw := range s.Swaps() {
+		resp.Swaps = append(resp.Swaps, dto.SubscriptionSwapResponse{
+			ProductID:    sw.ProductID.String(),
+			SubstituteID: sw.SubstituteID.String(),
+		})
+	}
+	return resp
+}
+
+func toSubscriptionRenewalResponses(renewals []*subscription.Renewal) []dto.SubscriptionRenewalResponse {
+	resp := make([]dto.SubscriptionRenewalResponse, 0, len(renewals))
+	for _, rn := range renewals {
+		resp = append(resp, toSubscriptionRenewalResponse(rn))
+	}
+	return resp
+}
+
+func toSubscriptionRenewalResponse(rn *subscription.Renewal) dto.SubscriptionRenewalResponse {
+	resp := dto.SubscriptionRenewalResponse{
+		ID:             rn.ID().String(),
+		SubscriptionID: rn.SubscriptionID().String(),
+		CustomerID:     rn.CustomerID().String(),
+		BranchID:       rn.BranchID().String(),
+		DeliveryDate:   rn.DeliveryDate().Format(time.DateOnly),
+		SlotStartsAt:   rn.SlotStartsAt(),
+		CutoffAt:       rn.CutoffAt(),
+		OrderID:        rn.OrderID().String(),
+		Cuts:           toSubscriptionCutResponses(rn.Cuts()),
+		TotalCents:     rn.TotalCents(),
+		Status:         string(rn.Status()),
+		Attempts:       rn.Attempts(),
+		NextAttemptAt:  rn.NextAttemptAt(),
+		FailureReason:  rn.FailureReason(),
+		CreatedAt:      rn.CreatedAt(),
+		UpdatedAt:      rn.UpdatedAt(),
+	}
+	if rn.PaymentID() != nil {
+		s := rn.PaymentID().String()
+		resp.PaymentID = &s
+	}
+	return resp
+}
+
+func writeSubscriptionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, subscription.ErrBoxNotFound),
+		errors.Is(err, subscription.ErrSubscriptionNotFound),
+		errors.Is(err, subscription.ErrRenewalNotFound),
+		errors.Is(err, fulfilment.ErrSlotTemplateNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, subscription.ErrBoxUnavailable),
+		errors.Is(err, subscription.ErrPastCutoff),
+		errors.Is(err, subscription.ErrNotActive),
+		errors.Is(err, subscription.ErrNotPaused),
+		errors.Is(err, subscription.ErrCancelled),
+		errors.Is(err, subscription.ErrConcurrentUpdate),
+		errors.Is(err, fulfilment.ErrSlotUnavailable):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, subscription.ErrInvalidCadence),
+		errors.Is(err, subscription.ErrEmptyBoxName),
+		errors.Is(err, subscription.ErrEmptyBox),
+		errors.Is(err, subscription.ErrInvalidWeight),
+		errors.Is(err, subscription.ErrNegativePrice),
+		errors.Is(err, subscription.ErrDuplicateCut),
+		errors.Is(err, subscription.ErrFixedBoxSubstitutes),
+		errors.Is(err, subscription.ErrInvalidSubstitute),
+		errors.Is(err, subscription.ErrEmptyPaymentMethod),
+		errors.Is(err, subscription.ErrWrongWeekday),
+		errors.Is(err, subscription.ErrNotScheduled),
+		errors.Is(err, subscription.ErrNotCustomizable),
+		errors.Is(err, subscription.ErrUnknownCut),
+		errors.Is(err, subscription.ErrSubstituteNotAllowed),
+		errors.Is(err, subscription.ErrSlotTemplateRequired),
+		errors.Is(err, fulfilment.ErrInvalidDate):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
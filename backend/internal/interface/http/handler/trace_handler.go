@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	traceqry "github.com/katerji/butchery-app/backend/internal/application/traceability/queries"
+	"github.com/katerji/butchery-app/backend/internal/domain/inventory"
+	"github.com/katerji/butchery-app/backend/internal/domain/traceability"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/dto"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// TraceHandler handles the public lot lookup behind the QR code on labels.
+type TraceHandler struct {
+	lotTraceHandler *traceqry.LotTraceHandler
+}
+
+// NewTraceHandler creates a new TraceHandler.
+func NewTraceHandler(lotTraceHandler *traceqry.LotTraceHandler) *TraceHandler {
+	return &TraceHandler{lotTraceHandler: lotTraceHandler}
+}
+
+// Lookup handles GET /api/v1/trace/{lotCode}.
+//
+//	@Summary		Trace a lot
+//	@Description	Look up where the meat with a given lot code came from: the farm, the slaughter date, the slaughterhouse and the halal certificate it was slaughtered under. Several lots can share a code, e.g. all the cuts from one carcass.
+//	@Tags			Traceability
+//	@Produce		json
+//	@Param			lotCode	path		string							true	"Lot code"
+//	@Success		200		{object}	dto.LotTracesSuccessResponse	"Lot traces"
+//	@Failure		404		{object}	dto.ErrorBody					"No traceable lot with this code"
+//	@Failure		500		{object}	dto.ErrorBody					"Internal server error"
+//	@Router			/trace/{lotCode} [get]
+func (h *TraceHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	traces, err := h.lotTraceHandler.Handle(r.Context(), chi.URLParam(r, "lotCode"))
+	if err != nil {
+		writeTraceabilityError(w, err)
+		return
+	}
+
+	resp := make([]dto.LotTraceResponse, 0, len(traces))
+	for _, t := range traces {
+		var expiresOn *string
+		if t.ExpiresOn != nil {
+			s := t.ExpiresOn.Format(time.DateOnly)
+			expiresOn = &s
+		}
+		trace := dto.LotTraceResponse{
+			LotCode:           t.LotCode,
+			ProductID:         t.ProductID.String(),
+			ReceivedAt:        t.ReceivedAt,
+			ExpiresOn:         expiresOn,
+			Species:           t.Species,
+			Farm:              t.Origin.Farm(),
+			SlaughteredOn:     t.Origin.SlaughteredOn().Format(time.DateOnly),
+			CertificateNumber: t.Origin.CertificateNumber(),
+			HalalVerified:     t.HalalVerified(),
+		}
+		if c := t.Certificate; c != nil {
+			trace.Certificate = &dto.TraceCertificateResponse{
+				Issuer:         c.Issuer(),
+				Number:         c.Number(),
+				Slaughterhouse: c.Slaughterhouse(),
+				ValidFrom:      c.ValidFrom().Format(time.DateOnly),
+				ValidUntil:     c.ValidUntil().Format(time.DateOnly),
+				DocumentURL:    optionalString(c.DocumentURL()),
+			}
+		}
+		resp = append(resp, trace)
+	}
+	httpresponse.Success(w, resp)
+}
+
+func writeTraceabilityError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, traceability.ErrTraceNotFound):
+		httpresponse.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, inventory.ErrLotNotFound):
+		httpresponse.Error(w, http.StatusNotFound, "lot not found")
+	case errors.Is(err, traceability.ErrDuplicateCertificate),
+		errors.Is(err, traceability.ErrOriginAlreadyRecorded):
+		httpresponse.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, traceability.ErrEmptyIssuer),
+		errors.Is(err, traceability.ErrEmptyCertificateNumber),
+		errors.Is(err, traceability.ErrEmptySlaughterhouse),
+		errors.Is(err, traceability.ErrInvalidValidity),
+		errors.Is(err, traceability.ErrInvalidDocumentURL),
+		errors.Is(err, traceability.ErrEmptyFarm),
+		errors.Is(err, traceability.ErrSlaughterDateInFuture),
+		errors.Is(err, traceability.ErrInvalidDate):
+		httpresponse.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+// optionalString returns nil for an empty string so it is omitted from responses.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
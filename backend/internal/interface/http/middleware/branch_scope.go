@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// BranchScopeMiddleware limits admins to the data of the branches they are
+// assigned to. Repositories read the scope from the request context.
+type BranchScopeMiddleware struct {
+	admins admin.Repository
+}
+
+// NewBranchScopeMiddleware creates a new BranchScopeMiddleware.
+func NewBranchScopeMiddleware(admins admin.Repository) *BranchScopeMiddleware {
+	return &BranchScopeMiddleware{admins: admins}
+}
+
+// ScopeToAdminBranches looks up the admin's branches and scopes the request
+// to them. It must run after RequireAdmin.
+func (m *BranchScopeMiddleware) ScopeToAdminBranches(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := ClaimsFromContext(r.Context())
+		if claims == nil {
+			httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		a, err := m.admins.FindByID(r.Context(), claims.SubjectID)
+		if err != nil {
+			if errors.Is(err, admin.ErrAdminNotFound) {
+				httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		scope := branch.AllBranches()
+		if !a.AllBranches() {
+			scope = branch.Only(a.BranchIDs()...)
+		}
+		next.ServeHTTP(w, r.WithContext(branch.WithScope(r.Context(), scope)))
+	})
+}
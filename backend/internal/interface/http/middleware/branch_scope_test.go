@@ -0,0 +1,120 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/auth"
+	"github.com/katerji/butchery-app/backend/internal/domain/branch"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+// scopedRequest runs an admin's request through the middleware and the
+// auth middleware before it, returning the status and the scope seen.
+func scopedRequest(t *testing.T, repo *mockAdminRepository, adminID uuid.UUID) (int, *branch.Scope) {
+	t.Helper()
+	validator := new(mockTokenValidator)
+	validator.On("ValidateAccessToken", "admin-token").
+		Return(&auth.AccessTokenClaims{SubjectID: adminID, SubjectType: auth.SubjectTypeAdmin}, nil)
+	authMW := middleware.NewAuthMiddleware(validator)
+	scoped := middleware.NewBranchScopeMiddleware(repo)
+
+	var seen *branch.Scope
+	handler := authMW.RequireAdmin(scoped.ScopeToAdminBranches(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := branch.ScopeFrom(r.Context())
+		seen = &s
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr.Code, seen
+}
+
+func TestScopeToAdminBranches_AssignedAdmin_ScopesToTheirBranches(t *testing.T) {
+	repo := new(mockAdminRepository)
+	a, err := admin.NewAdmin(uuid.New(), "e1@butchery.com", "$2a$10$hash", "East Cashier", admin.RoleCashier)
+	require.NoError(t, err)
+	east, west := uuid.New(), uuid.New()
+	a.AssignBranches(false, []uuid.UUID{east})
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+
+	code, scope := scopedRequest(t, repo, a.ID())
+
+	assert.Equal(t, http.StatusOK, code)
+	require.NotNil(t, scope)
+	assert.True(t, scope.Allows(east))
+	assert.False(t, scope.Allows(west))
+}
+
+func TestScopeToAdminBranches_AdminOfEveryBranch_IsNotLimited(t *testing.T) {
+	repo := new(mockAdminRepository)
+	a, err := admin.NewAdmin(uuid.New(), "admin@butchery.com", "$2a$10$hash", "Manager", admin.RoleManager)
+	require.NoError(t, err)
+	a.AssignBranches(true, nil)
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+
+	code, scope := scopedRequest(t, repo, a.ID())
+
+	assert.Equal(t, http.StatusOK, code)
+	require.NotNil(t, scope)
+	assert.True(t, scope.All())
+}
+
+func TestScopeToAdminBranches_UnknownAdmin_Returns401(t *testing.T) {
+	repo := new(mockAdminRepository)
+	id := uuid.New()
+	repo.On("FindByID", mock.Anything, id).Return(nil, admin.ErrAdminNotFound)
+
+	code, scope := scopedRequest(t, repo, id)
+
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Nil(t, scope)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/pkg/httpresponse"
+)
+
+// RoleMiddleware limits a group of routes to admins with certain roles, so
+// that drivers, say, only reach the routes meant for them.
+type RoleMiddleware struct {
+	admins admin.Repository
+}
+
+// NewRoleMiddleware creates a new RoleMiddleware.
+func NewRoleMiddleware(admins admin.Repository) *RoleMiddleware {
+	return &RoleMiddleware{admins: admins}
+}
+
+// RequireRole looks up the admin and refuses the request unless they have
+// one of roles. It must run after RequireAdmin.
+func (m *RoleMiddleware) RequireRole(roles ...admin.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := ClaimsFromContext(r.Context())
+			if claims == nil {
+				httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+
+			a, err := m.admins.FindByID(r.Context(), claims.SubjectID)
+			if err != nil {
+				if errors.Is(err, admin.ErrAdminNotFound) {
+					httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+					return
+				}
+				httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+				return
+			}
+
+			if !slices.Contains(roles, a.Role()) {
+				httpresponse.Error(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
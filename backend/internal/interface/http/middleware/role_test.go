@@ -0,0 +1,68 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/auth"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// roleRequest runs an admin's request through RequireRole and the auth
+// middleware before it, returning the status.
+func roleRequest(t *testing.T, role admin.Role, allowed ...admin.Role) int {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "d1@butchery.com", "$2a$10$hash", "Some Admin", role)
+	require.NoError(t, err)
+	repo := new(mockAdminRepository)
+	repo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	validator := new(mockTokenValidator)
+	validator.On("ValidateAccessToken", "admin-token").
+		Return(&auth.AccessTokenClaims{SubjectID: a.ID(), SubjectType: auth.SubjectTypeAdmin}, nil)
+	authMW := middleware.NewAuthMiddleware(validator)
+	roles := middleware.NewRoleMiddleware(repo)
+
+	handler := authMW.RequireAdmin(roles.RequireRole(allowed...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func TestRequireRole_AllowedRole_PassesThrough(t *testing.T) {
+	assert.Equal(t, http.StatusOK, roleRequest(t, admin.RoleManager, admin.RoleStaff, admin.RoleManager))
+}
+
+func TestRequireRole_OtherRole_Forbidden(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, roleRequest(t, admin.RoleDriver, admin.RoleStaff, admin.RoleManager))
+}
+
+func TestRequireRole_UnknownAdmin_Unauthorized(t *testing.T) {
+	repo := new(mockAdminRepository)
+	adminID := uuid.New()
+	repo.On("FindByID", mock.Anything, adminID).Return(nil, admin.ErrAdminNotFound)
+	validator := new(mockTokenValidator)
+	validator.On("ValidateAccessToken", "admin-token").
+		Return(&auth.AccessTokenClaims{SubjectID: adminID, SubjectType: auth.SubjectTypeAdmin}, nil)
+	handler := middleware.NewAuthMiddleware(validator).RequireAdmin(
+		middleware.NewRoleMiddleware(repo).RequireRole(admin.RoleDriver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-chi/cors"
 	httpSwagger "github.com/swaggo/http-swagger"
 
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
 	"github.com/katerji/butchery-app/backend/internal/interface/http/handler"
 	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
 )
@@ -18,9 +19,53 @@ import (
 type RouterDeps struct {
 	Logger              *slog.Logger
 	AuthMiddleware      *middleware.AuthMiddleware
+	BranchScope         *middleware.BranchScopeMiddleware
+	Roles               *middleware.RoleMiddleware
 	AdminAuthHandler    *handler.AdminAuthHandler
 	CustomerAuthHandler *handler.CustomerAuthHandler
 	AuthHandler         *handler.AuthHandler
+	FulfilmentHandler   *handler.FulfilmentHandler
+	AdminFulfilment     *handler.AdminFulfilmentHandler
+	AddressHandler      *handler.AddressHandler
+	DeliveryHandler     *handler.DeliveryHandler
+	AdminDelivery       *handler.AdminDeliveryHandler
+	InventoryHandler    *handler.InventoryHandler
+	AdminInventory      *handler.AdminInventoryHandler
+	AdminWaste          *handler.AdminWasteHandler
+	AdminCarcass        *handler.AdminCarcassHandler
+	TraceHandler        *handler.TraceHandler
+	AdminTraceability   *handler.AdminTraceabilityHandler
+	AdminSupplier       *handler.AdminSupplierHandler
+	AdminPurchaseOrder  *handler.AdminPurchaseOrderHandler
+	PaymentHandler      *handler.PaymentHandler
+	AdminPayment        *handler.AdminPaymentHandler
+	OrderHandler        *handler.OrderHandler
+	AdminOrder          *handler.AdminOrderHandler
+	InvoiceHandler      *handler.InvoiceHandler
+	AdminInvoice        *handler.AdminInvoiceHandler
+	CartHandler         *handler.CartHandler
+	AdminTax            *handler.AdminTaxHandler
+	AdminPromotion      *handler.AdminPromotionHandler
+	LoyaltyHandler      *handler.LoyaltyHandler
+	AdminLoyalty        *handler.AdminLoyaltyHandler
+	QurbaniHandler      *handler.QurbaniHandler
+	AdminQurbani        *handler.AdminQurbaniHandler
+	SubscriptionHandler *handler.SubscriptionHandler
+	AdminSubscription   *handler.AdminSubscriptionHandler
+	BusinessHandler     *handler.BusinessHandler
+	AdminBusiness       *handler.AdminBusinessHandler
+	AdminBarcode        *handler.AdminBarcodeHandler
+	AdminLabel          *handler.AdminLabelHandler
+	AdminScale          *handler.AdminScaleHandler
+	AdminPOS            *handler.AdminPOSHandler
+	HACCPHandler        *handler.HACCPHandler
+	AdminHACCP          *handler.AdminHACCPHandler
+	AdminStaff          *handler.AdminStaffHandler
+	DispatchHandler     *handler.DispatchHandler
+	AdminDispatch       *handler.AdminDispatchHandler
+	Driver              *handler.DriverHandler
+	BranchHandler       *handler.BranchHandler
+	AdminBranch         *handler.AdminBranchHandler
 }
 
 // NewRouter creates a new chi router with all routes and middleware.
@@ -30,12 +75,12 @@ func NewRouter(deps RouterDeps) *chi.Mux {
 	r.Use(chimw.RequestID)
 	r.Use(chimw.RealIP)
 	r.Use(chimw.Recoverer)
-	r.Use(chimw.Timeout(30 * time.Second))
+	r.Use(requestTimeout(30 * time.Second))
 	r.Use(requestLogger(deps.Logger))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Idempotency-Key", "Last-Event-ID"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -61,16 +106,308 @@ func NewRouter(deps RouterDeps) *chi.Mux {
 		// Public admin auth routes
 		r.Post("/admin/auth/login", deps.AdminAuthHandler.Login)
 
+		// Public branch details
+		r.Get("/branches", deps.BranchHandler.List)
+		r.Get("/branches/{branchID}", deps.BranchHandler.Get)
+
+		// Public fulfilment slot availability
+		r.Get("/fulfilment/slots", deps.FulfilmentHandler.ListSlots)
+
+		// Public lot traceability lookup
+		r.Get("/trace/{lotCode}", deps.TraceHandler.Lookup)
+
+		// Public cart pricing
+		r.Post("/cart/quote", deps.CartHandler.Quote)
+
+		// Public Qurbani offerings
+		r.Get("/qurbani/offerings", deps.QurbaniHandler.ListOfferings)
+
+		// Public subscription boxes
+		r.Get("/subscriptions/boxes", deps.SubscriptionHandler.ListBoxes)
+
+		// Public payment provider webhook, authenticated by its signature
+		r.Post("/payments/webhook", deps.PaymentHandler.Webhook)
+
+		// Public temperature sensor readings, authenticated by the sensor key
+		r.Post("/haccp/readings", deps.HACCPHandler.PushReadings)
+
 		// Authenticated routes
 		r.Group(func(r chi.Router) {
 			r.Use(deps.AuthMiddleware.RequireAuth)
 			r.Post("/auth/logout", deps.AuthHandler.Logout)
 		})
+
+		// Customer routes
+		r.Group(func(r chi.Router) {
+			r.Use(deps.AuthMiddleware.RequireCustomer)
+			r.Post("/fulfilment/reservations", deps.FulfilmentHandler.Reserve)
+			r.Delete("/fulfilment/reservations/{reservationID}", deps.FulfilmentHandler.Release)
+			r.Get("/me/addresses", deps.AddressHandler.List)
+			r.Post("/me/addresses", deps.AddressHandler.Create)
+			r.Put("/me/addresses/{addressID}", deps.AddressHandler.Update)
+			r.Delete("/me/addresses/{addressID}", deps.AddressHandler.Delete)
+			r.Put("/me/addresses/{addressID}/default", deps.AddressHandler.SetDefault)
+			r.Get("/me/invoices", deps.InvoiceHandler.List)
+			r.Get("/me/invoices/{invoiceID}/pdf", deps.InvoiceHandler.Download)
+			r.Get("/me/loyalty", deps.LoyaltyHandler.GetAccount)
+			r.Put("/me/loyalty/birthday", deps.LoyaltyHandler.SetBirthday)
+			r.Get("/me/qurbani/bookings", deps.QurbaniHandler.ListBookings)
+			r.Post("/me/qurbani/bookings", deps.QurbaniHandler.Book)
+			r.Post("/me/qurbani/bookings/{bookingID}/cancel", deps.QurbaniHandler.Cancel)
+			r.Get("/me/subscriptions", deps.SubscriptionHandler.List)
+			r.Post("/me/subscriptions", deps.SubscriptionHandler.Subscribe)
+			r.Post("/me/subscriptions/{subscriptionID}/skips", deps.SubscriptionHandler.Skip)
+			r.Put("/me/subscriptions/{subscriptionID}/swaps", deps.SubscriptionHandler.Swap)
+			r.Put("/me/subscriptions/{subscriptionID}/slot", deps.SubscriptionHandler.ChangeSlot)
+			r.Put("/me/subscriptions/{subscriptionID}/payment-method", deps.SubscriptionHandler.ChangePaymentMethod)
+			r.Post("/me/subscriptions/{subscriptionID}/pause", deps.SubscriptionHandler.Pause)
+			r.Post("/me/subscriptions/{subscriptionID}/resume", deps.SubscriptionHandler.Resume)
+			r.Post("/me/subscriptions/{subscriptionID}/cancel", deps.SubscriptionHandler.Cancel)
+			r.Get("/me/subscriptions/{subscriptionID}/renewals", deps.SubscriptionHandler.ListRenewals)
+			r.Get("/me/business", deps.BusinessHandler.GetMembership)
+			r.Get("/me/business/orders", deps.BusinessHandler.ListOrders)
+			r.Post("/me/business/orders", deps.BusinessHandler.PlaceOrder)
+			r.Post("/me/business/orders/{orderID}/approve", deps.BusinessHandler.ApproveOrder)
+			r.Post("/me/business/orders/{orderID}/reject", deps.BusinessHandler.RejectOrder)
+			r.Get("/me/business/statement", deps.BusinessHandler.Statement)
+			r.Post("/me/orders", deps.OrderHandler.PlaceOrder)
+			r.Get("/me/orders/stream", deps.OrderHandler.StreamOrders)
+			r.Post("/me/orders/{orderID}/cancel", deps.OrderHandler.CancelOrder)
+			r.Get("/me/orders/{orderID}/delivery", deps.DispatchHandler.OrderDelivery)
+			r.Get("/delivery/quote", deps.DeliveryHandler.Quote)
+			r.Post("/inventory/reservations", deps.InventoryHandler.Reserve)
+			r.Delete("/inventory/reservations/{reservationID}", deps.InventoryHandler.Release)
+			r.Post("/payments", deps.PaymentHandler.Authorize)
+		})
+
+		// Admin routes, for shop staff and managers
+		r.Group(func(r chi.Router) {
+			r.Use(deps.AuthMiddleware.RequireAdmin)
+			r.Use(deps.Roles.RequireRole(admin.RoleStaff, admin.RoleManager))
+			r.Use(deps.BranchScope.ScopeToAdminBranches)
+			r.Get("/admin/branches", deps.AdminBranch.ListBranches)
+			r.Post("/admin/branches", deps.AdminBranch.CreateBranch)
+			r.Get("/admin/branches/{branchID}", deps.AdminBranch.GetBranch)
+			r.Put("/admin/branches/{branchID}", deps.AdminBranch.UpdateBranch)
+			r.Get("/admin/branches/{branchID}/prices", deps.AdminBranch.ListPrices)
+			r.Put("/admin/branches/{branchID}/prices/{productID}", deps.AdminBranch.SetPrice)
+			r.Delete("/admin/branches/{branchID}/prices/{productID}", deps.AdminBranch.DeletePrice)
+			r.Get("/admin/admins", deps.AdminBranch.ListAdmins)
+			r.Put("/admin/admins/{adminID}/branches", deps.AdminBranch.AssignBranches)
+			r.Put("/admin/branches/{branchID}/opening-hours", deps.AdminFulfilment.SetOpeningHours)
+			r.Post("/admin/branches/{branchID}/closures", deps.AdminFulfilment.AddClosure)
+			r.Post("/admin/fulfilment/slot-templates", deps.AdminFulfilment.CreateSlotTemplate)
+			r.Get("/admin/delivery-zones", deps.AdminDelivery.ListZones)
+			r.Post("/admin/delivery-zones", deps.AdminDelivery.CreateZone)
+			r.Put("/admin/delivery-zones/{zoneID}", deps.AdminDelivery.UpdateZone)
+			r.Post("/admin/inventory/lots", deps.AdminInventory.ReceiveLot)
+			r.Post("/admin/inventory/adjustments", deps.AdminInventory.RecordAdjustment)
+			r.Post("/admin/inventory/stock-takes", deps.AdminInventory.StockTake)
+			r.Get("/admin/inventory/stock", deps.AdminInventory.ListStock)
+			r.Get("/admin/inventory/movements", deps.AdminInventory.ListMovements)
+			r.Post("/admin/inventory/reservations/{reservationID}/fulfil", deps.AdminInventory.FulfilReservation)
+			r.Put("/admin/products/{productID}/low-stock-threshold", deps.AdminInventory.SetLowStockThreshold)
+			r.Post("/admin/inventory/waste", deps.AdminWaste.RecordWaste)
+			r.Put("/admin/inventory/lots/{lotID}/use-by", deps.AdminWaste.SetUseBy)
+			r.Post("/admin/inventory/expiry/scan", deps.AdminWaste.ScanExpiry)
+			r.Get("/admin/inventory/expiry/proposals", deps.AdminWaste.ListProposals)
+			r.Post("/admin/inventory/expiry/proposals/{proposalID}/accept", deps.AdminWaste.AcceptProposal)
+			r.Post("/admin/inventory/expiry/proposals/{proposalID}/dismiss", deps.AdminWaste.DismissProposal)
+			r.Get("/admin/inventory/shrinkage", deps.AdminWaste.Shrinkage)
+			r.Post("/admin/carcasses", deps.AdminCarcass.RecordIntake)
+			r.Get("/admin/carcasses", deps.AdminCarcass.ListCarcasses)
+			r.Post("/admin/carcasses/{carcassID}/breakdown", deps.AdminCarcass.RecordBreakdown)
+			r.Get("/admin/carcasses/{carcassID}/yield", deps.AdminCarcass.YieldReport)
+			r.Get("/admin/yield-templates", deps.AdminCarcass.ListYieldTemplates)
+			r.Put("/admin/yield-templates/{species}", deps.AdminCarcass.SetYieldTemplate)
+			r.Post("/admin/halal-certificates", deps.AdminTraceability.RegisterCertificate)
+			r.Get("/admin/halal-certificates", deps.AdminTraceability.ListCertificates)
+			r.Post("/admin/inventory/lots/{lotID}/origin", deps.AdminTraceability.RecordOrigin)
+			r.Get("/admin/orders/{orderID}/lots", deps.AdminTraceability.ListOrderLots)
+			r.Post("/admin/suppliers", deps.AdminSupplier.CreateSupplier)
+			r.Get("/admin/suppliers", deps.AdminSupplier.ListSuppliers)
+			r.Put("/admin/suppliers/{supplierID}", deps.AdminSupplier.UpdateSupplier)
+			r.Get("/admin/suppliers/{supplierID}/prices", deps.AdminSupplier.PriceHistory)
+			r.Post("/admin/purchase-orders", deps.AdminPurchaseOrder.CreatePurchaseOrder)
+			r.Get("/admin/purchase-orders", deps.AdminPurchaseOrder.ListPurchaseOrders)
+			r.Get("/admin/purchase-orders/{purchaseOrderID}", deps.AdminPurchaseOrder.GetPurchaseOrder)
+			r.Put("/admin/purchase-orders/{purchaseOrderID}", deps.AdminPurchaseOrder.RevisePurchaseOrder)
+			r.Post("/admin/purchase-orders/{purchaseOrderID}/send", deps.AdminPurchaseOrder.SendPurchaseOrder)
+			r.Post("/admin/purchase-orders/{purchaseOrderID}/close", deps.AdminPurchaseOrder.ClosePurchaseOrder)
+			r.Post("/admin/purchase-orders/{purchaseOrderID}/receipts", deps.AdminPurchaseOrder.ReceiveGoods)
+			r.Get("/admin/purchase-orders/{purchaseOrderID}/receipts", deps.AdminPurchaseOrder.ListReceipts)
+			r.Get("/admin/procurement/variances", deps.AdminPurchaseOrder.VarianceReport)
+			r.Get("/admin/payments/{paymentID}", deps.AdminPayment.GetPayment)
+			r.Post("/admin/payments/{paymentID}/capture", deps.AdminPayment.CapturePayment)
+			r.Post("/admin/payments/{paymentID}/void", deps.AdminPayment.VoidPayment)
+			r.Post("/admin/orders", deps.AdminOrder.CreateOrder)
+			r.Get("/admin/orders/stream", deps.AdminOrder.StreamOrders)
+			r.Get("/admin/orders/{orderID}", deps.AdminOrder.GetOrder)
+			r.Put("/admin/orders/{orderID}/stage", deps.AdminOrder.AdvanceStage)
+			r.Post("/admin/orders/{orderID}/cancel", deps.AdminOrder.CancelOrder)
+			r.Post("/admin/orders/{orderID}/refunds", deps.AdminOrder.RequestRefund)
+			r.Post("/admin/orders/{orderID}/refunds/{refundID}/approve", deps.AdminOrder.ApproveRefund)
+			r.Post("/admin/orders/{orderID}/refunds/{refundID}/reject", deps.AdminOrder.RejectRefund)
+			r.Get("/admin/orders/{orderID}/refunds/{refundID}/receipt", deps.AdminOrder.RefundReceipt)
+			r.Get("/admin/orders/{orderID}/audit", deps.AdminOrder.Audit)
+			r.Post("/admin/orders/{orderID}/invoice", deps.AdminInvoice.IssueInvoice)
+			r.Post("/admin/orders/{orderID}/refunds/{refundID}/credit-note", deps.AdminInvoice.IssueCreditNote)
+			r.Get("/admin/orders/{orderID}/invoices", deps.AdminInvoice.ListOrderInvoices)
+			r.Get("/admin/invoices/{invoiceID}/pdf", deps.AdminInvoice.DownloadInvoice)
+			r.Get("/admin/tax/rates", deps.AdminTax.ListRates)
+			r.Post("/admin/tax/rates", deps.AdminTax.CreateRate)
+			r.Put("/admin/tax/products/{productID}", deps.AdminTax.SetProductCategory)
+			r.Get("/admin/promotions", deps.AdminPromotion.ListPromotions)
+			r.Post("/admin/promotions", deps.AdminPromotion.CreatePromotion)
+			r.Put("/admin/promotions/{promotionID}", deps.AdminPromotion.UpdatePromotion)
+			r.Put("/admin/promotions/products/{productID}", deps.AdminPromotion.ClassifyProduct)
+			r.Get("/admin/loyalty/rules", deps.AdminLoyalty.GetRules)
+			r.Put("/admin/loyalty/rules", deps.AdminLoyalty.UpdateRules)
+			r.Put("/admin/loyalty/products/{productID}", deps.AdminLoyalty.SetProductBonus)
+			r.Post("/admin/loyalty/expiry", deps.AdminLoyalty.ExpirePoints)
+			r.Post("/admin/loyalty/birthday-bonuses", deps.AdminLoyalty.AwardBirthdayBonuses)
+			r.Get("/admin/customers/{customerID}/loyalty", deps.AdminLoyalty.GetAccount)
+			r.Post("/admin/customers/{customerID}/loyalty/adjustments", deps.AdminLoyalty.AdjustPoints)
+			r.Post("/admin/customers/{customerID}/loyalty/rebuild", deps.AdminLoyalty.RebuildBalance)
+			r.Get("/admin/qurbani/offerings", deps.AdminQurbani.ListOfferings)
+			r.Post("/admin/qurbani/offerings", deps.AdminQurbani.CreateOffering)
+			r.Put("/admin/qurbani/offerings/{offeringID}", deps.AdminQurbani.UpdateOffering)
+			r.Post("/admin/qurbani/offerings/{offeringID}/allocation", deps.AdminQurbani.AllocateShares)
+			r.Get("/admin/qurbani/bookings", deps.AdminQurbani.ListBookings)
+			r.Post("/admin/qurbani/bookings/{bookingID}/cancel", deps.AdminQurbani.CancelBooking)
+			r.Get("/admin/qurbani/animals", deps.AdminQurbani.SlaughterSheet)
+			r.Get("/admin/qurbani/batches", deps.AdminQurbani.ListBatches)
+			r.Post("/admin/qurbani/batches", deps.AdminQurbani.PlanBatches)
+			r.Post("/admin/qurbani/batches/{batchID}/complete", deps.AdminQurbani.CompleteBatch)
+			r.Post("/admin/qurbani/batches/{batchID}/notifications", deps.AdminQurbani.NotifyBatch)
+			r.Get("/admin/qurbani/distribution", deps.AdminQurbani.DistributionList)
+			r.Get("/admin/subscriptions", deps.AdminSubscription.ListSubscriptions)
+			r.Get("/admin/subscriptions/boxes", deps.AdminSubscription.ListBoxes)
+			r.Post("/admin/subscriptions/boxes", deps.AdminSubscription.CreateBox)
+			r.Put("/admin/subscriptions/boxes/{boxID}", deps.AdminSubscription.UpdateBox)
+			r.Get("/admin/subscriptions/renewals", deps.AdminSubscription.ListRenewals)
+			r.Post("/admin/subscriptions/renewals/run", deps.AdminSubscription.RunRenewals)
+			r.Get("/admin/business-accounts", deps.AdminBusiness.List)
+			r.Post("/admin/business-accounts", deps.AdminBusiness.Create)
+			r.Get("/admin/business-accounts/{accountID}", deps.AdminBusiness.Get)
+			r.Put("/admin/business-accounts/{accountID}", deps.AdminBusiness.Update)
+			r.Put("/admin/business-accounts/{accountID}/prices", deps.AdminBusiness.SetPrices)
+			r.Get("/admin/business-accounts/{accountID}/members", deps.AdminBusiness.ListMembers)
+			r.Put("/admin/business-accounts/{accountID}/members/{customerID}", deps.AdminBusiness.SaveMember)
+			r.Delete("/admin/business-accounts/{accountID}/members/{customerID}", deps.AdminBusiness.RemoveMember)
+			r.Post("/admin/business-accounts/{accountID}/payments", deps.AdminBusiness.RecordPayment)
+			r.Get("/admin/business-accounts/{accountID}/orders", deps.AdminBusiness.ListOrders)
+			r.Get("/admin/business-accounts/{accountID}/statement", deps.AdminBusiness.Statement)
+			r.Get("/admin/plu-items", deps.AdminBarcode.ListItems)
+			r.Post("/admin/plu-items", deps.AdminBarcode.CreateItem)
+			r.Put("/admin/plu-items/{itemID}", deps.AdminBarcode.UpdateItem)
+			r.Post("/admin/plu-items/{itemID}/barcodes", deps.AdminBarcode.Encode)
+			r.Get("/admin/barcodes/{code}/image", deps.AdminBarcode.Image)
+			r.Get("/admin/printers", deps.AdminLabel.ListPrinters)
+			r.Post("/admin/printers", deps.AdminLabel.CreatePrinter)
+			r.Put("/admin/printers/{printerID}", deps.AdminLabel.UpdatePrinter)
+			r.Get("/admin/orders/{orderID}/labels", deps.AdminLabel.OrderLabels)
+			r.Post("/admin/orders/{orderID}/labels/print", deps.AdminLabel.PrintOrderLabels)
+			r.Get("/admin/inventory/lots/{lotID}/labels", deps.AdminLabel.LotLabels)
+			r.Post("/admin/inventory/lots/{lotID}/labels/print", deps.AdminLabel.PrintLotLabels)
+			r.Get("/admin/print-jobs", deps.AdminLabel.ListJobs)
+			r.Post("/admin/print-jobs/dispatch", deps.AdminLabel.DispatchJobs)
+			r.Post("/admin/print-jobs/{jobID}/retry", deps.AdminLabel.RetryJob)
+			r.Post("/admin/scales", deps.AdminScale.CreateScale)
+			r.Put("/admin/scales/{scaleID}", deps.AdminScale.UpdateScale)
+			r.Post("/admin/pos/tills", deps.AdminPOS.CreateTill)
+			r.Put("/admin/pos/tills/{tillID}", deps.AdminPOS.UpdateTill)
+			r.Get("/admin/haccp/units", deps.AdminHACCP.ListUnits)
+			r.Post("/admin/haccp/units", deps.AdminHACCP.CreateUnit)
+			r.Put("/admin/haccp/units/{unitID}", deps.AdminHACCP.UpdateUnit)
+			r.Post("/admin/haccp/units/{unitID}/readings", deps.AdminHACCP.RecordReading)
+			r.Post("/admin/haccp/units/{unitID}/corrective-actions", deps.AdminHACCP.RecordAction)
+			r.Get("/admin/haccp/readings", deps.AdminHACCP.ListReadings)
+			r.Get("/admin/haccp/alerts", deps.AdminHACCP.ListAlerts)
+			r.Post("/admin/haccp/checks/run", deps.AdminHACCP.RunChecks)
+			r.Get("/admin/haccp/report", deps.AdminHACCP.Report)
+			r.Get("/admin/staff", deps.AdminStaff.ListProfiles)
+			r.Put("/admin/staff/{adminID}", deps.AdminStaff.SetProfile)
+			r.Get("/admin/staff/rota", deps.AdminStaff.Rota)
+			r.Post("/admin/staff/shifts", deps.AdminStaff.ScheduleShift)
+			r.Put("/admin/staff/shifts/{shiftID}", deps.AdminStaff.UpdateShift)
+			r.Delete("/admin/staff/shifts/{shiftID}", deps.AdminStaff.DeleteShift)
+			r.Post("/admin/staff/clock/in", deps.AdminStaff.ClockIn)
+			r.Post("/admin/staff/clock/break/start", deps.AdminStaff.StartBreak)
+			r.Post("/admin/staff/clock/break/end", deps.AdminStaff.EndBreak)
+			r.Post("/admin/staff/clock/out", deps.AdminStaff.ClockOut)
+			r.Get("/admin/staff/timesheets", deps.AdminStaff.Timesheets)
+			r.Get("/admin/staff/timesheets/export", deps.AdminStaff.ExportTimesheets)
+			r.Get("/admin/drivers", deps.AdminDispatch.ListDrivers)
+			r.Post("/admin/drivers", deps.AdminDispatch.CreateDriver)
+			r.Put("/admin/drivers/{adminID}", deps.AdminDispatch.UpdateDriver)
+			r.Get("/admin/dispatch/runs", deps.AdminDispatch.ListRuns)
+			r.Post("/admin/dispatch/runs", deps.AdminDispatch.PlanRun)
+			r.Get("/admin/dispatch/runs/{runID}", deps.AdminDispatch.GetRun)
+			r.Post("/admin/dispatch/runs/{runID}/stops", deps.AdminDispatch.AddStop)
+			r.Delete("/admin/dispatch/runs/{runID}/stops/{stopID}", deps.AdminDispatch.RemoveStop)
+			r.Post("/admin/dispatch/runs/{runID}/optimise", deps.AdminDispatch.OptimiseRun)
+			r.Put("/admin/dispatch/runs/{runID}/driver", deps.AdminDispatch.AssignDriver)
+			r.Get("/admin/dispatch/stops/{stopID}/proofs/{kind}", deps.AdminDispatch.Proof)
+		})
+
+		// Till routes, for cashiers as well as shop staff and managers
+		r.Group(func(r chi.Router) {
+			r.Use(deps.AuthMiddleware.RequireAdmin)
+			r.Use(deps.Roles.RequireRole(admin.RoleCashier, admin.RoleStaff, admin.RoleManager))
+			r.Use(deps.BranchScope.ScopeToAdminBranches)
+			r.Get("/admin/barcodes/{code}", deps.AdminBarcode.Resolve)
+			r.Get("/admin/scales", deps.AdminScale.ListScales)
+			r.Get("/admin/scales/{scaleID}/readings/stream", deps.AdminScale.StreamReadings)
+			r.Get("/admin/scales/{scaleID}/reading", deps.AdminScale.Weigh)
+			r.Post("/admin/scales/{scaleID}/tare", deps.AdminScale.Tare)
+			r.Get("/admin/pos/tills", deps.AdminPOS.ListTills)
+			r.Get("/admin/pos/tills/{tillID}/sessions", deps.AdminPOS.ListSessions)
+			r.Post("/admin/pos/tills/{tillID}/sessions", deps.AdminPOS.OpenSession)
+			r.Post("/admin/pos/sessions/{sessionID}/close", deps.AdminPOS.CloseSession)
+			r.Get("/admin/pos/sessions/{sessionID}/report", deps.AdminPOS.SessionReport)
+			r.Post("/admin/pos/sessions/{sessionID}/sales", deps.AdminPOS.StartSale)
+			r.Post("/admin/pos/sessions/{sessionID}/returns", deps.AdminPOS.ReturnSale)
+			r.Get("/admin/pos/sales/{saleID}", deps.AdminPOS.GetSale)
+			r.Post("/admin/pos/sales/{saleID}/lines", deps.AdminPOS.AddSaleLine)
+			r.Delete("/admin/pos/sales/{saleID}/lines/{lineID}", deps.AdminPOS.VoidSaleLine)
+			r.Post("/admin/pos/sales/{saleID}/payments", deps.AdminPOS.TakePayment)
+			r.Post("/admin/pos/sales/{saleID}/void", deps.AdminPOS.VoidSale)
+			r.Get("/admin/pos/sales/{saleID}/receipt", deps.AdminPOS.GetReceipt)
+		})
+
+		// Driver routes
+		r.Group(func(r chi.Router) {
+			r.Use(deps.AuthMiddleware.RequireAdmin)
+			r.Use(deps.Roles.RequireRole(admin.RoleDriver))
+			r.Use(deps.BranchScope.ScopeToAdminBranches)
+			r.Get("/driver/runs", deps.Driver.ListRuns)
+			r.Get("/driver/runs/{runID}", deps.Driver.GetRun)
+			r.Post("/driver/runs/{runID}/start", deps.Driver.StartRun)
+			r.Post("/driver/runs/{runID}/stops/{stopID}/delivered", deps.Driver.DeliverStop)
+			r.Post("/driver/runs/{runID}/stops/{stopID}/failed", deps.Driver.FailStop)
+		})
 	})
 
 	return r
 }
 
+// requestTimeout cancels a request's context after timeout, except for
+// event streams, which stay open for as long as the client listens.
+func requestTimeout(timeout time.Duration) func(next http.Handler) http.Handler {
+	limit := chimw.Timeout(timeout)
+	return func(next http.Handler) http.Handler {
+		limited := limit(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") == "text/event-stream" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
+
 func requestLogger(logger *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,149 @@
+package http_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/katerji/butchery-app/backend/internal/domain/admin"
+	"github.com/katerji/butchery-app/backend/internal/domain/auth"
+	apphttp "github.com/katerji/butchery-app/backend/internal/interface/http"
+	"github.com/katerji/butchery-app/backend/internal/interface/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mocks ---
+
+type mockTokenValidator struct {
+	mock.Mock
+}
+
+func (m *mockTokenValidator) ValidateAccessToken(token string) (*auth.AccessTokenClaims, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.AccessTokenClaims), args.Error(1)
+}
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) FindByEmail(ctx context.Context, email string) (*admin.Admin, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindByID(ctx context.Context, id uuid.UUID) (*admin.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) FindAll(ctx context.Context) ([]*admin.Admin, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.Admin), args.Error(1)
+}
+
+func (m *mockAdminRepository) SaveBranches(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *mockAdminRepository) SaveRole(ctx context.Context, a *admin.Admin) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+// --- Fixtures ---
+
+// newRouter builds the router with a token for an admin of the given role.
+// No handlers are wired, so only requests refused by middleware can be
+// made.
+func newRouter(t *testing.T, role admin.Role) (http.Handler, string) {
+	t.Helper()
+	a, err := admin.NewAdmin(uuid.New(), "someone@butchery.com", "$2a$10$hash", "Someone", role)
+	require.NoError(t, err)
+	a.AssignBranches(true, nil)
+	admins := new(mockAdminRepository)
+	admins.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	validator := new(mockTokenValidator)
+	validator.On("ValidateAccessToken", "token").
+		Return(&auth.AccessTokenClaims{SubjectID: a.ID(), SubjectType: auth.SubjectTypeAdmin}, nil)
+
+	router := apphttp.NewRouter(apphttp.RouterDeps{
+		Logger:         slog.New(slog.DiscardHandler),
+		AuthMiddleware: middleware.NewAuthMiddleware(validator),
+		BranchScope:    middleware.NewBranchScopeMiddleware(admins),
+		Roles:          middleware.NewRoleMiddleware(admins),
+	})
+	return router, "token"
+}
+
+func get(router http.Handler, path, token string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+// --- Tests ---
+
+func TestRouter_DriverCannotReachAdminRoutes(t *testing.T) {
+	router, token := newRouter(t, admin.RoleDriver)
+
+	for _, path := range []string{
+		"/api/v1/admin/carcasses",
+		"/api/v1/admin/orders/stream",
+		"/api/v1/admin/dispatch/runs",
+	} {
+		assert.Equal(t, http.StatusForbidden, get(router, path, token), path)
+	}
+}
+
+func TestRouter_OnlyDriversReachDriverRoutes(t *testing.T) {
+	for _, role := range []admin.Role{admin.RoleStaff, admin.RoleCashier, admin.RoleManager} {
+		router, token := newRouter(t, role)
+
+		assert.Equal(t, http.StatusForbidden, get(router, "/api/v1/driver/runs", token), role)
+	}
+}
+
+func TestRouter_CashierOnlyReachesTillRoutes(t *testing.T) {
+	router, token := newRouter(t, admin.RoleCashier)
+
+	for _, path := range []string{
+		"/api/v1/admin/carcasses",
+		"/api/v1/admin/orders/stream",
+		"/api/v1/admin/staff",
+		"/api/v1/admin/tax/rates",
+	} {
+		assert.Equal(t, http.StatusForbidden, get(router, path, token), path)
+	}
+}
+
+func TestRouter_DriverCannotReachTillRoutes(t *testing.T) {
+	router, token := newRouter(t, admin.RoleDriver)
+
+	for _, path := range []string{
+		"/api/v1/admin/pos/tills",
+		"/api/v1/admin/scales",
+	} {
+		assert.Equal(t, http.StatusForbidden, get(router, path, token), path)
+	}
+}
@@ -0,0 +1,115 @@
+// Package barcode encodes and decodes EAN-13 barcodes, including the
+// variable-measure codes in-store scales print on weighed packs, and draws
+// them as SVG or PNG images.
+package barcode
+
+import "errors"
+
+var (
+	ErrInvalidCode      = errors.New("barcode must be 13 digits")
+	ErrCheckDigit       = errors.New("barcode check digit is wrong")
+	ErrPriceCheckDigit  = errors.New("barcode price check digit is wrong")
+	ErrInvalidLayout    = errors.New("layout must be 13 characters: a prefix from 20 to 29, item digits I, weight digits W or price digits P optionally preceded by a price check digit V, and the check digit C")
+	ErrOverlappingCodes = errors.New("layouts must have different prefixes")
+	ErrNoLayout         = errors.New("barcode is not a variable-measure code of a known layout")
+	ErrNoLayoutFor      = errors.New("no layout carries that measure")
+	ErrItemTooLarge     = errors.New("item number does not fit the layout")
+	ErrValueTooLarge    = errors.New("weight or price does not fit the layout")
+)
+
+// leftOdd, leftEven and right are the 7-module patterns of each digit: the
+// left half uses odd (L) or even (G) parity and the right half R codes.
+var (
+	leftOdd = [10]string{
+		"0001101", "0011001", "0010011", "0111101", "0100011",
+		"0110001", "0101111", "0111011", "0110111", "0001011",
+	}
+	leftEven = [10]string{
+		"0100111", "0110011", "0011011", "0100001", "0011101",
+		"0111001", "0000101", "0010001", "0001001", "0010111",
+	}
+	right = [10]string{
+		"1110010", "1100110", "1101100", "1000010", "1011100",
+		"1001110", "1010000", "1000100", "1001000", "1110100",
+	}
+)
+
+// parity is the odd/even pattern of the left half that encodes the first
+// digit, which has no bars of its own.
+var parity = [10]string{
+	"OOOOOO", "OOEOEE", "OOEEOE", "OOEEEO", "OEOOEE",
+	"OEEOOE", "OEEEOO", "OEOEOE", "OEOEEO", "OEEOEO",
+}
+
+// CheckDigit returns the check digit of the first 12 digits of an EAN-13
+// code.
+func CheckDigit(digits string) (byte, error) {
+	if len(digits) != 12 || !allDigits(digits) {
+		return 0, ErrInvalidCode
+	}
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// Validate checks that code is 13 digits with the right check digit.
+func Validate(code string) error {
+	if len(code) != 13 || !allDigits(code) {
+		return ErrInvalidCode
+	}
+	check, _ := CheckDigit(code[:12])
+	if code[12] != check {
+		return ErrCheckDigit
+	}
+	return nil
+}
+
+// Modules returns the 95 modules of code's bars from left to right, true
+// for a dark module. Quiet zones are not included.
+func Modules(code string) ([]bool, error) {
+	if err := Validate(code); err != nil {
+		return nil, err
+	}
+	pattern := "101"
+	first := code[0] - '0'
+	for i := 1; i <= 6; i++ {
+		d := code[i] - '0'
+		if parity[first][i-1] == 'O' {
+			pattern += leftOdd[d]
+		} else {
+			pattern += leftEven[d]
+		}
+	}
+	pattern += "01010"
+	for i := 7; i <= 12; i++ {
+		pattern += right[code[i]-'0']
+	}
+	pattern += "101"
+
+	modules := make([]bool, len(pattern))
+	for i := range pattern {
+		modules[i] = pattern[i] == '1'
+	}
+	return modules, nil
+}
+
+// guard reports whether module i belongs to the start, middle or end guard
+// bars, which are drawn longer than the rest.
+func guard(i int) bool {
+	return i < 3 || (i >= 45 && i < 50) || i >= 92
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
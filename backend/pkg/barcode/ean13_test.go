@@ -0,0 +1,65 @@
+package barcode_test
+
+import (
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDigit(t *testing.T) {
+	check, err := barcode.CheckDigit("400638133393")
+	require.NoError(t, err)
+	assert.Equal(t, byte('1'), check)
+
+	_, err = barcode.CheckDigit("40063813339")
+	assert.ErrorIs(t, err, barcode.ErrInvalidCode)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, barcode.Validate("4006381333931"))
+	assert.ErrorIs(t, barcode.Validate("4006381333932"), barcode.ErrCheckDigit)
+	assert.ErrorIs(t, barcode.Validate("400638133393x"), barcode.ErrInvalidCode)
+	assert.ErrorIs(t, barcode.Validate("400638133393"), barcode.ErrInvalidCode)
+}
+
+func TestModules(t *testing.T) {
+	modules, err := barcode.Modules("4006381333931")
+	require.NoError(t, err)
+	require.Len(t, modules, 95)
+
+	bits := make([]byte, len(modules))
+	for i, dark := range modules {
+		bits[i] = '0'
+		if dark {
+			bits[i] = '1'
+		}
+	}
+	// Start guard, then 0 with odd parity and 0 with even parity as the
+	// leading 4 requires; the middle guard; and 1 and the end guard last.
+	assert.Equal(t, "101"+"0001101"+"0100111", string(bits[:17]))
+	assert.Equal(t, "01010", string(bits[45:50]))
+	assert.Equal(t, "1100110"+"101", string(bits[85:]))
+}
+
+func TestPriceCheckDigit(t *testing.T) {
+	tests := []struct {
+		price string
+		want  byte
+	}{
+		{"2875", '9'},
+		{"14685", '6'},
+		{"0000", '0'},
+	}
+	for _, tt := range tests {
+		t.Run(tt.price, func(t *testing.T) {
+			got, err := barcode.PriceCheckDigit(tt.price)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := barcode.PriceCheckDigit("287")
+	assert.ErrorIs(t, err, barcode.ErrInvalidLayout)
+}
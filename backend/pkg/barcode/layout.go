@@ -0,0 +1,186 @@
+package barcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Measure is what the value field of a variable-measure code holds.
+type Measure string
+
+const (
+	// Weight codes carry the pack's weight in grams.
+	Weight Measure = "weight"
+	// Price codes carry the pack's price in cents.
+	Price Measure = "price"
+)
+
+// Layout is how a scale lays out a variable-measure code. It is written as
+// a 13-character template such as "20IIIIIWWWWWC": the prefix digits, then
+// I for each digit of the item number, W for each digit of the weight in
+// grams or P for each digit of the price in cents, optionally preceded by V
+// for a price check digit, and C for the check digit. Prefixes run from 20
+// to 29, the range GS1 keeps for use within a store.
+type Layout struct {
+	template    string
+	prefix      string
+	itemDigits  int
+	measure     Measure
+	valueDigits int
+	priceCheck  bool
+}
+
+// ParseLayout parses a layout template.
+func ParseLayout(template string) (Layout, error) {
+	l := Layout{template: template}
+	if len(template) != 13 || template[0] != '2' || template[12] != 'C' {
+		return Layout{}, ErrInvalidLayout
+	}
+	i := 0
+	for i < 12 && template[i] >= '0' && template[i] <= '9' {
+		i++
+	}
+	l.prefix = template[:i]
+	for i < 12 && template[i] == 'I' {
+		l.itemDigits++
+		i++
+	}
+	if i < 12 && template[i] == 'V' {
+		l.priceCheck = true
+		i++
+	}
+	var valueDigit byte
+	if i < 12 {
+		valueDigit = template[i]
+	}
+	switch valueDigit {
+	case 'W':
+		l.measure = Weight
+	case 'P':
+		l.measure = Price
+	}
+	for l.measure != "" && i < 12 && template[i] == valueDigit {
+		l.valueDigits++
+		i++
+	}
+
+	switch {
+	case i != 12,
+		len(l.prefix) < 2,
+		l.itemDigits == 0,
+		l.valueDigits < 4,
+		l.priceCheck && (l.measure != Price || l.valueDigits > 5):
+		return Layout{}, ErrInvalidLayout
+	}
+	return l, nil
+}
+
+func (l Layout) Prefix() string   { return l.prefix }
+func (l Layout) Measure() Measure { return l.measure }
+func (l Layout) String() string   { return l.template }
+
+// Code is a variable-measure code read back into its parts. Value is grams
+// for a weight code and cents for a price code.
+type Code struct {
+	Code    string
+	Layout  Layout
+	Item    int
+	Measure Measure
+	Value   int64
+}
+
+// Encode builds the code of a pack of item weighing or costing value.
+func (l Layout) Encode(item int, value int64) (string, error) {
+	if item < 0 || len(strconv.Itoa(item)) > l.itemDigits {
+		return "", ErrItemTooLarge
+	}
+	if value < 0 || len(strconv.FormatInt(value, 10)) > l.valueDigits {
+		return "", ErrValueTooLarge
+	}
+	valueField := fmt.Sprintf("%0*d", l.valueDigits, value)
+	var b strings.Builder
+	b.WriteString(l.prefix)
+	fmt.Fprintf(&b, "%0*d", l.itemDigits, item)
+	if l.priceCheck {
+		check, err := PriceCheckDigit(valueField)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(check)
+	}
+	b.WriteString(valueField)
+	check, err := CheckDigit(b.String())
+	if err != nil {
+		return "", err
+	}
+	b.WriteByte(check)
+	return b.String(), nil
+}
+
+// Decode reads a code laid out by l. A code with another prefix gives
+// ErrNoLayout.
+func (l Layout) Decode(code string) (Code, error) {
+	if err := Validate(code); err != nil {
+		return Code{}, err
+	}
+	if !strings.HasPrefix(code, l.prefix) {
+		return Code{}, ErrNoLayout
+	}
+	i := len(l.prefix)
+	item, _ := strconv.Atoi(code[i : i+l.itemDigits])
+	i += l.itemDigits
+	if l.priceCheck {
+		check, _ := PriceCheckDigit(code[i+1 : i+1+l.valueDigits])
+		if code[i] != check {
+			return Code{}, ErrPriceCheckDigit
+		}
+		i++
+	}
+	value, _ := strconv.ParseInt(code[i:i+l.valueDigits], 10, 64)
+	return Code{Code: code, Layout: l, Item: item, Measure: l.measure, Value: value}, nil
+}
+
+// Layouts are the layouts in use in a store, told apart by their prefixes.
+type Layouts []Layout
+
+// ParseLayouts parses a set of layout templates.
+func ParseLayouts(templates []string) (Layouts, error) {
+	layouts := make(Layouts, 0, len(templates))
+	for _, t := range templates {
+		l, err := ParseLayout(strings.TrimSpace(t))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", t, err)
+		}
+		for _, other := range layouts {
+			if strings.HasPrefix(l.prefix, other.prefix) || strings.HasPrefix(other.prefix, l.prefix) {
+				return nil, fmt.Errorf("%q: %w", t, ErrOverlappingCodes)
+			}
+		}
+		layouts = append(layouts, l)
+	}
+	return layouts, nil
+}
+
+// Decode reads a code with whichever layout its prefix belongs to.
+func (ls Layouts) Decode(code string) (Code, error) {
+	if err := Validate(code); err != nil {
+		return Code{}, err
+	}
+	for _, l := range ls {
+		if strings.HasPrefix(code, l.prefix) {
+			return l.Decode(code)
+		}
+	}
+	return Code{}, ErrNoLayout
+}
+
+// For returns the first layout that carries measure.
+func (ls Layouts) For(measure Measure) (Layout, error) {
+	for _, l := range ls {
+		if l.measure == measure {
+			return l, nil
+		}
+	}
+	return Layout{}, ErrNoLayoutFor
+}
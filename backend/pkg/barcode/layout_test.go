@@ -0,0 +1,118 @@
+package barcode_test
+
+import (
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	valid := []string{"20IIIIIWWWWWC", "21IIIIIPPPPPC", "22IIIIIVPPPPC", "23IIIIWWWWWWC"}
+	for _, template := range valid {
+		_, err := barcode.ParseLayout(template)
+		assert.NoError(t, err, template)
+	}
+
+	invalid := []string{
+		"",
+		"20IIIIIWWWWW",
+		"19IIIIIWWWWWC",
+		"2IIIIIIWWWWWC",
+		"20WWWWWWWWWWC",
+		"20IIIIIIIWWWC",
+		"20IIIIIWWPPPC",
+		"20IIIIVWWWWWC",
+		"20IIIVPPPPPPC",
+		"20IIIIIWWWWWX",
+	}
+	for _, template := range invalid {
+		_, err := barcode.ParseLayout(template)
+		assert.ErrorIs(t, err, barcode.ErrInvalidLayout, template)
+	}
+}
+
+func TestLayout_EncodeDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		item     int
+		value    int64
+		want     string
+		measure  barcode.Measure
+	}{
+		{"weight in grams", "20IIIIIWWWWWC", 123, 1250, "2000123012506", barcode.Weight},
+		{"price in cents", "21IIIIIPPPPPC", 42, 899, "2100042008991", barcode.Price},
+		{"price with a price check digit", "22IIIIIVPPPPC", 7, 2875, "2200007928750", barcode.Price},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := barcode.ParseLayout(tt.template)
+			require.NoError(t, err)
+
+			code, err := l.Encode(tt.item, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+			assert.NoError(t, barcode.Validate(code))
+
+			decoded, err := l.Decode(code)
+			require.NoError(t, err)
+			assert.Equal(t, tt.item, decoded.Item)
+			assert.Equal(t, tt.value, decoded.Value)
+			assert.Equal(t, tt.measure, decoded.Measure)
+		})
+	}
+}
+
+func TestLayout_EncodeOutOfRange(t *testing.T) {
+	l, err := barcode.ParseLayout("20IIIIIWWWWWC")
+	require.NoError(t, err)
+
+	_, err = l.Encode(123456, 1000)
+	assert.ErrorIs(t, err, barcode.ErrItemTooLarge)
+	_, err = l.Encode(1, 100000)
+	assert.ErrorIs(t, err, barcode.ErrValueTooLarge)
+	_, err = l.Encode(1, -1)
+	assert.ErrorIs(t, err, barcode.ErrValueTooLarge)
+}
+
+func TestLayout_DecodeWrongPriceCheckDigit(t *testing.T) {
+	l, err := barcode.ParseLayout("22IIIIIVPPPPC")
+	require.NoError(t, err)
+
+	// The price check digit says 9 but the price field reads 2876.
+	code := "220000792876"
+	check, err := barcode.CheckDigit(code)
+	require.NoError(t, err)
+
+	_, err = l.Decode(code + string(check))
+	assert.ErrorIs(t, err, barcode.ErrPriceCheckDigit)
+}
+
+func TestLayouts(t *testing.T) {
+	_, err := barcode.ParseLayouts([]string{"20IIIIIWWWWWC", "20IIIIIPPPPPC"})
+	assert.ErrorIs(t, err, barcode.ErrOverlappingCodes)
+	_, err = barcode.ParseLayouts([]string{"20IIIIIWWWWWC", "201IIIIPPPPPC"})
+	assert.ErrorIs(t, err, barcode.ErrOverlappingCodes)
+
+	layouts, err := barcode.ParseLayouts([]string{"20IIIIIWWWWWC", " 21IIIIIPPPPPC"})
+	require.NoError(t, err)
+
+	decoded, err := layouts.Decode("2100042008991")
+	require.NoError(t, err)
+	assert.Equal(t, barcode.Price, decoded.Measure)
+	assert.Equal(t, "21IIIIIPPPPPC", decoded.Layout.String())
+
+	_, err = layouts.Decode("4006381333931")
+	assert.ErrorIs(t, err, barcode.ErrNoLayout)
+
+	weight, err := layouts.For(barcode.Weight)
+	require.NoError(t, err)
+	assert.Equal(t, "20", weight.Prefix())
+
+	priced, err := barcode.ParseLayouts([]string{"21IIIIIPPPPPC"})
+	require.NoError(t, err)
+	_, err = priced.For(barcode.Weight)
+	assert.ErrorIs(t, err, barcode.ErrNoLayoutFor)
+}
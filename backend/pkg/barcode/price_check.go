@@ -0,0 +1,34 @@
+package barcode
+
+// The weighting tables of the GS1 price check digit. Each maps a digit to
+// the units digit of its weighted product: "2-" and "5-" subtract the tens
+// digit of the product from its units digit, "5+" adds them.
+var (
+	weight2Minus = [10]int{0, 2, 4, 6, 8, 9, 1, 3, 5, 7}
+	weight3      = [10]int{0, 3, 6, 9, 2, 5, 8, 1, 4, 7}
+	weight5Plus  = [10]int{0, 5, 1, 6, 2, 7, 3, 8, 4, 9}
+	weight5Minus = [10]int{0, 5, 9, 4, 8, 3, 7, 2, 6, 1}
+)
+
+// PriceCheckDigit returns the GS1 check digit of a 4- or 5-digit price,
+// which lets a till catch a misread price field on its own.
+func PriceCheckDigit(price string) (byte, error) {
+	if !allDigits(price) {
+		return 0, ErrInvalidLayout
+	}
+	d := func(i int) int { return int(price[i] - '0') }
+	switch len(price) {
+	case 4:
+		sum := weight2Minus[d(0)] + weight2Minus[d(1)] + weight3[d(2)] + weight5Minus[d(3)]
+		return byte('0' + sum*3%10), nil
+	case 5:
+		sum := weight5Plus[d(0)] + weight2Minus[d(1)] + weight5Minus[d(2)] + weight5Plus[d(3)] + weight2Minus[d(4)]
+		want := (10 - sum%10) % 10
+		for c, v := range weight5Minus {
+			if v == want {
+				return byte('0' + c), nil
+			}
+		}
+	}
+	return 0, ErrInvalidLayout
+}
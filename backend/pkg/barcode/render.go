@@ -0,0 +1,108 @@
+package barcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Proportions of a drawn code in modules, the width of the narrowest bar.
+// The quiet zones either side are what scanners need to find the code.
+const (
+	leftQuiet   = 11
+	rightQuiet  = 7
+	barHeight   = 60
+	guardExtra  = 5
+	digitHeight = 9
+)
+
+// SVG draws code with its digits beneath the bars, the first digit to the
+// left of the start guard as printed on packs. module is the width of the
+// narrowest bar in pixels.
+func SVG(code string, module int) ([]byte, error) {
+	modules, err := Modules(code)
+	if err != nil {
+		return nil, err
+	}
+	if module < 1 {
+		module = 1
+	}
+	width := (leftQuiet + len(modules) + rightQuiet) * module
+	height := (barHeight + guardExtra + digitHeight) * module
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/>`, width, height)
+	for start := 0; start < len(modules); {
+		if !modules[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < len(modules) && modules[end] {
+			end++
+		}
+		h := barHeight
+		if guard(start) {
+			h += guardExtra
+		}
+		fmt.Fprintf(&b, `<rect x="%d" width="%d" height="%d"/>`, (leftQuiet+start)*module, (end-start)*module, h*module)
+		start = end
+	}
+
+	y := (barHeight + guardExtra + digitHeight - 1) * module
+	fmt.Fprintf(&b, `<g font-family="monospace" font-size="%d" text-anchor="middle">`, digitHeight*module)
+	digit := func(x float64, d byte) {
+		fmt.Fprintf(&b, `<text x="%g" y="%d">%c</text>`, x*float64(module), y, d)
+	}
+	digit(float64(leftQuiet)/2, code[0])
+	for i := 1; i <= 6; i++ {
+		digit(float64(leftQuiet+3+7*(i-1))+3.5, code[i])
+	}
+	for i := 7; i <= 12; i++ {
+		digit(float64(leftQuiet+50+7*(i-7))+3.5, code[i])
+	}
+	b.WriteString(`</g></svg>`)
+	return b.Bytes(), nil
+}
+
+// PNG draws code's bars, without digits, as a black and white image.
+// module is the width of the narrowest bar in pixels.
+func PNG(code string, module int) ([]byte, error) {
+	modules, err := Modules(code)
+	if err != nil {
+		return nil, err
+	}
+	if module < 1 {
+		module = 1
+	}
+	width := (leftQuiet + len(modules) + rightQuiet) * module
+	height := (barHeight + guardExtra) * module
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for i, dark := range modules {
+		if !dark {
+			continue
+		}
+		h := barHeight
+		if guard(i) {
+			h += guardExtra
+		}
+		for x := (leftQuiet + i) * module; x < (leftQuiet+i+1)*module; x++ {
+			for y := 0; y < h*module; y++ {
+				img.SetGray(x, y, color.Gray{})
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("encoding png: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,41 @@
+package barcode_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSVG(t *testing.T) {
+	svg, err := barcode.SVG("4006381333931", 2)
+	require.NoError(t, err)
+
+	s := string(svg)
+	assert.True(t, strings.HasPrefix(s, `<svg xmlns="http://www.w3.org/2000/svg" width="226" height="148"`))
+	assert.Equal(t, 13, strings.Count(s, "<text "))
+	assert.Equal(t, 30+1, strings.Count(s, "<rect "), "an EAN-13 has 30 bars plus the background")
+
+	_, err = barcode.SVG("4006381333932", 2)
+	assert.ErrorIs(t, err, barcode.ErrCheckDigit)
+}
+
+func TestPNG(t *testing.T) {
+	raw, err := barcode.PNG("4006381333931", 3)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, 113*3, img.Bounds().Dx())
+	assert.Equal(t, 65*3, img.Bounds().Dy())
+
+	// The first bar of the start guard is dark after the quiet zone.
+	r, _, _, _ := img.At(11*3, 0).RGBA()
+	assert.Zero(t, r)
+	r, _, _, _ = img.At(11*3-1, 0).RGBA()
+	assert.NotZero(t, r)
+}
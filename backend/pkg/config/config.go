@@ -6,12 +6,26 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/katerji/butchery-app/backend/pkg/barcode"
 )
 
 type Config struct {
-	DB     DBConfig
-	JWT    JWTConfig
-	Server ServerConfig
+	DB           DBConfig
+	JWT          JWTConfig
+	Server       ServerConfig
+	Fulfilment   FulfilmentConfig
+	Inventory    InventoryConfig
+	Traceability TraceabilityConfig
+	Payment      PaymentConfig
+	Refund       RefundConfig
+	Shop         ShopConfig
+	Tax          TaxConfig
+	Subscription SubscriptionConfig
+	Barcode      BarcodeConfig
+	Label        LabelConfig
+	Scale        ScaleConfig
+	HACCP        HACCPConfig
+	Staff        StaffConfig
 }
 
 type DBConfig struct {
@@ -43,6 +57,149 @@ type ServerConfig struct {
 	Port int `env:"SERVER_PORT" envDefault:"8080"`
 }
 
+type FulfilmentConfig struct {
+	Timezone string        `env:"FULFILMENT_TIMEZONE" envDefault:"UTC"`
+	HoldTTL  time.Duration `env:"FULFILMENT_HOLD_TTL" envDefault:"15m"`
+	LeadTime time.Duration `env:"FULFILMENT_LEAD_TIME" envDefault:"2h"`
+}
+
+// InventoryConfig holds how long checkout holds stock and the expiry
+// policy: lots are marked down by MarkdownBP basis points over the last
+// MarkdownDays days up to their use-by date. Zero MarkdownDays turns
+// markdowns off. The server scans for expiring lots every ScanEvery, so a
+// new day's proposals are raised within that long of midnight.
+type InventoryConfig struct {
+	HoldTTL      time.Duration `env:"INVENTORY_HOLD_TTL" envDefault:"30m"`
+	MarkdownDays int           `env:"INVENTORY_MARKDOWN_DAYS" envDefault:"1"`
+	MarkdownBP   int           `env:"INVENTORY_MARKDOWN_BP" envDefault:"3000"`
+	ScanEvery    time.Duration `env:"INVENTORY_EXPIRY_SCAN_EVERY" envDefault:"1h"`
+}
+
+type TraceabilityConfig struct {
+	CertificateExpiryWarning time.Duration `env:"HALAL_CERTIFICATE_EXPIRY_WARNING" envDefault:"720h"`
+}
+
+// PaymentConfig selects the payment provider. The fake provider runs in
+// process and never moves money. AuthorizationMarginBP is how far above the
+// estimated total, in basis points, a card is authorized so that the weighed
+// total can still be captured.
+type PaymentConfig struct {
+	Provider              string `env:"PAYMENT_PROVIDER" envDefault:"fake"`
+	Currency              string `env:"PAYMENT_CURRENCY" envDefault:"gbp"`
+	AuthorizationMarginBP int    `env:"PAYMENT_AUTHORIZATION_MARGIN_BP" envDefault:"1500"`
+	WebhookSecret         string `env:"PAYMENT_WEBHOOK_SECRET" envDefault:"change-me-to-the-provider-webhook-secret"`
+	StripeAPIKey          string `env:"STRIPE_API_KEY"`
+	StripeBaseURL         string `env:"STRIPE_BASE_URL" envDefault:"https://api.stripe.com"`
+}
+
+// RefundConfig sets ApprovalThresholdCents, the largest refund staff may
+// issue without a manager approving it.
+type RefundConfig struct {
+	ApprovalThresholdCents int64 `env:"REFUND_APPROVAL_THRESHOLD_CENTS" envDefault:"5000"`
+}
+
+// ShopConfig holds the registration details printed on invoices and credit
+// notes. Address lines are separated by semicolons. Fiscal years start on
+// the first of FiscalYearStartMonth (1-12) and are named after the calendar
+// year they start in.
+type ShopConfig struct {
+	Name                 string   `env:"SHOP_NAME" envDefault:"Butchery"`
+	Address              []string `env:"SHOP_ADDRESS" envSeparator:";"`
+	VATNumber            string   `env:"SHOP_VAT_NUMBER"`
+	CompanyNumber        string   `env:"SHOP_COMPANY_NUMBER"`
+	Email                string   `env:"SHOP_EMAIL"`
+	FiscalYearStartMonth int      `env:"SHOP_FISCAL_YEAR_START_MONTH" envDefault:"4"`
+}
+
+// TaxConfig says how prices are taxed. Shelf prices include VAT unless
+// PricesIncludeVAT is false, and VAT is rounded on every line or once per
+// rate on the whole invoice.
+type TaxConfig struct {
+	PricesIncludeVAT bool   `env:"TAX_PRICES_INCLUDE_VAT" envDefault:"true"`
+	Rounding         string `env:"TAX_ROUNDING" envDefault:"line"`
+}
+
+// SubscriptionConfig says when subscription orders are generated and how
+// failed payments are chased. Orders are generated GenerateAhead before a
+// delivery's cutoff; a declined payment is retried after each of
+// RetryIntervals in turn, and a subscription is paused once
+// MaxFailedRenewals orders in a row have failed. The server looks for work
+// every RunEvery.
+type SubscriptionConfig struct {
+	GenerateAhead     time.Duration   `env:"SUBSCRIPTION_GENERATE_AHEAD" envDefault:"72h"`
+	RetryIntervals    []time.Duration `env:"SUBSCRIPTION_RETRY_INTERVALS" envDefault:"1h,6h,24h"`
+	MaxFailedRenewals int             `env:"SUBSCRIPTION_MAX_FAILED_RENEWALS" envDefault:"2"`
+	RunEvery          time.Duration   `env:"SUBSCRIPTION_RUN_EVERY" envDefault:"5m"`
+}
+
+// BarcodeConfig lists the layouts of the variable-measure barcodes scales
+// print on weighed packs, such as "20IIIIIWWWWWC" for a weight in grams.
+// Packs are labelled with the first layout for the measure chosen.
+type BarcodeConfig struct {
+	Layouts []string `env:"BARCODE_LAYOUTS" envDefault:"20IIIIIWWWWWC,21IIIIIPPPPPC,22IIIIIVPPPPC"`
+}
+
+// LabelConfig says how pack labels are laid out and sent to printers.
+// Labels use Template unless another is asked for. A printer that has not
+// taken a job within PrintTimeout fails the attempt; it is retried after
+// RetryAfter, and the job is given up on after MaxAttempts attempts.
+type LabelConfig struct {
+	Template     string        `env:"LABEL_TEMPLATE" envDefault:"standard"`
+	PrintTimeout time.Duration `env:"PRINT_TIMEOUT" envDefault:"5s"`
+	RetryAfter   time.Duration `env:"PRINT_RETRY_AFTER" envDefault:"30s"`
+	MaxAttempts  int           `env:"PRINT_MAX_ATTEMPTS" envDefault:"5"`
+}
+
+// ScaleConfig says how weighing scales are read. A scale that does not
+// connect or answer within ReadTimeout is taken as disconnected, and scales
+// that must be asked for their weight are asked every PollInterval. A weight
+// is stable once it has stayed within StableToleranceGrams for StableWindow,
+// and a single weighing waits up to WeighTimeout for that.
+type ScaleConfig struct {
+	ReadTimeout          time.Duration `env:"SCALE_READ_TIMEOUT" envDefault:"5s"`
+	PollInterval         time.Duration `env:"SCALE_POLL_INTERVAL" envDefault:"200ms"`
+	StableWindow         time.Duration `env:"SCALE_STABLE_WINDOW" envDefault:"500ms"`
+	StableToleranceGrams int64         `env:"SCALE_STABLE_TOLERANCE_GRAMS" envDefault:"2"`
+	WeighTimeout         time.Duration `env:"SCALE_WEIGH_TIMEOUT" envDefault:"10s"`
+}
+
+// HACCPConfig holds SensorKey, the shared key temperature sensors send with
+// their readings. Sensors are turned away while it is empty. The server
+// checks for missed readings every CheckEvery.
+type HACCPConfig struct {
+	SensorKey  string        `env:"HACCP_SENSOR_KEY"`
+	CheckEvery time.Duration `env:"HACCP_CHECK_EVERY" envDefault:"5m"`
+}
+
+// StaffConfig holds the rota rules: shifts and clock entries longer than
+// BreakAfter need breaks of at least MinBreak, and each trained butcher on
+// shift can handle OrdersPerButcher orders or KgPerButcher kg booked into a
+// fulfilment slot. Zero turns the respective check off.
+type StaffConfig struct {
+	BreakAfter       time.Duration `env:"STAFF_BREAK_AFTER" envDefault:"6h"`
+	MinBreak         time.Duration `env:"STAFF_MIN_BREAK" envDefault:"20m"`
+	OrdersPerButcher int           `env:"STAFF_ORDERS_PER_BUTCHER" envDefault:"10"`
+	KgPerButcher     int           `env:"STAFF_KG_PER_BUTCHER" envDefault:"80"`
+}
+
+// Location returns the time zone in which slot times and dates are interpreted.
+func (c FulfilmentConfig) Location() (*time.Location, error) {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("loading fulfilment timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// ParsedLayouts returns the barcode layouts in use.
+func (c BarcodeConfig) ParsedLayouts() (barcode.Layouts, error) {
+	layouts, err := barcode.ParseLayouts(c.Layouts)
+	if err != nil {
+		return nil, fmt.Errorf("BARCODE_LAYOUTS: %w", err)
+	}
+	return layouts, nil
+}
+
 func Load() (*Config, error) {
 	cfg := &Config{}
 	if err := env.Parse(cfg); err != nil {
@@ -51,5 +208,91 @@ func Load() (*Config, error) {
 	if cfg.JWT.Secret == "" || cfg.JWT.Secret == "change-me-to-a-secure-random-string" {
 		return nil, fmt.Errorf("JWT_SECRET must be set to a secure value")
 	}
+	if _, err := cfg.Fulfilment.Location(); err != nil {
+		return nil, err
+	}
+	switch cfg.Payment.Provider {
+	case "fake":
+	case "stripe":
+		if cfg.Payment.StripeAPIKey == "" {
+			return nil, fmt.Errorf("STRIPE_API_KEY must be set when PAYMENT_PROVIDER is stripe")
+		}
+		if cfg.Payment.WebhookSecret == "" || cfg.Payment.WebhookSecret == "change-me-to-the-provider-webhook-secret" {
+			return nil, fmt.Errorf("PAYMENT_WEBHOOK_SECRET must be set when PAYMENT_PROVIDER is stripe")
+		}
+	default:
+		return nil, fmt.Errorf("PAYMENT_PROVIDER must be fake or stripe")
+	}
+	if cfg.Payment.AuthorizationMarginBP < 0 {
+		return nil, fmt.Errorf("PAYMENT_AUTHORIZATION_MARGIN_BP must not be negative")
+	}
+	if cfg.Inventory.MarkdownDays < 0 {
+		return nil, fmt.Errorf("INVENTORY_MARKDOWN_DAYS must not be negative")
+	}
+	if cfg.Inventory.MarkdownDays > 0 && (cfg.Inventory.MarkdownBP <= 0 || cfg.Inventory.MarkdownBP >= 10000) {
+		return nil, fmt.Errorf("INVENTORY_MARKDOWN_BP must be between 1 and 9999")
+	}
+	if cfg.Inventory.ScanEvery <= 0 {
+		return nil, fmt.Errorf("INVENTORY_EXPIRY_SCAN_EVERY must be positive")
+	}
+	if cfg.Refund.ApprovalThresholdCents < 0 {
+		return nil, fmt.Errorf("REFUND_APPROVAL_THRESHOLD_CENTS must not be negative")
+	}
+	if cfg.Shop.FiscalYearStartMonth < 1 || cfg.Shop.FiscalYearStartMonth > 12 {
+		return nil, fmt.Errorf("SHOP_FISCAL_YEAR_START_MONTH must be between 1 and 12")
+	}
+	if cfg.Tax.Rounding != "line" && cfg.Tax.Rounding != "invoice" {
+		return nil, fmt.Errorf("TAX_ROUNDING must be line or invoice")
+	}
+	if cfg.Subscription.GenerateAhead <= 0 {
+		return nil, fmt.Errorf("SUBSCRIPTION_GENERATE_AHEAD must be positive")
+	}
+	for _, d := range cfg.Subscription.RetryIntervals {
+		if d <= 0 {
+			return nil, fmt.Errorf("SUBSCRIPTION_RETRY_INTERVALS must all be positive")
+		}
+	}
+	if cfg.Subscription.MaxFailedRenewals < 1 {
+		return nil, fmt.Errorf("SUBSCRIPTION_MAX_FAILED_RENEWALS must be at least 1")
+	}
+	if cfg.Subscription.RunEvery <= 0 {
+		return nil, fmt.Errorf("SUBSCRIPTION_RUN_EVERY must be positive")
+	}
+	if cfg.HACCP.CheckEvery <= 0 {
+		return nil, fmt.Errorf("HACCP_CHECK_EVERY must be positive")
+	}
+	if _, err := cfg.Barcode.ParsedLayouts(); err != nil {
+		return nil, err
+	}
+	if cfg.Label.PrintTimeout <= 0 || cfg.Label.PrintTimeout > time.Minute {
+		return nil, fmt.Errorf("PRINT_TIMEOUT must be positive and at most 1m")
+	}
+	if cfg.Label.RetryAfter <= 0 {
+		return nil, fmt.Errorf("PRINT_RETRY_AFTER must be positive")
+	}
+	if cfg.Label.MaxAttempts < 1 {
+		return nil, fmt.Errorf("PRINT_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.Scale.ReadTimeout <= 0 || cfg.Scale.ReadTimeout > time.Minute {
+		return nil, fmt.Errorf("SCALE_READ_TIMEOUT must be positive and at most 1m")
+	}
+	if cfg.Scale.PollInterval <= 0 {
+		return nil, fmt.Errorf("SCALE_POLL_INTERVAL must be positive")
+	}
+	if cfg.Scale.StableWindow < 0 {
+		return nil, fmt.Errorf("SCALE_STABLE_WINDOW must not be negative")
+	}
+	if cfg.Scale.StableToleranceGrams < 0 {
+		return nil, fmt.Errorf("SCALE_STABLE_TOLERANCE_GRAMS must not be negative")
+	}
+	if cfg.Scale.WeighTimeout <= 0 || cfg.Scale.WeighTimeout > 25*time.Second {
+		return nil, fmt.Errorf("SCALE_WEIGH_TIMEOUT must be positive and at most 25s")
+	}
+	if cfg.Staff.BreakAfter < 0 || cfg.Staff.MinBreak < 0 {
+		return nil, fmt.Errorf("STAFF_BREAK_AFTER and STAFF_MIN_BREAK must not be negative")
+	}
+	if cfg.Staff.OrdersPerButcher < 0 || cfg.Staff.KgPerButcher < 0 {
+		return nil, fmt.Errorf("STAFF_ORDERS_PER_BUTCHER and STAFF_KG_PER_BUTCHER must not be negative")
+	}
 	return cfg, nil
 }
@@ -0,0 +1,70 @@
+// Package sse writes server-sent event streams, which browsers read with
+// EventSource.
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Stream is an open event stream to one client.
+type Stream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// Open starts an event stream on w. Proxies are told not to buffer it.
+func Open(w http.ResponseWriter) (*Stream, error) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	s := &Stream{w: w, rc: http.NewResponseController(w)}
+	if err := s.rc.Flush(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Send writes an event named event carrying data as JSON.
+func (s *Stream) Send(event string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, body); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// SendWithID writes an event like Send, tagged with id. A client that
+// reconnects sends the last ID it got in the Last-Event-ID header.
+func (s *Stream) SendWithID(id, event string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "id: %s\nevent: %s\ndata: %s\n\n", id, event, body); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// LastEventID returns the ID of the last event a reconnecting client got,
+// or "" if it has not connected before.
+func LastEventID(r *http.Request) string {
+	return r.Header.Get("Last-Event-ID")
+}
+
+// Ping writes a comment, which clients ignore, so that idle connections are
+// not closed by proxies and a client that has gone is noticed.
+func (s *Stream) Ping() error {
+	if _, err := fmt.Fprint(s.w, ": ping\n\n"); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
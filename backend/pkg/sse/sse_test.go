@@ -0,0 +1,42 @@
+package sse_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katerji/butchery-app/backend/pkg/sse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_WritesEventsAndPings(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	s, err := sse.Open(rec)
+	require.NoError(t, err)
+	require.NoError(t, s.Send("reading", map[string]int{"grams": 1250}))
+	require.NoError(t, s.Ping())
+
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "event: reading\ndata: {\"grams\":1250}\n\n: ping\n\n", rec.Body.String())
+}
+
+func TestStream_SendWithID(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	s, err := sse.Open(rec)
+	require.NoError(t, err)
+	require.NoError(t, s.SendWithID("42", "order", map[string]string{"stage": "ready"}))
+
+	assert.Equal(t, "id: 42\nevent: order\ndata: {\"stage\":\"ready\"}\n\n", rec.Body.String())
+}
+
+func TestLastEventID(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	assert.Equal(t, "", sse.LastEventID(r))
+
+	r.Header.Set("Last-Event-ID", "42")
+	assert.Equal(t, "42", sse.LastEventID(r))
+}